@@ -42,6 +42,12 @@ var writeTests = []WriteTest{
 		Method: Deflate,
 		Mode:   0644,
 	},
+	{
+		Name:   "zstd",
+		Data:   []byte("Rabbits, guinea pigs, gophers, marsupial rats, and quolls. Rabbits, gophers and quolls."),
+		Method: Zstd,
+		Mode:   0644,
+	},
 	{
 		Name:   "setuid",
 		Data:   []byte("setuid file"),
@@ -0,0 +1,131 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import "math"
+
+// histogramCost estimates the number of bits needed to write the
+// symbols counted in h, including the description of the prefix code.
+func histogramCost(h *[256]uint32) float64 {
+	total, used := uint32(0), 0
+	bits := 0.0
+	for _, c := range h {
+		if c > 0 {
+			total += c
+			used++
+			bits -= float64(c) * math.Log2(float64(c))
+		}
+	}
+	if used <= 1 {
+		return 12
+	}
+	bits += float64(total) * math.Log2(float64(total))
+	// Roughly what each code length costs to write.
+	return bits + 4*float64(used) + 16
+}
+
+// clusterHistograms merges the literal histograms of each of the 64
+// contexts in hists into at most maxTrees histograms, joining those
+// whose combined cost is least. It sets ctxMap to the histogram for
+// each context, leaves the merged histograms at the start of hists,
+// and returns how many there are.
+func clusterHistograms(hists [][256]uint32, maxTrees int, ctxMap []uint8) int {
+	// Start with a cluster for each context that has any literals.
+	type cluster struct {
+		hist     [256]uint32
+		cost     float64
+		contexts uint64 // bit set of the contexts in the cluster
+	}
+	var clusters []*cluster
+	for ctx := range hists {
+		h := &hists[ctx]
+		empty := true
+		for _, c := range h {
+			if c > 0 {
+				empty = false
+				break
+			}
+		}
+		if !empty {
+			clusters = append(clusters, &cluster{hist: *h, cost: histogramCost(h), contexts: 1 << ctx})
+		}
+	}
+	if len(clusters) == 0 {
+		clear(ctxMap)
+		return 1
+	}
+
+	// gain[i][j] is how much merging clusters i and j saves.
+	n := len(clusters)
+	gain := make([][]float64, n)
+	var merged [256]uint32
+	mergeGain := func(a, b *cluster) float64 {
+		for s := range merged {
+			merged[s] = a.hist[s] + b.hist[s]
+		}
+		return a.cost + b.cost - histogramCost(&merged)
+	}
+	for i := range gain {
+		gain[i] = make([]float64, n)
+		for j := range i {
+			gain[i][j] = mergeGain(clusters[i], clusters[j])
+		}
+	}
+
+	alive := n
+	for alive > 1 {
+		bi, bj := -1, -1
+		for i := range n {
+			if clusters[i] == nil {
+				continue
+			}
+			for j := range i {
+				if clusters[j] != nil && (bi < 0 || gain[i][j] > gain[bi][bj]) {
+					bi, bj = i, j
+				}
+			}
+		}
+		if gain[bi][bj] <= 0 && alive <= maxTrees {
+			break
+		}
+		// Merge cluster bi into bj.
+		a, b := clusters[bj], clusters[bi]
+		for s := range a.hist {
+			a.hist[s] += b.hist[s]
+		}
+		a.cost = histogramCost(&a.hist)
+		a.contexts |= b.contexts
+		clusters[bi] = nil
+		alive--
+		for k := range n {
+			if k == bj || clusters[k] == nil {
+				continue
+			}
+			g := mergeGain(a, clusters[k])
+			if k < bj {
+				gain[bj][k] = g
+			} else {
+				gain[k][bj] = g
+			}
+		}
+	}
+
+	// Contexts with no literals use the first cluster.
+	clear(ctxMap)
+	t := 0
+	for _, c := range clusters {
+		if c == nil {
+			continue
+		}
+		hists[t] = c.hist
+		for ctx := range ctxMap {
+			if c.contexts&(1<<ctx) != 0 {
+				ctxMap[ctx] = uint8(t)
+			}
+		}
+		t++
+	}
+	return t
+}
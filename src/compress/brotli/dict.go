@@ -0,0 +1,232 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+// The static dictionary holds words of 4 to 24 bytes, grouped by length.
+// A backward reference past the start of the output refers to a word,
+// optionally transformed. RFC 7932, Section 8.
+const (
+	minDictWordLen = 4
+	maxDictWordLen = 24
+)
+
+// dictSizeBits is NDBITS, the log of the number of words of each length.
+var dictSizeBits = [maxDictWordLen + 1]uint8{
+	0, 0, 0, 0, 10, 10, 11, 11, 10, 10, 10, 10, 10, 9, 9, 8, 7, 7, 8, 7, 7, 6, 6, 5, 5,
+}
+
+// dictOffsets is DOFFSET, the offset in dictData of the words of each length.
+var dictOffsets = [maxDictWordLen + 1]uint32{
+	0, 0, 0, 0, 0, 4096, 9216, 21504, 35840, 44032, 53248, 63488, 74752,
+	87040, 93696, 100864, 104704, 106752, 108928, 113536, 115968, 118528,
+	119872, 121280, 122016,
+}
+
+// dictWord returns the word with index i among the words of length n.
+func dictWord(n, i int) string {
+	off := int(dictOffsets[n]) + i*n
+	return dictData[off : off+n]
+}
+
+// Kinds of word transformation.
+const (
+	identity = iota
+	omitLast1
+	omitLast2
+	omitLast3
+	omitLast4
+	omitLast5
+	omitLast6
+	omitLast7
+	omitLast8
+	omitLast9
+	uppercaseFirst
+	uppercaseAll
+	omitFirst1
+	omitFirst2
+	omitFirst3
+	omitFirst4
+	omitFirst5
+	omitFirst6
+	omitFirst7
+	omitFirst8
+	omitFirst9
+)
+
+// A transform turns a dictionary word into the bytes it stands for.
+type transform struct {
+	prefix string
+	kind   uint8
+	suffix string
+}
+
+// transforms lists the word transformations. RFC 7932, Appendix B.
+var transforms = [...]transform{
+	{"", identity, ""},
+	{"", identity, " "},
+	{" ", identity, " "},
+	{"", omitFirst1, ""},
+	{"", uppercaseFirst, " "},
+	{"", identity, " the "},
+	{" ", identity, ""},
+	{"s ", identity, " "},
+	{"", identity, " of "},
+	{"", uppercaseFirst, ""},
+	{"", identity, " and "},
+	{"", omitFirst2, ""},
+	{"", omitLast1, ""},
+	{", ", identity, " "},
+	{"", identity, ", "},
+	{" ", uppercaseFirst, " "},
+	{"", identity, " in "},
+	{"", identity, " to "},
+	{"e ", identity, " "},
+	{"", identity, "\""},
+	{"", identity, "."},
+	{"", identity, "\">"},
+	{"", identity, "\n"},
+	{"", omitLast3, ""},
+	{"", identity, "]"},
+	{"", identity, " for "},
+	{"", omitFirst3, ""},
+	{"", omitLast2, ""},
+	{"", identity, " a "},
+	{"", identity, " that "},
+	{" ", uppercaseFirst, ""},
+	{"", identity, ". "},
+	{".", identity, ""},
+	{" ", identity, ", "},
+	{"", omitFirst4, ""},
+	{"", identity, " with "},
+	{"", identity, "'"},
+	{"", identity, " from "},
+	{"", identity, " by "},
+	{"", omitFirst5, ""},
+	{"", omitFirst6, ""},
+	{" the ", identity, ""},
+	{"", omitLast4, ""},
+	{"", identity, ". The "},
+	{"", uppercaseAll, ""},
+	{"", identity, " on "},
+	{"", identity, " as "},
+	{"", identity, " is "},
+	{"", omitLast7, ""},
+	{"", omitLast1, "ing "},
+	{"", identity, "\n\t"},
+	{"", identity, ":"},
+	{" ", identity, ". "},
+	{"", identity, "ed "},
+	{"", omitFirst9, ""},
+	{"", omitFirst7, ""},
+	{"", omitLast6, ""},
+	{"", identity, "("},
+	{"", uppercaseFirst, ", "},
+	{"", omitLast8, ""},
+	{"", identity, " at "},
+	{"", identity, "ly "},
+	{" the ", identity, " of "},
+	{"", omitLast5, ""},
+	{"", omitLast9, ""},
+	{" ", uppercaseFirst, ", "},
+	{"", uppercaseFirst, "\""},
+	{".", identity, "("},
+	{"", uppercaseAll, " "},
+	{"", uppercaseFirst, "\">"},
+	{"", identity, "=\""},
+	{" ", identity, "."},
+	{".com/", identity, ""},
+	{" the ", identity, " of the "},
+	{"", uppercaseFirst, "'"},
+	{"", identity, ". This "},
+	{"", identity, ","},
+	{".", identity, " "},
+	{"", uppercaseFirst, "("},
+	{"", uppercaseFirst, "."},
+	{"", identity, " not "},
+	{" ", identity, "=\""},
+	{"", identity, "er "},
+	{" ", uppercaseAll, " "},
+	{"", identity, "al "},
+	{" ", uppercaseAll, ""},
+	{"", identity, "='"},
+	{"", uppercaseAll, "\""},
+	{"", uppercaseFirst, ". "},
+	{" ", identity, "("},
+	{"", identity, "ful "},
+	{" ", uppercaseFirst, ". "},
+	{"", identity, "ive "},
+	{"", identity, "less "},
+	{"", uppercaseAll, "'"},
+	{"", identity, "est "},
+	{" ", uppercaseFirst, "."},
+	{"", uppercaseAll, "\">"},
+	{" ", identity, "='"},
+	{"", uppercaseFirst, ","},
+	{"", identity, "ize "},
+	{"", uppercaseAll, "."},
+	{"\xc2\xa0", identity, ""},
+	{" ", identity, ","},
+	{"", uppercaseFirst, "=\""},
+	{"", uppercaseAll, "=\""},
+	{"", identity, "ous "},
+	{"", uppercaseAll, ", "},
+	{"", uppercaseFirst, "='"},
+	{" ", uppercaseFirst, ","},
+	{" ", uppercaseAll, "=\""},
+	{" ", uppercaseAll, ", "},
+	{"", uppercaseAll, ","},
+	{"", uppercaseAll, "("},
+	{"", uppercaseAll, ". "},
+	{" ", uppercaseAll, "."},
+	{"", uppercaseAll, "='"},
+	{" ", uppercaseAll, ". "},
+	{" ", uppercaseFirst, "=\""},
+	{" ", uppercaseAll, "='"},
+	{" ", uppercaseFirst, "='"},
+}
+
+// appendWord appends the dictionary word transformed by t to dst.
+func appendWord(dst []byte, word string, t *transform) []byte {
+	dst = append(dst, t.prefix...)
+	switch {
+	case t.kind <= omitLast9:
+		word = word[:len(word)-min(int(t.kind), len(word))]
+	case t.kind >= omitFirst1:
+		word = word[min(int(t.kind-omitFirst1+1), len(word)):]
+	}
+	start := len(dst)
+	dst = append(dst, word...)
+	switch t.kind {
+	case uppercaseFirst:
+		toUpper(dst[start:])
+	case uppercaseAll:
+		for b := dst[start:]; len(b) > 0; {
+			b = b[min(toUpper(b), len(b)):]
+		}
+	}
+	return append(dst, t.suffix...)
+}
+
+// toUpper changes the case of the character at the start of b in the
+// simplified way the format defines, and returns its length in bytes.
+func toUpper(b []byte) int {
+	switch {
+	case b[0] < 0xc0:
+		if 'a' <= b[0] && b[0] <= 'z' {
+			b[0] ^= 0x20
+		}
+		return 1
+	case b[0] < 0xe0:
+		if len(b) > 1 {
+			b[1] ^= 0x20
+		}
+		return 2
+	default:
+		if len(b) > 2 {
+			b[2] ^= 5
+		}
+		return 3
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+//
+// usage:
+//
+// go run gendict.go -dict path/to/dictionary.bin -output zdict.go
+//
+// The dictionary is c/common/dictionary.bin in the Brotli
+// reference implementation, and is listed in RFC 7932, Appendix A.
+//
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+var (
+	dictFile = flag.String("dict", "dictionary.bin", "dictionary file name")
+	filename = flag.String("output", "zdict.go", "output file name")
+)
+
+// dictSHA256 is the SHA-256 hash of the dictionary.
+const dictSHA256 = "20e42eb1b511c21806d4d227d07e5dd06877d8ce7b3a817f378f313653f35c70"
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gendict: ")
+	flag.Parse()
+
+	data, err := os.ReadFile(*dictFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != dictSHA256 {
+		log.Fatalf("%s: unexpected SHA-256 %x", *dictFile, sum)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by go run gendict.go -output %s; DO NOT EDIT.
+
+package brotli
+
+// dictData is the static dictionary. RFC 7932, Appendix A.
+const dictData = "`, *filename)
+	for _, c := range data {
+		switch {
+		case c == '"' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c >= ' ' && c < 0x7f:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, `\x%02x`, c)
+		}
+	}
+	buf.WriteString("\"\n")
+
+	if err := os.WriteFile(*filename, buf.Bytes(), 0666); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"io"
+	"math/bits"
+)
+
+// A bitReader reads a stream of bits, least significant bit first.
+//
+// Errors are sticky: after the input runs out, reads return zeros and
+// err is set, so callers check err once they have read a unit of data.
+// Bytes are read from the underlying reader only when needed, so that
+// reading stops at the end of the compressed stream.
+type bitReader struct {
+	r     io.ByteReader
+	bits  uint64 // unread bits, least significant first
+	nbits uint   // number of unread bits
+	off   int64  // number of bytes read
+	err   error
+}
+
+func (br *bitReader) reset(r io.ByteReader) {
+	*br = bitReader{r: r}
+}
+
+// more reads another byte into the buffer. It reports false at the end
+// of the input, after setting br.err.
+func (br *bitReader) more() bool {
+	if br.err != nil {
+		return false
+	}
+	c, err := br.r.ReadByte()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		br.err = err
+		return false
+	}
+	br.bits |= uint64(c) << br.nbits
+	br.nbits += 8
+	br.off++
+	return true
+}
+
+// readBits reads an n bit value, for n up to 32.
+func (br *bitReader) readBits(n uint) uint32 {
+	for br.nbits < n {
+		if !br.more() {
+			return 0
+		}
+	}
+	v := uint32(br.bits & (1<<n - 1))
+	br.bits >>= n
+	br.nbits -= n
+	return v
+}
+
+// readBit reads a single bit as a bool.
+func (br *bitReader) readBit() bool {
+	return br.readBits(1) == 1
+}
+
+// align skips to the next byte boundary. It reports whether the
+// skipped bits were all zero, as the format requires.
+func (br *bitReader) align() bool {
+	n := br.nbits % 8
+	return br.readBits(n) == 0
+}
+
+// readSymbol reads a symbol coded with h.
+func (br *bitReader) readSymbol(h *huffmanDecoder) int {
+	for {
+		// Bits past nbits are zero. An entry found with them is only
+		// used if it needs no more than the bits actually read.
+		e := h.table[br.bits&huffmanRootMask]
+		if e.bits > huffmanRootBits {
+			e = h.table[int(e.value)+int(br.bits>>huffmanRootBits)&(1<<(e.bits-huffmanRootBits)-1)]
+		}
+		if uint(e.bits) <= br.nbits {
+			br.bits >>= e.bits
+			br.nbits -= uint(e.bits)
+			return int(e.value)
+		}
+		if !br.more() {
+			return 0
+		}
+	}
+}
+
+// maxCodeLen is the longest prefix code length.
+const maxCodeLen = 15
+
+// A huffmanDecoder decodes a canonical prefix code. RFC 7932, Section 3.
+//
+// The first 1<<huffmanRootBits entries of table are indexed by the next
+// huffmanRootBits bits of input. An entry with bits at most
+// huffmanRootBits holds a symbol and its code length. Codes that are
+// longer continue in a second level table: the root entry holds its
+// offset in table and, as bits, the longest code length in it.
+type huffmanDecoder struct {
+	table []huffmanEntry
+}
+
+type huffmanEntry struct {
+	bits  uint8
+	value uint16
+}
+
+const (
+	huffmanRootBits = 8
+	huffmanRootMask = 1<<huffmanRootBits - 1
+)
+
+// initSingle makes h decode sym without reading any bits.
+func (h *huffmanDecoder) initSingle(sym int) {
+	h.table = h.table[:0]
+	for range 1 << huffmanRootBits {
+		h.table = append(h.table, huffmanEntry{0, uint16(sym)})
+	}
+}
+
+// init builds h from the code lengths of each symbol, which must
+// describe a complete prefix code.
+func (h *huffmanDecoder) init(lengths []uint8) {
+	// Assign canonical codes: shorter codes first,
+	// and codes of the same length in symbol order.
+	var count [maxCodeLen + 1]uint16
+	for _, n := range lengths {
+		count[n]++
+	}
+	count[0] = 0
+	var next [maxCodeLen + 1]uint16
+	code := uint16(0)
+	for n := 1; n <= maxCodeLen; n++ {
+		code = (code + count[n-1]) << 1
+		next[n] = code
+	}
+
+	// The codes are read starting at their most significant bit,
+	// so the table is indexed by the reversed code.
+	reversed := func(sym int) uint16 {
+		n := lengths[sym]
+		c := next[n]
+		next[n]++
+		return bits.Reverse16(c) >> (16 - n)
+	}
+
+	// Size the second level tables by the longest code in each.
+	var sub [1 << huffmanRootBits]uint8
+	saved := next
+	for sym, n := range lengths {
+		if n > huffmanRootBits {
+			r := reversed(sym) & huffmanRootMask
+			sub[r] = max(sub[r], n)
+		}
+	}
+	next = saved
+
+	h.table = h.table[:0]
+	for range 1 << huffmanRootBits {
+		h.table = append(h.table, huffmanEntry{})
+	}
+	for r, n := range sub {
+		if n > 0 {
+			h.table[r] = huffmanEntry{n, uint16(len(h.table))}
+			for range 1 << (n - huffmanRootBits) {
+				h.table = append(h.table, huffmanEntry{})
+			}
+		}
+	}
+
+	for sym, n := range lengths {
+		if n == 0 {
+			continue
+		}
+		r := int(reversed(sym))
+		e := huffmanEntry{n, uint16(sym)}
+		if n <= huffmanRootBits {
+			for i := r; i < 1<<huffmanRootBits; i += 1 << n {
+				h.table[i] = e
+			}
+			continue
+		}
+		link := h.table[r&huffmanRootMask]
+		t := h.table[link.value : int(link.value)+1<<(link.bits-huffmanRootBits)]
+		for i := r >> huffmanRootBits; i < len(t); i += 1 << (n - huffmanRootBits) {
+			t[i] = e
+		}
+	}
+}
+
+// codeLengthOrder is the order in which the code lengths of the code
+// length alphabet are stored. RFC 7932, Section 3.5.
+var codeLengthOrder = [...]uint8{1, 2, 3, 4, 0, 5, 17, 6, 16, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// The static prefix code for code length code lengths, indexed by the
+// next 4 bits of input.
+var (
+	codeLengthCodeBits  = [16]uint8{2, 2, 2, 3, 2, 2, 2, 4, 2, 2, 2, 3, 2, 2, 2, 4}
+	codeLengthCodeValue = [16]uint8{0, 4, 3, 2, 0, 4, 3, 1, 0, 4, 3, 2, 0, 4, 3, 5}
+)
+
+const (
+	repeatPreviousCode = 16 // repeat the previous nonzero length
+	repeatZeroCode     = 17 // repeat a zero length
+)
+
+// readPrefixCode reads a prefix code for an alphabet of size symbols
+// into h. It reports false if the code is invalid. RFC 7932, Section 3.4-3.5.
+func (r *Reader) readPrefixCode(h *huffmanDecoder, size int) bool {
+	br := &r.br
+	hskip := br.readBits(2)
+	if hskip == 1 {
+		return r.readSimplePrefixCode(h, size)
+	}
+
+	// Read the code for the code lengths.
+	var clens [len(codeLengthOrder)]uint8
+	space := 32
+	numCodes := 0
+	for _, sym := range codeLengthOrder[hskip:] {
+		for br.nbits < 4 && br.more() {
+		}
+		v := br.bits & 15
+		br.readBits(uint(codeLengthCodeBits[v]))
+		n := codeLengthCodeValue[v]
+		clens[sym] = n
+		if n != 0 {
+			space -= 32 >> n
+			numCodes++
+			if space <= 0 {
+				break
+			}
+		}
+	}
+	if br.err != nil || !(numCodes == 1 || space == 0) {
+		return false
+	}
+	var clh huffmanDecoder
+	if numCodes == 1 {
+		for sym, n := range clens {
+			if n != 0 {
+				clh.initSingle(sym)
+			}
+		}
+	} else {
+		clh.init(clens[:])
+	}
+
+	// Read the code lengths.
+	lengths := r.lengths[:size]
+	clear(lengths)
+	prevLen := uint8(8) // the last nonzero code length
+	repeatLen := uint8(0)
+	repeat := 0
+	space = 1 << maxCodeLen
+	for i := 0; i < size && space > 0; {
+		sym := br.readSymbol(&clh)
+		if br.err != nil {
+			return false
+		}
+		if sym < repeatPreviousCode {
+			repeat = 0
+			lengths[i] = uint8(sym)
+			i++
+			if sym != 0 {
+				prevLen = uint8(sym)
+				space -= (1 << maxCodeLen) >> sym
+			}
+			continue
+		}
+
+		// A run of repeat codes builds up a longer run:
+		// each code shifts the previous count into the high digits.
+		newLen, extra := uint8(0), uint(3)
+		if sym == repeatPreviousCode {
+			newLen, extra = prevLen, 2
+		}
+		if repeatLen != newLen {
+			repeat = 0
+			repeatLen = newLen
+		}
+		old := repeat
+		if repeat > 0 {
+			repeat = (repeat - 2) << extra
+		}
+		repeat += int(br.readBits(extra)) + 3
+		delta := repeat - old
+		if i+delta > size {
+			return false
+		}
+		for j := range delta {
+			lengths[i+j] = newLen
+		}
+		i += delta
+		if newLen != 0 {
+			space -= delta * ((1 << maxCodeLen) >> newLen)
+		}
+	}
+	if br.err != nil || space != 0 {
+		return false
+	}
+	h.init(lengths)
+	return true
+}
+
+// readSimplePrefixCode reads a code of 1 to 4 symbols. RFC 7932, Section 3.4.
+func (r *Reader) readSimplePrefixCode(h *huffmanDecoder, size int) bool {
+	br := &r.br
+	nsym := int(br.readBits(2)) + 1
+	alphabetBits := uint(bits.Len(uint(size - 1)))
+	var syms [4]int
+	for i := range nsym {
+		syms[i] = int(br.readBits(alphabetBits))
+		if syms[i] >= size {
+			return false
+		}
+		for _, s := range syms[:i] {
+			if s == syms[i] {
+				return false
+			}
+		}
+	}
+	var lens [4]uint8
+	switch nsym {
+	case 1:
+		if br.err != nil {
+			return false
+		}
+		h.initSingle(syms[0])
+		return true
+	case 2:
+		lens = [4]uint8{1, 1}
+	case 3:
+		lens = [4]uint8{1, 2, 2}
+	case 4:
+		if br.readBit() {
+			lens = [4]uint8{1, 2, 3, 3}
+		} else {
+			lens = [4]uint8{2, 2, 2, 2}
+		}
+	}
+	if br.err != nil {
+		return false
+	}
+	lengths := r.lengths[:size]
+	clear(lengths)
+	for i, s := range syms[:nsym] {
+		lengths[s] = lens[i]
+	}
+	h.init(lengths)
+	return true
+}
@@ -0,0 +1,317 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"compress/internal/huffman"
+	"math/bits"
+	"slices"
+)
+
+// A bitWriter writes a stream of bits, least significant bit first.
+type bitWriter struct {
+	out   []byte
+	bits  uint64
+	nbits uint
+}
+
+// writeBits writes the low n bits of v, for n up to 32.
+func (bw *bitWriter) writeBits(n uint, v uint32) {
+	bw.bits |= uint64(v) << bw.nbits
+	bw.nbits += n
+	for bw.nbits >= 32 {
+		bw.out = append(bw.out, byte(bw.bits), byte(bw.bits>>8), byte(bw.bits>>16), byte(bw.bits>>24))
+		bw.bits >>= 32
+		bw.nbits -= 32
+	}
+}
+
+// align writes zero bits up to the next byte boundary and flushes
+// all whole bytes to out.
+func (bw *bitWriter) align() {
+	for bw.nbits > 0 {
+		bw.out = append(bw.out, byte(bw.bits))
+		bw.bits >>= 8
+		bw.nbits -= min(bw.nbits, 8)
+	}
+	bw.bits = 0
+}
+
+// flushBytes moves whole bytes from the bit buffer to out.
+func (bw *bitWriter) flushBytes() {
+	for bw.nbits >= 8 {
+		bw.out = append(bw.out, byte(bw.bits))
+		bw.bits >>= 8
+		bw.nbits -= 8
+	}
+}
+
+// A bitWriterMark records a bitWriter position to return to.
+type bitWriterMark struct {
+	n     int
+	bits  uint64
+	nbits uint
+}
+
+func (bw *bitWriter) mark() bitWriterMark {
+	return bitWriterMark{len(bw.out), bw.bits, bw.nbits}
+}
+
+func (bw *bitWriter) rewind(m bitWriterMark) {
+	bw.out, bw.bits, bw.nbits = bw.out[:m.n], m.bits, m.nbits
+}
+
+// bitLen returns the number of bits written since m.
+func (bw *bitWriter) bitLen(m bitWriterMark) int {
+	return (len(bw.out)-m.n)*8 + int(bw.nbits) - int(m.nbits)
+}
+
+// A huffmanCode is a prefix code for writing symbols.
+type huffmanCode struct {
+	lens  []uint8  // code length of each symbol, 0 if absent
+	codes []uint16 // bit reversed code of each symbol
+	used  int      // number of symbols with a code
+
+	// Scratch space for building the code.
+	hb     huffman.Builder
+	leaves []int // the symbols with a code, by increasing count
+}
+
+// write writes the code for sym.
+func (h *huffmanCode) write(bw *bitWriter, sym int) {
+	bw.writeBits(uint(h.lens[sym]), uint32(h.codes[sym]))
+}
+
+// build builds a canonical prefix code with codes of at most maxBits
+// bits for the symbols counted in hist.
+func (h *huffmanCode) build(hist []uint32, maxBits uint8) {
+	h.lens = slices.Grow(h.lens[:0], len(hist))[:len(hist)]
+	h.codes = slices.Grow(h.codes[:0], len(hist))[:len(hist)]
+	h.leaves = h.hb.Lengths(h.lens, hist, maxBits)
+	h.used = len(h.leaves)
+	if h.used < 2 {
+		// A single symbol is written with no bits, so its code
+		// must not be left over from an earlier build.
+		clear(h.codes)
+		return
+	}
+	h.assignCodes()
+}
+
+// assignCodes assigns canonical codes from the lengths: shorter codes
+// first, and codes of the same length in symbol order.
+func (h *huffmanCode) assignCodes() {
+	var count, next [maxCodeLen + 1]uint16
+	for _, n := range h.lens {
+		count[n]++
+	}
+	count[0] = 0
+	code := uint16(0)
+	for n := 1; n <= maxCodeLen; n++ {
+		code = (code + count[n-1]) << 1
+		next[n] = code
+	}
+	for sym, n := range h.lens {
+		if n > 0 {
+			h.codes[sym] = bits.Reverse16(next[n]) >> (16 - n)
+			next[n]++
+		}
+	}
+}
+
+// cost returns the number of bits needed to write the symbols
+// counted in hist with h.
+func (h *huffmanCode) cost(hist []uint32) int {
+	n := 0
+	for sym, c := range hist {
+		n += int(c) * int(h.lens[sym])
+	}
+	return n
+}
+
+// The static code for code length code lengths, indexed by length:
+// the bits to write and their number. RFC 7932, Section 3.5.
+var (
+	codeLengthCodeLenCode  = [6]uint8{0, 7, 3, 2, 1, 15}
+	codeLengthCodeLenCount = [6]uint8{2, 4, 3, 2, 2, 4}
+)
+
+// writeCode writes the description of h, for an alphabet of size
+// symbols. RFC 7932, Sections 3.4 and 3.5.
+func (h *huffmanCode) writeCode(bw *bitWriter, size int) {
+	if h.used <= 4 {
+		h.writeSimpleCode(bw, size)
+		return
+	}
+
+	// Write the code lengths as codes 0-15 and repeat codes,
+	// trimming trailing zeros.
+	last := len(h.lens) - 1
+	for h.lens[last] == 0 {
+		last--
+	}
+	var tokens [][2]uint8 // code length code and extra bits
+	tokens = appendCodeLengthTokens(tokens, h.lens[:last+1])
+
+	var hist [len(codeLengthOrder)]uint32
+	for _, t := range tokens {
+		hist[t[0]]++
+	}
+	var clc huffmanCode
+	clc.build(hist[:], 5)
+
+	// The code length code lengths, skipping zeros at the start.
+	var clens [len(codeLengthOrder)]uint8
+	for i, sym := range codeLengthOrder {
+		clens[i] = clc.lens[sym]
+	}
+	if clc.used == 1 {
+		// The decoder reads no bits for the single symbol, but still
+		// needs a nonzero length for it.
+		for i, sym := range codeLengthOrder {
+			if hist[sym] > 0 {
+				clens[i] = 1
+			}
+		}
+	}
+	skip := 0
+	if clens[0] == 0 && clens[1] == 0 {
+		skip = 2
+		if clens[2] == 0 {
+			skip = 3
+		}
+	}
+	end := len(clens)
+	if clc.used > 1 {
+		// The decoder stops once the code is complete.
+		for clens[end-1] == 0 {
+			end--
+		}
+	}
+	bw.writeBits(2, uint32(skip))
+	for _, n := range clens[skip:end] {
+		bw.writeBits(uint(codeLengthCodeLenCount[n]), uint32(codeLengthCodeLenCode[n]))
+	}
+
+	for _, t := range tokens {
+		if clc.used > 1 {
+			clc.write(bw, int(t[0]))
+		}
+		switch t[0] {
+		case repeatPreviousCode:
+			bw.writeBits(2, uint32(t[1]))
+		case repeatZeroCode:
+			bw.writeBits(3, uint32(t[1]))
+		}
+	}
+}
+
+// appendCodeLengthTokens appends the code lengths in lens to tokens,
+// using repeat codes for runs.
+func appendCodeLengthTokens(tokens [][2]uint8, lens []uint8) [][2]uint8 {
+	prev := uint8(8) // the last nonzero length, as the decoder tracks it
+	for i := 0; i < len(lens); {
+		v := lens[i]
+		run := 1
+		for i+run < len(lens) && lens[i+run] == v {
+			run++
+		}
+		i += run
+		if v == 0 {
+			tokens = appendZeroRun(tokens, run)
+		} else {
+			tokens = appendRepeatRun(tokens, prev, v, run)
+			prev = v
+		}
+	}
+	return tokens
+}
+
+// appendZeroRun appends a run of n zero lengths. A sequence of repeat
+// codes holds the run length in base 8, most significant digit first.
+func appendZeroRun(tokens [][2]uint8, n int) [][2]uint8 {
+	if n == 11 {
+		tokens = append(tokens, [2]uint8{0, 0})
+		n--
+	}
+	if n < 3 {
+		for range n {
+			tokens = append(tokens, [2]uint8{0, 0})
+		}
+		return tokens
+	}
+	start := len(tokens)
+	n -= 3
+	for {
+		tokens = append(tokens, [2]uint8{repeatZeroCode, uint8(n & 7)})
+		n >>= 3
+		if n == 0 {
+			break
+		}
+		n--
+	}
+	slices.Reverse(tokens[start:])
+	return tokens
+}
+
+// appendRepeatRun appends a run of n lengths v, after the last nonzero
+// length prev. A sequence of repeat codes holds the run length in
+// base 4, most significant digit first.
+func appendRepeatRun(tokens [][2]uint8, prev, v uint8, n int) [][2]uint8 {
+	if prev != v {
+		tokens = append(tokens, [2]uint8{v, 0})
+		n--
+	}
+	if n == 7 {
+		tokens = append(tokens, [2]uint8{v, 0})
+		n--
+	}
+	if n < 3 {
+		for range n {
+			tokens = append(tokens, [2]uint8{v, 0})
+		}
+		return tokens
+	}
+	start := len(tokens)
+	n -= 3
+	for {
+		tokens = append(tokens, [2]uint8{repeatPreviousCode, uint8(n & 3)})
+		n >>= 2
+		if n == 0 {
+			break
+		}
+		n--
+	}
+	slices.Reverse(tokens[start:])
+	return tokens
+}
+
+// writeSimpleCode writes a code of at most 4 symbols.
+// RFC 7932, Section 3.4.
+func (h *huffmanCode) writeSimpleCode(bw *bitWriter, size int) {
+	var syms [4]int
+	n := copy(syms[:], h.leaves)
+	if n == 0 {
+		// An unused code still needs a symbol.
+		n = 1
+	}
+	// List the symbols by increasing code length.
+	slices.SortStableFunc(syms[:n], func(a, b int) int {
+		return int(h.lens[a]) - int(h.lens[b])
+	})
+	bw.writeBits(2, 1)
+	bw.writeBits(2, uint32(n-1))
+	alphabetBits := uint(bits.Len(uint(size - 1)))
+	for _, s := range syms[:n] {
+		bw.writeBits(alphabetBits, uint32(s))
+	}
+	if n == 4 {
+		if h.lens[syms[0]] == 1 {
+			bw.writeBits(1, 1)
+		} else {
+			bw.writeBits(1, 0)
+		}
+	}
+}
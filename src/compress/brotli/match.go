@@ -0,0 +1,300 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"bytes"
+	"compress/internal/lz"
+	"encoding/binary"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+// levelParams are the encoder parameters for a compression level.
+type levelParams struct {
+	lz.Params
+	blockLog uint8 // log of the largest meta-block size
+	dict     bool  // whether to look for static dictionary words
+	trees    int   // most literal prefix codes, chosen by context
+}
+
+// levels holds the parameters for each compression level.
+var levels = [BestCompression + 1]levelParams{
+	0:  {Params: lz.Params{WindowLog: 18, HashLog: 14, ChainLog: 0, Depth: 1, Lazy: 0, Nice: 16}, blockLog: 16, trees: 1},
+	1:  {Params: lz.Params{WindowLog: 18, HashLog: 15, ChainLog: 0, Depth: 1, Lazy: 0, Nice: 16}, blockLog: 16, trees: 1},
+	2:  {Params: lz.Params{WindowLog: 20, HashLog: 16, ChainLog: 16, Depth: 2, Lazy: 0, Nice: 32}, blockLog: 18, trees: 1},
+	3:  {Params: lz.Params{WindowLog: 20, HashLog: 16, ChainLog: 16, Depth: 4, Lazy: 1, Nice: 32}, blockLog: 18, trees: 1},
+	4:  {Params: lz.Params{WindowLog: 21, HashLog: 17, ChainLog: 17, Depth: 8, Lazy: 1, Nice: 64}, blockLog: 18, dict: true, trees: 1},
+	5:  {Params: lz.Params{WindowLog: 22, HashLog: 17, ChainLog: 17, Depth: 8, Lazy: 1, Nice: 64}, blockLog: 18, dict: true, trees: 4},
+	6:  {Params: lz.Params{WindowLog: 22, HashLog: 17, ChainLog: 18, Depth: 16, Lazy: 1, Nice: 96}, blockLog: 18, dict: true, trees: 8},
+	7:  {Params: lz.Params{WindowLog: 22, HashLog: 17, ChainLog: 18, Depth: 32, Lazy: 1, Nice: 128}, blockLog: 20, dict: true, trees: 8},
+	8:  {Params: lz.Params{WindowLog: 22, HashLog: 18, ChainLog: 19, Depth: 64, Lazy: 2, Nice: 192}, blockLog: 20, dict: true, trees: 16},
+	9:  {Params: lz.Params{WindowLog: 22, HashLog: 18, ChainLog: 19, Depth: 128, Lazy: 2, Nice: 256}, blockLog: 20, dict: true, trees: 16},
+	10: {Params: lz.Params{WindowLog: 22, HashLog: 18, ChainLog: 20, Depth: 256, Lazy: 2, Nice: 512}, blockLog: 20, dict: true, trees: 32},
+	11: {Params: lz.Params{WindowLog: 22, HashLog: 18, ChainLog: 20, Depth: 512, Lazy: 2, Nice: 1024}, blockLog: 20, dict: true, trees: 64},
+}
+
+// A command is a run of literals followed by a copy, either from
+// earlier in the output or of a static dictionary word.
+type command struct {
+	insert uint32 // number of literals
+	copy   uint32 // number of bytes copied
+	dist   uint32 // backward distance, or the dictionary word ID
+	word   uint8  // dictionary word length, or 0 for a backward copy
+}
+
+// Matches are chosen by a score that estimates the bits they save,
+// in units of 1/30 bit, as the reference encoder does.
+const (
+	literalScore  = 135 // per byte covered by a match
+	distanceCost  = 30  // per bit of distance
+	lastDistBonus = 15  // for reusing the last distance
+	minScore      = 100 // score needed to use a match
+	lazyCost      = 175 // score a later match must gain to be used
+)
+
+// score returns the score of a match of length n at distance dist.
+func score(n, dist int) int {
+	return literalScore*n - distanceCost*(bits.Len(uint(dist))-1)
+}
+
+// matcher finds matches using the hash chains in t.
+type matcher struct {
+	p levelParams
+	t lz.Table
+
+	// The distance of the last backward copy.
+	last int
+
+	// Output for the current meta-block.
+	cmds []command
+}
+
+// reset prepares the matcher for a new stream. Data up to sizeHint
+// bytes long can use smaller tables; 0 means the size is unknown.
+func (m *matcher) reset(p levelParams, sizeHint int) {
+	m.p = p
+	m.t.Reset(&p.Params, sizeHint)
+	m.last = 0
+}
+
+// A match is a candidate copy for the bytes at some position.
+type match struct {
+	length int
+	dist   int   // backward distance, or the dictionary word ID
+	word   uint8 // dictionary word length, or 0
+	score  int
+}
+
+// find returns the best scoring match for buf[i:end], looking no
+// further back than low. The returned match has a length of 0 if there
+// is none worth using. maxDist is the largest backward distance at i,
+// past which distances refer to dictionary words.
+func (m *matcher) find(buf []byte, i, end, low, maxDist int) match {
+	best := match{score: minScore}
+	src := buf[i:end]
+
+	// Cheaply check the last distance first.
+	if d := m.last; d > 0 && i-d >= low {
+		if n := lz.MatchLen(buf[i-d:end], src); n >= lz.MinMatch {
+			if s := literalScore*n + lastDistBonus; s > best.score {
+				best = match{length: n, dist: d, score: s}
+			}
+		}
+	}
+
+	ch := m.t.Chain(buf, i)
+	for depth := m.p.Depth; depth > 0 && best.length < m.p.Nice; depth-- {
+		c, ok := ch.Next()
+		if !ok || c < low || c >= i {
+			break
+		}
+		// Check the byte that would make the match longer first.
+		if best.length < len(src) && buf[c+best.length] == src[best.length] {
+			n := lz.MatchLen(buf[c:c+len(src)], src)
+			if s := score(n, i-c); n >= lz.MinMatch && s > best.score {
+				best = match{length: n, dist: i - c, score: s}
+				if n == len(src) {
+					break
+				}
+			}
+		}
+	}
+
+	if m.p.dict && best.length < maxDictMatch {
+		best = findWord(src, maxDist, best)
+	}
+	if best.score <= minScore {
+		return match{}
+	}
+	return best
+}
+
+// compress finds commands for buf[start:end], where buf[:start] is
+// history, and sets m.cmds. The stream position of buf[0] is pos.
+// Matches reach back at most maxDist bytes.
+func (m *matcher) compress(buf []byte, start, end int, pos int64, maxDist int) {
+	m.cmds = m.cmds[:0]
+	m.t.Index(buf, start)
+
+	litStart := start
+	i := start
+	misses := 0
+	for i+lz.Margin <= end {
+		// Before the window fills, distances past the start of the
+		// stream refer to dictionary words.
+		limit := int(min(int64(maxDist), pos+int64(i)))
+		low := max(i-limit, 0)
+		mt := m.find(buf, i, end, low, limit)
+		m.t.Insert(buf, i)
+
+		if mt.length == 0 {
+			// Skip ahead faster through data that doesn't match.
+			misses++
+			i += m.p.Step(misses)
+			continue
+		}
+		misses = 0
+
+		// Look for a better match at the following positions.
+		for k := 0; k < m.p.Lazy && mt.length < m.p.Nice && i+1+lz.Margin <= end; k++ {
+			limit := int(min(int64(maxDist), pos+int64(i+1)))
+			next := m.find(buf, i+1, end, max(i+1-limit, 0), limit)
+			if next.score <= mt.score+lazyCost {
+				break
+			}
+			m.t.Insert(buf, i+1)
+			i++
+			mt = next
+		}
+
+		// Extend a backward copy into the literals.
+		if mt.word == 0 {
+			for i > litStart && i-mt.dist > low && buf[i-1] == buf[i-1-mt.dist] {
+				i--
+				mt.length++
+			}
+			m.last = mt.dist
+		}
+
+		m.cmds = append(m.cmds, command{
+			insert: uint32(i - litStart),
+			copy:   uint32(mt.length),
+			dist:   uint32(mt.dist),
+			word:   mt.word,
+		})
+
+		m.t.InsertMatch(buf, i, mt.length, end)
+		i += mt.length
+		litStart = i
+	}
+
+	if litStart < end {
+		m.cmds = append(m.cmds, command{insert: uint32(end - litStart)})
+	}
+	m.t.MarkIndexed(min(i, end-lz.Margin+1))
+}
+
+// The static dictionary is searched for words with the transforms
+// that most often match ordinary text.
+var wordTransforms = [...]uint8{0, 1, 9, 4, 6, 2, 13, 5, 8, 10}
+
+// maxDictMatch is the longest output of an indexed dictionary word.
+const maxDictMatch = maxDictWordLen + len(" the ")
+
+// A dictEntry is a transformed dictionary word.
+type dictEntry struct {
+	off uint32 // offset of the transformed word in dictIndex.out
+	id  uint32 // the word index and transform, as in a backward distance
+	n   uint8  // the word length
+	len uint8  // the transformed word length
+}
+
+// A dictIndex is a hash table of transformed dictionary words, keyed
+// by their first 4 bytes. It holds no pointers, so that the garbage
+// collector need not scan it.
+type dictIndex struct {
+	out     []byte      // the transformed words
+	buckets []uint32    // entries[buckets[h]:buckets[h+1]] have hash h
+	entries []dictEntry // longest first within each bucket
+}
+
+const dictHashLog = 15
+
+func dictHash(b []byte) uint32 {
+	return (binary.LittleEndian.Uint32(b) * 0x9e3779b1) >> (32 - dictHashLog)
+}
+
+var dictWords = sync.OnceValue(func() *dictIndex {
+	d := new(dictIndex)
+	var entries []dictEntry
+	for n := minDictWordLen; n <= maxDictWordLen; n++ {
+		nbits := dictSizeBits[n]
+		for i := range 1 << nbits {
+			word := dictWord(n, i)
+			for _, t := range wordTransforms {
+				off := len(d.out)
+				d.out = appendWord(d.out, word, &transforms[t])
+				if len(d.out)-off < lz.MinMatch {
+					d.out = d.out[:off]
+					continue
+				}
+				entries = append(entries, dictEntry{
+					off: uint32(off),
+					id:  uint32(t)<<nbits | uint32(i),
+					n:   uint8(n),
+					len: uint8(len(d.out) - off),
+				})
+			}
+		}
+	}
+
+	// Sort the entries into buckets.
+	d.buckets = make([]uint32, 1<<dictHashLog+1)
+	for _, e := range entries {
+		d.buckets[dictHash(d.out[e.off:])+1]++
+	}
+	for h := range 1 << dictHashLog {
+		d.buckets[h+1] += d.buckets[h]
+	}
+	next := slices.Clone(d.buckets)
+	d.entries = make([]dictEntry, len(entries))
+	for _, e := range entries {
+		h := dictHash(d.out[e.off:])
+		d.entries[next[h]] = e
+		next[h]++
+	}
+	for h := range 1 << dictHashLog {
+		slices.SortStableFunc(d.entries[d.buckets[h]:d.buckets[h+1]], func(a, b dictEntry) int {
+			return int(b.len) - int(a.len)
+		})
+	}
+	return d
+})
+
+// findWord returns the best of best and the dictionary words that
+// match the start of src. maxDist is the largest backward distance.
+func findWord(src []byte, maxDist int, best match) match {
+	if len(src) < lz.MinMatch {
+		return best
+	}
+	d := dictWords()
+	h := dictHash(src)
+	for _, e := range d.entries[d.buckets[h]:d.buckets[h+1]] {
+		n := int(e.len)
+		if n <= best.length {
+			break
+		}
+		if n > len(src) || !bytes.Equal(src[:n], d.out[e.off:e.off+uint32(n)]) {
+			continue
+		}
+		if s := score(n, maxDist+1+int(e.id)); s > best.score {
+			best = match{length: n, dist: int(e.id), word: e.n, score: s}
+		}
+		break
+	}
+	return best
+}
@@ -0,0 +1,387 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"math/bits"
+	"unicode/utf8"
+)
+
+// numDistanceCodes is the size of the distance alphabet with no
+// direct distance codes and no postfix bits.
+const numDistanceCodes = numDistanceShortCodes + 48
+
+// noDistance marks a command that writes no distance code.
+const noDistance = 0xffff
+
+// A commandCode is a command's insert-and-copy symbol and distance
+// symbol with their extra bits.
+type commandCode struct {
+	sym       uint16
+	distSym   uint16 // or noDistance
+	insExtra  uint32
+	copyExtra uint32
+	distExtra uint32
+	insBits   uint8
+	copyBits  uint8
+	distBits  uint8
+}
+
+// An encoder writes meta-blocks.
+type encoder struct {
+	bw   bitWriter
+	m    matcher
+	p    levelParams
+	dist [4]int // the last distances, as the decoder tracks them
+
+	codes    []commandCode
+	mode     uint8           // literal context mode
+	ctxMap   [64]uint8       // literal context to prefix code
+	litHist  [64][256]uint32 // literal counts by context, then by code
+	cmdHist  [numInsertCopyCodes]uint32
+	distHist [numDistanceCodes]uint32
+	litCodes []huffmanCode
+	cmdCode  huffmanCode
+	distCode huffmanCode
+}
+
+// reset prepares e for a new stream, as for matcher.reset.
+func (e *encoder) reset(p levelParams, sizeHint int) {
+	e.bw = bitWriter{out: e.bw.out[:0]}
+	e.p = p
+	e.m.reset(p, sizeHint)
+	e.dist = [4]int{4, 11, 15, 16}
+}
+
+// encode writes buf[start:end] as meta-blocks, where buf[:start] is
+// history and buf[0] is at stream position pos.
+func (e *encoder) encode(buf []byte, start, end int, pos int64, maxDist int) {
+	for start < end {
+		n := min(end-start, 1<<e.p.blockLog)
+		e.encodeMetaBlock(buf, start, start+n, pos, maxDist)
+		start += n
+	}
+}
+
+// encodeMetaBlock writes buf[start:end] as a compressed meta-block,
+// or as an uncompressed one if that is smaller.
+func (e *encoder) encodeMetaBlock(buf []byte, start, end int, pos int64, maxDist int) {
+	e.m.compress(buf, start, end, pos, maxDist)
+	saved := e.dist
+	e.buildCodes(buf, start, pos, maxDist)
+
+	mark := e.bw.mark()
+	e.writeMetaBlockHeader(end - start)
+	e.bw.writeBits(1, 0) // ISUNCOMPRESSED
+	e.writeCodes()
+	e.writeCommands(buf, start)
+
+	// The header of an uncompressed meta-block is at most 4 bytes,
+	// and then it is padded to a byte boundary.
+	if e.bw.bitLen(mark) > 8*(end-start+5) {
+		e.bw.rewind(mark)
+		e.dist = saved
+		e.writeMetaBlockHeader(end - start)
+		e.bw.writeBits(1, 1) // ISUNCOMPRESSED
+		e.bw.align()
+		e.bw.out = append(e.bw.out, buf[start:end]...)
+	}
+}
+
+// writeMetaBlockHeader writes ISLAST, clear, and the length n of
+// a meta-block. RFC 7932, Section 9.2.
+func (e *encoder) writeMetaBlockHeader(n int) {
+	nibbles := max((bits.Len(uint(n-1))+3)/4, 4)
+	e.bw.writeBits(1, 0)
+	e.bw.writeBits(2, uint32(nibbles-4))
+	e.bw.writeBits(uint(4*nibbles), uint32(n-1))
+}
+
+// buildCodes turns the matcher's commands into symbols and builds
+// prefix codes for them.
+func (e *encoder) buildCodes(buf []byte, start int, pos int64, maxDist int) {
+	e.mode = contextLSB6
+	ntrees := 1
+	if e.p.trees > 1 {
+		e.mode = chooseContextMode(buf[start : start+min(len(buf)-start, 1<<16)])
+		ntrees = 64
+	}
+	clear(e.litHist[:ntrees])
+	clear(e.cmdHist[:])
+	clear(e.distHist[:])
+
+	e.codes = e.codes[:0]
+	i := start
+	for _, c := range e.m.cmds {
+		// Count the literals.
+		for j := i; j < i+int(c.insert); j++ {
+			ctx := 0
+			if ntrees > 1 {
+				ctx = int(e.context(buf, j))
+			}
+			e.litHist[ctx][buf[j]]++
+		}
+		i += int(c.insert)
+
+		cc := e.commandCode(c, pos+int64(i), maxDist)
+		e.cmdHist[cc.sym]++
+		if cc.distSym != noDistance {
+			e.distHist[cc.distSym]++
+		}
+		e.codes = append(e.codes, cc)
+		i += int(c.copy)
+	}
+
+	if ntrees > 1 {
+		ntrees = clusterHistograms(e.litHist[:], e.p.trees, e.ctxMap[:])
+	} else {
+		e.ctxMap = [64]uint8{}
+	}
+	if cap(e.litCodes) < ntrees {
+		e.litCodes = append(e.litCodes[:cap(e.litCodes)], make([]huffmanCode, ntrees-cap(e.litCodes))...)
+	}
+	e.litCodes = e.litCodes[:ntrees]
+	for t := range e.litCodes {
+		e.litCodes[t].build(e.litHist[t][:], maxCodeLen)
+	}
+	e.cmdCode.build(e.cmdHist[:], maxCodeLen)
+	e.distCode.build(e.distHist[:], maxCodeLen)
+}
+
+// context returns the literal context for buf[j]. The buffer starts
+// either at the start of the stream or a whole window before j.
+func (e *encoder) context(buf []byte, j int) uint8 {
+	var p1, p2 byte
+	if j > 0 {
+		p1 = buf[j-1]
+	}
+	if j > 1 {
+		p2 = buf[j-2]
+	}
+	return literalContext(e.mode, p1, p2)
+}
+
+// chooseContextMode picks the literal context mode for data
+// that starts like sample.
+func chooseContextMode(sample []byte) uint8 {
+	// Text is best modeled as UTF-8, and anything else by the
+	// signed value of the preceding bytes.
+	valid := 0
+	for i := 0; i < len(sample); {
+		r, n := utf8.DecodeRune(sample[i:])
+		if r != utf8.RuneError {
+			valid += n
+		}
+		i += n
+	}
+	if valid*4 >= len(sample)*3 {
+		return contextUTF8
+	}
+	return contextSigned
+}
+
+// commandCode returns the codes for c, whose copy starts at stream
+// position pos, and updates the last distances as the decoder does.
+func (e *encoder) commandCode(c command, pos int64, maxDist int) commandCode {
+	ic := insertLengthCode(c.insert)
+	cc := commandCode{
+		insExtra: c.insert - insertBase[ic],
+		insBits:  insertExtra[ic],
+		distSym:  noDistance,
+	}
+	if c.copy == 0 {
+		// The meta-block ends with the literals. The copy length is
+		// ignored, and there is no distance.
+		cc.sym = uint16(insertCopySymbol(ic, 0, true))
+		return cc
+	}
+
+	copyLen := c.copy
+	if c.word > 0 {
+		copyLen = uint32(c.word)
+	}
+	lc := copyLengthCode(copyLen)
+	cc.copyExtra = copyLen - copyBase[lc]
+	cc.copyBits = copyExtra[lc]
+
+	if c.word > 0 {
+		// Distances past the start of the output or window refer to
+		// dictionary words, and are not remembered.
+		limit := int(min(int64(maxDist), pos))
+		cc.distSym, cc.distExtra, cc.distBits = longDistanceCode(limit + 1 + int(c.dist))
+		cc.sym = uint16(insertCopySymbol(ic, lc, false))
+		return cc
+	}
+
+	dist := int(c.dist)
+	code := -1
+	for k := range numDistanceShortCodes {
+		if e.dist[shortCodeIndex[k]]+int(shortCodeOffset[k]) == dist {
+			code = k
+			break
+		}
+	}
+	switch {
+	case code == 0:
+		cc.sym = uint16(insertCopySymbol(ic, lc, true))
+		if cc.sym >= 128 {
+			cc.distSym = 0
+		}
+		return cc
+	case code > 0:
+		cc.distSym = uint16(code)
+	default:
+		cc.distSym, cc.distExtra, cc.distBits = longDistanceCode(dist)
+	}
+	cc.sym = uint16(insertCopySymbol(ic, lc, false))
+	e.dist = [4]int{dist, e.dist[0], e.dist[1], e.dist[2]}
+	return cc
+}
+
+// insertLengthCode returns the insert length code for n.
+func insertLengthCode(n uint32) int {
+	switch {
+	case n < 6:
+		return int(n)
+	case n < 130:
+		nbits := bits.Len32(n-2) - 2
+		return nbits<<1 + int((n-2)>>nbits) + 2
+	case n < 2114:
+		return bits.Len32(n-66) - 1 + 10
+	case n < 6210:
+		return 21
+	case n < 22594:
+		return 22
+	}
+	return 23
+}
+
+// copyLengthCode returns the copy length code for n.
+func copyLengthCode(n uint32) int {
+	switch {
+	case n < 10:
+		return int(n) - 2
+	case n < 134:
+		nbits := bits.Len32(n-6) - 2
+		return nbits<<1 + int((n-6)>>nbits) + 4
+	case n < 2118:
+		return bits.Len32(n-70) - 1 + 12
+	}
+	return 23
+}
+
+// insertCopySymbol returns the insert-and-copy symbol for insert
+// length code ic and copy length code lc. If dist0 is set and the
+// codes allow, the symbol implies distance code 0.
+func insertCopySymbol(ic, lc int, dist0 bool) int {
+	low := (ic&7)<<3 | lc&7
+	if dist0 && ic < 8 && lc < 16 {
+		return (lc>>3)<<6 | low
+	}
+	cells := [3][3]int{{2, 3, 6}, {4, 5, 8}, {7, 9, 10}}
+	return cells[ic>>3][lc>>3]<<6 | low
+}
+
+// longDistanceCode returns the distance code and extra bits for
+// dist, with no direct codes or postfix bits.
+func longDistanceCode(dist int) (sym uint16, extra uint32, nbits uint8) {
+	d := dist + 3
+	n := bits.Len(uint(d)) - 2
+	prefix := d >> n & 1
+	sym = uint16(numDistanceShortCodes + 2*(n-1) + prefix)
+	return sym, uint32(d - (2+prefix)<<n), uint8(n)
+}
+
+// writeVarLen writes a count from 1 to 256. RFC 7932, Section 9.2.
+func (bw *bitWriter) writeVarLen(v int) {
+	if v == 1 {
+		bw.writeBits(1, 0)
+		return
+	}
+	n := bits.Len(uint(v-1)) - 1
+	bw.writeBits(1, 1)
+	bw.writeBits(3, uint32(n))
+	bw.writeBits(uint(n), uint32(v-1-1<<n))
+}
+
+// writeCodes writes the rest of a compressed meta-block header:
+// a single block type of each category, the context map and
+// the prefix codes. RFC 7932, Section 9.2.
+func (e *encoder) writeCodes() {
+	bw := &e.bw
+	bw.writeVarLen(1)  // NBLTYPESL
+	bw.writeVarLen(1)  // NBLTYPESI
+	bw.writeVarLen(1)  // NBLTYPESD
+	bw.writeBits(2, 0) // NPOSTFIX
+	bw.writeBits(4, 0) // NDIRECT
+	bw.writeBits(2, uint32(e.mode))
+
+	bw.writeVarLen(len(e.litCodes))
+	if len(e.litCodes) > 1 {
+		e.writeContextMap()
+	}
+	bw.writeVarLen(1) // NTREESD
+
+	for t := range e.litCodes {
+		e.litCodes[t].writeCode(bw, numLiteralCodes)
+	}
+	e.cmdCode.writeCode(bw, numInsertCopyCodes)
+	e.distCode.writeCode(bw, numDistanceCodes)
+}
+
+// writeContextMap writes the literal context map, with no run length
+// coding of zeros. RFC 7932, Section 7.3.
+func (e *encoder) writeContextMap() {
+	bw := &e.bw
+	bw.writeBits(1, 0) // RLEMAX
+	var hist [64]uint32
+	for _, t := range e.ctxMap {
+		hist[t]++
+	}
+	var h huffmanCode
+	h.build(hist[:len(e.litCodes)], maxCodeLen)
+	h.writeCode(bw, len(e.litCodes))
+	for _, t := range e.ctxMap {
+		h.write(bw, int(t))
+	}
+	bw.writeBits(1, 0) // IMTF
+}
+
+// writeCommands writes the commands and their literals, which start
+// at buf[start].
+func (e *encoder) writeCommands(buf []byte, start int) {
+	bw := &e.bw
+	i := start
+	for k, c := range e.m.cmds {
+		cc := &e.codes[k]
+		e.cmdCode.write(bw, int(cc.sym))
+		bw.writeBits(uint(cc.insBits), cc.insExtra)
+		bw.writeBits(uint(cc.copyBits), cc.copyExtra)
+		if len(e.litCodes) == 1 {
+			h := &e.litCodes[0]
+			for _, b := range buf[i : i+int(c.insert)] {
+				h.write(bw, int(b))
+			}
+		} else {
+			var p1, p2 byte
+			if i > 0 {
+				p1 = buf[i-1]
+			}
+			if i > 1 {
+				p2 = buf[i-2]
+			}
+			for _, b := range buf[i : i+int(c.insert)] {
+				ctx := literalContext(e.mode, p1, p2)
+				e.litCodes[e.ctxMap[ctx]].write(bw, int(b))
+				p1, p2 = b, p1
+			}
+		}
+		i += int(c.insert) + int(c.copy)
+		if cc.distSym != noDistance {
+			e.distCode.write(bw, int(cc.distSym))
+			bw.writeBits(uint(cc.distBits), cc.distExtra)
+		}
+	}
+}
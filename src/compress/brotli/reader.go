@@ -0,0 +1,726 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package brotli implements reading and writing of Brotli compressed data,
+// as specified in RFC 7932.
+//
+// Brotli is widely used as an HTTP content coding, named "br".
+package brotli
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// A CorruptInputError reports the presence of corrupt input at a given offset.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "brotli: corrupt input before offset " + strconv.FormatInt(int64(e), 10)
+}
+
+// Alphabet sizes. RFC 7932, Section 5.
+const (
+	numLiteralCodes    = 256
+	numInsertCopyCodes = 704
+	numBlockCountCodes = 26
+)
+
+// Insert length codes: the base length and number of extra bits.
+// RFC 7932, Section 5.
+var (
+	insertBase = [24]uint32{
+		0, 1, 2, 3, 4, 5, 6, 8, 10, 14, 18, 26, 34, 50, 66, 98,
+		130, 194, 322, 578, 1090, 2114, 6210, 22594,
+	}
+	insertExtra = [24]uint8{
+		0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5,
+		6, 7, 8, 9, 10, 12, 14, 24,
+	}
+)
+
+// Copy length codes: the base length and number of extra bits.
+var (
+	copyBase = [24]uint32{
+		2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 14, 18, 22, 30, 38, 54,
+		70, 102, 134, 198, 326, 582, 1094, 2118,
+	}
+	copyExtra = [24]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4,
+		5, 5, 6, 7, 8, 9, 10, 24,
+	}
+)
+
+// The insert-and-copy length code is split into 64 symbol cells. Each
+// cell gives the first insert and copy length codes it covers; symbols
+// in the first two cells also use the last distance.
+var (
+	cellInsertBase = [11]uint8{0, 0, 0, 0, 8, 8, 0, 16, 8, 16, 16}
+	cellCopyBase   = [11]uint8{0, 8, 0, 8, 0, 8, 16, 0, 16, 8, 16}
+)
+
+// Block count codes: the base count and number of extra bits.
+// RFC 7932, Section 6.
+var (
+	blockCountBase = [numBlockCountCodes]uint32{
+		1, 5, 9, 13, 17, 25, 33, 41, 49, 65, 81, 97, 113, 145, 177, 209,
+		241, 305, 369, 497, 753, 1265, 2289, 4337, 8433, 16625,
+	}
+	blockCountExtra = [numBlockCountCodes]uint8{
+		2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5,
+		6, 6, 7, 8, 9, 10, 11, 12, 13, 24,
+	}
+)
+
+// The number of distance short codes, and the ring buffer entry
+// and adjustment each refers to. RFC 7932, Section 4.
+const numDistanceShortCodes = 16
+
+var (
+	shortCodeIndex  = [numDistanceShortCodes]uint8{0, 1, 2, 3, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1}
+	shortCodeOffset = [numDistanceShortCodes]int8{0, 0, 0, 0, -1, 1, -2, 2, -3, 3, -1, 1, -2, 2, -3, 3}
+)
+
+// Block categories.
+const (
+	literalBlock = iota
+	insertCopyBlock
+	distanceBlock
+)
+
+// blockTypes tracks the block types of one category. RFC 7932, Section 6.
+type blockTypes struct {
+	num       int // NBLTYPES
+	typ       int // current block type
+	prev      int // previous block type
+	count     int // remaining length of the current block
+	typeCode  huffmanDecoder
+	countCode huffmanDecoder
+}
+
+// Decoder states.
+const (
+	stateStreamHeader = iota
+	stateMetaBlock
+	stateUncompressed
+	stateCommand
+	stateInsert
+	stateCopy
+	stateWord
+	stateDone
+)
+
+// A Reader is an io.Reader that decompresses a Brotli stream.
+type Reader struct {
+	br    bitReader
+	err   error
+	state int
+
+	// The window holds the most recently decoded bytes, of which the
+	// last pos-flushed have not yet been returned by Read.
+	window  []byte
+	mask    int   // len(window) - 1
+	pos     int64 // number of bytes decoded
+	flushed int64 // number of bytes returned by Read
+	maxDist int   // maximum backward distance
+
+	// The last four distances, dist[0] being the most recent.
+	dist [4]int
+
+	// The current meta-block.
+	isLast      bool
+	remaining   int // bytes left in the meta-block
+	blocks      [3]blockTypes
+	postfixBits uint
+	direct      int
+	modes       []uint8 // context mode for each literal block type
+	litMap      []uint8 // literal context map
+	distMap     []uint8 // distance context map
+	litCodes    []huffmanDecoder
+	cmdCodes    []huffmanDecoder
+	distCodes   []huffmanDecoder
+
+	// The current command.
+	insert    int // literals left to insert
+	copyLen   int
+	copyDist  int
+	dist0     bool // whether the command implies distance code 0
+	word      []byte
+	wordStart int
+
+	lengths [numInsertCopyCodes]uint8
+}
+
+// NewReader returns a new Reader that decompresses data from r.
+//
+// If r does not also implement io.ByteReader,
+// the decompressor may read more data than necessary from r.
+func NewReader(r io.Reader) *Reader {
+	z := new(Reader)
+	z.Reset(r)
+	return z
+}
+
+// Reset discards the Reader z's state and makes it equivalent to the
+// result of NewReader, but reading from r instead. This permits reusing
+// a Reader rather than allocating a new one.
+func (z *Reader) Reset(r io.Reader) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	z.br.reset(br)
+	z.err = nil
+	z.state = stateStreamHeader
+	z.pos = 0
+	z.flushed = 0
+	z.dist = [4]int{4, 11, 15, 16}
+}
+
+// Read reads decompressed data from the underlying stream.
+func (z *Reader) Read(p []byte) (int, error) {
+	for {
+		if avail := z.pos - z.flushed; avail > 0 {
+			start := int(z.flushed) & z.mask
+			end := start + int(min(avail, int64(len(p))))
+			end = min(end, len(z.window))
+			n := copy(p, z.window[start:end])
+			z.flushed += int64(n)
+			return n, nil
+		}
+		if len(p) == 0 {
+			return 0, z.err
+		}
+		if z.err != nil {
+			return 0, z.err
+		}
+		z.decode()
+	}
+}
+
+// corrupt records that the input is corrupt.
+func (z *Reader) corrupt() {
+	if z.br.err != nil {
+		z.err = z.br.err
+		return
+	}
+	z.err = CorruptInputError(z.br.off)
+}
+
+// space returns the number of bytes that can be decoded into the
+// window without overwriting bytes not yet returned by Read.
+func (z *Reader) space() int {
+	return len(z.window) - int(z.pos-z.flushed)
+}
+
+// put appends b to the decoded data.
+func (z *Reader) put(b byte) {
+	z.window[int(z.pos)&z.mask] = b
+	z.pos++
+}
+
+// decode decodes data until the window is full, a meta-block ends,
+// the stream ends or an error occurs.
+func (z *Reader) decode() {
+	for z.err == nil {
+		switch z.state {
+		case stateStreamHeader:
+			z.readStreamHeader()
+		case stateMetaBlock:
+			if z.pos > z.flushed {
+				// Return what is decoded before reading on, so that
+				// data flushed by the writer is available without
+				// waiting for more input.
+				return
+			}
+			z.readMetaBlockHeader()
+		case stateUncompressed:
+			for z.remaining > 0 {
+				if z.space() == 0 {
+					return
+				}
+				b := z.br.readBits(8)
+				if z.br.err != nil {
+					z.corrupt()
+					return
+				}
+				z.put(byte(b))
+				z.remaining--
+			}
+			z.endMetaBlock()
+		case stateCommand:
+			if z.remaining == 0 {
+				z.endMetaBlock()
+				continue
+			}
+			z.readCommand()
+		case stateInsert:
+			if !z.insertLiterals() {
+				return
+			}
+		case stateCopy:
+			n := min(z.copyLen, z.space())
+			if n == 0 {
+				return
+			}
+			for range n {
+				z.put(z.window[int(z.pos-int64(z.copyDist))&z.mask])
+			}
+			z.copyLen -= n
+			if z.copyLen == 0 {
+				z.state = stateCommand
+			}
+		case stateWord:
+			// A transformed word can be empty.
+			n := min(len(z.word)-z.wordStart, z.space())
+			for _, b := range z.word[z.wordStart : z.wordStart+n] {
+				z.put(b)
+			}
+			z.wordStart += n
+			if z.wordStart == len(z.word) {
+				z.state = stateCommand
+			}
+		case stateDone:
+			if !z.br.align() {
+				z.corrupt()
+				return
+			}
+			z.err = io.EOF
+		}
+		if z.space() == 0 {
+			return
+		}
+	}
+}
+
+// readStreamHeader reads the window size. RFC 7932, Section 9.1.
+func (z *Reader) readStreamHeader() {
+	br := &z.br
+	wbits := uint(16)
+	if br.readBit() {
+		if n := br.readBits(3); n != 0 {
+			wbits = 17 + uint(n)
+		} else {
+			switch n := br.readBits(3); n {
+			case 0:
+				wbits = 17
+			case 1:
+				// Reserved for large windows, which RFC 7932 does not allow.
+				z.corrupt()
+				return
+			default:
+				wbits = 8 + uint(n)
+			}
+		}
+	}
+	if br.err != nil {
+		z.corrupt()
+		return
+	}
+	size := 1 << wbits
+	if cap(z.window) < size {
+		z.window = make([]byte, size)
+	}
+	z.window = z.window[:size]
+	z.mask = size - 1
+	z.maxDist = size - 16
+	z.state = stateMetaBlock
+}
+
+// endMetaBlock moves on after the end of a meta-block.
+func (z *Reader) endMetaBlock() {
+	if z.isLast {
+		z.state = stateDone
+	} else {
+		z.state = stateMetaBlock
+	}
+}
+
+// readMetaBlockHeader reads a meta-block header, and for compressed
+// meta-blocks the prefix codes and context maps.
+// RFC 7932, Sections 9.2 and 9.3.
+func (z *Reader) readMetaBlockHeader() {
+	br := &z.br
+	z.isLast = br.readBit()
+	if z.isLast && br.readBit() {
+		// ISLASTEMPTY
+		z.state = stateDone
+		if br.err != nil {
+			z.corrupt()
+		}
+		return
+	}
+
+	nibbles := br.readBits(2) + 4
+	if nibbles == 7 {
+		// A metadata block, which is skipped.
+		if z.isLast || br.readBit() {
+			z.corrupt()
+			return
+		}
+		nbytes := br.readBits(2)
+		skip := 0
+		for i := range nbytes {
+			b := br.readBits(8)
+			if i > 0 && i == nbytes-1 && b == 0 {
+				z.corrupt()
+				return
+			}
+			skip |= int(b) << (8 * i)
+		}
+		if nbytes > 0 {
+			skip++
+		}
+		if !br.align() {
+			z.corrupt()
+			return
+		}
+		for range skip {
+			br.readBits(8)
+		}
+		if br.err != nil {
+			z.corrupt()
+		}
+		return
+	}
+	mlen := 0
+	for i := range nibbles {
+		n := br.readBits(4)
+		if nibbles > 4 && i == nibbles-1 && n == 0 {
+			z.corrupt()
+			return
+		}
+		mlen |= int(n) << (4 * i)
+	}
+	z.remaining = mlen + 1
+
+	if !z.isLast && br.readBit() {
+		// ISUNCOMPRESSED
+		if !br.align() {
+			z.corrupt()
+			return
+		}
+		z.state = stateUncompressed
+		return
+	}
+
+	for i := range z.blocks {
+		if !z.readBlockTypes(&z.blocks[i]) {
+			z.corrupt()
+			return
+		}
+	}
+
+	z.postfixBits = uint(br.readBits(2))
+	z.direct = int(br.readBits(4)) << z.postfixBits
+
+	nlit := z.blocks[literalBlock].num
+	z.modes = resize(z.modes, nlit)
+	for i := range z.modes {
+		z.modes[i] = uint8(br.readBits(2))
+	}
+
+	var ok bool
+	var nLitTrees, nDistTrees int
+	if z.litMap, nLitTrees, ok = z.readContextMap(z.litMap, 64*nlit); !ok {
+		z.corrupt()
+		return
+	}
+	if z.distMap, nDistTrees, ok = z.readContextMap(z.distMap, 4*z.blocks[distanceBlock].num); !ok {
+		z.corrupt()
+		return
+	}
+
+	z.litCodes = resizeCodes(z.litCodes, nLitTrees)
+	for i := range z.litCodes {
+		if !z.readPrefixCode(&z.litCodes[i], numLiteralCodes) {
+			z.corrupt()
+			return
+		}
+	}
+	z.cmdCodes = resizeCodes(z.cmdCodes, z.blocks[insertCopyBlock].num)
+	for i := range z.cmdCodes {
+		if !z.readPrefixCode(&z.cmdCodes[i], numInsertCopyCodes) {
+			z.corrupt()
+			return
+		}
+	}
+	distAlphabet := numDistanceShortCodes + z.direct + 48<<z.postfixBits
+	z.distCodes = resizeCodes(z.distCodes, nDistTrees)
+	for i := range z.distCodes {
+		if !z.readPrefixCode(&z.distCodes[i], distAlphabet) {
+			z.corrupt()
+			return
+		}
+	}
+	if br.err != nil {
+		z.corrupt()
+		return
+	}
+	z.state = stateCommand
+}
+
+func resize(b []uint8, n int) []uint8 {
+	if cap(b) < n {
+		return make([]uint8, n)
+	}
+	return b[:n]
+}
+
+func resizeCodes(h []huffmanDecoder, n int) []huffmanDecoder {
+	if cap(h) < n {
+		h = append(h[:cap(h)], make([]huffmanDecoder, n-cap(h))...)
+	}
+	return h[:n]
+}
+
+// readVarLen reads a value from 1 to 256. RFC 7932, Section 9.2.
+func (br *bitReader) readVarLen() int {
+	if !br.readBit() {
+		return 1
+	}
+	n := uint(br.readBits(3))
+	return 1<<n + int(br.readBits(n)) + 1
+}
+
+// readBlockTypes reads the number of block types of a category and,
+// if there is more than one, their codes and the first block count.
+func (z *Reader) readBlockTypes(b *blockTypes) bool {
+	b.num = z.br.readVarLen()
+	b.typ, b.prev = 0, 1
+	if b.num < 2 {
+		b.count = 1 << 24
+		return z.br.err == nil
+	}
+	if !z.readPrefixCode(&b.typeCode, b.num+2) || !z.readPrefixCode(&b.countCode, numBlockCountCodes) {
+		return false
+	}
+	b.count = z.readBlockCount(b)
+	return z.br.err == nil
+}
+
+func (z *Reader) readBlockCount(b *blockTypes) int {
+	sym := z.br.readSymbol(&b.countCode)
+	return int(blockCountBase[sym] + z.br.readBits(uint(blockCountExtra[sym])))
+}
+
+// switchBlock reads a block switch command. RFC 7932, Section 6.
+func (z *Reader) switchBlock(b *blockTypes) {
+	typ := z.br.readSymbol(&b.typeCode)
+	switch typ {
+	case 0:
+		typ = b.prev
+	case 1:
+		typ = (b.typ + 1) % b.num
+	default:
+		typ -= 2
+	}
+	b.prev, b.typ = b.typ, typ
+	b.count = z.readBlockCount(b)
+}
+
+// readContextMap reads a context map of size entries into m, returning
+// the number of prefix codes it refers to. RFC 7932, Section 7.3.
+func (z *Reader) readContextMap(m []uint8, size int) ([]uint8, int, bool) {
+	br := &z.br
+	m = resize(m, size)
+	clear(m)
+	ntrees := br.readVarLen()
+	if ntrees < 2 {
+		return m, ntrees, br.err == nil
+	}
+
+	rleMax := 0
+	if br.readBit() {
+		rleMax = int(br.readBits(4)) + 1
+	}
+	var h huffmanDecoder
+	if !z.readPrefixCode(&h, ntrees+rleMax) {
+		return m, 0, false
+	}
+	for i := 0; i < size; {
+		sym := br.readSymbol(&h)
+		switch {
+		case sym == 0:
+			i++
+		case sym <= rleMax:
+			n := 1<<sym + int(br.readBits(uint(sym)))
+			if i+n > size {
+				return m, 0, false
+			}
+			i += n // m is already zero
+		default:
+			m[i] = uint8(sym - rleMax)
+			i++
+		}
+		if br.err != nil {
+			return m, 0, false
+		}
+	}
+	if br.readBit() {
+		inverseMoveToFront(m)
+	}
+	return m, ntrees, br.err == nil
+}
+
+func inverseMoveToFront(m []uint8) {
+	var mtf [256]uint8
+	for i := range mtf {
+		mtf[i] = uint8(i)
+	}
+	for i, idx := range m {
+		v := mtf[idx]
+		m[i] = v
+		copy(mtf[1:idx+1], mtf[:idx])
+		mtf[0] = v
+	}
+}
+
+// readCommand reads an insert-and-copy length code. RFC 7932, Section 5.
+func (z *Reader) readCommand() {
+	br := &z.br
+	b := &z.blocks[insertCopyBlock]
+	if b.count == 0 {
+		z.switchBlock(b)
+	}
+	b.count--
+	sym := br.readSymbol(&z.cmdCodes[b.typ])
+	cell := sym >> 6
+	ic := int(cellInsertBase[cell]) + (sym>>3)&7
+	cc := int(cellCopyBase[cell]) + sym&7
+	z.insert = int(insertBase[ic] + br.readBits(uint(insertExtra[ic])))
+	z.copyLen = int(copyBase[cc] + br.readBits(uint(copyExtra[cc])))
+	z.dist0 = cell < 2
+	if br.err != nil {
+		z.corrupt()
+		return
+	}
+	if z.insert > z.remaining {
+		z.corrupt()
+		return
+	}
+	z.state = stateInsert
+}
+
+// insertLiterals inserts the command's literals and then reads its
+// distance. It reports false if it stopped for lack of space.
+func (z *Reader) insertLiterals() bool {
+	br := &z.br
+	b := &z.blocks[literalBlock]
+	for ; z.insert > 0; z.insert-- {
+		if z.space() == 0 {
+			return false
+		}
+		if b.count == 0 {
+			z.switchBlock(b)
+		}
+		b.count--
+		var p1, p2 byte
+		if z.pos > 0 {
+			p1 = z.window[int(z.pos-1)&z.mask]
+		}
+		if z.pos > 1 {
+			p2 = z.window[int(z.pos-2)&z.mask]
+		}
+		ctx := literalContext(z.modes[b.typ], p1, p2)
+		lit := br.readSymbol(&z.litCodes[z.litMap[64*b.typ+int(ctx)]])
+		if br.err != nil {
+			z.corrupt()
+			return false
+		}
+		z.put(byte(lit))
+		z.remaining--
+	}
+
+	if z.remaining == 0 {
+		// The copy of the last command of a meta-block is ignored.
+		z.state = stateCommand
+		return true
+	}
+
+	dist, push := z.readDistance()
+	if z.err != nil {
+		return false
+	}
+	maxDist := int(min(int64(z.maxDist), z.pos))
+	if dist > maxDist {
+		return z.dictionaryWord(dist - maxDist - 1)
+	}
+	if push {
+		z.dist = [4]int{dist, z.dist[0], z.dist[1], z.dist[2]}
+	}
+	if z.copyLen > z.remaining {
+		z.corrupt()
+		return false
+	}
+	z.remaining -= z.copyLen
+	z.copyDist = dist
+	z.state = stateCopy
+	return true
+}
+
+// readDistance reads the command's distance and reports whether it
+// is pushed onto the last distances. RFC 7932, Section 4.
+func (z *Reader) readDistance() (dist int, push bool) {
+	if z.dist0 {
+		return z.dist[0], false
+	}
+	br := &z.br
+	b := &z.blocks[distanceBlock]
+	if b.count == 0 {
+		z.switchBlock(b)
+	}
+	b.count--
+	ctx := min(z.copyLen-2, 3)
+	code := br.readSymbol(&z.distCodes[z.distMap[4*b.typ+ctx]])
+	switch {
+	case code < numDistanceShortCodes:
+		dist = z.dist[shortCodeIndex[code]] + int(shortCodeOffset[code])
+		if dist <= 0 {
+			z.corrupt()
+			return 0, false
+		}
+	case code < numDistanceShortCodes+z.direct:
+		dist = code - numDistanceShortCodes + 1
+	default:
+		c := code - numDistanceShortCodes - z.direct
+		nbits := 1 + uint(c>>(z.postfixBits+1))
+		hcode := c >> z.postfixBits
+		lcode := c & (1<<z.postfixBits - 1)
+		offset := (2+hcode&1)<<nbits - 4
+		dist = (offset+int(br.readBits(nbits)))<<z.postfixBits + lcode + z.direct + 1
+	}
+	if br.err != nil {
+		z.corrupt()
+		return 0, false
+	}
+	return dist, code != 0
+}
+
+// dictionaryWord inserts the static dictionary word with the given ID
+// and the command's copy length. RFC 7932, Section 8.
+func (z *Reader) dictionaryWord(id int) bool {
+	n := z.copyLen
+	if n < minDictWordLen || n > maxDictWordLen {
+		z.corrupt()
+		return false
+	}
+	sizeBits := dictSizeBits[n]
+	t := id >> sizeBits
+	if t >= len(transforms) {
+		z.corrupt()
+		return false
+	}
+	word := dictWord(n, id&(1<<sizeBits-1))
+	z.word = appendWord(z.word[:0], word, &transforms[t])
+	if len(z.word) > z.remaining {
+		z.corrupt()
+		return false
+	}
+	z.remaining -= len(z.word)
+	z.wordStart = 0
+	z.state = stateWord
+	return true
+}
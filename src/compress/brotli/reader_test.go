@@ -0,0 +1,168 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var readerTests = []struct {
+	name, uncompressed, compressed string
+}{
+	{
+		"empty",
+		"",
+		"\x06",
+	},
+	{
+		"hello",
+		"hello, world\n",
+		"\x82\x01\x00\x80\xa4\x02\x08\x4b\x0e\xa2\xc8\x24\x35\x79\x4e\x7f\x0c",
+	},
+	{
+		"uncompressed",
+		"hello, world\n",
+		"\x0b\x06\x80\x68\x65\x6c\x6c\x6f\x2c\x20\x77\x6f\x72\x6c\x64\x0a\x03",
+	},
+	{
+		"repeat",
+		strings.Repeat("ab", 3000),
+		"\xe2\xed\x02\x80\x54\x98\x58\x5c\x16\x06\xca\x03\x80",
+	},
+	{
+		// Uses dictionary words with transforms.
+		"dictionary",
+		"The government announced a conference",
+		"\x1b\x24\x00\xf8\x25\x00\xf2\x10\x60\x84\xc9\x4d\xc2\x01\x69\xe2\x0d\x60\x04",
+	},
+}
+
+func TestReader(t *testing.T) {
+	for _, test := range readerTests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(test.compressed))
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.uncompressed {
+				t.Errorf("got %q, want %q", got, test.uncompressed)
+			}
+		})
+	}
+}
+
+func TestReaderFiles(t *testing.T) {
+	opticks, err := os.ReadFile("../../testdata/Isaac.Newton-Opticks.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	random, err := os.ReadFile("../bzip2/testdata/pass-random1.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]byte{
+		"Isaac.Newton-Opticks.txt.br": opticks,
+		"opticks-16k.q0.w10.br":       opticks[:16384],
+		"opticks-16k.q2.w18.br":       opticks[:16384],
+		"opticks-16k.q5.w16.br":       opticks[:16384],
+		"opticks-16k.q9.w24.br":       opticks[:16384],
+		"pass-random1.br":             random,
+	}
+	for name, data := range want {
+		t.Run(name, func(t *testing.T) {
+			c, err := os.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := io.ReadAll(NewReader(bytes.NewReader(c)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("got %d bytes, want %d", len(got), len(data))
+			}
+
+			// Read a byte at a time from a reader that is not
+			// an io.ByteReader.
+			r := NewReader(iotest.OneByteReader(bytes.NewReader(c)))
+			got, err = io.ReadAll(iotest.OneByteReader(r))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("reading a byte at a time: got %d bytes, want %d", len(got), len(data))
+			}
+		})
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	hello := readerTests[1].compressed
+	tests := []struct {
+		name, compressed string
+		err              error
+	}{
+		{"no input", "", io.ErrUnexpectedEOF},
+		{"truncated", hello[:len(hello)-1], io.ErrUnexpectedEOF},
+		{"large window", "\x11", CorruptInputError(1)},
+		{"padding", "\x0e", CorruptInputError(1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := io.ReadAll(NewReader(strings.NewReader(test.compressed)))
+			if !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestReaderReset(t *testing.T) {
+	r := NewReader(strings.NewReader(readerTests[1].compressed))
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range readerTests {
+		r.Reset(strings.NewReader(test.compressed))
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if string(got) != test.uncompressed {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.uncompressed)
+		}
+	}
+}
+
+// TestReaderStopsAtEnd checks that the Reader does not consume input
+// after the end of the stream when reading from an io.ByteReader.
+func TestReaderStopsAtEnd(t *testing.T) {
+	in := strings.NewReader(readerTests[1].compressed + "trailing")
+	if _, err := io.ReadAll(NewReader(in)); err != nil {
+		t.Fatal(err)
+	}
+	rest, _ := io.ReadAll(in)
+	if string(rest) != "trailing" {
+		t.Errorf("left %q unread, want %q", rest, "trailing")
+	}
+}
+
+func FuzzReader(f *testing.F) {
+	for _, test := range readerTests {
+		f.Add([]byte(test.compressed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := NewReader(bytes.NewReader(data))
+		io.Copy(io.Discard, r)
+	})
+}
@@ -0,0 +1,243 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Compression levels, which are the Brotli quality settings.
+const (
+	BestSpeed          = 0
+	BestCompression    = 11
+	DefaultCompression = -1
+)
+
+// defaultLevel is the level used for DefaultCompression.
+const defaultLevel = 6
+
+// minWindowLog is the log of the smallest window size.
+const minWindowLog = 10
+
+var errWriterClosed = errors.New("brotli: closed writer")
+
+// A Writer is an io.WriteCloser.
+// Writes to a Writer are compressed and written to w.
+type Writer struct {
+	w         io.Writer
+	level     int
+	p         levelParams
+	windowLog uint8 // log of the window size written in the header
+
+	buf   []byte // history followed by data not yet compressed
+	start int    // start of the data not yet compressed
+	pos   int64  // stream position of buf[0]
+
+	enc encoder
+
+	wroteHeader bool // whether the stream header was written
+	closed      bool
+	err         error
+}
+
+// NewWriter returns a new Writer.
+// Writes to the returned writer are compressed and written to w.
+//
+// It is the caller's responsibility to call Close on the Writer when done.
+// Writes may be buffered and not flushed until Close.
+func NewWriter(w io.Writer) *Writer {
+	z, _ := NewWriterLevel(w, DefaultCompression)
+	return z
+}
+
+// NewWriterLevel is like NewWriter but specifies the compression level
+// instead of assuming DefaultCompression.
+//
+// The compression level can be DefaultCompression, or any integer
+// value between BestSpeed and BestCompression inclusive.
+// The error returned will be nil if the level is valid.
+func NewWriterLevel(w io.Writer, level int) (*Writer, error) {
+	if level == DefaultCompression {
+		level = defaultLevel
+	}
+	if level < BestSpeed || level > BestCompression {
+		return nil, fmt.Errorf("brotli: invalid compression level %d: want value in range [%d, %d] or %d", level, BestSpeed, BestCompression, DefaultCompression)
+	}
+	z := &Writer{level: level, p: levels[level]}
+	z.Reset(w)
+	return z, nil
+}
+
+// Reset discards the Writer z's state and makes it equivalent to the
+// result of its original state from NewWriter or NewWriterLevel, but
+// writing to w instead. This permits reusing a Writer rather than
+// allocating a new one. The compression level is kept.
+func (z *Writer) Reset(w io.Writer) {
+	z.w = w
+	z.buf = z.buf[:0]
+	z.start = 0
+	z.pos = 0
+	z.wroteHeader = false
+	z.closed = false
+	z.err = nil
+}
+
+// maxDist returns the largest backward distance allowed by the window.
+func (z *Writer) maxDist() int {
+	return 1<<z.windowLog - 16
+}
+
+// Write writes a compressed form of p to the underlying io.Writer.
+// The compressed bytes are not necessarily flushed until
+// the Writer is closed or explicitly flushed.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.closed {
+		return 0, errWriterClosed
+	}
+	n := len(p)
+	blockSize := 1 << z.p.blockLog
+	for len(p) > 0 {
+		k := min(len(p), blockSize-(len(z.buf)-z.start))
+		z.buf = append(z.buf, p[:k]...)
+		p = p[k:]
+		if len(z.buf)-z.start == blockSize {
+			if err := z.compress(); err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush flushes any pending compressed data to the underlying writer.
+//
+// It is useful mainly in compressed network protocols, to ensure that
+// a remote reader has enough data to reconstruct a packet. Flush does
+// not return until the data has been written. If the underlying writer
+// returns an error, Flush returns that error.
+func (z *Writer) Flush() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	z.writeHeader(false)
+	z.enc.encode(z.buf, z.start, len(z.buf), z.pos, z.maxDist())
+	z.advance()
+
+	// An empty metadata block pads the output to a byte boundary.
+	// RFC 7932, Section 9.2.
+	bw := &z.enc.bw
+	bw.writeBits(1, 0) // ISLAST
+	bw.writeBits(2, 3) // MNIBBLES: metadata
+	bw.writeBits(1, 0) // reserved
+	bw.writeBits(2, 0) // MSKIPBYTES
+	bw.align()
+	return z.write()
+}
+
+// Close closes the Writer by flushing any unwritten data to the
+// underlying io.Writer and ending the stream. It does not close
+// the underlying io.Writer.
+func (z *Writer) Close() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	z.closed = true
+	z.writeHeader(true)
+	z.enc.encode(z.buf, z.start, len(z.buf), z.pos, z.maxDist())
+	z.advance()
+
+	// An empty last meta-block ends the stream.
+	bw := &z.enc.bw
+	bw.writeBits(1, 1) // ISLAST
+	bw.writeBits(1, 1) // ISLASTEMPTY
+	bw.align()
+	return z.write()
+}
+
+// compress compresses the pending data as a meta-block and writes out
+// the whole bytes of output.
+func (z *Writer) compress() error {
+	z.writeHeader(false)
+	z.enc.encode(z.buf, z.start, len(z.buf), z.pos, z.maxDist())
+	z.advance()
+	z.enc.bw.flushBytes()
+	return z.write()
+}
+
+// writeHeader writes the stream header, giving the window size, if it
+// has not been written yet. If last is set, all of the data is in the
+// buffer, and the window and encoder are sized to fit it.
+// RFC 7932, Section 9.1.
+func (z *Writer) writeHeader(last bool) {
+	if z.wroteHeader {
+		return
+	}
+	z.wroteHeader = true
+	z.windowLog = z.p.WindowLog
+	sizeHint := 0
+	if last {
+		// A smaller window saves the decoder memory.
+		sizeHint = len(z.buf)
+		for z.windowLog > minWindowLog && 1<<(z.windowLog-1)-16 >= sizeHint {
+			z.windowLog--
+		}
+	}
+	z.enc.reset(z.p, sizeHint)
+
+	bw := &z.enc.bw
+	switch wbits := uint32(z.windowLog); {
+	case wbits == 16:
+		bw.writeBits(1, 0)
+	case wbits == 17:
+		bw.writeBits(7, 1)
+	case wbits > 17:
+		bw.writeBits(4, (wbits-17)<<1|1)
+	default:
+		bw.writeBits(7, (wbits-8)<<4|1)
+	}
+}
+
+// advance marks the pending data as compressed and drops history
+// that is no longer needed.
+func (z *Writer) advance() {
+	z.start = len(z.buf)
+	keep := 1 << z.windowLog
+	// Sliding only occasionally keeps the copying cheap.
+	if z.start < 2*keep {
+		return
+	}
+	delta := z.start - keep
+	copy(z.buf, z.buf[delta:])
+	z.buf = z.buf[:len(z.buf)-delta]
+	z.start -= delta
+	z.pos += int64(delta)
+	z.enc.m.t.Slide(z.buf, delta)
+}
+
+// write writes the whole bytes of output to the underlying writer,
+// recording any error.
+func (z *Writer) write() error {
+	bw := &z.enc.bw
+	if len(bw.out) == 0 {
+		return nil
+	}
+	_, err := z.w.Write(bw.out)
+	bw.out = bw.out[:0]
+	if err != nil {
+		z.err = err
+	}
+	return err
+}
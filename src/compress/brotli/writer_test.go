@@ -0,0 +1,664 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package brotli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"internal/testenv"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testInputs returns inputs for which the encoder makes different
+// choices of context mode, dictionary use and meta-block type.
+func testInputs(t testing.TB) map[string][]byte {
+	opticks, err := os.ReadFile("../../testdata/Isaac.Newton-Opticks.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 200<<10)
+	r.Read(random)
+
+	// Text in scripts with multi-byte UTF-8 sequences, whose contexts
+	// differ from those of ASCII.
+	words := strings.Fields("καλημέρα κόσμε ελληνική γλώσσα こんにちは 世界 日本語の 文字 привет мир")
+	var utf8 bytes.Buffer
+	for utf8.Len() < 100<<10 {
+		utf8.WriteString(words[r.Intn(len(words))])
+		utf8.WriteByte(" \n"[r.Intn(2)])
+	}
+
+	return map[string][]byte{
+		"empty":   nil,
+		"byte":    {'x'},
+		"text":    opticks[:200<<10],
+		"utf8":    utf8.Bytes(),
+		"samples": samples(r, 100<<10),
+		"random":  random,
+		"zeros":   make([]byte, 300<<10),
+	}
+}
+
+// samples returns n/2 noisy 16-bit audio samples, which are best
+// modeled in the signed context mode.
+func samples(r *rand.Rand, n int) []byte {
+	b := make([]byte, 0, n)
+	for i := 0; len(b) < n; i++ {
+		v := 8000*math.Sin(float64(i)/20) + float64(r.Intn(200))
+		b = binary.LittleEndian.AppendUint16(b, uint16(int16(v)))
+	}
+	return b
+}
+
+func compress(t testing.TB, data []byte, level int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriterLevel(&buf, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write in pieces that don't line up with the meta-blocks.
+	for len(data) > 0 {
+		n := min(len(data), 70000)
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// compressParams compresses data with the encoder parameters p,
+// rather than those of a level.
+func compressParams(t testing.TB, data []byte, p levelParams) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := &Writer{p: p}
+	w.Reset(&buf)
+	w.Write(data)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decompress(t testing.TB, data []byte) []byte {
+	t.Helper()
+	got, err := io.ReadAll(NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+// A metaBlock describes a meta-block holding data.
+type metaBlock struct {
+	len          int
+	uncompressed bool
+	mode         uint8 // literal context mode
+	trees        int   // number of literal prefix codes
+}
+
+// metaBlocks decodes the stream c, checking that it holds want, and
+// returns its meta-blocks. It runs the decoder a state at a time to
+// see each meta-block header.
+func metaBlocks(t *testing.T, c, want []byte) []metaBlock {
+	t.Helper()
+	z := NewReader(bytes.NewReader(c))
+	var blocks []metaBlock
+	var out []byte
+	for z.err == nil {
+		switch z.state {
+		case stateStreamHeader:
+			z.readStreamHeader()
+		case stateMetaBlock:
+			z.readMetaBlockHeader()
+			switch z.state {
+			case stateUncompressed:
+				blocks = append(blocks, metaBlock{len: z.remaining, uncompressed: true})
+			case stateCommand:
+				blocks = append(blocks, metaBlock{len: z.remaining, mode: z.modes[0], trees: len(z.litCodes)})
+			}
+		default:
+			// Decoding stops at the end of each meta-block, as
+			// long as the output is taken before it fills the window.
+			z.decode()
+			for z.flushed < z.pos {
+				start := int(z.flushed) & z.mask
+				end := min(start+int(z.pos-z.flushed), len(z.window))
+				out = append(out, z.window[start:end]...)
+				z.flushed += int64(end - start)
+			}
+		}
+	}
+	if z.err != io.EOF {
+		t.Fatalf("decoding: %v", z.err)
+	}
+	if !bytes.Equal(out, want) {
+		t.Fatalf("decoded %d bytes, want %d", len(out), len(want))
+	}
+	n := 0
+	for _, b := range blocks {
+		n += b.len
+	}
+	if n != len(out) {
+		t.Fatalf("meta-blocks hold %d bytes, decoded %d", n, len(out))
+	}
+	return blocks
+}
+
+func TestRoundTrip(t *testing.T) {
+	for name, data := range testInputs(t) {
+		for level := BestSpeed; level <= BestCompression; level++ {
+			if testing.Short() && level%4 != 1 {
+				continue
+			}
+			t.Run(fmt.Sprintf("%s/%d", name, level), func(t *testing.T) {
+				c := compress(t, data, level)
+				blocks := metaBlocks(t, c, data)
+				// Falling back to uncompressed meta-blocks bounds
+				// the expansion.
+				if max := len(data) + 5*len(blocks) + 8; len(c) > max {
+					t.Errorf("compressed %d bytes to %d, want at most %d", len(data), len(c), max)
+				}
+			})
+		}
+	}
+}
+
+// TestLevels checks that the parameters of every level are within the
+// limits of the format.
+func TestLevels(t *testing.T) {
+	for _, level := range []int{-2, 12} {
+		if _, err := NewWriterLevel(io.Discard, level); err == nil {
+			t.Errorf("NewWriterLevel(%d) succeeded", level)
+		}
+	}
+	for level, p := range levels {
+		// RFC 7932 allows windows of 2^10 to 2^24 bytes, meta-blocks
+		// of up to 2^24 bytes and up to 256 prefix codes of a kind.
+		if p.WindowLog < minWindowLog || p.WindowLog > 24 {
+			t.Errorf("level %d: window log %d", level, p.WindowLog)
+		}
+		if p.blockLog > 24 {
+			t.Errorf("level %d: meta-block log %d", level, p.blockLog)
+		}
+		if p.trees < 1 || p.trees > 256 {
+			t.Errorf("level %d: %d literal prefix codes", level, p.trees)
+		}
+		if level > 0 && p.WindowLog < levels[level-1].WindowLog {
+			t.Errorf("level %d has a smaller window than level %d", level, level-1)
+		}
+	}
+}
+
+// TestWindowSize checks the window size written in the stream header.
+// A stream that is all written by Close gets the smallest window that
+// holds it; a stream that starts before then gets the level's window.
+func TestWindowSize(t *testing.T) {
+	text := testInputs(t)["text"]
+	var buf bytes.Buffer
+	for _, level := range []int{BestSpeed, defaultLevel} {
+		p := levels[level]
+		w, _ := NewWriterLevel(&buf, level)
+		for _, n := range []int{0, 1, 1<<10 - 16, 1<<10 - 15, 5000, 1<<16 - 16, 1 << 16, 200000} {
+			for _, flush := range []bool{false, true} {
+				buf.Reset()
+				w.Reset(&buf)
+				w.Write(text[:n])
+				if flush {
+					w.Flush()
+				}
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+				want := 1 << p.WindowLog
+				if !flush && n < 1<<p.blockLog {
+					for want > 1<<minWindowLog && want/2-16 >= n {
+						want /= 2
+					}
+				}
+				z := NewReader(bytes.NewReader(buf.Bytes()))
+				z.readStreamHeader()
+				if z.err != nil || len(z.window) != want {
+					t.Errorf("level %d, %d bytes, flush %v: window of %d bytes, want %d (error %v)", level, n, flush, len(z.window), want, z.err)
+				}
+				metaBlocks(t, buf.Bytes(), text[:n])
+			}
+		}
+	}
+}
+
+func TestChooseContextMode(t *testing.T) {
+	inputs := testInputs(t)
+	tests := []struct {
+		name string
+		want uint8
+	}{
+		{"text", contextUTF8},
+		{"utf8", contextUTF8},
+		{"samples", contextSigned},
+		{"random", contextSigned},
+	}
+	for _, tt := range tests {
+		if got := chooseContextMode(inputs[tt.name][:1<<16]); got != tt.want {
+			t.Errorf("chooseContextMode(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestContextModeling(t *testing.T) {
+	inputs := testInputs(t)
+	for _, name := range []string{"text", "utf8", "samples"} {
+		data := inputs[name]
+		want := chooseContextMode(data)
+		for _, level := range []int{1, 5, BestCompression} {
+			p := levels[level]
+			for _, b := range metaBlocks(t, compress(t, data, level), data) {
+				if b.uncompressed {
+					t.Fatalf("%s/%d: data stored uncompressed", name, level)
+				}
+				mode, trees := uint8(contextLSB6), 1
+				if p.trees > 1 {
+					mode, trees = want, p.trees
+				}
+				if b.mode != mode || b.trees > trees {
+					t.Errorf("%s/%d: meta-block has mode %d and %d trees, want mode %d and at most %d trees", name, level, b.mode, b.trees, mode, trees)
+				}
+			}
+		}
+
+		// Modeling the contexts must pay for their description.
+		p := levels[BestCompression]
+		c := len(compressParams(t, data, p))
+		p.trees = 1
+		c1 := len(compressParams(t, data, p))
+		if c >= c1 {
+			t.Errorf("%s: compressed to %d bytes with context modeling, %d without", name, c, c1)
+		}
+	}
+}
+
+func TestClusterHistograms(t *testing.T) {
+	// Contexts of three kinds, with disjoint alphabets, so that
+	// only contexts of the same kind are worth merging.
+	var hists [64][256]uint32
+	kind := func(ctx int) int { return ctx % 3 }
+	for ctx := range 60 {
+		for s := range 50 {
+			hists[ctx][kind(ctx)*50+s] = uint32(100 + 7*s)
+		}
+	}
+	var want [3][256]uint32
+	for ctx := range 64 {
+		for s, c := range hists[ctx] {
+			want[kind(ctx)][s] += c
+		}
+	}
+	var total uint64
+	for _, h := range want {
+		for _, c := range h {
+			total += uint64(c)
+		}
+	}
+
+	for _, maxTrees := range []int{1, 2, 3, 64} {
+		h := hists
+		var ctxMap [64]uint8
+		n := clusterHistograms(h[:], maxTrees, ctxMap[:])
+		if n > maxTrees {
+			t.Errorf("maxTrees %d: got %d trees", maxTrees, n)
+		}
+		if maxTrees >= 3 && n != 3 {
+			t.Errorf("maxTrees %d: got %d trees, want 3", maxTrees, n)
+		}
+		var sum uint64
+		for _, hist := range h[:n] {
+			for _, c := range hist {
+				sum += uint64(c)
+			}
+		}
+		if sum != total {
+			t.Errorf("maxTrees %d: trees hold %d symbols, want %d", maxTrees, sum, total)
+		}
+		for ctx := range 60 {
+			if int(ctxMap[ctx]) >= n {
+				t.Fatalf("maxTrees %d: context %d maps to tree %d of %d", maxTrees, ctx, ctxMap[ctx], n)
+			}
+			if n == 3 && h[ctxMap[ctx]] != want[kind(ctx)] {
+				t.Errorf("maxTrees %d: context %d is in the wrong tree", maxTrees, ctx)
+			}
+		}
+	}
+}
+
+func TestAppendWord(t *testing.T) {
+	tests := []struct {
+		word string
+		t    int
+		want string
+	}{
+		{"time", 0, "time"},
+		{"time", 2, " time "},
+		{"time", 4, "Time "},
+		{"time", 7, "s time "},
+		{"time", 11, "me"},
+		{"time", 12, "tim"},
+		{"time", 83, " TIME "},
+		{"café", 83, " CAFÉ "},
+		{"ünder", 9, "Ünder"},
+		// The format's simplified upper case of a 3 byte sequence.
+		{"ab日", 44, "AB\xe6\x97\xa0"},
+	}
+	for _, tt := range tests {
+		if got := appendWord(nil, tt.word, &transforms[tt.t]); string(got) != tt.want {
+			t.Errorf("appendWord(%q, %d) = %q, want %q", tt.word, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestDictionaryTransforms(t *testing.T) {
+	// Bytes that are in no dictionary word keep the matcher from
+	// using the dictionary anywhere but the transformed word.
+	pad := bytes.Repeat([]byte{0xff}, 8)
+	for _, tr := range wordTransforms {
+		for _, n := range []int{6, 9, 12} {
+			word := appendWord(nil, dictWord(n, 100), &transforms[tr])
+			data := slices.Concat(pad, word, pad)
+
+			var m matcher
+			p := levels[defaultLevel]
+			m.reset(p, len(data))
+			m.compress(data, 0, len(data), 0, 1<<p.WindowLog-16)
+			i, found := 0, false
+			for _, c := range m.cmds {
+				i += int(c.insert)
+				if c.word > 0 {
+					nbits := dictSizeBits[c.word]
+					got := appendWord(nil, dictWord(int(c.word), int(c.dist&(1<<nbits-1))), &transforms[c.dist>>nbits])
+					if !bytes.Equal(got, data[i:i+int(c.copy)]) {
+						t.Fatalf("transform %d of %q: word %d/%d covers %q, want %q", tr, dictWord(n, 100), c.word, c.dist, data[i:i+int(c.copy)], got)
+					}
+					found = found || i == len(pad) && int(c.copy) == len(word)
+				}
+				i += int(c.copy)
+			}
+			if !found {
+				t.Errorf("transform %d of %q: no dictionary match for %q", tr, dictWord(n, 100), word)
+			}
+			if got := decompress(t, compress(t, data, DefaultCompression)); !bytes.Equal(got, data) {
+				t.Fatalf("transform %d of %q: round trip mismatch", tr, dictWord(n, 100))
+			}
+		}
+	}
+}
+
+func TestDictionaryLevels(t *testing.T) {
+	// Text that never repeats itself can only be compressed
+	// with the static dictionary.
+	data := []byte("The government announced that the international conference would take place in December.")
+	for level := BestSpeed; level <= BestCompression; level++ {
+		var m matcher
+		p := levels[level]
+		m.reset(p, len(data))
+		m.compress(data, 0, len(data), 0, 1<<p.WindowLog-16)
+		words := 0
+		for _, c := range m.cmds {
+			if c.word > 0 {
+				words++
+			}
+		}
+		if p.dict != (words > 0) {
+			t.Errorf("level %d: used %d dictionary words", level, words)
+		}
+	}
+	plain := compress(t, data, BestSpeed)
+	dict := compress(t, data, DefaultCompression)
+	if got := decompress(t, dict); !bytes.Equal(got, data) {
+		t.Fatal("round trip mismatch")
+	}
+	if len(dict) >= len(plain) {
+		t.Errorf("compressed to %d bytes with dictionary, %d without", len(dict), len(plain))
+	}
+}
+
+func TestMetaBlockHeader(t *testing.T) {
+	for _, n := range []int{1, 1 << 16, 1<<16 + 1, 1 << 20, 1<<20 + 1, 1 << 24} {
+		var e encoder
+		e.writeMetaBlockHeader(n)
+		e.bw.writeBits(1, 1) // ISUNCOMPRESSED
+		e.bw.align()
+
+		var z Reader
+		z.br.reset(bytes.NewReader(e.bw.out))
+		z.readMetaBlockHeader()
+		if z.err != nil || z.state != stateUncompressed || z.remaining != n {
+			t.Errorf("header for %d bytes read as %d bytes, state %d, error %v", n, z.remaining, z.state, z.err)
+		}
+	}
+}
+
+func TestMetaBlockBoundaries(t *testing.T) {
+	inputs := testInputs(t)
+	text, random := inputs["text"], inputs["random"]
+
+	t.Run("size", func(t *testing.T) {
+		for _, level := range []int{BestSpeed, defaultLevel, BestCompression} {
+			size := 1 << levels[level].blockLog
+			data := text[:min(len(text), 2*size+1000)]
+			blocks := metaBlocks(t, compress(t, data, level), data)
+			for i, b := range blocks[:len(blocks)-1] {
+				if b.len != size {
+					t.Errorf("level %d: meta-block %d holds %d bytes, want %d", level, i, b.len, size)
+				}
+			}
+		}
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		var data []byte
+		for _, n := range []int{1000, 1, 5000} {
+			w.Write(text[:n])
+			w.Flush()
+			data = append(data, text[:n]...)
+		}
+		w.Close()
+		blocks := metaBlocks(t, buf.Bytes(), data)
+		if len(blocks) != 3 || blocks[0].len != 1000 || blocks[1].len != 1 || blocks[2].len != 5000 {
+			t.Errorf("got meta-blocks %v, want one for each flush", blocks)
+		}
+	})
+
+	// An incompressible meta-block is stored, and the distances used
+	// to plan it must not leak into the next one.
+	t.Run("uncompressed", func(t *testing.T) {
+		size := 1 << levels[BestSpeed].blockLog
+		data := slices.Concat(text[:size], random[:size], text[:size])
+		blocks := metaBlocks(t, compress(t, data, BestSpeed), data)
+		if len(blocks) != 3 || blocks[0].uncompressed || !blocks[1].uncompressed || blocks[2].uncompressed {
+			t.Errorf("got meta-blocks %v, want only the second uncompressed", blocks)
+		}
+	})
+
+	// Matches reach back into earlier meta-blocks.
+	t.Run("history", func(t *testing.T) {
+		size := 1 << levels[BestSpeed].blockLog
+		data := slices.Concat(random[:size], random[:size])
+		c := compress(t, data, BestSpeed)
+		blocks := metaBlocks(t, c, data)
+		if len(blocks) != 2 || blocks[1].uncompressed || len(c) > size+100 {
+			t.Errorf("compressed a repeated meta-block to %d bytes in %v", len(c), blocks)
+		}
+	})
+}
+
+// TestFlush checks that a flush ends on a byte boundary with an empty
+// metadata block, so that a reader gets all of the data written so far.
+func TestFlush(t *testing.T) {
+	var buf, out bytes.Buffer
+	w := NewWriter(io.MultiWriter(&buf, &out))
+	r := NewReader(&buf)
+	for i := range 5 {
+		msg := []byte(strings.Repeat(fmt.Sprintf("message %d ", i), 10+i))
+		w.Write(msg)
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, len(msg))
+		if _, err := io.ReadFull(r, got); err != nil {
+			t.Fatalf("reading message %d: %v", i, err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Fatalf("got %q, want %q", got, msg)
+		}
+
+		// The flush ended on a byte boundary, so with nothing
+		// pending the next one writes just the metadata block.
+		out.Reset()
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), []byte{0x06}) {
+			t.Fatalf("flush %d with no data wrote %x", i, out.Bytes())
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+		t.Errorf("Read at end = %d, %v; want 0, EOF", n, err)
+	}
+	if _, err := w.Write([]byte("x")); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+}
+
+// TestResetHistory checks that a Writer reset in the middle of a stream
+// does not refer back to the data of that stream in the next one.
+func TestResetHistory(t *testing.T) {
+	text := testInputs(t)["text"][:50000]
+	for _, level := range []int{BestSpeed, defaultLevel, BestCompression} {
+		w, _ := NewWriterLevel(io.Discard, level)
+		w.Write(text)
+		w.Flush()
+		var buf bytes.Buffer
+		w.Reset(&buf)
+		w.Write(text)
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		metaBlocks(t, buf.Bytes(), text)
+	}
+}
+
+func TestLengthCodes(t *testing.T) {
+	for n := uint32(0); n < 30000; n++ {
+		c := insertLengthCode(n)
+		if n < insertBase[c] || n-insertBase[c] >= 1<<insertExtra[c] {
+			t.Fatalf("insertLengthCode(%d) = %d", n, c)
+		}
+		if n < 2 {
+			continue
+		}
+		c = copyLengthCode(n)
+		if n < copyBase[c] || n-copyBase[c] >= 1<<copyExtra[c] {
+			t.Fatalf("copyLengthCode(%d) = %d", n, c)
+		}
+	}
+}
+
+func TestSingleSymbolCode(t *testing.T) {
+	// A code for a single symbol, rebuilt from a code for several,
+	// as when a meta-block uses only one distance code.
+	var h huffmanCode
+	h.build([]uint32{1, 2, 3, 4, 5}, maxCodeLen)
+	h.build([]uint32{0, 0, 0, 0, 7}, maxCodeLen)
+	var bw bitWriter
+	h.write(&bw, 4)
+	if bw.bits != 0 || bw.nbits != 0 {
+		t.Errorf("writing the single symbol wrote %d bits %#x, want none", bw.nbits, bw.bits)
+	}
+}
+
+// TestBrotliTool checks that the brotli tool can decompress our output
+// at every level, and that we can decompress its output for a range of
+// qualities and window sizes.
+func TestBrotliTool(t *testing.T) {
+	brotli, err := exec.LookPath("brotli")
+	if err != nil {
+		t.Skip("skipping because brotli not found")
+	}
+	testenv.MustHaveExec(t)
+
+	inputs := testInputs(t)
+	dir := t.TempDir()
+	for _, name := range []string{"text", "utf8", "samples", "random"} {
+		data := inputs[name]
+		for level := BestSpeed; level <= BestCompression; level++ {
+			cmd := exec.Command(brotli, "-d", "-c")
+			cmd.Stdin = bytes.NewReader(compress(t, data, level))
+			got, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%s/%d: brotli -d: %v", name, level, err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("%s/%d: brotli -d output mismatch", name, level)
+			}
+		}
+
+		in := filepath.Join(dir, "in")
+		if err := os.WriteFile(in, data, 0o666); err != nil {
+			t.Fatal(err)
+		}
+		for _, qw := range [][2]int{{0, 10}, {5, 16}, {9, 18}, {11, 24}} {
+			q, w := fmt.Sprint(qw[0]), fmt.Sprint(qw[1])
+			out, err := exec.Command(brotli, "-c", "-q", q, "-w", w, in).Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := decompress(t, out); !bytes.Equal(got, data) {
+				t.Fatalf("%s: decompressing brotli -q %s -w %s output mismatch", name, q, w)
+			}
+		}
+	}
+}
+
+// FuzzRoundTrip compresses data with a flush part way through, which
+// ends a meta-block there without ending the stream.
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte("hello, hello, hello, world"), uint8(3), uint16(7))
+	f.Add(bytes.Repeat([]byte("abcd"), 1000), uint8(1), uint16(2000))
+	f.Add([]byte("The government announced a conference"), uint8(6), uint16(0))
+	f.Add([]byte("ünder the TIME of the Wörld"), uint8(11), uint16(14))
+	f.Fuzz(func(t *testing.T, data []byte, level uint8, flush uint16) {
+		var buf bytes.Buffer
+		w, _ := NewWriterLevel(&buf, int(level)%(BestCompression+1))
+		k := min(int(flush), len(data))
+		w.Write(data[:k])
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data[k:])
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		metaBlocks(t, buf.Bytes(), data)
+	})
+}
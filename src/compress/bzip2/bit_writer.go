@@ -0,0 +1,46 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bzip2
+
+// bitWriter accumulates bits, most significant first, in a byte slice.
+// It is the counterpart of bitReader.
+type bitWriter struct {
+	out   []byte
+	n     uint64 // pending bits, in the low nbits bits
+	nbits uint
+}
+
+// WriteBits writes the low bits bits of v. bits must be at most 32.
+func (bw *bitWriter) WriteBits(bits uint, v uint32) {
+	bw.n = bw.n<<bits | uint64(v)&(1<<bits-1)
+	bw.nbits += bits
+	for bw.nbits >= 8 {
+		bw.nbits -= 8
+		bw.out = append(bw.out, byte(bw.n>>bw.nbits))
+	}
+}
+
+func (bw *bitWriter) WriteBits64(bits uint, v uint64) {
+	if bits > 32 {
+		bw.WriteBits(bits-32, uint32(v>>32))
+		bits = 32
+	}
+	bw.WriteBits(bits, uint32(v))
+}
+
+func (bw *bitWriter) WriteBit(b bool) {
+	if b {
+		bw.WriteBits(1, 1)
+	} else {
+		bw.WriteBits(1, 0)
+	}
+}
+
+// Align pads the output with zero bits to a byte boundary.
+func (bw *bitWriter) Align() {
+	if bw.nbits > 0 {
+		bw.WriteBits(8-bw.nbits, 0)
+	}
+}
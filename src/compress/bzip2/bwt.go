@@ -0,0 +1,272 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bzip2
+
+// bwtState holds the buffers used to compute the Burrows-Wheeler transform
+// of a block, so that they can be reused from one block to the next.
+type bwtState struct {
+	text []byte
+	sa   []int32
+}
+
+// transform computes the Burrows-Wheeler transform of block into out,
+// which must have the same length, and returns the index in the sorted
+// rotations of the rotation that starts at block[0], called origPtr in the
+// bzip2 source.
+//
+// The rotations are sorted with a suffix array. A block rotated to start
+// at its smallest rotation is a power y^k of a Lyndon word y, one that is
+// smaller than all of its proper suffixes, and the suffixes of a Lyndon
+// word are in the same order as the rotations that start with them.
+// The rotations of y^k are those of y, each repeated k times.
+func (s *bwtState) transform(out, block []byte) (origPtr int) {
+	n := len(block)
+	if n == 0 {
+		return 0
+	}
+	if cap(s.text) < n {
+		s.text = make([]byte, n)
+		s.sa = make([]int32, n)
+	}
+	r := minRotation(block)
+	text := s.text[:n]
+	copy(text, block[r:])
+	copy(text[n-r:], block[:r])
+
+	// Find the period p of text. Since text is its own smallest rotation,
+	// Duval's algorithm for the Lyndon factorization finds that it is
+	// repetitions of one factor.
+	j, k := 1, 0
+	for j < n && text[k] <= text[j] {
+		if text[k] < text[j] {
+			k = 0
+		} else {
+			k++
+		}
+		j++
+	}
+	p := j - k
+	if n%p != 0 {
+		panic("bzip2: internal error: block is not a power of a Lyndon word")
+	}
+	reps := n / p
+	y, sa := text[:p], s.sa[:p]
+	sais(y, sa, 256)
+
+	start := (n - r) % p // where the block starts in y
+	for i, q := range sa {
+		if int(q) == start {
+			origPtr = i * reps
+		}
+		c := y[p-1]
+		if q > 0 {
+			c = y[q-1]
+		}
+		for m := range reps {
+			out[i*reps+m] = c
+		}
+	}
+	return origPtr
+}
+
+// minRotation returns the index at which the smallest rotation of b
+// starts, finding it in linear time by ruling out candidates in pairs.
+func minRotation(b []byte) int {
+	n := len(b)
+	i, j, k := 0, 1, 0
+	for i < n && j < n && k < n {
+		x, y := i+k, j+k
+		if x >= n {
+			x -= n
+		}
+		if y >= n {
+			y -= n
+		}
+		switch {
+		case b[x] == b[y]:
+			k++
+			continue
+		case b[x] > b[y]:
+			i += k + 1
+		default:
+			j += k + 1
+		}
+		if i == j {
+			j++
+		}
+		k = 0
+	}
+	return min(i, j)
+}
+
+// sais computes the suffix array sa of text, whose values are all in
+// [0, k), using the SA-IS algorithm of Nong, Zhang and Chan, "Two Efficient
+// Algorithms for Linear Time Suffix Array Construction". The end of text
+// acts as a sentinel smaller than every value, and is not in sa.
+func sais[T byte | int32](text []T, sa []int32, k int) {
+	n := len(text)
+	switch n {
+	case 0:
+		return
+	case 1:
+		sa[0] = 0
+		return
+	}
+
+	// Classify the suffixes: stype[i] is whether suffix i is smaller than
+	// suffix i+1. The last suffix is larger than the empty one.
+	stype := make([]bool, n)
+	for i := n - 2; i >= 0; i-- {
+		stype[i] = text[i] < text[i+1] || text[i] == text[i+1] && stype[i+1]
+	}
+	isLMS := func(i int) bool {
+		return i > 0 && stype[i] && !stype[i-1]
+	}
+	bkt := make([]int32, k)
+
+	// Sort the LMS substrings by placing the LMS suffixes at the ends of
+	// their buckets and inducing the order of the others.
+	for i := range sa {
+		sa[i] = -1
+	}
+	bucketEnds(text, bkt)
+	for i := n - 1; i > 0; i-- {
+		if isLMS(i) {
+			c := text[i]
+			bkt[c]--
+			sa[bkt[c]] = int32(i)
+		}
+	}
+	induce(text, sa, bkt, stype)
+
+	// Move the sorted LMS substrings to the front of sa
+	// and name them by their rank.
+	n1 := 0
+	for _, p := range sa {
+		if isLMS(int(p)) {
+			sa[n1] = p
+			n1++
+		}
+	}
+	for i := n1; i < n; i++ {
+		sa[i] = -1
+	}
+	name := int32(0)
+	prev := -1
+	for _, p := range sa[:n1] {
+		pos := int(p)
+		diff := prev < 0
+		for d := 0; !diff; d++ {
+			if pos+d == n || prev+d == n ||
+				text[pos+d] != text[prev+d] || stype[pos+d] != stype[prev+d] {
+				diff = true
+			} else if d > 0 && (isLMS(pos+d) || isLMS(prev+d)) {
+				break
+			}
+		}
+		if diff {
+			name++
+			prev = pos
+		}
+		sa[n1+pos/2] = name - 1
+	}
+	j := n - 1
+	for i := n - 1; i >= n1; i-- {
+		if sa[i] >= 0 {
+			sa[j] = sa[i]
+			j--
+		}
+	}
+
+	// Sort the LMS suffixes, recursing if their names are not unique.
+	text1, sa1 := sa[n-n1:], sa[:n1]
+	if int(name) < n1 {
+		sais(text1, sa1, int(name))
+	} else {
+		for i, c := range text1 {
+			sa1[c] = int32(i)
+		}
+	}
+
+	// Induce the order of all suffixes from that of the LMS suffixes.
+	j = 0
+	for i := 1; i < n; i++ {
+		if isLMS(i) {
+			text1[j] = int32(i)
+			j++
+		}
+	}
+	for i, p := range sa1 {
+		sa1[i] = text1[p]
+	}
+	for i := n1; i < n; i++ {
+		sa[i] = -1
+	}
+	bucketEnds(text, bkt)
+	for i := n1 - 1; i >= 0; i-- {
+		p := sa[i]
+		sa[i] = -1
+		c := text[p]
+		bkt[c]--
+		sa[bkt[c]] = p
+	}
+	induce(text, sa, bkt, stype)
+}
+
+// induce sorts the L-type suffixes, then the S-type suffixes, given the
+// LMS suffixes in place at the ends of their buckets.
+func induce[T byte | int32](text []T, sa, bkt []int32, stype []bool) {
+	n := len(text)
+	bucketStarts(text, bkt)
+	// The suffix before the sentinel comes first in its bucket.
+	c := text[n-1]
+	sa[bkt[c]] = int32(n - 1)
+	bkt[c]++
+	for i := 0; i < n; i++ {
+		j := sa[i] - 1
+		if j >= 0 && !stype[j] {
+			c := text[j]
+			sa[bkt[c]] = j
+			bkt[c]++
+		}
+	}
+	bucketEnds(text, bkt)
+	for i := n - 1; i >= 0; i-- {
+		j := sa[i] - 1
+		if j >= 0 && stype[j] {
+			c := text[j]
+			bkt[c]--
+			sa[bkt[c]] = j
+		}
+	}
+}
+
+// bucketStarts sets bkt[c] to the index in the suffix array
+// of the first suffix starting with c.
+func bucketStarts[T byte | int32](text []T, bkt []int32) {
+	clear(bkt)
+	for _, c := range text {
+		bkt[c]++
+	}
+	sum := int32(0)
+	for i, n := range bkt {
+		bkt[i] = sum
+		sum += n
+	}
+}
+
+// bucketEnds sets bkt[c] to one past the index in the suffix array
+// of the last suffix starting with c.
+func bucketEnds[T byte | int32](text []T, bkt []int32) {
+	clear(bkt)
+	for _, c := range text {
+		bkt[c]++
+	}
+	sum := int32(0)
+	for i, n := range bkt {
+		sum += n
+		bkt[i] = sum
+	}
+}
@@ -6,6 +6,7 @@ package bzip2
 
 import (
 	"cmp"
+	"compress/internal/huffman"
 	"slices"
 )
 
@@ -232,3 +233,48 @@ func buildHuffmanNode(t *huffmanTree, codes []huffmanCode, level uint32) (nodeIn
 
 	return
 }
+
+// huffmanCodeLengths sets lengths to the code lengths of a Huffman code for
+// symbols with the given frequencies, using b to build the code. Every
+// symbol is given a code, since the block format has a length for each,
+// and no code is longer than maxLen.
+//
+// As in the bzip2 source, a code that would be too long is fitted by
+// flattening the frequencies and building it again, rather than by
+// adjusting the lengths as b does when given a limit.
+func huffmanCodeLengths(b *huffman.Builder, lengths []uint8, freqs []uint32, maxLen uint8) {
+	if len(freqs) < 2 {
+		panic("huffmanCodeLengths: too few symbols")
+	}
+	weights := make([]uint32, len(freqs))
+	for i, f := range freqs {
+		weights[i] = max(f, 1)
+	}
+	for {
+		// A Huffman tree over weights totaling less than 2^41
+		// is never deeper than 60, so this does not limit it.
+		b.Lengths(lengths, weights, 64)
+		if slices.Max(lengths[:len(freqs)]) <= maxLen {
+			return
+		}
+		for i, w := range weights {
+			weights[i] = 1 + w/2
+		}
+	}
+}
+
+// huffmanCodes sets codes to the canonical codes for the given code
+// lengths, which are assigned in order of length and then of symbol,
+// as newHuffmanTree expects.
+func huffmanCodes(codes []uint32, lengths []uint8) {
+	code := uint32(0)
+	for l := uint8(1); l <= slices.Max(lengths); l++ {
+		for i, length := range lengths {
+			if length == l {
+				codes[i] = code
+				code++
+			}
+		}
+		code <<= 1
+	}
+}
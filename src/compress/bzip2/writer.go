@@ -0,0 +1,501 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bzip2
+
+import (
+	"bytes"
+	"compress/internal/huffman"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Compression levels, which give the block size in units of 100 kB.
+// A larger block compresses better but needs more memory to compress
+// and decompress.
+const (
+	BestSpeed          = 1
+	BestCompression    = 9
+	DefaultCompression = -1
+)
+
+const (
+	// maxRun is the longest run of a byte written as a single run by the
+	// initial run-length encoding: four copies followed by a count.
+	maxRun = 4 + 251
+
+	// blockMargin is the space left free in a block, as in the bzip2
+	// source, which makes room for a whole run after the block is
+	// found to be full.
+	blockMargin = 19
+
+	// groupSize is the number of symbols coded with each Huffman table
+	// selected by the selectors.
+	groupSize = 50
+
+	// maxCodeLen is the longest Huffman code used, as in the bzip2 source.
+	maxCodeLen = 17
+
+	// numIters is the number of rounds of refinement of the Huffman tables.
+	numIters = 4
+)
+
+var errWriterClosed = errors.New("bzip2: write to closed Writer")
+
+// A Writer is an io.WriteCloser.
+// Writes to a Writer are compressed and written to w.
+type Writer struct {
+	w     io.Writer
+	level int
+	bw    bitWriter
+
+	block     []byte // data of the current block, after run-length encoding
+	blockCRC  uint32 // CRC of the input in block
+	streamCRC uint32 // combined CRC of the blocks of the current stream
+	runByte   byte   // byte of the pending run
+	runLen    int    // length of the pending run, which is not yet in block
+
+	inStream    bool // whether a stream header has been written
+	endedStream bool // whether a stream has been ended
+	closed      bool
+	err         error
+
+	// Buffers reused from block to block.
+	bwt       bwtState
+	hb        huffman.Builder
+	bwtOut    []byte
+	mtfv      []uint16
+	selectors []uint8
+}
+
+// NewWriter returns a new Writer.
+// Writes to the returned writer are compressed and written to w.
+//
+// It is the caller's responsibility to call Close on the Writer when done.
+// Writes may be buffered and not flushed until Close.
+func NewWriter(w io.Writer) *Writer {
+	z, _ := NewWriterLevel(w, DefaultCompression)
+	return z
+}
+
+// NewWriterLevel is like NewWriter but specifies the compression level
+// instead of assuming DefaultCompression, which is BestCompression as for
+// the bzip2 tool.
+//
+// The compression level can be DefaultCompression, or any integer value
+// between BestSpeed and BestCompression inclusive. The error returned
+// will be nil if the level is valid.
+func NewWriterLevel(w io.Writer, level int) (*Writer, error) {
+	if level == DefaultCompression {
+		level = BestCompression
+	}
+	if level < BestSpeed || level > BestCompression {
+		return nil, fmt.Errorf("bzip2: invalid compression level: %d", level)
+	}
+	z := &Writer{level: level}
+	z.Reset(w)
+	return z, nil
+}
+
+// Reset discards the Writer z's state and makes it equivalent to the
+// result of its original state from NewWriter or NewWriterLevel, but
+// writing to w instead. This permits reusing a Writer rather than
+// allocating a new one.
+func (z *Writer) Reset(w io.Writer) {
+	z.w = w
+	z.bw = bitWriter{out: z.bw.out[:0]}
+	if z.block == nil {
+		z.block = make([]byte, 0, z.level*100*1000)
+	}
+	z.block = z.block[:0]
+	z.blockCRC = 0
+	z.streamCRC = 0
+	z.runLen = 0
+	z.inStream = false
+	z.endedStream = false
+	z.closed = false
+	z.err = nil
+}
+
+// Write writes a compressed form of p to the underlying io.Writer.
+// The compressed bytes are not necessarily flushed until
+// the Writer is closed or explicitly flushed.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.closed {
+		return 0, errWriterClosed
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if z.runLen > 0 && (p[0] != z.runByte || z.runLen == maxRun) {
+			if err := z.flushRun(); err != nil {
+				return n - len(p), err
+			}
+		}
+		if z.runLen == 0 {
+			z.runByte = p[0]
+		}
+		i := 0
+		for i < len(p) && p[i] == z.runByte && z.runLen < maxRun {
+			i++
+			z.runLen++
+		}
+		p = p[i:]
+	}
+	return n, nil
+}
+
+// flushRun adds the pending run to the block, applying the initial
+// run-length encoding, and compresses the block if it is full.
+func (z *Writer) flushRun() error {
+	b, n := z.runByte, z.runLen
+	z.runLen = 0
+	crc := ^z.blockCRC
+	for range n {
+		crc = crctab[byte(crc>>24)^b] ^ (crc << 8)
+	}
+	z.blockCRC = ^crc
+	for range min(n, 4) {
+		z.block = append(z.block, b)
+	}
+	if n >= 4 {
+		z.block = append(z.block, byte(n-4))
+	}
+	if len(z.block) >= cap(z.block)-blockMargin {
+		return z.writeBlock()
+	}
+	return nil
+}
+
+// Flush compresses any pending data and ends the current bzip2 stream,
+// so that all of the data written so far can be decompressed. Later
+// writes begin a new stream. Like the output of parallel implementations
+// of bzip2, which compress blocks as separate streams, a file of several
+// streams is decompressed as the concatenation of their data by NewReader
+// and by the bzip2 tool.
+//
+// Since ending a stream ends its last block, frequent calls to Flush
+// make for worse compression. If nothing has been written since the
+// last call, Flush does nothing.
+func (z *Writer) Flush() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed || !z.pending() {
+		return nil
+	}
+	return z.endStream()
+}
+
+// Close closes the Writer by flushing any unwritten data to the underlying
+// io.Writer and ending the stream. It does not close the underlying
+// io.Writer.
+func (z *Writer) Close() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	z.closed = true
+	if !z.pending() && z.endedStream {
+		return nil
+	}
+	// Even empty input is written as a stream.
+	return z.endStream()
+}
+
+// pending reports whether there is data in the current stream
+// that is not yet ended.
+func (z *Writer) pending() bool {
+	return z.inStream || z.runLen > 0 || len(z.block) > 0
+}
+
+// startStream writes the stream header, if it has not been written.
+func (z *Writer) startStream() {
+	if z.inStream {
+		return
+	}
+	z.inStream = true
+	z.streamCRC = 0
+	z.bw.WriteBits(16, bzip2FileMagic)
+	z.bw.WriteBits(8, 'h')
+	z.bw.WriteBits(8, '0'+uint32(z.level))
+}
+
+// endStream writes any pending data and the end of the stream.
+func (z *Writer) endStream() error {
+	if z.runLen > 0 {
+		if err := z.flushRun(); err != nil {
+			return err
+		}
+	}
+	if err := z.writeBlock(); err != nil {
+		return err
+	}
+	z.startStream()
+	z.bw.WriteBits64(48, bzip2FinalMagic)
+	z.bw.WriteBits(32, z.streamCRC)
+	z.bw.Align()
+	z.inStream = false
+	z.endedStream = true
+	return z.write()
+}
+
+// write writes the whole bytes of output to the underlying writer,
+// recording any error.
+func (z *Writer) write() error {
+	if len(z.bw.out) == 0 {
+		return nil
+	}
+	_, err := z.w.Write(z.bw.out)
+	z.bw.out = z.bw.out[:0]
+	if err != nil {
+		z.err = err
+	}
+	return err
+}
+
+// writeBlock compresses the block, if it is not empty, and writes it out.
+func (z *Writer) writeBlock() error {
+	if len(z.block) == 0 {
+		return nil
+	}
+	z.startStream()
+	z.streamCRC = (z.streamCRC<<1 | z.streamCRC>>31) ^ z.blockCRC
+
+	bw := &z.bw
+	bw.WriteBits64(48, bzip2BlockMagic)
+	bw.WriteBits(32, z.blockCRC)
+	bw.WriteBits(1, 0) // not randomized
+
+	n := len(z.block)
+	if cap(z.bwtOut) < n {
+		z.bwtOut = make([]byte, cap(z.block))
+	}
+	out := z.bwtOut[:n]
+	origPtr := z.bwt.transform(out, z.block)
+	bw.WriteBits(24, uint32(origPtr))
+
+	// The symbols in use are sent as a two-level bitmap, and the
+	// move-to-front transform works on them alone.
+	var inUse [256]bool
+	for _, b := range z.block {
+		inUse[b] = true
+	}
+	var unseq [256]byte
+	numInUse := 0
+	var ranges uint32
+	for i, used := range inUse {
+		if used {
+			unseq[i] = byte(numInUse)
+			numInUse++
+			ranges |= 1 << (15 - i/16)
+		}
+	}
+	bw.WriteBits(16, ranges)
+	for r := range 16 {
+		if ranges&(1<<(15-r)) == 0 {
+			continue
+		}
+		var bits uint32
+		for i, used := range inUse[16*r : 16*r+16] {
+			if used {
+				bits |= 1 << (15 - i)
+			}
+		}
+		bw.WriteBits(16, bits)
+	}
+
+	z.mtfv = appendMTF(z.mtfv[:0], out, &unseq, numInUse)
+	z.writeSymbols(z.mtfv, numInUse+2)
+
+	z.block = z.block[:0]
+	z.blockCRC = 0
+	return z.write()
+}
+
+// appendMTF appends to mtfv the symbols for the block data after the
+// Burrows-Wheeler transform: the move-to-front transform of the data,
+// with runs of zeros written in bijective base 2 using the RUNA and RUNB
+// symbols, 0 and 1, and other values v written as v+1, followed by the
+// end of block symbol.
+func appendMTF(mtfv []uint16, data []byte, unseq *[256]byte, numInUse int) []uint16 {
+	var list [256]byte
+	for i := range numInUse {
+		list[i] = byte(i)
+	}
+	zeros := 0
+	for _, b := range data {
+		c := unseq[b]
+		if list[0] == c {
+			zeros++
+			continue
+		}
+		if zeros > 0 {
+			mtfv = appendZeroRun(mtfv, zeros)
+			zeros = 0
+		}
+		j := bytes.IndexByte(list[1:numInUse], c) + 1
+		copy(list[1:j+1], list[:j])
+		list[0] = c
+		mtfv = append(mtfv, uint16(j+1))
+	}
+	if zeros > 0 {
+		mtfv = appendZeroRun(mtfv, zeros)
+	}
+	return append(mtfv, uint16(numInUse+1))
+}
+
+// appendZeroRun appends the RUNA and RUNB symbols for n zeros.
+func appendZeroRun(mtfv []uint16, n int) []uint16 {
+	n--
+	for {
+		mtfv = append(mtfv, uint16(n&1))
+		if n < 2 {
+			return mtfv
+		}
+		n = (n - 2) / 2
+	}
+}
+
+// writeSymbols chooses Huffman tables for the symbols mtfv, which are
+// in [0, alphaSize), and writes the tables, the selectors that say which
+// table codes each group of symbols, and the coded symbols.
+func (z *Writer) writeSymbols(mtfv []uint16, alphaSize int) {
+	var numTables int
+	switch n := len(mtfv); {
+	case n < 200:
+		numTables = 2
+	case n < 600:
+		numTables = 3
+	case n < 1200:
+		numTables = 4
+	case n < 2400:
+		numTables = 5
+	default:
+		numTables = 6
+	}
+
+	freqs := make([]uint32, alphaSize)
+	for _, v := range mtfv {
+		freqs[v]++
+	}
+
+	// Start with tables that each favor a range of symbols with about
+	// the same share of the total frequency, as the bzip2 source does.
+	var lengths [6][]uint8
+	for t := range numTables {
+		lengths[t] = make([]uint8, alphaSize)
+	}
+	const lesserCost, greaterCost = 0, 15
+	remaining := uint32(len(mtfv))
+	start := 0
+	for part := numTables; part > 0; part-- {
+		target := remaining / uint32(part)
+		end := start - 1
+		sum := uint32(0)
+		for sum < target && end < alphaSize-1 {
+			end++
+			sum += freqs[end]
+		}
+		if end > start && part != numTables && part != 1 && (numTables-part)%2 == 1 {
+			sum -= freqs[end]
+			end--
+		}
+		for v := range lengths[part-1] {
+			if v >= start && v <= end {
+				lengths[part-1][v] = lesserCost
+			} else {
+				lengths[part-1][v] = greaterCost
+			}
+		}
+		start = end + 1
+		remaining -= sum
+	}
+
+	// Refine the tables by choosing the cheapest table for each group
+	// and then fitting each table to the groups that chose it.
+	numSelectors := (len(mtfv) + groupSize - 1) / groupSize
+	if cap(z.selectors) < numSelectors {
+		z.selectors = make([]uint8, numSelectors)
+	}
+	selectors := z.selectors[:numSelectors]
+	var tableFreqs [6][]uint32
+	for t := range numTables {
+		tableFreqs[t] = make([]uint32, alphaSize)
+	}
+	for range numIters {
+		for t := range numTables {
+			clear(tableFreqs[t])
+		}
+		for g := range selectors {
+			group := mtfv[g*groupSize : min((g+1)*groupSize, len(mtfv))]
+			var cost [6]int
+			for _, v := range group {
+				for t := range numTables {
+					cost[t] += int(lengths[t][v])
+				}
+			}
+			best := 0
+			for t := 1; t < numTables; t++ {
+				if cost[t] < cost[best] {
+					best = t
+				}
+			}
+			selectors[g] = uint8(best)
+			for _, v := range group {
+				tableFreqs[best][v]++
+			}
+		}
+		for t := range numTables {
+			huffmanCodeLengths(&z.hb, lengths[t], tableFreqs[t], maxCodeLen)
+		}
+	}
+
+	bw := &z.bw
+	bw.WriteBits(3, uint32(numTables))
+	bw.WriteBits(15, uint32(numSelectors))
+
+	// The selectors are move-to-front transformed and written in unary.
+	list := [6]uint8{0, 1, 2, 3, 4, 5}
+	for _, s := range selectors {
+		j := 0
+		for list[j] != s {
+			j++
+		}
+		copy(list[1:j+1], list[:j])
+		list[0] = s
+		bw.WriteBits(uint(j+1), 1<<(j+1)-2)
+	}
+
+	// The code lengths are delta coded from a 5-bit base value.
+	var codes [6][]uint32
+	for t := range numTables {
+		l := lengths[t][0]
+		bw.WriteBits(5, uint32(l))
+		for _, want := range lengths[t] {
+			for ; l < want; l++ {
+				bw.WriteBits(2, 2)
+			}
+			for ; l > want; l-- {
+				bw.WriteBits(2, 3)
+			}
+			bw.WriteBits(1, 0)
+		}
+		codes[t] = make([]uint32, alphaSize)
+		huffmanCodes(codes[t], lengths[t])
+	}
+
+	for g, s := range selectors {
+		group := mtfv[g*groupSize : min((g+1)*groupSize, len(mtfv))]
+		l, c := lengths[s], codes[s]
+		for _, v := range group {
+			bw.WriteBits(uint(l[v]), c[v])
+		}
+	}
+}
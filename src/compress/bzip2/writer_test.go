@@ -0,0 +1,623 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bzip2
+
+import (
+	"bytes"
+	"compress/internal/huffman"
+	"fmt"
+	"internal/testenv"
+	"io"
+	"math/rand"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writerInputs returns inputs that exercise the different stages of
+// the compressor.
+func writerInputs(t testing.TB) map[string][]byte {
+	newtonText, err := io.ReadAll(NewReader(bytes.NewReader(newton)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 250<<10)
+	r.Read(random)
+
+	// Runs of every length around the limits of the initial run-length
+	// encoding, some of which cross block boundaries.
+	var runs []byte
+	for len(runs) < 700<<10 {
+		n := []int{1, 3, 4, 5, 254, 255, 256, 259, 510, 511, 2000}[r.Intn(11)]
+		runs = append(runs, bytes.Repeat([]byte{byte(r.Intn(4))}, n)...)
+	}
+
+	sawtooth := make([]byte, 300<<10)
+	for i := range sawtooth {
+		sawtooth[i] = byte(i)
+	}
+
+	return map[string][]byte{
+		"empty":    nil,
+		"byte":     {'x'},
+		"hello":    []byte("hello world\n"),
+		"zeros":    make([]byte, 1<<20),
+		"periodic": bytes.Repeat([]byte("abcab"), 50000),
+		"random":   random,
+		"runs":     runs,
+		"sawtooth": sawtooth,
+		"newton":   newtonText,
+	}
+}
+
+func compress(t testing.TB, data []byte, level int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriterLevel(&buf, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write in uneven pieces.
+	for len(data) > 0 {
+		n := min(len(data), 70000)
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decompress(t testing.TB, data []byte) []byte {
+	t.Helper()
+	got, err := io.ReadAll(NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+// A stream describes a bzip2 stream.
+type stream struct {
+	level  int
+	blocks []int // lengths of the blocks after the initial run-length encoding
+}
+
+// streams decodes the file c, checking that it holds want, and returns
+// its streams. It runs the decoder a block at a time to see the blocks.
+func streams(t *testing.T, c, want []byte) []stream {
+	t.Helper()
+	if got := decompress(t, c); !bytes.Equal(got, want) {
+		t.Fatalf("round trip mismatch:\ngot  %s\nwant %s", trim(got), trim(want))
+	}
+	z := NewReader(bytes.NewReader(c)).(*reader)
+	br := &z.br
+	var ss []stream
+	for {
+		if err := z.setup(len(ss) == 0); err != nil {
+			t.Fatal(err)
+		}
+		s := stream{level: z.blockSize / (100 * 1000)}
+		for br.ReadBits64(48) == bzip2BlockMagic {
+			if err := z.readBlock(); err != nil {
+				t.Fatal(err)
+			}
+			s.blocks = append(s.blocks, len(z.preRLE))
+		}
+		br.ReadBits64(32) // stream CRC
+		ss = append(ss, s)
+
+		// Another stream follows on the next byte boundary.
+		if br.bits%8 != 0 {
+			br.ReadBits(br.bits % 8)
+		}
+		if br.ReadBits(8) != 'B' || br.err != nil {
+			return ss
+		}
+		br.ReadBits(8) // 'Z'
+	}
+}
+
+// TestWriterBlocks checks that each block but the last of a stream is
+// filled to within a run of the block size less blockMargin.
+func TestWriterBlocks(t *testing.T) {
+	for name, data := range writerInputs(t) {
+		for _, level := range []int{BestSpeed, 2, 5, BestCompression} {
+			if testing.Short() && level != BestSpeed && level != BestCompression {
+				continue
+			}
+			t.Run(fmt.Sprintf("%s/%d", name, level), func(t *testing.T) {
+				ss := streams(t, compress(t, data, level), data)
+				if len(ss) != 1 || ss[0].level != level {
+					t.Fatalf("got streams %v, want one at level %d", ss, level)
+				}
+				full := level*100*1000 - blockMargin
+				blocks := ss[0].blocks
+				for i, n := range blocks {
+					if n > full+4 || i < len(blocks)-1 && n < full {
+						t.Errorf("block %d of %d holds %d bytes, want %d to %d", i, len(blocks), n, full, full+4)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestWriterBlockSize checks the exact block size for data with no runs.
+func TestWriterBlockSize(t *testing.T) {
+	for _, level := range []int{-2, 0, 10} {
+		if _, err := NewWriterLevel(io.Discard, level); err == nil {
+			t.Errorf("NewWriterLevel(%d) succeeded", level)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	data := make([]byte, 250000)
+	for i := range data {
+		data[i] = byte(r.Intn(255))
+		if i > 0 && data[i] == data[i-1] {
+			data[i] = 255
+		}
+	}
+	ss := streams(t, compress(t, data, BestSpeed), data)
+	if want := []int{99981, 99981, 50038}; len(ss) != 1 || !slices.Equal(ss[0].blocks, want) {
+		t.Errorf("got streams %v, want blocks %v", ss, want)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	if ss := streams(t, buf.Bytes(), data); ss[0].level != BestCompression || len(ss[0].blocks) != 1 {
+		t.Errorf("NewWriter wrote streams %v, want one block at level %d", ss, BestCompression)
+	}
+}
+
+// TestRunLengthEncoding checks the initial run-length encoding, which
+// writes runs of four to 255 bytes as four copies and a count, and the
+// block CRC, which is of the data before it.
+func TestRunLengthEncoding(t *testing.T) {
+	a := func(n int) string { return strings.Repeat("a", n) }
+	tests := []struct {
+		in, want string
+	}{
+		{"a", "a"},
+		{a(3), a(3)},
+		{a(4), a(4) + "\x00"},
+		{a(5), a(4) + "\x01"},
+		{a(4) + "b", a(4) + "\x00b"},
+		{a(3) + "b" + a(3), a(3) + "b" + a(3)},
+		{a(255), a(4) + "\xfb"},
+		{a(256), a(4) + "\xfb" + "a"},
+		{a(259), a(4) + "\xfb" + a(4) + "\x00"},
+		{a(510), a(4) + "\xfb" + a(4) + "\xfb"},
+		{a(511), a(4) + "\xfb" + a(4) + "\xfb" + "a"},
+		{a(300) + "bbbbb", a(4) + "\xfb" + a(4) + "\x29" + "bbbb\x01"},
+	}
+	for _, tt := range tests {
+		// A run split across writes is encoded as a single run.
+		for _, piece := range []int{1, 3, len(tt.in)} {
+			w, _ := NewWriterLevel(io.Discard, BestSpeed)
+			for in := tt.in; len(in) > 0; {
+				n := min(piece, len(in))
+				w.Write([]byte(in[:n]))
+				in = in[n:]
+			}
+			w.flushRun()
+			if string(w.block) != tt.want {
+				t.Errorf("%d bytes in pieces of %d: block is %q, want %q", len(tt.in), piece, w.block, tt.want)
+			}
+			if want := updateCRC(0, []byte(tt.in)); w.blockCRC != want {
+				t.Errorf("%d bytes in pieces of %d: block CRC is %#x, want %#x", len(tt.in), piece, w.blockCRC, want)
+			}
+		}
+	}
+}
+
+// TestAppendMTF checks the move-to-front transform of a block and the
+// bijective base 2 coding of runs of zeros with RUNA and RUNB.
+func TestAppendMTF(t *testing.T) {
+	var runs []uint16
+	for n := 1; n < 1000; n++ {
+		runs = appendZeroRun(runs[:0], n)
+		got := 0
+		for i, v := range runs {
+			got += int(v+1) << i
+		}
+		if got != n {
+			t.Fatalf("appendZeroRun(%d) = %v, which is %d zeros", n, runs, got)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for range 100 {
+		// Data that uses a few byte values, with runs of each.
+		data := make([]byte, r.Intn(2000))
+		for i := range data {
+			if i > 0 && r.Intn(3) == 0 {
+				data[i] = data[i-1]
+			} else {
+				data[i] = byte(r.Intn(10) * 20)
+			}
+		}
+		var unseq [256]byte
+		var symbols []byte
+		for b := range 256 {
+			if bytes.IndexByte(data, byte(b)) >= 0 {
+				unseq[b] = byte(len(symbols))
+				symbols = append(symbols, byte(b))
+			}
+		}
+		mtfv := appendMTF(nil, data, &unseq, len(symbols))
+		if mtfv[len(mtfv)-1] != uint16(len(symbols)+1) {
+			t.Fatalf("block ends with symbol %d, want end of block %d", mtfv[len(mtfv)-1], len(symbols)+1)
+		}
+
+		// Decode the symbols as the reader does.
+		mtf := newMTFDecoder(slices.Clone(symbols))
+		var got []byte
+		zeros, shift := 0, 0
+		for _, v := range mtfv[:len(mtfv)-1] {
+			if v <= 1 {
+				zeros += int(v+1) << shift
+				shift++
+				continue
+			}
+			for ; zeros > 0; zeros-- {
+				got = append(got, mtf.First())
+			}
+			shift = 0
+			got = append(got, mtf.Decode(int(v-1)))
+		}
+		for ; zeros > 0; zeros-- {
+			got = append(got, mtf.First())
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("decoding the move-to-front symbols of %q gave %q", data, got)
+		}
+	}
+}
+
+// TestWriteSymbols checks the number of Huffman tables and selectors
+// chosen for a block, and that its symbols decode with them.
+func TestWriteSymbols(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range []struct{ n, tables int }{
+		{1, 2}, {199, 2}, {200, 3}, {599, 3}, {600, 4},
+		{1199, 4}, {1200, 5}, {2399, 5}, {2400, 6}, {20000, 6},
+	} {
+		const alphaSize = 40
+		mtfv := make([]uint16, tt.n)
+		for i := range mtfv {
+			// Groups of mostly small or mostly large values,
+			// which are best coded with different tables.
+			if i/groupSize%2 == 0 {
+				mtfv[i] = uint16(min(r.ExpFloat64()*2, alphaSize-1))
+			} else {
+				mtfv[i] = uint16(alphaSize - 1 - min(r.ExpFloat64()*4, alphaSize-1))
+			}
+		}
+		var z Writer
+		z.writeSymbols(mtfv, alphaSize)
+		z.bw.Align()
+
+		br := newBitReader(bytes.NewReader(z.bw.out))
+		if n := int(br.ReadBits(3)); n != tt.tables {
+			t.Errorf("%d symbols: %d tables, want %d", tt.n, n, tt.tables)
+		}
+		numSelectors := int(br.ReadBits(15))
+		if want := (tt.n + groupSize - 1) / groupSize; numSelectors != want {
+			t.Fatalf("%d symbols: %d selectors, want %d", tt.n, numSelectors, want)
+		}
+		selectors := make([]uint8, numSelectors)
+		list := newMTFDecoderWithRange(tt.tables)
+		for i := range selectors {
+			j := 0
+			for br.ReadBit() {
+				j++
+			}
+			selectors[i] = list.Decode(j)
+		}
+		trees := make([]huffmanTree, tt.tables)
+		for i := range trees {
+			lengths := make([]uint8, alphaSize)
+			l := int(br.ReadBits(5))
+			for j := range lengths {
+				for br.ReadBit() {
+					if br.ReadBit() {
+						l--
+					} else {
+						l++
+					}
+				}
+				if l < 1 || l > maxCodeLen {
+					t.Fatalf("%d symbols: table %d has a code of length %d", tt.n, i, l)
+				}
+				lengths[j] = uint8(l)
+			}
+			var err error
+			if trees[i], err = newHuffmanTree(lengths); err != nil {
+				t.Fatalf("%d symbols: table %d: %v", tt.n, i, err)
+			}
+		}
+		for i, want := range mtfv {
+			if v := trees[selectors[i/groupSize]].Decode(&br); v != want {
+				t.Fatalf("%d symbols: symbol %d decoded as %d, want %d", tt.n, i, v, want)
+			}
+		}
+		if tt.n > 1000 && slices.Max(selectors) == slices.Min(selectors) {
+			t.Errorf("%d symbols: all groups use table %d", tt.n, selectors[0])
+		}
+	}
+}
+
+// TestHuffmanCodeLengths checks that code lengths are limited to
+// maxCodeLen and make a complete code, even for frequencies that would
+// give longer codes.
+func TestHuffmanCodeLengths(t *testing.T) {
+	var hb huffman.Builder
+	fib := make([]uint32, 40)
+	fib[0], fib[1] = 1, 1
+	for i := 2; i < len(fib); i++ {
+		fib[i] = fib[i-1] + fib[i-2]
+	}
+	for _, freqs := range [][]uint32{
+		{0, 0},
+		{1, 0, 0, 1000},
+		fib,
+		slices.Concat(fib, make([]uint32, 218)),
+	} {
+		lengths := make([]uint8, len(freqs))
+		huffmanCodeLengths(&hb, lengths, freqs, maxCodeLen)
+		kraft := 0
+		for _, l := range lengths {
+			if l < 1 || l > maxCodeLen {
+				t.Fatalf("%d symbols: code of length %d", len(freqs), l)
+			}
+			kraft += 1 << (maxCodeLen - l)
+		}
+		if kraft != 1<<maxCodeLen {
+			t.Errorf("%d symbols: lengths %v do not make a complete code", len(freqs), lengths)
+		}
+		if _, err := newHuffmanTree(lengths); err != nil {
+			t.Errorf("%d symbols: %v", len(freqs), err)
+		}
+	}
+}
+
+func TestWriterCompresses(t *testing.T) {
+	// The reference implementation compresses the Newton text
+	// to 132469 bytes.
+	newtonText := writerInputs(t)["newton"]
+	c := compress(t, newtonText, BestCompression)
+	if len(c) > 133000 {
+		t.Errorf("compressed Newton text to %d bytes, want at most 133000", len(c))
+	}
+	if fast := compress(t, newtonText, BestSpeed); len(fast) <= len(c) {
+		t.Errorf("BestSpeed output is %d bytes, BestCompression is %d", len(fast), len(c))
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	// The bzip2 tool compresses empty input to a stream with no blocks.
+	want := mustDecodeHex("425a683917724538509000000000")
+	if got := compress(t, nil, BestCompression); !bytes.Equal(got, want) {
+		t.Errorf("compressing empty input: got %x, want %x", got, want)
+	}
+}
+
+// TestWriterFlush checks that Flush ends a stream, including its pending
+// run, and that it does nothing when there is nothing to flush.
+func TestWriterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	var want []byte
+	write := func(s string) {
+		w.Write([]byte(s))
+		want = append(want, s...)
+	}
+	flush := func() {
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got := decompress(t, buf.Bytes()); !bytes.Equal(got, want) {
+			t.Fatalf("after Flush: got %q, want %q", got, want)
+		}
+		// Nothing more is written when there is nothing to flush.
+		n := buf.Len()
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != n {
+			t.Errorf("Flush with no pending data wrote %d bytes", buf.Len()-n)
+		}
+	}
+	write("hello, world")
+	flush()
+	write("aaaaaa")
+	flush()
+	write("aaaa")
+	n := buf.Len()
+	w.Close()
+	w.Close()
+	if buf.Len() == n {
+		t.Error("Close did not end the last stream")
+	}
+	ss := streams(t, buf.Bytes(), want)
+	if len(ss) != 3 || !slices.Equal(ss[0].blocks, []int{12}) || !slices.Equal(ss[1].blocks, []int{5}) || !slices.Equal(ss[2].blocks, []int{5}) {
+		t.Errorf("got streams %v, want one for each flush", ss)
+	}
+
+	n = buf.Len()
+	w.Reset(&buf)
+	write("x")
+	w.Flush()
+	w.Close()
+	if buf.Len() == n {
+		t.Error("Flush after Reset wrote nothing")
+	}
+	if _, err := w.Write([]byte("x")); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+}
+
+// TestWriterReset checks that Reset drops the pending run and block.
+func TestWriterReset(t *testing.T) {
+	text := writerInputs(t)["newton"]
+	var buf bytes.Buffer
+	w, _ := NewWriterLevel(io.Discard, 2)
+	w.Write(text[:5000])
+	w.Write([]byte("aaaaaa"))
+	w.Reset(&buf)
+	w.Write([]byte("hello"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if ss := streams(t, buf.Bytes(), []byte("hello")); len(ss) != 1 || ss[0].level != 2 || !slices.Equal(ss[0].blocks, []int{5}) {
+		t.Errorf("got streams %v, want one block of 5 bytes at level 2", ss)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var s bwtState
+	for i := range 2000 {
+		n := 1 + r.Intn(40)
+		block := make([]byte, n)
+		for j := range block {
+			block[j] = byte('a' + r.Intn(1+i%4))
+		}
+		if i%3 == 0 {
+			// Make a periodic block.
+			p := 1 + r.Intn(n)
+			for j := p; j < n; j++ {
+				block[j] = block[j-p]
+			}
+			block = block[:n/p*p]
+			n = len(block)
+		}
+
+		rotations := make([]string, n)
+		for j := range rotations {
+			rotations[j] = string(block[j:]) + string(block[:j])
+		}
+		slices.Sort(rotations)
+		want := make([]byte, n)
+		for j, rot := range rotations {
+			want[j] = rot[n-1]
+		}
+
+		got := make([]byte, n)
+		origPtr := s.transform(got, block)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("transform(%q) = %q, want %q", block, got, want)
+		}
+		if rotations[origPtr] != string(block) {
+			t.Fatalf("transform(%q) origPtr = %d, which is rotation %q", block, origPtr, rotations[origPtr])
+		}
+	}
+}
+
+// TestBzip2Tool checks that the bzip2 tool can decompress our output,
+// including a file of several streams, and that the blocks of its output
+// are the same as ours, which they are as both fill blocks to the same
+// margin.
+func TestBzip2Tool(t *testing.T) {
+	bzip2, err := exec.LookPath("bzip2")
+	if err != nil {
+		t.Skip("skipping because bzip2 not found")
+	}
+	testenv.MustHaveExec(t)
+
+	run := func(in []byte, args ...string) []byte {
+		t.Helper()
+		cmd := exec.Command(bzip2, args...)
+		cmd.Stdin = bytes.NewReader(in)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("bzip2 %v: %v", args, err)
+		}
+		return out
+	}
+	for name, data := range writerInputs(t) {
+		for _, level := range []int{BestSpeed, BestCompression} {
+			c := compress(t, data, level)
+			if got := run(c, "-d", "-c"); !bytes.Equal(got, data) {
+				t.Fatalf("%s/%d: bzip2 -d output mismatch", name, level)
+			}
+			want := streams(t, c, data)
+			got := streams(t, run(data, "-c", fmt.Sprintf("-%d", level)), data)
+			if len(data) > 0 && !slices.Equal(got[0].blocks, want[0].blocks) {
+				t.Errorf("%s/%d: bzip2 wrote blocks %v, we wrote %v", name, level, got[0].blocks, want[0].blocks)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	text := writerInputs(t)["newton"]
+	for _, n := range []int{1000, 1, 50000} {
+		w.Write(text[:n])
+		w.Flush()
+	}
+	w.Close()
+	want := slices.Concat(text[:1000], text[:1], text[:50000])
+	if got := run(buf.Bytes(), "-d", "-c"); !bytes.Equal(got, want) {
+		t.Fatal("bzip2 -d output mismatch for flushed streams")
+	}
+}
+
+// FuzzWriter compresses data with a flush part way through, which ends
+// a stream there.
+func FuzzWriter(f *testing.F) {
+	f.Add([]byte("hello, hello, hello, world"), uint8(1), uint16(7))
+	f.Add(bytes.Repeat([]byte("abcd"), 1000), uint8(9), uint16(2000))
+	f.Add(bytes.Repeat([]byte{0}, 300), uint8(5), uint16(3))
+	f.Fuzz(func(t *testing.T, data []byte, level uint8, flush uint16) {
+		var buf bytes.Buffer
+		w, _ := NewWriterLevel(&buf, BestSpeed+int(level)%BestCompression)
+		k := min(int(flush), len(data))
+		w.Write(data[:k])
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data[k:])
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		ss := streams(t, buf.Bytes(), data)
+		if k > 0 && k < len(data) && len(ss) != 2 {
+			t.Errorf("flush after %d of %d bytes made %d streams, want 2", k, len(data), len(ss))
+		}
+	})
+}
+
+func benchmarkEncode(b *testing.B, compressed []byte) {
+	data, err := io.ReadAll(NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	w := NewWriter(io.Discard)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		w.Reset(io.Discard)
+		w.Write(data)
+		w.Close()
+	}
+}
+
+func BenchmarkEncodeDigits(b *testing.B) { benchmarkEncode(b, digits) }
+func BenchmarkEncodeNewton(b *testing.B) { benchmarkEncode(b, newton) }
+func BenchmarkEncodeRand(b *testing.B)   { benchmarkEncode(b, random) }
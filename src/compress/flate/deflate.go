@@ -745,3 +745,18 @@ func (w *Writer) Reset(dst io.Writer) {
 		w.d.reset(dst)
 	}
 }
+
+// ResetDict discards the writer's state and makes it equivalent to
+// the result of [NewWriterDict] called with dst, w's level and dict.
+// This permits reusing a [Writer] for streams that each continue
+// from a different preset dictionary.
+func (w *Writer) ResetDict(dst io.Writer, dict []byte) {
+	dw, ok := w.d.w.writer.(*dictWriter)
+	if !ok {
+		dw = new(dictWriter)
+	}
+	dw.w = dst
+	w.d.reset(dw)
+	w.dict = append(w.dict[:0], dict...)
+	w.d.fillWindow(w.dict)
+}
@@ -564,6 +564,45 @@ func TestWriterReset(t *testing.T) {
 	})
 }
 
+func TestWriterResetDict(t *testing.T) {
+	dict1 := []byte("now is the time for all good gophers")
+	dict2 := bytes.Repeat([]byte("hello world "), 100)
+	text := []byte("hello world, now is the time for all good gophers to say hello world")
+	for _, level := range []int{HuffmanOnly, NoCompression, BestSpeed, 2, DefaultCompression, BestCompression} {
+		w, err := NewWriter(io.Discard, level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, dict := range [][]byte{dict1, dict2, nil, dict1} {
+			var got, want bytes.Buffer
+			w.ResetDict(&got, dict)
+			w.Write(text)
+			w.Close()
+
+			wref, _ := NewWriterDict(&want, level, dict)
+			wref.Write(text)
+			wref.Close()
+			if !bytes.Equal(got.Bytes(), want.Bytes()) {
+				t.Errorf("level %d: ResetDict output differs from NewWriterDict", level)
+			}
+
+			// Reset keeps the dictionary set by ResetDict.
+			got.Reset()
+			w.Reset(&got)
+			w.Write(text)
+			w.Close()
+			if !bytes.Equal(got.Bytes(), want.Bytes()) {
+				t.Errorf("level %d: Reset after ResetDict lost the dictionary", level)
+			}
+
+			r := NewReaderDict(bytes.NewReader(want.Bytes()), dict)
+			if out, err := io.ReadAll(r); err != nil || !bytes.Equal(out, text) {
+				t.Errorf("level %d: decompressed %q, %v; want %q", level, out, err, text)
+			}
+		}
+	}
+}
+
 func testResetOutput(t *testing.T, level int, dict []byte) {
 	writeData := func(w *Writer) {
 		msg := []byte("now is the time for all good gophers")
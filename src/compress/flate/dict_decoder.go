@@ -63,6 +63,15 @@ func (dd *dictDecoder) histSize() int {
 	return dd.wrPos
 }
 
+// appendHistory appends the historical data in the dictionary to b,
+// oldest first.
+func (dd *dictDecoder) appendHistory(b []byte) []byte {
+	if dd.full {
+		b = append(b, dd.hist[dd.wrPos:]...)
+	}
+	return append(b, dd.hist[:dd.wrPos]...)
+}
+
 // availRead reports the number of bytes that can be flushed by readFlush.
 func (dd *dictDecoder) availRead() int {
 	return dd.wrPos - dd.rdPos
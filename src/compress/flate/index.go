@@ -0,0 +1,394 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"sync"
+)
+
+// defaultSpan is the distance between checkpoints used by
+// BuildIndex and NewIndexer for a span of zero or less.
+const defaultSpan = 1 << 20
+
+var (
+	errInvalidIndex = errors.New("flate: invalid index format")
+	errIndexData    = errors.New("flate: compressed data does not match index")
+)
+
+// An Index records checkpoints in a DEFLATE stream from which decompression
+// can start, for random access to the uncompressed data without
+// decompressing everything before it. A checkpoint is at the start of a
+// block, and holds the 32 KB of uncompressed data before it that the block
+// may refer back to, which is kept compressed.
+//
+// An Index is made by [BuildIndex] or an [Indexer] and is used with an
+// [IndexedReader]. It does not hold the compressed data, and must be used
+// with the data it was built from. [Index.MarshalBinary] encodes an Index
+// so that it can be stored next to the compressed data.
+type Index struct {
+	size   int64 // length of the uncompressed data
+	points []checkpoint
+}
+
+// A checkpoint is a point in a DEFLATE stream where decompression can start.
+type checkpoint struct {
+	in     int64  // offset in bits of a block in the compressed data
+	out    int64  // offset of the block's data in the uncompressed data
+	window []byte // the uncompressed data before out, DEFLATE compressed
+}
+
+// Size returns the length of the uncompressed data.
+func (x *Index) Size() int64 {
+	return x.size
+}
+
+// find returns the last checkpoint at or before the uncompressed offset off.
+func (x *Index) find(off int64) int {
+	return sort.Search(len(x.points), func(i int) bool {
+		return x.points[i].out > off
+	}) - 1
+}
+
+const indexMagic = "flx\x01"
+
+// MarshalBinary encodes the index in a compact binary form
+// that [Index.UnmarshalBinary] decodes.
+func (x *Index) MarshalBinary() ([]byte, error) {
+	b := []byte(indexMagic)
+	b = binary.AppendUvarint(b, uint64(x.size))
+	b = binary.AppendUvarint(b, uint64(len(x.points)))
+	var in, out int64
+	for _, p := range x.points {
+		b = binary.AppendUvarint(b, uint64(p.in-in))
+		b = binary.AppendUvarint(b, uint64(p.out-out))
+		b = binary.AppendUvarint(b, uint64(len(p.window)))
+		b = append(b, p.window...)
+		in, out = p.in, p.out
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes an index encoded by [Index.MarshalBinary].
+func (x *Index) UnmarshalBinary(b []byte) error {
+	if !bytes.HasPrefix(b, []byte(indexMagic)) {
+		return errInvalidIndex
+	}
+	b = b[len(indexMagic):]
+	var bad bool
+	next := func() int64 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 || v > math.MaxInt64 {
+			bad = true
+			return 0
+		}
+		b = b[n:]
+		return int64(v)
+	}
+	size := next()
+	n := next()
+	if bad || n < 1 || n > int64(len(b)) {
+		return errInvalidIndex
+	}
+	points := make([]checkpoint, n)
+	var in, out int64
+	for i := range points {
+		in += next()
+		out += next()
+		w := next()
+		if bad || in < 0 || out < 0 || out > size || w > int64(len(b)) {
+			return errInvalidIndex
+		}
+		points[i] = checkpoint{in: in, out: out, window: b[:w:w]}
+		b = b[w:]
+	}
+	if len(b) > 0 || points[0].in != 0 || points[0].out != 0 {
+		return errInvalidIndex
+	}
+	for i := range points {
+		points[i].window = bytes.Clone(points[i].window)
+	}
+	x.size = size
+	x.points = points
+	return nil
+}
+
+// BuildIndex decompresses the DEFLATE stream read from r and returns an
+// [Index] with checkpoints about span bytes of uncompressed data apart,
+// or 1 MB apart if span is zero or less. Less space between checkpoints
+// makes for faster random access and a larger index. If r does not also
+// implement [io.ByteReader], the decompressor may read more data than
+// necessary from r.
+func BuildIndex(r io.Reader, span int64) (*Index, error) {
+	z := NewIndexer(r, span)
+	if _, err := io.Copy(io.Discard, z); err != nil {
+		return nil, err
+	}
+	return z.Index(), nil
+}
+
+// An Indexer is an [io.Reader] that decompresses a DEFLATE stream, like
+// the reader returned by [NewReader], and records an [Index] of the
+// stream as it goes, so that the index can be built while the data is
+// used for something else.
+type Indexer struct {
+	f      decompressor
+	span   int64
+	out    int64 // amount of data returned by Read
+	points []checkpoint
+	done   bool
+
+	window []byte
+	buf    bytes.Buffer
+	zw     *Writer
+}
+
+// NewIndexer returns a new Indexer that decompresses r, recording
+// checkpoints about span bytes of uncompressed data apart, or 1 MB apart
+// if span is zero or less. If r does not also implement [io.ByteReader],
+// the decompressor may read more data than necessary from r.
+func NewIndexer(r io.Reader, span int64) *Indexer {
+	fixedHuffmanDecoderInit()
+
+	if span <= 0 {
+		span = defaultSpan
+	}
+	z := &Indexer{span: span}
+	z.f.bits = new([maxNumLit + maxNumDist]int)
+	z.f.codebits = new([numCodes]int)
+	z.f.Reset(r, nil)
+	z.f.indexer = z
+	return z
+}
+
+// Read implements [io.Reader], reading uncompressed data.
+func (z *Indexer) Read(p []byte) (int, error) {
+	n, err := z.f.Read(p)
+	z.out += int64(n)
+	if err == io.EOF {
+		z.done = true
+	}
+	return n, err
+}
+
+// Index returns the index of the stream, once Read has returned [io.EOF].
+// Before then, it returns nil.
+func (z *Indexer) Index() *Index {
+	if !z.done {
+		return nil
+	}
+	return &Index{size: z.out, points: z.points}
+}
+
+// checkpoint records a checkpoint at the start of the next block,
+// if it is far enough from the last one.
+func (z *Indexer) checkpoint() {
+	f := &z.f
+	// The decompressor only moves on to the next block once the data it
+	// has returned has been read, so only the pending data in the
+	// dictionary has yet to be read.
+	out := z.out + int64(f.dict.availRead())
+	if n := len(z.points); n > 0 && out-z.points[n-1].out < z.span {
+		return
+	}
+	z.window = f.dict.appendHistory(z.window[:0])
+	z.buf.Reset()
+	if z.zw == nil {
+		z.zw, _ = NewWriter(&z.buf, BestSpeed)
+	} else {
+		z.zw.Reset(&z.buf)
+	}
+	z.zw.Write(z.window)
+	z.zw.Close()
+	z.points = append(z.points, checkpoint{
+		in:     f.roffset*8 - int64(f.nb),
+		out:    out,
+		window: bytes.Clone(z.buf.Bytes()),
+	})
+}
+
+// An IndexedReader reads the uncompressed data of a DEFLATE stream from
+// any offset, using an [Index] of the stream to start decompressing from
+// the nearest checkpoint before the offset.
+//
+// An IndexedReader implements [io.Reader], [io.Seeker] and [io.ReaderAt].
+// Calls to ReadAt may be made in parallel, but not with Read or Seek.
+type IndexedReader struct {
+	r   io.ReaderAt
+	x   *Index
+	pos int64 // offset of the next Read
+
+	d    *indexDecompressor // for Read, or nil
+	dpos int64              // offset of the data d returns next
+}
+
+// NewIndexedReader returns an IndexedReader that reads the uncompressed
+// data of the DEFLATE stream in r that x is an index of. The stream must
+// start at the beginning of r.
+func NewIndexedReader(r io.ReaderAt, x *Index) *IndexedReader {
+	return &IndexedReader{r: r, x: x}
+}
+
+// Size returns the length of the uncompressed data.
+func (z *IndexedReader) Size() int64 {
+	return z.x.size
+}
+
+// Read implements [io.Reader], reading uncompressed data from the
+// current offset.
+func (z *IndexedReader) Read(p []byte) (int, error) {
+	if z.pos >= z.x.size {
+		return 0, io.EOF
+	}
+	if z.d == nil {
+		z.d = getIndexDecompressor()
+		z.dpos = -1
+	}
+	// Decompress forward to the offset if that is quicker
+	// than starting at the checkpoint before it.
+	if z.dpos < 0 || z.dpos > z.pos || z.x.points[z.x.find(z.pos)].out > z.dpos {
+		var err error
+		if z.dpos, err = z.d.start(z.r, z.x, z.pos); err != nil {
+			z.dpos = -1
+			return 0, err
+		}
+	}
+	if err := z.d.discard(z.pos - z.dpos); err != nil {
+		z.dpos = -1
+		return 0, err
+	}
+
+	p = p[:min(int64(len(p)), z.x.size-z.pos)]
+	n, err := z.d.f.Read(p)
+	z.pos += int64(n)
+	z.dpos = z.pos
+	if err == io.EOF {
+		if z.pos < z.x.size {
+			err = errIndexData
+		} else {
+			err = nil
+		}
+	}
+	if err != nil {
+		z.dpos = -1
+	}
+	return n, err
+}
+
+// Seek implements [io.Seeker], setting the offset for the next Read.
+func (z *IndexedReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = z.pos + offset
+	case io.SeekEnd:
+		abs = z.x.size + offset
+	default:
+		return 0, errors.New("flate.IndexedReader.Seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("flate.IndexedReader.Seek: negative position")
+	}
+	z.pos = abs
+	return abs, nil
+}
+
+// ReadAt implements [io.ReaderAt], reading uncompressed data
+// from offset off.
+func (z *IndexedReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("flate.IndexedReader.ReadAt: negative offset")
+	}
+	if off >= z.x.size {
+		return 0, io.EOF
+	}
+	d := getIndexDecompressor()
+	defer indexDecompressorPool.Put(d)
+	pos, err := d.start(z.r, z.x, off)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.discard(off - pos); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(&d.f, p[:min(int64(len(p)), z.x.size-off)])
+	if err == io.ErrUnexpectedEOF || err == io.EOF {
+		err = errIndexData
+	}
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// An indexDecompressor decompresses from a checkpoint.
+type indexDecompressor struct {
+	f      decompressor
+	window []byte
+}
+
+var indexDecompressorPool sync.Pool
+
+func getIndexDecompressor() *indexDecompressor {
+	if d, ok := indexDecompressorPool.Get().(*indexDecompressor); ok {
+		return d
+	}
+	fixedHuffmanDecoderInit()
+	d := new(indexDecompressor)
+	d.f.bits = new([maxNumLit + maxNumDist]int)
+	d.f.codebits = new([numCodes]int)
+	return d
+}
+
+// start sets up d to decompress the stream in r from the last checkpoint
+// in x at or before the uncompressed offset off, and returns the offset
+// of the checkpoint.
+func (d *indexDecompressor) start(r io.ReaderAt, x *Index, off int64) (int64, error) {
+	p := x.points[x.find(off)]
+
+	if d.window == nil {
+		d.window = make([]byte, maxMatchOffset+1)
+	}
+	d.f.Reset(bytes.NewReader(p.window), nil)
+	n, err := io.ReadFull(&d.f, d.window)
+	switch err {
+	case nil:
+		return 0, errInvalidIndex
+	case io.EOF, io.ErrUnexpectedEOF:
+	default:
+		return 0, err
+	}
+
+	in := p.in / 8
+	d.f.Reset(io.NewSectionReader(r, in, math.MaxInt64-in), d.window[:n])
+	d.f.roffset = in
+	if k := uint(p.in % 8); k != 0 {
+		if err := d.f.moreBits(); err != nil {
+			return 0, err
+		}
+		d.f.b >>= k
+		d.f.nb -= k
+	}
+	return p.out, nil
+}
+
+// discard decompresses and discards n bytes.
+func (d *indexDecompressor) discard(n int64) error {
+	if n == 0 {
+		return nil
+	}
+	_, err := io.CopyN(io.Discard, &d.f, n)
+	if err == io.EOF {
+		err = errIndexData
+	}
+	return err
+}
@@ -0,0 +1,264 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+)
+
+func indexTestData(t *testing.T) []byte {
+	data, err := os.ReadFile("../../testdata/Isaac.Newton-Opticks.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func compressLevel(t *testing.T, data []byte, level int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flush now and then, for byte-aligned blocks.
+	for len(data) > 0 {
+		n := min(len(data), 100000)
+		w.Write(data[:n])
+		w.Flush()
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// checkIndexedReader checks reads from z at random offsets against data.
+func checkIndexedReader(t *testing.T, z *IndexedReader, data []byte) {
+	t.Helper()
+	if z.Size() != int64(len(data)) {
+		t.Fatalf("Size() = %d, want %d", z.Size(), len(data))
+	}
+	r := rand.New(rand.NewSource(1))
+	buf := make([]byte, 5000)
+	for range 50 {
+		off := r.Intn(len(data) + 100)
+		p := buf[:r.Intn(len(buf))]
+		want := data[min(off, len(data)):min(off+len(p), len(data))]
+
+		n, err := z.ReadAt(p, int64(off))
+		if !bytes.Equal(p[:n], want) {
+			t.Fatalf("ReadAt(%d bytes, %d) returned wrong data", len(p), off)
+		}
+		if n < len(p) && err != io.EOF || n == len(p) && err != nil {
+			t.Fatalf("ReadAt(%d bytes, %d) = %d, %v", len(p), off, n, err)
+		}
+
+		if _, err := z.Seek(int64(off), io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(io.LimitReader(z, int64(len(p))))
+		if err != nil {
+			t.Fatalf("Read at %d: %v", off, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("Read at %d returned wrong data", off)
+		}
+	}
+
+	// Read everything in one go, from the start.
+	z.Seek(0, io.SeekStart)
+	got, err := io.ReadAll(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("reading from the start returned wrong data")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	data := indexTestData(t)
+	for _, level := range []int{NoCompression, BestSpeed, DefaultCompression, HuffmanOnly} {
+		for _, span := range []int64{0, 4096, 100000} {
+			t.Run(fmt.Sprintf("%d/%d", level, span), func(t *testing.T) {
+				c := compressLevel(t, data, level)
+				x, err := BuildIndex(bytes.NewReader(c), span)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if span > 0 && len(x.points) < 2 {
+					t.Errorf("index has %d checkpoints for %d bytes", len(x.points), len(data))
+				}
+				for i := 1; i < len(x.points); i++ {
+					if d := x.points[i].out - x.points[i-1].out; span > 0 && d < span {
+						t.Errorf("checkpoints %d and %d are %d bytes apart, want at least %d", i-1, i, d, span)
+					}
+				}
+				checkIndexedReader(t, NewIndexedReader(bytes.NewReader(c), x), data)
+
+				b, err := x.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+				var x2 Index
+				if err := x2.UnmarshalBinary(b); err != nil {
+					t.Fatal(err)
+				}
+				checkIndexedReader(t, NewIndexedReader(bytes.NewReader(c), &x2), data)
+			})
+		}
+	}
+}
+
+func TestIndexEmpty(t *testing.T) {
+	c := compressLevel(t, nil, DefaultCompression)
+	x, err := BuildIndex(bytes.NewReader(c), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := NewIndexedReader(bytes.NewReader(c), x)
+	if n, err := z.Read(make([]byte, 10)); n != 0 || err != io.EOF {
+		t.Errorf("Read = %d, %v, want 0, EOF", n, err)
+	}
+	if n, err := z.ReadAt(make([]byte, 10), 0); n != 0 || err != io.EOF {
+		t.Errorf("ReadAt = %d, %v, want 0, EOF", n, err)
+	}
+}
+
+func TestIndexer(t *testing.T) {
+	data := indexTestData(t)
+	c := compressLevel(t, data, DefaultCompression)
+	z := NewIndexer(bytes.NewReader(c), 50000)
+	var buf bytes.Buffer
+	// Read in small pieces, so that the reader is behind
+	// the decompressor when checkpoints are recorded.
+	if _, err := io.CopyBuffer(&buf, struct{ io.Reader }{z}, make([]byte, 1000)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("Indexer returned wrong data")
+	}
+	x := z.Index()
+	if x == nil {
+		t.Fatal("Index() = nil after EOF")
+	}
+	checkIndexedReader(t, NewIndexedReader(bytes.NewReader(c), x), data)
+
+	if NewIndexer(bytes.NewReader(c), 0).Index() != nil {
+		t.Error("Index() != nil before EOF")
+	}
+}
+
+func TestIndexConcurrentReadAt(t *testing.T) {
+	data := indexTestData(t)
+	c := compressLevel(t, data, BestSpeed)
+	x, err := BuildIndex(bytes.NewReader(c), 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := NewIndexedReader(bytes.NewReader(c), x)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(i)))
+			p := make([]byte, 1000)
+			for range 20 {
+				off := r.Intn(len(data) - len(p))
+				if _, err := z.ReadAt(p, int64(off)); err != nil {
+					t.Error(err)
+					return
+				}
+				if !bytes.Equal(p, data[off:off+len(p)]) {
+					t.Errorf("ReadAt(%d) returned wrong data", off)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIndexSeek(t *testing.T) {
+	data := indexTestData(t)
+	c := compressLevel(t, data, DefaultCompression)
+	x, err := BuildIndex(bytes.NewReader(c), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := NewIndexedReader(bytes.NewReader(c), x)
+	tests := []struct {
+		off     int64
+		whence  int
+		want    int64
+		wantErr bool
+	}{
+		{10, io.SeekStart, 10, false},
+		{5, io.SeekCurrent, 15, false},
+		{-5, io.SeekEnd, int64(len(data)) - 5, false},
+		{10, io.SeekEnd, int64(len(data)) + 10, false},
+		{-1, io.SeekStart, 0, true},
+		{0, 3, 0, true},
+	}
+	for _, tt := range tests {
+		pos, err := z.Seek(tt.off, tt.whence)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Seek(%d, %d) succeeded", tt.off, tt.whence)
+			}
+			continue
+		}
+		if pos != tt.want || err != nil {
+			t.Errorf("Seek(%d, %d) = %d, %v, want %d, nil", tt.off, tt.whence, pos, err, tt.want)
+		}
+	}
+	if n, err := z.Read(make([]byte, 10)); n != 0 || err != io.EOF {
+		t.Errorf("Read past end = %d, %v, want 0, EOF", n, err)
+	}
+}
+
+func TestIndexUnmarshalInvalid(t *testing.T) {
+	c := compressLevel(t, indexTestData(t), DefaultCompression)
+	x, err := BuildIndex(bytes.NewReader(c), 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := x.MarshalBinary()
+	var x2 Index
+	for i := range len(b) {
+		if err := x2.UnmarshalBinary(b[:i]); err == nil {
+			t.Fatalf("UnmarshalBinary of %d of %d bytes succeeded", i, len(b))
+		}
+	}
+	if err := x2.UnmarshalBinary(append(b, 0)); err == nil {
+		t.Error("UnmarshalBinary with trailing data succeeded")
+	}
+}
+
+func TestIndexWrongData(t *testing.T) {
+	data := indexTestData(t)
+	c := compressLevel(t, data, DefaultCompression)
+	x, err := BuildIndex(bytes.NewReader(c), 50000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Reading with the index of different data must fail, not panic.
+	z := NewIndexedReader(bytes.NewReader(c[:len(c)/2]), x)
+	if _, err := z.ReadAt(make([]byte, 1000), int64(len(data)-1000)); err == nil {
+		t.Error("ReadAt of truncated data succeeded")
+	}
+	if _, err := io.ReadAll(z); err == nil {
+		t.Error("Read of truncated data succeeded")
+	}
+}
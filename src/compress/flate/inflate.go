@@ -297,9 +297,15 @@ type decompressor struct {
 	hl, hd    *huffmanDecoder
 	copyLen   int
 	copyDist  int
+
+	// If not nil, records checkpoints at the starts of blocks.
+	indexer *Indexer
 }
 
 func (f *decompressor) nextBlock() {
+	if f.indexer != nil {
+		f.indexer.checkpoint()
+	}
 	for f.nb < 1+2 {
 		if f.err = f.moreBits(); f.err != nil {
 			return
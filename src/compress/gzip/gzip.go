@@ -36,6 +36,13 @@ type Writer struct {
 	digest      uint32 // CRC-32, IEEE polynomial (section 8)
 	size        uint32 // Uncompressed size (section 2.3.1)
 	err         error
+
+	// Concurrent compression, set by SetConcurrency.
+	blockSize int
+	blocks    int      // most blocks in flight, or 0 for none
+	pending   []byte   // input for the next block
+	history   []byte   // last windowSize bytes of input before pending
+	inflight  []*block // blocks being compressed, in stream order
 }
 
 // NewWriter returns a new [Writer].
@@ -71,6 +78,8 @@ func (z *Writer) init(w io.Writer, level int) {
 	if compressor != nil {
 		compressor.Reset(w)
 	}
+	z.waitBlocks()
+	clear(z.inflight)
 	*z = Writer{
 		Header: Header{
 			OS: 255, // unknown
@@ -78,6 +87,11 @@ func (z *Writer) init(w io.Writer, level int) {
 		w:          w,
 		level:      level,
 		compressor: compressor,
+		blockSize:  z.blockSize,
+		blocks:     z.blocks,
+		pending:    z.pending[:0],
+		history:    z.history[:0],
+		inflight:   z.inflight[:0],
 	}
 }
 
@@ -85,6 +99,7 @@ func (z *Writer) init(w io.Writer, level int) {
 // result of its original state from [NewWriter] or [NewWriterLevel], but
 // writing to w instead. This permits reusing a [Writer] rather than
 // allocating a new one.
+// Any concurrency set by [Writer.SetConcurrency] is kept.
 func (z *Writer) Reset(w io.Writer) {
 	z.init(w, z.level)
 }
@@ -187,12 +202,16 @@ func (z *Writer) Write(p []byte) (int, error) {
 				return 0, z.err
 			}
 		}
-		if z.compressor == nil {
+		if z.compressor == nil && z.blocks == 0 {
 			z.compressor, _ = flate.NewWriter(z.w, z.level)
 		}
 	}
 	z.size += uint32(len(p))
 	z.digest = crc32.Update(z.digest, crc32.IEEETable, p)
+	if z.blocks > 0 {
+		n, z.err = z.writeBlocks(p)
+		return n, z.err
+	}
 	n, z.err = z.compressor.Write(p)
 	return n, z.err
 }
@@ -218,6 +237,10 @@ func (z *Writer) Flush() error {
 			return z.err
 		}
 	}
+	if z.blocks > 0 {
+		z.err = z.flushBlocks(false)
+		return z.err
+	}
 	z.err = z.compressor.Flush()
 	return z.err
 }
@@ -239,7 +262,11 @@ func (z *Writer) Close() error {
 			return z.err
 		}
 	}
-	z.err = z.compressor.Close()
+	if z.blocks > 0 {
+		z.err = z.flushBlocks(true)
+	} else {
+		z.err = z.compressor.Close()
+	}
 	if z.err != nil {
 		return z.err
 	}
@@ -7,6 +7,7 @@ package gzip
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
 	"reflect"
 	"testing"
@@ -278,3 +279,122 @@ func TestLimitedWrite(t *testing.T) {
 		}
 	}
 }
+
+// concurrencyTestData returns compressible data with matches at
+// distances that cross block boundaries.
+func concurrencyTestData() []byte {
+	var b bytes.Buffer
+	for i := 0; b.Len() < 3<<20; i++ {
+		fmt.Fprintf(&b, "line %d: %x the quick brown fox %d\n", i, i*i%1000, i%97)
+	}
+	return b.Bytes()
+}
+
+func TestWriterConcurrency(t *testing.T) {
+	data := concurrencyTestData()
+	levels := []int{HuffmanOnly, NoCompression, BestSpeed, DefaultCompression, BestCompression}
+	if testing.Short() {
+		levels = []int{BestSpeed, DefaultCompression}
+	}
+	for _, level := range levels {
+		for _, blockSize := range []int{10000, 1 << 20} {
+			var serial, buf bytes.Buffer
+			z, _ := NewWriterLevel(&serial, level)
+			z.Write(data)
+			z.Close()
+
+			z, _ = NewWriterLevel(&buf, level)
+			if err := z.SetConcurrency(blockSize, 4); err != nil {
+				t.Fatal(err)
+			}
+			for p := data; len(p) > 0; {
+				n := min(len(p), 12345)
+				if _, err := z.Write(p[:n]); err != nil {
+					t.Fatal(err)
+				}
+				p = p[n:]
+			}
+			if err := z.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Priming each block with the previous window keeps the
+			// output close to the serial output.
+			if level > NoCompression && blockSize == 1<<20 && buf.Len() > serial.Len()+serial.Len()/100+64 {
+				t.Errorf("level %d, block size %d: compressed to %d bytes, %d without concurrency", level, blockSize, buf.Len(), serial.Len())
+			}
+
+			// The output must be a single member.
+			r, err := NewReader(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Multistream(false)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("level %d, block size %d: %v", level, blockSize, err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("level %d, block size %d: round trip mismatch", level, blockSize)
+			}
+			if buf.Len() > 0 {
+				t.Errorf("level %d, block size %d: %d bytes after the first member", level, blockSize, buf.Len())
+			}
+		}
+	}
+}
+
+func TestWriterConcurrencyFlushReset(t *testing.T) {
+	var buf bytes.Buffer
+	z := NewWriter(&buf)
+	if err := z.SetConcurrency(0, 2); err == nil {
+		t.Error("SetConcurrency with zero block size succeeded")
+	}
+	if err := z.SetConcurrency(1<<16, 2); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(&buf)
+	var zr *Reader
+	for i := range 5 {
+		msg := []byte(fmt.Sprintf("message %d, hello hello hello\n", i))
+		z.Write(msg)
+		if err := z.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if zr == nil {
+			var err error
+			if zr, err = NewReader(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got := make([]byte, len(msg))
+		if _, err := io.ReadFull(zr, got); err != nil || !bytes.Equal(got, msg) {
+			t.Fatalf("read %q, %v after Flush; want %q", got, err, msg)
+		}
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := z.SetConcurrency(1<<16, 2); err == nil {
+		t.Error("SetConcurrency after Close succeeded")
+	}
+
+	// Reset keeps the concurrency setting and gives the same output.
+	data := concurrencyTestData()[:300000]
+	var out1, out2 bytes.Buffer
+	for _, out := range []*bytes.Buffer{&out1, &out2} {
+		z.Reset(out)
+		z.Write(data)
+		z.Close()
+	}
+	if !bytes.Equal(out1.Bytes(), out2.Bytes()) {
+		t.Error("output differs after Reset")
+	}
+	var serial bytes.Buffer
+	zs := NewWriter(&serial)
+	zs.Write(data)
+	zs.Close()
+	if bytes.Equal(out1.Bytes(), serial.Bytes()) {
+		t.Error("concurrency setting lost by Reset")
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"math"
+	"sort"
+)
+
+var errInvalidIndex = errors.New("gzip: invalid index format")
+
+// An Index records checkpoints in a gzip file from which decompression
+// can start, for random access to the uncompressed data without
+// decompressing everything before it. It holds a [flate.Index] for the
+// compressed data of each member of the file.
+//
+// An Index is made by [BuildIndex] and is used with an [IndexedReader].
+// It does not hold the compressed data, and must be used with the file
+// it was built from. [Index.MarshalBinary] encodes an Index so that it
+// can be stored next to the file.
+type Index struct {
+	size    int64 // length of the uncompressed data
+	members []member
+}
+
+// A member is a gzip member with data in it.
+type member struct {
+	in    int64 // offset of the member's DEFLATE stream in the file
+	out   int64 // offset of the member's data in the uncompressed data
+	index *flate.Index
+}
+
+// Size returns the length of the uncompressed data.
+func (x *Index) Size() int64 {
+	return x.size
+}
+
+const indexMagic = "gzx\x01"
+
+// MarshalBinary encodes the index in a compact binary form
+// that [Index.UnmarshalBinary] decodes.
+func (x *Index) MarshalBinary() ([]byte, error) {
+	b := []byte(indexMagic)
+	b = binary.AppendUvarint(b, uint64(len(x.members)))
+	for _, m := range x.members {
+		fb, err := m.index.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = binary.AppendUvarint(b, uint64(m.in))
+		b = binary.AppendUvarint(b, uint64(len(fb)))
+		b = append(b, fb...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes an index encoded by [Index.MarshalBinary].
+func (x *Index) UnmarshalBinary(b []byte) error {
+	if !bytes.HasPrefix(b, []byte(indexMagic)) {
+		return errInvalidIndex
+	}
+	b = b[len(indexMagic):]
+	var bad bool
+	next := func() int64 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 || v > math.MaxInt64 {
+			bad = true
+			return 0
+		}
+		b = b[n:]
+		return int64(v)
+	}
+	n := next()
+	if bad || n > int64(len(b)) {
+		return errInvalidIndex
+	}
+	members := make([]member, n)
+	var size int64
+	for i := range members {
+		in := next()
+		l := next()
+		if bad || l > int64(len(b)) || i > 0 && in <= members[i-1].in {
+			return errInvalidIndex
+		}
+		fx := new(flate.Index)
+		if err := fx.UnmarshalBinary(b[:l]); err != nil {
+			return errInvalidIndex
+		}
+		b = b[l:]
+		if fx.Size() == 0 || fx.Size() > math.MaxInt64-size {
+			return errInvalidIndex
+		}
+		members[i] = member{in: in, out: size, index: fx}
+		size += fx.Size()
+	}
+	if len(b) > 0 {
+		return errInvalidIndex
+	}
+	x.size = size
+	x.members = members
+	return nil
+}
+
+// BuildIndex decompresses the gzip file read from r, checking its
+// checksums, and returns an [Index] with checkpoints about span bytes of
+// uncompressed data apart, as [flate.BuildIndex] does for each member of
+// the file.
+func BuildIndex(r io.Reader, span int64) (*Index, error) {
+	rr, ok := r.(flate.Reader)
+	if !ok {
+		rr = bufio.NewReader(r)
+	}
+	cr := &countingReader{r: rr}
+	z := &Reader{r: cr}
+	x := new(Index)
+	for i := 0; ; i++ {
+		if _, err := z.readHeader(); err != nil {
+			if err == io.EOF {
+				if i > 0 {
+					return x, nil
+				}
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		in := cr.n
+		zx := flate.NewIndexer(cr, span)
+		digest := crc32.NewIEEE()
+		n, err := io.Copy(digest, zx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(cr, z.buf[:8]); err != nil {
+			return nil, noEOF(err)
+		}
+		if le.Uint32(z.buf[:4]) != digest.Sum32() || le.Uint32(z.buf[4:8]) != uint32(n) {
+			return nil, ErrChecksum
+		}
+		if n > 0 {
+			x.members = append(x.members, member{in: in, out: x.size, index: zx.Index()})
+			x.size += n
+		}
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r flate.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReader) ReadByte() (byte, error) {
+	c, err := r.r.ReadByte()
+	if err == nil {
+		r.n++
+	}
+	return c, err
+}
+
+// An IndexedReader reads the uncompressed data of a gzip file from any
+// offset, using an [Index] of the file to start decompressing from the
+// nearest checkpoint before the offset. It does not check the checksums
+// of the file, which [BuildIndex] has done.
+//
+// An IndexedReader implements [io.Reader], [io.Seeker] and [io.ReaderAt].
+// Calls to ReadAt may be made in parallel, but not with Read or Seek.
+type IndexedReader struct {
+	x       *Index
+	pos     int64 // offset of the next Read
+	members []*flate.IndexedReader
+}
+
+// NewIndexedReader returns an IndexedReader that reads the uncompressed
+// data of the gzip file in r that x is an index of.
+func NewIndexedReader(r io.ReaderAt, x *Index) *IndexedReader {
+	z := &IndexedReader{x: x}
+	for _, m := range x.members {
+		sr := io.NewSectionReader(r, m.in, math.MaxInt64-m.in)
+		z.members = append(z.members, flate.NewIndexedReader(sr, m.index))
+	}
+	return z
+}
+
+// Size returns the length of the uncompressed data.
+func (z *IndexedReader) Size() int64 {
+	return z.x.size
+}
+
+// find returns the member that holds the uncompressed offset off,
+// which must be less than the size of the data.
+func (z *IndexedReader) find(off int64) int {
+	ms := z.x.members
+	return sort.Search(len(ms), func(i int) bool {
+		return ms[i].out > off
+	}) - 1
+}
+
+// Read implements [io.Reader], reading uncompressed data from the
+// current offset.
+func (z *IndexedReader) Read(p []byte) (int, error) {
+	if z.pos >= z.x.size {
+		return 0, io.EOF
+	}
+	i := z.find(z.pos)
+	mr := z.members[i]
+	if _, err := mr.Seek(z.pos-z.x.members[i].out, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := mr.Read(p)
+	z.pos += int64(n)
+	if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// Seek implements [io.Seeker], setting the offset for the next Read.
+func (z *IndexedReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = z.pos + offset
+	case io.SeekEnd:
+		abs = z.x.size + offset
+	default:
+		return 0, errors.New("gzip.IndexedReader.Seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("gzip.IndexedReader.Seek: negative position")
+	}
+	z.pos = abs
+	return abs, nil
+}
+
+// ReadAt implements [io.ReaderAt], reading uncompressed data
+// from offset off.
+func (z *IndexedReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("gzip.IndexedReader.ReadAt: negative offset")
+	}
+	for n < len(p) {
+		if off >= z.x.size {
+			return n, io.EOF
+		}
+		i := z.find(off)
+		m := z.x.members[i]
+		k, err := z.members[i].ReadAt(p[n:], off-m.out)
+		n += k
+		off += int64(k)
+		if err != nil && err != io.EOF {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+// indexTestFile returns a gzip file of several members, some of them
+// empty, and its uncompressed data.
+func indexTestFile(t *testing.T) (file, data []byte) {
+	text, err := os.ReadFile("../../testdata/Isaac.Newton-Opticks.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	for _, part := range [][]byte{text[:100000], nil, text[100000:300000], text[300000:]} {
+		w := NewWriter(&buf)
+		w.Name = "part"
+		w.Write(part)
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes(), text
+}
+
+func checkIndexedReader(t *testing.T, z *IndexedReader, data []byte) {
+	t.Helper()
+	if z.Size() != int64(len(data)) {
+		t.Fatalf("Size() = %d, want %d", z.Size(), len(data))
+	}
+	r := rand.New(rand.NewSource(1))
+	buf := make([]byte, 50000)
+	for range 50 {
+		off := r.Intn(len(data) + 100)
+		p := buf[:r.Intn(len(buf))]
+		want := data[min(off, len(data)):min(off+len(p), len(data))]
+
+		n, err := z.ReadAt(p, int64(off))
+		if !bytes.Equal(p[:n], want) {
+			t.Fatalf("ReadAt(%d bytes, %d) returned wrong data", len(p), off)
+		}
+		if n < len(p) && err != io.EOF || n == len(p) && err != nil {
+			t.Fatalf("ReadAt(%d bytes, %d) = %d, %v", len(p), off, n, err)
+		}
+
+		if _, err := z.Seek(int64(off), io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(io.LimitReader(z, int64(len(p))))
+		if err != nil {
+			t.Fatalf("Read at %d: %v", off, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("Read at %d returned wrong data", off)
+		}
+	}
+
+	z.Seek(0, io.SeekStart)
+	got, err := io.ReadAll(z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("reading from the start returned wrong data")
+	}
+}
+
+func TestIndex(t *testing.T) {
+	file, data := indexTestFile(t)
+	// Hide the io.ByteReader method, which BuildIndex
+	// must not need.
+	x, err := BuildIndex(struct{ io.Reader }{bytes.NewReader(file)}, 20000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(x.members) != 3 {
+		t.Errorf("index has %d members, want 3", len(x.members))
+	}
+	checkIndexedReader(t, NewIndexedReader(bytes.NewReader(file), x), data)
+
+	b, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x2 Index
+	if err := x2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	checkIndexedReader(t, NewIndexedReader(bytes.NewReader(file), &x2), data)
+
+	for i := range len(b) {
+		if err := x2.UnmarshalBinary(b[:i]); err == nil {
+			t.Fatalf("UnmarshalBinary of %d of %d bytes succeeded", i, len(b))
+		}
+	}
+}
+
+func TestBuildIndexErrors(t *testing.T) {
+	file, _ := indexTestFile(t)
+	if _, err := BuildIndex(bytes.NewReader(nil), 0); err != io.ErrUnexpectedEOF {
+		t.Errorf("BuildIndex of empty file: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if _, err := BuildIndex(bytes.NewReader(file[:len(file)-4]), 0); err != io.ErrUnexpectedEOF {
+		t.Errorf("BuildIndex of truncated file: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	bad := bytes.Clone(file)
+	bad[len(bad)-8]++
+	if _, err := BuildIndex(bytes.NewReader(bad), 0); err != ErrChecksum {
+		t.Errorf("BuildIndex with bad checksum: got %v, want %v", err, ErrChecksum)
+	}
+	if _, err := BuildIndex(bytes.NewReader(append(bytes.Clone(file), 0)), 0); err == nil {
+		t.Error("BuildIndex with trailing garbage succeeded")
+	}
+}
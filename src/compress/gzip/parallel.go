@@ -0,0 +1,182 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gzip
+
+import (
+	"compress/flate"
+	"errors"
+	"sync"
+)
+
+// windowSize is the size of the DEFLATE window. Each block is primed
+// with this much of the preceding input.
+const windowSize = 1 << 15
+
+// SetConcurrency makes z compress its input in independent blocks of
+// blockSize bytes, with up to blocks of them compressed concurrently
+// on their own goroutines. Each block is primed with the last 32 KiB
+// of the input before it as a preset dictionary, so matches can still
+// refer back across block boundaries, and ends with a sync flush,
+// as in pigz. The output is a standard single member gzip stream that
+// is slightly larger than without concurrency.
+//
+// Up to about 2*blocks*blockSize bytes are buffered in memory.
+// Block sizes of around 1 MiB work well.
+//
+// SetConcurrency must be called before the first call to Write, Flush
+// or Close. The setting is kept by Reset.
+func (z *Writer) SetConcurrency(blockSize, blocks int) error {
+	if z.wroteHeader {
+		return errors.New("gzip: SetConcurrency called after writing")
+	}
+	if blockSize <= 0 {
+		return errors.New("gzip: SetConcurrency: block size must be positive")
+	}
+	if blocks <= 0 {
+		return errors.New("gzip: SetConcurrency: number of blocks must be positive")
+	}
+	z.blockSize = blockSize
+	z.blocks = blocks
+	return nil
+}
+
+// A block is a piece of the input compressed on its own goroutine.
+type block struct {
+	in   []byte // dictionary followed by the data to compress
+	dict int    // length of the dictionary in in
+	last bool   // whether this is the final block of the stream
+	out  []byte // compressed data
+	done chan struct{}
+}
+
+// Write implements io.Writer, collecting the compressed data.
+func (b *block) Write(p []byte) (int, error) {
+	b.out = append(b.out, p...)
+	return len(p), nil
+}
+
+// flateWriterPools holds flate.Writers for each compression level.
+var flateWriterPools [BestCompression - HuffmanOnly + 1]sync.Pool
+
+// blockBufPool holds buffers for block input and output.
+var blockBufPool sync.Pool
+
+func getBlockBuf() []byte {
+	if b, ok := blockBufPool.Get().(*[]byte); ok {
+		return (*b)[:0]
+	}
+	return nil
+}
+
+func putBlockBuf(b []byte) {
+	if b != nil {
+		blockBufPool.Put(&b)
+	}
+}
+
+// compress compresses the block at the given level and closes b.done.
+func (b *block) compress(level int) {
+	pool := &flateWriterPools[level-HuffmanOnly]
+	fw, _ := pool.Get().(*flate.Writer)
+	if fw == nil {
+		fw, _ = flate.NewWriter(nil, level)
+	}
+	fw.ResetDict(b, b.in[:b.dict])
+	// Writes to a block never fail.
+	fw.Write(b.in[b.dict:])
+	if b.last {
+		fw.Close()
+	} else {
+		fw.Flush()
+	}
+	// Don't keep a reference to b or its dictionary.
+	fw.ResetDict(nil, nil)
+	pool.Put(fw)
+	close(b.done)
+}
+
+// writeBlocks buffers p, starting a block each time blockSize bytes
+// are pending.
+func (z *Writer) writeBlocks(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		k := min(len(p), z.blockSize-len(z.pending))
+		z.pending = append(z.pending, p[:k]...)
+		p = p[k:]
+		n += k
+		if len(z.pending) == z.blockSize {
+			if err := z.startBlock(false); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// startBlock starts compressing the pending data, first waiting for
+// and writing out the oldest block if there are too many in flight.
+func (z *Writer) startBlock(last bool) error {
+	if len(z.inflight) >= z.blocks {
+		if err := z.writeBlock(); err != nil {
+			return err
+		}
+	}
+	b := &block{
+		in:   append(append(getBlockBuf(), z.history...), z.pending...),
+		dict: len(z.history),
+		last: last,
+		out:  getBlockBuf(),
+		done: make(chan struct{}),
+	}
+	go b.compress(z.level)
+	z.inflight = append(z.inflight, b)
+
+	// Keep the last windowSize bytes of input for the next block.
+	if len(z.pending) >= windowSize {
+		z.history = append(z.history[:0], z.pending[len(z.pending)-windowSize:]...)
+	} else {
+		z.history = append(z.history, z.pending...)
+		if extra := len(z.history) - windowSize; extra > 0 {
+			z.history = z.history[:copy(z.history, z.history[extra:])]
+		}
+	}
+	z.pending = z.pending[:0]
+	return nil
+}
+
+// writeBlock waits for the oldest block in flight and writes its output.
+func (z *Writer) writeBlock() error {
+	b := z.inflight[0]
+	copy(z.inflight, z.inflight[1:])
+	z.inflight[len(z.inflight)-1] = nil
+	z.inflight = z.inflight[:len(z.inflight)-1]
+	<-b.done
+	_, err := z.w.Write(b.out)
+	putBlockBuf(b.in)
+	putBlockBuf(b.out)
+	return err
+}
+
+// flushBlocks compresses any pending data, ending with a sync flush or,
+// if last is set, the final block of the stream, and writes out every
+// block in flight.
+func (z *Writer) flushBlocks(last bool) error {
+	if err := z.startBlock(last); err != nil {
+		return err
+	}
+	for len(z.inflight) > 0 {
+		if err := z.writeBlock(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitBlocks waits for any blocks in flight and discards them.
+func (z *Writer) waitBlocks() {
+	for _, b := range z.inflight {
+		<-b.done
+	}
+}
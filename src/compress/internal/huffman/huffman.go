@@ -0,0 +1,127 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package huffman computes the length limited Huffman codes used by
+// the compressors in compress/zstd, compress/brotli and compress/bzip2.
+package huffman
+
+import (
+	"cmp"
+	"slices"
+)
+
+// A Builder computes Huffman code lengths. The zero value is ready to
+// use, and a Builder reuses its scratch space from call to call.
+type Builder struct {
+	nodes  []node
+	leaves []int
+}
+
+// A node is a node in the tree built by Lengths.
+type node struct {
+	count  uint64
+	parent int32
+}
+
+// Lengths sets lens[sym] to the code length of each symbol sym counted
+// in counts, with no code longer than maxBits bits, and to 0 for the
+// symbols with a count of 0. If only one symbol is counted, its length
+// is also 0. lens must be at least as long as counts.
+//
+// Lengths returns the counted symbols in increasing order of count.
+// The slice is valid until the next call.
+func (b *Builder) Lengths(lens []uint8, counts []uint32, maxBits uint8) []int {
+	clear(lens[:len(counts)])
+	b.leaves = b.leaves[:0]
+	for sym, c := range counts {
+		if c > 0 {
+			b.leaves = append(b.leaves, sym)
+		}
+	}
+	n := len(b.leaves)
+	if n < 2 {
+		return b.leaves
+	}
+	slices.SortStableFunc(b.leaves, func(x, y int) int {
+		return cmp.Compare(counts[x], counts[y])
+	})
+
+	// Build the tree by repeatedly combining the two lowest weight
+	// nodes. Leaves and internal nodes are each created in increasing
+	// weight order, so the next lowest is at the front of one of them.
+	b.nodes = slices.Grow(b.nodes[:0], 2*n-1)[:2*n-1]
+	for i, sym := range b.leaves {
+		b.nodes[i] = node{count: uint64(counts[sym])}
+	}
+	leaf, internal := 0, n
+	pick := func(k int) int {
+		if leaf < n && (internal >= k || b.nodes[leaf].count <= b.nodes[internal].count) {
+			leaf++
+			return leaf - 1
+		}
+		internal++
+		return internal - 1
+	}
+	for k := n; k < 2*n-1; k++ {
+		x := pick(k)
+		y := pick(k)
+		b.nodes[k] = node{count: b.nodes[x].count + b.nodes[y].count}
+		b.nodes[x].parent = int32(k)
+		b.nodes[y].parent = int32(k)
+	}
+
+	// Parents come after their children, so walk down from the root,
+	// reusing count to hold the depth.
+	b.nodes[2*n-2].count = 0
+	for k := 2*n - 3; k >= 0; k-- {
+		b.nodes[k].count = b.nodes[b.nodes[k].parent].count + 1
+	}
+	longest := 0
+	for i, sym := range b.leaves {
+		l := int(b.nodes[i].count)
+		longest = max(longest, l)
+		lens[sym] = uint8(min(l, int(maxBits)))
+	}
+	if longest > int(maxBits) {
+		b.limitLengths(lens, maxBits)
+	}
+	return b.leaves
+}
+
+// limitLengths fixes up lengths that were clamped to maxBits,
+// so that they again form a complete prefix code.
+func (b *Builder) limitLengths(lens []uint8, maxBits uint8) {
+	// The Kraft sum, scaled so that a complete code sums to total.
+	total := 1 << maxBits
+	sum := 0
+	for _, sym := range b.leaves {
+		sum += total >> lens[sym]
+	}
+
+	// Lengthen the codes of the rarest symbols until the code fits.
+	// The leaves are sorted by increasing count.
+	for sum > total {
+		for _, sym := range b.leaves {
+			if lens[sym] < maxBits {
+				lens[sym]++
+				sum -= total >> lens[sym]
+				break
+			}
+		}
+	}
+
+	// Shorten the codes of the most common symbols to fill any gap.
+	// A gap is always at least as large as the gain from shortening
+	// one of the longest codes.
+	for sum < total {
+		for i := len(b.leaves) - 1; i >= 0; i-- {
+			sym := b.leaves[i]
+			if gain := total >> lens[sym]; lens[sym] > 1 && sum+gain <= total {
+				lens[sym]--
+				sum += gain
+				break
+			}
+		}
+	}
+}
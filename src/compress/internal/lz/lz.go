@@ -0,0 +1,206 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lz implements the hash chain match finder shared by the
+// LZ77 compressors in compress/zstd and compress/brotli.
+package lz
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// MinMatch is the shortest match the tables are keyed by.
+const MinMatch = 4
+
+// Margin is the number of bytes a compressor leaves after a position
+// it looks up, to read a hash and compare without bounds checks.
+const Margin = 8
+
+// Params are the match finder parameters for a compression level.
+type Params struct {
+	WindowLog uint8 // log of the window size
+	HashLog   uint8 // log of the hash table size
+	ChainLog  uint8 // log of the hash chain size, or 0 for no chains
+	Depth     int   // most candidates to check for a match
+	Lazy      int   // number of following positions to try for a longer match
+	Nice      int   // stop searching at a match this long
+}
+
+// Step returns how far to move on from a position with no match,
+// the misses'th such position in a row. Levels without lazy matching
+// skip ahead faster through data that doesn't match.
+func (p *Params) Step(misses int) int {
+	if p.Lazy > 0 {
+		return 1
+	}
+	return 1 + misses>>5
+}
+
+// A Table indexes the positions in a buffer by a hash of their first
+// MinMatch bytes, with a chain linking each position to the previous
+// one with the same hash.
+//
+// The buffer holds history followed by the data being compressed.
+// Entries hold a buffer index plus base, so that the buffer can slide
+// without rewriting the tables; 0 is an empty entry.
+type Table struct {
+	hashLog uint8
+	table   []int32
+	chain   []int32
+	base    int32
+
+	// The buffer index up to which positions have been indexed.
+	indexed int
+}
+
+// maxBase is the largest base before the tables must be rebuilt.
+const maxBase = 1 << 30
+
+// Reset prepares t for a new buffer with the table sizes in p. Data up
+// to sizeHint bytes long can use smaller tables; 0 means the size is
+// unknown.
+func (t *Table) Reset(p *Params, sizeHint int) {
+	hashLog, chainLog := p.HashLog, p.ChainLog
+	if sizeHint > 0 {
+		// There is no point in tables much bigger than the data.
+		log := uint8(max(bits.Len(uint(sizeHint)), 10))
+		hashLog = min(hashLog, log)
+		if chainLog > 0 {
+			chainLog = min(chainLog, log)
+		}
+	}
+	t.hashLog = hashLog
+	t.table = resize(t.table, 1<<hashLog)
+	if chainLog > 0 {
+		t.chain = resize(t.chain, 1<<chainLog)
+	} else {
+		t.chain = t.chain[:0]
+	}
+	t.base = 1
+	t.indexed = 0
+}
+
+// resize returns a zeroed slice of n entries, reusing s if possible.
+func resize(s []int32, n int) []int32 {
+	if cap(s) < n {
+		return make([]int32, n)
+	}
+	s = s[:n]
+	clear(s)
+	return s
+}
+
+// Slide records that the first delta bytes were dropped from buf,
+// which now holds what remains.
+func (t *Table) Slide(buf []byte, delta int) {
+	t.base += int32(delta)
+	t.indexed = max(t.indexed-delta, 0)
+	if t.base > maxBase {
+		// Start again, indexing the remaining history.
+		indexed := t.indexed
+		clear(t.table)
+		clear(t.chain)
+		t.base = 1
+		t.indexed = 0
+		t.Index(buf, indexed)
+	}
+}
+
+// hash returns the hash table index for the 4 bytes at buf[i:].
+func (t *Table) hash(buf []byte, i int) uint32 {
+	return (binary.LittleEndian.Uint32(buf[i:]) * 0x9e3779b1) >> (32 - t.hashLog)
+}
+
+// Insert adds position i to the tables.
+func (t *Table) Insert(buf []byte, i int) {
+	h := t.hash(buf, i)
+	v := int32(i) + t.base
+	if len(t.chain) > 0 {
+		t.chain[v&int32(len(t.chain)-1)] = t.table[h]
+	}
+	t.table[h] = v
+}
+
+// Index adds the positions not yet indexed up to end to the tables.
+func (t *Table) Index(buf []byte, end int) {
+	end = min(end, len(buf)-MinMatch+1)
+	for i := t.indexed; i < end; i++ {
+		t.Insert(buf, i)
+	}
+	t.MarkIndexed(end)
+}
+
+// MarkIndexed records that the positions before end have been
+// inserted, or skipped on purpose.
+func (t *Table) MarkIndexed(end int) {
+	t.indexed = max(t.indexed, end)
+}
+
+// InsertMatch adds the positions inside a match of length n at buf[i:],
+// stopping Margin bytes before end. Without hash chains, it adds only a
+// couple, which finds most of the later matches.
+func (t *Table) InsertMatch(buf []byte, i, n, end int) {
+	matchEnd := i + n
+	if len(t.chain) == 0 {
+		for _, j := range [...]int{i + 1, matchEnd - 2} {
+			if j > i && j+Margin <= end {
+				t.Insert(buf, j)
+			}
+		}
+		return
+	}
+	for j := i + 1; j < matchEnd && j+Margin <= end; j++ {
+		t.Insert(buf, j)
+	}
+}
+
+// A Chain lists the positions with the same hash as some position,
+// most recent first.
+type Chain struct {
+	t *Table
+	v int32
+}
+
+// Chain returns the positions inserted so far with the same hash as
+// buf[i:]. They need not match, and may lie outside buf after a slide,
+// so the caller checks them.
+func (t *Table) Chain(buf []byte, i int) Chain {
+	return Chain{t, t.table[t.hash(buf, i)]}
+}
+
+// Next returns the next position on the chain, or false at its end.
+func (c *Chain) Next() (int, bool) {
+	v := c.v
+	if v <= 0 {
+		return 0, false
+	}
+	c.v = 0
+	if len(c.t.chain) > 0 {
+		// A chain entry that is not earlier was overwritten
+		// by a later position.
+		if next := c.t.chain[v&int32(len(c.t.chain)-1)]; next < v {
+			c.v = next
+		}
+	}
+	return int(v - c.t.base), true
+}
+
+// MatchLen returns the length of the common prefix of a and b.
+func MatchLen(a, b []byte) int {
+	n := 0
+	for len(a) >= 8 && len(b) >= 8 {
+		x := binary.LittleEndian.Uint64(a) ^ binary.LittleEndian.Uint64(b)
+		if x != 0 {
+			return n + bits.TrailingZeros64(x)/8
+		}
+		a, b, n = a[8:], b[8:], n+8
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return n + i
+		}
+	}
+	return n + min(len(a), len(b))
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+// bitWriter writes a bit stream that is read in reverse.
+// Bits are packed starting at the least significant bit of each byte.
+// The decoder starts at the end of the stream, so the last value
+// written is the first value read. RFC 4.1.
+type bitWriter struct {
+	out   []byte
+	bits  uint64 // pending bits, starting at the low bit
+	nbits uint   // number of valid bits in bits
+}
+
+// reset prepares to append a new bit stream to out.
+func (bw *bitWriter) reset(out []byte) {
+	bw.out = out
+	bw.bits = 0
+	bw.nbits = 0
+}
+
+// addBits adds the low n bits of v to the stream. n must be at most 32.
+func (bw *bitWriter) addBits(v uint32, n uint8) {
+	bw.bits |= uint64(v&(1<<n-1)) << bw.nbits
+	bw.nbits += uint(n)
+	if bw.nbits >= 32 {
+		bw.out = append(bw.out, byte(bw.bits), byte(bw.bits>>8), byte(bw.bits>>16), byte(bw.bits>>24))
+		bw.bits >>= 32
+		bw.nbits -= 32
+	}
+}
+
+// close adds the end marker, a single 1 bit, flushes the pending bits,
+// and returns the extended out slice.
+func (bw *bitWriter) close() []byte {
+	bw.addBits(1, 1)
+	for bw.nbits > 0 {
+		bw.out = append(bw.out, byte(bw.bits))
+		bw.bits >>= 8
+		if bw.nbits < 8 {
+			bw.nbits = 0
+		} else {
+			bw.nbits -= 8
+		}
+	}
+	return bw.out
+}
@@ -0,0 +1,425 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import "math"
+
+// maxBlockSize is the largest amount of data in a block. RFC 3.1.1.2.3.
+const maxBlockSize = 128 << 10
+
+// Block types. RFC 3.1.1.2.2.
+const (
+	blockRaw        = 0
+	blockRLE        = 1
+	blockCompressed = 2
+)
+
+// Literals block types. RFC 3.1.1.3.1.1.
+const (
+	literalsRaw        = 0
+	literalsRLE        = 1
+	literalsCompressed = 2
+)
+
+// Sequence table compression modes. RFC 3.1.1.3.2.1.1.
+const (
+	modePredefined = 0
+	modeRLE        = 1
+	modeFSE        = 2
+)
+
+// seq is a sequence: litLen literals followed by a match of
+// matchLen bytes. offBase is the Offset_Value: 1 to 3 for a repeat
+// offset, or the offset plus 3. RFC 3.1.1.3.2.1.
+type seq struct {
+	litLen   uint32
+	matchLen uint32
+	offBase  uint32
+}
+
+// Literal length codes. RFC 3.1.1.3.2.1.1.
+var (
+	llBase = [36]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512,
+		1024, 2048, 4096, 8192, 16384, 32768, 65536,
+	}
+	llBits = [36]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9,
+		10, 11, 12, 13, 14, 15, 16,
+	}
+)
+
+// Match length codes. RFC 3.1.1.3.2.1.1.
+var (
+	mlBase = [53]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515,
+		1027, 2051, 4099, 8195, 16387, 32771, 65539,
+	}
+	mlBits = [53]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9,
+		10, 11, 12, 13, 14, 15, 16,
+	}
+)
+
+// codeTable returns a table mapping small values to codes,
+// for values below the base of the first code with 5 or more extra bits.
+func codeTable(base []uint32, n int) []uint8 {
+	t := make([]uint8, n)
+	code := 0
+	for v := range t {
+		for code+1 < len(base) && base[code+1] <= uint32(v)+base[0] {
+			code++
+		}
+		t[v] = uint8(code)
+	}
+	return t
+}
+
+var (
+	llCodeTable = codeTable(llBase[:], 64)
+	mlCodeTable = codeTable(mlBase[:], 128)
+)
+
+// llCode returns the literal length code for ll.
+func llCode(ll uint32) uint8 {
+	if ll < 64 {
+		return llCodeTable[ll]
+	}
+	return highBit(ll) + 19
+}
+
+// mlCode returns the match length code for a match of ml bytes.
+func mlCode(ml uint32) uint8 {
+	if ml-3 < 128 {
+		return mlCodeTable[ml-3]
+	}
+	return highBit(ml-3) + 36
+}
+
+// seqCodeInfo describes the tables for one kind of sequence code.
+type seqCodeInfo struct {
+	predefNorm []int16 // predefined distribution
+	predefLog  uint8   // predefined accuracy log
+	maxSym     int     // largest symbol
+	maxLog     uint8   // largest accuracy log
+}
+
+// Kinds of sequence codes, in the order their tables appear.
+const (
+	seqLiteral = iota
+	seqOffset
+	seqMatch
+)
+
+// seqInfo holds the seqCodeInfo for each kind of sequence code.
+// The predefined distributions are from RFC 3.1.1.3.2.2.
+var seqInfo = [3]seqCodeInfo{
+	seqLiteral: {
+		predefNorm: []int16{
+			4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+			2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+			-1, -1, -1, -1,
+		},
+		predefLog: 6,
+		maxSym:    35,
+		maxLog:    9,
+	},
+	seqOffset: {
+		predefNorm: []int16{
+			1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+			1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+		},
+		predefLog: 5,
+		maxSym:    31,
+		maxLog:    8,
+	},
+	seqMatch: {
+		predefNorm: []int16{
+			1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+			1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+			1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+			-1, -1, -1, -1, -1,
+		},
+		predefLog: 6,
+		maxSym:    52,
+		maxLog:    9,
+	},
+}
+
+// predefTables are the encoding tables for the predefined distributions.
+var predefTables = func() (t [3]fseEncoder) {
+	for i := range t {
+		t[i].build(seqInfo[i].predefNorm, seqInfo[i].predefLog)
+	}
+	return t
+}()
+
+// blockEncoder encodes the literals and sequences of compressed blocks.
+type blockEncoder struct {
+	huff      huffmanEncoder
+	litCounts [256]uint32
+	litBuf    []byte
+
+	codes  [3][]uint8     // sequence codes for each kind
+	norms  [3][]int16     // scratch normalized counts
+	tables [3]fseEncoder  // custom and RLE tables
+	use    [3]*fseEncoder // tables used for the current block
+}
+
+// appendBlock appends a block holding src, compressed as lits and seqs,
+// to out. It falls back to a raw block if that is smaller.
+func (e *blockEncoder) appendBlock(out, src, lits []byte, seqs []seq, last bool) []byte {
+	start := len(out)
+	out = append(out, 0, 0, 0)
+	out = e.appendLiterals(out, lits)
+	out = e.appendSequences(out, seqs)
+	size := len(out) - start - 3
+	if size >= len(src) {
+		return appendRawBlock(out[:start], src, last)
+	}
+	return putBlockHeader(out, start, blockCompressed, size, last)
+}
+
+// appendRawBlock appends a raw block holding src to out.
+func appendRawBlock(out, src []byte, last bool) []byte {
+	start := len(out)
+	out = append(out, 0, 0, 0)
+	out = append(out, src...)
+	return putBlockHeader(out, start, blockRaw, len(src), last)
+}
+
+// appendRLEBlock appends a block of n copies of b to out.
+func appendRLEBlock(out []byte, b byte, n int, last bool) []byte {
+	start := len(out)
+	out = append(out, 0, 0, 0, b)
+	return putBlockHeader(out, start, blockRLE, n, last)
+}
+
+// putBlockHeader fills in the block header at out[start:]. RFC 3.1.1.2.
+func putBlockHeader(out []byte, start, typ, size int, last bool) []byte {
+	h := uint32(typ)<<1 | uint32(size)<<3
+	if last {
+		h |= 1
+	}
+	out[start] = byte(h)
+	out[start+1] = byte(h >> 8)
+	out[start+2] = byte(h >> 16)
+	return out
+}
+
+// appendLiterals appends the literals section for lits to out.
+// RFC 3.1.1.3.1.
+func (e *blockEncoder) appendLiterals(out, lits []byte) []byte {
+	n := len(lits)
+	if n == 0 {
+		return appendLiteralsHeader(out, literalsRaw, 0)
+	}
+
+	clear(e.litCounts[:])
+	for _, b := range lits {
+		e.litCounts[b]++
+	}
+	if e.litCounts[lits[0]] == uint32(n) {
+		out = appendLiteralsHeader(out, literalsRLE, n)
+		return append(out, lits[0])
+	}
+
+	// Huffman coding is not worth its table for a few literals.
+	const minHuffmanLiterals = 32
+	if n < minHuffmanLiterals {
+		return appendRawLiterals(out, lits)
+	}
+
+	e.huff.build(&e.litCounts)
+	streams := 1
+	if n >= 256 {
+		streams = 4
+	}
+	if e.huff.encodedSize(&e.litCounts)+(streams-1)*2+8 >= n {
+		return appendRawLiterals(out, lits)
+	}
+
+	buf, ok := e.huff.appendTable(e.litBuf[:0])
+	if !ok {
+		return appendRawLiterals(out, lits)
+	}
+	if streams == 1 {
+		buf = e.huff.encodeStream(buf, lits)
+	} else {
+		// Four streams, preceded by a jump table of the sizes of
+		// the first three. RFC 3.1.1.3.1.6.
+		jump := len(buf)
+		buf = append(buf, 0, 0, 0, 0, 0, 0)
+		segment := (n + 3) / 4
+		for i := 0; i < 4; i++ {
+			streamStart := len(buf)
+			buf = e.huff.encodeStream(buf, lits[i*segment:min((i+1)*segment, n)])
+			if i < 3 {
+				size := len(buf) - streamStart
+				buf[jump+2*i] = byte(size)
+				buf[jump+2*i+1] = byte(size >> 8)
+			}
+		}
+	}
+	e.litBuf = buf
+
+	compressed := len(buf)
+	if compressed >= n {
+		return appendRawLiterals(out, lits)
+	}
+
+	// Literals_Section_Header for a compressed block. RFC 3.1.1.3.1.1.
+	hdr := uint64(literalsCompressed)
+	switch {
+	case streams == 1:
+		hdr |= uint64(n)<<4 | uint64(compressed)<<14
+		out = append(out, byte(hdr), byte(hdr>>8), byte(hdr>>16))
+	case n < 1<<10 && compressed < 1<<10:
+		hdr |= 1<<2 | uint64(n)<<4 | uint64(compressed)<<14
+		out = append(out, byte(hdr), byte(hdr>>8), byte(hdr>>16))
+	case n < 1<<14 && compressed < 1<<14:
+		hdr |= 2<<2 | uint64(n)<<4 | uint64(compressed)<<18
+		out = append(out, byte(hdr), byte(hdr>>8), byte(hdr>>16), byte(hdr>>24))
+	default:
+		hdr |= 3<<2 | uint64(n)<<4 | uint64(compressed)<<22
+		out = append(out, byte(hdr), byte(hdr>>8), byte(hdr>>16), byte(hdr>>24), byte(hdr>>32))
+	}
+	return append(out, buf...)
+}
+
+// appendRawLiterals appends an uncompressed literals section.
+func appendRawLiterals(out, lits []byte) []byte {
+	out = appendLiteralsHeader(out, literalsRaw, len(lits))
+	return append(out, lits...)
+}
+
+// appendLiteralsHeader appends the Literals_Section_Header for a raw
+// or RLE literals section of n bytes. RFC 3.1.1.3.1.1.
+func appendLiteralsHeader(out []byte, typ, n int) []byte {
+	switch {
+	case n < 1<<5:
+		return append(out, byte(typ|n<<3))
+	case n < 1<<12:
+		h := typ | 1<<2 | n<<4
+		return append(out, byte(h), byte(h>>8))
+	default:
+		h := typ | 3<<2 | n<<4
+		return append(out, byte(h), byte(h>>8), byte(h>>16))
+	}
+}
+
+// appendSequences appends the sequences section for seqs to out.
+// RFC 3.1.1.3.2.
+func (e *blockEncoder) appendSequences(out []byte, seqs []seq) []byte {
+	n := len(seqs)
+	switch {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7f00:
+		out = append(out, byte(n>>8+128), byte(n))
+	default:
+		out = append(out, 255, byte(n-0x7f00), byte((n-0x7f00)>>8))
+	}
+	if n == 0 {
+		return out
+	}
+
+	var counts [3][53]uint32
+	for kind := range e.codes {
+		e.codes[kind] = e.codes[kind][:0]
+	}
+	for _, s := range seqs {
+		ll := llCode(s.litLen)
+		of := highBit(s.offBase)
+		ml := mlCode(s.matchLen)
+		e.codes[seqLiteral] = append(e.codes[seqLiteral], ll)
+		e.codes[seqOffset] = append(e.codes[seqOffset], of)
+		e.codes[seqMatch] = append(e.codes[seqMatch], ml)
+		counts[seqLiteral][ll]++
+		counts[seqOffset][of]++
+		counts[seqMatch][ml]++
+	}
+
+	modes := len(out)
+	out = append(out, 0)
+	for kind := range e.codes {
+		var mode byte
+		info := &seqInfo[kind]
+		out, mode = e.chooseTable(out, kind, counts[kind][:info.maxSym+1], n)
+		out[modes] |= mode << (6 - 2*kind)
+	}
+
+	// The decoder reads the initial states, then for each sequence the
+	// offset, match length and literal length extra bits, followed by
+	// the literal length, match length and offset state updates,
+	// except after the last sequence. Encode backward from the end.
+	llt, oft, mlt := e.use[seqLiteral], e.use[seqOffset], e.use[seqMatch]
+	llc, ofc, mlc := e.codes[seqLiteral], e.codes[seqOffset], e.codes[seqMatch]
+	var bw bitWriter
+	bw.reset(out)
+	last := n - 1
+	mlState := uint32(mlt.symbols[mlc[last]].initState)
+	ofState := uint32(oft.symbols[ofc[last]].initState)
+	llState := uint32(llt.symbols[llc[last]].initState)
+	for i := last; i >= 0; i-- {
+		s := &seqs[i]
+		if i < last {
+			oft.encode(&bw, &ofState, ofc[i])
+			mlt.encode(&bw, &mlState, mlc[i])
+			llt.encode(&bw, &llState, llc[i])
+		}
+		bw.addBits(s.litLen-llBase[llc[i]], llBits[llc[i]])
+		bw.addBits(s.matchLen-mlBase[mlc[i]], mlBits[mlc[i]])
+		bw.addBits(s.offBase-1<<ofc[i], ofc[i])
+	}
+	mlt.flush(&bw, mlState)
+	oft.flush(&bw, ofState)
+	llt.flush(&bw, llState)
+	return bw.close()
+}
+
+// chooseTable picks the cheapest way to encode the sequence codes
+// of the given kind, counted in counts, and appends its description
+// to out. It sets e.use[kind] and returns the compression mode.
+func (e *blockEncoder) chooseTable(out []byte, kind int, counts []uint32, n int) ([]byte, byte) {
+	info := &seqInfo[kind]
+	maxSym := 0
+	for sym, c := range counts {
+		if c > 0 {
+			maxSym = sym
+		}
+	}
+	if int(counts[maxSym]) == n {
+		// A single symbol needs no bits at all.
+		norm := append(e.norms[kind][:0], make([]int16, maxSym+1)...)
+		norm[maxSym] = 1
+		e.norms[kind] = norm
+		e.tables[kind].build(norm, 0)
+		e.use[kind] = &e.tables[kind]
+		return append(out, byte(maxSym)), modeRLE
+	}
+
+	predefCost := bitCost(counts, info.predefNorm, info.predefLog)
+
+	tableLog := optimalTableLog(info.maxLog, n, maxSym)
+	e.norms[kind] = normalizeCounts(e.norms[kind], counts[:maxSym+1], n, tableLog)
+	start := len(out)
+	out = writeNCount(out, e.norms[kind], tableLog)
+	customCost := bitCost(counts, e.norms[kind], tableLog) + float64(8*(len(out)-start))
+
+	if predefCost <= customCost && !math.IsInf(predefCost, 1) {
+		e.use[kind] = &predefTables[kind]
+		return out[:start], modePredefined
+	}
+	e.tables[kind].build(e.norms[kind], tableLog)
+	e.use[kind] = &e.tables[kind]
+	return out, modeFSE
+}
@@ -0,0 +1,299 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// fseEncoder is a table for encoding symbols with FSE. RFC 4.1.
+//
+// An encoder state is a value in [1<<tableLog, 2<<tableLog);
+// it is the decoder state plus 1<<tableLog.
+type fseEncoder struct {
+	tableLog uint8
+	states   []uint16         // next states, grouped by symbol
+	symbols  []fseSymbolTrans // indexed by symbol
+}
+
+// fseSymbolTrans describes how to encode a symbol.
+type fseSymbolTrans struct {
+	deltaNbBits    uint32 // added to the state; the high 16 bits are the bits to write
+	deltaFindState int32  // added to the shifted state to index states
+	initState      uint16 // a state for the symbol, used for the last symbol
+}
+
+// build builds the encoding table for the normalized counts in norm,
+// which must sum to 1<<tableLog. A count of -1 is a symbol with a
+// probability below 1 that uses a single state.
+// The table layout must match the one built by the decoder.
+func (e *fseEncoder) build(norm []int16, tableLog uint8) {
+	tableSize := 1 << tableLog
+	mask := tableSize - 1
+	highThreshold := tableSize - 1
+
+	var symbolBuf [1 << maxTableLog]uint8
+	tableSymbol := symbolBuf[:tableSize]
+	var cumul [257]int
+	for i, n := range norm {
+		if n == -1 {
+			cumul[i+1] = cumul[i] + 1
+			tableSymbol[highThreshold] = uint8(i)
+			highThreshold--
+		} else {
+			cumul[i+1] = cumul[i] + int(n)
+		}
+	}
+
+	// Spread the symbols in the same way as the decoder.
+	pos := 0
+	step := (tableSize >> 1) + (tableSize >> 3) + 3
+	for i, n := range norm {
+		for j := 0; j < int(n); j++ {
+			tableSymbol[pos] = uint8(i)
+			pos = (pos + step) & mask
+			for pos > highThreshold {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+
+	e.tableLog = tableLog
+	if cap(e.states) < tableSize {
+		e.states = make([]uint16, tableSize)
+	}
+	e.states = e.states[:tableSize]
+	if cap(e.symbols) < len(norm) {
+		e.symbols = make([]fseSymbolTrans, len(norm))
+	}
+	e.symbols = e.symbols[:len(norm)]
+
+	// The decoder gives the states of each symbol increasing next
+	// state values in table order, so list them in the same order.
+	next := cumul
+	for u, sym := range tableSymbol {
+		e.states[next[sym]] = uint16(tableSize + u)
+		next[sym]++
+	}
+
+	for i, n := range norm {
+		st := &e.symbols[i]
+		switch n {
+		case 0:
+			*st = fseSymbolTrans{}
+			continue
+		case -1, 1:
+			st.deltaNbBits = uint32(tableLog)<<16 - uint32(tableSize)
+			st.deltaFindState = int32(cumul[i] - 1)
+		default:
+			maxBitsOut := uint32(tableLog) - uint32(bits.Len16(uint16(n-1))-1)
+			minStatePlus := uint32(n) << maxBitsOut
+			st.deltaNbBits = maxBitsOut<<16 - minStatePlus
+			st.deltaFindState = int32(cumul[i] - int(n))
+		}
+		// The first state of a symbol reads at least one bit
+		// unless the symbol has every state.
+		st.initState = e.states[cumul[i]]
+	}
+}
+
+// encode writes the bits that let the decoder move from a state for sym
+// to the current state, and updates state to that state for sym.
+func (e *fseEncoder) encode(bw *bitWriter, state *uint32, sym uint8) {
+	st := &e.symbols[sym]
+	nbBits := (*state + st.deltaNbBits) >> 16
+	bw.addBits(*state, uint8(nbBits))
+	*state = uint32(e.states[int32(*state>>nbBits)+st.deltaFindState])
+}
+
+// flush writes the final state, which the decoder reads first.
+func (e *fseEncoder) flush(bw *bitWriter, state uint32) {
+	bw.addBits(state, e.tableLog)
+}
+
+// bitCost returns the approximate cost in bits of encoding
+// the symbols counted in counts with the normalized counts in norm.
+// It returns +Inf if some counted symbol has no probability.
+func bitCost(counts []uint32, norm []int16, tableLog uint8) float64 {
+	cost := 0.0
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if i >= len(norm) || norm[i] == 0 {
+			return math.Inf(1)
+		}
+		p := float64(norm[i])
+		if p < 0 {
+			p = 1
+		}
+		cost += float64(c) * (float64(tableLog) - math.Log2(p))
+	}
+	return cost
+}
+
+// optimalTableLog returns the accuracy log to use for an FSE table of
+// total symbols with largest symbol maxSym, limited to maxLog.
+func optimalTableLog(maxLog uint8, total int, maxSym int) uint8 {
+	log := bits.Len(uint(total-1)) - 2
+	if log > int(maxLog) {
+		log = int(maxLog)
+	}
+	minLog := min(bits.Len(uint(total-1))+1, bits.Len(uint(maxSym))+2)
+	if log < minLog {
+		log = minLog
+	}
+	return uint8(max(min(log, int(maxLog)), minTableLog))
+}
+
+// The smallest and largest accuracy logs of the tables we build.
+const (
+	minTableLog = 5
+	maxTableLog = 9
+)
+
+// normalizeCounts scales counts, which sum to total, to sum to 1<<tableLog,
+// keeping every present symbol. It appends the result to norm.
+// The number of present symbols must be at most 1<<tableLog.
+func normalizeCounts(norm []int16, counts []uint32, total int, tableLog uint8) []int16 {
+	tableSize := 1 << tableLog
+	norm = norm[:0]
+
+	// Use the largest remainder method, giving each present
+	// symbol a count of at least 1.
+	type rem struct {
+		sym  int
+		frac uint64
+	}
+	var remBuf [256]rem
+	rems := remBuf[:0]
+	sum := 0
+	for i, c := range counts {
+		if c == 0 {
+			norm = append(norm, 0)
+			continue
+		}
+		scaled := uint64(c) << tableLog
+		n := int(scaled / uint64(total))
+		if n == 0 {
+			n = 1
+		} else {
+			rems = append(rems, rem{i, scaled % uint64(total)})
+		}
+		norm = append(norm, int16(n))
+		sum += n
+	}
+
+	if sum < tableSize {
+		slices.SortFunc(rems, func(a, b rem) int {
+			if a.frac != b.frac {
+				if a.frac > b.frac {
+					return -1
+				}
+				return 1
+			}
+			return a.sym - b.sym
+		})
+		for i := 0; sum < tableSize; i++ {
+			norm[rems[i%len(rems)].sym]++
+			sum++
+		}
+	}
+
+	// Giving rare symbols a count of 1 may have overshot the total.
+	// Take the excess from the symbols with the largest counts,
+	// where the relative change is smallest.
+	for sum > tableSize {
+		largest := 0
+		for i, n := range norm {
+			if n > norm[largest] {
+				largest = i
+			}
+		}
+		norm[largest]--
+		sum--
+	}
+
+	return norm
+}
+
+// writeNCount appends the FSE table description of norm to out.
+// RFC 4.1.1.
+func writeNCount(out []byte, norm []int16, tableLog uint8) []byte {
+	var bitStream uint64
+	var bitCount uint
+
+	flush := func() {
+		for bitCount >= 8 {
+			out = append(out, byte(bitStream))
+			bitStream >>= 8
+			bitCount -= 8
+		}
+	}
+
+	bitStream = uint64(tableLog - minTableLog)
+	bitCount = 4
+
+	tableSize := 1 << tableLog
+	remaining := tableSize + 1
+	threshold := tableSize
+	nbBits := uint(tableLog) + 1
+	previous0 := false
+	sym := 0
+	for remaining > 1 {
+		if previous0 {
+			// Encode a run of zero counts in 2-bit repeat flags.
+			start := sym
+			for norm[sym] == 0 {
+				sym++
+			}
+			for sym >= start+24 {
+				start += 24
+				bitStream |= 0xffff << bitCount
+				bitCount += 16
+				flush()
+			}
+			for sym >= start+3 {
+				start += 3
+				bitStream |= 3 << bitCount
+				bitCount += 2
+			}
+			bitStream |= uint64(sym-start) << bitCount
+			bitCount += 2
+			flush()
+		}
+
+		count := int(norm[sym])
+		sym++
+		max := 2*threshold - 1 - remaining
+		if count < 0 {
+			remaining += count
+		} else {
+			remaining -= count
+		}
+		count++
+		if count >= threshold {
+			count += max
+		}
+		bitStream |= uint64(count) << bitCount
+		bitCount += nbBits
+		if count < max {
+			bitCount--
+		}
+		previous0 = count == 1
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+		flush()
+	}
+
+	if bitCount > 0 {
+		out = append(out, byte(bitStream))
+	}
+	return out
+}
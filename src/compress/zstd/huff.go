@@ -0,0 +1,180 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"compress/internal/huffman"
+	"math/bits"
+)
+
+// maxHuffmanBits is the largest code length a decoder must support.
+const maxHuffmanBits = 11
+
+// huffmanEncoder holds a Huffman code for literals. RFC 4.2.
+type huffmanEncoder struct {
+	codes   [256]uint16 // code for each symbol
+	lens    [256]uint8  // code length for each symbol, 0 if absent
+	maxBits uint8       // longest code length
+	maxSym  int         // largest symbol with a code
+
+	// Scratch space for building the code.
+	hb    huffman.Builder
+	wfse  fseEncoder
+	wnorm []int16
+}
+
+// build builds a length limited Huffman code for the symbols counted
+// in counts. There must be at least two symbols.
+func (h *huffmanEncoder) build(counts *[256]uint32) {
+	h.maxSym = 0
+	h.maxBits = 0
+	for _, sym := range h.hb.Lengths(h.lens[:], counts[:], maxHuffmanBits) {
+		h.maxSym = max(h.maxSym, sym)
+		h.maxBits = max(h.maxBits, h.lens[sym])
+	}
+	h.assignCodes()
+}
+
+// assignCodes assigns codes from the lengths in the way that the
+// decoder builds its table: symbols with the longest codes come first,
+// and symbols with the same length are in increasing order.
+func (h *huffmanEncoder) assignCodes() {
+	var start [maxHuffmanBits + 2]uint32
+	var count [maxHuffmanBits + 2]uint32
+	for _, l := range h.lens[:h.maxSym+1] {
+		if l > 0 {
+			count[h.maxBits+1-l]++
+		}
+	}
+	next := uint32(0)
+	for w := 1; w <= int(h.maxBits); w++ {
+		start[w] = next
+		next += count[w] << (w - 1)
+	}
+	for sym, l := range h.lens[:h.maxSym+1] {
+		if l == 0 {
+			continue
+		}
+		w := h.maxBits + 1 - l
+		h.codes[sym] = uint16(start[w] >> (w - 1))
+		start[w] += 1 << (w - 1)
+	}
+}
+
+// encodedSize returns the size in bytes of the Huffman coded streams
+// for symbols counted in counts, not counting any jump table.
+func (h *huffmanEncoder) encodedSize(counts *[256]uint32) int {
+	n := 0
+	for i, c := range counts[:h.maxSym+1] {
+		n += int(c) * int(h.lens[i])
+	}
+	return (n + 7) / 8
+}
+
+// appendTable appends the Huffman tree description to out.
+// It returns false if the description can't be written. RFC 4.2.1.
+func (h *huffmanEncoder) appendTable(out []byte) ([]byte, bool) {
+	// The weight of the last symbol is implied.
+	var weightBuf [256]uint8
+	weights := weightBuf[:h.maxSym]
+	for i := range weights {
+		if h.lens[i] > 0 {
+			weights[i] = h.maxBits + 1 - h.lens[i]
+		}
+	}
+
+	// Use FSE compressed weights if they fit and are smaller,
+	// or if there are too many weights to write directly.
+	start := len(out)
+	directSize := (len(weights) + 1) / 2
+	out = append(out, 0)
+	out, ok := h.appendFSEWeights(out, weights)
+	if size := len(out) - start - 1; ok && size < 128 && (size < directSize || len(weights) > 128) {
+		out[start] = byte(size)
+		return out, true
+	}
+	out = out[:start]
+
+	if len(weights) > 128 {
+		return out, false
+	}
+	out = append(out, byte(127+len(weights)))
+	for i := 0; i < len(weights); i += 2 {
+		b := weights[i] << 4
+		if i+1 < len(weights) {
+			b |= weights[i+1]
+		}
+		out = append(out, b)
+	}
+	return out, true
+}
+
+// appendFSEWeights appends the Huffman weights compressed with FSE.
+// RFC 4.2.1.2.
+func (h *huffmanEncoder) appendFSEWeights(out []byte, weights []uint8) ([]byte, bool) {
+	if len(weights) < 2 {
+		return out, false
+	}
+	var counts [maxHuffmanBits + 1]uint32
+	maxWeight := 0
+	for _, w := range weights {
+		counts[w]++
+		maxWeight = max(maxWeight, int(w))
+	}
+	for _, c := range counts {
+		if int(c) == len(weights) {
+			// A single weight has no FSE description.
+			return out, false
+		}
+	}
+
+	const maxWeightTableLog = 6
+	tableLog := optimalTableLog(maxWeightTableLog, len(weights), maxWeight)
+	h.wnorm = normalizeCounts(h.wnorm, counts[:maxWeight+1], len(weights), tableLog)
+	out = writeNCount(out, h.wnorm, tableLog)
+	h.wfse.build(h.wnorm, tableLog)
+
+	// Two interleaved states, decoded alternately starting with
+	// the first. Encode backward from the end.
+	var bw bitWriter
+	bw.reset(out)
+	n := len(weights)
+	var state1, state2 uint32
+	if n%2 == 1 {
+		state1 = uint32(h.wfse.symbols[weights[n-1]].initState)
+		state2 = uint32(h.wfse.symbols[weights[n-2]].initState)
+		h.wfse.encode(&bw, &state1, weights[n-3])
+		n -= 3
+	} else {
+		state2 = uint32(h.wfse.symbols[weights[n-1]].initState)
+		state1 = uint32(h.wfse.symbols[weights[n-2]].initState)
+		n -= 2
+	}
+	for ; n > 0; n -= 2 {
+		h.wfse.encode(&bw, &state2, weights[n-1])
+		h.wfse.encode(&bw, &state1, weights[n-2])
+	}
+	h.wfse.flush(&bw, state2)
+	h.wfse.flush(&bw, state1)
+	return bw.close(), true
+}
+
+// encodeStream appends the Huffman coded literals in lits to out
+// as a single stream. The decoder reads the stream backward,
+// so the literals are encoded from last to first.
+func (h *huffmanEncoder) encodeStream(out []byte, lits []byte) []byte {
+	var bw bitWriter
+	bw.reset(out)
+	for i := len(lits) - 1; i >= 0; i-- {
+		b := lits[i]
+		bw.addBits(uint32(h.codes[b]), h.lens[b])
+	}
+	return bw.close()
+}
+
+// highBit returns the index of the highest set bit of v, which must not be 0.
+func highBit(v uint32) uint8 {
+	return uint8(bits.Len32(v) - 1)
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"compress/internal/lz"
+	"encoding/binary"
+)
+
+// levels holds the parameters for each compression level.
+var levels = [BestCompression + 1]lz.Params{
+	1: {WindowLog: 19, HashLog: 15, ChainLog: 0, Depth: 1, Lazy: 0, Nice: 16},
+	2: {WindowLog: 20, HashLog: 16, ChainLog: 16, Depth: 2, Lazy: 0, Nice: 32},
+	3: {WindowLog: 21, HashLog: 17, ChainLog: 16, Depth: 4, Lazy: 1, Nice: 32},
+	4: {WindowLog: 21, HashLog: 17, ChainLog: 17, Depth: 8, Lazy: 1, Nice: 64},
+	5: {WindowLog: 22, HashLog: 17, ChainLog: 18, Depth: 16, Lazy: 1, Nice: 96},
+	6: {WindowLog: 22, HashLog: 18, ChainLog: 18, Depth: 32, Lazy: 2, Nice: 128},
+	7: {WindowLog: 22, HashLog: 18, ChainLog: 19, Depth: 64, Lazy: 2, Nice: 256},
+	8: {WindowLog: 23, HashLog: 18, ChainLog: 20, Depth: 128, Lazy: 2, Nice: 512},
+	9: {WindowLog: 23, HashLog: 18, ChainLog: 20, Depth: 256, Lazy: 2, Nice: 1024},
+}
+
+// repeats tracks the repeat offsets as the decoder will see them.
+// When blocks are compressed concurrently, a block starts without
+// knowing the offsets left by the previous block, so each offset
+// also records whether it is known. RFC 3.1.1.5.
+type repeats struct {
+	off   [3]uint32
+	known [3]bool
+}
+
+// reset sets the repeat offsets to offs, all known.
+func (r *repeats) reset(offs [3]uint32) {
+	r.off = offs
+	r.known = [3]bool{true, true, true}
+}
+
+// forget marks all repeat offsets as unknown.
+func (r *repeats) forget() {
+	r.known = [3]bool{}
+}
+
+// offBase returns the Offset_Value for a match at offset off after
+// litLen literals, and updates the repeat offsets as the decoder does.
+func (r *repeats) offBase(off, litLen uint32) uint32 {
+	// Find the repeat code, numbered as the decoder does after
+	// adjusting for no literals. RFC 3.1.1.5.
+	code := uint32(0)
+	switch {
+	case litLen > 0 && r.known[0] && off == r.off[0]:
+		code = 1
+	case r.known[1] && off == r.off[1]:
+		code = 2
+		r.use(1)
+	case r.known[2] && off == r.off[2]:
+		code = 3
+		r.use(2)
+	case litLen == 0 && r.known[0] && off == r.off[0]-1:
+		code = 4
+		r.push(off)
+	default:
+		r.push(off)
+		return off + 3
+	}
+	if litLen == 0 {
+		code--
+	}
+	return code
+}
+
+// use moves repeat offset i to the front.
+func (r *repeats) use(i int) {
+	off, known := r.off[i], r.known[i]
+	copy(r.off[1:i+1], r.off[:i])
+	copy(r.known[1:i+1], r.known[:i])
+	r.off[0], r.known[0] = off, known
+}
+
+// push makes off the most recent repeat offset.
+func (r *repeats) push(off uint32) {
+	r.off[2], r.off[1], r.off[0] = r.off[1], r.off[0], off
+	r.known[2], r.known[1], r.known[0] = r.known[1], r.known[0], true
+}
+
+// matcher finds matches using the hash chains in t.
+type matcher struct {
+	p lz.Params
+	t lz.Table
+
+	// The current repeat offsets.
+	reps repeats
+
+	// Output for the current block.
+	seqs []seq
+	lits []byte
+}
+
+// reset prepares the matcher for a new buffer. Data up to sizeHint
+// bytes long can use smaller tables; 0 means the size is unknown.
+func (m *matcher) reset(p lz.Params, sizeHint int) {
+	m.p = p
+	m.t.Reset(&p, sizeHint)
+}
+
+// find returns the longest match for buf[i:end], looking no further
+// back than low, or a length of 0 if there is none.
+func (m *matcher) find(buf []byte, i, end, low int) (length, pos int) {
+	src := buf[i:end]
+	ch := m.t.Chain(buf, i)
+	for depth := m.p.Depth; depth > 0; depth-- {
+		c, ok := ch.Next()
+		if !ok || c < low || c >= i {
+			break
+		}
+		// Check the byte that would make the match longer first.
+		if length < len(src) && buf[c+length] == src[length] {
+			if n := lz.MatchLen(buf[c:c+len(src)], src); n > length {
+				length, pos = n, c
+				if n >= m.p.Nice || n == len(src) {
+					break
+				}
+			}
+		}
+	}
+	if length < lz.MinMatch {
+		return 0, 0
+	}
+	return length, pos
+}
+
+// compress finds matches for buf[start:end], where buf[:start] is
+// history, appending to m.seqs and m.lits. Matches reach back at most
+// maxOffset bytes.
+func (m *matcher) compress(buf []byte, start, end, maxOffset int) {
+	m.seqs = m.seqs[:0]
+	m.lits = m.lits[:0]
+	m.t.Index(buf, start)
+
+	litStart := start
+	i := start
+	misses := 0
+	for i+lz.Margin <= end {
+		low := max(i-maxOffset, 0)
+
+		// Cheaply check the most recent offset first.
+		length, pos := 0, 0
+		if r := int(m.reps.off[0]); m.reps.known[0] && i > litStart && i-r >= low && r > 0 {
+			if binary.LittleEndian.Uint32(buf[i-r:]) == binary.LittleEndian.Uint32(buf[i:]) {
+				length, pos = lz.MatchLen(buf[i-r:end], buf[i:end]), i-r
+			}
+		}
+		if length < m.p.Nice {
+			if n, p := m.find(buf, i, end, low); n > length {
+				length, pos = n, p
+			}
+		}
+		m.t.Insert(buf, i)
+
+		if length == 0 {
+			// Skip ahead faster through data that doesn't match.
+			misses++
+			i += m.p.Step(misses)
+			continue
+		}
+		misses = 0
+
+		// Look for a longer match at the following positions.
+		for k := 0; k < m.p.Lazy && length < m.p.Nice && i+1+lz.Margin <= end; k++ {
+			n, p := m.find(buf, i+1, end, max(i+1-maxOffset, 0))
+			if n <= length {
+				break
+			}
+			m.t.Insert(buf, i+1)
+			i++
+			length, pos = n, p
+		}
+
+		// Extend the match backward into the literals.
+		for i > litStart && pos > low && buf[i-1] == buf[pos-1] {
+			i--
+			pos--
+			length++
+		}
+
+		litLen := uint32(i - litStart)
+		m.lits = append(m.lits, buf[litStart:i]...)
+		m.seqs = append(m.seqs, seq{
+			litLen:   litLen,
+			matchLen: uint32(length),
+			offBase:  m.reps.offBase(uint32(i-pos), litLen),
+		})
+
+		m.t.InsertMatch(buf, i, length, end)
+		i += length
+		litStart = i
+	}
+
+	m.lits = append(m.lits, buf[litStart:end]...)
+	m.t.MarkIndexed(min(i, end-lz.Margin+1))
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package zstd implements reading and writing of zstd compressed data,
+// as specified in RFC 8878.
+//
+// The Reader keeps at most 8 MiB of history, the minimum that
+// RFC 8878 requires decoders to support. Streams with matches
+// that reach back further than that are rejected.
+package zstd
+
+import (
+	izstd "internal/zstd"
+	"io"
+)
+
+// A Reader is an io.Reader that can be read to retrieve
+// uncompressed data from a zstd compressed stream.
+//
+// A stream may hold several concatenated frames, which are read
+// as a single stream of data. Skippable frames are ignored.
+//
+// Data read from a Reader is checked against the frame's content
+// checksum, if any, when the end of the frame is reached.
+type Reader struct {
+	r *izstd.Reader
+}
+
+// NewReader creates a new Reader reading the given reader.
+// The Reader may read more data than necessary from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: izstd.NewReader(r)}
+}
+
+// NewReaderDict is like NewReader but decompresses frames that were
+// compressed with the given dictionary. The dictionary is either raw
+// content or a dictionary in the zstd format; see NewWriterLevelDict.
+// Frames that name a different dictionary are rejected.
+//
+// The dictionary must not be modified while the Reader is in use.
+func NewReaderDict(r io.Reader, dict []byte) (*Reader, error) {
+	d, err := izstd.ParseDict(dict)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{r: izstd.NewReaderDict(r, d)}, nil
+}
+
+// Reset discards the Reader z's state and makes it equivalent to the
+// result of its original state from NewReader or NewReaderDict,
+// but reading from r instead. Any dictionary is kept.
+func (z *Reader) Reset(r io.Reader) {
+	z.r.Reset(r)
+}
+
+// Read reads uncompressed data from the underlying stream.
+func (z *Reader) Read(p []byte) (int, error) {
+	return z.r.Read(p)
+}
+
+// ReadByte reads a single uncompressed byte.
+func (z *Reader) ReadByte() (byte, error) {
+	return z.r.ReadByte()
+}
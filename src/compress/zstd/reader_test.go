@@ -0,0 +1,351 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"internal/testenv"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testdata = "../../internal/zstd/testdata"
+
+func readTestdata(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(testdata, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// TestReaderTestdata decompresses files written by the zstd tool.
+// Each file name starts with a prefix of the SHA-256 hash of its content.
+func TestReaderTestdata(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(testdata, "*.zst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no testdata files")
+	}
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(name, func(t *testing.T) {
+			got := decompress(t, readTestdata(t, name), nil)
+			sum := fmt.Sprintf("%x", sha256.Sum256(got))
+			if want, _, _ := strings.Cut(name, "."); !strings.HasPrefix(sum, want) {
+				t.Errorf("got hash %s, want prefix %s", sum, want)
+			}
+		})
+	}
+}
+
+// TestReaderTestdataDict decompresses files written by the zstd tool
+// with a trained dictionary and with a raw content dictionary.
+func TestReaderTestdataDict(t *testing.T) {
+	want := readTestdata(t, "dict/input.json")
+	tests := []struct {
+		compressed, dict string
+	}{
+		{"input.json.zst", "json.dict"},
+		{"noid.zst", "json.dict"},
+		{"raw.zst", "raw.dict"},
+	}
+	for _, test := range tests {
+		data := readTestdata(t, "dict/"+test.compressed)
+		dict := readTestdata(t, "dict/"+test.dict)
+		if got := decompress(t, data, dict); !bytes.Equal(got, want) {
+			t.Errorf("%s: decompressed data does not match", test.compressed)
+		}
+	}
+}
+
+func TestReaderDictErrors(t *testing.T) {
+	data := readTestdata(t, "dict/input.json.zst")
+	tests := []struct {
+		name string
+		dict []byte
+		err  string
+	}{
+		{"missing", nil, "missing dictionary"},
+		{"wrong", readTestdata(t, "dict/json.dict")[:8:8], "invalid dictionary"},
+		{"raw", readTestdata(t, "dict/raw.dict"), "wrong dictionary"},
+	}
+	for _, test := range tests {
+		var err error
+		if test.dict == nil {
+			_, err = io.ReadAll(NewReader(bytes.NewReader(data)))
+		} else {
+			var r *Reader
+			r, err = NewReaderDict(bytes.NewReader(data), test.dict)
+			if err == nil {
+				_, err = io.ReadAll(r)
+			}
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %v, want %q", test.name, err, test.err)
+		}
+	}
+}
+
+// TestReaderTool decompresses the output of the zstd tool
+// for a range of its options.
+func TestReaderTool(t *testing.T) {
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("skipping because zstd not found")
+	}
+	testenv.MustHaveExec(t)
+
+	options := []struct {
+		args []string
+		dict string
+	}{
+		{[]string{"--fast=3"}, ""},
+		{[]string{"-1"}, ""},
+		{[]string{"-3", "--no-check"}, ""},
+		{[]string{"-19"}, ""},
+		{[]string{"--ultra", "-22"}, ""},
+		{[]string{"-3"}, "json.dict"},
+		{[]string{"-19", "--no-dictID"}, "json.dict"},
+		{[]string{"-3"}, "raw.dict"},
+	}
+	inputs := testInputs(t)
+	for _, name := range []string{"empty", "short", "zeros", "random", "text", "mixed"} {
+		data := inputs[name]
+		for _, opts := range options {
+			args := append([]string{"-q", "-c"}, opts.args...)
+			var dict []byte
+			if opts.dict != "" {
+				dict = readTestdata(t, "dict/"+opts.dict)
+				args = append(args, "-D", filepath.Join(testdata, "dict", opts.dict))
+			}
+			cmd := exec.Command(zstd, args...)
+			cmd.Stdin = bytes.NewReader(data)
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%s: zstd %v: %v", name, args, err)
+			}
+			if got := decompress(t, out, dict); !bytes.Equal(got, data) {
+				t.Errorf("%s: zstd %v: decompressed data does not match", name, args)
+			}
+		}
+	}
+}
+
+// skippable returns a skippable frame holding data.
+func skippable(magic byte, data string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, 0x184d2a50|uint32(magic))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+func TestReaderSkippable(t *testing.T) {
+	hello := compress(t, []byte("hello, "), DefaultCompression, 1, nil)
+	world := compress(t, []byte("world"), DefaultCompression, 1, nil)
+	join := func(frames ...[]byte) []byte { return bytes.Join(frames, nil) }
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"before", join(skippable(0, "abc"), hello), "hello, "},
+		{"after", join(hello, skippable(0xf, "abc")), "hello, "},
+		{"between", join(hello, skippable(3, "xyz"), skippable(4, ""), world), "hello, world"},
+		{"only", skippable(0, "abc"), ""},
+		{"concatenated", join(hello, world, hello), "hello, worldhello, "},
+	}
+	for _, test := range tests {
+		// A bytes.Reader skips with Seek, a plain io.Reader by reading.
+		for _, r := range []io.Reader{bytes.NewReader(test.data), io.MultiReader(bytes.NewReader(test.data))} {
+			got, err := io.ReadAll(NewReader(r))
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+			} else if string(got) != test.want {
+				t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+			}
+		}
+	}
+
+	// A skippable frame must be complete.
+	data := join(hello, skippable(0, "abcdef"))
+	for _, r := range []io.Reader{bytes.NewReader(data[:len(data)-1]), io.MultiReader(bytes.NewReader(data[:len(data)-1]))} {
+		if _, err := io.ReadAll(NewReader(r)); err == nil {
+			t.Error("truncated skippable frame: got no error")
+		}
+	}
+}
+
+func TestReaderChecksum(t *testing.T) {
+	data := testInputs(t)["text"]
+	c := compress(t, data, DefaultCompression, 1, nil)
+	for i := 1; i <= 4; i++ {
+		bad := bytes.Clone(c)
+		bad[len(bad)-i] ^= 0x10
+		_, err := io.ReadAll(NewReader(bytes.NewReader(bad)))
+		if err == nil || !strings.Contains(err.Error(), "checksum") {
+			t.Errorf("checksum byte %d changed: got error %v, want checksum error", 4-i, err)
+		}
+	}
+
+	// A change to the content is caught by the checksum, if by nothing else.
+	short := compress(t, []byte("hello, world\n"), DefaultCompression, 1, nil)
+	i := bytes.Index(short, []byte("hello"))
+	if i < 0 {
+		t.Fatal("literal content not found in frame")
+	}
+	short[i] = 'j'
+	_, err := io.ReadAll(NewReader(bytes.NewReader(short)))
+	if err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("content changed: got error %v, want checksum error", err)
+	}
+}
+
+func TestReaderTruncated(t *testing.T) {
+	inputs := testInputs(t)
+	for _, name := range []string{"short", "textshort"} {
+		c := compress(t, inputs[name], DefaultCompression, 1, nil)
+		for n := range len(c) {
+			_, err := io.ReadAll(NewReader(bytes.NewReader(c[:n])))
+			if err == nil {
+				t.Errorf("%s: truncated to %d of %d bytes: got no error", name, n, len(c))
+			}
+		}
+	}
+
+	// Larger inputs are cut at a few places, including within blocks.
+	c := compress(t, inputs["mixed"], DefaultCompression, 1, nil)
+	for _, n := range []int{4, 6, len(c) / 3, len(c) / 2, len(c) - 5, len(c) - 1} {
+		_, err := io.ReadAll(NewReader(bytes.NewReader(c[:n])))
+		if err == nil {
+			t.Errorf("mixed: truncated to %d of %d bytes: got no error", n, len(c))
+		}
+	}
+}
+
+// TestReaderWindow checks that the Reader accepts frames that
+// declare a window larger than 8 MiB, as long as no match reaches
+// back further than that, and rejects those that do.
+func TestReaderWindow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("skipping because zstd not found")
+	}
+	testenv.MustHaveExec(t)
+
+	r := rand.New(rand.NewSource(1))
+	repeat := func(n int) []byte {
+		data := make([]byte, n, n+1<<20)
+		r.Read(data)
+		return append(data, data[:1<<20]...)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		ok   bool
+	}{
+		{"near", repeat(7 << 20), true},
+		{"far", repeat(9 << 20), false},
+	}
+	for _, test := range tests {
+		// Without a known content size, the tool writes
+		// a frame with a 16 MiB window.
+		cmd := exec.Command(zstd, "-q", "-c", "--long=24")
+		cmd.Stdin = bytes.NewReader(test.data)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%s: zstd: %v", test.name, err)
+		}
+		if len(out) > len(test.data)-1<<19 {
+			t.Fatalf("%s: zstd did not find the repeated data", test.name)
+		}
+		got, err := io.ReadAll(NewReader(bytes.NewReader(out)))
+		switch {
+		case test.ok && err != nil:
+			t.Errorf("%s: %v", test.name, err)
+		case test.ok && !bytes.Equal(got, test.data):
+			t.Errorf("%s: decompressed data does not match", test.name)
+		case !test.ok && (err == nil || !strings.Contains(err.Error(), "past window")):
+			t.Errorf("%s: got error %v for a match beyond 8 MiB, want offset past window", test.name, err)
+		}
+	}
+}
+
+func TestReaderReset(t *testing.T) {
+	inputs := testInputs(t)
+	text := compress(t, inputs["text"], DefaultCompression, 1, nil)
+	short := compress(t, inputs["short"], DefaultCompression, 1, nil)
+
+	r := NewReader(bytes.NewReader(text))
+	// Stop in the middle of the stream.
+	if _, err := io.ReadFull(r, make([]byte, 1000)); err != nil {
+		t.Fatal(err)
+	}
+	r.Reset(bytes.NewReader(short))
+	if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, inputs["short"]) {
+		t.Fatalf("after Reset mid-stream: got %q, %v", got, err)
+	}
+
+	// Reset after an error.
+	r.Reset(bytes.NewReader(text[:len(text)/2]))
+	if _, err := io.ReadAll(r); err == nil {
+		t.Fatal("truncated stream: got no error")
+	}
+	r.Reset(bytes.NewReader(text))
+	if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, inputs["text"]) {
+		t.Fatalf("after Reset following an error: %v", err)
+	}
+
+	// Reset keeps the dictionary.
+	want := readTestdata(t, "dict/input.json")
+	data := readTestdata(t, "dict/input.json.zst")
+	r, err := NewReaderDict(bytes.NewReader(short), readTestdata(t, "dict/json.dict"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 2 {
+		r.Reset(bytes.NewReader(data))
+		if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("dictionary frame after %d Resets: %v", i+1, err)
+		}
+	}
+}
+
+func TestReaderReadByte(t *testing.T) {
+	want := []byte("hello, world\n")
+	data := bytes.Join([][]byte{
+		compress(t, want[:5], DefaultCompression, 1, nil),
+		compress(t, nil, DefaultCompression, 1, nil),
+		compress(t, want[5:], DefaultCompression, 1, nil),
+	}, nil)
+	r := NewReader(bytes.NewReader(data))
+	var got []byte
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, b)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,516 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"compress/internal/lz"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	izstd "internal/zstd"
+	"io"
+	"sync"
+)
+
+// These constants are copied from the flate package, so that code that
+// imports "compress/zstd" does not also have to import "compress/flate".
+const (
+	BestSpeed          = 1
+	BestCompression    = 9
+	DefaultCompression = -1
+)
+
+// defaultLevel is the level used for DefaultCompression.
+const defaultLevel = 3
+
+// concurrentJobSize is the amount of data compressed by each goroutine
+// when compressing concurrently.
+const concurrentJobSize = 1 << 20
+
+// concurrentHistory is the most history given to each goroutine
+// when compressing concurrently.
+const concurrentHistory = 256 << 10
+
+var errWriterClosed = errors.New("zstd: closed writer")
+
+// A Writer is an io.WriteCloser.
+// Writes to a Writer are compressed and written to w.
+//
+// Each Writer writes a single zstd frame with a content checksum.
+type Writer struct {
+	// Concurrency is the maximum number of goroutines used to
+	// compress data. If it is greater than 1, the input is split into
+	// large chunks that are compressed in parallel. Each chunk can only
+	// refer back to a limited amount of earlier data, so the output may
+	// be a little larger. The output is a standard zstd stream either way.
+	//
+	// Concurrency must be set before the first call to Write, Flush
+	// or Close. The zero value compresses on the calling goroutine.
+	Concurrency int
+
+	w     io.Writer
+	level int
+	p     lz.Params
+	dict  *izstd.Dict
+
+	buf   []byte // history followed by data not yet compressed
+	start int    // start of the data not yet compressed
+
+	enc      *encoder // for compressing on the calling goroutine
+	encReady bool     // whether enc is set up for the current frame
+	jobs     []*job   // concurrent jobs, in output order
+	out      []byte   // output buffer
+	checksum izstd.XXHash64
+
+	wroteHeader   bool // whether the frame header was written
+	compressedAny bool // whether any data in the frame was compressed
+	closed        bool
+	err           error
+}
+
+// NewWriter returns a new Writer.
+// Writes to the returned writer are compressed and written to w.
+//
+// It is the caller's responsibility to call Close on the Writer when done.
+// Writes may be buffered and not flushed until Close.
+func NewWriter(w io.Writer) *Writer {
+	z, _ := NewWriterLevelDict(w, DefaultCompression, nil)
+	return z
+}
+
+// NewWriterLevel is like NewWriter but specifies the compression level
+// instead of assuming DefaultCompression.
+//
+// The compression level can be DefaultCompression, or any integer
+// value between BestSpeed and BestCompression inclusive.
+// The error returned will be nil if the level is valid.
+func NewWriterLevel(w io.Writer, level int) (*Writer, error) {
+	return NewWriterLevelDict(w, level, nil)
+}
+
+// NewWriterLevelDict is like NewWriterLevel but compresses with a
+// dictionary. The dictionary is either raw content, which is treated as
+// data that precedes the input, or a dictionary in the zstd format,
+// as produced by the zstd tool's --train option. The frame records the
+// ID of a formatted dictionary, and it must be decompressed with the
+// same dictionary. A nil or empty dict means no dictionary.
+//
+// The dictionary must not be modified while the Writer is in use.
+func NewWriterLevelDict(w io.Writer, level int, dict []byte) (*Writer, error) {
+	if level == DefaultCompression {
+		level = defaultLevel
+	}
+	if level < BestSpeed || level > BestCompression {
+		return nil, fmt.Errorf("zstd: invalid compression level %d: want value in range [%d, %d] or %d", level, BestSpeed, BestCompression, DefaultCompression)
+	}
+	z := &Writer{level: level, p: levels[level]}
+	if len(dict) > 0 {
+		d, err := izstd.ParseDict(dict)
+		if err != nil {
+			return nil, err
+		}
+		z.dict = d
+	}
+	z.Reset(w)
+	return z, nil
+}
+
+// Reset discards the Writer z's state and makes it equivalent to the
+// result of its original state from NewWriter or NewWriterLevelDict,
+// but writing to w instead. This permits reusing a Writer rather than
+// allocating a new one. The compression level, dictionary and
+// Concurrency are kept.
+func (z *Writer) Reset(w io.Writer) {
+	for _, j := range z.jobs {
+		<-j.done
+		j.release()
+	}
+	clear(z.jobs)
+	z.jobs = z.jobs[:0]
+
+	z.w = w
+	z.buf = z.buf[:0]
+	if z.dict != nil {
+		z.buf = append(z.buf, z.dict.Content()...)
+	}
+	z.start = len(z.buf)
+	z.encReady = false
+	z.checksum.Reset()
+	z.wroteHeader = false
+	z.compressedAny = false
+	z.closed = false
+	z.err = nil
+}
+
+// windowSize returns the window size written in the frame header.
+func (z *Writer) windowSize() int {
+	return 1 << z.p.WindowLog
+}
+
+// concurrent reports whether z compresses on multiple goroutines.
+func (z *Writer) concurrent() bool {
+	return z.Concurrency > 1
+}
+
+// chunkSize returns the amount of data to collect before compressing.
+func (z *Writer) chunkSize() int {
+	if z.concurrent() {
+		return concurrentJobSize
+	}
+	return maxBlockSize
+}
+
+// Write writes a compressed form of p to the underlying io.Writer.
+// The compressed bytes are not necessarily flushed until
+// the Writer is closed or explicitly flushed.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.closed {
+		return 0, errWriterClosed
+	}
+	z.checksum.Write(p)
+	n := len(p)
+	chunk := z.chunkSize()
+	for len(p) > 0 {
+		k := min(len(p), chunk-(len(z.buf)-z.start))
+		z.buf = append(z.buf, p[:k]...)
+		p = p[k:]
+		if len(z.buf)-z.start == chunk {
+			if err := z.compress(false); err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush flushes any pending compressed data to the underlying writer.
+//
+// It is useful mainly in compressed network protocols, to ensure that
+// a remote reader has enough data to reconstruct a packet. Flush does
+// not return until the data has been written. If the underlying writer
+// returns an error, Flush returns that error.
+func (z *Writer) Flush() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	if len(z.buf) > z.start || !z.wroteHeader {
+		if err := z.compress(false); err != nil {
+			return err
+		}
+	}
+	for len(z.jobs) > 0 {
+		if err := z.writeJob(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the Writer by flushing any unwritten data to the
+// underlying io.Writer and writing the content checksum. It does not
+// close the underlying io.Writer.
+func (z *Writer) Close() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.closed {
+		return nil
+	}
+	z.closed = true
+	return z.compress(true)
+}
+
+// compress compresses the pending data, as the last block of the frame
+// if last is set, and writes it out or hands it to a goroutine.
+func (z *Writer) compress(last bool) error {
+	z.out = z.out[:0]
+	contentSize := -1
+	if !z.wroteHeader {
+		// If all the data is here, record its size in the header
+		// and let matches reach all the way back into the dictionary.
+		if last {
+			contentSize = len(z.buf) - z.start
+		}
+		z.out = z.appendHeader(z.out, contentSize)
+		z.wroteHeader = true
+	}
+
+	if z.concurrent() {
+		if len(z.out) > 0 {
+			if err := z.write(z.out); err != nil {
+				return err
+			}
+			z.out = z.out[:0]
+		}
+		if !last && len(z.buf) > z.start {
+			return z.startJob()
+		}
+		for len(z.jobs) > 0 {
+			if err := z.writeJob(); err != nil {
+				return err
+			}
+		}
+		if !last {
+			return nil
+		}
+	}
+
+	maxOffset := z.windowSize()
+	if contentSize >= 0 {
+		maxOffset = len(z.buf)
+	}
+	enc := z.enc
+	if enc == nil {
+		enc = new(encoder)
+		z.enc = enc
+	}
+	if !z.encReady || z.concurrent() {
+		sizeHint := 0
+		if contentSize >= 0 {
+			sizeHint = len(z.buf)
+		}
+		enc.m.reset(z.p, sizeHint)
+		z.initRepeats(&enc.m.reps)
+		z.encReady = true
+	}
+	z.out = enc.encodeBlocks(z.out, z.buf, z.start, len(z.buf), maxOffset, last)
+	if last {
+		z.out = binary.LittleEndian.AppendUint32(z.out, uint32(z.checksum.Sum64()))
+	}
+	z.advance(len(z.buf))
+	return z.write(z.out)
+}
+
+// initRepeats sets the repeat offsets for compressing the pending data.
+func (z *Writer) initRepeats(r *repeats) {
+	if z.compressedAny {
+		// The offsets depend on what another goroutine did.
+		r.forget()
+		return
+	}
+	offs := [3]uint32{1, 4, 8}
+	if z.dict != nil {
+		offs = z.dict.RepeatOffsets()
+	}
+	r.reset(offs)
+}
+
+// advance marks the data up to end as compressed and drops history
+// that is no longer needed.
+func (z *Writer) advance(end int) {
+	z.start = end
+	z.compressedAny = true
+
+	keep := z.windowSize()
+	if z.concurrent() {
+		keep = min(keep, concurrentHistory)
+	}
+	// Sliding only occasionally keeps the copying cheap.
+	if z.start < 2*keep {
+		return
+	}
+	delta := z.start - keep
+	copy(z.buf, z.buf[delta:])
+	z.buf = z.buf[:len(z.buf)-delta]
+	z.start -= delta
+	if z.enc != nil && !z.concurrent() {
+		z.enc.m.t.Slide(z.buf, delta)
+	}
+}
+
+// write writes b to the underlying writer, recording any error.
+func (z *Writer) write(b []byte) error {
+	if _, err := z.w.Write(b); err != nil {
+		z.err = err
+		return err
+	}
+	return nil
+}
+
+// appendHeader appends the frame header to out. The content size is
+// recorded if it is not negative. RFC 3.1.1.1.
+func (z *Writer) appendHeader(out []byte, contentSize int) []byte {
+	out = append(out, 0x28, 0xb5, 0x2f, 0xfd)
+
+	// Frame_Header_Descriptor, with the Content_Checksum_Flag.
+	desc := byte(1 << 2)
+	var id uint32
+	if z.dict != nil {
+		id = z.dict.ID()
+	}
+	switch {
+	case id == 0:
+	case id < 1<<8:
+		desc |= 1
+	case id < 1<<16:
+		desc |= 2
+	default:
+		desc |= 3
+	}
+	fcsSize := 0
+	if contentSize >= 0 {
+		// Single_Segment_Flag: the content size is also the window size.
+		desc |= 1 << 5
+		switch {
+		case contentSize < 1<<8:
+			fcsSize = 1
+		case contentSize < 1<<16+256:
+			fcsSize = 2
+			desc |= 1 << 6
+		case uint64(contentSize) < 1<<32:
+			fcsSize = 4
+			desc |= 2 << 6
+		default:
+			fcsSize = 8
+			desc |= 3 << 6
+		}
+	}
+	out = append(out, desc)
+
+	if contentSize < 0 {
+		// Window_Descriptor, with a mantissa of 0.
+		out = append(out, (z.p.WindowLog-10)<<3)
+	}
+
+	switch {
+	case id == 0:
+	case id < 1<<8:
+		out = append(out, byte(id))
+	case id < 1<<16:
+		out = binary.LittleEndian.AppendUint16(out, uint16(id))
+	default:
+		out = binary.LittleEndian.AppendUint32(out, id)
+	}
+
+	switch fcsSize {
+	case 1:
+		out = append(out, byte(contentSize))
+	case 2:
+		out = binary.LittleEndian.AppendUint16(out, uint16(contentSize-256))
+	case 4:
+		out = binary.LittleEndian.AppendUint32(out, uint32(contentSize))
+	case 8:
+		out = binary.LittleEndian.AppendUint64(out, uint64(contentSize))
+	}
+	return out
+}
+
+// encoder compresses blocks.
+type encoder struct {
+	m   matcher
+	blk blockEncoder
+}
+
+// encodeBlocks appends blocks holding buf[start:end] to out.
+// The matcher must have been reset for buf.
+func (e *encoder) encodeBlocks(out, buf []byte, start, end, maxOffset int, last bool) []byte {
+	for {
+		blockEnd := min(start+maxBlockSize, end)
+		lastBlock := last && blockEnd == end
+		src := buf[start:blockEnd]
+		switch {
+		case len(src) == 0:
+			if lastBlock {
+				out = appendRawBlock(out, src, true)
+			}
+		case len(src) > 1 && bytes.Equal(src[1:], src[:len(src)-1]):
+			out = appendRLEBlock(out, src[0], len(src), lastBlock)
+		default:
+			e.m.compress(buf, start, blockEnd, maxOffset)
+			out = e.blk.appendBlock(out, src, e.m.lits, e.m.seqs, lastBlock)
+		}
+		start = blockEnd
+		if start >= end {
+			return out
+		}
+	}
+}
+
+// encoderPool holds encoders for concurrent jobs.
+var encoderPool sync.Pool
+
+// job is a chunk of data compressed on its own goroutine.
+type job struct {
+	buf  []byte // history followed by the data to compress
+	out  []byte // compressed blocks
+	done chan struct{}
+}
+
+// jobBufPool holds buffers for job data and output.
+var jobBufPool sync.Pool
+
+// getJobBuf returns an empty buffer from jobBufPool.
+func getJobBuf() []byte {
+	if b, ok := jobBufPool.Get().(*[]byte); ok {
+		return (*b)[:0]
+	}
+	return nil
+}
+
+// release returns the job's buffers to jobBufPool.
+func (j *job) release() {
+	for _, b := range [...][]byte{j.buf, j.out} {
+		if b != nil {
+			jobBufPool.Put(&b)
+		}
+	}
+	j.buf, j.out = nil, nil
+}
+
+// startJob compresses the pending data on a new goroutine.
+// It first waits for the oldest job if there are already
+// Concurrency jobs running.
+func (z *Writer) startJob() error {
+	if len(z.jobs) >= z.Concurrency {
+		if err := z.writeJob(); err != nil {
+			return err
+		}
+	}
+
+	histStart := max(z.start-concurrentHistory, 0)
+	j := &job{
+		buf:  append(getJobBuf(), z.buf[histStart:]...),
+		out:  getJobBuf(),
+		done: make(chan struct{}),
+	}
+	start := z.start - histStart
+	p := z.p
+	maxOffset := z.windowSize()
+	var reps repeats
+	z.initRepeats(&reps)
+	go func() {
+		e, _ := encoderPool.Get().(*encoder)
+		if e == nil {
+			e = new(encoder)
+		}
+		e.m.reset(p, 0)
+		e.m.reps = reps
+		j.out = e.encodeBlocks(j.out, j.buf, start, len(j.buf), maxOffset, false)
+		encoderPool.Put(e)
+		close(j.done)
+	}()
+	z.jobs = append(z.jobs, j)
+	z.advance(len(z.buf))
+	return nil
+}
+
+// writeJob waits for the oldest job and writes its output.
+func (z *Writer) writeJob() error {
+	j := z.jobs[0]
+	copy(z.jobs, z.jobs[1:])
+	z.jobs[len(z.jobs)-1] = nil
+	z.jobs = z.jobs[:len(z.jobs)-1]
+	<-j.done
+	err := z.write(j.out)
+	j.release()
+	return err
+}
@@ -0,0 +1,321 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"fmt"
+	"internal/testenv"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testInputs returns inputs that exercise the different block
+// and literal encodings.
+func testInputs(t testing.TB) map[string][]byte {
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 300<<10)
+	r.Read(random)
+
+	// Text-like data with a skewed alphabet and repeated words.
+	words := strings.Fields("the quick brown fox jumps over a lazy dog while zstd compresses text with Huffman and FSE")
+	var text bytes.Buffer
+	for text.Len() < 400<<10 {
+		text.WriteString(words[r.Intn(len(words))])
+		text.WriteByte(" \n,."[r.Intn(4)])
+	}
+
+	// Mostly random data with some repeats at varying distances,
+	// so that repeat offsets and long offsets are used.
+	mixed := make([]byte, 0, 600<<10)
+	for len(mixed) < 600<<10 {
+		n := r.Intn(200) + 1
+		if len(mixed) > 1000 && r.Intn(3) > 0 {
+			off := r.Intn(len(mixed)-1) + 1
+			if r.Intn(2) == 0 {
+				off = 1 + r.Intn(16)
+			}
+			for i := 0; i < n; i++ {
+				mixed = append(mixed, mixed[len(mixed)-off])
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				mixed = append(mixed, byte(r.Intn(40)))
+			}
+		}
+	}
+
+	gettysburg, err := os.ReadFile("../../internal/zstd/testdata/1890a371.gettysburg.txt-100x.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string][]byte{
+		"empty":     nil,
+		"byte":      {'x'},
+		"short":     []byte("hello, world\n"),
+		"zeros":     make([]byte, 500<<10),
+		"random":    random,
+		"text":      text.Bytes(),
+		"mixed":     mixed,
+		"zst":       gettysburg,
+		"textshort": text.Bytes()[:1000],
+	}
+}
+
+func compress(t testing.TB, data []byte, level, concurrency int, dict []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriterLevelDict(&buf, level, dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Concurrency = concurrency
+	// Write in uneven pieces.
+	for len(data) > 0 {
+		n := min(len(data), 70000)
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decompress(t testing.TB, data, dict []byte) []byte {
+	t.Helper()
+	var r *Reader
+	if dict == nil {
+		r = NewReader(bytes.NewReader(data))
+	} else {
+		var err error
+		r, err = NewReaderDict(bytes.NewReader(data), dict)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestRoundTrip(t *testing.T) {
+	for name, data := range testInputs(t) {
+		for level := BestSpeed; level <= BestCompression; level++ {
+			if testing.Short() && level%3 != 1 {
+				continue
+			}
+			for _, concurrency := range []int{0, 4} {
+				t.Run(fmt.Sprintf("%s/%d/%d", name, level, concurrency), func(t *testing.T) {
+					c := compress(t, data, level, concurrency, nil)
+					if got := decompress(t, c, nil); !bytes.Equal(got, data) {
+						t.Fatalf("round trip mismatch: got %d bytes, want %d", len(got), len(data))
+					}
+					if len(data) > 1000 && name != "random" && name != "zst" && len(c) >= len(data)/2 {
+						t.Errorf("compressed %d bytes to %d", len(data), len(c))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestLevels(t *testing.T) {
+	for _, level := range []int{-2, 0, 10} {
+		if _, err := NewWriterLevel(io.Discard, level); err == nil {
+			t.Errorf("NewWriterLevel(%d) succeeded", level)
+		}
+	}
+	text := testInputs(t)["text"]
+	fast := len(compress(t, text, BestSpeed, 0, nil))
+	best := len(compress(t, text, BestCompression, 0, nil))
+	if best >= fast {
+		t.Errorf("BestCompression output is %d bytes, BestSpeed is %d", best, fast)
+	}
+}
+
+func TestDict(t *testing.T) {
+	dir := "../../internal/zstd/testdata/dict"
+	dict, err := os.ReadFile(filepath.Join(dir, "json.dict"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := os.ReadFile(filepath.Join(dir, "input.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "raw.dict"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []struct {
+		name string
+		dict []byte
+	}{{"json", dict}, {"raw", raw}} {
+		for _, n := range []int{100, 2000, len(input)} {
+			for _, concurrency := range []int{0, 2} {
+				data := input[:n]
+				c := compress(t, data, DefaultCompression, concurrency, d.dict)
+				if got := decompress(t, c, d.dict); !bytes.Equal(got, data) {
+					t.Fatalf("%s/%d: round trip mismatch", d.name, n)
+				}
+				plain := compress(t, data, DefaultCompression, concurrency, nil)
+				if len(c) >= len(plain) {
+					t.Errorf("%s/%d: compressed to %d bytes with dictionary, %d without", d.name, n, len(c), len(plain))
+				}
+			}
+		}
+	}
+
+	// A frame with a dictionary ID can't be read without it.
+	c := compress(t, input, DefaultCompression, 0, dict)
+	if _, err := io.ReadAll(NewReader(bytes.NewReader(c))); err == nil {
+		t.Error("decompressing without dictionary succeeded")
+	}
+	if _, err := NewWriterLevelDict(io.Discard, DefaultCompression, dict[:20]); err == nil {
+		t.Error("NewWriterLevelDict with truncated dictionary succeeded")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	for _, concurrency := range []int{0, 3} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		w.Concurrency = concurrency
+		r := NewReader(&buf)
+		for i := range 5 {
+			msg := []byte(strings.Repeat(fmt.Sprintf("message %d ", i), 10+i))
+			if _, err := w.Write(msg); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			got := make([]byte, len(msg))
+			if _, err := io.ReadFull(r, got); err != nil {
+				t.Fatalf("reading message %d: %v", i, err)
+			}
+			if !bytes.Equal(got, msg) {
+				t.Fatalf("got %q, want %q", got, msg)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+			t.Errorf("Read at end = %d, %v; want 0, EOF", n, err)
+		}
+		if _, err := w.Write([]byte("x")); err == nil {
+			t.Error("Write after Close succeeded")
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	inputs := testInputs(t)
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Concurrency = 2
+	for _, name := range []string{"text", "mixed", "short", "text"} {
+		buf.Reset()
+		w.Reset(&buf)
+		w.Write(inputs[name])
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got := decompress(t, buf.Bytes(), nil); !bytes.Equal(got, inputs[name]) {
+			t.Fatalf("%s: round trip mismatch after Reset", name)
+		}
+	}
+}
+
+// TestZstdTool checks that the zstd tool can decompress our output
+// and that we can decompress its output.
+func TestZstdTool(t *testing.T) {
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("skipping because zstd not found")
+	}
+	testenv.MustHaveExec(t)
+
+	dictFile := "../../internal/zstd/testdata/dict/json.dict"
+	dict, err := os.ReadFile(dictFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for name, data := range testInputs(t) {
+		for _, level := range []int{BestSpeed, DefaultCompression, BestCompression} {
+			for _, withDict := range []bool{false, true} {
+				var d []byte
+				args := []string{"-d", "-c"}
+				if withDict {
+					d = dict
+					args = append(args, "-D", dictFile)
+				}
+				c := compress(t, data, level, 2, d)
+				cmd := exec.Command(zstd, args...)
+				cmd.Stdin = bytes.NewReader(c)
+				got, err := cmd.Output()
+				if err != nil {
+					t.Fatalf("%s/%d/%v: zstd -d: %v", name, level, withDict, err)
+				}
+				if !bytes.Equal(got, data) {
+					t.Fatalf("%s/%d/%v: zstd -d output mismatch", name, level, withDict)
+				}
+			}
+		}
+
+		in := filepath.Join(dir, "in")
+		if err := os.WriteFile(in, data, 0o666); err != nil {
+			t.Fatal(err)
+		}
+		out, err := exec.Command(zstd, "-c", "-9", in).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := decompress(t, out, nil); !bytes.Equal(got, data) {
+			t.Fatalf("%s: decompressing zstd output mismatch", name)
+		}
+	}
+}
+
+func TestPredefinedTables(t *testing.T) {
+	for kind, info := range seqInfo {
+		sum := 0
+		for _, n := range info.predefNorm {
+			if n < 0 {
+				n = 1
+			}
+			sum += int(n)
+		}
+		if sum != 1<<info.predefLog {
+			t.Errorf("table %d: counts sum to %d, want %d", kind, sum, 1<<info.predefLog)
+		}
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte("hello, hello, hello, world"), uint8(3))
+	f.Add(bytes.Repeat([]byte("abcd"), 1000), uint8(1))
+	f.Fuzz(func(t *testing.T, data []byte, level uint8) {
+		l := int(level)%BestCompression + 1
+		c := compress(t, data, l, 0, nil)
+		if got := decompress(t, c, nil); !bytes.Equal(got, data) {
+			t.Fatal("round trip mismatch")
+		}
+	})
+}
@@ -0,0 +1,366 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package acme implements a client for the Automatic Certificate Management
+// Environment (ACME) protocol, as specified in RFC 8555.
+//
+// ACME is used by certificate authorities such as Let's Encrypt to issue
+// certificates for identifiers, typically domain names, after the client has
+// proven control over them by completing challenges. This package supports
+// the http-01 and dns-01 challenges of RFC 8555 and the tls-alpn-01 challenge
+// of RFC 8737.
+//
+// Most programs that serve TLS should use package
+// [crypto/acme/autocert] instead, which obtains and renews certificates
+// automatically.
+package acme
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// LetsEncryptURL is the directory URL of the Let's Encrypt production CA.
+	LetsEncryptURL = "https://acme-v02.api.letsencrypt.org/directory"
+
+	// ALPNProto is the ALPN protocol name used by a CA when validating
+	// tls-alpn-01 challenges. Servers must be able to negotiate it for
+	// tls-alpn-01 challenges to succeed; see [crypto/tls.Config.NextProtos].
+	ALPNProto = "acme-tls/1"
+)
+
+// Status values of Account, Order, Authorization and Challenge objects,
+// as specified in RFC 8555, Section 7.1.6.
+const (
+	StatusDeactivated = "deactivated"
+	StatusExpired     = "expired"
+	StatusInvalid     = "invalid"
+	StatusPending     = "pending"
+	StatusProcessing  = "processing"
+	StatusReady       = "ready"
+	StatusRevoked     = "revoked"
+	StatusValid       = "valid"
+)
+
+var (
+	// ErrUnsupportedKey is returned when an account or certificate key is
+	// neither an RSA nor an ECDSA key on a supported curve.
+	ErrUnsupportedKey = errors.New("acme: unsupported key type; only RSA and ECDSA P-256, P-384 and P-521 keys are supported")
+
+	// ErrNoAccount is returned by [Client.GetAccount] when the Client's key
+	// is not registered with the CA.
+	ErrNoAccount = errors.New("acme: account does not exist")
+)
+
+// A Client is an ACME client. A Client is safe for concurrent use by multiple
+// goroutines once its fields are set.
+//
+// The only required field is Key.
+type Client struct {
+	// Key is the account key, used to register with the CA and to sign all
+	// requests. Key.Public() must return an *rsa.PublicKey or an
+	// *ecdsa.PublicKey on P-256, P-384 or P-521.
+	Key crypto.Signer
+
+	// DirectoryURL is the URL of the CA's directory. If empty, LetsEncryptURL
+	// is used. Changing it after the first request has no effect.
+	DirectoryURL string
+
+	// HTTPClient is the HTTP client used to send requests. If nil,
+	// [net/http.DefaultClient] is used.
+	HTTPClient *http.Client
+
+	// UserAgent is prepended to the User-Agent header sent to the CA.
+	// Libraries and tools should set it so that CAs can identify them.
+	UserAgent string
+
+	// KID is the URL of the account of Key, assigned by the CA. It is set by
+	// Register and GetAccount. If empty, it is looked up with GetAccount
+	// before the first request that needs it.
+	KID string
+
+	mu  sync.Mutex
+	dir *Directory
+
+	noncesMu sync.Mutex
+	nonces   []string
+}
+
+// A Directory lists the endpoints and metadata of an ACME CA, as specified in
+// RFC 8555, Section 7.1.1.
+type Directory struct {
+	NewNonceURL   string
+	NewAccountURL string
+	NewOrderURL   string
+	RevokeCertURL string
+	KeyChangeURL  string
+
+	// Terms is the URL of the current terms of service, if any.
+	Terms string
+
+	// Website is the URL of the CA's website, if any.
+	Website string
+
+	// CAA lists the domain names the CA recognizes in CAA records.
+	CAA []string
+
+	// ExternalAccountRequired reports whether new accounts must be bound to
+	// an external account; see [ExternalAccountBinding].
+	ExternalAccountRequired bool
+}
+
+// Discover fetches the directory of the CA at c.DirectoryURL. The result is
+// cached for the lifetime of the Client.
+func (c *Client) Discover(ctx context.Context) (*Directory, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dir != nil {
+		return c.dir, nil
+	}
+
+	res, err := c.get(ctx, c.directoryURL())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	c.addNonce(res.Header)
+
+	var v struct {
+		NewNonce   string `json:"newNonce"`
+		NewAccount string `json:"newAccount"`
+		NewOrder   string `json:"newOrder"`
+		RevokeCert string `json:"revokeCert"`
+		KeyChange  string `json:"keyChange"`
+		Meta       struct {
+			TermsOfService          string   `json:"termsOfService"`
+			Website                 string   `json:"website"`
+			CAAIdentities           []string `json:"caaIdentities"`
+			ExternalAccountRequired bool     `json:"externalAccountRequired"`
+		} `json:"meta"`
+	}
+	if err := decodeJSON(res, &v); err != nil {
+		return nil, fmt.Errorf("acme: invalid directory: %w", err)
+	}
+	if v.NewNonce == "" || v.NewAccount == "" || v.NewOrder == "" {
+		return nil, errors.New("acme: directory is missing required endpoints")
+	}
+	c.dir = &Directory{
+		NewNonceURL:             v.NewNonce,
+		NewAccountURL:           v.NewAccount,
+		NewOrderURL:             v.NewOrder,
+		RevokeCertURL:           v.RevokeCert,
+		KeyChangeURL:            v.KeyChange,
+		Terms:                   v.Meta.TermsOfService,
+		Website:                 v.Meta.Website,
+		CAA:                     v.Meta.CAAIdentities,
+		ExternalAccountRequired: v.Meta.ExternalAccountRequired,
+	}
+	return c.dir, nil
+}
+
+func (c *Client) directoryURL() string {
+	if c.DirectoryURL != "" {
+		return c.DirectoryURL
+	}
+	return LetsEncryptURL
+}
+
+// An Account is an ACME account, as specified in RFC 8555, Section 7.1.2.
+type Account struct {
+	// URL is the account URL, used as the key ID of requests.
+	URL string
+
+	// Status is the account status, such as StatusValid.
+	Status string
+
+	// Contact lists URLs the CA can use to contact the account holder,
+	// such as "mailto:admin@example.com".
+	Contact []string
+
+	// OrdersURL is the URL of the list of orders of the account.
+	OrdersURL string
+
+	// ExternalAccountBinding binds a new account to an account the holder
+	// has with the CA outside of ACME. It is only used by Register, and is
+	// required by CAs whose Directory has ExternalAccountRequired set.
+	ExternalAccountBinding *ExternalAccountBinding
+}
+
+// An ExternalAccountBinding identifies an external account and holds the MAC
+// key the CA provided for it, as specified in RFC 8555, Section 7.3.4.
+type ExternalAccountBinding struct {
+	// KID is the key identifier provided by the CA.
+	KID string
+
+	// Key is the HMAC-SHA256 key provided by the CA, without any encoding.
+	Key []byte
+}
+
+// AcceptTOS is a prompt function for [Client.Register] that always accepts
+// the terms of service of the CA.
+func AcceptTOS(tosURL string) bool { return true }
+
+// Register creates an account for c.Key with the CA, and sets c.KID.
+//
+// If the CA has terms of service, prompt is called with their URL, and must
+// return true for the terms to be accepted. Most CAs refuse to create accounts
+// whose holders did not accept the terms.
+//
+// If c.Key is already registered, Register returns the existing account.
+func (c *Client) Register(ctx context.Context, acct *Account, prompt func(tosURL string) bool) (*Account, error) {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		Contact                []string          `json:"contact,omitempty"`
+		TermsAgreed            bool              `json:"termsOfServiceAgreed,omitempty"`
+		ExternalAccountBinding *jsonWebSignature `json:"externalAccountBinding,omitempty"`
+	}{
+		Contact: acct.Contact,
+	}
+	if dir.Terms != "" {
+		if prompt == nil {
+			return nil, errors.New("acme: the CA has terms of service, but prompt is nil")
+		}
+		req.TermsAgreed = prompt(dir.Terms)
+	}
+	if eab := acct.ExternalAccountBinding; eab != nil {
+		req.ExternalAccountBinding, err = jwsWithMAC(eab.Key, eab.KID, dir.NewAccountURL, c.Key.Public())
+		if err != nil {
+			return nil, err
+		}
+	} else if dir.ExternalAccountRequired {
+		return nil, errors.New("acme: the CA requires an external account binding")
+	}
+
+	res, err := c.post(ctx, c.Key, dir.NewAccountURL, req, http.StatusOK, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	a, err := responseAccount(res, "")
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.KID = a.URL
+	c.mu.Unlock()
+	return a, nil
+}
+
+// GetAccount returns the account of c.Key, and sets c.KID. It returns
+// [ErrNoAccount] if c.Key is not registered with the CA.
+func (c *Client) GetAccount(ctx context.Context) (*Account, error) {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := json.RawMessage(`{"onlyReturnExisting":true}`)
+	res, err := c.post(ctx, c.Key, dir.NewAccountURL, req, http.StatusOK)
+	if err != nil {
+		if e, ok := err.(*Error); ok && e.ProblemType == ProblemAccountDoesNotExist {
+			return nil, ErrNoAccount
+		}
+		return nil, err
+	}
+	defer res.Body.Close()
+	a, err := responseAccount(res, "")
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.KID = a.URL
+	c.mu.Unlock()
+	return a, nil
+}
+
+// UpdateAccount replaces the contact URLs of the account of c.Key with
+// acct.Contact.
+func (c *Client) UpdateAccount(ctx context.Context, acct *Account) (*Account, error) {
+	kid, err := c.accountKID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		Contact []string `json:"contact"`
+	}{
+		Contact: acct.Contact,
+	}
+	if req.Contact == nil {
+		req.Contact = []string{}
+	}
+	res, err := c.post(ctx, nil, kid, req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseAccount(res, kid)
+}
+
+// DeactivateAccount permanently deactivates the account of c.Key. The CA
+// rejects all further requests signed by c.Key.
+func (c *Client) DeactivateAccount(ctx context.Context) error {
+	kid, err := c.accountKID(ctx)
+	if err != nil {
+		return err
+	}
+	req := json.RawMessage(`{"status":"deactivated"}`)
+	res, err := c.post(ctx, nil, kid, req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+// accountKID returns c.KID, looking it up with GetAccount if necessary.
+func (c *Client) accountKID(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	kid := c.KID
+	c.mu.Unlock()
+	if kid != "" {
+		return kid, nil
+	}
+	a, err := c.GetAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	return a.URL, nil
+}
+
+// responseAccount decodes an account object. Its URL is taken from the
+// Location header, or is url if the header is missing.
+func responseAccount(res *http.Response, url string) (*Account, error) {
+	var v struct {
+		Status  string   `json:"status"`
+		Contact []string `json:"contact"`
+		Orders  string   `json:"orders"`
+	}
+	if err := decodeJSON(res, &v); err != nil {
+		return nil, fmt.Errorf("acme: invalid account: %w", err)
+	}
+	if loc := res.Header.Get("Location"); loc != "" {
+		url = loc
+	}
+	if url == "" {
+		return nil, errors.New("acme: account response is missing its URL")
+	}
+	return &Account{
+		URL:       url,
+		Status:    v.Status,
+		Contact:   v.Contact,
+		OrdersURL: v.Orders,
+	}, nil
+}
+
+// timeNow is time.Now, except in tests.
+var timeNow = time.Now
@@ -0,0 +1,338 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme_test
+
+import (
+	"context"
+	"crypto/acme"
+	"crypto/acme/internal/acmetest"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newCA(t *testing.T) *acmetest.CAServer {
+	ca := acmetest.NewCAServer()
+	t.Cleanup(ca.Close)
+	return ca
+}
+
+func newClient(t *testing.T, ca *acmetest.CAServer) *acme.Client {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &acme.Client{Key: key, DirectoryURL: ca.URL}
+}
+
+func register(t *testing.T, c *acme.Client) *acme.Account {
+	a, err := c.Register(context.Background(), &acme.Account{Contact: []string{"mailto:admin@example.com"}}, acme.AcceptTOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestRegister(t *testing.T) {
+	ca := newCA(t)
+	ctx := context.Background()
+	c := newClient(t, ca)
+
+	if _, err := c.Register(ctx, &acme.Account{}, func(string) bool { return false }); !isProblem(err, acme.ProblemUserActionRequired) {
+		t.Errorf("Register without agreeing to the terms: got error %v, want %s", err, acme.ProblemUserActionRequired)
+	}
+	if _, err := c.GetAccount(ctx); err != acme.ErrNoAccount {
+		t.Errorf("GetAccount before Register: got error %v, want ErrNoAccount", err)
+	}
+
+	a := register(t, c)
+	if a.URL == "" || a.Status != acme.StatusValid || c.KID != a.URL {
+		t.Errorf("Register returned %+v, and set KID to %q", a, c.KID)
+	}
+	if len(a.Contact) != 1 || a.Contact[0] != "mailto:admin@example.com" {
+		t.Errorf("Contact = %q", a.Contact)
+	}
+	if again := register(t, c); again.URL != a.URL {
+		t.Errorf("second Register returned account %q, want %q", again.URL, a.URL)
+	}
+
+	// A new Client with the same key finds the account.
+	c2 := &acme.Client{Key: c.Key, DirectoryURL: ca.URL}
+	got, err := c2.GetAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != a.URL {
+		t.Errorf("GetAccount returned account %q, want %q", got.URL, a.URL)
+	}
+
+	updated, err := c2.UpdateAccount(ctx, &acme.Account{Contact: []string{"mailto:ops@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.URL != a.URL || len(updated.Contact) != 1 || updated.Contact[0] != "mailto:ops@example.com" {
+		t.Errorf("UpdateAccount returned %+v", updated)
+	}
+
+	if err := c.DeactivateAccount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewOrder(ctx, acme.DomainIdentifiers("example.org")); !isProblem(err, acme.ProblemUnauthorized) {
+		t.Errorf("NewOrder with a deactivated account: got error %v, want %s", err, acme.ProblemUnauthorized)
+	}
+}
+
+func isProblem(err error, typ string) bool {
+	var e *acme.Error
+	return errors.As(err, &e) && e.ProblemType == typ
+}
+
+// pendingChallenge creates an order for name, and returns its authorization
+// and the challenge of type typ.
+func pendingChallenge(t *testing.T, c *acme.Client, name, typ string) (*acme.Order, *acme.Authorization, *acme.Challenge) {
+	ctx := context.Background()
+	o, err := c.NewOrder(ctx, acme.DomainIdentifiers(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != acme.StatusPending || len(o.AuthorizationURLs) != 1 || o.URL == "" || o.FinalizeURL == "" {
+		t.Fatalf("NewOrder returned %+v", o)
+	}
+	z, err := c.GetAuthorization(ctx, o.AuthorizationURLs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z.Identifier != (acme.Identifier{Type: "dns", Value: name}) || z.Status != acme.StatusPending {
+		t.Fatalf("GetAuthorization returned %+v", z)
+	}
+	for _, ch := range z.Challenges {
+		if ch.Type == typ {
+			return o, z, ch
+		}
+	}
+	t.Fatalf("authorization has no %s challenge", typ)
+	return nil, nil, nil
+}
+
+// finishOrder completes an order whose authorizations were accepted, and
+// checks the issued certificate.
+func finishOrder(t *testing.T, ca *acmetest.CAServer, c *acme.Client, o *acme.Order, z *acme.Authorization) []byte {
+	ctx := context.Background()
+	if _, err := c.WaitAuthorization(ctx, z.URL); err != nil {
+		t.Fatal(err)
+	}
+	o, err := c.WaitOrder(ctx, o.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != acme.StatusReady {
+		t.Fatalf("order status is %s, want ready", o.Status)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := z.Identifier.Value
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{name}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, certURL, err := c.CreateOrderCert(ctx, o, csr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 {
+		t.Fatalf("got chain of %d certificates, want 2", len(chain))
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range chain[1:] {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: ca.Roots(), Intermediates: intermediates}); err != nil {
+		t.Errorf("issued certificate doesn't verify: %v", err)
+	}
+	if !key.PublicKey.Equal(leaf.PublicKey) {
+		t.Errorf("issued certificate is for another key")
+	}
+
+	again, err := c.FetchCert(ctx, certURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != len(chain) || string(again[0]) != string(chain[0]) {
+		t.Errorf("FetchCert returned a different chain")
+	}
+	return chain[0]
+}
+
+func TestOrderDNS01(t *testing.T) {
+	ca := newCA(t)
+	ctx := context.Background()
+	c := newClient(t, ca)
+	register(t, c)
+
+	o, z, ch := pendingChallenge(t, c, "example.org", "dns-01")
+	rec, err := c.DNS01ChallengeRecord(ch.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca.SetTXT("_acme-challenge.example.org", rec)
+	if _, err := c.Accept(ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+	cert := finishOrder(t, ca, c, o, z)
+
+	leaf, _ := x509.ParseCertificate(cert)
+	if err := c.RevokeCert(ctx, nil, cert, 4); err != nil {
+		t.Fatal(err)
+	}
+	if !ca.Revoked(leaf.SerialNumber) {
+		t.Error("certificate was not revoked")
+	}
+	if err := c.RevokeCert(ctx, nil, cert, 4); !isProblem(err, acme.ProblemAlreadyRevoked) {
+		t.Errorf("second RevokeCert: got error %v, want %s", err, acme.ProblemAlreadyRevoked)
+	}
+}
+
+func TestOrderHTTP01(t *testing.T) {
+	ca := newCA(t)
+	ctx := context.Background()
+	c := newClient(t, ca)
+	register(t, c)
+
+	o, z, ch := pendingChallenge(t, c, "example.org", "http-01")
+	resp, err := c.HTTP01ChallengeResponse(ch.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "example.org" || r.URL.Path != acme.HTTP01ChallengePath(ch.Token) {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+	ca.Resolve("example.org", srv.Listener.Addr().String())
+
+	if _, err := c.Accept(ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+	finishOrder(t, ca, c, o, z)
+}
+
+func TestOrderTLSALPN01(t *testing.T) {
+	ca := newCA(t)
+	ctx := context.Background()
+	c := newClient(t, ca)
+	register(t, c)
+
+	o, z, ch := pendingChallenge(t, c, "example.org", "tls-alpn-01")
+	cert, err := c.TLSALPN01ChallengeCert(ch.Token, "example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{acme.ALPNProto},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go serveHandshakes(ln)
+	ca.Resolve("example.org", ln.Addr().String())
+
+	if _, err := c.Accept(ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+	finishOrder(t, ca, c, o, z)
+}
+
+func serveHandshakes(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}()
+	}
+}
+
+func TestAuthorizationError(t *testing.T) {
+	ca := newCA(t)
+	ctx := context.Background()
+	c := newClient(t, ca)
+	register(t, c)
+
+	o, z, ch := pendingChallenge(t, c, "example.org", "dns-01")
+	ca.SetTXT("_acme-challenge.example.org", "wrong")
+	if _, err := c.Accept(ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.WaitAuthorization(ctx, z.URL)
+	var aerr *acme.AuthorizationError
+	if !errors.As(err, &aerr) {
+		t.Fatalf("WaitAuthorization: got error %v, want *AuthorizationError", err)
+	}
+	if aerr.Identifier.Value != "example.org" || !isProblem(err, acme.ProblemIncorrectResponse) {
+		t.Errorf("WaitAuthorization: got error %v", err)
+	}
+
+	_, err = c.WaitOrder(ctx, o.URL)
+	var oerr *acme.OrderError
+	if !errors.As(err, &oerr) || oerr.Status != acme.StatusInvalid {
+		t.Errorf("WaitOrder: got error %v, want invalid *OrderError", err)
+	}
+}
+
+func TestFinalizeNotReady(t *testing.T) {
+	ca := newCA(t)
+	c := newClient(t, ca)
+	register(t, c)
+
+	o, _, _ := pendingChallenge(t, c, "example.org", "dns-01")
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{"example.org"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.CreateOrderCert(context.Background(), o, csr); !isProblem(err, acme.ProblemOrderNotReady) {
+		t.Errorf("CreateOrderCert: got error %v, want %s", err, acme.ProblemOrderNotReady)
+	}
+}
+
+func TestBadNonceRetry(t *testing.T) {
+	ca := newCA(t)
+	c := newClient(t, ca)
+	register(t, c)
+
+	c.AddNonce("bogus")
+	if _, err := c.NewOrder(context.Background(), acme.DomainIdentifiers("example.org")); err != nil {
+		t.Errorf("NewOrder with a bad nonce: %v", err)
+	}
+}
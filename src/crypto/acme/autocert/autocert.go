@@ -0,0 +1,731 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package autocert obtains and renews TLS certificates automatically from an
+// ACME certificate authority, such as Let's Encrypt.
+//
+// A [Manager] obtains a certificate for a server name the first time a
+// client connects with it, stores it in a [Cache], and renews it in the
+// background before it expires. Use [Manager.TLSConfig] or
+// [Manager.GetCertificate] to configure a TLS server:
+//
+//	m := &autocert.Manager{
+//		Prompt:     autocert.AcceptTOS,
+//		Cache:      autocert.DirCache("certs"),
+//		HostPolicy: autocert.HostAllowlist("example.org", "www.example.org"),
+//	}
+//	s := &http.Server{
+//		Addr:      ":https",
+//		TLSConfig: m.TLSConfig(),
+//	}
+//	go http.ListenAndServe(":http", m.HTTPHandler(nil))
+//	log.Fatal(s.ListenAndServeTLS("", ""))
+package autocert
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/acme"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRenewBefore is the default of Manager.RenewBefore.
+	defaultRenewBefore = 30 * 24 * time.Hour
+
+	// obtainTimeout bounds the time spent obtaining a certificate.
+	obtainTimeout = 5 * time.Minute
+
+	// renewMinRetry and renewMaxRetry bound the delay before retrying a
+	// failed renewal.
+	renewMinRetry = time.Minute
+	renewMaxRetry = time.Hour
+
+	// accountKeyName is the cache key of the account key.
+	accountKeyName = "acme_account+key"
+)
+
+// AcceptTOS is a Manager.Prompt function that always accepts the terms of
+// service of the CA.
+func AcceptTOS(tosURL string) bool { return true }
+
+// A HostPolicy decides whether the Manager may obtain a certificate for host.
+// It returns a non-nil error to deny it.
+//
+// A HostPolicy is called for every new server name sent by clients, so it
+// must prevent the Manager from requesting certificates for arbitrary names,
+// which would quickly exhaust the rate limits of the CA.
+type HostPolicy func(ctx context.Context, host string) error
+
+// HostAllowlist returns a HostPolicy that only allows the given hosts.
+// Hosts are compared case-insensitively, and must not contain ports.
+func HostAllowlist(hosts ...string) HostPolicy {
+	allowed := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		allowed[normalizeHost(h)] = true
+	}
+	return func(_ context.Context, host string) error {
+		if !allowed[normalizeHost(host)] {
+			return fmt.Errorf("autocert: host %q is not in the allowlist", host)
+		}
+		return nil
+	}
+}
+
+// A DNS01Solver publishes the TXT records of dns-01 challenges.
+type DNS01Solver interface {
+	// Present publishes a TXT record with the given value at
+	// "_acme-challenge." followed by domain, and returns once the record
+	// is visible to the CA.
+	Present(ctx context.Context, domain, value string) error
+
+	// CleanUp removes the record published by Present.
+	CleanUp(ctx context.Context, domain, value string) error
+}
+
+// A Manager obtains certificates from an ACME CA for the server names
+// requested by TLS clients, and renews them before they expire.
+//
+// The Manager registers an account with the CA the first time it needs a
+// certificate. Certificates use ECDSA P-256 keys.
+//
+// A Manager must not be copied after first use, and its fields must not be
+// changed once it's in use.
+type Manager struct {
+	// Prompt is called with the URL of the CA's terms of service when
+	// registering the account, and must return true to accept them. It is
+	// required by most CAs; see AcceptTOS.
+	Prompt func(tosURL string) bool
+
+	// Cache stores certificates and the account key. If nil, nothing is
+	// stored: a new account is registered and new certificates are obtained
+	// every time the program starts, which is likely to hit the rate limits
+	// of the CA.
+	Cache Cache
+
+	// HostPolicy restricts the names the Manager obtains certificates for.
+	// If nil, any name is allowed, which is only appropriate for servers
+	// that can't be reached with arbitrary names.
+	HostPolicy HostPolicy
+
+	// RenewBefore is how long before expiry certificates are renewed. If
+	// zero, they are renewed 30 days before expiry. Certificates with a
+	// shorter lifetime are renewed halfway through their remaining validity.
+	RenewBefore time.Duration
+
+	// Client is the ACME client used to talk to the CA. If nil, a client for
+	// acme.LetsEncryptURL is used. If Client.Key is nil, it is set to a key
+	// loaded from the Cache, or to a new ECDSA P-256 key stored in the Cache.
+	Client *acme.Client
+
+	// Email is the contact address of the account, if any. CAs may use it
+	// to warn about expiring certificates or problems with the account.
+	Email string
+
+	// ExternalAccountBinding binds the account to an existing account with
+	// the CA, as required by some CAs.
+	ExternalAccountBinding *acme.ExternalAccountBinding
+
+	// DNS01Solver publishes the records of dns-01 challenges. If nil, dns-01
+	// challenges are not used.
+	DNS01Solver DNS01Solver
+
+	// ChallengeTypes are the challenge types the Manager uses, in order of
+	// preference. If nil, it uses "dns-01" if DNS01Solver is set, then
+	// "tls-alpn-01", then "http-01" if HTTPHandler was called.
+	//
+	// tls-alpn-01 challenges require the server to negotiate the
+	// acme.ALPNProto protocol, as configured by TLSConfig. http-01
+	// challenges require the handler returned by HTTPHandler to serve port
+	// 80 of the host.
+	ChallengeTypes []string
+
+	clientMu sync.Mutex
+	client   *acme.Client // registered client
+
+	stateMu sync.Mutex
+	state   map[string]*certState
+
+	challengeMu     sync.Mutex
+	alpnCerts       map[string]*tls.Certificate // by server name
+	httpTokens      map[string][]byte           // by request path
+	httpHandlerUsed bool
+}
+
+// A certState holds the certificate of a server name.
+type certState struct {
+	mu      sync.Mutex
+	cert    *tls.Certificate // with Leaf set, or nil
+	timer   *time.Timer      // renewal timer
+	retry   time.Duration    // delay before retrying a failed renewal
+	renewal bool             // a renewal is in progress
+}
+
+// TLSConfig returns a TLS configuration that serves certificates from m, and
+// negotiates HTTP/2, HTTP/1.1 and the ALPN protocol of tls-alpn-01
+// challenges.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: m.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1", acme.ALPNProto},
+	}
+}
+
+// GetCertificate returns a certificate for hello.ServerName, obtaining it from
+// the CA if necessary. It implements [crypto/tls.Config.GetCertificate].
+//
+// It also serves the certificates of pending tls-alpn-01 challenges to the
+// CA.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	name := hello.ServerName
+	if name == "" {
+		return nil, errors.New("autocert: missing server name")
+	}
+	if !strings.Contains(strings.Trim(name, "."), ".") {
+		return nil, fmt.Errorf("autocert: server name %q is not a fully qualified domain name", name)
+	}
+	if strings.ContainsAny(name, `+/\:*?"<>|`) {
+		return nil, fmt.Errorf("autocert: invalid server name %q", name)
+	}
+	name = normalizeHost(name)
+
+	if slices.Equal(hello.SupportedProtos, []string{acme.ALPNProto}) {
+		m.challengeMu.Lock()
+		defer m.challengeMu.Unlock()
+		if cert := m.alpnCerts[name]; cert != nil {
+			return cert, nil
+		}
+		return nil, fmt.Errorf("autocert: no tls-alpn-01 challenge pending for %q", name)
+	}
+
+	ctx := hello.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, obtainTimeout)
+	defer cancel()
+	return m.cert(ctx, name)
+}
+
+// cert returns the certificate of name, from memory, the cache, or the CA.
+func (m *Manager) cert(ctx context.Context, name string) (*tls.Certificate, error) {
+	m.stateMu.Lock()
+	st := m.state[name]
+	m.stateMu.Unlock()
+	if st != nil {
+		st.mu.Lock()
+		cert := st.cert
+		st.mu.Unlock()
+		if cert != nil && timeNow().Before(cert.Leaf.NotAfter) {
+			return cert, nil
+		}
+	}
+
+	if err := m.hostPolicy()(ctx, name); err != nil {
+		return nil, err
+	}
+
+	m.stateMu.Lock()
+	if m.state == nil {
+		m.state = make(map[string]*certState)
+	}
+	st = m.state[name]
+	if st == nil {
+		st = &certState{}
+		m.state[name] = st
+	}
+	m.stateMu.Unlock()
+
+	// Holding st.mu makes concurrent handshakes for name wait for a single
+	// certificate to be obtained.
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.cert != nil && timeNow().Before(st.cert.Leaf.NotAfter) {
+		return st.cert, nil
+	}
+	cert, err := m.cacheGet(ctx, name)
+	if err != nil {
+		if cert, err = m.obtain(ctx, name); err != nil {
+			return nil, err
+		}
+		m.cachePut(ctx, name, cert)
+	}
+	st.cert = cert
+	m.scheduleRenewal(name, st)
+	return cert, nil
+}
+
+func (m *Manager) hostPolicy() HostPolicy {
+	if m.HostPolicy != nil {
+		return m.HostPolicy
+	}
+	return func(context.Context, string) error { return nil }
+}
+
+func (m *Manager) renewBefore() time.Duration {
+	if m.RenewBefore > 0 {
+		return m.RenewBefore
+	}
+	return defaultRenewBefore
+}
+
+// scheduleRenewal sets the renewal timer of st. st.mu must be held.
+func (m *Manager) scheduleRenewal(name string, st *certState) {
+	remaining := st.cert.Leaf.NotAfter.Sub(timeNow())
+	d := remaining - m.renewBefore()
+	if d < 0 {
+		d = remaining / 2
+	}
+	st.retry = renewMinRetry
+	st.resetTimer(d, func() { m.renew(name, st) })
+}
+
+func (st *certState) resetTimer(d time.Duration, f func()) {
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	st.timer = time.AfterFunc(d, f)
+}
+
+// renew obtains a new certificate for name in the background, while the
+// current one keeps being served.
+func (m *Manager) renew(name string, st *certState) {
+	st.mu.Lock()
+	if st.renewal {
+		st.mu.Unlock()
+		return
+	}
+	st.renewal = true
+	st.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), obtainTimeout)
+	defer cancel()
+	cert, err := m.obtain(ctx, name)
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.renewal = false
+	if err != nil {
+		retry := st.retry
+		st.retry = min(2*st.retry, renewMaxRetry)
+		st.resetTimer(retry, func() { m.renew(name, st) })
+		return
+	}
+	m.cachePut(ctx, name, cert)
+	st.cert = cert
+	m.scheduleRenewal(name, st)
+}
+
+// obtain gets a new certificate for name from the CA.
+func (m *Manager) obtain(ctx context.Context, name string) (*tls.Certificate, error) {
+	client, err := m.acmeClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := client.NewOrder(ctx, acme.DomainIdentifiers(name))
+	if err != nil {
+		return nil, err
+	}
+	for _, url := range order.AuthorizationURLs {
+		z, err := client.GetAuthorization(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		switch z.Status {
+		case acme.StatusValid:
+			continue
+		case acme.StatusPending:
+		default:
+			return nil, fmt.Errorf("autocert: authorization for %s is %s", z.Identifier.Value, z.Status)
+		}
+		if err := m.authorize(ctx, client, z); err != nil {
+			return nil, err
+		}
+	}
+	order, err = client.WaitOrder(ctx, order.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{name}}, key)
+	if err != nil {
+		return nil, err
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order, csr)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := validCert(name, chain, key, timeNow())
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{Certificate: chain, PrivateKey: key, Leaf: leaf}, nil
+}
+
+// authorize completes one of the challenges of the pending authorization z.
+func (m *Manager) authorize(ctx context.Context, client *acme.Client, z *acme.Authorization) error {
+	var chal *acme.Challenge
+	for _, typ := range m.challengeTypes() {
+		for _, c := range z.Challenges {
+			if c.Type == typ {
+				chal = c
+				break
+			}
+		}
+		if chal != nil {
+			break
+		}
+	}
+	if chal == nil {
+		var offered []string
+		for _, c := range z.Challenges {
+			offered = append(offered, c.Type)
+		}
+		return fmt.Errorf("autocert: no supported challenge for %s among %v", z.Identifier.Value, offered)
+	}
+
+	cleanup, err := m.fulfill(ctx, client, chal, z.Identifier.Value)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return err
+	}
+	_, err = client.WaitAuthorization(ctx, z.URL)
+	return err
+}
+
+func (m *Manager) challengeTypes() []string {
+	if m.ChallengeTypes != nil {
+		return m.ChallengeTypes
+	}
+	var types []string
+	if m.DNS01Solver != nil {
+		types = append(types, "dns-01")
+	}
+	types = append(types, "tls-alpn-01")
+	m.challengeMu.Lock()
+	if m.httpHandlerUsed {
+		types = append(types, "http-01")
+	}
+	m.challengeMu.Unlock()
+	return types
+}
+
+// fulfill puts in place the response to chal, and returns a function that
+// removes it.
+func (m *Manager) fulfill(ctx context.Context, client *acme.Client, chal *acme.Challenge, name string) (cleanup func(), err error) {
+	switch chal.Type {
+	case "tls-alpn-01":
+		cert, err := client.TLSALPN01ChallengeCert(chal.Token, name)
+		if err != nil {
+			return nil, err
+		}
+		m.challengeMu.Lock()
+		defer m.challengeMu.Unlock()
+		if m.alpnCerts == nil {
+			m.alpnCerts = make(map[string]*tls.Certificate)
+		}
+		m.alpnCerts[name] = &cert
+		return func() {
+			m.challengeMu.Lock()
+			defer m.challengeMu.Unlock()
+			delete(m.alpnCerts, name)
+		}, nil
+
+	case "http-01":
+		resp, err := client.HTTP01ChallengeResponse(chal.Token)
+		if err != nil {
+			return nil, err
+		}
+		path := acme.HTTP01ChallengePath(chal.Token)
+		m.challengeMu.Lock()
+		if m.httpTokens == nil {
+			m.httpTokens = make(map[string][]byte)
+		}
+		m.httpTokens[path] = []byte(resp)
+		m.challengeMu.Unlock()
+		// Store the response in the cache too, so that other servers
+		// sharing it can answer the challenge.
+		key := httpTokenCacheKey(chal.Token)
+		if m.Cache != nil {
+			m.Cache.Put(ctx, key, []byte(resp))
+		}
+		return func() {
+			m.challengeMu.Lock()
+			delete(m.httpTokens, path)
+			m.challengeMu.Unlock()
+			if m.Cache != nil {
+				m.Cache.Delete(context.Background(), key)
+			}
+		}, nil
+
+	case "dns-01":
+		if m.DNS01Solver == nil {
+			return nil, errors.New("autocert: dns-01 challenges require a DNS01Solver")
+		}
+		value, err := client.DNS01ChallengeRecord(chal.Token)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.DNS01Solver.Present(ctx, name, value); err != nil {
+			return nil, err
+		}
+		return func() {
+			m.DNS01Solver.CleanUp(context.Background(), name, value)
+		}, nil
+	}
+	return nil, fmt.Errorf("autocert: unsupported challenge type %q", chal.Type)
+}
+
+func httpTokenCacheKey(token string) string {
+	return token + "+http-01"
+}
+
+// acmeClient returns m's ACME client, registering its account the first
+// time.
+func (m *Manager) acmeClient(ctx context.Context) (*acme.Client, error) {
+	m.clientMu.Lock()
+	defer m.clientMu.Unlock()
+	if m.client != nil {
+		return m.client, nil
+	}
+
+	client := m.Client
+	if client == nil {
+		client = &acme.Client{DirectoryURL: acme.LetsEncryptURL}
+	}
+	if client.Key == nil {
+		key, err := m.accountKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		client.Key = key
+	}
+	acct := &acme.Account{ExternalAccountBinding: m.ExternalAccountBinding}
+	if m.Email != "" {
+		acct.Contact = []string{"mailto:" + m.Email}
+	}
+	if _, err := client.Register(ctx, acct, m.Prompt); err != nil {
+		return nil, err
+	}
+	m.client = client
+	return client, nil
+}
+
+// accountKey loads the account key from the cache, or generates and stores
+// a new one.
+func (m *Manager) accountKey(ctx context.Context) (crypto.Signer, error) {
+	if m.Cache != nil {
+		data, err := m.Cache.Get(ctx, accountKeyName)
+		switch {
+		case err == nil:
+			block, _ := pem.Decode(data)
+			if block == nil || block.Type != "PRIVATE KEY" {
+				return nil, errors.New("autocert: invalid account key in cache")
+			}
+			return parsePrivateKey(block.Bytes)
+		case err != ErrCacheMiss:
+			return nil, err
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cache != nil {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		if err := m.Cache.Put(ctx, accountKeyName, data); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// cacheGet loads a valid certificate for name from the cache. The cached
+// data holds the PEM-encoded private key followed by the certificate chain.
+func (m *Manager) cacheGet(ctx context.Context, name string) (*tls.Certificate, error) {
+	if m.Cache == nil {
+		return nil, ErrCacheMiss
+	}
+	data, err := m.Cache.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	block, rest := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("autocert: invalid private key in cache")
+	}
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	var chain [][]byte
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.New("autocert: invalid certificate in cache")
+		}
+		chain = append(chain, block.Bytes)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("autocert: trailing data in cache")
+	}
+	leaf, err := validCert(name, chain, key, timeNow())
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{Certificate: chain, PrivateKey: key, Leaf: leaf}, nil
+}
+
+// cachePut stores cert under name. Errors are ignored: the certificate can
+// still be served, and will be obtained again after a restart.
+func (m *Manager) cachePut(ctx context.Context, name string, cert *tls.Certificate) {
+	if m.Cache == nil {
+		return
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	pem.Encode(&buf, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	for _, c := range cert.Certificate {
+		pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: c})
+	}
+	m.Cache.Put(ctx, name, buf.Bytes())
+}
+
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("autocert: unsupported private key type")
+	}
+	return signer, nil
+}
+
+// validCert parses chain and checks that its leaf is valid for name at now
+// and matches key.
+func validCert(name string, chain [][]byte, key crypto.Signer, now time.Time) (*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("autocert: empty certificate chain")
+	}
+	var leaf *x509.Certificate
+	for i, der := range chain {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			leaf = c
+		}
+	}
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return nil, errors.New("autocert: certificate is expired or not yet valid")
+	}
+	if err := leaf.VerifyHostname(name); err != nil {
+		return nil, err
+	}
+	pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(leaf.PublicKey) {
+		return nil, errors.New("autocert: private key doesn't match the certificate")
+	}
+	return leaf, nil
+}
+
+// HTTPHandler returns a handler that answers http-01 challenges, and passes
+// all other requests to fallback. If fallback is nil, other GET and HEAD
+// requests are redirected to HTTPS, and other requests are rejected.
+//
+// Calling HTTPHandler enables http-01 challenges, unless m.ChallengeTypes is
+// set. The handler must serve port 80 of the hosts m obtains certificates
+// for.
+func (m *Manager) HTTPHandler(fallback http.Handler) http.Handler {
+	m.challengeMu.Lock()
+	m.httpHandlerUsed = true
+	m.challengeMu.Unlock()
+	if fallback == nil {
+		fallback = http.HandlerFunc(redirectToHTTPS)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.URL.Path, "/.well-known/acme-challenge/")
+		if !ok {
+			fallback.ServeHTTP(w, r)
+			return
+		}
+		if err := m.hostPolicy()(r.Context(), normalizeHost(stripPort(r.Host))); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		m.challengeMu.Lock()
+		resp, ok := m.httpTokens[r.URL.Path]
+		m.challengeMu.Unlock()
+		if !ok && m.Cache != nil && !strings.ContainsAny(token, `+/\:*?"<>|`) {
+			var err error
+			resp, err = m.Cache.Get(r.Context(), httpTokenCacheKey(token))
+			ok = err == nil
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(resp)
+	})
+}
+
+func redirectToHTTPS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, "Use HTTPS", http.StatusBadRequest)
+		return
+	}
+	target := "https://" + stripPort(r.Host) + r.URL.RequestURI()
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+func stripPort(hostport string) string {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport
+	}
+	return host
+}
+
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// timeNow is time.Now, except in tests.
+var timeNow = time.Now
@@ -0,0 +1,317 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autocert
+
+import (
+	"context"
+	"crypto/acme"
+	"crypto/acme/internal/acmetest"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newCA(t *testing.T, challengeTypes ...string) *acmetest.CAServer {
+	ca := acmetest.NewCAServer()
+	t.Cleanup(ca.Close)
+	ca.ChallengeTypes(challengeTypes...)
+	return ca
+}
+
+func newManager(ca *acmetest.CAServer, cache Cache) *Manager {
+	return &Manager{
+		Prompt:     AcceptTOS,
+		Cache:      cache,
+		HostPolicy: HostAllowlist("example.org"),
+		Client:     &acme.Client{DirectoryURL: ca.URL},
+	}
+}
+
+// serveTLS serves TLS handshakes for m on a local address until the test
+// ends, and returns the address.
+func serveTLS(t *testing.T, m *Manager) string {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", m.TLSConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// dial connects to addr as a client of name, verifying the certificate
+// with the roots of ca, and returns the connection state.
+func dial(t *testing.T, ca *acmetest.CAServer, addr, name string) *tls.ConnectionState {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: name, RootCAs: ca.Roots()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	state := conn.ConnectionState()
+	return &state
+}
+
+func TestManagerTLSALPN01(t *testing.T) {
+	ca := newCA(t, "tls-alpn-01")
+	cache := DirCache(t.TempDir())
+	m := newManager(ca, cache)
+	addr := serveTLS(t, m)
+	ca.Resolve("example.org", addr)
+
+	state := dial(t, ca, addr, "example.org")
+	if n := len(ca.IssuedCerts()); n != 1 {
+		t.Fatalf("CA issued %d certificates, want 1", n)
+	}
+	serial := state.PeerCertificates[0].SerialNumber
+
+	// The certificate is reused for new connections, and by a new Manager
+	// sharing the cache.
+	state = dial(t, ca, addr, "EXAMPLE.org.")
+	if state.PeerCertificates[0].SerialNumber.Cmp(serial) != 0 {
+		t.Errorf("second connection got a different certificate")
+	}
+	m2 := newManager(ca, cache)
+	cert, err := m2.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.Leaf.SerialNumber.Cmp(serial) != 0 {
+		t.Errorf("new Manager didn't use the cached certificate")
+	}
+	if n := len(ca.IssuedCerts()); n != 1 {
+		t.Errorf("CA issued %d certificates, want 1", n)
+	}
+}
+
+func TestManagerHTTP01(t *testing.T) {
+	ca := newCA(t, "http-01")
+	m := newManager(ca, nil)
+	srv := httptest.NewServer(m.HTTPHandler(nil))
+	defer srv.Close()
+	ca.Resolve("example.org", srv.Listener.Addr().String())
+
+	cert, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.Leaf.VerifyHostname("example.org"); err != nil {
+		t.Error(err)
+	}
+	if len(m.httpTokens) != 0 {
+		t.Errorf("http-01 responses were not cleaned up")
+	}
+}
+
+func TestManagerHTTP01SharedCache(t *testing.T) {
+	// The challenge is answered by another server sharing the cache.
+	ca := newCA(t, "http-01")
+	cache := DirCache(t.TempDir())
+	m := newManager(ca, cache)
+	m.ChallengeTypes = []string{"http-01"}
+	other := newManager(ca, cache)
+	srv := httptest.NewServer(other.HTTPHandler(nil))
+	defer srv.Close()
+	ca.Resolve("example.org", srv.Listener.Addr().String())
+
+	if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type dnsSolver struct {
+	ca *acmetest.CAServer
+
+	mu      sync.Mutex
+	present map[string]string
+}
+
+func (s *dnsSolver) Present(ctx context.Context, domain, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.present[domain] = value
+	s.ca.SetTXT("_acme-challenge."+domain, value)
+	return nil
+}
+
+func (s *dnsSolver) CleanUp(ctx context.Context, domain, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.present, domain)
+	s.ca.SetTXT("_acme-challenge." + domain)
+	return nil
+}
+
+func TestManagerDNS01(t *testing.T) {
+	ca := newCA(t, "tls-alpn-01", "dns-01")
+	solver := &dnsSolver{ca: ca, present: make(map[string]string)}
+	m := newManager(ca, nil)
+	m.DNS01Solver = solver
+
+	if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(solver.present) != 0 {
+		t.Errorf("TXT records were not cleaned up: %v", solver.present)
+	}
+}
+
+func TestManagerChallengeFailure(t *testing.T) {
+	ca := newCA(t, "http-01")
+	m := newManager(ca, nil)
+	m.HTTPHandler(nil) // no server answers the challenge
+
+	_, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"})
+	if err == nil {
+		t.Fatal("GetCertificate succeeded without answering the challenge")
+	}
+	if aerr := new(acme.AuthorizationError); !errors.As(err, &aerr) {
+		t.Errorf("GetCertificate: got error %v, want *acme.AuthorizationError", err)
+	}
+}
+
+func TestManagerHostPolicy(t *testing.T) {
+	ca := newCA(t, "tls-alpn-01")
+	m := newManager(ca, nil)
+	for _, name := range []string{"example.com", "", "localhost", "a/b.example.org"} {
+		if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: name}); err == nil {
+			t.Errorf("GetCertificate(%q) succeeded", name)
+		}
+	}
+	if n := len(ca.IssuedCerts()); n != 0 {
+		t.Errorf("CA issued %d certificates, want 0", n)
+	}
+}
+
+func TestManagerRenewal(t *testing.T) {
+	ca := newCA(t, "tls-alpn-01")
+	ca.CertValidity(4 * time.Second)
+	m := newManager(ca, nil)
+	addr := serveTLS(t, m)
+	ca.Resolve("example.org", addr)
+
+	first := dial(t, ca, addr, "example.org").PeerCertificates[0]
+	// Certificates valid for less than RenewBefore are renewed halfway
+	// through their remaining validity.
+	ca.CertValidity(time.Hour)
+	deadline := time.Now().Add(10 * time.Second)
+	for len(ca.IssuedCerts()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("certificate was not renewed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	for {
+		cert, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cert.Leaf.SerialNumber.Cmp(first.SerialNumber) != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("renewed certificate is not served")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if n := len(ca.IssuedCerts()); n != 2 {
+		t.Errorf("CA issued %d certificates, want 2", n)
+	}
+}
+
+func TestHTTPHandlerRedirect(t *testing.T) {
+	m := &Manager{}
+	h := m.HTTPHandler(nil)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://example.org:80/path?q=1", nil))
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "https://example.org/path?q=1" {
+		t.Errorf("GET: got %d to %q", w.Code, w.Header().Get("Location"))
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "http://example.org/path", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("POST: got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://example.org/.well-known/acme-challenge/unknown", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown token: got status %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestDirCache(t *testing.T) {
+	ctx := context.Background()
+	d := DirCache(t.TempDir() + "/certs")
+	if _, err := d.Get(ctx, "example.org"); err != ErrCacheMiss {
+		t.Fatalf("Get of missing key: got error %v, want ErrCacheMiss", err)
+	}
+	if err := d.Put(ctx, "example.org", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := d.Get(ctx, "example.org"); err != nil || string(data) != "data" {
+		t.Fatalf("Get = %q, %v; want %q", data, err, "data")
+	}
+	if err := d.Put(ctx, "../escape", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := d.Get(ctx, "escape"); err != nil || string(data) != "data" {
+		t.Errorf("key with a relative path was not confined to the directory: %q, %v", data, err)
+	}
+	if err := d.Delete(ctx, "example.org"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Delete(ctx, "example.org"); err != nil {
+		t.Errorf("Delete of missing key: %v", err)
+	}
+	if _, err := d.Get(ctx, "example.org"); err != ErrCacheMiss {
+		t.Errorf("Get of deleted key: got error %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestHostAllowlist(t *testing.T) {
+	policy := HostAllowlist("Example.org", "www.example.org.")
+	for host, ok := range map[string]bool{
+		"example.org":      true,
+		"EXAMPLE.ORG":      true,
+		"www.example.org":  true,
+		"example.com":      false,
+		"sub.example.org":  false,
+		"example.org:443":  false,
+		"":                 false,
+		"wwwexample.org":   false,
+		"www.example.org.": true,
+	} {
+		if err := policy(context.Background(), host); (err == nil) != ok {
+			t.Errorf("policy(%q) = %v", host, err)
+		}
+	}
+}
+
+func TestGetCertificateALPNChallenge(t *testing.T) {
+	m := &Manager{}
+	hello := &tls.ClientHelloInfo{ServerName: "example.org", SupportedProtos: []string{acme.ALPNProto}}
+	if _, err := m.GetCertificate(hello); err == nil || !strings.Contains(err.Error(), "no tls-alpn-01 challenge") {
+		t.Errorf("GetCertificate without a pending challenge: got error %v", err)
+	}
+}
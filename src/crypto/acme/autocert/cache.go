@@ -0,0 +1,91 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package autocert
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrCacheMiss is returned by [Cache.Get] when there is no data for a key.
+var ErrCacheMiss = errors.New("autocert: cache miss")
+
+// A Cache stores certificates, their private keys, and account keys obtained
+// by a [Manager], so that they survive restarts and can be shared between
+// servers.
+//
+// The data is sensitive: implementations must protect it from unauthorized
+// access. Keys are made of printable ASCII characters other than
+// \/:*?"<>|, but implementations should not rely on their format.
+type Cache interface {
+	// Get returns the data stored under key, or ErrCacheMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Put stores data under key, replacing any previous data.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Delete removes the data stored under key. It returns nil if there is
+	// no such data.
+	Delete(ctx context.Context, key string) error
+}
+
+// DirCache is a [Cache] that stores each key in a file of the named
+// directory. The directory is created with permissions 0700 if it doesn't
+// exist, and files are created with permissions 0600.
+type DirCache string
+
+func (d DirCache) path(key string) string {
+	return filepath.Join(string(d), filepath.Clean("/"+key))
+}
+
+// Get reads the file of key.
+func (d DirCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	return data, err
+}
+
+// Put atomically replaces the file of key.
+func (d DirCache) Put(ctx context.Context, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(string(d), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(string(d), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), d.path(key))
+}
+
+// Delete removes the file of key.
+func (d DirCache) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := os.Remove(d.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
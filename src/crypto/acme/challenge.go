@@ -0,0 +1,111 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net"
+	"time"
+)
+
+// idPeACMEIdentifier is the OID of the acmeIdentifier extension of
+// tls-alpn-01 challenge certificates, as specified in RFC 8737, Section 6.1.
+var idPeACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// keyAuthorization returns the key authorization of token for the account
+// key pub, as specified in RFC 8555, Section 8.1.
+func keyAuthorization(pub crypto.PublicKey, token string) (string, error) {
+	th, err := JWKThumbprint(pub)
+	if err != nil {
+		return "", err
+	}
+	return token + "." + th, nil
+}
+
+// HTTP01ChallengePath returns the path at which the response to an http-01
+// challenge with the given token must be served, over plain HTTP on port 80.
+func HTTP01ChallengePath(token string) string {
+	return "/.well-known/acme-challenge/" + token
+}
+
+// HTTP01ChallengeResponse returns the body that must be served at
+// [HTTP01ChallengePath] for the http-01 challenge with the given token.
+func (c *Client) HTTP01ChallengeResponse(token string) (string, error) {
+	return keyAuthorization(c.Key.Public(), token)
+}
+
+// DNS01ChallengeRecord returns the value of the TXT record that must be
+// published at "_acme-challenge." followed by the domain name, for the dns-01
+// challenge with the given token.
+func (c *Client) DNS01ChallengeRecord(token string) (string, error) {
+	ka, err := keyAuthorization(c.Key.Public(), token)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(ka))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// TLSALPN01ChallengeCert returns the self-signed certificate that must be
+// served for the tls-alpn-01 challenge with the given token, as specified in
+// RFC 8737. identifier is the domain name or IP address being validated.
+//
+// The certificate must only be served to connections whose ClientHello
+// offers only the [ALPNProto] protocol and, for domain names, whose server
+// name is identifier.
+func (c *Client) TLSALPN01ChallengeCert(token, identifier string) (tls.Certificate, error) {
+	ka, err := keyAuthorization(c.Key.Public(), token)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	sum := sha256.Sum256([]byte(ka))
+	ext, err := asn1.Marshal(sum[:])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := timeNow()
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{
+			{Id: idPeACMEIdentifier, Critical: true, Value: ext},
+		},
+	}
+	if ip := net.ParseIP(identifier); ip != nil {
+		tmpl.IPAddresses = []net.IP{ip}
+	} else if identifier != "" {
+		tmpl.DNSNames = []string{identifier}
+	} else {
+		return tls.Certificate{}, errors.New("acme: empty identifier")
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
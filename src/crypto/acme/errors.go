@@ -0,0 +1,195 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Problem types defined in RFC 8555, Section 6.7.
+const (
+	ProblemAccountDoesNotExist     = "urn:ietf:params:acme:error:accountDoesNotExist"
+	ProblemAlreadyRevoked          = "urn:ietf:params:acme:error:alreadyRevoked"
+	ProblemBadCSR                  = "urn:ietf:params:acme:error:badCSR"
+	ProblemBadNonce                = "urn:ietf:params:acme:error:badNonce"
+	ProblemBadPublicKey            = "urn:ietf:params:acme:error:badPublicKey"
+	ProblemBadRevocationReason     = "urn:ietf:params:acme:error:badRevocationReason"
+	ProblemBadSignatureAlgorithm   = "urn:ietf:params:acme:error:badSignatureAlgorithm"
+	ProblemCAA                     = "urn:ietf:params:acme:error:caa"
+	ProblemCompound                = "urn:ietf:params:acme:error:compound"
+	ProblemConnection              = "urn:ietf:params:acme:error:connection"
+	ProblemDNS                     = "urn:ietf:params:acme:error:dns"
+	ProblemExternalAccountRequired = "urn:ietf:params:acme:error:externalAccountRequired"
+	ProblemIncorrectResponse       = "urn:ietf:params:acme:error:incorrectResponse"
+	ProblemInvalidContact          = "urn:ietf:params:acme:error:invalidContact"
+	ProblemMalformed               = "urn:ietf:params:acme:error:malformed"
+	ProblemOrderNotReady           = "urn:ietf:params:acme:error:orderNotReady"
+	ProblemRateLimited             = "urn:ietf:params:acme:error:rateLimited"
+	ProblemRejectedIdentifier      = "urn:ietf:params:acme:error:rejectedIdentifier"
+	ProblemServerInternal          = "urn:ietf:params:acme:error:serverInternal"
+	ProblemTLS                     = "urn:ietf:params:acme:error:tls"
+	ProblemUnauthorized            = "urn:ietf:params:acme:error:unauthorized"
+	ProblemUnsupportedContact      = "urn:ietf:params:acme:error:unsupportedContact"
+	ProblemUnsupportedIdentifier   = "urn:ietf:params:acme:error:unsupportedIdentifier"
+	ProblemUserActionRequired      = "urn:ietf:params:acme:error:userActionRequired"
+)
+
+// An Error is a problem document returned by the CA, as specified in
+// RFC 7807 and RFC 8555, Section 6.7.
+type Error struct {
+	// StatusCode is the HTTP status code of the response, or zero if the
+	// problem was embedded in another object, such as a Challenge.
+	StatusCode int
+
+	// ProblemType identifies the type of the problem, such as
+	// ProblemBadNonce.
+	ProblemType string
+
+	// Detail is a human-readable explanation of the problem.
+	Detail string
+
+	// Instance is a URL the account holder should visit to resolve the
+	// problem, for example to agree to updated terms of service.
+	Instance string
+
+	// Header holds the headers of the response. It may be nil.
+	Header http.Header
+
+	// Subproblems lists the problems with individual identifiers that make
+	// up this problem.
+	Subproblems []Subproblem
+}
+
+// A Subproblem is a problem with a single identifier, as specified in
+// RFC 8555, Section 6.7.1.
+type Subproblem struct {
+	ProblemType string
+	Detail      string
+	Identifier  *Identifier
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("acme: ")
+	if e.StatusCode != 0 {
+		b.WriteString(strconv.Itoa(e.StatusCode))
+		b.WriteString(" ")
+	}
+	if e.ProblemType != "" {
+		b.WriteString(e.ProblemType)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Detail)
+	for _, sp := range e.Subproblems {
+		b.WriteString("; ")
+		if sp.Identifier != nil {
+			b.WriteString(sp.Identifier.Value)
+			b.WriteString(": ")
+		}
+		b.WriteString(sp.ProblemType)
+		if sp.Detail != "" {
+			b.WriteString(": ")
+			b.WriteString(sp.Detail)
+		}
+	}
+	return b.String()
+}
+
+// wireError is the JSON encoding of a problem document.
+type wireError struct {
+	Type        string `json:"type"`
+	Detail      string `json:"detail"`
+	Instance    string `json:"instance"`
+	Status      int    `json:"status"`
+	Subproblems []struct {
+		Type       string      `json:"type"`
+		Detail     string      `json:"detail"`
+		Identifier *Identifier `json:"identifier"`
+	} `json:"subproblems"`
+}
+
+func (we *wireError) error(h http.Header) *Error {
+	e := &Error{
+		StatusCode:  we.Status,
+		ProblemType: we.Type,
+		Detail:      we.Detail,
+		Instance:    we.Instance,
+		Header:      h,
+	}
+	for _, sp := range we.Subproblems {
+		e.Subproblems = append(e.Subproblems, Subproblem{
+			ProblemType: sp.Type,
+			Detail:      sp.Detail,
+			Identifier:  sp.Identifier,
+		})
+	}
+	return e
+}
+
+// An AuthorizationError is returned by [Client.WaitAuthorization] when an
+// authorization becomes invalid.
+type AuthorizationError struct {
+	// URL is the URL of the authorization.
+	URL string
+
+	// Identifier is the identifier of the authorization.
+	Identifier Identifier
+
+	// Errors holds the errors of the challenges that failed, as *Error.
+	Errors []error
+}
+
+func (e *AuthorizationError) Error() string {
+	msg := "acme: authorization for " + e.Identifier.Value + " failed"
+	if len(e.Errors) == 0 {
+		return msg
+	}
+	var details []string
+	for _, err := range e.Errors {
+		details = append(details, strings.TrimPrefix(err.Error(), "acme: "))
+	}
+	return msg + ": " + strings.Join(details, "; ")
+}
+
+func (e *AuthorizationError) Unwrap() []error {
+	return e.Errors
+}
+
+// An OrderError is returned by [Client.WaitOrder] and
+// [Client.CreateOrderCert] when an order becomes invalid.
+type OrderError struct {
+	// URL is the URL of the order.
+	URL string
+
+	// Status is the status of the order.
+	Status string
+
+	// Err is the problem reported by the CA, if any.
+	Err *Error
+}
+
+func (e *OrderError) Error() string {
+	msg := "acme: order " + e.URL + " is " + e.Status
+	if e.Err != nil {
+		msg += ": " + strings.TrimPrefix(e.Err.Error(), "acme: ")
+	}
+	return msg
+}
+
+func (e *OrderError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// isProblem reports whether err is an *Error of the given problem type.
+func isProblem(err error, problemType string) bool {
+	var e *Error
+	return errors.As(err, &e) && e.ProblemType == problemType
+}
@@ -0,0 +1,12 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+// AddNonce adds nonce to the nonces of c, for testing bad nonce handling.
+func (c *Client) AddNonce(nonce string) {
+	c.noncesMu.Lock()
+	defer c.noncesMu.Unlock()
+	c.nonces = append(c.nonces, nonce)
+}
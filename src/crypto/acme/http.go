@@ -0,0 +1,288 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRetries is the maximum number of attempts of a request that fails
+	// with a bad nonce or a retriable status code.
+	maxRetries = 5
+
+	// maxBackoff caps the delay between retries.
+	maxBackoff = 10 * time.Second
+
+	// maxNonces bounds the number of unused nonces kept by a Client.
+	maxNonces = 64
+
+	// maxResponseSize bounds the size of response bodies.
+	maxResponseSize = 5 << 20
+)
+
+// get sends an unauthenticated GET request, only used for the directory.
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, responseError(res)
+	}
+	return res, nil
+}
+
+// postAsGet fetches the resource at url with a POST-as-GET request, as
+// specified in RFC 8555, Section 6.3.
+func (c *Client) postAsGet(ctx context.Context, url string) (*http.Response, error) {
+	return c.post(ctx, nil, url, nil, http.StatusOK)
+}
+
+// post sends body to url in a JWS signed request, and returns the response if
+// its status code is one of ok. The caller must close the response body.
+//
+// If key is nil, the request is signed with c.Key and identifies the account
+// by its key ID. Otherwise, it is signed with key and includes its public key.
+// If body is nil, the payload is empty, as in POST-as-GET requests.
+//
+// Requests that fail with a bad nonce, a 429 or a 5xx status code are
+// retried, with an exponential backoff for the latter two.
+func (c *Client) post(ctx context.Context, key crypto.Signer, url string, body any, ok ...int) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := c.postNoRetry(ctx, key, url, body)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(ok, res.StatusCode) {
+			return res, nil
+		}
+		err = responseError(res)
+		res.Body.Close()
+		if attempt == maxRetries {
+			return nil, err
+		}
+		switch {
+		case isProblem(err, ProblemBadNonce):
+			// The response carried a fresh nonce; try again right away.
+		case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+			if sleep(ctx, backoff(attempt, res.Header)) != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) postNoRetry(ctx context.Context, key crypto.Signer, url string, body any) (*http.Response, error) {
+	if c.Key == nil {
+		return nil, errors.New("acme: Client.Key is nil")
+	}
+	kid := ""
+	if key == nil {
+		var err error
+		if kid, err = c.accountKID(ctx); err != nil {
+			return nil, err
+		}
+		key = c.Key
+	}
+	nonce, err := c.popNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var payload []byte
+	if body != nil {
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	b, err := jwsEncodeJSON(payload, key, kid, nonce, url)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.addNonce(res.Header)
+	return res, nil
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ua := "Go-crypto-acme"
+	if c.UserAgent != "" {
+		ua = c.UserAgent + " " + ua
+	}
+	req.Header.Set("User-Agent", ua)
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return hc.Do(req)
+}
+
+// popNonce returns a nonce collected from a previous response, or fetches a
+// new one from the CA.
+func (c *Client) popNonce(ctx context.Context) (string, error) {
+	c.noncesMu.Lock()
+	if n := len(c.nonces); n > 0 {
+		nonce := c.nonces[n-1]
+		c.nonces = c.nonces[:n-1]
+		c.noncesMu.Unlock()
+		return nonce, nil
+	}
+	c.noncesMu.Unlock()
+
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "HEAD", dir.NewNonceURL, nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		if res.StatusCode >= 300 {
+			return "", responseError(res)
+		}
+		return "", errors.New("acme: CA did not provide a nonce")
+	}
+	return nonce, nil
+}
+
+// addNonce saves the nonce in h, if any, for a later request.
+func (c *Client) addNonce(h http.Header) {
+	nonce := h.Get("Replay-Nonce")
+	if nonce == "" {
+		return
+	}
+	c.noncesMu.Lock()
+	defer c.noncesMu.Unlock()
+	if len(c.nonces) < maxNonces {
+		c.nonces = append(c.nonces, nonce)
+	}
+}
+
+// backoff returns how long to wait before the next attempt of a request that
+// failed attempt times, honoring a Retry-After header in h.
+func backoff(attempt int, h http.Header) time.Duration {
+	jitter := rand.N(time.Second)
+	if d, ok := retryAfter(h); ok {
+		return min(d, maxBackoff) + jitter
+	}
+	return min(time.Second<<(attempt-1), maxBackoff) + jitter
+}
+
+// retryAfter parses the Retry-After header in h.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(timeNow()), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// decodeJSON decodes the JSON body of res into v.
+func decodeJSON(res *http.Response, v any) error {
+	return json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(v)
+}
+
+// responseError returns the problem document in the body of res as an *Error.
+func responseError(res *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	var we wireError
+	if err := json.Unmarshal(b, &we); err != nil || we.Type == "" && we.Detail == "" {
+		we = wireError{Detail: strings.TrimSpace(string(b))}
+		if we.Detail == "" {
+			we.Detail = res.Status
+		}
+	}
+	we.Status = res.StatusCode
+	return we.error(res.Header)
+}
+
+// linkHeader returns the targets of the Link headers in h with relation rel.
+func linkHeader(h http.Header, rel string) []string {
+	var links []string
+	for _, v := range h.Values("Link") {
+		for _, link := range strings.Split(v, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, p := range strings.Split(params, ";") {
+				k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+				if strings.EqualFold(k, "rel") && strings.Trim(v, `"`) == rel {
+					links = append(links, target[1:len(target)-1])
+				}
+			}
+		}
+	}
+	return links
+}
+
+// wait sleeps before polling a resource again, as indicated by the
+// Retry-After header of its last response.
+func wait(ctx context.Context, h http.Header) error {
+	d, ok := retryAfter(h)
+	if !ok {
+		d = time.Second
+	}
+	if err := sleep(ctx, min(d, time.Minute)); err != nil {
+		return fmt.Errorf("acme: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,1058 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package acmetest provides an in-process ACME CA for testing ACME clients.
+//
+// The CA implements the subset of RFC 8555 used by package crypto/acme:
+// accounts, orders, authorizations with http-01, dns-01 and tls-alpn-01
+// challenges, finalization, certificate download and revocation. Requests
+// are authenticated as specified, including nonces and JWS signatures.
+// Challenges are validated against addresses and TXT records registered with
+// the CA, rather than with real DNS.
+package acmetest
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/acme"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // for ES384 and ES512
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A CAServer is an ACME CA serving on a local address.
+type CAServer struct {
+	// URL is the directory URL of the CA.
+	URL string
+
+	srv *httptest.Server
+
+	root, intermediate       *x509.Certificate
+	rootKey, intermediateKey *ecdsa.PrivateKey
+
+	mu             sync.Mutex
+	challengeTypes []string
+	validity       time.Duration
+	addrs          map[string]string
+	txt            map[string][]string
+	nonces         map[string]bool
+	nonceSeq       int
+	accounts       []*account
+	orders         []*order
+	authzs         []*authz
+	challenges     []*challenge
+	certs          []*issuedCert
+}
+
+type account struct {
+	url     string
+	key     crypto.PublicKey
+	thumb   string
+	status  string
+	contact []string
+}
+
+type order struct {
+	id          int
+	account     *account
+	identifiers []acme.Identifier
+	authzs      []*authz
+	expires     time.Time
+	cert        *issuedCert
+}
+
+type authz struct {
+	id         int
+	account    *account
+	identifier acme.Identifier
+	status     string
+	expires    time.Time
+	challenges []*challenge
+}
+
+type challenge struct {
+	id        int
+	authz     *authz
+	typ       string
+	token     string
+	status    string
+	validated time.Time
+	err       *problem
+}
+
+type issuedCert struct {
+	id      int
+	account *account
+	leaf    *x509.Certificate
+	chain   [][]byte
+	revoked bool
+}
+
+// NewCAServer starts and returns a new CA. It offers all supported challenge
+// types, and issues certificates valid for 90 days. The caller should call
+// Close when finished, to shut it down.
+func NewCAServer() *CAServer {
+	ca := &CAServer{
+		challengeTypes: []string{"http-01", "dns-01", "tls-alpn-01"},
+		validity:       90 * 24 * time.Hour,
+		addrs:          make(map[string]string),
+		txt:            make(map[string][]string),
+		nonces:         make(map[string]bool),
+	}
+	ca.rootKey, ca.root = newCA("ACME Test Root", nil, nil)
+	ca.intermediateKey, ca.intermediate = newCA("ACME Test Intermediate", ca.root, ca.rootKey)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /directory", ca.handleDirectory)
+	mux.HandleFunc("HEAD /new-nonce", ca.handleNewNonce)
+	mux.HandleFunc("GET /new-nonce", ca.handleNewNonce)
+	mux.HandleFunc("POST /new-account", ca.handleNewAccount)
+	mux.HandleFunc("POST /account/{id}", ca.handleAccount)
+	mux.HandleFunc("POST /new-order", ca.handleNewOrder)
+	mux.HandleFunc("POST /order/{id}", ca.handleOrder)
+	mux.HandleFunc("POST /order/{id}/finalize", ca.handleFinalize)
+	mux.HandleFunc("POST /authz/{id}", ca.handleAuthz)
+	mux.HandleFunc("POST /challenge/{id}", ca.handleChallenge)
+	mux.HandleFunc("POST /cert/{id}", ca.handleCert)
+	mux.HandleFunc("POST /revoke-cert", ca.handleRevokeCert)
+	mux.HandleFunc("GET /terms", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "Terms of service of the ACME test CA.\n")
+	})
+	ca.srv = httptest.NewServer(mux)
+	ca.URL = ca.srv.URL + "/directory"
+	return ca
+}
+
+func newCA(name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          randomSerial(),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return key, cert
+}
+
+func randomSerial() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		panic(err)
+	}
+	return serial
+}
+
+// Close shuts down the CA.
+func (ca *CAServer) Close() {
+	ca.srv.Close()
+}
+
+// Roots returns a pool containing the root certificate of the CA.
+func (ca *CAServer) Roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.root)
+	return pool
+}
+
+// ChallengeTypes sets the challenge types offered in new authorizations.
+func (ca *CAServer) ChallengeTypes(types ...string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.challengeTypes = types
+}
+
+// CertValidity sets the validity period of certificates issued from now on.
+func (ca *CAServer) CertValidity(d time.Duration) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.validity = d
+}
+
+// Resolve makes the CA connect to addr, a "host:port" address, to validate
+// http-01 and tls-alpn-01 challenges for identifier.
+func (ca *CAServer) Resolve(identifier, addr string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.addrs[identifier] = addr
+}
+
+// SetTXT sets the TXT records of name, used to validate dns-01 challenges.
+// If values is empty, the records are removed.
+func (ca *CAServer) SetTXT(name string, values ...string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	name = strings.TrimSuffix(name, ".")
+	if len(values) == 0 {
+		delete(ca.txt, name)
+		return
+	}
+	ca.txt[name] = values
+}
+
+// IssuedCerts returns the leaf certificates issued so far, in order.
+func (ca *CAServer) IssuedCerts() []*x509.Certificate {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	var certs []*x509.Certificate
+	for _, c := range ca.certs {
+		certs = append(certs, c.leaf)
+	}
+	return certs
+}
+
+// Revoked reports whether the CA revoked the certificate with the given
+// serial number.
+func (ca *CAServer) Revoked(serial *big.Int) bool {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	for _, c := range ca.certs {
+		if c.leaf.SerialNumber.Cmp(serial) == 0 {
+			return c.revoked
+		}
+	}
+	return false
+}
+
+func (ca *CAServer) url(format string, args ...any) string {
+	return ca.srv.URL + fmt.Sprintf(format, args...)
+}
+
+// A problem is an ACME problem document, and the HTTP status to send it with.
+type problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status,omitempty"`
+}
+
+func (p *problem) Error() string { return p.Type + ": " + p.Detail }
+
+func newProblem(status int, typ, format string, args ...any) *problem {
+	return &problem{Type: typ, Detail: fmt.Sprintf(format, args...), Status: status}
+}
+
+func malformed(format string, args ...any) *problem {
+	return newProblem(http.StatusBadRequest, acme.ProblemMalformed, format, args...)
+}
+
+func notFound() *problem {
+	return newProblem(http.StatusNotFound, acme.ProblemMalformed, "no such resource")
+}
+
+func unauthorized(format string, args ...any) *problem {
+	return newProblem(http.StatusForbidden, acme.ProblemUnauthorized, format, args...)
+}
+
+// newNonce returns a new nonce. ca.mu must be held.
+func (ca *CAServer) newNonce() string {
+	ca.nonceSeq++
+	nonce := base64.RawURLEncoding.EncodeToString([]byte("nonce-" + strconv.Itoa(ca.nonceSeq)))
+	ca.nonces[nonce] = true
+	return nonce
+}
+
+func (ca *CAServer) writeJSON(w http.ResponseWriter, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+func (ca *CAServer) writeProblem(w http.ResponseWriter, p *problem) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	w.Write(b)
+}
+
+// handle wraps the handling of an authenticated request. f is called with
+// ca.mu held, and either writes a JSON response with the returned status and
+// value, or writes the returned problem.
+func (ca *CAServer) handle(w http.ResponseWriter, r *http.Request, f func(req *request) (int, any, *problem)) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	w.Header().Set("Replay-Nonce", ca.newNonce())
+	req, p := ca.verify(r)
+	if p == nil {
+		var status int
+		var v any
+		if status, v, p = f(req); p == nil {
+			if b, ok := v.([]byte); ok {
+				w.WriteHeader(status)
+				w.Write(b)
+			} else {
+				ca.writeJSON(w, status, v)
+			}
+			return
+		}
+	}
+	ca.writeProblem(w, p)
+}
+
+func (ca *CAServer) handleDirectory(w http.ResponseWriter, r *http.Request) {
+	ca.writeJSON(w, http.StatusOK, map[string]any{
+		"newNonce":   ca.url("/new-nonce"),
+		"newAccount": ca.url("/new-account"),
+		"newOrder":   ca.url("/new-order"),
+		"revokeCert": ca.url("/revoke-cert"),
+		"meta": map[string]any{
+			"termsOfService": ca.url("/terms"),
+		},
+	})
+}
+
+func (ca *CAServer) handleNewNonce(w http.ResponseWriter, r *http.Request) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	w.Header().Set("Replay-Nonce", ca.newNonce())
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method == "GET" {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (ca *CAServer) handleNewAccount(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		if req.jwk == nil {
+			return 0, nil, malformed("newAccount requests must include a JWK")
+		}
+		var v struct {
+			Contact            []string `json:"contact"`
+			TermsAgreed        bool     `json:"termsOfServiceAgreed"`
+			OnlyReturnExisting bool     `json:"onlyReturnExisting"`
+		}
+		if err := json.Unmarshal(req.payload, &v); err != nil {
+			return 0, nil, malformed("invalid newAccount payload: %v", err)
+		}
+		thumb, err := acme.JWKThumbprint(req.jwk)
+		if err != nil {
+			return 0, nil, malformed("%v", err)
+		}
+		for _, a := range ca.accounts {
+			if a.thumb == thumb {
+				w.Header().Set("Location", a.url)
+				return http.StatusOK, a.json(), nil
+			}
+		}
+		if v.OnlyReturnExisting {
+			return 0, nil, newProblem(http.StatusBadRequest, acme.ProblemAccountDoesNotExist, "no account for this key")
+		}
+		if !v.TermsAgreed {
+			return 0, nil, newProblem(http.StatusForbidden, acme.ProblemUserActionRequired, "the terms of service must be agreed to")
+		}
+		a := &account{
+			url:     ca.url("/account/%d", len(ca.accounts)),
+			key:     req.jwk,
+			thumb:   thumb,
+			status:  acme.StatusValid,
+			contact: v.Contact,
+		}
+		ca.accounts = append(ca.accounts, a)
+		w.Header().Set("Location", a.url)
+		return http.StatusCreated, a.json(), nil
+	})
+}
+
+func (a *account) json() any {
+	return map[string]any{
+		"status":  a.status,
+		"contact": a.contact,
+		"orders":  a.url + "/orders",
+	}
+}
+
+func (ca *CAServer) handleAccount(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		if req.account == nil || req.account.url != req.url {
+			return 0, nil, unauthorized("requests must be signed by the account")
+		}
+		a := req.account
+		if len(req.payload) > 0 {
+			var v struct {
+				Contact []string `json:"contact"`
+				Status  string   `json:"status"`
+			}
+			if err := json.Unmarshal(req.payload, &v); err != nil {
+				return 0, nil, malformed("invalid account payload: %v", err)
+			}
+			if v.Contact != nil {
+				a.contact = v.Contact
+			}
+			switch v.Status {
+			case "":
+			case acme.StatusDeactivated:
+				a.status = acme.StatusDeactivated
+			default:
+				return 0, nil, malformed("invalid account status %q", v.Status)
+			}
+		}
+		return http.StatusOK, a.json(), nil
+	})
+}
+
+func (ca *CAServer) handleNewOrder(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		if req.account == nil {
+			return 0, nil, malformed("newOrder requests must be signed by an account")
+		}
+		var v struct {
+			Identifiers []acme.Identifier `json:"identifiers"`
+		}
+		if err := json.Unmarshal(req.payload, &v); err != nil {
+			return 0, nil, malformed("invalid newOrder payload: %v", err)
+		}
+		if len(v.Identifiers) == 0 {
+			return 0, nil, malformed("order has no identifiers")
+		}
+		o := &order{
+			id:          len(ca.orders),
+			account:     req.account,
+			identifiers: v.Identifiers,
+			expires:     time.Now().Add(24 * time.Hour),
+		}
+		for _, id := range v.Identifiers {
+			switch {
+			case id.Type == "dns" && id.Value != "" && !strings.HasPrefix(id.Value, "*."):
+			case id.Type == "ip" && net.ParseIP(id.Value) != nil:
+			default:
+				return 0, nil, newProblem(http.StatusBadRequest, acme.ProblemRejectedIdentifier, "unsupported identifier %s:%s", id.Type, id.Value)
+			}
+			az := &authz{
+				id:         len(ca.authzs),
+				account:    req.account,
+				identifier: id,
+				status:     acme.StatusPending,
+				expires:    o.expires,
+			}
+			ca.authzs = append(ca.authzs, az)
+			for _, typ := range ca.challengeTypes {
+				if typ == "dns-01" && id.Type != "dns" {
+					continue
+				}
+				ch := &challenge{
+					id:     len(ca.challenges),
+					authz:  az,
+					typ:    typ,
+					token:  randomToken(),
+					status: acme.StatusPending,
+				}
+				ca.challenges = append(ca.challenges, ch)
+				az.challenges = append(az.challenges, ch)
+			}
+			o.authzs = append(o.authzs, az)
+		}
+		ca.orders = append(ca.orders, o)
+		w.Header().Set("Location", ca.url("/order/%d", o.id))
+		return http.StatusCreated, ca.orderJSON(o), nil
+	})
+}
+
+func randomToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (o *order) status() string {
+	if o.cert != nil {
+		return acme.StatusValid
+	}
+	status := acme.StatusReady
+	for _, az := range o.authzs {
+		switch az.status {
+		case acme.StatusValid:
+		case acme.StatusPending:
+			status = acme.StatusPending
+		default:
+			return acme.StatusInvalid
+		}
+	}
+	return status
+}
+
+func (ca *CAServer) orderJSON(o *order) any {
+	v := map[string]any{
+		"status":      o.status(),
+		"expires":     o.expires.UTC().Format(time.RFC3339),
+		"identifiers": o.identifiers,
+		"finalize":    ca.url("/order/%d/finalize", o.id),
+	}
+	var authzs []string
+	for _, az := range o.authzs {
+		authzs = append(authzs, ca.url("/authz/%d", az.id))
+	}
+	v["authorizations"] = authzs
+	if o.cert != nil {
+		v["certificate"] = ca.url("/cert/%d", o.cert.id)
+	}
+	return v
+}
+
+// lookup returns the element of s whose index is the id path value of r.
+func lookup[T any](r *http.Request, s []T) (T, bool) {
+	var zero T
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 0 || id >= len(s) {
+		return zero, false
+	}
+	return s[id], true
+}
+
+func (ca *CAServer) handleOrder(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		o, ok := lookup(r, ca.orders)
+		if !ok {
+			return 0, nil, notFound()
+		}
+		if req.account != o.account {
+			return 0, nil, unauthorized("order belongs to another account")
+		}
+		return http.StatusOK, ca.orderJSON(o), nil
+	})
+}
+
+func (ca *CAServer) handleFinalize(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		o, ok := lookup(r, ca.orders)
+		if !ok {
+			return 0, nil, notFound()
+		}
+		if req.account != o.account {
+			return 0, nil, unauthorized("order belongs to another account")
+		}
+		if s := o.status(); s != acme.StatusReady {
+			return 0, nil, newProblem(http.StatusForbidden, acme.ProblemOrderNotReady, "order is %s", s)
+		}
+		var v struct {
+			CSR string `json:"csr"`
+		}
+		if err := json.Unmarshal(req.payload, &v); err != nil {
+			return 0, nil, malformed("invalid finalize payload: %v", err)
+		}
+		der, err := base64.RawURLEncoding.DecodeString(v.CSR)
+		if err != nil {
+			return 0, nil, newProblem(http.StatusBadRequest, acme.ProblemBadCSR, "invalid CSR encoding")
+		}
+		csr, err := x509.ParseCertificateRequest(der)
+		if err == nil {
+			err = csr.CheckSignature()
+		}
+		if err != nil {
+			return 0, nil, newProblem(http.StatusBadRequest, acme.ProblemBadCSR, "%v", err)
+		}
+		if p := checkCSRIdentifiers(csr, o.identifiers); p != nil {
+			return 0, nil, p
+		}
+		leaf, err := ca.issue(csr)
+		if err != nil {
+			return 0, nil, newProblem(http.StatusInternalServerError, acme.ProblemServerInternal, "%v", err)
+		}
+		o.cert = &issuedCert{
+			id:      len(ca.certs),
+			account: o.account,
+			leaf:    leaf,
+			chain:   [][]byte{leaf.Raw, ca.intermediate.Raw},
+		}
+		ca.certs = append(ca.certs, o.cert)
+		w.Header().Set("Location", ca.url("/order/%d", o.id))
+		return http.StatusOK, ca.orderJSON(o), nil
+	})
+}
+
+// checkCSRIdentifiers checks that the names in csr are exactly ids.
+func checkCSRIdentifiers(csr *x509.CertificateRequest, ids []acme.Identifier) *problem {
+	var want, got []string
+	for _, id := range ids {
+		want = append(want, id.Type+":"+id.Value)
+	}
+	for _, name := range csr.DNSNames {
+		got = append(got, "dns:"+name)
+	}
+	for _, ip := range csr.IPAddresses {
+		got = append(got, "ip:"+ip.String())
+	}
+	slices.Sort(want)
+	slices.Sort(got)
+	want, got = slices.Compact(want), slices.Compact(got)
+	if !slices.Equal(want, got) {
+		return newProblem(http.StatusBadRequest, acme.ProblemBadCSR, "CSR names %v don't match the order identifiers %v", got, want)
+	}
+	if cn := csr.Subject.CommonName; cn != "" && !slices.Contains(csr.DNSNames, cn) {
+		return newProblem(http.StatusBadRequest, acme.ProblemBadCSR, "CSR common name %q is not one of its DNS names", cn)
+	}
+	return nil
+}
+
+// issue creates a leaf certificate for csr. ca.mu must be held.
+func (ca *CAServer) issue(csr *x509.CertificateRequest) (*x509.Certificate, error) {
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: randomSerial(),
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(ca.validity),
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if len(csr.DNSNames) > 0 {
+		tmpl.Subject.CommonName = csr.DNSNames[0]
+	}
+	if _, ok := csr.PublicKey.(*rsa.PublicKey); ok {
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.intermediate, csr.PublicKey, ca.intermediateKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func (ca *CAServer) handleAuthz(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		az, ok := lookup(r, ca.authzs)
+		if !ok {
+			return 0, nil, notFound()
+		}
+		if req.account != az.account {
+			return 0, nil, unauthorized("authorization belongs to another account")
+		}
+		if len(req.payload) > 0 {
+			var v struct {
+				Status string `json:"status"`
+			}
+			if err := json.Unmarshal(req.payload, &v); err != nil || v.Status != acme.StatusDeactivated {
+				return 0, nil, malformed("invalid authorization update")
+			}
+			az.status = acme.StatusDeactivated
+		}
+		return http.StatusOK, ca.authzJSON(az), nil
+	})
+}
+
+func (ca *CAServer) authzJSON(az *authz) any {
+	var chals []any
+	for _, ch := range az.challenges {
+		chals = append(chals, ca.challengeJSON(ch))
+	}
+	return map[string]any{
+		"status":     az.status,
+		"identifier": az.identifier,
+		"expires":    az.expires.UTC().Format(time.RFC3339),
+		"challenges": chals,
+	}
+}
+
+func (ca *CAServer) challengeJSON(ch *challenge) any {
+	v := map[string]any{
+		"type":   ch.typ,
+		"url":    ca.url("/challenge/%d", ch.id),
+		"token":  ch.token,
+		"status": ch.status,
+	}
+	if !ch.validated.IsZero() {
+		v["validated"] = ch.validated.UTC().Format(time.RFC3339)
+	}
+	if ch.err != nil {
+		v["error"] = ch.err
+	}
+	return v
+}
+
+func (ca *CAServer) handleChallenge(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		ch, ok := lookup(r, ca.challenges)
+		if !ok {
+			return 0, nil, notFound()
+		}
+		az := ch.authz
+		if req.account != az.account {
+			return 0, nil, unauthorized("challenge belongs to another account")
+		}
+		w.Header().Set("Link", fmt.Sprintf("<%s>;rel=\"up\"", ca.url("/authz/%d", az.id)))
+		if len(req.payload) == 0 || ch.status != acme.StatusPending || az.status != acme.StatusPending {
+			return http.StatusOK, ca.challengeJSON(ch), nil
+		}
+
+		// Validate synchronously, without holding the lock, so that clients
+		// never need to poll. Responses of other types are not affected.
+		ka := ch.token + "." + az.account.thumb
+		addr := ca.addrs[az.identifier.Value]
+		txt := ca.txt["_acme-challenge."+az.identifier.Value]
+		ca.mu.Unlock()
+		p := validate(ch.typ, az.identifier, ka, addr, txt)
+		ca.mu.Lock()
+
+		if ch.status != acme.StatusPending {
+			return http.StatusOK, ca.challengeJSON(ch), nil
+		}
+		if p != nil {
+			ch.status, ch.err = acme.StatusInvalid, p
+			az.status = acme.StatusInvalid
+		} else {
+			ch.status, ch.validated = acme.StatusValid, time.Now()
+			az.status = acme.StatusValid
+		}
+		return http.StatusOK, ca.challengeJSON(ch), nil
+	})
+}
+
+// validate checks the response to a challenge with key authorization ka.
+func validate(typ string, id acme.Identifier, ka, addr string, txt []string) *problem {
+	switch typ {
+	case "http-01":
+		return validateHTTP01(id, ka, addr)
+	case "tls-alpn-01":
+		return validateTLSALPN01(id, ka, addr)
+	case "dns-01":
+		sum := sha256.Sum256([]byte(ka))
+		if slices.Contains(txt, base64.RawURLEncoding.EncodeToString(sum[:])) {
+			return nil
+		}
+		return &problem{Type: acme.ProblemIncorrectResponse, Detail: "no TXT record matches the key authorization"}
+	}
+	return &problem{Type: acme.ProblemMalformed, Detail: "unsupported challenge type " + typ}
+}
+
+func validateHTTP01(id acme.Identifier, ka, addr string) *problem {
+	if addr == "" {
+		return &problem{Type: acme.ProblemConnection, Detail: "no address for " + id.Value}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	token, _, _ := strings.Cut(ka, ".")
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://"+addr+acme.HTTP01ChallengePath(token), nil)
+	if err != nil {
+		return &problem{Type: acme.ProblemConnection, Detail: err.Error()}
+	}
+	req.Host = id.Value
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return &problem{Type: acme.ProblemConnection, Detail: err.Error()}
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<10))
+	if err != nil {
+		return &problem{Type: acme.ProblemConnection, Detail: err.Error()}
+	}
+	if res.StatusCode != http.StatusOK {
+		return &problem{Type: acme.ProblemUnauthorized, Detail: "challenge response has status " + res.Status}
+	}
+	if string(bytes.TrimSpace(body)) != ka {
+		return &problem{Type: acme.ProblemIncorrectResponse, Detail: fmt.Sprintf("challenge response is %q, want %q", body, ka)}
+	}
+	return nil
+}
+
+func validateTLSALPN01(id acme.Identifier, ka, addr string) *problem {
+	if addr == "" {
+		return &problem{Type: acme.ProblemConnection, Detail: "no address for " + id.Value}
+	}
+	config := &tls.Config{
+		NextProtos:         []string{acme.ALPNProto},
+		InsecureSkipVerify: true,
+	}
+	if id.Type == "dns" {
+		config.ServerName = id.Value
+	}
+	dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: 10 * time.Second}, Config: config}
+	conn, err := dialer.DialContext(context.Background(), "tcp", addr)
+	if err != nil {
+		return &problem{Type: acme.ProblemTLS, Detail: err.Error()}
+	}
+	defer conn.Close()
+	state := conn.(*tls.Conn).ConnectionState()
+	if state.NegotiatedProtocol != acme.ALPNProto {
+		return &problem{Type: acme.ProblemTLS, Detail: "server didn't negotiate " + acme.ALPNProto}
+	}
+	leaf := state.PeerCertificates[0]
+	if err := leaf.VerifyHostname(id.Value); err != nil {
+		return &problem{Type: acme.ProblemIncorrectResponse, Detail: err.Error()}
+	}
+	sum := sha256.Sum256([]byte(ka))
+	for _, ext := range leaf.Extensions {
+		if !ext.Id.Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}) {
+			continue
+		}
+		var v []byte
+		if rest, err := asn1.Unmarshal(ext.Value, &v); err != nil || len(rest) != 0 || !ext.Critical {
+			return &problem{Type: acme.ProblemIncorrectResponse, Detail: "malformed acmeIdentifier extension"}
+		}
+		if subtle.ConstantTimeCompare(v, sum[:]) != 1 {
+			return &problem{Type: acme.ProblemIncorrectResponse, Detail: "acmeIdentifier doesn't match the key authorization"}
+		}
+		return nil
+	}
+	return &problem{Type: acme.ProblemIncorrectResponse, Detail: "certificate has no acmeIdentifier extension"}
+}
+
+func (ca *CAServer) handleCert(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		c, ok := lookup(r, ca.certs)
+		if !ok {
+			return 0, nil, notFound()
+		}
+		if req.account != c.account {
+			return 0, nil, unauthorized("certificate belongs to another account")
+		}
+		var b []byte
+		for _, der := range c.chain {
+			b = append(b, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+		}
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		return http.StatusOK, b, nil
+	})
+}
+
+func (ca *CAServer) handleRevokeCert(w http.ResponseWriter, r *http.Request) {
+	ca.handle(w, r, func(req *request) (int, any, *problem) {
+		var v struct {
+			Certificate string `json:"certificate"`
+			Reason      int    `json:"reason"`
+		}
+		if err := json.Unmarshal(req.payload, &v); err != nil {
+			return 0, nil, malformed("invalid revokeCert payload: %v", err)
+		}
+		der, err := base64.RawURLEncoding.DecodeString(v.Certificate)
+		if err != nil {
+			return 0, nil, malformed("invalid certificate encoding")
+		}
+		for _, c := range ca.certs {
+			if !bytes.Equal(c.leaf.Raw, der) {
+				continue
+			}
+			switch {
+			case req.account == c.account:
+			case req.jwk != nil && publicKeysEqual(req.jwk, c.leaf.PublicKey):
+			default:
+				return 0, nil, unauthorized("request is not signed by the certificate's account or key")
+			}
+			if c.revoked {
+				return 0, nil, newProblem(http.StatusBadRequest, acme.ProblemAlreadyRevoked, "certificate is already revoked")
+			}
+			c.revoked = true
+			return http.StatusOK, []byte(nil), nil
+		}
+		return 0, nil, notFound()
+	})
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	k, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(b)
+}
+
+// A request is a verified JWS request.
+type request struct {
+	url     string
+	account *account         // set if the request identifies its key by ID
+	jwk     crypto.PublicKey // set if the request includes its key
+	payload []byte
+}
+
+// verify parses and authenticates a JWS request, as specified in RFC 8555,
+// Section 6.2. ca.mu must be held.
+func (ca *CAServer) verify(r *http.Request) (*request, *problem) {
+	if ct := r.Header.Get("Content-Type"); ct != "application/jose+json" {
+		return nil, newProblem(http.StatusUnsupportedMediaType, acme.ProblemMalformed, "unexpected Content-Type %q", ct)
+	}
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&jws); err != nil {
+		return nil, malformed("invalid JWS: %v", err)
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return nil, malformed("invalid JWS protected header encoding")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, malformed("invalid JWS payload encoding")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+	if err != nil {
+		return nil, malformed("invalid JWS signature encoding")
+	}
+	var header struct {
+		Alg   string          `json:"alg"`
+		JWK   json.RawMessage `json:"jwk"`
+		KID   string          `json:"kid"`
+		Nonce string          `json:"nonce"`
+		URL   string          `json:"url"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return nil, malformed("invalid JWS protected header: %v", err)
+	}
+
+	if !ca.nonces[header.Nonce] {
+		return nil, newProblem(http.StatusBadRequest, acme.ProblemBadNonce, "invalid or reused nonce")
+	}
+	delete(ca.nonces, header.Nonce)
+	if want := ca.srv.URL + r.URL.Path; header.URL != want {
+		return nil, unauthorized("JWS url %q doesn't match the request URL %q", header.URL, want)
+	}
+
+	req := &request{url: header.URL, payload: payload}
+	var pub crypto.PublicKey
+	switch {
+	case header.JWK != nil && header.KID != "":
+		return nil, malformed("JWS has both jwk and kid")
+	case header.JWK != nil:
+		if pub, err = parseJWK(header.JWK); err != nil {
+			return nil, newProblem(http.StatusBadRequest, acme.ProblemBadPublicKey, "%v", err)
+		}
+		req.jwk = pub
+	case header.KID != "":
+		for _, a := range ca.accounts {
+			if a.url == header.KID {
+				req.account = a
+			}
+		}
+		if req.account == nil {
+			return nil, newProblem(http.StatusBadRequest, acme.ProblemAccountDoesNotExist, "no account %s", header.KID)
+		}
+		if req.account.status != acme.StatusValid {
+			return nil, unauthorized("account is %s", req.account.status)
+		}
+		pub = req.account.key
+	default:
+		return nil, malformed("JWS has neither jwk nor kid")
+	}
+
+	if err := verifySignature(header.Alg, pub, []byte(jws.Protected+"."+jws.Payload), sig); err != nil {
+		return nil, newProblem(http.StatusBadRequest, acme.ProblemBadSignatureAlgorithm, "%v", err)
+	}
+	return req, nil
+}
+
+func parseJWK(b []byte) (crypto.PublicKey, error) {
+	var jwk struct {
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+	if err := json.Unmarshal(b, &jwk); err != nil {
+		return nil, err
+	}
+	decode := func(s string) *big.Int {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil || len(b) == 0 {
+			return nil
+		}
+		return new(big.Int).SetBytes(b)
+	}
+	switch jwk.Kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported curve " + jwk.Crv)
+		}
+		x, y := decode(jwk.X), decode(jwk.Y)
+		if x == nil || y == nil || !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid EC public key")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "RSA":
+		n, e := decode(jwk.N), decode(jwk.E)
+		if n == nil || e == nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA public key")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	}
+	return nil, errors.New("unsupported key type " + jwk.Kty)
+}
+
+func verifySignature(alg string, pub crypto.PublicKey, signed, sig []byte) error {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		var hash crypto.Hash
+		switch {
+		case alg == "ES256" && pub.Curve == elliptic.P256():
+			hash = crypto.SHA256
+		case alg == "ES384" && pub.Curve == elliptic.P384():
+			hash = crypto.SHA384
+		case alg == "ES512" && pub.Curve == elliptic.P521():
+			hash = crypto.SHA512
+		default:
+			return fmt.Errorf("algorithm %s doesn't match the key", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid ECDSA signature length")
+		}
+		h := hash.New()
+		h.Write(signed)
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, h.Sum(nil), r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if alg != "RS256" {
+			return fmt.Errorf("algorithm %s doesn't match the key", alg)
+		}
+		sum := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, sum[:], sig)
+	}
+	return errors.New("unsupported key")
+}
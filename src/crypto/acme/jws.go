@@ -0,0 +1,182 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // for ES384 and ES512
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+// jsonWebSignature is a JWS in the flattened JSON serialization of
+// RFC 7515, Section 7.2.2.
+type jsonWebSignature struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// jwsEncodeJSON signs payload with key, as specified in RFC 8555, Section 6.2.
+//
+// If kid is empty, the protected header includes the public key as "jwk",
+// otherwise it includes kid as "kid". An empty payload is encoded as an empty
+// string, as required for POST-as-GET requests.
+func jwsEncodeJSON(payload []byte, key crypto.Signer, kid, nonce, url string) ([]byte, error) {
+	alg, hash := jwsHasher(key.Public())
+	if alg == "" {
+		return nil, ErrUnsupportedKey
+	}
+	header := struct {
+		Alg   string          `json:"alg"`
+		KID   string          `json:"kid,omitempty"`
+		JWK   json.RawMessage `json:"jwk,omitempty"`
+		Nonce string          `json:"nonce"`
+		URL   string          `json:"url"`
+	}{
+		Alg:   alg,
+		KID:   kid,
+		Nonce: nonce,
+		URL:   url,
+	}
+	if kid == "" {
+		jwk, err := jwkEncode(key.Public())
+		if err != nil {
+			return nil, err
+		}
+		header.JWK = jwk
+	}
+	protected, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	jws := jsonWebSignature{
+		Protected: base64.RawURLEncoding.EncodeToString(protected),
+		Payload:   base64.RawURLEncoding.EncodeToString(payload),
+	}
+	h := hash.New()
+	h.Write([]byte(jws.Protected + "." + jws.Payload))
+	sig, err := jwsSign(key, hash, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	jws.Signature = base64.RawURLEncoding.EncodeToString(sig)
+	return json.Marshal(jws)
+}
+
+// jwsWithMAC returns an external account binding JWS over the JWK of pub,
+// signed with HMAC-SHA256, as specified in RFC 8555, Section 7.3.4.
+func jwsWithMAC(key []byte, kid, url string, pub crypto.PublicKey) (*jsonWebSignature, error) {
+	if len(key) == 0 {
+		return nil, errors.New("acme: external account binding key is empty")
+	}
+	jwk, err := jwkEncode(pub)
+	if err != nil {
+		return nil, err
+	}
+	protected, err := json.Marshal(struct {
+		Alg string `json:"alg"`
+		KID string `json:"kid"`
+		URL string `json:"url"`
+	}{"HS256", kid, url})
+	if err != nil {
+		return nil, err
+	}
+	jws := &jsonWebSignature{
+		Protected: base64.RawURLEncoding.EncodeToString(protected),
+		Payload:   base64.RawURLEncoding.EncodeToString(jwk),
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(jws.Protected + "." + jws.Payload))
+	jws.Signature = base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	return jws, nil
+}
+
+// jwkEncode returns the JSON Web Key of pub, as specified in RFC 7517. The
+// members are in lexicographic order and without whitespace, so the result is
+// also the input of its thumbprint, as specified in RFC 7638.
+func jwkEncode(pub crypto.PublicKey) ([]byte, error) {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		e := big.NewInt(int64(pub.E)).Bytes()
+		return []byte(`{"e":"` + b64(e) + `","kty":"RSA","n":"` + b64(pub.N.Bytes()) + `"}`), nil
+	case *ecdsa.PublicKey:
+		crv := pub.Curve.Params().Name
+		if alg, _ := jwsHasher(pub); alg == "" {
+			return nil, ErrUnsupportedKey
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		x := pub.X.FillBytes(make([]byte, size))
+		y := pub.Y.FillBytes(make([]byte, size))
+		return []byte(`{"crv":"` + crv + `","kty":"EC","x":"` + b64(x) + `","y":"` + b64(y) + `"}`), nil
+	}
+	return nil, ErrUnsupportedKey
+}
+
+// jwsSign signs digest with key. ECDSA signatures are encoded as the
+// concatenation of r and s, as specified in RFC 7518, Section 3.4.
+func jwsSign(key crypto.Signer, hash crypto.Hash, digest []byte) ([]byte, error) {
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		return key.Sign(rand.Reader, digest, hash)
+	case *ecdsa.PublicKey:
+		der, err := key.Sign(rand.Reader, digest, hash)
+		if err != nil {
+			return nil, err
+		}
+		var sig struct{ R, S *big.Int }
+		if rest, err := asn1.Unmarshal(der, &sig); err != nil || len(rest) != 0 {
+			return nil, errors.New("acme: invalid ECDSA signature")
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 8*size || sig.S.BitLen() > 8*size {
+			return nil, errors.New("acme: invalid ECDSA signature")
+		}
+		out := make([]byte, 2*size)
+		sig.R.FillBytes(out[:size])
+		sig.S.FillBytes(out[size:])
+		return out, nil
+	}
+	return nil, ErrUnsupportedKey
+}
+
+// jwsHasher returns the JWS algorithm and the hash to use with pub, or "" if
+// pub is not supported.
+func jwsHasher(pub crypto.PublicKey) (string, crypto.Hash) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return "RS256", crypto.SHA256
+	case *ecdsa.PublicKey:
+		switch pub.Curve.Params().Name {
+		case "P-256":
+			return "ES256", crypto.SHA256
+		case "P-384":
+			return "ES384", crypto.SHA384
+		case "P-521":
+			return "ES512", crypto.SHA512
+		}
+	}
+	return "", 0
+}
+
+// JWKThumbprint returns the base64url-encoded SHA-256 thumbprint of the JSON
+// Web Key of pub, as specified in RFC 7638.
+func JWKThumbprint(pub crypto.PublicKey) (string, error) {
+	jwk, err := jwkEncode(pub)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(jwk)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func mustDecodeBigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// Example from RFC 7638, Section 3.1.
+	pub := &rsa.PublicKey{
+		N: mustDecodeBigInt(t, "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"),
+		E: 65537,
+	}
+	th, err := JWKThumbprint(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; th != want {
+		t.Errorf("JWKThumbprint = %q, want %q", th, want)
+	}
+
+	if _, err := JWKThumbprint(&ecdsa.PublicKey{Curve: elliptic.P224()}); err != ErrUnsupportedKey {
+		t.Errorf("JWKThumbprint(P-224 key) error = %v, want ErrUnsupportedKey", err)
+	}
+}
+
+func TestJWSEncodeJSON(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testJWSEncodeJSON(t, key, "")
+		testJWSEncodeJSON(t, key, "https://example.com/acct/1")
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testJWSEncodeJSON(t, key, "")
+}
+
+func testJWSEncodeJSON(t *testing.T, key crypto.Signer, kid string) {
+	b, err := jwsEncodeJSON([]byte(`{"a":1}`), key, kid, "nonce", "https://example.com/url")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jws jsonWebSignature
+	if err := json.Unmarshal(b, &jws); err != nil {
+		t.Fatal(err)
+	}
+	protected, _ := base64.RawURLEncoding.DecodeString(jws.Protected)
+	var header struct {
+		Alg, KID, Nonce, URL string
+		JWK                  json.RawMessage
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		t.Fatal(err)
+	}
+	alg, hash := jwsHasher(key.Public())
+	if header.Alg != alg || header.KID != kid || header.Nonce != "nonce" || header.URL != "https://example.com/url" {
+		t.Errorf("unexpected protected header %s", protected)
+	}
+	if jwk, _ := jwkEncode(key.Public()); kid == "" && string(header.JWK) != string(jwk) {
+		t.Errorf("jwk = %s, want %s", header.JWK, jwk)
+	} else if kid != "" && header.JWK != nil {
+		t.Errorf("protected header has both kid and jwk")
+	}
+	if payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload); string(payload) != `{"a":1}` {
+		t.Errorf("payload = %q", payload)
+	}
+
+	sig, _ := base64.RawURLEncoding.DecodeString(jws.Signature)
+	h := hash.New()
+	h.Write([]byte(jws.Protected + "." + jws.Payload))
+	switch pub := key.Public().(type) {
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			t.Fatalf("%s signature is %d bytes, want %d", alg, len(sig), 2*size)
+		}
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, h.Sum(nil), r, s) {
+			t.Errorf("%s signature doesn't verify", alg)
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, hash, h.Sum(nil), sig); err != nil {
+			t.Errorf("%s signature doesn't verify: %v", alg, err)
+		}
+	}
+}
+
+func TestJWSWithMAC(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jws, err := jwsWithMAC([]byte("secret"), "kid-1", "https://example.com/new-account", key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	protected, _ := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if want := `{"alg":"HS256","kid":"kid-1","url":"https://example.com/new-account"}`; string(protected) != want {
+		t.Errorf("protected header = %s, want %s", protected, want)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if jwk, _ := jwkEncode(key.Public()); string(payload) != string(jwk) {
+		t.Errorf("payload = %s, want %s", payload, jwk)
+	}
+	if _, err := jwsWithMAC(nil, "kid-1", "https://example.com/new-account", key.Public()); err == nil {
+		t.Error("jwsWithMAC with an empty key succeeded")
+	}
+}
+
+func TestKeyAuthorizations(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{Key: key}
+	th, _ := JWKThumbprint(key.Public())
+	ka, err := c.HTTP01ChallengeResponse("token")
+	if err != nil || ka != "token."+th {
+		t.Errorf("HTTP01ChallengeResponse = %q, %v; want %q", ka, err, "token."+th)
+	}
+	rec, err := c.DNS01ChallengeRecord("token")
+	sum := sha256.Sum256([]byte("token." + th))
+	if want := base64.RawURLEncoding.EncodeToString(sum[:]); err != nil || rec != want {
+		t.Errorf("DNS01ChallengeRecord = %q, %v; want %q", rec, err, want)
+	}
+	if p := HTTP01ChallengePath("token"); p != "/.well-known/acme-challenge/token" {
+		t.Errorf("HTTP01ChallengePath = %q", p)
+	}
+}
+
+func TestLinkHeader(t *testing.T) {
+	h := http.Header{"Link": {
+		`<https://example.com/alt/1>;rel="alternate", <https://example.com/dir>;rel="index"`,
+		`<https://example.com/alt/2>; rel=alternate`,
+	}}
+	got := linkHeader(h, "alternate")
+	want := []string{"https://example.com/alt/1", "https://example.com/alt/2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("linkHeader = %q, want %q", got, want)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	defer func(f func() time.Time) { timeNow = f }(timeNow)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	tests := []struct {
+		value string
+		d     time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"10", 10 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(time.Minute).Format(http.TimeFormat), time.Minute, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set("Retry-After", tt.value)
+		}
+		if d, ok := retryAfter(h); d != tt.d || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, d, ok, tt.d, tt.ok)
+		}
+	}
+}
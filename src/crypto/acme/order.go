@@ -0,0 +1,509 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// An Identifier is a subject of a certificate, as specified in RFC 8555,
+// Section 9.7.7.
+type Identifier struct {
+	// Type is the identifier type, "dns" for domain names or "ip" for IP
+	// addresses as specified in RFC 8738.
+	Type string `json:"type"`
+
+	// Value is the domain name or the IP address. Domain names may start
+	// with "*." to request a wildcard certificate.
+	Value string `json:"value"`
+}
+
+// DomainIdentifiers returns an Identifier for each of names, which may be
+// domain names or IP addresses.
+func DomainIdentifiers(names ...string) []Identifier {
+	ids := make([]Identifier, len(names))
+	for i, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			ids[i] = Identifier{Type: "ip", Value: ip.String()}
+		} else {
+			ids[i] = Identifier{Type: "dns", Value: name}
+		}
+	}
+	return ids
+}
+
+// An Order is a request for a certificate, as specified in RFC 8555,
+// Section 7.1.3.
+type Order struct {
+	// URL is the order URL.
+	URL string
+
+	// Status is the order status. An order is StatusPending until all its
+	// authorizations are valid, then StatusReady until it is finalized, and
+	// StatusValid once the certificate is issued.
+	Status string
+
+	// Expires is when the order expires, if the CA set it.
+	Expires time.Time
+
+	// Identifiers are the subjects of the requested certificate.
+	Identifiers []Identifier
+
+	// NotBefore and NotAfter are the requested validity period of the
+	// certificate, if any.
+	NotBefore, NotAfter time.Time
+
+	// AuthorizationURLs are the URLs of the authorizations the account must
+	// complete before the order can be finalized, one per identifier.
+	AuthorizationURLs []string
+
+	// FinalizeURL is the URL the certificate signing request is sent to.
+	FinalizeURL string
+
+	// CertificateURL is the URL of the issued certificate, once the order is
+	// valid.
+	CertificateURL string
+
+	// Error is the problem that caused the order to become invalid, if any.
+	Error *Error
+}
+
+// An OrderOption changes the certificate requested by [Client.NewOrder].
+type OrderOption func(*orderRequest)
+
+type orderRequest struct {
+	Identifiers []Identifier `json:"identifiers"`
+	NotBefore   string       `json:"notBefore,omitempty"`
+	NotAfter    string       `json:"notAfter,omitempty"`
+}
+
+// WithValidity requests a certificate valid from notBefore to notAfter.
+// Zero values are omitted. Many CAs reject orders with a requested validity.
+func WithValidity(notBefore, notAfter time.Time) OrderOption {
+	return func(r *orderRequest) {
+		if !notBefore.IsZero() {
+			r.NotBefore = notBefore.UTC().Format(time.RFC3339)
+		}
+		if !notAfter.IsZero() {
+			r.NotAfter = notAfter.UTC().Format(time.RFC3339)
+		}
+	}
+}
+
+// NewOrder creates an order for a certificate for the given identifiers.
+func (c *Client) NewOrder(ctx context.Context, ids []Identifier, opts ...OrderOption) (*Order, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("acme: order has no identifiers")
+	}
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &orderRequest{Identifiers: ids}
+	for _, opt := range opts {
+		opt(req)
+	}
+	res, err := c.post(ctx, nil, dir.NewOrderURL, req, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseOrder(res, "")
+}
+
+// GetOrder fetches the order at url.
+func (c *Client) GetOrder(ctx context.Context, url string) (*Order, error) {
+	res, err := c.postAsGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseOrder(res, url)
+}
+
+// WaitOrder polls the order at url until it is ready to be finalized or
+// valid. If the order becomes invalid, WaitOrder returns an *OrderError.
+func (c *Client) WaitOrder(ctx context.Context, url string) (*Order, error) {
+	for {
+		res, err := c.postAsGet(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		o, err := responseOrder(res, url)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		switch o.Status {
+		case StatusReady, StatusValid:
+			return o, nil
+		case StatusPending, StatusProcessing:
+		default:
+			return nil, &OrderError{URL: url, Status: o.Status, Err: o.Error}
+		}
+		if err := wait(ctx, res.Header); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func responseOrder(res *http.Response, url string) (*Order, error) {
+	var v struct {
+		Status         string       `json:"status"`
+		Expires        time.Time    `json:"expires"`
+		Identifiers    []Identifier `json:"identifiers"`
+		NotBefore      time.Time    `json:"notBefore"`
+		NotAfter       time.Time    `json:"notAfter"`
+		Error          *wireError   `json:"error"`
+		Authorizations []string     `json:"authorizations"`
+		Finalize       string       `json:"finalize"`
+		Certificate    string       `json:"certificate"`
+	}
+	if err := decodeJSON(res, &v); err != nil {
+		return nil, fmt.Errorf("acme: invalid order: %w", err)
+	}
+	if loc := res.Header.Get("Location"); loc != "" {
+		url = loc
+	}
+	o := &Order{
+		URL:               url,
+		Status:            v.Status,
+		Expires:           v.Expires,
+		Identifiers:       v.Identifiers,
+		NotBefore:         v.NotBefore,
+		NotAfter:          v.NotAfter,
+		AuthorizationURLs: v.Authorizations,
+		FinalizeURL:       v.Finalize,
+		CertificateURL:    v.Certificate,
+	}
+	if v.Error != nil {
+		o.Error = v.Error.error(nil)
+	}
+	return o, nil
+}
+
+// CreateOrderCert finalizes order by submitting csr, a DER-encoded
+// certificate signing request, waits for the certificate to be issued, and
+// returns it. The order must be ready; see [Client.WaitOrder].
+//
+// The returned chain holds the DER-encoded leaf certificate followed by its
+// issuers. certURL can be used to fetch the certificate again with
+// [Client.FetchCert].
+func (c *Client) CreateOrderCert(ctx context.Context, order *Order, csr []byte) (chain [][]byte, certURL string, err error) {
+	req := struct {
+		CSR string `json:"csr"`
+	}{
+		CSR: base64.RawURLEncoding.EncodeToString(csr),
+	}
+	res, err := c.post(ctx, nil, order.FinalizeURL, req, http.StatusOK)
+	if err != nil {
+		return nil, "", err
+	}
+	o, err := responseOrder(res, order.URL)
+	res.Body.Close()
+	if err != nil {
+		return nil, "", err
+	}
+
+	for o.Status != StatusValid {
+		switch o.Status {
+		case StatusProcessing:
+		case StatusInvalid, StatusPending, StatusReady:
+			// A ready or pending order would need to be finalized again.
+			return nil, "", &OrderError{URL: o.URL, Status: o.Status, Err: o.Error}
+		default:
+			return nil, "", fmt.Errorf("acme: order %s has unexpected status %q", o.URL, o.Status)
+		}
+		if err := wait(ctx, res.Header); err != nil {
+			return nil, "", err
+		}
+		res, err = c.postAsGet(ctx, o.URL)
+		if err != nil {
+			return nil, "", err
+		}
+		o, err = responseOrder(res, o.URL)
+		res.Body.Close()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if o.CertificateURL == "" {
+		return nil, "", errors.New("acme: valid order is missing the certificate URL")
+	}
+	chain, err = c.FetchCert(ctx, o.CertificateURL)
+	if err != nil {
+		return nil, "", err
+	}
+	return chain, o.CertificateURL, nil
+}
+
+// FetchCert fetches the certificate chain at url, and returns the
+// DER-encoded leaf certificate followed by its issuers.
+func (c *Client) FetchCert(ctx context.Context, url string) ([][]byte, error) {
+	res, err := c.postAsGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxResponseSize {
+		return nil, errors.New("acme: certificate chain is too large")
+	}
+	var chain [][]byte
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("acme: unexpected %q PEM block in certificate chain", block.Type)
+		}
+		chain = append(chain, block.Bytes)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("acme: certificate chain is empty")
+	}
+	return chain, nil
+}
+
+// AlternateCertURLs returns the URLs of alternate chains for the certificate
+// at url, as specified in RFC 8555, Section 7.4.2.
+func (c *Client) AlternateCertURLs(ctx context.Context, url string) ([]string, error) {
+	res, err := c.postAsGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return linkHeader(res.Header, "alternate"), nil
+}
+
+// RevokeCert revokes the DER-encoded certificate cert, giving reason as its
+// CRL reason code, such as 1 for keyCompromise. If key is nil, the request is
+// signed by the account that requested the certificate; otherwise key must be
+// the private key of the certificate.
+func (c *Client) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason int) error {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return err
+	}
+	if dir.RevokeCertURL == "" {
+		return errors.New("acme: the CA doesn't support certificate revocation")
+	}
+	req := struct {
+		Certificate string `json:"certificate"`
+		Reason      int    `json:"reason"`
+	}{
+		Certificate: base64.RawURLEncoding.EncodeToString(cert),
+		Reason:      reason,
+	}
+	res, err := c.post(ctx, key, dir.RevokeCertURL, req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+// An Authorization represents the account's authorization to obtain
+// certificates for an identifier, as specified in RFC 8555, Section 7.1.4.
+type Authorization struct {
+	// URL is the authorization URL.
+	URL string
+
+	// Status is the authorization status. It is StatusPending until one of
+	// its challenges is validated, then StatusValid.
+	Status string
+
+	// Identifier is the subject of the authorization.
+	Identifier Identifier
+
+	// Expires is when the authorization expires, if the CA set it.
+	Expires time.Time
+
+	// Wildcard reports whether the authorization is for a wildcard domain.
+	// Identifier.Value doesn't include the "*." prefix.
+	Wildcard bool
+
+	// Challenges are the ways the account can prove control over the
+	// identifier. Completing any one of them is enough.
+	Challenges []*Challenge
+}
+
+// A Challenge is a way to prove control over an identifier, as specified in
+// RFC 8555, Section 8.
+type Challenge struct {
+	// Type is the challenge type, such as "http-01", "dns-01" or
+	// "tls-alpn-01".
+	Type string
+
+	// URL is the challenge URL, passed to [Client.Accept].
+	URL string
+
+	// Token is the token the key authorization is computed from.
+	Token string
+
+	// Status is the challenge status.
+	Status string
+
+	// Validated is when the CA validated the challenge, if it did.
+	Validated time.Time
+
+	// Error is the problem the CA encountered validating the challenge,
+	// if any.
+	Error *Error
+}
+
+// wireChallenge is the JSON encoding of a challenge.
+type wireChallenge struct {
+	Type      string     `json:"type"`
+	URL       string     `json:"url"`
+	Token     string     `json:"token"`
+	Status    string     `json:"status"`
+	Validated time.Time  `json:"validated"`
+	Error     *wireError `json:"error"`
+}
+
+func (wc *wireChallenge) challenge() *Challenge {
+	ch := &Challenge{
+		Type:      wc.Type,
+		URL:       wc.URL,
+		Token:     wc.Token,
+		Status:    wc.Status,
+		Validated: wc.Validated,
+	}
+	if wc.Error != nil {
+		ch.Error = wc.Error.error(nil)
+	}
+	return ch
+}
+
+// GetAuthorization fetches the authorization at url.
+func (c *Client) GetAuthorization(ctx context.Context, url string) (*Authorization, error) {
+	res, err := c.postAsGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseAuthorization(res, url)
+}
+
+// WaitAuthorization polls the authorization at url until it is valid. If the
+// authorization becomes invalid, WaitAuthorization returns an
+// *AuthorizationError.
+func (c *Client) WaitAuthorization(ctx context.Context, url string) (*Authorization, error) {
+	for {
+		res, err := c.postAsGet(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		a, err := responseAuthorization(res, url)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		switch a.Status {
+		case StatusValid:
+			return a, nil
+		case StatusPending, StatusProcessing:
+		default:
+			aerr := &AuthorizationError{URL: url, Identifier: a.Identifier}
+			for _, ch := range a.Challenges {
+				if ch.Error != nil {
+					aerr.Errors = append(aerr.Errors, ch.Error)
+				}
+			}
+			if len(aerr.Errors) == 0 {
+				aerr.Errors = append(aerr.Errors, fmt.Errorf("acme: authorization is %s", a.Status))
+			}
+			return nil, aerr
+		}
+		if err := wait(ctx, res.Header); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// DeactivateAuthorization relinquishes the authorization at url.
+func (c *Client) DeactivateAuthorization(ctx context.Context, url string) error {
+	req := struct {
+		Status string `json:"status"`
+	}{StatusDeactivated}
+	res, err := c.post(ctx, nil, url, req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+func responseAuthorization(res *http.Response, url string) (*Authorization, error) {
+	var v struct {
+		Status     string           `json:"status"`
+		Identifier Identifier       `json:"identifier"`
+		Expires    time.Time        `json:"expires"`
+		Wildcard   bool             `json:"wildcard"`
+		Challenges []*wireChallenge `json:"challenges"`
+	}
+	if err := decodeJSON(res, &v); err != nil {
+		return nil, fmt.Errorf("acme: invalid authorization: %w", err)
+	}
+	a := &Authorization{
+		URL:        url,
+		Status:     v.Status,
+		Identifier: v.Identifier,
+		Expires:    v.Expires,
+		Wildcard:   v.Wildcard,
+	}
+	for _, wc := range v.Challenges {
+		a.Challenges = append(a.Challenges, wc.challenge())
+	}
+	return a, nil
+}
+
+// GetChallenge fetches the challenge at url.
+func (c *Client) GetChallenge(ctx context.Context, url string) (*Challenge, error) {
+	res, err := c.postAsGet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseChallenge(res)
+}
+
+// Accept tells the CA that the challenge is ready to be validated. The
+// response to the challenge, such as the HTTP resource of an http-01
+// challenge, must be in place before Accept is called.
+//
+// The CA validates the challenge asynchronously; use
+// [Client.WaitAuthorization] to wait for the result.
+func (c *Client) Accept(ctx context.Context, chal *Challenge) (*Challenge, error) {
+	res, err := c.post(ctx, nil, chal.URL, struct{}{}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseChallenge(res)
+}
+
+func responseChallenge(res *http.Response) (*Challenge, error) {
+	var wc wireChallenge
+	if err := decodeJSON(res, &wc); err != nil {
+		return nil, fmt.Errorf("acme: invalid challenge: %w", err)
+	}
+	return wc.challenge(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package aes
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+)
+
+const (
+	xaesKeySize   = 32
+	xaesNonceSize = 24
+)
+
+// NewXAES256GCM returns an AEAD implementing XAES-256-GCM, as specified at
+// https://c2sp.org/XAES-256-GCM, with the given 32-byte key.
+//
+// XAES-256-GCM is an extended-nonce variant of AES-256-GCM, which takes
+// 24-byte nonces: for each message, it derives an AES-256-GCM key from the
+// key and the first 12 bytes of the nonce, and uses the last 12 bytes as the
+// AES-256-GCM nonce. Nonces can be generated randomly, with no practical limit
+// to the number of messages encrypted with a given key, see
+// [cipher.NewAEADWithRandomNonce].
+func NewXAES256GCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != xaesKeySize {
+		return nil, errors.New("crypto/aes: XAES-256-GCM requires a 32-byte key")
+	}
+	c, err := NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	x := &xaes256gcm{c: c}
+
+	// K1 is derived as in NIST SP 800-38B (CMAC), by doubling L = AES(K, 0)
+	// in GF(2^128).
+	c.Encrypt(x.k1[:], x.k1[:])
+	var msb byte
+	for i := len(x.k1) - 1; i >= 0; i-- {
+		msb, x.k1[i] = x.k1[i]>>7, x.k1[i]<<1|msb
+	}
+	x.k1[len(x.k1)-1] ^= msb * 0b10000111
+	return x, nil
+}
+
+type xaes256gcm struct {
+	c  cipher.Block
+	k1 [BlockSize]byte
+}
+
+func (*xaes256gcm) NonceSize() int {
+	return xaesNonceSize
+}
+
+func (*xaes256gcm) Overhead() int {
+	return 16
+}
+
+// deriveGCM returns the AES-256-GCM instance for the first half of a nonce.
+func (x *xaes256gcm) deriveGCM(nonce []byte) cipher.AEAD {
+	// The derived key is CMAC(K, [0x00, 0x01, 'X', 0x00] || N[:12]) ||
+	// CMAC(K, [0x00, 0x02, 'X', 0x00] || N[:12]), where each CMAC input is a
+	// single full block, so it's the encryption of the block XORed with K1.
+	var key [xaesKeySize]byte
+	m1, m2 := key[:BlockSize], key[BlockSize:]
+	m1[1], m1[2] = 1, 'X'
+	copy(m1[4:], nonce)
+	m2[1], m2[2] = 2, 'X'
+	copy(m2[4:], nonce)
+	subtle.XORBytes(m1, m1, x.k1[:])
+	subtle.XORBytes(m2, m2, x.k1[:])
+	x.c.Encrypt(m1, m1)
+	x.c.Encrypt(m2, m2)
+
+	c, err := NewCipher(key[:])
+	if err != nil {
+		panic("crypto/aes: internal error: " + err.Error())
+	}
+	g, err := cipher.NewGCM(c)
+	if err != nil {
+		panic("crypto/aes: internal error: " + err.Error())
+	}
+	return g
+}
+
+func (x *xaes256gcm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != xaesNonceSize {
+		panic("crypto/aes: incorrect nonce length given to XAES-256-GCM")
+	}
+	return x.deriveGCM(nonce[:12]).Seal(dst, nonce[12:], plaintext, additionalData)
+}
+
+func (x *xaes256gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != xaesNonceSize {
+		panic("crypto/aes: incorrect nonce length given to XAES-256-GCM")
+	}
+	return x.deriveGCM(nonce[:12]).Open(dst, nonce[12:], ciphertext, additionalData)
+}
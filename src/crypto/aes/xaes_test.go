@@ -0,0 +1,116 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package aes_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/sha3"
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors from https://c2sp.org/XAES-256-GCM.
+
+func TestXAES256GCM(t *testing.T) {
+	tests := []struct {
+		key        []byte
+		nonce      string
+		plaintext  string
+		ad         string
+		ciphertext string
+	}{
+		{
+			key:        bytes.Repeat([]byte{0x01}, 32),
+			nonce:      "ABCDEFGHIJKLMNOPQRSTUVWX",
+			plaintext:  "XAES-256-GCM",
+			ciphertext: "ce546ef63c9cc60765923609b33a9a1974e96e52daf2fcf7075e2271",
+		},
+		{
+			key:        bytes.Repeat([]byte{0x03}, 32),
+			nonce:      "ABCDEFGHIJKLMNOPQRSTUVWX",
+			plaintext:  "XAES-256-GCM",
+			ad:         "c2sp.org/XAES-256-GCM",
+			ciphertext: "986ec1832593df5443a179437fd083bf3fdb41abd740a21f71eb769d",
+		},
+	}
+	for _, tt := range tests {
+		a, err := aes.NewXAES256GCM(tt.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.NonceSize() != 24 || a.Overhead() != 16 {
+			t.Errorf("NonceSize, Overhead = %d, %d, want 24, 16", a.NonceSize(), a.Overhead())
+		}
+		ct := a.Seal(nil, []byte(tt.nonce), []byte(tt.plaintext), []byte(tt.ad))
+		if got := hex.EncodeToString(ct); got != tt.ciphertext {
+			t.Errorf("Seal = %s, want %s", got, tt.ciphertext)
+		}
+		pt, err := a.Open(nil, []byte(tt.nonce), ct, []byte(tt.ad))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(pt) != tt.plaintext {
+			t.Errorf("Open = %q, want %q", pt, tt.plaintext)
+		}
+		ct[0] ^= 1
+		if _, err := a.Open(nil, []byte(tt.nonce), ct, []byte(tt.ad)); err == nil {
+			t.Error("Open of modified ciphertext succeeded")
+		}
+	}
+}
+
+func TestXAES256GCMAccumulated(t *testing.T) {
+	iterations, expected := 10_000, "e6b9edf2df6cec60c8cbd864e2211b597fb69a529160cd040d56c0c210081939"
+	if testing.Short() {
+		iterations, expected = 1_000, ""
+	}
+
+	s, d := sha3.NewSHAKE128(), sha3.NewSHAKE128()
+	for i := 0; i < iterations; i++ {
+		key := make([]byte, 32)
+		s.Read(key)
+		nonce := make([]byte, 24)
+		s.Read(nonce)
+		n := make([]byte, 1)
+		s.Read(n)
+		plaintext := make([]byte, n[0])
+		s.Read(plaintext)
+		s.Read(n)
+		ad := make([]byte, n[0])
+		s.Read(ad)
+
+		a, err := aes.NewXAES256GCM(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ct := a.Seal(nil, nonce, plaintext, ad)
+		d.Write(ct)
+		pt, err := a.Open(nil, nonce, ct, ad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pt, plaintext) {
+			t.Fatalf("Open = %x, want %x", pt, plaintext)
+		}
+	}
+
+	if expected == "" {
+		return
+	}
+	sum := make([]byte, 32)
+	d.Read(sum)
+	if got := hex.EncodeToString(sum); got != expected {
+		t.Errorf("accumulated hash = %s, want %s", got, expected)
+	}
+}
+
+func TestXAES256GCMKeySize(t *testing.T) {
+	for _, n := range []int{0, 16, 24, 31, 33} {
+		if _, err := aes.NewXAES256GCM(make([]byte, n)); err == nil {
+			t.Errorf("NewXAES256GCM accepted a %d-byte key", n)
+		}
+	}
+}
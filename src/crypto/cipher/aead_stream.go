@@ -0,0 +1,256 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"crypto/internal/sysrand"
+	"errors"
+	"internal/byteorder"
+	"io"
+)
+
+// This file implements the STREAM construction of Hoang, Reyhanitabar, Rogaway
+// and Vizár, "Online Authenticated-Encryption and its Nonce-Reuse
+// Misuse-Resistance", as used by age and Tink, to encrypt a stream of data in
+// authenticated chunks.
+//
+// The stream starts with a random nonce prefix of NonceSize-5 bytes. The
+// plaintext is split into chunks of aeadChunkSize bytes, the last of which
+// may be shorter, and each chunk is sealed with the nonce
+//
+//	prefix || uint32(chunk index) || last flag
+//
+// where the chunk index is big-endian and the last flag is 1 for the last
+// chunk and 0 otherwise. The last chunk can be empty only if it's the first.
+
+const (
+	aeadChunkSize     = 64 << 10
+	aeadChunkNonceLen = 5 // chunk index and last flag
+	maxAEADChunks     = 1 << 32
+)
+
+var errAEADStreamTooLong = errors.New("cipher: AEAD stream is too long")
+
+// errAEADStream is returned when a stream fails authentication, including
+// when it is truncated or extended.
+var errAEADStream = errors.New("cipher: AEAD stream authentication failed")
+
+func checkAEADStreamNonceSize(aead AEAD) error {
+	if aead.NonceSize() < 12 {
+		return errors.New("cipher: AEAD streams require nonces of at least 96 bits")
+	}
+	return nil
+}
+
+// aeadChunkNonce sets the chunk index and last flag of nonce.
+func aeadChunkNonce(nonce []byte, index uint64, last bool) {
+	n := len(nonce) - aeadChunkNonceLen
+	byteorder.BePutUint32(nonce[n:], uint32(index))
+	nonce[len(nonce)-1] = 0
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+}
+
+// NewAEADWriter returns a writer that encrypts the data written to it with
+// aead, in chunks of 64 KiB authenticated individually, and writes the
+// encrypted stream to w. The returned writer must be closed to write the
+// final chunk, which protects the stream from truncation. Close doesn't
+// close w.
+//
+// aead must take nonces of at least 96 bits. Each stream starts with a random
+// nonce prefix of the nonce size minus 5 bytes, so a given key must not
+// encrypt more than 2^24 streams with 96-bit nonces. Larger nonces, like the
+// ones of aes.NewXAES256GCM, remove any practical limit. Each stream holds up
+// to 2^32 chunks, 256 TiB.
+//
+// The stream can be decrypted with [NewAEADReader].
+func NewAEADWriter(aead AEAD, w io.Writer) (io.WriteCloser, error) {
+	if err := checkAEADStreamNonceSize(aead); err != nil {
+		return nil, err
+	}
+	return &aeadWriter{
+		aead:  aead,
+		w:     w,
+		nonce: make([]byte, aead.NonceSize()),
+		buf:   make([]byte, 0, aeadChunkSize+aead.Overhead()),
+	}, nil
+}
+
+type aeadWriter struct {
+	aead    AEAD
+	w       io.Writer
+	nonce   []byte
+	started bool   // whether the nonce prefix was written
+	index   uint64 // index of the next chunk
+	buf     []byte // plaintext of the next chunk
+	err     error
+}
+
+func (w *aeadWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	prefix := w.nonce[:len(w.nonce)-aeadChunkNonceLen]
+	if err := sysrand.Read(prefix); err != nil {
+		return err
+	}
+	_, err := w.w.Write(prefix)
+	return err
+}
+
+// flush seals and writes the buffered chunk.
+func (w *aeadWriter) flush(last bool) error {
+	if w.index >= maxAEADChunks {
+		return errAEADStreamTooLong
+	}
+	aeadChunkNonce(w.nonce, w.index, last)
+	w.index++
+	ciphertext := w.aead.Seal(w.buf[:0], w.nonce, w.buf, nil)
+	w.buf = w.buf[:0]
+	_, err := w.w.Write(ciphertext)
+	return err
+}
+
+// Write encrypts p and writes it to the underlying writer, in full chunks.
+// The last, partial or full, chunk is buffered until Close.
+func (w *aeadWriter) Write(p []byte) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.err = w.start(); w.err != nil {
+		return 0, w.err
+	}
+	for len(p) > 0 {
+		// A full chunk is only flushed when more data follows, since the
+		// last chunk must be marked as such.
+		if len(w.buf) == aeadChunkSize {
+			if w.err = w.flush(false); w.err != nil {
+				return n, w.err
+			}
+		}
+		m := min(len(p), aeadChunkSize-len(w.buf))
+		w.buf = append(w.buf, p[:m]...)
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+// Close encrypts and writes the last chunk. It doesn't close the underlying
+// writer.
+func (w *aeadWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.err = w.start(); w.err != nil {
+		return w.err
+	}
+	if w.err = w.flush(true); w.err != nil {
+		return w.err
+	}
+	w.err = errors.New("cipher: write to closed AEAD stream")
+	return nil
+}
+
+// NewAEADReader returns a reader that decrypts and authenticates the stream
+// read from r, which was encrypted with aead by [NewAEADWriter].
+//
+// The reader returns plaintext only after authenticating the chunk that
+// contains it, but an attacker can still truncate the stream at a chunk
+// boundary or modify it after a chunk has been returned: the stream is only
+// known to be complete and authentic when Read returns io.EOF.
+func NewAEADReader(aead AEAD, r io.Reader) (io.Reader, error) {
+	if err := checkAEADStreamNonceSize(aead); err != nil {
+		return nil, err
+	}
+	return &aeadReader{
+		aead:  aead,
+		r:     r,
+		nonce: make([]byte, aead.NonceSize()),
+		// One extra byte is read to tell whether a chunk is the last.
+		buf: make([]byte, 0, aeadChunkSize+aead.Overhead()+1),
+	}, nil
+}
+
+type aeadReader struct {
+	aead    AEAD
+	r       io.Reader
+	nonce   []byte
+	started bool   // whether the nonce prefix was read
+	index   uint64 // index of the next chunk
+	buf     []byte // ciphertext of the current chunk
+	plain   []byte // decrypted plaintext not yet returned
+	ahead   []byte // byte read after the current chunk, if any
+	last    bool   // whether the last chunk was decrypted
+	err     error
+}
+
+func (r *aeadReader) Read(p []byte) (n int, err error) {
+	for len(r.plain) == 0 && r.err == nil {
+		if r.last {
+			r.err = io.EOF
+			break
+		}
+		r.err = r.readChunk()
+	}
+	if len(r.plain) > 0 {
+		n = copy(p, r.plain)
+		r.plain = r.plain[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+// readChunk reads and decrypts the next chunk into r.plain.
+func (r *aeadReader) readChunk() error {
+	if !r.started {
+		prefix := r.nonce[:len(r.nonce)-aeadChunkNonceLen]
+		if _, err := io.ReadFull(r.r, prefix); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return errAEADStream
+			}
+			return err
+		}
+		r.started = true
+	}
+	if r.index >= maxAEADChunks {
+		return errAEADStreamTooLong
+	}
+
+	// Fill the buffer, so that it contains a full chunk and one more byte
+	// unless the stream ends.
+	chunkLen := aeadChunkSize + r.aead.Overhead()
+	r.buf = append(r.buf[:0], r.ahead...)
+	n, err := io.ReadFull(r.r, r.buf[len(r.buf):cap(r.buf)])
+	r.buf = r.buf[:len(r.buf)+n]
+	last := false
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		last = true
+		if len(r.buf) < r.aead.Overhead() || len(r.buf) == r.aead.Overhead() && r.index > 0 {
+			return errAEADStream
+		}
+	case err != nil:
+		return err
+	}
+
+	chunk := r.buf
+	r.ahead = nil
+	if !last {
+		chunk = r.buf[:chunkLen]
+		r.ahead = r.buf[chunkLen:]
+	}
+	aeadChunkNonce(r.nonce, r.index, last)
+	plain, err := r.aead.Open(chunk[:0], r.nonce, chunk, nil)
+	if err != nil {
+		return errAEADStream
+	}
+	r.index++
+	r.last = last
+	r.plain = plain
+	return nil
+}
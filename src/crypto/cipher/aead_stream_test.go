@@ -0,0 +1,197 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+const aeadChunkSize = 64 << 10
+
+func streamAEADs(t *testing.T) map[string]cipher.AEAD {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := aes.NewXAES256GCM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]cipher.AEAD{"GCM": g, "XAES-256-GCM": x}
+}
+
+func encryptStream(t *testing.T, a cipher.AEAD, plaintext []byte, writeSize int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := cipher.NewAEADWriter(a, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p := plaintext; len(p) > 0; {
+		n := min(len(p), writeSize)
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("x")); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+	return buf.Bytes()
+}
+
+func decryptStream(a cipher.AEAD, r io.Reader) ([]byte, error) {
+	sr, err := cipher.NewAEADReader(a, r)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(sr)
+}
+
+func TestAEADStream(t *testing.T) {
+	sizes := []int{0, 1, 1000, aeadChunkSize - 1, aeadChunkSize, aeadChunkSize + 1,
+		3 * aeadChunkSize, 3*aeadChunkSize + 500}
+	for name, a := range streamAEADs(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, size := range sizes {
+				plaintext := make([]byte, size)
+				for i := range plaintext {
+					plaintext[i] = byte(i * 7)
+				}
+				for _, writeSize := range []int{size + 1, 1000, aeadChunkSize} {
+					ct := encryptStream(t, a, plaintext, writeSize)
+
+					chunks := max(1, (size+aeadChunkSize-1)/aeadChunkSize)
+					wantLen := a.NonceSize() - 5 + size + chunks*a.Overhead()
+					if len(ct) != wantLen {
+						t.Errorf("size %d: len(ciphertext) = %d, want %d", size, len(ct), wantLen)
+					}
+
+					for _, r := range []io.Reader{
+						bytes.NewReader(ct),
+						iotest.OneByteReader(bytes.NewReader(ct)),
+						iotest.HalfReader(bytes.NewReader(ct)),
+					} {
+						pt, err := decryptStream(a, r)
+						if err != nil {
+							t.Fatalf("size %d: %v", size, err)
+						}
+						if !bytes.Equal(pt, plaintext) {
+							t.Fatalf("size %d: decrypted stream doesn't match", size)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestAEADStreamFormat decrypts the chunks of a stream by hand.
+func TestAEADStreamFormat(t *testing.T) {
+	a := streamAEADs(t)["GCM"]
+	plaintext := bytes.Repeat([]byte("A"), aeadChunkSize+10)
+	ct := encryptStream(t, a, plaintext, len(plaintext))
+
+	nonce := make([]byte, 12)
+	copy(nonce, ct[:7])
+	ct = ct[7:]
+	chunk0 := ct[:aeadChunkSize+a.Overhead()]
+	pt0, err := a.Open(nil, nonce, chunk0, nil)
+	if err != nil {
+		t.Fatalf("first chunk: %v", err)
+	}
+	binary.BigEndian.PutUint32(nonce[7:], 1)
+	nonce[11] = 1
+	pt1, err := a.Open(nil, nonce, ct[len(chunk0):], nil)
+	if err != nil {
+		t.Fatalf("last chunk: %v", err)
+	}
+	if !bytes.Equal(append(pt0, pt1...), plaintext) {
+		t.Error("decrypted chunks don't match")
+	}
+}
+
+func TestAEADStreamTampering(t *testing.T) {
+	a := streamAEADs(t)["GCM"]
+	plaintext := make([]byte, 2*aeadChunkSize+100)
+	ct := encryptStream(t, a, plaintext, len(plaintext))
+	chunkLen := aeadChunkSize + a.Overhead()
+	prefixLen := a.NonceSize() - 5
+
+	// A stream of a single full chunk, to check that the last chunk can't be
+	// made to look like a middle one.
+	full := encryptStream(t, a, plaintext[:aeadChunkSize], aeadChunkSize)
+
+	tests := map[string][]byte{
+		"empty":             nil,
+		"short prefix":      ct[:prefixLen-1],
+		"prefix only":       ct[:prefixLen],
+		"short chunk":       ct[:prefixLen+a.Overhead()-1],
+		"truncated chunk":   ct[:len(ct)-1],
+		"truncated at 1":    ct[:prefixLen+chunkLen],
+		"truncated at 2":    ct[:prefixLen+2*chunkLen],
+		"empty last chunk":  append(bytes.Clone(full), make([]byte, a.Overhead())...),
+		"appended byte":     append(bytes.Clone(ct), 0),
+		"appended chunk":    append(bytes.Clone(full), ct[prefixLen+chunkLen:]...),
+		"modified prefix":   flipByte(ct, 0),
+		"modified chunk":    flipByte(ct, prefixLen+chunkLen+10),
+		"modified last":     flipByte(ct, len(ct)-1),
+		"swapped chunks":    swapChunks(ct, prefixLen, chunkLen),
+		"dropped chunk":     append(bytes.Clone(ct[:prefixLen+chunkLen]), ct[prefixLen+2*chunkLen:]...),
+		"full as non-final": append(bytes.Clone(full), full[prefixLen:]...),
+	}
+	for name, bad := range tests {
+		if _, err := decryptStream(a, bytes.NewReader(bad)); err == nil {
+			t.Errorf("%s: decryption succeeded", name)
+		}
+	}
+}
+
+func flipByte(b []byte, i int) []byte {
+	b = bytes.Clone(b)
+	b[i] ^= 1
+	return b
+}
+
+func swapChunks(ct []byte, prefixLen, chunkLen int) []byte {
+	b := bytes.Clone(ct)
+	copy(b[prefixLen:], ct[prefixLen+chunkLen:prefixLen+2*chunkLen])
+	copy(b[prefixLen+chunkLen:], ct[prefixLen:prefixLen+chunkLen])
+	return b
+}
+
+func TestAEADStreamNonceSize(t *testing.T) {
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := cipher.NewGCMWithNonceSize(block, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewAEADWriter(g, io.Discard); err == nil {
+		t.Error("NewAEADWriter accepted an AEAD with 64-bit nonces")
+	}
+	if _, err := cipher.NewAEADReader(g, bytes.NewReader(nil)); err == nil {
+		t.Error("NewAEADReader accepted an AEAD with 64-bit nonces")
+	}
+}
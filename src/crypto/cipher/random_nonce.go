@@ -0,0 +1,124 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"crypto/internal/alias"
+	"crypto/internal/sysrand"
+	"errors"
+)
+
+// NewGCMWithRandomNonce returns the given cipher wrapped in Galois Counter
+// Mode, with randomly-generated nonces. The cipher must be a 128-bit block
+// cipher, such as one created by aes.NewCipher.
+//
+// It generates a random 96-bit nonce, which is prepended to the ciphertext by
+// Seal, and is extracted from the ciphertext by Open. The NonceSize of the
+// AEAD is zero, while the Overhead is 28 bytes (the combination of nonce size
+// and tag size).
+//
+// A given key MUST NOT be used to encrypt more than 2^32 messages, to limit
+// the risk of a random nonce collision to negligible levels.
+func NewGCMWithRandomNonce(cipher Block) (AEAD, error) {
+	if cipher.BlockSize() != gcmBlockSize {
+		return nil, errors.New("cipher: NewGCM requires 128-bit block cipher")
+	}
+	g, err := newGCMWithNonceAndTagSize(cipher, gcmStandardNonceSize, gcmTagSize)
+	if err != nil {
+		return nil, err
+	}
+	return &randomNonceAEAD{aead: g}, nil
+}
+
+// minRandomNonceSize is the nonce size required by NewAEADWithRandomNonce,
+// for which random nonce collisions are negligible for any practical number
+// of messages.
+const minRandomNonceSize = 24
+
+// NewAEADWithRandomNonce returns an AEAD that generates a random nonce for
+// each message it encrypts with aead, which must take nonces of at least 192
+// bits, such as the one returned by aes.NewXAES256GCM or an XChaCha20-Poly1305
+// AEAD.
+//
+// Like with [NewGCMWithRandomNonce], the nonce is prepended to the ciphertext
+// by Seal and extracted from it by Open, and the NonceSize of the returned AEAD
+// is zero. Thanks to the larger nonces, there is no practical limit to the
+// number of messages that can be encrypted with a given key.
+func NewAEADWithRandomNonce(aead AEAD) (AEAD, error) {
+	if aead.NonceSize() < minRandomNonceSize {
+		return nil, errors.New("cipher: NewAEADWithRandomNonce requires an AEAD with nonces of at least 192 bits")
+	}
+	return &randomNonceAEAD{aead: aead}, nil
+}
+
+// randomNonceAEAD wraps an AEAD, generating its nonces and prepending them to
+// the ciphertexts.
+type randomNonceAEAD struct {
+	aead AEAD
+}
+
+func (a *randomNonceAEAD) NonceSize() int {
+	return 0
+}
+
+func (a *randomNonceAEAD) Overhead() int {
+	return a.aead.NonceSize() + a.aead.Overhead()
+}
+
+func (a *randomNonceAEAD) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != 0 {
+		panic("crypto/cipher: non-empty nonce passed to an AEAD with random nonces")
+	}
+	nonceSize := a.aead.NonceSize()
+	ret, out := sliceForAppend(dst, nonceSize+len(plaintext)+a.aead.Overhead())
+	if alias.InexactOverlap(out, plaintext) {
+		panic("crypto/cipher: invalid buffer overlap")
+	}
+	nonce, ciphertext := out[:nonceSize], out[nonceSize:]
+
+	// If plaintext[:0] was passed as dst, the plaintext starts where the
+	// nonce goes. Move it to where the ciphertext goes, so that the wrapped
+	// AEAD sees an exact overlap.
+	if alias.AnyOverlap(out, plaintext) {
+		copy(ciphertext, plaintext)
+		plaintext = ciphertext[:len(plaintext)]
+	}
+
+	if err := sysrand.Read(nonce); err != nil {
+		panic("crypto/cipher: failed to generate random nonce: " + err.Error())
+	}
+	a.aead.Seal(ciphertext[:0], nonce, plaintext, additionalData)
+	return ret
+}
+
+func (a *randomNonceAEAD) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != 0 {
+		panic("crypto/cipher: non-empty nonce passed to an AEAD with random nonces")
+	}
+	nonceSize := a.aead.NonceSize()
+	if len(ciphertext) < nonceSize+a.aead.Overhead() {
+		return nil, errOpen
+	}
+	ret, out := sliceForAppend(dst, len(ciphertext)-nonceSize-a.aead.Overhead())
+	if alias.InexactOverlap(out, ciphertext) {
+		panic("crypto/cipher: invalid buffer overlap")
+	}
+
+	// If ciphertext[:0] was passed as dst, the output starts where the nonce
+	// is. Save the nonce and move the ciphertext to the start of the output,
+	// so that the wrapped AEAD sees an exact overlap.
+	var nonceBuf [minRandomNonceSize * 2]byte
+	nonce = append(nonceBuf[:0], ciphertext[:nonceSize]...)
+	ciphertext = ciphertext[nonceSize:]
+	if alias.AnyOverlap(out, ciphertext) {
+		n := copy(out[:cap(out)], ciphertext)
+		ciphertext = out[:n]
+	}
+
+	if _, err := a.aead.Open(out[:0], nonce, ciphertext, additionalData); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+)
+
+func randomNonceAEADs(t *testing.T) map[string]cipher.AEAD {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := cipher.NewGCMWithRandomNonce(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := aes.NewXAES256GCM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xr, err := cipher.NewAEADWithRandomNonce(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]cipher.AEAD{"GCM": g, "XAES-256-GCM": xr}
+}
+
+func TestRandomNonceAEAD(t *testing.T) {
+	overheads := map[string]int{"GCM": 28, "XAES-256-GCM": 40}
+	for name, a := range randomNonceAEADs(t) {
+		t.Run(name, func(t *testing.T) {
+			if a.NonceSize() != 0 {
+				t.Errorf("NonceSize = %d, want 0", a.NonceSize())
+			}
+			if a.Overhead() != overheads[name] {
+				t.Errorf("Overhead = %d, want %d", a.Overhead(), overheads[name])
+			}
+
+			plaintext := []byte("random nonces are the safe default")
+			ad := []byte("additional data")
+			prefix := []byte("prefix")
+			ct := a.Seal(bytes.Clone(prefix), nil, plaintext, ad)
+			if !bytes.HasPrefix(ct, prefix) {
+				t.Fatalf("Seal didn't append to dst")
+			}
+			ct = ct[len(prefix):]
+			if len(ct) != len(plaintext)+a.Overhead() {
+				t.Errorf("len(ciphertext) = %d, want %d", len(ct), len(plaintext)+a.Overhead())
+			}
+			if ct2 := a.Seal(nil, nil, plaintext, ad); bytes.Equal(ct, ct2) {
+				t.Errorf("Seal returned the same ciphertext twice")
+			}
+
+			pt, err := a.Open(bytes.Clone(prefix), nil, ct, ad)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(pt, append(prefix, plaintext...)) {
+				t.Errorf("Open = %q, want %q", pt, plaintext)
+			}
+
+			for i := range ct {
+				bad := bytes.Clone(ct)
+				bad[i] ^= 0x80
+				if _, err := a.Open(nil, nil, bad, ad); err == nil {
+					t.Fatalf("Open succeeded with byte %d modified", i)
+				}
+			}
+			if _, err := a.Open(nil, nil, ct, nil); err == nil {
+				t.Errorf("Open succeeded with wrong additional data")
+			}
+			for n := 0; n < a.Overhead(); n++ {
+				if _, err := a.Open(nil, nil, ct[:n], ad); err == nil {
+					t.Errorf("Open succeeded with a %d-byte ciphertext", n)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomNonceAEADInPlace(t *testing.T) {
+	for name, a := range randomNonceAEADs(t) {
+		t.Run(name, func(t *testing.T) {
+			plaintext := []byte("encrypted and decrypted in place")
+			buf := make([]byte, len(plaintext), len(plaintext)+a.Overhead())
+			copy(buf, plaintext)
+
+			ct := a.Seal(buf[:0], nil, buf, nil)
+			if &ct[0] != &buf[0] {
+				t.Errorf("Seal with dst = plaintext[:0] reallocated")
+			}
+			pt, err := a.Open(ct[:0], nil, ct, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(pt, plaintext) {
+				t.Errorf("Open = %q, want %q", pt, plaintext)
+			}
+			if &pt[0] != &buf[0] {
+				t.Errorf("Open with dst = ciphertext[:0] reallocated")
+			}
+		})
+	}
+}
+
+func TestRandomNonceAEADNonce(t *testing.T) {
+	a := randomNonceAEADs(t)["GCM"]
+	defer func() {
+		if recover() == nil {
+			t.Error("Seal with a nonce didn't panic")
+		}
+	}()
+	a.Seal(nil, make([]byte, 12), nil, nil)
+}
+
+func TestNewAEADWithRandomNonceNonceSize(t *testing.T) {
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cipher.NewAEADWithRandomNonce(g); err == nil {
+		t.Error("NewAEADWithRandomNonce accepted an AEAD with 96-bit nonces")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hkdf implements the HMAC-based Extract-and-Expand Key Derivation
+// Function (HKDF) as defined in RFC 5869.
+//
+// HKDF is a cryptographic key derivation function (KDF) with the goal of
+// expanding limited input keying material into one or more cryptographically
+// strong secret keys.
+package hkdf
+
+import (
+	"crypto/hmac"
+	"errors"
+	"hash"
+)
+
+// Extract generates a pseudorandom key for use with [Expand] from an input
+// secret and an optional independent salt.
+//
+// Only use this function if you need to reuse the extracted key with multiple
+// Expand invocations and different context values. Most common scenarios,
+// including the generation of multiple keys, should use [Key] instead.
+func Extract[H hash.Hash](h func() H, secret, salt []byte) ([]byte, error) {
+	return extract(wrap(h), secret, salt), nil
+}
+
+// Expand derives a key from the given hash, key, and optional context info,
+// returning a []byte of length keyLength that can be used as cryptographic key.
+// The extraction step is skipped.
+//
+// The key should have been generated by [Extract], or be a uniformly
+// random or pseudorandom cryptographically strong key. See RFC 5869, Section
+// 3.3. Most common scenarios will want to use [Key] instead.
+func Expand[H hash.Hash](h func() H, pseudorandomKey []byte, info string, keyLength int) ([]byte, error) {
+	fh := wrap(h)
+	if err := checkKeyLength(fh, keyLength); err != nil {
+		return nil, err
+	}
+	return expand(fh, pseudorandomKey, info, keyLength), nil
+}
+
+// Key derives a key from the given hash, secret, salt and context info,
+// returning a []byte of length keyLength that can be used as cryptographic key.
+// Salt and info can be nil.
+func Key[H hash.Hash](h func() H, secret, salt []byte, info string, keyLength int) ([]byte, error) {
+	fh := wrap(h)
+	if err := checkKeyLength(fh, keyLength); err != nil {
+		return nil, err
+	}
+	prk := extract(fh, secret, salt)
+	return expand(fh, prk, info, keyLength), nil
+}
+
+func wrap[H hash.Hash](h func() H) func() hash.Hash {
+	if h, ok := any(h).(func() hash.Hash); ok {
+		return h
+	}
+	return func() hash.Hash { return h() }
+}
+
+func checkKeyLength(h func() hash.Hash, keyLength int) error {
+	if keyLength < 0 {
+		return errors.New("hkdf: requested key length is negative")
+	}
+	if keyLength > h().Size()*255 {
+		return errors.New("hkdf: requested key length too large")
+	}
+	return nil
+}
+
+func extract(h func() hash.Hash, secret, salt []byte) []byte {
+	if salt == nil {
+		salt = make([]byte, h().Size())
+	}
+	extractor := hmac.New(h, salt)
+	extractor.Write(secret)
+	return extractor.Sum(nil)
+}
+
+func expand(h func() hash.Hash, pseudorandomKey []byte, info string, keyLength int) []byte {
+	out := make([]byte, 0, keyLength)
+	expander := hmac.New(h, pseudorandomKey)
+	var counter uint8
+	var buf []byte
+
+	for len(out) < keyLength {
+		counter++
+		if counter == 0 {
+			panic("hkdf: counter overflow")
+		}
+		if counter > 1 {
+			expander.Reset()
+		}
+		expander.Write(buf)
+		expander.Write([]byte(info))
+		expander.Write([]byte{counter})
+		buf = expander.Sum(buf[:0])
+		remain := keyLength - len(out)
+		remain = min(remain, len(buf))
+		out = append(out, buf[:remain]...)
+	}
+
+	return out
+}
@@ -28,12 +28,11 @@ package mlkem768
 // background at https://words.filippo.io/kyber-math/ useful.
 
 import (
+	"crypto/internal/sha3"
 	"crypto/rand"
 	"crypto/subtle"
 	"errors"
 	"internal/byteorder"
-
-	"golang.org/x/crypto/sha3"
 )
 
 const (
@@ -181,7 +180,9 @@ func kemKeyGen(dk *DecapsulationKey, d, z *[32]byte) *DecapsulationKey {
 		dk = &DecapsulationKey{}
 	}
 
-	G := sha3.Sum512(d[:])
+	g := sha3.New512()
+	g.Write(d[:])
+	G := g.Sum(make([]byte, 0, 64))
 	ρ, σ := G[:32], G[32:]
 
 	A := &dk.A
@@ -272,10 +273,12 @@ func kemEncaps(cc *[CiphertextSize]byte, ek []byte, m *[messageSize]byte) (c, K
 		cc = &[CiphertextSize]byte{}
 	}
 
-	H := sha3.Sum256(ek[:])
+	h := sha3.New256()
+	h.Write(ek[:])
+	H := h.Sum(make([]byte, 0, 32))
 	g := sha3.New512()
 	g.Write(m[:])
-	g.Write(H[:])
+	g.Write(H)
 	G := g.Sum(nil)
 	K, r := G[:SharedKeySize], G[SharedKeySize:]
 	var ex encryptionKey
@@ -6,6 +6,7 @@ package mlkem768
 
 import (
 	"bytes"
+	"crypto/internal/sha3"
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
@@ -14,8 +15,6 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
-
-	"golang.org/x/crypto/sha3"
 )
 
 func TestFieldReduce(t *testing.T) {
@@ -0,0 +1,29 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !purego
+
+package sha3
+
+import "internal/cpu"
+
+var useSHA3 = cpu.ARM64.HasSHA3
+
+// keccakF1600NEON is implemented in sha3_arm64.s using the ARMv8.2
+// SHA3 extension (EOR3, RAX1, XAR and BCAX).
+//
+//go:noescape
+func keccakF1600NEON(a *[200]byte)
+
+func keccakF1600(a *[200]byte) {
+	if useSHA3 {
+		keccakF1600NEON(a)
+	} else {
+		keccakF1600Generic(a)
+	}
+}
+
+func (d *Digest) write(p []byte) (n int, err error)  { return d.writeGeneric(p) }
+func (d *Digest) read(out []byte) (n int, err error) { return d.readGeneric(out) }
+func (d *Digest) sum(b []byte) []byte                { return d.sumGeneric(b) }
@@ -0,0 +1,26 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha3
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestKeccakF1600 checks that the assembly implementation, if any, matches
+// the generic one.
+func TestKeccakF1600(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		var a, b [200]byte
+		r.Read(a[:])
+		b = a
+		keccakF1600(&a)
+		keccakF1600Generic(&b)
+		if a != b {
+			t.Fatalf("keccakF1600 = %x, want %x", a, b)
+		}
+	}
+}
@@ -4,7 +4,7 @@
 
 //go:build unix
 
-package rand
+package sysrand
 
 import (
 	"bytes"
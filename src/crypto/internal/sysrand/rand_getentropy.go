@@ -4,7 +4,7 @@
 
 //go:build openbsd || netbsd
 
-package rand
+package sysrand
 
 import "internal/syscall/unix"
 
@@ -0,0 +1,32 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sysrand
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+const randomDevice = "/dev/random"
+
+var random struct {
+	sync.Mutex
+	f *os.File
+}
+
+func read(b []byte) error {
+	random.Lock()
+	defer random.Unlock()
+	if random.f == nil {
+		f, err := os.Open(randomDevice)
+		if err != nil {
+			return err
+		}
+		random.f = f
+	}
+	_, err := io.ReadFull(random.f, b)
+	return err
+}
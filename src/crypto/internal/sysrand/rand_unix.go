@@ -0,0 +1,59 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package sysrand
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+)
+
+const urandomDevice = "/dev/urandom"
+
+// altGetRandom if non-nil specifies an OS-specific function to get
+// urandom-style randomness.
+var altGetRandom func([]byte) (err error)
+
+var urandom struct {
+	sync.Mutex
+	f io.Reader
+}
+
+func read(b []byte) error {
+	if altGetRandom != nil && altGetRandom(b) == nil {
+		return nil
+	}
+	urandom.Lock()
+	if urandom.f == nil {
+		f, err := os.Open(urandomDevice)
+		if err != nil {
+			urandom.Unlock()
+			return err
+		}
+		urandom.f = hideAgainReader{f}
+	}
+	f := urandom.f
+	urandom.Unlock()
+	_, err := io.ReadFull(f, b)
+	return err
+}
+
+// hideAgainReader masks EAGAIN reads from /dev/urandom.
+// See golang.org/issue/9205
+type hideAgainReader struct {
+	r io.Reader
+}
+
+func (hr hideAgainReader) Read(p []byte) (n int, err error) {
+	n, err = hr.r.Read(p)
+	if errors.Is(err, syscall.EAGAIN) {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sysrand implements cryptographically secure random number
+// generation using the operating system's random number generator.
+//
+// It backs crypto/rand.Reader, and is usable by packages, like crypto/cipher,
+// that crypto/rand depends on.
+package sysrand
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var firstUse atomic.Bool
+
+func warnBlocked() {
+	println("crypto/rand: blocked for 60 seconds waiting to read random data from the kernel")
+}
+
+// Read fills b with cryptographically secure random bytes from the operating
+// system. It returns an error only if the operating system fails to provide
+// them.
+//
+//   - On Linux, FreeBSD, Dragonfly, and Solaris, Read uses getrandom(2)
+//     if available, and /dev/urandom otherwise.
+//   - On macOS and iOS, Read uses arc4random_buf(3).
+//   - On OpenBSD and NetBSD, Read uses getentropy(2).
+//   - On other Unix-like systems, Read reads from /dev/urandom.
+//   - On Windows, Read uses the ProcessPrng API.
+//   - On js/wasm, Read uses the Web Crypto API.
+//   - On wasip1/wasm, Read uses random_get from wasi_snapshot_preview1.
+//   - On Plan 9, Read reads from /dev/random.
+func Read(b []byte) error {
+	if firstUse.CompareAndSwap(false, true) {
+		// First use of randomness. Start timer to warn about
+		// being blocked on entropy not being available.
+		t := time.AfterFunc(time.Minute, warnBlocked)
+		defer t.Stop()
+	}
+	return read(b)
+}
+
+// batched returns a function that calls f to populate a []byte by chunking it
+// into subslices of, at most, readMax bytes.
+func batched(f func([]byte) error, readMax int) func([]byte) error {
+	return func(out []byte) error {
+		for len(out) > 0 {
+			read := len(out)
+			if read > readMax {
+				read = readMax
+			}
+			if err := f(out[:read]); err != nil {
+				return err
+			}
+			out = out[read:]
+		}
+		return nil
+	}
+}
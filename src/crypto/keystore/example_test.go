@@ -0,0 +1,55 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keystore_test
+
+import (
+	"context"
+	"crypto/keystore"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"log"
+	"net/http"
+)
+
+func ExampleOpen() {
+	// The key is configured by URI, such as a file path or a URI handled by
+	// a provider registered with keystore.Register.
+	keyURI := flag.String("key", "file:///etc/ssl/private/server.pem", "URI of the server key")
+	flag.Parse()
+
+	key, err := keystore.Open(context.Background(), *keyURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer key.Close()
+	cert, err := key.TLSCertificate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv := &http.Server{
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+	}
+	log.Fatal(srv.ListenAndServeTLS("", ""))
+}
+
+func ExampleMemoryProvider() {
+	// A MemoryProvider can stand in for a hardware-backed provider in tests.
+	p := &keystore.MemoryProvider{}
+	var r keystore.Registry
+	r.Register("mem", p)
+
+	if _, err := p.GenerateKey("ca", x509.ECDSA); err != nil {
+		log.Fatal(err)
+	}
+	key, err := r.Open(context.Background(), "mem:ca")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// key.Signer can be passed to x509.CreateCertificate to issue
+	// certificates, and the resulting chain attached to the key.
+	_ = key.Signer
+}
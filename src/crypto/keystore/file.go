@@ -0,0 +1,146 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// openFileURI opens a key from a file URI. The optional "cert" query
+// parameter is the path of a PEM file with the certificate chain, for keys
+// stored without it.
+//
+// Both "file:///path/to/key.pem" and "file:relative/key.pem" are accepted.
+func openFileURI(uri string) (*Key, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		return nil, fmt.Errorf("keystore: file URI with non-local host %q", u.Host)
+	}
+	name := u.Path
+	if u.Opaque != "" {
+		if name, err = url.PathUnescape(u.Opaque); err != nil {
+			return nil, err
+		}
+	} else if runtime.GOOS == "windows" && len(name) >= 3 && name[0] == '/' && name[2] == ':' {
+		name = name[1:] // file:///C:/path
+	}
+	if name == "" {
+		return nil, errors.New("keystore: file URI without a path")
+	}
+	return openFile(name, u.Query().Get("cert"))
+}
+
+// openFile loads a key from the file name, and its certificate chain from
+// that file or from certFile, if not empty.
+//
+// The key file is either PEM-encoded, holding a PKCS #8, PKCS #1 or SEC 1
+// private key and optionally the certificate chain, or a DER-encoded PKCS #8
+// private key.
+func openFile(name, certFile string) (*Key, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var keyDER []byte
+	var chain [][]byte
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		keyDER = data
+	} else {
+		rest := data
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			switch {
+			case block.Type == "CERTIFICATE":
+				chain = append(chain, block.Bytes)
+			case block.Type == "ENCRYPTED PRIVATE KEY" || block.Headers["Proc-Type"] == "4,ENCRYPTED":
+				return nil, fmt.Errorf("keystore: %s: encrypted private keys are not supported", name)
+			case block.Type == "PRIVATE KEY" || strings.HasSuffix(block.Type, " PRIVATE KEY"):
+				if keyDER != nil {
+					return nil, fmt.Errorf("keystore: %s: more than one private key", name)
+				}
+				keyDER = block.Bytes
+			}
+		}
+		if keyDER == nil {
+			return nil, fmt.Errorf("keystore: %s: no private key found", name)
+		}
+	}
+
+	signer, err := parsePrivateKey(keyDER)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %s: %w", name, err)
+	}
+	if certFile != "" {
+		if chain, err = readCertificates(certFile); err != nil {
+			return nil, err
+		}
+	}
+	k := &Key{Signer: signer}
+	if err := k.setCertificate(chain); err != nil {
+		return nil, fmt.Errorf("keystore: %s: %w", name, err)
+	}
+	return k, nil
+}
+
+// parsePrivateKey parses a PKCS #8, PKCS #1 or SEC 1 DER-encoded private key.
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		switch key := key.(type) {
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+			return key.(crypto.Signer), nil
+		default:
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("failed to parse private key")
+}
+
+// readCertificates reads the PEM-encoded certificates in the file name.
+func readCertificates(name string) ([][]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var chain [][]byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			chain = append(chain, block.Bytes)
+		}
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("keystore: %s: no certificates found", name)
+	}
+	return chain, nil
+}
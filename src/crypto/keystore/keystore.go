@@ -0,0 +1,213 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package keystore loads private keys and their certificate chains from
+// configurable locations, identified by URIs.
+//
+// A key is opened with [Open] from a URI such as
+//
+//	file:///etc/ssl/private/server.pem
+//	pkcs11:token=Production;object=server?module-path=/usr/lib/softhsm/libsofthsm2.so
+//
+// and is returned as a [Key], whose [crypto.Signer] can be used by
+// [crypto/tls] or [crypto/x509.CreateCertificate] without the private key
+// leaving the store that holds it.
+//
+// The "file" scheme, and plain file paths, are handled by this package.
+// Other schemes are handled by a [Provider] registered with [Register], such
+// as a PKCS #11 module, a cloud key management service, or the in-memory
+// [MemoryProvider]. Providers for PKCS #11 URIs can use [ParsePKCS11URI].
+package keystore
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ErrKeyNotFound is returned by providers when the key identified by a URI
+// doesn't exist.
+var ErrKeyNotFound = errors.New("keystore: key not found")
+
+// A Key is a private key in a key store, with its certificate chain.
+type Key struct {
+	// Signer performs operations with the private key. It might also
+	// implement [crypto.Decrypter], and [io.Closer] if it holds resources
+	// that must be released, see [Key.Close].
+	Signer crypto.Signer
+
+	// Certificate is the certificate chain of the key, leaf first, in DER
+	// form, or nil if the store has no certificate for the key.
+	Certificate [][]byte
+
+	// Leaf is the parsed form of the leaf certificate, if any.
+	Leaf *x509.Certificate
+}
+
+// TLSCertificate returns a [tls.Certificate] that uses the key and its
+// certificate chain. It returns an error if the key has no certificate.
+func (k *Key) TLSCertificate() (tls.Certificate, error) {
+	if len(k.Certificate) == 0 {
+		return tls.Certificate{}, errors.New("keystore: key has no certificate")
+	}
+	leaf := k.Leaf
+	if leaf == nil {
+		var err error
+		if leaf, err = x509.ParseCertificate(k.Certificate[0]); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	return tls.Certificate{
+		Certificate: k.Certificate,
+		PrivateKey:  k.Signer,
+		Leaf:        leaf,
+	}, nil
+}
+
+// Close releases the resources held by the key's Signer, if it implements
+// [io.Closer]. The key must not be used after Close.
+func (k *Key) Close() error {
+	if c, ok := k.Signer.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// setCertificate sets the certificate chain of k, checking that the leaf is
+// for the key's public key.
+func (k *Key) setCertificate(chain [][]byte) error {
+	if len(chain) == 0 {
+		k.Certificate, k.Leaf = nil, nil
+		return nil
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return err
+	}
+	pub, ok := k.Signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(leaf.PublicKey) {
+		return errors.New("keystore: private key does not match public key in certificate")
+	}
+	k.Certificate, k.Leaf = chain, leaf
+	return nil
+}
+
+// A Provider loads keys from a key store, identified by URIs of the schemes
+// it is registered for.
+type Provider interface {
+	// OpenKey returns the key identified by uri. If the key doesn't exist,
+	// the returned error should wrap ErrKeyNotFound.
+	OpenKey(ctx context.Context, uri string) (*Key, error)
+}
+
+// A Registry maps URI schemes to the providers that handle them.
+//
+// The "file" scheme, and URIs without a scheme, which are file paths, are
+// handled by the registry unless a provider is registered for "file".
+//
+// The zero value is an empty registry ready to use. A Registry is safe for
+// concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+// DefaultRegistry is the Registry used by [Register] and [Open].
+var DefaultRegistry = &Registry{}
+
+// Register makes p handle the URIs with the given scheme, which is not case
+// sensitive. It panics if p is nil or if a provider is already registered
+// for scheme.
+func (r *Registry) Register(scheme string, p Provider) {
+	if p == nil {
+		panic("keystore: Register provider is nil")
+	}
+	scheme = strings.ToLower(scheme)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.providers[scheme]; dup {
+		panic("keystore: Register called twice for scheme " + scheme)
+	}
+	if r.providers == nil {
+		r.providers = make(map[string]Provider)
+	}
+	r.providers[scheme] = p
+}
+
+// Open returns the key identified by uri, using the provider registered for
+// its scheme.
+func (r *Registry) Open(ctx context.Context, uri string) (*Key, error) {
+	scheme := uriScheme(uri)
+	r.mu.RLock()
+	p := r.providers[scheme]
+	r.mu.RUnlock()
+	if p == nil {
+		switch scheme {
+		case "file":
+			return openFileURI(uri)
+		case "":
+			return openFile(uri, "")
+		}
+		return nil, fmt.Errorf("keystore: no provider for URI scheme %q", scheme)
+	}
+	return p.OpenKey(ctx, uri)
+}
+
+// Register makes p handle the URIs with the given scheme in the
+// [DefaultRegistry].
+func Register(scheme string, p Provider) {
+	DefaultRegistry.Register(scheme, p)
+}
+
+// Open returns the key identified by uri, using the [DefaultRegistry].
+func Open(ctx context.Context, uri string) (*Key, error) {
+	return DefaultRegistry.Open(ctx, uri)
+}
+
+// uriScheme returns the lower-case scheme of uri, or "" if uri is a file
+// path.
+func uriScheme(uri string) string {
+	i := strings.IndexByte(uri, ':')
+	if i <= 0 {
+		return ""
+	}
+	if runtime.GOOS == "windows" && i == 1 {
+		return "" // drive letter
+	}
+	scheme := uri[:i]
+	for j, c := range scheme {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9', c == '+', c == '-', c == '.':
+			if j == 0 {
+				return ""
+			}
+		default:
+			return ""
+		}
+	}
+	return strings.ToLower(scheme)
+}
+
+// uriName returns the opaque part or the path of a URI like "scheme:name"
+// or "scheme:///name", which is how the providers in this package identify
+// keys.
+func uriName(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Opaque != "" {
+		return url.PathUnescape(u.Opaque)
+	}
+	return strings.TrimPrefix(u.Path, "/"), nil
+}
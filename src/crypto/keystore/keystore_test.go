@@ -0,0 +1,400 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keystore_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/keystore"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/fs"
+	"maps"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// selfSigned returns a DER-encoded self-signed CA certificate for
+// example.com, signed by key.
+func selfSigned(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "example.com"},
+		DNSNames:              []string{"example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func writePEM(t *testing.T, name string, blocks ...*pem.Block) string {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, b := range blocks {
+		pem.Encode(&buf, b)
+	}
+	return writeFile(t, name, buf.Bytes())
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fileURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive letter
+	}
+	return "file://" + path
+}
+
+func TestOpenFile(t *testing.T) {
+	ctx := context.Background()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecCert := selfSigned(t, ecKey)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPath := writePEM(t, "cert.pem", &pem.Block{Type: "CERTIFICATE", Bytes: ecCert})
+
+	for _, tt := range []struct {
+		name     string
+		uri      string
+		hasChain bool
+	}{
+		{"PKCS #8 with chain", writePEM(t, "key.pem",
+			&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8},
+			&pem.Block{Type: "CERTIFICATE", Bytes: ecCert}), true},
+		{"chain first", fileURI(writePEM(t, "key.pem",
+			&pem.Block{Type: "CERTIFICATE", Bytes: ecCert},
+			&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})), true},
+		{"SEC 1", fileURI(writePEM(t, "key.pem", &pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})), false},
+		{"DER PKCS #8", fileURI(writeFile(t, "key.der", pkcs8)), false},
+		{"separate chain", fileURI(writeFile(t, "key.der", pkcs8)) + "?cert=" + certPath, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := keystore.Open(ctx, tt.uri)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ecKey.PublicKey.Equal(k.Signer.Public()) {
+				t.Errorf("opened a different key")
+			}
+			if !tt.hasChain {
+				if k.Certificate != nil || k.Leaf != nil {
+					t.Errorf("key has a certificate")
+				}
+				if _, err := k.TLSCertificate(); err == nil {
+					t.Errorf("TLSCertificate succeeded without a certificate")
+				}
+				return
+			}
+			if len(k.Certificate) != 1 || !bytes.Equal(k.Certificate[0], ecCert) || k.Leaf == nil {
+				t.Fatalf("got chain of %d certificates", len(k.Certificate))
+			}
+			if _, err := k.TLSCertificate(); err != nil {
+				t.Errorf("TLSCertificate: %v", err)
+			}
+		})
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writePEM(t, "rsa.pem", &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	k, err := keystore.Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := k.Signer.(crypto.Decrypter); !ok || !rsaKey.PublicKey.Equal(k.Signer.Public()) {
+		t.Errorf("opened RSA key is %T", k.Signer)
+	}
+	if err := k.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestOpenFileErrors(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBlock := &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}
+
+	for _, tt := range []struct {
+		name string
+		uri  string
+		err  string
+	}{
+		{"mismatched certificate", writePEM(t, "key.pem", keyBlock,
+			&pem.Block{Type: "CERTIFICATE", Bytes: selfSigned(t, otherKey)}), "does not match"},
+		{"two keys", writePEM(t, "key.pem", keyBlock, keyBlock), "more than one private key"},
+		{"no key", writePEM(t, "key.pem", &pem.Block{Type: "CERTIFICATE", Bytes: selfSigned(t, key)}), "no private key"},
+		{"encrypted", writePEM(t, "key.pem", &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("x")}), "encrypted"},
+		{"garbage", writeFile(t, "key.der", []byte("garbage")), "failed to parse"},
+		{"no chain in cert file", fileURI(writePEM(t, "key.pem", keyBlock)) + "?cert=" + writeFile(t, "cert.pem", nil), "no certificates"},
+		{"remote host", "file://example.com/key.pem", "non-local host"},
+		{"unknown scheme", "unknown:key", `no provider for URI scheme "unknown"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := keystore.Open(ctx, tt.uri)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Open(%q): got error %v, want %q", tt.uri, err, tt.err)
+			}
+		})
+	}
+
+	if _, err := keystore.Open(ctx, filepath.Join(t.TempDir(), "missing.pem")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Open of a missing file: got error %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestMemoryProvider(t *testing.T) {
+	ctx := context.Background()
+	p := &keystore.MemoryProvider{}
+	var r keystore.Registry
+	r.Register("mem", p)
+
+	pub, err := p.GenerateKey("server", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GenerateKey("server", x509.ECDSA); err == nil {
+		t.Errorf("GenerateKey succeeded with an existing name")
+	}
+	if _, err := r.Open(ctx, "mem:missing"); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Errorf("Open of a missing key: got error %v, want ErrKeyNotFound", err)
+	}
+
+	k, err := r.Open(ctx, "MEM:server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := k.Signer.(*ecdsa.PrivateKey); ok {
+		t.Errorf("MemoryProvider exposed the private key")
+	}
+	if !pub.(*ecdsa.PublicKey).Equal(k.Signer.Public()) || k.Certificate != nil {
+		t.Errorf("opened unexpected key")
+	}
+
+	// The Signer can issue the certificate of its own key.
+	cert := selfSigned(t, k.Signer)
+	if err := p.SetCertificateChain("server", [][]byte{cert}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetCertificateChain("missing", [][]byte{cert}); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Errorf("SetCertificateChain of a missing key: got error %v, want ErrKeyNotFound", err)
+	}
+	if _, err := p.GenerateKey("other", x509.Ed25519); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetCertificateChain("other", [][]byte{cert}); err == nil {
+		t.Errorf("SetCertificateChain succeeded with another key's certificate")
+	}
+
+	k, err = r.Open(ctx, "mem:///server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsCert, err := k.TLSCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(k.Leaf)
+	handshake(t, &tls.Config{Certificates: []tls.Certificate{tlsCert}}, &tls.Config{ServerName: "example.com", RootCAs: roots})
+
+	p.DeleteKey("server")
+	if _, err := r.Open(ctx, "mem:server"); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Errorf("Open of a deleted key: got error %v, want ErrKeyNotFound", err)
+	}
+	if _, err := k.Signer.Sign(rand.Reader, make([]byte, 32), crypto.SHA256); err != nil {
+		t.Errorf("Sign with a deleted key: %v", err)
+	}
+}
+
+func TestMemoryProviderRSA(t *testing.T) {
+	p := &keystore.MemoryProvider{}
+	if _, err := p.GenerateKey("rsa", x509.RSA); err != nil {
+		t.Fatal(err)
+	}
+	k, err := p.OpenKey(context.Background(), "mem:rsa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, ok := k.Signer.(crypto.Decrypter)
+	if !ok {
+		t.Fatal("RSA Signer is not a crypto.Decrypter")
+	}
+	msg := []byte("hello")
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, d.Public().(*rsa.PublicKey), msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := d.Decrypt(rand.Reader, ciphertext, &rsa.OAEPOptions{Hash: crypto.SHA256})
+	if err != nil || !bytes.Equal(plaintext, msg) {
+		t.Errorf("Decrypt = %q, %v; want %q", plaintext, err, msg)
+	}
+}
+
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) {
+	t.Helper()
+	c, s := net.Pipe()
+	defer c.Close()
+	defer s.Close()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- tls.Server(s, serverConfig).Handshake()
+	}()
+	if err := tls.Client(c, clientConfig).Handshake(); err != nil {
+		t.Fatalf("client handshake: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("server handshake: %v", err)
+	}
+}
+
+type fakeProvider struct{}
+
+func (fakeProvider) OpenKey(ctx context.Context, uri string) (*keystore.Key, error) {
+	return nil, errors.New("fake: " + uri)
+}
+
+func TestRegistry(t *testing.T) {
+	var r keystore.Registry
+	r.Register("Fake", fakeProvider{})
+	if _, err := r.Open(context.Background(), "fake:key"); err == nil || err.Error() != "fake: fake:key" {
+		t.Errorf("Open didn't use the registered provider: %v", err)
+	}
+	r.Register("file", fakeProvider{})
+	if _, err := r.Open(context.Background(), "file:///key.pem"); err == nil || !strings.HasPrefix(err.Error(), "fake: ") {
+		t.Errorf("Open didn't use the provider registered for file: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("second Register for a scheme didn't panic")
+		}
+	}()
+	r.Register("FAKE", fakeProvider{})
+}
+
+func TestParsePKCS11URI(t *testing.T) {
+	pinFile := writeFile(t, "pin", []byte("1234\n"))
+	for _, tt := range []struct {
+		uri   string
+		path  map[string]string
+		query map[string]string
+	}{
+		// From RFC 7512, Section 3.4.
+		{"pkcs11:", map[string]string{}, map[string]string{}},
+		{"pkcs11:object=my-pubkey;type=public",
+			map[string]string{"object": "my-pubkey", "type": "public"}, map[string]string{}},
+		{"pkcs11:object=my-key;type=private?pin-source=file:" + filepath.ToSlash(pinFile),
+			map[string]string{"object": "my-key", "type": "private"},
+			map[string]string{"pin-source": "file:" + filepath.ToSlash(pinFile)}},
+		{"pkcs11:token=The%20Software%20PKCS%2311%20Softtoken;manufacturer=Snake%20Oil,%20Inc.;model=1.0;object=my-certificate;type=cert;id=%69%95%3E%5C%F4%BD%EC%91;serial=?pin-source=file:" + filepath.ToSlash(pinFile),
+			map[string]string{
+				"token":        "The Software PKCS#11 Softtoken",
+				"manufacturer": "Snake Oil, Inc.",
+				"model":        "1.0",
+				"object":       "my-certificate",
+				"type":         "cert",
+				"id":           "\x69\x95\x3e\x5c\xf4\xbd\xec\x91",
+				"serial":       "",
+			},
+			map[string]string{"pin-source": "file:" + filepath.ToSlash(pinFile)}},
+		{"pkcs11:token=Software%20PKCS%2311%20softtoken;manufacturer=Snake%20Oil,%20Inc.?pin-value=the-pin",
+			map[string]string{"token": "Software PKCS#11 softtoken", "manufacturer": "Snake Oil, Inc."},
+			map[string]string{"pin-value": "the-pin"}},
+		{"pkcs11:token=A%20name%20with%20a%20substring%20%25%3B;object=my-certificate;type=cert",
+			map[string]string{"token": "A name with a substring %;", "object": "my-certificate", "type": "cert"}, map[string]string{}},
+		{"pkcs11:object=my-sign-key;type=private?module-name=mypkcs11&module-path=/mnt/libmypkcs11.so.1",
+			map[string]string{"object": "my-sign-key", "type": "private"},
+			map[string]string{"module-name": "mypkcs11", "module-path": "/mnt/libmypkcs11.so.1"}},
+	} {
+		u, err := keystore.ParsePKCS11URI(tt.uri)
+		if err != nil {
+			t.Errorf("ParsePKCS11URI(%q): %v", tt.uri, err)
+			continue
+		}
+		if !maps.Equal(u.PathAttributes, tt.path) || !maps.Equal(u.QueryAttributes, tt.query) {
+			t.Errorf("ParsePKCS11URI(%q) = %q, %q; want %q, %q", tt.uri, u.PathAttributes, u.QueryAttributes, tt.path, tt.query)
+		}
+		if pin, err := u.PIN(); err != nil {
+			t.Errorf("%q: PIN: %v", tt.uri, err)
+		} else if (pin == "1234") != strings.Contains(tt.uri, "pin-source") || (pin == "the-pin") != strings.Contains(tt.uri, "pin-value") {
+			t.Errorf("%q: PIN = %q", tt.uri, pin)
+		}
+	}
+
+	u, err := keystore.ParsePKCS11URI("pkcs11:token=t;object=o;id=%01%02?module-path=/lib/p11.so")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Token() != "t" || u.Object() != "o" || !bytes.Equal(u.ID(), []byte{1, 2}) || u.ModulePath() != "/lib/p11.so" {
+		t.Errorf("accessors returned %q, %q, %x, %q", u.Token(), u.Object(), u.ID(), u.ModulePath())
+	}
+
+	for _, bad := range []string{
+		"file:///key.pem",
+		"pkcs11:object=a;object=b",
+		"pkcs11:object",
+		"pkcs11:=value",
+		"pkcs11:object=%zz",
+		"pkcs11:object=a#fragment",
+		"pkcs11:?pin-value=1&pin-value=2",
+	} {
+		if _, err := keystore.ParsePKCS11URI(bad); err == nil {
+			t.Errorf("ParsePKCS11URI(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// A MemoryProvider is a [Provider] of software keys held in memory, for tests
+// and for programs that want to treat their keys uniformly.
+//
+// Like a hardware security module, it doesn't let private keys out once they
+// are generated or added: the Signers it returns only expose the operations
+// of the keys. Keys are identified by name, in URIs such as "mem:name" or
+// "mem:///name", where "mem" is the scheme the provider is registered for.
+//
+// The zero value is an empty MemoryProvider ready to use. A MemoryProvider is
+// safe for concurrent use.
+type MemoryProvider struct {
+	mu   sync.Mutex
+	keys map[string]*memoryKey
+}
+
+type memoryKey struct {
+	signer *memorySigner
+	chain  [][]byte
+}
+
+// GenerateKey generates a new key with the given name and returns its public
+// key. ECDSA keys use the P-256 curve, and RSA keys are 2048 bits long.
+// GenerateKey returns an error if a key with the same name exists.
+func (p *MemoryProvider) GenerateKey(name string, algorithm x509.PublicKeyAlgorithm) (crypto.PublicKey, error) {
+	var key crypto.Signer
+	var err error
+	switch algorithm {
+	case x509.ECDSA:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case x509.Ed25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case x509.RSA:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return nil, fmt.Errorf("keystore: unsupported key algorithm %v", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.AddKey(name, key); err != nil {
+		return nil, err
+	}
+	return key.Public(), nil
+}
+
+// AddKey adds an existing key with the given name. It returns an error if a
+// key with the same name exists.
+func (p *MemoryProvider) AddKey(name string, key crypto.Signer) error {
+	if name == "" {
+		return errors.New("keystore: empty key name")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.keys[name]; ok {
+		return fmt.Errorf("keystore: key %q already exists", name)
+	}
+	if p.keys == nil {
+		p.keys = make(map[string]*memoryKey)
+	}
+	p.keys[name] = &memoryKey{signer: &memorySigner{key: key}}
+	return nil
+}
+
+// SetCertificateChain sets the DER-encoded certificate chain of the named
+// key, leaf first. The leaf must be for the key's public key. A nil chain
+// removes the certificates of the key.
+func (p *MemoryProvider) SetCertificateChain(name string, chain [][]byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	mk, ok := p.keys[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, name)
+	}
+	k := &Key{Signer: mk.signer}
+	if err := k.setCertificate(chain); err != nil {
+		return err
+	}
+	mk.chain = k.Certificate
+	return nil
+}
+
+// DeleteKey removes the named key. Signers already returned for it keep
+// working.
+func (p *MemoryProvider) DeleteKey(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.keys, name)
+}
+
+// OpenKey returns the key identified by uri.
+func (p *MemoryProvider) OpenKey(ctx context.Context, uri string) (*Key, error) {
+	name, err := uriName(uri)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	mk, ok := p.keys[name]
+	var chain [][]byte
+	if ok {
+		chain = mk.chain
+	}
+	p.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, name)
+	}
+	k := &Key{Signer: mk.signer}
+	if err := k.setCertificate(chain); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// memorySigner hides the private key of a MemoryProvider key from type
+// assertions, while exposing its operations.
+type memorySigner struct {
+	key crypto.Signer
+}
+
+func (s *memorySigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *memorySigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+// Decrypt implements [crypto.Decrypter] for RSA keys.
+func (s *memorySigner) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	d, ok := s.key.(crypto.Decrypter)
+	if !ok {
+		return nil, fmt.Errorf("keystore: %T keys don't support decryption", s.key.Public())
+	}
+	return d.Decrypt(rand, msg, opts)
+}
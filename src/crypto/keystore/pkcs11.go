@@ -0,0 +1,131 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// A PKCS11URI is a parsed PKCS #11 URI, as specified in RFC 7512, which
+// identifies objects in cryptographic tokens such as hardware security
+// modules and smart cards. For example,
+//
+//	pkcs11:token=Production;object=server;type=private?pin-source=file:/etc/pin
+type PKCS11URI struct {
+	// PathAttributes identify the library, slot, token and object, such as
+	// "token", "object", "id" and "type". Values are percent-decoded, so
+	// binary attributes like "id" hold raw bytes.
+	PathAttributes map[string]string
+
+	// QueryAttributes specify how to access the object, such as
+	// "pin-source", "pin-value", "module-name" and "module-path". Values are
+	// percent-decoded.
+	QueryAttributes map[string]string
+}
+
+// ParsePKCS11URI parses a PKCS #11 URI. Attribute names are not validated
+// against the ones defined by RFC 7512, so vendor-specific attributes are
+// accepted, but each attribute may appear only once.
+func ParsePKCS11URI(uri string) (*PKCS11URI, error) {
+	if uriScheme(uri) != "pkcs11" {
+		return nil, errors.New("keystore: not a pkcs11 URI")
+	}
+	rest := uri[len("pkcs11:"):]
+	if strings.Contains(rest, "#") {
+		return nil, errors.New("keystore: pkcs11 URI with a fragment")
+	}
+	path, query, _ := strings.Cut(rest, "?")
+	u := &PKCS11URI{
+		PathAttributes:  make(map[string]string),
+		QueryAttributes: make(map[string]string),
+	}
+	if err := parsePKCS11Attributes(u.PathAttributes, path, ";"); err != nil {
+		return nil, err
+	}
+	if err := parsePKCS11Attributes(u.QueryAttributes, query, "&"); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func parsePKCS11Attributes(attrs map[string]string, s, sep string) error {
+	if s == "" {
+		return nil
+	}
+	for _, attr := range strings.Split(s, sep) {
+		name, value, ok := strings.Cut(attr, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("keystore: malformed pkcs11 URI attribute %q", attr)
+		}
+		if _, dup := attrs[name]; dup {
+			return fmt.Errorf("keystore: duplicate pkcs11 URI attribute %q", name)
+		}
+		v, err := url.PathUnescape(value)
+		if err != nil {
+			return fmt.Errorf("keystore: malformed pkcs11 URI attribute %q: %w", attr, err)
+		}
+		attrs[name] = v
+	}
+	return nil
+}
+
+// Token returns the "token" path attribute, the label of the token.
+func (u *PKCS11URI) Token() string {
+	return u.PathAttributes["token"]
+}
+
+// Object returns the "object" path attribute, the label of the object.
+func (u *PKCS11URI) Object() string {
+	return u.PathAttributes["object"]
+}
+
+// ID returns the "id" path attribute, the CKA_ID of the object.
+func (u *PKCS11URI) ID() []byte {
+	if id, ok := u.PathAttributes["id"]; ok {
+		return []byte(id)
+	}
+	return nil
+}
+
+// ModulePath returns the "module-path" query attribute, the path of the
+// PKCS #11 library to load.
+func (u *PKCS11URI) ModulePath() string {
+	return u.QueryAttributes["module-path"]
+}
+
+// PIN returns the PIN to log into the token, from the "pin-value" query
+// attribute or read from the local file named by the "pin-source" query
+// attribute, as a path or a "file:" URI, with trailing newlines removed.
+// It returns "" if the URI specifies no PIN.
+func (u *PKCS11URI) PIN() (string, error) {
+	if pin, ok := u.QueryAttributes["pin-value"]; ok {
+		return pin, nil
+	}
+	source, ok := u.QueryAttributes["pin-source"]
+	if !ok {
+		return "", nil
+	}
+	name := source
+	if uriScheme(source) == "file" {
+		pu, err := url.Parse(source)
+		if err != nil {
+			return "", err
+		}
+		if name = pu.Path; pu.Opaque != "" {
+			name = pu.Opaque
+		}
+	} else if uriScheme(source) != "" {
+		return "", fmt.Errorf("keystore: unsupported pin-source %q", source)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
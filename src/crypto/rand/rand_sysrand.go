@@ -0,0 +1,31 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !plan9
+
+package rand
+
+import (
+	"crypto/internal/boring"
+	"crypto/internal/sysrand"
+)
+
+func init() {
+	if boring.Enabled {
+		Reader = boring.RandReader
+		return
+	}
+	Reader = &reader{}
+}
+
+// reader reads from the operating system's random number generator.
+type reader struct{}
+
+func (r *reader) Read(b []byte) (n int, err error) {
+	boring.Unreachable()
+	if err := sysrand.Read(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
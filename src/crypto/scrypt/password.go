@@ -0,0 +1,166 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scrypt
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Params are the cost parameters of a password hash produced by
+// [GenerateFromPassword].
+type Params struct {
+	// LogN is the base-2 logarithm of the CPU/memory cost parameter N.
+	// It must be between 1 and 63.
+	LogN int
+
+	// R is the block size parameter.
+	R int
+
+	// P is the parallelization parameter.
+	P int
+}
+
+// DefaultParams are the parameters used by [GenerateFromPassword] when params
+// is nil. They require 32 MiB of memory per hash, and are subject to change.
+var DefaultParams = Params{LogN: 15, R: 8, P: 1}
+
+const (
+	saltSize = 16
+	hashSize = 32
+)
+
+// ErrMismatchedHashAndPassword is returned by [CompareHashAndPassword] when a
+// password and hash do not match.
+var ErrMismatchedHashAndPassword = errors.New("scrypt: hashedPassword is not the hash of the given password")
+
+// GenerateFromPassword returns the scrypt hash of the password, computed with
+// a new random salt and the given parameters. If params is nil,
+// [DefaultParams] are used.
+//
+// The hash is encoded in the PHC string format, for example
+//
+//	$scrypt$ln=15,r=8,p=1$<salt>$<hash>
+//
+// where salt and hash are encoded with unpadded standard base64. Use
+// [CompareHashAndPassword] to compare the returned hash with a password.
+func GenerateFromPassword(password []byte, params *Params) (string, error) {
+	if params == nil {
+		params = &DefaultParams
+	}
+	if params.LogN < 1 || params.LogN > 63 {
+		return "", errors.New("scrypt: LogN must be between 1 and 63")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := Key(password, salt, 1<<params.LogN, params.R, params.P, hashSize)
+	if err != nil {
+		return "", err
+	}
+	return encodeHash(*params, salt, key), nil
+}
+
+// CompareHashAndPassword compares a hash produced by [GenerateFromPassword]
+// with a possible plaintext equivalent. It returns nil on success, or
+// [ErrMismatchedHashAndPassword] if the password does not match.
+//
+// The cost of the comparison is determined by the parameters encoded in the
+// hash, so hashedPassword must come from a trusted source.
+func CompareHashAndPassword(hashedPassword string, password []byte) error {
+	params, salt, key, err := decodeHash(hashedPassword)
+	if err != nil {
+		return err
+	}
+	other, err := Key(password, salt, 1<<params.LogN, params.R, params.P, len(key))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+	return nil
+}
+
+// HashParams returns the parameters used to create the given hash. It can be
+// used to decide whether a stored hash should be regenerated with stronger
+// parameters.
+func HashParams(hashedPassword string) (Params, error) {
+	params, _, _, err := decodeHash(hashedPassword)
+	return params, err
+}
+
+func encodeHash(params Params, salt, key []byte) string {
+	b := make([]byte, 0, 64+base64.RawStdEncoding.EncodedLen(len(salt)+len(key)))
+	b = append(b, "$scrypt$ln="...)
+	b = strconv.AppendInt(b, int64(params.LogN), 10)
+	b = append(b, ",r="...)
+	b = strconv.AppendInt(b, int64(params.R), 10)
+	b = append(b, ",p="...)
+	b = strconv.AppendInt(b, int64(params.P), 10)
+	b = append(b, '$')
+	b = base64.RawStdEncoding.AppendEncode(b, salt)
+	b = append(b, '$')
+	b = base64.RawStdEncoding.AppendEncode(b, key)
+	return string(b)
+}
+
+var errMalformedHash = errors.New("scrypt: malformed hash")
+
+func decodeHash(s string) (params Params, salt, key []byte, err error) {
+	rest, ok := strings.CutPrefix(s, "$scrypt$")
+	if !ok {
+		return Params{}, nil, nil, errors.New("scrypt: hash is not an scrypt PHC string")
+	}
+	fields := strings.Split(rest, "$")
+	if len(fields) != 3 {
+		return Params{}, nil, nil, errMalformedHash
+	}
+
+	var seen [3]bool
+	for _, kv := range strings.Split(fields[0], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return Params{}, nil, nil, errMalformedHash
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || strconv.Itoa(n) != v {
+			return Params{}, nil, nil, errMalformedHash
+		}
+		var i int
+		switch k {
+		case "ln":
+			i, params.LogN = 0, n
+		case "r":
+			i, params.R = 1, n
+		case "p":
+			i, params.P = 2, n
+		default:
+			return Params{}, nil, nil, errMalformedHash
+		}
+		if seen[i] {
+			return Params{}, nil, nil, errMalformedHash
+		}
+		seen[i] = true
+	}
+	if seen != [3]bool{true, true, true} || params.LogN > 63 {
+		return Params{}, nil, nil, errMalformedHash
+	}
+
+	salt, err = base64.RawStdEncoding.Strict().DecodeString(fields[1])
+	if err != nil {
+		return Params{}, nil, nil, errMalformedHash
+	}
+	key, err = base64.RawStdEncoding.Strict().DecodeString(fields[2])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, errMalformedHash
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scrypt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testParams keeps the tests fast; they are not suitable for real use.
+var testParams = &Params{LogN: 4, R: 8, P: 1}
+
+func TestGenerateFromPassword(t *testing.T) {
+	hash, err := GenerateFromPassword([]byte("password"), testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$scrypt$ln=4,r=8,p=1$") {
+		t.Errorf("unexpected hash format: %q", hash)
+	}
+	if err := CompareHashAndPassword(hash, []byte("password")); err != nil {
+		t.Errorf("CompareHashAndPassword with correct password: %v", err)
+	}
+	if err := CompareHashAndPassword(hash, []byte("Password")); err != ErrMismatchedHashAndPassword {
+		t.Errorf("CompareHashAndPassword with wrong password: got %v, want %v", err, ErrMismatchedHashAndPassword)
+	}
+
+	other, err := GenerateFromPassword([]byte("password"), testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == hash {
+		t.Errorf("two hashes of the same password are equal; salt is not random")
+	}
+
+	params, err := HashParams(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params != *testParams {
+		t.Errorf("HashParams = %+v, want %+v", params, *testParams)
+	}
+}
+
+func TestGenerateFromPasswordInvalidParams(t *testing.T) {
+	for _, p := range []Params{
+		{LogN: 0, R: 8, P: 1},
+		{LogN: 64, R: 8, P: 1},
+		{LogN: 4, R: 0, P: 1},
+		{LogN: 4, R: 8, P: -1},
+	} {
+		if _, err := GenerateFromPassword([]byte("password"), &p); err == nil {
+			t.Errorf("GenerateFromPassword(%+v): expected error", p)
+		}
+	}
+}
+
+func TestCompareHashAndPasswordVector(t *testing.T) {
+	// Generated with Python's hashlib.scrypt.
+	const hash = "$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA"
+	if err := CompareHashAndPassword(hash, []byte("password")); err != nil {
+		t.Errorf("CompareHashAndPassword: %v", err)
+	}
+	if err := CompareHashAndPassword(hash, []byte("passwore")); !errors.Is(err, ErrMismatchedHashAndPassword) {
+		t.Errorf("CompareHashAndPassword with wrong password: got %v", err)
+	}
+}
+
+func TestMalformedHash(t *testing.T) {
+	for _, hash := range []string{
+		"",
+		"$2a$10$abcdefghijklmnopqrstuu",
+		"$scrypt$ln=4,r=8,p=1$c2FsdA",
+		"$scrypt$ln=4,r=8,p=1$c2FsdA$",
+		"$scrypt$ln=4,r=8,p=1$c2FsdA$aGFzaA$",
+		"$scrypt$ln=4,r=8$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r=8,p=1,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r=8,x=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=04,r=8,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=0,r=8,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=64,r=8,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r=-8,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r=8,p=1$c2FsdA==$aGFzaA",
+		"$scrypt$ln=4,r=8,p=1$c2FsdA$aGFzaA!",
+	} {
+		if _, err := HashParams(hash); err == nil {
+			t.Errorf("HashParams(%q): expected error", hash)
+		}
+		if err := CompareHashAndPassword(hash, []byte("password")); err == nil {
+			t.Errorf("CompareHashAndPassword(%q): expected error", hash)
+		}
+	}
+}
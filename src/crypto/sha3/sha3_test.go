@@ -0,0 +1,476 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha3_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/internal/boring"
+	. "crypto/sha3"
+	"encoding"
+	"encoding/hex"
+	"hash"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var testMessages = []string{
+	"",
+	"abc",
+	"The quick brown fox jumps over the lazy dog",
+	strings.Repeat("a", 200),
+}
+
+var testDigests = map[string][]string{
+	"SHA3-224": {
+		"6b4e03423667dbb73b6e15454f0eb1abd4597f9a1b078e3f5b5a6bc7",
+		"e642824c3f8cf24ad09234ee7d3c766fc9a3a5168d0c94ad73b46fdf",
+		"d15dadceaa4d5d7bb3b48f446421d542e08ad8887305e28d58335795",
+		"455e0ccfc6010738ed93a793dffd79aff36debbd1a7eb6621bd6c722",
+	},
+	"SHA3-256": {
+		"a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a",
+		"3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+		"69070dda01975c8c120c3aada1b282394e7f032fa9cf32f4cb2259a0897dfc04",
+		"cce34485baf2bf2aca99b94833892a4f52896d3d153f7b840cc4f9fe695f1387",
+	},
+	"SHA3-384": {
+		"0c63a75b845e4f7d01107d852e4c2485c51a50aaaa94fc61995e71bbee983a2ac3713831264adb47fb6bd1e058d5f004",
+		"ec01498288516fc926459f58e2c6ad8df9b473cb0fc08c2596da7cf0e49be4b298d88cea927ac7f539f1edf228376d25",
+		"7063465e08a93bce31cd89d2e3ca8f602498696e253592ed26f07bf7e703cf328581e1471a7ba7ab119b1a9ebdf8be41",
+		"f97756776c1874724c94a8008f7f155553b4bf00fbf8fbeac246624ad59c258a3c0977d9f2543d7cbd75b9ac8fdc0d40",
+	},
+	"SHA3-512": {
+		"a69f73cca23a9ac5c8b567dc185a756e97c982164fe25859e0d1dcc1475c80a615b2123af1f5f94c11e3e9402c3ac558f500199d95b6d3e301758586281dcd26",
+		"b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0",
+		"01dedd5de4ef14642445ba5f5b97c15e47b9ad931326e4b0727cd94cefc44fff23f07bf543139939b49128caf436dc1bdee54fcb24023a08d9403f9b4bf0d450",
+		"eae6c85c6904f11075de9f9d5e1064371d000510fa3d2d79d40cf9be34892fb01859d0a0234e138bcb0ad5c84f6c0dca226a414b0c9a2897cb695f5185fe36ec",
+	},
+	"SHAKE128": {
+		"7f9c2ba4e88f827d616045507605853ed73b8093f6efbc88eb1a6eacfa66ef26",
+		"5881092dd818bf5cf8a3ddb793fbcba74097d5c526a6d35f97b83351940f2cc8",
+		"f4202e3c5852f9182a0430fd8144f0a74b95e7417ecae17db0f8cfeed0e3e66e",
+		"70ac9b97e891be583e08929ce4cce50d346b05f9597356d6af94d4643d2af3b6",
+	},
+	"SHAKE256": {
+		"46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762fd75dc4ddd8c0f200cb05019d67b592f6fc821c49479ab48640292eacb3b7c4be",
+		"483366601360a8771c6863080cc4114d8db44530f8f1e1ee4f94ea37e78b5739d5a15bef186a5386c75744c0527e1faa9f8726e462a12a4feb06bd8801e751e4",
+		"2f671343d9b2e1604dc9dcf0753e5fe15c7c64a0d283cbbf722d411a0e36f6ca1d01d1369a23539cd80f7c054b6e5daf9c962cad5b8ed5bd11998b40d5734442",
+		"e49647491c9d12d125a2f75826c96f6307d2fabebcbb9fb1616d76b09499380e8bcf60f72750879140e73fb7453a979b69d25efa8de613462f108ce7f2f1d7c5",
+	},
+}
+
+var testSHA3 = map[string]func() *SHA3{
+	"SHA3-224": New224,
+	"SHA3-256": New256,
+	"SHA3-384": New384,
+	"SHA3-512": New512,
+}
+
+var testSHAKE = map[string]func() *SHAKE{
+	"SHAKE128": NewSHAKE128,
+	"SHAKE256": NewSHAKE256,
+}
+
+var testSum = map[string]func([]byte) []byte{
+	"SHA3-224": func(b []byte) []byte { s := Sum224(b); return s[:] },
+	"SHA3-256": func(b []byte) []byte { s := Sum256(b); return s[:] },
+	"SHA3-384": func(b []byte) []byte { s := Sum384(b); return s[:] },
+	"SHA3-512": func(b []byte) []byte { s := Sum512(b); return s[:] },
+	"SHAKE128": func(b []byte) []byte { return SumSHAKE128(b, 32) },
+	"SHAKE256": func(b []byte) []byte { return SumSHAKE256(b, 64) },
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestGolden(t *testing.T) {
+	for name, digests := range testDigests {
+		for i, msg := range testMessages {
+			want := decodeHex(t, digests[i])
+			if got := testSum[name]([]byte(msg)); !bytes.Equal(got, want) {
+				t.Errorf("%s sum(%q) = %x, want %x", name, msg, got, want)
+			}
+
+			if newHash, ok := testSHA3[name]; ok {
+				h := newHash()
+				for j := 0; j < 3; j++ {
+					if j < 2 {
+						io.WriteString(h, msg)
+					} else {
+						io.WriteString(h, msg[:len(msg)/2])
+						io.WriteString(h, msg[len(msg)/2:])
+					}
+					if got := h.Sum(nil); !bytes.Equal(got, want) {
+						t.Errorf("%s(%q) = %x, want %x", name, msg, got, want)
+					}
+					h.Reset()
+				}
+			}
+
+			if newXOF, ok := testSHAKE[name]; ok {
+				h := newXOF()
+				io.WriteString(h, msg)
+				got := make([]byte, len(want))
+				// Read one byte at a time to exercise the squeezing loop.
+				for j := range got {
+					h.Read(got[j : j+1])
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%s(%q) = %x, want %x", name, msg, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestRegisterHash(t *testing.T) {
+	for _, tt := range []struct {
+		h    crypto.Hash
+		size int
+	}{
+		{crypto.SHA3_224, 28},
+		{crypto.SHA3_256, 32},
+		{crypto.SHA3_384, 48},
+		{crypto.SHA3_512, 64},
+	} {
+		if !tt.h.Available() {
+			t.Errorf("%v is not available", tt.h)
+			continue
+		}
+		if got := tt.h.New().Size(); got != tt.size {
+			t.Errorf("%v.New().Size() = %d, want %d", tt.h, got, tt.size)
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	for name, newHash := range testSHA3 {
+		h := newHash()
+		want := len(testSum[name](nil))
+		if h.Size() != want {
+			t.Errorf("%s: Size() = %d, want %d", name, h.Size(), want)
+		}
+	}
+	wantBlockSize := map[string]int{
+		"SHA3-224": 144, "SHA3-256": 136, "SHA3-384": 104, "SHA3-512": 72,
+		"SHAKE128": 168, "SHAKE256": 136,
+	}
+	for name, newHash := range testSHA3 {
+		if got := newHash().BlockSize(); got != wantBlockSize[name] {
+			t.Errorf("%s: BlockSize() = %d, want %d", name, got, wantBlockSize[name])
+		}
+	}
+	for name, newXOF := range testSHAKE {
+		if got := newXOF().BlockSize(); got != wantBlockSize[name] {
+			t.Errorf("%s: BlockSize() = %d, want %d", name, got, wantBlockSize[name])
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var h SHA3
+	io.WriteString(&h, "abc")
+	if got, want := h.Sum(nil), decodeHex(t, testDigests["SHA3-256"][1]); !bytes.Equal(got, want) {
+		t.Errorf("zero SHA3 = %x, want SHA3-256 %x", got, want)
+	}
+
+	var x SHAKE
+	io.WriteString(&x, "abc")
+	got := make([]byte, 64)
+	x.Read(got)
+	if want := decodeHex(t, testDigests["SHAKE256"][1]); !bytes.Equal(got, want) {
+		t.Errorf("zero SHAKE = %x, want SHAKE256 %x", got, want)
+	}
+}
+
+func TestSumAppend(t *testing.T) {
+	h := New256()
+	io.WriteString(h, "abc")
+	prefix := []byte("prefix")
+	got := h.Sum(prefix)
+	want := append([]byte("prefix"), decodeHex(t, testDigests["SHA3-256"][1])...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Sum(prefix) = %x, want %x", got, want)
+	}
+	// Sum must not change the underlying state.
+	io.WriteString(h, "def")
+	if got, want := h.Sum(nil), Sum256([]byte("abcdef")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Sum after Sum = %x, want %x", got, want)
+	}
+}
+
+// TestUnalignedWrite tests that writing data in an arbitrary pattern with
+// small input buffers produces the same output as a single large write.
+func TestUnalignedWrite(t *testing.T) {
+	buf := make([]byte, 4096)
+	rand.New(rand.NewSource(1)).Read(buf)
+
+	for name, newHash := range testSHA3 {
+		want := newHash()
+		want.Write(buf)
+
+		got := newHash()
+		for i := 0; i < len(buf); {
+			// Cycle through offsets which make a 137 byte sequence.
+			// Because 137 is prime this sequence should exercise all corner cases.
+			for _, j := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 1} {
+				j = min(j, len(buf)-i)
+				got.Write(buf[i : i+j])
+				i += j
+			}
+		}
+		if !bytes.Equal(got.Sum(nil), want.Sum(nil)) {
+			t.Errorf("%s: unaligned writes produced a different digest", name)
+		}
+	}
+}
+
+func TestSHAKELongOutput(t *testing.T) {
+	for name, newXOF := range testSHAKE {
+		h := newXOF()
+		io.WriteString(h, "abc")
+		want := make([]byte, 1000)
+		h.Read(want)
+
+		h.Reset()
+		io.WriteString(h, "abc")
+		got := make([]byte, 0, 1000)
+		for len(got) < 1000 {
+			n := min(len(got)%97+1, 1000-len(got))
+			b := make([]byte, n)
+			h.Read(b)
+			got = append(got, b...)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: chunked reads produced a different output", name)
+		}
+
+		var sum []byte
+		switch name {
+		case "SHAKE128":
+			sum = SumSHAKE128([]byte("abc"), 1000)
+		case "SHAKE256":
+			sum = SumSHAKE256([]byte("abc"), 1000)
+		}
+		if !bytes.Equal(sum, want) {
+			t.Errorf("%s: Sum produced a different output", name)
+		}
+	}
+}
+
+func TestWriteAfterRead(t *testing.T) {
+	h := NewSHAKE128()
+	h.Read(make([]byte, 10))
+	defer func() {
+		if recover() == nil {
+			t.Error("Write after Read did not panic")
+		}
+	}()
+	h.Write([]byte("abc"))
+}
+
+// TestCSHAKE checks the cSHAKE samples from
+// https://csrc.nist.gov/projects/cryptographic-standards-and-guidelines/example-values.
+func TestCSHAKE(t *testing.T) {
+	long := make([]byte, 200)
+	for i := range long {
+		long[i] = byte(i)
+	}
+	tests := []struct {
+		newXOF func(N, S []byte) *SHAKE
+		S      string
+		data   []byte
+		want   string
+	}{
+		{NewCSHAKE128, "Email Signature", []byte{0, 1, 2, 3},
+			"c1c36925b6409a04f1b504fcbca9d82b4017277cb5ed2b2065fc1d3814d5aaf5"},
+		{NewCSHAKE128, "Email Signature", long,
+			"c5221d50e4f822d96a2e8881a961420f294b7b24fe3d2094baed2c6524cc166b"},
+		{NewCSHAKE256, "Email Signature", []byte{0, 1, 2, 3},
+			"d008828e2b80ac9d2218ffee1d070c48b8e4c87bff32c9699d5b6896eee0edd1" +
+				"64020e2be0560858d9c00c037e34a96937c561a74c412bb4c746469527281c8c"},
+		{NewCSHAKE256, "Email Signature", long,
+			"07dc27b11e51fbac75bc7b3c1d983e8b4b85fb1defaf218912ac86430273091" +
+				"727f42b17ed1df63e8ec118f04b23633c1dfb1574c8fb55cb45da8e25afb092bb"},
+	}
+	for i, tt := range tests {
+		want := decodeHex(t, tt.want)
+		h := tt.newXOF(nil, []byte(tt.S))
+		for j := 0; j < 2; j++ {
+			h.Write(tt.data)
+			got := make([]byte, len(want))
+			h.Read(got)
+			if !bytes.Equal(got, want) {
+				t.Errorf("#%d: got %x, want %x", i, got, want)
+			}
+			// Reset must restore the customization.
+			h.Reset()
+		}
+	}
+
+	// With empty N and S, cSHAKE is SHAKE.
+	c, s := NewCSHAKE128(nil, nil), NewSHAKE128()
+	c.Write([]byte("abc"))
+	s.Write([]byte("abc"))
+	cOut, sOut := make([]byte, 32), make([]byte, 32)
+	c.Read(cOut)
+	s.Read(sOut)
+	if !bytes.Equal(cOut, sOut) {
+		t.Errorf("cSHAKE128 with empty N and S = %x, want %x", cOut, sOut)
+	}
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	msg := []byte(strings.Repeat("marshal me ", 50))
+	type xof interface {
+		io.Writer
+		io.Reader
+		encoding.BinaryMarshaler
+		encoding.BinaryUnmarshaler
+	}
+	newXOFs := map[string]func() xof{
+		"SHAKE128":  func() xof { return NewSHAKE128() },
+		"SHAKE256":  func() xof { return NewSHAKE256() },
+		"cSHAKE128": func() xof { return NewCSHAKE128([]byte("N"), []byte("S")) },
+		"cSHAKE256": func() xof { return NewCSHAKE256([]byte("N"), []byte("S")) },
+	}
+	for name, newHash := range testSHA3 {
+		for _, split := range []int{0, 1, 71, 136, 200, len(msg)} {
+			h := newHash()
+			h.Write(msg[:split])
+			state, err := h.MarshalBinary()
+			if err != nil {
+				t.Fatalf("%s: MarshalBinary: %v", name, err)
+			}
+			h2 := newHash()
+			if err := h2.UnmarshalBinary(state); err != nil {
+				t.Fatalf("%s: UnmarshalBinary: %v", name, err)
+			}
+			h.Write(msg[split:])
+			h2.Write(msg[split:])
+			if got, want := h2.Sum(nil), h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("%s split at %d: got %x, want %x", name, split, got, want)
+			}
+		}
+	}
+	for name, newXOF := range newXOFs {
+		for _, read := range []int{0, 1, 300} {
+			h := newXOF()
+			h.Write(msg)
+			if read > 0 {
+				h.Read(make([]byte, read))
+			}
+			state, err := h.MarshalBinary()
+			if err != nil {
+				t.Fatalf("%s: MarshalBinary: %v", name, err)
+			}
+			h2 := newXOF()
+			if err := h2.UnmarshalBinary(state); err != nil {
+				t.Fatalf("%s: UnmarshalBinary: %v", name, err)
+			}
+			want, got := make([]byte, 500), make([]byte, 500)
+			h.Read(want)
+			h2.Read(got)
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s after reading %d bytes: got %x, want %x", name, read, got, want)
+			}
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	h := New256()
+	h.Write([]byte("abc"))
+	state, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := New512().UnmarshalBinary(state); err == nil {
+		t.Error("SHA3-512 accepted a SHA3-256 state")
+	}
+	if err := NewSHAKE256().UnmarshalBinary(state); err == nil {
+		t.Error("SHAKE256 accepted a SHA3-256 state")
+	}
+	if err := New256().UnmarshalBinary(state[:len(state)-1]); err == nil {
+		t.Error("accepted a truncated state")
+	}
+	bad := bytes.Clone(state)
+	bad[len(bad)-2] = 200 // n > rate
+	if err := New256().UnmarshalBinary(bad); err == nil {
+		t.Error("accepted an out of range buffer offset")
+	}
+}
+
+var _ hash.Hash = (*SHA3)(nil)
+
+func TestAllocations(t *testing.T) {
+	if boring.Enabled {
+		t.Skip("BoringCrypto doesn't allocate the same way as stdlib")
+	}
+	in := []byte("hello, world!")
+	out := make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(10, func() {
+		h := New256()
+		h.Write(in)
+		out = h.Sum(out[:0])
+	}); n > 0 {
+		t.Errorf("allocs = %v, want 0", n)
+	}
+	if n := testing.AllocsPerRun(10, func() {
+		s := Sum256(in)
+		out = append(out[:0], s[:]...)
+	}); n > 0 {
+		t.Errorf("allocs = %v, want 0", n)
+	}
+	if n := testing.AllocsPerRun(10, func() {
+		out = append(out[:0], SumSHAKE128(in, 32)...)
+	}); n > 0 {
+		t.Errorf("allocs = %v, want 0", n)
+	}
+}
+
+var bench = New256()
+var buf = make([]byte, 8192)
+
+func benchmarkSize(b *testing.B, size int) {
+	b.SetBytes(int64(size))
+	sum := make([]byte, bench.Size())
+	for i := 0; i < b.N; i++ {
+		bench.Reset()
+		bench.Write(buf[:size])
+		bench.Sum(sum[:0])
+	}
+}
+
+func BenchmarkHash8Bytes(b *testing.B) {
+	benchmarkSize(b, 8)
+}
+
+func BenchmarkHash1K(b *testing.B) {
+	benchmarkSize(b, 1024)
+}
+
+func BenchmarkHash8K(b *testing.B) {
+	benchmarkSize(b, 8192)
+}
+
+func BenchmarkSHAKE256(b *testing.B) {
+	out := make([]byte, 64)
+	b.SetBytes(1024)
+	for i := 0; i < b.N; i++ {
+		h := NewSHAKE256()
+		h.Write(buf[:1024])
+		h.Read(out)
+	}
+}
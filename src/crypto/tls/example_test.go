@@ -227,3 +227,35 @@ func ExampleConfig_verifyConnection() {
 	// Note that when certificates are not handled by the default verifier
 	// ConnectionState.VerifiedChains will be nil.
 }
+
+func ExampleConfig_verifyConnectionCertificateTransparency() {
+	// This example shows a client VerifyConnection implementation that, in
+	// addition to the usual verification, requires the server certificate to
+	// be logged in at least two Certificate Transparency logs, using the SCTs
+	// embedded in the certificate, sent in the TLS handshake, or included in
+	// the stapled OCSP response.
+
+	// The trusted logs, usually loaded from a log list.
+	var logs []*x509.CTLog
+
+	_ = &tls.Config{
+		// Set InsecureSkipVerify to skip the default validation we are
+		// replacing. This will not disable VerifyConnection.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			opts := x509.VerifyOptions{
+				DNSName:                     cs.ServerName,
+				Intermediates:               x509.NewCertPool(),
+				OCSPStaple:                  cs.OCSPResponse,
+				SignedCertificateTimestamps: cs.SignedCertificateTimestamps,
+				CTLogs:                      logs,
+				MinValidSCTs:                2,
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
@@ -6,16 +6,16 @@ package tls
 
 import (
 	"crypto/ecdh"
+	"crypto/hkdf"
 	"crypto/hmac"
 	"crypto/internal/mlkem768"
+	"crypto/sha3"
 	"errors"
 	"fmt"
 	"hash"
 	"io"
 
 	"golang.org/x/crypto/cryptobyte"
-	"golang.org/x/crypto/hkdf"
-	"golang.org/x/crypto/sha3"
 )
 
 // This file contains the functions necessary to compute the TLS 1.3 key
@@ -60,9 +60,8 @@ func (c *cipherSuiteTLS13) expandLabel(secret []byte, label string, context []by
 		// significantly more confusing to users.
 		panic(fmt.Errorf("failed to construct HKDF label: %s", err))
 	}
-	out := make([]byte, length)
-	n, err := hkdf.Expand(c.hash.New, secret, hkdfLabelBytes).Read(out)
-	if err != nil || n != length {
+	out, err := hkdf.Expand(c.hash.New, secret, string(hkdfLabelBytes), length)
+	if err != nil {
 		panic("tls: HKDF-Expand-Label invocation failed unexpectedly")
 	}
 	return out
@@ -81,7 +80,11 @@ func (c *cipherSuiteTLS13) extract(newSecret, currentSecret []byte) []byte {
 	if newSecret == nil {
 		newSecret = make([]byte, c.hash.Size())
 	}
-	return hkdf.Extract(c.hash.New, newSecret, currentSecret)
+	prk, err := hkdf.Extract(c.hash.New, newSecret, currentSecret)
+	if err != nil {
+		panic("tls: HKDF-Extract invocation failed unexpectedly")
+	}
+	return prk
 }
 
 // nextTrafficSecret generates the next traffic secret, given the current one,
@@ -147,7 +150,7 @@ func kyberSharedSecret(K, c []byte) []byte {
 	// Package mlkem768 implements ML-KEM, which compared to Kyber removed a
 	// final hashing step. Compute SHAKE-256(K || SHA3-256(c), 32) to match Kyber.
 	// See https://words.filippo.io/mlkem768/#bonus-track-using-a-ml-kem-implementation-as-kyber-v3.
-	h := sha3.NewShake256()
+	h := sha3.NewSHAKE256()
 	h.Write(K)
 	ch := sha3.Sum256(c)
 	h.Write(ch[:])
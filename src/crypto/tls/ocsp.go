@@ -0,0 +1,192 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// An OCSPFetcher sends a DER-encoded OCSP request to the OCSP responder at
+// responderURL, and returns its DER-encoded response.
+//
+// A typical implementation sends the request as the body of an HTTP POST
+// with Content-Type "application/ocsp-request", as specified in RFC 6960,
+// Appendix A.1.
+type OCSPFetcher func(ctx context.Context, responderURL string, request []byte) ([]byte, error)
+
+// An OCSPStapler keeps the OCSP staple of a [Certificate] fresh, by fetching
+// new responses from the OCSP responder of the leaf certificate before the
+// current one expires.
+//
+// Use [OCSPStapler.GetCertificate] as [Config.GetCertificate] to serve the
+// certificate with its latest staple, and call [OCSPStapler.Run] in its own
+// goroutine to keep it up to date.
+type OCSPStapler struct {
+	fetch        OCSPFetcher
+	leaf, issuer *x509.Certificate
+	responder    string
+	request      []byte
+
+	mu   sync.Mutex // serializes updates to cert and resp
+	cert atomic.Pointer[Certificate]
+	resp atomic.Pointer[x509.OCSPResponse]
+}
+
+const (
+	// ocspMinRetry and ocspMaxRetry bound the delay before retrying a failed
+	// OCSP fetch.
+	ocspMinRetry = time.Minute
+	ocspMaxRetry = time.Hour
+	// ocspDefaultRefresh is used when a response has no NextUpdate.
+	ocspDefaultRefresh = time.Hour
+)
+
+// NewOCSPStapler returns an OCSPStapler for cert, which will use fetch to
+// retrieve OCSP responses.
+//
+// cert.Certificate must contain the leaf certificate followed by its issuer,
+// and the leaf must list an OCSP server. Any OCSPStaple already in cert is
+// served until it is replaced by a fresher one, provided it's valid.
+func NewOCSPStapler(cert *Certificate, fetch OCSPFetcher) (*OCSPStapler, error) {
+	if fetch == nil {
+		return nil, errors.New("tls: OCSPStapler requires an OCSPFetcher")
+	}
+	if len(cert.Certificate) < 2 {
+		return nil, errors.New("tls: OCSP stapling requires the issuer certificate in the chain")
+	}
+	leaf, err := cert.leaf()
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := x509.ParseCertificate(cert.Certificate[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return nil, errors.New("tls: certificate doesn't list an OCSP server")
+	}
+	request, err := x509.CreateOCSPRequest(leaf, issuer, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &OCSPStapler{
+		fetch:     fetch,
+		leaf:      leaf,
+		issuer:    issuer,
+		responder: leaf.OCSPServer[0],
+		request:   request,
+	}
+	c := *cert
+	c.Leaf = leaf
+	c.OCSPStaple = nil
+	if len(cert.OCSPStaple) > 0 {
+		if resp, err := s.check(cert.OCSPStaple, time.Now()); err == nil {
+			c.OCSPStaple = cert.OCSPStaple
+			s.resp.Store(resp)
+		}
+	}
+	s.cert.Store(&c)
+	return s, nil
+}
+
+// Certificate returns the certificate with its current OCSP staple, if any.
+// The returned Certificate must not be modified.
+func (s *OCSPStapler) Certificate() *Certificate {
+	return s.cert.Load()
+}
+
+// GetCertificate returns the certificate with its current OCSP staple, if any.
+// It can be used as [Config.GetCertificate].
+func (s *OCSPStapler) GetCertificate(*ClientHelloInfo) (*Certificate, error) {
+	return s.cert.Load(), nil
+}
+
+// Refresh fetches a new OCSP response, and if it's valid, makes it the
+// staple served from now on.
+//
+// A valid response is signed by the issuer of the certificate or by its
+// delegated responder, is current, and reports the certificate as good or
+// revoked. If the fetched response is not valid, the previous staple is kept
+// until it expires.
+func (s *OCSPStapler) Refresh(ctx context.Context) error {
+	der, err := s.fetch(ctx, s.responder, s.request)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		var resp *x509.OCSPResponse
+		if resp, err = s.check(der, time.Now()); err == nil {
+			c := *s.cert.Load()
+			c.OCSPStaple = der
+			s.cert.Store(&c)
+			s.resp.Store(resp)
+			return nil
+		}
+	}
+
+	// Stop serving a staple that has expired: clients reject them.
+	if resp := s.resp.Load(); resp != nil && !resp.NextUpdate.IsZero() && time.Now().After(resp.NextUpdate) {
+		c := *s.cert.Load()
+		c.OCSPStaple = nil
+		s.cert.Store(&c)
+		s.resp.Store(nil)
+	}
+	return err
+}
+
+// check parses and validates an OCSP response for the stapler's certificate.
+func (s *OCSPStapler) check(der []byte, now time.Time) (*x509.OCSPResponse, error) {
+	resp, err := x509.ParseOCSPResponseForCert(der, s.leaf, s.issuer)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == x509.OCSPUnknown {
+		return nil, errors.New("tls: OCSP responder doesn't know the certificate")
+	}
+	if now.Before(resp.ThisUpdate) || !resp.NextUpdate.IsZero() && now.After(resp.NextUpdate) {
+		return nil, errors.New("tls: OCSP response is not current")
+	}
+	return resp, nil
+}
+
+// Run refreshes the OCSP staple until ctx is canceled. It fetches a new
+// response immediately, then again halfway through the validity period of
+// each response. Failed fetches are retried with exponential backoff.
+func (s *OCSPStapler) Run(ctx context.Context) {
+	retry := ocspMinRetry
+	for {
+		var wait time.Duration
+		if err := s.Refresh(ctx); err != nil {
+			wait = retry
+			retry = min(2*retry, ocspMaxRetry)
+		} else {
+			wait = nextOCSPRefresh(s.resp.Load(), time.Now())
+			retry = ocspMinRetry
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// nextOCSPRefresh returns how long to wait before refreshing resp.
+func nextOCSPRefresh(resp *x509.OCSPResponse, now time.Time) time.Duration {
+	if resp.NextUpdate.IsZero() {
+		return ocspDefaultRefresh
+	}
+	refresh := resp.ThisUpdate.Add(resp.NextUpdate.Sub(resp.ThisUpdate) / 2)
+	return max(refresh.Sub(now), ocspMinRetry)
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type ocspTestPKI struct {
+	ca, leaf *x509.Certificate
+	caKey    *ecdsa.PrivateKey
+	cert     *Certificate
+}
+
+func newOCSPTestPKI(t *testing.T) *ocspTestPKI {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "OCSP Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		OCSPServer:   []string{"http://ocsp.example.com"},
+	}, ca, leafKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ocspTestPKI{
+		ca:    ca,
+		leaf:  leaf,
+		caKey: caKey,
+		cert: &Certificate{
+			Certificate: [][]byte{leafDER, caDER},
+			PrivateKey:  leafKey,
+		},
+	}
+}
+
+func (p *ocspTestPKI) response(t *testing.T, status x509.OCSPStatus, thisUpdate, nextUpdate time.Time) []byte {
+	der, err := x509.CreateOCSPResponse(rand.Reader, &x509.OCSPResponse{
+		Status:       status,
+		SerialNumber: p.leaf.SerialNumber,
+		ThisUpdate:   thisUpdate,
+		NextUpdate:   nextUpdate,
+		RevokedAt:    thisUpdate,
+	}, p.ca, nil, p.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestOCSPStapler(t *testing.T) {
+	p := newOCSPTestPKI(t)
+	now := time.Now()
+	good := p.response(t, x509.OCSPGood, now.Add(-time.Minute), now.Add(time.Hour))
+	unknown := p.response(t, x509.OCSPUnknown, now.Add(-time.Minute), now.Add(time.Hour))
+
+	var next []byte
+	var nextErr error
+	fetch := func(ctx context.Context, url string, req []byte) ([]byte, error) {
+		if url != "http://ocsp.example.com" {
+			t.Errorf("fetch URL = %q", url)
+		}
+		r, err := x509.ParseOCSPRequest(req)
+		if err != nil {
+			t.Errorf("invalid OCSP request: %v", err)
+		} else if r.SerialNumber.Cmp(p.leaf.SerialNumber) != 0 {
+			t.Errorf("OCSP request for serial %v", r.SerialNumber)
+		}
+		return next, nextErr
+	}
+	s, err := NewOCSPStapler(p.cert, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Certificate().OCSPStaple != nil {
+		t.Fatal("new OCSPStapler has a staple")
+	}
+
+	next = good
+	if err := s.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.Certificate().OCSPStaple, good) {
+		t.Fatal("staple was not updated")
+	}
+	if p.cert.OCSPStaple != nil {
+		t.Fatal("original certificate was modified")
+	}
+
+	for _, bad := range [][]byte{unknown, []byte("garbage")} {
+		next = bad
+		if err := s.Refresh(context.Background()); err == nil {
+			t.Error("Refresh accepted an invalid response")
+		}
+		if !bytes.Equal(s.Certificate().OCSPStaple, good) {
+			t.Error("valid staple was replaced by an invalid one")
+		}
+	}
+	next, nextErr = nil, errors.New("network error")
+	if err := s.Refresh(context.Background()); err == nil {
+		t.Error("Refresh succeeded with a failing fetcher")
+	}
+	if !bytes.Equal(s.Certificate().OCSPStaple, good) {
+		t.Error("valid staple was dropped after a failed fetch")
+	}
+
+	// The staple is served, and verifies, in a handshake.
+	serverConfig := testConfig.Clone()
+	serverConfig.Certificates = nil
+	serverConfig.GetCertificate = s.GetCertificate
+	serverConfig.Time = time.Now
+	clientConfig := testConfig.Clone()
+	clientConfig.Time = time.Now
+	clientConfig.InsecureSkipVerify = false
+	clientConfig.ServerName = "example.com"
+	clientConfig.RootCAs = x509.NewCertPool()
+	clientConfig.RootCAs.AddCert(p.ca)
+	clientConfig.VerifyConnection = func(cs ConnectionState) error {
+		_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+			DNSName:           cs.ServerName,
+			Roots:             clientConfig.RootCAs,
+			Intermediates:     x509.NewCertPool(),
+			OCSPStaple:        cs.OCSPResponse,
+			RequireOCSPStaple: true,
+		})
+		return err
+	}
+	for _, v := range []uint16{VersionTLS12, VersionTLS13} {
+		clientConfig.MaxVersion = v
+		_, cs, err := testHandshake(t, clientConfig, serverConfig)
+		if err != nil {
+			t.Fatalf("TLS %x handshake: %v", v, err)
+		}
+		if !bytes.Equal(cs.OCSPResponse, good) {
+			t.Errorf("TLS %x: staple not received", v)
+		}
+	}
+}
+
+func TestOCSPStaplerInitialStaple(t *testing.T) {
+	p := newOCSPTestPKI(t)
+	now := time.Now()
+
+	cert := *p.cert
+	cert.OCSPStaple = p.response(t, x509.OCSPGood, now.Add(-time.Minute), now.Add(time.Hour))
+	s, err := NewOCSPStapler(&cert, func(context.Context, string, []byte) ([]byte, error) {
+		return nil, errors.New("unavailable")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.Certificate().OCSPStaple, cert.OCSPStaple) {
+		t.Error("valid initial staple was dropped")
+	}
+
+	cert.OCSPStaple = p.response(t, x509.OCSPGood, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	s, err = NewOCSPStapler(&cert, func(context.Context, string, []byte) ([]byte, error) {
+		return nil, errors.New("unavailable")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Certificate().OCSPStaple != nil {
+		t.Error("expired initial staple was kept")
+	}
+}
+
+func TestOCSPStaplerRun(t *testing.T) {
+	p := newOCSPTestPKI(t)
+	now := time.Now()
+	good := p.response(t, x509.OCSPGood, now.Add(-time.Minute), now.Add(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := NewOCSPStapler(p.cert, func(context.Context, string, []byte) ([]byte, error) {
+		cancel()
+		return good, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Run(ctx)
+	if !bytes.Equal(s.Certificate().OCSPStaple, good) {
+		t.Error("Run did not fetch a staple")
+	}
+}
+
+func TestNewOCSPStaplerErrors(t *testing.T) {
+	p := newOCSPTestPKI(t)
+	fetch := func(context.Context, string, []byte) ([]byte, error) { return nil, nil }
+
+	if _, err := NewOCSPStapler(p.cert, nil); err == nil {
+		t.Error("NewOCSPStapler succeeded without a fetcher")
+	}
+	noIssuer := *p.cert
+	noIssuer.Certificate = noIssuer.Certificate[:1]
+	if _, err := NewOCSPStapler(&noIssuer, fetch); err == nil {
+		t.Error("NewOCSPStapler succeeded without an issuer")
+	}
+	noServer := *p.cert
+	noServer.Certificate = [][]byte{p.ca.Raw, p.ca.Raw}
+	if _, err := NewOCSPStapler(&noServer, fetch); err == nil {
+		t.Error("NewOCSPStapler succeeded without an OCSP server")
+	}
+}
+
+func TestNextOCSPRefresh(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		thisUpdate, nextUpdate time.Time
+		want                   time.Duration
+	}{
+		{now, now.Add(48 * time.Hour), 24 * time.Hour},
+		{now.Add(-24 * time.Hour), now.Add(72 * time.Hour), 24 * time.Hour},
+		{now.Add(-24 * time.Hour), now.Add(time.Hour), ocspMinRetry},
+		{now, time.Time{}, ocspDefaultRefresh},
+	} {
+		resp := &x509.OCSPResponse{ThisUpdate: tt.thisUpdate, NextUpdate: tt.nextUpdate}
+		if got := nextOCSPRefresh(resp, now); got != tt.want {
+			t.Errorf("nextOCSPRefresh(%v, %v) = %v, want %v", tt.thisUpdate, tt.nextUpdate, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,1242 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/asn1/der"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// This file implements the SignedData and EnvelopedData content types of the
+// Cryptographic Message Syntax (CMS), as specified in RFC 5652, which is a
+// superset of PKCS #7.
+
+var (
+	oidCMSData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidCMSSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidCMSEnvelopedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 3}
+
+	oidAttributeContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+
+	oidRSAESOAEP   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 7}
+	oidDESEDE3CBC  = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+	oidAES128Wrap  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 5}
+	oidAES192Wrap  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 25}
+	oidAES256Wrap  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 45}
+	oidSHA224      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 4}
+	oidSHA1StdDH   = asn1.ObjectIdentifier{1, 3, 133, 16, 840, 63, 0, 2}
+	oidSHA224StdDH = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 0}
+	oidSHA256StdDH = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 1}
+	oidSHA384StdDH = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 2}
+	oidSHA512StdDH = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 3}
+)
+
+var (
+	cmsTag0          = der.Tag(0).ContextSpecific()
+	cmsTag0Construct = der.Tag(0).ContextSpecific().Constructed()
+	cmsTag1Construct = der.Tag(1).ContextSpecific().Constructed()
+)
+
+// cmsDigestOIDs are the digest algorithms supported in SignedData, and the
+// hash functions used by the KDF of the dhSinglePass-stdDH key agreement
+// schemes of RFC 5753, Section 7.1.4.
+var cmsDigestOIDs = []struct {
+	hash  crypto.Hash
+	oid   asn1.ObjectIdentifier
+	stdDH asn1.ObjectIdentifier
+}{
+	{crypto.SHA1, oidSHA1, oidSHA1StdDH},
+	{crypto.SHA224, oidSHA224, oidSHA224StdDH},
+	{crypto.SHA256, oidSHA256, oidSHA256StdDH},
+	{crypto.SHA384, oidSHA384, oidSHA384StdDH},
+	{crypto.SHA512, oidSHA512, oidSHA512StdDH},
+}
+
+// cmsBlockCiphers are the supported content encryption algorithms, which
+// are all used in CBC mode with the IV as their parameters.
+var cmsBlockCiphers = []struct {
+	oid      asn1.ObjectIdentifier
+	keySize  int
+	newBlock func(key []byte) (cipher.Block, error)
+}{
+	{oidAES128CBC, 16, aes.NewCipher},
+	{oidAES192CBC, 24, aes.NewCipher},
+	{oidAES256CBC, 32, aes.NewCipher},
+	{oidDESEDE3CBC, 24, des.NewTripleDESCipher},
+}
+
+var errCMSMalformedSignedData = errors.New("x509: malformed CMS SignedData")
+
+// CMSAttribute is a signed or unsigned attribute of a CMS signer.
+type CMSAttribute struct {
+	Type asn1.ObjectIdentifier
+	// Values holds the DER encoding of each value of the attribute.
+	Values [][]byte
+}
+
+// CMSSigner represents a SignerInfo of a CMS SignedData structure.
+type CMSSigner struct {
+	// The signer is identified either by the issuer and serial number of its
+	// certificate, or by its subject key identifier.
+	RawIssuer    []byte
+	SerialNumber *big.Int
+	SubjectKeyId []byte
+
+	DigestAlgorithm    crypto.Hash
+	SignatureAlgorithm SignatureAlgorithm
+	Signature          []byte
+
+	// SignedAttributes holds the attributes covered by the signature,
+	// including the content type and message digest. They are absent in
+	// some PKCS #7 signatures, which are made over the content directly.
+	SignedAttributes   []CMSAttribute
+	UnsignedAttributes []CMSAttribute
+
+	// SigningTime is the time asserted by the signer in the signing-time
+	// signed attribute, or the zero time if there is no such attribute.
+	SigningTime time.Time
+
+	rawSignedAttributes []byte // The SET OF encoding that was signed.
+}
+
+// CMSSignedData represents a CMS SignedData structure, as defined in RFC
+// 5652, Section 5. It is also used for the signatures of S/MIME messages
+// and Authenticode binaries, and, with no signers, for distributing
+// certificates in "certs-only" PKCS #7 files.
+type CMSSignedData struct {
+	Raw []byte // Complete ContentInfo, converted to DER.
+
+	// ContentType is the type of the signed content, id-data
+	// (1.2.840.113549.1.7.1) when it is arbitrary data.
+	ContentType asn1.ObjectIdentifier
+	// Content is the signed content, or nil if the signature is detached.
+	// For content that is not wrapped in an OCTET STRING, as produced by
+	// Authenticode, it holds the contents of the element without its tag
+	// and length, which is what the signature covers.
+	Content []byte
+
+	// Certificates holds the certificates included by the signer, usually
+	// its own and the intermediates needed to verify it.
+	Certificates []*Certificate
+	Signers      []*CMSSigner
+}
+
+// ParseCMSSignedData parses a ContentInfo holding a CMS or PKCS #7
+// SignedData structure. The input may be encoded in BER, as many producers
+// do.
+//
+// The signatures are not checked. Use [CMSSignedData.Verify].
+func ParseCMSSignedData(data []byte) (*CMSSignedData, error) {
+	raw, content, err := parseContentInfo(data, oidCMSSignedData)
+	if err != nil {
+		return nil, err
+	}
+	sd := &CMSSignedData{Raw: raw}
+
+	var digestAlgs, encap, signerInfos der.Parser
+	var version int64
+	if !content.ReadInt64(&version) ||
+		!content.ReadElement(&digestAlgs, der.Set) ||
+		!content.ReadElement(&encap, der.Sequence) {
+		return nil, errCMSMalformedSignedData
+	}
+	if version < 1 || version > 5 {
+		return nil, fmt.Errorf("x509: unsupported CMS SignedData version: %d", version)
+	}
+
+	var eContent der.Parser
+	var hasContent bool
+	if !encap.ReadObjectIdentifier(&sd.ContentType) ||
+		!encap.ReadOptionalElement(&eContent, &hasContent, cmsTag0Construct) ||
+		!encap.Empty() {
+		return nil, errCMSMalformedSignedData
+	}
+	if hasContent {
+		var tag der.Tag
+		var c der.Parser
+		if !eContent.ReadAnyElement(&c, &tag) || !eContent.Empty() {
+			return nil, errCMSMalformedSignedData
+		}
+		sd.Content = c
+		if sd.Content == nil {
+			sd.Content = []byte{}
+		}
+	}
+
+	var certs der.Parser
+	var hasCerts bool
+	if !content.ReadOptionalElement(&certs, &hasCerts, cmsTag0Construct) ||
+		!content.SkipOptionalElement(cmsTag1Construct) ||
+		!content.ReadElement(&signerInfos, der.Set) ||
+		!content.Empty() {
+		return nil, errCMSMalformedSignedData
+	}
+	for !certs.Empty() {
+		var cert der.Parser
+		var tag der.Tag
+		if !certs.ReadAnyRawElement(&cert, &tag) {
+			return nil, errCMSMalformedSignedData
+		}
+		// Skip the obsolete and attribute certificate choices.
+		if tag != der.Sequence {
+			continue
+		}
+		c, err := ParseCertificate(cert)
+		if err != nil {
+			return nil, err
+		}
+		sd.Certificates = append(sd.Certificates, c)
+	}
+
+	for !signerInfos.Empty() {
+		var si der.Parser
+		if !signerInfos.ReadElement(&si, der.Sequence) {
+			return nil, errCMSMalformedSignedData
+		}
+		s, err := parseCMSSigner(si)
+		if err != nil {
+			return nil, err
+		}
+		sd.Signers = append(sd.Signers, s)
+	}
+	return sd, nil
+}
+
+// parseContentInfo converts data to DER and parses it as a ContentInfo of
+// the given type, returning the DER encoding and the contents of the
+// content SEQUENCE.
+func parseContentInfo(data []byte, contentType asn1.ObjectIdentifier) ([]byte, der.Parser, error) {
+	raw, err := der.FromBER(data)
+	if err != nil {
+		return nil, nil, errors.New("x509: malformed CMS ContentInfo: " + err.Error())
+	}
+	input := der.Parser(raw)
+	var ci, explicit, content der.Parser
+	var oid asn1.ObjectIdentifier
+	if !input.ReadElement(&ci, der.Sequence) ||
+		!ci.ReadObjectIdentifier(&oid) ||
+		!ci.ReadElement(&explicit, cmsTag0Construct) ||
+		!ci.Empty() {
+		return nil, nil, errors.New("x509: malformed CMS ContentInfo")
+	}
+	if !oid.Equal(contentType) {
+		return nil, nil, fmt.Errorf("x509: unexpected CMS content type: %v", oid)
+	}
+	if !explicit.ReadElement(&content, der.Sequence) || !explicit.Empty() {
+		return nil, nil, errors.New("x509: malformed CMS ContentInfo")
+	}
+	return raw, content, nil
+}
+
+func parseCMSSigner(si der.Parser) (*CMSSigner, error) {
+	s := &CMSSigner{}
+	var version int64
+	if !si.ReadInt64(&version) {
+		return nil, errCMSMalformedSignedData
+	}
+	switch version {
+	case 1:
+		var ias der.Parser
+		if !si.ReadElement(&ias, der.Sequence) {
+			return nil, errCMSMalformedSignedData
+		}
+		var err error
+		if s.RawIssuer, s.SerialNumber, err = parseIssuerAndSerial(ias); err != nil {
+			return nil, err
+		}
+	case 3:
+		if !si.ReadBytes(&s.SubjectKeyId, cmsTag0) {
+			return nil, errCMSMalformedSignedData
+		}
+	default:
+		return nil, fmt.Errorf("x509: unsupported CMS SignerInfo version: %d", version)
+	}
+
+	var digestAI, rawAttrs, sigAI, unsigned der.Parser
+	var hasAttrs, hasUnsigned bool
+	if !si.ReadElement(&digestAI, der.Sequence) {
+		return nil, errCMSMalformedSignedData
+	}
+	if hasAttrs = si.PeekTag(cmsTag0Construct); hasAttrs && !si.ReadRawElement(&rawAttrs, cmsTag0Construct) ||
+		!si.ReadElement(&sigAI, der.Sequence) ||
+		!si.ReadOctetString(&s.Signature) ||
+		!si.ReadOptionalElement(&unsigned, &hasUnsigned, cmsTag1Construct) ||
+		!si.Empty() {
+		return nil, errCMSMalformedSignedData
+	}
+
+	digest, err := parseAI(cryptobyte.String(digestAI))
+	if err != nil {
+		return nil, err
+	}
+	s.DigestAlgorithm = cmsHashFromOID(digest.Algorithm)
+	if s.DigestAlgorithm == 0 {
+		return nil, fmt.Errorf("x509: unsupported CMS digest algorithm: %v", digest.Algorithm)
+	}
+	ai, err := parseAI(cryptobyte.String(sigAI))
+	if err != nil {
+		return nil, err
+	}
+	s.SignatureAlgorithm = cmsSignatureAlgorithm(ai, s.DigestAlgorithm)
+
+	if hasAttrs {
+		// The signature covers the DER encoding of the attributes with the
+		// SET OF tag, rather than the IMPLICIT one of the SignerInfo.
+		s.rawSignedAttributes = bytes.Clone(rawAttrs)
+		s.rawSignedAttributes[0] = 0x31 // SET OF
+		var attrs der.Parser
+		if !rawAttrs.ReadElement(&attrs, cmsTag0Construct) {
+			return nil, errCMSMalformedSignedData
+		}
+		if s.SignedAttributes, err = parseCMSAttributes(attrs); err != nil {
+			return nil, err
+		}
+		for _, a := range s.SignedAttributes {
+			if !a.Type.Equal(oidAttributeSigningTime) {
+				continue
+			}
+			if len(a.Values) != 1 {
+				return nil, errors.New("x509: malformed CMS signing-time attribute")
+			}
+			p := der.Parser(a.Values[0])
+			if !p.ReadTime(&s.SigningTime) || !p.Empty() {
+				return nil, errors.New("x509: malformed CMS signing-time attribute")
+			}
+		}
+	}
+	if hasUnsigned {
+		if s.UnsignedAttributes, err = parseCMSAttributes(unsigned); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func parseIssuerAndSerial(ias der.Parser) (issuer []byte, serial *big.Int, err error) {
+	var name der.Parser
+	serial = new(big.Int)
+	if !ias.ReadRawElement(&name, der.Sequence) ||
+		!ias.ReadBigInt(serial) ||
+		!ias.Empty() {
+		return nil, nil, errors.New("x509: malformed CMS IssuerAndSerialNumber")
+	}
+	return name, serial, nil
+}
+
+func parseCMSAttributes(attrs der.Parser) ([]CMSAttribute, error) {
+	var out []CMSAttribute
+	for !attrs.Empty() {
+		var attr, values der.Parser
+		var a CMSAttribute
+		if !attrs.ReadElement(&attr, der.Sequence) ||
+			!attr.ReadObjectIdentifier(&a.Type) ||
+			!attr.ReadElement(&values, der.Set) ||
+			!attr.Empty() || values.Empty() {
+			return nil, errors.New("x509: malformed CMS attribute")
+		}
+		for !values.Empty() {
+			var v der.Parser
+			var tag der.Tag
+			if !values.ReadAnyRawElement(&v, &tag) {
+				return nil, errors.New("x509: malformed CMS attribute")
+			}
+			a.Values = append(a.Values, v)
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func cmsHashFromOID(oid asn1.ObjectIdentifier) crypto.Hash {
+	for _, d := range cmsDigestOIDs {
+		if oid.Equal(d.oid) {
+			return d.hash
+		}
+	}
+	return 0
+}
+
+// cmsSignatureAlgorithm returns the signature algorithm identified by ai.
+// Many producers identify RSA and ECDSA signatures by the public key
+// algorithm alone, leaving the hash to the digest algorithm.
+func cmsSignatureAlgorithm(ai pkix.AlgorithmIdentifier, digest crypto.Hash) SignatureAlgorithm {
+	var pubKeyAlgo PublicKeyAlgorithm
+	switch {
+	case ai.Algorithm.Equal(oidPublicKeyRSA):
+		pubKeyAlgo = RSA
+	case ai.Algorithm.Equal(oidPublicKeyECDSA):
+		pubKeyAlgo = ECDSA
+	default:
+		return getSignatureAlgorithmFromAI(ai)
+	}
+	for _, details := range signatureAlgorithmDetails {
+		if details.pubKeyAlgo == pubKeyAlgo && details.hash == digest && !details.algo.isRSAPSS() {
+			return details.algo
+		}
+	}
+	return UnknownSignatureAlgorithm
+}
+
+// matches reports whether s identifies cert as the signer certificate.
+func (s *CMSSigner) matches(cert *Certificate) bool {
+	return cmsIdentifies(s.RawIssuer, s.SerialNumber, s.SubjectKeyId, cert)
+}
+
+func cmsIdentifies(issuer []byte, serial *big.Int, keyId []byte, cert *Certificate) bool {
+	if keyId != nil {
+		return len(cert.SubjectKeyId) > 0 && bytes.Equal(keyId, cert.SubjectKeyId)
+	}
+	return cert.SerialNumber != nil && serial.Cmp(cert.SerialNumber) == 0 &&
+		bytes.Equal(issuer, cert.RawIssuer)
+}
+
+// CheckSignature verifies that s is a valid signature over content from
+// cert's public key. If s has signed attributes, it also checks that they
+// hold the content type, which must be contentType, and the digest of
+// content.
+//
+// This is a low-level API that performs no validity checks on the
+// certificate. SHA-1 signatures are rejected.
+func (s *CMSSigner) CheckSignature(contentType asn1.ObjectIdentifier, content []byte, cert *Certificate) error {
+	if s.rawSignedAttributes == nil {
+		if !contentType.Equal(oidCMSData) {
+			return errors.New("x509: CMS signer without signed attributes for content that is not id-data")
+		}
+		return checkSignature(s.SignatureAlgorithm, content, s.Signature, cert.PublicKey, false)
+	}
+
+	var gotType, gotDigest bool
+	for _, a := range s.SignedAttributes {
+		switch {
+		case a.Type.Equal(oidAttributeContentType):
+			var oid asn1.ObjectIdentifier
+			if gotType || len(a.Values) != 1 {
+				return errors.New("x509: malformed CMS content-type attribute")
+			}
+			p := der.Parser(a.Values[0])
+			if !p.ReadObjectIdentifier(&oid) || !p.Empty() {
+				return errors.New("x509: malformed CMS content-type attribute")
+			}
+			if !oid.Equal(contentType) {
+				return errors.New("x509: CMS content-type attribute does not match the content")
+			}
+			gotType = true
+		case a.Type.Equal(oidAttributeMessageDigest):
+			var digest []byte
+			if gotDigest || len(a.Values) != 1 {
+				return errors.New("x509: malformed CMS message-digest attribute")
+			}
+			p := der.Parser(a.Values[0])
+			if !p.ReadOctetString(&digest) || !p.Empty() {
+				return errors.New("x509: malformed CMS message-digest attribute")
+			}
+			if !s.DigestAlgorithm.Available() {
+				return ErrUnsupportedAlgorithm
+			}
+			h := s.DigestAlgorithm.New()
+			h.Write(content)
+			if subtle.ConstantTimeCompare(h.Sum(nil), digest) != 1 {
+				return errors.New("x509: CMS message digest does not match the content")
+			}
+			gotDigest = true
+		}
+	}
+	if !gotType || !gotDigest {
+		return errors.New("x509: CMS signed attributes lack the content type or message digest")
+	}
+	return checkSignature(s.SignatureAlgorithm, s.rawSignedAttributes, s.Signature, cert.PublicKey, false)
+}
+
+// Verify checks the signature of every signer of sd, and verifies the
+// certificate of each signer with opts, using the certificates included in
+// sd as additional intermediates. It returns the signer certificates, in
+// the order of sd.Signers.
+//
+// If sd.Content is nil, the signature is detached and the signed content
+// must be passed as detached, which must otherwise be nil.
+//
+// If opts.KeyUsages is empty, any extended key usage is accepted, rather
+// than requiring ExtKeyUsageServerAuth as [Certificate.Verify] does. The
+// signing time asserted by a signer is not used: callers that want to
+// verify certificates at that time should set opts.CurrentTime.
+func (sd *CMSSignedData) Verify(detached []byte, opts VerifyOptions) ([]*Certificate, error) {
+	content := sd.Content
+	if content == nil {
+		if detached == nil {
+			return nil, errors.New("x509: CMS signature is detached but no content was provided")
+		}
+		content = detached
+	} else if detached != nil {
+		return nil, errors.New("x509: content provided for a CMS signature that is not detached")
+	}
+	if len(sd.Signers) == 0 {
+		return nil, errors.New("x509: CMS SignedData has no signers")
+	}
+
+	if opts.Intermediates == nil {
+		opts.Intermediates = NewCertPool()
+	} else {
+		opts.Intermediates = opts.Intermediates.Clone()
+	}
+	for _, c := range sd.Certificates {
+		opts.Intermediates.AddCert(c)
+	}
+	if len(opts.KeyUsages) == 0 {
+		opts.KeyUsages = []ExtKeyUsage{ExtKeyUsageAny}
+	}
+
+	var certs []*Certificate
+	for _, s := range sd.Signers {
+		var cert *Certificate
+		for _, c := range sd.Certificates {
+			if s.matches(c) {
+				cert = c
+				break
+			}
+		}
+		if cert == nil {
+			return nil, errors.New("x509: CMS signer certificate not found")
+		}
+		if err := s.CheckSignature(sd.ContentType, content, cert); err != nil {
+			return nil, err
+		}
+		if _, err := cert.Verify(opts); err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// CMSSignOptions holds options for [CreateCMSSignedData].
+type CMSSignOptions struct {
+	// ContentType is the type of the content. If nil, id-data is used.
+	ContentType asn1.ObjectIdentifier
+	// Detached causes the content to be left out of the SignedData.
+	Detached bool
+	// SignatureAlgorithm is the signature algorithm to use. If zero, the
+	// default for the key is used, as for [CreateCertificate].
+	SignatureAlgorithm SignatureAlgorithm
+	// SigningTime is the time to include in the signing-time attribute. If
+	// zero, the current time is used.
+	SigningTime time.Time
+	// SignedAttributes are additional attributes to sign, after the
+	// content type, message digest and signing time.
+	SignedAttributes []CMSAttribute
+	// Certificates are included in the SignedData after the signer
+	// certificate, typically to provide its chain.
+	Certificates []*Certificate
+	// UseSubjectKeyId identifies the signer by the subject key identifier
+	// of its certificate, rather than by its issuer and serial number.
+	UseSubjectKeyId bool
+}
+
+// CreateCMSSignedData returns a ContentInfo holding a DER-encoded CMS
+// SignedData structure, in which content is signed by priv, the private
+// key of cert. opts may be nil to use the defaults.
+//
+// The signature covers signed attributes holding the content type, the
+// digest of content and the signing time. Ed25519 signatures use SHA-512
+// for the digest, as specified in RFC 8419.
+func CreateCMSSignedData(rand io.Reader, content []byte, cert *Certificate, priv crypto.Signer, opts *CMSSignOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &CMSSignOptions{}
+	}
+	if cert == nil {
+		return nil, errors.New("x509: CMS signer certificate can not be nil")
+	}
+	type privateKey interface {
+		Equal(crypto.PublicKey) bool
+	}
+	if privPub, ok := priv.Public().(privateKey); !ok {
+		return nil, errors.New("x509: internal error: supported public key does not implement Equal")
+	} else if !privPub.Equal(cert.PublicKey) {
+		return nil, errors.New("x509: provided PrivateKey doesn't match the signer certificate's PublicKey")
+	}
+	if opts.UseSubjectKeyId && len(cert.SubjectKeyId) == 0 {
+		return nil, errors.New("x509: CMS signer certificate has no subject key identifier")
+	}
+	if !opts.UseSubjectKeyId && cert.SerialNumber == nil {
+		return nil, errors.New("x509: CMS signer certificate has a nil SerialNumber")
+	}
+
+	sigAlg, sigAI, err := signingParamsForKey(priv, opts.SignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	digestHash := sigAlg.hashFunc()
+	if digestHash == 0 {
+		digestHash = crypto.SHA512
+	}
+	var digestOID asn1.ObjectIdentifier
+	for _, d := range cmsDigestOIDs {
+		if d.hash == digestHash {
+			digestOID = d.oid
+		}
+	}
+	h := digestHash.New()
+	h.Write(content)
+	digest := h.Sum(nil)
+
+	contentType := opts.ContentType
+	if contentType == nil {
+		contentType = oidCMSData
+	}
+	signingTime := opts.SigningTime
+	if signingTime.IsZero() {
+		signingTime = time.Now()
+	}
+
+	attrs := der.NewBuilder(nil)
+	attrs.AddSetOf(func(set *der.Builder) {
+		addCMSAttribute(set, oidAttributeContentType, func(b *der.Builder) {
+			b.AddObjectIdentifier(contentType)
+		})
+		addCMSAttribute(set, oidAttributeMessageDigest, func(b *der.Builder) {
+			b.AddOctetString(digest)
+		})
+		addCMSAttribute(set, oidAttributeSigningTime, func(b *der.Builder) {
+			// RFC 5652, Section 11.3 requires UTCTime for the years it
+			// can represent.
+			if y := signingTime.UTC().Year(); y >= 1950 && y < 2050 {
+				b.AddUTCTime(signingTime)
+			} else {
+				b.AddGeneralizedTime(signingTime)
+			}
+		})
+		for _, a := range opts.SignedAttributes {
+			addCMSAttribute(set, a.Type, func(b *der.Builder) {
+				for _, v := range a.Values {
+					b.AddRawElement(v)
+				}
+			})
+		}
+	})
+	signedAttrs, err := attrs.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signTBS(signedAttrs, priv, sigAlg, rand)
+	if err != nil {
+		return nil, err
+	}
+	// The attributes are signed as a SET OF, but included with the IMPLICIT
+	// tag of the SignerInfo.
+	signedAttrs = bytes.Clone(signedAttrs)
+	signedAttrs[0] = 0xa0 // [0] IMPLICIT
+
+	version := int64(1)
+	if opts.UseSubjectKeyId || !contentType.Equal(oidCMSData) {
+		version = 3
+	}
+	b := der.NewBuilder(nil)
+	b.AddElement(der.Sequence, func(ci *der.Builder) {
+		ci.AddObjectIdentifier(oidCMSSignedData)
+		ci.AddElement(cmsTag0Construct, func(explicit *der.Builder) {
+			explicit.AddElement(der.Sequence, func(sd *der.Builder) {
+				sd.AddInt64(version)
+				sd.AddSetOf(func(set *der.Builder) {
+					set.AddElement(der.Sequence, func(ai *der.Builder) {
+						ai.AddObjectIdentifier(digestOID)
+					})
+				})
+				sd.AddElement(der.Sequence, func(encap *der.Builder) {
+					encap.AddObjectIdentifier(contentType)
+					if !opts.Detached {
+						encap.AddElement(cmsTag0Construct, func(explicit *der.Builder) {
+							explicit.AddOctetString(content)
+						})
+					}
+				})
+				sd.AddElement(cmsTag0Construct, func(certs *der.Builder) {
+					certs.AddRawElement(cert.Raw)
+					for _, c := range opts.Certificates {
+						certs.AddRawElement(c.Raw)
+					}
+				})
+				sd.AddSetOf(func(set *der.Builder) {
+					set.AddElement(der.Sequence, func(si *der.Builder) {
+						if opts.UseSubjectKeyId {
+							si.AddInt64(3)
+							si.AddBytes(cmsTag0, cert.SubjectKeyId)
+						} else {
+							si.AddInt64(1)
+							si.AddElement(der.Sequence, func(ias *der.Builder) {
+								ias.AddRawElement(cert.RawIssuer)
+								ias.AddBigInt(cert.SerialNumber)
+							})
+						}
+						si.AddElement(der.Sequence, func(ai *der.Builder) {
+							ai.AddObjectIdentifier(digestOID)
+						})
+						si.AddRawElement(signedAttrs)
+						si.AddElement(der.Sequence, func(ai *der.Builder) {
+							ai.AddObjectIdentifier(sigAI.Algorithm)
+							switch {
+							case len(sigAI.Parameters.FullBytes) > 0:
+								ai.AddRawElement(sigAI.Parameters.FullBytes)
+							case sigAI.Parameters.Tag == asn1.TagNull:
+								ai.AddNull()
+							}
+						})
+						si.AddOctetString(signature)
+					})
+				})
+			})
+		})
+	})
+	return b.Bytes()
+}
+
+func addCMSAttribute(b *der.Builder, oid asn1.ObjectIdentifier, values der.BuilderContinuation) {
+	b.AddElement(der.Sequence, func(attr *der.Builder) {
+		attr.AddObjectIdentifier(oid)
+		attr.AddSetOf(values)
+	})
+}
+
+// CMSEnvelopedData represents a CMS EnvelopedData structure, as defined in
+// RFC 5652, Section 6, which holds content encrypted for a set of
+// recipients.
+type CMSEnvelopedData struct {
+	Raw []byte // Complete ContentInfo, converted to DER.
+
+	// ContentType is the type of the encrypted content, id-data
+	// (1.2.840.113549.1.7.1) when it is arbitrary data.
+	ContentType asn1.ObjectIdentifier
+
+	recipients       []cmsRecipient
+	contentAlgorithm pkix.AlgorithmIdentifier
+	encryptedContent []byte
+}
+
+// cmsRecipient is a KeyTransRecipientInfo or a RecipientEncryptedKey of a
+// KeyAgreeRecipientInfo.
+type cmsRecipient struct {
+	keyAgree bool
+
+	// The recipient certificate.
+	rawIssuer    []byte
+	serialNumber *big.Int
+	subjectKeyId []byte
+
+	keyEncryptionAlgorithm pkix.AlgorithmIdentifier
+	encryptedKey           []byte
+
+	// For key agreement, the originator's ephemeral public key and the
+	// optional user keying material.
+	originatorKey []byte
+	ukm           []byte
+}
+
+var errCMSMalformedEnvelopedData = errors.New("x509: malformed CMS EnvelopedData")
+
+// ParseCMSEnvelopedData parses a ContentInfo holding a CMS or PKCS #7
+// EnvelopedData structure. The input may be encoded in BER, as many
+// producers do.
+//
+// Only key transport and key agreement recipients are retained; others,
+// such as password recipients, are ignored.
+func ParseCMSEnvelopedData(data []byte) (*CMSEnvelopedData, error) {
+	raw, content, err := parseContentInfo(data, oidCMSEnvelopedData)
+	if err != nil {
+		return nil, err
+	}
+	ed := &CMSEnvelopedData{Raw: raw}
+
+	var version int64
+	var recipientInfos, eci, contentAI der.Parser
+	if !content.ReadInt64(&version) ||
+		!content.SkipOptionalElement(cmsTag0Construct) ||
+		!content.ReadElement(&recipientInfos, der.Set) ||
+		!content.ReadElement(&eci, der.Sequence) ||
+		!content.SkipOptionalElement(cmsTag1Construct) ||
+		!content.Empty() {
+		return nil, errCMSMalformedEnvelopedData
+	}
+	if version < 0 || version > 4 {
+		return nil, fmt.Errorf("x509: unsupported CMS EnvelopedData version: %d", version)
+	}
+
+	if !eci.ReadObjectIdentifier(&ed.ContentType) ||
+		!eci.ReadElement(&contentAI, der.Sequence) {
+		return nil, errCMSMalformedEnvelopedData
+	}
+	if ed.contentAlgorithm, err = parseAI(cryptobyte.String(contentAI)); err != nil {
+		return nil, err
+	}
+	// The encrypted content is an IMPLICIT OCTET STRING, which BER allows to
+	// be split into constructed segments.
+	var segments der.Parser
+	switch {
+	case eci.PeekTag(cmsTag0):
+		if !eci.ReadBytes(&ed.encryptedContent, cmsTag0) {
+			return nil, errCMSMalformedEnvelopedData
+		}
+	case eci.PeekTag(cmsTag0Construct):
+		if !eci.ReadElement(&segments, cmsTag0Construct) {
+			return nil, errCMSMalformedEnvelopedData
+		}
+		for !segments.Empty() {
+			var s []byte
+			if !segments.ReadOctetString(&s) {
+				return nil, errCMSMalformedEnvelopedData
+			}
+			ed.encryptedContent = append(ed.encryptedContent, s...)
+		}
+	default:
+		return nil, errors.New("x509: CMS EnvelopedData without encrypted content is not supported")
+	}
+	if !eci.Empty() {
+		return nil, errCMSMalformedEnvelopedData
+	}
+
+	for !recipientInfos.Empty() {
+		var ri der.Parser
+		var tag der.Tag
+		if !recipientInfos.ReadAnyElement(&ri, &tag) {
+			return nil, errCMSMalformedEnvelopedData
+		}
+		switch tag {
+		case der.Sequence:
+			r, err := parseKeyTransRecipient(ri)
+			if err != nil {
+				return nil, err
+			}
+			ed.recipients = append(ed.recipients, r)
+		case cmsTag1Construct:
+			rs, err := parseKeyAgreeRecipients(ri)
+			if err != nil {
+				return nil, err
+			}
+			ed.recipients = append(ed.recipients, rs...)
+		}
+	}
+	return ed, nil
+}
+
+func parseKeyTransRecipient(ri der.Parser) (cmsRecipient, error) {
+	var r cmsRecipient
+	var version int64
+	var ai der.Parser
+	if !ri.ReadInt64(&version) {
+		return r, errCMSMalformedEnvelopedData
+	}
+	switch {
+	case version == 0 && ri.PeekTag(der.Sequence):
+		var ias der.Parser
+		if !ri.ReadElement(&ias, der.Sequence) {
+			return r, errCMSMalformedEnvelopedData
+		}
+		var err error
+		if r.rawIssuer, r.serialNumber, err = parseIssuerAndSerial(ias); err != nil {
+			return r, err
+		}
+	case version == 2:
+		if !ri.ReadBytes(&r.subjectKeyId, cmsTag0) {
+			return r, errCMSMalformedEnvelopedData
+		}
+	default:
+		return r, errCMSMalformedEnvelopedData
+	}
+	if !ri.ReadElement(&ai, der.Sequence) ||
+		!ri.ReadOctetString(&r.encryptedKey) ||
+		!ri.Empty() {
+		return r, errCMSMalformedEnvelopedData
+	}
+	var err error
+	r.keyEncryptionAlgorithm, err = parseAI(cryptobyte.String(ai))
+	return r, err
+}
+
+func parseKeyAgreeRecipients(ri der.Parser) ([]cmsRecipient, error) {
+	var version int64
+	var originator, ukm, ai, keys der.Parser
+	var hasUKM bool
+	if !ri.ReadInt64(&version) || version != 3 ||
+		!ri.ReadElement(&originator, cmsTag0Construct) ||
+		!ri.ReadOptionalElement(&ukm, &hasUKM, cmsTag1Construct) ||
+		!ri.ReadElement(&ai, der.Sequence) ||
+		!ri.ReadElement(&keys, der.Sequence) ||
+		!ri.Empty() {
+		return nil, errCMSMalformedEnvelopedData
+	}
+	keyAlgo, err := parseAI(cryptobyte.String(ai))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the originatorKey choice, used with ephemeral keys, is supported.
+	var originatorKey, originatorAI der.Parser
+	var pub asn1.BitString
+	if !originator.ReadElement(&originatorKey, der.Tag(1).ContextSpecific().Constructed()) {
+		return nil, errors.New("x509: unsupported CMS key agreement originator")
+	}
+	if !originator.Empty() ||
+		!originatorKey.ReadElement(&originatorAI, der.Sequence) ||
+		!originatorKey.ReadBitString(&pub) ||
+		!originatorKey.Empty() || pub.BitLength%8 != 0 {
+		return nil, errCMSMalformedEnvelopedData
+	}
+	var ukmBytes []byte
+	if hasUKM && (!ukm.ReadOctetString(&ukmBytes) || !ukm.Empty()) {
+		return nil, errCMSMalformedEnvelopedData
+	}
+
+	var rs []cmsRecipient
+	for !keys.Empty() {
+		var rek der.Parser
+		r := cmsRecipient{
+			keyAgree:               true,
+			keyEncryptionAlgorithm: keyAlgo,
+			originatorKey:          pub.Bytes,
+			ukm:                    ukmBytes,
+		}
+		if !keys.ReadElement(&rek, der.Sequence) {
+			return nil, errCMSMalformedEnvelopedData
+		}
+		if rek.PeekTag(der.Sequence) {
+			var ias der.Parser
+			if !rek.ReadElement(&ias, der.Sequence) {
+				return nil, errCMSMalformedEnvelopedData
+			}
+			if r.rawIssuer, r.serialNumber, err = parseIssuerAndSerial(ias); err != nil {
+				return nil, err
+			}
+		} else {
+			var rKeyId der.Parser
+			if !rek.ReadElement(&rKeyId, cmsTag0Construct) ||
+				!rKeyId.ReadOctetString(&r.subjectKeyId) {
+				return nil, errCMSMalformedEnvelopedData
+			}
+		}
+		if !rek.ReadOctetString(&r.encryptedKey) || !rek.Empty() {
+			return nil, errCMSMalformedEnvelopedData
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+// Decrypt decrypts the content of ed for the recipient with certificate
+// cert and private key priv, and returns it.
+//
+// priv must be an *rsa.PrivateKey, or a [crypto.Decrypter] with an RSA
+// public key, for key transport recipients, or an *ecdh.PrivateKey or
+// *ecdsa.PrivateKey for key agreement recipients using the
+// dhSinglePass-stdDH schemes of RFC 5753. If cert is nil, every recipient
+// of the matching kind is tried.
+//
+// The content may be encrypted with AES-CBC or, for compatibility with
+// older producers, DES-EDE3-CBC.
+func (ed *CMSEnvelopedData) Decrypt(cert *Certificate, priv crypto.PrivateKey) ([]byte, error) {
+	var blockCipher func([]byte) (cipher.Block, error)
+	keySize := 0
+	for _, c := range cmsBlockCiphers {
+		if ed.contentAlgorithm.Algorithm.Equal(c.oid) {
+			blockCipher, keySize = c.newBlock, c.keySize
+		}
+	}
+	if blockCipher == nil {
+		return nil, fmt.Errorf("x509: unsupported CMS content encryption algorithm: %v", ed.contentAlgorithm.Algorithm)
+	}
+
+	var ecdhKey *ecdh.PrivateKey
+	var rsaKey crypto.Decrypter
+	switch k := priv.(type) {
+	case *ecdh.PrivateKey:
+		ecdhKey = k
+	case *ecdsa.PrivateKey:
+		var err error
+		if ecdhKey, err = k.ECDH(); err != nil {
+			return nil, err
+		}
+	case crypto.Decrypter:
+		if _, ok := k.Public().(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("x509: unsupported CMS recipient key type: %T", priv)
+		}
+		rsaKey = k
+	default:
+		return nil, fmt.Errorf("x509: unsupported CMS recipient key type: %T", priv)
+	}
+
+	var found bool
+	for _, r := range ed.recipients {
+		if r.keyAgree != (ecdhKey != nil) {
+			continue
+		}
+		if cert != nil && !cmsIdentifies(r.rawIssuer, r.serialNumber, r.subjectKeyId, cert) {
+			continue
+		}
+		found = true
+		var key []byte
+		var err error
+		if r.keyAgree {
+			key, err = r.agreeKey(ecdhKey)
+		} else {
+			key, err = r.transportKey(rsaKey, keySize)
+		}
+		if err == nil && len(key) != keySize {
+			err = errors.New("x509: CMS content-encryption key has the wrong size")
+		}
+		var content []byte
+		if err == nil {
+			content, err = decryptCMSContent(blockCipher, key, ed.contentAlgorithm, ed.encryptedContent)
+		}
+		if err == nil || cert != nil {
+			return content, err
+		}
+	}
+	if !found {
+		return nil, errors.New("x509: no CMS recipient matches the certificate or key")
+	}
+	return nil, errors.New("x509: CMS content decryption failed")
+}
+
+// transportKey decrypts the content-encryption key of a KeyTransRecipientInfo.
+func (r *cmsRecipient) transportKey(priv crypto.Decrypter, keySize int) ([]byte, error) {
+	ai := r.keyEncryptionAlgorithm
+	switch {
+	case ai.Algorithm.Equal(oidPublicKeyRSA):
+		// Returning a random key on padding errors, which fails to decrypt
+		// the content, hides the Bleichenbacher padding oracle.
+		return priv.Decrypt(rand.Reader, r.encryptedKey, &rsa.PKCS1v15DecryptOptions{SessionKeyLen: keySize})
+	case ai.Algorithm.Equal(oidRSAESOAEP):
+		opts, err := parseOAEPParameters(ai.Parameters.FullBytes)
+		if err != nil {
+			return nil, err
+		}
+		return priv.Decrypt(rand.Reader, r.encryptedKey, opts)
+	}
+	return nil, fmt.Errorf("x509: unsupported CMS key encryption algorithm: %v", ai.Algorithm)
+}
+
+// parseOAEPParameters parses the RSAES-OAEP-params of RFC 4055, Section 4.1.
+func parseOAEPParameters(params []byte) (*rsa.OAEPOptions, error) {
+	opts := &rsa.OAEPOptions{Hash: crypto.SHA1, MGFHash: crypto.SHA1}
+	if len(params) == 0 {
+		return opts, nil
+	}
+	input := der.Parser(params)
+	var seq, hashAI, mgfAI, pSource der.Parser
+	var hasHash, hasMGF, hasPSource bool
+	if !input.ReadElement(&seq, der.Sequence) || !input.Empty() ||
+		!seq.ReadOptionalElement(&hashAI, &hasHash, cmsTag0Construct) ||
+		!seq.ReadOptionalElement(&mgfAI, &hasMGF, cmsTag1Construct) ||
+		!seq.ReadOptionalElement(&pSource, &hasPSource, der.Tag(2).ContextSpecific().Constructed()) ||
+		!seq.Empty() {
+		return nil, errors.New("x509: malformed RSAES-OAEP parameters")
+	}
+	if hasHash {
+		var ai der.Parser
+		if !hashAI.ReadElement(&ai, der.Sequence) || !hashAI.Empty() {
+			return nil, errors.New("x509: malformed RSAES-OAEP parameters")
+		}
+		hash, err := parseAI(cryptobyte.String(ai))
+		if err != nil {
+			return nil, err
+		}
+		if opts.Hash = cmsHashFromOID(hash.Algorithm); opts.Hash == 0 {
+			return nil, fmt.Errorf("x509: unsupported RSAES-OAEP hash function: %v", hash.Algorithm)
+		}
+	}
+	if hasMGF {
+		var ai, mgfParams der.Parser
+		var oid asn1.ObjectIdentifier
+		if !mgfAI.ReadElement(&ai, der.Sequence) || !mgfAI.Empty() ||
+			!ai.ReadObjectIdentifier(&oid) ||
+			!ai.ReadElement(&mgfParams, der.Sequence) || !ai.Empty() {
+			return nil, errors.New("x509: malformed RSAES-OAEP parameters")
+		}
+		if !oid.Equal(oidMGF1) {
+			return nil, fmt.Errorf("x509: unsupported RSAES-OAEP mask generation function: %v", oid)
+		}
+		hash, err := parseAI(cryptobyte.String(mgfParams))
+		if err != nil {
+			return nil, err
+		}
+		if opts.MGFHash = cmsHashFromOID(hash.Algorithm); opts.MGFHash == 0 {
+			return nil, fmt.Errorf("x509: unsupported RSAES-OAEP hash function: %v", hash.Algorithm)
+		}
+	}
+	if hasPSource {
+		// Only the id-pSpecified source is defined, which holds the label.
+		var ai der.Parser
+		var oid asn1.ObjectIdentifier
+		if !pSource.ReadElement(&ai, der.Sequence) || !pSource.Empty() ||
+			!ai.ReadObjectIdentifier(&oid) ||
+			!ai.ReadOctetString(&opts.Label) || !ai.Empty() {
+			return nil, errors.New("x509: malformed RSAES-OAEP parameters")
+		}
+	}
+	return opts, nil
+}
+
+// agreeKey derives the key-encryption key of a KeyAgreeRecipientInfo as
+// specified in RFC 5753, Section 7.2, and unwraps the content-encryption
+// key with it.
+func (r *cmsRecipient) agreeKey(priv *ecdh.PrivateKey) ([]byte, error) {
+	var hash crypto.Hash
+	for _, d := range cmsDigestOIDs {
+		if r.keyEncryptionAlgorithm.Algorithm.Equal(d.stdDH) {
+			hash = d.hash
+		}
+	}
+	if hash == 0 || !hash.Available() {
+		return nil, fmt.Errorf("x509: unsupported CMS key agreement algorithm: %v", r.keyEncryptionAlgorithm.Algorithm)
+	}
+	input := der.Parser(r.keyEncryptionAlgorithm.Parameters.FullBytes)
+	var wrapSeq der.Parser
+	if !input.ReadElement(&wrapSeq, der.Sequence) || !input.Empty() {
+		return nil, errors.New("x509: malformed CMS key wrap algorithm")
+	}
+	wrapAI, err := parseAI(cryptobyte.String(wrapSeq))
+	if err != nil {
+		return nil, err
+	}
+	var kekSize int
+	switch {
+	case wrapAI.Algorithm.Equal(oidAES128Wrap):
+		kekSize = 16
+	case wrapAI.Algorithm.Equal(oidAES192Wrap):
+		kekSize = 24
+	case wrapAI.Algorithm.Equal(oidAES256Wrap):
+		kekSize = 32
+	default:
+		return nil, fmt.Errorf("x509: unsupported CMS key wrap algorithm: %v", wrapAI.Algorithm)
+	}
+
+	pub, err := priv.Curve().NewPublicKey(r.originatorKey)
+	if err != nil {
+		return nil, errors.New("x509: invalid CMS originator public key")
+	}
+	z, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ECC-CMS-SharedInfo of RFC 5753, Section 7.2.
+	b := der.NewBuilder(nil)
+	b.AddElement(der.Sequence, func(info *der.Builder) {
+		info.AddElement(der.Sequence, func(ai *der.Builder) {
+			ai.AddObjectIdentifier(wrapAI.Algorithm)
+		})
+		if r.ukm != nil {
+			info.AddElement(cmsTag0Construct, func(explicit *der.Builder) {
+				explicit.AddOctetString(r.ukm)
+			})
+		}
+		info.AddElement(der.Tag(2).ContextSpecific().Constructed(), func(explicit *der.Builder) {
+			explicit.AddOctetString(binary.BigEndian.AppendUint32(nil, uint32(kekSize*8)))
+		})
+	})
+	sharedInfo, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	kek := x963KDF(hash, z, sharedInfo, kekSize)
+	return aesKeyUnwrap(kek, r.encryptedKey)
+}
+
+// x963KDF is the key derivation function of ANSI X9.63, as described in SEC
+// 1, Version 2.0, Section 3.6.1.
+func x963KDF(hash crypto.Hash, z, sharedInfo []byte, size int) []byte {
+	h := hash.New()
+	var key []byte
+	for counter := uint32(1); len(key) < size; counter++ {
+		h.Reset()
+		h.Write(z)
+		h.Write(binary.BigEndian.AppendUint32(nil, counter))
+		h.Write(sharedInfo)
+		key = h.Sum(key)
+	}
+	return key[:size]
+}
+
+// aesKeyWrapIV is the default initial value of RFC 3394, Section 2.2.3.1.
+var aesKeyWrapIV = [8]byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+// aesKeyUnwrap implements the AES key unwrap algorithm of RFC 3394, Section
+// 2.2.2.
+func aesKeyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 24 || len(wrapped)%8 != 0 {
+		return nil, errors.New("x509: invalid CMS wrapped key length")
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(wrapped)/8 - 1
+	var buf [16]byte
+	copy(buf[:8], wrapped[:8])
+	key := bytes.Clone(wrapped[8:])
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(buf[8:], binary.BigEndian.Uint64(buf[:8])^t)
+			copy(buf[:8], buf[8:])
+			copy(buf[8:], key[(i-1)*8:i*8])
+			block.Decrypt(buf[:], buf[:])
+			copy(key[(i-1)*8:], buf[8:])
+		}
+	}
+	if subtle.ConstantTimeCompare(buf[:8], aesKeyWrapIV[:]) != 1 {
+		return nil, errors.New("x509: CMS key unwrap failed")
+	}
+	return key, nil
+}
+
+// decryptCMSContent decrypts CBC-mode content, whose parameters hold the IV,
+// and removes its padding.
+func decryptCMSContent(newBlock func([]byte) (cipher.Block, error), key []byte, ai pkix.AlgorithmIdentifier, ciphertext []byte) ([]byte, error) {
+	block, err := newBlock(key)
+	if err != nil {
+		return nil, err
+	}
+	var iv []byte
+	params := der.Parser(ai.Parameters.FullBytes)
+	if !params.ReadOctetString(&iv) || !params.Empty() || len(iv) != block.BlockSize() {
+		return nil, errors.New("x509: invalid CMS content encryption IV")
+	}
+	bs := block.BlockSize()
+	if len(ciphertext) == 0 || len(ciphertext)%bs != 0 {
+		return nil, errors.New("x509: CMS encrypted content is not a multiple of the block size")
+	}
+	data := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(data, ciphertext)
+
+	// The padding of RFC 5652, Section 6.3, with n bytes equal to n.
+	n := int(data[len(data)-1])
+	if n == 0 || n > bs {
+		return nil, errors.New("x509: CMS content decryption failed")
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, errors.New("x509: CMS content decryption failed")
+		}
+	}
+	return data[:len(data)-n], nil
+}
@@ -0,0 +1,559 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/asn1/der"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The following were generated with OpenSSL 3.0, with cmsTestRSARecipientPEM
+// issued for the key in pemPrivateKey:
+//
+//	openssl cms -sign -binary -nodetach -stream -in msg.txt -signer ec.pem \
+//		-inkey ec.key -certfile ca.pem -md sha256 -outform DER
+//	openssl cms -sign -binary -in msg.txt -signer ec.pem -inkey ec.key \
+//		-md sha256 -outform DER
+//	openssl cms -encrypt -binary -des3 -in msg.txt -recip rsa.pem -outform DER
+//	openssl cms -encrypt -binary -aes-256-cbc -in msg.txt -recip rsa.pem \
+//		-keyopt rsa_padding_mode:oaep -keyopt rsa_oaep_md:sha256 -outform DER
+//	openssl cms -encrypt -binary -aes-192-cbc -in msg.txt -recip ec.pem \
+//		-keyopt ecdh_kdf_md:sha256 -outform DER
+//	openssl cms -encrypt -binary -aes-128-cbc -stream -in msg.txt \
+//		-recip ec.pem -recip rsa.pem -outform DER
+//
+// where msg.txt holds cmsTestMessage. The streamed ones are encoded in BER.
+
+const cmsTestMessage = "Hello, CMS!\n"
+
+const cmsTestCAPEM = `-----BEGIN CERTIFICATE-----
+MIIBYDCCAQWgAwIBAgIBATAKBggqhkjOPQQDAjAWMRQwEgYDVQQDDAtUZXN0IENN
+UyBDQTAgFw0yNjEwMTkxMjUwNTFaGA8yMTI2MDkyNTEyNTA1MVowFjEUMBIGA1UE
+AwwLVGVzdCBDTVMgQ0EwWTATBgcqhkjOPQIBBggqhkjOPQMBBwNCAATfesnyq3QE
+Q3fwTYUmmOIzSGAyqi8PLtuOfgi3T1ZXfPdQbRaMzB6B09RPcqHYyHApT37F6ZNG
++mTb1zLPUqp/o0IwQDAPBgNVHRMBAf8EBTADAQH/MA4GA1UdDwEB/wQEAwIBBjAd
+BgNVHQ4EFgQUUYcWtp6VUFneIDSpXOeZh9Nc4dwwCgYIKoZIzj0EAwIDSQAwRgIh
+APH5493wpHWvUg2PBVmpQNsr0VLIxK0k23IUGbMwu8KWAiEAxKCMAY/uYNVdmhye
+7Iw14MsQTArhcUWwQWcrX7m/W5I=
+-----END CERTIFICATE-----`
+
+const cmsTestSignerPEM = `-----BEGIN CERTIFICATE-----
+MIIBjjCCATSgAwIBAgICEjQwCgYIKoZIzj0EAwIwFjEUMBIGA1UEAwwLVGVzdCBD
+TVMgQ0EwIBcNMjYxMDE5MTI1MDUxWhgPMjEyNjA5MjUxMjUwNTFaMBQxEjAQBgNV
+BAMMCUVDIFNpZ25lcjBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABAfXjGClUOIM
+8swu1ZRPgSosQDyUxfNVjNQdDVrlkZRtsPkHF1pf20WLn8xwkGPRHK0tn7HzOJcm
+zaaPpGdwn6GjcjBwMAkGA1UdEwQCMAAwDgYDVR0PAQH/BAQDAgOoMBMGA1UdJQQM
+MAoGCCsGAQUFBwMEMB0GA1UdDgQWBBQeVO4KC1A79avqREsAChd2P+nTNDAfBgNV
+HSMEGDAWgBRRhxa2npVQWd4gNKlc55mH01zh3DAKBggqhkjOPQQDAgNIADBFAiBx
+EugFNKs7FMhRpYbzooAJ2woiHU0dirlpx9R7gN6sggIhALVreEXZjOAaAPT0OQji
+IJuorh57gArbSCXKeBa0SK9m
+-----END CERTIFICATE-----`
+
+const cmsTestRSARecipientPEM = `-----BEGIN CERTIFICATE-----
+MIIB2DCCAX+gAwIBAgICEjUwCgYIKoZIzj0EAwIwFjEUMBIGA1UEAwwLVGVzdCBD
+TVMgQ0EwIBcNMjYxMDE5MTI1MDUxWhgPMjEyNjA5MjUxMjUwNTFaMBgxFjAUBgNV
+BAMMDVJTQSBSZWNpcGllbnQwgZ8wDQYJKoZIhvcNAQEBBQADgY0AMIGJAoGBALGh
+4JRbkonE0/Eyn4qYLEotzVm/03L7gIWpxRdVRgfr0veZDu8hasn0YF9xoDsE9CpS
+VbFYz44IRBkfURk0i6pEw1BW4gYJvPlRDzDq1LSByB14ZfsnuOAJDhErcX8+4Izf
+xAEtofH3zyobw0xzpUoSsGNy0JcUdC3XiV6t3kqlAgMBAAGjcjBwMAkGA1UdEwQC
+MAAwDgYDVR0PAQH/BAQDAgOoMBMGA1UdJQQMMAoGCCsGAQUFBwMEMB0GA1UdDgQW
+BBStuuTS2pAASxx7y5iJ8/QEhWG4UDAfBgNVHSMEGDAWgBRRhxa2npVQWd4gNKlc
+55mH01zh3DAKBggqhkjOPQQDAgNHADBEAiA7jvKYwVt/Hn4/f1mvbZED/naOhlaO
+8pw6pWRnD9BMJgIgeJjtJRougd8SHyGrnz1RnEjLaReudNfNXPRXMMQbj0M=
+-----END CERTIFICATE-----`
+
+// cmsTestECKeyPEM is the key of cmsTestSignerPEM, which is also a key
+// agreement recipient.
+var cmsTestECKeyPEM = testingKey(`-----BEGIN EC TESTING KEY-----
+MHcCAQEEIBEHjEdNQbh5cWCK449/z6azq3BmF74wjXbIgt0XfN7JoAoGCCqGSM49
+AwEHoUQDQgAEB9eMYKVQ4gzyzC7VlE+BKixAPJTF81WM1B0NWuWRlG2w+QcXWl/b
+RYufzHCQY9EcrS2fsfM4lybNpo+kZ3CfoQ==
+-----END EC TESTING KEY-----`)
+
+const cmsTestSignedBase64 = "" +
+	"MIAGCSqGSIb3DQEHAqCAMIACAQExDTALBglghkgBZQMEAgEwgAYJKoZIhvcNAQcBoIAkgAQMSGVs" +
+	"bG8sIENNUyEKAAAAAAAAoIIC9jCCAWAwggEFoAMCAQICAQEwCgYIKoZIzj0EAwIwFjEUMBIGA1UE" +
+	"AwwLVGVzdCBDTVMgQ0EwIBcNMjYxMDE5MTI1MDUxWhgPMjEyNjA5MjUxMjUwNTFaMBYxFDASBgNV" +
+	"BAMMC1Rlc3QgQ01TIENBMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE33rJ8qt0BEN38E2FJpji" +
+	"M0hgMqovDy7bjn4It09WV3z3UG0WjMwegdPUT3Kh2MhwKU9+xemTRvpk29cyz1Kqf6NCMEAwDwYD" +
+	"VR0TAQH/BAUwAwEB/zAOBgNVHQ8BAf8EBAMCAQYwHQYDVR0OBBYEFFGHFraelVBZ3iA0qVznmYfT" +
+	"XOHcMAoGCCqGSM49BAMCA0kAMEYCIQDx+ePd8KR1r1INjwVZqUDbK9FSyMStJNtyFBmzMLvClgIh" +
+	"AMSgjAGP7mDVXZocnuyMNeDLEEwK4XFFsEFnK1+5v1uSMIIBjjCCATSgAwIBAgICEjQwCgYIKoZI" +
+	"zj0EAwIwFjEUMBIGA1UEAwwLVGVzdCBDTVMgQ0EwIBcNMjYxMDE5MTI1MDUxWhgPMjEyNjA5MjUx" +
+	"MjUwNTFaMBQxEjAQBgNVBAMMCUVDIFNpZ25lcjBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABAfX" +
+	"jGClUOIM8swu1ZRPgSosQDyUxfNVjNQdDVrlkZRtsPkHF1pf20WLn8xwkGPRHK0tn7HzOJcmzaaP" +
+	"pGdwn6GjcjBwMAkGA1UdEwQCMAAwDgYDVR0PAQH/BAQDAgOoMBMGA1UdJQQMMAoGCCsGAQUFBwME" +
+	"MB0GA1UdDgQWBBQeVO4KC1A79avqREsAChd2P+nTNDAfBgNVHSMEGDAWgBRRhxa2npVQWd4gNKlc" +
+	"55mH01zh3DAKBggqhkjOPQQDAgNIADBFAiBxEugFNKs7FMhRpYbzooAJ2woiHU0dirlpx9R7gN6s" +
+	"ggIhALVreEXZjOAaAPT0OQjiIJuorh57gArbSCXKeBa0SK9mMYIBbTCCAWkCAQEwHDAWMRQwEgYD" +
+	"VQQDDAtUZXN0IENNUyBDQQICEjQwCwYJYIZIAWUDBAIBoIHkMBgGCSqGSIb3DQEJAzELBgkqhkiG" +
+	"9w0BBwEwHAYJKoZIhvcNAQkFMQ8XDTI2MTAxOTEyNTA1MVowLwYJKoZIhvcNAQkEMSIEIOcxo2u/" +
+	"8DOwJOi3YO3QsaiTHfF6KvAvs+t8+po6/QmHMHkGCSqGSIb3DQEJDzFsMGowCwYJYIZIAWUDBAEq" +
+	"MAsGCWCGSAFlAwQBFjALBglghkgBZQMEAQIwCgYIKoZIhvcNAwcwDgYIKoZIhvcNAwICAgCAMA0G" +
+	"CCqGSIb3DQMCAgFAMAcGBSsOAwIHMA0GCCqGSIb3DQMCAgEoMAoGCCqGSM49BAMCBEYwRAIgFUte" +
+	"tDGedXRBviZnfkyExhVPuxDut6g/yuW/OO6iJdgCIA10d77wXY2mKb+3rEaO1tAqriqFC6D4zNX1" +
+	"4kWny2UFAAAAAAAA"
+
+const cmsTestDetachedBase64 = "" +
+	"MIIDOgYJKoZIhvcNAQcCoIIDKzCCAycCAQExDTALBglghkgBZQMEAgEwCwYJKoZIhvcNAQcBoIIB" +
+	"kjCCAY4wggE0oAMCAQICAhI0MAoGCCqGSM49BAMCMBYxFDASBgNVBAMMC1Rlc3QgQ01TIENBMCAX" +
+	"DTI2MTAxOTEyNTA1MVoYDzIxMjYwOTI1MTI1MDUxWjAUMRIwEAYDVQQDDAlFQyBTaWduZXIwWTAT" +
+	"BgcqhkjOPQIBBggqhkjOPQMBBwNCAAQH14xgpVDiDPLMLtWUT4EqLEA8lMXzVYzUHQ1a5ZGUbbD5" +
+	"BxdaX9tFi5/McJBj0RytLZ+x8ziXJs2mj6RncJ+ho3IwcDAJBgNVHRMEAjAAMA4GA1UdDwEB/wQE" +
+	"AwIDqDATBgNVHSUEDDAKBggrBgEFBQcDBDAdBgNVHQ4EFgQUHlTuCgtQO/Wr6kRLAAoXdj/p0zQw" +
+	"HwYDVR0jBBgwFoAUUYcWtp6VUFneIDSpXOeZh9Nc4dwwCgYIKoZIzj0EAwIDSAAwRQIgcRLoBTSr" +
+	"OxTIUaWG86KACdsKIh1NHYq5acfUe4DerIICIQC1a3hF2YzgGgD09DkI4iCbqK4ee4AK20glyngW" +
+	"tEivZjGCAW4wggFqAgEBMBwwFjEUMBIGA1UEAwwLVGVzdCBDTVMgQ0ECAhI0MAsGCWCGSAFlAwQC" +
+	"AaCB5DAYBgkqhkiG9w0BCQMxCwYJKoZIhvcNAQcBMBwGCSqGSIb3DQEJBTEPFw0yNjEwMTkxMjUw" +
+	"NTFaMC8GCSqGSIb3DQEJBDEiBCDnMaNrv/AzsCTot2Dt0LGokx3xeirwL7PrfPqaOv0JhzB5Bgkq" +
+	"hkiG9w0BCQ8xbDBqMAsGCWCGSAFlAwQBKjALBglghkgBZQMEARYwCwYJYIZIAWUDBAECMAoGCCqG" +
+	"SIb3DQMHMA4GCCqGSIb3DQMCAgIAgDANBggqhkiG9w0DAgIBQDAHBgUrDgMCBzANBggqhkiG9w0D" +
+	"AgIBKDAKBggqhkjOPQQDAgRHMEUCIQDW7T/lvu754QPyCJnpjW24r6ez6H4XyQV8wFZ/CxO1+wIg" +
+	"S+Yx+9mK7ju2g9U+E7p8Cmlg/BkYzH/s1i8dljlTqGU="
+
+const cmsTestEnvelopedRSABase64 = "" +
+	"MIIBAgYJKoZIhvcNAQcDoIH0MIHxAgEAMYG2MIGzAgEAMBwwFjEUMBIGA1UEAwwLVGVzdCBDTVMg" +
+	"Q0ECAhI1MA0GCSqGSIb3DQEBAQUABIGAIb5/eyokL/t+GAlDqjJL2oYNruqQKLkI3jexlAQ2F/iy" +
+	"upL1BrdgWboUs/zJ4GPzVK7vOAceVtFIpxdtvNeE3PF5Y+gNRfNkF7/p27PnzK3xuiRmBKuoaDj5" +
+	"8bgYqZe/vsws4peEwCnactLpw2zDo7FCrEYpthXZ2LNKuikrrdIwMwYJKoZIhvcNAQcBMBQGCCqG" +
+	"SIb3DQMHBAhmp1l+t3Cq7YAQUrRVctfAHMBqSgDU56A0hQ=="
+
+const cmsTestEnvelopedOAEPBase64 = "" +
+	"MIIBOAYJKoZIhvcNAQcDoIIBKTCCASUCAQAxgeEwgd4CAQAwHDAWMRQwEgYDVQQDDAtUZXN0IENN" +
+	"UyBDQQICEjUwOAYJKoZIhvcNAQEHMCugDTALBglghkgBZQMEAgGhGjAYBgkqhkiG9w0BAQgwCwYJ" +
+	"YIZIAWUDBAIBBIGAKd+whQn+ruY2BfeyaPiVJefPdg2G60brhv8a1wmzUnbczFXuPMtkwOHQYhbW" +
+	"qYam7bK/YGrpIP4696ywGb2wfeqGRUD63DWsyY3GcW/bXfETQT/vOkfCEEsci1ubonnPagPM9tSb" +
+	"D5JT2bFADV2r6WW3OHJJHYQx4BlWO0MfMg4wPAYJKoZIhvcNAQcBMB0GCWCGSAFlAwQBKgQQQfBr" +
+	"LOg3/r6qVAc/tqsu9YAQXNu5uHS5I7fYRciDLZtAqA=="
+
+const cmsTestEnvelopedECBase64 = "" +
+	"MIIBCQYJKoZIhvcNAQcDoIH7MIH4AgECMYG0oYGxAgEDoFGhTzAJBgcqhkjOPQIBA0IABDqaGD2v" +
+	"LfkprVB0klakPpiTzZkITAb4DHxx9rtj12565V79G1MI9j/bdlF30dJZA3rHlYwyoWaj4gRXqzOs" +
+	"Y7QwFQYGK4EEAQsBMAsGCWCGSAFlAwQBGTBCMEAwHDAWMRQwEgYDVQQDDAtUZXN0IENNUyBDQQIC" +
+	"EjQEIDP31MtzVdjSD2O4bqQkHg5oZ5a3c3sOeLllMciiJMlBMDwGCSqGSIb3DQEHATAdBglghkgB" +
+	"ZQMEARYEEJtgNhboB8gDi4mdDxQ/1aOAEEDs+LYFzEI1mfEYlzkgY0E="
+
+const cmsTestEnvelopedMultiBase64 = "" +
+	"MIAGCSqGSIb3DQEHA6CAMIACAQIxggFlMIGzAgEAMBwwFjEUMBIGA1UEAwwLVGVzdCBDTVMgQ0EC" +
+	"AhI1MA0GCSqGSIb3DQEBAQUABIGANo8zG1pAdCwike/lqn3TDAlAP/sAXn6/esirUu26K2OQD2mr" +
+	"vKOYoKqIYy+ytmk6bCZGV6BPIkck33YYMX8uO0IiLkH9G7ObVtDHqZrONCfCkY7OGxOXc4Uypg/G" +
+	"gjnRXWsudtDrgKCtsfz7Rhp3HzMC1TiXGzEmYwmV3WVuTEKhgawCAQOgUaFPMAkGByqGSM49AgED" +
+	"QgAEJkeghTI2ggpuKMcHYxGba3Qm2ViiafNEDVXDj+9dk98D3W2ErInhH/oAZ/F5OIuvWYSnp5Gq" +
+	"7i8nbrqlkBE9IjAYBgkrgQUQhkg/AAIwCwYJYIZIAWUDBAEFMDowODAcMBYxFDASBgNVBAMMC1Rl" +
+	"c3QgQ01TIENBAgISNAQY4tlhJ6LpwXg59KQGr4N20cdCIuw2+PeOMIAGCSqGSIb3DQEHATAdBglg" +
+	"hkgBZQMEAQIEEEXcx0DfZ/0/ecocZRRMAPOggAQQfabdEBENLmMC4B9VmtHOvwAAAAAAAAAAAAA="
+
+func mustDecodeBase64(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func cmsTestRoots(t *testing.T) *CertPool {
+	t.Helper()
+	roots := NewCertPool()
+	roots.AddCert(mustParsePEMCertificate(t, cmsTestCAPEM))
+	return roots
+}
+
+func TestParseCMSSignedDataOpenSSL(t *testing.T) {
+	signer := mustParsePEMCertificate(t, cmsTestSignerPEM)
+	ber := mustDecodeBase64(t, cmsTestSignedBase64)
+	sd, err := ParseCMSSignedData(ber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sd.ContentType.Equal(oidCMSData) {
+		t.Errorf("ContentType = %v, want id-data", sd.ContentType)
+	}
+	if string(sd.Content) != cmsTestMessage {
+		t.Errorf("Content = %q, want %q", sd.Content, cmsTestMessage)
+	}
+	if len(sd.Certificates) != 2 {
+		t.Fatalf("got %d certificates, want 2", len(sd.Certificates))
+	}
+	if len(sd.Signers) != 1 {
+		t.Fatalf("got %d signers, want 1", len(sd.Signers))
+	}
+	s := sd.Signers[0]
+	if !bytes.Equal(s.RawIssuer, signer.RawIssuer) || s.SerialNumber.Cmp(signer.SerialNumber) != 0 {
+		t.Errorf("signer identifies %x/%v, want %x/%v", s.RawIssuer, s.SerialNumber, signer.RawIssuer, signer.SerialNumber)
+	}
+	if s.DigestAlgorithm != crypto.SHA256 || s.SignatureAlgorithm != ECDSAWithSHA256 {
+		t.Errorf("algorithms = %v, %v; want SHA-256, ECDSA-SHA256", s.DigestAlgorithm, s.SignatureAlgorithm)
+	}
+	if s.SigningTime.IsZero() {
+		t.Errorf("SigningTime is not set")
+	}
+	if _, err := der.FromBER(sd.Raw); err != nil || bytes.Equal(sd.Raw, ber) {
+		t.Errorf("Raw is not the DER conversion of the input")
+	}
+
+	certs, err := sd.Verify(nil, VerifyOptions{Roots: cmsTestRoots(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 || !certs[0].Equal(signer) {
+		t.Errorf("Verify returned %v, want the signer certificate", certs)
+	}
+	if _, err := sd.Verify(nil, VerifyOptions{Roots: NewCertPool()}); err == nil {
+		t.Errorf("Verify with no roots succeeded")
+	}
+	if _, err := sd.Verify([]byte(cmsTestMessage), VerifyOptions{Roots: cmsTestRoots(t)}); err == nil {
+		t.Errorf("Verify with detached content for attached signature succeeded")
+	}
+
+	// Tampering with the parsed content must be caught by the digest.
+	sd.Content = []byte("Goodbye, CMS!\n")
+	if _, err := sd.Verify(nil, VerifyOptions{Roots: cmsTestRoots(t)}); err == nil {
+		t.Errorf("Verify with modified content succeeded")
+	}
+}
+
+func TestCMSSignedDataDetachedOpenSSL(t *testing.T) {
+	sd, err := ParseCMSSignedData(mustDecodeBase64(t, cmsTestDetachedBase64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sd.Content != nil {
+		t.Errorf("Content = %q, want nil", sd.Content)
+	}
+	opts := VerifyOptions{Roots: cmsTestRoots(t)}
+	if _, err := sd.Verify(nil, opts); err == nil {
+		t.Errorf("Verify without detached content succeeded")
+	}
+	if _, err := sd.Verify([]byte(cmsTestMessage), opts); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if _, err := sd.Verify([]byte("Hello, CMS?\n"), opts); err == nil {
+		t.Errorf("Verify with wrong content succeeded")
+	}
+	// The signer certificate is not included, so it must be found in the
+	// SignedData for Verify, but CheckSignature works with it directly.
+	sd.Certificates = nil
+	if _, err := sd.Verify([]byte(cmsTestMessage), opts); err == nil {
+		t.Errorf("Verify without signer certificate succeeded")
+	}
+	signer := mustParsePEMCertificate(t, cmsTestSignerPEM)
+	if err := sd.Signers[0].CheckSignature(oidCMSData, []byte(cmsTestMessage), signer); err != nil {
+		t.Errorf("CheckSignature: %v", err)
+	}
+	if err := sd.Signers[0].CheckSignature(oidCMSEnvelopedData, []byte(cmsTestMessage), signer); err == nil {
+		t.Errorf("CheckSignature with wrong content type succeeded")
+	}
+}
+
+// cmsTestLeaf issues a certificate for pub from a new CA, and returns it with
+// a pool holding the CA.
+func cmsTestLeaf(t *testing.T, pub crypto.PublicKey) (*Certificate, *CertPool) {
+	t.Helper()
+	ca, caKey := ocspTestCA(t, "Test CMS CA")
+	template := &Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Test CMS Signer"},
+		NotBefore:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		KeyUsage:     KeyUsageDigitalSignature,
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageCodeSigning},
+		SubjectKeyId: []byte{1, 2, 3, 4},
+	}
+	der, err := CreateCertificate(rand.Reader, template, ca, pub, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := NewCertPool()
+	roots.AddCert(ca)
+	return leaf, roots
+}
+
+func TestCreateCMSSignedData(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("firmware image")
+	signingTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	for _, tt := range []struct {
+		name   string
+		key    crypto.Signer
+		opts   *CMSSignOptions
+		digest crypto.Hash
+		alg    SignatureAlgorithm
+	}{
+		{"RSA", testPrivateKey, nil, crypto.SHA256, SHA256WithRSA},
+		{"RSA-PSS", testPrivateKey, &CMSSignOptions{SignatureAlgorithm: SHA384WithRSAPSS}, crypto.SHA384, SHA384WithRSAPSS},
+		{"ECDSA", ecKey, &CMSSignOptions{Detached: true, SigningTime: signingTime}, crypto.SHA384, ECDSAWithSHA384},
+		{"Ed25519", edKey, &CMSSignOptions{UseSubjectKeyId: true}, crypto.SHA512, PureEd25519},
+		{"ContentType", ecKey, &CMSSignOptions{
+			ContentType:      asn1.ObjectIdentifier{1, 2, 3, 4},
+			SigningTime:      time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC),
+			SignedAttributes: []CMSAttribute{{Type: asn1.ObjectIdentifier{1, 2, 3, 5}, Values: [][]byte{{0x05, 0x00}}}},
+		}, crypto.SHA384, ECDSAWithSHA384},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			leaf, roots := cmsTestLeaf(t, tt.key.Public())
+			signed, err := CreateCMSSignedData(rand.Reader, content, leaf, tt.key, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sd, err := ParseCMSSignedData(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(sd.Raw, signed) {
+				t.Errorf("Raw doesn't match the DER encoding")
+			}
+			opts := tt.opts
+			if opts == nil {
+				opts = &CMSSignOptions{}
+			}
+			var detached []byte
+			if opts.Detached {
+				if sd.Content != nil {
+					t.Errorf("Content = %q, want nil", sd.Content)
+				}
+				detached = content
+			} else if !bytes.Equal(sd.Content, content) {
+				t.Errorf("Content = %q, want %q", sd.Content, content)
+			}
+			if opts.ContentType != nil && !sd.ContentType.Equal(opts.ContentType) {
+				t.Errorf("ContentType = %v, want %v", sd.ContentType, opts.ContentType)
+			}
+			if len(sd.Certificates) != 1 || !sd.Certificates[0].Equal(leaf) {
+				t.Errorf("Certificates don't hold the signer certificate")
+			}
+			s := sd.Signers[0]
+			if s.DigestAlgorithm != tt.digest || s.SignatureAlgorithm != tt.alg {
+				t.Errorf("algorithms = %v, %v; want %v, %v", s.DigestAlgorithm, s.SignatureAlgorithm, tt.digest, tt.alg)
+			}
+			if opts.UseSubjectKeyId != (s.SubjectKeyId != nil) {
+				t.Errorf("SubjectKeyId = %x, UseSubjectKeyId = %v", s.SubjectKeyId, opts.UseSubjectKeyId)
+			}
+			if !opts.SigningTime.IsZero() && !s.SigningTime.Equal(opts.SigningTime) {
+				t.Errorf("SigningTime = %v, want %v", s.SigningTime, opts.SigningTime)
+			}
+			if len(s.SignedAttributes) != 3+len(opts.SignedAttributes) {
+				t.Errorf("got %d signed attributes, want %d", len(s.SignedAttributes), 3+len(opts.SignedAttributes))
+			}
+
+			certs, err := sd.Verify(detached, VerifyOptions{Roots: roots})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(certs) != 1 || !certs[0].Equal(leaf) {
+				t.Errorf("Verify returned %v, want the signer certificate", certs)
+			}
+			if _, err := sd.Verify(detached, VerifyOptions{Roots: roots, KeyUsages: []ExtKeyUsage{ExtKeyUsageServerAuth}}); err == nil {
+				t.Errorf("Verify with wrong key usage succeeded")
+			}
+		})
+	}
+}
+
+func TestCreateCMSSignedDataErrors(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := cmsTestLeaf(t, ecKey.Public())
+	if _, err := CreateCMSSignedData(rand.Reader, nil, leaf, testPrivateKey, nil); err == nil {
+		t.Errorf("signing with a key that doesn't match the certificate succeeded")
+	}
+	if _, err := CreateCMSSignedData(rand.Reader, nil, leaf, ecKey, &CMSSignOptions{SignatureAlgorithm: SHA256WithRSA}); err == nil {
+		t.Errorf("signing with a mismatched signature algorithm succeeded")
+	}
+	if _, err := CreateCMSSignedData(rand.Reader, nil, nil, ecKey, nil); err == nil {
+		t.Errorf("signing without a certificate succeeded")
+	}
+	noKeyId := *leaf
+	noKeyId.SubjectKeyId = nil
+	if _, err := CreateCMSSignedData(rand.Reader, nil, &noKeyId, ecKey, &CMSSignOptions{UseSubjectKeyId: true}); err == nil {
+		t.Errorf("signing by subject key identifier without one succeeded")
+	}
+}
+
+func TestParseCMSSignedDataCertsOnly(t *testing.T) {
+	ca := mustParsePEMCertificate(t, cmsTestCAPEM)
+	signer := mustParsePEMCertificate(t, cmsTestSignerPEM)
+	b := der.NewBuilder(nil)
+	b.AddElement(der.Sequence, func(ci *der.Builder) {
+		ci.AddObjectIdentifier(oidCMSSignedData)
+		ci.AddElement(cmsTag0Construct, func(explicit *der.Builder) {
+			explicit.AddElement(der.Sequence, func(sd *der.Builder) {
+				sd.AddInt64(1)
+				sd.AddSetOf(func(*der.Builder) {})
+				sd.AddElement(der.Sequence, func(encap *der.Builder) {
+					encap.AddObjectIdentifier(oidCMSData)
+				})
+				sd.AddElement(cmsTag0Construct, func(certs *der.Builder) {
+					certs.AddRawElement(signer.Raw)
+					certs.AddRawElement(ca.Raw)
+				})
+				sd.AddSetOf(func(*der.Builder) {})
+			})
+		})
+	})
+	sd, err := ParseCMSSignedData(b.BytesOrPanic())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sd.Signers) != 0 || sd.Content != nil {
+		t.Errorf("got %d signers and content %q, want none", len(sd.Signers), sd.Content)
+	}
+	if len(sd.Certificates) != 2 || !sd.Certificates[0].Equal(signer) || !sd.Certificates[1].Equal(ca) {
+		t.Errorf("Certificates don't match")
+	}
+	if _, err := sd.Verify(nil, VerifyOptions{}); err == nil {
+		t.Errorf("Verify with no signers succeeded")
+	}
+}
+
+func TestParseCMSErrors(t *testing.T) {
+	signed := mustDecodeBase64(t, cmsTestDetachedBase64)
+	enveloped := mustDecodeBase64(t, cmsTestEnvelopedRSABase64)
+	if _, err := ParseCMSSignedData(enveloped); err == nil || !strings.Contains(err.Error(), "unexpected CMS content type") {
+		t.Errorf("ParseCMSSignedData(EnvelopedData) error = %v", err)
+	}
+	if _, err := ParseCMSEnvelopedData(signed); err == nil || !strings.Contains(err.Error(), "unexpected CMS content type") {
+		t.Errorf("ParseCMSEnvelopedData(SignedData) error = %v", err)
+	}
+	for i := 0; i < len(signed); i += 7 {
+		if _, err := ParseCMSSignedData(signed[:i]); err == nil {
+			t.Errorf("ParseCMSSignedData succeeded on %d of %d bytes", i, len(signed))
+		}
+	}
+	for i := 0; i < len(enveloped); i += 7 {
+		if _, err := ParseCMSEnvelopedData(enveloped[:i]); err == nil {
+			t.Errorf("ParseCMSEnvelopedData succeeded on %d of %d bytes", i, len(enveloped))
+		}
+	}
+}
+
+func TestCMSEnvelopedDataOpenSSL(t *testing.T) {
+	rsaCert := mustParsePEMCertificate(t, cmsTestRSARecipientPEM)
+	ecCert := mustParsePEMCertificate(t, cmsTestSignerPEM)
+	block, _ := pem.Decode([]byte(cmsTestECKeyPEM))
+	ecKey, err := ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdhKey, err := ecKey.ECDH()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		data string
+		cert *Certificate
+		key  crypto.PrivateKey
+	}{
+		{"RSA", cmsTestEnvelopedRSABase64, rsaCert, testPrivateKey},
+		{"RSA/no cert", cmsTestEnvelopedRSABase64, nil, testPrivateKey},
+		{"OAEP", cmsTestEnvelopedOAEPBase64, rsaCert, testPrivateKey},
+		{"ECDH", cmsTestEnvelopedECBase64, ecCert, ecKey},
+		{"ECDH/ecdh key", cmsTestEnvelopedECBase64, nil, ecdhKey},
+		{"multi/RSA", cmsTestEnvelopedMultiBase64, rsaCert, testPrivateKey},
+		{"multi/ECDH", cmsTestEnvelopedMultiBase64, ecCert, ecKey},
+	} {
+		ed, err := ParseCMSEnvelopedData(mustDecodeBase64(t, tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !ed.ContentType.Equal(oidCMSData) {
+			t.Errorf("%s: ContentType = %v, want id-data", tt.name, ed.ContentType)
+		}
+		got, err := ed.Decrypt(tt.cert, tt.key)
+		if err != nil {
+			t.Errorf("%s: Decrypt: %v", tt.name, err)
+		} else if string(got) != cmsTestMessage {
+			t.Errorf("%s: Decrypt = %q, want %q", tt.name, got, cmsTestMessage)
+		}
+	}
+
+	ed, err := ParseCMSEnvelopedData(mustDecodeBase64(t, cmsTestEnvelopedRSABase64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ed.Decrypt(ecCert, testPrivateKey); err == nil {
+		t.Errorf("Decrypt for a certificate that is not a recipient succeeded")
+	}
+	if _, err := ed.Decrypt(nil, ecKey); err == nil {
+		t.Errorf("Decrypt with a key of the wrong kind succeeded")
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed, err = ParseCMSEnvelopedData(mustDecodeBase64(t, cmsTestEnvelopedECBase64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ed.Decrypt(ecCert, otherKey); err == nil {
+		t.Errorf("Decrypt with the wrong key succeeded")
+	}
+	if _, err := ed.Decrypt(ecCert, ed25519.NewKeyFromSeed(make([]byte, 32))); err == nil {
+		t.Errorf("Decrypt with an Ed25519 key succeeded")
+	}
+}
+
+func TestAESKeyUnwrap(t *testing.T) {
+	// Test vectors from RFC 3394, Sections 4.1 and 4.6.
+	for _, tt := range []struct {
+		kek, wrapped, key string
+	}{
+		{
+			"000102030405060708090A0B0C0D0E0F",
+			"1FA68B0A8112B447AEF34BD8FB5A7B829D3E862371D2CFE5",
+			"00112233445566778899AABBCCDDEEFF",
+		},
+		{
+			"000102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F",
+			"28C9F404C4B810F4CBCCB35CFB87F8263F5786E2D80ED326CBC7F0E71A99F43BFB988B9B7A02DD21",
+			"00112233445566778899AABBCCDDEEFF000102030405060708090A0B0C0D0E0F",
+		},
+	} {
+		kek, _ := hex.DecodeString(tt.kek)
+		wrapped, _ := hex.DecodeString(tt.wrapped)
+		want, _ := hex.DecodeString(tt.key)
+		got, err := aesKeyUnwrap(kek, wrapped)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("aesKeyUnwrap(%s, %s) = %x, %v; want %s", tt.kek, tt.wrapped, got, err, tt.key)
+		}
+		wrapped[len(wrapped)-1] ^= 1
+		if _, err := aesKeyUnwrap(kek, wrapped); err == nil {
+			t.Errorf("aesKeyUnwrap succeeded with corrupted input")
+		}
+		if _, err := aesKeyUnwrap(kek, wrapped[:16]); err == nil {
+			t.Errorf("aesKeyUnwrap succeeded with short input")
+		}
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// This file implements the verification of Certificate Transparency signed
+// certificate timestamps, as specified in RFC 6962.
+
+var (
+	oidExtensionSCTList     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+	oidExtensionOCSPSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 5}
+)
+
+// SCT entry types, see RFC 6962, Section 3.1.
+const (
+	sctX509Entry    = 0
+	sctPrecertEntry = 1
+)
+
+// A SignedCertificateTimestamp (SCT) is a promise by a Certificate
+// Transparency log to include a certificate in the log, as specified in RFC
+// 6962, Section 3.2.
+type SignedCertificateTimestamp struct {
+	Raw []byte // Complete TLS-encoded SCT.
+
+	// Version is the SCT version. Only version 1, encoded as zero, is
+	// supported for verification.
+	Version int
+
+	// LogID is the SHA-256 hash of the log's DER-encoded public key, see
+	// [CTLog.ID].
+	LogID [32]byte
+
+	// Timestamp is the time at which the log issued the SCT, with millisecond
+	// precision.
+	Timestamp time.Time
+
+	// Extensions contains the raw CtExtensions of the SCT.
+	Extensions []byte
+
+	// SignatureAlgorithm is ECDSAWithSHA256 or SHA256WithRSA for SCTs using
+	// the algorithms permitted by RFC 6962, and UnknownSignatureAlgorithm
+	// otherwise.
+	SignatureAlgorithm SignatureAlgorithm
+	Signature          []byte
+}
+
+// ParseSignedCertificateTimestamp parses a single TLS-encoded SCT, such as
+// an element of tls.ConnectionState.SignedCertificateTimestamps.
+func ParseSignedCertificateTimestamp(b []byte) (*SignedCertificateTimestamp, error) {
+	s := cryptobyte.String(b)
+	sct, err := parseSCT(&s)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Empty() {
+		return nil, errors.New("x509: trailing data after signed certificate timestamp")
+	}
+	return sct, nil
+}
+
+func parseSCT(s *cryptobyte.String) (*SignedCertificateTimestamp, error) {
+	sct := &SignedCertificateTimestamp{Raw: *s}
+	var version uint8
+	var logID []byte
+	var timestamp uint64
+	var extensions, signature cryptobyte.String
+	var hash, sig uint8
+	if !s.ReadUint8(&version) || !s.ReadBytes(&logID, 32) || !s.ReadUint64(&timestamp) ||
+		!s.ReadUint16LengthPrefixed(&extensions) || !s.ReadUint8(&hash) || !s.ReadUint8(&sig) ||
+		!s.ReadUint16LengthPrefixed(&signature) {
+		return nil, errors.New("x509: malformed signed certificate timestamp")
+	}
+	if timestamp > 1<<63-1 {
+		return nil, errors.New("x509: signed certificate timestamp has an invalid timestamp")
+	}
+	sct.Raw = sct.Raw[:len(sct.Raw)-len(*s)]
+	sct.Version = int(version)
+	copy(sct.LogID[:], logID)
+	sct.Timestamp = time.UnixMilli(int64(timestamp))
+	sct.Extensions = extensions
+	sct.Signature = signature
+	// Only SHA-256 (4) with RSA (1) or ECDSA (3) is allowed by RFC 6962,
+	// Section 2.1.4.
+	switch {
+	case hash == 4 && sig == 1:
+		sct.SignatureAlgorithm = SHA256WithRSA
+	case hash == 4 && sig == 3:
+		sct.SignatureAlgorithm = ECDSAWithSHA256
+	}
+	return sct, nil
+}
+
+// ParseSignedCertificateTimestampList parses a TLS-encoded
+// SignedCertificateTimestampList, as carried by the signed_certificate_timestamp
+// TLS extension and by the SCT list extensions of certificates and OCSP
+// responses.
+func ParseSignedCertificateTimestampList(b []byte) ([]*SignedCertificateTimestamp, error) {
+	s := cryptobyte.String(b)
+	var list cryptobyte.String
+	if !s.ReadUint16LengthPrefixed(&list) || !s.Empty() {
+		return nil, errors.New("x509: malformed signed certificate timestamp list")
+	}
+	var scts []*SignedCertificateTimestamp
+	for !list.Empty() {
+		var serialized cryptobyte.String
+		if !list.ReadUint16LengthPrefixed(&serialized) {
+			return nil, errors.New("x509: malformed signed certificate timestamp list")
+		}
+		sct, err := ParseSignedCertificateTimestamp(serialized)
+		if err != nil {
+			return nil, err
+		}
+		scts = append(scts, sct)
+	}
+	return scts, nil
+}
+
+// parseSCTListExtension parses the value of an SCT list extension, which is
+// an OCTET STRING wrapping the TLS-encoded list.
+func parseSCTListExtension(value []byte) ([]*SignedCertificateTimestamp, error) {
+	s := cryptobyte.String(value)
+	var list cryptobyte.String
+	if !s.ReadASN1(&list, cryptobyte_asn1.OCTET_STRING) || !s.Empty() {
+		return nil, errors.New("x509: malformed signed certificate timestamp list extension")
+	}
+	return ParseSignedCertificateTimestampList(list)
+}
+
+// SignedCertificateTimestamps returns the SCTs embedded in the certificate
+// by its issuer, or nil if it has none. Embedded SCTs must be verified with
+// [CTLog.VerifyEmbeddedSCT].
+func (c *Certificate) SignedCertificateTimestamps() ([]*SignedCertificateTimestamp, error) {
+	for _, e := range c.Extensions {
+		if e.Id.Equal(oidExtensionSCTList) {
+			return parseSCTListExtension(e.Value)
+		}
+	}
+	return nil, nil
+}
+
+// SignedCertificateTimestamps returns the SCTs included in the OCSP response
+// for the certificate it reports on, or nil if it has none. They are usually
+// in the singleExtensions of the response, but are also accepted in its
+// responseExtensions. SCTs from OCSP responses must be verified with
+// [CTLog.VerifySCT].
+func (resp *OCSPResponse) SignedCertificateTimestamps() ([]*SignedCertificateTimestamp, error) {
+	for _, exts := range [][]pkix.Extension{resp.SingleExtensions, resp.Extensions} {
+		for _, e := range exts {
+			if e.Id.Equal(oidExtensionOCSPSCTList) {
+				return parseSCTListExtension(e.Value)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// A CTLog is a Certificate Transparency log trusted to issue SCTs.
+type CTLog struct {
+	// Description is a human-readable name for the log, used in errors.
+	Description string
+
+	// PublicKey is the public key of the log, an *ecdsa.PublicKey on the
+	// P-256 curve or an *rsa.PublicKey.
+	PublicKey crypto.PublicKey
+}
+
+// ID returns the log ID, the SHA-256 hash of the log's public key in PKIX,
+// ASN.1 DER form, which identifies the log in the SCTs it issues.
+func (l *CTLog) ID() ([32]byte, error) {
+	der, err := MarshalPKIXPublicKey(l.PublicKey)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(der), nil
+}
+
+// VerifySCT verifies that sct is a valid SCT issued by the log for cert, as
+// delivered in a TLS extension or an OCSP response.
+func (l *CTLog) VerifySCT(sct *SignedCertificateTimestamp, cert *Certificate) error {
+	return l.verifySCT(sct, sctX509Entry, func(b *cryptobyte.Builder) {
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(cert.Raw)
+		})
+	})
+}
+
+// VerifyEmbeddedSCT verifies that sct is a valid SCT issued by the log for
+// the precertificate of cert, as embedded in cert, which was issued by issuer.
+func (l *CTLog) VerifyEmbeddedSCT(sct *SignedCertificateTimestamp, cert, issuer *Certificate) error {
+	tbs, err := tbsWithoutSCTs(cert.RawTBSCertificate)
+	if err != nil {
+		return err
+	}
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	return l.verifySCT(sct, sctPrecertEntry, func(b *cryptobyte.Builder) {
+		b.AddBytes(issuerKeyHash[:])
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddBytes(tbs)
+		})
+	})
+}
+
+func (l *CTLog) verifySCT(sct *SignedCertificateTimestamp, entryType uint16, addEntry cryptobyte.BuilderContinuation) error {
+	id, err := l.ID()
+	if err != nil {
+		return err
+	}
+	if sct.LogID != id {
+		return errors.New("x509: signed certificate timestamp is from another log")
+	}
+	if sct.Version != 0 {
+		return fmt.Errorf("x509: unsupported signed certificate timestamp version %d", sct.Version+1)
+	}
+	if sct.SignatureAlgorithm == UnknownSignatureAlgorithm {
+		return ErrUnsupportedAlgorithm
+	}
+
+	// The signed data is the digitally-signed struct of RFC 6962, Section 3.2.
+	var b cryptobyte.Builder
+	b.AddUint8(uint8(sct.Version))
+	b.AddUint8(0) // certificate_timestamp
+	b.AddUint64(uint64(sct.Timestamp.UnixMilli()))
+	b.AddUint16(entryType)
+	addEntry(&b)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(sct.Extensions)
+	})
+	signed, err := b.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := checkSignature(sct.SignatureAlgorithm, signed, sct.Signature, l.PublicKey, false); err != nil {
+		if l.Description != "" {
+			return fmt.Errorf("x509: invalid signed certificate timestamp from %s: %w", l.Description, err)
+		}
+		return fmt.Errorf("x509: invalid signed certificate timestamp: %w", err)
+	}
+	return nil
+}
+
+// tbsWithoutSCTs returns the DER-encoded TBSCertificate tbs with the SCT list
+// extension removed, which is the TBSCertificate of the precertificate
+// signed by the log.
+func tbsWithoutSCTs(tbs []byte) ([]byte, error) {
+	input := cryptobyte.String(tbs)
+	var fields cryptobyte.String
+	if !input.ReadASN1(&fields, cryptobyte_asn1.SEQUENCE) || !input.Empty() {
+		return nil, errors.New("x509: malformed tbs certificate")
+	}
+	extensionsTag := cryptobyte_asn1.Tag(3).Constructed().ContextSpecific()
+	var b cryptobyte.Builder
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		for !fields.Empty() {
+			var field cryptobyte.String
+			var tag cryptobyte_asn1.Tag
+			if !fields.ReadAnyASN1Element(&field, &tag) {
+				b.SetError(errors.New("x509: malformed tbs certificate"))
+				return
+			}
+			if tag != extensionsTag {
+				b.AddBytes(field)
+				continue
+			}
+			var exts cryptobyte.String
+			if !field.ReadASN1(&field, extensionsTag) || !field.ReadASN1(&exts, cryptobyte_asn1.SEQUENCE) {
+				b.SetError(errors.New("x509: malformed extensions"))
+				return
+			}
+			var kept [][]byte
+			for !exts.Empty() {
+				var ext, e cryptobyte.String
+				var oid asn1.ObjectIdentifier
+				if !exts.ReadASN1Element(&ext, cryptobyte_asn1.SEQUENCE) {
+					b.SetError(errors.New("x509: malformed extension"))
+					return
+				}
+				e = ext
+				if !e.ReadASN1(&e, cryptobyte_asn1.SEQUENCE) || !e.ReadASN1ObjectIdentifier(&oid) {
+					b.SetError(errors.New("x509: malformed extension"))
+					return
+				}
+				if !oid.Equal(oidExtensionSCTList) {
+					kept = append(kept, ext)
+				}
+			}
+			if len(kept) == 0 {
+				continue
+			}
+			b.AddASN1(extensionsTag, func(b *cryptobyte.Builder) {
+				b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					for _, ext := range kept {
+						b.AddBytes(ext)
+					}
+				})
+			})
+		}
+	})
+	return b.Bytes()
+}
+
+// checkSCTs checks that the leaf of chain has valid SCTs, not issued after
+// now, from at least opts.MinValidSCTs distinct logs in opts.CTLogs.
+func checkSCTs(chain []*Certificate, opts *VerifyOptions, now time.Time) error {
+	leaf := chain[0]
+	if len(chain) < 2 {
+		// A trusted root is not subject to Certificate Transparency.
+		return nil
+	}
+	issuer := chain[1]
+
+	logs := make(map[[32]byte]*CTLog, len(opts.CTLogs))
+	for _, l := range opts.CTLogs {
+		if id, err := l.ID(); err == nil {
+			logs[id] = l
+		}
+	}
+	valid := make(map[[32]byte]bool)
+	check := func(sct *SignedCertificateTimestamp, embedded bool) {
+		l := logs[sct.LogID]
+		if l == nil || valid[sct.LogID] || sct.Timestamp.After(now) {
+			return
+		}
+		var err error
+		if embedded {
+			err = l.VerifyEmbeddedSCT(sct, leaf, issuer)
+		} else {
+			err = l.VerifySCT(sct, leaf)
+		}
+		if err == nil {
+			valid[sct.LogID] = true
+		}
+	}
+
+	// Malformed SCTs are ignored, like SCTs from unknown logs.
+	if scts, err := leaf.SignedCertificateTimestamps(); err == nil {
+		for _, sct := range scts {
+			check(sct, true)
+		}
+	}
+	for _, b := range opts.SignedCertificateTimestamps {
+		if sct, err := ParseSignedCertificateTimestamp(b); err == nil {
+			check(sct, false)
+		}
+	}
+	if len(opts.OCSPStaple) > 0 {
+		// The SCTs are signed by the logs, so the response doesn't need to be
+		// verified for them to be trusted.
+		if resp, err := ParseOCSPResponse(opts.OCSPStaple); err == nil && resp.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+			if scts, err := resp.SignedCertificateTimestamps(); err == nil {
+				for _, sct := range scts {
+					check(sct, false)
+				}
+			}
+		}
+	}
+
+	if len(valid) < opts.MinValidSCTs {
+		return CertificateInvalidError{leaf, InsufficientSCTs,
+			fmt.Sprintf("%d valid, %d required", len(valid), opts.MinValidSCTs)}
+	}
+	return nil
+}
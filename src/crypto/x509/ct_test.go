@@ -0,0 +1,391 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+func TestParseSCTsGoogleLeaf(t *testing.T) {
+	leaf := mustParsePEMCertificate(t, googleLeaf)
+	scts, err := leaf.SignedCertificateTimestamps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// As printed by openssl x509 -text.
+	want := []struct {
+		logID     string
+		timestamp time.Time
+	}{
+		{"7a328c54d8b72db620ea38e0521ee98416703213854d3bd22bc13a57a352eb52", time.Date(2023, 1, 2, 9, 19, 20, 101e6, time.UTC)},
+		{"e83ed0da3ef5063532e75728bc896bc903d3cbd1116beceb69e1777d6d06bd6e", time.Date(2023, 1, 2, 9, 19, 20, 52e6, time.UTC)},
+	}
+	if len(scts) != len(want) {
+		t.Fatalf("got %d SCTs, want %d", len(scts), len(want))
+	}
+	for i, sct := range scts {
+		if got := fmt.Sprintf("%x", sct.LogID); got != want[i].logID {
+			t.Errorf("SCT %d: LogID = %s, want %s", i, got, want[i].logID)
+		}
+		if !sct.Timestamp.Equal(want[i].timestamp) {
+			t.Errorf("SCT %d: Timestamp = %v, want %v", i, sct.Timestamp, want[i].timestamp)
+		}
+		if sct.Version != 0 || len(sct.Extensions) != 0 || sct.SignatureAlgorithm != ECDSAWithSHA256 || len(sct.Signature) != 71 {
+			t.Errorf("SCT %d: got %+v", i, sct)
+		}
+		again, err := ParseSignedCertificateTimestamp(sct.Raw)
+		if err != nil {
+			t.Errorf("SCT %d: reparsing Raw: %v", i, err)
+		} else if again.LogID != sct.LogID || !bytes.Equal(again.Signature, sct.Signature) {
+			t.Errorf("SCT %d: reparsing Raw returned a different SCT", i)
+		}
+	}
+
+	root := mustParsePEMCertificate(t, gtsRoot)
+	if scts, err := root.SignedCertificateTimestamps(); scts != nil || err != nil {
+		t.Errorf("root SignedCertificateTimestamps() = %v, %v; want nil, nil", scts, err)
+	}
+}
+
+func TestParseSCTErrors(t *testing.T) {
+	key := ctTestKey(t)
+	sct := ctTestSCT(t, key, sctX509Entry, []byte("cert"), time.Now())
+	if _, err := ParseSignedCertificateTimestamp(sct); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(sct); i++ {
+		if _, err := ParseSignedCertificateTimestamp(sct[:i]); err == nil {
+			t.Errorf("ParseSignedCertificateTimestamp succeeded with %d of %d bytes", i, len(sct))
+		}
+	}
+	if _, err := ParseSignedCertificateTimestamp(append(sct, 0)); err == nil {
+		t.Errorf("ParseSignedCertificateTimestamp succeeded with trailing data")
+	}
+
+	var list []byte
+	if _, err := asn1.Unmarshal(ctTestSCTList(sct, sct), &list); err != nil {
+		t.Fatal(err)
+	}
+	if scts, err := ParseSignedCertificateTimestampList(list); err != nil || len(scts) != 2 {
+		t.Fatalf("ParseSignedCertificateTimestampList = %d SCTs, %v; want 2", len(scts), err)
+	}
+	for _, bad := range [][]byte{nil, list[:len(list)-1], append(list, 0), {0, 3, 0, 1, 0}} {
+		if _, err := ParseSignedCertificateTimestampList(bad); err == nil {
+			t.Errorf("ParseSignedCertificateTimestampList(%x) succeeded", bad)
+		}
+	}
+}
+
+func ctTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// ctTestSCT returns a TLS-encoded SCT signed by the log with key key, with
+// the given entry type and the encoding of the entry that follows it.
+func ctTestSCT(t *testing.T, key crypto.Signer, entryType uint16, entry []byte, timestamp time.Time) []byte {
+	t.Helper()
+	spki, err := MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(spki)
+	ts := uint64(timestamp.UnixMilli())
+
+	var signed cryptobyte.Builder
+	signed.AddUint8(0) // v1
+	signed.AddUint8(0) // certificate_timestamp
+	signed.AddUint64(ts)
+	signed.AddUint16(entryType)
+	signed.AddBytes(entry)
+	signed.AddUint16(0) // extensions
+	digest := sha256.Sum256(signed.BytesOrPanic())
+	sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashAndSig := []byte{4, 3}
+	if _, ok := key.Public().(*ecdsa.PublicKey); !ok {
+		hashAndSig = []byte{4, 1}
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(0)
+	b.AddBytes(logID[:])
+	b.AddUint64(ts)
+	b.AddUint16(0)
+	b.AddBytes(hashAndSig)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(sig)
+	})
+	return b.BytesOrPanic()
+}
+
+// ctTestX509Entry returns the encoding of an x509_entry for cert.
+func ctTestX509Entry(cert *Certificate) []byte {
+	var b cryptobyte.Builder
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(cert.Raw)
+	})
+	return b.BytesOrPanic()
+}
+
+// ctTestPrecertEntry returns the encoding of a precert_entry for a
+// precertificate with the given TBSCertificate, issued by issuer.
+func ctTestPrecertEntry(tbs []byte, issuer *Certificate) []byte {
+	keyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	var b cryptobyte.Builder
+	b.AddBytes(keyHash[:])
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(tbs)
+	})
+	return b.BytesOrPanic()
+}
+
+// ctTestSCTList returns the value of an SCT list extension.
+func ctTestSCTList(scts ...[]byte) []byte {
+	var b cryptobyte.Builder
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, sct := range scts {
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddBytes(sct)
+			})
+		}
+	})
+	v, err := asn1.Marshal(b.BytesOrPanic())
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ctTestIssueWithSCTs issues a certificate from template, with SCTs embedded
+// by the logs with the given keys. It returns the certificate and the
+// TBSCertificate of its precertificate.
+func ctTestIssueWithSCTs(t *testing.T, template, issuer *Certificate, issuerKey crypto.Signer, timestamp time.Time, logKeys ...crypto.Signer) (*Certificate, []byte) {
+	t.Helper()
+	key := ctTestKey(t)
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		template.NotAfter = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	// ExtraExtensions are encoded last, so the precertificate has the same
+	// TBSCertificate as the final certificate without the SCT list extension.
+	der, err := CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	precert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scts [][]byte
+	for _, logKey := range logKeys {
+		scts = append(scts, ctTestSCT(t, logKey, sctPrecertEntry, ctTestPrecertEntry(precert.RawTBSCertificate, issuer), timestamp))
+	}
+	template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: oidExtensionSCTList, Value: ctTestSCTList(scts...)})
+	der, err = CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, precert.RawTBSCertificate
+}
+
+func TestVerifySCT(t *testing.T) {
+	ca, caKey := ocspTestCA(t, "CT CA")
+	otherCA, _ := ocspTestCA(t, "Other CA")
+	logKey, otherLogKey := ctTestKey(t), ctTestKey(t)
+	log := &CTLog{Description: "test log", PublicKey: logKey.Public()}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	leaf, precertTBS := ctTestIssueWithSCTs(t, &Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		DNSNames:     []string{"example.com"},
+	}, ca, caKey, now, logKey)
+
+	tbs, err := tbsWithoutSCTs(leaf.RawTBSCertificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tbs, precertTBS) {
+		t.Errorf("tbsWithoutSCTs didn't return the precertificate TBSCertificate")
+	}
+
+	scts, err := leaf.SignedCertificateTimestamps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scts) != 1 {
+		t.Fatalf("got %d SCTs, want 1", len(scts))
+	}
+	embedded := scts[0]
+	if id, _ := log.ID(); embedded.LogID != id || !embedded.Timestamp.Equal(now) {
+		t.Errorf("embedded SCT has LogID %x, Timestamp %v", embedded.LogID, embedded.Timestamp)
+	}
+	if err := log.VerifyEmbeddedSCT(embedded, leaf, ca); err != nil {
+		t.Errorf("VerifyEmbeddedSCT: %v", err)
+	}
+	if err := log.VerifyEmbeddedSCT(embedded, leaf, otherCA); err == nil {
+		t.Errorf("VerifyEmbeddedSCT succeeded with the wrong issuer")
+	}
+	if err := log.VerifySCT(embedded, leaf); err == nil {
+		t.Errorf("VerifySCT succeeded with an embedded SCT")
+	}
+	other := &CTLog{PublicKey: otherLogKey.Public()}
+	if err := other.VerifyEmbeddedSCT(embedded, leaf, ca); err == nil {
+		t.Errorf("VerifyEmbeddedSCT succeeded with the wrong log")
+	}
+
+	sct, err := ParseSignedCertificateTimestamp(ctTestSCT(t, logKey, sctX509Entry, ctTestX509Entry(leaf), now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := log.VerifySCT(sct, leaf); err != nil {
+		t.Errorf("VerifySCT: %v", err)
+	}
+	if err := log.VerifySCT(sct, ca); err == nil {
+		t.Errorf("VerifySCT succeeded with the wrong certificate")
+	}
+	tampered := *sct
+	tampered.Timestamp = tampered.Timestamp.Add(time.Millisecond)
+	if err := log.VerifySCT(&tampered, leaf); err == nil {
+		t.Errorf("VerifySCT succeeded with a modified timestamp")
+	}
+	tampered = *sct
+	tampered.SignatureAlgorithm = UnknownSignatureAlgorithm
+	if err := log.VerifySCT(&tampered, leaf); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("VerifySCT with an unknown algorithm: got error %v, want ErrUnsupportedAlgorithm", err)
+	}
+
+	rsaLog := &CTLog{PublicKey: &testPrivateKey.PublicKey}
+	sct, err = ParseSignedCertificateTimestamp(ctTestSCT(t, testPrivateKey, sctX509Entry, ctTestX509Entry(leaf), now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sct.SignatureAlgorithm != SHA256WithRSA {
+		t.Errorf("RSA SCT has SignatureAlgorithm %v", sct.SignatureAlgorithm)
+	}
+	if err := rsaLog.VerifySCT(sct, leaf); err != nil {
+		t.Errorf("VerifySCT with an RSA log: %v", err)
+	}
+}
+
+func TestVerifyMinValidSCTs(t *testing.T) {
+	ca, caKey := ocspTestCA(t, "CT CA")
+	roots := NewCertPool()
+	roots.AddCert(ca)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var logKeys []*ecdsa.PrivateKey
+	var logs []*CTLog
+	for i := 0; i < 4; i++ {
+		key := ctTestKey(t)
+		logKeys = append(logKeys, key)
+		logs = append(logs, &CTLog{PublicKey: key.Public()})
+	}
+	unknownLog := ctTestKey(t)
+
+	// The leaf embeds SCTs from logs 0 and 1, and from an unknown log.
+	leaf, _ := ctTestIssueWithSCTs(t, &Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		DNSNames:     []string{"example.com"},
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageServerAuth},
+	}, ca, caKey, now.Add(-time.Hour), logKeys[0], logKeys[1], unknownLog)
+
+	tlsSCT := func(key crypto.Signer, timestamp time.Time) []byte {
+		return ctTestSCT(t, key, sctX509Entry, ctTestX509Entry(leaf), timestamp)
+	}
+	staple := func(scts ...[]byte) []byte {
+		der, err := CreateOCSPResponse(rand.Reader, &OCSPResponse{
+			Status:       OCSPGood,
+			SerialNumber: leaf.SerialNumber,
+			ThisUpdate:   now.Add(-time.Hour),
+			NextUpdate:   now.Add(time.Hour),
+			ExtraSingleExtensions: []pkix.Extension{
+				{Id: oidExtensionOCSPSCTList, Value: ctTestSCTList(scts...)},
+			},
+		}, ca, nil, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+
+	for _, tt := range []struct {
+		name   string
+		logs   []*CTLog
+		tls    [][]byte
+		staple []byte
+		min    int
+		ok     bool
+	}{
+		{"no policy", nil, nil, nil, 0, true},
+		{"embedded", logs, nil, nil, 2, true},
+		{"embedded insufficient", logs, nil, nil, 3, false},
+		{"embedded unknown logs", logs[2:], nil, nil, 1, false},
+		{"tls", logs, [][]byte{tlsSCT(logKeys[2], now)}, nil, 3, true},
+		{"tls same log", logs, [][]byte{tlsSCT(logKeys[0], now)}, nil, 3, false},
+		{"tls future", logs, [][]byte{tlsSCT(logKeys[2], now.Add(time.Minute))}, nil, 3, false},
+		{"tls wrong entry", logs, [][]byte{ctTestSCT(t, logKeys[2], sctX509Entry, ctTestX509Entry(ca), now)}, nil, 3, false},
+		{"tls garbage", logs, [][]byte{[]byte("garbage")}, nil, 2, true},
+		{"ocsp", logs, nil, staple(tlsSCT(logKeys[3], now)), 3, true},
+		{"all sources", logs, [][]byte{tlsSCT(logKeys[2], now)}, staple(tlsSCT(logKeys[3], now)), 4, true},
+		{"all sources insufficient", logs, [][]byte{tlsSCT(logKeys[2], now)}, staple(tlsSCT(logKeys[3], now)), 5, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			chains, err := leaf.Verify(VerifyOptions{
+				Roots:                       roots,
+				CurrentTime:                 now,
+				DNSName:                     "example.com",
+				CTLogs:                      tt.logs,
+				SignedCertificateTimestamps: tt.tls,
+				OCSPStaple:                  tt.staple,
+				MinValidSCTs:                tt.min,
+			})
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("Verify failed: %v", err)
+				}
+				if len(chains) != 1 {
+					t.Fatalf("got %d chains, want 1", len(chains))
+				}
+				return
+			}
+			var invalidErr CertificateInvalidError
+			if !errors.As(err, &invalidErr) || invalidErr.Reason != InsufficientSCTs || invalidErr.Cert != leaf {
+				t.Fatalf("Verify error = %v, want CertificateInvalidError with reason InsufficientSCTs", err)
+			}
+		})
+	}
+
+	// A trusted root is not checked.
+	if _, err := ca.Verify(VerifyOptions{Roots: roots, CurrentTime: now, MinValidSCTs: 1}); err != nil {
+		t.Errorf("Verify of a root with MinValidSCTs failed: %v", err)
+	}
+}
@@ -0,0 +1,886 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// This file implements the Online Certificate Status Protocol (OCSP), as
+// specified in RFC 6960, and the lightweight profile of RFC 5019.
+
+var (
+	oidOCSPBasicResponse = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+	oidSHA1              = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+)
+
+var ocspHashOIDs = []struct {
+	hash crypto.Hash
+	oid  asn1.ObjectIdentifier
+}{
+	{crypto.SHA1, oidSHA1},
+	{crypto.SHA256, oidSHA256},
+	{crypto.SHA384, oidSHA384},
+	{crypto.SHA512, oidSHA512},
+}
+
+func ocspHashFromOID(oid asn1.ObjectIdentifier) crypto.Hash {
+	for _, h := range ocspHashOIDs {
+		if h.oid.Equal(oid) {
+			return h.hash
+		}
+	}
+	return 0
+}
+
+func ocspOIDFromHash(hash crypto.Hash) (asn1.ObjectIdentifier, bool) {
+	for _, h := range ocspHashOIDs {
+		if h.hash == hash {
+			return h.oid, true
+		}
+	}
+	return nil, false
+}
+
+// OCSPStatus is the revocation status of a certificate, as reported in an
+// OCSP response.
+type OCSPStatus int
+
+const (
+	// OCSPGood indicates that the certificate is not revoked.
+	OCSPGood OCSPStatus = iota
+	// OCSPRevoked indicates that the certificate has been revoked.
+	OCSPRevoked
+	// OCSPUnknown indicates that the responder doesn't know about the
+	// certificate.
+	OCSPUnknown
+)
+
+func (s OCSPStatus) String() string {
+	switch s {
+	case OCSPGood:
+		return "good"
+	case OCSPRevoked:
+		return "revoked"
+	case OCSPUnknown:
+		return "unknown"
+	}
+	return "OCSPStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// OCSPResponseStatus is the status of an OCSP response as a whole. Only
+// responses with status [OCSPSuccess] carry certificate status information.
+type OCSPResponseStatus int
+
+const (
+	OCSPSuccess           OCSPResponseStatus = 0
+	OCSPMalformedRequest  OCSPResponseStatus = 1
+	OCSPInternalError     OCSPResponseStatus = 2
+	OCSPTryLater          OCSPResponseStatus = 3
+	OCSPSignatureRequired OCSPResponseStatus = 5
+	OCSPUnauthorized      OCSPResponseStatus = 6
+)
+
+func (s OCSPResponseStatus) String() string {
+	switch s {
+	case OCSPSuccess:
+		return "success"
+	case OCSPMalformedRequest:
+		return "malformed request"
+	case OCSPInternalError:
+		return "internal error"
+	case OCSPTryLater:
+		return "try later"
+	case OCSPSignatureRequired:
+		return "signature required"
+	case OCSPUnauthorized:
+		return "unauthorized"
+	}
+	return "OCSPResponseStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// OCSPResponseError is returned by [ParseOCSPResponse] and
+// [ParseOCSPResponseForCert] when the responder reported an error instead of
+// certificate status information.
+type OCSPResponseError struct {
+	Status OCSPResponseStatus
+}
+
+func (e OCSPResponseError) Error() string {
+	return "x509: OCSP responder returned an error: " + e.Status.String()
+}
+
+// OCSPRequest represents a single-certificate OCSP request.
+type OCSPRequest struct {
+	Raw []byte // Complete ASN.1 DER content.
+
+	// HashAlgorithm is the hash used to compute IssuerNameHash and
+	// IssuerKeyHash.
+	HashAlgorithm crypto.Hash
+	// IssuerNameHash is the hash of the DER-encoded issuer name.
+	IssuerNameHash []byte
+	// IssuerKeyHash is the hash of the issuer's public key, excluding the
+	// tag, length and number of unused bits of the BIT STRING.
+	IssuerKeyHash []byte
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int
+}
+
+// certID reflects the CertID structure from RFC 6960, Section 4.1.1.
+type certID struct {
+	HashAlgorithm  pkix.AlgorithmIdentifier
+	IssuerNameHash []byte
+	IssuerKeyHash  []byte
+	SerialNumber   *big.Int
+}
+
+func newCertID(cert, issuer *Certificate, hash crypto.Hash) (certID, error) {
+	if hash == 0 {
+		hash = crypto.SHA1
+	}
+	oid, ok := ocspOIDFromHash(hash)
+	if !ok || !hash.Available() {
+		return certID{}, ErrUnsupportedAlgorithm
+	}
+	nameHash, keyHash, err := issuerHashes(issuer, hash)
+	if err != nil {
+		return certID{}, err
+	}
+	return certID{
+		HashAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oid,
+			Parameters: asn1.NullRawValue,
+		},
+		IssuerNameHash: nameHash,
+		IssuerKeyHash:  keyHash,
+		SerialNumber:   cert.SerialNumber,
+	}, nil
+}
+
+// issuerHashes returns the hashes of the issuer's name and public key, as
+// used to identify it in OCSP requests and responses.
+func issuerHashes(issuer *Certificate, hash crypto.Hash) (nameHash, keyHash []byte, err error) {
+	keyBytes, err := subjectPublicKeyBytes(issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+	h := hash.New()
+	h.Write(issuer.RawSubject)
+	nameHash = h.Sum(nil)
+	h.Reset()
+	h.Write(keyBytes)
+	keyHash = h.Sum(nil)
+	return nameHash, keyHash, nil
+}
+
+// subjectPublicKeyBytes returns the contents of the subjectPublicKey BIT
+// STRING of cert.
+func subjectPublicKeyBytes(cert *Certificate) ([]byte, error) {
+	spki := cryptobyte.String(cert.RawSubjectPublicKeyInfo)
+	var key asn1.BitString
+	if !spki.ReadASN1(&spki, cryptobyte_asn1.SEQUENCE) ||
+		!spki.SkipASN1(cryptobyte_asn1.SEQUENCE) ||
+		!spki.ReadASN1BitString(&key) {
+		return nil, errors.New("x509: malformed subject public key info")
+	}
+	return key.RightAlign(), nil
+}
+
+func parseCertID(der cryptobyte.String) (certID, error) {
+	var id certID
+	var ai cryptobyte.String
+	if !der.ReadASN1(&ai, cryptobyte_asn1.SEQUENCE) {
+		return id, errors.New("x509: malformed OCSP CertID hash algorithm")
+	}
+	var err error
+	if id.HashAlgorithm, err = parseAI(ai); err != nil {
+		return id, err
+	}
+	id.SerialNumber = new(big.Int)
+	if !der.ReadASN1Bytes(&id.IssuerNameHash, cryptobyte_asn1.OCTET_STRING) ||
+		!der.ReadASN1Bytes(&id.IssuerKeyHash, cryptobyte_asn1.OCTET_STRING) ||
+		!der.ReadASN1Integer(id.SerialNumber) || !der.Empty() {
+		return id, errors.New("x509: malformed OCSP CertID")
+	}
+	return id, nil
+}
+
+// matches reports whether id identifies cert, issued by issuer.
+func (id *certID) matches(cert, issuer *Certificate) bool {
+	if cert.SerialNumber == nil || id.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		return false
+	}
+	hash := ocspHashFromOID(id.HashAlgorithm.Algorithm)
+	if hash == 0 || !hash.Available() {
+		return false
+	}
+	nameHash, keyHash, err := issuerHashes(issuer, hash)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(nameHash, id.IssuerNameHash) && bytes.Equal(keyHash, id.IssuerKeyHash)
+}
+
+// CreateOCSPRequest returns a DER-encoded OCSP request for the status of cert,
+// which must have been issued by issuer.
+//
+// hash is used to identify the issuer in the request. If zero, SHA-1 is used,
+// as required by RFC 5019 and expected by most responders.
+func CreateOCSPRequest(cert, issuer *Certificate, hash crypto.Hash) ([]byte, error) {
+	if cert == nil || issuer == nil {
+		return nil, errors.New("x509: cert and issuer can not be nil")
+	}
+	if cert.SerialNumber == nil {
+		return nil, errors.New("x509: cert has a nil SerialNumber")
+	}
+	id, err := newCertID(cert, issuer, hash)
+	if err != nil {
+		return nil, err
+	}
+	idBytes, err := asn1.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var b cryptobyte.Builder
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // OCSPRequest
+		b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // TBSRequest
+			b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // requestList
+				b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // Request
+					b.AddBytes(idBytes)
+				})
+			})
+		})
+	})
+	return b.Bytes()
+}
+
+// ParseOCSPRequest parses a DER-encoded OCSP request. Only requests for the
+// status of a single certificate are supported. Signed requests are accepted,
+// but their signature is ignored.
+func ParseOCSPRequest(der []byte) (*OCSPRequest, error) {
+	input := cryptobyte.String(der)
+	var req cryptobyte.String
+	if !input.ReadASN1Element(&req, cryptobyte_asn1.SEQUENCE) || !input.Empty() {
+		return nil, errors.New("x509: malformed OCSP request")
+	}
+	out := &OCSPRequest{Raw: req}
+
+	var tbs, list, single, id cryptobyte.String
+	if !req.ReadASN1(&req, cryptobyte_asn1.SEQUENCE) ||
+		!req.ReadASN1(&tbs, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP request")
+	}
+	var version int64
+	if !tbs.ReadOptionalASN1Integer(&version, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), int64(0)) {
+		return nil, errors.New("x509: malformed OCSP request version")
+	}
+	if version != 0 {
+		return nil, fmt.Errorf("x509: unsupported OCSP request version: %d", version)
+	}
+	if !tbs.SkipOptionalASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) ||
+		!tbs.ReadASN1(&list, cryptobyte_asn1.SEQUENCE) ||
+		!list.ReadASN1(&single, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP request")
+	}
+	if !list.Empty() {
+		return nil, errors.New("x509: OCSP requests for multiple certificates are not supported")
+	}
+	if !single.ReadASN1(&id, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP request")
+	}
+	certID, err := parseCertID(id)
+	if err != nil {
+		return nil, err
+	}
+	out.HashAlgorithm = ocspHashFromOID(certID.HashAlgorithm.Algorithm)
+	if out.HashAlgorithm == 0 {
+		return nil, errors.New("x509: unsupported OCSP request hash algorithm")
+	}
+	out.IssuerNameHash = certID.IssuerNameHash
+	out.IssuerKeyHash = certID.IssuerKeyHash
+	out.SerialNumber = certID.SerialNumber
+	return out, nil
+}
+
+// OCSPResponse represents the status of a single certificate in an OCSP
+// response.
+type OCSPResponse struct {
+	Raw                []byte // Complete ASN.1 DER content.
+	RawTBSResponseData []byte // Signed part of the response.
+
+	// Status is the revocation status of the certificate.
+	Status OCSPStatus
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int
+	// IssuerHash is the hash used to identify the certificate's issuer. When
+	// creating a response, zero means SHA-1.
+	IssuerHash crypto.Hash
+
+	// ProducedAt is the time at which the responder signed the response.
+	// When creating a response, zero means the current time.
+	ProducedAt time.Time
+	// ThisUpdate is the time at which the status is known to be correct.
+	ThisUpdate time.Time
+	// NextUpdate is the time at or before which newer information will be
+	// available. It is zero if the responder didn't set it.
+	NextUpdate time.Time
+
+	// RevokedAt and RevocationReason are set if Status is OCSPRevoked.
+	// RevocationReason is one of the CRLReason values of RFC 5280, Section
+	// 5.3.1.
+	RevokedAt        time.Time
+	RevocationReason int
+
+	// RawResponderName and ResponderKeyHash identify the responder. Only one
+	// of them is set.
+	RawResponderName []byte
+	ResponderKeyHash []byte
+
+	// Certificate is the delegated responder certificate included in the
+	// response, if any. It must be signed by the issuer of the certificate
+	// the response is for.
+	Certificate *Certificate
+
+	Signature          []byte
+	SignatureAlgorithm SignatureAlgorithm
+
+	// Extensions contains the raw responseExtensions of the response, and
+	// SingleExtensions the raw singleExtensions of the certificate status.
+	// When creating a response, both are ignored, see ExtraExtensions and
+	// ExtraSingleExtensions.
+	Extensions       []pkix.Extension
+	SingleExtensions []pkix.Extension
+
+	// ExtraExtensions contains extensions to be copied, raw, into the
+	// responseExtensions of a created response.
+	ExtraExtensions []pkix.Extension
+
+	// ExtraSingleExtensions contains extensions to be copied, raw, into the
+	// singleExtensions of a created response.
+	ExtraSingleExtensions []pkix.Extension
+}
+
+// ParseOCSPResponse parses a DER-encoded OCSP response. The response must
+// contain the status of exactly one certificate; use
+// [ParseOCSPResponseForCert] to pick one from a response for multiple
+// certificates.
+//
+// If the responder returned an error, it returns an [OCSPResponseError].
+//
+// The signature on the response is not checked. Use
+// [OCSPResponse.CheckSignatureFrom] to verify it.
+func ParseOCSPResponse(der []byte) (*OCSPResponse, error) {
+	return parseOCSPResponse(der, nil, nil)
+}
+
+// ParseOCSPResponseForCert parses a DER-encoded OCSP response, and returns
+// the status of cert, issued by issuer. It also checks the signature on the
+// response with [OCSPResponse.CheckSignatureFrom].
+//
+// It doesn't check that the response is current: callers should compare
+// ThisUpdate and NextUpdate with the current time.
+func ParseOCSPResponseForCert(der []byte, cert, issuer *Certificate) (*OCSPResponse, error) {
+	if cert == nil || issuer == nil {
+		return nil, errors.New("x509: cert and issuer can not be nil")
+	}
+	resp, err := parseOCSPResponse(der, cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.CheckSignatureFrom(issuer); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func parseOCSPResponse(der []byte, cert, issuer *Certificate) (*OCSPResponse, error) {
+	input := cryptobyte.String(der)
+	var outer cryptobyte.String
+	if !input.ReadASN1Element(&outer, cryptobyte_asn1.SEQUENCE) || !input.Empty() {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	raw := outer
+	var status int
+	if !outer.ReadASN1(&outer, cryptobyte_asn1.SEQUENCE) ||
+		!outer.ReadASN1Enum(&status) {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	if OCSPResponseStatus(status) != OCSPSuccess {
+		return nil, OCSPResponseError{OCSPResponseStatus(status)}
+	}
+
+	var responseBytes, basic cryptobyte.String
+	var responseType asn1.ObjectIdentifier
+	if !outer.ReadASN1(&responseBytes, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) ||
+		!responseBytes.ReadASN1(&responseBytes, cryptobyte_asn1.SEQUENCE) ||
+		!responseBytes.ReadASN1ObjectIdentifier(&responseType) ||
+		!responseBytes.ReadASN1(&basic, cryptobyte_asn1.OCTET_STRING) {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	if !responseType.Equal(oidOCSPBasicResponse) {
+		return nil, errors.New("x509: unsupported OCSP response type")
+	}
+
+	resp := &OCSPResponse{Raw: raw}
+
+	// BasicOCSPResponse, RFC 6960, Section 4.2.1.
+	var tbs, sigAISeq cryptobyte.String
+	if !basic.ReadASN1(&basic, cryptobyte_asn1.SEQUENCE) ||
+		!basic.ReadASN1Element(&tbs, cryptobyte_asn1.SEQUENCE) ||
+		!basic.ReadASN1(&sigAISeq, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP response")
+	}
+	resp.RawTBSResponseData = tbs
+	sigAI, err := parseAI(sigAISeq)
+	if err != nil {
+		return nil, err
+	}
+	resp.SignatureAlgorithm = getSignatureAlgorithmFromAI(sigAI)
+	var signature asn1.BitString
+	if !basic.ReadASN1BitString(&signature) {
+		return nil, errors.New("x509: malformed OCSP response signature")
+	}
+	resp.Signature = signature.RightAlign()
+	var certs cryptobyte.String
+	var hasCerts bool
+	if !basic.ReadOptionalASN1(&certs, &hasCerts, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) ||
+		(hasCerts && !certs.ReadASN1(&certs, cryptobyte_asn1.SEQUENCE)) {
+		return nil, errors.New("x509: malformed OCSP response certificates")
+	}
+	if hasCerts && !certs.Empty() {
+		var certDER cryptobyte.String
+		if !certs.ReadASN1Element(&certDER, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP response certificates")
+		}
+		resp.Certificate, err = ParseCertificate(certDER)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// ResponseData, RFC 6960, Section 4.2.1.
+	if !tbs.ReadASN1(&tbs, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP response data")
+	}
+	var version int64
+	if !tbs.ReadOptionalASN1Integer(&version, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), int64(0)) {
+		return nil, errors.New("x509: malformed OCSP response version")
+	}
+	if version != 0 {
+		return nil, fmt.Errorf("x509: unsupported OCSP response version: %d", version)
+	}
+	var responderID cryptobyte.String
+	switch {
+	case tbs.PeekASN1Tag(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()):
+		if !tbs.ReadASN1(&responderID, cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) ||
+			!responderID.ReadASN1Element((*cryptobyte.String)(&resp.RawResponderName), cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP responder ID")
+		}
+	case tbs.PeekASN1Tag(cryptobyte_asn1.Tag(2).Constructed().ContextSpecific()):
+		if !tbs.ReadASN1(&responderID, cryptobyte_asn1.Tag(2).Constructed().ContextSpecific()) ||
+			!responderID.ReadASN1Bytes(&resp.ResponderKeyHash, cryptobyte_asn1.OCTET_STRING) {
+			return nil, errors.New("x509: malformed OCSP responder ID")
+		}
+	default:
+		return nil, errors.New("x509: malformed OCSP responder ID")
+	}
+	if !tbs.ReadASN1GeneralizedTime(&resp.ProducedAt) {
+		return nil, errors.New("x509: malformed OCSP response producedAt")
+	}
+	var responses cryptobyte.String
+	if !tbs.ReadASN1(&responses, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP responses")
+	}
+	var extensions cryptobyte.String
+	var present bool
+	if !tbs.ReadOptionalASN1(&extensions, &present, cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) {
+		return nil, errors.New("x509: malformed OCSP response extensions")
+	}
+	if present {
+		if resp.Extensions, err = parseOCSPExtensions(extensions); err != nil {
+			return nil, err
+		}
+	}
+
+	var single cryptobyte.String
+	found := false
+	for !responses.Empty() {
+		if !responses.ReadASN1(&single, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP single response")
+		}
+		if cert == nil {
+			if found {
+				return nil, errors.New("x509: OCSP response contains multiple certificate statuses")
+			}
+			found = true
+			if err := parseOCSPSingleResponse(single, resp); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var id cryptobyte.String
+		if !single.ReadASN1(&id, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP single response")
+		}
+		certID, err := parseCertID(id)
+		if err != nil {
+			return nil, err
+		}
+		if certID.matches(cert, issuer) {
+			if err := parseOCSPSingleResponse(single, resp); err != nil {
+				return nil, err
+			}
+			resp.SerialNumber = certID.SerialNumber
+			resp.IssuerHash = ocspHashFromOID(certID.HashAlgorithm.Algorithm)
+			found = true
+			break
+		}
+	}
+	if !found {
+		if cert == nil {
+			return nil, errors.New("x509: OCSP response contains no certificate status")
+		}
+		return nil, errors.New("x509: OCSP response does not contain the status of the certificate")
+	}
+	return resp, nil
+}
+
+// parseOCSPSingleResponse parses a SingleResponse into resp. If single still
+// starts with the CertID, it's parsed as well.
+func parseOCSPSingleResponse(single cryptobyte.String, resp *OCSPResponse) error {
+	if single.PeekASN1Tag(cryptobyte_asn1.SEQUENCE) {
+		var id cryptobyte.String
+		if !single.ReadASN1(&id, cryptobyte_asn1.SEQUENCE) {
+			return errors.New("x509: malformed OCSP single response")
+		}
+		certID, err := parseCertID(id)
+		if err != nil {
+			return err
+		}
+		resp.SerialNumber = certID.SerialNumber
+		resp.IssuerHash = ocspHashFromOID(certID.HashAlgorithm.Algorithm)
+	}
+
+	var status cryptobyte.String
+	var tag cryptobyte_asn1.Tag
+	if !single.ReadAnyASN1(&status, &tag) {
+		return errors.New("x509: malformed OCSP certificate status")
+	}
+	switch tag {
+	case cryptobyte_asn1.Tag(0).ContextSpecific():
+		resp.Status = OCSPGood
+	case cryptobyte_asn1.Tag(1).Constructed().ContextSpecific():
+		resp.Status = OCSPRevoked
+		if !status.ReadASN1GeneralizedTime(&resp.RevokedAt) {
+			return errors.New("x509: malformed OCSP revocation time")
+		}
+		var reason cryptobyte.String
+		var present bool
+		if !status.ReadOptionalASN1(&reason, &present, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) ||
+			(present && !reason.ReadASN1Enum(&resp.RevocationReason)) {
+			return errors.New("x509: malformed OCSP revocation reason")
+		}
+	case cryptobyte_asn1.Tag(2).ContextSpecific():
+		resp.Status = OCSPUnknown
+	default:
+		return errors.New("x509: malformed OCSP certificate status")
+	}
+
+	if !single.ReadASN1GeneralizedTime(&resp.ThisUpdate) {
+		return errors.New("x509: malformed OCSP thisUpdate")
+	}
+	var nextUpdate cryptobyte.String
+	var present bool
+	if !single.ReadOptionalASN1(&nextUpdate, &present, cryptobyte_asn1.Tag(0).Constructed().ContextSpecific()) ||
+		(present && !nextUpdate.ReadASN1GeneralizedTime(&resp.NextUpdate)) {
+		return errors.New("x509: malformed OCSP nextUpdate")
+	}
+	var extensions cryptobyte.String
+	if !single.ReadOptionalASN1(&extensions, &present, cryptobyte_asn1.Tag(1).Constructed().ContextSpecific()) {
+		return errors.New("x509: malformed OCSP single extensions")
+	}
+	if present {
+		var err error
+		if resp.SingleExtensions, err = parseOCSPExtensions(extensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseOCSPExtensions(der cryptobyte.String) ([]pkix.Extension, error) {
+	if !der.ReadASN1(&der, cryptobyte_asn1.SEQUENCE) {
+		return nil, errors.New("x509: malformed OCSP extensions")
+	}
+	var exts []pkix.Extension
+	for !der.Empty() {
+		var extension cryptobyte.String
+		if !der.ReadASN1(&extension, cryptobyte_asn1.SEQUENCE) {
+			return nil, errors.New("x509: malformed OCSP extension")
+		}
+		ext, err := parseExtension(extension)
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+	return exts, nil
+}
+
+// CheckSignatureFrom verifies that the signature on resp is a valid signature
+// from issuer, the issuer of the certificate the response is for.
+//
+// If the response includes a delegated responder certificate, that
+// certificate must be signed by issuer and have the OCSP signing extended key
+// usage, and the response must be signed by it. The validity period of the
+// responder certificate is not checked.
+func (resp *OCSPResponse) CheckSignatureFrom(issuer *Certificate) error {
+	signer := issuer
+	if resp.Certificate != nil && !bytes.Equal(resp.Certificate.Raw, issuer.Raw) {
+		if err := resp.Certificate.CheckSignatureFrom(issuer); err != nil {
+			return fmt.Errorf("x509: OCSP responder certificate is not signed by the issuer: %w", err)
+		}
+		authorized := false
+		for _, eku := range resp.Certificate.ExtKeyUsage {
+			if eku == ExtKeyUsageOCSPSigning {
+				authorized = true
+				break
+			}
+		}
+		if !authorized {
+			return errors.New("x509: OCSP responder certificate is not authorized for OCSP signing")
+		}
+		signer = resp.Certificate
+	}
+	if signer.PublicKeyAlgorithm == UnknownPublicKeyAlgorithm {
+		return ErrUnsupportedAlgorithm
+	}
+	return signer.CheckSignature(resp.SignatureAlgorithm, resp.RawTBSResponseData, resp.Signature)
+}
+
+// CreateOCSPResponse creates a DER-encoded OCSP response for the status of a
+// certificate issued by issuer, based on template. The following members of
+// template are used:
+//
+//   - ExtraExtensions
+//   - ExtraSingleExtensions
+//   - IssuerHash
+//   - NextUpdate
+//   - ProducedAt
+//   - RevocationReason
+//   - RevokedAt
+//   - SerialNumber
+//   - SignatureAlgorithm
+//   - Status
+//   - ThisUpdate
+//
+// The response is signed by priv. If responder is nil, priv must be the
+// private key of issuer. Otherwise, responder is a delegated responder
+// certificate, signed by issuer, which is included in the response and whose
+// private key is priv.
+func CreateOCSPResponse(rand io.Reader, template *OCSPResponse, issuer, responder *Certificate, priv crypto.Signer) ([]byte, error) {
+	if template == nil {
+		return nil, errors.New("x509: template can not be nil")
+	}
+	if issuer == nil {
+		return nil, errors.New("x509: issuer can not be nil")
+	}
+	if template.SerialNumber == nil {
+		return nil, errors.New("x509: template contains nil SerialNumber field")
+	}
+	if !template.NextUpdate.IsZero() && template.NextUpdate.Before(template.ThisUpdate) {
+		return nil, errors.New("x509: template.ThisUpdate is after template.NextUpdate")
+	}
+
+	signatureAlgorithm, algorithmIdentifier, err := signingParamsForKey(priv, template.SignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newCertID(&Certificate{SerialNumber: template.SerialNumber}, issuer, template.IssuerHash)
+	if err != nil {
+		return nil, err
+	}
+	idBytes, err := asn1.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := issuer
+	if responder != nil {
+		signer = responder
+	}
+	signerKey, err := subjectPublicKeyBytes(signer)
+	if err != nil {
+		return nil, err
+	}
+	responderKeyHash := sha1.Sum(signerKey)
+
+	producedAt := template.ProducedAt
+	if producedAt.IsZero() {
+		producedAt = time.Now()
+	}
+
+	var b cryptobyte.Builder
+	b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // ResponseData
+		b.AddASN1(cryptobyte_asn1.Tag(2).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+			b.AddASN1OctetString(responderKeyHash[:])
+		})
+		b.AddASN1GeneralizedTime(producedAt.UTC().Truncate(time.Second))
+		b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // responses
+			b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // SingleResponse
+				b.AddBytes(idBytes)
+				switch template.Status {
+				case OCSPGood:
+					b.AddASN1(cryptobyte_asn1.Tag(0).ContextSpecific(), func(b *cryptobyte.Builder) {})
+				case OCSPRevoked:
+					if template.RevokedAt.IsZero() {
+						b.SetError(errors.New("x509: template contains zero RevokedAt field"))
+						return
+					}
+					b.AddASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+						b.AddASN1GeneralizedTime(template.RevokedAt.UTC().Truncate(time.Second))
+						if template.RevocationReason != 0 {
+							b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+								b.AddASN1Enum(int64(template.RevocationReason))
+							})
+						}
+					})
+				case OCSPUnknown:
+					b.AddASN1(cryptobyte_asn1.Tag(2).ContextSpecific(), func(b *cryptobyte.Builder) {})
+				default:
+					b.SetError(errors.New("x509: template contains invalid Status field"))
+					return
+				}
+				b.AddASN1GeneralizedTime(template.ThisUpdate.UTC().Truncate(time.Second))
+				if !template.NextUpdate.IsZero() {
+					b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+						b.AddASN1GeneralizedTime(template.NextUpdate.UTC().Truncate(time.Second))
+					})
+				}
+				if len(template.ExtraSingleExtensions) > 0 {
+					exts, err := asn1.Marshal(template.ExtraSingleExtensions)
+					if err != nil {
+						b.SetError(err)
+						return
+					}
+					b.AddASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+						b.AddBytes(exts)
+					})
+				}
+			})
+		})
+		if len(template.ExtraExtensions) > 0 {
+			exts, err := asn1.Marshal(template.ExtraExtensions)
+			if err != nil {
+				b.SetError(err)
+				return
+			}
+			b.AddASN1(cryptobyte_asn1.Tag(1).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+				b.AddBytes(exts)
+			})
+		}
+	})
+	tbs, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signTBS(tbs, priv, signatureAlgorithm, rand)
+	if err != nil {
+		return nil, err
+	}
+	aiBytes, err := asn1.Marshal(algorithmIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var basic cryptobyte.Builder
+	basic.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // BasicOCSPResponse
+		b.AddBytes(tbs)
+		b.AddBytes(aiBytes)
+		b.AddASN1BitString(signature)
+		if responder != nil {
+			b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+				b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					b.AddBytes(responder.Raw)
+				})
+			})
+		}
+	})
+	basicBytes, err := basic.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var out cryptobyte.Builder
+	out.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // OCSPResponse
+		b.AddASN1Enum(int64(OCSPSuccess))
+		b.AddASN1(cryptobyte_asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+			b.AddASN1(cryptobyte_asn1.SEQUENCE, func(b *cryptobyte.Builder) { // ResponseBytes
+				b.AddASN1ObjectIdentifier(oidOCSPBasicResponse)
+				b.AddASN1OctetString(basicBytes)
+			})
+		})
+	})
+	return out.Bytes()
+}
+
+// isCurrent reports whether the status in resp is valid at now.
+func (resp *OCSPResponse) isCurrent(now time.Time) bool {
+	if now.Before(resp.ThisUpdate) {
+		return false
+	}
+	return resp.NextUpdate.IsZero() || !now.After(resp.NextUpdate)
+}
+
+// checkOCSPStaple checks the stapled OCSP response for the leaf of chain,
+// according to opts. It returns nil if the chain is acceptable.
+func checkOCSPStaple(chain []*Certificate, opts *VerifyOptions, now time.Time) error {
+	leaf := chain[0]
+	if len(chain) < 2 {
+		// A trusted root can't be revoked through OCSP.
+		return nil
+	}
+	issuer := chain[1]
+
+	resp, err := ParseOCSPResponseForCert(opts.OCSPStaple, leaf, issuer)
+	if err == nil && resp.Certificate != nil && !bytes.Equal(resp.Certificate.Raw, issuer.Raw) &&
+		(now.Before(resp.Certificate.NotBefore) || now.After(resp.Certificate.NotAfter)) {
+		err = errors.New("x509: OCSP responder certificate has expired or is not yet valid")
+	}
+	if err == nil && !resp.isCurrent(now) {
+		err = fmt.Errorf("x509: OCSP response is not current: %s is outside of %s to %s",
+			now.Format(time.RFC3339), resp.ThisUpdate.Format(time.RFC3339), resp.NextUpdate.Format(time.RFC3339))
+	}
+	switch {
+	case err == nil && resp.Status == OCSPRevoked:
+		return CertificateInvalidError{leaf, Revoked, fmt.Sprintf("revoked at %s (OCSP, reason %d)",
+			resp.RevokedAt.Format(time.RFC3339), resp.RevocationReason)}
+	case !opts.RequireOCSPStaple:
+		return nil
+	case len(opts.OCSPStaple) == 0:
+		return CertificateInvalidError{leaf, RevocationStatusUnknown, "OCSP staple is missing"}
+	case err != nil:
+		return CertificateInvalidError{leaf, RevocationStatusUnknown, err.Error()}
+	case resp.Status != OCSPGood:
+		return CertificateInvalidError{leaf, RevocationStatusUnknown, "OCSP response status is " + resp.Status.String()}
+	}
+	return nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// The following were generated with OpenSSL 3.0:
+//
+//	openssl ocsp -issuer ca.pem -cert leaf.pem -no_nonce -reqout req.der
+//	openssl ocsp -index index.txt -rsigner resp.pem -rkey resp.key -CA ca.pem \
+//		-reqin req.der -respout resp.der -ndays 36500
+//
+// where index.txt marks the leaf as revoked on 2024-01-01 for keyCompromise,
+// and resp.pem is a delegated responder certificate issued by ca.pem.
+
+const ocspTestIssuerPEM = `-----BEGIN CERTIFICATE-----
+MIIBYTCCAQegAwIBAgIBATAKBggqhkjOPQQDAjAXMRUwEwYDVQQDDAxUZXN0IE9D
+U1AgQ0EwIBcNMjYxMDE5MDgwMTAxWhgPMjEyNjA5MjUwODAxMDFaMBcxFTATBgNV
+BAMMDFRlc3QgT0NTUCBDQTBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABDNul6Tz
+eW1ZcXYZWI10dFTv9wDNr2zFqN4E9KzHSlWgtqV6jEVjaDANC0ZnDRNxHlvGiTt1
+QUwdBehZ1Or+NI+jQjBAMA8GA1UdEwEB/wQFMAMBAf8wDgYDVR0PAQH/BAQDAgEG
+MB0GA1UdDgQWBBQAg4U8FaZZoDZ8HuO52jQkswGnBDAKBggqhkjOPQQDAgNIADBF
+AiA6g3zIRlbGgpSWE3CXj6A5QJGOjo08sEvAcDDbM0TsIwIhALjGqWIh2n/oaZWz
+attRqrQTtdb+OpfcA7pEFL7EmcdE
+-----END CERTIFICATE-----`
+
+const ocspTestLeafPEM = `-----BEGIN CERTIFICATE-----
+MIIB3TCCAYOgAwIBAgICEjQwCgYIKoZIzj0EAwIwFzEVMBMGA1UEAwwMVGVzdCBP
+Q1NQIENBMCAXDTI2MTAxOTA4MDEwMVoYDzIxMjYwOTI1MDgwMTAxWjAWMRQwEgYD
+VQQDDAtleGFtcGxlLmNvbTBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABA1QY7Zl
+hAj6w20Wi8Snnzvn56X94OLwxGLOXbK54yneg3T0TDZDEJQFR7qjLojfTpVtuh2A
+qlWpsEwsDv/Uw9ajgb0wgbowCQYDVR0TBAIwADALBgNVHQ8EBAMCB4AwEwYDVR0l
+BAwwCgYIKwYBBQUHAwEwFgYDVR0RBA8wDYILZXhhbXBsZS5jb20wMwYIKwYBBQUH
+AQEEJzAlMCMGCCsGAQUFBzABhhdodHRwOi8vb2NzcC5leGFtcGxlLmNvbTAdBgNV
+HQ4EFgQU8gjTH1WTDgeatbI6x/RJt5u/DPkwHwYDVR0jBBgwFoAUAIOFPBWmWaA2
+fB7judo0JLMBpwQwCgYIKoZIzj0EAwIDSAAwRQIhAPNwkPv6M0T7bALCb2grrrp2
+EqakTBw65isICpwws/bMAiB7Rn/ugooVLBVtcfrkAof+IeECEYiBQJwmgo5rIqAG
+xQ==
+-----END CERTIFICATE-----`
+
+const ocspTestRequestHex = "30433041303f303d303b300906052b0e03021a05000414bf3455757a69f58f2b376caf5395f6706a2104c704140083853c15a659a0367c1ee3b9da3424b301a70402021234"
+
+const ocspTestResponseBase64 = "" +
+	"MIICyAoBAKCCAsEwggK9BgkrBgEFBQcwAQEEggKuMIICqjCBsKEgMB4xHDAaBgNVBAMME1Rlc3Qg" +
+	"T0NTUCBSZXNwb25kZXIYDzIwMjYxMDE5MDgwMTAxWjB7MHkwOzAJBgUrDgMCGgUABBS/NFV1emn1" +
+	"jys3bK9TlfZwaiEExwQUAIOFPBWmWaA2fB7judo0JLMBpwQCAhI0oRYYDzIwMjQwMTAxMDAwMDAw" +
+	"WqADCgEBGA8yMDI2MTAxOTA4MDEwMVqgERgPMjEyNjA5MjUwODAxMDFaMAoGCCqGSM49BAMCA0cA" +
+	"MEQCIDN1rGxhKcCiIKRx092GaJjZ3RxUZfZ6oHrb4sktcwrbAiAyTcFf6p3jOO1xAVoD14mmHplg" +
+	"/UqYHYQ5KbCX+Z7C0aCCAZ4wggGaMIIBljCCATygAwIBAgICEjUwCgYIKoZIzj0EAwIwFzEVMBMG" +
+	"A1UEAwwMVGVzdCBPQ1NQIENBMCAXDTI2MTAxOTA4MDEwMVoYDzIxMjYwOTI1MDgwMTAxWjAeMRww" +
+	"GgYDVQQDDBNUZXN0IE9DU1AgUmVzcG9uZGVyMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEnELd" +
+	"XfiVo+mhp/L3r/vZPfwR7ZVcisaztrHqVZUT6d8SxsFAt2rQT1HxUv3OcKgosxpekDR/zi6Ois2v" +
+	"+71VRqNvMG0wCQYDVR0TBAIwADALBgNVHQ8EBAMCB4AwEwYDVR0lBAwwCgYIKwYBBQUHAwkwHQYD" +
+	"VR0OBBYEFIzdYEm2+uYFuG2r8jkjITdLFh5yMB8GA1UdIwQYMBaAFACDhTwVplmgNnwe47naNCSz" +
+	"AacEMAoGCCqGSM49BAMCA0gAMEUCIQDwQPfOm11roxMZEvOW7LiIbvrHUy2uJvthTLuQVOIlhQIg" +
+	"QPWqS5ye0EFuxwXKFO+RqKP+RJHx1NW62Ccy8EeUnCg="
+
+func mustParsePEMCertificate(t *testing.T, s string) *Certificate {
+	t.Helper()
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		t.Fatal("failed to decode PEM certificate")
+	}
+	cert, err := ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestCreateOCSPRequestOpenSSL(t *testing.T) {
+	issuer := mustParsePEMCertificate(t, ocspTestIssuerPEM)
+	leaf := mustParsePEMCertificate(t, ocspTestLeafPEM)
+	want, _ := hex.DecodeString(ocspTestRequestHex)
+
+	got, err := CreateOCSPRequest(leaf, issuer, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("CreateOCSPRequest = %x, want %x", got, want)
+	}
+
+	req, err := ParseOCSPRequest(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.HashAlgorithm != crypto.SHA1 {
+		t.Errorf("HashAlgorithm = %v, want SHA-1", req.HashAlgorithm)
+	}
+	if req.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		t.Errorf("SerialNumber = %v, want %v", req.SerialNumber, leaf.SerialNumber)
+	}
+	id := certID{
+		HashAlgorithm:  pkix.AlgorithmIdentifier{Algorithm: oidSHA1},
+		IssuerNameHash: req.IssuerNameHash,
+		IssuerKeyHash:  req.IssuerKeyHash,
+		SerialNumber:   req.SerialNumber,
+	}
+	if !id.matches(leaf, issuer) {
+		t.Errorf("parsed request does not identify the certificate")
+	}
+}
+
+func TestOCSPRequestRoundTrip(t *testing.T) {
+	issuer := mustParsePEMCertificate(t, ocspTestIssuerPEM)
+	leaf := mustParsePEMCertificate(t, ocspTestLeafPEM)
+	for _, h := range []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		der, err := CreateOCSPRequest(leaf, issuer, h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := ParseOCSPRequest(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.HashAlgorithm != h {
+			t.Errorf("HashAlgorithm = %v, want %v", req.HashAlgorithm, h)
+		}
+		if !bytes.Equal(req.Raw, der) {
+			t.Errorf("Raw doesn't match the request")
+		}
+	}
+	if _, err := CreateOCSPRequest(leaf, issuer, crypto.MD5); err == nil {
+		t.Errorf("CreateOCSPRequest with MD5 succeeded")
+	}
+}
+
+func TestParseOCSPResponseOpenSSL(t *testing.T) {
+	issuer := mustParsePEMCertificate(t, ocspTestIssuerPEM)
+	leaf := mustParsePEMCertificate(t, ocspTestLeafPEM)
+	der, _ := base64.StdEncoding.DecodeString(ocspTestResponseBase64)
+
+	resp, err := ParseOCSPResponseForCert(der, leaf, issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != OCSPRevoked {
+		t.Errorf("Status = %v, want revoked", resp.Status)
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !resp.RevokedAt.Equal(want) {
+		t.Errorf("RevokedAt = %v, want %v", resp.RevokedAt, want)
+	}
+	if resp.RevocationReason != 1 {
+		t.Errorf("RevocationReason = %d, want 1 (keyCompromise)", resp.RevocationReason)
+	}
+	if resp.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		t.Errorf("SerialNumber = %v, want %v", resp.SerialNumber, leaf.SerialNumber)
+	}
+	if resp.IssuerHash != crypto.SHA1 {
+		t.Errorf("IssuerHash = %v, want SHA-1", resp.IssuerHash)
+	}
+	if resp.Certificate == nil || resp.Certificate.Subject.CommonName != "Test OCSP Responder" {
+		t.Errorf("missing or unexpected responder certificate")
+	}
+	if resp.Certificate != nil && !bytes.Equal(resp.RawResponderName, resp.Certificate.RawSubject) {
+		t.Errorf("RawResponderName doesn't match the responder certificate")
+	}
+	if resp.NextUpdate.Before(resp.ThisUpdate) || resp.ThisUpdate.IsZero() {
+		t.Errorf("unexpected validity %v to %v", resp.ThisUpdate, resp.NextUpdate)
+	}
+	if !bytes.Equal(resp.Raw, der) {
+		t.Errorf("Raw doesn't match the response")
+	}
+
+	single, err := ParseOCSPResponse(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single.Status != OCSPRevoked || single.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		t.Errorf("ParseOCSPResponse returned a different status")
+	}
+
+	// The responder certificate is not authorized to sign for another CA.
+	otherCA, _ := ocspTestCA(t, "Other CA")
+	if _, err := ParseOCSPResponseForCert(der, leaf, otherCA); err == nil {
+		t.Errorf("ParseOCSPResponseForCert succeeded with the wrong issuer")
+	}
+}
+
+func TestParseOCSPResponseError(t *testing.T) {
+	// OCSPResponse with responseStatus tryLater.
+	_, err := ParseOCSPResponse([]byte{0x30, 0x03, 0x0a, 0x01, 0x03})
+	var respErr OCSPResponseError
+	if !errors.As(err, &respErr) || respErr.Status != OCSPTryLater {
+		t.Errorf("ParseOCSPResponse error = %v, want tryLater OCSPResponseError", err)
+	}
+
+	der, _ := base64.StdEncoding.DecodeString(ocspTestResponseBase64)
+	for i := range der {
+		if _, err := ParseOCSPResponse(der[:i]); err == nil {
+			t.Errorf("ParseOCSPResponse succeeded on a response truncated to %d bytes", i)
+		}
+	}
+	if _, err := ParseOCSPResponse(append(der, 0)); err == nil {
+		t.Errorf("ParseOCSPResponse succeeded with trailing data")
+	}
+}
+
+func ocspTestCA(t *testing.T, name string) (*Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		KeyUsage:              KeyUsageCertSign | KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func ocspTestIssue(t *testing.T, template, issuer *Certificate, issuerKey crypto.Signer) (*Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		template.NotAfter = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	der, err := CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestCreateOCSPResponse(t *testing.T) {
+	ca, caKey := ocspTestCA(t, "OCSP CA")
+	leaf, _ := ocspTestIssue(t, &Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		DNSNames:     []string{"example.com"},
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	responder, responderKey := ocspTestIssue(t, &Certificate{
+		SerialNumber: big.NewInt(43),
+		Subject:      pkix.Name{CommonName: "responder"},
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageOCSPSigning},
+	}, ca, caKey)
+	unauthorized, unauthorizedKey := ocspTestIssue(t, &Certificate{
+		SerialNumber: big.NewInt(44),
+		Subject:      pkix.Name{CommonName: "unauthorized"},
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageServerAuth},
+	}, ca, caKey)
+
+	thisUpdate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name      string
+		template  OCSPResponse
+		responder *Certificate
+		key       crypto.Signer
+		wantErr   bool
+	}{
+		{name: "good", template: OCSPResponse{Status: OCSPGood}, key: caKey},
+		{name: "revoked", template: OCSPResponse{Status: OCSPRevoked, RevokedAt: thisUpdate.Add(-time.Hour), RevocationReason: 4}, key: caKey},
+		{name: "unknown", template: OCSPResponse{Status: OCSPUnknown}, key: caKey},
+		{name: "sha256", template: OCSPResponse{Status: OCSPGood, IssuerHash: crypto.SHA256}, key: caKey},
+		{name: "delegated", template: OCSPResponse{Status: OCSPGood}, responder: responder, key: responderKey},
+		{name: "unauthorized", template: OCSPResponse{Status: OCSPGood}, responder: unauthorized, key: unauthorizedKey, wantErr: true},
+		{name: "wrong key", template: OCSPResponse{Status: OCSPGood}, key: responderKey, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			template := tt.template
+			template.SerialNumber = leaf.SerialNumber
+			template.ThisUpdate = thisUpdate
+			template.NextUpdate = thisUpdate.Add(24 * time.Hour)
+			template.ExtraExtensions = []pkix.Extension{{Id: []int{1, 2, 3}, Value: []byte{0x05, 0x00}}}
+			der, err := CreateOCSPResponse(rand.Reader, &template, ca, tt.responder, tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := ParseOCSPResponseForCert(der, leaf, ca)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ParseOCSPResponseForCert succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != template.Status {
+				t.Errorf("Status = %v, want %v", resp.Status, template.Status)
+			}
+			if !resp.ThisUpdate.Equal(template.ThisUpdate) || !resp.NextUpdate.Equal(template.NextUpdate) {
+				t.Errorf("validity = %v to %v, want %v to %v", resp.ThisUpdate, resp.NextUpdate, template.ThisUpdate, template.NextUpdate)
+			}
+			if !resp.RevokedAt.Equal(template.RevokedAt) || resp.RevocationReason != template.RevocationReason {
+				t.Errorf("revocation = %v (%d), want %v (%d)", resp.RevokedAt, resp.RevocationReason, template.RevokedAt, template.RevocationReason)
+			}
+			if want := template.IssuerHash; want != 0 && resp.IssuerHash != want {
+				t.Errorf("IssuerHash = %v, want %v", resp.IssuerHash, want)
+			}
+			if len(resp.Extensions) != 1 || !resp.Extensions[0].Id.Equal(template.ExtraExtensions[0].Id) {
+				t.Errorf("Extensions = %v, want %v", resp.Extensions, template.ExtraExtensions)
+			}
+			if (resp.Certificate != nil) != (tt.responder != nil) {
+				t.Errorf("Certificate = %v, want %v", resp.Certificate, tt.responder)
+			}
+			if len(resp.ResponderKeyHash) != 20 {
+				t.Errorf("ResponderKeyHash = %x, want a SHA-1 hash", resp.ResponderKeyHash)
+			}
+		})
+	}
+
+	other, _ := ocspTestIssue(t, &Certificate{SerialNumber: big.NewInt(45)}, ca, caKey)
+	der, err := CreateOCSPResponse(rand.Reader, &OCSPResponse{SerialNumber: leaf.SerialNumber, ThisUpdate: thisUpdate}, ca, nil, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseOCSPResponseForCert(der, other, ca); err == nil {
+		t.Errorf("ParseOCSPResponseForCert succeeded for a different certificate")
+	}
+	if _, err := CreateOCSPResponse(rand.Reader, &OCSPResponse{SerialNumber: leaf.SerialNumber, Status: OCSPRevoked}, ca, nil, caKey); err == nil {
+		t.Errorf("CreateOCSPResponse succeeded for a revoked status without RevokedAt")
+	}
+}
+
+func TestVerifyOCSPStaple(t *testing.T) {
+	ca, caKey := ocspTestCA(t, "OCSP CA")
+	leaf, _ := ocspTestIssue(t, &Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		DNSNames:     []string{"example.com"},
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	roots := NewCertPool()
+	roots.AddCert(ca)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	staple := func(status OCSPStatus, thisUpdate, nextUpdate time.Time) []byte {
+		der, err := CreateOCSPResponse(rand.Reader, &OCSPResponse{
+			Status:       status,
+			SerialNumber: leaf.SerialNumber,
+			ThisUpdate:   thisUpdate,
+			NextUpdate:   nextUpdate,
+			RevokedAt:    thisUpdate,
+		}, ca, nil, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+	good := staple(OCSPGood, now.Add(-time.Hour), now.Add(time.Hour))
+	revoked := staple(OCSPRevoked, now.Add(-time.Hour), now.Add(time.Hour))
+	unknown := staple(OCSPUnknown, now.Add(-time.Hour), now.Add(time.Hour))
+	expired := staple(OCSPRevoked, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	for _, tt := range []struct {
+		name    string
+		staple  []byte
+		require bool
+		reason  InvalidReason // -1 if the chain is valid
+	}{
+		{"none", nil, false, -1},
+		{"good", good, false, -1},
+		{"revoked", revoked, false, Revoked},
+		{"unknown", unknown, false, -1},
+		{"expired revoked", expired, false, -1},
+		{"garbage", []byte("not an OCSP response"), false, -1},
+		{"required none", nil, true, RevocationStatusUnknown},
+		{"required good", good, true, -1},
+		{"required revoked", revoked, true, Revoked},
+		{"required unknown", unknown, true, RevocationStatusUnknown},
+		{"required expired", expired, true, RevocationStatusUnknown},
+		{"required garbage", []byte("not an OCSP response"), true, RevocationStatusUnknown},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			chains, err := leaf.Verify(VerifyOptions{
+				Roots:             roots,
+				CurrentTime:       now,
+				DNSName:           "example.com",
+				OCSPStaple:        tt.staple,
+				RequireOCSPStaple: tt.require,
+			})
+			if tt.reason == -1 {
+				if err != nil {
+					t.Fatalf("Verify failed: %v", err)
+				}
+				if len(chains) != 1 {
+					t.Fatalf("got %d chains, want 1", len(chains))
+				}
+				return
+			}
+			var invalidErr CertificateInvalidError
+			if !errors.As(err, &invalidErr) || invalidErr.Reason != tt.reason {
+				t.Fatalf("Verify error = %v, want CertificateInvalidError with reason %d", err, tt.reason)
+			}
+			if invalidErr.Cert != leaf {
+				t.Errorf("error is not about the leaf certificate")
+			}
+		})
+	}
+
+	// A trusted root is not checked.
+	if _, err := ca.Verify(VerifyOptions{Roots: roots, CurrentTime: now, RequireOCSPStaple: true}); err != nil {
+		t.Errorf("Verify of a root with RequireOCSPStaple failed: %v", err)
+	}
+}
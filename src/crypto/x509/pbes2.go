@@ -0,0 +1,319 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x509
+
+// Encrypted PKCS #8 private keys, as defined in RFC 5958, Section 3, hold a
+// PKCS #8 PrivateKeyInfo encrypted with the PBES2 scheme of RFC 8018. PBES2
+// derives the encryption key from the password with PBKDF2; only AES-CBC is
+// supported for the encryption.
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// pbkdf2PRFs lists the pseudorandom functions supported for PBKDF2.
+var pbkdf2PRFs = []struct {
+	oid  asn1.ObjectIdentifier
+	hash crypto.Hash
+}{
+	{oidHMACWithSHA1, crypto.SHA1},
+	{oidHMACWithSHA224, crypto.SHA224},
+	{oidHMACWithSHA256, crypto.SHA256},
+	{oidHMACWithSHA384, crypto.SHA384},
+	{oidHMACWithSHA512, crypto.SHA512},
+}
+
+// pbes2Ciphers lists the encryption schemes supported for PBES2.
+var pbes2Ciphers = []struct {
+	oid     asn1.ObjectIdentifier
+	keySize int
+}{
+	{oidAES128CBC, 16},
+	{oidAES192CBC, 24},
+	{oidAES256CBC, 32},
+}
+
+// maxPBKDF2Iterations bounds the work done to decrypt a key, which is chosen
+// by whoever encrypted it.
+const maxPBKDF2Iterations = 10_000_000
+
+// encryptedPrivateKeyInfo reflects an ASN.1 EncryptedPrivateKeyInfo.
+// See RFC 5958, Section 3.
+type encryptedPrivateKeyInfo struct {
+	Algo          pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params reflects the ASN.1 PBES2-params. See RFC 8018, Appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params reflects the ASN.1 PBKDF2-params, for a salt given as an
+// OCTET STRING. See RFC 8018, Appendix A.2. A missing PRF means HMAC-SHA1.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// EncryptedPKCS8Options are the parameters used by
+// [MarshalEncryptedPKCS8PrivateKey] to encrypt a private key.
+type EncryptedPKCS8Options struct {
+	// KeySize is the size in bytes of the AES key: 16, 24 or 32.
+	// If zero, 32 is used.
+	KeySize int
+
+	// Hash is the hash function of the HMAC used by PBKDF2 to derive the
+	// key from the password: crypto.SHA1, crypto.SHA224, crypto.SHA256,
+	// crypto.SHA384 or crypto.SHA512. If zero, crypto.SHA256 is used.
+	Hash crypto.Hash
+
+	// Iterations is the PBKDF2 iteration count, which sets the cost of
+	// deriving the key from the password. If zero, 600000 is used.
+	Iterations int
+}
+
+// ParseEncryptedPKCS8PrivateKey decrypts with password and parses a private
+// key in encrypted PKCS #8, ASN.1 DER form, as defined in RFC 5958, Section 3.
+//
+// The key must be encrypted with the PBES2 scheme of RFC 8018, using PBKDF2
+// with HMAC-SHA1, HMAC-SHA224, HMAC-SHA256, HMAC-SHA384 or HMAC-SHA512 and
+// AES-128-CBC, AES-192-CBC or AES-256-CBC, as produced by OpenSSL and most
+// other tools. If the password is incorrect, [IncorrectPasswordError] is
+// returned.
+//
+// It returns the same key types as [ParsePKCS8PrivateKey].
+//
+// This kind of key is commonly encoded in PEM blocks of type
+// "ENCRYPTED PRIVATE KEY".
+func ParseEncryptedPKCS8PrivateKey(der, password []byte) (key any, err error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after encrypted PKCS#8 private key")
+	}
+	if !info.Algo.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("x509: unsupported PKCS#8 encryption algorithm: %v", info.Algo.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algo.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.New("x509: invalid PBES2 parameters: " + err.Error())
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("x509: unsupported PBES2 key derivation function: %v", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, errors.New("x509: invalid PBKDF2 parameters: " + err.Error())
+	}
+	if kdf.IterationCount < 1 || kdf.IterationCount > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("x509: unsupported PBKDF2 iteration count: %d", kdf.IterationCount)
+	}
+	hash := crypto.SHA1
+	if len(kdf.PRF.Algorithm) != 0 {
+		hash = 0
+		for _, prf := range pbkdf2PRFs {
+			if kdf.PRF.Algorithm.Equal(prf.oid) {
+				hash = prf.hash
+			}
+		}
+		if hash == 0 {
+			return nil, fmt.Errorf("x509: unsupported PBKDF2 pseudorandom function: %v", kdf.PRF.Algorithm)
+		}
+	}
+	keySize := 0
+	for _, c := range pbes2Ciphers {
+		if params.EncryptionScheme.Algorithm.Equal(c.oid) {
+			keySize = c.keySize
+		}
+	}
+	if keySize == 0 {
+		return nil, fmt.Errorf("x509: unsupported PBES2 encryption scheme: %v", params.EncryptionScheme.Algorithm)
+	}
+	if kdf.KeyLength != 0 && kdf.KeyLength != keySize {
+		return nil, errors.New("x509: PBKDF2 key length does not match the encryption scheme")
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("x509: invalid AES-CBC initialization vector")
+	}
+	ciphertext := info.EncryptedData
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("x509: encrypted PKCS#8 data is not a multiple of the block size")
+	}
+
+	encKey, err := pbkdf2.Key(hash.New, string(password), kdf.Salt, kdf.IterationCount, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(data, ciphertext)
+
+	// The data is padded as described in RFC 8018, Section 6.1.1, with n
+	// bytes equal to n. Bad padding means that the password is incorrect,
+	// and so does a padded PrivateKeyInfo that is not a DER SEQUENCE, which
+	// catches the rare incorrect passwords that produce valid padding.
+	n := int(data[len(data)-1])
+	if n == 0 || n > aes.BlockSize {
+		return nil, IncorrectPasswordError
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, IncorrectPasswordError
+		}
+	}
+	data = data[:len(data)-n]
+	var seq asn1.RawValue
+	if rest, err := asn1.Unmarshal(data, &seq); err != nil || len(rest) != 0 ||
+		seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence {
+		return nil, IncorrectPasswordError
+	}
+	return ParsePKCS8PrivateKey(data)
+}
+
+// MarshalEncryptedPKCS8PrivateKey converts a private key to PKCS #8, ASN.1 DER
+// form and encrypts it with password, producing an encrypted PKCS #8 key as
+// defined in RFC 5958, Section 3. The key is encrypted with the PBES2 scheme
+// of RFC 8018, using PBKDF2 and AES-CBC with the parameters in opts, which
+// may be nil to use the defaults. The salt and initialization vector are read
+// from rand.
+//
+// The same key types as for [MarshalPKCS8PrivateKey] are supported.
+//
+// This kind of key is commonly encoded in PEM blocks of type
+// "ENCRYPTED PRIVATE KEY".
+func MarshalEncryptedPKCS8PrivateKey(rand io.Reader, key any, password []byte, opts *EncryptedPKCS8Options) ([]byte, error) {
+	keySize, hash, iterations := 32, crypto.SHA256, 600000
+	if opts != nil {
+		if opts.KeySize != 0 {
+			keySize = opts.KeySize
+		}
+		if opts.Hash != 0 {
+			hash = opts.Hash
+		}
+		if opts.Iterations != 0 {
+			iterations = opts.Iterations
+		}
+	}
+	var encOID asn1.ObjectIdentifier
+	for _, c := range pbes2Ciphers {
+		if c.keySize == keySize {
+			encOID = c.oid
+		}
+	}
+	if encOID == nil {
+		return nil, fmt.Errorf("x509: unsupported AES key size for PKCS#8 encryption: %d", keySize)
+	}
+	var prfOID asn1.ObjectIdentifier
+	for _, prf := range pbkdf2PRFs {
+		if prf.hash == hash {
+			prfOID = prf.oid
+		}
+	}
+	if prfOID == nil {
+		return nil, fmt.Errorf("x509: unsupported hash function for PKCS#8 encryption: %v", hash)
+	}
+	if iterations < 1 || iterations > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("x509: unsupported PBKDF2 iteration count: %d", iterations)
+	}
+
+	data, err := MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand, salt); err != nil {
+		return nil, errors.New("x509: cannot generate salt: " + err.Error())
+	}
+	if _, err := io.ReadFull(rand, iv); err != nil {
+		return nil, errors.New("x509: cannot generate IV: " + err.Error())
+	}
+
+	encKey, err := pbkdf2.Key(hash.New, string(password), salt, iterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, err
+	}
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	for i := 0; i < pad; i++ {
+		data = append(data, byte(pad))
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	kdf := pbkdf2Params{
+		Salt:           salt,
+		IterationCount: iterations,
+	}
+	// HMAC-SHA1 is the DEFAULT, which DER requires to be omitted.
+	if hash != crypto.SHA1 {
+		kdf.PRF = pkix.AlgorithmIdentifier{
+			Algorithm:  prfOID,
+			Parameters: asn1.NullRawValue,
+		}
+	}
+	kdfBytes, err := asn1.Marshal(kdf)
+	if err != nil {
+		return nil, err
+	}
+	ivBytes, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	paramBytes, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBKDF2,
+			Parameters: asn1.RawValue{FullBytes: kdfBytes},
+		},
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm:  encOID,
+			Parameters: asn1.RawValue{FullBytes: ivBytes},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: paramBytes},
+		},
+		EncryptedData: data,
+	})
+}
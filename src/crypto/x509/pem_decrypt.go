@@ -121,6 +121,8 @@ var IncorrectPasswordError = errors.New("x509: decryption password incorrect")
 // Deprecated: Legacy PEM encryption as specified in RFC 1423 is insecure by
 // design. Since it does not authenticate the ciphertext, it is vulnerable to
 // padding oracle attacks that can let an attacker recover the plaintext.
+// Private keys encrypted with a password are better stored in encrypted
+// PKCS #8 form, which [ParseEncryptedPKCS8PrivateKey] decrypts.
 func DecryptPEMBlock(b *pem.Block, password []byte) ([]byte, error) {
 	dek, ok := b.Headers["DEK-Info"]
 	if !ok {
@@ -192,6 +194,7 @@ func DecryptPEMBlock(b *pem.Block, password []byte) ([]byte, error) {
 // Deprecated: Legacy PEM encryption as specified in RFC 1423 is insecure by
 // design. Since it does not authenticate the ciphertext, it is vulnerable to
 // padding oracle attacks that can let an attacker recover the plaintext.
+// Use [MarshalEncryptedPKCS8PrivateKey] to encrypt private keys with a password.
 func EncryptPEMBlock(rand io.Reader, blockType string, data, password []byte, alg PEMCipher) (*pem.Block, error) {
 	ciph := cipherByKey(alg)
 	if ciph == nil {
@@ -56,6 +56,17 @@ const (
 	// CANotAuthorizedForExtKeyUsage results when an intermediate or root
 	// certificate does not permit a requested extended key usage.
 	CANotAuthorizedForExtKeyUsage
+	// Revoked results when a certificate has been revoked by its issuer,
+	// according to the revocation information in the VerifyOptions.
+	Revoked
+	// RevocationStatusUnknown results when the VerifyOptions require
+	// revocation information for a certificate, but none that is valid and
+	// current is available.
+	RevocationStatusUnknown
+	// InsufficientSCTs results when the leaf certificate doesn't have enough
+	// valid signed certificate timestamps to satisfy
+	// VerifyOptions.MinValidSCTs.
+	InsufficientSCTs
 )
 
 // CertificateInvalidError results when an odd error occurs. Users of this
@@ -86,6 +97,12 @@ func (e CertificateInvalidError) Error() string {
 		return "x509: issuer has name constraints but leaf doesn't have a SAN extension"
 	case UnconstrainedName:
 		return "x509: issuer has name constraints but leaf contains unknown or unconstrained name: " + e.Detail
+	case Revoked:
+		return "x509: certificate has been revoked: " + e.Detail
+	case RevocationStatusUnknown:
+		return "x509: revocation status of certificate is unknown: " + e.Detail
+	case InsufficientSCTs:
+		return "x509: certificate has insufficient signed certificate timestamps: " + e.Detail
 	}
 	return "x509: unknown error"
 }
@@ -201,6 +218,51 @@ type VerifyOptions struct {
 	// certificates from consuming excessive amounts of CPU time when
 	// validating. It does not apply to the platform verifier.
 	MaxConstraintComparisions int
+
+	// OCSPStaple is an optional DER-encoded OCSP response for the leaf
+	// certificate, such as the one stapled to a TLS handshake and exposed as
+	// tls.ConnectionState.OCSPResponse. If it is a valid and current response
+	// from the leaf's issuer reporting the leaf as revoked, the chain is
+	// rejected with a [Revoked] error. Otherwise, it is ignored unless
+	// RequireOCSPStaple is set.
+	OCSPStaple []byte
+
+	// RequireOCSPStaple rejects chains for which OCSPStaple is not a valid
+	// response, signed by the leaf's issuer or its delegated responder and
+	// current at CurrentTime, reporting the leaf as good. Chains consisting
+	// only of a trusted root are not affected.
+	RequireOCSPStaple bool
+
+	// RevocationLists is an optional source of certificate revocation lists
+	// (CRLs). If set, every certificate in a chain except the root is looked
+	// up in the CRLs signed by its issuer that are current at CurrentTime, and
+	// chains containing a revoked certificate are rejected with a [Revoked]
+	// error. CRLs that are not signed by the issuer or not current are
+	// ignored, as are errors returned by the source, unless
+	// RequireRevocationLists is set.
+	RevocationLists RevocationListSource
+
+	// RequireRevocationLists rejects chains containing a certificate, other
+	// than the root, whose revocation status is not determined by a complete
+	// and current CRL from its issuer.
+	RequireRevocationLists bool
+
+	// CTLogs is the set of Certificate Transparency logs whose signed
+	// certificate timestamps (SCTs) are counted towards MinValidSCTs.
+	CTLogs []*CTLog
+
+	// SignedCertificateTimestamps is an optional list of TLS-encoded SCTs
+	// for the leaf certificate delivered alongside it, such as
+	// tls.ConnectionState.SignedCertificateTimestamps. SCTs embedded in the
+	// leaf and included in OCSPStaple are used in addition to these.
+	SignedCertificateTimestamps [][]byte
+
+	// MinValidSCTs, if positive, rejects chains whose leaf doesn't have
+	// valid SCTs from at least this many distinct logs in CTLogs, with an
+	// [InsufficientSCTs] error. SCTs with a timestamp after CurrentTime, from
+	// unknown logs, or that fail to parse or verify are ignored. Chains
+	// consisting only of a trusted root are not affected.
+	MinValidSCTs int
 }
 
 const (
@@ -772,14 +834,21 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 		// i.e. if SetFallbackRoots was called with x509usefallbackroots=1.
 		systemPool := systemRootsPool()
 		if opts.Roots == nil && (systemPool == nil || systemPool.systemPool) {
-			return c.systemVerify(&opts)
+			platformChains, err := c.systemVerify(&opts)
+			if err != nil {
+				return nil, err
+			}
+			return checkChainsPolicy(platformChains, &opts)
 		}
 		if opts.Roots != nil && opts.Roots.systemPool {
 			platformChains, err := c.systemVerify(&opts)
 			// If the platform verifier succeeded, or there are no additional
 			// roots, return the platform verifier result. Otherwise, continue
 			// with the Go verifier.
-			if err == nil || opts.Roots.len() == 0 {
+			if err == nil {
+				return checkChainsPolicy(platformChains, &opts)
+			}
+			if opts.Roots.len() == 0 {
 				return platformChains, err
 			}
 		}
@@ -822,7 +891,7 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 		if eku == ExtKeyUsageAny {
 			// If any key usage is acceptable, no need to check the chain for
 			// key usages.
-			return candidateChains, nil
+			return checkChainsPolicy(candidateChains, &opts)
 		}
 	}
 
@@ -837,7 +906,96 @@ func (c *Certificate) Verify(opts VerifyOptions) (chains [][]*Certificate, err e
 		return nil, CertificateInvalidError{c, IncompatibleUsage, ""}
 	}
 
-	return chains, nil
+	return checkChainsPolicy(chains, &opts)
+}
+
+// checkChainsPolicy drops the chains that are rejected by the revocation and
+// Certificate Transparency checks requested in opts. If no chains are left, it
+// returns the error that rejected the first one.
+func checkChainsPolicy(chains [][]*Certificate, opts *VerifyOptions) ([][]*Certificate, error) {
+	checkOCSP := len(opts.OCSPStaple) > 0 || opts.RequireOCSPStaple
+	checkCRLs := opts.RevocationLists != nil || opts.RequireRevocationLists
+	checkCT := opts.MinValidSCTs > 0
+	if !checkOCSP && !checkCRLs && !checkCT {
+		return chains, nil
+	}
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var firstErr error
+	valid := chains[:0:0]
+	for _, chain := range chains {
+		var err error
+		if checkOCSP {
+			err = checkOCSPStaple(chain, opts, now)
+		}
+		if err == nil && checkCRLs {
+			err = checkRevocationLists(chain, opts, now)
+		}
+		if err == nil && checkCT {
+			err = checkSCTs(chain, opts, now)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		valid = append(valid, chain)
+	}
+	if len(valid) == 0 {
+		return nil, firstErr
+	}
+	return valid, nil
+}
+
+// checkRevocationLists checks the certificates in chain, except the root,
+// against the CRLs provided by opts.RevocationLists.
+func checkRevocationLists(chain []*Certificate, opts *VerifyOptions, now time.Time) error {
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		var crls []*RevocationList
+		var err error
+		if opts.RevocationLists != nil {
+			crls, err = opts.RevocationLists.RevocationLists(cert, issuer)
+		}
+		if err != nil {
+			if opts.RequireRevocationLists {
+				return CertificateInvalidError{cert, RevocationStatusUnknown, "failed to obtain CRLs: " + err.Error()}
+			}
+			continue
+		}
+
+		covered := false
+		for _, rl := range crls {
+			if !bytes.Equal(rl.RawIssuer, issuer.RawSubject) {
+				continue
+			}
+			if len(rl.AuthorityKeyId) > 0 && len(issuer.SubjectKeyId) > 0 &&
+				!bytes.Equal(rl.AuthorityKeyId, issuer.SubjectKeyId) {
+				continue
+			}
+			if now.Before(rl.ThisUpdate) || !rl.NextUpdate.IsZero() && now.After(rl.NextUpdate) {
+				continue
+			}
+			if rl.CheckSignatureFrom(issuer) != nil {
+				continue
+			}
+			if entry := rl.entryFor(cert.SerialNumber); entry != nil {
+				return CertificateInvalidError{cert, Revoked, fmt.Sprintf("revoked at %s (CRL, reason %d)",
+					entry.RevocationTime.Format(time.RFC3339), entry.ReasonCode)}
+			}
+			if rl.isComplete() {
+				covered = true
+			}
+		}
+		if !covered && opts.RequireRevocationLists {
+			return CertificateInvalidError{cert, RevocationStatusUnknown, "no current CRL from the issuer covers the certificate"}
+		}
+	}
+	return nil
 }
 
 func appendToFreshChain(chain []*Certificate, cert *Certificate) []*Certificate {
@@ -2837,3 +2837,121 @@ func TestVerifyBareWildcard(t *testing.T) {
 		t.Fatalf("VerifyHostname unexpected success with bare wildcard SAN")
 	}
 }
+
+type revocationListSourceFunc func(cert, issuer *Certificate) ([]*RevocationList, error)
+
+func (f revocationListSourceFunc) RevocationLists(cert, issuer *Certificate) ([]*RevocationList, error) {
+	return f(cert, issuer)
+}
+
+func TestVerifyRevocationLists(t *testing.T) {
+	root, rootKey := ocspTestCA(t, "CRL Root")
+	intermediate, intermediateKey := ocspTestIssue(t, &Certificate{
+		SerialNumber:          big.NewInt(10),
+		Subject:               pkix.Name{CommonName: "CRL Intermediate"},
+		KeyUsage:              KeyUsageCertSign | KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, root, rootKey)
+	leaf, _ := ocspTestIssue(t, &Certificate{
+		SerialNumber: big.NewInt(20),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		DNSNames:     []string{"example.com"},
+		ExtKeyUsage:  []ExtKeyUsage{ExtKeyUsageServerAuth},
+	}, intermediate, intermediateKey)
+
+	roots := NewCertPool()
+	roots.AddCert(root)
+	intermediates := NewCertPool()
+	intermediates.AddCert(intermediate)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	crl := func(issuer *Certificate, key crypto.Signer, thisUpdate time.Time, exts []pkix.Extension, entries ...RevocationListEntry) *RevocationList {
+		der, err := CreateRevocationList(rand.Reader, &RevocationList{
+			Number:                    big.NewInt(1),
+			ThisUpdate:                thisUpdate,
+			NextUpdate:                thisUpdate.Add(2 * time.Hour),
+			RevokedCertificateEntries: entries,
+			ExtraExtensions:           exts,
+		}, issuer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rl, err := ParseRevocationList(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rl
+	}
+	revoke := func(c *Certificate, reason int) RevocationListEntry {
+		return RevocationListEntry{SerialNumber: c.SerialNumber, RevocationTime: now.Add(-time.Hour), ReasonCode: reason}
+	}
+	current := now.Add(-time.Hour)
+	stale := now.Add(-3 * time.Hour)
+
+	rootCRL := crl(root, rootKey, current, nil)
+	intermediateCRL := crl(intermediate, intermediateKey, current, nil, revoke(&Certificate{SerialNumber: big.NewInt(99)}, 1))
+	leafRevoked := crl(intermediate, intermediateKey, current, nil, revoke(leaf, 1))
+	intermediateRevoked := crl(root, rootKey, current, nil, revoke(intermediate, 4))
+	staleLeafRevoked := crl(intermediate, intermediateKey, stale, nil, revoke(leaf, 1))
+	leafRemoved := crl(intermediate, intermediateKey, current, nil, revoke(leaf, 8))
+	forged := crl(root, rootKey, current, nil, revoke(leaf, 1))
+	forged.RawIssuer = intermediate.RawSubject
+	partial := crl(intermediate, intermediateKey, current, []pkix.Extension{
+		{Id: []int{2, 5, 29, 28}, Critical: true, Value: []byte{0x30, 0x03, 0x81, 0x01, 0xff}},
+	})
+
+	sourceErr := revocationListSourceFunc(func(cert, issuer *Certificate) ([]*RevocationList, error) {
+		return nil, errors.New("CRL server unavailable")
+	})
+
+	for _, tt := range []struct {
+		name    string
+		source  RevocationListSource
+		require bool
+		revoked *Certificate // the certificate in the error, or nil
+		reason  InvalidReason
+	}{
+		{name: "none"},
+		{name: "empty", source: RevocationListSet{}},
+		{name: "not revoked", source: RevocationListSet{rootCRL, intermediateCRL}},
+		{name: "leaf revoked", source: RevocationListSet{rootCRL, leafRevoked}, revoked: leaf, reason: Revoked},
+		{name: "intermediate revoked", source: RevocationListSet{intermediateRevoked, intermediateCRL}, revoked: intermediate, reason: Revoked},
+		{name: "stale", source: RevocationListSet{staleLeafRevoked}},
+		{name: "removed from CRL", source: RevocationListSet{leafRemoved}},
+		{name: "forged", source: RevocationListSet{forged}},
+		{name: "source error", source: sourceErr},
+		{name: "required", source: RevocationListSet{rootCRL, intermediateCRL}, require: true},
+		{name: "required none", require: true, revoked: leaf, reason: RevocationStatusUnknown},
+		{name: "required missing intermediate", source: RevocationListSet{intermediateCRL}, require: true, revoked: intermediate, reason: RevocationStatusUnknown},
+		{name: "required stale", source: RevocationListSet{rootCRL, staleLeafRevoked}, require: true, revoked: leaf, reason: RevocationStatusUnknown},
+		{name: "required forged", source: RevocationListSet{rootCRL, forged}, require: true, revoked: leaf, reason: RevocationStatusUnknown},
+		{name: "required partial", source: RevocationListSet{rootCRL, partial}, require: true, revoked: leaf, reason: RevocationStatusUnknown},
+		{name: "required source error", source: sourceErr, require: true, revoked: leaf, reason: RevocationStatusUnknown},
+		{name: "required revoked", source: RevocationListSet{rootCRL, leafRevoked}, require: true, revoked: leaf, reason: Revoked},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := leaf.Verify(VerifyOptions{
+				Roots:                  roots,
+				Intermediates:          intermediates,
+				CurrentTime:            now,
+				DNSName:                "example.com",
+				RevocationLists:        tt.source,
+				RequireRevocationLists: tt.require,
+			})
+			if tt.revoked == nil {
+				if err != nil {
+					t.Fatalf("Verify failed: %v", err)
+				}
+				return
+			}
+			var invalidErr CertificateInvalidError
+			if !errors.As(err, &invalidErr) || invalidErr.Reason != tt.reason {
+				t.Fatalf("Verify error = %v, want CertificateInvalidError with reason %d", err, tt.reason)
+			}
+			if invalidErr.Cert != tt.revoked {
+				t.Errorf("error is about %v, want %v", invalidErr.Cert.Subject, tt.revoked.Subject)
+			}
+		})
+	}
+}
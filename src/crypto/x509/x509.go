@@ -2470,3 +2470,63 @@ func (rl *RevocationList) CheckSignatureFrom(parent *Certificate) error {
 
 	return parent.CheckSignature(rl.SignatureAlgorithm, rl.RawTBSRevocationList, rl.Signature)
 }
+
+// entryFor returns the entry of rl that revokes the certificate with the
+// given serial number, if any.
+func (rl *RevocationList) entryFor(serial *big.Int) *RevocationListEntry {
+	if serial == nil {
+		return nil
+	}
+	for i := range rl.RevokedCertificateEntries {
+		entry := &rl.RevokedCertificateEntries[i]
+		// removeFromCRL is only used in delta CRLs, to unrevoke certificates
+		// that were on hold.
+		const removeFromCRL = 8
+		if entry.ReasonCode != removeFromCRL && entry.SerialNumber.Cmp(serial) == 0 {
+			return entry
+		}
+	}
+	return nil
+}
+
+// isComplete reports whether rl is a complete CRL for its issuer's scope: a
+// delta CRL, or one with critical extensions that may limit its scope (such
+// as the issuingDistributionPoint extension), can't prove that a certificate
+// is not revoked.
+func (rl *RevocationList) isComplete() bool {
+	for _, ext := range rl.Extensions {
+		if ext.Critical {
+			return false
+		}
+	}
+	return true
+}
+
+// A RevocationListSource provides the certificate revocation lists that
+// [Certificate.Verify] consults when [VerifyOptions.RevocationLists] is set.
+//
+// Implementations may fetch CRLs on demand, for example from the
+// CRLDistributionPoints of cert, and should cache them. They must be safe
+// for concurrent use.
+type RevocationListSource interface {
+	// RevocationLists returns the CRLs that may list cert, which was issued by
+	// issuer. It may return CRLs from other issuers, or that are no longer
+	// current: they are ignored by Verify.
+	RevocationLists(cert, issuer *Certificate) ([]*RevocationList, error)
+}
+
+// RevocationListSet is a [RevocationListSource] backed by a fixed set of
+// parsed CRLs.
+type RevocationListSet []*RevocationList
+
+// RevocationLists returns the CRLs in s whose issuer name matches the subject
+// of issuer.
+func (s RevocationListSet) RevocationLists(cert, issuer *Certificate) ([]*RevocationList, error) {
+	var crls []*RevocationList
+	for _, rl := range s {
+		if bytes.Equal(rl.RawIssuer, issuer.RawSubject) {
+			crls = append(crls, rl)
+		}
+	}
+	return crls, nil
+}
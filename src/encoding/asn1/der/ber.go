@@ -0,0 +1,185 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"errors"
+)
+
+// maxBERDepth is the maximum nesting of constructed elements accepted by
+// FromBER.
+const maxBERDepth = 100
+
+var (
+	errMalformedBER = errors.New("der: malformed BER element")
+	errBERTooDeep   = errors.New("der: BER element nested too deeply")
+)
+
+// FromBER converts data holding a single BER element to DER. Many
+// producers of PKCS #7 and CMS structures, among others, use BER.
+//
+// FromBER replaces indefinite and non-minimal lengths with minimal
+// definite lengths, joins the segments of constructed strings into a
+// primitive string and encodes BOOLEAN true as 0xff. It does not reorder
+// the elements of SET and SET OF values, and it does not check the
+// contents of other primitive elements, which a [Parser] checks when they
+// are read.
+func FromBER(data []byte) ([]byte, error) {
+	var b Builder
+	rest, err := convertBER(&b, data, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("der: trailing data after BER element")
+	}
+	return b.Bytes()
+}
+
+// convertBER appends the DER encoding of the BER element at the start of
+// data to b, and returns the data that follows the element.
+func convertBER(b *Builder, data []byte, depth int) ([]byte, error) {
+	if depth > maxBERDepth {
+		return nil, errBERTooDeep
+	}
+	tag, hdrLen, length, ok := readHeader(data, true)
+	if !ok {
+		return nil, errMalformedBER
+	}
+	data = data[hdrLen:]
+
+	if !tag.IsConstructed() {
+		contents := data[:length]
+		if tag == Boolean {
+			if len(contents) != 1 {
+				return nil, errMalformedBER
+			}
+			b.AddBoolean(contents[0] != 0)
+		} else {
+			b.AddBytes(tag, contents)
+		}
+		return data[length:], nil
+	}
+
+	if prim := tag &^ tagConstructed; isStringTag(prim) {
+		var segments [][]byte
+		rest, err := stringSegments(&segments, data, length, prim, depth)
+		if err != nil {
+			return nil, err
+		}
+		contents, err := joinSegments(segments, prim)
+		if err != nil {
+			return nil, err
+		}
+		b.AddBytes(prim, contents)
+		return rest, nil
+	}
+
+	var rest []byte
+	var err error
+	b.AddElement(tag, func(child *Builder) {
+		rest, err = forEachChild(data, length, func(data []byte) ([]byte, error) {
+			return convertBER(child, data, depth+1)
+		})
+	})
+	return rest, err
+}
+
+// forEachChild calls f for each element in the contents of a constructed
+// element, which start at data and have the given length, or end with an
+// end-of-contents marker if length is -1. f consumes an element from the
+// start of its argument and returns the remaining data. forEachChild
+// returns the data that follows the contents.
+func forEachChild(data []byte, length int, f func([]byte) ([]byte, error)) ([]byte, error) {
+	if length >= 0 {
+		contents := data[:length]
+		for len(contents) > 0 {
+			var err error
+			if contents, err = f(contents); err != nil {
+				return nil, err
+			}
+		}
+		return data[length:], nil
+	}
+	for {
+		if len(data) >= 2 && data[0] == 0 && data[1] == 0 {
+			return data[2:], nil
+		}
+		if len(data) == 0 {
+			return nil, errMalformedBER
+		}
+		var err error
+		if data, err = f(data); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isStringTag reports whether tag is a UNIVERSAL string type, which BER
+// allows to be split into segments in a constructed element.
+func isStringTag(tag Tag) bool {
+	if tag.Class() != ClassUniversal {
+		return false
+	}
+	switch tag.Number() {
+	case 3, 4, 12, 18, 19, 20, 21, 22, 25, 26, 27, 28, 30:
+		// BIT STRING, OCTET STRING and the restricted character string
+		// types.
+		return true
+	case 23, 24:
+		// UTCTime and GeneralizedTime are VisibleStrings.
+		return true
+	}
+	return false
+}
+
+// stringSegments appends the contents of the segments of a constructed
+// string with the given primitive tag to segments.
+func stringSegments(segments *[][]byte, data []byte, length int, tag Tag, depth int) ([]byte, error) {
+	if depth > maxBERDepth {
+		return nil, errBERTooDeep
+	}
+	return forEachChild(data, length, func(data []byte) ([]byte, error) {
+		t, hdrLen, length, ok := readHeader(data, true)
+		if !ok {
+			return nil, errMalformedBER
+		}
+		data = data[hdrLen:]
+		switch t {
+		case tag:
+			*segments = append(*segments, data[:length])
+			return data[length:], nil
+		case tag | tagConstructed:
+			return stringSegments(segments, data, length, tag, depth+1)
+		}
+		return nil, errors.New("der: invalid segment in constructed BER string")
+	})
+}
+
+// joinSegments returns the contents of the primitive string made of
+// segments. The segments of a BIT STRING each start with a count of unused
+// bits, which must be zero in all but the last one.
+func joinSegments(segments [][]byte, tag Tag) ([]byte, error) {
+	if tag != BitString {
+		var n int
+		for _, s := range segments {
+			n += len(s)
+		}
+		contents := make([]byte, 0, n)
+		for _, s := range segments {
+			contents = append(contents, s...)
+		}
+		return contents, nil
+	}
+	contents := []byte{0}
+	for i, s := range segments {
+		if len(s) == 0 || s[0] > 7 || s[0] != 0 && i != len(segments)-1 {
+			return nil, errMalformedBER
+		}
+		contents[0] = s[0]
+		contents = append(contents, s[1:]...)
+	}
+	return contents, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFromBER(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"der", "3008020101a0030101ff", "3008020101a0030101ff"},
+		{"indefinite", "3080020101a0800101ff00000000", "3008020101a0030101ff"},
+		{"long length", "30830000030201" + "01", "3003020101"},
+		{"boolean", "010101", "0101ff"},
+		{"boolean false", "010100", "010100"},
+		{"constructed octet string", "2480" + "040201" + "02" + "2480" + "040103" + "0000" + "0400" + "0000", "0403010203"},
+		{"definite constructed octet string", "2409" + "040101" + "2404" + "04020203", "0403010203"},
+		{"constructed bit string", "2380" + "03020001" + "03020780" + "0000", "0303070180"},
+		{"empty constructed bit string", "2300", "030100"},
+		{"constructed utf8", "2c80" + "0c0161" + "0c0162" + "0000", "0c026162"},
+		{"implicit constructed", "a180" + "2480" + "040101" + "0000" + "0000", "a103040101"},
+		{"high tag", "ff8749" + "80" + "0500" + "0000", "ff874902" + "0500"},
+		{"long", "3080" + "0482" + "0080" + strings.Repeat("aa", 128) + "0000", "3081" + "83" + "048180" + strings.Repeat("aa", 128)},
+	} {
+		got, err := FromBER(mustHex(tt.in))
+		if err != nil {
+			t.Errorf("%s: FromBER(%s): %v", tt.name, tt.in, err)
+			continue
+		}
+		if want := mustHex(tt.want); !bytes.Equal(got, want) {
+			t.Errorf("%s: FromBER(%s) = %x, want %x", tt.name, tt.in, got, want)
+		}
+	}
+}
+
+func TestFromBERErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", "der: malformed BER element"},
+		{"trailing", "05000500", "der: trailing data after BER element"},
+		{"truncated", "30030201", "der: malformed BER element"},
+		{"unterminated", "3080020101", "der: malformed BER element"},
+		{"indefinite primitive", "0480", "der: malformed BER element"},
+		{"bad boolean", "0102ffff", "der: malformed BER element"},
+		{"child overflows", "3003020201", "der: malformed BER element"},
+		{"wrong segment", "2480" + "0c0161" + "0000", "der: invalid segment in constructed BER string"},
+		{"bit string padding", "2380" + "03020701" + "03020000" + "0000", "der: malformed BER element"},
+		{"deep", strings.Repeat("3080", maxBERDepth+2) + strings.Repeat("0000", maxBERDepth+2), "der: BER element nested too deeply"},
+		{"deep string", strings.Repeat("2480", maxBERDepth+2) + strings.Repeat("0000", maxBERDepth+2), "der: BER element nested too deeply"},
+	} {
+		in := mustHex(tt.in)
+		_, err := FromBER(in)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: FromBER(%x) error = %v, want %s", tt.name, in, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,475 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A Builder builds DER encodings by appending elements to a byte slice.
+// Builders either allocate space as needed, or are fixed, which means that
+// they write into a given buffer and produce an error if it is exhausted.
+//
+// The zero value is a usable Builder that allocates space as needed.
+//
+// Primitive values are appended with the Add methods of the Builder.
+// Constructed values, such as a SEQUENCE, are appended by providing a
+// [BuilderContinuation] that writes their contents to a child Builder:
+//
+//	var b der.Builder
+//	b.AddElement(der.Sequence, func(b *der.Builder) {
+//		b.AddInt64(1)
+//		b.AddObjectIdentifier(oid)
+//	})
+//	encoding, err := b.Bytes()
+//
+// The first error, such as an invalid value, is returned by
+// [Builder.Bytes]. Writes that follow it are ignored.
+type Builder struct {
+	err            error
+	result         []byte
+	fixedSize      bool
+	pending        bool
+	inContinuation *bool
+}
+
+// NewBuilder returns a Builder that appends its output to buffer. Like
+// append, it reallocates the buffer if its capacity is exceeded.
+func NewBuilder(buffer []byte) *Builder {
+	return &Builder{result: buffer}
+}
+
+// NewFixedBuilder returns a Builder that appends its output to buffer
+// without reallocating it. Writes that would exceed the capacity of the
+// buffer are an error.
+func NewFixedBuilder(buffer []byte) *Builder {
+	return &Builder{result: buffer, fixedSize: true}
+}
+
+// SetError sets the error to be returned by [Builder.Bytes]. Writes that
+// follow it are ignored.
+func (b *Builder) SetError(err error) {
+	b.err = err
+}
+
+// Bytes returns the bytes written by the Builder, or the error that
+// occurred while building them.
+func (b *Builder) Bytes() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.result, nil
+}
+
+// BytesOrPanic returns the bytes written by the Builder, or panics if an
+// error occurred while building them.
+func (b *Builder) BytesOrPanic() []byte {
+	if b.err != nil {
+		panic(b.err)
+	}
+	return b.result
+}
+
+// A BuilderContinuation writes the contents of a constructed element to
+// the child Builder it is given. The child must not be used after the
+// continuation returns.
+//
+// If the continuation panics with a value of type [BuildError], the error
+// it holds is returned by [Builder.Bytes]. Other panics are not recovered.
+type BuilderContinuation func(child *Builder)
+
+// A BuildError wraps an error. A [BuilderContinuation] may panic with a
+// BuildError to stop building and make [Builder.Bytes] return Err.
+type BuildError struct {
+	Err error
+}
+
+var errChildDone = errors.New("der: use of child Builder after its continuation returned")
+
+func (b *Builder) add(v ...byte) {
+	if b.err != nil {
+		return
+	}
+	if b.pending {
+		panic("der: write to Builder while a child is pending")
+	}
+	if b.fixedSize && len(b.result)+len(v) > cap(b.result) {
+		b.err = errors.New("der: Builder is exceeding its fixed-size buffer")
+		return
+	}
+	b.result = append(b.result, v...)
+}
+
+// addPrimitive appends an element with the given tag and contents.
+func (b *Builder) addPrimitive(tag Tag, contents []byte) {
+	if len(contents) > maxLength {
+		b.err = errors.New("der: element too long")
+		return
+	}
+	var hdr [12]byte
+	b.add(appendHeader(hdr[:0], tag, len(contents))...)
+	b.add(contents...)
+}
+
+func (b *Builder) callContinuation(f BuilderContinuation, child *Builder) {
+	if !*b.inContinuation {
+		*b.inContinuation = true
+		defer func() {
+			*b.inContinuation = false
+			r := recover()
+			if r == nil {
+				return
+			}
+			if buildError, ok := r.(BuildError); ok {
+				b.err = buildError.Err
+			} else {
+				panic(r)
+			}
+		}()
+	}
+	f(child)
+}
+
+// AddElement appends an element with the given tag whose contents are
+// written by f.
+func (b *Builder) AddElement(tag Tag, f BuilderContinuation) {
+	b.addElement(tag, false, f)
+}
+
+// AddSetOf appends a SET OF whose elements are written by f. The elements
+// are sorted by their encodings, as DER requires.
+func (b *Builder) AddSetOf(f BuilderContinuation) {
+	b.addElement(Set, true, f)
+}
+
+func (b *Builder) addElement(tag Tag, sorted bool, f BuilderContinuation) {
+	if b.err != nil {
+		return
+	}
+	// Reserve a single byte for the length and move the contents along
+	// if they turn out to need more.
+	var hdr [7]byte
+	b.add(appendHeader(hdr[:0], tag, 0)...)
+	if b.err != nil {
+		return
+	}
+	offset := len(b.result)
+
+	if b.inContinuation == nil {
+		b.inContinuation = new(bool)
+	}
+	child := &Builder{
+		result:         b.result,
+		fixedSize:      b.fixedSize,
+		inContinuation: b.inContinuation,
+	}
+	b.pending = true
+	b.callContinuation(f, child)
+	b.pending = false
+	result, err := child.result, child.err
+	child.result, child.err = nil, errChildDone
+	if b.err != nil {
+		return
+	}
+	if err != nil {
+		b.err = err
+		return
+	}
+
+	length := len(result) - offset
+	if length > maxLength {
+		b.err = errors.New("der: element too long")
+		return
+	}
+	if sorted && !sortElements(result[offset:]) {
+		b.err = errors.New("der: malformed element in SET OF")
+		return
+	}
+	var lenBuf [5]byte
+	lenBytes := appendLength(lenBuf[:0], length)
+	if extra := len(lenBytes) - 1; extra > 0 {
+		if b.fixedSize && len(result)+extra > cap(result) {
+			b.err = errors.New("der: Builder is exceeding its fixed-size buffer")
+			return
+		}
+		result = append(result, lenBytes[1:]...)
+		copy(result[offset+extra:], result[offset:offset+length])
+	}
+	copy(result[offset-1:], lenBytes)
+	b.result = result
+}
+
+// sortElements sorts the DER elements in b by their encodings, in place.
+func sortElements(b []byte) bool {
+	var elems [][]byte
+	for p := Parser(b); !p.Empty(); {
+		var elem Parser
+		var tag Tag
+		if !p.ReadAnyRawElement(&elem, &tag) {
+			return false
+		}
+		elems = append(elems, elem)
+	}
+	if slices.IsSortedFunc(elems, bytes.Compare) {
+		return true
+	}
+	// The elements alias b, so sort copies of them.
+	buf := bytes.Clone(b)
+	off := 0
+	for i, e := range elems {
+		elems[i] = buf[off : off+len(e)]
+		off += len(e)
+	}
+	slices.SortFunc(elems, bytes.Compare)
+	off = 0
+	for _, e := range elems {
+		off += copy(b[off:], e)
+	}
+	return true
+}
+
+// AddRawElement appends an element that is already DER encoded. It is an
+// error if elem does not hold exactly one element.
+func (b *Builder) AddRawElement(elem []byte) {
+	if b.err != nil {
+		return
+	}
+	_, hdrLen, length, ok := readHeader(elem, false)
+	if !ok || hdrLen+length != len(elem) {
+		b.err = errors.New("der: invalid raw element")
+		return
+	}
+	b.add(elem...)
+}
+
+// AddBytes appends a primitive element with the given tag and contents.
+// It writes primitive values of any type, such as IMPLICIT tagged OCTET
+// STRINGs, without interpreting them.
+func (b *Builder) AddBytes(tag Tag, contents []byte) {
+	if b.err != nil {
+		return
+	}
+	b.addPrimitive(tag, contents)
+}
+
+// AddBoolean appends a BOOLEAN.
+func (b *Builder) AddBoolean(v bool) {
+	if v {
+		b.AddBytes(Boolean, []byte{0xff})
+	} else {
+		b.AddBytes(Boolean, []byte{0})
+	}
+}
+
+func appendInt64(dst []byte, v int64) []byte {
+	n := 1
+	for i := v; i >= 0x80 || i < -0x80; i >>= 8 {
+		n++
+	}
+	for ; n > 0; n-- {
+		dst = append(dst, byte(v>>((n-1)*8)))
+	}
+	return dst
+}
+
+// AddInt64 appends an INTEGER.
+func (b *Builder) AddInt64(v int64) {
+	var buf [8]byte
+	b.AddBytes(Integer, appendInt64(buf[:0], v))
+}
+
+// AddUint64 appends an INTEGER.
+func (b *Builder) AddUint64(v uint64) {
+	var buf [9]byte
+	n := 1
+	for i := v; i >= 0x80; i >>= 8 {
+		n++
+	}
+	c := buf[:0]
+	for ; n > 0; n-- {
+		if n > 8 {
+			c = append(c, 0)
+			continue
+		}
+		c = append(c, byte(v>>((n-1)*8)))
+	}
+	b.AddBytes(Integer, c)
+}
+
+// AddBigInt appends an INTEGER.
+func (b *Builder) AddBigInt(n *big.Int) {
+	if b.err != nil {
+		return
+	}
+	var c []byte
+	switch n.Sign() {
+	case -1:
+		// Convert to two's complement form by subtracting one from the
+		// absolute value and inverting it. If the most significant bit is
+		// not set, a leading 0xff keeps the value negative.
+		m := new(big.Int).Neg(n)
+		m.Sub(m, bigOne)
+		c = m.Bytes()
+		for i := range c {
+			c[i] ^= 0xff
+		}
+		if len(c) == 0 || c[0]&0x80 == 0 {
+			c = append([]byte{0xff}, c...)
+		}
+	case 0:
+		c = []byte{0}
+	case 1:
+		c = n.Bytes()
+		if c[0]&0x80 != 0 {
+			c = append([]byte{0}, c...)
+		}
+	}
+	b.AddBytes(Integer, c)
+}
+
+// AddIntegerBytes appends an INTEGER with the given contents, which must be
+// the minimal big-endian two's complement encoding of its value.
+func (b *Builder) AddIntegerBytes(v []byte) {
+	if !checkInteger(v) {
+		b.SetError(errors.New("der: invalid INTEGER encoding"))
+		return
+	}
+	b.AddBytes(Integer, v)
+}
+
+// AddEnum appends an ENUMERATED value.
+func (b *Builder) AddEnum(v int) {
+	var buf [8]byte
+	b.AddBytes(Enumerated, appendInt64(buf[:0], int64(v)))
+}
+
+func validOID(oid asn1.ObjectIdentifier) bool {
+	if len(oid) < 2 || oid[0] > 2 || oid[0] < 2 && oid[1] >= 40 {
+		return false
+	}
+	for _, v := range oid {
+		if v < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AddObjectIdentifier appends an OBJECT IDENTIFIER.
+func (b *Builder) AddObjectIdentifier(oid asn1.ObjectIdentifier) {
+	if b.err != nil {
+		return
+	}
+	if !validOID(oid) {
+		b.err = fmt.Errorf("der: invalid OBJECT IDENTIFIER %v", oid)
+		return
+	}
+	c := appendBase128(make([]byte, 0, 2*len(oid)), uint64(oid[0]*40+oid[1]))
+	for _, v := range oid[2:] {
+		c = appendBase128(c, uint64(v))
+	}
+	b.AddBytes(ObjectIdentifier, c)
+}
+
+// AddBitString appends a BIT STRING. The unused bits of the last byte of
+// s.Bytes are written as zero.
+func (b *Builder) AddBitString(s asn1.BitString) {
+	if b.err != nil {
+		return
+	}
+	n := (s.BitLength + 7) / 8
+	if s.BitLength < 0 || n > len(s.Bytes) {
+		b.err = errors.New("der: invalid BIT STRING length")
+		return
+	}
+	pad := byte(n*8 - s.BitLength)
+	c := make([]byte, 1+n)
+	c[0] = pad
+	copy(c[1:], s.Bytes[:n])
+	if n > 0 {
+		c[n] &^= 1<<pad - 1
+	}
+	b.AddBytes(BitString, c)
+}
+
+// AddOctetString appends an OCTET STRING.
+func (b *Builder) AddOctetString(v []byte) {
+	b.AddBytes(OctetString, v)
+}
+
+// AddNull appends a NULL.
+func (b *Builder) AddNull() {
+	b.AddBytes(Null, nil)
+}
+
+// AddString appends a string with the given tag.
+//
+// For the UTF8String, PrintableString, IA5String and NumericString tags,
+// it is an error if s holds characters not allowed by the type. A
+// BMPString is converted from UTF-8 to UTF-16. T61String and GeneralString
+// values, and values with tags of other classes, are written unchanged.
+// AddString fails for other UNIVERSAL tags.
+func (b *Builder) AddString(tag Tag, s string) {
+	if b.err != nil {
+		return
+	}
+	if tag == BMPString {
+		if !utf8.ValidString(s) {
+			b.err = errors.New("der: invalid UTF-8 in BMPString")
+			return
+		}
+		u := utf16.Encode([]rune(s))
+		c := make([]byte, 2*len(u))
+		for i, v := range u {
+			c[2*i] = byte(v >> 8)
+			c[2*i+1] = byte(v)
+		}
+		b.AddBytes(tag, c)
+		return
+	}
+	if _, ok := decodeString([]byte(s), tag); !ok {
+		b.err = fmt.Errorf("der: invalid %v %q", tag, s)
+		return
+	}
+	b.AddBytes(tag, []byte(s))
+}
+
+// AddUTCTime appends a UTCTime. The time is converted to UTC, and it is an
+// error if its year is not between 1950 and 2049.
+func (b *Builder) AddUTCTime(t time.Time) {
+	if b.err != nil {
+		return
+	}
+	t = t.UTC()
+	if t.Year() < 1950 || t.Year() >= 2050 {
+		b.err = fmt.Errorf("der: cannot represent %v as a UTCTime", t)
+		return
+	}
+	var buf [len("YYMMDDhhmmssZ")]byte
+	b.AddBytes(UTCTime, t.AppendFormat(buf[:0], utcTimeFormat))
+}
+
+// AddGeneralizedTime appends a GeneralizedTime. The time is converted to
+// UTC, and it is an error if its year is not between 0 and 9999.
+func (b *Builder) AddGeneralizedTime(t time.Time) {
+	if b.err != nil {
+		return
+	}
+	t = t.UTC()
+	if t.Year() < 0 || t.Year() > 9999 {
+		b.err = fmt.Errorf("der: cannot represent %v as a GeneralizedTime", t)
+		return
+	}
+	var buf [len("YYYYMMDDhhmmss.999999999Z")]byte
+	b.AddBytes(GeneralizedTime, t.AppendFormat(buf[:0], generalizedTimeFormat))
+}
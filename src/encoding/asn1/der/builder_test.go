@@ -0,0 +1,264 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuilderValues(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		add  func(*Builder)
+		want string
+	}{
+		{"true", func(b *Builder) { b.AddBoolean(true) }, "0101ff"},
+		{"false", func(b *Builder) { b.AddBoolean(false) }, "010100"},
+		{"int 0", func(b *Builder) { b.AddInt64(0) }, "020100"},
+		{"int 127", func(b *Builder) { b.AddInt64(127) }, "02017f"},
+		{"int 128", func(b *Builder) { b.AddInt64(128) }, "02020080"},
+		{"int -128", func(b *Builder) { b.AddInt64(-128) }, "020180"},
+		{"int -129", func(b *Builder) { b.AddInt64(-129) }, "0202ff7f"},
+		{"int min", func(b *Builder) { b.AddInt64(math.MinInt64) }, "02088000000000000000"},
+		{"uint 0", func(b *Builder) { b.AddUint64(0) }, "020100"},
+		{"uint 255", func(b *Builder) { b.AddUint64(255) }, "020200ff"},
+		{"uint max", func(b *Builder) { b.AddUint64(math.MaxUint64) }, "020900ffffffffffffffff"},
+		{"big 0", func(b *Builder) { b.AddBigInt(new(big.Int)) }, "020100"},
+		{"big -1", func(b *Builder) { b.AddBigInt(big.NewInt(-1)) }, "0201ff"},
+		{"big -256", func(b *Builder) { b.AddBigInt(big.NewInt(-256)) }, "0202ff00"},
+		{"big 2^64", func(b *Builder) { b.AddBigInt(new(big.Int).Lsh(big.NewInt(1), 64)) }, "0209010000000000000000"},
+		{"big -2^63", func(b *Builder) { b.AddBigInt(big.NewInt(math.MinInt64)) }, "02088000000000000000"},
+		{"integer bytes", func(b *Builder) { b.AddIntegerBytes([]byte{0x00, 0x80}) }, "02020080"},
+		{"enum", func(b *Builder) { b.AddEnum(-5) }, "0a01fb"},
+		{"oid", func(b *Builder) { b.AddObjectIdentifier(asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}) }, "06092a864886f70d010101"},
+		{"oid 2.999", func(b *Builder) { b.AddObjectIdentifier(asn1.ObjectIdentifier{2, 999}) }, "06028837"},
+		{"bit string", func(b *Builder) { b.AddBitString(asn1.BitString{Bytes: []byte{0xff, 0xff}, BitLength: 9}) }, "030307ff80"},
+		{"empty bit string", func(b *Builder) { b.AddBitString(asn1.BitString{}) }, "030100"},
+		{"octet string", func(b *Builder) { b.AddOctetString([]byte{1, 2}) }, "04020102"},
+		{"null", func(b *Builder) { b.AddNull() }, "0500"},
+		{"utf8", func(b *Builder) { b.AddString(UTF8String, "€") }, "0c03e282ac"},
+		{"printable", func(b *Builder) { b.AddString(PrintableString, "A-B.") }, "1304412d422e"},
+		{"bmp", func(b *Builder) { b.AddString(BMPString, "é😀") }, "1e0600e9d83dde00"},
+		{"implicit", func(b *Builder) { b.AddString(Tag(2).ContextSpecific(), "a.b") }, "8203612e62"},
+		{"utc", func(b *Builder) { b.AddUTCTime(time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC)) }, "170d3439313233313233353935395a"},
+		{"utc zone", func(b *Builder) {
+			b.AddUTCTime(time.Date(1950, 1, 1, 1, 0, 0, 0, time.FixedZone("", 3600)))
+		}, "170d3530303130313030303030305a"},
+		{"generalized", func(b *Builder) {
+			b.AddGeneralizedTime(time.Date(2000, 1, 1, 0, 0, 0, 5e8, time.UTC))
+		}, "1811" + "32303030303130313030303030302e355a"},
+		{"bytes", func(b *Builder) { b.AddBytes(Tag(1).ContextSpecific(), []byte{0xab}) }, "8101ab"},
+		{"raw", func(b *Builder) { b.AddRawElement(mustHex("3003020101")) }, "3003020101"},
+		{"high tag", func(b *Builder) { b.AddBytes(Tag(1000).Private(), nil) }, "df876800"},
+		{"sequence", func(b *Builder) {
+			b.AddElement(Sequence, func(b *Builder) {
+				b.AddInt64(1)
+				b.AddElement(Tag(0).ContextSpecific().Constructed(), func(b *Builder) {
+					b.AddBoolean(true)
+				})
+			})
+		}, "3008020101a0030101ff"},
+		{"empty sequence", func(b *Builder) { b.AddElement(Sequence, func(*Builder) {}) }, "3000"},
+		{"set of", func(b *Builder) {
+			b.AddSetOf(func(b *Builder) {
+				b.AddInt64(3)
+				b.AddOctetString([]byte{1})
+				b.AddInt64(256)
+				b.AddInt64(2)
+			})
+		}, "310d" + "020102" + "020103" + "02020100" + "040101"},
+	} {
+		var b Builder
+		tt.add(&b)
+		got, err := b.Bytes()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if want := mustHex(tt.want); !bytes.Equal(got, want) {
+			t.Errorf("%s: got %x, want %x", tt.name, got, want)
+		}
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		add  func(*Builder)
+		want string
+	}{
+		{"oid short", func(b *Builder) { b.AddObjectIdentifier(asn1.ObjectIdentifier{1}) }, "der: invalid OBJECT IDENTIFIER 1"},
+		{"oid first", func(b *Builder) { b.AddObjectIdentifier(asn1.ObjectIdentifier{3, 1}) }, "der: invalid OBJECT IDENTIFIER 3.1"},
+		{"oid second", func(b *Builder) { b.AddObjectIdentifier(asn1.ObjectIdentifier{1, 40}) }, "der: invalid OBJECT IDENTIFIER 1.40"},
+		{"oid negative", func(b *Builder) { b.AddObjectIdentifier(asn1.ObjectIdentifier{1, 2, -3}) }, "der: invalid OBJECT IDENTIFIER 1.2.-3"},
+		{"bit string", func(b *Builder) { b.AddBitString(asn1.BitString{Bytes: []byte{1}, BitLength: 9}) }, "der: invalid BIT STRING length"},
+		{"integer bytes", func(b *Builder) { b.AddIntegerBytes([]byte{0, 1}) }, "der: invalid INTEGER encoding"},
+		{"printable", func(b *Builder) { b.AddString(PrintableString, "a*b") }, `der: invalid PrintableString "a*b"`},
+		{"utf8", func(b *Builder) { b.AddString(UTF8String, "\xff") }, `der: invalid UTF8String "\xff"`},
+		{"bmp", func(b *Builder) { b.AddString(BMPString, "\xff") }, "der: invalid UTF-8 in BMPString"},
+		{"octet string as string", func(b *Builder) { b.AddString(OctetString, "x") }, `der: invalid OCTET STRING "x"`},
+		{"utc range", func(b *Builder) { b.AddUTCTime(time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC)) }, "der: cannot represent 2050-01-01 00:00:00 +0000 UTC as a UTCTime"},
+		{"generalized range", func(b *Builder) { b.AddGeneralizedTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)) }, "der: cannot represent 10000-01-01 00:00:00 +0000 UTC as a GeneralizedTime"},
+		{"raw trailing", func(b *Builder) { b.AddRawElement(mustHex("05000500")) }, "der: invalid raw element"},
+		{"raw ber", func(b *Builder) { b.AddRawElement(mustHex("308005000000")) }, "der: invalid raw element"},
+		{"set of malformed", func(b *Builder) {
+			b.AddSetOf(func(b *Builder) { b.add(0x02) })
+		}, "der: malformed element in SET OF"},
+		{"child error", func(b *Builder) {
+			b.AddElement(Sequence, func(b *Builder) { b.AddObjectIdentifier(nil) })
+		}, "der: invalid OBJECT IDENTIFIER "},
+		{"build error", func(b *Builder) {
+			b.AddElement(Sequence, func(b *Builder) {
+				b.AddElement(Sequence, func(b *Builder) {
+					panic(BuildError{errors.New("stop")})
+				})
+			})
+		}, "stop"},
+		{"set error", func(b *Builder) {
+			b.SetError(errors.New("set"))
+			b.AddInt64(1)
+		}, "set"},
+	} {
+		var b Builder
+		tt.add(&b)
+		_, err := b.Bytes()
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestBuilderLongElement(t *testing.T) {
+	for _, n := range []int{0x7f, 0x80, 0xff, 0x100, 0x10000, 0x1000000} {
+		var b Builder
+		b.AddElement(Sequence, func(b *Builder) {
+			b.AddElement(Sequence, func(b *Builder) {
+				b.AddOctetString(make([]byte, n))
+			})
+			b.AddNull()
+		})
+		got := b.BytesOrPanic()
+
+		p := Parser(got)
+		var outer, inner Parser
+		var contents []byte
+		if !p.ReadElement(&outer, Sequence) || !p.Empty() ||
+			!outer.ReadElement(&inner, Sequence) ||
+			!inner.ReadOctetString(&contents) || !inner.Empty() || len(contents) != n ||
+			!outer.ReadNull() || !outer.Empty() {
+			t.Errorf("failed to read back element with %d bytes of contents", n)
+		}
+	}
+}
+
+func TestFixedBuilder(t *testing.T) {
+	buf := make([]byte, 0, 8)
+	b := NewFixedBuilder(buf)
+	b.AddElement(Sequence, func(b *Builder) {
+		b.AddInt64(1)
+		b.AddBoolean(true)
+	})
+	got, err := b.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := mustHex("3006020101" + "0101ff"); !bytes.Equal(got, want) || &got[0] != &buf[:1][0] {
+		t.Errorf("got %x, want %x in the given buffer", got, want)
+	}
+
+	b.AddNull()
+	if _, err := b.Bytes(); err == nil {
+		t.Errorf("writing past the end of a fixed buffer succeeded")
+	}
+
+	// A length that needs more than one byte must also fit.
+	b = NewFixedBuilder(make([]byte, 0, 130))
+	b.AddElement(Sequence, func(b *Builder) { b.AddOctetString(make([]byte, 126)) })
+	if _, err := b.Bytes(); err == nil {
+		t.Errorf("moving contents past the end of a fixed buffer succeeded")
+	}
+}
+
+func TestBuilderMisuse(t *testing.T) {
+	var b Builder
+	var saved *Builder
+	b.AddElement(Sequence, func(child *Builder) {
+		saved = child
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(r.(string), "child is pending") {
+				t.Errorf("writing to the parent within a continuation: recover() = %v", r)
+			}
+		}()
+		b.AddNull()
+	})
+	saved.AddNull()
+	if got, err := b.Bytes(); err != nil || !bytes.Equal(got, mustHex("3000")) {
+		t.Errorf("Bytes() = %x, %v; want 3000, nil", got, err)
+	}
+	if _, err := saved.Bytes(); err != errChildDone {
+		t.Errorf("child Bytes() error = %v, want %v", err, errChildDone)
+	}
+}
+
+// TestBuilderCompatibility checks that the Builder produces the same
+// encodings as encoding/asn1.
+func TestBuilderCompatibility(t *testing.T) {
+	type record struct {
+		N    int64
+		Big  *big.Int
+		OID  asn1.ObjectIdentifier
+		Bits asn1.BitString
+		S    string `asn1:"utf8"`
+		IA5  string `asn1:"ia5"`
+		T    time.Time
+		G    time.Time `asn1:"generalized"`
+		Set  []int     `asn1:"set"`
+	}
+	v := record{
+		N:    1 << 40,
+		Big:  new(big.Int).Lsh(big.NewInt(-3), 100),
+		OID:  asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2},
+		Bits: asn1.BitString{Bytes: []byte{0xa0}, BitLength: 3},
+		S:    "ünïcode",
+		IA5:  "user@example.com",
+		T:    time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+		G:    time.Date(2100, 2, 28, 12, 0, 0, 0, time.UTC),
+		Set:  []int{1, 2},
+	}
+	want, err := asn1.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b Builder
+	b.AddElement(Sequence, func(b *Builder) {
+		b.AddInt64(v.N)
+		b.AddBigInt(v.Big)
+		b.AddObjectIdentifier(v.OID)
+		b.AddBitString(v.Bits)
+		b.AddString(UTF8String, v.S)
+		b.AddString(IA5String, v.IA5)
+		b.AddUTCTime(v.T)
+		b.AddGeneralizedTime(v.G)
+		b.AddSetOf(func(b *Builder) {
+			b.AddInt64(2)
+			b.AddInt64(1)
+		})
+	})
+	got, err := b.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Builder:\n%s\nencoding/asn1:\n%s", hex.Dump(got), hex.Dump(want))
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package der reads and writes ASN.1 data in the Distinguished Encoding
+// Rules (DER) of ITU-T Rec. X.690, and converts data in the Basic Encoding
+// Rules (BER) to DER.
+//
+// Unlike [encoding/asn1], which maps ASN.1 values onto Go types using
+// reflection, this package works on one element at a time: a [Parser]
+// consumes DER elements from a byte slice and a [Builder] appends them to
+// one. Any tag can be read and written, so structures that encoding/asn1
+// cannot describe, such as IMPLICIT tags on arbitrary types, high tag
+// numbers, large SET OF values and ANY DEFINED BY fields, are handled by the
+// caller directly.
+//
+// A Parser does not copy its input. The values it returns, such as the
+// contents of an OCTET STRING, refer to the parsed data.
+package der
+
+import (
+	"strconv"
+)
+
+// A Tag is an ASN.1 tag: a class, a tag number and whether the element
+// is constructed. Tag(n) is the tag of UNIVERSAL class with number n, and
+// the methods of Tag derive the tags of other classes from it, as in
+//
+//	der.Tag(0).ContextSpecific().Constructed() // [0] EXPLICIT
+//
+// Tag numbers must be less than 1<<29.
+type Tag uint32
+
+const (
+	tagClassShift  = 30
+	tagConstructed = 1 << 29
+	tagNumberMask  = tagConstructed - 1
+)
+
+// The tags of the UNIVERSAL types supported by [Parser] and [Builder].
+const (
+	Boolean          Tag = 1
+	Integer          Tag = 2
+	BitString        Tag = 3
+	OctetString      Tag = 4
+	Null             Tag = 5
+	ObjectIdentifier Tag = 6
+	Enumerated       Tag = 10
+	UTF8String       Tag = 12
+	Sequence         Tag = 16 | tagConstructed
+	Set              Tag = 17 | tagConstructed
+	NumericString    Tag = 18
+	PrintableString  Tag = 19
+	T61String        Tag = 20
+	IA5String        Tag = 22
+	UTCTime          Tag = 23
+	GeneralizedTime  Tag = 24
+	GeneralString    Tag = 27
+	BMPString        Tag = 30
+)
+
+// A Class is the class of a [Tag].
+type Class uint8
+
+const (
+	ClassUniversal Class = iota
+	ClassApplication
+	ClassContextSpecific
+	ClassPrivate
+)
+
+// Constructed returns t with the constructed bit set.
+func (t Tag) Constructed() Tag { return t | tagConstructed }
+
+// ContextSpecific returns t with its class set to context-specific.
+func (t Tag) ContextSpecific() Tag { return t.withClass(ClassContextSpecific) }
+
+// Application returns t with its class set to APPLICATION.
+func (t Tag) Application() Tag { return t.withClass(ClassApplication) }
+
+// Private returns t with its class set to PRIVATE.
+func (t Tag) Private() Tag { return t.withClass(ClassPrivate) }
+
+func (t Tag) withClass(c Class) Tag {
+	return t&(tagConstructed|tagNumberMask) | Tag(c)<<tagClassShift
+}
+
+// Class returns the class of t.
+func (t Tag) Class() Class { return Class(t >> tagClassShift) }
+
+// Number returns the tag number of t.
+func (t Tag) Number() uint32 { return uint32(t & tagNumberMask) }
+
+// IsConstructed reports whether t has the constructed bit set.
+func (t Tag) IsConstructed() bool { return t&tagConstructed != 0 }
+
+var universalNames = map[Tag]string{
+	Boolean:          "BOOLEAN",
+	Integer:          "INTEGER",
+	BitString:        "BIT STRING",
+	OctetString:      "OCTET STRING",
+	Null:             "NULL",
+	ObjectIdentifier: "OBJECT IDENTIFIER",
+	Enumerated:       "ENUMERATED",
+	UTF8String:       "UTF8String",
+	Sequence:         "SEQUENCE",
+	Set:              "SET",
+	NumericString:    "NumericString",
+	PrintableString:  "PrintableString",
+	T61String:        "T61String",
+	IA5String:        "IA5String",
+	UTCTime:          "UTCTime",
+	GeneralizedTime:  "GeneralizedTime",
+	GeneralString:    "GeneralString",
+	BMPString:        "BMPString",
+}
+
+// String returns the ASN.1 notation for t, such as "INTEGER", "[0]" or
+// "[APPLICATION 2] (constructed)".
+func (t Tag) String() string {
+	if name, ok := universalNames[t]; ok {
+		return name
+	}
+	var s string
+	switch t.Class() {
+	case ClassUniversal:
+		s = "[UNIVERSAL " + strconv.FormatUint(uint64(t.Number()), 10) + "]"
+	case ClassApplication:
+		s = "[APPLICATION " + strconv.FormatUint(uint64(t.Number()), 10) + "]"
+	case ClassContextSpecific:
+		s = "[" + strconv.FormatUint(uint64(t.Number()), 10) + "]"
+	case ClassPrivate:
+		s = "[PRIVATE " + strconv.FormatUint(uint64(t.Number()), 10) + "]"
+	}
+	if t.IsConstructed() {
+		s += " (constructed)"
+	}
+	return s
+}
+
+// maxLength is the largest length of contents that is accepted, so that
+// lengths fit in an int on all platforms.
+const maxLength = 1<<31 - 1
+
+// appendHeader appends the identifier and length octets of an element
+// with the given tag and length of contents.
+func appendHeader(b []byte, tag Tag, length int) []byte {
+	first := byte(tag.Class()) << 6
+	if tag.IsConstructed() {
+		first |= 0x20
+	}
+	n := tag.Number()
+	if n < 0x1f {
+		b = append(b, first|byte(n))
+	} else {
+		b = append(b, first|0x1f)
+		b = appendBase128(b, uint64(n))
+	}
+	return appendLength(b, length)
+}
+
+func appendLength(b []byte, length int) []byte {
+	if length < 0x80 {
+		return append(b, byte(length))
+	}
+	n := 1
+	for l := length >> 8; l > 0; l >>= 8 {
+		n++
+	}
+	b = append(b, 0x80|byte(n))
+	for ; n > 0; n-- {
+		b = append(b, byte(length>>((n-1)*8)))
+	}
+	return b
+}
+
+// appendBase128 appends v in base 128, most significant group first, with
+// the high bit set on all but the last byte.
+func appendBase128(b []byte, v uint64) []byte {
+	n := 1
+	for i := v >> 7; i > 0; i >>= 7 {
+		n++
+	}
+	for ; n > 1; n-- {
+		b = append(b, 0x80|byte(v>>((n-1)*7)))
+	}
+	return append(b, byte(v&0x7f))
+}
+
+// readTag parses the identifier octets at the start of data, returning
+// the tag and the number of bytes it takes.
+func readTag(data []byte) (tag Tag, n int, ok bool) {
+	if len(data) == 0 {
+		return 0, 0, false
+	}
+	b := data[0]
+	tag = Tag(b>>6) << tagClassShift
+	if b&0x20 != 0 {
+		tag |= tagConstructed
+	}
+	if b&0x1f != 0x1f {
+		tag |= Tag(b & 0x1f)
+		// [UNIVERSAL 0] is reserved for the end-of-contents marker of BER.
+		return tag, 1, tag&^tagConstructed != 0
+	}
+	var num uint64
+	for i := 1; i < len(data); i++ {
+		c := data[i]
+		if num == 0 && c == 0x80 {
+			// The tag number is not minimally encoded.
+			return 0, 0, false
+		}
+		num = num<<7 | uint64(c&0x7f)
+		if num > tagNumberMask {
+			return 0, 0, false
+		}
+		if c&0x80 == 0 {
+			// Tag numbers below 31 must use the single-byte form.
+			if num < 0x1f {
+				return 0, 0, false
+			}
+			return tag | Tag(num), i + 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+// readHeader parses the identifier and length octets at the start of data
+// and checks that data holds the contents they announce. In DER, lengths
+// must be minimally encoded. In BER, they need not be, and constructed
+// elements may use the indefinite form, which is returned as a length of -1.
+func readHeader(data []byte, ber bool) (tag Tag, hdrLen, length int, ok bool) {
+	tag, i, ok := readTag(data)
+	if !ok || i >= len(data) {
+		return 0, 0, 0, false
+	}
+	l := data[i]
+	i++
+	switch {
+	case l < 0x80:
+		length = int(l)
+	case l == 0x80:
+		if !ber || !tag.IsConstructed() {
+			return 0, 0, 0, false
+		}
+		return tag, i, -1, true
+	default:
+		n := int(l & 0x7f)
+		if n > 8 || len(data)-i < n {
+			return 0, 0, 0, false
+		}
+		if !ber && data[i] == 0 {
+			return 0, 0, 0, false
+		}
+		var v uint64
+		for _, c := range data[i : i+n] {
+			v = v<<8 | uint64(c)
+		}
+		if v > maxLength || !ber && v < 0x80 {
+			return 0, 0, 0, false
+		}
+		i += n
+		length = int(v)
+	}
+	if len(data)-i < length {
+		return 0, 0, 0, false
+	}
+	return tag, i, length, true
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestTag(t *testing.T) {
+	for _, tt := range []struct {
+		tag         Tag
+		class       Class
+		number      uint32
+		constructed bool
+		str         string
+		enc         string
+	}{
+		{Integer, ClassUniversal, 2, false, "INTEGER", "02"},
+		{Sequence, ClassUniversal, 16, true, "SEQUENCE", "30"},
+		{Set, ClassUniversal, 17, true, "SET", "31"},
+		{OctetString.Constructed(), ClassUniversal, 4, true, "[UNIVERSAL 4] (constructed)", "24"},
+		{Tag(40), ClassUniversal, 40, false, "[UNIVERSAL 40]", "1f28"},
+		{Tag(0).ContextSpecific(), ClassContextSpecific, 0, false, "[0]", "80"},
+		{Tag(3).ContextSpecific().Constructed(), ClassContextSpecific, 3, true, "[3] (constructed)", "a3"},
+		{Tag(30).Application(), ClassApplication, 30, false, "[APPLICATION 30]", "5e"},
+		{Tag(31).Application(), ClassApplication, 31, false, "[APPLICATION 31]", "5f1f"},
+		{Tag(201).Private().Constructed(), ClassPrivate, 201, true, "[PRIVATE 201] (constructed)", "ff8149"},
+		{Tag(1<<29 - 1).ContextSpecific(), ClassContextSpecific, 1<<29 - 1, false, "[536870911]", "9f81ffffff7f"},
+		{Sequence.ContextSpecific(), ClassContextSpecific, 16, true, "[16] (constructed)", "b0"},
+	} {
+		if got := tt.tag.Class(); got != tt.class {
+			t.Errorf("%v.Class() = %v, want %v", tt.tag, got, tt.class)
+		}
+		if got := tt.tag.Number(); got != tt.number {
+			t.Errorf("%v.Number() = %v, want %v", tt.tag, got, tt.number)
+		}
+		if got := tt.tag.IsConstructed(); got != tt.constructed {
+			t.Errorf("%v.IsConstructed() = %v, want %v", tt.tag, got, tt.constructed)
+		}
+		if got := tt.tag.String(); got != tt.str {
+			t.Errorf("String() = %q, want %q", got, tt.str)
+		}
+		enc := appendHeader(nil, tt.tag, 0)
+		if want := append(mustHex(tt.enc), 0); !bytes.Equal(enc, want) {
+			t.Errorf("%v encodes as %x, want %x", tt.tag, enc, want)
+		}
+		tag, n, ok := readTag(enc)
+		if !ok || tag != tt.tag || n != len(enc)-1 {
+			t.Errorf("readTag(%x) = %v, %d, %v; want %v, %d, true", enc, tag, n, ok, tt.tag, len(enc)-1)
+		}
+	}
+}
+
+func TestReadHeader(t *testing.T) {
+	for _, tt := range []struct {
+		in     string
+		ber    bool
+		tag    Tag
+		hdrLen int
+		length int
+		ok     bool
+	}{
+		{"0400", false, OctetString, 2, 0, true},
+		{"04027f", false, 0, 0, 0, false},
+		{"04017f", false, OctetString, 2, 1, true},
+		{"0481" + "80" + strings.Repeat("00", 128), false, OctetString, 3, 128, true},
+		{"048101ff", false, 0, 0, 0, false}, // non-minimal length
+		{"048101ff", true, OctetString, 3, 1, true},
+		{"04820001ff", true, OctetString, 4, 1, true},
+		{"04820001ff", false, 0, 0, 0, false},
+		{"3080", false, 0, 0, 0, false}, // indefinite length
+		{"3080", true, Sequence, 2, -1, true},
+		{"0480", true, 0, 0, 0, false}, // indefinite primitive
+		{"04ff", true, 0, 0, 0, false},
+		{"0489ffffffffffffffffff", true, 0, 0, 0, false},
+		{"048480000000", true, 0, 0, 0, false}, // too long
+		{"0000", true, 0, 0, 0, false},         // end-of-contents
+		{"1f1e00", false, 0, 0, 0, false},      // low tag number in high form
+		{"1f801f00", false, 0, 0, 0, false},    // non-minimal tag number
+		{"1f8f", false, 0, 0, 0, false},        // truncated tag
+		{"1fa0808080" + "0000", false, 0, 0, 0, false},
+		{"04", false, 0, 0, 0, false},
+		{"", false, 0, 0, 0, false},
+	} {
+		tag, hdrLen, length, ok := readHeader(mustHex(tt.in), tt.ber)
+		if ok != tt.ok || tag != tt.tag || hdrLen != tt.hdrLen || length != tt.length {
+			t.Errorf("readHeader(%s, %v) = %v, %d, %d, %v; want %v, %d, %d, %v",
+				tt.in, tt.ber, tag, hdrLen, length, ok, tt.tag, tt.hdrLen, tt.length, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der_test
+
+import (
+	"encoding/asn1"
+	"encoding/asn1/der"
+	"fmt"
+	"log"
+)
+
+// AuthorityKeyIdentifier is the X.509 extension of RFC 5280, Section 4.2.1.1:
+//
+//	AuthorityKeyIdentifier ::= SEQUENCE {
+//	    keyIdentifier             [0] KeyIdentifier           OPTIONAL,
+//	    authorityCertIssuer       [1] GeneralNames            OPTIONAL,
+//	    authorityCertSerialNumber [2] CertificateSerialNumber OPTIONAL  }
+//
+// The module uses IMPLICIT tags, so [0] replaces the OCTET STRING tag of the
+// KeyIdentifier and [2] replaces the INTEGER tag of the serial number.
+type AuthorityKeyIdentifier struct {
+	KeyID  []byte
+	Serial []byte // two's complement, as read by ReadIntegerBytes
+}
+
+func ExampleBuilder() {
+	aki := AuthorityKeyIdentifier{KeyID: []byte{0x01, 0x02, 0x03}, Serial: []byte{0x7b}}
+
+	var b der.Builder
+	b.AddElement(der.Sequence, func(b *der.Builder) {
+		b.AddBytes(der.Tag(0).ContextSpecific(), aki.KeyID)
+		b.AddBytes(der.Tag(2).ContextSpecific(), aki.Serial)
+	})
+	enc, err := b.Bytes()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%x\n", enc)
+	// Output:
+	// 3008800301020382017b
+}
+
+func ExampleParser() {
+	enc := []byte{0x30, 0x08, 0x80, 0x03, 0x01, 0x02, 0x03, 0x82, 0x01, 0x7b}
+
+	var aki AuthorityKeyIdentifier
+	input := der.Parser(enc)
+	var seq der.Parser
+	if !input.ReadElement(&seq, der.Sequence) || !input.Empty() {
+		log.Fatal("invalid AuthorityKeyIdentifier")
+	}
+	if seq.PeekTag(der.Tag(0).ContextSpecific()) &&
+		!seq.ReadBytes(&aki.KeyID, der.Tag(0).ContextSpecific()) {
+		log.Fatal("invalid keyIdentifier")
+	}
+	if !seq.SkipOptionalElement(der.Tag(1).ContextSpecific().Constructed()) {
+		log.Fatal("invalid authorityCertIssuer")
+	}
+	if seq.PeekTag(der.Tag(2).ContextSpecific()) &&
+		!seq.ReadBytes(&aki.Serial, der.Tag(2).ContextSpecific()) {
+		log.Fatal("invalid authorityCertSerialNumber")
+	}
+	if !seq.Empty() {
+		log.Fatal("trailing data in AuthorityKeyIdentifier")
+	}
+	fmt.Printf("key ID %x, serial %x\n", aki.KeyID, aki.Serial)
+	// Output:
+	// key ID 010203, serial 7b
+}
+
+func ExampleBuilder_AddSetOf() {
+	// Attribute ::= SEQUENCE { type OBJECT IDENTIFIER, values SET OF ANY }
+	var b der.Builder
+	b.AddElement(der.Sequence, func(b *der.Builder) {
+		b.AddObjectIdentifier(asn1.ObjectIdentifier{2, 5, 4, 11})
+		b.AddSetOf(func(b *der.Builder) {
+			b.AddString(der.UTF8String, "Sales")
+			b.AddString(der.UTF8String, "Engineering")
+		})
+	})
+	// The values are sorted by their encodings, so "Sales", whose length
+	// byte is smaller, comes first.
+	fmt.Printf("%x\n", b.BytesOrPanic())
+	// Output:
+	// 301b060355040b31140c0553616c65730c0b456e67696e656572696e67
+}
+
+func ExampleFromBER() {
+	// An OCTET STRING in the constructed, indefinite length form of BER.
+	ber := []byte{0x24, 0x80, 0x04, 0x02, 'h', 'e', 0x04, 0x03, 'l', 'l', 'o', 0x00, 0x00}
+	enc, err := der.FromBER(ber)
+	if err != nil {
+		log.Fatal(err)
+	}
+	p := der.Parser(enc)
+	var s []byte
+	if !p.ReadOctetString(&s) {
+		log.Fatal("invalid OCTET STRING")
+	}
+	fmt.Printf("%x %s\n", enc, s)
+	// Output:
+	// 040568656c6c6f hello
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzFromBER(f *testing.F) {
+	f.Add(mustHex("3080020101a0800101ff00000000"))
+	f.Add(mustHex("2480" + "040201" + "02" + "2480" + "040103" + "0000" + "0400" + "0000"))
+	f.Add(mustHex("2380" + "03020001" + "03020780" + "0000"))
+	f.Add(mustHex("ff8749" + "80" + "0500" + "0000"))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		enc, err := FromBER(b)
+		if err != nil {
+			return
+		}
+		// The result must be a single well-formed DER element that
+		// converts to itself.
+		p := Parser(enc)
+		var elem Parser
+		var tag Tag
+		if !p.ReadAnyRawElement(&elem, &tag) || !p.Empty() {
+			t.Fatalf("FromBER(%x) = %x, which is not a DER element", b, enc)
+		}
+		again, err := FromBER(enc)
+		if err != nil {
+			t.Fatalf("FromBER(%x): %v", enc, err)
+		}
+		if !bytes.Equal(again, enc) {
+			t.Fatalf("FromBER(%x) = %x, want it unchanged", enc, again)
+		}
+
+		// The Builder reproduces it element by element.
+		var bld Builder
+		rebuild(&bld, enc)
+		if got, err := bld.Bytes(); err != nil || !bytes.Equal(got, enc) {
+			t.Fatalf("rebuilding %x = %x, %v", enc, got, err)
+		}
+	})
+}
+
+// rebuild appends the DER elements in data to b, recreating constructed
+// elements with AddElement.
+func rebuild(b *Builder, data Parser) {
+	for !data.Empty() {
+		var contents Parser
+		var tag Tag
+		if !data.ReadAnyElement(&contents, &tag) {
+			b.SetError(errMalformedBER)
+			return
+		}
+		if tag.IsConstructed() {
+			b.AddElement(tag, func(b *Builder) { rebuild(b, contents) })
+		} else {
+			b.AddBytes(tag, contents)
+		}
+	}
+}
@@ -0,0 +1,532 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"encoding/asn1"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A Parser reads DER elements from the byte slice it holds. Each Read
+// method consumes one element from the start of the slice and reports
+// whether it succeeded. A method that fails, because the element is
+// malformed or does not have the expected tag, consumes nothing.
+//
+// Parsers are typically nested: reading a SEQUENCE with [Parser.ReadElement]
+// yields a Parser for its contents, from which its fields are read in turn.
+//
+//	var seq der.Parser
+//	var version int64
+//	var oid asn1.ObjectIdentifier
+//	if !p.ReadElement(&seq, der.Sequence) ||
+//		!seq.ReadInt64(&version) ||
+//		!seq.ReadObjectIdentifier(&oid) ||
+//		!seq.Empty() {
+//		return errors.New("malformed structure")
+//	}
+type Parser []byte
+
+// Empty reports whether p holds no more data.
+func (p Parser) Empty() bool {
+	return len(p) == 0
+}
+
+// PeekTag reports whether the next element in p has the given tag.
+func (p Parser) PeekTag(tag Tag) bool {
+	t, _, ok := readTag(p)
+	return ok && t == tag
+}
+
+// next splits the element at the start of p, returning its tag, the
+// length of its header and the element.
+func (p Parser) next() (tag Tag, hdrLen int, elem []byte, ok bool) {
+	tag, hdrLen, length, ok := readHeader(p, false)
+	if !ok {
+		return 0, 0, nil, false
+	}
+	n := hdrLen + length
+	return tag, hdrLen, p[:n:n], true
+}
+
+func (p *Parser) read(out *Parser, outTag *Tag, tag Tag, anyTag, raw bool) bool {
+	t, hdrLen, elem, ok := p.next()
+	if !ok || !anyTag && t != tag {
+		return false
+	}
+	*p = (*p)[len(elem):]
+	if outTag != nil {
+		*outTag = t
+	}
+	if !raw {
+		elem = elem[hdrLen:]
+	}
+	*out = elem
+	return true
+}
+
+// contents reads an element with the given tag, returning its contents
+// and the data that follows it without consuming it.
+func (p Parser) contents(tag Tag) (contents, rest Parser, ok bool) {
+	t, hdrLen, elem, ok := p.next()
+	if !ok || t != tag {
+		return nil, nil, false
+	}
+	return elem[hdrLen:], p[len(elem):], true
+}
+
+// ReadElement reads an element with the given tag and sets out to its
+// contents.
+func (p *Parser) ReadElement(out *Parser, tag Tag) bool {
+	return p.read(out, nil, tag, false, false)
+}
+
+// ReadRawElement reads an element with the given tag and sets out to the
+// whole element, including its tag and length.
+func (p *Parser) ReadRawElement(out *Parser, tag Tag) bool {
+	return p.read(out, nil, tag, false, true)
+}
+
+// ReadAnyElement reads an element with any tag, sets outTag to its tag and
+// out to its contents.
+func (p *Parser) ReadAnyElement(out *Parser, outTag *Tag) bool {
+	return p.read(out, outTag, 0, true, false)
+}
+
+// ReadAnyRawElement reads an element with any tag, sets outTag to its tag
+// and out to the whole element, including its tag and length.
+func (p *Parser) ReadAnyRawElement(out *Parser, outTag *Tag) bool {
+	return p.read(out, outTag, 0, true, true)
+}
+
+// ReadOptionalElement reads an element with the given tag if it is next
+// in p. It sets present to whether it was there and, if so, out to its
+// contents. It reports false only if the element is malformed.
+func (p *Parser) ReadOptionalElement(out *Parser, present *bool, tag Tag) bool {
+	*present = p.PeekTag(tag)
+	if !*present {
+		return true
+	}
+	return p.ReadElement(out, tag)
+}
+
+// SkipElement reads an element with the given tag and discards it.
+func (p *Parser) SkipElement(tag Tag) bool {
+	var unused Parser
+	return p.ReadElement(&unused, tag)
+}
+
+// SkipOptionalElement discards the next element in p if it has the given
+// tag. It reports false only if the element is malformed.
+func (p *Parser) SkipOptionalElement(tag Tag) bool {
+	if !p.PeekTag(tag) {
+		return true
+	}
+	return p.SkipElement(tag)
+}
+
+// ReadBytes reads an element with the given tag and sets out to its
+// contents. It reads primitive values of any type, such as IMPLICIT tagged
+// OCTET STRINGs, without interpreting them.
+func (p *Parser) ReadBytes(out *[]byte, tag Tag) bool {
+	var c Parser
+	if !p.ReadElement(&c, tag) {
+		return false
+	}
+	*out = c
+	return true
+}
+
+// ReadBoolean reads a BOOLEAN into out.
+func (p *Parser) ReadBoolean(out *bool) bool {
+	c, rest, ok := p.contents(Boolean)
+	if !ok || len(c) != 1 {
+		return false
+	}
+	switch c[0] {
+	case 0:
+		*out = false
+	case 0xff:
+		*out = true
+	default:
+		return false
+	}
+	*p = rest
+	return true
+}
+
+// checkInteger reports whether b is the minimal two's complement encoding
+// of an INTEGER.
+func checkInteger(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	if len(b) == 1 {
+		return true
+	}
+	if b[0] == 0 && b[1]&0x80 == 0 || b[0] == 0xff && b[1]&0x80 == 0x80 {
+		return false
+	}
+	return true
+}
+
+func int64Value(b []byte) (int64, bool) {
+	if len(b) > 8 {
+		return 0, false
+	}
+	var v int64
+	for _, c := range b {
+		v = v<<8 | int64(c)
+	}
+	// Sign-extend the result.
+	shift := 64 - 8*len(b)
+	return v << shift >> shift, true
+}
+
+// ReadInt64 reads an INTEGER into out. It fails if the value does not fit
+// in an int64.
+func (p *Parser) ReadInt64(out *int64) bool {
+	c, rest, ok := p.contents(Integer)
+	if !ok || !checkInteger(c) {
+		return false
+	}
+	v, ok := int64Value(c)
+	if !ok {
+		return false
+	}
+	*out = v
+	*p = rest
+	return true
+}
+
+// ReadUint64 reads an INTEGER into out. It fails if the value is negative
+// or does not fit in a uint64.
+func (p *Parser) ReadUint64(out *uint64) bool {
+	c, rest, ok := p.contents(Integer)
+	if !ok || !checkInteger(c) || c[0]&0x80 != 0 {
+		return false
+	}
+	if len(c) == 9 && c[0] == 0 {
+		c = c[1:]
+	}
+	if len(c) > 8 {
+		return false
+	}
+	var v uint64
+	for _, b := range c {
+		v = v<<8 | uint64(b)
+	}
+	*out = v
+	*p = rest
+	return true
+}
+
+var bigOne = big.NewInt(1)
+
+// ReadBigInt reads an INTEGER into out.
+func (p *Parser) ReadBigInt(out *big.Int) bool {
+	c, rest, ok := p.contents(Integer)
+	if !ok || !checkInteger(c) {
+		return false
+	}
+	if c[0]&0x80 != 0 {
+		// Negate the two's complement form: invert and add one.
+		neg := make([]byte, len(c))
+		for i, b := range c {
+			neg[i] = ^b
+		}
+		out.SetBytes(neg)
+		out.Add(out, bigOne)
+		out.Neg(out)
+	} else {
+		out.SetBytes(c)
+	}
+	*p = rest
+	return true
+}
+
+// ReadIntegerBytes reads an INTEGER and sets out to its contents, the
+// minimal big-endian two's complement encoding of its value. It can be
+// used to read values such as certificate serial numbers without
+// allocating.
+func (p *Parser) ReadIntegerBytes(out *[]byte) bool {
+	c, rest, ok := p.contents(Integer)
+	if !ok || !checkInteger(c) {
+		return false
+	}
+	*out = c
+	*p = rest
+	return true
+}
+
+// ReadEnum reads an ENUMERATED value into out.
+func (p *Parser) ReadEnum(out *int) bool {
+	c, rest, ok := p.contents(Enumerated)
+	if !ok || !checkInteger(c) {
+		return false
+	}
+	v, ok := int64Value(c)
+	if !ok || int64(int(v)) != v {
+		return false
+	}
+	*out = int(v)
+	*p = rest
+	return true
+}
+
+// readBase128 reads a base 128 integer, as used by OBJECT IDENTIFIER
+// components, from the start of b.
+func readBase128(b []byte) (v int, n int, ok bool) {
+	var ret int64
+	for i, c := range b {
+		if i == 0 && c == 0x80 {
+			// Not minimally encoded.
+			return 0, 0, false
+		}
+		ret = ret<<7 | int64(c&0x7f)
+		if ret > math.MaxInt32 {
+			return 0, 0, false
+		}
+		if c&0x80 == 0 {
+			return int(ret), i + 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+// ReadObjectIdentifier reads an OBJECT IDENTIFIER into out. Components
+// must be less than 1<<31.
+func (p *Parser) ReadObjectIdentifier(out *asn1.ObjectIdentifier) bool {
+	c, rest, ok := p.contents(ObjectIdentifier)
+	if !ok || len(c) == 0 {
+		return false
+	}
+	n := 1
+	for _, b := range c {
+		if b&0x80 == 0 {
+			n++
+		}
+	}
+	oid := make(asn1.ObjectIdentifier, 0, n)
+	for i := 0; i < len(c); {
+		v, m, ok := readBase128(c[i:])
+		if !ok {
+			return false
+		}
+		if i == 0 {
+			// The first subidentifier encodes the first two components.
+			if v < 80 {
+				oid = append(oid, v/40, v%40)
+			} else {
+				oid = append(oid, 2, v-80)
+			}
+		} else {
+			oid = append(oid, v)
+		}
+		i += m
+	}
+	*out = oid
+	*p = rest
+	return true
+}
+
+// ReadBitString reads a BIT STRING into out. The unused bits of its last
+// byte must be zero.
+func (p *Parser) ReadBitString(out *asn1.BitString) bool {
+	c, rest, ok := p.contents(BitString)
+	if !ok || len(c) == 0 {
+		return false
+	}
+	pad := c[0]
+	if pad > 7 || len(c) == 1 && pad > 0 || c[len(c)-1]&(1<<pad-1) != 0 {
+		return false
+	}
+	out.Bytes = c[1:]
+	out.BitLength = (len(c)-1)*8 - int(pad)
+	*p = rest
+	return true
+}
+
+// ReadOctetString reads an OCTET STRING and sets out to its contents.
+func (p *Parser) ReadOctetString(out *[]byte) bool {
+	return p.ReadBytes(out, OctetString)
+}
+
+// ReadNull reads a NULL.
+func (p *Parser) ReadNull() bool {
+	c, rest, ok := p.contents(Null)
+	if !ok || len(c) != 0 {
+		return false
+	}
+	*p = rest
+	return true
+}
+
+// ReadString reads a string with the given tag into out.
+//
+// For the UTF8String, PrintableString, IA5String and NumericString tags,
+// the contents must hold only the characters allowed by the type. A
+// BMPString is converted from UTF-16 to UTF-8. T61String and GeneralString
+// contents are returned unchanged. For tags of other classes, such as the
+// IMPLICIT tags of GeneralName, the contents are returned without being
+// checked. ReadString fails for other UNIVERSAL tags.
+func (p *Parser) ReadString(out *string, tag Tag) bool {
+	c, rest, ok := p.contents(tag)
+	if !ok {
+		return false
+	}
+	s, ok := decodeString(c, tag)
+	if !ok {
+		return false
+	}
+	*out = s
+	*p = rest
+	return true
+}
+
+func decodeString(b []byte, tag Tag) (string, bool) {
+	if tag.Class() != ClassUniversal {
+		return string(b), true
+	}
+	switch tag {
+	case UTF8String:
+		if !utf8.Valid(b) {
+			return "", false
+		}
+	case PrintableString:
+		for _, c := range b {
+			if !isPrintable(c) {
+				return "", false
+			}
+		}
+	case IA5String:
+		for _, c := range b {
+			if c >= utf8.RuneSelf {
+				return "", false
+			}
+		}
+	case NumericString:
+		for _, c := range b {
+			if c != ' ' && (c < '0' || c > '9') {
+				return "", false
+			}
+		}
+	case BMPString:
+		if len(b)%2 != 0 {
+			return "", false
+		}
+		s := make([]rune, 0, len(b)/2)
+		for i := 0; i < len(b); i += 2 {
+			r := rune(b[i])<<8 | rune(b[i+1])
+			if utf16.IsSurrogate(r) {
+				if i+4 > len(b) {
+					return "", false
+				}
+				r = utf16.DecodeRune(r, rune(b[i+2])<<8|rune(b[i+3]))
+				if r == utf8.RuneError {
+					return "", false
+				}
+				i += 2
+			}
+			s = append(s, r)
+		}
+		return string(s), true
+	case T61String, GeneralString:
+	default:
+		return "", false
+	}
+	return string(b), true
+}
+
+// isPrintable reports whether c is in the character set of PrintableString.
+func isPrintable(c byte) bool {
+	return 'a' <= c && c <= 'z' ||
+		'A' <= c && c <= 'Z' ||
+		'0' <= c && c <= '9' ||
+		'\'' <= c && c <= ')' ||
+		'+' <= c && c <= '/' ||
+		c == ' ' ||
+		c == ':' ||
+		c == '=' ||
+		c == '?'
+}
+
+const (
+	utcTimeFormat         = "060102150405Z0700"
+	generalizedTimeFormat = "20060102150405.999999999Z0700"
+)
+
+// ReadUTCTime reads a UTCTime into out. As required by DER, the time must
+// include seconds and be in UTC. Two-digit years from 50 to 99 are in the
+// twentieth century, as in RFC 5280.
+func (p *Parser) ReadUTCTime(out *time.Time) bool {
+	c, rest, ok := p.contents(UTCTime)
+	if !ok {
+		return false
+	}
+	t, ok := parseUTCTime(c)
+	if !ok {
+		return false
+	}
+	*out = t
+	*p = rest
+	return true
+}
+
+func parseUTCTime(b []byte) (time.Time, bool) {
+	if len(b) != len("YYMMDDhhmmssZ") || b[len(b)-1] != 'Z' {
+		return time.Time{}, false
+	}
+	s := string(b)
+	t, err := time.Parse(utcTimeFormat, s)
+	if err != nil || t.Format(utcTimeFormat) != s {
+		return time.Time{}, false
+	}
+	if t.Year() >= 2050 {
+		t = t.AddDate(-100, 0, 0)
+	}
+	return t, true
+}
+
+// ReadGeneralizedTime reads a GeneralizedTime into out. As required by DER,
+// the time must be in UTC, and a fraction of a second must not have
+// trailing zeros.
+func (p *Parser) ReadGeneralizedTime(out *time.Time) bool {
+	c, rest, ok := p.contents(GeneralizedTime)
+	if !ok {
+		return false
+	}
+	t, ok := parseGeneralizedTime(c)
+	if !ok {
+		return false
+	}
+	*out = t
+	*p = rest
+	return true
+}
+
+func parseGeneralizedTime(b []byte) (time.Time, bool) {
+	if len(b) < len("YYYYMMDDhhmmssZ") || b[len(b)-1] != 'Z' {
+		return time.Time{}, false
+	}
+	s := string(b)
+	t, err := time.Parse(generalizedTimeFormat, s)
+	if err != nil || t.Format(generalizedTimeFormat) != s {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ReadTime reads either a UTCTime or a GeneralizedTime into out, as
+// used by the Time type of RFC 5280 and RFC 5652.
+func (p *Parser) ReadTime(out *time.Time) bool {
+	if p.PeekTag(UTCTime) {
+		return p.ReadUTCTime(out)
+	}
+	return p.ReadGeneralizedTime(out)
+}
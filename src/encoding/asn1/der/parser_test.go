@@ -0,0 +1,351 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package der
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type readTest struct {
+	in   string
+	ok   bool
+	want any
+}
+
+// runReadTests calls read on a Parser holding each input followed by a
+// trailing byte, and checks its result and that it consumed exactly the
+// first element on success and nothing on failure.
+func runReadTests[T any](t *testing.T, name string, tests []readTest, read func(*Parser, *T) bool) {
+	t.Helper()
+	for _, tt := range tests {
+		in := append(mustHex(tt.in), 0xee)
+		p := Parser(in)
+		var out T
+		ok := read(&p, &out)
+		if ok != tt.ok {
+			t.Errorf("%s(%s) = %v, want %v", name, tt.in, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			if len(p) != len(in) {
+				t.Errorf("%s(%s) failed but consumed input", name, tt.in)
+			}
+			continue
+		}
+		if !bytes.Equal(p, []byte{0xee}) {
+			t.Errorf("%s(%s) left %x", name, tt.in, []byte(p))
+		}
+		if !reflect.DeepEqual(out, tt.want) {
+			t.Errorf("%s(%s) = %#v, want %#v", name, tt.in, out, tt.want)
+		}
+	}
+}
+
+func TestReadBoolean(t *testing.T) {
+	runReadTests(t, "ReadBoolean", []readTest{
+		{"0101ff", true, true},
+		{"010100", true, false},
+		{"010101", false, nil},
+		{"0100", false, nil},
+		{"01020000", false, nil},
+		{"0201ff", false, nil},
+	}, (*Parser).ReadBoolean)
+}
+
+func TestReadInt64(t *testing.T) {
+	runReadTests(t, "ReadInt64", []readTest{
+		{"020100", true, int64(0)},
+		{"02017f", true, int64(127)},
+		{"02020080", true, int64(128)},
+		{"020180", true, int64(-128)},
+		{"0202ff7f", true, int64(-129)},
+		{"02087fffffffffffffff", true, int64(math.MaxInt64)},
+		{"02088000000000000000", true, int64(math.MinInt64)},
+		{"0209008000000000000000", false, nil},
+		{"0200", false, nil},
+		{"0202007f", false, nil},
+		{"0202ff80", false, nil},
+		{"0a0101", false, nil},
+	}, (*Parser).ReadInt64)
+}
+
+func TestReadUint64(t *testing.T) {
+	runReadTests(t, "ReadUint64", []readTest{
+		{"020100", true, uint64(0)},
+		{"02020080", true, uint64(128)},
+		{"020900ffffffffffffffff", true, uint64(math.MaxUint64)},
+		{"020a01000000000000000000", false, nil},
+		{"0201ff", false, nil},
+		{"0202007f", false, nil},
+	}, (*Parser).ReadUint64)
+}
+
+func TestReadBigInt(t *testing.T) {
+	runReadTests(t, "ReadBigInt", []readTest{
+		{"020100", true, "0"},
+		{"0201ff", true, "-1"},
+		{"020900ffffffffffffffff", true, "18446744073709551615"},
+		{"0209ff0000000000000000", true, "-18446744073709551616"},
+		{"0202ff80", false, nil},
+	}, func(p *Parser, out *string) bool {
+		var n big.Int
+		if !p.ReadBigInt(&n) {
+			return false
+		}
+		*out = n.String()
+		return true
+	})
+}
+
+func TestReadIntegerBytes(t *testing.T) {
+	runReadTests(t, "ReadIntegerBytes", []readTest{
+		{"020100", true, []byte{0}},
+		{"020900ffffffffffffffff", true, mustHex("00ffffffffffffffff")},
+		{"02020001", false, nil},
+	}, (*Parser).ReadIntegerBytes)
+}
+
+func TestReadEnum(t *testing.T) {
+	runReadTests(t, "ReadEnum", []readTest{
+		{"0a0105", true, 5},
+		{"0a01fb", true, -5},
+		{"020105", false, nil},
+		{"0a020005", false, nil},
+	}, (*Parser).ReadEnum)
+}
+
+func TestReadObjectIdentifier(t *testing.T) {
+	runReadTests(t, "ReadObjectIdentifier", []readTest{
+		{"06092a864886f70d010101", true, asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}},
+		{"0603550403", true, asn1.ObjectIdentifier{2, 5, 4, 3}},
+		{"060100", true, asn1.ObjectIdentifier{0, 0}},
+		{"06028837", true, asn1.ObjectIdentifier{2, 999}},
+		{"060587ffffff7f", true, asn1.ObjectIdentifier{2, math.MaxInt32 - 80}},
+		{"060588808080" + "00", false, nil}, // too large
+		{"0600", false, nil},
+		{"060255", false, nil},
+		{"06025580", false, nil}, // truncated component
+		{"0603558001", false, nil},
+	}, (*Parser).ReadObjectIdentifier)
+}
+
+func TestReadBitString(t *testing.T) {
+	runReadTests(t, "ReadBitString", []readTest{
+		{"030100", true, asn1.BitString{Bytes: []byte{}, BitLength: 0}},
+		{"030200ff", true, asn1.BitString{Bytes: []byte{0xff}, BitLength: 8}},
+		{"030307ff80", true, asn1.BitString{Bytes: mustHex("ff80"), BitLength: 9}},
+		{"030307ff81", false, nil}, // unused bits are not zero
+		{"030101", false, nil},
+		{"030208ff", false, nil},
+		{"0300", false, nil},
+	}, (*Parser).ReadBitString)
+}
+
+func TestReadOctetString(t *testing.T) {
+	runReadTests(t, "ReadOctetString", []readTest{
+		{"0400", true, []byte{}},
+		{"0403010203", true, []byte{1, 2, 3}},
+		{"2403040101", false, nil}, // constructed
+	}, (*Parser).ReadOctetString)
+}
+
+func TestReadNull(t *testing.T) {
+	runReadTests(t, "ReadNull", []readTest{
+		{"0500", true, struct{}{}},
+		{"050100", false, nil},
+	}, func(p *Parser, _ *struct{}) bool { return p.ReadNull() })
+}
+
+func TestReadString(t *testing.T) {
+	for _, tt := range []struct {
+		tag  Tag
+		in   string
+		ok   bool
+		want string
+	}{
+		{UTF8String, "0c03e282ac", true, "€"},
+		{UTF8String, "0c01ff", false, ""},
+		{PrintableString, "1304412d422e", true, "A-B."},
+		{PrintableString, "13012a", false, ""},
+		{PrintableString, "130126", false, ""},
+		{IA5String, "160461406222", true, "a@b\""},
+		{IA5String, "160180", false, ""},
+		{NumericString, "12023120", true, "1 "},
+		{NumericString, "120141", false, ""},
+		{BMPString, "1e040041d83d", false, ""},
+		{BMPString, "1e0600e9d83dde00", true, "é😀"},
+		{BMPString, "1e0300", false, ""},
+		{T61String, "1401ff", true, "\xff"},
+		{Tag(2).ContextSpecific(), "8203612e62", true, "a.b"},
+		{OctetString, "0400", false, ""},
+		{UTF8String, "130161", false, ""},
+	} {
+		p := Parser(mustHex(tt.in))
+		var s string
+		ok := p.ReadString(&s, tt.tag)
+		if ok != tt.ok || s != tt.want {
+			t.Errorf("ReadString(%s, %v) = %q, %v; want %q, %v", tt.in, tt.tag, s, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReadTime(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+	}
+	runReadTests(t, "ReadUTCTime", []readTest{
+		{"170d3439313233313233353935395a", true, date(2049, 12, 31, 23, 59, 59, 0)},
+		{"170d3530303130313030303030305a", true, date(1950, 1, 1, 0, 0, 0, 0)},
+		{"170b393130353036323334355a", false, nil},             // no seconds
+		{"17113931303530363233343534302b30313030", false, nil}, // offset
+		{"170d3931303533323233343534305a", false, nil},         // day 32
+	}, (*Parser).ReadUTCTime)
+
+	runReadTests(t, "ReadGeneralizedTime", []readTest{
+		{"180f32303530303130313030303030305a", true, date(2050, 1, 1, 0, 0, 0, 0)},
+		{"1811323030303031303130303030303030352e355a", false, nil},
+		{"1811" + "32303030303130313030303030302e355a", true, date(2000, 1, 1, 0, 0, 0, 5e8)},
+		{"1812" + "32303030303130313030303030302e35305a", false, nil}, // trailing zero
+		{"1811" + "32303030303130313030303030302c355a", false, nil},   // comma
+		{"1813" + "32303030303130313030303030302b30313030", false, nil},
+		{"180d" + "323030303031303130303030", false, nil},
+	}, (*Parser).ReadGeneralizedTime)
+
+	runReadTests(t, "ReadTime", []readTest{
+		{"170d3439313233313233353935395a", true, date(2049, 12, 31, 23, 59, 59, 0)},
+		{"180f32303530303130313030303030305a", true, date(2050, 1, 1, 0, 0, 0, 0)},
+		{"0400", false, nil},
+	}, (*Parser).ReadTime)
+}
+
+func TestReadElement(t *testing.T) {
+	// SEQUENCE { INTEGER 1, [0] EXPLICIT { BOOLEAN TRUE }, [1] IMPLICIT OCTET STRING 'ab' }
+	in := mustHex("300c020101a0030101ff8102abcd")
+	p := Parser(in)
+
+	var seq Parser
+	if p.ReadElement(&seq, Set) {
+		t.Fatal("ReadElement read SEQUENCE as SET")
+	}
+	if !p.PeekTag(Sequence) || p.PeekTag(Integer) {
+		t.Fatal("PeekTag mismatch")
+	}
+	if !p.ReadElement(&seq, Sequence) || !p.Empty() {
+		t.Fatal("ReadElement(Sequence) failed")
+	}
+	if !bytes.Equal(seq, in[2:]) || cap(seq) != len(seq) {
+		t.Errorf("contents = %x (cap %d), want %x", []byte(seq), cap(seq), in[2:])
+	}
+
+	var raw Parser
+	var tag Tag
+	if !seq.ReadAnyRawElement(&raw, &tag) || tag != Integer || !bytes.Equal(raw, mustHex("020101")) {
+		t.Errorf("ReadAnyRawElement = %x, %v", []byte(raw), tag)
+	}
+
+	var explicit Parser
+	var present bool
+	if !seq.ReadOptionalElement(&explicit, &present, Tag(2).ContextSpecific().Constructed()) || present {
+		t.Errorf("ReadOptionalElement found absent element")
+	}
+	if !seq.ReadOptionalElement(&explicit, &present, Tag(0).ContextSpecific().Constructed()) || !present {
+		t.Fatalf("ReadOptionalElement did not find [0]")
+	}
+	var v bool
+	if !explicit.ReadBoolean(&v) || !v || !explicit.Empty() {
+		t.Errorf("[0] EXPLICIT BOOLEAN: %v", v)
+	}
+
+	if !seq.SkipOptionalElement(Tag(0).ContextSpecific()) {
+		t.Errorf("SkipOptionalElement failed")
+	}
+	var b []byte
+	if !seq.ReadBytes(&b, Tag(1).ContextSpecific()) || !bytes.Equal(b, []byte{0xab, 0xcd}) || !seq.Empty() {
+		t.Errorf("ReadBytes = %x", b)
+	}
+
+	// Truncated and trailing elements.
+	p = Parser(mustHex("3004020101"))
+	if p.ReadElement(&seq, Sequence) || len(p) != 5 {
+		t.Errorf("ReadElement read a truncated element")
+	}
+	p = Parser(mustHex("020101020102"))
+	if !p.SkipElement(Integer) || !p.ReadAnyElement(&raw, &tag) || tag != Integer || !bytes.Equal(raw, []byte{2}) {
+		t.Errorf("reading consecutive elements failed")
+	}
+}
+
+// TestReadCompatibility checks that values encoded by encoding/asn1 read
+// back as the same values.
+func TestReadCompatibility(t *testing.T) {
+	type record struct {
+		N     int64
+		Big   *big.Int
+		OID   asn1.ObjectIdentifier
+		Bits  asn1.BitString
+		Bytes []byte
+		S     string `asn1:"utf8"`
+		P     string `asn1:"printable"`
+		IA5   string `asn1:"ia5"`
+		T     time.Time
+		G     time.Time `asn1:"generalized"`
+		Flag  bool
+		Opt   int `asn1:"optional,explicit,tag:5"`
+	}
+	want := record{
+		N:     -1 << 40,
+		Big:   new(big.Int).Lsh(big.NewInt(-3), 100),
+		OID:   asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129},
+		Bits:  asn1.BitString{Bytes: []byte{0xa0}, BitLength: 3},
+		Bytes: []byte("bytes"),
+		S:     "ünïcode",
+		P:     "Printable",
+		IA5:   "user@example.com",
+		T:     time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+		G:     time.Date(2100, 2, 28, 12, 0, 0, 0, time.UTC),
+		Flag:  true,
+		Opt:   7,
+	}
+	enc, err := asn1.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got record
+	got.Big = new(big.Int)
+	p := Parser(enc)
+	var seq, opt Parser
+	var present bool
+	if !p.ReadElement(&seq, Sequence) ||
+		!seq.ReadInt64(&got.N) ||
+		!seq.ReadBigInt(got.Big) ||
+		!seq.ReadObjectIdentifier(&got.OID) ||
+		!seq.ReadBitString(&got.Bits) ||
+		!seq.ReadOctetString(&got.Bytes) ||
+		!seq.ReadString(&got.S, UTF8String) ||
+		!seq.ReadString(&got.P, PrintableString) ||
+		!seq.ReadString(&got.IA5, IA5String) ||
+		!seq.ReadTime(&got.T) ||
+		!seq.ReadTime(&got.G) ||
+		!seq.ReadBoolean(&got.Flag) ||
+		!seq.ReadOptionalElement(&opt, &present, Tag(5).ContextSpecific().Constructed()) || !present ||
+		!seq.Empty() || !p.Empty() {
+		t.Fatalf("failed to read %x", enc)
+	}
+	var n int64
+	if !opt.ReadInt64(&n) || !opt.Empty() {
+		t.Fatalf("failed to read [5] EXPLICIT INTEGER")
+	}
+	got.Opt = int(n)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("read %+v, want %+v", got, want)
+	}
+}
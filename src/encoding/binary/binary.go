@@ -15,10 +15,19 @@
 // For a specification, see
 // https://developers.google.com/protocol-buffers/docs/encoding.
 //
-// This package favors simplicity over efficiency. Clients that require
-// high-performance serialization, especially for large data structures,
-// should look at more advanced solutions such as the [encoding/gob]
-// package or [google.golang.org/protobuf] for protocol buffers.
+// The message functions and types, such as [AppendMessage] and
+// [MessageDecoder], encode and decode structs of any size in the same
+// wire format as protocol buffers, numbering the fields with struct
+// tags so that the encoder and decoder can add and remove fields
+// independently.
+//
+// The fixed-size functions, [Read] and [Write] in particular, favor
+// simplicity over efficiency. Clients that require high-performance
+// serialization of large data structures should use the message
+// functions, which cache the layout of each struct type and append to a
+// caller's buffer, or look at more advanced solutions such as the
+// [encoding/gob] package or [google.golang.org/protobuf] for protocol
+// buffers.
 package binary
 
 import (
@@ -185,3 +185,36 @@ func ExampleVarint() {
 	// 63
 	// 64
 }
+
+func ExampleAppendMessage() {
+	type Point struct {
+		X     int32  `binary:"1,zigzag"`
+		Y     int32  `binary:"2,zigzag"`
+		Label string `binary:"3"`
+	}
+	type Path struct {
+		Points []Point `binary:"1"`
+		Closed bool    `binary:"2"`
+	}
+
+	path := Path{Points: []Point{{X: 1, Y: 2, Label: "a"}, {X: -1}}, Closed: true}
+	buf, err := binary.AppendMessage(nil, path)
+	if err != nil {
+		fmt.Println("binary.AppendMessage failed:", err)
+	}
+	fmt.Printf("% x\n", buf)
+
+	// An older version of Path, without the Closed field,
+	// decodes the fields it knows.
+	type OldPath struct {
+		Points []Point `binary:"1"`
+	}
+	var old OldPath
+	if err := binary.UnmarshalMessage(buf, &old); err != nil {
+		fmt.Println("binary.UnmarshalMessage failed:", err)
+	}
+	fmt.Printf("%+v\n", old)
+	// Output:
+	// 0a 07 08 02 10 04 1a 01 61 0a 02 08 01 10 01
+	// {Points:[{X:1 Y:2 Label:a} {X:-1 Y:0 Label:}]}
+}
@@ -0,0 +1,1031 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binary
+
+// This file implements the encoding of structs as messages in the wire
+// format of protocol buffers, described at
+// https://protobuf.dev/programming-guides/encoding/.
+//
+// A message is a sequence of fields. Each field starts with a varint key
+// holding the field number and the wire type, which is enough to skip
+// the value of a field the decoder does not know about:
+// - wireVarint: an unsigned varint
+// - wireFixed64: 8 bytes, in little-endian order
+// - wireBytes: a varint length followed by that many bytes
+// - wireFixed32: 4 bytes, in little-endian order
+// Wire types 3 and 4 delimit groups, which are deprecated and not
+// supported.
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"unsafe"
+)
+
+// Wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+const maxFieldNumber = 1<<29 - 1
+
+// maxMessageDepth is the maximum nesting depth of embedded messages
+// that UnmarshalMessage decodes, as in the Go protocol buffers package.
+const maxMessageDepth = 10000
+
+// startDetectingCyclesAfter is the nesting depth of embedded messages
+// after which AppendMessage checks for cycles of pointers, slices and maps.
+const startDetectingCyclesAfter = 1000
+
+var (
+	errTruncated = errors.New("binary: truncated message")
+	errTooDeep   = errors.New("binary: exceeded max depth")
+)
+
+// AppendMessage appends the encoding of the struct v, or of the struct v
+// points to, as a message in the wire format of protocol buffers, and
+// returns the extended buffer.
+//
+// Only the exported fields with a "binary" struct tag are encoded. The
+// tag gives the field number, from 1 to 1<<29 - 1, optionally followed
+// by comma-separated options:
+//
+//	A int32     `binary:"1"`        // varint, like int32
+//	B int64     `binary:"2,zigzag"` // zig-zag varint, like sint64
+//	C uint32    `binary:"3,fixed"`  // 4 bytes, like fixed32
+//	D []float64 `binary:"4"`        // packed, like repeated double
+//	E *Inner    `binary:"5"`        // embedded message
+//
+// Booleans and integers are encoded as varints, like the bool, int32,
+// int64, uint32 and uint64 types of protocol buffers. With the "zigzag"
+// option, signed integers are encoded as by [AppendVarint], like sint32
+// and sint64. With the "fixed" option, integers are encoded in 4 bytes,
+// or in 8 bytes for int, uint and 64-bit types, like fixed32, sfixed32,
+// fixed64 and sfixed64. Floating-point numbers are encoded like float
+// and double, strings and byte slices as length-delimited values, and
+// structs and pointers to structs as embedded messages.
+//
+// Fields with zero values are omitted, and so are structs none of whose
+// fields are encoded. Pointers to other types are omitted if nil and
+// otherwise always encoded, which gives the field explicit presence.
+//
+// Slices other than byte slices are repeated fields. Slices of booleans
+// and numbers are packed into a single length-delimited value, while
+// each element of other slices is encoded as a separate field. Maps are
+// encoded as repeated embedded messages holding the key as field 1 and
+// the value as field 2, in increasing order of their keys. The options
+// of slices and maps apply to their elements and values.
+//
+// A []byte field with the tag `binary:",unknown"` holds the encoded
+// fields that [UnmarshalMessage] did not recognize, and AppendMessage
+// writes them after the other fields. This lets a program using an old
+// version of a struct pass on the fields added by newer versions.
+//
+// AppendMessage returns an error if the type of v is not supported, or
+// if v refers to itself through pointers, slices or maps.
+// It does not allocate if buf has enough capacity, except to sort the
+// keys of maps.
+func AppendMessage(buf []byte, v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return buf, errors.New("binary.AppendMessage: invalid type " + typeString(v))
+	}
+	mi, err := getMessageInfo(rv.Type())
+	if err != nil {
+		return buf, err
+	}
+	var s appendState
+	return s.appendMessage(buf, mi, rv)
+}
+
+// UnmarshalMessage decodes the message in buf, encoded as described for
+// [AppendMessage], into the struct pointed to by v, which it first sets
+// to its zero value.
+//
+// Fields are matched by number, so that the struct types of the encoder
+// and the decoder can evolve separately: the fields of v that are not
+// in buf are left zero, and the fields in buf that are not in v are
+// skipped, or kept in a field with the "unknown" option. A field can be
+// changed to another type with the same encoding, such as a wider
+// integer type, and repeated fields of numbers accept both packed and
+// unpacked encodings. As in protocol buffers, if a field occurs more
+// than once, the last value wins, except that the values of repeated
+// fields and maps are accumulated and embedded messages are merged.
+//
+// UnmarshalMessage returns an error if buf is not a valid message, if
+// the wire type of a field does not match the type of its struct field,
+// if a number does not fit in its struct field, or if embedded messages
+// are nested more than 10000 deep. The strings and byte slices it stores
+// in v do not refer to buf.
+func UnmarshalMessage(buf []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("binary.UnmarshalMessage: invalid type " + typeString(v))
+	}
+	rv = rv.Elem()
+	mi, err := getMessageInfo(rv.Type())
+	if err != nil {
+		return err
+	}
+	rv.SetZero()
+	return unmarshalMessage(buf, mi, rv, 0)
+}
+
+func typeString(v any) string {
+	if v == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(v).String()
+}
+
+// A MessageEncoder writes a stream of messages to an [io.Writer], each
+// preceded by its length as an unsigned varint. This is the delimited
+// format of protocol buffers.
+type MessageEncoder struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewMessageEncoder returns a new encoder that writes to w.
+func NewMessageEncoder(w io.Writer) *MessageEncoder {
+	return &MessageEncoder{w: w}
+}
+
+// Encode writes the message encoding of v, as described for
+// [AppendMessage], preceded by its length. Each call makes a single
+// Write call on the underlying writer.
+func (e *MessageEncoder) Encode(v any) error {
+	// Encode the message after room for the longest length,
+	// then put the length right before it.
+	b, err := AppendMessage(append(e.buf[:0], make([]byte, MaxVarintLen64)...), v)
+	if cap(b) > cap(e.buf) {
+		e.buf = b[:0]
+	}
+	if err != nil {
+		return err
+	}
+	n := len(b) - MaxVarintLen64
+	start := MaxVarintLen64 - uvarintLen(uint64(n))
+	PutUvarint(b[start:], uint64(n))
+	_, err = e.w.Write(b[start:])
+	return err
+}
+
+// A MessageDecoder reads a stream of messages written by a
+// [MessageEncoder] from an [io.Reader].
+type MessageDecoder struct {
+	r   io.Reader
+	buf []byte // buf[off:] holds the input read but not yet decoded
+	off int
+	err error // sticky error from r
+}
+
+// NewMessageDecoder returns a new decoder that reads from r.
+// The decoder buffers its input and may read from r beyond the
+// messages it decodes.
+func NewMessageDecoder(r io.Reader) *MessageDecoder {
+	return &MessageDecoder{r: r}
+}
+
+// Decode reads the next message and stores it in the struct pointed to
+// by v, as described for [UnmarshalMessage]. If there are no messages
+// left, Decode returns [io.EOF]. If the input ends in the middle of a
+// message, Decode returns [io.ErrUnexpectedEOF].
+//
+// Errors decoding a message into v do not prevent decoding the
+// messages that follow. Other errors are returned by every later call.
+func (d *MessageDecoder) Decode(v any) error {
+	var n uint64
+	for {
+		var k int
+		n, k = Uvarint(d.buf[d.off:])
+		if k > 0 {
+			d.off += k
+			break
+		}
+		if k < 0 {
+			d.err = errOverflow
+			return d.err
+		}
+		if err := d.fill(len(d.buf) - d.off + 1); err != nil {
+			if err == io.EOF && d.off < len(d.buf) {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	if n > uint64(math.MaxInt-len(d.buf)) {
+		d.err = errors.New("binary: message too long")
+		return d.err
+	}
+	if err := d.fill(int(n)); err != nil {
+		if err == io.EOF {
+			d.err = io.ErrUnexpectedEOF
+			err = d.err
+		}
+		return err
+	}
+	msg := d.buf[d.off : d.off+int(n)]
+	d.off += int(n)
+	return UnmarshalMessage(msg, v)
+}
+
+// fill reads from d.r until at least n bytes are buffered. It grows
+// the buffer no faster than the input arrives, so that a corrupt
+// length cannot cause a large allocation.
+func (d *MessageDecoder) fill(n int) error {
+	if d.off > 0 {
+		d.buf = d.buf[:copy(d.buf, d.buf[d.off:])]
+		d.off = 0
+	}
+	for len(d.buf) < n {
+		if d.err != nil {
+			return d.err
+		}
+		if len(d.buf) == cap(d.buf) {
+			d.buf = slices.Grow(d.buf, min(n-len(d.buf), max(len(d.buf), 512)))
+		}
+		m, err := d.r.Read(d.buf[len(d.buf):cap(d.buf)])
+		d.buf = d.buf[:len(d.buf)+m]
+		if err != nil {
+			d.err = err
+		}
+	}
+	return nil
+}
+
+// A valueKind is the encoding of a single value.
+type valueKind uint8
+
+const (
+	kindBool valueKind = iota
+	kindInt
+	kindUint
+	kindZigzag
+	kindFixed32
+	kindSfixed32
+	kindFixed64
+	kindSfixed64
+	kindFloat32
+	kindFloat64
+	kindString
+	kindBytes
+	kindMessage
+)
+
+// A fieldForm is the way a struct field holds its values.
+type fieldForm uint8
+
+const (
+	formSingle   fieldForm = iota
+	formPointer            // pointer to a value other than a struct
+	formRepeated           // slice of values encoded as separate fields
+	formPacked             // slice of values encoded as one field
+	formMap
+)
+
+// A valueCodec describes the encoding of a value of a Go type.
+type valueCodec struct {
+	kind valueKind
+	ptr  bool         // for kindMessage, the value is a pointer to a struct
+	msg  *messageInfo // for kindMessage
+}
+
+// wireType returns the wire type of a value encoded by c.
+func (c *valueCodec) wireType() uint64 {
+	switch c.kind {
+	case kindFixed32, kindSfixed32, kindFloat32:
+		return wireFixed32
+	case kindFixed64, kindSfixed64, kindFloat64:
+		return wireFixed64
+	case kindString, kindBytes, kindMessage:
+		return wireBytes
+	}
+	return wireVarint
+}
+
+// A messageField describes a tagged field of a struct.
+type messageField struct {
+	num   int
+	index int
+	name  string
+	form  fieldForm
+	val   valueCodec // the value, element or map value
+	key   valueCodec // for formMap, the map key
+
+	keyBytes []byte // encoded key of the field: number and wire type
+}
+
+// A messageInfo describes the encoding of a struct type.
+type messageInfo struct {
+	typ     reflect.Type
+	fields  []messageField // in order of field number
+	unknown int            // index of the field holding unknown fields, or -1
+}
+
+var messageInfos sync.Map // map[reflect.Type]*messageInfo
+
+// getMessageInfo returns the encoding of the struct type t.
+func getMessageInfo(t reflect.Type) (*messageInfo, error) {
+	if mi, ok := messageInfos.Load(t); ok {
+		return mi.(*messageInfo), nil
+	}
+	// The messageInfos of recursive types refer to each other,
+	// so they are only cached once all of them are complete.
+	building := make(map[reflect.Type]*messageInfo)
+	mi, err := buildMessageInfo(t, building)
+	if err != nil {
+		return nil, err
+	}
+	for t, mi := range building {
+		messageInfos.Store(t, mi)
+	}
+	return mi, nil
+}
+
+func buildMessageInfo(t reflect.Type, building map[reflect.Type]*messageInfo) (*messageInfo, error) {
+	if mi, ok := messageInfos.Load(t); ok {
+		return mi.(*messageInfo), nil
+	}
+	if mi, ok := building[t]; ok {
+		return mi, nil
+	}
+	mi := &messageInfo{typ: t, unknown: -1}
+	building[t] = mi
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("binary")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		name := t.String() + "." + sf.Name
+		num, opts, _ := cut(tag, ',')
+		if num == "" && opts == "unknown" {
+			if sf.Type.Kind() != reflect.Slice || sf.Type.Elem().Kind() != reflect.Uint8 || mi.unknown >= 0 {
+				return nil, errors.New("binary: invalid unknown fields field " + name)
+			}
+			mi.unknown = i
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 1 || n > maxFieldNumber {
+			return nil, errors.New("binary: invalid field number " + strconv.Quote(num) + " of field " + name)
+		}
+		f := messageField{num: n, index: i, name: name}
+		if err := f.init(sf.Type, opts, building); err != nil {
+			return nil, err
+		}
+		mi.fields = append(mi.fields, f)
+	}
+	slices.SortFunc(mi.fields, func(a, b messageField) int { return a.num - b.num })
+	for i := 1; i < len(mi.fields); i++ {
+		if mi.fields[i].num == mi.fields[i-1].num {
+			return nil, errors.New("binary: duplicate field number " + strconv.Itoa(mi.fields[i].num) + " in type " + t.String())
+		}
+	}
+	return mi, nil
+}
+
+// init sets the form and codecs of f for a struct field of type t.
+func (f *messageField) init(t reflect.Type, opts string, building map[reflect.Type]*messageInfo) error {
+	var err error
+	switch {
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() != reflect.Struct:
+		f.form = formPointer
+		f.val, err = newValueCodec(t.Elem(), opts, f.name, building)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		f.val, err = newValueCodec(t.Elem(), opts, f.name, building)
+		f.form = formRepeated
+		if f.val.wireType() != wireBytes {
+			f.form = formPacked
+		}
+	case t.Kind() == reflect.Map:
+		f.form = formMap
+		f.key, err = newValueCodec(t.Key(), "", f.name, building)
+		if err == nil && (f.key.wireType() != wireVarint && f.key.kind != kindString) {
+			err = errUnsupported
+		}
+		if err == nil {
+			f.val, err = newValueCodec(t.Elem(), opts, f.name, building)
+		}
+	default:
+		f.val, err = newValueCodec(t, opts, f.name, building)
+	}
+	if err == errUnsupported {
+		return errors.New("binary: unsupported type " + t.String() + " of field " + f.name)
+	}
+	if err != nil {
+		return err
+	}
+	wire := f.val.wireType()
+	if f.form == formPacked || f.form == formMap {
+		wire = wireBytes
+	}
+	f.keyBytes = AppendUvarint(nil, uint64(f.num)<<3|wire)
+	return nil
+}
+
+var errUnsupported = errors.New("unsupported type")
+
+// newValueCodec returns the codec of a single value of type t in the
+// field with the given name, encoded with the given options.
+func newValueCodec(t reflect.Type, opts, name string, building map[reflect.Type]*messageInfo) (valueCodec, error) {
+	var c valueCodec
+	switch t.Kind() {
+	case reflect.Bool:
+		c.kind = kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.kind = kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		c.kind = kindUint
+	case reflect.Float32:
+		c.kind = kindFloat32
+	case reflect.Float64:
+		c.kind = kindFloat64
+	case reflect.String:
+		c.kind = kindString
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return c, errUnsupported
+		}
+		c.kind = kindBytes
+	case reflect.Pointer:
+		if t.Elem().Kind() != reflect.Struct {
+			return c, errUnsupported
+		}
+		c.ptr = true
+		t = t.Elem()
+		fallthrough
+	case reflect.Struct:
+		mi, err := buildMessageInfo(t, building)
+		if err != nil {
+			return c, err
+		}
+		c.kind = kindMessage
+		c.msg = mi
+	default:
+		return c, errUnsupported
+	}
+	for opts != "" {
+		var opt string
+		opt, opts, _ = cut(opts, ',')
+		switch {
+		case opt == "zigzag" && c.kind == kindInt:
+			c.kind = kindZigzag
+		case opt == "fixed" && (c.kind == kindInt || c.kind == kindUint):
+			// int and uint take 8 bytes whatever their size,
+			// so that the encoding does not depend on the platform.
+			wide := t.Kind() == reflect.Int || t.Kind() == reflect.Uint || t.Size() == 8
+			switch {
+			case c.kind == kindInt && wide:
+				c.kind = kindSfixed64
+			case c.kind == kindInt:
+				c.kind = kindSfixed32
+			case wide:
+				c.kind = kindFixed64
+			default:
+				c.kind = kindFixed32
+			}
+		default:
+			return c, errors.New("binary: invalid option " + strconv.Quote(opt) + " for type " + t.String() + " of field " + name)
+		}
+	}
+	return c, nil
+}
+
+// cut is strings.Cut, which this package cannot import.
+func cut(s string, sep byte) (before, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == sep {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// uvarintLen returns the length of the varint encoding of x.
+func uvarintLen(x uint64) int {
+	n := 1
+	for x >= 0x80 {
+		x >>= 7
+		n++
+	}
+	return n
+}
+
+// beginBytes appends room for the length of a length-delimited value
+// to b and returns the offset at which the value starts.
+func beginBytes(b []byte) ([]byte, int) {
+	return append(b, 0), len(b) + 1
+}
+
+// endBytes writes the length of the length-delimited value that starts
+// at offset start of b, moving the value if its length takes more than
+// the one byte reserved by beginBytes.
+func endBytes(b []byte, start int) []byte {
+	n := len(b) - start
+	if n < 0x80 {
+		b[start-1] = byte(n)
+		return b
+	}
+	extra := uvarintLen(uint64(n)) - 1
+	b = append(b, make([]byte, extra)...)
+	copy(b[start+extra:], b[start:start+n])
+	PutUvarint(b[start-1:], uint64(n))
+	return b
+}
+
+// appendState is the state of AppendMessage.
+type appendState struct {
+	depth int // nesting depth of embedded messages
+	// Once depth exceeds startDetectingCyclesAfter,
+	// seen holds the messages and maps being encoded.
+	seen map[seenKey]struct{}
+}
+
+// seenKey identifies a message or map being encoded.
+type seenKey struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+}
+
+// enter records that s is encoding the message or map at ptr, and reports
+// an error if it is already encoding it. If enter succeeds, the caller
+// must call exit when it is done.
+func (s *appendState) enter(ptr unsafe.Pointer, t reflect.Type) error {
+	if s.depth++; s.depth <= startDetectingCyclesAfter || ptr == nil {
+		return nil
+	}
+	if s.seen == nil {
+		s.seen = make(map[seenKey]struct{})
+	}
+	k := seenKey{ptr, t}
+	if _, ok := s.seen[k]; ok {
+		s.depth--
+		return errors.New("binary: encountered a cycle via " + t.String())
+	}
+	s.seen[k] = struct{}{}
+	return nil
+}
+
+func (s *appendState) exit(ptr unsafe.Pointer, t reflect.Type) {
+	if s.depth--; s.seen != nil {
+		delete(s.seen, seenKey{ptr, t})
+	}
+}
+
+func (s *appendState) appendMessage(b []byte, mi *messageInfo, v reflect.Value) ([]byte, error) {
+	// A cycle goes through the message at an address,
+	// or through a map, which appendMap checks.
+	var ptr unsafe.Pointer
+	if v.CanAddr() {
+		ptr = v.Addr().UnsafePointer()
+	}
+	if err := s.enter(ptr, v.Type()); err != nil {
+		return b, err
+	}
+	defer s.exit(ptr, v.Type())
+
+	var err error
+	for i := range mi.fields {
+		f := &mi.fields[i]
+		fv := v.Field(f.index)
+		switch f.form {
+		case formSingle:
+			b, err = s.appendField(b, f.keyBytes, &f.val, fv, false)
+		case formPointer:
+			if !fv.IsNil() {
+				b, err = s.appendField(b, f.keyBytes, &f.val, fv.Elem(), true)
+			}
+		case formRepeated:
+			for j := range fv.Len() {
+				if b, err = s.appendField(b, f.keyBytes, &f.val, fv.Index(j), true); err != nil {
+					break
+				}
+			}
+		case formPacked:
+			if fv.Len() == 0 {
+				continue
+			}
+			var start int
+			b, start = beginBytes(append(b, f.keyBytes...))
+			for j := range fv.Len() {
+				b = appendValue(b, &f.val, fv.Index(j))
+			}
+			b = endBytes(b, start)
+		case formMap:
+			b, err = s.appendMap(b, f, fv)
+		}
+		if err != nil {
+			return b, err
+		}
+	}
+	if mi.unknown >= 0 {
+		b = append(b, v.Field(mi.unknown).Bytes()...)
+	}
+	return b, nil
+}
+
+// appendField appends a field holding the value v, or nothing if the
+// value is zero and always is false.
+func (s *appendState) appendField(b, key []byte, c *valueCodec, v reflect.Value, always bool) ([]byte, error) {
+	if c.kind != kindMessage {
+		if !always && isZero(c, v) {
+			return b, nil
+		}
+		return appendValue(append(b, key...), c, v), nil
+	}
+	if c.ptr {
+		if v.IsNil() {
+			if !always {
+				return b, nil
+			}
+			return append(append(b, key...), 0), nil
+		}
+		v = v.Elem()
+		always = true
+	}
+	mark := len(b)
+	b, start := beginBytes(append(b, key...))
+	b, err := s.appendMessage(b, c.msg, v)
+	if err != nil {
+		return b, err
+	}
+	if !always && len(b) == start {
+		return b[:mark], nil
+	}
+	return endBytes(b, start), nil
+}
+
+func isZero(c *valueCodec, v reflect.Value) bool {
+	switch c.kind {
+	case kindFloat32, kindFloat64:
+		// Keep negative zero.
+		return math.Float64bits(v.Float()) == 0
+	case kindBytes:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// appendValue appends the value v, which is not a message, without a key.
+func appendValue(b []byte, c *valueCodec, v reflect.Value) []byte {
+	switch c.kind {
+	case kindBool:
+		if v.Bool() {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case kindInt:
+		return AppendUvarint(b, uint64(v.Int()))
+	case kindUint:
+		return AppendUvarint(b, v.Uint())
+	case kindZigzag:
+		return AppendVarint(b, v.Int())
+	case kindFixed32:
+		return LittleEndian.AppendUint32(b, uint32(v.Uint()))
+	case kindSfixed32:
+		return LittleEndian.AppendUint32(b, uint32(v.Int()))
+	case kindFixed64:
+		return LittleEndian.AppendUint64(b, v.Uint())
+	case kindSfixed64:
+		return LittleEndian.AppendUint64(b, uint64(v.Int()))
+	case kindFloat32:
+		return LittleEndian.AppendUint32(b, math.Float32bits(float32(v.Float())))
+	case kindFloat64:
+		return LittleEndian.AppendUint64(b, math.Float64bits(v.Float()))
+	case kindString:
+		s := v.String()
+		return append(AppendUvarint(b, uint64(len(s))), s...)
+	case kindBytes:
+		return append(AppendUvarint(b, uint64(v.Len())), v.Bytes()...)
+	}
+	panic("unreachable")
+}
+
+var (
+	mapKeyBytes   = []byte{1<<3 | wireVarint}
+	mapKeyString  = []byte{1<<3 | wireBytes}
+	mapValueBytes = [...][]byte{
+		wireVarint:  {2<<3 | wireVarint},
+		wireFixed64: {2<<3 | wireFixed64},
+		wireBytes:   {2<<3 | wireBytes},
+		wireFixed32: {2<<3 | wireFixed32},
+	}
+)
+
+func (s *appendState) appendMap(b []byte, f *messageField, v reflect.Value) ([]byte, error) {
+	if v.Len() == 0 {
+		return b, nil
+	}
+	if err := s.enter(v.UnsafePointer(), v.Type()); err != nil {
+		return b, err
+	}
+	defer s.exit(v.UnsafePointer(), v.Type())
+	keyKey := mapKeyBytes
+	if f.key.kind == kindString {
+		keyKey = mapKeyString
+	}
+	valueKey := mapValueBytes[f.val.wireType()]
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(x, y reflect.Value) int {
+		switch f.key.kind {
+		case kindBool:
+			return compareBool(x.Bool(), y.Bool())
+		case kindUint:
+			return cmp.Compare(x.Uint(), y.Uint())
+		case kindString:
+			return cmp.Compare(x.String(), y.String())
+		}
+		return cmp.Compare(x.Int(), y.Int())
+	})
+	var err error
+	for _, k := range keys {
+		var start int
+		b, start = beginBytes(append(b, f.keyBytes...))
+		b = appendValue(append(b, keyKey...), &f.key, k)
+		if b, err = s.appendField(b, valueKey, &f.val, v.MapIndex(k), true); err != nil {
+			return b, err
+		}
+		b = endBytes(b, start)
+	}
+	return b, nil
+}
+
+func compareBool(x, y bool) int {
+	switch {
+	case x == y:
+		return 0
+	case x:
+		return +1
+	}
+	return -1
+}
+
+// unmarshalMessage decodes the message b, embedded depth messages deep,
+// into the struct v, merging it with the current contents of v.
+func unmarshalMessage(b []byte, mi *messageInfo, v reflect.Value, depth int) error {
+	if depth > maxMessageDepth {
+		return errTooDeep
+	}
+	next := 0 // index of the field expected next
+	for len(b) > 0 {
+		key, n := Uvarint(b)
+		if n <= 0 {
+			return errTruncatedOrOverflow(n)
+		}
+		num, wire := key>>3, key&7
+		if num < 1 || num > maxFieldNumber {
+			return errors.New("binary: invalid field number " + strconv.FormatUint(num, 10))
+		}
+		// Fields are usually in order, so try the next one
+		// before searching for it.
+		i := next
+		if i >= len(mi.fields) || mi.fields[i].num != int(num) {
+			var ok bool
+			i, ok = slices.BinarySearchFunc(mi.fields, int(num), func(f messageField, num int) int { return f.num - num })
+			if !ok {
+				m, err := skipValue(b[n:], wire)
+				if err != nil {
+					return err
+				}
+				if mi.unknown >= 0 {
+					u := v.Field(mi.unknown)
+					u.SetBytes(append(u.Bytes(), b[:n+m]...))
+				}
+				b = b[n+m:]
+				continue
+			}
+		}
+		next = i + 1
+		m, err := unmarshalField(b[n:], wire, &mi.fields[i], v.Field(mi.fields[i].index), depth)
+		if err != nil {
+			return err
+		}
+		b = b[n+m:]
+	}
+	return nil
+}
+
+func errTruncatedOrOverflow(n int) error {
+	if n == 0 {
+		return errTruncated
+	}
+	return errOverflow
+}
+
+// skipValue returns the length of the value of wire type wire at the
+// start of b.
+func skipValue(b []byte, wire uint64) (int, error) {
+	switch wire {
+	case wireVarint:
+		_, n := Uvarint(b)
+		if n <= 0 {
+			return 0, errTruncatedOrOverflow(n)
+		}
+		return n, nil
+	case wireFixed64:
+		if len(b) < 8 {
+			return 0, errTruncated
+		}
+		return 8, nil
+	case wireBytes:
+		_, n, err := consumeBytes(b)
+		return n, err
+	case wireFixed32:
+		if len(b) < 4 {
+			return 0, errTruncated
+		}
+		return 4, nil
+	}
+	return 0, errors.New("binary: unsupported wire type " + strconv.FormatUint(wire, 10))
+}
+
+// consumeBytes returns the length-delimited value at the start of b
+// and the length of its encoding.
+func consumeBytes(b []byte) ([]byte, int, error) {
+	l, n := Uvarint(b)
+	if n <= 0 {
+		return nil, 0, errTruncatedOrOverflow(n)
+	}
+	if l > uint64(len(b)-n) {
+		return nil, 0, errTruncated
+	}
+	return b[n : n+int(l)], n + int(l), nil
+}
+
+// unmarshalField decodes the value of wire type wire at the start of b
+// into the struct field v described by f, and returns the length of
+// the value. The field is in a message depth messages deep.
+func unmarshalField(b []byte, wire uint64, f *messageField, v reflect.Value, depth int) (int, error) {
+	if f.form == formPacked && wire == wireBytes {
+		data, n, err := consumeBytes(b)
+		if err != nil {
+			return 0, err
+		}
+		for len(data) > 0 {
+			m, err := unmarshalValue(data, f.val.wireType(), f, &f.val, appendElem(v), depth)
+			if err != nil {
+				return 0, err
+			}
+			data = data[m:]
+		}
+		return n, nil
+	}
+	if f.form == formMap {
+		if wire != wireBytes {
+			return 0, errors.New("binary: wrong wire type " + strconv.FormatUint(wire, 10) + " for field " + f.name)
+		}
+		data, n, err := consumeBytes(b)
+		if err != nil {
+			return 0, err
+		}
+		return n, unmarshalMapEntry(data, f, v, depth)
+	}
+	switch f.form {
+	case formPointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	case formRepeated, formPacked:
+		v = appendElem(v)
+	}
+	return unmarshalValue(b, wire, f, &f.val, v, depth)
+}
+
+// appendElem appends a zero element to the slice v and returns it.
+func appendElem(v reflect.Value) reflect.Value {
+	n := v.Len()
+	v.Grow(1)
+	v.SetLen(n + 1)
+	e := v.Index(n)
+	e.SetZero()
+	return e
+}
+
+// unmarshalValue decodes the value of wire type wire at the start of b
+// into v, using the codec c of the field f, and returns the length of
+// the value. The field is in a message depth messages deep.
+func unmarshalValue(b []byte, wire uint64, f *messageField, c *valueCodec, v reflect.Value, depth int) (int, error) {
+	if wire != c.wireType() {
+		return 0, errors.New("binary: wrong wire type " + strconv.FormatUint(wire, 10) + " for field " + f.name)
+	}
+	var x uint64
+	var n int
+	switch wire {
+	case wireVarint:
+		x, n = Uvarint(b)
+		if n <= 0 {
+			return 0, errTruncatedOrOverflow(n)
+		}
+	case wireFixed32:
+		if len(b) < 4 {
+			return 0, errTruncated
+		}
+		x, n = uint64(LittleEndian.Uint32(b)), 4
+	case wireFixed64:
+		if len(b) < 8 {
+			return 0, errTruncated
+		}
+		x, n = LittleEndian.Uint64(b), 8
+	case wireBytes:
+		data, n, err := consumeBytes(b)
+		if err != nil {
+			return 0, err
+		}
+		switch c.kind {
+		case kindString:
+			v.SetString(string(data))
+		case kindBytes:
+			v.SetBytes(append([]byte{}, data...))
+		case kindMessage:
+			if c.ptr {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+			if err := unmarshalMessage(data, c.msg, v, depth+1); err != nil {
+				return 0, err
+			}
+		}
+		return n, nil
+	}
+
+	switch c.kind {
+	case kindBool:
+		v.SetBool(x != 0)
+	case kindInt, kindZigzag, kindSfixed32, kindSfixed64:
+		i := int64(x)
+		switch c.kind {
+		case kindZigzag:
+			i = int64(x>>1) ^ -int64(x&1)
+		case kindSfixed32:
+			i = int64(int32(x))
+		}
+		if v.OverflowInt(i) {
+			return 0, errors.New("binary: value out of range for field " + f.name)
+		}
+		v.SetInt(i)
+	case kindUint, kindFixed32, kindFixed64:
+		if v.OverflowUint(x) {
+			return 0, errors.New("binary: value out of range for field " + f.name)
+		}
+		v.SetUint(x)
+	case kindFloat32:
+		v.SetFloat(float64(math.Float32frombits(uint32(x))))
+	case kindFloat64:
+		v.SetFloat(math.Float64frombits(x))
+	}
+	return n, nil
+}
+
+// unmarshalMapEntry decodes the map entry message b into the map v.
+// The map is in a message depth messages deep.
+func unmarshalMapEntry(b []byte, f *messageField, v reflect.Value, depth int) error {
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	k := reflect.New(v.Type().Key()).Elem()
+	e := reflect.New(v.Type().Elem()).Elem()
+	for len(b) > 0 {
+		key, n := Uvarint(b)
+		if n <= 0 {
+			return errTruncatedOrOverflow(n)
+		}
+		var m int
+		var err error
+		switch key >> 3 {
+		case 1:
+			m, err = unmarshalValue(b[n:], key&7, f, &f.key, k, depth+1)
+		case 2:
+			m, err = unmarshalValue(b[n:], key&7, f, &f.val, e, depth+1)
+		default:
+			m, err = skipValue(b[n:], key&7)
+		}
+		if err != nil {
+			return err
+		}
+		b = b[n+m:]
+	}
+	if f.val.kind == kindMessage && f.val.ptr && e.IsNil() {
+		e.Set(reflect.New(e.Type().Elem()))
+	}
+	v.SetMapIndex(k, e)
+	return nil
+}
@@ -0,0 +1,620 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binary
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type msgInner struct {
+	A int32  `binary:"1"`
+	S string `binary:"2"`
+}
+
+type msgAll struct {
+	Bool    bool              `binary:"1"`
+	Int     int               `binary:"2"`
+	Int8    int8              `binary:"3"`
+	Int32   int32             `binary:"4"`
+	Uint16  uint16            `binary:"5"`
+	Uint64  uint64            `binary:"6"`
+	Zigzag  int64             `binary:"7,zigzag"`
+	Fixed32 uint32            `binary:"8,fixed"`
+	Sfixed  int               `binary:"9,fixed"`
+	Float32 float32           `binary:"10"`
+	Float64 float64           `binary:"11"`
+	String  string            `binary:"12"`
+	Bytes   []byte            `binary:"13"`
+	Inner   msgInner          `binary:"14"`
+	PInner  *msgInner         `binary:"15"`
+	PInt    *int              `binary:"16"`
+	Ints    []int32           `binary:"17,zigzag"`
+	Strings []string          `binary:"18"`
+	Inners  []*msgInner       `binary:"19"`
+	Map     map[string]int    `binary:"20"`
+	MapMsg  map[int]msgInner  `binary:"21"`
+	Floats  []float64         `binary:"22"`
+	Named   []byteSliceNamed  `binary:"23"`
+	Bools   map[bool][]byte   `binary:"24"`
+	Skipped int               `binary:"-"`
+	Untag   int               //
+	private int               `binary:"25"`
+	Big     string            `binary:"536870911"`
+	_       struct{}          //
+	Uints   map[uint8]float32 `binary:"26,"`
+}
+
+type byteSliceNamed []byte
+
+type msgList struct {
+	Value int      `binary:"1"`
+	Next  *msgList `binary:"2"`
+}
+
+var messageTests = []struct {
+	name string
+	in   any
+	out  []byte
+}{
+	// Examples from https://protobuf.dev/programming-guides/encoding/.
+	{"Varint", struct {
+		A int32 `binary:"1"`
+	}{150}, []byte{0x08, 0x96, 0x01}},
+	{"String", struct {
+		B string `binary:"2"`
+	}{"testing"}, []byte{0x12, 0x07, 't', 'e', 's', 't', 'i', 'n', 'g'}},
+	{"Embedded", struct {
+		C struct {
+			A int32 `binary:"1"`
+		} `binary:"3"`
+	}{C: struct {
+		A int32 `binary:"1"`
+	}{150}}, []byte{0x1a, 0x03, 0x08, 0x96, 0x01}},
+	{"Packed", struct {
+		D []int32 `binary:"4"`
+	}{[]int32{3, 270, 86942}}, []byte{0x22, 0x06, 0x03, 0x8e, 0x02, 0x9e, 0xa7, 0x05}},
+
+	{"Zero", msgAll{}, []byte{}},
+	{"Negative", struct {
+		A int32 `binary:"1"`
+	}{-1}, []byte{0x08, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	{"Zigzag", struct {
+		A int32 `binary:"1,zigzag"`
+	}{-2}, []byte{0x08, 0x03}},
+	{"Fixed", struct {
+		A int8  `binary:"1,fixed"`
+		B uint  `binary:"2,fixed"`
+		C int64 `binary:"3,fixed"`
+	}{-1, 1, -2}, []byte{
+		0x0d, 0xff, 0xff, 0xff, 0xff,
+		0x11, 0x01, 0, 0, 0, 0, 0, 0, 0,
+		0x19, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}},
+	{"Floats", struct {
+		F float32 `binary:"1"`
+		D float64 `binary:"2"`
+		Z float64 `binary:"3"`
+	}{1, math.Copysign(0, -1), 0}, []byte{
+		0x0d, 0, 0, 0x80, 0x3f,
+		0x11, 0, 0, 0, 0, 0, 0, 0, 0x80,
+	}},
+	{"Presence", struct {
+		P *int      `binary:"1"`
+		Q *int      `binary:"2"`
+		M *msgInner `binary:"3"`
+		N msgInner  `binary:"4"`
+	}{P: new(int), M: new(msgInner)}, []byte{0x08, 0x00, 0x1a, 0x00}},
+	{"Repeated", struct {
+		S []string     `binary:"1"`
+		M []msgInner   `binary:"2"`
+		B [][]byte     `binary:"3"`
+		E []bool       `binary:"4"`
+		P []*msgInner  `binary:"5"`
+		N []uint64     `binary:"6"`
+		Z []*msgInner  `binary:"7"`
+		F []float32    `binary:"8"`
+		_ []complex128 //
+	}{S: []string{"", "a"}, M: []msgInner{{}}, B: [][]byte{nil}, E: []bool{true, false}, P: []*msgInner{nil}}, []byte{
+		0x0a, 0x00, 0x0a, 0x01, 'a',
+		0x12, 0x00,
+		0x1a, 0x00,
+		0x22, 0x02, 0x01, 0x00,
+		0x2a, 0x00,
+	}},
+	{"Map", struct {
+		M map[int32]string `binary:"1"`
+	}{map[int32]string{2: "b", -1: "", 1: "a"}}, []byte{
+		0x0a, 0x0d, 0x08, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x12, 0x00,
+		0x0a, 0x05, 0x08, 0x01, 0x12, 0x01, 'a',
+		0x0a, 0x05, 0x08, 0x02, 0x12, 0x01, 'b',
+	}},
+	{"Order", struct {
+		B int `binary:"2"`
+		A int `binary:"1"`
+	}{1, 2}, []byte{0x08, 0x02, 0x10, 0x01}},
+	{"Unknown", struct {
+		A int    `binary:"1"`
+		U []byte `binary:",unknown"`
+	}{1, []byte{0x10, 0x02}}, []byte{0x08, 0x01, 0x10, 0x02}},
+}
+
+func TestAppendMessage(t *testing.T) {
+	for _, tt := range messageTests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := AppendMessage([]byte{}, tt.in)
+			if err != nil {
+				t.Fatalf("AppendMessage error: %v", err)
+			}
+			if !bytes.Equal(b, tt.out) {
+				t.Errorf("AppendMessage:\ngot  %x\nwant %x", b, tt.out)
+			}
+
+			// Decoding gives back the input.
+			v := reflect.New(reflect.TypeOf(tt.in))
+			if err := UnmarshalMessage(b, v.Interface()); err != nil {
+				t.Fatalf("UnmarshalMessage error: %v", err)
+			}
+			// Nil pointers in repeated fields decode as empty messages.
+			if tt.name != "Repeated" && !reflect.DeepEqual(v.Elem().Interface(), tt.in) {
+				t.Errorf("UnmarshalMessage:\ngot  %+v\nwant %+v", v.Elem().Interface(), tt.in)
+			}
+		})
+	}
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	one := 1
+	in := msgAll{
+		Bool:    true,
+		Int:     math.MinInt,
+		Int8:    -8,
+		Int32:   math.MaxInt32,
+		Uint16:  math.MaxUint16,
+		Uint64:  math.MaxUint64,
+		Zigzag:  math.MinInt64,
+		Fixed32: math.MaxUint32,
+		Sfixed:  -1,
+		Float32: float32(math.Inf(-1)),
+		Float64: math.Pi,
+		String:  strings.Repeat("long string ", 20),
+		Bytes:   []byte{0, 1, 2},
+		Inner:   msgInner{A: 1, S: "a"},
+		PInner:  &msgInner{},
+		PInt:    &one,
+		Ints:    []int32{-1, 0, 1, math.MinInt32},
+		Strings: []string{"x", "", "y"},
+		Inners:  []*msgInner{{A: 2}, {S: "b"}},
+		Map:     map[string]int{"a": 1, "": 0},
+		MapMsg:  map[int]msgInner{-3: {A: 3}, 0: {}},
+		Floats:  []float64{1.5, math.MaxFloat64},
+		Named:   []byteSliceNamed{{}, {'n'}},
+		Bools:   map[bool][]byte{true: {1}, false: {}},
+		Big:     "big",
+		Uints:   map[uint8]float32{255: 0.5},
+	}
+	b, err := AppendMessage(nil, &in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := msgAll{Skipped: 1, Untag: 2, private: 3, Strings: []string{"stale"}}
+	if err := UnmarshalMessage(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip:\ngot  %+v\nwant %+v", out, in)
+	}
+
+	list := msgList{1, &msgList{2, &msgList{Value: 3}}}
+	b, err = AppendMessage(nil, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list2 msgList
+	if err := UnmarshalMessage(b, &list2); err != nil || !reflect.DeepEqual(list2, list) {
+		t.Errorf("round trip of recursive type = %+v, %v", list2, err)
+	}
+}
+
+type personV1 struct {
+	Name    string `binary:"1"`
+	Age     int32  `binary:"2"`
+	Unknown []byte `binary:",unknown"`
+}
+
+type personV2 struct {
+	Name   string            `binary:"1"`
+	Age    int64             `binary:"2"`
+	Email  string            `binary:"3"`
+	Scores []int32           `binary:"4"`
+	Tags   map[string]string `binary:"5"`
+	Score  float64           `binary:"6"`
+	Parent *personV2         `binary:"7"`
+}
+
+func TestMessageEvolution(t *testing.T) {
+	v2 := personV2{
+		Name:   "Ann",
+		Age:    42,
+		Email:  "ann@example.com",
+		Scores: []int32{1, 2},
+		Tags:   map[string]string{"k": "v"},
+		Score:  0.5,
+		Parent: &personV2{Name: "Bob"},
+	}
+	b, err := AppendMessage(nil, v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An old program decodes the fields it knows, keeps the others
+	// and passes them on.
+	var v1 personV1
+	if err := UnmarshalMessage(b, &v1); err != nil {
+		t.Fatal(err)
+	}
+	if v1.Name != "Ann" || v1.Age != 42 || len(v1.Unknown) == 0 {
+		t.Fatalf("decoded old version = %+v", v1)
+	}
+	v1.Age++
+	b, err = AppendMessage(nil, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got personV2
+	if err := UnmarshalMessage(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	v2.Age++
+	if !reflect.DeepEqual(got, v2) {
+		t.Errorf("new version after old program:\ngot  %+v\nwant %+v", got, v2)
+	}
+
+	// A new program decodes messages of the old version.
+	b, err = AppendMessage(nil, personV1{Name: "Cy", Age: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = personV2{}
+	if err := UnmarshalMessage(b, &got); err != nil || !reflect.DeepEqual(got, personV2{Name: "Cy", Age: -1}) {
+		t.Errorf("new version of old message = %+v, %v", got, err)
+	}
+}
+
+func TestUnmarshalMessageMerge(t *testing.T) {
+	type msg struct {
+		A int       `binary:"1"`
+		P []int     `binary:"2"`
+		M *msgInner `binary:"3"`
+	}
+	in := []byte{
+		0x08, 0x01, 0x08, 0x02, // last value wins
+		0x10, 0x01, 0x12, 0x02, 0x02, 0x03, 0x10, 0x04, // unpacked and packed
+		0x1a, 0x02, 0x08, 0x05, 0x1a, 0x03, 0x12, 0x01, 'x', // merged
+		0x25, 0, 0, 0, 0, 0x29, 0, 0, 0, 0, 0, 0, 0, 0, // skipped
+	}
+	var v msg
+	if err := UnmarshalMessage(in, &v); err != nil {
+		t.Fatal(err)
+	}
+	want := msg{A: 2, P: []int{1, 2, 3, 4}, M: &msgInner{A: 5, S: "x"}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("UnmarshalMessage = %+v, want %+v", v, want)
+	}
+}
+
+func TestMessageErrors(t *testing.T) {
+	type (
+		small struct {
+			A int8 `binary:"1"`
+		}
+		unsigned struct {
+			A uint8 `binary:"1,fixed"`
+		}
+	)
+	decodeTests := []struct {
+		name string
+		in   []byte
+		v    any
+		err  string
+	}{
+		{"NotPointer", nil, small{}, "binary.UnmarshalMessage: invalid type binary.small"},
+		{"Nil", nil, nil, "binary.UnmarshalMessage: invalid type nil"},
+		{"TruncatedKey", []byte{0x80}, &small{}, "binary: truncated message"},
+		{"TruncatedValue", []byte{0x08}, &small{}, "binary: truncated message"},
+		{"TruncatedBytes", []byte{0x12, 0x02, 0}, &small{}, "binary: truncated message"},
+		{"TruncatedFixed", []byte{0x0d, 0, 0, 0}, &unsigned{}, "binary: truncated message"},
+		{"Overflow", bytes.Repeat([]byte{0xff}, 11), &small{}, "binary: varint overflows a 64-bit integer"},
+		{"FieldNumber", []byte{0x00, 0x00}, &small{}, "binary: invalid field number 0"},
+		{"Group", []byte{0x13, 0x14}, &small{}, "binary: unsupported wire type 3"},
+		{"WireType", []byte{0x0a, 0x00}, &small{}, "binary: wrong wire type 2 for field binary.small.A"},
+		{"Range", []byte{0x08, 0x80, 0x01}, &small{}, "binary: value out of range for field binary.small.A"},
+		{"NegativeRange", []byte{0x08, 0xff, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, &small{}, "binary: value out of range for field binary.small.A"},
+		{"FixedRange", []byte{0x0d, 0, 1, 0, 0}, &unsigned{}, "binary: value out of range for field binary.unsigned.A"},
+	}
+	for _, tt := range decodeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UnmarshalMessage(tt.in, tt.v)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("UnmarshalMessage error = %v, want %s", err, tt.err)
+			}
+		})
+	}
+
+	typeTests := []struct {
+		name string
+		v    any
+		err  string
+	}{
+		{"NotStruct", 1, "binary.AppendMessage: invalid type int"},
+		{"NilPointer", (*small)(nil), "binary.AppendMessage: invalid type *binary.small"},
+		{"Number", struct {
+			A int `binary:"0"`
+		}{}, `binary: invalid field number "0" of field struct { A int "binary:\"0\"" }.A`},
+		{"BigNumber", struct {
+			A int `binary:"536870912"`
+		}{}, `binary: invalid field number "536870912" of field struct { A int "binary:\"536870912\"" }.A`},
+		{"Duplicate", struct {
+			A int `binary:"1"`
+			B int `binary:"1"`
+		}{}, `binary: duplicate field number 1 in type struct { A int "binary:\"1\""; B int "binary:\"1\"" }`},
+		{"Option", struct {
+			A uint `binary:"1,zigzag"`
+		}{}, `binary: invalid option "zigzag" for type uint of field struct { A uint "binary:\"1,zigzag\"" }.A`},
+		{"Unsupported", struct {
+			A []map[int]int `binary:"1"`
+		}{}, `binary: unsupported type []map[int]int of field struct { A []map[int]int "binary:\"1\"" }.A`},
+		{"MapKey", struct {
+			A map[float64]int `binary:"1"`
+		}{}, `binary: unsupported type map[float64]int of field struct { A map[float64]int "binary:\"1\"" }.A`},
+		{"Unknown", struct {
+			A string `binary:",unknown"`
+		}{}, `binary: invalid unknown fields field struct { A string "binary:\",unknown\"" }.A`},
+		{"Nested", struct {
+			A []struct {
+				B any `binary:"1"`
+			} `binary:"1"`
+		}{}, `binary: unsupported type interface {} of field struct { B interface {} "binary:\"1\"" }.B`},
+	}
+	for _, tt := range typeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AppendMessage(nil, tt.v)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("AppendMessage error = %v, want %s", err, tt.err)
+			}
+		})
+	}
+}
+
+type msgTree struct {
+	Next *msgTree          `binary:"1"`
+	Kids []msgTree         `binary:"2"`
+	Map  map[int32]msgTree `binary:"3"`
+}
+
+// nestedMessage returns the encoding of a msgTree with n more
+// msgTrees nested in it through field 1.
+func nestedMessage(n int) []byte {
+	lens := make([]int, n+1) // lengths of the nested messages
+	for i := n - 1; i >= 0; i-- {
+		lens[i] = 1 + uvarintLen(uint64(lens[i+1])) + lens[i+1]
+	}
+	var b []byte
+	for i := 1; i <= n; i++ {
+		b = AppendUvarint(append(b, 0x0a), uint64(lens[i]))
+	}
+	return b
+}
+
+func TestUnmarshalMessageDepth(t *testing.T) {
+	var v msgTree
+	if err := UnmarshalMessage(nestedMessage(maxMessageDepth), &v); err != nil {
+		t.Fatalf("UnmarshalMessage of %d nested messages: %v", maxMessageDepth, err)
+	}
+	if err := UnmarshalMessage(nestedMessage(maxMessageDepth+1), &v); err != errTooDeep {
+		t.Errorf("UnmarshalMessage of %d nested messages: got %v, want %v", maxMessageDepth+1, err, errTooDeep)
+	}
+
+	// Map entries nest too, as a message and its value.
+	entry := []byte{0x12, 0x00} // value: empty msgTree
+	for range maxMessageDepth/2 + 1 {
+		entry = append(AppendUvarint([]byte{0x1a}, uint64(len(entry))), entry...)
+		entry = append(AppendUvarint([]byte{0x12}, uint64(len(entry))), entry...)
+	}
+	if err := UnmarshalMessage(entry, &v); err != errTooDeep {
+		t.Errorf("UnmarshalMessage of nested maps: got %v, want %v", err, errTooDeep)
+	}
+}
+
+func TestAppendMessageCycle(t *testing.T) {
+	p := new(msgTree)
+	p.Next = p
+	s := make([]msgTree, 1)
+	s[0].Kids = s
+	m := make(map[int32]msgTree)
+	m[1] = msgTree{Map: m}
+	tests := []struct {
+		name string
+		v    any
+		err  string
+	}{
+		{"Pointer", p, "binary: encountered a cycle via binary.msgTree"},
+		{"Slice", &s[0], "binary: encountered a cycle via binary.msgTree"},
+		{"Map", m[1], "binary: encountered a cycle via map[int32]binary.msgTree"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AppendMessage(nil, tt.v)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("AppendMessage error = %v, want %s", err, tt.err)
+			}
+		})
+	}
+
+	// A deep message with no cycle, sharing a value
+	// in two places, encodes as usual.
+	shared := new(msgTree)
+	deep := &msgTree{Next: shared, Kids: []msgTree{{Next: shared}}}
+	for range 2 * startDetectingCyclesAfter {
+		deep = &msgTree{Next: deep}
+	}
+	b, err := AppendMessage(nil, deep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v msgTree
+	if err := UnmarshalMessage(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&v, deep) {
+		t.Error("deep message did not round-trip")
+	}
+}
+
+func TestAppendMessageAllocs(t *testing.T) {
+	in := msgAll{
+		Int:     1,
+		String:  strings.Repeat("x", 200),
+		Inner:   msgInner{A: 1},
+		PInner:  &msgInner{S: "s"},
+		Ints:    []int32{1, 2, 3},
+		Strings: []string{"a", "b"},
+		Inners:  []*msgInner{{A: 2}},
+	}
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		var err error
+		if _, err = AppendMessage(buf, &in); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("AppendMessage allocated %v times, want 0", allocs)
+	}
+}
+
+func TestMessageStream(t *testing.T) {
+	in := []personV2{
+		{Name: "a"},
+		{},
+		{Name: strings.Repeat("long", 100), Scores: make([]int32, 1000)},
+		{Age: 1},
+	}
+	var buf bytes.Buffer
+	enc := NewMessageEncoder(&buf)
+	for _, v := range in {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Encode(1); err == nil {
+		t.Error("Encode(1) succeeded")
+	}
+	data := buf.Bytes()
+
+	for _, r := range []io.Reader{
+		bytes.NewReader(data),
+		iotest.OneByteReader(bytes.NewReader(data)),
+		iotest.DataErrReader(bytes.NewReader(data)),
+	} {
+		dec := NewMessageDecoder(r)
+		for i, want := range in {
+			var got personV2
+			if err := dec.Decode(&got); err != nil {
+				t.Fatalf("Decode #%d error: %v", i, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Decode #%d = %+v, want %+v", i, got, want)
+			}
+		}
+		if err := dec.Decode(new(personV2)); err != io.EOF {
+			t.Errorf("Decode at end = %v, want io.EOF", err)
+		}
+	}
+
+	// Messages that do not decode do not stop the stream.
+	dec := NewMessageDecoder(bytes.NewReader(data))
+	var wrong struct {
+		Name int  `binary:"1"`
+		Age  int8 `binary:"2"`
+	}
+	for i, ok := range []bool{false, true, false, true} {
+		err := dec.Decode(&wrong)
+		if ok != (err == nil) {
+			t.Fatalf("Decode #%d error = %v", i, err)
+		}
+	}
+	if wrong.Age != 1 {
+		t.Errorf("Decode after errors = %+v", wrong)
+	}
+
+	for _, n := range []int{1, 3, 6, 7, len(data) - 1} {
+		dec := NewMessageDecoder(bytes.NewReader(data[:n]))
+		var err error
+		for err == nil {
+			err = dec.Decode(new(personV2))
+		}
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("Decode of %d bytes: error = %v, want io.ErrUnexpectedEOF", n, err)
+		}
+	}
+}
+
+func TestMessageDecoderLongLength(t *testing.T) {
+	// A large length must not allocate a large buffer
+	// before the data arrives.
+	data := AppendUvarint(nil, 1<<30)
+	var allocs float64
+	allocs = testing.AllocsPerRun(10, func() {
+		dec := NewMessageDecoder(bytes.NewReader(data))
+		if err := dec.Decode(new(personV2)); err != io.ErrUnexpectedEOF {
+			t.Fatalf("Decode error = %v, want io.ErrUnexpectedEOF", err)
+		}
+	})
+	if allocs > 10 {
+		t.Errorf("Decode allocated %v times", allocs)
+	}
+}
+
+func BenchmarkAppendMessage(b *testing.B) {
+	in := personV2{
+		Name:   "Ann",
+		Age:    42,
+		Email:  "ann@example.com",
+		Scores: []int32{1, 2, 3, 4},
+		Parent: &personV2{Name: "Bob"},
+	}
+	buf, _ := AppendMessage(nil, &in)
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+	for range b.N {
+		buf, _ = AppendMessage(buf[:0], &in)
+	}
+}
+
+func BenchmarkUnmarshalMessage(b *testing.B) {
+	in := personV2{
+		Name:   "Ann",
+		Age:    42,
+		Email:  "ann@example.com",
+		Scores: []int32{1, 2, 3, 4},
+		Parent: &personV2{Name: "Bob"},
+	}
+	buf, _ := AppendMessage(nil, &in)
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+	var out personV2
+	for range b.N {
+		if err := UnmarshalMessage(buf, &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,948 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/internal/structfield"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Unmarshal parses the CBOR-encoded data and stores the result
+// in the value pointed to by v. If v is nil or not a pointer,
+// Unmarshal returns an [InvalidUnmarshalError].
+//
+// data must hold exactly one well-formed CBOR data item whose text
+// strings are valid UTF-8 and whose arrays, maps and tags nest at most
+// 1000 levels deep; otherwise Unmarshal returns a [SyntaxError] without
+// modifying v. Use a [Decoder] to change the nesting limit or to reject
+// maps with duplicate keys.
+//
+// Unmarshal uses the inverse of the encodings that
+// [Marshal] uses, allocating maps, slices, and pointers as necessary,
+// with the following additional rules:
+//
+// To unmarshal CBOR into a pointer, Unmarshal first handles the case of
+// the CBOR being null or undefined. In that case, Unmarshal sets the
+// pointer to nil. Otherwise, Unmarshal unmarshals the CBOR into the value
+// pointed at by the pointer. If the pointer is nil, Unmarshal allocates a
+// new value for it to point to.
+//
+// To unmarshal CBOR into a value implementing [Unmarshaler],
+// Unmarshal calls that value's [Unmarshaler.UnmarshalCBOR] method,
+// including when the input is null.
+// Otherwise, if the value implements [encoding.TextUnmarshaler]
+// and the input is a CBOR text string, Unmarshal calls
+// [encoding.TextUnmarshaler.UnmarshalText] with the contents of the string.
+//
+// To unmarshal CBOR into a struct, Unmarshal matches incoming map keys to
+// the keys used by [Marshal] (either the struct field name or its tag).
+// Unlike [encoding/json], keys are matched case-sensitively.
+// By default, map keys that don't have a corresponding struct field are
+// ignored (see [Decoder.DisallowUnknownFields] for an alternative).
+// A struct with the "toarray" option is unmarshaled from a CBOR array.
+//
+// To unmarshal CBOR into an interface value,
+// Unmarshal stores one of these in the interface value:
+//
+//   - uint64, for CBOR unsigned integers
+//   - int64, for CBOR negative integers that fit in an int64
+//   - *[big.Int], for other negative integers and for bignums
+//   - float64, for CBOR floating-point numbers
+//   - bool, for CBOR booleans
+//   - []byte, for CBOR byte strings
+//   - string, for CBOR text strings
+//   - []any, for CBOR arrays
+//   - map[any]any, for CBOR maps
+//   - [time.Time], for tag 0 and tag 1 date/times
+//   - [Tag], for other tags
+//   - [Simple], for simple values other than false, true, null and undefined
+//   - nil, for CBOR null and undefined
+//
+// Map keys that cannot be Go map keys, such as byte strings and arrays,
+// cause an [UnmarshalTypeError] when unmarshaling into an interface value.
+//
+// A [time.Time] is unmarshaled from a tag 0 date/time string, a tag 1
+// epoch-based date/time, or an untagged value of either form.
+// A [big.Int] is unmarshaled from a CBOR integer or a tag 2 or tag 3
+// bignum, which can also be unmarshaled into Go integer types if it fits.
+// Tags without a built-in Go representation are ignored when
+// unmarshaling into a value that is not an interface or [Tag], and the
+// tag's content is unmarshaled instead. The self-described CBOR tag 55799
+// is always ignored.
+//
+// Integers, including bignums, are converted when unmarshaling into a
+// floating-point value. If a CBOR value is not appropriate for a given
+// target type, or if an integer overflows the target type, Unmarshal
+// skips that field and completes the unmarshaling as best it can.
+// If no more serious errors are encountered, Unmarshal returns
+// an [UnmarshalTypeError] describing the earliest such error.
+//
+// The CBOR null and undefined values unmarshal into an interface, map,
+// pointer, or slice by setting that Go value to nil. Otherwise,
+// unmarshaling them has no effect on the value and produces no error.
+func Unmarshal(data []byte, v any) error {
+	// Check for well-formedness first so that a syntax error
+	// does not leave v half-filled.
+	if err := checkValid(data, defaultMaxDepth); err != nil {
+		return err
+	}
+	d := decodeState{data: data}
+	return d.unmarshal(v)
+}
+
+// Unmarshaler is the interface implemented by types
+// that can unmarshal a CBOR description of themselves.
+// The input can be assumed to be a single well-formed CBOR data item.
+// UnmarshalCBOR must copy the CBOR data
+// if it wishes to retain the data after returning.
+type Unmarshaler interface {
+	UnmarshalCBOR([]byte) error
+}
+
+// An UnmarshalTypeError describes a CBOR value that was
+// not appropriate for a value of a specific Go type.
+type UnmarshalTypeError struct {
+	Value  string       // description of CBOR value - "bool", "array", "integer -5"
+	Type   reflect.Type // type of Go value it could not be assigned to
+	Offset int64        // the value starts at this byte offset
+	Struct string       // name of the struct type containing the field
+	Field  string       // the full path from root node to the field, include embedded struct
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	if e.Struct != "" || e.Field != "" {
+		return "cbor: cannot unmarshal " + e.Value + " into Go struct field " + e.Struct + "." + e.Field + " of type " + e.Type.String()
+	}
+	return "cbor: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
+}
+
+// An InvalidUnmarshalError describes an invalid argument passed to [Unmarshal].
+// (The argument to [Unmarshal] must be a non-nil pointer.)
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnmarshalError) Error() string {
+	if e.Type == nil {
+		return "cbor: Unmarshal(nil)"
+	}
+
+	if e.Type.Kind() != reflect.Pointer {
+		return "cbor: Unmarshal(non-pointer " + e.Type.String() + ")"
+	}
+	return "cbor: Unmarshal(nil " + e.Type.String() + ")"
+}
+
+func (d *decodeState) unmarshal(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &InvalidUnmarshalError{reflect.TypeOf(v)}
+	}
+
+	// We decode rv not rv.Elem because the Unmarshaler interface
+	// test must be applied at the top level of the value.
+	err := d.value(rv)
+	if err != nil {
+		return d.addErrorContext(err)
+	}
+	return d.savedError
+}
+
+// decodeState represents the state while decoding a CBOR data item that
+// has already been checked for well-formedness.
+type decodeState struct {
+	data         []byte
+	off          int   // next read offset in data
+	base         int64 // offset of data[0] in the input, for errors
+	errorContext *errorContext
+	savedError   error
+	errorCount   int
+
+	disallowUnknownFields bool
+	disallowDuplicateKeys bool
+}
+
+// An errorContext provides context for type errors during decoding.
+type errorContext struct {
+	Struct     reflect.Type
+	FieldStack []string
+}
+
+// saveError saves the first err it is called with,
+// for reporting at the end of the unmarshal.
+func (d *decodeState) saveError(err error) {
+	d.errorCount++
+	if d.savedError == nil {
+		d.savedError = d.addErrorContext(err)
+	}
+}
+
+// addErrorContext returns a new error enhanced with information from d.errorContext
+func (d *decodeState) addErrorContext(err error) error {
+	if d.errorContext != nil && (d.errorContext.Struct != nil || len(d.errorContext.FieldStack) > 0) {
+		switch err := err.(type) {
+		case *UnmarshalTypeError:
+			err.Struct = d.errorContext.Struct.Name()
+			fieldStack := d.errorContext.FieldStack
+			if err.Field != "" {
+				fieldStack = append(fieldStack, err.Field)
+			}
+			err.Field = joinFields(fieldStack)
+		}
+	}
+	return err
+}
+
+func joinFields(fields []string) string {
+	var b []byte
+	for i, f := range fields {
+		if i > 0 {
+			b = append(b, '.')
+		}
+		b = append(b, f...)
+	}
+	return string(b)
+}
+
+// typeError records an UnmarshalTypeError for the item at start and
+// skips the rest of it.
+func (d *decodeState) typeError(start int, t reflect.Type) {
+	d.off = start
+	desc := d.describe()
+	d.skip()
+	d.saveError(&UnmarshalTypeError{Value: desc, Type: t, Offset: d.base + int64(start)})
+}
+
+// describe returns a description of the item at d.off for use in errors.
+func (d *decodeState) describe() string {
+	b := d.data[d.off]
+	switch b >> 5 {
+	case majorUint, majorNegint:
+		return "integer"
+	case majorBytes:
+		return "byte string"
+	case majorText:
+		return "text string"
+	case majorArray:
+		return "array"
+	case majorMap:
+		return "map"
+	case majorTag:
+		_, _, num := d.peekHead()
+		return "tag " + strconv.FormatUint(num, 10)
+	}
+	switch {
+	case b == cborFalse || b == cborTrue:
+		return "bool"
+	case b == cborNull:
+		return "null"
+	case b == cborUndefined:
+		return "undefined"
+	case b >= cborFloat16 && b <= cborFloat64:
+		return "float"
+	}
+	return "simple value"
+}
+
+// head reads the initial byte and argument of a data item.
+func (d *decodeState) head() (major, ai byte, arg uint64) {
+	b := d.data[d.off]
+	d.off++
+	major, ai = b>>5, b&0x1f
+	switch {
+	case ai < 24:
+		arg = uint64(ai)
+	case ai <= 27:
+		n := 1 << (ai - 24)
+		arg = readUint(d.data[d.off : d.off+n])
+		d.off += n
+	}
+	return major, ai, arg
+}
+
+func (d *decodeState) peekHead() (major, ai byte, arg uint64) {
+	off := d.off
+	major, ai, arg = d.head()
+	d.off = off
+	return major, ai, arg
+}
+
+// skip skips over the data item at d.off.
+func (d *decodeState) skip() {
+	s := scanner{data: d.data, off: d.off, maxDepth: int(^uint(0) >> 1)}
+	if err := s.item(0); err != nil {
+		panic("cbor: skip of invalid data: " + err.Error())
+	}
+	d.off = s.off
+}
+
+// skipSelfDescribed skips any self-described CBOR tags at d.off.
+func (d *decodeState) skipSelfDescribed() {
+	for {
+		major, _, arg := d.peekHead()
+		if major != majorTag || arg != tagSelfDescribed {
+			return
+		}
+		d.head()
+	}
+}
+
+// more reports whether another element or key-value pair follows in
+// an array or map whose head had additional information ai and argument
+// n, when i elements have been read. It consumes the break code that
+// ends an indefinite-length item.
+func (d *decodeState) more(ai byte, n, i uint64) bool {
+	if ai == aiIndefinite {
+		if d.data[d.off] == cborBreak {
+			d.off++
+			return false
+		}
+		return true
+	}
+	return i < n
+}
+
+// readString returns the contents of a byte or text string whose head
+// has been read. The result aliases d.data for definite-length strings.
+func (d *decodeState) readString(ai byte, n uint64) []byte {
+	if ai != aiIndefinite {
+		b := d.data[d.off : d.off+int(n)]
+		d.off += int(n)
+		return b
+	}
+	b := []byte{}
+	for d.data[d.off] != cborBreak {
+		_, _, n := d.head()
+		b = append(b, d.data[d.off:d.off+int(n)]...)
+		d.off += int(n)
+	}
+	d.off++
+	return b
+}
+
+// readFloat returns the value of a floating-point item whose initial
+// byte is b and whose bits have been read as arg.
+func readFloat(b byte, arg uint64) float64 {
+	switch b {
+	case cborFloat16:
+		return float16ToFloat64(uint16(arg))
+	case cborFloat32:
+		return float64(math.Float32frombits(uint32(arg)))
+	default:
+		return math.Float64frombits(arg)
+	}
+}
+
+// float16ToFloat64 converts IEEE 754 half-precision bits to a float64.
+func float16ToFloat64(h uint16) float64 {
+	exp := int(h >> 10 & 0x1f)
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// readBignum decodes the content of a tag 2 or tag 3 bignum
+// whose tag head has been read. It reports false if the content
+// is not a byte string, in which case it has not been consumed.
+func (d *decodeState) readBignum(num uint64) (*big.Int, bool) {
+	major, ai, arg := d.peekHead()
+	if major != majorBytes {
+		return nil, false
+	}
+	d.head()
+	x := new(big.Int).SetBytes(d.readString(ai, arg))
+	if num == tagNegBignum {
+		x.Not(x)
+	}
+	return x, true
+}
+
+// indirect walks down v allocating pointers as needed,
+// until it gets to a non-pointer.
+// If it encounters an Unmarshaler, indirect stops and returns that.
+// If decodingNull is true, indirect stops at the first settable pointer so it
+// can be set to nil.
+func indirect(v reflect.Value, decodingNull bool) (u Unmarshaler, ut encoding.TextUnmarshaler, pv reflect.Value) {
+	pv = structfield.Indirect(v, decodingNull, func(v reflect.Value) bool {
+		var ok bool
+		if u, ok = v.Interface().(Unmarshaler); ok {
+			return true
+		}
+		// Times and big integers have CBOR representations
+		// that take precedence over their text form.
+		if et := v.Type().Elem(); decodingNull || et == timeType || et == bigIntType {
+			return false
+		}
+		ut, ok = v.Interface().(encoding.TextUnmarshaler)
+		return ok
+	})
+	return u, ut, pv
+}
+
+// value decodes the data item at d.off into v.
+// If v is invalid, the item is skipped.
+func (d *decodeState) value(v reflect.Value) error {
+	d.skipSelfDescribed()
+	if !v.IsValid() {
+		d.skip()
+		return nil
+	}
+	start := d.off
+	b := d.data[start]
+	isNull := b == cborNull || b == cborUndefined
+	u, ut, pv := indirect(v, isNull)
+	if u != nil {
+		d.skip()
+		return u.UnmarshalCBOR(d.data[start:d.off])
+	}
+	if ut != nil {
+		if b>>5 != majorText {
+			d.typeError(start, v.Type())
+			return nil
+		}
+		_, ai, arg := d.head()
+		return ut.UnmarshalText(d.readString(ai, arg))
+	}
+	v = pv
+
+	if isNull {
+		d.off++
+		switch v.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
+			v.SetZero()
+			// otherwise, ignore null for primitives/string
+		}
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		return d.time(v)
+	case bigIntType:
+		return d.bigInt(v)
+	case tagType:
+		if b>>5 != majorTag {
+			d.typeError(start, v.Type())
+			return nil
+		}
+		_, _, num := d.head()
+		v.Set(reflect.ValueOf(Tag{Number: num, Content: d.valueInterface()}))
+		return nil
+	}
+
+	if v.Kind() == reflect.Interface {
+		if v.NumMethod() != 0 {
+			d.typeError(start, v.Type())
+			return nil
+		}
+		if x := d.valueInterface(); x != nil {
+			v.Set(reflect.ValueOf(x))
+		} else {
+			v.SetZero()
+		}
+		return nil
+	}
+
+	major, ai, arg := d.head()
+	switch major {
+	case majorUint, majorNegint:
+		d.integer(v, start, major == majorNegint, arg)
+
+	case majorBytes:
+		s := d.readString(ai, arg)
+		switch {
+		case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+			v.SetBytes(bytes.Clone(s))
+		case v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 && v.Len() == len(s):
+			for i, c := range s {
+				v.Index(i).SetUint(uint64(c))
+			}
+		default:
+			d.typeError(start, v.Type())
+		}
+
+	case majorText:
+		s := d.readString(ai, arg)
+		if v.Kind() != reflect.String {
+			d.typeError(start, v.Type())
+			return nil
+		}
+		v.SetString(string(s))
+
+	case majorArray:
+		return d.array(v, start, ai, arg)
+
+	case majorMap:
+		switch v.Kind() {
+		case reflect.Map:
+			return d.mapValue(v, start, ai, arg)
+		case reflect.Struct:
+			if !cachedTypeFields(v.Type()).toArray {
+				return d.object(v, ai, arg)
+			}
+		}
+		d.typeError(start, v.Type())
+
+	case majorTag:
+		if arg == tagPosBignum || arg == tagNegBignum {
+			switch v.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64:
+				if x, ok := d.readBignum(arg); ok {
+					d.setBigInt(v, start, x)
+					return nil
+				}
+			}
+		}
+		// Ignore tags without a Go representation
+		// and decode their content.
+		return d.value(v)
+
+	case majorSimple:
+		switch {
+		case b == cborFalse || b == cborTrue:
+			if v.Kind() != reflect.Bool {
+				d.typeError(start, v.Type())
+				return nil
+			}
+			v.SetBool(b == cborTrue)
+		case b >= cborFloat16 && b <= cborFloat64:
+			f := readFloat(b, arg)
+			if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+				d.typeError(start, v.Type())
+				return nil
+			}
+			if v.OverflowFloat(f) {
+				d.saveError(&UnmarshalTypeError{Value: "float " + strconv.FormatFloat(f, 'g', -1, 64), Type: v.Type(), Offset: d.base + int64(start)})
+				return nil
+			}
+			v.SetFloat(f)
+		default:
+			if v.Type() != simpleType {
+				d.typeError(start, v.Type())
+				return nil
+			}
+			v.SetUint(arg)
+		}
+	}
+	return nil
+}
+
+// integer stores the CBOR integer n, or -1-n if neg is set, into v.
+func (d *decodeState) integer(v reflect.Value, start int, neg bool, n uint64) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n <= math.MaxInt64 {
+			i := int64(n)
+			if neg {
+				i = -1 - i
+			}
+			if !v.OverflowInt(i) {
+				v.SetInt(i)
+				return
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if neg {
+			break
+		}
+		if !v.OverflowUint(n) {
+			v.SetUint(n)
+			return
+		}
+	case reflect.Float32, reflect.Float64:
+		f := float64(n)
+		if neg {
+			f = -1 - f
+		}
+		v.SetFloat(f)
+		return
+	default:
+		d.saveError(&UnmarshalTypeError{Value: "integer", Type: v.Type(), Offset: d.base + int64(start)})
+		return
+	}
+	s := strconv.FormatUint(n, 10)
+	if neg {
+		s = new(big.Int).Not(new(big.Int).SetUint64(n)).String()
+	}
+	d.saveError(&UnmarshalTypeError{Value: "integer " + s, Type: v.Type(), Offset: d.base + int64(start)})
+}
+
+// setBigInt stores x into the integer or floating-point value v.
+func (d *decodeState) setBigInt(v reflect.Value, start int, x *big.Int) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, _ := new(big.Float).SetInt(x).Float64()
+		v.SetFloat(f)
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if x.IsInt64() && !v.OverflowInt(x.Int64()) {
+			v.SetInt(x.Int64())
+			return
+		}
+	default:
+		if x.IsUint64() && !v.OverflowUint(x.Uint64()) {
+			v.SetUint(x.Uint64())
+			return
+		}
+	}
+	d.saveError(&UnmarshalTypeError{Value: "integer " + x.String(), Type: v.Type(), Offset: d.base + int64(start)})
+}
+
+func (d *decodeState) bigInt(v reflect.Value) error {
+	start := d.off
+	major, _, arg := d.head()
+	x := v.Addr().Interface().(*big.Int)
+	switch major {
+	case majorUint:
+		x.SetUint64(arg)
+	case majorNegint:
+		x.Not(x.SetUint64(arg))
+	case majorTag:
+		if arg == tagPosBignum || arg == tagNegBignum {
+			if y, ok := d.readBignum(arg); ok {
+				x.Set(y)
+				return nil
+			}
+		}
+		d.typeError(start, v.Type())
+	default:
+		d.typeError(start, v.Type())
+	}
+	return nil
+}
+
+func (d *decodeState) time(v reflect.Value) error {
+	start := d.off
+	major, _, arg := d.peekHead()
+	if major == majorTag {
+		if arg != tagDateTimeString && arg != tagEpochDateTime {
+			d.typeError(start, v.Type())
+			return nil
+		}
+		d.head()
+	}
+	t, ok := d.readTime()
+	if !ok {
+		d.typeError(start, v.Type())
+		return nil
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// readTime decodes an RFC 3339 date/time string or a number of seconds
+// since the Unix epoch. It reports false if the item at d.off is neither,
+// in which case it has not been consumed.
+func (d *decodeState) readTime() (time.Time, bool) {
+	start := d.off
+	b := d.data[start]
+	major, ai, arg := d.head()
+	switch major {
+	case majorText:
+		t, err := time.Parse(time.RFC3339, string(d.readString(ai, arg)))
+		if err == nil {
+			return t, true
+		}
+	case majorUint:
+		if arg <= math.MaxInt64 {
+			return time.Unix(int64(arg), 0).UTC(), true
+		}
+	case majorNegint:
+		if arg <= math.MaxInt64 {
+			return time.Unix(-1-int64(arg), 0).UTC(), true
+		}
+	case majorSimple:
+		if b >= cborFloat16 && b <= cborFloat64 {
+			f := readFloat(b, arg)
+			if math.Abs(f) < 1<<63 {
+				sec, frac := math.Modf(f)
+				return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+			}
+		}
+	}
+	d.off = start
+	return time.Time{}, false
+}
+
+// array decodes an array whose head has been read into v.
+func (d *decodeState) array(v reflect.Value, start int, ai byte, n uint64) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		i := 0
+		for ; d.more(ai, n, uint64(i)); i++ {
+			if i >= v.Cap() {
+				v.Grow(1)
+			}
+			if i >= v.Len() {
+				v.SetLen(i + 1)
+			}
+			if err := d.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		if i < v.Len() {
+			v.SetLen(i)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		}
+	case reflect.Array:
+		i := 0
+		for ; d.more(ai, n, uint64(i)); i++ {
+			var elem reflect.Value
+			if i < v.Len() {
+				elem = v.Index(i)
+			}
+			if err := d.value(elem); err != nil {
+				return err
+			}
+		}
+		for ; i < v.Len(); i++ {
+			v.Index(i).SetZero() // zero remainder of array
+		}
+	case reflect.Struct:
+		fields := cachedTypeFields(v.Type())
+		if !fields.toArray {
+			d.typeError(start, v.Type())
+			return nil
+		}
+		for i := 0; d.more(ai, n, uint64(i)); i++ {
+			if i >= len(fields.list) {
+				d.skip()
+				continue
+			}
+			f := &fields.list[i]
+			if err := d.fieldValue(v, d.structField(v, f), f.name); err != nil {
+				return err
+			}
+		}
+	default:
+		d.typeError(start, v.Type())
+	}
+	return nil
+}
+
+// fieldValue decodes the next item into subv, which is the field with the
+// given name of the struct v, maintaining the error context.
+func (d *decodeState) fieldValue(v, subv reflect.Value, name string) error {
+	if !subv.IsValid() {
+		d.skip()
+		return nil
+	}
+	if d.errorContext == nil {
+		d.errorContext = new(errorContext)
+	}
+	origErrorContext := *d.errorContext
+	d.errorContext.FieldStack = append(d.errorContext.FieldStack, name)
+	d.errorContext.Struct = v.Type()
+	err := d.value(subv)
+	d.errorContext.FieldStack = origErrorContext.FieldStack
+	d.errorContext.Struct = origErrorContext.Struct
+	return err
+}
+
+// structField returns the field f of the struct v, allocating embedded
+// pointers as needed. It returns the zero Value if f cannot be set.
+func (d *decodeState) structField(v reflect.Value, f *field) reflect.Value {
+	subv, err := structfield.ByIndexAlloc(v, f.index)
+	if err != nil {
+		d.saveError(errors.New("cbor: " + err.Error()))
+	}
+	return subv
+}
+
+// duplicateKey records an error for a repeated map key at off.
+func (d *decodeState) duplicateKey(off int) {
+	d.saveError(&SyntaxError{"cbor: duplicate map key", d.base + int64(off)})
+}
+
+// mapValue decodes a map whose head has been read into the Go map v.
+func (d *decodeState) mapValue(v reflect.Value, start int, ai byte, n uint64) error {
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	var seen map[any]struct{}
+	if d.disallowDuplicateKeys {
+		seen = make(map[any]struct{})
+	}
+	for i := uint64(0); d.more(ai, n, i); i++ {
+		keyStart := d.off
+		errs := d.errorCount
+		kv := reflect.New(t.Key()).Elem()
+		if err := d.value(kv); err != nil {
+			return err
+		}
+		if d.errorCount != errs {
+			// The key could not be decoded; drop the entry.
+			d.skip()
+			continue
+		}
+		if seen != nil && kv.Comparable() {
+			k := kv.Interface()
+			if _, ok := seen[k]; ok {
+				d.duplicateKey(keyStart)
+			}
+			seen[k] = struct{}{}
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if err := d.value(elem); err != nil {
+			return err
+		}
+		v.SetMapIndex(kv, elem)
+	}
+	return nil
+}
+
+// object decodes a map whose head has been read into the struct v.
+func (d *decodeState) object(v reflect.Value, ai byte, n uint64) error {
+	fields := cachedTypeFields(v.Type())
+	var seen map[*field]bool
+	if d.disallowDuplicateKeys {
+		seen = make(map[*field]bool)
+	}
+	for i := uint64(0); d.more(ai, n, i); i++ {
+		keyStart := d.off
+		var f *field
+		var key string
+		major, kai, karg := d.peekHead()
+		switch major {
+		case majorText:
+			d.head()
+			key = string(d.readString(kai, karg))
+			f = fields.byName[key]
+			key = strconv.Quote(key)
+		case majorUint, majorNegint:
+			d.head()
+			if karg <= math.MaxInt64 {
+				k := int64(karg)
+				if major == majorNegint {
+					k = -1 - k
+				}
+				f = fields.byInt[k]
+				key = strconv.FormatInt(k, 10)
+			} else {
+				key = "integer key"
+			}
+		default:
+			key = d.describe() + " key"
+			d.skip()
+		}
+		if f == nil {
+			if d.disallowUnknownFields {
+				d.saveError(fmt.Errorf("cbor: unknown field %s", key))
+			}
+			d.skip()
+			continue
+		}
+		if seen != nil {
+			if seen[f] {
+				d.duplicateKey(keyStart)
+			}
+			seen[f] = true
+		}
+		subv := d.structField(v, f)
+		if err := d.fieldValue(v, subv, f.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// valueInterface decodes the data item at d.off into a Go value
+// of the kind documented for Unmarshal into an interface.
+func (d *decodeState) valueInterface() any {
+	d.skipSelfDescribed()
+	start := d.off
+	b := d.data[start]
+	major, ai, arg := d.head()
+	switch major {
+	case majorUint:
+		return arg
+	case majorNegint:
+		if arg <= math.MaxInt64 {
+			return -1 - int64(arg)
+		}
+		x := new(big.Int).SetUint64(arg)
+		return x.Not(x)
+	case majorBytes:
+		return bytes.Clone(d.readString(ai, arg))
+	case majorText:
+		return string(d.readString(ai, arg))
+	case majorArray:
+		a := []any{}
+		for i := uint64(0); d.more(ai, arg, i); i++ {
+			a = append(a, d.valueInterface())
+		}
+		return a
+	case majorMap:
+		m := make(map[any]any)
+		for i := uint64(0); d.more(ai, arg, i); i++ {
+			keyStart := d.off
+			k := d.valueInterface()
+			if k != nil && !reflect.ValueOf(k).Comparable() {
+				d.typeError(keyStart, reflect.TypeFor[any]())
+				d.skip()
+				continue
+			}
+			if _, ok := m[k]; ok && d.disallowDuplicateKeys {
+				d.duplicateKey(keyStart)
+			}
+			m[k] = d.valueInterface()
+		}
+		return m
+	case majorTag:
+		switch arg {
+		case tagDateTimeString, tagEpochDateTime:
+			if t, ok := d.readTime(); ok {
+				return t
+			}
+		case tagPosBignum, tagNegBignum:
+			if x, ok := d.readBignum(arg); ok {
+				return x
+			}
+		default:
+			return Tag{Number: arg, Content: d.valueInterface()}
+		}
+		d.typeError(start, reflect.TypeFor[any]())
+		return nil
+	}
+	switch {
+	case b == cborFalse:
+		return false
+	case b == cborTrue:
+		return true
+	case b == cborNull || b == cborUndefined:
+		return nil
+	case b >= cborFloat16 && b <= cborFloat64:
+		return readFloat(b, arg)
+	}
+	return Simple(arg)
+}
@@ -0,0 +1,441 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func bigInt(s string) *big.Int {
+	x, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("bad big.Int " + s)
+	}
+	return x
+}
+
+// appendixA lists the examples of RFC 8949 Appendix A together with the
+// value they unmarshal to when decoded into an interface. If enc is set,
+// marshaling the value produces the example again.
+var appendixA = []struct {
+	hex string
+	val any
+	enc bool
+}{
+	{"00", uint64(0), true},
+	{"01", uint64(1), true},
+	{"0a", uint64(10), true},
+	{"17", uint64(23), true},
+	{"1818", uint64(24), true},
+	{"1819", uint64(25), true},
+	{"1864", uint64(100), true},
+	{"1903e8", uint64(1000), true},
+	{"1a000f4240", uint64(1000000), true},
+	{"1b000000e8d4a51000", uint64(1000000000000), true},
+	{"1bffffffffffffffff", uint64(18446744073709551615), true},
+	{"c249010000000000000000", bigInt("18446744073709551616"), true},
+	{"3bffffffffffffffff", bigInt("-18446744073709551616"), true},
+	{"c349010000000000000000", bigInt("-18446744073709551617"), true},
+	{"20", int64(-1), true},
+	{"29", int64(-10), true},
+	{"3863", int64(-100), true},
+	{"3903e7", int64(-1000), true},
+	{"f90000", 0.0, true},
+	{"f98000", math.Copysign(0, -1), true},
+	{"f93c00", 1.0, true},
+	{"fb3ff199999999999a", 1.1, true},
+	{"f93e00", 1.5, true},
+	{"f97bff", 65504.0, true},
+	{"fa47c35000", 100000.0, true},
+	{"fa7f7fffff", 3.4028234663852886e+38, true},
+	{"fb7e37e43c8800759c", 1.0e+300, true},
+	{"f90001", 5.960464477539063e-8, true},
+	{"f90400", 0.00006103515625, true},
+	{"f9c400", -4.0, true},
+	{"fbc010666666666666", -4.1, true},
+	{"f97c00", math.Inf(1), true},
+	{"f97e00", math.NaN(), true},
+	{"f9fc00", math.Inf(-1), true},
+	{"fa7f800000", math.Inf(1), false},
+	{"fa7fc00000", math.NaN(), false},
+	{"faff800000", math.Inf(-1), false},
+	{"fb7ff0000000000000", math.Inf(1), false},
+	{"fb7ff8000000000000", math.NaN(), false},
+	{"fbfff0000000000000", math.Inf(-1), false},
+	{"f4", false, true},
+	{"f5", true, true},
+	{"f6", nil, true},
+	{"f7", nil, false},
+	{"f0", Simple(16), true},
+	{"f8ff", Simple(255), true},
+	{"c074323031332d30332d32315432303a30343a30305a", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), true},
+	{"c11a514b67b0", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), false},
+	{"c1fb41d452d9ec200000", time.Date(2013, 3, 21, 20, 4, 0, 5e8, time.UTC), false},
+	{"d74401020304", Tag{23, []byte{1, 2, 3, 4}}, true},
+	{"d818456449455446", Tag{24, []byte("dIETF")}, true},
+	{"d82076687474703a2f2f7777772e6578616d706c652e636f6d", Tag{32, "http://www.example.com"}, true},
+	{"40", []byte{}, true},
+	{"4401020304", []byte{1, 2, 3, 4}, true},
+	{"60", "", true},
+	{"6161", "a", true},
+	{"6449455446", "IETF", true},
+	{"62225c", `"\`, true},
+	{"62c3bc", "ü", true},
+	{"63e6b0b4", "水", true},
+	{"64f0908591", "𐅑", true},
+	{"80", []any{}, true},
+	{"83010203", []any{uint64(1), uint64(2), uint64(3)}, true},
+	{"8301820203820405", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}, true},
+	{"98190102030405060708090a0b0c0d0e0f101112131415161718181819", count(25), true},
+	{"a0", map[any]any{}, true},
+	{"a201020304", map[any]any{uint64(1): uint64(2), uint64(3): uint64(4)}, true},
+	{"a26161016162820203", map[any]any{"a": uint64(1), "b": []any{uint64(2), uint64(3)}}, true},
+	{"826161a161626163", []any{"a", map[any]any{"b": "c"}}, true},
+	{"a56161614161626142616361436164614461656145", map[any]any{"a": "A", "b": "B", "c": "C", "d": "D", "e": "E"}, true},
+	{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}, false},
+	{"7f657374726561646d696e67ff", "streaming", false},
+	{"9fff", []any{}, false},
+	{"9f018202039f0405ffff", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}, false},
+	{"9f01820203820405ff", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}, false},
+	{"83018202039f0405ff", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}, false},
+	{"83019f0203ff820405", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}, false},
+	{"9f0102030405060708090a0b0c0d0e0f101112131415161718181819ff", count(25), false},
+	{"bf61610161629f0203ffff", map[any]any{"a": uint64(1), "b": []any{uint64(2), uint64(3)}}, false},
+	{"826161bf61626163ff", []any{"a", map[any]any{"b": "c"}}, false},
+	{"bf6346756ef563416d7421ff", map[any]any{"Fun": true, "Amt": int64(-2)}, false},
+}
+
+// count returns the slice of uint64 values 1 through n.
+func count(n int) []any {
+	a := make([]any, n)
+	for i := range a {
+		a[i] = uint64(i + 1)
+	}
+	return a
+}
+
+// equal is like reflect.DeepEqual but compares NaNs, big integers and
+// times by value.
+func equal(x, y any) bool {
+	switch x := x.(type) {
+	case float64:
+		if y, ok := y.(float64); ok && math.IsNaN(x) && math.IsNaN(y) {
+			return true
+		}
+		if y, ok := y.(float64); ok && x == 0 && y == 0 {
+			return math.Signbit(x) == math.Signbit(y)
+		}
+	case *big.Int:
+		y, ok := y.(*big.Int)
+		return ok && x.Cmp(y) == 0
+	case time.Time:
+		y, ok := y.(time.Time)
+		return ok && x.Equal(y)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestUnmarshalAppendixA(t *testing.T) {
+	for _, tt := range appendixA {
+		var v any
+		if err := Unmarshal(mustHex(tt.hex), &v); err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tt.hex, err)
+			continue
+		}
+		if !equal(v, tt.val) {
+			t.Errorf("Unmarshal(%s):\n\tgot  %#v\n\twant %#v", tt.hex, v, tt.val)
+		}
+	}
+}
+
+type unmarshalTest struct {
+	name string
+	in   string // hex
+	ptr  any    // new(type)
+	out  any
+	err  error
+}
+
+type COSEKey struct {
+	Kty int    `cbor:"1,keyasint"`
+	Alg int    `cbor:"3,keyasint,omitempty"`
+	Crv int    `cbor:"-1,keyasint,omitempty"`
+	X   []byte `cbor:"-2,keyasint,omitempty"`
+}
+
+type Pair struct {
+	_ struct{} `cbor:",toarray"`
+	A int
+	B string
+}
+
+type Inner struct {
+	N int `cbor:"n"`
+}
+
+type Outer struct {
+	Name  string `cbor:"name"`
+	Inner        // promoted
+	Tags  []string
+	When  time.Time
+	Big   *big.Int
+	Addr  netip.Addr
+	Skip  int `cbor:"-"`
+}
+
+var unmarshalTests = []unmarshalTest{
+	{name: "Int", in: "182a", ptr: new(int), out: 42},
+	{name: "NegInt8", in: "387f", ptr: new(int8), out: int8(-128)},
+	{name: "Uint8", in: "18ff", ptr: new(uint8), out: uint8(255)},
+	{name: "IntToFloat", in: "20", ptr: new(float64), out: -1.0},
+	{name: "Float32", in: "fa47c35000", ptr: new(float32), out: float32(100000)},
+	{name: "BignumToInt64", in: "c24101", ptr: new(int64), out: int64(1)},
+	{name: "Bytes", in: "4401020304", ptr: new([]byte), out: []byte{1, 2, 3, 4}},
+	{name: "ByteArray", in: "43010203", ptr: new([3]byte), out: [3]byte{1, 2, 3}},
+	{name: "String", in: "6449455446", ptr: new(string), out: "IETF"},
+	{name: "StringIndefinite", in: "7f657374726561646d696e67ff", ptr: new(string), out: "streaming"},
+	{name: "Slice", in: "83010203", ptr: new([]int), out: []int{1, 2, 3}},
+	{name: "Array", in: "83010203", ptr: new([2]int), out: [2]int{1, 2}},
+	{name: "Map", in: "a201020304", ptr: new(map[int]int), out: map[int]int{1: 2, 3: 4}},
+	{name: "StringMap", in: "a26161016162f5", ptr: new(map[string]any), out: map[string]any{"a": uint64(1), "b": true}},
+	{name: "NullPointer", in: "f6", ptr: new(*int), out: (*int)(nil)},
+	{name: "Pointer", in: "01", ptr: new(*int), out: func() *int { i := 1; return &i }()},
+	{name: "SelfDescribed", in: "d9d9f701", ptr: new(int), out: 1},
+	{name: "UnknownTagContent", in: "d8206161", ptr: new(string), out: "a"},
+	{name: "Tag", in: "d8206161", ptr: new(Tag), out: Tag{32, "a"}},
+	{name: "Simple", in: "f0", ptr: new(Simple), out: Simple(16)},
+	{name: "TimeString", in: "c074323031332d30332d32315432303a30343a30305a", ptr: new(time.Time), out: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+	{name: "TimeEpoch", in: "c11a514b67b0", ptr: new(time.Time), out: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+	{name: "TimeUntagged", in: "1a514b67b0", ptr: new(time.Time), out: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+	{name: "BigInt", in: "c249010000000000000000", ptr: new(big.Int), out: *bigInt("18446744073709551616")},
+	{name: "BigIntSmall", in: "3863", ptr: new(big.Int), out: *big.NewInt(-100)},
+	{name: "TextUnmarshaler", in: "6a3139322e302e322e3130", ptr: new(netip.Addr), out: netip.MustParseAddr("192.0.2.10")},
+	{
+		name: "COSEKey",
+		in:   "a4010203262001215820" + strings.Repeat("ab", 32),
+		ptr:  new(COSEKey),
+		out:  COSEKey{Kty: 2, Alg: -7, Crv: 1, X: mustHex(strings.Repeat("ab", 32))},
+	},
+	{name: "ToArray", in: "8202626263", ptr: new(Pair), out: Pair{A: 2, B: "bc"}},
+	{name: "ToArrayExtra", in: "83026162f5", ptr: new(Pair), out: Pair{A: 2, B: "b"}},
+	{
+		name: "Embedded",
+		in:   "a3646e616d656178616e0764536b697001",
+		ptr:  new(Outer),
+		out:  Outer{Name: "x", Inner: Inner{N: 7}},
+	},
+	{
+		name: "CaseSensitive",
+		in:   "a1644e414d456178",
+		ptr:  new(Outer),
+		out:  Outer{},
+	},
+
+	// Type errors.
+	{
+		name: "IntOverflow",
+		in:   "190100",
+		ptr:  new(uint8),
+		out:  uint8(0),
+		err:  &UnmarshalTypeError{Value: "integer 256", Type: reflect.TypeFor[uint8](), Offset: 0},
+	},
+	{
+		name: "NegativeToUint",
+		in:   "20",
+		ptr:  new(uint),
+		out:  uint(0),
+		err:  &UnmarshalTypeError{Value: "integer -1", Type: reflect.TypeFor[uint](), Offset: 0},
+	},
+	{
+		name: "TextToInt",
+		in:   "6161",
+		ptr:  new(int),
+		out:  0,
+		err:  &UnmarshalTypeError{Value: "text string", Type: reflect.TypeFor[int](), Offset: 0},
+	},
+	{
+		name: "BytesToString",
+		in:   "4101",
+		ptr:  new(string),
+		out:  "",
+		err:  &UnmarshalTypeError{Value: "byte string", Type: reflect.TypeFor[string](), Offset: 0},
+	},
+	{
+		name: "ByteArrayLength",
+		in:   "420102",
+		ptr:  new([3]byte),
+		out:  [3]byte{},
+		err:  &UnmarshalTypeError{Value: "byte string", Type: reflect.TypeFor[[3]byte](), Offset: 0},
+	},
+	{
+		name: "StructField",
+		in:   "a2646e616d6501636e6f7702",
+		ptr:  new(Outer),
+		out:  Outer{},
+		err:  &UnmarshalTypeError{Value: "integer", Type: reflect.TypeFor[string](), Offset: 6, Struct: "Outer", Field: "name"},
+	},
+	{
+		name: "TagToTime",
+		in:   "c26161",
+		ptr:  new(time.Time),
+		out:  time.Time{},
+		err:  &UnmarshalTypeError{Value: "tag 2", Type: reflect.TypeFor[time.Time](), Offset: 0},
+	},
+	{
+		name: "BytesMapKey",
+		in:   "a2410001410102",
+		ptr:  new(any),
+		out:  map[any]any{},
+		err:  &UnmarshalTypeError{Value: "byte string", Type: reflect.TypeFor[any](), Offset: 1},
+	},
+	{
+		name: "MapToArrayStruct",
+		in:   "a0",
+		ptr:  new(Pair),
+		out:  Pair{},
+		err:  &UnmarshalTypeError{Value: "map", Type: reflect.TypeFor[Pair](), Offset: 0},
+	},
+
+	// Syntax errors.
+	{name: "Empty", in: "", ptr: new(any), err: &SyntaxError{"cbor: unexpected end of input", 0}},
+	{name: "Truncated", in: "1a0001", ptr: new(any), err: &SyntaxError{"cbor: unexpected end of input", 3}},
+	{name: "TruncatedString", in: "6461", ptr: new(any), err: &SyntaxError{"cbor: unexpected end of input", 2}},
+	{name: "HugeLength", in: "5bffffffffffffffff", ptr: new(any), err: &SyntaxError{"cbor: unexpected end of input", 9}},
+	{name: "Trailing", in: "0102", ptr: new(any), err: &SyntaxError{"cbor: trailing data after top-level item", 1}},
+	{name: "Reserved", in: "1c", ptr: new(any), err: &SyntaxError{"cbor: reserved additional information 28", 0}},
+	{name: "IndefiniteInt", in: "1f", ptr: new(any), err: &SyntaxError{"cbor: indefinite length not allowed for major type 0", 0}},
+	{name: "IndefiniteTag", in: "df", ptr: new(any), err: &SyntaxError{"cbor: indefinite length not allowed for major type 6", 0}},
+	{name: "Break", in: "ff", ptr: new(any), err: &SyntaxError{"cbor: unexpected break", 0}},
+	{name: "BreakInArray", in: "81ff", ptr: new(any), err: &SyntaxError{"cbor: unexpected break", 1}},
+	{name: "BadSimple", in: "f818", ptr: new(any), err: &SyntaxError{"cbor: invalid simple value 24", 0}},
+	{name: "BadChunk", in: "5f6161ff", ptr: new(any), err: &SyntaxError{"cbor: invalid chunk in indefinite-length string", 1}},
+	{name: "NestedChunk", in: "5f5fffff", ptr: new(any), err: &SyntaxError{"cbor: invalid chunk in indefinite-length string", 1}},
+	{name: "OddMap", in: "bf01ff", ptr: new(any), err: &SyntaxError{"cbor: missing value in indefinite-length map", 2}},
+	{name: "InvalidUTF8", in: "62c328", ptr: new(any), err: &SyntaxError{"cbor: invalid UTF-8 in text string", 0}},
+	{name: "Depth", in: strings.Repeat("81", defaultMaxDepth+1) + "00", ptr: new(any), err: &SyntaxError{"cbor: exceeded max depth", defaultMaxDepth + 1}},
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, tt := range unmarshalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := reflect.New(reflect.TypeOf(tt.ptr).Elem())
+			err := Unmarshal(mustHex(tt.in), v.Interface())
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Fatalf("Unmarshal error:\n\tgot:  %#v\n\twant: %#v", err, tt.err)
+			}
+			if tt.out == nil {
+				return
+			}
+			if got := v.Elem().Interface(); !equal(got, tt.out) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", got, tt.out)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBigIntValue(t *testing.T) {
+	var v struct{ X big.Int }
+	if err := Unmarshal(mustHex("a16158c349010000000000000000"), &v); err != nil {
+		t.Fatal(err)
+	}
+	if want := bigInt("-18446744073709551617"); v.X.Cmp(want) != 0 {
+		t.Errorf("X = %v, want %v", &v.X, want)
+	}
+}
+
+func TestUnmarshalNullClears(t *testing.T) {
+	v := struct {
+		P *int
+		S []int
+		M map[string]int
+		N int
+	}{new(int), []int{1}, map[string]int{"a": 1}, 5}
+	if err := Unmarshal(mustHex("a46150f66153f6614df6614ef6"), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.P != nil || v.S != nil || v.M != nil || v.N != 5 {
+		t.Errorf("got %+v, want nil P, S and M and unchanged N", v)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "cbor: Unmarshal(nil)"},
+		{struct{}{}, "cbor: Unmarshal(non-pointer struct {})"},
+		{(*int)(nil), "cbor: Unmarshal(nil *int)"},
+	}
+	for _, tt := range tests {
+		err := Unmarshal(mustHex("00"), tt.v)
+		var uerr *InvalidUnmarshalError
+		if !errors.As(err, &uerr) || err.Error() != tt.want {
+			t.Errorf("Unmarshal error = %v, want %s", err, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalRawMessage(t *testing.T) {
+	var v struct {
+		A RawMessage
+		B int
+	}
+	data := mustHex("a26141820102614205")
+	if err := Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if want := mustHex("820102"); !reflect.DeepEqual([]byte(v.A), want) || v.B != 5 {
+		t.Errorf("got A=%x B=%d, want A=%x B=5", v.A, v.B, want)
+	}
+	data[4] = 0x09 // must not alias the input
+	if v.A[1] != 0x01 {
+		t.Error("RawMessage aliases the input")
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, tt := range appendixA {
+		if !Valid(mustHex(tt.hex)) {
+			t.Errorf("Valid(%s) = false, want true", tt.hex)
+		}
+	}
+	for _, tt := range unmarshalTests {
+		if _, ok := tt.err.(*SyntaxError); ok && Valid(mustHex(tt.in)) {
+			t.Errorf("Valid(%s) = true, want false", tt.in)
+		}
+	}
+}
+
+func TestFloat16(t *testing.T) {
+	// Every half-precision value round-trips through float64
+	// and is encoded in half precision again.
+	for h := range 1 << 16 {
+		f := float16ToFloat64(uint16(h))
+		if math.IsNaN(f) {
+			continue
+		}
+		got, ok := float16Bits(float32(f))
+		if !ok || got != uint16(h) {
+			t.Fatalf("float16Bits(%g) = %#04x, %v; want %#04x, true", f, got, ok, h)
+		}
+	}
+}
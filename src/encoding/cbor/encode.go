@@ -0,0 +1,1036 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cbor implements encoding and decoding of the Concise Binary
+// Object Representation (CBOR) as defined in RFC 8949. The mapping between
+// CBOR and Go values is described in the documentation for the Marshal and
+// Unmarshal functions.
+//
+// The package follows the conventions of [encoding/json]: struct fields are
+// controlled by a "cbor" struct tag that accepts the same names and options
+// as the "json" tag, plus the CBOR-specific options "keyasint" and
+// "toarray" described in the documentation for [Marshal].
+//
+// [Marshal] always produces the deterministic encoding described in
+// RFC 8949 Section 4.2.1, so that equal values encode to identical bytes,
+// as required when CBOR data is signed or hashed. [Encoder.SetCanonical]
+// selects the older length-first map key ordering of RFC 7049 Section 3.9
+// instead, which is required by some protocols such as CTAP2.
+//
+// CBOR is often received from untrusted parties. [Unmarshal] and
+// [Decoder] reject data that is not well-formed, text strings that are not
+// valid UTF-8, and data that nests more deeply than a fixed limit.
+// A [Decoder] can additionally be configured to reject maps with duplicate
+// keys and to change the nesting limit.
+package cbor
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/binary"
+	"encoding/internal/structfield"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Marshal returns the CBOR encoding of v.
+//
+// Marshal traverses the value v recursively.
+// If an encountered value implements [Marshaler]
+// and is not a nil pointer, Marshal calls [Marshaler.MarshalCBOR]
+// to produce CBOR. If no [Marshaler.MarshalCBOR] method is present but the
+// value implements [encoding.TextMarshaler] instead, Marshal calls
+// [encoding.TextMarshaler.MarshalText] and encodes the result as a CBOR
+// text string.
+//
+// Otherwise, Marshal uses the following type-dependent default encodings:
+//
+// Boolean values encode as the CBOR simple values false and true.
+//
+// Integer values encode as CBOR unsigned or negative integers.
+//
+// Floating point values encode as CBOR floating-point numbers, using the
+// shortest of the half, single and double precision forms that
+// represents the value exactly. All NaN values encode as the
+// half-precision quiet NaN 0xf97e00.
+//
+// String values encode as CBOR text strings. Strings that are not valid
+// UTF-8 cause Marshal to return an [UnsupportedValueError].
+//
+// Byte slices and byte arrays encode as CBOR byte strings. Other array and
+// slice values encode as CBOR arrays. A nil slice encodes as null.
+//
+// Struct values encode as CBOR maps keyed by text strings.
+// Each exported struct field becomes an entry of the map, using the field
+// name as the key, unless the field is omitted for one of the reasons
+// given below.
+//
+// The encoding of each struct field can be customized by the format string
+// stored under the "cbor" key in the struct field's tag. The name and the
+// "omitempty", "omitzero" and "-" options have the same meaning as for the
+// "json" tag of [encoding/json]. In addition:
+//
+// The "keyasint" option specifies that the name is a decimal integer and
+// that the field's key is encoded as that CBOR integer rather than as a
+// text string. Protocols such as COSE (RFC 9052) identify map entries this
+// way:
+//
+//	Alg int    `cbor:"1,keyasint"`
+//	Crv int    `cbor:"-1,keyasint,omitempty"`
+//	X   []byte `cbor:"-2,keyasint,omitempty"`
+//
+// The "toarray" option on a blank field of any type specifies that the
+// struct encodes as a CBOR array of its field values in declaration order,
+// rather than as a map. The "omitempty" and "omitzero" options are ignored
+// for such structs:
+//
+//	type Sign1 struct {
+//		_           struct{} `cbor:",toarray"`
+//		Protected   []byte
+//		Unprotected map[int]any
+//		Payload     []byte
+//		Signature   []byte
+//	}
+//
+// Embedded struct fields are handled as described for [encoding/json].
+//
+// Map values encode as CBOR maps. The map's keys may be of any type that
+// can be encoded. A nil map encodes as null.
+//
+// The entries of maps and structs are sorted by the bytewise lexicographic
+// order of the encodings of their keys, as required by RFC 8949 Section
+// 4.2.1. Marshal returns an [UnsupportedValueError] if two keys of a map
+// have the same encoding.
+//
+// Pointer values encode as the value pointed to.
+// A nil pointer encodes as null.
+//
+// Interface values encode as the value contained in the interface.
+// A nil interface value encodes as null.
+//
+// A [time.Time] encodes as a tag 0 date/time string in RFC 3339 format.
+// A [big.Int] encodes as a CBOR integer if it fits in one, and otherwise as
+// a tag 2 or tag 3 bignum.
+// A [Tag] encodes as its tag number followed by its content, and a
+// [Simple] encodes as the corresponding CBOR simple value.
+//
+// Channel, complex, and function values cannot be encoded in CBOR.
+// Attempting to encode such a value causes Marshal to return
+// an [UnsupportedTypeError].
+//
+// CBOR cannot represent cyclic data structures, and Marshal returns an
+// [UnsupportedValueError] for them.
+func Marshal(v any) ([]byte, error) {
+	e := newEncodeState()
+	defer encodeStatePool.Put(e)
+
+	err := e.marshal(v, encOpts{})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(e.buf), nil
+}
+
+// Marshaler is the interface implemented by types that
+// can marshal themselves into a single well-formed CBOR data item.
+type Marshaler interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+// An UnsupportedTypeError is returned by [Marshal] when attempting
+// to encode an unsupported value type.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "cbor: unsupported type: " + e.Type.String()
+}
+
+// An UnsupportedValueError is returned by [Marshal] when attempting
+// to encode an unsupported value.
+type UnsupportedValueError struct {
+	Value reflect.Value
+	Str   string
+}
+
+func (e *UnsupportedValueError) Error() string {
+	return "cbor: unsupported value: " + e.Str
+}
+
+// A MarshalerError represents an error from calling a
+// [Marshaler.MarshalCBOR] or [encoding.TextMarshaler.MarshalText] method.
+type MarshalerError struct {
+	Type       reflect.Type
+	Err        error
+	sourceFunc string
+}
+
+func (e *MarshalerError) Error() string {
+	srcFunc := e.sourceFunc
+	if srcFunc == "" {
+		srcFunc = "MarshalCBOR"
+	}
+	return "cbor: error calling " + srcFunc +
+		" for type " + e.Type.String() +
+		": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *MarshalerError) Unwrap() error { return e.Err }
+
+// Major types, as defined in RFC 8949 Section 3.1.
+const (
+	majorUint   = 0
+	majorNegint = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// Initial bytes of frequently used data items.
+const (
+	cborFalse     = 0xf4
+	cborTrue      = 0xf5
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+	cborFloat16   = 0xf9
+	cborFloat32   = 0xfa
+	cborFloat64   = 0xfb
+	cborBreak     = 0xff
+)
+
+// Tag numbers with a built-in Go representation.
+const (
+	tagDateTimeString = 0
+	tagEpochDateTime  = 1
+	tagPosBignum      = 2
+	tagNegBignum      = 3
+	tagSelfDescribed  = 55799
+)
+
+// appendHead appends the initial byte and argument of a data item of the
+// given major type, using the shortest form that can hold n.
+func appendHead(b []byte, major byte, n uint64) []byte {
+	m := major << 5
+	switch {
+	case n < 24:
+		return append(b, m|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, m|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, m|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, m|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, m|27), n)
+	}
+}
+
+// appendInt appends the CBOR encoding of the integer n.
+func appendInt(b []byte, n int64) []byte {
+	if n < 0 {
+		return appendHead(b, majorNegint, uint64(^n))
+	}
+	return appendHead(b, majorUint, uint64(n))
+}
+
+// appendFloat appends the preferred serialization of f: the shortest
+// floating-point form that preserves its value.
+func appendFloat(b []byte, f float64) []byte {
+	if math.IsNaN(f) {
+		return append(b, cborFloat16, 0x7e, 0x00)
+	}
+	f32 := float32(f)
+	if float64(f32) != f {
+		return binary.BigEndian.AppendUint64(append(b, cborFloat64), math.Float64bits(f))
+	}
+	if h, ok := float16Bits(f32); ok {
+		return binary.BigEndian.AppendUint16(append(b, cborFloat16), h)
+	}
+	return binary.BigEndian.AppendUint32(append(b, cborFloat32), math.Float32bits(f32))
+}
+
+// float16Bits returns the IEEE 754 half-precision representation of f
+// and reports whether it represents f exactly.
+func float16Bits(f float32) (uint16, bool) {
+	u := math.Float32bits(f)
+	sign := uint16(u>>16) & 0x8000
+	exp := int(u>>23&0xff) - 127
+	mant := u & 0x7fffff
+	switch {
+	case exp == 128: // Inf; NaN is handled by the caller.
+		return sign | 0x7c00, mant == 0
+	case exp == -127: // zero or float32 subnormal
+		return sign, mant == 0
+	case exp >= -14 && exp <= 15: // normal
+		return sign | uint16(exp+15)<<10 | uint16(mant>>13), mant&0x1fff == 0
+	case exp >= -24 && exp < -14: // half-precision subnormal
+		full := 0x800000 | mant
+		shift := uint(-exp - 1)
+		return sign | uint16(full>>shift), full&(1<<shift-1) == 0
+	}
+	return 0, false
+}
+
+// An encodeState encodes CBOR into a byte slice.
+type encodeState struct {
+	buf []byte
+
+	// Keep track of what pointers we've seen in the current recursive call
+	// path, to avoid cycles that could lead to a stack overflow. Only do
+	// the relatively expensive map operations if ptrLevel is larger than
+	// startDetectingCyclesAfter, so that we skip the work if we're within a
+	// reasonable amount of nested pointers deep.
+	ptrLevel uint
+	ptrSeen  map[any]struct{}
+}
+
+const startDetectingCyclesAfter = 1000
+
+var encodeStatePool sync.Pool
+
+func newEncodeState() *encodeState {
+	if v := encodeStatePool.Get(); v != nil {
+		e := v.(*encodeState)
+		e.buf = e.buf[:0]
+		if len(e.ptrSeen) > 0 {
+			panic("ptrEncoder.encode should have emptied ptrSeen via defers")
+		}
+		e.ptrLevel = 0
+		return e
+	}
+	return &encodeState{ptrSeen: make(map[any]struct{})}
+}
+
+// cborError is an error wrapper type for internal use only.
+// Panics with errors are wrapped in cborError so that the top-level recover
+// can distinguish intentional panics from this package.
+type cborError struct{ error }
+
+func (e *encodeState) marshal(v any, opts encOpts) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if ce, ok := r.(cborError); ok {
+				err = ce.error
+			} else {
+				panic(r)
+			}
+		}
+	}()
+	e.reflectValue(reflect.ValueOf(v), opts)
+	return nil
+}
+
+// error aborts the encoding by panicking with err wrapped in cborError.
+func (e *encodeState) error(err error) {
+	panic(cborError{err})
+}
+
+func (e *encodeState) reflectValue(v reflect.Value, opts encOpts) {
+	valueEncoder(v)(e, v, opts)
+}
+
+type encOpts struct {
+	// canonical causes map keys to be sorted length-first.
+	canonical bool
+}
+
+type encoderFunc func(e *encodeState, v reflect.Value, opts encOpts)
+
+var encoderCache sync.Map // map[reflect.Type]encoderFunc
+
+func valueEncoder(v reflect.Value) encoderFunc {
+	if !v.IsValid() {
+		return invalidValueEncoder
+	}
+	return typeEncoder(v.Type())
+}
+
+func typeEncoder(t reflect.Type) encoderFunc {
+	if fi, ok := encoderCache.Load(t); ok {
+		return fi.(encoderFunc)
+	}
+
+	// To deal with recursive types, populate the map with an
+	// indirect func before we build it. This type waits on the
+	// real func (f) to be ready and then calls it. This indirect
+	// func is only used for recursive types.
+	var (
+		wg sync.WaitGroup
+		f  encoderFunc
+	)
+	wg.Add(1)
+	fi, loaded := encoderCache.LoadOrStore(t, encoderFunc(func(e *encodeState, v reflect.Value, opts encOpts) {
+		wg.Wait()
+		f(e, v, opts)
+	}))
+	if loaded {
+		return fi.(encoderFunc)
+	}
+
+	// Compute the real encoder and replace the indirect func with it.
+	f = newTypeEncoder(t, true)
+	wg.Done()
+	encoderCache.Store(t, f)
+	return f
+}
+
+var (
+	marshalerType     = reflect.TypeFor[Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	timeType          = reflect.TypeFor[time.Time]()
+	bigIntType        = reflect.TypeFor[big.Int]()
+	tagType           = reflect.TypeFor[Tag]()
+	simpleType        = reflect.TypeFor[Simple]()
+)
+
+// newTypeEncoder constructs an encoderFunc for a type.
+// The returned encoder only checks CanAddr when allowAddr is true.
+func newTypeEncoder(t reflect.Type, allowAddr bool) encoderFunc {
+	switch t {
+	case timeType:
+		return timeEncoder
+	case bigIntType:
+		return bigIntEncoder
+	case tagType:
+		return tagEncoder
+	case simpleType:
+		return simpleEncoder
+	case reflect.PointerTo(timeType), reflect.PointerTo(bigIntType):
+		// Don't use their MarshalText methods.
+		return newPtrEncoder(t)
+	}
+
+	// If we have a non-pointer value whose type implements
+	// Marshaler with a value receiver, then we're better off taking
+	// the address of the value - otherwise we end up with an
+	// allocation as we cast the value to an interface.
+	if t.Kind() != reflect.Pointer && allowAddr && reflect.PointerTo(t).Implements(marshalerType) {
+		return newCondAddrEncoder(addrMarshalerEncoder, newTypeEncoder(t, false))
+	}
+	if t.Implements(marshalerType) {
+		return marshalerEncoder
+	}
+	if t.Kind() != reflect.Pointer && allowAddr && reflect.PointerTo(t).Implements(textMarshalerType) {
+		return newCondAddrEncoder(addrTextMarshalerEncoder, newTypeEncoder(t, false))
+	}
+	if t.Implements(textMarshalerType) {
+		return textMarshalerEncoder
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return boolEncoder
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intEncoder
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintEncoder
+	case reflect.Float32, reflect.Float64:
+		return floatEncoder
+	case reflect.String:
+		return stringEncoder
+	case reflect.Interface:
+		return interfaceEncoder
+	case reflect.Struct:
+		return newStructEncoder(t)
+	case reflect.Map:
+		return newMapEncoder(t)
+	case reflect.Slice:
+		return newSliceEncoder(t)
+	case reflect.Array:
+		return newArrayEncoder(t)
+	case reflect.Pointer:
+		return newPtrEncoder(t)
+	default:
+		return unsupportedTypeEncoder
+	}
+}
+
+func invalidValueEncoder(e *encodeState, v reflect.Value, _ encOpts) {
+	e.buf = append(e.buf, cborNull)
+}
+
+func marshalerEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	m, ok := v.Interface().(Marshaler)
+	if !ok {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	e.appendMarshaled(v, m)
+}
+
+func addrMarshalerEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	va := v.Addr()
+	if va.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	e.appendMarshaled(v, va.Interface().(Marshaler))
+}
+
+// appendMarshaled appends the output of m.MarshalCBOR after checking that
+// it is a single well-formed data item.
+func (e *encodeState) appendMarshaled(v reflect.Value, m Marshaler) {
+	b, err := m.MarshalCBOR()
+	if err == nil {
+		err = checkItem(b)
+	}
+	if err != nil {
+		e.error(&MarshalerError{v.Type(), err, "MarshalCBOR"})
+	}
+	e.buf = append(e.buf, b...)
+}
+
+func textMarshalerEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	m, ok := v.Interface().(encoding.TextMarshaler)
+	if !ok {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	e.appendMarshaledText(v, m)
+}
+
+func addrTextMarshalerEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	va := v.Addr()
+	if va.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	e.appendMarshaledText(v, va.Interface().(encoding.TextMarshaler))
+}
+
+func (e *encodeState) appendMarshaledText(v reflect.Value, m encoding.TextMarshaler) {
+	b, err := m.MarshalText()
+	if err != nil {
+		e.error(&MarshalerError{v.Type(), err, "MarshalText"})
+	}
+	if !utf8.Valid(b) {
+		e.error(&MarshalerError{v.Type(), errInvalidUTF8, "MarshalText"})
+	}
+	e.buf = appendHead(e.buf, majorText, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func boolEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.Bool() {
+		e.buf = append(e.buf, cborTrue)
+	} else {
+		e.buf = append(e.buf, cborFalse)
+	}
+}
+
+func intEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	e.buf = appendInt(e.buf, v.Int())
+}
+
+func uintEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	e.buf = appendHead(e.buf, majorUint, v.Uint())
+}
+
+func floatEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	e.buf = appendFloat(e.buf, v.Float())
+}
+
+func stringEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	s := v.String()
+	if !utf8.ValidString(s) {
+		e.error(&UnsupportedValueError{v, "invalid UTF-8 in string: " + strconv.Quote(s)})
+	}
+	e.buf = appendHead(e.buf, majorText, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func interfaceEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	e.reflectValue(v.Elem(), opts)
+}
+
+func unsupportedTypeEncoder(e *encodeState, v reflect.Value, _ encOpts) {
+	e.error(&UnsupportedTypeError{v.Type()})
+}
+
+func timeEncoder(e *encodeState, v reflect.Value, _ encOpts) {
+	t := v.Interface().(time.Time)
+	if y := t.Year(); y < 0 || y >= 10000 {
+		e.error(&UnsupportedValueError{v, "time " + strconv.Quote(t.String()) + " is outside the range of RFC 3339"})
+	}
+	e.buf = appendHead(e.buf, majorTag, tagDateTimeString)
+	var a [len(time.RFC3339Nano)]byte
+	s := t.AppendFormat(a[:0], time.RFC3339Nano)
+	e.buf = appendHead(e.buf, majorText, uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func bigIntEncoder(e *encodeState, v reflect.Value, _ encOpts) {
+	var x *big.Int
+	if v.CanAddr() {
+		x = v.Addr().Interface().(*big.Int)
+	} else {
+		x = new(big.Int)
+		reflect.ValueOf(x).Elem().Set(v)
+	}
+	e.buf = appendBigInt(e.buf, x)
+}
+
+// appendBigInt appends the preferred serialization of x: a CBOR integer
+// if x fits in one, and a bignum otherwise.
+func appendBigInt(b []byte, x *big.Int) []byte {
+	major, tag := byte(majorUint), uint64(tagPosBignum)
+	if x.Sign() < 0 {
+		// A negative integer n is encoded as -1-n.
+		major, tag = majorNegint, tagNegBignum
+		x = new(big.Int).Not(x)
+	}
+	if x.IsUint64() {
+		return appendHead(b, major, x.Uint64())
+	}
+	b = appendHead(b, majorTag, tag)
+	mag := x.Bytes()
+	b = appendHead(b, majorBytes, uint64(len(mag)))
+	return append(b, mag...)
+}
+
+func tagEncoder(e *encodeState, v reflect.Value, opts encOpts) {
+	t := v.Interface().(Tag)
+	e.buf = appendHead(e.buf, majorTag, t.Number)
+	e.reflectValue(reflect.ValueOf(t.Content), opts)
+}
+
+func simpleEncoder(e *encodeState, v reflect.Value, _ encOpts) {
+	n := v.Uint()
+	switch {
+	case n < 24:
+		e.buf = append(e.buf, majorSimple<<5|byte(n))
+	case n < 32:
+		e.error(&UnsupportedValueError{v, "reserved simple value " + strconv.FormatUint(n, 10)})
+	default:
+		e.buf = append(e.buf, majorSimple<<5|24, byte(n))
+	}
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+type structEncoder struct {
+	fields structFields
+}
+
+type structFields struct {
+	list    []field
+	byName  map[string]*field
+	byInt   map[int64]*field
+	toArray bool
+
+	// sorted and canonical hold the indexes into list of the fields in
+	// bytewise and length-first order of their encoded keys.
+	sorted    []int
+	canonical []int
+}
+
+func (se structEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
+	fields := se.fields.list
+	if se.fields.toArray {
+		e.buf = appendHead(e.buf, majorArray, uint64(len(fields)))
+		for i := range fields {
+			fv, ok := structfield.ByIndex(v, fields[i].index)
+			if !ok {
+				e.buf = append(e.buf, cborNull)
+				continue
+			}
+			fields[i].encoder(e, fv, opts)
+		}
+		return
+	}
+
+	order := se.fields.sorted
+	if opts.canonical {
+		order = se.fields.canonical
+	}
+	// Determine which fields are present before encoding any of them,
+	// so that the map header can be written in its shortest form.
+	var present [64]bool
+	var keep []bool
+	if len(fields) <= len(present) {
+		keep = present[:len(fields)]
+	} else {
+		keep = make([]bool, len(fields))
+	}
+	n := 0
+	for i := range fields {
+		f := &fields[i]
+		fv, ok := structfield.ByIndex(v, f.index)
+		if !ok ||
+			(f.omitEmpty && isEmptyValue(fv)) ||
+			(f.omitZero && (f.isZero == nil && fv.IsZero() || (f.isZero != nil && f.isZero(fv)))) {
+			continue
+		}
+		keep[i] = true
+		n++
+	}
+	e.buf = appendHead(e.buf, majorMap, uint64(n))
+	for _, i := range order {
+		if !keep[i] {
+			continue
+		}
+		f := &fields[i]
+		fv, _ := structfield.ByIndex(v, f.index)
+		e.buf = append(e.buf, f.key...)
+		f.encoder(e, fv, opts)
+	}
+}
+
+func newStructEncoder(t reflect.Type) encoderFunc {
+	se := structEncoder{fields: cachedTypeFields(t)}
+	return se.encode
+}
+
+type mapEncoder struct {
+	keyEnc  encoderFunc
+	elemEnc encoderFunc
+}
+
+// A mapEntry records the position of an encoded key and value in the
+// output buffer.
+type mapEntry struct {
+	keyStart, keyEnd, end int
+}
+
+func (me mapEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	if e.ptrLevel++; e.ptrLevel > startDetectingCyclesAfter {
+		// We're a large number of nested ptrEncoder.encode calls deep;
+		// start checking if we've run into a pointer cycle.
+		ptr := v.UnsafePointer()
+		if _, ok := e.ptrSeen[ptr]; ok {
+			e.error(&UnsupportedValueError{v, fmt.Sprintf("encountered a cycle via %s", v.Type())})
+		}
+		e.ptrSeen[ptr] = struct{}{}
+		defer delete(e.ptrSeen, ptr)
+	}
+
+	e.buf = appendHead(e.buf, majorMap, uint64(v.Len()))
+	start := len(e.buf)
+	entries := make([]mapEntry, 0, v.Len())
+	mi := v.MapRange()
+	for mi.Next() {
+		var ent mapEntry
+		ent.keyStart = len(e.buf)
+		me.keyEnc(e, mi.Key(), opts)
+		ent.keyEnd = len(e.buf)
+		me.elemEnc(e, mi.Value(), opts)
+		ent.end = len(e.buf)
+		entries = append(entries, ent)
+	}
+
+	// Sort the entries by their encoded keys and rewrite them in order.
+	body := slices.Clone(e.buf[start:])
+	key := func(ent mapEntry) []byte {
+		return body[ent.keyStart-start : ent.keyEnd-start]
+	}
+	slices.SortFunc(entries, func(a, b mapEntry) int {
+		return compareKeys(key(a), key(b), opts.canonical)
+	})
+	e.buf = e.buf[:start]
+	for i, ent := range entries {
+		if i > 0 && bytes.Equal(key(entries[i-1]), key(ent)) {
+			e.error(&UnsupportedValueError{v, "duplicate map key"})
+		}
+		e.buf = append(e.buf, body[ent.keyStart-start:ent.end-start]...)
+	}
+	e.ptrLevel--
+}
+
+// compareKeys orders two encoded map keys bytewise, as required by
+// RFC 8949 Section 4.2.1, or, if canonical is set, shortest first as
+// required by RFC 7049 Section 3.9.
+func compareKeys(a, b []byte, canonical bool) int {
+	if canonical {
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+	}
+	return bytes.Compare(a, b)
+}
+
+func newMapEncoder(t reflect.Type) encoderFunc {
+	me := mapEncoder{typeEncoder(t.Key()), typeEncoder(t.Elem())}
+	return me.encode
+}
+
+func encodeByteSlice(e *encodeState, v reflect.Value, _ encOpts) {
+	if v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	b := v.Bytes()
+	e.buf = appendHead(e.buf, majorBytes, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func encodeByteArray(e *encodeState, v reflect.Value, _ encOpts) {
+	e.buf = appendHead(e.buf, majorBytes, uint64(v.Len()))
+	if v.CanAddr() {
+		e.buf = append(e.buf, v.Bytes()...)
+		return
+	}
+	for i := range v.Len() {
+		e.buf = append(e.buf, byte(v.Index(i).Uint()))
+	}
+}
+
+// sliceEncoder just wraps an arrayEncoder, checking to make sure the value isn't nil.
+type sliceEncoder struct {
+	arrayEnc encoderFunc
+}
+
+func (se sliceEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	if e.ptrLevel++; e.ptrLevel > startDetectingCyclesAfter {
+		// We're a large number of nested ptrEncoder.encode calls deep;
+		// start checking if we've run into a pointer cycle.
+		// Here we use a struct to memorize the pointer to the first element of the slice
+		// and its length.
+		ptr := struct {
+			ptr any // always an unsafe.Pointer, but avoids a dependency on package unsafe
+			len int
+		}{v.UnsafePointer(), v.Len()}
+		if _, ok := e.ptrSeen[ptr]; ok {
+			e.error(&UnsupportedValueError{v, fmt.Sprintf("encountered a cycle via %s", v.Type())})
+		}
+		e.ptrSeen[ptr] = struct{}{}
+		defer delete(e.ptrSeen, ptr)
+	}
+	se.arrayEnc(e, v, opts)
+	e.ptrLevel--
+}
+
+func newSliceEncoder(t reflect.Type) encoderFunc {
+	// Byte slices get special treatment; arrays don't.
+	if t.Elem().Kind() == reflect.Uint8 && !isMarshaled(t.Elem()) {
+		return encodeByteSlice
+	}
+	enc := sliceEncoder{newArrayEncoder(t)}
+	return enc.encode
+}
+
+type arrayEncoder struct {
+	elemEnc encoderFunc
+}
+
+func (ae arrayEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
+	n := v.Len()
+	e.buf = appendHead(e.buf, majorArray, uint64(n))
+	for i := range n {
+		ae.elemEnc(e, v.Index(i), opts)
+	}
+}
+
+func newArrayEncoder(t reflect.Type) encoderFunc {
+	if t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8 && !isMarshaled(t.Elem()) {
+		return encodeByteArray
+	}
+	enc := arrayEncoder{typeEncoder(t.Elem())}
+	return enc.encode
+}
+
+// isMarshaled reports whether values of type t are encoded by a
+// Marshaler or TextMarshaler method.
+func isMarshaled(t reflect.Type) bool {
+	p := reflect.PointerTo(t)
+	return p.Implements(marshalerType) || p.Implements(textMarshalerType)
+}
+
+type ptrEncoder struct {
+	elemEnc encoderFunc
+}
+
+func (pe ptrEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.IsNil() {
+		e.buf = append(e.buf, cborNull)
+		return
+	}
+	if e.ptrLevel++; e.ptrLevel > startDetectingCyclesAfter {
+		// We're a large number of nested ptrEncoder.encode calls deep;
+		// start checking if we've run into a pointer cycle.
+		ptr := v.Interface()
+		if _, ok := e.ptrSeen[ptr]; ok {
+			e.error(&UnsupportedValueError{v, fmt.Sprintf("encountered a cycle via %s", v.Type())})
+		}
+		e.ptrSeen[ptr] = struct{}{}
+		defer delete(e.ptrSeen, ptr)
+	}
+	pe.elemEnc(e, v.Elem(), opts)
+	e.ptrLevel--
+}
+
+func newPtrEncoder(t reflect.Type) encoderFunc {
+	enc := ptrEncoder{typeEncoder(t.Elem())}
+	return enc.encode
+}
+
+type condAddrEncoder struct {
+	canAddrEnc, elseEnc encoderFunc
+}
+
+func (ce condAddrEncoder) encode(e *encodeState, v reflect.Value, opts encOpts) {
+	if v.CanAddr() {
+		ce.canAddrEnc(e, v, opts)
+	} else {
+		ce.elseEnc(e, v, opts)
+	}
+}
+
+// newCondAddrEncoder returns an encoder that checks whether its value
+// CanAddr and delegates to canAddrEnc if so, else to elseEnc.
+func newCondAddrEncoder(canAddrEnc, elseEnc encoderFunc) encoderFunc {
+	enc := condAddrEncoder{canAddrEnc: canAddrEnc, elseEnc: elseEnc}
+	return enc.encode
+}
+
+func typeByIndex(t reflect.Type, index []int) reflect.Type {
+	for _, i := range index {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		t = t.Field(i).Type
+	}
+	return t
+}
+
+// A field represents a single field found in a struct.
+type field struct {
+	name   string
+	intKey int64 // the key if keyAsInt is set
+	key    []byte
+
+	keyAsInt  bool
+	index     []int
+	omitEmpty bool
+	omitZero  bool
+	isZero    func(reflect.Value) bool
+
+	encoder encoderFunc
+}
+
+// typeFields returns a list of fields that CBOR should recognize for the
+// given type.
+func typeFields(t reflect.Type) structFields {
+	// A blank field carries options for the struct as a whole.
+	toArray := false
+	for i := range t.NumField() {
+		if sf := t.Field(i); sf.Name == "_" {
+			_, opts := structfield.ParseTag(sf.Tag.Get("cbor"))
+			toArray = toArray || opts.Contains("toarray")
+		}
+	}
+
+	list := structfield.Fields(t, "cbor", func(name string, opts structfield.Options) string {
+		if opts.Contains("keyasint") {
+			if _, err := strconv.ParseInt(name, 10, 64); err == nil {
+				// Distinguish integer keys from text keys
+				// with the same spelling during conflict resolution.
+				return "\x00" + name
+			}
+			// An invalid integer key is treated as a text key,
+			// so that the field is not silently lost.
+		}
+		if !structfield.ValidName(name) {
+			return ""
+		}
+		return name
+	})
+
+	fields := make([]field, len(list))
+	sf := structFields{
+		list:    fields,
+		byName:  make(map[string]*field, len(fields)),
+		byInt:   make(map[int64]*field),
+		toArray: toArray,
+	}
+	for i, lf := range list {
+		f := &fields[i]
+		*f = field{
+			name:      lf.Name,
+			index:     lf.Index,
+			omitEmpty: lf.Options.Contains("omitempty"),
+			omitZero:  lf.Options.Contains("omitzero"),
+			encoder:   typeEncoder(typeByIndex(t, lf.Index)),
+		}
+		if f.omitZero {
+			f.isZero = structfield.IsZeroFunc(lf.Type)
+		}
+		if name, ok := strings.CutPrefix(f.name, "\x00"); ok {
+			f.name = name
+			f.keyAsInt = true
+			f.intKey, _ = strconv.ParseInt(name, 10, 64)
+			f.key = appendInt(nil, f.intKey)
+			sf.byInt[f.intKey] = f
+		} else {
+			f.key = appendHead(nil, majorText, uint64(len(f.name)))
+			f.key = append(f.key, f.name...)
+			sf.byName[f.name] = f
+		}
+		sf.sorted = append(sf.sorted, i)
+	}
+	sf.canonical = slices.Clone(sf.sorted)
+	slices.SortFunc(sf.sorted, func(i, j int) int {
+		return compareKeys(fields[i].key, fields[j].key, false)
+	})
+	slices.SortFunc(sf.canonical, func(i, j int) int {
+		return compareKeys(fields[i].key, fields[j].key, true)
+	})
+	return sf
+}
+
+var fieldCache sync.Map // map[reflect.Type]structFields
+
+// cachedTypeFields is like typeFields but uses a cache to avoid repeated work.
+func cachedTypeFields(t reflect.Type) structFields {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.(structFields)
+	}
+	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	return f.(structFields)
+}
@@ -0,0 +1,306 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalAppendixA(t *testing.T) {
+	for _, tt := range appendixA {
+		if !tt.enc {
+			continue
+		}
+		b, err := Marshal(tt.val)
+		if err != nil {
+			t.Errorf("Marshal(%#v) error: %v", tt.val, err)
+			continue
+		}
+		if got := hex.EncodeToString(b); got != tt.hex {
+			t.Errorf("Marshal(%#v) = %s, want %s", tt.val, got, tt.hex)
+		}
+	}
+}
+
+type Optionals struct {
+	Sr string `cbor:"sr"`
+	So string `cbor:"so,omitempty"`
+	Sw string `cbor:"-"`
+
+	Ir int `cbor:"omitempty"` // actually named omitempty, not an option
+	Io int `cbor:"io,omitempty"`
+
+	Slr []string `cbor:"slr"`
+	Slo []string `cbor:"slo,omitempty"`
+
+	Mr map[string]any `cbor:"mr"`
+	Mo map[string]any `cbor:",omitempty"`
+
+	Tz time.Time `cbor:"tz,omitzero"`
+	Bz *big.Int  `cbor:"bz,omitzero"`
+}
+
+type Embed0 struct {
+	Level1a int // overridden by Embed0a's Level1a with cbor tag
+	Level1b int // used because Embed0a's Level1b is renamed
+	Level1c int // used because Embed0a's Level1c is ignored
+	Level1d int // annihilated by Embed0a's Level1d
+	Level1e int `cbor:"x"` // annihilated by Embed0a.Level1e
+}
+
+type Embed0a struct {
+	Level1a int `cbor:"Level1a,omitempty"`
+	Level1b int `cbor:"LEVEL1B,omitempty"`
+	Level1c int `cbor:"-"`
+	Level1d int // annihilated by Embed0's Level1d
+	Level1f int `cbor:"x"` // annihilated by Embed0's Level1e
+}
+
+type Top struct {
+	Level0 int
+	Embed0
+	*Embed0a
+}
+
+type marshalerString string
+
+func (s marshalerString) MarshalCBOR() ([]byte, error) {
+	return Marshal(strings.ToUpper(string(s)))
+}
+
+type badMarshaler struct{}
+
+func (badMarshaler) MarshalCBOR() ([]byte, error) { return []byte{0x82, 0x01}, nil }
+
+type errMarshaler struct{}
+
+func (errMarshaler) MarshalCBOR() ([]byte, error) { return nil, errors.New("boom") }
+
+type selfRef struct {
+	Next *selfRef
+}
+
+var marshalTests = []struct {
+	name string
+	in   any
+	out  string // hex
+}{
+	{"Int8", int8(-128), "387f"},
+	{"Uint16", uint16(500), "1901f4"},
+	{"Float32", float32(1.5), "f93e00"},
+	{"Float32Exact", float32(0.1), "fa3dcccccd"},
+	{"ByteArray", [3]byte{1, 2, 3}, "43010203"},
+	{"NilSlice", []int(nil), "f6"},
+	{"NilBytes", []byte(nil), "f6"},
+	{"NilMap", map[string]int(nil), "f6"},
+	{"NilPointer", (*int)(nil), "f6"},
+	{"Pointer", func() *int { i := 7; return &i }(), "07"},
+	{"BigIntSmall", big.NewInt(-100), "3863"},
+	{"BigIntValue", struct{ X big.Int }{}, "a1615800"},
+	{"Time", time.Date(2013, 3, 21, 20, 4, 0, 5e8, time.FixedZone("", 3600)), "c0781b323031332d30332d32315432303a30343a30302e352b30313a3030"},
+	{"TextMarshaler", netip.MustParseAddr("192.0.2.10"), "6a3139322e302e322e3130"},
+	{"Marshaler", marshalerString("abc"), "63414243"},
+	{"MarshalerInSlice", []marshalerString{"a"}, "816141"},
+	{"RawMessage", RawMessage(mustHex("820102")), "820102"},
+	{"NilRawMessage", RawMessage(nil), "f6"},
+	{"TagOfTag", Tag{1, Tag{2, uint64(3)}}, "c1c203"},
+	{"IntMapSorted", map[int]string{10: "a", -1: "b", 24: "c", 1: "d"}, "a4016164" + "0a6161" + "18186163" + "206162"},
+	{
+		"COSEKey",
+		COSEKey{Kty: 2, Alg: -7, Crv: 1, X: []byte{0xab}},
+		"a4" + "0102" + "0326" + "2001" + "2141ab",
+	},
+	{"COSEKeyOmitEmpty", COSEKey{Kty: 1}, "a10101"},
+	{"ToArray", Pair{A: 1, B: "x"}, "82016178"},
+	{
+		"Optionals",
+		Optionals{Sr: "", So: "", Ir: 0, Io: 0},
+		// Keys in bytewise order: "mr", "sr", "slr", "omitempty".
+		"a4" + "626d72f6" + "62737260" + "63736c72f6" + "696f6d6974656d70747900",
+	},
+	{
+		"Embedded",
+		Top{Level0: 1, Embed0: Embed0{Level1b: 2, Level1c: 3}, Embed0a: &Embed0a{Level1a: 5, Level1b: 6}},
+		// Level0, Level1c, LEVEL1B, Level1a, Level1b in bytewise order.
+		"a5" + "664c6576656c3001" + "674c4556454c3142" + "06" + "674c6576656c316105" + "674c6576656c316202" + "674c6576656c316303",
+	},
+	{"EmbeddedNilPointer", Top{Level0: 1}, "a3" + "664c6576656c3001" + "674c6576656c316200" + "674c6576656c316300"},
+}
+
+func TestMarshal(t *testing.T) {
+	for _, tt := range marshalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if got := hex.EncodeToString(b); got != tt.out {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.out)
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	type T struct {
+		Name  string            `cbor:"name"`
+		Key   COSEKey           `cbor:"key"`
+		Pairs []Pair            `cbor:"pairs"`
+		Attrs map[string][]byte `cbor:"attrs,omitempty"`
+		When  time.Time         `cbor:"when"`
+		Big   *big.Int          `cbor:"big"`
+		Opt   *float64          `cbor:"opt"`
+	}
+	f := 2.5
+	in := T{
+		Name:  "device",
+		Key:   COSEKey{Kty: 2, Alg: -7, Crv: 1, X: bytes.Repeat([]byte{1}, 32)},
+		Pairs: []Pair{{A: 1, B: "one"}, {A: -2, B: "minus two"}},
+		Attrs: map[string][]byte{"fw": {1, 2}, "hw": {}},
+		When:  time.Date(2024, 2, 29, 12, 0, 0, 123456789, time.UTC),
+		Big:   new(big.Int).Lsh(big.NewInt(-3), 100),
+		Opt:   &f,
+	}
+	b, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out T
+	if err := Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Big.Cmp(in.Big) != 0 {
+		t.Errorf("Big = %v, want %v", out.Big, in.Big)
+	}
+	out.Big = in.Big
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip:\n\tgot:  %+v\n\twant: %+v", out, in)
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	in := map[any]int{"aa": 1, "b": 2, 24: 3, -1: 4, false: 5}
+	tests := []struct {
+		canonical bool
+		want      string
+	}{
+		// Bytewise: 1818 < 20 < 6162 < 626161 < f4.
+		{false, "a5" + "181803" + "2004" + "616202" + "62616101" + "f405"},
+		// Length-first: 20 < f4 < 1818 < 6162 < 626161.
+		{true, "a5" + "2004" + "f405" + "181803" + "616202" + "62616101"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		enc.SetCanonical(tt.canonical)
+		if err := enc.Encode(in); err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(buf.Bytes()); got != tt.want {
+			t.Errorf("canonical=%v:\n\tgot:  %s\n\twant: %s", tt.canonical, got, tt.want)
+		}
+	}
+
+	// Struct keys follow the same order.
+	type S struct {
+		Long  int `cbor:"long"`
+		Neg   int `cbor:"-1,keyasint"`
+		Large int `cbor:"24,keyasint"`
+	}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetCanonical(true)
+	if err := enc.Encode(S{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(buf.Bytes()), "a3"+"2002"+"181803"+"646c6f6e6701"; got != want {
+		t.Errorf("canonical struct:\n\tgot:  %s\n\twant: %s", got, want)
+	}
+	b, _ := Marshal(S{1, 2, 3})
+	if got, want := hex.EncodeToString(b), "a3"+"181803"+"2002"+"646c6f6e6701"; got != want {
+		t.Errorf("deterministic struct:\n\tgot:  %s\n\twant: %s", got, want)
+	}
+}
+
+// Marshal must produce the same bytes for equal maps regardless of
+// the iteration order.
+func TestMarshalDeterministic(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 100 {
+		m[strings.Repeat("k", i%7)+string(rune('a'+i%26))+string(rune('A'+i/26))] = i
+	}
+	want, err := Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 10 {
+		got, err := Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatal("Marshal of the same map produced different output")
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	cyclic := &selfRef{}
+	cyclic.Next = cyclic
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"Chan", make(chan int), "cbor: unsupported type: chan int"},
+		{"Complex", complex(1, 2), "cbor: unsupported type: complex128"},
+		{"InvalidUTF8", "\xff", `cbor: unsupported value: invalid UTF-8 in string: "\xff"`},
+		{"DuplicateKey", map[any]int{1: 1, uint(1): 2}, "cbor: unsupported value: duplicate map key"},
+		{"ReservedSimple", Simple(24), "cbor: unsupported value: reserved simple value 24"},
+		{"TimeRange", time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), `cbor: unsupported value: time "10000-01-01 00:00:00 +0000 UTC" is outside the range of RFC 3339`},
+		{"MarshalerError", errMarshaler{}, "cbor: error calling MarshalCBOR for type cbor.errMarshaler: boom"},
+		{"MarshalerInvalid", badMarshaler{}, "cbor: error calling MarshalCBOR for type cbor.badMarshaler: cbor: unexpected end of input"},
+		{"RawMessageTrailing", RawMessage{0x01, 0x02}, "cbor: error calling MarshalCBOR for type cbor.RawMessage: cbor: trailing data after top-level item"},
+		{"Cycle", cyclic, "cbor: unsupported value: encountered a cycle via *cbor.selfRef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Marshal error:\n\tgot:  %v\n\twant: %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalFloatPreferred(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		{math.SmallestNonzeroFloat64, "fb0000000000000001"},
+		{math.MaxFloat32, "fa7f7fffff"},
+		{1.0 / (1 << 14), "f90400"},
+		{1.0 / (1 << 24) * 3, "f90003"},
+		{1.0 / (1 << 25), "fa33000000"},
+		{65504, "f97bff"},
+		{65520, "fa477ff000"},
+		{-0.5, "f9b800"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(appendFloat(nil, tt.f)); got != tt.want {
+			t.Errorf("appendFloat(%g) = %s, want %s", tt.f, got, tt.want)
+		}
+	}
+}
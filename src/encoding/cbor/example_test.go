@@ -0,0 +1,89 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor_test
+
+import (
+	"bytes"
+	"encoding/cbor"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+func ExampleMarshal() {
+	// A COSE_Key (RFC 9052) uses integer map keys.
+	type COSEKey struct {
+		Kty int    `cbor:"1,keyasint"`
+		Alg int    `cbor:"3,keyasint"`
+		Crv int    `cbor:"-1,keyasint"`
+		X   []byte `cbor:"-2,keyasint"`
+		Y   []byte `cbor:"-3,keyasint"`
+	}
+	key := COSEKey{Kty: 2, Alg: -7, Crv: 1, X: []byte{0x01, 0x02}, Y: []byte{0x03, 0x04}}
+	b, err := cbor.Marshal(key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%x\n", b)
+	// Output:
+	// a50102032620012142010222420304
+}
+
+func ExampleUnmarshal() {
+	type Reading struct {
+		Sensor string    `cbor:"sensor"`
+		Time   time.Time `cbor:"time"`
+		Value  float64   `cbor:"value"`
+	}
+	// {"sensor": "t1", "time": 1(1700000000), "value": 21.5}
+	data := []byte("\xa3\x66sensor\x62t1\x64time\xc1\x1a\x65\x53\xf1\x00\x65value\xf9\x4d\x60")
+	var r Reading
+	if err := cbor.Unmarshal(data, &r); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(r.Sensor, r.Time.Format(time.RFC3339), r.Value)
+	// Output:
+	// t1 2023-11-14T22:13:20Z 21.5
+}
+
+func ExampleDecoder() {
+	// A CBOR sequence of three data items: 1, "two", [3].
+	dec := cbor.NewDecoder(strings.NewReader("\x01\x63two\x81\x03"))
+	dec.DisallowDuplicateKeys()
+	for {
+		var v any
+		if err := dec.Decode(&v); err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%T %v\n", v, v)
+	}
+	// Output:
+	// uint64 1
+	// string two
+	// []interface {} [3]
+}
+
+func ExampleEncoder_SetCanonical() {
+	// The encoding of 100 is longer than that of -1 but sorts first bytewise.
+	m := map[int]string{100: "a", -1: "b"}
+
+	b, _ := cbor.Marshal(m)
+	fmt.Printf("deterministic: %x\n", b)
+
+	var buf bytes.Buffer
+	enc := cbor.NewEncoder(&buf)
+	enc.SetCanonical(true)
+	if err := enc.Encode(m); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("canonical:     %x\n", buf.Bytes())
+	// Output:
+	// deterministic: a218646161206162
+	// canonical:     a220616218646161
+}
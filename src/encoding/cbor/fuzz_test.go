@@ -0,0 +1,74 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, tt := range appendixA {
+		f.Add(mustHex(tt.hex))
+	}
+	for _, tt := range unmarshalTests {
+		f.Add(mustHex(tt.in))
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if Valid(b) != (checkValid(b, defaultMaxDepth) == nil) {
+			t.Fatal("Valid disagrees with checkValid")
+		}
+		for _, typ := range []func() any{
+			func() any { return new(any) },
+			func() any { return new(map[string]any) },
+			func() any { return new([]any) },
+			func() any { return new(COSEKey) },
+		} {
+			i := typ()
+			if err := Unmarshal(b, i); err != nil {
+				continue
+			}
+
+			// Decoded values may hold map keys that encode the same way,
+			// such as distinct *big.Int pointers, so only check that
+			// successful encodings decode again.
+			encoded, err := Marshal(i)
+			if err != nil {
+				continue
+			}
+			if err := Unmarshal(encoded, i); err != nil {
+				t.Fatalf("failed to roundtrip: %s", err)
+			}
+		}
+	})
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Add([]byte("\x01\x63two\x81\x03"))
+	f.Add([]byte("\x9f\x01\x5f\x41\x00\xff\xff\xa1\x00\x00"))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		// Decoding a stream must agree with Unmarshal of each item.
+		dec := NewDecoder(bytes.NewReader(b))
+		for {
+			start := dec.InputOffset()
+			var v any
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				return
+			}
+			if _, ok := err.(*SyntaxError); ok || err == io.ErrUnexpectedEOF {
+				return
+			}
+			end := dec.InputOffset()
+			var w any
+			if err2 := Unmarshal(b[start:end], &w); (err == nil) != (err2 == nil) {
+				t.Fatalf("Decode error = %v, Unmarshal error = %v", err, err2)
+			}
+		}
+	})
+}
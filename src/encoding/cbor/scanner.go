@@ -0,0 +1,226 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"unicode/utf8"
+)
+
+// A SyntaxError describes CBOR data that is not well-formed, or that is
+// well-formed but not valid, such as a text string containing invalid
+// UTF-8 or, when requested, a map with duplicate keys.
+type SyntaxError struct {
+	msg    string // description of error
+	Offset int64  // error occurred at this byte offset
+}
+
+func (e *SyntaxError) Error() string { return e.msg }
+
+var errInvalidUTF8 = errors.New("invalid UTF-8")
+
+// defaultMaxDepth is the maximum nesting depth of arrays, maps and tags
+// accepted by Unmarshal and by a Decoder unless changed with
+// Decoder.SetMaxDepth.
+const defaultMaxDepth = 1000
+
+// aiIndefinite is the additional information value
+// of an indefinite-length data item or break code.
+const aiIndefinite = 31
+
+// Valid reports whether data is a single well-formed and valid CBOR data
+// item.
+func Valid(data []byte) bool {
+	return checkValid(data, defaultMaxDepth) == nil
+}
+
+// checkValid verifies that data is a single well-formed CBOR data item
+// with valid UTF-8 text strings nested at most maxDepth deep.
+func checkValid(data []byte, maxDepth int) error {
+	s := scanner{data: data, maxDepth: maxDepth}
+	if err := s.item(0); err != nil {
+		return err
+	}
+	if s.off < len(data) {
+		return s.errorAt(s.off, "trailing data after top-level item")
+	}
+	return nil
+}
+
+// checkItem is like checkValid without a nesting limit. It is used to
+// check the output of Marshaler methods.
+func checkItem(data []byte) error {
+	return checkValid(data, int(^uint(0)>>1))
+}
+
+// A scanner checks the well-formedness of CBOR data items.
+type scanner struct {
+	data     []byte
+	off      int   // next read offset in data
+	base     int64 // offset of data[0] in the input, for errors
+	maxDepth int
+
+	// fill, if not nil, is called to extend data when fewer than n bytes
+	// remain after off. It returns an error if they cannot be provided.
+	// If fill is nil, data holds the complete input.
+	fill func(n uint64) error
+}
+
+func (s *scanner) errorAt(off int, msg string) error {
+	return &SyntaxError{"cbor: " + msg, s.base + int64(off)}
+}
+
+// need ensures that at least n bytes are available after s.off.
+func (s *scanner) need(n uint64) error {
+	if uint64(len(s.data)-s.off) >= n {
+		return nil
+	}
+	if s.fill == nil {
+		return s.errorAt(len(s.data), "unexpected end of input")
+	}
+	return s.fill(n)
+}
+
+// head reads the initial byte and argument of a data item.
+func (s *scanner) head() (major, ai byte, arg uint64, err error) {
+	if err := s.need(1); err != nil {
+		return 0, 0, 0, err
+	}
+	start := s.off
+	major, ai = s.data[s.off]>>5, s.data[s.off]&0x1f
+	s.off++
+	switch {
+	case ai < 24:
+		arg = uint64(ai)
+	case ai <= 27:
+		n := 1 << (ai - 24)
+		if err := s.need(uint64(n)); err != nil {
+			return 0, 0, 0, err
+		}
+		arg = readUint(s.data[s.off : s.off+n])
+		s.off += n
+	case ai < aiIndefinite:
+		return 0, 0, 0, s.errorAt(start, "reserved additional information "+strconv.Itoa(int(ai)))
+	}
+	return major, ai, arg, nil
+}
+
+// readUint decodes a big-endian unsigned integer of 1, 2, 4 or 8 bytes.
+func readUint(b []byte) uint64 {
+	switch len(b) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b))
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b))
+	default:
+		return binary.BigEndian.Uint64(b)
+	}
+}
+
+// item scans a complete data item nested depth levels deep.
+func (s *scanner) item(depth int) error {
+	start := s.off
+	if depth > s.maxDepth {
+		return s.errorAt(start, "exceeded max depth")
+	}
+	major, ai, arg, err := s.head()
+	if err != nil {
+		return err
+	}
+	switch major {
+	case majorUint, majorNegint, majorTag:
+		if ai == aiIndefinite {
+			return s.errorAt(start, "indefinite length not allowed for major type "+strconv.Itoa(int(major)))
+		}
+		if major == majorTag {
+			return s.item(depth + 1)
+		}
+
+	case majorBytes, majorText:
+		if ai != aiIndefinite {
+			return s.payload(start, major, arg)
+		}
+		for {
+			if err := s.need(1); err != nil {
+				return err
+			}
+			if s.data[s.off] == cborBreak {
+				s.off++
+				return nil
+			}
+			cstart := s.off
+			cmajor, cai, carg, err := s.head()
+			if err != nil {
+				return err
+			}
+			if cmajor != major || cai == aiIndefinite {
+				return s.errorAt(cstart, "invalid chunk in indefinite-length string")
+			}
+			if err := s.payload(cstart, major, carg); err != nil {
+				return err
+			}
+		}
+
+	case majorArray, majorMap:
+		per := 1
+		if major == majorMap {
+			per = 2
+		}
+		if ai == aiIndefinite {
+			for i := 0; ; i++ {
+				if err := s.need(1); err != nil {
+					return err
+				}
+				if s.data[s.off] == cborBreak {
+					if i%per != 0 {
+						return s.errorAt(s.off, "missing value in indefinite-length map")
+					}
+					s.off++
+					return nil
+				}
+				if err := s.item(depth + 1); err != nil {
+					return err
+				}
+			}
+		}
+		// Each item occupies at least one byte, so a length that is
+		// too large is reported when the input runs out.
+		for i := uint64(0); i < arg; i++ {
+			for range per {
+				if err := s.item(depth + 1); err != nil {
+					return err
+				}
+			}
+		}
+
+	case majorSimple:
+		switch ai {
+		case 24:
+			if arg < 32 {
+				return s.errorAt(start, "invalid simple value "+strconv.FormatUint(arg, 10))
+			}
+		case aiIndefinite:
+			return s.errorAt(start, "unexpected break")
+		}
+	}
+	return nil
+}
+
+// payload scans the n content bytes of a definite-length string.
+func (s *scanner) payload(start int, major byte, n uint64) error {
+	if err := s.need(n); err != nil {
+		return err
+	}
+	b := s.data[s.off : s.off+int(n)]
+	if major == majorText && !utf8.Valid(b) {
+		return s.errorAt(start, "invalid UTF-8 in text string")
+	}
+	s.off += int(n)
+	return nil
+}
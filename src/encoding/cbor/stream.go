@@ -0,0 +1,233 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+)
+
+// A Decoder reads and decodes CBOR data items from an input stream,
+// such as a CBOR sequence (RFC 8742).
+type Decoder struct {
+	r       io.Reader
+	buf     []byte
+	scanp   int   // start of unread data in buf
+	scanned int64 // amount of data already scanned
+	err     error
+
+	maxDepth              int
+	disallowUnknownFields bool
+	disallowDuplicateKeys bool
+}
+
+// NewDecoder returns a new decoder that reads from r.
+//
+// The decoder introduces its own buffering and may
+// read data from r beyond the CBOR data items requested.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, maxDepth: defaultMaxDepth}
+}
+
+// DisallowUnknownFields causes the Decoder to return an error when the destination
+// is a struct and the input contains map keys which do not match any
+// non-ignored, exported fields in the destination.
+func (dec *Decoder) DisallowUnknownFields() { dec.disallowUnknownFields = true }
+
+// DisallowDuplicateKeys causes the Decoder to return a [SyntaxError] when
+// a map in the input contains the same key more than once. Keys are
+// compared after decoding them into the destination's key type, or as
+// described for [Unmarshal] into an interface value.
+//
+// RFC 8949 Section 5.6 describes how accepting duplicate keys can let
+// different parties interpret the same data differently.
+func (dec *Decoder) DisallowDuplicateKeys() { dec.disallowDuplicateKeys = true }
+
+// SetMaxDepth sets the maximum nesting depth of arrays, maps and tags
+// that the Decoder accepts. Deeper data is rejected with a [SyntaxError]
+// before any of it is decoded. The default is 1000.
+func (dec *Decoder) SetMaxDepth(n int) { dec.maxDepth = n }
+
+// Decode reads the next CBOR-encoded data item from its
+// input and stores it in the value pointed to by v.
+//
+// See the documentation for [Unmarshal] for details about
+// the conversion of CBOR into a Go value.
+// At the end of the input stream, Decode returns [io.EOF].
+func (dec *Decoder) Decode(v any) error {
+	if dec.err != nil {
+		return dec.err
+	}
+
+	n, err := dec.readItem()
+	if err != nil {
+		return err
+	}
+
+	// Don't save err from unmarshal into dec.err:
+	// the connection is still usable since we read a complete item
+	// from it before the error happened.
+	d := decodeState{
+		data:                  dec.buf[dec.scanp : dec.scanp+n],
+		base:                  dec.InputOffset(),
+		disallowUnknownFields: dec.disallowUnknownFields,
+		disallowDuplicateKeys: dec.disallowDuplicateKeys,
+	}
+	dec.scanp += n
+	return d.unmarshal(v)
+}
+
+// Buffered returns a reader of the data remaining in the Decoder's
+// buffer. The reader is valid until the next call to [Decoder.Decode].
+func (dec *Decoder) Buffered() io.Reader {
+	return bytes.NewReader(dec.buf[dec.scanp:])
+}
+
+// InputOffset returns the input stream byte offset of the current decoder
+// position: the end of the most recently decoded data item and the
+// beginning of the next one.
+func (dec *Decoder) InputOffset() int64 {
+	return dec.scanned + int64(dec.scanp)
+}
+
+// readItem reads a complete data item into dec.buf[dec.scanp:]
+// and returns its length.
+func (dec *Decoder) readItem() (int, error) {
+	if dec.scanp == len(dec.buf) {
+		if err := dec.refill(1); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			dec.err = err
+			return 0, err
+		}
+	}
+	s := scanner{
+		data:     dec.buf[dec.scanp:],
+		base:     dec.InputOffset(),
+		maxDepth: dec.maxDepth,
+	}
+	s.fill = func(n uint64) error {
+		need := uint64(s.off) + n
+		if need < n || need > math.MaxInt {
+			need = math.MaxInt
+		}
+		err := dec.refill(int(need))
+		s.data = dec.buf[dec.scanp:]
+		return err
+	}
+	if err := s.item(0); err != nil {
+		dec.err = err
+		return 0, err
+	}
+	return s.off, nil
+}
+
+// refill reads from dec.r until at least n bytes are buffered after
+// dec.scanp. It returns [io.ErrUnexpectedEOF] if the input ends first.
+// The buffer grows with the data actually read, so that a declared length
+// larger than the input does not cause a large allocation.
+func (dec *Decoder) refill(n int) error {
+	// Make room to read more into the buffer.
+	// First slide down data already consumed.
+	if dec.scanp > 0 {
+		dec.scanned += int64(dec.scanp)
+		k := copy(dec.buf, dec.buf[dec.scanp:])
+		dec.buf = dec.buf[:k]
+		dec.scanp = 0
+	}
+
+	for len(dec.buf) < n {
+		// Grow buffer if not large enough.
+		const minRead = 512
+		if cap(dec.buf)-len(dec.buf) < minRead {
+			newBuf := make([]byte, len(dec.buf), 2*cap(dec.buf)+minRead)
+			copy(newBuf, dec.buf)
+			dec.buf = newBuf
+		}
+
+		k, err := dec.r.Read(dec.buf[len(dec.buf):cap(dec.buf)])
+		dec.buf = dec.buf[0 : len(dec.buf)+k]
+		if err != nil && len(dec.buf) < n {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// An Encoder writes CBOR data items to an output stream.
+type Encoder struct {
+	w         io.Writer
+	err       error
+	canonical bool
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the CBOR encoding of v to the stream.
+// Successive items form a CBOR sequence (RFC 8742).
+//
+// See the documentation for [Marshal] for details about the
+// conversion of Go values to CBOR.
+func (enc *Encoder) Encode(v any) error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	e := newEncodeState()
+	defer encodeStatePool.Put(e)
+
+	err := e.marshal(v, encOpts{canonical: enc.canonical})
+	if err != nil {
+		return err
+	}
+	if _, err = enc.w.Write(e.buf); err != nil {
+		enc.err = err
+	}
+	return err
+}
+
+// SetCanonical specifies whether the keys of maps and structs are sorted
+// length-first, as required by the canonical CBOR of RFC 7049 Section 3.9
+// and by protocols derived from it such as CTAP2, instead of in the
+// bytewise lexicographic order of RFC 8949 Section 4.2.1 used by default.
+// Both orders are deterministic; they differ only when keys of different
+// encoded lengths are compared.
+func (enc *Encoder) SetCanonical(on bool) {
+	enc.canonical = on
+}
+
+// RawMessage is a raw encoded CBOR data item.
+// It implements [Marshaler] and [Unmarshaler] and can
+// be used to delay CBOR decoding or precompute a CBOR encoding.
+type RawMessage []byte
+
+// MarshalCBOR returns m as the CBOR encoding of m.
+func (m RawMessage) MarshalCBOR() ([]byte, error) {
+	if m == nil {
+		return []byte{cborNull}, nil
+	}
+	return m, nil
+}
+
+// UnmarshalCBOR sets *m to a copy of data.
+func (m *RawMessage) UnmarshalCBOR(data []byte) error {
+	if m == nil {
+		return errors.New("cbor.RawMessage: UnmarshalCBOR on nil pointer")
+	}
+	*m = append((*m)[0:0], data...)
+	return nil
+}
+
+var _ Marshaler = (*RawMessage)(nil)
+var _ Unmarshaler = (*RawMessage)(nil)
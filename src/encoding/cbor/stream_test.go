@@ -0,0 +1,218 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var streamTest = []any{
+	uint64(1),
+	"hello",
+	[]any{uint64(1), "two", []byte{3}},
+	map[any]any{"a": true, int64(-1): nil},
+	3.5,
+}
+
+func TestEncoderDecoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, v := range streamTest {
+		if err := enc.Encode(v); err != nil {
+			t.Fatalf("Encode(%v) error: %v", v, err)
+		}
+	}
+	data := buf.Bytes()
+
+	// Decode with readers that return the input in pieces
+	// to exercise refilling the buffer mid-item.
+	readers := map[string]func() io.Reader{
+		"Full":    func() io.Reader { return bytes.NewReader(data) },
+		"OneByte": func() io.Reader { return iotest.OneByteReader(bytes.NewReader(data)) },
+		"DataErr": func() io.Reader { return iotest.DataErrReader(bytes.NewReader(data)) },
+	}
+	for name, r := range readers {
+		t.Run(name, func(t *testing.T) {
+			dec := NewDecoder(r())
+			for i, want := range streamTest {
+				var got any
+				if err := dec.Decode(&got); err != nil {
+					t.Fatalf("Decode #%d error: %v", i, err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("Decode #%d:\n\tgot:  %#v\n\twant: %#v", i, got, want)
+				}
+			}
+			if off := dec.InputOffset(); off != int64(len(data)) {
+				t.Errorf("InputOffset = %d, want %d", off, len(data))
+			}
+			var v any
+			if err := dec.Decode(&v); err != io.EOF {
+				t.Errorf("Decode at end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestDecoderBuffered(t *testing.T) {
+	dec := NewDecoder(strings.NewReader("\x01\x61a rest"))
+	var n int
+	if err := dec.Decode(&n); err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(dec.Buffered())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\x61a rest"; string(b) != want {
+		t.Errorf("Buffered = %q, want %q", b, want)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"Truncated", "\x01\x82\x01", io.ErrUnexpectedEOF},
+		{"HugeLength", "\x01\x5b\xff\xff\xff\xff\xff\xff\xff\xff", io.ErrUnexpectedEOF},
+		{"Syntax", "\x01\x82\xff", &SyntaxError{"cbor: unexpected break", 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(tt.in))
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				t.Fatalf("first Decode error: %v", err)
+			}
+			err := dec.Decode(&v)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Fatalf("Decode error:\n\tgot:  %#v\n\twant: %#v", err, tt.err)
+			}
+			// Errors reading the stream are sticky.
+			if err2 := dec.Decode(&v); err2 != err {
+				t.Errorf("second Decode error = %v, want %v", err2, err)
+			}
+		})
+	}
+}
+
+func TestDecoderTypeErrorOffset(t *testing.T) {
+	dec := NewDecoder(strings.NewReader("\x01\x82\x01\x61a\x02"))
+	var n int
+	if err := dec.Decode(&n); err != nil {
+		t.Fatal(err)
+	}
+	var s []int
+	want := &UnmarshalTypeError{Value: "text string", Type: reflect.TypeFor[int](), Offset: 3}
+	if err := dec.Decode(&s); !reflect.DeepEqual(err, want) {
+		t.Fatalf("Decode error:\n\tgot:  %#v\n\twant: %#v", err, want)
+	}
+	// A type error does not prevent decoding the rest of the stream.
+	if err := dec.Decode(&n); err != nil || n != 2 {
+		t.Errorf("Decode after type error = %d, %v; want 2, nil", n, err)
+	}
+}
+
+func TestDecoderMaxDepth(t *testing.T) {
+	in := strings.Repeat("\x81", 10) + "\x00"
+	dec := NewDecoder(strings.NewReader(in))
+	dec.SetMaxDepth(9)
+	var v any
+	want := &SyntaxError{"cbor: exceeded max depth", 10}
+	if err := dec.Decode(&v); !reflect.DeepEqual(err, want) {
+		t.Errorf("Decode error:\n\tgot:  %#v\n\twant: %#v", err, want)
+	}
+
+	dec = NewDecoder(strings.NewReader(in))
+	dec.SetMaxDepth(10)
+	if err := dec.Decode(&v); err != nil {
+		t.Errorf("Decode error: %v", err)
+	}
+}
+
+func TestDecoderDisallowDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		ptr  any
+		off  int64 // offset of the repeated key
+	}{
+		{"Any", "\xa2\x61a\x01\x61a\x02", new(any), 4},
+		{"Indefinite", "\xbf\x61a\x01\x61a\x02\xff", new(any), 4},
+		{"Map", "\xa2\x01\x01\x01\x02", new(map[int]int), 3},
+		{"MapEquivalentKeys", "\xa2\x01\x01\x18\x01\x02", new(map[int]int), 3},
+		{"Struct", "\xa2\x61A\x01\x61A\x02", new(struct{ A int }), 4},
+		{"StructIntKey", "\xa2\x20\x01\x20\x02", new(struct {
+			A int `cbor:"-1,keyasint"`
+		}), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Duplicates are accepted by default.
+			if err := NewDecoder(strings.NewReader(tt.in)).Decode(tt.ptr); err != nil {
+				t.Fatalf("Decode error: %v", err)
+			}
+
+			dec := NewDecoder(strings.NewReader(tt.in))
+			dec.DisallowDuplicateKeys()
+			err := dec.Decode(tt.ptr)
+			var serr *SyntaxError
+			if !errors.As(err, &serr) || serr.Error() != "cbor: duplicate map key" {
+				t.Fatalf("Decode error = %v, want duplicate map key", err)
+			}
+			if serr.Offset != tt.off {
+				t.Errorf("Offset = %d, want %d", serr.Offset, tt.off)
+			}
+		})
+	}
+}
+
+func TestDecoderDisallowUnknownFields(t *testing.T) {
+	type T struct {
+		A int `cbor:"a"`
+		B int `cbor:"1,keyasint"`
+	}
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"\xa2\x61a\x01\x61b\x02", `cbor: unknown field "b"`},
+		{"\xa1\x02\x01", `cbor: unknown field 2`},
+		{"\xa1\x41a\x01", `cbor: unknown field byte string key`},
+	}
+	for _, tt := range tests {
+		var v T
+		if err := Unmarshal([]byte(tt.in), &v); err != nil {
+			t.Errorf("Unmarshal(%q) error: %v", tt.in, err)
+		}
+		dec := NewDecoder(strings.NewReader(tt.in))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&v); err == nil || err.Error() != tt.want {
+			t.Errorf("Decode(%q) error = %v, want %s", tt.in, err, tt.want)
+		}
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestEncoderWriteError(t *testing.T) {
+	enc := NewEncoder(errWriter{})
+	if err := enc.Encode(1); err == nil {
+		t.Fatal("Encode succeeded on failing writer")
+	}
+	if err := enc.Encode(2); err == nil || err.Error() != "write failed" {
+		t.Errorf("second Encode error = %v, want write failed", err)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cbor
+
+// A Tag is a CBOR tagged data item (RFC 8949 Section 3.4).
+//
+// [Marshal] encodes a Tag as its number followed by the encoding of its
+// content. [Unmarshal] produces a Tag when decoding a tagged data item
+// into an interface value, unless the tag has a more specific Go
+// representation such as [time.Time], or when decoding into a Tag.
+type Tag struct {
+	Number  uint64
+	Content any
+}
+
+// A Simple is a CBOR simple value (RFC 8949 Section 3.3).
+//
+// The simple values 20 through 23 are false, true, null and undefined;
+// [Unmarshal] decodes those to Go values rather than to Simple.
+// The values 24 through 31 are reserved and cannot be encoded.
+type Simple uint8
@@ -0,0 +1,286 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csv
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/internal/structfield"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal parses CSV data that starts with a header record and stores
+// the records that follow in the slice pointed to by v, whose elements
+// must be structs or pointers to structs. The slice is truncated before
+// the first record is appended.
+//
+// Columns are matched to struct fields by name, as described for
+// [Marshal], preferring an exact match but also accepting a
+// case-insensitive one. Columns without a matching field are ignored
+// and fields without a matching column are left unchanged.
+//
+// A field whose type implements [encoding.TextUnmarshaler] is set by
+// calling UnmarshalText with the text of the column. Otherwise strings
+// are set as is, and booleans, integers and floating-point numbers are
+// parsed with the strconv package. An unquoted empty field is null: it
+// sets the struct field to its zero value, which is nil for pointers.
+// Pointers are otherwise set to newly allocated values.
+//
+// If a field cannot be converted, Unmarshal returns a [*ParseError]
+// wrapping an [*UnmarshalTypeError].
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("csv: Unmarshal requires a non-nil pointer to a slice")
+	}
+	slice := rv.Elem()
+	et := slice.Type().Elem()
+	st := et
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	dec := NewDecoder(NewReader(bytes.NewReader(data)))
+	if err := dec.init(st); err != nil {
+		return err
+	}
+	slice.SetLen(0)
+	for i := 0; ; i++ {
+		err := dec.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		slice.Grow(1)
+		slice.SetLen(i + 1)
+		ev := slice.Index(i)
+		if et.Kind() == reflect.Pointer {
+			ev.Set(reflect.New(st))
+			ev = ev.Elem()
+		} else {
+			ev.SetZero()
+		}
+		if err := dec.decode(ev); err != nil {
+			return err
+		}
+	}
+}
+
+// An UnmarshalTypeError describes a CSV field that could not be
+// converted to the type of its struct field.
+type UnmarshalTypeError struct {
+	Value  string       // text of the field
+	Type   reflect.Type // type of the struct field
+	Column string       // name of the column in the header
+	Err    error        // underlying error, if any
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	s := "cannot unmarshal " + strconv.Quote(e.Value) + " in column " + strconv.Quote(e.Column) + " into Go value of type " + e.Type.String()
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+func (e *UnmarshalTypeError) Unwrap() error { return e.Err }
+
+// A Decoder reads records from a [Reader] into structs.
+type Decoder struct {
+	r       *Reader
+	header  []string
+	typ     reflect.Type // struct type of the last Decode
+	columns []int        // index into the fields of typ of each column, or -1
+	fields  []field
+	record  []string
+	err     error // sticky error from reading the header or a record
+
+	disallowUnknownFields bool
+}
+
+// NewDecoder returns a new decoder that reads from r. The first record
+// read from r is the header. The null handling of the records it reads
+// is controlled by [Reader.Null].
+func NewDecoder(r *Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// DisallowUnknownFields causes the Decoder to return an error when the
+// header names a column that does not match any field of the
+// destination struct.
+func (d *Decoder) DisallowUnknownFields() { d.disallowUnknownFields = true }
+
+// Header returns the header record, reading it if no record has been
+// read yet.
+func (d *Decoder) Header() ([]string, error) {
+	if d.header == nil && d.err == nil {
+		header, err := d.r.readRecord(nil)
+		if err != nil {
+			d.err = err
+			return nil, err
+		}
+		d.header = header
+	}
+	return d.header, d.err
+}
+
+// Decode reads the next record and stores it in the struct pointed to
+// by v, converting its fields as described for [Unmarshal]. If there
+// are no records left, Decode returns [io.EOF].
+//
+// A [*ParseError] reading a record is returned as is; other errors
+// from the underlying [io.Reader] are returned by every later call.
+func (d *Decoder) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("csv: Decode requires a non-nil pointer to a struct")
+	}
+	if t := rv.Elem().Type(); t != d.typ {
+		if err := d.init(t); err != nil {
+			return err
+		}
+	}
+	if err := d.next(); err != nil {
+		return err
+	}
+	return d.decode(rv.Elem())
+}
+
+// init reads the header, if necessary, and maps its columns to the
+// fields of the struct type t.
+func (d *Decoder) init(t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return &UnsupportedTypeError{t}
+	}
+	fields := cachedTypeFields(t)
+	for _, f := range fields {
+		if !decodable(f.typ) {
+			return &UnsupportedTypeError{f.typ}
+		}
+	}
+	header, err := d.Header()
+	if err == io.EOF {
+		// An empty input has no records to map.
+		header, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	columns := make([]int, len(header))
+	for i, name := range header {
+		columns[i] = slices.IndexFunc(fields, func(f field) bool { return f.name == name })
+		if columns[i] < 0 {
+			columns[i] = slices.IndexFunc(fields, func(f field) bool { return strings.EqualFold(f.name, name) })
+		}
+		if columns[i] < 0 && d.disallowUnknownFields {
+			return errors.New("csv: unknown column " + strconv.Quote(name))
+		}
+		if columns[i] >= 0 && slices.Contains(columns[:i], columns[i]) {
+			return errors.New("csv: duplicate column " + strconv.Quote(name))
+		}
+	}
+	d.typ = t
+	d.fields = fields
+	d.columns = columns
+	return nil
+}
+
+// next reads the next record into d.record. The Reader can continue
+// after a [*ParseError], but other errors, including io.EOF, are sticky.
+func (d *Decoder) next() error {
+	if d.err != nil {
+		return d.err
+	}
+	record, err := d.r.readRecord(d.record)
+	d.record = record
+	if _, ok := err.(*ParseError); err != nil && !ok {
+		d.err = err
+	}
+	return err
+}
+
+// decode stores the record in d.record in the struct v.
+func (d *Decoder) decode(v reflect.Value) error {
+	for i, s := range d.record {
+		if i >= len(d.columns) || d.columns[i] < 0 {
+			continue
+		}
+		f := &d.fields[d.columns[i]]
+		if !d.r.fieldQuoted[i] && s == d.r.Null {
+			if fv, ok := structfield.ByIndex(v, f.index); ok {
+				fv.SetZero()
+			}
+			continue
+		}
+		fv, err := structfield.ByIndexAlloc(v, f.index)
+		if err == nil {
+			err = setField(fv, s)
+		}
+		if err != nil {
+			line, col := d.r.FieldPos(i)
+			startLine, _ := d.r.FieldPos(0)
+			return &ParseError{
+				StartLine: startLine,
+				Line:      line,
+				Column:    col,
+				Err: &UnmarshalTypeError{
+					Value:  s,
+					Type:   f.typ,
+					Column: d.header[i],
+					Err:    err,
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// setField sets the addressable value v from the text s.
+func setField(v reflect.Value, s string) error {
+	for v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return &UnsupportedTypeError{v.Type()}
+	}
+	return nil
+}
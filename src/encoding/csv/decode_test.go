@@ -0,0 +1,206 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	in := "name,AGE,score,ok,level,comment,id,note,extra\n" +
+		`Ann,31,1.5,true,high,"a, b",7,n,x` + "\n" +
+		`Bob,-2,1e21,false,low,"",,,` + "\n" +
+		"Cy,0,0,0,low,,,,\n"
+	want := []record{
+		{Name: "Ann", Age: 31, Score: 1.5, OK: true, Level: 1, Comment: ptr("a, b"), Embedded: &Embedded{ID: 7, Note: "n"}},
+		{Name: "Bob", Age: -2, Score: 1e21, Level: 0, Comment: ptr("")},
+		{Name: "Cy"},
+	}
+	got := []record{{Name: "stale"}}
+	if err := Unmarshal([]byte(in), &got); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	var ptrs []*record
+	if err := Unmarshal([]byte(in), &ptrs); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(ptrs) != len(want) || !reflect.DeepEqual(*ptrs[0], want[0]) {
+		t.Errorf("Unmarshal into []*record = %+v", ptrs)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	in := []record{
+		{Name: "", Age: 1, Comment: ptr("")},
+		{Name: "x\ny", Age: 2, Comment: nil, Embedded: &Embedded{Note: `"q"`}},
+	}
+	for _, quote := range []QuoteMode{QuoteAll, QuoteStrings} {
+		var b strings.Builder
+		w := NewWriter(&b)
+		w.Quote = quote
+		enc := NewEncoder(w)
+		for _, r := range in {
+			if err := enc.Encode(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w.Flush()
+		var out []record
+		if err := Unmarshal([]byte(b.String()), &out); err != nil {
+			t.Fatalf("Unmarshal(%q) error: %v", b.String(), err)
+		}
+		// The empty embedded struct of the second record is allocated
+		// because its Note column is not null.
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("Quote %d: round trip of %q:\ngot  %+v\nwant %+v", quote, b.String(), out, in)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		v    any
+		err  error
+	}{
+		{
+			name: "NotPointer",
+			in:   "A\n1\n",
+			v:    []struct{ A int }{},
+			err:  errors.New("csv: Unmarshal requires a non-nil pointer to a slice"),
+		},
+		{
+			name: "FieldType",
+			in:   "A\n1\n",
+			v:    new([]struct{ A []int }),
+			err:  &UnsupportedTypeError{reflect.TypeFor[[]int]()},
+		},
+		{
+			name: "Syntax",
+			in:   "A,B\n1,x\n2,3\n",
+			v:    new([]struct{ A, B int }),
+			err: &ParseError{StartLine: 2, Line: 2, Column: 3, Err: &UnmarshalTypeError{
+				Value:  "x",
+				Type:   reflect.TypeFor[int](),
+				Column: "B",
+				Err:    &strconv.NumError{Func: "ParseInt", Num: "x", Err: strconv.ErrSyntax},
+			}},
+		},
+		{
+			name: "FieldCount",
+			in:   "A\n\"a\nb\",256\n",
+			v:    new([]struct{ A uint8 }),
+			err:  &ParseError{StartLine: 2, Line: 2, Column: 1, Err: ErrFieldCount},
+		},
+		{
+			name: "Marshaler",
+			in:   "B,L\n1,\"x,\"\n",
+			v:    new([]struct{ L level }),
+			err: &ParseError{StartLine: 2, Line: 2, Column: 3, Err: &UnmarshalTypeError{
+				Value:  "x,",
+				Type:   reflect.TypeFor[level](),
+				Column: "L",
+				Err:    errors.New("bad level"),
+			}},
+		},
+		{
+			name: "DuplicateColumn",
+			in:   "a,A\n1,2\n",
+			v:    new([]struct{ A int }),
+			err:  errors.New(`csv: duplicate column "A"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.in), tt.v)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("Unmarshal error:\ngot  %v\nwant %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	r := NewReader(strings.NewReader("x,n,p\na,1,\\N\n\"\\N\",\\N,2\n"))
+	r.Null = `\N`
+	dec := NewDecoder(r)
+	header, err := dec.Header()
+	if err != nil || !reflect.DeepEqual(header, []string{"x", "n", "p"}) {
+		t.Fatalf("Header = %q, %v", header, err)
+	}
+	type row struct {
+		X string
+		N int
+		P *int
+	}
+	want := []row{{X: "a", N: 1}, {X: `\N`, P: ptr(2)}}
+	v := row{N: 5, P: ptr(5)}
+	for i, w := range want {
+		if err := dec.Decode(&v); err != nil {
+			t.Fatalf("Decode #%d error: %v", i, err)
+		}
+		if !reflect.DeepEqual(v, w) {
+			t.Errorf("Decode #%d = %+v, want %+v", i, v, w)
+		}
+	}
+	if err := dec.Decode(&v); err != io.EOF {
+		t.Errorf("Decode at end = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderContinueAfterError(t *testing.T) {
+	dec := NewDecoder(NewReader(strings.NewReader("a\n1\nx\n3\n")))
+	var v struct{ A int }
+	var got []int
+	var errs int
+	for {
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		var terr *UnmarshalTypeError
+		if errors.As(err, &terr) {
+			errs++
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v.A)
+	}
+	if errs != 1 || !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("got %v with %d errors, want [1 3] with 1 error", got, errs)
+	}
+}
+
+func TestDecoderDisallowUnknownFields(t *testing.T) {
+	in := "A,B\n1,2\n"
+	var v struct{ A int }
+	if err := NewDecoder(NewReader(strings.NewReader(in))).Decode(&v); err != nil || v.A != 1 {
+		t.Fatalf("Decode = %+v, %v", v, err)
+	}
+	dec := NewDecoder(NewReader(strings.NewReader(in)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&v); err == nil || err.Error() != `csv: unknown column "B"` {
+		t.Errorf("Decode error = %v, want unknown column", err)
+	}
+}
+
+func TestUnmarshalEmpty(t *testing.T) {
+	v := []struct{ A int }{{1}}
+	if err := Unmarshal(nil, &v); err != nil || len(v) != 0 {
+		t.Errorf("Unmarshal(nil) = %v, %v; want empty slice, nil", v, err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csv
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/internal/structfield"
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// Marshal returns the CSV encoding of v, which must be a slice or array
+// of structs or of pointers to structs. The output starts with a header
+// record naming the columns, followed by one record per element,
+// written as by an [Encoder] on a [Writer] returned by [NewWriter].
+//
+// Each exported struct field is a column named after the field, or
+// after the name given by its "csv" struct tag. A field with the tag
+// "-" is omitted. Fields of embedded structs are promoted following
+// the same rules as encoding/json.
+//
+// A field whose type implements [encoding.TextMarshaler] is written as
+// the text it returns. Otherwise strings are written as is, and
+// booleans, integers and floating-point numbers are formatted with the
+// strconv package. A nil pointer, or a field of an embedded struct
+// reached through a nil pointer, is a null value, written as
+// [Writer.Null]. Any other field type causes an [UnsupportedTypeError].
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, &UnsupportedTypeError{reflect.TypeOf(v)}
+	}
+	var buf bytes.Buffer
+	enc := NewEncoder(NewWriter(&buf))
+	if err := enc.init(rv.Type().Elem()); err != nil {
+		return nil, err
+	}
+	if err := enc.writeHeader(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.encode(rv.Index(i)); err != nil {
+			return nil, err
+		}
+	}
+	enc.w.Flush()
+	if err := enc.w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// An Encoder writes structs as records to a [Writer].
+type Encoder struct {
+	w      *Writer
+	typ    reflect.Type // struct type of the records
+	fields []field
+	header bool // header record has been written
+	record []string
+	kinds  []fieldKind
+}
+
+// NewEncoder returns a new encoder that writes to w. The quoting and
+// null handling of the records it writes are controlled by the fields
+// of w.
+func NewEncoder(w *Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the struct v, or the struct v points to, as a record,
+// converting its fields as described for [Marshal]. The first call
+// writes a header record before the record for v, and all calls must
+// pass values of the same struct type.
+//
+// Like [Writer.Write], Encode buffers its output, so [Writer.Flush]
+// must eventually be called on the underlying Writer.
+func (e *Encoder) Encode(v any) error {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return errors.New("csv: Encode(nil)")
+	}
+	if e.typ == nil {
+		if err := e.init(rv.Type()); err != nil {
+			return err
+		}
+	} else if t := rv.Type(); t != e.typ && !(t.Kind() == reflect.Pointer && t.Elem() == e.typ) {
+		return errors.New("csv: Encode of " + t.String() + " after " + e.typ.String())
+	}
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+	return e.encode(rv)
+}
+
+// init prepares e to encode values of type t, a struct type or a
+// pointer to one.
+func (e *Encoder) init(t reflect.Type) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return &UnsupportedTypeError{t}
+	}
+	fields := cachedTypeFields(t)
+	for _, f := range fields {
+		if !encodable(f.typ) {
+			return &UnsupportedTypeError{f.typ}
+		}
+	}
+	e.typ = t
+	e.fields = fields
+	e.record = make([]string, len(fields))
+	e.kinds = make([]fieldKind, len(fields))
+	return nil
+}
+
+func (e *Encoder) writeHeader() error {
+	for i, f := range e.fields {
+		e.record[i] = f.name
+		e.kinds[i] = textField
+	}
+	e.header = true
+	return e.w.write(e.record, e.kinds)
+}
+
+// encode writes the struct v, or the struct v points to, as a record.
+func (e *Encoder) encode(v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v = reflect.Value{}
+		} else {
+			v = v.Elem()
+		}
+	}
+	if v.IsValid() && !v.CanAddr() {
+		// Make v addressable so that methods with pointer
+		// receivers can be called on its fields.
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	for i, f := range e.fields {
+		var fv reflect.Value
+		if v.IsValid() {
+			fv, _ = structfield.ByIndex(v, f.index)
+		}
+		s, kind, err := e.formatField(fv)
+		if err != nil {
+			return err
+		}
+		e.record[i] = s
+		e.kinds[i] = kind
+	}
+	return e.w.write(e.record, e.kinds)
+}
+
+// formatField returns the text of the field value v.
+// The zero Value and nil pointers are null.
+func (e *Encoder) formatField(v reflect.Value) (string, fieldKind, error) {
+	for v.IsValid() && v.Kind() == reflect.Pointer && !v.Type().Implements(textMarshalerType) {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return e.w.Null, nullField, nil
+	}
+	if !v.Type().Implements(textMarshalerType) && v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		v = v.Addr()
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return "", 0, &MarshalerError{v.Type(), err}
+		}
+		return string(b), textField, nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), textField, nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), otherField, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), otherField, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), otherField, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), otherField, nil
+	}
+	return "", 0, &UnsupportedTypeError{v.Type()}
+}
+
+// A MarshalerError is returned by [Marshal] and [Encoder.Encode] when
+// the MarshalText method of a field fails.
+type MarshalerError struct {
+	Type reflect.Type
+	Err  error
+}
+
+func (e *MarshalerError) Error() string {
+	return "csv: error calling MarshalText for type " + e.Type.String() + ": " + e.Err.Error()
+}
+
+func (e *MarshalerError) Unwrap() error { return e.Err }
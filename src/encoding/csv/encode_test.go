@@ -0,0 +1,189 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// level implements encoding.TextMarshaler with a pointer receiver
+// and encoding.TextUnmarshaler.
+type level int
+
+func (l *level) MarshalText() ([]byte, error) {
+	switch *l {
+	case 0:
+		return []byte("low"), nil
+	case 1:
+		return []byte("high"), nil
+	}
+	return nil, errors.New("bad level")
+}
+
+func (l *level) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return errors.New("bad level")
+	}
+	return nil
+}
+
+type Embedded struct {
+	ID   int
+	Note string `csv:"note"`
+}
+
+type record struct {
+	Name    string `csv:"name"`
+	Age     int    `csv:"age"`
+	Score   float64
+	OK      bool
+	Level   level
+	Comment *string `csv:"comment"`
+	Skipped int     `csv:"-"`
+	private int
+	*Embedded
+}
+
+func ptr[T any](v T) *T { return &v }
+
+var marshalTests = []struct {
+	name string
+	in   any
+	out  string
+}{
+	{
+		name: "Empty",
+		in:   []record{},
+		out:  "name,age,Score,OK,Level,comment,ID,note\n",
+	},
+	{
+		name: "Values",
+		in: []record{
+			{Name: "Ann", Age: 31, Score: 1.5, OK: true, Level: 1, Comment: ptr("a, b"), Embedded: &Embedded{ID: 7, Note: ""}},
+			{Name: " Bob", Age: -2, Score: 1e21},
+		},
+		out: "name,age,Score,OK,Level,comment,ID,note\n" +
+			`Ann,31,1.5,true,high,"a, b",7,` + "\n" +
+			`" Bob",-2,1e+21,false,low,,,` + "\n",
+	},
+	{
+		name: "Pointers",
+		in:   []*struct{ A, B *int }{{A: ptr(1)}, nil},
+		out:  "A,B\n1,\n,\n",
+	},
+	{
+		name: "Array",
+		in:   [1]struct{ X uint8 }{{255}},
+		out:  "X\n255\n",
+	},
+	{
+		name: "Conflicts",
+		in: []struct {
+			Embedded
+			ID   string // hides Embedded.ID
+			Note string // names are case-sensitive: does not hide Embedded.Note
+		}{{Embedded: Embedded{ID: 1, Note: "n"}, ID: "id", Note: "x"}},
+		out: "note,ID,Note\nn,id,x\n",
+	},
+}
+
+func TestMarshal(t *testing.T) {
+	for _, tt := range marshalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(b) != tt.out {
+				t.Errorf("Marshal:\ngot  %q\nwant %q", b, tt.out)
+			}
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		err  error
+	}{
+		{"NotSlice", struct{ A int }{}, &UnsupportedTypeError{reflect.TypeFor[struct{ A int }]()}},
+		{"NotStruct", []int{1}, &UnsupportedTypeError{reflect.TypeFor[int]()}},
+		{"FieldType", []struct{ M map[string]int }{}, &UnsupportedTypeError{reflect.TypeFor[map[string]int]()}},
+		{"Marshaler", []struct{ L level }{{L: 2}}, &MarshalerError{reflect.TypeFor[*level](), errors.New("bad level")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("Marshal error:\ngot  %v\nwant %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEncoderQuote(t *testing.T) {
+	type row struct {
+		S  string
+		N  int
+		P  *float64
+		L  level
+		PS *string
+	}
+	rows := []row{
+		{S: "a", N: 1, P: ptr(2.5), PS: ptr("")},
+		{S: "", N: 0},
+	}
+	tests := []struct {
+		quote QuoteMode
+		null  string
+		out   string
+	}{
+		{QuoteMinimal, "", "S,N,P,L,PS\na,1,2.5,low,\n,0,,low,\n"},
+		{QuoteAll, "", `"S","N","P","L","PS"` + "\n" + `"a","1","2.5","low",""` + "\n" + `"","0",,"low",` + "\n"},
+		{QuoteStrings, "", `"S","N","P","L","PS"` + "\n" + `"a",1,2.5,"low",""` + "\n" + `"",0,,"low",` + "\n"},
+		{QuoteStrings, `\N`, `"S","N","P","L","PS"` + "\n" + `"a",1,2.5,"low",""` + "\n" + `"",0,\N,"low",\N` + "\n"},
+		{QuoteMinimal, "a,b", "S,N,P,L,PS\na,1,2.5,low,\n,0,\"a,b\",low,\"a,b\"\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		w := NewWriter(&b)
+		w.Quote = tt.quote
+		w.Null = tt.null
+		enc := NewEncoder(w)
+		for _, r := range rows {
+			if err := enc.Encode(r); err != nil {
+				t.Fatalf("Encode error: %v", err)
+			}
+		}
+		w.Flush()
+		if b.String() != tt.out {
+			t.Errorf("Quote %d, Null %q:\ngot  %q\nwant %q", tt.quote, tt.null, b.String(), tt.out)
+		}
+	}
+}
+
+func TestEncoderTypeChange(t *testing.T) {
+	enc := NewEncoder(NewWriter(new(strings.Builder)))
+	type A struct{ X int }
+	if err := enc.Encode(A{1}); err != nil {
+		t.Fatal(err)
+	}
+	// A pointer to the same type is allowed.
+	if err := enc.Encode(&A{2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(struct{ X int }{3}); err == nil {
+		t.Error("Encode of a different type succeeded")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 )
 
 func ExampleReader() {
@@ -129,3 +130,100 @@ func ExampleWriter_WriteAll() {
 	// Ken,Thompson,ken
 	// Robert,Griesemer,gri
 }
+
+func ExampleMarshal() {
+	type User struct {
+		FirstName string `csv:"first_name"`
+		LastName  string `csv:"last_name"`
+		Username  string `csv:"username"`
+		Commits   int    `csv:"commits"`
+	}
+	users := []User{
+		{"Rob", "Pike", "rob", 1741},
+		{"Ken", "Thompson", "ken", 0},
+	}
+
+	b, err := csv.Marshal(users)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(string(b))
+	// Output:
+	// first_name,last_name,username,commits
+	// Rob,Pike,rob,1741
+	// Ken,Thompson,ken,0
+}
+
+// This example writes a null value that Postgres COPY can tell apart
+// from an empty string.
+func ExampleEncoder() {
+	type Row struct {
+		ID   int
+		Name string
+		Note *string
+	}
+	empty := ""
+
+	w := csv.NewWriter(os.Stdout)
+	w.Quote = csv.QuoteStrings
+	enc := csv.NewEncoder(w)
+	for _, r := range []Row{{1, "a", &empty}, {2, "", nil}} {
+		if err := enc.Encode(r); err != nil {
+			log.Fatal(err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// "ID","Name","Note"
+	// 1,"a",""
+	// 2,"",
+}
+
+func ExampleDecoder() {
+	in := `username,commits,last_login
+rob,1741,2024-05-01T09:00:00Z
+ken,0,
+`
+	type User struct {
+		Username  string     `csv:"username"`
+		Commits   int        `csv:"commits"`
+		LastLogin *time.Time `csv:"last_login"`
+	}
+
+	dec := csv.NewDecoder(csv.NewReader(strings.NewReader(in)))
+	for {
+		var u User
+		if err := dec.Decode(&u); err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(u.Username, u.Commits, u.LastLogin)
+	}
+	// Output:
+	// rob 1741 2024-05-01 09:00:00 +0000 UTC
+	// ken 0 <nil>
+}
+
+func ExampleReader_Records() {
+	in := `first_name,last_name,username
+"Rob","Pike",rob
+Ken,Thompson,ken
+`
+	r := csv.NewReader(strings.NewReader(in))
+
+	for record, err := range r.Records() {
+		if err != nil {
+			log.Fatal(err)
+		}
+		// record is reused by the next iteration.
+		fmt.Println(record)
+	}
+	// Output:
+	// [first_name last_name username]
+	// [Rob Pike rob]
+	// [Ken Thompson ken]
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csv
+
+import (
+	"encoding"
+	"encoding/internal/structfield"
+	"reflect"
+	"sync"
+)
+
+// An UnsupportedTypeError is returned by [Marshal], [Unmarshal],
+// [Encoder.Encode] and [Decoder.Decode] when asked to convert a value
+// of a type that has no CSV representation.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "csv: unsupported type: " + e.Type.String()
+}
+
+// A field is a column of a struct type: an exported field,
+// possibly promoted from an embedded struct.
+type field struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// encodable reports whether values of type t can be written as a field.
+func encodable(t reflect.Type) bool {
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer:
+		return encodable(t.Elem())
+	}
+	return false
+}
+
+// decodable reports whether values of type t can be read from a field.
+func decodable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer:
+		return decodable(t.Elem())
+	}
+	return false
+}
+
+// typeFields returns the columns of the struct type t, in field order.
+// It follows the same rules as encoding/json for names and for
+// fields promoted from embedded structs.
+func typeFields(t reflect.Type) []field {
+	list := structfield.Fields(t, "csv", nil)
+	fields := make([]field, len(list))
+	for i, f := range list {
+		fields[i] = field{name: f.Name, index: f.Index, typ: f.Type}
+	}
+	return fields
+}
+
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// cachedTypeFields is like typeFields but uses a cache to avoid repeated work.
+func cachedTypeFields(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field)
+	}
+	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	return f.([]field)
+}
@@ -58,6 +58,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"unicode"
 	"unicode/utf8"
 )
@@ -145,6 +146,12 @@ type Reader struct {
 	// By default, each call to Read returns newly allocated memory owned by the caller.
 	ReuseRecord bool
 
+	// Null is the text of a null value for a Decoder. An unquoted field
+	// equal to Null sets its struct field to the zero value, which is nil
+	// for pointers; a quoted field is never null. Null does not affect
+	// the records returned by Read.
+	Null string
+
 	// Deprecated: TrailingComma is no longer used.
 	TrailingComma bool
 
@@ -173,6 +180,10 @@ type Reader struct {
 	// last record returned by Read.
 	fieldPositions []position
 
+	// fieldQuoted records which fields of the last record
+	// returned by Read were quoted.
+	fieldQuoted []bool
+
 	// lastRecord is a record cache and only used when ReuseRecord == true.
 	lastRecord []string
 }
@@ -225,6 +236,31 @@ func (r *Reader) InputOffset() int64 {
 	return r.offset
 }
 
+// Records returns an iterator over the remaining records in r.
+//
+// The iterator reuses the backing array of the record slice between
+// iterations, as [Reader.Read] does when [Reader.ReuseRecord] is true,
+// so a record is only valid until the next iteration; use
+// [slices.Clone] to retain one. The strings in a record are not reused.
+//
+// Iteration stops at the end of the input. If a record cannot be read,
+// the iterator yields it along with the error, as Read would return
+// them, and stops.
+func (r *Reader) Records() iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		for {
+			record, err := r.readRecord(r.lastRecord)
+			if err == io.EOF {
+				return
+			}
+			r.lastRecord = record
+			if !yield(record, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
 // pos holds the position of a field in the current line.
 type position struct {
 	line, col int
@@ -326,6 +362,7 @@ func (r *Reader) readRecord(dst []string) ([]string, error) {
 	r.recordBuffer = r.recordBuffer[:0]
 	r.fieldIndexes = r.fieldIndexes[:0]
 	r.fieldPositions = r.fieldPositions[:0]
+	r.fieldQuoted = r.fieldQuoted[:0]
 	pos := position{line: r.numLine, col: 1}
 parseField:
 	for {
@@ -360,6 +397,7 @@ parseField:
 			r.recordBuffer = append(r.recordBuffer, field...)
 			r.fieldIndexes = append(r.fieldIndexes, len(r.recordBuffer))
 			r.fieldPositions = append(r.fieldPositions, pos)
+			r.fieldQuoted = append(r.fieldQuoted, false)
 			if i >= 0 {
 				line = line[i+commaLen:]
 				pos.col += i + commaLen
@@ -390,11 +428,13 @@ parseField:
 						pos.col += commaLen
 						r.fieldIndexes = append(r.fieldIndexes, len(r.recordBuffer))
 						r.fieldPositions = append(r.fieldPositions, fieldPos)
+						r.fieldQuoted = append(r.fieldQuoted, true)
 						continue parseField
 					case lengthNL(line) == len(line):
 						// `"\n` sequence (end of line).
 						r.fieldIndexes = append(r.fieldIndexes, len(r.recordBuffer))
 						r.fieldPositions = append(r.fieldPositions, fieldPos)
+						r.fieldQuoted = append(r.fieldQuoted, true)
 						break parseField
 					case r.LazyQuotes:
 						// `"` sequence (bare quote).
@@ -427,6 +467,7 @@ parseField:
 					}
 					r.fieldIndexes = append(r.fieldIndexes, len(r.recordBuffer))
 					r.fieldPositions = append(r.fieldPositions, fieldPos)
+					r.fieldQuoted = append(r.fieldQuoted, true)
 					break parseField
 				}
 			}
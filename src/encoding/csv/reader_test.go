@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"unicode/utf8"
@@ -484,10 +485,51 @@ func TestRead(t *testing.T) {
 					}
 				}
 			}
+
+			// Check the Records iterator, which stops at the first error.
+			r, _, _, _ = newReader(tt)
+			var recs [][]string
+			var recsErr error
+			for rec, err := range r.Records() {
+				if err != nil {
+					recsErr = err
+					continue
+				}
+				recs = append(recs, slices.Clone(rec))
+			}
+			if wantErr := firstError(tt.Errors, positions, errPositions); !reflect.DeepEqual(recsErr, wantErr) {
+				t.Fatalf("Records() error:\ngot  %v\nwant %v", recsErr, wantErr)
+			} else if wantErr == nil && !slices.EqualFunc(recs, tt.Output, slices.Equal) {
+				t.Fatalf("Records() output:\ngot  %q\nwant %q", recs, tt.Output)
+			}
 		})
 	}
 }
 
+func TestRecordsReuse(t *testing.T) {
+	r := NewReader(strings.NewReader("a,b\nc,d\ne,f\n"))
+	var first []string
+	n := 0
+	for rec, err := range r.Records() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			first = rec
+		} else if &rec[0] != &first[0] {
+			t.Errorf("record %d does not reuse the backing array", n)
+		}
+		if n++; n == 2 {
+			break
+		}
+	}
+	// Breaking out of the loop leaves the rest of the input.
+	rec, err := r.Read()
+	if err != nil || !slices.Equal(rec, []string{"e", "f"}) {
+		t.Errorf("Read after Records = %q, %v; want [e f], nil", rec, err)
+	}
+}
+
 // firstError returns the first non-nil error in errs,
 // with the position adjusted according to the error's
 // index inside positions.
@@ -24,17 +24,56 @@ import (
 // If [Writer.UseCRLF] is true,
 // the Writer ends each output line with \r\n instead of \n.
 //
+// [Writer.Quote] selects which fields are enclosed in quotes, and
+// [Writer.Null] is the text an [Encoder] writes for null values.
+//
 // The writes of individual records are buffered.
 // After all data has been written, the client should call the
 // [Writer.Flush] method to guarantee all data has been forwarded to
 // the underlying [io.Writer].  Any errors that occurred should
 // be checked by calling the [Writer.Error] method.
 type Writer struct {
-	Comma   rune // Field delimiter (set to ',' by NewWriter)
-	UseCRLF bool // True to use \r\n as the line terminator
+	Comma   rune      // Field delimiter (set to ',' by NewWriter)
+	UseCRLF bool      // True to use \r\n as the line terminator
+	Quote   QuoteMode // Which fields to enclose in quotes
+	Null    string    // Text written for null values by an Encoder
 	w       *bufio.Writer
 }
 
+// A QuoteMode specifies which fields a [Writer] encloses in quotes.
+//
+// Null values written by an [Encoder] are quoted only if the text of
+// [Writer.Null] requires it, whatever the mode, so that a reader such
+// as Postgres COPY can tell them apart from empty strings.
+type QuoteMode int
+
+const (
+	// QuoteMinimal quotes only fields that require it: fields that
+	// contain the delimiter, a quote or a newline, fields that begin
+	// with white space, and the Postgres end-of-data marker `\.`.
+	// Empty fields are not quoted.
+	QuoteMinimal QuoteMode = iota
+
+	// QuoteAll quotes every field, including empty ones.
+	QuoteAll
+
+	// QuoteStrings quotes fields that hold text and uses minimal
+	// quoting for the others. All fields passed to [Writer.Write] are
+	// text; an [Encoder] treats strings and values implementing
+	// [encoding.TextMarshaler] as text, but not numbers or booleans.
+	QuoteStrings
+)
+
+// A fieldKind describes the Go value a field was formatted from,
+// which the Quote modes other than QuoteMinimal depend on.
+type fieldKind uint8
+
+const (
+	textField fieldKind = iota
+	otherField
+	nullField
+)
+
 // NewWriter returns a new Writer that writes to w.
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{
@@ -48,6 +87,12 @@ func NewWriter(w io.Writer) *Writer {
 // Writes are buffered, so [Writer.Flush] must eventually be called to ensure
 // that the record is written to the underlying [io.Writer].
 func (w *Writer) Write(record []string) error {
+	return w.write(record, nil)
+}
+
+// write writes record. If kinds is not nil, kinds[i] describes record[i];
+// otherwise all fields are text.
+func (w *Writer) write(record []string, kinds []fieldKind) error {
 	if !validDelim(w.Comma) {
 		return errInvalidDelim
 	}
@@ -59,9 +104,14 @@ func (w *Writer) Write(record []string) error {
 			}
 		}
 
+		kind := textField
+		if kinds != nil {
+			kind = kinds[n]
+		}
+
 		// If we don't have to have a quoted field then just
 		// write out the field and continue to the next field.
-		if !w.fieldNeedsQuotes(field) {
+		if !w.shouldQuote(field, kind) {
 			if _, err := w.w.WriteString(field); err != nil {
 				return err
 			}
@@ -145,6 +195,19 @@ func (w *Writer) WriteAll(records [][]string) error {
 	return w.w.Flush()
 }
 
+// shouldQuote reports whether field, formatted from a value of the
+// given kind, is enclosed in quotes under w.Quote.
+func (w *Writer) shouldQuote(field string, kind fieldKind) bool {
+	switch {
+	case kind == nullField:
+	case w.Quote == QuoteAll:
+		return true
+	case w.Quote == QuoteStrings && kind == textField:
+		return true
+	}
+	return w.fieldNeedsQuotes(field)
+}
+
 // fieldNeedsQuotes reports whether our field must be enclosed in quotes.
 // Fields with a Comma, fields with a quote or newline, and
 // fields which start with a space must be enclosed in quotes.
@@ -17,6 +17,7 @@ var writeTests = []struct {
 	Error   error
 	UseCRLF bool
 	Comma   rune
+	Quote   QuoteMode
 }{
 	{Input: [][]string{{"abc"}}, Output: "abc\n"},
 	{Input: [][]string{{"abc"}}, Output: "abc\r\n", UseCRLF: true},
@@ -47,6 +48,9 @@ var writeTests = []struct {
 	{Input: [][]string{{"a", "a", ""}}, Output: "a|a|\n", Comma: '|'},
 	{Input: [][]string{{",", ",", ""}}, Output: ",|,|\n", Comma: '|'},
 	{Input: [][]string{{"foo"}}, Comma: '"', Error: errInvalidDelim},
+	{Input: [][]string{{"a", "", "b c"}}, Output: `"a","","b c"` + "\n", Quote: QuoteAll},
+	{Input: [][]string{{`a"b`, "1"}}, Output: `"a""b","1"` + "\n", Quote: QuoteAll},
+	{Input: [][]string{{"a", ""}}, Output: `"a",""` + "\n", Quote: QuoteStrings},
 }
 
 func TestWrite(t *testing.T) {
@@ -54,6 +58,7 @@ func TestWrite(t *testing.T) {
 		b := &strings.Builder{}
 		f := NewWriter(b)
 		f.UseCRLF = tt.UseCRLF
+		f.Quote = tt.Quote
 		if tt.Comma != 0 {
 			f.Comma = tt.Comma
 		}
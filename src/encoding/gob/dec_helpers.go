@@ -58,7 +58,7 @@ func decBoolArray(state *decoderState, v reflect.Value, length int, ovfl error)
 }
 
 func decBoolSlice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]bool)
+	slice, ok := sliceOf[bool](v)
 	if !ok {
 		// It is kind bool but not type bool. TODO: We can handle this unsafely.
 		return false
@@ -85,7 +85,7 @@ func decComplex64Array(state *decoderState, v reflect.Value, length int, ovfl er
 }
 
 func decComplex64Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]complex64)
+	slice, ok := sliceOf[complex64](v)
 	if !ok {
 		// It is kind complex64 but not type complex64. TODO: We can handle this unsafely.
 		return false
@@ -114,7 +114,7 @@ func decComplex128Array(state *decoderState, v reflect.Value, length int, ovfl e
 }
 
 func decComplex128Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]complex128)
+	slice, ok := sliceOf[complex128](v)
 	if !ok {
 		// It is kind complex128 but not type complex128. TODO: We can handle this unsafely.
 		return false
@@ -143,7 +143,7 @@ func decFloat32Array(state *decoderState, v reflect.Value, length int, ovfl erro
 }
 
 func decFloat32Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]float32)
+	slice, ok := sliceOf[float32](v)
 	if !ok {
 		// It is kind float32 but not type float32. TODO: We can handle this unsafely.
 		return false
@@ -170,7 +170,7 @@ func decFloat64Array(state *decoderState, v reflect.Value, length int, ovfl erro
 }
 
 func decFloat64Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]float64)
+	slice, ok := sliceOf[float64](v)
 	if !ok {
 		// It is kind float64 but not type float64. TODO: We can handle this unsafely.
 		return false
@@ -197,7 +197,7 @@ func decIntArray(state *decoderState, v reflect.Value, length int, ovfl error) b
 }
 
 func decIntSlice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]int)
+	slice, ok := sliceOf[int](v)
 	if !ok {
 		// It is kind int but not type int. TODO: We can handle this unsafely.
 		return false
@@ -229,7 +229,7 @@ func decInt16Array(state *decoderState, v reflect.Value, length int, ovfl error)
 }
 
 func decInt16Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]int16)
+	slice, ok := sliceOf[int16](v)
 	if !ok {
 		// It is kind int16 but not type int16. TODO: We can handle this unsafely.
 		return false
@@ -260,7 +260,7 @@ func decInt32Array(state *decoderState, v reflect.Value, length int, ovfl error)
 }
 
 func decInt32Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]int32)
+	slice, ok := sliceOf[int32](v)
 	if !ok {
 		// It is kind int32 but not type int32. TODO: We can handle this unsafely.
 		return false
@@ -291,7 +291,7 @@ func decInt64Array(state *decoderState, v reflect.Value, length int, ovfl error)
 }
 
 func decInt64Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]int64)
+	slice, ok := sliceOf[int64](v)
 	if !ok {
 		// It is kind int64 but not type int64. TODO: We can handle this unsafely.
 		return false
@@ -318,7 +318,7 @@ func decInt8Array(state *decoderState, v reflect.Value, length int, ovfl error)
 }
 
 func decInt8Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]int8)
+	slice, ok := sliceOf[int8](v)
 	if !ok {
 		// It is kind int8 but not type int8. TODO: We can handle this unsafely.
 		return false
@@ -349,7 +349,7 @@ func decStringArray(state *decoderState, v reflect.Value, length int, ovfl error
 }
 
 func decStringSlice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]string)
+	slice, ok := sliceOf[string](v)
 	if !ok {
 		// It is kind string but not type string. TODO: We can handle this unsafely.
 		return false
@@ -390,7 +390,7 @@ func decUintArray(state *decoderState, v reflect.Value, length int, ovfl error)
 }
 
 func decUintSlice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]uint)
+	slice, ok := sliceOf[uint](v)
 	if !ok {
 		// It is kind uint but not type uint. TODO: We can handle this unsafely.
 		return false
@@ -421,7 +421,7 @@ func decUint16Array(state *decoderState, v reflect.Value, length int, ovfl error
 }
 
 func decUint16Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]uint16)
+	slice, ok := sliceOf[uint16](v)
 	if !ok {
 		// It is kind uint16 but not type uint16. TODO: We can handle this unsafely.
 		return false
@@ -452,7 +452,7 @@ func decUint32Array(state *decoderState, v reflect.Value, length int, ovfl error
 }
 
 func decUint32Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]uint32)
+	slice, ok := sliceOf[uint32](v)
 	if !ok {
 		// It is kind uint32 but not type uint32. TODO: We can handle this unsafely.
 		return false
@@ -483,7 +483,7 @@ func decUint64Array(state *decoderState, v reflect.Value, length int, ovfl error
 }
 
 func decUint64Slice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]uint64)
+	slice, ok := sliceOf[uint64](v)
 	if !ok {
 		// It is kind uint64 but not type uint64. TODO: We can handle this unsafely.
 		return false
@@ -510,7 +510,7 @@ func decUintptrArray(state *decoderState, v reflect.Value, length int, ovfl erro
 }
 
 func decUintptrSlice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]uintptr)
+	slice, ok := sliceOf[uintptr](v)
 	if !ok {
 		// It is kind uintptr but not type uintptr. TODO: We can handle this unsafely.
 		return false
@@ -231,7 +231,7 @@ func dec%[2]sArray(state *decoderState, v reflect.Value, length int, ovfl error)
 
 const sliceHelper = `
 func dec%[2]sSlice(state *decoderState, v reflect.Value, length int, ovfl error) bool {
-	slice, ok := v.Interface().([]%[1]s)
+	slice, ok := sliceOf[%[1]s](v)
 	if !ok {
 		// It is kind %[1]s but not type %[1]s. TODO: We can handle this unsafely.
 		return false
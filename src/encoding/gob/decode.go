@@ -14,6 +14,9 @@ import (
 	"math"
 	"math/bits"
 	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -369,41 +372,18 @@ func decUint8Slice(i *decInstr, state *decoderState, value reflect.Value) {
 	if !ok {
 		errorf("bad %s slice length: %d", value.Type(), n)
 	}
+	// getLength has checked n against the size of the input,
+	// so a new slice can be allocated in one step.
+	var b []byte
 	if value.Cap() < n {
-		safe := saferio.SliceCap[byte](uint64(n))
-		if safe < 0 {
-			errorf("%s slice too big: %d elements", value.Type(), n)
-		}
-		value.Set(reflect.MakeSlice(value.Type(), safe, safe))
-		ln := safe
-		i := 0
-		for i < n {
-			if i >= ln {
-				// We didn't allocate the entire slice,
-				// due to using saferio.SliceCap.
-				// Grow the slice for one more element.
-				// The slice is full, so this should
-				// bump up the capacity.
-				value.Grow(1)
-			}
-			// Copy into s up to the capacity or n,
-			// whichever is less.
-			ln = value.Cap()
-			if ln > n {
-				ln = n
-			}
-			value.SetLen(ln)
-			sub := value.Slice(i, ln)
-			if _, err := state.b.Read(sub.Bytes()); err != nil {
-				errorf("error decoding []byte at %d: %s", i, err)
-			}
-			i = ln
-		}
+		b = make([]byte, n)
+		value.SetBytes(b)
 	} else {
 		value.SetLen(n)
-		if _, err := state.b.Read(value.Bytes()); err != nil {
-			errorf("error decoding []byte: %s", err)
-		}
+		b = value.Bytes()
+	}
+	if _, err := state.b.Read(b); err != nil {
+		errorf("error decoding []byte: %s", err)
 	}
 }
 
@@ -444,7 +424,8 @@ func ignoreUint8Array(i *decInstr, state *decoderState, value reflect.Value) {
 // decoder. It is executed with random access according to field number.
 type decEngine struct {
 	instr    []decInstr
-	numInstr int // the number of active instructions
+	numInstr int     // the number of active instructions
+	unset    [][]int // field indices of local fields no instruction stores into
 }
 
 // decodeSingle decodes a top-level value that is not a struct and stores it in value.
@@ -467,8 +448,14 @@ func (dec *Decoder) decodeSingle(engine *decEngine, value reflect.Value) {
 // This state cannot arise for decodeSingle, which is called directly
 // from the user's value, not from the innards of an engine.
 func (dec *Decoder) decodeStruct(engine *decEngine, value reflect.Value) {
+	dec.enter()
 	state := dec.newDecoderState(&dec.buf)
 	defer dec.freeDecoderState(state)
+	if dec.resetValues {
+		for _, index := range engine.unset {
+			zeroField(value, index)
+		}
+	}
 	state.fieldnum = -1
 	for state.b.Len() > 0 {
 		delta := int(state.decodeUint())
@@ -482,6 +469,9 @@ func (dec *Decoder) decodeStruct(engine *decEngine, value reflect.Value) {
 			error_(errRange)
 		}
 		fieldnum := state.fieldnum + delta
+		if dec.resetValues {
+			dec.zeroSkipped(engine, value, state.fieldnum+1, fieldnum)
+		}
 		instr := &engine.instr[fieldnum]
 		var field reflect.Value
 		if instr.index != nil {
@@ -494,12 +484,52 @@ func (dec *Decoder) decodeStruct(engine *decEngine, value reflect.Value) {
 		instr.op(instr, state, field)
 		state.fieldnum = fieldnum
 	}
+	if dec.resetValues {
+		dec.zeroSkipped(engine, value, state.fieldnum+1, len(engine.instr))
+	}
+	dec.leave()
+}
+
+// zeroSkipped sets to zero the local fields for the wire fields numbered from
+// lo up to but not including hi, which the encoder did not send.
+func (dec *Decoder) zeroSkipped(engine *decEngine, value reflect.Value, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		if index := engine.instr[i].index; index != nil {
+			zeroField(value, index)
+		}
+	}
+}
+
+// zeroField sets to zero the field of the struct value with the given index.
+// A field promoted through a nil embedded pointer is already unset.
+func zeroField(value reflect.Value, index []int) {
+	if field, err := value.FieldByIndexErr(index); err == nil {
+		field.SetZero()
+	}
+}
+
+// enter records that the decoder is descending into a struct, array, slice,
+// map or interface value, and fails if that makes the values nest deeper than
+// the limit set by [Decoder.SetMaxDepth]. Each call is paired with a call to
+// leave once the value is decoded; if decoding fails, DecodeValue resets the
+// depth for the next value.
+func (dec *Decoder) enter() {
+	dec.depth++
+	if dec.depth > dec.maxDepth {
+		errorf("value nesting depth exceeds limit of %d", dec.maxDepth)
+	}
+}
+
+// leave records that the decoder has finished decoding a value passed to enter.
+func (dec *Decoder) leave() {
+	dec.depth--
 }
 
 var noValue reflect.Value
 
 // ignoreStruct discards the data for a struct with no destination.
 func (dec *Decoder) ignoreStruct(engine *decEngine) {
+	dec.enter()
 	state := dec.newDecoderState(&dec.buf)
 	defer dec.freeDecoderState(state)
 	state.fieldnum = -1
@@ -519,6 +549,7 @@ func (dec *Decoder) ignoreStruct(engine *decEngine) {
 		instr.op(instr, state, noValue)
 		state.fieldnum = fieldnum
 	}
+	dec.leave()
 }
 
 // ignoreSingle discards the data for a top-level non-struct value with no
@@ -537,6 +568,8 @@ func (dec *Decoder) ignoreSingle(engine *decEngine) {
 
 // decodeArrayHelper does the work for decoding arrays and slices.
 func (dec *Decoder) decodeArrayHelper(state *decoderState, value reflect.Value, elemOp decOp, length int, ovfl error, helper decHelper) {
+	dec.enter()
+	defer dec.leave()
 	if helper != nil && helper(state, value, length, ovfl) {
 		return
 	}
@@ -592,9 +625,13 @@ func decodeIntoValue(state *decoderState, op decOp, isPtr bool, value reflect.Va
 // Because the internals of maps are not visible to us, we must
 // use reflection rather than pointer magic.
 func (dec *Decoder) decodeMap(mtyp reflect.Type, state *decoderState, value reflect.Value, keyOp, elemOp decOp, ovfl error) {
+	dec.enter()
+	defer dec.leave()
 	n := int(state.decodeUint())
 	if value.IsNil() {
 		value.Set(reflect.MakeMapWithSize(mtyp, n))
+	} else if dec.resetValues {
+		value.Clear()
 	}
 	keyIsPtr := mtyp.Key().Kind() == reflect.Pointer
 	elemIsPtr := mtyp.Elem().Kind() == reflect.Pointer
@@ -613,6 +650,8 @@ func (dec *Decoder) decodeMap(mtyp reflect.Type, state *decoderState, value refl
 
 // ignoreArrayHelper does the work for discarding arrays and slices.
 func (dec *Decoder) ignoreArrayHelper(state *decoderState, elemOp decOp, length int) {
+	dec.enter()
+	defer dec.leave()
 	instr := &decInstr{elemOp, 0, nil, errors.New("no error")}
 	for i := 0; i < length; i++ {
 		if state.b.Len() == 0 {
@@ -632,6 +671,8 @@ func (dec *Decoder) ignoreArray(state *decoderState, elemOp decOp, length int) {
 
 // ignoreMap discards the data for a map value with no destination.
 func (dec *Decoder) ignoreMap(state *decoderState, keyOp, elemOp decOp) {
+	dec.enter()
+	defer dec.leave()
 	n := int(state.decodeUint())
 	keyInstr := &decInstr{keyOp, 0, nil, errors.New("no error")}
 	elemInstr := &decInstr{elemOp, 0, nil, errors.New("no error")}
@@ -676,6 +717,8 @@ func (dec *Decoder) ignoreSlice(state *decoderState, elemOp decOp) {
 // Interfaces are encoded as the name of a concrete type followed by a value.
 // If the name is empty, the value is nil and no value is sent.
 func (dec *Decoder) decodeInterface(ityp reflect.Type, state *decoderState, value reflect.Value) {
+	dec.enter()
+	defer dec.leave()
 	// Read the name of the concrete type.
 	nr := state.decodeUint()
 	if nr > 1<<31 { // zero is permissible for anonymous types
@@ -768,6 +811,10 @@ func (dec *Decoder) decodeGobDecoder(ut *userTypeInfo, state *decoderState, valu
 	// We know it's one of these.
 	switch ut.externalDec {
 	case xGob:
+		// Unlike the encoding interfaces, GobDecoder does not require
+		// that the method copy the data it retains, so the Decoder
+		// must not reuse its buffer for the next message.
+		dec.bufRetained = true
 		err = value.Interface().(GobDecoder).GobDecode(b)
 	case xBinary:
 		err = value.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(b)
@@ -894,7 +941,7 @@ func (dec *Decoder) decOpFor(wireId typeId, rt reflect.Type, name string, inProg
 			}
 			op = func(i *decInstr, state *decoderState, value reflect.Value) {
 				// indirect through enginePtr to delay evaluation for recursive structs.
-				dec.decodeStruct(*enginePtr, value)
+				state.dec.decodeStruct(*enginePtr, value)
 			}
 		case reflect.Interface:
 			op = func(i *decInstr, state *decoderState, value reflect.Value) {
@@ -1149,6 +1196,7 @@ func (dec *Decoder) compileDec(remoteId typeId, ut *userTypeInfo) (engine *decEn
 	engine = new(decEngine)
 	engine.instr = make([]decInstr, len(wireStruct.Field))
 	seen := make(map[reflect.Type]*decOp)
+	set := make([]bool, srt.NumField())
 	// Loop over the fields of the wire type.
 	for fieldnum := 0; fieldnum < len(wireStruct.Field); fieldnum++ {
 		wireField := wireStruct.Field[fieldnum]
@@ -1170,6 +1218,14 @@ func (dec *Decoder) compileDec(remoteId typeId, ut *userTypeInfo) (engine *decEn
 		op := dec.decOpFor(wireField.Id, localField.Type, localField.Name, seen)
 		engine.instr[fieldnum] = decInstr{*op, fieldnum, localField.Index, ovfl}
 		engine.numInstr++
+		if len(localField.Index) == 1 {
+			set[localField.Index[0]] = true
+		}
+	}
+	for i := range set {
+		if f := srt.Field(i); !set[i] && f.IsExported() {
+			engine.unset = append(engine.unset, f.Index)
+		}
 	}
 	return
 }
@@ -1183,17 +1239,171 @@ func (dec *Decoder) getDecEnginePtr(remoteId typeId, ut *userTypeInfo) (enginePt
 		dec.decoderCache[rt] = decoderMap
 	}
 	if enginePtr, ok = decoderMap[remoteId]; !ok {
+		key := engineKey{rt: rt}
+		var share bool
+		if key.shape, share = dec.wireShape(remoteId); share {
+			if p, ok := sharedEngines.Load(key); ok {
+				enginePtr = p.(**decEngine)
+				decoderMap[remoteId] = enginePtr
+				return enginePtr, nil
+			}
+		}
 		// To handle recursive types, mark this engine as underway before compiling.
 		enginePtr = new(*decEngine)
 		decoderMap[remoteId] = enginePtr
+		dec.compiling++
 		*enginePtr, err = dec.compileDec(remoteId, ut)
+		dec.compiling--
 		if err != nil {
 			delete(decoderMap, remoteId)
+		} else if share {
+			dec.compiled = append(dec.compiled, sharedEngine{key, enginePtr})
+		}
+		if dec.compiling == 0 {
+			// Engines compiled for nested types may refer to the engines
+			// of enclosing types, so share them only once all are complete.
+			if err == nil {
+				for _, e := range dec.compiled {
+					shareEngine(e.key, e.enginePtr)
+				}
+			}
+			clear(dec.compiled)
+			dec.compiled = dec.compiled[:0]
 		}
 	}
 	return
 }
 
+// Compiled engines depend only on the local type and on the remote type's
+// description, not on the Decoder that compiled them: the ops find the
+// Decoder they are running in through the decoderState. So an engine can be
+// shared by all Decoders that receive the same remote type for the same
+// local type, which spares Decoders used for a single message, such as
+// those of net/rpc, from compiling again.
+
+// sharedEngines maps an engineKey to the **decEngine compiled for it.
+var sharedEngines sync.Map
+
+// sharedEngineCount maps a local type to the number of its engines in
+// sharedEngines, as an *atomic.Int32. The count is limited by
+// maxSharedEngines, so that a peer sending many variants of a type cannot
+// grow the cache without bound.
+var sharedEngineCount sync.Map
+
+const maxSharedEngines = 4
+
+// engineKey identifies a shared engine by the local type and the description
+// of the remote type built by wireShape.
+type engineKey struct {
+	rt    reflect.Type
+	shape string
+}
+
+// sharedEngine is an engine waiting to be added to sharedEngines.
+type sharedEngine struct {
+	key       engineKey
+	enginePtr **decEngine
+}
+
+// shareEngine adds an engine to sharedEngines unless the limit for its
+// local type has been reached.
+func shareEngine(key engineKey, enginePtr **decEngine) {
+	c, _ := sharedEngineCount.LoadOrStore(key.rt, new(atomic.Int32))
+	count := c.(*atomic.Int32)
+	if count.Add(1) > maxSharedEngines {
+		count.Add(-1)
+		return
+	}
+	if _, loaded := sharedEngines.LoadOrStore(key, enginePtr); loaded {
+		count.Add(-1)
+	}
+}
+
+// maxShapeSize is the size beyond which wireShape gives up,
+// so that very large remote types are not shared.
+const maxShapeSize = 1 << 16
+
+// wireShape returns a description of the remote type and all the types it
+// refers to. Unlike the type ids, which depend on the order in which the
+// encoder sent its types, the description depends only on what compileDec
+// uses from the types: their kinds, array lengths, and the names of struct
+// fields. It reports false if the description would be too large.
+func (dec *Decoder) wireShape(id typeId) (shape string, ok bool) {
+	s := &shapeBuilder{dec: dec, seen: make(map[typeId]int)}
+	if !s.add(id, 0) {
+		return "", false
+	}
+	return string(s.b), true
+}
+
+type shapeBuilder struct {
+	dec  *Decoder
+	seen map[typeId]int // the order in which each type was first visited
+	b    []byte
+}
+
+// add appends the description of the type id. Builtin types are identified
+// by their id, which is fixed. The other types are described in full on the
+// first visit and by their visiting order afterwards. Every item starts with
+// a distinct byte and numbers and strings are delimited, so that different
+// types cannot have the same description.
+func (s *shapeBuilder) add(id typeId, depth int) bool {
+	if len(s.b) > maxShapeSize || depth > maxIgnoreNestingDepth {
+		return false
+	}
+	if id < firstUserId {
+		s.b = append(s.b, 'b')
+		s.appendInt(int(id))
+		return true
+	}
+	if n, ok := s.seen[id]; ok {
+		s.b = append(s.b, '#')
+		s.appendInt(n)
+		return true
+	}
+	s.seen[id] = len(s.seen)
+	wire := s.dec.wireType[id]
+	switch {
+	case wire == nil:
+		return false
+	case wire.ArrayT != nil:
+		s.b = append(s.b, 'A')
+		s.appendInt(wire.ArrayT.Len)
+		return s.add(wire.ArrayT.Elem, depth+1)
+	case wire.SliceT != nil:
+		s.b = append(s.b, 'S')
+		return s.add(wire.SliceT.Elem, depth+1)
+	case wire.MapT != nil:
+		s.b = append(s.b, 'M')
+		return s.add(wire.MapT.Key, depth+1) && s.add(wire.MapT.Elem, depth+1)
+	case wire.StructT != nil:
+		s.b = append(s.b, 'T')
+		s.appendInt(len(wire.StructT.Field))
+		for _, f := range wire.StructT.Field {
+			s.appendInt(len(f.Name))
+			s.b = append(s.b, f.Name...)
+			if !s.add(f.Id, depth+1) {
+				return false
+			}
+		}
+		return true
+	case wire.GobEncoderT != nil:
+		s.b = append(s.b, 'G')
+	case wire.BinaryMarshalerT != nil:
+		s.b = append(s.b, 'B')
+	case wire.TextMarshalerT != nil:
+		s.b = append(s.b, 'X')
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *shapeBuilder) appendInt(n int) {
+	s.b = strconv.AppendInt(s.b, int64(n), 10)
+	s.b = append(s.b, ';')
+}
+
 // emptyStruct is the type we compile into when ignoring a struct value.
 type emptyStruct struct{}
 
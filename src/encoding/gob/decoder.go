@@ -18,23 +18,35 @@ import (
 // without overflow.
 const tooBig = (1 << 30) << (^uint(0) >> 62)
 
+// maxNestingDepth is the default limit on the nesting depth of decoded values.
+const maxNestingDepth = 10000
+
 // A Decoder manages the receipt of type and data information read from the
 // remote side of a connection.  It is safe for concurrent use by multiple
 // goroutines.
 //
-// The Decoder does only basic sanity checking on decoded input sizes,
-// and its limits are not configurable. Take caution when decoding gob data
-// from untrusted sources.
+// The Decoder checks the sizes of the data it decodes against the input, and
+// limits the size of each message and the nesting depth of values, as set by
+// [Decoder.SetMaxMessageSize] and [Decoder.SetMaxDepth]. The default limits
+// are generous; take caution when decoding gob data from untrusted sources
+// and lower them as the application allows.
 type Decoder struct {
-	mutex        sync.Mutex                              // each item must be received atomically
-	r            io.Reader                               // source of the data
-	buf          decBuffer                               // buffer for more efficient i/o from r
-	wireType     map[typeId]*wireType                    // map from remote ID to local description
-	decoderCache map[reflect.Type]map[typeId]**decEngine // cache of compiled engines
-	ignorerCache map[typeId]**decEngine                  // ditto for ignored objects
-	freeList     *decoderState                           // list of free decoderStates; avoids reallocation
-	countBuf     []byte                                  // used for decoding integers while parsing messages
-	err          error
+	mutex          sync.Mutex                              // each item must be received atomically
+	r              io.Reader                               // source of the data
+	buf            decBuffer                               // buffer for more efficient i/o from r
+	bufRetained    bool                                    // whether a GobDecoder may have kept part of buf
+	wireType       map[typeId]*wireType                    // map from remote ID to local description
+	decoderCache   map[reflect.Type]map[typeId]**decEngine // cache of compiled engines
+	ignorerCache   map[typeId]**decEngine                  // ditto for ignored objects
+	freeList       *decoderState                           // list of free decoderStates; avoids reallocation
+	countBuf       []byte                                  // used for decoding integers while parsing messages
+	maxMessageSize int                                     // if positive, the limit on the size of a message
+	maxDepth       int                                     // limit on the nesting depth of values
+	depth          int                                     // nesting depth of the value being decoded
+	resetValues    bool                                    // reset values before decoding into them
+	compiling      int                                     // nesting depth of engine compilations
+	compiled       []sharedEngine                          // engines to share once compilation completes
+	err            error
 }
 
 // NewDecoder returns a new decoder that reads from the [io.Reader].
@@ -51,10 +63,51 @@ func NewDecoder(r io.Reader) *Decoder {
 	dec.decoderCache = make(map[reflect.Type]map[typeId]**decEngine)
 	dec.ignorerCache = make(map[typeId]**decEngine)
 	dec.countBuf = make([]byte, 9) // counts may be uint64s (unlikely!), require 9 bytes
+	dec.maxDepth = maxNestingDepth
 
 	return dec
 }
 
+// SetMaxMessageSize sets the maximum size in bytes of the messages the
+// Decoder reads. A stream holds a message for each value and each type
+// definition sent by the [Encoder], so n bounds the memory needed to decode
+// one value. Decoding a larger message fails. If n <= 0, the limit is reset
+// to the default, which is 1GB on 32-bit systems and 8GB on 64-bit systems.
+func (dec *Decoder) SetMaxMessageSize(n int) {
+	dec.mutex.Lock()
+	defer dec.mutex.Unlock()
+	dec.maxMessageSize = max(n, 0)
+}
+
+// SetMaxDepth sets the maximum nesting depth of the values the Decoder
+// decodes. Each struct, array, slice, map and interface value adds one level
+// to the values it contains. Decoding a value that nests deeper fails.
+// If n <= 0, the limit is reset to the default of 10000.
+func (dec *Decoder) SetMaxDepth(n int) {
+	dec.mutex.Lock()
+	defer dec.mutex.Unlock()
+	if n <= 0 {
+		n = maxNestingDepth
+	}
+	dec.maxDepth = n
+}
+
+// ResetValues causes later calls to Decode and DecodeValue to reset the value
+// they decode into, so that one value can be reused for a sequence of values
+// without any of them leaking into the next. By default, the Decoder stores
+// only the data it receives: as the [Encoder] does not send fields holding
+// zero values, nor fields unknown to the sender, a reused struct keeps the
+// previous values of such fields, and a reused map keeps its elements.
+//
+// After ResetValues, exported struct fields that are not received are set to
+// zero and maps are cleared before their elements are stored. The memory of
+// the slices, maps and pointed-to values that are received is still reused.
+func (dec *Decoder) ResetValues() {
+	dec.mutex.Lock()
+	defer dec.mutex.Unlock()
+	dec.resetValues = true
+}
+
 // recvType loads the definition of a type.
 func (dec *Decoder) recvType(id typeId) {
 	// Have we already seen this type? That's an error
@@ -73,7 +126,10 @@ func (dec *Decoder) recvType(id typeId) {
 	dec.wireType[id] = wire
 }
 
-var errBadCount = errors.New("invalid message length")
+var (
+	errBadCount = errors.New("invalid message length")
+	errTooLarge = errors.New("gob: message exceeds maximum size")
+)
 
 // recvMessage reads the next count-delimited item from the input. It is the converse
 // of Encoder.writeMessage. It returns false on EOF or other error reading the message.
@@ -88,6 +144,10 @@ func (dec *Decoder) recvMessage() bool {
 		dec.err = errBadCount
 		return false
 	}
+	if dec.maxMessageSize > 0 && nbytes > uint64(dec.maxMessageSize) {
+		dec.err = errTooLarge
+		return false
+	}
 	dec.readMessage(int(nbytes))
 	return dec.err == nil
 }
@@ -98,9 +158,17 @@ func (dec *Decoder) readMessage(nbytes int) {
 		// The buffer should always be empty now.
 		panic("non-empty decoder buffer")
 	}
-	// Read the data
+	// Read the data, reusing the buffer from the previous message if it is
+	// large enough. Decoded values refer to the buffer only if it was passed
+	// to a GobDecoder, which may keep it, so then it is not reused.
 	var buf []byte
-	buf, dec.err = saferio.ReadData(dec.r, uint64(nbytes))
+	if nbytes <= cap(dec.buf.data) && !dec.bufRetained {
+		buf = dec.buf.data[:nbytes]
+		_, dec.err = io.ReadFull(dec.r, buf)
+	} else {
+		buf, dec.err = saferio.ReadData(dec.r, uint64(nbytes))
+		dec.bufRetained = false
+	}
 	dec.buf.SetBytes(buf)
 	if dec.err == io.EOF {
 		dec.err = io.ErrUnexpectedEOF
@@ -224,6 +292,7 @@ func (dec *Decoder) DecodeValue(v reflect.Value) error {
 
 	dec.buf.Reset() // In case data lingers from previous invocation.
 	dec.err = nil
+	dec.depth = 0
 	id := dec.decodeTypeSequence(false)
 	if dec.err == nil {
 		dec.decodeValue(id, v)
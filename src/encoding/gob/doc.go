@@ -281,9 +281,11 @@ https://blog.golang.org/gobs-of-data
 
 This package is not designed to be hardened against adversarial inputs, and is
 outside the scope of https://go.dev/security/policy. In particular, the [Decoder]
-does only basic sanity checking on decoded input sizes, and its limits are not
-configurable. Care should be taken when decoding gob data from untrusted
-sources, which may consume significant resources.
+does only basic sanity checking on decoded input sizes, and its default limits
+on the size of messages and the nesting depth of values are generous. Care
+should be taken when decoding gob data from untrusted sources, which may
+consume significant resources; [Decoder.SetMaxMessageSize] and
+[Decoder.SetMaxDepth] lower the limits.
 */
 package gob
 
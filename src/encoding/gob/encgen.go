@@ -208,7 +208,7 @@ func enc%[2]sArray(state *encoderState, v reflect.Value) bool {
 
 const sliceHelper = `
 func enc%[2]sSlice(state *encoderState, v reflect.Value) bool {
-	slice, ok := v.Interface().([]%[1]s)
+	slice, ok := sliceOf[%[1]s](v)
 	if !ok {
 		// It is kind %[1]s but not type %[1]s. TODO: We can handle this unsafely.
 		return false
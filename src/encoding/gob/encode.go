@@ -19,6 +19,22 @@ const uint64Size = 8
 
 type encHelper func(state *encoderState, v reflect.Value) bool
 
+// sliceOf returns the slice held in v, which must be of type []E for ok
+// to be true. Unlike v.Interface, it does not allocate when v is addressable,
+// which is the common case for struct fields and slice elements.
+// It is used by the generated helpers in enc_helpers.go and dec_helpers.go.
+func sliceOf[E any](v reflect.Value) (s []E, ok bool) {
+	if v.CanAddr() {
+		p, ok := v.Addr().Interface().(*[]E)
+		if !ok {
+			return nil, false
+		}
+		return *p, true
+	}
+	s, ok = v.Interface().([]E)
+	return s, ok
+}
+
 // encoderState is the global execution state of an instance of the encoder.
 // Field numbers are delta encoded and always increase. The field
 // number is initialized to -1 so 0 comes out as delta(1). A delta of
@@ -14,6 +14,8 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -1279,3 +1281,230 @@ func TestDecoderOverflow(t *testing.T) {
 		t.Fatalf("expected an error")
 	}
 }
+
+type shareT struct {
+	A int
+	B string
+	C *shareT
+	M map[string]shareU
+}
+
+type shareU struct {
+	X []int
+}
+
+func TestDecoderSharesEngines(t *testing.T) {
+	v := shareT{A: 1, B: "b", C: &shareT{A: 2}, M: map[string]shareU{"u": {X: []int{3}}}}
+	var engines []**decEngine
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		dec := NewDecoder(&buf)
+		if i == 1 {
+			// Send shareU first so that the type ids differ from the first stream.
+			if err := enc.Encode(shareU{X: []int{1}}); err != nil {
+				t.Fatal(err)
+			}
+			var u shareU
+			if err := dec.Decode(&u); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+		var got shareT
+		if err := dec.Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, v) {
+			t.Fatalf("stream %d: got %+v; want %+v", i, got, v)
+		}
+		for _, e := range dec.decoderCache[reflect.TypeFor[*shareT]()] {
+			engines = append(engines, e)
+		}
+	}
+	if len(engines) != 2 || engines[0] != engines[1] {
+		t.Errorf("Decoders did not share the engine for %T", v)
+	}
+}
+
+type shareLocal struct {
+	A, B, C int
+}
+
+func TestDecoderSharesEnginesPerRemoteType(t *testing.T) {
+	// Each remote type needs its own engine, even if the local type is the same.
+	remotes := []any{
+		struct{ A, B, C int }{1, 2, 3},
+		struct{ C, B, A int }{3, 2, 1},
+		struct{ B, A int }{2, 1},
+		struct{ A, C, D int }{1, 3, 4},
+		struct{ C, A int }{3, 1},
+		struct{ A, B, C, D int }{1, 2, 3, 4},
+	}
+	for i, r := range remotes {
+		for range 2 {
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf).Encode(r); err != nil {
+				t.Fatal(err)
+			}
+			var got shareLocal
+			if err := NewDecoder(&buf).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			var want shareLocal
+			rv, wv := reflect.ValueOf(r), reflect.ValueOf(&want).Elem()
+			for _, name := range []string{"A", "B", "C"} {
+				if f := rv.FieldByName(name); f.IsValid() {
+					wv.FieldByName(name).Set(f)
+				}
+			}
+			if got != want {
+				t.Errorf("%d: decoding %+v: got %+v; want %+v", i, r, got, want)
+			}
+		}
+	}
+	c, _ := sharedEngineCount.Load(reflect.TypeFor[*shareLocal]())
+	if n := c.(*atomic.Int32).Load(); n != maxSharedEngines {
+		t.Errorf("%d shared engines; want %d", n, maxSharedEngines)
+	}
+}
+
+type shareConcurrent struct {
+	A []string
+	M map[int]*shareConcurrent
+}
+
+func TestDecoderSharesEnginesConcurrently(t *testing.T) {
+	v := shareConcurrent{A: []string{"a"}, M: map[int]*shareConcurrent{1: {A: []string{"b"}}}}
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				var got shareConcurrent
+				if err := NewDecoder(bytes.NewReader(data)).Decode(&got); err != nil {
+					t.Error(err)
+					return
+				}
+				if !reflect.DeepEqual(got, v) {
+					t.Errorf("got %+v; want %+v", got, v)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDecoderMaxMessageSize(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(strings.Repeat("x", 100)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	var s string
+	dec := NewDecoder(bytes.NewReader(data))
+	dec.SetMaxMessageSize(50)
+	if err := dec.Decode(&s); err != errTooLarge {
+		t.Fatalf("Decode with limit 50: got error %v; want %v", err, errTooLarge)
+	}
+	dec = NewDecoder(bytes.NewReader(data))
+	dec.SetMaxMessageSize(200)
+	if err := dec.Decode(&s); err != nil || len(s) != 100 {
+		t.Fatalf("Decode with limit 200: got %d bytes, error %v", len(s), err)
+	}
+}
+
+type depthList struct {
+	Next *depthList
+}
+
+type depthSlice []depthSlice
+
+func TestDecoderMaxDepth(t *testing.T) {
+	var list *depthList
+	for range 10 {
+		list = &depthList{Next: list}
+	}
+	slice := depthSlice{}
+	for range 9 {
+		slice = depthSlice{slice}
+	}
+	for _, v := range []any{list, slice} {
+		var buf bytes.Buffer
+		if err := NewEncoder(&buf).Encode(v); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		for _, ignore := range []bool{false, true} {
+			decode := func(depth int) error {
+				dec := NewDecoder(bytes.NewReader(data))
+				dec.SetMaxDepth(depth)
+				if ignore {
+					return dec.Decode(nil)
+				}
+				return dec.Decode(reflect.New(reflect.TypeOf(v)).Interface())
+			}
+			if err := decode(10); err != nil {
+				t.Errorf("%T, ignore=%v: decoding at depth limit: %v", v, ignore, err)
+			}
+			if err := decode(9); err == nil || !strings.Contains(err.Error(), "nesting depth") {
+				t.Errorf("%T, ignore=%v: decoding beyond depth limit: got error %v", v, ignore, err)
+			}
+		}
+	}
+}
+
+type resetT struct {
+	A int
+	B string
+	P *resetT
+	M map[string]int
+	S []int
+	X int
+}
+
+type resetRemote struct {
+	A int
+	B string
+	P *resetT
+	M map[string]int
+	S []int
+}
+
+func TestDecoderResetValues(t *testing.T) {
+	first := resetRemote{A: 1, B: "x", P: &resetT{A: 2, B: "y"}, M: map[string]int{"a": 1, "b": 2}, S: []int{1, 2, 3}}
+	second := resetRemote{B: "z", P: &resetT{S: []int{5}}, M: map[string]int{"c": 3}, S: []int{4}}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, v := range []resetRemote{first, second} {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dec := NewDecoder(&buf)
+	dec.ResetValues()
+	v := resetT{X: 7}
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	p, s := v.P, v.S
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	want := resetT{B: "z", P: &resetT{S: []int{5}}, M: map[string]int{"c": 3}, S: []int{4}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("got %+v; want %+v", v, want)
+	}
+	if v.P != p || &v.S[0] != &s[0] {
+		t.Errorf("memory of the first value was not reused")
+	}
+}
@@ -820,3 +820,47 @@ func TestIgnoreDepthLimit(t *testing.T) {
 		t.Errorf("Decode didn't fail with depth limit of 100: want %q, got %q", expectedErr, err)
 	}
 }
+
+// retainer is a GobDecoder that keeps the data it is given, as
+// GobDecoders are allowed to.
+type retainer struct {
+	data []byte
+}
+
+func (r retainer) GobEncode() ([]byte, error) {
+	return r.data, nil
+}
+
+func (r *retainer) GobDecode(data []byte) error {
+	r.data = data
+	return nil
+}
+
+func TestGobDecoderRetainsData(t *testing.T) {
+	type T struct {
+		R retainer
+		N int
+	}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	words := []string{"first", "later", "again"}
+	for i, w := range words {
+		if err := enc.Encode(T{retainer{[]byte(w)}, i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dec := NewDecoder(&buf)
+	got := make([]T, len(words))
+	for i := range got {
+		if err := dec.Decode(&got[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Decoding later messages must not overwrite the data kept
+	// from earlier ones.
+	for i, w := range words {
+		if string(got[i].R.data) != w || got[i].N != i {
+			t.Errorf("value %d decoded as %q, %d; want %q, %d", i, got[i].R.data, got[i].N, w, i)
+		}
+	}
+}
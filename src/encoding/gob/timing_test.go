@@ -129,8 +129,48 @@ func TestCountDecodeMallocs(t *testing.T) {
 			t.Fatal("decode:", err)
 		}
 	})
-	if allocs != 3 {
-		t.Fatalf("mallocs per decode of type Bench: %v; wanted 3\n", allocs)
+	if allocs != 2 {
+		t.Fatalf("mallocs per decode of type Bench: %v; wanted 2\n", allocs)
+	}
+}
+
+func TestCountDecodeMallocsGobDecoder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping malloc count in short mode")
+	}
+	if runtime.GOMAXPROCS(0) > 1 {
+		t.Skip("skipping; GOMAXPROCS>1")
+	}
+
+	const N = 1000
+
+	type T struct {
+		B ByteStruct
+		N int
+	}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	v := &T{ByteStruct{'a'}, 7}
+	testing.AllocsPerRun(N, func() {
+		err := enc.Encode(v)
+		if err != nil {
+			t.Fatal("encode:", err)
+		}
+	})
+
+	// The Decoder does not reuse a buffer that it has passed to a
+	// GobDecoder, so it allocates one for each message, but it does
+	// not copy the data for each GobDecoder.
+	dec := NewDecoder(&buf)
+	allocs := testing.AllocsPerRun(N, func() {
+		*v = T{}
+		err := dec.Decode(v)
+		if err != nil {
+			t.Fatal("decode:", err)
+		}
+	})
+	if allocs != 1 {
+		t.Fatalf("mallocs per decode of type T: %v; wanted 1\n", allocs)
 	}
 }
 
@@ -305,6 +345,26 @@ func BenchmarkDecodeInterfaceSlice(b *testing.B) {
 	benchmarkDecodeSlice(b, a)
 }
 
+// BenchmarkDecodeNewDecoder measures decoding a stream holding a single
+// value, as net/rpc and many servers do, where each Decoder must receive the
+// type before the value.
+func BenchmarkDecodeNewDecoder(b *testing.B) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(&Bench{7, 3.2, "now is the time", []byte("for all good men")}); err != nil {
+		b.Fatal(err)
+	}
+	bbuf := benchmarkBuf{data: buf.Bytes()}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v Bench
+		bbuf.reset()
+		if err := NewDecoder(&bbuf).Decode(&v); err != nil {
+			b.Fatal(i, err)
+		}
+	}
+}
+
 func BenchmarkDecodeMap(b *testing.B) {
 	count := 1000
 	m := make(map[int]int, count)
@@ -0,0 +1,350 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structfield
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Inner struct {
+	A int
+	B int `test:"b2"`
+	C int
+}
+
+type Other struct {
+	C int
+	D int
+}
+
+type Tagged struct {
+	E int
+}
+
+type unexported struct {
+	F int
+}
+
+type Outer struct {
+	Inner
+	*Other
+	Tagged `test:"tagged"`
+	*unexported
+	A       string // hides Inner.A
+	Skip    int    `test:"-"`
+	Dash    int    `test:"-,"`
+	private int
+	G       int `test:",omitempty"`
+}
+
+// fieldNames returns "name index" for each field.
+func fieldNames(fields []Field) []string {
+	var s []string
+	for _, f := range fields {
+		idx := make([]string, len(f.Index))
+		for i, x := range f.Index {
+			idx[i] = string(rune('0' + x))
+		}
+		s = append(s, f.Name+" "+strings.Join(idx, "."))
+	}
+	return s
+}
+
+func TestFields(t *testing.T) {
+	fields := Fields(reflect.TypeFor[Outer](), "test", nil)
+	got := fieldNames(fields)
+	want := []string{
+		"b2 0.1",   // promoted, named by its tag
+		"D 1.1",    // promoted through a pointer
+		"tagged 2", // an embedded struct with a tag name is a field
+		"F 3.0",    // promoted from an unexported embedded struct
+		"A 4",      // hides Inner.A, which is deeper
+		"- 6",      // named "-" by a tag of "-,"
+		"G 8",      // no name in the tag
+	}
+	// Inner.C and Other.C are at the same depth and untagged, so
+	// neither is a field.
+	if !slices.Equal(got, want) {
+		t.Errorf("Fields:\ngot  %q\nwant %q", got, want)
+	}
+	for _, f := range fields {
+		if f.Name == "G" && (f.Tag || f.Options != "omitempty") {
+			t.Errorf("field G: Tag = %v, Options = %q", f.Tag, f.Options)
+		}
+		if f.Name == "b2" && (!f.Tag || f.Type != reflect.TypeFor[int]()) {
+			t.Errorf("field b2: Tag = %v, Type = %v", f.Tag, f.Type)
+		}
+	}
+}
+
+type TagWins1 struct {
+	X int `test:"X"`
+}
+
+type TagWins2 struct {
+	X int
+}
+
+type Deep struct {
+	Mid
+}
+
+type Deep2 struct {
+	Mid
+}
+
+type Mid struct {
+	X int
+}
+
+func TestFieldsConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		want []string
+	}{{
+		// At the same depth, a field named by its tag wins.
+		name: "tag",
+		typ: reflect.TypeFor[struct {
+			TagWins1
+			TagWins2
+		}](),
+		want: []string{"X 0.0"},
+	}, {
+		// A shallower field wins, even over a tagged one.
+		name: "depth",
+		typ: reflect.TypeFor[struct {
+			Deep
+			TagWins1
+		}](),
+		want: []string{"X 1.0"},
+	}, {
+		// Two tagged fields at the same depth cancel out.
+		name: "both tagged",
+		typ: reflect.TypeFor[struct {
+			A int `test:"X"`
+			B int `test:"X"`
+		}](),
+		want: nil,
+	}, {
+		// The same embedded type twice at one depth cancels its fields.
+		name: "repeated type",
+		typ: reflect.TypeFor[struct {
+			Deep
+			Deep2
+		}](),
+		want: nil,
+	}, {
+		// Unless a shallower field hides them.
+		name: "repeated type hidden",
+		typ: reflect.TypeFor[struct {
+			Deep
+			Deep2
+			X string
+		}](),
+		want: []string{"X 2"},
+	}}
+	for _, tt := range tests {
+		got := fieldNames(Fields(tt.typ, "test", nil))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFieldsRename(t *testing.T) {
+	type T struct {
+		A int `test:"a,upper"`
+		B int `test:"b"`
+		C int
+	}
+	rename := func(name string, opts Options) string {
+		if opts.Contains("upper") {
+			return strings.ToUpper(name)
+		}
+		return name
+	}
+	got := fieldNames(Fields(reflect.TypeFor[T](), "test", rename))
+	if want := []string{"A 0", "b 1", "C 2"}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag, name string
+		opts      Options
+		has       []string
+		hasNot    []string
+	}{
+		{"", "", "", nil, []string{""}},
+		{"name", "name", "", nil, []string{"name"}},
+		{",omitempty", "", "omitempty", []string{"omitempty"}, []string{"omit"}},
+		{"n,omitzero,string", "n", "omitzero,string", []string{"omitzero", "string"}, []string{"zero", "omitzero,string"}},
+		{"n,,x", "n", ",x", []string{"x", ""}, []string{"n"}},
+	}
+	for _, tt := range tests {
+		name, opts := ParseTag(tt.tag)
+		if name != tt.name || opts != tt.opts {
+			t.Errorf("ParseTag(%q) = %q, %q; want %q, %q", tt.tag, name, opts, tt.name, tt.opts)
+		}
+		for _, o := range tt.has {
+			if !opts.Contains(o) {
+				t.Errorf("ParseTag(%q) options do not contain %q", tt.tag, o)
+			}
+		}
+		for _, o := range tt.hasNot {
+			if opts.Contains(o) {
+				t.Errorf("ParseTag(%q) options contain %q", tt.tag, o)
+			}
+		}
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for _, s := range []string{"a", "A_b", "x-y.z", "ünïcode", "$%&", "1"} {
+		if !ValidName(s) {
+			t.Errorf("ValidName(%q) = false", s)
+		}
+	}
+	for _, s := range []string{"", `a"b`, `a\b`, "a\x00"} {
+		if ValidName(s) {
+			t.Errorf("ValidName(%q) = true", s)
+		}
+	}
+}
+
+// valueZero is zero when it is odd, to tell IsZero from the zero value.
+type valueZero int
+
+func (v valueZero) IsZero() bool { return v%2 == 1 }
+
+type pointerZero int
+
+func (v *pointerZero) IsZero() bool { return *v%2 == 1 }
+
+type zeroer interface{ IsZero() bool }
+
+func TestIsZeroFunc(t *testing.T) {
+	if IsZeroFunc(reflect.TypeFor[int]()) != nil {
+		t.Error("IsZeroFunc(int) is not nil")
+	}
+
+	vz := valueZero(1)
+	pz := pointerZero(1)
+	var nilPZ *pointerZero
+	tests := []struct {
+		name string
+		v    reflect.Value
+		want bool
+	}{
+		{"value method", reflect.ValueOf(valueZero(1)), true},
+		{"value method nonzero", reflect.ValueOf(valueZero(0)), false},
+		{"pointer to value method", reflect.ValueOf(&vz), true},
+		{"nil pointer", reflect.ValueOf(nilPZ), true},
+		{"pointer method", reflect.ValueOf(&pz), true},
+		{"pointer method addressable", reflect.ValueOf(&pz).Elem(), true},
+		{"pointer method not addressable", reflect.ValueOf(pointerZero(3)), true},
+		{"pointer method nonzero", reflect.ValueOf(pointerZero(2)), false},
+		{"time", reflect.ValueOf(time.Time{}), true},
+		{"time nonzero", reflect.ValueOf(time.Unix(1, 0)), false},
+		{"nil interface", reflect.ValueOf(new(zeroer)).Elem(), true},
+		{"interface with nil pointer", reflect.ValueOf(&struct{ Z zeroer }{nilPZ}).Elem().Field(0), true},
+		{"interface", reflect.ValueOf(&struct{ Z zeroer }{valueZero(1)}).Elem().Field(0), true},
+		{"interface nonzero", reflect.ValueOf(&struct{ Z zeroer }{valueZero(2)}).Elem().Field(0), false},
+	}
+	for _, tt := range tests {
+		f := IsZeroFunc(tt.v.Type())
+		if f == nil {
+			t.Errorf("%s: IsZeroFunc(%v) is nil", tt.name, tt.v.Type())
+			continue
+		}
+		if got := f(tt.v); got != tt.want {
+			t.Errorf("%s: IsZero = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type Embedded struct {
+	X int
+}
+
+type hidden struct {
+	Y int
+}
+
+type Holder struct {
+	*Embedded
+	*hidden
+}
+
+func TestByIndex(t *testing.T) {
+	var h Holder
+	v := reflect.ValueOf(&h).Elem()
+	x := []int{0, 0}
+	y := []int{1, 0}
+
+	if _, ok := ByIndex(v, x); ok {
+		t.Error("ByIndex through a nil pointer succeeded")
+	}
+
+	f, err := ByIndexAlloc(v, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetInt(7)
+	if h.Embedded == nil || h.X != 7 {
+		t.Fatalf("ByIndexAlloc did not allocate the embedded pointer: %+v", h)
+	}
+	if f, ok := ByIndex(v, x); !ok || f.Int() != 7 {
+		t.Errorf("ByIndex after ByIndexAlloc = %v, %v", f, ok)
+	}
+
+	// A nil pointer to an unexported type cannot be set.
+	if _, err := ByIndexAlloc(v, y); err == nil {
+		t.Error("ByIndexAlloc through a nil pointer to an unexported type succeeded")
+	}
+	h.hidden = &hidden{Y: 3}
+	if f, err := ByIndexAlloc(v, y); err != nil || f.Int() != 3 {
+		t.Errorf("ByIndexAlloc through a set pointer = %v, %v", f, err)
+	}
+}
+
+type stopper struct{}
+
+func (*stopper) Stop() {}
+
+func TestIndirect(t *testing.T) {
+	var p **int
+	v := Indirect(reflect.ValueOf(&p).Elem(), false, func(reflect.Value) bool { return false })
+	if v.Kind() != reflect.Int || p == nil || *p == nil {
+		t.Fatalf("Indirect did not allocate through the pointers: %v", v.Kind())
+	}
+	v.SetInt(5)
+	if **p != 5 {
+		t.Errorf("setting the result did not set **p")
+	}
+
+	// decodingNull stops at the last pointer, so that it can be set to nil.
+	v = Indirect(reflect.ValueOf(&p).Elem(), true, func(reflect.Value) bool { return false })
+	if v.Type() != reflect.TypeFor[**int]() {
+		t.Errorf("Indirect with decodingNull returned a %v", v.Type())
+	}
+
+	// stop is called at pointers with methods.
+	var s *stopper
+	var stopped bool
+	v = Indirect(reflect.ValueOf(&s).Elem(), false, func(v reflect.Value) bool {
+		_, stopped = v.Interface().(*stopper)
+		return stopped
+	})
+	if !stopped || v.IsValid() || s == nil {
+		t.Errorf("Indirect did not stop at *stopper: stopped %v, %v", stopped, v)
+	}
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build !goexperiment.jsonv2
+
 // Represents JSON data structure using native Go types: booleans, floats,
 // strings, arrays, and maps.
 
@@ -10,6 +12,7 @@ package json
 import (
 	"encoding"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -46,6 +49,8 @@ import (
 // preferring an exact match but also accepting a case-insensitive match. By
 // default, object keys which don't have a corresponding struct field are
 // ignored (see [Decoder.DisallowUnknownFields] for an alternative).
+// The "required" option in a struct field's tag has no effect unless
+// decoding with [Decoder.DisallowMissingRequiredFields].
 //
 // To unmarshal JSON into an interface value,
 // Unmarshal stores one of these in the interface value:
@@ -86,6 +91,9 @@ import (
 // case, it's not guaranteed that all the remaining fields following
 // the problematic one will be unmarshaled into the target object.
 //
+// Both [SyntaxError] and [UnmarshalTypeError] report where the error
+// occurred as a byte offset and as an RFC 6901 JSON Pointer.
+//
 // The JSON null value unmarshals into an interface, map, pointer, or slice
 // by setting that Go value to nil. Because null is often used in JSON to mean
 // “not present,” unmarshaling a JSON null into any other Go type has no effect
@@ -124,11 +132,12 @@ type Unmarshaler interface {
 // An UnmarshalTypeError describes a JSON value that was
 // not appropriate for a value of a specific Go type.
 type UnmarshalTypeError struct {
-	Value  string       // description of JSON value - "bool", "array", "number -5"
-	Type   reflect.Type // type of Go value it could not be assigned to
-	Offset int64        // error occurred after reading Offset bytes
-	Struct string       // name of the struct type containing the field
-	Field  string       // the full path from root node to the field
+	Value   string       // description of JSON value - "bool", "array", "number -5"
+	Type    reflect.Type // type of Go value it could not be assigned to
+	Offset  int64        // error occurred after reading Offset bytes
+	Struct  string       // name of the struct type containing the field
+	Field   string       // the full path from root node to the field
+	Pointer string       // RFC 6901 JSON Pointer to the JSON value
 }
 
 func (e *UnmarshalTypeError) Error() string {
@@ -138,6 +147,33 @@ func (e *UnmarshalTypeError) Error() string {
 	return "json: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
 }
 
+// An UnknownFieldError describes a JSON object key that does not match
+// any field of the destination struct, as reported by a [Decoder]
+// after a call to [Decoder.DisallowUnknownFields].
+type UnknownFieldError struct {
+	Field   string // the JSON object key
+	Offset  int64  // error occurred after reading Offset bytes
+	Pointer string // RFC 6901 JSON Pointer to the object member
+}
+
+func (e *UnknownFieldError) Error() string {
+	return "json: unknown field " + strconv.Quote(e.Field)
+}
+
+// A MissingFieldError describes a JSON object that lacks a member for
+// a struct field marked with the "required" option, as reported by a
+// [Decoder] after a call to [Decoder.DisallowMissingRequiredFields].
+type MissingFieldError struct {
+	Field   string       // the JSON object key of the required field
+	Type    reflect.Type // type of the Go struct containing the field
+	Offset  int64        // error occurred after reading Offset bytes
+	Pointer string       // RFC 6901 JSON Pointer to the JSON object
+}
+
+func (e *MissingFieldError) Error() string {
+	return "json: missing required field " + strconv.Quote(e.Field) + " for Go struct of type " + e.Type.String()
+}
+
 // An UnmarshalFieldError describes a JSON object key that
 // led to an unexported (and therefore unwritable) struct field.
 //
@@ -181,7 +217,14 @@ func (d *decodeState) unmarshal(v any) error {
 	// test must be applied at the top level of the value.
 	err := d.value(rv)
 	if err != nil {
-		return d.addErrorContext(err)
+		err = d.addErrorContext(err)
+		if d.savedError == nil || !d.reportAllErrors {
+			return err
+		}
+		d.moreErrors = append(d.moreErrors, err)
+	}
+	if len(d.moreErrors) > 0 {
+		return errors.Join(append([]error{d.savedError}, d.moreErrors...)...)
 	}
 	return d.savedError
 }
@@ -208,6 +251,13 @@ type errorContext struct {
 	FieldStack []string
 }
 
+// A pathToken is a reference token in the JSON Pointer to a value.
+// It is the quoted name of an object member, or else an array index.
+type pathToken struct {
+	name  []byte
+	index int
+}
+
 // decodeState represents the state while decoding a JSON value.
 type decodeState struct {
 	data                  []byte
@@ -215,9 +265,13 @@ type decodeState struct {
 	opcode                int // last read result
 	scan                  scanner
 	errorContext          *errorContext
+	path                  []pathToken // location of the value being decoded
 	savedError            error
+	moreErrors            []error // errors after savedError if reportAllErrors
 	useNumber             bool
 	disallowUnknownFields bool
+	requireFields         bool
+	reportAllErrors       bool
 }
 
 // readIndex returns the position of the last byte read.
@@ -234,6 +288,8 @@ func (d *decodeState) init(data []byte) *decodeState {
 	d.data = data
 	d.off = 0
 	d.savedError = nil
+	d.moreErrors = nil
+	d.path = d.path[:0]
 	if d.errorContext != nil {
 		d.errorContext.Struct = nil
 		// Reuse the allocated space for the FieldStack slice.
@@ -244,13 +300,17 @@ func (d *decodeState) init(data []byte) *decodeState {
 
 // saveError saves the first err it is called with,
 // for reporting at the end of the unmarshal.
+// If d.reportAllErrors is set, later errors are saved as well.
 func (d *decodeState) saveError(err error) {
 	if d.savedError == nil {
 		d.savedError = d.addErrorContext(err)
+	} else if d.reportAllErrors {
+		d.moreErrors = append(d.moreErrors, d.addErrorContext(err))
 	}
 }
 
 // addErrorContext returns a new error enhanced with information from d.errorContext
+// and with the location of the error in the input.
 func (d *decodeState) addErrorContext(err error) error {
 	if d.errorContext != nil && (d.errorContext.Struct != nil || len(d.errorContext.FieldStack) > 0) {
 		switch err := err.(type) {
@@ -259,9 +319,32 @@ func (d *decodeState) addErrorContext(err error) error {
 			err.Field = strings.Join(d.errorContext.FieldStack, ".")
 		}
 	}
+	switch err := err.(type) {
+	case *UnmarshalTypeError:
+		err.Pointer = d.pointer()
+	case *UnknownFieldError:
+		err.Pointer = d.pointer()
+	case *MissingFieldError:
+		err.Pointer = d.pointer()
+	}
 	return err
 }
 
+// pointer returns the JSON Pointer to the value being decoded.
+func (d *decodeState) pointer() string {
+	var b []byte
+	for _, t := range d.path {
+		if t.name == nil {
+			b = append(b, '/')
+			b = strconv.AppendInt(b, int64(t.index), 10)
+			continue
+		}
+		name, _ := unquote(t.name)
+		b = appendPointerToken(b, name)
+	}
+	return string(b)
+}
+
 // skip scans to the end of what was started.
 func (d *decodeState) skip() {
 	s, data, i := &d.scan, d.data, d.off
@@ -551,6 +634,7 @@ func (d *decodeState) array(v reflect.Value) error {
 			}
 		}
 
+		d.path = append(d.path, pathToken{index: i})
 		if i < v.Len() {
 			// Decode into element.
 			if err := d.value(v.Index(i)); err != nil {
@@ -562,6 +646,7 @@ func (d *decodeState) array(v reflect.Value) error {
 				return err
 			}
 		}
+		d.path = d.path[:len(d.path)-1]
 		i++
 
 		// Next token must be , or ].
@@ -653,6 +738,7 @@ func (d *decodeState) object(v reflect.Value) error {
 	}
 
 	var mapElem reflect.Value
+	var seenRequired []*field // required fields seen if d.requireFields
 	var origErrorContext errorContext
 	if d.errorContext != nil {
 		origErrorContext = *d.errorContext
@@ -677,6 +763,7 @@ func (d *decodeState) object(v reflect.Value) error {
 		if !ok {
 			panic(phasePanicMsg)
 		}
+		d.path = append(d.path, pathToken{name: item})
 
 		// Figure out field corresponding to key.
 		var subv reflect.Value
@@ -725,8 +812,11 @@ func (d *decodeState) object(v reflect.Value) error {
 				}
 				d.errorContext.FieldStack = append(d.errorContext.FieldStack, f.name)
 				d.errorContext.Struct = t
+				if f.required && d.requireFields {
+					seenRequired = append(seenRequired, f)
+				}
 			} else if d.disallowUnknownFields {
-				d.saveError(fmt.Errorf("json: unknown field %q", key))
+				d.saveError(&UnknownFieldError{Field: string(key), Offset: int64(d.readIndex())})
 			}
 		}
 
@@ -812,6 +902,7 @@ func (d *decodeState) object(v reflect.Value) error {
 			d.errorContext.FieldStack = d.errorContext.FieldStack[:len(origErrorContext.FieldStack)]
 			d.errorContext.Struct = origErrorContext.Struct
 		}
+		d.path = d.path[:len(d.path)-1]
 		if d.opcode == scanEndObject {
 			break
 		}
@@ -819,6 +910,22 @@ func (d *decodeState) object(v reflect.Value) error {
 			panic(phasePanicMsg)
 		}
 	}
+
+	if d.requireFields && v.Kind() == reflect.Struct {
+	Required:
+		for i := range fields.list {
+			f := &fields.list[i]
+			if !f.required {
+				continue
+			}
+			for _, seen := range seenRequired {
+				if seen == f {
+					continue Required
+				}
+			}
+			d.saveError(&MissingFieldError{Field: f.name, Type: t, Offset: int64(d.off)})
+		}
+	}
 	return nil
 }
 
@@ -1047,7 +1154,9 @@ func (d *decodeState) arrayInterface() []any {
 			break
 		}
 
+		d.path = append(d.path, pathToken{index: len(v)})
 		v = append(v, d.valueInterface())
+		d.path = d.path[:len(d.path)-1]
 
 		// Next token must be , or ].
 		if d.opcode == scanSkipSpace {
@@ -1096,7 +1205,9 @@ func (d *decodeState) objectInterface() map[string]any {
 		d.scanWhile(scanSkipSpace)
 
 		// Read value.
+		d.path = append(d.path, pathToken{name: item})
 		m[key] = d.valueInterface()
+		d.path = d.path[:len(d.path)-1]
 
 		// Next token must be , or }.
 		if d.opcode == scanSkipSpace {
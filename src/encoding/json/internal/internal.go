@@ -0,0 +1,70 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package internal holds declarations shared by the packages that
+// implement encoding/json.
+package internal
+
+import (
+	"errors"
+	"reflect"
+)
+
+// NotForPublicUse is a marker type that an API is for internal use only.
+// It does not perfectly prevent usage of that API, but helps to restrict
+// usage. Anything with this marker is not covered by the Go compatibility
+// agreement.
+type NotForPublicUse struct{}
+
+// AllowInternalUse is passed from "json" to "jsontext" to authenticate
+// that the caller can have access to internal functionality.
+var AllowInternalUse NotForPublicUse
+
+// Errors produced by "json" that "encoding/json" translates
+// into the error types it has historically returned.
+var (
+	ErrCycle           = errors.New("encountered a cycle")
+	ErrNilField        = errors.New("cannot set embedded pointer to unexported struct type")
+	ErrMissingField    = errors.New("missing required field")
+	ErrNonFiniteNumber = errors.New("cannot marshal non-finite number")
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// NewMarshalerError constructs the error returned by v1 when a
+// MarshalJSON or MarshalText method (or equivalent function) fails.
+// It is injected by the v1 "json" package.
+var NewMarshalerError = func(val any, err error, funcName string) error {
+	return err
+}
+
+// NewInvalidOutputError rewords the error from writing the invalid
+// JSON output b of a MarshalJSON method (or equivalent function)
+// as v1 historically reported it.
+// It is injected by the v1 "json" package.
+var NewInvalidOutputError = func(b []byte, err error) error {
+	return err
+}
+
+// PointerMarshalerError adjusts an error returned by v1 when a
+// MarshalJSON or MarshalText method fails on the value referenced
+// by a pointer of type t, since v1 historically reported the pointer type.
+// It is injected by the v1 "json" package.
+var PointerMarshalerError = func(err error, t reflect.Type) error {
+	return err
+}
+
+// NewUnmarshalerError wraps the error returned by v1 when an
+// UnmarshalJSON or UnmarshalText method fails, where ptr is the
+// JSON Pointer to the value that the method was called on.
+// It is injected by the v1 "json" package.
+var NewUnmarshalerError = func(err error, ptr string) error {
+	return err
+}
+
+// RawNumberOf returns the value that a JSON number is unmarshaled as
+// into an empty interface when v1 Decoder.UseNumber is in effect.
+// It is injected by the v1 "json" package.
+var RawNumberOf = func(b []byte) any {
+	panic("RawNumberOf not set")
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jsonflags implements the bit flags behind the options of the
+// "jsontext" and "json" packages.
+//
+// Each boolean option is represented by a bit in a Bools. A Bools used as
+// an option holds exactly one flag bit together with the option value in
+// the least significant bit, so that an option can be applied with a
+// single bitwise operation.
+package jsonflags
+
+import "encoding/json/internal"
+
+// Bools represents zero or more boolean flags, all set to true or all set
+// to false, depending on the least significant bit.
+type Bools uint64
+
+func (Bools) JSONOptions(internal.NotForPublicUse) {}
+
+const (
+	// AllFlags is the set of all flags.
+	AllFlags = AllCoderFlags | AllArshalV2Flags | AllArshalV1Flags
+
+	// AllCoderFlags is the set of all encoder/decoder flags.
+	AllCoderFlags = (maxCoderFlag - 1) - initFlag
+
+	// AllArshalV2Flags is the set of all v2 marshal/unmarshal flags.
+	AllArshalV2Flags = (maxArshalV2Flag - 1) - (maxCoderFlag - 1)
+
+	// AllArshalV1Flags is the set of all v1 marshal/unmarshal flags.
+	AllArshalV1Flags = (maxArshalV1Flag - 1) - (maxArshalV2Flag - 1)
+
+	// NonBooleanFlags is the set of flags that are not boolean options,
+	// whose presence only records that the corresponding value is set.
+	NonBooleanFlags = 0 |
+		Indent |
+		IndentPrefix |
+		Marshalers |
+		Unmarshalers |
+		FormatBytes |
+		FormatDuration
+
+	// DefaultV1Flags is the set of flags set to true by default in v1.
+	DefaultV1Flags = 0 |
+		AllowDuplicateNames |
+		AllowInvalidUTF8 |
+		EscapeForHTML |
+		EscapeForJS |
+		EscapeInvalidUTF8 |
+		Deterministic |
+		FormatNilMapAsNull |
+		FormatNilSliceAsNull |
+		MatchCaseInsensitiveNames |
+		CallMethodsWithLegacySemantics |
+		FormatBytesWithLegacySemantics |
+		FormatTimeWithLegacySemantics |
+		MatchCaseSensitiveDelimiter |
+		MergeWithLegacySemantics |
+		OmitEmptyWithLegacyDefinition |
+		ReportErrorsWithLegacySemantics |
+		StringifyWithLegacySemantics |
+		UnmarshalArrayFromAnyLength
+
+	// InternalFlags is the set of flags that are only ever set internally
+	// and are never part of the default options.
+	InternalFlags = 0 |
+		WithinArshalCall |
+		OmitTopLevelNewline |
+		PreserveRawStrings |
+		CanonicalizeNumbers
+
+	// AnyWhitespace reports whether the encoded output might contain any
+	// whitespace other than the top-level newline.
+	AnyWhitespace = Multiline | SpaceAfterColon | SpaceAfterComma
+)
+
+// Encoder and decoder flags.
+const (
+	initFlag Bools = 1 << iota // reserved for the boolean value itself
+
+	AllowDuplicateNames // encode or decode
+	AllowInvalidUTF8    // encode or decode
+	WithinArshalCall    // encode or decode; for internal use by json.Marshal and json.Unmarshal
+	OmitTopLevelNewline // encode only; for internal use by json.Marshal and json.MarshalWrite
+	PreserveRawStrings  // encode only; for internal use by jsontext.Value and v1
+	CanonicalizeNumbers // encode only; for internal use by jsontext.Value.Canonicalize
+	EscapeForHTML       // encode only
+	EscapeForJS         // encode only
+	EscapeInvalidUTF8   // encode only; for internal use by v1
+	Multiline           // encode only
+	SpaceAfterColon     // encode only
+	SpaceAfterComma     // encode only
+	Indent              // encode only; non-boolean flag
+	IndentPrefix        // encode only; non-boolean flag
+
+	maxCoderFlag
+)
+
+// Marshal and Unmarshal flags (for v2).
+const (
+	_ Bools = (maxCoderFlag >> 1) << iota
+
+	StringifyNumbers          // marshal or unmarshal
+	Deterministic             // marshal only
+	FormatNilMapAsNull        // marshal only
+	FormatNilSliceAsNull      // marshal only
+	MatchCaseInsensitiveNames // marshal or unmarshal
+	DiscardUnknownMembers     // marshal only
+	RejectUnknownMembers      // unmarshal only
+	Marshalers                // marshal only; non-boolean flag
+	Unmarshalers              // unmarshal only; non-boolean flag
+	FormatBytes               // marshal or unmarshal; non-boolean flag
+	FormatDuration            // marshal or unmarshal; non-boolean flag
+
+	maxArshalV2Flag
+)
+
+// Marshal and Unmarshal flags (for v1).
+const (
+	_ Bools = (maxArshalV2Flag >> 1) << iota
+
+	CallMethodsWithLegacySemantics  // marshal or unmarshal
+	FormatBytesWithLegacySemantics  // marshal or unmarshal
+	FormatTimeWithLegacySemantics   // marshal or unmarshal
+	MatchCaseSensitiveDelimiter     // marshal or unmarshal
+	MergeWithLegacySemantics        // unmarshal
+	OmitEmptyWithLegacyDefinition   // marshal
+	RejectMissingRequiredFields     // unmarshal; for internal use by v1 Decoder
+	ReportAllErrors                 // unmarshal; for internal use by v1 Decoder
+	ReportErrorsWithLegacySemantics // marshal or unmarshal
+	StringifyWithLegacySemantics    // marshal or unmarshal
+	UnmarshalAnyWithRawNumber       // unmarshal
+	UnmarshalArrayFromAnyLength     // unmarshal
+
+	maxArshalV1Flag
+)
+
+// Flags is a set of boolean flags.
+// If the presence bit is zero, then the value bit must also be zero.
+// The least-significant bit of both fields is always zero.
+//
+// Unlike Bools, which can represent a set of bools that are all true or
+// all false, Flags represents a set of bools, each individually true or
+// false.
+type Flags struct{ Presence, Values uint64 }
+
+// Join joins two sets of flags such that the latter takes precedence.
+func (dst *Flags) Join(src Flags) {
+	dst.Values &= ^src.Presence
+	dst.Values |= src.Values
+	dst.Presence |= src.Presence
+}
+
+// Set sets both the presence and value for the provided bool (or set of
+// bools), according to the least significant bit of f.
+func (fs *Flags) Set(f Bools) {
+	mask := uint64(f) &^ uint64(initFlag)
+	fs.Presence |= mask
+	fs.Values &= ^mask
+	if f&initFlag != 0 {
+		fs.Values |= mask
+	}
+}
+
+// Get reports whether the bool (or any of the bools) is true.
+// This is generally only used with a singular bool.
+// The value bit of f (i.e., the LSB) is ignored.
+func (fs Flags) Get(f Bools) bool {
+	return fs.Values&uint64(f) > 0
+}
+
+// Has reports whether the bool (or any of the bools) is set.
+// The value bit of f (i.e., the LSB) is ignored.
+func (fs Flags) Has(f Bools) bool {
+	return fs.Presence&uint64(f) > 0
+}
+
+// Clear clears both the presence and value for the provided bool or bools.
+// The value bit of f (i.e., the LSB) is ignored.
+func (fs *Flags) Clear(f Bools) {
+	mask := uint64(f) &^ uint64(initFlag)
+	fs.Presence &= ^mask
+	fs.Values &= ^mask
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jsonopts implements the Options type shared by the "jsontext"
+// and "json" packages.
+package jsonopts
+
+import (
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+)
+
+// Options is the common options type shared by the "jsontext" and "json"
+// packages. It is sealed: only the packages implementing encoding/json
+// can declare options.
+type Options interface {
+	JSONOptions(internal.NotForPublicUse)
+}
+
+// Struct is the combination of all options in struct form.
+// This is efficient to pass down the call stack and to query.
+type Struct struct {
+	Flags jsonflags.Flags
+
+	CoderValues
+	ArshalValues
+}
+
+// CoderValues holds the non-boolean options of the encoder and decoder.
+type CoderValues struct {
+	Indent       string // jsonflags.Indent
+	IndentPrefix string // jsonflags.IndentPrefix
+}
+
+// ArshalValues holds the non-boolean options of marshal and unmarshal.
+type ArshalValues struct {
+	// The Marshalers and Unmarshalers fields hold values of the
+	// json.Marshalers and json.Unmarshalers types, which this package
+	// cannot reference.
+	Marshalers   any // jsonflags.Marshalers
+	Unmarshalers any // jsonflags.Unmarshalers
+
+	FormatBytes    string // jsonflags.FormatBytes
+	FormatDuration string // jsonflags.FormatDuration
+
+	// Format is the format of the value currently being processed,
+	// as specified by a struct field option. It only applies to values
+	// at exactly FormatDepth, so that it does not leak into nested values.
+	// These are not options, but state threaded down the call stack.
+	Format      string
+	FormatDepth int
+}
+
+// DefaultOptionsV2 is the set of all options that define default v2 behavior.
+var DefaultOptionsV2 = Struct{
+	Flags: jsonflags.Flags{
+		Presence: uint64(jsonflags.AllFlags &^ jsonflags.NonBooleanFlags &^ jsonflags.InternalFlags),
+		Values:   uint64(0),
+	},
+}
+
+// DefaultOptionsV1 is the set of all options that define default v1 behavior.
+var DefaultOptionsV1 = Struct{
+	Flags: jsonflags.Flags{
+		Presence: uint64(jsonflags.AllFlags &^ jsonflags.NonBooleanFlags &^ jsonflags.InternalFlags),
+		Values:   uint64(jsonflags.DefaultV1Flags),
+	},
+}
+
+func (*Struct) JSONOptions(internal.NotForPublicUse) {}
+
+// Indent is the value of the jsontext.WithIndent option.
+type Indent string
+
+// IndentPrefix is the value of the jsontext.WithIndentPrefix option.
+type IndentPrefix string
+
+// FormatBytes is the value of the json.FormatBytes option.
+type FormatBytes string
+
+// FormatDuration is the value of the json.FormatDuration option.
+type FormatDuration string
+
+func (Indent) JSONOptions(internal.NotForPublicUse)         {}
+func (IndentPrefix) JSONOptions(internal.NotForPublicUse)   {}
+func (FormatBytes) JSONOptions(internal.NotForPublicUse)    {}
+func (FormatDuration) JSONOptions(internal.NotForPublicUse) {}
+
+// JoinUnknownOption is injected by the "json" package to handle the
+// options declared in that package, which this package cannot reference.
+var JoinUnknownOption = func(*Struct, Options) { panic("unknown option") }
+
+// GetUnknownOption is injected by the "json" package to handle the
+// options declared in that package, which this package cannot reference.
+var GetUnknownOption = func(*Struct, Options) (any, bool) { panic("unknown option") }
+
+// Join joins the options in srcs into dst, such that latter options take
+// precedence over earlier ones.
+func (dst *Struct) Join(srcs ...Options) {
+	for _, src := range srcs {
+		switch src := src.(type) {
+		case nil:
+			continue
+		case jsonflags.Bools:
+			dst.Flags.Set(src)
+		case Indent:
+			dst.Flags.Set(jsonflags.Multiline | jsonflags.Indent | 1)
+			dst.Indent = string(src)
+		case IndentPrefix:
+			dst.Flags.Set(jsonflags.Multiline | jsonflags.IndentPrefix | 1)
+			dst.IndentPrefix = string(src)
+		case FormatBytes:
+			dst.Flags.Set(jsonflags.FormatBytes | 1)
+			dst.FormatBytes = string(src)
+		case FormatDuration:
+			dst.Flags.Set(jsonflags.FormatDuration | 1)
+			dst.FormatDuration = string(src)
+		case *Struct:
+			dst.JoinStruct(src)
+		default:
+			JoinUnknownOption(dst, src)
+		}
+	}
+}
+
+// JoinStruct joins the options in src into dst.
+func (dst *Struct) JoinStruct(src *Struct) {
+	dst.Flags.Join(src.Flags)
+	if src.Flags.Has(jsonflags.Indent) {
+		dst.Indent = src.Indent
+	}
+	if src.Flags.Has(jsonflags.IndentPrefix) {
+		dst.IndentPrefix = src.IndentPrefix
+	}
+	if src.Flags.Has(jsonflags.Marshalers) {
+		dst.Marshalers = src.Marshalers
+	}
+	if src.Flags.Has(jsonflags.Unmarshalers) {
+		dst.Unmarshalers = src.Unmarshalers
+	}
+	if src.Flags.Has(jsonflags.FormatBytes) {
+		dst.FormatBytes = src.FormatBytes
+	}
+	if src.Flags.Has(jsonflags.FormatDuration) {
+		dst.FormatDuration = src.FormatDuration
+	}
+}
+
+// JoinWithoutCoderOptions joins the options in srcs into dst,
+// ignoring any options that affect the encoder or decoder.
+// It is used when options are provided to an existing Encoder or Decoder.
+func (dst *Struct) JoinWithoutCoderOptions(srcs ...Options) {
+	var src Struct
+	src.Join(srcs...)
+	src.Flags.Clear(jsonflags.AllCoderFlags)
+	dst.JoinStruct(&src)
+}
+
+// GetOption returns the value stored in opts for the option that setter
+// constructs, and whether it is present.
+func GetOption[T any](opts Options, setter func(T) Options) (T, bool) {
+	var zero T
+	// Calling the setter with the zero value reveals which option it sets,
+	// from the dynamic type and flag of the result.
+	switch opt := setter(zero).(type) {
+	case jsonflags.Bools:
+		v, ok := getBool(opts, opt)
+		if !ok {
+			return zero, false
+		}
+		return any(v).(T), true
+	default:
+		s := new(Struct)
+		s.Join(opts)
+		var v any
+		var ok bool
+		switch opt.(type) {
+		case Indent:
+			v, ok = s.Indent, s.Flags.Has(jsonflags.Indent)
+		case IndentPrefix:
+			v, ok = s.IndentPrefix, s.Flags.Has(jsonflags.IndentPrefix)
+		case FormatBytes:
+			v, ok = s.FormatBytes, s.Flags.Has(jsonflags.FormatBytes)
+		case FormatDuration:
+			v, ok = s.FormatDuration, s.Flags.Has(jsonflags.FormatDuration)
+		default:
+			v, ok = GetUnknownOption(s, opt)
+		}
+		if !ok {
+			return zero, false
+		}
+		return v.(T), true
+	}
+}
+
+func getBool(opts Options, f jsonflags.Bools) (bool, bool) {
+	s := new(Struct)
+	s.Join(opts)
+	if !s.Flags.Has(f) {
+		return false, false
+	}
+	return s.Flags.Get(f), true
+}
@@ -0,0 +1,553 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsonwire
+
+import (
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ValueFlags records properties of a consumed JSON value.
+type ValueFlags uint
+
+const (
+	_ ValueFlags = (1 << iota) / 2 // powers of two starting with zero
+
+	stringNonVerbatim  // string cannot be naively treated as valid UTF-8
+	stringNonCanonical // string not formatted according to RFC 8785, section 3.2.2.2.
+)
+
+func (f *ValueFlags) Join(f2 ValueFlags) { *f |= f2 }
+
+// IsVerbatim reports whether the string contains no escape sequences and
+// no invalid UTF-8, so that its contents can be used as is.
+func (f ValueFlags) IsVerbatim() bool { return f&stringNonVerbatim == 0 }
+
+// IsCanonical reports whether the string is formatted as specified by
+// RFC 8785, section 3.2.2.2.
+func (f ValueFlags) IsCanonical() bool { return f&stringNonCanonical == 0 }
+
+// ConsumeWhitespace consumes leading JSON whitespace per RFC 7159, section 2.
+func ConsumeWhitespace(b []byte) (n int) {
+	for len(b) > n && (b[n] == ' ' || b[n] == '\t' || b[n] == '\r' || b[n] == '\n') {
+		n++
+	}
+	return n
+}
+
+// ConsumeNull consumes the next JSON null literal per RFC 7159, section 3.
+// It returns 0 if it is invalid, in which case ConsumeLiteral reports the
+// error.
+func ConsumeNull(b []byte) int {
+	const literal = "null"
+	if len(b) >= len(literal) && string(b[:len(literal)]) == literal {
+		return len(literal)
+	}
+	return 0
+}
+
+// ConsumeFalse consumes the next JSON false literal per RFC 7159, section 3.
+// It returns 0 if it is invalid, in which case ConsumeLiteral reports the
+// error.
+func ConsumeFalse(b []byte) int {
+	const literal = "false"
+	if len(b) >= len(literal) && string(b[:len(literal)]) == literal {
+		return len(literal)
+	}
+	return 0
+}
+
+// ConsumeTrue consumes the next JSON true literal per RFC 7159, section 3.
+// It returns 0 if it is invalid, in which case ConsumeLiteral reports the
+// error.
+func ConsumeTrue(b []byte) int {
+	const literal = "true"
+	if len(b) >= len(literal) && string(b[:len(literal)]) == literal {
+		return len(literal)
+	}
+	return 0
+}
+
+// ConsumeLiteral consumes the next JSON literal per RFC 7159, section 3.
+// If the input appears truncated, it returns io.ErrUnexpectedEOF.
+func ConsumeLiteral(b []byte, lit string) (n int, err error) {
+	for i := 0; i < len(b) && i < len(lit); i++ {
+		if b[i] != lit[i] {
+			return i, NewInvalidCharacterError(b[i:], "within literal "+lit+" (expecting "+strconv.QuoteRune(rune(lit[i]))+")")
+		}
+	}
+	if len(b) < len(lit) {
+		return len(b), io.ErrUnexpectedEOF
+	}
+	return len(lit), nil
+}
+
+// ConsumeSimpleString consumes the next JSON string per RFC 7159, section 7
+// but is limited to the grammar for an ASCII string without escape sequences.
+// It returns 0 if it is invalid or more complicated than a simple string,
+// in which case consumption should be attempted with ConsumeString.
+func ConsumeSimpleString(b []byte) (n int) {
+	if len(b) > 0 && b[0] == '"' {
+		n++
+		for len(b) > n && b[n] < utf8.RuneSelf && escapeASCII[b[n]] == 0 {
+			n++
+		}
+		if len(b) > n && b[n] == '"' {
+			n++
+			return n
+		}
+	}
+	return 0
+}
+
+// ConsumeString consumes the next JSON string per RFC 7159, section 7.
+// If validateUTF8 is false, then this allows the presence of invalid UTF-8
+// characters within the string itself.
+// It reports the number of bytes consumed and whether an error was
+// encountered. If the input appears truncated, it returns
+// io.ErrUnexpectedEOF.
+func ConsumeString(flags *ValueFlags, b []byte, validateUTF8 bool) (n int, err error) {
+	// Consume the leading double quote.
+	switch {
+	case len(b) == 0:
+		return n, io.ErrUnexpectedEOF
+	case b[0] == '"':
+		n++
+	default:
+		return n, NewInvalidCharacterError(b[n:], `at start of string (expecting '"')`)
+	}
+
+	// Consume every character in the string.
+	for len(b) > n {
+		// Optimize for long sequences of unescaped characters.
+		for len(b) > n && isUnescapedASCII(b[n]) {
+			n++
+		}
+		if len(b) == n {
+			return n, io.ErrUnexpectedEOF
+		}
+
+		switch r, rn := utf8.DecodeRune(b[n:]); {
+		// Handle UTF-8 encoded byte sequence.
+		// Due to specialized handling of ASCII above, we know that
+		// all normal sequences at this point must be 2 bytes or larger.
+		case rn > 1:
+			n += rn
+		// Handle escape sequence.
+		case r == '\\':
+			flags.Join(stringNonVerbatim)
+			resumeOffset := n
+			if len(b) < n+2 {
+				return resumeOffset, io.ErrUnexpectedEOF
+			}
+			switch r := b[n+1]; r {
+			case '/':
+				// Forward slash is the only character with 3 representations.
+				// Per RFC 8785, section 3.2.2.2., this must not be escaped.
+				flags.Join(stringNonCanonical)
+				n += 2
+			case '"', '\\', 'b', 'f', 'n', 'r', 't':
+				n += 2
+			case 'u':
+				if len(b) < n+6 {
+					if !isHexDigits(b[n+2:]) {
+						return resumeOffset, NewInvalidEscapeSequenceError(b[n:])
+					}
+					return resumeOffset, io.ErrUnexpectedEOF
+				}
+				v1, ok := parseHexUint16(b[n+2 : n+6])
+				if !ok {
+					return resumeOffset, NewInvalidEscapeSequenceError(b[n : n+6])
+				}
+				// Only certain control characters can use the \uXXXX notation
+				// for canonical formatting (per RFC 8785, section 3.2.2.2.).
+				switch v1 {
+				// \uXXXX notation not permitted for these characters.
+				case '\b', '\f', '\n', '\r', '\t':
+					flags.Join(stringNonCanonical)
+				default:
+					// \uXXXX notation only permitted for control characters.
+					if v1 >= ' ' {
+						flags.Join(stringNonCanonical)
+					} else {
+						// \uXXXX notation must be lower case.
+						for _, c := range b[n+2 : n+6] {
+							if 'A' <= c && c <= 'F' {
+								flags.Join(stringNonCanonical)
+							}
+						}
+					}
+				}
+				n += 6
+
+				r := rune(v1)
+				if validateUTF8 && utf16.IsSurrogate(r) {
+					// A surrogate not followed by another \uXXXX escape
+					// is reported by itself, so that the error does not
+					// depend on how much of the input has been buffered.
+					if len(b) < n+6 {
+						if !isEscapedHexPrefix(b[n:]) {
+							return resumeOffset, NewInvalidEscapeSequenceError(b[resumeOffset:n])
+						}
+						return resumeOffset, io.ErrUnexpectedEOF
+					}
+					// Only validate the second half of a surrogate pair.
+					if b[n] != '\\' || b[n+1] != 'u' {
+						return resumeOffset, NewInvalidEscapeSequenceError(b[resumeOffset:n])
+					}
+					v2, ok := parseHexUint16(b[n+2 : n+6])
+					if !ok {
+						return resumeOffset, NewInvalidEscapeSequenceError(b[n : n+6])
+					}
+					if utf16.DecodeRune(r, rune(v2)) == utf8.RuneError {
+						return resumeOffset, NewInvalidEscapeSequenceError(b[resumeOffset : n+6])
+					}
+					n += 6
+				}
+			default:
+				return resumeOffset, NewInvalidEscapeSequenceError(b[n : n+2])
+			}
+		// Handle invalid UTF-8.
+		case r == utf8.RuneError:
+			if !utf8.FullRune(b[n:]) {
+				return n, io.ErrUnexpectedEOF
+			}
+			flags.Join(stringNonVerbatim | stringNonCanonical)
+			if validateUTF8 {
+				return n, ErrInvalidUTF8
+			}
+			n++
+		// Handle end of string.
+		case r == '"':
+			n++
+			return n, nil
+		// Handle invalid control characters.
+		case r < ' ':
+			flags.Join(stringNonVerbatim | stringNonCanonical)
+			return n, NewInvalidCharacterError(b[n:], "within string (expecting non-control character)")
+		default:
+			panic("BUG: unhandled character " + QuoteRune(b[n:]))
+		}
+	}
+	return n, io.ErrUnexpectedEOF
+}
+
+// AppendUnquote appends the unescaped form of a JSON string in src to dst.
+// Any invalid UTF-8 within the string will be replaced with utf8.RuneError,
+// but the error will be specified as having encountered such an error.
+// The input must be an entire JSON string with no surrounding whitespace.
+func AppendUnquote[Bytes ~[]byte | ~string](dst []byte, src Bytes) (v []byte, err error) {
+	dst = slices.Grow(dst, len(src))
+
+	// Consume the leading double quote.
+	var i, n int
+	switch {
+	case len(src) == 0:
+		return dst, io.ErrUnexpectedEOF
+	case src[0] == '"':
+		i, n = 1, 1
+	default:
+		return dst, NewInvalidCharacterError([]byte(src), `at start of string (expecting '"')`)
+	}
+
+	// Consume every character in the string.
+	for uint(len(src)) > uint(n) {
+		// Optimize for long sequences of unescaped characters.
+		for uint(len(src)) > uint(n) && isUnescapedASCII(src[n]) {
+			n++
+		}
+		if uint(len(src)) <= uint(n) {
+			dst = append(dst, src[i:n]...)
+			return dst, io.ErrUnexpectedEOF
+		}
+
+		switch r, rn := utf8.DecodeRuneInString(string(truncateMaxUTF8(src[n:]))); {
+		// Handle UTF-8 encoded byte sequence.
+		// Due to specialized handling of ASCII above, we know that
+		// all normal sequences at this point must be 2 bytes or larger.
+		case rn > 1:
+			n += rn
+		// Handle escape sequence.
+		case r == '\\':
+			dst = append(dst, src[i:n]...)
+
+			// Handle escape sequence.
+			if uint(len(src)) < uint(n+2) {
+				return dst, io.ErrUnexpectedEOF
+			}
+			switch r := src[n+1]; r {
+			case '"', '\\', '/':
+				dst = append(dst, r)
+				n += 2
+			case 'b':
+				dst = append(dst, '\b')
+				n += 2
+			case 'f':
+				dst = append(dst, '\f')
+				n += 2
+			case 'n':
+				dst = append(dst, '\n')
+				n += 2
+			case 'r':
+				dst = append(dst, '\r')
+				n += 2
+			case 't':
+				dst = append(dst, '\t')
+				n += 2
+			case 'u':
+				if uint(len(src)) < uint(n+6) {
+					if !isHexDigits(src[n+2:]) {
+						return dst, NewInvalidEscapeSequenceError(src[n:])
+					}
+					return dst, io.ErrUnexpectedEOF
+				}
+				v1, ok := parseHexUint16(src[n+2 : n+6])
+				if !ok {
+					return dst, NewInvalidEscapeSequenceError(src[n : n+6])
+				}
+				n += 6
+
+				// Check whether this is a surrogate half.
+				r := rune(v1)
+				if utf16.IsSurrogate(r) {
+					r = utf8.RuneError // assume failure unless the following succeeds
+					if uint(len(src)) >= uint(n+6) && src[n] == '\\' && src[n+1] == 'u' {
+						if v2, ok := parseHexUint16(src[n+2 : n+6]); ok {
+							if r = utf16.DecodeRune(rune(v1), rune(v2)); r != utf8.RuneError {
+								n += 6
+							}
+						}
+					}
+					if r == utf8.RuneError {
+						err = ErrInvalidUTF8
+					}
+				}
+				dst = utf8.AppendRune(dst, r)
+			default:
+				return dst, NewInvalidEscapeSequenceError(src[n : n+2])
+			}
+			i = n
+		// Handle invalid UTF-8.
+		case r == utf8.RuneError:
+			dst = append(dst, src[i:n]...)
+			if !utf8.FullRuneInString(string(truncateMaxUTF8(src[n:]))) {
+				return dst, io.ErrUnexpectedEOF
+			}
+			// NOTE: An unescaped string may be longer than the escaped string
+			// because invalid UTF-8 bytes are being replaced.
+			dst = append(dst, "\ufffd"...)
+			n += rn
+			i = n
+			err = ErrInvalidUTF8
+		// Handle end of string.
+		case r == '"':
+			dst = append(dst, src[i:n]...)
+			n++
+			if n < len(src) {
+				err = NewInvalidCharacterError([]byte(src[n:]), "after string value")
+			}
+			return dst, err
+		// Handle invalid control characters.
+		case r < ' ':
+			dst = append(dst, src[i:n]...)
+			return dst, NewInvalidCharacterError([]byte(src[n:]), "within string (expecting non-control character)")
+		default:
+			panic("BUG: unhandled character " + QuoteRune(src[n:]))
+		}
+	}
+	dst = append(dst, src[i:n]...)
+	return dst, io.ErrUnexpectedEOF
+}
+
+// isUnescapedASCII reports whether c is an ASCII character that
+// may appear within a JSON string without being escaped.
+func isUnescapedASCII(c byte) bool {
+	return ' ' <= c && c < utf8.RuneSelf && c != '"' && c != '\\'
+}
+
+// isHexDigits reports whether b consists of only hexadecimal digits.
+func isHexDigits[Bytes ~[]byte | ~string](b Bytes) bool {
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// isEscapedHexPrefix reports whether b is a prefix of a \uXXXX escape
+// sequence.
+func isEscapedHexPrefix(b []byte) bool {
+	switch {
+	case len(b) >= 1 && b[0] != '\\':
+		return false
+	case len(b) >= 2 && b[1] != 'u':
+		return false
+	case len(b) >= 2:
+		return isHexDigits(b[2:])
+	}
+	return true
+}
+
+// parseHexUint16 is similar to strconv.ParseUint,
+// but operates directly on []byte and is optimized for base-16.
+// See https://go.dev/issue/42429.
+func parseHexUint16[Bytes ~[]byte | ~string](b Bytes) (v uint16, ok bool) {
+	if len(b) != 4 {
+		return 0, false
+	}
+	for i := 0; i < 4; i++ {
+		c := b[i]
+		switch {
+		case '0' <= c && c <= '9':
+			c = c - '0'
+		case 'a' <= c && c <= 'f':
+			c = 10 + c - 'a'
+		case 'A' <= c && c <= 'F':
+			c = 10 + c - 'A'
+		default:
+			return 0, false
+		}
+		v = v*16 + uint16(c)
+	}
+	return v, true
+}
+
+// ConsumeSimpleNumber consumes the next JSON number per RFC 7159, section 6
+// but is limited to the grammar for a positive integer.
+// It returns 0 if it is invalid or more complicated than a simple integer,
+// in which case consumption should be attempted with ConsumeNumber.
+func ConsumeSimpleNumber(b []byte) (n int) {
+	if len(b) > 0 {
+		if b[0] == '0' {
+			n++
+		} else if '1' <= b[0] && b[0] <= '9' {
+			n++
+			for len(b) > n && ('0' <= b[n] && b[n] <= '9') {
+				n++
+			}
+		} else {
+			return 0
+		}
+		if uint(len(b)) <= uint(n) || (b[n] != '.' && b[n] != 'e' && b[n] != 'E') {
+			return n
+		}
+	}
+	return 0
+}
+
+// ConsumeNumber consumes the next JSON number per RFC 7159, section 6.
+// It reports the number of bytes consumed and whether an error was
+// encountered. If the input appears truncated, it returns
+// io.ErrUnexpectedEOF.
+//
+// A number that ends at the end of b is reported as complete: a caller
+// reading a stream must check whether more digits follow.
+func ConsumeNumber(b []byte) (n int, err error) {
+	// Optional minus sign.
+	if len(b) > 0 && b[0] == '-' {
+		n++
+	}
+
+	// Integer part.
+	switch {
+	case len(b) == n:
+		return n, io.ErrUnexpectedEOF
+	case b[n] == '0':
+		n++
+	case '1' <= b[n] && b[n] <= '9':
+		n++
+		for len(b) > n && ('0' <= b[n] && b[n] <= '9') {
+			n++
+		}
+	default:
+		return n, NewInvalidCharacterError(b[n:], "within number (expecting digit)")
+	}
+
+	// Optional fractional part.
+	if len(b) > n && b[n] == '.' {
+		n++
+		switch {
+		case len(b) == n:
+			return n, io.ErrUnexpectedEOF
+		case '0' <= b[n] && b[n] <= '9':
+			n++
+		default:
+			return n, NewInvalidCharacterError(b[n:], "within number (expecting digit)")
+		}
+		for len(b) > n && ('0' <= b[n] && b[n] <= '9') {
+			n++
+		}
+	}
+
+	// Optional exponent.
+	if len(b) > n && (b[n] == 'e' || b[n] == 'E') {
+		n++
+		if len(b) > n && (b[n] == '-' || b[n] == '+') {
+			n++
+		}
+		switch {
+		case len(b) == n:
+			return n, io.ErrUnexpectedEOF
+		case '0' <= b[n] && b[n] <= '9':
+			n++
+		default:
+			return n, NewInvalidCharacterError(b[n:], "within number (expecting digit)")
+		}
+		for len(b) > n && ('0' <= b[n] && b[n] <= '9') {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// ParseUint parses b as a decimal unsigned integer according to
+// a strict subset of the JSON number grammar, returning the value if valid.
+// It returns (0, false) if there is a syntax error and
+// returns (math.MaxUint64, false) if there is an overflow.
+func ParseUint(b []byte) (v uint64, ok bool) {
+	const unsafeWidth = 20 // len(fmt.Sprint(uint64(math.MaxUint64)))
+	var n int
+	for ; len(b) > n && ('0' <= b[n] && b[n] <= '9'); n++ {
+		v = 10*v + uint64(b[n]-'0')
+	}
+	switch {
+	case n == 0 || len(b) != n || (b[0] == '0' && string(b) != "0"):
+		return 0, false
+	case n >= unsafeWidth && (b[0] != '1' || v < 1e19 || n > unsafeWidth):
+		return math.MaxUint64, false
+	}
+	return v, true
+}
+
+// ParseFloat parses a floating point number according to the Go float
+// grammar. Note that the JSON number grammar is a strict subset.
+//
+// If the number overflows the finite representation of a float,
+// then we return MaxFloat since any finite value will always be
+// infinitely more accurate at representing another finite value
+// than an infinite value.
+func ParseFloat(b []byte, bits int) (v float64, ok bool) {
+	fv, err := strconv.ParseFloat(string(b), bits)
+	if math.IsInf(fv, 0) {
+		switch {
+		case bits == 32 && math.IsInf(fv, +1):
+			fv = +math.MaxFloat32
+		case bits == 64 && math.IsInf(fv, +1):
+			fv = +math.MaxFloat64
+		case bits == 32 && math.IsInf(fv, -1):
+			fv = -math.MaxFloat32
+		case bits == 64 && math.IsInf(fv, -1):
+			fv = -math.MaxFloat64
+		}
+	}
+	return fv, err == nil
+}
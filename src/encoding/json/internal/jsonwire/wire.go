@@ -0,0 +1,112 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jsonwire implements stateless functions for handling
+// JSON text as it appears on the wire.
+package jsonwire
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 reports that a JSON string contains invalid UTF-8.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// NewError returns an error with the given message, to be wrapped with
+// location information by the caller.
+func NewError(s string) error { return errors.New(s) }
+
+// NewInvalidCharacterError returns an error for the character at the
+// start of prefix, found in the context described by where.
+func NewInvalidCharacterError(prefix []byte, where string) error {
+	what := QuoteRune(prefix)
+	return errors.New("invalid character " + what + " " + where)
+}
+
+// NewInvalidEscapeSequenceError returns an error for the invalid escape
+// sequence that starts with what.
+func NewInvalidEscapeSequenceError[Bytes ~[]byte | ~string](what Bytes) error {
+	label := "escape sequence"
+	if len(what) > 6 {
+		label = "surrogate pair"
+	}
+	needEscape := false
+	for _, r := range string(what) {
+		if r == '`' || r == utf8.RuneError || r < ' ' {
+			needEscape = true
+			break
+		}
+	}
+	if needEscape {
+		return errors.New("invalid " + label + " " + strconv.Quote(string(what)) + " in string")
+	}
+	return errors.New("invalid " + label + " `" + string(what) + "` in string")
+}
+
+// TruncatePointer shortens a long JSON Pointer for use in error messages.
+func TruncatePointer(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	i := n / 2
+	j := len(s) - n/2
+	// Avoid cutting in the middle of a UTF-8 sequence.
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	for j < len(s) && !utf8.RuneStart(s[j]) {
+		j++
+	}
+	return s[:i] + "…" + s[j:]
+}
+
+// QuoteRune quotes the first rune in the input, for use in error messages.
+func QuoteRune[Bytes ~[]byte | ~string](b Bytes) string {
+	r, n := utf8.DecodeRuneInString(string(truncateMaxUTF8(b)))
+	if r == utf8.RuneError && n == 1 {
+		return `'\x` + strconv.FormatUint(uint64(b[0]), 16) + `'`
+	}
+	return strconv.QuoteRune(r)
+}
+
+func truncateMaxUTF8[Bytes ~[]byte | ~string](b Bytes) Bytes {
+	if len(b) > utf8.UTFMax {
+		return b[:utf8.UTFMax]
+	}
+	return b
+}
+
+// errorOrUnexpectedEOF returns err, or io.ErrUnexpectedEOF if the input
+// ends early, which happens when b is exhausted.
+func errorOrUnexpectedEOF(b []byte, err error) error {
+	if len(b) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// TrimSuffixWhitespace trims JSON whitespace from the end of b.
+func TrimSuffixWhitespace(b []byte) []byte {
+	n := len(b) - 1
+	for n >= 0 && (b[n] == ' ' || b[n] == '\t' || b[n] == '\r' || b[n] == '\n') {
+		n--
+	}
+	return b[:n+1]
+}
+
+// HasSuffixByte reports whether b ends with c.
+func HasSuffixByte(b []byte, c byte) bool {
+	return len(b) > 0 && b[len(b)-1] == c
+}
+
+// TrimSuffixByte removes c from the end of b if it is present.
+func TrimSuffixByte(b []byte, c byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == c {
+		return b[:len(b)-1]
+	}
+	return b
+}
@@ -0,0 +1,581 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"io"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+// Decoder is a streaming decoder for raw JSON tokens and values.
+// It is used to read a stream of top-level JSON values,
+// each separated by optional whitespace characters.
+//
+// [Decoder.ReadToken] and [Decoder.ReadValue] calls may be interleaved.
+// For example, the following JSON value:
+//
+//	{"name":"value","array":[null,false,true,3.14159],"object":{"k":"v"}}
+//
+// can be parsed with the following calls (ignoring errors for brevity):
+//
+//	d.ReadToken() // {
+//	d.ReadToken() // "name"
+//	d.ReadToken() // "value"
+//	d.ReadValue() // "array"
+//	d.ReadToken() // [
+//	d.ReadToken() // null
+//	d.ReadToken() // false
+//	d.ReadValue() // true
+//	d.ReadToken() // 3.14159
+//	d.ReadToken() // ]
+//	d.ReadValue() // "object"
+//	d.ReadValue() // {"k":"v"}
+//	d.ReadToken() // }
+//
+// The above is one of many possible sequence of calls and
+// may not represent the most sensible method to call for any given token/value.
+// For example, it is probably more common to call [Decoder.ReadToken] to obtain a
+// string token for object names.
+type Decoder struct {
+	s decoderState
+}
+
+// decoderState is the low-level state of Decoder.
+// It has exported fields and methods for use by the "json" package.
+type decoderState struct {
+	state
+	decodeBuffer
+	jsonopts.Struct
+
+	// nameBuf is a scratch buffer for unescaping object names.
+	nameBuf []byte
+}
+
+// decodeBuffer is a buffer split into 3 segments:
+//
+//   - buf[0:prevEnd]         // already read portion of the buffer
+//   - buf[prevStart:prevEnd] // previously read value
+//   - buf[prevEnd:len(buf)]  // unread portion of the buffer
+//
+// Positions within the unread portion are tracked relative to prevEnd,
+// which remain valid across calls to fetch.
+type decodeBuffer struct {
+	buf       []byte
+	prevStart int
+	prevEnd   int
+
+	// baseOffset is added to prevStart and prevEnd to obtain
+	// the absolute offset relative to the start of io.Reader stream.
+	baseOffset int64
+
+	rd io.Reader
+}
+
+const (
+	minBufferSize = 512
+	maxGrowSize   = 64 << 10
+)
+
+// NewDecoder constructs a new streaming decoder reading from r.
+func NewDecoder(r io.Reader, opts ...Options) *Decoder {
+	d := new(Decoder)
+	d.Reset(r, opts...)
+	return d
+}
+
+// Reset resets a decoder such that it is reading afresh from r and
+// configured with the provided options. Reset must not be called on
+// a Decoder passed to the [encoding/json/v2.UnmarshalerFrom.UnmarshalJSONFrom] method
+// or the [encoding/json/v2.UnmarshalFromFunc] function.
+func (d *Decoder) Reset(r io.Reader, opts ...Options) {
+	switch {
+	case d == nil:
+		panic("jsontext: invalid nil Decoder")
+	case r == nil:
+		panic("jsontext: invalid nil io.Reader")
+	case d.s.Flags.Get(jsonflags.WithinArshalCall):
+		panic("jsontext: cannot reset Decoder passed to json.UnmarshalerFrom")
+	}
+	// Reuse the buffer if it was previously allocated by the decoder.
+	var b []byte
+	if d.s.rd != nil {
+		b = d.s.buf[:0]
+	}
+	d.s.reset(b, r, opts...)
+}
+
+func (d *decoderState) reset(b []byte, r io.Reader, opts ...Options) {
+	d.state.reset()
+	d.decodeBuffer = decodeBuffer{buf: b, rd: r}
+	opts2 := jsonopts.Struct{} // avoid mutating d.Struct in case it is part of opts
+	opts2.Join(opts...)
+	d.Struct = opts2
+}
+
+// Options returns the options used to construct the decoder and
+// may additionally contain semantic options passed to a
+// [encoding/json/v2.UnmarshalDecode] call.
+//
+// If operating within
+// a [encoding/json/v2.UnmarshalerFrom.UnmarshalJSONFrom] method call or
+// a [encoding/json/v2.UnmarshalFromFunc] function call,
+// then the returned options are only valid within the call.
+func (d *Decoder) Options() Options {
+	return &d.s.Struct
+}
+
+// unread returns the unread portion of the buffer.
+func (d *decodeBuffer) unread() []byte {
+	return d.buf[d.prevEnd:]
+}
+
+// PreviousOffsetStart returns the offset of the start of the previous token or value.
+func (d *decodeBuffer) PreviousOffsetStart() int64 {
+	return d.baseOffset + int64(d.prevStart)
+}
+
+// previousOffsetEnd returns the offset immediately after the previous token or value.
+func (d *decodeBuffer) previousOffsetEnd() int64 {
+	return d.baseOffset + int64(d.prevEnd)
+}
+
+// PreviousTokenOrValue returns the previously read token or value
+// unless it has been invalidated by a call to PeekKind.
+func (d *decodeBuffer) PreviousTokenOrValue() []byte {
+	return d.buf[d.prevStart:d.prevEnd]
+}
+
+// fetch reads at least 1 byte from the underlying io.Reader.
+// It returns io.ErrUnexpectedEOF if zero bytes were read and io.EOF was seen.
+// Data before prevEnd is discarded, so that any position relative
+// to prevEnd remains valid.
+func (d *decodeBuffer) fetch() error {
+	if d.rd == nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	// Discard the read portion of the buffer and
+	// grow the buffer if it is mostly full or if the stream
+	// is large relative to the size of the buffer.
+	unread := len(d.buf) - d.prevEnd
+	switch {
+	case cap(d.buf) == 0:
+		d.buf = make([]byte, 0, minBufferSize)
+	case unread > cap(d.buf)/2 ||
+		(cap(d.buf) < maxGrowSize && d.previousOffsetEnd() > int64(8*cap(d.buf))):
+		newBuf := make([]byte, unread, 2*cap(d.buf))
+		copy(newBuf, d.buf[d.prevEnd:])
+		d.buf = newBuf
+	default:
+		d.buf = d.buf[:copy(d.buf, d.buf[d.prevEnd:])]
+	}
+	d.baseOffset += int64(d.prevEnd)
+	d.prevStart, d.prevEnd = 0, 0
+
+	// Read more data into the internal buffer.
+	for range 100 {
+		n, err := d.rd.Read(d.buf[len(d.buf):cap(d.buf)])
+		d.buf = d.buf[:len(d.buf)+n]
+		switch {
+		case n > 0:
+			return nil // ignore errors if any bytes are read
+		case err == io.EOF:
+			return io.ErrUnexpectedEOF
+		case err != nil:
+			return &ioError{action: "read", err: err}
+		}
+	}
+	return &ioError{action: "read", err: io.ErrNoProgress}
+}
+
+// newSyntacticError wraps err in a SyntacticError located at the position
+// relative to the unread portion of the buffer and pointing to the
+// next value. I/O errors and io.EOF are returned as is.
+func (d *decoderState) newSyntacticError(pos int, err error) error {
+	if _, ok := err.(*ioError); ok || err == io.EOF {
+		return err
+	}
+	return &SyntacticError{
+		ByteOffset:  d.previousOffsetEnd() + int64(pos),
+		JSONPointer: d.stackPointer(+1),
+		Err:         err,
+	}
+}
+
+// skipWhitespace skips whitespace starting at the relative position pos,
+// fetching more data as necessary. It returns the position of the first
+// non-whitespace character, or io.ErrUnexpectedEOF if there is none.
+func (d *decoderState) skipWhitespace(pos int) (int, error) {
+	for {
+		b := d.unread()
+		pos += jsonwire.ConsumeWhitespace(b[pos:])
+		if pos < len(b) {
+			return pos, nil
+		}
+		if err := d.fetch(); err != nil {
+			return pos, err
+		}
+	}
+}
+
+// skipToToken skips whitespace and any delimiter starting at the relative
+// position pos, and returns the position of the start of the next token.
+// It returns io.EOF if the input ends at the top-level.
+// Any other error is already wrapped in a SyntacticError.
+func (d *decoderState) skipToToken(pos int) (int, error) {
+	pos, err := d.skipWhitespace(pos)
+	if err != nil {
+		if err == io.ErrUnexpectedEOF && d.Tokens.Depth() == 1 {
+			err = io.EOF // the input ended between top-level values
+		}
+		return pos, d.newSyntacticError(pos, err)
+	}
+
+	last := d.Tokens.last()
+	b := d.unread()
+	delim := last.needDelim(Kind(b[pos]))
+	if delim == 0 {
+		return pos, nil
+	}
+	if b[pos] != delim {
+		var where string
+		switch {
+		case last.needObjectValue():
+			where = "after object name (expecting ':')"
+		case last.kind == '{':
+			where = "after object value (expecting ',' or '}')"
+		default:
+			where = "after array element (expecting ',' or ']')"
+		}
+		return pos, d.newSyntacticError(pos, jsonwire.NewInvalidCharacterError(b[pos:], where))
+	}
+	pos++
+
+	if pos, err = d.skipWhitespace(pos); err != nil {
+		return pos, d.newSyntacticError(pos, err)
+	}
+	b = d.unread()
+	if delim == ',' && (b[pos] == '}' || b[pos] == ']') {
+		where := "at start of value"
+		if last.kind == '{' {
+			where = `at start of string (expecting '"')`
+		}
+		return pos, d.newSyntacticError(pos, jsonwire.NewInvalidCharacterError(b[pos:], where))
+	}
+	return pos, nil
+}
+
+// consumeToken consumes the token starting at the relative position pos,
+// fetching more data as necessary, and updates the state machine.
+// It reports the length of the token and, for strings, sets flags.
+// Any error is already wrapped in a SyntacticError.
+func (d *decoderState) consumeToken(pos int, flags *jsonwire.ValueFlags) (int, error) {
+	var n int
+	var err error
+	k := Kind(d.unread()[pos]).normalize()
+	for {
+		b := d.unread()[pos:]
+		switch k {
+		case 'n', 'f', 't':
+			n, err = jsonwire.ConsumeLiteral(b, literalString(k))
+		case '"':
+			*flags = 0
+			n, err = jsonwire.ConsumeString(flags, b, !d.Flags.Get(jsonflags.AllowInvalidUTF8))
+		case '0':
+			n, err = jsonwire.ConsumeNumber(b)
+			if err == nil && n == len(b) && d.rd != nil {
+				// The number may continue in data that has not been read yet.
+				if ferr := d.fetch(); ferr != nil {
+					if ferr == io.ErrUnexpectedEOF {
+						break // the number is terminated by the end of input
+					}
+					return n, d.newSyntacticError(pos+n, ferr)
+				}
+				continue
+			}
+		case '{', '}', '[', ']':
+			n = 1
+		default:
+			where := "at start of value"
+			if d.Tokens.NeedObjectName() {
+				where = `at start of string (expecting '"')`
+			}
+			return 0, d.newSyntacticError(pos, jsonwire.NewInvalidCharacterError(b, where))
+		}
+		if err != io.ErrUnexpectedEOF {
+			break
+		}
+		if ferr := d.fetch(); ferr != nil {
+			return n, d.newSyntacticError(pos+n, ferr)
+		}
+	}
+	if err != nil {
+		return n, d.newSyntacticError(pos+n, err)
+	}
+
+	allowDupNames := d.Flags.Get(jsonflags.AllowDuplicateNames)
+	switch k {
+	case 'n', 'f', 't', '0':
+		err = d.Tokens.appendValue()
+	case '"':
+		if d.Tokens.NeedObjectName() {
+			b := d.unread()[pos : pos+n]
+			name := b[len(`"`) : n-len(`"`)]
+			if !flags.IsVerbatim() {
+				d.nameBuf, _ = jsonwire.AppendUnquote(d.nameBuf[:0], b)
+				name = d.nameBuf
+			}
+			if err := d.processName(name, allowDupNames); err != nil {
+				ptr := d.appendStackPointer(nil, +1)
+				ptr = appendEscapePointerName(append(ptr, '/'), name)
+				return n, &SyntacticError{
+					ByteOffset:  d.previousOffsetEnd() + int64(pos),
+					JSONPointer: Pointer(ptr),
+					Err:         err,
+				}
+			}
+		}
+		err = d.Tokens.appendString()
+	case '{':
+		err = d.pushObject(allowDupNames)
+	case '}':
+		err = d.popObject(allowDupNames)
+	case '[':
+		err = d.Tokens.pushArray()
+	case ']':
+		err = d.Tokens.popArray()
+	}
+	if err != nil {
+		return n, d.newSyntacticError(pos, err)
+	}
+	return n, nil
+}
+
+// PeekKind retrieves the next token kind, but does not advance the read offset.
+//
+// It returns 0 if an error occurs, in which case the error is reported
+// by the next read call. It is the caller's responsibility to eventually
+// follow up a PeekKind call with a read call.
+func (d *Decoder) PeekKind() Kind {
+	return d.s.PeekKind()
+}
+
+func (d *decoderState) PeekKind() Kind {
+	pos, err := d.skipToToken(0)
+	if err != nil {
+		return invalidKind
+	}
+	switch k := Kind(d.unread()[pos]).normalize(); k {
+	case 'n', 'f', 't', '"', '0', '{', '}', '[', ']':
+		return k
+	}
+	return invalidKind
+}
+
+// SkipValue is semantically equivalent to calling [Decoder.ReadValue] and discarding
+// the result except that memory is not wasted trying to hold the entire result.
+func (d *Decoder) SkipValue() error {
+	return d.s.SkipValue()
+}
+
+func (d *decoderState) SkipValue() error {
+	switch d.PeekKind() {
+	case '{', '[':
+		// For JSON objects and arrays, keep skipping all tokens
+		// until the depth matches the starting depth.
+		depth := d.Tokens.Depth()
+		for {
+			if err := d.skipToken(); err != nil {
+				return err
+			}
+			if depth >= d.Tokens.Depth() {
+				return nil
+			}
+		}
+	default:
+		// Trying to skip a value when the next token is a '}' or ']'
+		// will result in an error being returned here.
+		var flags jsonwire.ValueFlags
+		_, err := d.ReadValue(&flags)
+		return err
+	}
+}
+
+// skipToken reads and discards the next token.
+func (d *decoderState) skipToken() error {
+	pos, err := d.skipToToken(0)
+	if err != nil {
+		return err
+	}
+	var flags jsonwire.ValueFlags
+	n, err := d.consumeToken(pos, &flags)
+	if err != nil {
+		return err
+	}
+	d.prevStart = d.prevEnd + pos
+	d.prevEnd = d.prevStart + n
+	return nil
+}
+
+// ReadToken reads the next [Token], advancing the read offset.
+// The returned token is only valid until the next Peek, Read, or Skip call.
+// It returns [io.EOF] if there are no more tokens.
+func (d *Decoder) ReadToken() (Token, error) {
+	return d.s.ReadToken()
+}
+
+func (d *decoderState) ReadToken() (Token, error) {
+	pos, err := d.skipToToken(0)
+	if err != nil {
+		return Token{}, err
+	}
+	var flags jsonwire.ValueFlags
+	n, err := d.consumeToken(pos, &flags)
+	if err != nil {
+		return Token{}, err
+	}
+	d.prevStart = d.prevEnd + pos
+	d.prevEnd = d.prevStart + n
+	b := d.buf[d.prevStart:d.prevEnd]
+	switch b[0] {
+	case 'n':
+		return Null, nil
+	case 'f':
+		return False, nil
+	case 't':
+		return True, nil
+	case '"':
+		if flags.IsVerbatim() {
+			return String(string(b[len(`"`) : n-len(`"`)])), nil
+		}
+		s, _ := jsonwire.AppendUnquote(nil, b)
+		return String(string(s)), nil
+	case '{':
+		return BeginObject, nil
+	case '}':
+		return EndObject, nil
+	case '[':
+		return BeginArray, nil
+	case ']':
+		return EndArray, nil
+	default:
+		return rawNumber(b), nil
+	}
+}
+
+// ReadValue returns the next raw JSON value, advancing the read offset.
+// The value is stripped of any leading or trailing whitespace and
+// contains the exact bytes of the input, which may contain invalid UTF-8
+// if [AllowInvalidUTF8] is specified.
+//
+// The returned value is only valid until the next Peek, Read, or Skip call and
+// may not be mutated while the Decoder remains in use.
+// If the decoder is currently at the end token for an object or array,
+// then it reports a [SyntacticError] and the internal state remains unchanged.
+// It returns [io.EOF] if there are no more values.
+func (d *Decoder) ReadValue() (Value, error) {
+	var flags jsonwire.ValueFlags
+	return d.s.ReadValue(&flags)
+}
+
+func (d *decoderState) ReadValue(flags *jsonwire.ValueFlags) (Value, error) {
+	start, err := d.skipToToken(0)
+	if err != nil {
+		return nil, err
+	}
+	if b := d.unread(); b[start] == '}' || b[start] == ']' {
+		return nil, d.newSyntacticError(start, jsonwire.NewInvalidCharacterError(b[start:], "at start of value"))
+	}
+
+	// Consume every token of the value, restoring the state on error.
+	ss := d.snapshot()
+	depth := d.Tokens.Depth()
+	pos := start
+	for {
+		var vf jsonwire.ValueFlags
+		n, err := d.consumeToken(pos, &vf)
+		if err != nil {
+			d.restore(ss, d.Flags.Get(jsonflags.AllowDuplicateNames))
+			return nil, err
+		}
+		flags.Join(vf)
+		pos += n
+		if d.Tokens.Depth() == depth {
+			break
+		}
+		if pos, err = d.skipToToken(pos); err != nil {
+			d.restore(ss, d.Flags.Get(jsonflags.AllowDuplicateNames))
+			return nil, err
+		}
+	}
+	d.prevStart = d.prevEnd + start
+	d.prevEnd += pos
+	return d.buf[d.prevStart:d.prevEnd], nil
+}
+
+// CheckEOF verifies that the input has no more data.
+func (d *decoderState) CheckEOF() error {
+	switch pos, err := d.skipWhitespace(0); err {
+	case nil:
+		b := d.unread()
+		return d.newSyntacticError(pos, jsonwire.NewInvalidCharacterError(b[pos:], "after top-level value"))
+	case io.ErrUnexpectedEOF:
+		return nil
+	default:
+		return err
+	}
+}
+
+// InputOffset returns the current input byte offset. It gives the location
+// of the next byte immediately after the most recently returned token or value.
+// The number of bytes actually read from the underlying [io.Reader] may be more
+// than this offset due to internal buffering effects.
+func (d *Decoder) InputOffset() int64 {
+	return d.s.previousOffsetEnd()
+}
+
+// UnreadBuffer returns the data remaining in the unread buffer,
+// which may contain zero or more bytes.
+// The returned buffer must not be mutated while Decoder continues to be used.
+// The buffer contents are valid until the next Peek, Read, or Skip call.
+func (d *Decoder) UnreadBuffer() []byte {
+	return d.s.unread()
+}
+
+// StackDepth returns the depth of the state machine for read JSON data.
+// Each level on the stack represents a nested JSON object or array.
+// It is incremented whenever an [BeginObject] or [BeginArray] token is encountered
+// and decremented whenever an [EndObject] or [EndArray] token is encountered.
+// The depth is zero-indexed, where zero represents the top-level JSON value.
+func (d *Decoder) StackDepth() int {
+	// NOTE: Keep in sync with Encoder.StackDepth.
+	return d.s.Tokens.Depth() - 1
+}
+
+// StackIndex returns information about the specified stack level.
+// It must be a number between 0 and [Decoder.StackDepth], inclusive.
+// For each level, it reports the kind:
+//
+//   - 0 for a level of zero,
+//   - '{' for a level representing a JSON object, and
+//   - '[' for a level representing a JSON array.
+//
+// It also reports the length of that JSON object or array.
+// Each name and value in a JSON object is counted separately,
+// so the effective number of members would be half the length.
+// A complete JSON object must have an even length.
+func (d *Decoder) StackIndex(i int) (Kind, int64) {
+	// NOTE: Keep in sync with Encoder.StackIndex.
+	se := d.s.Tokens.index(i)
+	return se.kind, se.length
+}
+
+// StackPointer returns a JSON Pointer (RFC 6901) to the most recently read value.
+func (d *Decoder) StackPointer() Pointer {
+	return d.s.stackPointer(-1)
+}
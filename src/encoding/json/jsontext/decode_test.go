@@ -0,0 +1,824 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+func TestDecoderTokens(t *testing.T) {
+	const in = ` { "name" : "gopher", "age" : 13.5, "tags" : [ true, false, null ], "nested" : { } } `
+	want := []Token{
+		BeginObject,
+		String("name"), String("gopher"),
+		String("age"), Float(13.5),
+		String("tags"), BeginArray, True, False, Null, EndArray,
+		String("nested"), BeginObject, EndObject,
+		EndObject,
+	}
+
+	// Reading one byte at a time must not change the result.
+	for _, r := range []io.Reader{strings.NewReader(in), iotest.OneByteReader(strings.NewReader(in))} {
+		dec := NewDecoder(r)
+		var got []Token
+		for {
+			tok, err := dec.ReadToken()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("ReadToken error: %v", err)
+			}
+			got = append(got, tok.Clone())
+		}
+		if len(got) != len(want) {
+			t.Fatalf("ReadToken returned %d tokens, want %d", len(got), len(want))
+		}
+		for i := range got {
+			if got[i].Kind() != want[i].Kind() || got[i].String() != want[i].String() {
+				t.Errorf("token %d = %v, want %v", i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestDecoderValues(t *testing.T) {
+	const in = `1 "two" [3] {"four":4} null`
+	want := []string{`1`, `"two"`, `[3]`, `{"four":4}`, `null`}
+	dec := NewDecoder(strings.NewReader(in))
+	for _, w := range want {
+		val, err := dec.ReadValue()
+		if err != nil {
+			t.Fatalf("ReadValue error: %v", err)
+		}
+		if string(val) != w {
+			t.Errorf("ReadValue = %s, want %s", val, w)
+		}
+	}
+	if _, err := dec.ReadValue(); err != io.EOF {
+		t.Errorf("ReadValue error = %v, want io.EOF", err)
+	}
+}
+
+// TestDecoderStreamArray checks that a large array can be processed
+// one element at a time without reading the entire array as a value.
+func TestDecoderStreamArray(t *testing.T) {
+	const n = 10000
+	in := "[" + strings.Repeat(`{"x":1},`, n-1) + `{"x":1}]`
+	dec := NewDecoder(strings.NewReader(in))
+	if tok, err := dec.ReadToken(); err != nil || tok.Kind() != '[' {
+		t.Fatalf("ReadToken = %v, %v, want '['", tok, err)
+	}
+	var count int
+	for dec.PeekKind() != ']' {
+		if err := dec.SkipValue(); err != nil {
+			t.Fatalf("SkipValue error: %v", err)
+		}
+		count++
+		if _, length := dec.StackIndex(dec.StackDepth()); length != int64(count) {
+			t.Fatalf("StackIndex length = %d, want %d", length, count)
+		}
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		t.Fatalf("ReadToken error: %v", err)
+	}
+	if count != n {
+		t.Errorf("got %d elements, want %d", count, n)
+	}
+	if got := dec.InputOffset(); got != int64(len(in)) {
+		t.Errorf("InputOffset = %d, want %d", got, len(in))
+	}
+}
+
+func TestDecoderStackPointer(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{"a":[0,{"b/c":{"~d":1}}]}`))
+	var got []Pointer
+	for {
+		if _, err := dec.ReadToken(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatalf("ReadToken error: %v", err)
+		}
+		got = append(got, dec.StackPointer())
+	}
+	want := []Pointer{"", "/a", "/a", "/a/0", "/a/1", "/a/1/b~1c", "/a/1/b~1c", "/a/1/b~1c/~0d", "/a/1/b~1c/~0d", "/a/1/b~1c", "/a/1", "/a", ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StackPointer:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		opts    []Options
+		wantErr error
+		offset  int64
+		pointer Pointer
+	}{{
+		name:   "InvalidCharacter",
+		in:     `{"a":[1,2,x]}`,
+		offset: 10, pointer: "/a/2",
+	}, {
+		name:    "DuplicateName",
+		in:      `{"a":1,"a":2}`,
+		wantErr: ErrDuplicateName,
+		offset:  7, pointer: "/a",
+	}, {
+		name: "AllowDuplicateNames",
+		in:   `{"a":1,"a":2}`,
+		opts: []Options{AllowDuplicateNames(true)},
+	}, {
+		name:    "InvalidUTF8",
+		in:      "[\"\xff\"]",
+		wantErr: jsonwire.ErrInvalidUTF8,
+		offset:  2, pointer: "/0",
+	}, {
+		name: "AllowInvalidUTF8",
+		in:   "[\"\xff\"]",
+		opts: []Options{AllowInvalidUTF8(true)},
+	}, {
+		name:    "Truncated",
+		in:      `{"a":[1,2`,
+		wantErr: io.ErrUnexpectedEOF,
+		offset:  9, pointer: "/a/2",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(tt.in), tt.opts...)
+			_, err := dec.ReadValue()
+			if tt.offset == 0 && tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ReadValue error: %v", err)
+				}
+				return
+			}
+			var serr *SyntacticError
+			if !errors.As(err, &serr) {
+				t.Fatalf("ReadValue error = %v, want SyntacticError", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadValue error = %v, want %v", err, tt.wantErr)
+			}
+			if serr.ByteOffset != tt.offset || serr.JSONPointer != tt.pointer {
+				t.Errorf("ReadValue error at (%d, %q), want (%d, %q)", serr.ByteOffset, serr.JSONPointer, tt.offset, tt.pointer)
+			}
+		})
+	}
+}
+
+func TestDecoderTokenSequences(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		opts []Options
+		want []string // kind followed by the String of each token
+	}{{
+		name: "Empty",
+		in:   " \t\r\n",
+	}, {
+		name: "Literals",
+		in:   `null false true`,
+		want: []string{"null", "false", "true"},
+	}, {
+		name: "Numbers",
+		in:   `0 -0 1.50 -1e+3 2E-7 12345678901234567890123`,
+		want: []string{"0:0", "0:-0", "0:1.50", "0:-1e+3", "0:2E-7", "0:12345678901234567890123"},
+	}, {
+		name: "Strings",
+		in:   `"" "hello" "é\n\t\"\\\/" "😀" "日本"`,
+		want: []string{`":`, `":hello`, "\":é\n\t\"\\/", "\":\U0001f600", "\":日本"},
+	}, {
+		name: "EmptyComposites",
+		in:   `{}[]{ }[ ]`,
+		want: []string{"{", "}", "[", "]", "{", "}", "[", "]"},
+	}, {
+		name: "NestedComposites",
+		in:   `[{"a":[{}]},[[]]]`,
+		want: []string{"[", "{", `":a`, "[", "{", "}", "]", "}", "[", "[", "]", "]", "]"},
+	}, {
+		name: "EscapedNames",
+		in:   `{"a":1,"ab":2}`,
+		want: []string{"{", `":a`, "0:1", `":ab`, "0:2", "}"},
+	}, {
+		name: "Whitespace",
+		in:   "\n{\n\t\"k\" \r: [ 1 ,\n2 ] }\n",
+		want: []string{"{", `":k`, "[", "0:1", "0:2", "]", "}"},
+	}, {
+		name: "DuplicateNamesInSeparateObjects",
+		in:   `[{"a":1},{"a":2}] {"a":{"a":3}}`,
+		want: []string{"[", "{", `":a`, "0:1", "}", "{", `":a`, "0:2", "}", "]", "{", `":a`, "{", `":a`, "0:3", "}", "}"},
+	}, {
+		name: "AllowDuplicateNames",
+		in:   `{"a":1,"a":2}`,
+		opts: []Options{AllowDuplicateNames(true)},
+		want: []string{"{", `":a`, "0:1", `":a`, "0:2", "}"},
+	}, {
+		name: "AllowInvalidUTF8",
+		in:   "[\"\xff\", \"a\xc0b\"]",
+		opts: []Options{AllowInvalidUTF8(true)},
+		want: []string{"[", "\":�", "\":a�b", "]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range []io.Reader{strings.NewReader(tt.in), iotest.OneByteReader(strings.NewReader(tt.in))} {
+				dec := NewDecoder(r, tt.opts...)
+				var got []string
+				for {
+					tok, err := dec.ReadToken()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("ReadToken error: %v", err)
+					}
+					switch tok.Kind() {
+					case '"', '0':
+						got = append(got, string(tok.Kind())+":"+tok.String())
+					default:
+						got = append(got, tok.String())
+					}
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ReadToken:\n\tgot:  %q\n\twant: %q", got, tt.want)
+				}
+				if got := dec.InputOffset(); got != int64(len(strings.TrimRight(tt.in, " \t\r\n"))) {
+					t.Errorf("InputOffset = %d, want %d", got, len(strings.TrimRight(tt.in, " \t\r\n")))
+				}
+			}
+		})
+	}
+}
+
+func TestDecoderSyntacticErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		opts    []Options
+		wantErr string
+		offset  int64
+		pointer Pointer
+
+		// wantValueErr is the error reported by ReadValue
+		// if it differs from the one reported by ReadToken.
+		wantValueErr string
+	}{{
+		name:         "TopLevelEndObject",
+		in:           ` }`,
+		wantErr:      `jsontext: mismatching structural token for object or array after offset 1`,
+		wantValueErr: `jsontext: invalid character '}' at start of value after offset 1`,
+		offset:       1,
+	}, {
+		name:         "TopLevelEndArray",
+		in:           `]`,
+		wantErr:      `jsontext: mismatching structural token for object or array`,
+		wantValueErr: `jsontext: invalid character ']' at start of value`,
+	}, {
+		name:    "InvalidLiteral",
+		in:      `[nul]`,
+		wantErr: `jsontext: invalid character ']' within literal null (expecting 'l') within "/0" after offset 4`,
+		offset:  4, pointer: "/0",
+	}, {
+		name:    "TruncatedLiteral",
+		in:      `tru`,
+		wantErr: `jsontext: unexpected EOF after offset 3`,
+		offset:  3,
+	}, {
+		name:    "LeadingZero",
+		in:      `[01]`,
+		wantErr: `jsontext: invalid character '1' after array element (expecting ',' or ']') within "/1" after offset 2`,
+		offset:  2, pointer: "/1",
+	}, {
+		name:    "MissingFraction",
+		in:      `{"n":1.}`,
+		wantErr: `jsontext: invalid character '}' within number (expecting digit) within "/n" after offset 7`,
+		offset:  7, pointer: "/n",
+	}, {
+		name:    "MissingExponent",
+		in:      `[1e+]`,
+		wantErr: `jsontext: invalid character ']' within number (expecting digit) within "/0" after offset 4`,
+		offset:  4, pointer: "/0",
+	}, {
+		name:    "LoneMinus",
+		in:      `-`,
+		wantErr: `jsontext: unexpected EOF after offset 1`,
+		offset:  1,
+	}, {
+		name:    "InvalidEscape",
+		in:      `["a\x"]`,
+		wantErr: "jsontext: invalid escape sequence `\\x` in string within \"/0\" after offset 3",
+		offset:  3, pointer: "/0",
+	}, {
+		name:    "UnpairedSurrogate",
+		in:      `["\ud83dA"]`,
+		wantErr: "jsontext: invalid escape sequence `\\ud83d` in string within \"/0\" after offset 2",
+		offset:  2, pointer: "/0",
+	}, {
+		name:    "UnpairedSurrogateBeforeEscape",
+		in:      `["\ud83d\n"]`,
+		wantErr: "jsontext: invalid escape sequence `\\ud83d` in string within \"/0\" after offset 2",
+		offset:  2, pointer: "/0",
+	}, {
+		name:    "InvalidSurrogatePair",
+		in:      `["\ud83d\u0041"]`,
+		wantErr: "jsontext: invalid surrogate pair `\\ud83d\\u0041` in string within \"/0\" after offset 2",
+		offset:  2, pointer: "/0",
+	}, {
+		name: "AllowInvalidSurrogate",
+		in:   `["\ud83dA"]`,
+		opts: []Options{AllowInvalidUTF8(true)},
+	}, {
+		name:    "ControlCharacter",
+		in:      "{\"a\":\"x\ty\"}",
+		wantErr: `jsontext: invalid character '\t' within string (expecting non-control character) within "/a" after offset 7`,
+		offset:  7, pointer: "/a",
+	}, {
+		name:    "InvalidUTF8",
+		in:      "{\"a\":{\"b\":\"x\xffy\"}}",
+		wantErr: `jsontext: invalid UTF-8 within "/a/b" after offset 12`,
+		offset:  12, pointer: "/a/b",
+	}, {
+		name:    "MissingColon",
+		in:      `{"a" 1}`,
+		wantErr: `jsontext: invalid character '1' after object name (expecting ':') within "/a" after offset 5`,
+		offset:  5, pointer: "/a",
+	}, {
+		name:    "MissingComma",
+		in:      `{"a":1 "b":2}`,
+		wantErr: `jsontext: invalid character '"' after object value (expecting ',' or '}') after offset 7`,
+		offset:  7,
+	}, {
+		name:    "MissingArrayComma",
+		in:      `[1 2]`,
+		wantErr: `jsontext: invalid character '2' after array element (expecting ',' or ']') within "/1" after offset 3`,
+		offset:  3, pointer: "/1",
+	}, {
+		name:    "TrailingObjectComma",
+		in:      `{"a":1,}`,
+		wantErr: `jsontext: invalid character '}' at start of string (expecting '"') after offset 7`,
+		offset:  7,
+	}, {
+		name:    "TrailingArrayComma",
+		in:      `[1,]`,
+		wantErr: `jsontext: invalid character ']' at start of value within "/1" after offset 3`,
+		offset:  3, pointer: "/1",
+	}, {
+		name:    "NonStringName",
+		in:      `{"a":1,2:3}`,
+		wantErr: `jsontext: object member name must be a string after offset 7`,
+		offset:  7,
+	}, {
+		name:    "NonStringNameObject",
+		in:      `{{}}`,
+		wantErr: `jsontext: object member name must be a string after offset 1`,
+		offset:  1,
+	}, {
+		name:    "NonStringNameInvalid",
+		in:      `{x}`,
+		wantErr: `jsontext: invalid character 'x' at start of string (expecting '"') after offset 1`,
+		offset:  1,
+	}, {
+		name:    "MissingValue",
+		in:      `{"a"}`,
+		wantErr: `jsontext: missing value after object name within "/a" after offset 4`,
+		offset:  4, pointer: "/a",
+	}, {
+		name:    "MismatchedObjectEnd",
+		in:      `[1}`,
+		wantErr: `jsontext: mismatching structural token for object or array within "/1" after offset 2`,
+		offset:  2, pointer: "/1",
+	}, {
+		name:    "MismatchedArrayEnd",
+		in:      `{"a":{}]`,
+		wantErr: `jsontext: mismatching structural token for object or array after offset 7`,
+		offset:  7,
+	}, {
+		name:    "DuplicateName",
+		in:      `{"a":{"x":1,"y":2,"x":3}}`,
+		wantErr: `jsontext: duplicate object member name "x" within "/a" after offset 18`,
+		offset:  18, pointer: "/a/x",
+	}, {
+		name:    "DuplicateEscapedName",
+		in:      `{"a/b":1,"a\/b":2}`,
+		wantErr: `jsontext: duplicate object member name "a/b" after offset 9`,
+		offset:  9, pointer: "/a~1b",
+	}, {
+		name:    "DuplicateNameInLargeObject",
+		in:      `{` + largeObjectMembers(40) + `,"n39":0}`,
+		wantErr: `jsontext: duplicate object member name "n39" after offset 311`,
+		offset:  311, pointer: "/n39",
+	}, {
+		name:    "TruncatedString",
+		in:      `{"a":"xyz`,
+		wantErr: `jsontext: unexpected EOF within "/a" after offset 9`,
+		offset:  9, pointer: "/a",
+	}, {
+		name:    "TruncatedName",
+		in:      `{"ab`,
+		wantErr: `jsontext: unexpected EOF after offset 4`,
+		offset:  4,
+	}, {
+		name:    "TruncatedAfterName",
+		in:      `{"ab"`,
+		wantErr: `jsontext: unexpected EOF within "/ab" after offset 5`,
+		offset:  5, pointer: "/ab",
+	}, {
+		name:    "TruncatedAfterComma",
+		in:      `[true,`,
+		wantErr: `jsontext: unexpected EOF within "/1" after offset 6`,
+		offset:  6, pointer: "/1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The error must not depend on how the input is read.
+			for _, mode := range []struct {
+				readValue bool
+				oneByte   bool
+			}{{false, false}, {false, true}, {true, false}, {true, true}} {
+				var r io.Reader = strings.NewReader(tt.in)
+				if mode.oneByte {
+					r = iotest.OneByteReader(r)
+				}
+				dec := NewDecoder(r, tt.opts...)
+				var err error
+				wantErr := tt.wantErr
+				if mode.readValue {
+					_, err = dec.ReadValue()
+					if tt.wantValueErr != "" {
+						wantErr = tt.wantValueErr
+					}
+				} else {
+					for err == nil {
+						_, err = dec.ReadToken()
+					}
+				}
+				if wantErr == "" {
+					if err != nil && err != io.EOF {
+						t.Fatalf("read error: %v", err)
+					}
+					continue
+				}
+				var serr *SyntacticError
+				if !errors.As(err, &serr) {
+					t.Fatalf("read error = %v, want SyntacticError", err)
+				}
+				if err.Error() != wantErr {
+					t.Errorf("read error:\n\tgot:  %s\n\twant: %s", err, wantErr)
+				}
+				if serr.ByteOffset != tt.offset || serr.JSONPointer != tt.pointer {
+					t.Errorf("read error at (%d, %q), want (%d, %q)", serr.ByteOffset, serr.JSONPointer, tt.offset, tt.pointer)
+				}
+			}
+		})
+	}
+}
+
+// largeObjectMembers returns n distinct object members
+// named "n0" through "n<n-1>", each with a value of zero.
+func largeObjectMembers(n int) string {
+	var b strings.Builder
+	for i := range n {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(`"n` + strconv.Itoa(i) + `":0`)
+	}
+	return b.String()
+}
+
+func TestDecoderMaxDepth(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		in      string
+		wantErr bool
+		offset  int64
+	}{
+		{"AtLimit", strings.Repeat("[", maxNestingDepth) + strings.Repeat("]", maxNestingDepth), false, 0},
+		{"ArraysBeyondLimit", strings.Repeat("[", maxNestingDepth+1), true, maxNestingDepth},
+		{"ObjectsBeyondLimit", strings.Repeat(`{"":`, maxNestingDepth+1), true, 4 * maxNestingDepth},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDecoder(strings.NewReader(tt.in)).SkipValue()
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("SkipValue error: %v", err)
+				}
+				return
+			}
+			var serr *SyntacticError
+			if !errors.As(err, &serr) || serr.Err != errMaxDepth {
+				t.Fatalf("SkipValue error = %v, want %v", err, errMaxDepth)
+			}
+			if serr.ByteOffset != tt.offset {
+				t.Errorf("SkipValue error offset = %d, want %d", serr.ByteOffset, tt.offset)
+			}
+		})
+	}
+}
+
+func TestDecoderPeekKind(t *testing.T) {
+	const in = ` {"a" : [ 1 , "x" , null , true , false , { } ] } 3`
+	dec := NewDecoder(strings.NewReader(in))
+	want := "{\"[0\"ntf{}]}0"
+	for i := range len(want) {
+		// Peeking is idempotent and does not advance the offset.
+		offset := dec.InputOffset()
+		if k := dec.PeekKind(); k != Kind(want[i]) {
+			t.Fatalf("PeekKind #%d = %v, want %v", i, k, Kind(want[i]))
+		}
+		if k := dec.PeekKind(); k != Kind(want[i]) {
+			t.Fatalf("PeekKind #%d = %v, want %v", i, k, Kind(want[i]))
+		}
+		if got := dec.InputOffset(); got != offset {
+			t.Fatalf("InputOffset after PeekKind = %d, want %d", got, offset)
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			t.Fatalf("ReadToken error: %v", err)
+		}
+	}
+	if k := dec.PeekKind(); k != 0 {
+		t.Errorf("PeekKind at EOF = %v, want 0", k)
+	}
+	if _, err := dec.ReadToken(); err != io.EOF {
+		t.Errorf("ReadToken error = %v, want io.EOF", err)
+	}
+
+	// An invalid token is reported by the next read.
+	dec = NewDecoder(strings.NewReader(`[1,x]`))
+	dec.ReadToken()
+	dec.ReadToken()
+	if k := dec.PeekKind(); k != 0 {
+		t.Errorf("PeekKind = %v, want 0", k)
+	}
+	if _, err := dec.ReadToken(); err == nil {
+		t.Errorf("ReadToken error = nil, want non-nil")
+	}
+}
+
+func TestDecoderSkipValue(t *testing.T) {
+	const in = `{"skip":{"a":[1,{"b":2}]},"keep":"v","also":[[],{}]}`
+	dec := NewDecoder(strings.NewReader(in))
+	if _, err := dec.ReadToken(); err != nil {
+		t.Fatalf("ReadToken error: %v", err)
+	}
+
+	// Skipping the name also skips only the name.
+	if err := dec.SkipValue(); err != nil {
+		t.Fatalf("SkipValue error: %v", err)
+	}
+	if got, want := dec.InputOffset(), int64(len(`{"skip"`)); got != want {
+		t.Errorf("InputOffset = %d, want %d", got, want)
+	}
+	if err := dec.SkipValue(); err != nil {
+		t.Fatalf("SkipValue error: %v", err)
+	}
+	if got, want := dec.InputOffset(), int64(len(`{"skip":{"a":[1,{"b":2}]}`)); got != want {
+		t.Errorf("InputOffset = %d, want %d", got, want)
+	}
+	if got := dec.StackPointer(); got != "/skip" {
+		t.Errorf("StackPointer = %q, want %q", got, "/skip")
+	}
+	if tok, err := dec.ReadToken(); err != nil || tok.String() != "keep" {
+		t.Fatalf("ReadToken = %v, %v, want %q", tok, err, "keep")
+	}
+	if err := dec.SkipValue(); err != nil {
+		t.Fatalf("SkipValue error: %v", err)
+	}
+	if val, err := dec.ReadValue(); err != nil || string(val) != `"also"` {
+		t.Fatalf("ReadValue = %s, %v, want %s", val, err, `"also"`)
+	}
+	if err := dec.SkipValue(); err != nil {
+		t.Fatalf("SkipValue error: %v", err)
+	}
+	if _, length := dec.StackIndex(1); length != 6 {
+		t.Errorf("StackIndex(1) length = %d, want 6", length)
+	}
+
+	// Skipping at the end of an object fails without changing the state.
+	if err := dec.SkipValue(); err == nil {
+		t.Fatalf("SkipValue error = nil, want non-nil")
+	}
+	if tok, err := dec.ReadToken(); err != nil || tok.Kind() != '}' {
+		t.Fatalf("ReadToken = %v, %v, want '}'", tok, err)
+	}
+	if err := dec.SkipValue(); err != io.EOF {
+		t.Errorf("SkipValue error = %v, want io.EOF", err)
+	}
+
+	// Skipping an invalid value reports the error.
+	dec = NewDecoder(strings.NewReader(`[{"a":1,"a":2}]`))
+	dec.ReadToken()
+	err := dec.SkipValue()
+	var serr *SyntacticError
+	if !errors.As(err, &serr) || serr.Err != ErrDuplicateName || serr.JSONPointer != "/0/a" {
+		t.Errorf("SkipValue error = %v, want duplicate name error at %q", err, "/0/a")
+	}
+}
+
+func TestDecoderReadValueRestoresState(t *testing.T) {
+	const in = `[1,{"a":"x","b":[true,nul]}]`
+	dec := NewDecoder(strings.NewReader(in))
+	for range 2 {
+		if _, err := dec.ReadToken(); err != nil {
+			t.Fatalf("ReadToken error: %v", err)
+		}
+	}
+	_, err := dec.ReadValue()
+	var serr *SyntacticError
+	if !errors.As(err, &serr) {
+		t.Fatalf("ReadValue error = %v, want SyntacticError", err)
+	}
+	if serr.ByteOffset != 25 || serr.JSONPointer != "/1/b/1" {
+		t.Errorf("ReadValue error at (%d, %q), want (25, %q)", serr.ByteOffset, serr.JSONPointer, "/1/b/1")
+	}
+	if got := dec.StackDepth(); got != 1 {
+		t.Errorf("StackDepth = %d, want 1", got)
+	}
+	if kind, length := dec.StackIndex(1); kind != '[' || length != 1 {
+		t.Errorf("StackIndex(1) = (%v, %d), want ([, 1)", kind, length)
+	}
+	if got := dec.StackPointer(); got != "/0" {
+		t.Errorf("StackPointer = %q, want %q", got, "/0")
+	}
+	if got := dec.InputOffset(); got != 2 {
+		t.Errorf("InputOffset = %d, want 2", got)
+	}
+}
+
+func TestDecoderReadValueAtEnd(t *testing.T) {
+	for _, in := range []string{`[]`, `{}`, `[1]`, `{"a":1}`} {
+		dec := NewDecoder(strings.NewReader(in))
+		for dec.PeekKind() != '}' && dec.PeekKind() != ']' {
+			if _, err := dec.ReadToken(); err != nil {
+				t.Fatalf("ReadToken error: %v", err)
+			}
+		}
+		offset := dec.InputOffset()
+		if _, err := dec.ReadValue(); err == nil {
+			t.Errorf("%s: ReadValue error = nil, want non-nil", in)
+		}
+		if got := dec.InputOffset(); got != offset {
+			t.Errorf("%s: InputOffset = %d, want %d", in, got, offset)
+		}
+		if tok, err := dec.ReadToken(); err != nil || (tok.Kind() != '}' && tok.Kind() != ']') {
+			t.Errorf("%s: ReadToken = %v, %v, want end token", in, tok, err)
+		}
+	}
+}
+
+func TestDecoderStackIndex(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{"a":[1,{"b":null}],"c":2} 3`))
+	type level struct {
+		kind   Kind
+		length int64
+	}
+	want := [][]level{
+		{{0, 1}, {'{', 0}},
+		{{0, 1}, {'{', 1}},
+		{{0, 1}, {'{', 2}, {'[', 0}},
+		{{0, 1}, {'{', 2}, {'[', 1}},
+		{{0, 1}, {'{', 2}, {'[', 2}, {'{', 0}},
+		{{0, 1}, {'{', 2}, {'[', 2}, {'{', 1}},
+		{{0, 1}, {'{', 2}, {'[', 2}, {'{', 2}},
+		{{0, 1}, {'{', 2}, {'[', 2}},
+		{{0, 1}, {'{', 2}},
+		{{0, 1}, {'{', 3}},
+		{{0, 1}, {'{', 4}},
+		{{0, 1}},
+		{{0, 2}},
+	}
+	for i, w := range want {
+		if _, err := dec.ReadToken(); err != nil {
+			t.Fatalf("ReadToken error: %v", err)
+		}
+		var got []level
+		for j := range dec.StackDepth() + 1 {
+			kind, length := dec.StackIndex(j)
+			got = append(got, level{kind, length})
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("after token %d, stack = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestDecoderUnreadBuffer(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{"a":1} [2]`))
+	if _, err := dec.ReadValue(); err != nil {
+		t.Fatalf("ReadValue error: %v", err)
+	}
+	if got := string(dec.UnreadBuffer()); got != ` [2]` {
+		t.Errorf("UnreadBuffer = %q, want %q", got, ` [2]`)
+	}
+	if got := dec.InputOffset(); got != 7 {
+		t.Errorf("InputOffset = %d, want 7", got)
+	}
+}
+
+func TestDecoderIOErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name    string
+		r       io.Reader
+		wantErr error
+		wantMsg string
+	}{{
+		name:    "ReadError",
+		r:       io.MultiReader(strings.NewReader(`[1,`), iotest.ErrReader(errBoom)),
+		wantErr: errBoom,
+		wantMsg: "jsontext: read error: boom",
+	}, {
+		name:    "NoProgress",
+		r:       io.MultiReader(strings.NewReader(`[1,`), zeroReader{}),
+		wantErr: io.ErrNoProgress,
+		wantMsg: "jsontext: read error: " + io.ErrNoProgress.Error(),
+	}, {
+		name:    "TimeoutAfterData",
+		r:       iotest.TimeoutReader(strings.NewReader(`[1,`)),
+		wantErr: iotest.ErrTimeout,
+		wantMsg: "jsontext: read error: timeout",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := NewDecoder(tt.r)
+			var err error
+			for err == nil {
+				_, err = dec.ReadToken()
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadToken error = %v, want %v", err, tt.wantErr)
+			}
+			var serr *SyntacticError
+			if errors.As(err, &serr) {
+				t.Errorf("ReadToken error = %v, want an I/O error", err)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("ReadToken error:\n\tgot:  %s\n\twant: %s", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// zeroReader is an io.Reader that never makes progress.
+type zeroReader struct{}
+
+func (zeroReader) Read([]byte) (int, error) { return 0, nil }
+
+func TestDecoderReset(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{"a":1,"a":2}`))
+	if _, err := dec.ReadValue(); err == nil {
+		t.Fatalf("ReadValue error = nil, want non-nil")
+	}
+
+	// Reset discards the state and the options.
+	dec.Reset(strings.NewReader(`  [1,2]`), AllowDuplicateNames(true))
+	if got := dec.InputOffset(); got != 0 {
+		t.Errorf("InputOffset = %d, want 0", got)
+	}
+	if got := dec.StackDepth(); got != 0 {
+		t.Errorf("StackDepth = %d, want 0", got)
+	}
+	if v, ok := jsonopts.GetOption(dec.Options(), AllowDuplicateNames); !ok || !v {
+		t.Errorf("GetOption(AllowDuplicateNames) = (%v, %v), want (true, true)", v, ok)
+	}
+	if val, err := dec.ReadValue(); err != nil || string(val) != `[1,2]` {
+		t.Errorf("ReadValue = %s, %v, want [1,2]", val, err)
+	}
+	dec.Reset(strings.NewReader(`{"a":1,"a":2}`))
+	if _, ok := jsonopts.GetOption(dec.Options(), AllowDuplicateNames); ok {
+		t.Errorf("GetOption(AllowDuplicateNames) reports presence after Reset")
+	}
+	if _, err := dec.ReadValue(); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("ReadValue error = %v, want %v", err, ErrDuplicateName)
+	}
+
+	for _, tt := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"NilDecoder", func() { (*Decoder)(nil).Reset(strings.NewReader("")) }, "jsontext: invalid nil Decoder"},
+		{"NilReader", func() { new(Decoder).Reset(nil) }, "jsontext: invalid nil io.Reader"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("Reset panic = %v, want %v", got, tt.want)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
@@ -0,0 +1,647 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"io"
+	"slices"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+)
+
+// Encoder is a streaming encoder from raw JSON tokens and values.
+// It is used to write a stream of top-level JSON values,
+// each terminated with a newline character.
+//
+// [Encoder.WriteToken] and [Encoder.WriteValue] calls may be interleaved.
+// For example, the following JSON value:
+//
+//	{"name":"value","array":[null,false,true,3.14159],"object":{"k":"v"}}
+//
+// can be composed with the following calls (ignoring errors for brevity):
+//
+//	e.WriteToken(BeginObject)        // {
+//	e.WriteToken(String("name"))     // "name"
+//	e.WriteToken(String("value"))    // "value"
+//	e.WriteValue(Value(`"array"`))   // "array"
+//	e.WriteToken(BeginArray)         // [
+//	e.WriteToken(Null)               // null
+//	e.WriteToken(False)              // false
+//	e.WriteValue(Value("true"))      // true
+//	e.WriteToken(Float(3.14159))     // 3.14159
+//	e.WriteToken(EndArray)           // ]
+//	e.WriteValue(Value(`"object"`))  // "object"
+//	e.WriteValue(Value(`{"k":"v"}`)) // {"k":"v"}
+//	e.WriteToken(EndObject)          // }
+//
+// The above is one of many possible sequence of calls and
+// may not represent the most sensible method to call for any given token/value.
+// For example, it is probably more common to call [Encoder.WriteToken] with a string
+// for object names.
+type Encoder struct {
+	s encoderState
+}
+
+// encoderState is the low-level state of Encoder.
+// It has exported fields and methods for use by the "json" package.
+type encoderState struct {
+	state
+	encodeBuffer
+	jsonopts.Struct
+
+	// SeenPointers is a map of pointers visited so far,
+	// used by the "json" package to detect cycles.
+	SeenPointers map[any]struct{}
+}
+
+// encodeBuffer is a buffer split into 2 segments:
+//
+//   - buf[0:len(buf)]        // written (but unflushed) portion of the buffer
+//   - buf[len(buf):cap(buf)] // unused portion of the buffer
+type encodeBuffer struct {
+	Buf []byte
+
+	// baseOffset is added to len(buf) to obtain the absolute offset
+	// relative to the start of io.Writer stream.
+	baseOffset int64
+
+	wr io.Writer
+
+	// unusedCache is the buffer returned by Encoder.AvailableBuffer.
+	unusedCache []byte
+}
+
+// flushThreshold is the buffer size above which the buffer is flushed
+// even in the middle of a top-level value.
+const flushThreshold = 16 << 10
+
+// NewEncoder constructs a new streaming encoder writing to w
+// configured with the provided options.
+// It flushes the internal buffer when the buffer is sufficiently full or
+// when a top-level value has been written.
+func NewEncoder(w io.Writer, opts ...Options) *Encoder {
+	e := new(Encoder)
+	e.Reset(w, opts...)
+	return e
+}
+
+// Reset resets an encoder such that it is writing afresh to w and
+// configured with the provided options. Reset must not be called on
+// an Encoder passed to the [encoding/json/v2.MarshalerTo.MarshalJSONTo] method
+// or the [encoding/json/v2.MarshalToFunc] function.
+func (e *Encoder) Reset(w io.Writer, opts ...Options) {
+	switch {
+	case e == nil:
+		panic("jsontext: invalid nil Encoder")
+	case w == nil:
+		panic("jsontext: invalid nil io.Writer")
+	case e.s.Flags.Get(jsonflags.WithinArshalCall):
+		panic("jsontext: cannot reset Encoder passed to json.MarshalerTo")
+	}
+	e.s.reset(nil, w, opts...)
+}
+
+func (e *encoderState) reset(b []byte, w io.Writer, opts ...Options) {
+	e.state.reset()
+	e.encodeBuffer = encodeBuffer{Buf: b, wr: w}
+	opts2 := jsonopts.Struct{} // avoid mutating e.Struct in case it is part of opts
+	opts2.Join(opts...)
+	e.Struct = opts2
+	clear(e.SeenPointers)
+}
+
+// Options returns the options used to construct the encoder and
+// may additionally contain semantic options passed to a
+// [encoding/json/v2.MarshalEncode] call.
+//
+// If operating within
+// a [encoding/json/v2.MarshalerTo.MarshalJSONTo] method call or
+// a [encoding/json/v2.MarshalToFunc] function call,
+// then the returned options are only valid within the call.
+func (e *Encoder) Options() Options {
+	return &e.s.Struct
+}
+
+// NeedFlush reports whether the buffer should be flushed,
+// which is the case at the end of a top-level value or
+// if the buffer has grown sufficiently large.
+func (e *encoderState) NeedFlush() bool {
+	return e.wr != nil && (e.Tokens.Depth() == 1 || len(e.Buf) > flushThreshold)
+}
+
+// Flush flushes the buffer to the underlying io.Writer.
+func (e *encoderState) Flush() error {
+	if e.wr == nil || len(e.Buf) == 0 {
+		return nil
+	}
+	n, err := e.wr.Write(e.Buf)
+	e.baseOffset += int64(n)
+	if err == nil && n < len(e.Buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		// Preserve the unwritten portion in case the caller retries.
+		e.Buf = append(e.Buf[:0], e.Buf[n:]...)
+		return &ioError{action: "write", err: err}
+	}
+	// Avoid retaining large buffers.
+	if cap(e.Buf) > 4*flushThreshold {
+		e.Buf = nil
+	} else {
+		e.Buf = e.Buf[:0]
+	}
+	return nil
+}
+
+// finishToken is called after a token or value has been appended.
+// It terminates each top-level value with a newline and
+// flushes the buffer if necessary.
+func (e *encoderState) finishToken() error {
+	if e.Tokens.Depth() == 1 && !e.Flags.Get(jsonflags.OmitTopLevelNewline) {
+		e.Buf = append(e.Buf, '\n')
+	}
+	if e.NeedFlush() {
+		return e.Flush()
+	}
+	return nil
+}
+
+// previousOffsetEnd returns the offset immediately after the output so far.
+func (e *encodeBuffer) previousOffsetEnd() int64 {
+	return e.baseOffset + int64(len(e.Buf))
+}
+
+// newSyntacticError wraps err in a SyntacticError for the next value,
+// as located at the end of the output so far.
+func (e *encoderState) newSyntacticError(err error) error {
+	return &SyntacticError{
+		ByteOffset:  e.previousOffsetEnd(),
+		JSONPointer: e.stackPointer(+1),
+		Err:         err,
+	}
+}
+
+// newDuplicateNameError returns a SyntacticError for a duplicate name
+// located at the specified offset.
+func (e *encoderState) newDuplicateNameError(offset int64, name []byte) error {
+	ptr := e.appendStackPointer(nil, +1)
+	ptr = appendEscapePointerName(append(ptr, '/'), name)
+	return &SyntacticError{
+		ByteOffset:  offset,
+		JSONPointer: Pointer(ptr),
+		Err:         ErrDuplicateName,
+	}
+}
+
+// appendWhitespace appends the delimiter and any optional whitespace
+// that precedes the next token of kind next.
+func (e *encoderState) appendWhitespace(b []byte, next Kind) []byte {
+	last := e.Tokens.last()
+	if last.kind == 0 {
+		return b // top-level values are terminated by a newline instead
+	}
+	multiline := e.Flags.Get(jsonflags.Multiline)
+	if next == '}' || next == ']' {
+		if multiline && last.length > 0 {
+			b = e.appendIndent(b, e.Tokens.Depth()-2)
+		}
+		return b
+	}
+	switch last.needDelim(next) {
+	case ':':
+		b = append(b, ':')
+		// Multiline output has a space after the colon unless specified.
+		if e.Flags.Get(jsonflags.SpaceAfterColon) || (multiline && !e.Flags.Has(jsonflags.SpaceAfterColon)) {
+			b = append(b, ' ')
+		}
+		return b
+	case ',':
+		b = append(b, ',')
+		if !multiline && e.Flags.Get(jsonflags.SpaceAfterComma) {
+			b = append(b, ' ')
+		}
+	}
+	if multiline {
+		b = e.appendIndent(b, e.Tokens.Depth()-1)
+	}
+	return b
+}
+
+// appendIndent appends a newline, the indent prefix,
+// and n copies of the indent.
+func (e *encoderState) appendIndent(b []byte, n int) []byte {
+	b = append(b, '\n')
+	b = append(b, e.IndentPrefix...)
+	indent := e.Indent
+	if !e.Flags.Has(jsonflags.Indent) {
+		indent = "\t" // default indent for multiline output
+	}
+	for range n {
+		b = append(b, indent...)
+	}
+	return b
+}
+
+// WriteToken writes the next token and advances the internal write offset.
+//
+// The provided token kind must be consistent with the JSON grammar.
+// For example, it is an error to provide a number when the encoder
+// is expecting an object name (which is always a string), or
+// to provide an end object delimiter when the encoder is finishing an array.
+// If the provided token is invalid, then it reports a [SyntacticError] and
+// the internal state remains unchanged. The offset reported
+// in [SyntacticError] will be relative to the [Encoder.OutputOffset].
+func (e *Encoder) WriteToken(t Token) error {
+	return e.s.WriteToken(t)
+}
+
+func (e *encoderState) WriteToken(t Token) error {
+	k := t.Kind()
+	b := e.Buf // use local variable to avoid mutating e in case of error
+
+	// Append any delimiters or optional whitespace.
+	b = e.appendWhitespace(b, k)
+
+	// Append the token to the output and to the state machine.
+	var err error
+	allowDupNames := e.Flags.Get(jsonflags.AllowDuplicateNames)
+	switch k {
+	case 'n':
+		b = append(b, "null"...)
+		err = e.Tokens.appendValue()
+	case 'f':
+		b = append(b, "false"...)
+		err = e.Tokens.appendValue()
+	case 't':
+		b = append(b, "true"...)
+		err = e.Tokens.appendValue()
+	case '"':
+		if b, err = jsonwire.AppendQuote(b, t.str, &e.Flags); err != nil {
+			break
+		}
+		if e.Tokens.NeedObjectName() {
+			if err = e.processName([]byte(t.str), allowDupNames); err != nil {
+				if err == ErrDuplicateName {
+					return e.newDuplicateNameError(e.previousOffsetEnd(), []byte(t.str))
+				}
+				break
+			}
+		}
+		err = e.Tokens.appendString()
+	case '0':
+		if err = e.Tokens.appendValue(); err != nil {
+			break
+		}
+		b = t.appendNumber(b, e.Flags.Get(jsonflags.CanonicalizeNumbers))
+	case '{':
+		b = append(b, '{')
+		err = e.pushObject(allowDupNames)
+	case '}':
+		b = append(b, '}')
+		err = e.popObject(allowDupNames)
+	case '[':
+		b = append(b, '[')
+		err = e.Tokens.pushArray()
+	case ']':
+		b = append(b, ']')
+		err = e.Tokens.popArray()
+	default:
+		err = errInvalidToken
+	}
+	if err != nil {
+		return e.newSyntacticError(err)
+	}
+
+	e.Buf = b
+	return e.finishToken()
+}
+
+// AppendRaw appends either a raw string (without double quotes) or number.
+// Specify safeASCII if the string output is guaranteed to be ASCII
+// without any characters (including '<', '>', and '&') that need escaping,
+// otherwise this will validate whether the string needs escaping.
+// The appended bytes for a JSON number must be valid.
+//
+// This is a specialized implementation of Encoder.WriteValue
+// that allows appending directly into the buffer.
+// It is only called from marshal logic in the "json" package.
+func (e *encoderState) AppendRaw(k Kind, safeASCII bool, appendFn func([]byte) ([]byte, error)) error {
+	b := e.Buf // use local variable to avoid mutating e in case of error
+
+	// Append any delimiters or optional whitespace.
+	b = e.appendWhitespace(b, k)
+
+	switch k {
+	case '"':
+		// Append directly into the encoder buffer by assuming that
+		// most of the time none of the characters need escaping.
+		b = append(b, '"')
+		pos := len(b)
+		var err error
+		if b, err = appendFn(b); err != nil {
+			return err
+		}
+
+		// Check whether we need to escape the string and if necessary
+		// copy it to a scratch buffer and then escape it back.
+		name := b[pos:]
+		if !safeASCII && jsonwire.NeedEscape(name) {
+			name = slices.Clone(name)
+			if b, err = jsonwire.AppendQuote(b[:pos-1], name, &e.Flags); err != nil {
+				return e.newSyntacticError(err)
+			}
+		} else {
+			b = append(b, '"')
+		}
+
+		if e.Tokens.NeedObjectName() {
+			if err := e.processName(name, e.Flags.Get(jsonflags.AllowDuplicateNames)); err != nil {
+				if err == ErrDuplicateName {
+					return e.newDuplicateNameError(e.previousOffsetEnd(), name)
+				}
+				return e.newSyntacticError(err)
+			}
+		}
+		if err := e.Tokens.appendString(); err != nil {
+			return e.newSyntacticError(err)
+		}
+	case '0':
+		if err := e.Tokens.appendValue(); err != nil {
+			return e.newSyntacticError(err)
+		}
+		var err error
+		if b, err = appendFn(b); err != nil {
+			return err
+		}
+	default:
+		panic("BUG: invalid kind")
+	}
+
+	e.Buf = b
+	return e.finishToken()
+}
+
+// WriteValue writes the next raw value and advances the internal write offset.
+// The Encoder does not simply copy the provided value verbatim, but
+// parses it to ensure that it is syntactically valid and reformats it
+// according to how the Encoder is configured to format whitespace and strings.
+// If [AllowInvalidUTF8] is specified, then any invalid UTF-8 is mangled
+// as the Unicode replacement character, U+FFFD.
+//
+// The provided value kind must be consistent with the JSON grammar
+// (see examples on [Encoder.WriteToken]). If the provided value is invalid,
+// then it reports a [SyntacticError] and the internal state remains unchanged.
+// The offset reported in [SyntacticError] will be relative to the
+// [Encoder.OutputOffset] plus the offset into v of any encountered syntax error.
+func (e *Encoder) WriteValue(v Value) error {
+	return e.s.WriteValue(v)
+}
+
+func (e *encoderState) WriteValue(v Value) error {
+	// Parse and reformat the value token by token,
+	// restoring the original state in case of an error.
+	ss := e.snapshot()
+	n0 := len(e.Buf)
+	if err := e.writeValue(v); err != nil {
+		e.restore(ss, e.Flags.Get(jsonflags.AllowDuplicateNames))
+		e.Buf = e.Buf[:n0]
+		return err
+	}
+	return e.finishToken()
+}
+
+func (e *encoderState) writeValue(v Value) error {
+	// The decoder only tracks the nesting within v in order to
+	// skip over delimiters, while e validates the grammar.
+	var d decoderState
+	d.reset(v, nil, AllowDuplicateNames(true), AllowInvalidUTF8(e.Flags.Get(jsonflags.AllowInvalidUTF8)))
+	allowDupNames := e.Flags.Get(jsonflags.AllowDuplicateNames)
+	depth := e.Tokens.Depth()
+
+	// newError reports an error at the offset relative to the unread
+	// portion of v, which is located after the output prior to v.
+	offset := e.previousOffsetEnd()
+	newError := func(off int, err error) error {
+		return &SyntacticError{
+			ByteOffset:  offset + int64(d.prevEnd+off),
+			JSONPointer: e.stackPointer(+1),
+			Err:         err,
+		}
+	}
+
+	for {
+		pos, err := d.skipToToken(0)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			} else if serr, ok := err.(*SyntacticError); ok {
+				err = serr.Err // relocate the error within the encoder output
+			}
+			return newError(pos, err)
+		}
+		b := d.unread()
+		k := Kind(b[pos]).normalize()
+		if (k == '}' || k == ']') && e.Tokens.Depth() == depth {
+			return newError(pos, jsonwire.NewInvalidCharacterError(b[pos:], "at start of value"))
+		}
+		e.Buf = e.appendWhitespace(e.Buf, k)
+		switch k {
+		case 'n', 'f', 't':
+			lit := literalString(k)
+			if n, err := jsonwire.ConsumeLiteral(b[pos:], lit); err != nil {
+				return newError(pos+n, err)
+			}
+			if err := e.Tokens.appendValue(); err != nil {
+				return newError(pos, err)
+			}
+			e.Buf = append(e.Buf, lit...)
+			d.Tokens.appendValue()
+			d.prevEnd += pos + len(lit)
+		case '"':
+			var n int
+			e.Buf, n, err = jsonwire.ReformatString(e.Buf, b[pos:], &e.Flags)
+			if err != nil {
+				return newError(pos+n, err)
+			}
+			if e.Tokens.NeedObjectName() {
+				name, _ := jsonwire.AppendUnquote(nil, b[pos:pos+n])
+				if err := e.processName(name, allowDupNames); err != nil {
+					if err == ErrDuplicateName {
+						return e.newDuplicateNameError(offset+int64(d.prevEnd+pos), name)
+					}
+					return newError(pos, err)
+				}
+			}
+			e.Tokens.appendString()
+			d.Tokens.appendString()
+			d.prevEnd += pos + n
+		case '0':
+			var n int
+			e.Buf, n, err = jsonwire.ReformatNumber(e.Buf, b[pos:], e.Flags.Get(jsonflags.CanonicalizeNumbers))
+			if err != nil {
+				return newError(pos+n, err)
+			}
+			if err := e.Tokens.appendValue(); err != nil {
+				return newError(pos, err)
+			}
+			d.Tokens.appendValue()
+			d.prevEnd += pos + n
+		case '{':
+			if err := e.pushObject(allowDupNames); err != nil {
+				return newError(pos, err)
+			}
+			e.Buf = append(e.Buf, '{')
+			d.Tokens.pushObject()
+			d.prevEnd += pos + 1
+		case '}':
+			if err := e.popObject(allowDupNames); err != nil {
+				return newError(pos, err)
+			}
+			e.Buf = append(e.Buf, '}')
+			d.Tokens.popObject()
+			d.prevEnd += pos + 1
+		case '[':
+			if err := e.Tokens.pushArray(); err != nil {
+				return newError(pos, err)
+			}
+			e.Buf = append(e.Buf, '[')
+			d.Tokens.pushArray()
+			d.prevEnd += pos + 1
+		case ']':
+			if err := e.Tokens.popArray(); err != nil {
+				return newError(pos, err)
+			}
+			e.Buf = append(e.Buf, ']')
+			d.Tokens.popArray()
+			d.prevEnd += pos + 1
+		default:
+			return newError(pos, jsonwire.NewInvalidCharacterError(b[pos:], "at start of value"))
+		}
+		if e.Tokens.Depth() == depth {
+			break
+		}
+	}
+
+	// Check for trailing data after the value.
+	b := d.unread()
+	if n := jsonwire.ConsumeWhitespace(b); n < len(b) {
+		return newError(n, jsonwire.NewInvalidCharacterError(b[n:], "after top-level value"))
+	}
+	return nil
+}
+
+// encoderCheckpoint is a position in the output of an encoder
+// together with the state at that position.
+type encoderCheckpoint struct {
+	ss         snapshot
+	n          int
+	baseOffset int64
+}
+
+// Checkpoint records the current output position and state so that
+// the "json" package can later omit an object member that it has
+// already written, using [encoderState.Rollback].
+func (e *encoderState) Checkpoint() encoderCheckpoint {
+	return encoderCheckpoint{e.snapshot(), len(e.Buf), e.baseOffset}
+}
+
+// OutputSince returns the output written since cp was recorded.
+// It reports false if the output has since been flushed.
+func (e *encoderState) OutputSince(cp encoderCheckpoint) ([]byte, bool) {
+	if e.baseOffset != cp.baseOffset || len(e.Buf) < cp.n {
+		return nil, false
+	}
+	return e.Buf[cp.n:], true
+}
+
+// Rollback discards all output and state changes since cp was recorded.
+// It must only be called at the same depth that cp was recorded at.
+// It reports false and changes nothing if the output has since been flushed.
+func (e *encoderState) Rollback(cp encoderCheckpoint) bool {
+	if _, ok := e.OutputSince(cp); !ok {
+		return false
+	}
+	e.restore(cp.ss, e.Flags.Get(jsonflags.AllowDuplicateNames))
+	e.Buf = e.Buf[:cp.n]
+	return true
+}
+
+// OutputOffset returns the current output byte offset. It gives the location
+// of the next byte immediately after the most recently written token or value.
+// The number of bytes actually written to the underlying [io.Writer] may be less
+// than this offset due to internal buffering effects.
+func (e *Encoder) OutputOffset() int64 {
+	return e.s.previousOffsetEnd()
+}
+
+// AvailableBuffer returns a zero-length buffer with a possible non-zero capacity.
+// This buffer is intended to be used to populate a [Value]
+// being passed to an immediately succeeding [Encoder.WriteValue] call.
+//
+// Example usage:
+//
+//	b := e.AvailableBuffer()
+//	b = append(b, '"')
+//	b = appendString(b, v) // append the string formatting of v
+//	b = append(b, '"')
+//	... := e.WriteValue(b)
+//
+// It is the user's responsibility to ensure that the value is valid JSON.
+func (e *Encoder) AvailableBuffer() []byte {
+	// NOTE: We don't return e.Buf[len(e.Buf):cap(e.Buf)] since WriteValue
+	// would need to take special care to avoid mangling the data while
+	// reformatting. Thus, we just return a different buffer.
+	if cap(e.s.unusedCache) < 64 {
+		e.s.unusedCache = make([]byte, 0, 64)
+	}
+	return e.s.unusedCache[:0]
+}
+
+// StackDepth returns the depth of the state machine for written JSON data.
+// Each level on the stack represents a nested JSON object or array.
+// It is incremented whenever an [BeginObject] or [BeginArray] token is encountered
+// and decremented whenever an [EndObject] or [EndArray] token is encountered.
+// The depth is zero-indexed, where zero represents the top-level JSON value.
+func (e *Encoder) StackDepth() int {
+	// NOTE: Keep in sync with Decoder.StackDepth.
+	return e.s.Tokens.Depth() - 1
+}
+
+// StackIndex returns information about the specified stack level.
+// It must be a number between 0 and [Encoder.StackDepth], inclusive.
+// For each level, it reports the kind:
+//
+//   - 0 for a level of zero,
+//   - '{' for a level representing a JSON object, and
+//   - '[' for a level representing a JSON array.
+//
+// It also reports the length of that JSON object or array.
+// Each name and value in a JSON object is counted separately,
+// so the effective number of members would be half the length.
+// A complete JSON object must have an even length.
+func (e *Encoder) StackIndex(i int) (Kind, int64) {
+	// NOTE: Keep in sync with Decoder.StackIndex.
+	se := e.s.Tokens.index(i)
+	return se.kind, se.length
+}
+
+// StackPointer returns a JSON Pointer (RFC 6901) to the most recently written value.
+func (e *Encoder) StackPointer() Pointer {
+	return e.s.stackPointer(-1)
+}
+
+// literalString returns the JSON literal for the kind 'n', 'f', or 't'.
+func literalString(k Kind) string {
+	switch k {
+	case 'n':
+		return "null"
+	case 'f':
+		return "false"
+	default:
+		return "true"
+	}
+}
@@ -0,0 +1,778 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"encoding/json/internal/jsonopts"
+)
+
+func TestEncoderTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		want string
+	}{{
+		name: "Compact",
+		want: `{"name":"gopher","age":13.5,"tags":[true,false,null],"big":18446744073709551615,"neg":-9223372036854775808}` + "\n",
+	}, {
+		name: "Indent",
+		opts: []Options{WithIndent("\t")},
+		want: "{\n\t\"name\": \"gopher\",\n\t\"age\": 13.5,\n\t\"tags\": [\n\t\ttrue,\n\t\tfalse,\n\t\tnull\n\t],\n\t\"big\": 18446744073709551615,\n\t\"neg\": -9223372036854775808\n}\n",
+	}, {
+		name: "SpaceAfterPunctuation",
+		opts: []Options{SpaceAfterColon(true), SpaceAfterComma(true)},
+		want: `{"name": "gopher", "age": 13.5, "tags": [true, false, null], "big": 18446744073709551615, "neg": -9223372036854775808}` + "\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf, tt.opts...)
+			for _, tok := range []Token{
+				BeginObject,
+				String("name"), String("gopher"),
+				String("age"), Float(13.5),
+				String("tags"), BeginArray, True, False, Null, EndArray,
+				String("big"), Uint(math.MaxUint64),
+				String("neg"), Int(math.MinInt64),
+				EndObject,
+			} {
+				if err := enc.WriteToken(tok); err != nil {
+					t.Fatalf("WriteToken error: %v", err)
+				}
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoderValues(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, v := range []string{`{ "a" : [ 1 , 2 ] }`, ` "x" `, `null`} {
+		if err := enc.WriteValue(Value(v)); err != nil {
+			t.Fatalf("WriteValue error: %v", err)
+		}
+	}
+	const want = "{\"a\":[1,2]}\n\"x\"\nnull\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+}
+
+func TestEncoderEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		want string
+	}{
+		{name: "Default", in: "<a&b> \u2028", want: "\"<a&b> \u2028\"\n"},
+		{name: "EscapeForHTML", opts: []Options{EscapeForHTML(true)}, in: "<a&b>", want: `"\u003ca\u0026b\u003e"` + "\n"},
+		{name: "EscapeForJS", opts: []Options{EscapeForJS(true)}, in: "\u2028\u2029", want: `"\u2028\u2029"` + "\n"},
+		{name: "AllowInvalidUTF8", opts: []Options{AllowInvalidUTF8(true)}, in: "\xff", want: "\"\ufffd\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf, tt.opts...).WriteToken(String(tt.in)); err != nil {
+				t.Fatalf("WriteToken error: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  []Token
+		opts    []Options
+		wantErr error
+	}{{
+		name:    "DuplicateName",
+		tokens:  []Token{BeginObject, String("a"), Null, String("a")},
+		wantErr: ErrDuplicateName,
+	}, {
+		name:    "NonStringName",
+		tokens:  []Token{BeginObject, Int(1)},
+		wantErr: ErrNonStringName,
+	}, {
+		name:    "MismatchedDelim",
+		tokens:  []Token{BeginArray, EndObject},
+		wantErr: errMismatchDelim,
+	}, {
+		name:    "InvalidUTF8",
+		tokens:  []Token{String("\xff")},
+		wantErr: errors.New("invalid UTF-8"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewEncoder(new(bytes.Buffer), tt.opts...)
+			var err error
+			for _, tok := range tt.tokens {
+				if err = enc.WriteToken(tok); err != nil {
+					break
+				}
+			}
+			var serr *SyntacticError
+			if !errors.As(err, &serr) {
+				t.Fatalf("WriteToken error = %v, want SyntacticError", err)
+			}
+			if serr.Err.Error() != tt.wantErr.Error() {
+				t.Errorf("WriteToken error = %v, want %v", serr.Err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncoderFormatting(t *testing.T) {
+	const in = `{"a":[1,{"b":[]},{}],"c":{"d":null}}`
+	tests := []struct {
+		name string
+		opts []Options
+		want string
+	}{{
+		name: "Default",
+		want: `{"a":[1,{"b":[]},{}],"c":{"d":null}}`,
+	}, {
+		name: "Multiline",
+		opts: []Options{Multiline(true)},
+		want: "{\n\t\"a\": [\n\t\t1,\n\t\t{\n\t\t\t\"b\": []\n\t\t},\n\t\t{}\n\t],\n\t\"c\": {\n\t\t\"d\": null\n\t}\n}",
+	}, {
+		name: "WithIndent",
+		opts: []Options{WithIndent("  ")},
+		want: "{\n  \"a\": [\n    1,\n    {\n      \"b\": []\n    },\n    {}\n  ],\n  \"c\": {\n    \"d\": null\n  }\n}",
+	}, {
+		name: "WithIndentPrefix",
+		opts: []Options{WithIndent(" "), WithIndentPrefix("\t")},
+		want: "{\n\t \"a\": [\n\t  1,\n\t  {\n\t   \"b\": []\n\t  },\n\t  {}\n\t ],\n\t \"c\": {\n\t  \"d\": null\n\t }\n\t}",
+	}, {
+		name: "WithIndentPrefixOnly",
+		opts: []Options{WithIndentPrefix("  ")},
+		want: "{\n  \t\"a\": [\n  \t\t1,\n  \t\t{\n  \t\t\t\"b\": []\n  \t\t},\n  \t\t{}\n  \t],\n  \t\"c\": {\n  \t\t\"d\": null\n  \t}\n  }",
+	}, {
+		name: "MultilineWithoutSpaceAfterColon",
+		opts: []Options{Multiline(true), SpaceAfterColon(false)},
+		want: "{\n\t\"a\":[\n\t\t1,\n\t\t{\n\t\t\t\"b\":[]\n\t\t},\n\t\t{}\n\t],\n\t\"c\":{\n\t\t\"d\":null\n\t}\n}",
+	}, {
+		name: "MultilineIgnoresSpaceAfterComma",
+		opts: []Options{Multiline(true), SpaceAfterComma(true)},
+		want: "{\n\t\"a\": [\n\t\t1,\n\t\t{\n\t\t\t\"b\": []\n\t\t},\n\t\t{}\n\t],\n\t\"c\": {\n\t\t\"d\": null\n\t}\n}",
+	}, {
+		name: "MultilineDisabled",
+		opts: []Options{WithIndent("\t"), Multiline(false)},
+		want: `{"a":[1,{"b":[]},{}],"c":{"d":null}}`,
+	}, {
+		name: "SpaceAfterColon",
+		opts: []Options{SpaceAfterColon(true)},
+		want: `{"a": [1,{"b": []},{}],"c": {"d": null}}`,
+	}, {
+		name: "SpaceAfterComma",
+		opts: []Options{SpaceAfterComma(true)},
+		want: `{"a":[1, {"b":[]}, {}], "c":{"d":null}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Writing the value and writing each token must be equivalent.
+			var buf1, buf2 bytes.Buffer
+			if err := NewEncoder(&buf1, tt.opts...).WriteValue(Value(in)); err != nil {
+				t.Fatalf("WriteValue error: %v", err)
+			}
+			dec := NewDecoder(strings.NewReader(in))
+			enc := NewEncoder(&buf2, tt.opts...)
+			for {
+				tok, err := dec.ReadToken()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadToken error: %v", err)
+				}
+				if err := enc.WriteToken(tok); err != nil {
+					t.Fatalf("WriteToken error: %v", err)
+				}
+			}
+			for _, got := range []string{buf1.String(), buf2.String()} {
+				if got != tt.want+"\n" {
+					t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, tt.want+"\n")
+				}
+			}
+		})
+	}
+}
+
+func TestEncoderWriteValueReformat(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		want string
+	}{
+		{name: "Whitespace", in: " \t\n[ 1 ,\r\n\"a\" , { \"b\" : true } ] ", want: `[1,"a",{"b":true}]`},
+		{name: "Numbers", in: `[0,-0,1.50,1E+2,-1e-07,12345678901234567890]`, want: `[0,-0,1.50,1E+2,-1e-07,12345678901234567890]`},
+		{name: "UnnecessaryEscapes", in: `"A\/é😀"`, want: "\"A/é\U0001f600\""},
+		{name: "NecessaryEscapes", in: `"\"\\\b\f\n\r\t\u0000\u001F"`, want: `"\"\\\b\f\n\r\t\u0000\u001f"`},
+		{name: "ShortEscapes", in: `"\u0008\u000c\u000a\u000d\u0009\"\\"`, want: `"\b\f\n\r\t\"\\"`},
+		{name: "EscapedNames", in: `{"a":1,"b\u0000":2}`, want: `{"a":1,"b\u0000":2}`},
+		{name: "EscapeForHTML", opts: []Options{EscapeForHTML(true)}, in: `"<a href=\"x\">&amp;</a>"`, want: `"\u003ca href=\"x\"\u003e\u0026amp;\u003c/a\u003e"`},
+		{name: "EscapeForJS", opts: []Options{EscapeForJS(true)}, in: "\"\u2028\\u2029\"", want: `"\u2028\u2029"`},
+		{name: "NoEscapeForJS", in: `"\u2028\u2029"`, want: "\"\u2028\u2029\""},
+		{name: "AllowInvalidUTF8", opts: []Options{AllowInvalidUTF8(true)}, in: "[\"\xff\",\"a\xc0\"]", want: "[\"�\",\"a�\"]"},
+		{name: "AllowDuplicateNames", opts: []Options{AllowDuplicateNames(true)}, in: `{"a":1,"a":2}`, want: `{"a":1,"a":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf, tt.opts...).WriteValue(Value(tt.in)); err != nil {
+				t.Fatalf("WriteValue error: %v", err)
+			}
+			if got := buf.String(); got != tt.want+"\n" {
+				t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestEncoderNumbers(t *testing.T) {
+	tests := []struct {
+		tok  Token
+		want string
+	}{
+		{Int(0), `0`},
+		{Int(-1), `-1`},
+		{Int(math.MaxInt64), `9223372036854775807`},
+		{Int(math.MinInt64), `-9223372036854775808`},
+		{Uint(0), `0`},
+		{Uint(math.MaxUint64), `18446744073709551615`},
+		{Float(0), `0`},
+		{Float(math.Copysign(0, -1)), `-0`},
+		{Float(1), `1`},
+		{Float(0.1), `0.1`},
+		{Float(-1.5), `-1.5`},
+		{Float(1e20), `100000000000000000000`},
+		{Float(1e21), `1e+21`},
+		{Float(1e-6), `0.000001`},
+		{Float(1e-7), `1e-7`},
+		{Float(123456789.125), `123456789.125`},
+		{Float(math.MaxFloat64), `1.7976931348623157e+308`},
+		{Float(math.SmallestNonzeroFloat64), `5e-324`},
+		{Float(math.NaN()), `"NaN"`},
+		{Float(math.Inf(+1)), `"Infinity"`},
+		{Float(math.Inf(-1)), `"-Infinity"`},
+		{rawNumber([]byte("1.50E+02")), `1.50E+02`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := NewEncoder(&buf).WriteToken(tt.tok); err != nil {
+			t.Errorf("WriteToken(%v) error: %v", tt.tok, err)
+			continue
+		}
+		if got := buf.String(); got != tt.want+"\n" {
+			t.Errorf("WriteToken(%v) = %q, want %q", tt.tok, got, tt.want+"\n")
+		}
+	}
+}
+
+func TestEncoderTokenErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Options
+		tokens  []Token
+		wantErr string
+		offset  int64
+		pointer Pointer
+	}{{
+		name:    "InvalidToken",
+		tokens:  []Token{BeginArray, {}},
+		wantErr: `jsontext: invalid jsontext.Token within "/0" after offset 1`,
+		offset:  1, pointer: "/0",
+	}, {
+		name:    "TopLevelEndArray",
+		tokens:  []Token{Null, EndArray},
+		wantErr: `jsontext: mismatching structural token for object or array after offset 5`,
+		offset:  5,
+	}, {
+		name:    "MissingValue",
+		tokens:  []Token{BeginObject, String("a"), EndObject},
+		wantErr: `jsontext: missing value after object name within "/a" after offset 4`,
+		offset:  4, pointer: "/a",
+	}, {
+		name:    "NonStringNameNumber",
+		tokens:  []Token{BeginObject, String("a"), Int(1), Int(2)},
+		wantErr: `jsontext: object member name must be a string after offset 6`,
+		offset:  6,
+	}, {
+		name:    "NonStringNameObject",
+		tokens:  []Token{BeginArray, BeginObject, BeginObject},
+		wantErr: `jsontext: object member name must be a string within "/0" after offset 2`,
+		offset:  2, pointer: "/0",
+	}, {
+		name:    "NonStringNameLiteral",
+		tokens:  []Token{BeginObject, True},
+		wantErr: `jsontext: object member name must be a string after offset 1`,
+		offset:  1,
+	}, {
+		name:    "MismatchedEndObject",
+		tokens:  []Token{BeginObject, String("a"), BeginArray, EndObject},
+		wantErr: `jsontext: mismatching structural token for object or array within "/a/0" after offset 6`,
+		offset:  6, pointer: "/a/0",
+	}, {
+		name:    "DuplicateNestedName",
+		tokens:  []Token{BeginArray, BeginObject, String("x"), Int(1), String("y"), Int(2), String("x")},
+		wantErr: `jsontext: duplicate object member name "x" within "/0" after offset 13`,
+		offset:  13, pointer: "/0/x",
+	}, {
+		name:    "DuplicateNameAfterTopLevelValues",
+		tokens:  []Token{Null, Null, BeginObject, String("a"), Int(1), String("a")},
+		wantErr: `jsontext: duplicate object member name "a" after offset 16`,
+		offset:  16, pointer: "/a",
+	}, {
+		name:   "DuplicateNamesInSeparateObjects",
+		tokens: []Token{BeginArray, BeginObject, String("a"), Null, EndObject, BeginObject, String("a"), Null, EndObject, EndArray},
+	}, {
+		name:   "AllowDuplicateNames",
+		opts:   []Options{AllowDuplicateNames(true)},
+		tokens: []Token{BeginObject, String("a"), Null, String("a"), Null, EndObject},
+	}, {
+		name:    "InvalidUTF8Name",
+		tokens:  []Token{BeginObject, String("a\xff")},
+		wantErr: `jsontext: invalid UTF-8 after offset 1`,
+		offset:  1,
+	}, {
+		name:   "AllowInvalidUTF8",
+		opts:   []Options{AllowInvalidUTF8(true)},
+		tokens: []Token{BeginObject, String("a\xff"), String("\xfe"), EndObject},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf, tt.opts...)
+			var err error
+			var last int
+			for i, tok := range tt.tokens {
+				if err = enc.WriteToken(tok); err != nil {
+					last = i
+					break
+				}
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("WriteToken error: %v", err)
+				}
+				return
+			}
+			var serr *SyntacticError
+			if !errors.As(err, &serr) {
+				t.Fatalf("WriteToken error = %v, want SyntacticError", err)
+			}
+			if last != len(tt.tokens)-1 {
+				t.Errorf("WriteToken failed at token %d, want %d", last, len(tt.tokens)-1)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("WriteToken error:\n\tgot:  %s\n\twant: %s", err, tt.wantErr)
+			}
+			if serr.ByteOffset != tt.offset || serr.JSONPointer != tt.pointer {
+				t.Errorf("WriteToken error at (%d, %q), want (%d, %q)", serr.ByteOffset, serr.JSONPointer, tt.offset, tt.pointer)
+			}
+			if got := enc.OutputOffset(); got != tt.offset {
+				t.Errorf("OutputOffset = %d, want %d", got, tt.offset)
+			}
+		})
+	}
+}
+
+func TestEncoderWriteValueErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Options
+		prefix  []Token
+		in      string
+		wantErr string
+		offset  int64
+		pointer Pointer
+	}{{
+		name:    "Empty",
+		in:      ` `,
+		wantErr: `jsontext: unexpected EOF after offset 1`,
+		offset:  1,
+	}, {
+		name:    "InvalidCharacter",
+		in:      `[1,x]`,
+		wantErr: `jsontext: invalid character 'x' at start of value within "/1" after offset 3`,
+		offset:  3, pointer: "/1",
+	}, {
+		name:    "InvalidCharacterAfterWhitespace",
+		in:      `[ 1 , x ]`,
+		wantErr: `jsontext: invalid character 'x' at start of value within "/1" after offset 6`,
+		offset:  6, pointer: "/1",
+	}, {
+		name:    "InvalidLiteral",
+		prefix:  []Token{BeginArray, Int(1)},
+		in:      `{"a":tru}`,
+		wantErr: `jsontext: invalid character '}' within literal true (expecting 'e') within "/1/a" after offset 10`,
+		offset:  10, pointer: "/1/a",
+	}, {
+		name:    "InvalidNumber",
+		prefix:  []Token{BeginObject, String("n")},
+		in:      `-x`,
+		wantErr: `jsontext: invalid character 'x' within number (expecting digit) within "/n" after offset 5`,
+		offset:  5, pointer: "/n",
+	}, {
+		name:    "InvalidString",
+		prefix:  []Token{BeginArray},
+		in:      "\"a\xffb\"",
+		wantErr: `jsontext: invalid UTF-8 within "/0" after offset 3`,
+		offset:  3, pointer: "/0",
+	}, {
+		name:    "DuplicateName",
+		in:      `{"a":1,"a":2}`,
+		wantErr: `jsontext: duplicate object member name "a" after offset 7`,
+		offset:  7, pointer: "/a",
+	}, {
+		name:    "DuplicateNameAfterWhitespace",
+		in:      `{ "a" : 1 , "a" : 2 }`,
+		wantErr: `jsontext: duplicate object member name "a" after offset 12`,
+		offset:  12, pointer: "/a",
+	}, {
+		name:    "DuplicateNameWithPrefix",
+		prefix:  []Token{BeginObject, String("a"), Null},
+		in:      `"a"`,
+		wantErr: `jsontext: duplicate object member name "a" after offset 9`,
+		offset:  9, pointer: "/a",
+	}, {
+		name:    "NonStringName",
+		prefix:  []Token{BeginObject},
+		in:      `1`,
+		wantErr: `jsontext: object member name must be a string after offset 1`,
+		offset:  1,
+	}, {
+		name:    "EndArray",
+		prefix:  []Token{BeginArray},
+		in:      `]`,
+		wantErr: `jsontext: invalid character ']' at start of value within "/0" after offset 1`,
+		offset:  1, pointer: "/0",
+	}, {
+		name:    "EndObject",
+		in:      ` }`,
+		wantErr: `jsontext: invalid character '}' at start of value after offset 1`,
+		offset:  1,
+	}, {
+		name:    "MismatchedDelim",
+		in:      `[1}`,
+		wantErr: `jsontext: mismatching structural token for object or array within "/1" after offset 2`,
+		offset:  2, pointer: "/1",
+	}, {
+		name:    "Truncated",
+		in:      `[1,2`,
+		wantErr: `jsontext: unexpected EOF within "/2" after offset 4`,
+		offset:  4, pointer: "/2",
+	}, {
+		name:    "TrailingData",
+		in:      `[1] x`,
+		wantErr: `jsontext: invalid character 'x' after top-level value after offset 4`,
+		offset:  4,
+	}, {
+		name:    "TwoValues",
+		prefix:  []Token{BeginArray},
+		in:      `1 2`,
+		wantErr: `jsontext: invalid character '2' after top-level value within "/1" after offset 3`,
+		offset:  3, pointer: "/1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf, tt.opts...)
+			for _, tok := range tt.prefix {
+				if err := enc.WriteToken(tok); err != nil {
+					t.Fatalf("WriteToken error: %v", err)
+				}
+			}
+			offset := enc.OutputOffset()
+			depth := enc.StackDepth()
+			_, length := enc.StackIndex(depth)
+
+			err := enc.WriteValue(Value(tt.in))
+			var serr *SyntacticError
+			if !errors.As(err, &serr) {
+				t.Fatalf("WriteValue error = %v, want SyntacticError", err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("WriteValue error:\n\tgot:  %s\n\twant: %s", err, tt.wantErr)
+			}
+			if serr.ByteOffset != tt.offset || serr.JSONPointer != tt.pointer {
+				t.Errorf("WriteValue error at (%d, %q), want (%d, %q)", serr.ByteOffset, serr.JSONPointer, tt.offset, tt.pointer)
+			}
+
+			// The state must remain unchanged.
+			if got := enc.OutputOffset(); got != offset {
+				t.Errorf("OutputOffset = %d, want %d", got, offset)
+			}
+			if got := enc.StackDepth(); got != depth {
+				t.Errorf("StackDepth = %d, want %d", got, depth)
+			}
+			if _, got := enc.StackIndex(depth); got != length {
+				t.Errorf("StackIndex(%d) length = %d, want %d", depth, got, length)
+			}
+		})
+	}
+}
+
+func TestEncoderRecoverAfterError(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	write := func(tok Token) {
+		t.Helper()
+		if err := enc.WriteToken(tok); err != nil {
+			t.Fatalf("WriteToken error: %v", err)
+		}
+	}
+	write(BeginObject)
+	write(String("a"))
+	if err := enc.WriteValue(Value(`{"x":1,"x":2}`)); err == nil {
+		t.Fatalf("WriteValue error = nil, want non-nil")
+	}
+	if err := enc.WriteToken(EndObject); err == nil {
+		t.Fatalf("WriteToken error = nil, want non-nil")
+	}
+	if err := enc.WriteValue(Value(`{"x":1,"y":[2]}`)); err != nil {
+		t.Fatalf("WriteValue error: %v", err)
+	}
+	if err := enc.WriteToken(String("a")); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("WriteToken error = %v, want %v", err, ErrDuplicateName)
+	}
+	write(String("b"))
+	write(Null)
+	write(EndObject)
+	const want = `{"a":{"x":1,"y":[2]},"b":null}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+}
+
+func TestEncoderStack(t *testing.T) {
+	enc := NewEncoder(new(bytes.Buffer))
+	type state struct {
+		depth   int
+		kind    Kind
+		length  int64
+		pointer Pointer
+	}
+	tests := []struct {
+		tok  Token
+		want state
+	}{
+		{BeginObject, state{1, '{', 0, ""}},
+		{String("a"), state{1, '{', 1, "/a"}},
+		{BeginArray, state{2, '[', 0, "/a"}},
+		{Int(1), state{2, '[', 1, "/a/0"}},
+		{BeginObject, state{3, '{', 0, "/a/1"}},
+		{String("b/c"), state{3, '{', 1, "/a/1/b~1c"}},
+		{Null, state{3, '{', 2, "/a/1/b~1c"}},
+		{EndObject, state{2, '[', 2, "/a/1"}},
+		{EndArray, state{1, '{', 2, "/a"}},
+		{EndObject, state{0, 0, 1, ""}},
+		{True, state{0, 0, 2, ""}},
+	}
+	for i, tt := range tests {
+		if err := enc.WriteToken(tt.tok); err != nil {
+			t.Fatalf("WriteToken error: %v", err)
+		}
+		depth := enc.StackDepth()
+		kind, length := enc.StackIndex(depth)
+		got := state{depth, kind, length, enc.StackPointer()}
+		if got != tt.want {
+			t.Errorf("after token %d (%v), state = %+v, want %+v", i, tt.tok, got, tt.want)
+		}
+	}
+}
+
+func TestEncoderFlush(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+
+	// Output is only flushed at the end of each top-level value.
+	enc.WriteToken(BeginArray)
+	enc.WriteToken(String("hello"))
+	if buf.Len() != 0 {
+		t.Errorf("buffer length = %d, want 0", buf.Len())
+	}
+	if got := enc.OutputOffset(); got != 8 {
+		t.Errorf("OutputOffset = %d, want 8", got)
+	}
+	enc.WriteToken(EndArray)
+	if got, want := buf.String(), "[\"hello\"]\n"; got != want {
+		t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+	if got := enc.OutputOffset(); got != 10 {
+		t.Errorf("OutputOffset = %d, want 10", got)
+	}
+
+	// Large values are flushed even if incomplete.
+	buf.Reset()
+	enc.WriteToken(BeginArray)
+	s := strings.Repeat("x", 1000)
+	var n int64 = 1
+	for range 2 * flushThreshold / len(s) {
+		enc.WriteToken(String(s))
+		n += int64(len(s)) + 3
+	}
+	if buf.Len() == 0 {
+		t.Errorf("buffer length = 0, want non-zero")
+	}
+	if got, want := enc.OutputOffset(), 10+n-1; got != want {
+		t.Errorf("OutputOffset = %d, want %d", got, want)
+	}
+	enc.WriteToken(EndArray)
+	if got, want := int64(buf.Len()), n+1; got != want {
+		t.Errorf("buffer length = %d, want %d", got, want)
+	}
+}
+
+type errorWriter struct {
+	n   int // number of bytes to accept before failing
+	err error
+}
+
+func (w *errorWriter) Write(b []byte) (int, error) {
+	if len(b) <= w.n {
+		w.n -= len(b)
+		return len(b), nil
+	}
+	n := w.n
+	w.n = 0
+	return n, w.err
+}
+
+func TestEncoderWriteErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name    string
+		w       io.Writer
+		wantErr error
+		wantMsg string
+	}{
+		{"WriteError", &errorWriter{err: errBoom}, errBoom, "jsontext: write error: boom"},
+		{"PartialWriteError", &errorWriter{n: 2, err: errBoom}, errBoom, "jsontext: write error: boom"},
+		{"ShortWrite", &errorWriter{n: 2}, io.ErrShortWrite, "jsontext: write error: short write"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewEncoder(tt.w)
+			err := enc.WriteValue(Value(`{"a":1}`))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WriteValue error = %v, want %v", err, tt.wantErr)
+			}
+			var serr *SyntacticError
+			if errors.As(err, &serr) {
+				t.Errorf("WriteValue error = %v, want an I/O error", err)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("WriteValue error:\n\tgot:  %s\n\twant: %s", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestEncoderReset(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	enc := NewEncoder(&buf1, Multiline(true))
+	enc.WriteToken(BeginArray)
+	enc.WriteToken(Null)
+
+	// Reset discards the state and the options.
+	enc.Reset(&buf2, SpaceAfterComma(true))
+	if got := enc.OutputOffset(); got != 0 {
+		t.Errorf("OutputOffset = %d, want 0", got)
+	}
+	if got := enc.StackDepth(); got != 0 {
+		t.Errorf("StackDepth = %d, want 0", got)
+	}
+	if _, ok := jsonopts.GetOption(enc.Options(), Multiline); ok {
+		t.Errorf("GetOption(Multiline) reports presence after Reset")
+	}
+	if v, ok := jsonopts.GetOption(enc.Options(), SpaceAfterComma); !ok || !v {
+		t.Errorf("GetOption(SpaceAfterComma) = (%v, %v), want (true, true)", v, ok)
+	}
+	if err := enc.WriteValue(Value(`[1,2]`)); err != nil {
+		t.Fatalf("WriteValue error: %v", err)
+	}
+	if got, want := buf2.String(), "[1, 2]\n"; got != want {
+		t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+	if buf1.Len() != 0 {
+		t.Errorf("unflushed output was written after Reset: %q", buf1.String())
+	}
+
+	for _, tt := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"NilEncoder", func() { (*Encoder)(nil).Reset(new(bytes.Buffer)) }, "jsontext: invalid nil Encoder"},
+		{"NilWriter", func() { new(Encoder).Reset(nil) }, "jsontext: invalid nil io.Writer"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("Reset panic = %v, want %v", got, tt.want)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
+
+func TestEncoderAvailableBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.WriteToken(BeginArray)
+	for i := range 3 {
+		b := enc.AvailableBuffer()
+		if len(b) != 0 {
+			t.Fatalf("AvailableBuffer length = %d, want 0", len(b))
+		}
+		b = strconv.AppendQuote(b, strings.Repeat("x", i))
+		if err := enc.WriteValue(b); err != nil {
+			t.Fatalf("WriteValue error: %v", err)
+		}
+	}
+	enc.WriteToken(EndArray)
+	if got, want := buf.String(), `["","x","xx"]`+"\n"; got != want {
+		t.Errorf("output mismatch:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+}
+
+func TestIndentOptionPanics(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"WithIndent", func() { WithIndent("\t-") }, `jsontext: invalid character '-' in indent`},
+		{"WithIndentPrefix", func() { WithIndentPrefix("# ") }, `jsontext: invalid character '#' in indent prefix`},
+		{"WithIndentNewline", func() { WithIndent("\n") }, `jsontext: invalid character '\n' in indent`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("panic = %v, want %v", got, tt.want)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
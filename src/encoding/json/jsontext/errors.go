@@ -0,0 +1,100 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"errors"
+	"strconv"
+
+	"encoding/json/internal/jsonwire"
+)
+
+const errorPrefix = "jsontext: "
+
+type ioError struct {
+	action string // either "read" or "write"
+	err    error
+}
+
+func (e *ioError) Error() string {
+	return errorPrefix + e.action + " error: " + e.err.Error()
+}
+func (e *ioError) Unwrap() error {
+	return e.err
+}
+
+// SyntacticError is a description of a syntactic error that occurred when
+// encoding or decoding JSON according to the grammar.
+//
+// The contents of this error as produced by this package may change over time.
+type SyntacticError struct {
+	requireKeyedLiterals
+	nonComparable
+
+	// ByteOffset indicates that an error occurred after this byte offset.
+	ByteOffset int64
+	// JSONPointer indicates that an error occurred within this JSON value
+	// as indicated using the JSON Pointer notation (see RFC 6901).
+	JSONPointer Pointer
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *SyntacticError) Error() string {
+	pointer := e.JSONPointer
+	offset := e.ByteOffset
+	b := []byte(errorPrefix)
+	if e.Err != nil {
+		b = append(b, e.Err.Error()...)
+		if e.Err == ErrDuplicateName {
+			b = strconv.AppendQuote(append(b, ' '), pointer.LastToken())
+			pointer = pointer.Parent()
+		}
+	} else {
+		b = append(b, "syntactic error"...)
+	}
+	if pointer != "" {
+		b = strconv.AppendQuote(append(b, " within "...), jsonwire.TruncatePointer(string(pointer), 100))
+	}
+	if offset > 0 {
+		b = strconv.AppendInt(append(b, " after offset "...), offset, 10)
+	}
+	return string(b)
+}
+
+func (e *SyntacticError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	// ErrDuplicateName indicates that a JSON token could not be
+	// encoded or decoded because it results in a duplicate JSON object name.
+	// This error is directly wrapped within a [SyntacticError] when produced.
+	//
+	// The name of a duplicate JSON object member can be extracted as:
+	//
+	//	err := ...
+	//	var serr *jsontext.SyntacticError
+	//	if errors.As(err, &serr) && serr.Err == jsontext.ErrDuplicateName {
+	//		ptr := serr.JSONPointer // JSON pointer to duplicate name
+	//		name := ptr.LastToken() // duplicate name itself
+	//		...
+	//	}
+	//
+	// This error is only returned if [AllowDuplicateNames] is false.
+	ErrDuplicateName = errors.New("duplicate object member name")
+
+	// ErrNonStringName indicates that a JSON token could not be
+	// encoded or decoded because it is not a string,
+	// as required for JSON object names according to RFC 8259, section 4.
+	// This error is directly wrapped within a [SyntacticError] when produced.
+	ErrNonStringName = errors.New("object member name must be a string")
+
+	errInvalidToken  = errors.New("invalid jsontext.Token")
+	errMissingValue  = errors.New("missing value after object name")
+	errMismatchDelim = errors.New("mismatching structural token for object or array")
+	errMaxDepth      = errors.New("exceeded max depth")
+)
@@ -0,0 +1,85 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the largest buffer size retained by the pools,
+// so that a single large value does not permanently pin a large buffer.
+const maxPooledBufferSize = 64 << 10
+
+// bufferedEncoderPool holds encoders that write into an internal buffer,
+// as used by json.Marshal and jsontext.Value.
+var bufferedEncoderPool = &sync.Pool{New: func() any { return new(Encoder) }}
+
+func getBufferedEncoder(opts ...Options) *Encoder {
+	e := bufferedEncoderPool.Get().(*Encoder)
+	if e.s.Buf == nil {
+		e.s.Buf = make([]byte, 0, 1024)
+	}
+	e.s.reset(e.s.Buf[:0], nil, opts...)
+	return e
+}
+
+func putBufferedEncoder(e *Encoder) {
+	if cap(e.s.Buf) > maxPooledBufferSize {
+		e.s.Buf = nil
+	}
+	bufferedEncoderPool.Put(e)
+}
+
+// streamingEncoderPool holds encoders that write to an io.Writer,
+// as used by json.MarshalWrite.
+var streamingEncoderPool = &sync.Pool{New: func() any { return new(Encoder) }}
+
+func getStreamingEncoder(w io.Writer, opts ...Options) *Encoder {
+	e := streamingEncoderPool.Get().(*Encoder)
+	e.s.reset(e.s.Buf[:0], w, opts...)
+	return e
+}
+
+func putStreamingEncoder(e *Encoder) {
+	if cap(e.s.Buf) > maxPooledBufferSize {
+		e.s.Buf = nil
+	}
+	e.s.wr = nil // avoid pinning the writer
+	streamingEncoderPool.Put(e)
+}
+
+// bufferedDecoderPool holds decoders that read from a []byte,
+// as used by json.Unmarshal and jsontext.Value.
+var bufferedDecoderPool = &sync.Pool{New: func() any { return new(Decoder) }}
+
+func getBufferedDecoder(b []byte, opts ...Options) *Decoder {
+	d := bufferedDecoderPool.Get().(*Decoder)
+	d.s.reset(b, nil, opts...)
+	return d
+}
+
+func putBufferedDecoder(d *Decoder) {
+	d.s.buf = nil // avoid pinning the input
+	bufferedDecoderPool.Put(d)
+}
+
+// streamingDecoderPool holds decoders that read from an io.Reader,
+// as used by json.UnmarshalRead.
+var streamingDecoderPool = &sync.Pool{New: func() any { return new(Decoder) }}
+
+func getStreamingDecoder(r io.Reader, opts ...Options) *Decoder {
+	d := streamingDecoderPool.Get().(*Decoder)
+	d.s.reset(d.s.buf[:0], r, opts...)
+	return d
+}
+
+func putStreamingDecoder(d *Decoder) {
+	if cap(d.s.buf) > maxPooledBufferSize {
+		d.s.buf = nil
+	}
+	d.s.rd = nil // avoid pinning the reader
+	streamingDecoderPool.Put(d)
+}
@@ -0,0 +1,437 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import "strconv"
+
+// maxNestingDepth is the maximum depth of nested JSON objects and arrays.
+// It matches the limit used by the v1 scanner.
+const maxNestingDepth = 10000
+
+// state tracks the state of an encoder or decoder, which is shared
+// between the streaming [Encoder] and [Decoder] and their use by
+// the "json" package.
+type state struct {
+	// Tokens validates whether the next token kind is valid.
+	Tokens stateMachine
+
+	// Names is a stack of the most recent object member name at each
+	// level of nesting, used to construct JSON Pointers.
+	Names objectNameStack
+
+	// Namespaces is a stack of object namespaces used to detect
+	// duplicate object member names.
+	// It is only maintained if [AllowDuplicateNames] is false.
+	Namespaces objectNamespaceStack
+}
+
+func (s *state) reset() {
+	s.Tokens.reset()
+	s.Names.reset()
+	s.Namespaces.reset()
+}
+
+// appendStackPointer appends a JSON Pointer (RFC 6901) to the current value.
+// If where is negative, the pointer refers to the most recently processed
+// value, otherwise it refers to the next value to be processed.
+func (s *state) appendStackPointer(b []byte, where int) []byte {
+	var objectDepth int
+	for i, e := range s.Tokens.stack {
+		innermost := i == len(s.Tokens.stack)-1
+		switch e.kind {
+		case '{':
+			objectDepth++
+			// The name of the object member is only known once it has
+			// been processed, and only applies until the member value.
+			if e.length == 0 || (innermost && where >= 0 && e.length%2 == 0) {
+				continue
+			}
+			b = append(b, '/')
+			b = appendEscapePointerName(b, s.Names.name(objectDepth-1))
+		case '[':
+			index := e.length - 1
+			if innermost && where >= 0 {
+				index = e.length
+			}
+			if index < 0 {
+				continue
+			}
+			b = strconv.AppendInt(append(b, '/'), index, 10)
+		}
+	}
+	return b
+}
+
+// stackPointer returns a JSON Pointer to the value as described by
+// [state.appendStackPointer].
+func (s *state) stackPointer(where int) Pointer {
+	return Pointer(s.appendStackPointer(nil, where))
+}
+
+// snapshot records enough of the state to later restore it with
+// [state.restore], provided that the state only grows in between
+// apart from the processing of a single value at the current depth.
+type snapshot struct {
+	depth int
+	last  stateEntry
+	names int
+
+	// namespaces and namespaceLen record the number of namespaces and
+	// the number of names in the innermost namespace.
+	namespaces   int
+	namespaceLen int
+}
+
+func (s *state) snapshot() snapshot {
+	ss := snapshot{
+		depth:      len(s.Tokens.stack),
+		last:       s.Tokens.stack[len(s.Tokens.stack)-1],
+		names:      len(s.Names.names),
+		namespaces: len(s.Namespaces),
+	}
+	if len(s.Namespaces) > 0 {
+		ss.namespaceLen = s.Namespaces.last().length()
+	}
+	return ss
+}
+
+func (s *state) restore(ss snapshot, allowDupNames bool) {
+	for len(s.Tokens.stack) > ss.depth {
+		if s.Tokens.last().kind == '{' {
+			s.Names.pop()
+			if !allowDupNames {
+				s.Namespaces.pop()
+			}
+		}
+		s.Tokens.stack = s.Tokens.stack[:len(s.Tokens.stack)-1]
+	}
+	s.Tokens.stack[len(s.Tokens.stack)-1] = ss.last
+	if ss.last.kind == '{' {
+		s.Names.names = s.Names.names[:ss.names]
+		if !allowDupNames && len(s.Namespaces) == ss.namespaces && ss.namespaces > 0 {
+			s.Namespaces.last().truncate(ss.namespaceLen)
+		}
+	}
+}
+
+// stateEntry is the state of a single level of nesting.
+type stateEntry struct {
+	// kind is 0 for the top-level, '{' for an object, or '[' for an array.
+	kind Kind
+
+	// length is the number of tokens processed at this level.
+	// For objects, names and values are counted separately,
+	// so the number of members is half the length.
+	length int64
+}
+
+// needObjectName reports whether the next token must be an object name.
+func (e stateEntry) needObjectName() bool {
+	return e.kind == '{' && e.length%2 == 0
+}
+
+// needObjectValue reports whether the next token must be an object value.
+func (e stateEntry) needObjectValue() bool {
+	return e.kind == '{' && e.length%2 == 1
+}
+
+// needDelim reports the delimiter (either ',' or ':') that must appear
+// before the next token of kind next, or 0 if none is needed.
+func (e stateEntry) needDelim(next Kind) byte {
+	switch {
+	case e.kind == 0 || e.length == 0 || next == '}' || next == ']':
+		return 0
+	case e.needObjectValue():
+		return ':'
+	default:
+		return ','
+	}
+}
+
+// stateMachine is a push-down automaton that validates whether
+// a sequence of tokens is valid according to the JSON grammar.
+// It is useful for both encoding and decoding.
+//
+// The stack always contains at least the top-level entry.
+type stateMachine struct {
+	stack []stateEntry
+}
+
+func (m *stateMachine) reset() {
+	m.stack = append(m.stack[:0], stateEntry{})
+}
+
+// Depth is the current nested depth of JSON objects and arrays.
+// It is one-indexed (i.e., top-level values have a depth of 1).
+func (m *stateMachine) Depth() int {
+	return len(m.stack)
+}
+
+// index returns the state entry at the specified zero-indexed depth.
+func (m *stateMachine) index(i int) stateEntry {
+	return m.stack[i]
+}
+
+// last returns the state entry of the current level.
+func (m *stateMachine) last() stateEntry {
+	return m.stack[len(m.stack)-1]
+}
+
+// Length reports the number of tokens processed at the current depth.
+func (m *stateMachine) Length() int64 {
+	return m.last().length
+}
+
+// NeedObjectName reports whether the next token must be an object name.
+func (m *stateMachine) NeedObjectName() bool {
+	return m.last().needObjectName()
+}
+
+// appendValue records that a value other than a string was processed.
+func (m *stateMachine) appendValue() error {
+	e := &m.stack[len(m.stack)-1]
+	if e.needObjectName() {
+		return ErrNonStringName
+	}
+	e.length++
+	return nil
+}
+
+// appendString records that a string was processed,
+// which may either be an object name or a value.
+func (m *stateMachine) appendString() error {
+	m.stack[len(m.stack)-1].length++
+	return nil
+}
+
+// pushObject records the start of an object.
+func (m *stateMachine) pushObject() error {
+	return m.push('{')
+}
+
+// pushArray records the start of an array.
+func (m *stateMachine) pushArray() error {
+	return m.push('[')
+}
+
+func (m *stateMachine) push(k Kind) error {
+	if err := m.appendValue(); err != nil {
+		return err
+	}
+	if len(m.stack) > maxNestingDepth {
+		m.stack[len(m.stack)-1].length--
+		return errMaxDepth
+	}
+	m.stack = append(m.stack, stateEntry{kind: k})
+	return nil
+}
+
+// popObject records the end of an object.
+func (m *stateMachine) popObject() error {
+	switch e := m.last(); {
+	case e.kind != '{':
+		return errMismatchDelim
+	case e.needObjectValue():
+		return errMissingValue
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	return nil
+}
+
+// popArray records the end of an array.
+func (m *stateMachine) popArray() error {
+	if m.last().kind != '[' {
+		return errMismatchDelim
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	return nil
+}
+
+// objectNameStack is a stack of the most recently processed object name
+// for each object on the stack, stored in their unescaped form.
+type objectNameStack struct {
+	// offsets is the start offset of the name for each object.
+	// The name extends until the next offset or the end of names.
+	offsets []int
+	names   []byte
+}
+
+func (s *objectNameStack) reset() {
+	s.offsets = s.offsets[:0]
+	s.names = s.names[:0]
+}
+
+// name returns the name for the i-th object on the stack.
+func (s *objectNameStack) name(i int) []byte {
+	end := len(s.names)
+	if i+1 < len(s.offsets) {
+		end = s.offsets[i+1]
+	}
+	return s.names[s.offsets[i]:end]
+}
+
+// push starts tracking the name of a new object.
+func (s *objectNameStack) push() {
+	s.offsets = append(s.offsets, len(s.names))
+}
+
+// pop stops tracking the name of the innermost object.
+func (s *objectNameStack) pop() {
+	s.names = s.names[:s.offsets[len(s.offsets)-1]]
+	s.offsets = s.offsets[:len(s.offsets)-1]
+}
+
+// replaceLast replaces the name of the innermost object.
+func (s *objectNameStack) replaceLast(name []byte) {
+	s.names = append(s.names[:s.offsets[len(s.offsets)-1]], name...)
+}
+
+// objectNamespaceStack is a stack of object namespaces.
+// The slice entries are reused across objects to reduce allocations.
+type objectNamespaceStack []objectNamespace
+
+func (s *objectNamespaceStack) reset() {
+	*s = (*s)[:0]
+}
+
+// push starts a new, empty namespace for an object.
+func (s *objectNamespaceStack) push() {
+	if cap(*s) > len(*s) {
+		*s = (*s)[:len(*s)+1]
+		s.last().reset()
+	} else {
+		*s = append(*s, objectNamespace{})
+	}
+}
+
+// pop discards the namespace of the innermost object.
+func (s *objectNamespaceStack) pop() {
+	*s = (*s)[:len(*s)-1]
+}
+
+// last returns the namespace of the innermost object.
+func (s objectNamespaceStack) last() *objectNamespace {
+	return &s[len(s)-1]
+}
+
+// objectNamespace is the set of names within a single object.
+// Small objects use a linear search over the names,
+// while large objects switch to using a map.
+type objectNamespace struct {
+	// endOffsets is the end offset of each name within allNames.
+	endOffsets []int
+	// allNames is the concatenation of every unescaped name.
+	allNames []byte
+	// mapNames is the set of names, only used for large objects.
+	mapNames map[string]struct{}
+}
+
+// maxLinearNames is the number of names above which a map is used.
+const maxLinearNames = 32
+
+func (ns *objectNamespace) reset() {
+	ns.endOffsets = ns.endOffsets[:0]
+	ns.allNames = ns.allNames[:0]
+	if len(ns.mapNames) > 1<<10 {
+		ns.mapNames = nil // avoid retaining large maps
+	} else {
+		clear(ns.mapNames)
+	}
+}
+
+// length reports the number of names in the namespace.
+func (ns *objectNamespace) length() int {
+	return len(ns.endOffsets)
+}
+
+// getName returns the i-th name in the namespace.
+func (ns *objectNamespace) getName(i int) []byte {
+	start := 0
+	if i > 0 {
+		start = ns.endOffsets[i-1]
+	}
+	return ns.allNames[start:ns.endOffsets[i]]
+}
+
+// truncate removes all but the first n names from the namespace.
+func (ns *objectNamespace) truncate(n int) {
+	if n >= ns.length() {
+		return
+	}
+	if ns.mapNames != nil {
+		for i := n; i < ns.length(); i++ {
+			delete(ns.mapNames, string(ns.getName(i)))
+		}
+	}
+	start := 0
+	if n > 0 {
+		start = ns.endOffsets[n-1]
+	}
+	ns.allNames = ns.allNames[:start]
+	ns.endOffsets = ns.endOffsets[:n]
+}
+
+// insert inserts the unescaped name into the namespace
+// and reports false if it is already present.
+func (ns *objectNamespace) insert(name []byte) bool {
+	if ns.length() < maxLinearNames {
+		for i := range ns.length() {
+			if string(ns.getName(i)) == string(name) {
+				return false
+			}
+		}
+	} else {
+		if ns.mapNames == nil || len(ns.mapNames) < ns.length() {
+			if ns.mapNames == nil {
+				ns.mapNames = make(map[string]struct{}, 2*ns.length())
+			}
+			for i := range ns.length() {
+				ns.mapNames[string(ns.getName(i))] = struct{}{}
+			}
+		}
+		if _, ok := ns.mapNames[string(name)]; ok {
+			return false
+		}
+		ns.mapNames[string(name)] = struct{}{}
+	}
+	ns.allNames = append(ns.allNames, name...)
+	ns.endOffsets = append(ns.endOffsets, len(ns.allNames))
+	return true
+}
+
+// processName records the unescaped object name in the state,
+// reporting ErrDuplicateName if the name was already present
+// and checking for duplicates is enabled.
+func (s *state) processName(name []byte, allowDupNames bool) error {
+	if !allowDupNames && !s.Namespaces.last().insert(name) {
+		return ErrDuplicateName
+	}
+	s.Names.replaceLast(name)
+	return nil
+}
+
+// pushObject records the start of an object in the state.
+func (s *state) pushObject(allowDupNames bool) error {
+	if err := s.Tokens.pushObject(); err != nil {
+		return err
+	}
+	s.Names.push()
+	if !allowDupNames {
+		s.Namespaces.push()
+	}
+	return nil
+}
+
+// popObject records the end of an object in the state.
+func (s *state) popObject(allowDupNames bool) error {
+	if err := s.Tokens.popObject(); err != nil {
+		return err
+	}
+	s.Names.pop()
+	if !allowDupNames {
+		s.Namespaces.pop()
+	}
+	return nil
+}
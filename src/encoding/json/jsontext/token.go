@@ -0,0 +1,378 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"math"
+	"strconv"
+
+	"encoding/json/internal/jsonwire"
+)
+
+// NOTE: Token is analogous to v1 json.Token.
+
+const (
+	maxInt64  = math.MaxInt64
+	minInt64  = math.MinInt64
+	maxUint64 = math.MaxUint64
+	minUint64 = 0 // for consistency and readability purposes
+)
+
+// requireKeyedLiterals can be embedded in a struct to require keyed literals.
+type requireKeyedLiterals struct{}
+
+// nonComparable can be embedded in a struct to prevent comparability.
+type nonComparable [0]func()
+
+// Token represents a lexical JSON token, which may be one of the following:
+//   - a JSON literal (i.e., null, true, or false)
+//   - a JSON string (e.g., "hello, world!")
+//   - a JSON number (e.g., 123.456)
+//   - a begin or end delimiter for a JSON object (i.e., { or } )
+//   - a begin or end delimiter for a JSON array (i.e., [ or ] )
+//
+// A Token cannot represent entire array or object values, while a [Value] can.
+// There is no Token to represent commas and colons since
+// these structural tokens can be inferred from the surrounding context.
+type Token struct {
+	nonComparable
+
+	kind Kind
+
+	// str is the value of a string, or the JSON representation of a
+	// number decoded from the input (when numType is 0).
+	str string
+
+	// num holds the value of a number constructed by Float, Int, or Uint,
+	// according to numType, as the bits of a float64, int64, or uint64.
+	num     uint64
+	numType byte // 0 for a raw number, or 'f', 'i', or 'u'
+}
+
+var (
+	Null  Token = Token{kind: 'n'}
+	False Token = Token{kind: 'f'}
+	True  Token = Token{kind: 't'}
+
+	BeginObject Token = Token{kind: '{'}
+	EndObject   Token = Token{kind: '}'}
+	BeginArray  Token = Token{kind: '['}
+	EndArray    Token = Token{kind: ']'}
+
+	zeroNumber Token = Int(0)
+	nanString  Token = String("NaN")
+	pinfString Token = String("Infinity")
+	ninfString Token = String("-Infinity")
+)
+
+// Bool constructs a Token representing a JSON boolean.
+func Bool(b bool) Token {
+	if b {
+		return True
+	}
+	return False
+}
+
+// String constructs a Token representing a JSON string.
+// The provided string should contain valid UTF-8, otherwise invalid characters
+// may be mangled as the Unicode replacement character.
+func String(s string) Token {
+	return Token{kind: '"', str: s}
+}
+
+// Float constructs a Token representing a JSON number.
+// The values NaN, +Inf, and -Inf will be represented
+// as a JSON string with the values "NaN", "Infinity", and "-Infinity".
+func Float(n float64) Token {
+	switch {
+	case math.Float64bits(n) == math.Float64bits(0):
+		return zeroNumber
+	case math.IsNaN(n):
+		return nanString
+	case math.IsInf(n, +1):
+		return pinfString
+	case math.IsInf(n, -1):
+		return ninfString
+	}
+	return Token{kind: '0', num: math.Float64bits(n), numType: 'f'}
+}
+
+// Int constructs a Token representing a JSON number from an int64.
+func Int(n int64) Token {
+	if n == 0 {
+		return Token{kind: '0', numType: 'i'}
+	}
+	return Token{kind: '0', num: uint64(n), numType: 'i'}
+}
+
+// Uint constructs a Token representing a JSON number from a uint64.
+func Uint(n uint64) Token {
+	if n == 0 {
+		return Token{kind: '0', numType: 'i'}
+	}
+	return Token{kind: '0', num: uint64(n), numType: 'u'}
+}
+
+// rawNumber constructs a Token representing a JSON number
+// from its validated textual representation.
+func rawNumber(b []byte) Token {
+	return Token{kind: '0', str: string(b)}
+}
+
+// Clone makes a copy of the Token such that its value remains valid
+// even after a subsequent [Decoder.ReadToken] call.
+func (t Token) Clone() Token {
+	return t
+}
+
+// Bool returns the value for a JSON boolean.
+// It panics if the token kind is not a JSON boolean.
+func (t Token) Bool() bool {
+	switch t.kind {
+	case 't':
+		return true
+	case 'f':
+		return false
+	default:
+		panic("invalid JSON token kind: " + t.Kind().String())
+	}
+}
+
+// String returns the unescaped string value for a JSON string.
+// For other JSON kinds, this returns the raw JSON representation.
+func (t Token) String() string {
+	switch t.kind {
+	case '"':
+		return t.str
+	case '0':
+		return string(t.appendNumber(nil, false))
+	case 0:
+		return "<invalid jsontext.Token>"
+	default:
+		return t.kind.String()
+	}
+}
+
+// appendNumber appends a JSON number to dst and returns it.
+// It panics if t is not a JSON number.
+func (t Token) appendNumber(dst []byte, canonicalize bool) []byte {
+	if t.kind != '0' {
+		panic("invalid JSON token kind: " + t.Kind().String())
+	}
+	switch t.numType {
+	case 'f':
+		return jsonwire.AppendFloat(dst, math.Float64frombits(t.num), 64)
+	case 'i':
+		return strconv.AppendInt(dst, int64(t.num), 10)
+	case 'u':
+		return strconv.AppendUint(dst, uint64(t.num), 10)
+	default:
+		if canonicalize {
+			dst, _, _ = jsonwire.ReformatNumber(dst, []byte(t.str), true)
+			return dst
+		}
+		return append(dst, t.str...)
+	}
+}
+
+// Float returns the floating-point value for a JSON number.
+// It returns a NaN, +Inf, or -Inf value for any JSON string
+// with the values "NaN", "Infinity", or "-Infinity".
+// It panics for all other cases.
+func (t Token) Float() float64 {
+	switch t.kind {
+	case '0':
+		switch t.numType {
+		case 'f':
+			return math.Float64frombits(t.num)
+		case 'i':
+			return float64(int64(t.num))
+		case 'u':
+			return float64(uint64(t.num))
+		default:
+			fv, _ := jsonwire.ParseFloat([]byte(t.str), 64)
+			return fv
+		}
+	case '"':
+		switch t.str {
+		case "NaN":
+			return math.NaN()
+		case "Infinity":
+			return math.Inf(+1)
+		case "-Infinity":
+			return math.Inf(-1)
+		}
+	}
+	panic("invalid JSON token kind: " + t.Kind().String())
+}
+
+// Int returns the signed integer value for a JSON number.
+// The fractional component of any number is ignored (truncation toward zero).
+// Any number beyond the representation of an int64 will be saturated
+// to the closest representable value.
+// It panics if the token kind is not a JSON number.
+func (t Token) Int() int64 {
+	if t.kind != '0' {
+		panic("invalid JSON token kind: " + t.Kind().String())
+	}
+	switch t.numType {
+	case 'i':
+		return int64(t.num)
+	case 'u':
+		if t.num > maxInt64 {
+			return maxInt64
+		}
+		return int64(t.num)
+	case 'f':
+		return floatToInt64(math.Float64frombits(t.num))
+	}
+
+	// Handle raw integer values.
+	b := []byte(t.str)
+	neg := len(b) > 0 && b[0] == '-'
+	if neg {
+		b = b[1:]
+	}
+	if n, ok := jsonwire.ParseUint(b); ok {
+		if neg {
+			if n > -minInt64 {
+				return minInt64
+			}
+			return int64(-n)
+		}
+		if n > maxInt64 {
+			return maxInt64
+		}
+		return int64(n)
+	} else if n == maxUint64 {
+		if neg {
+			return minInt64
+		}
+		return maxInt64
+	}
+
+	// Handle raw floating-point values.
+	fv, _ := jsonwire.ParseFloat([]byte(t.str), 64)
+	return floatToInt64(fv)
+}
+
+func floatToInt64(fv float64) int64 {
+	switch {
+	case fv >= maxInt64:
+		return maxInt64
+	case fv <= minInt64:
+		return minInt64
+	}
+	return int64(fv) // truncation toward zero
+}
+
+// Uint returns the unsigned integer value for a JSON number.
+// The fractional component of any number is ignored (truncation toward zero).
+// Any number beyond the representation of an uint64 will be saturated
+// to the closest representable value.
+// It panics if the token kind is not a JSON number.
+func (t Token) Uint() uint64 {
+	if t.kind != '0' {
+		panic("invalid JSON token kind: " + t.Kind().String())
+	}
+	switch t.numType {
+	case 'i':
+		if int64(t.num) < minUint64 {
+			return minUint64
+		}
+		return t.num
+	case 'u':
+		return t.num
+	case 'f':
+		return floatToUint64(math.Float64frombits(t.num))
+	}
+
+	// Handle raw integer values.
+	b := []byte(t.str)
+	neg := len(b) > 0 && b[0] == '-'
+	if n, ok := jsonwire.ParseUint(b); ok || (!neg && n == maxUint64) {
+		return n
+	}
+	if neg {
+		return minUint64
+	}
+
+	// Handle raw floating-point values.
+	fv, _ := jsonwire.ParseFloat(b, 64)
+	return floatToUint64(fv)
+}
+
+func floatToUint64(fv float64) uint64 {
+	switch {
+	case fv >= maxUint64:
+		return maxUint64
+	case fv <= minUint64:
+		return minUint64
+	}
+	return uint64(fv) // truncation toward zero
+}
+
+// Kind returns the token kind.
+func (t Token) Kind() Kind {
+	return t.kind
+}
+
+// Kind represents each possible JSON token kind with a single byte,
+// which is conveniently the first byte of that kind's grammar
+// with the restriction that numbers always be represented with '0':
+//
+//   - 'n': null
+//   - 'f': false
+//   - 't': true
+//   - '"': string
+//   - '0': number
+//   - '{': object begin
+//   - '}': object end
+//   - '[': array begin
+//   - ']': array end
+//
+// An invalid kind is usually represented using 0,
+// but may be non-zero due to invalid JSON data.
+type Kind byte
+
+const invalidKind Kind = 0
+
+// String prints the kind in a humanly readable fashion.
+func (k Kind) String() string {
+	switch k {
+	case 'n':
+		return "null"
+	case 'f':
+		return "false"
+	case 't':
+		return "true"
+	case '"':
+		return "string"
+	case '0':
+		return "number"
+	case '{':
+		return "{"
+	case '}':
+		return "}"
+	case '[':
+		return "["
+	case ']':
+		return "]"
+	default:
+		return "<invalid jsontext.Kind: " + quoteRune([]byte{byte(k)}) + ">"
+	}
+}
+
+// normalize coalesces all possible starting characters of a number as just '0'.
+func (k Kind) normalize() Kind {
+	if k == '-' || ('0' <= k && k <= '9') {
+		return '0'
+	}
+	return k
+}
+
+func quoteRune[Bytes ~[]byte | ~string](b Bytes) string {
+	return jsonwire.QuoteRune(b)
+}
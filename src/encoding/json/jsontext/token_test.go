@@ -0,0 +1,171 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTokenAccessors(t *testing.T) {
+	type numbers struct {
+		float float64
+		int   int64
+		uint  uint64
+	}
+	tests := []struct {
+		name       string
+		tok        Token
+		wantKind   Kind
+		wantString string
+		wantNum    *numbers // nil if accessors must panic
+	}{
+		{"Zero", Token{}, 0, "<invalid jsontext.Token>", nil},
+		{"Null", Null, 'n', "null", nil},
+		{"False", False, 'f', "false", nil},
+		{"True", True, 't', "true", nil},
+		{"BeginObject", BeginObject, '{', "{", nil},
+		{"EndObject", EndObject, '}', "}", nil},
+		{"BeginArray", BeginArray, '[', "[", nil},
+		{"EndArray", EndArray, ']', "]", nil},
+		{"String", String("hello"), '"', "hello", nil},
+		{"StringNaN", String("NaN"), '"', "NaN", &numbers{math.NaN(), 0, 0}},
+		{"StringInfinity", String("Infinity"), '"', "Infinity", &numbers{math.Inf(+1), 0, 0}},
+
+		{"IntZero", Int(0), '0', "0", &numbers{0, 0, 0}},
+		{"IntNegative", Int(-5), '0', "-5", &numbers{-5, -5, 0}},
+		{"IntMax", Int(math.MaxInt64), '0', "9223372036854775807", &numbers{math.MaxInt64, math.MaxInt64, math.MaxInt64}},
+		{"IntMin", Int(math.MinInt64), '0', "-9223372036854775808", &numbers{math.MinInt64, math.MinInt64, 0}},
+		{"UintMax", Uint(math.MaxUint64), '0', "18446744073709551615", &numbers{math.MaxUint64, math.MaxInt64, math.MaxUint64}},
+		{"FloatFraction", Float(-2.75), '0', "-2.75", &numbers{-2.75, -2, 0}},
+		{"FloatLarge", Float(1e30), '0', "1e+30", &numbers{1e30, math.MaxInt64, math.MaxUint64}},
+		{"FloatNegativeLarge", Float(-1e30), '0', "-1e+30", &numbers{-1e30, math.MinInt64, 0}},
+
+		{"RawInteger", rawNumber([]byte("123")), '0', "123", &numbers{123, 123, 123}},
+		{"RawNegativeInteger", rawNumber([]byte("-123")), '0', "-123", &numbers{-123, -123, 0}},
+		{"RawNegativeZero", rawNumber([]byte("-0")), '0', "-0", &numbers{math.Copysign(0, -1), 0, 0}},
+		{"RawFraction", rawNumber([]byte("99.9")), '0', "99.9", &numbers{99.9, 99, 99}},
+		{"RawExponent", rawNumber([]byte("1.5e3")), '0', "1.5e3", &numbers{1500, 1500, 1500}},
+		{"RawSmallExponent", rawNumber([]byte("1E-2")), '0', "1E-2", &numbers{0.01, 0, 0}},
+		{"RawMaxInt64", rawNumber([]byte("9223372036854775807")), '0', "9223372036854775807", &numbers{math.MaxInt64, math.MaxInt64, math.MaxInt64}},
+		{"RawBeyondMaxInt64", rawNumber([]byte("9223372036854775808")), '0', "9223372036854775808", &numbers{1 << 63, math.MaxInt64, 1 << 63}},
+		{"RawMinInt64", rawNumber([]byte("-9223372036854775808")), '0', "-9223372036854775808", &numbers{math.MinInt64, math.MinInt64, 0}},
+		{"RawBeyondMinInt64", rawNumber([]byte("-9223372036854775809")), '0', "-9223372036854775809", &numbers{math.MinInt64, math.MinInt64, 0}},
+		{"RawMaxUint64", rawNumber([]byte("18446744073709551615")), '0', "18446744073709551615", &numbers{math.MaxUint64, math.MaxInt64, math.MaxUint64}},
+		{"RawBeyondMaxUint64", rawNumber([]byte("18446744073709551616")), '0', "18446744073709551616", &numbers{math.MaxUint64, math.MaxInt64, math.MaxUint64}},
+		{"RawHuge", rawNumber([]byte("1e1000")), '0', "1e1000", &numbers{math.MaxFloat64, math.MaxInt64, math.MaxUint64}},
+		{"RawNegativeHuge", rawNumber([]byte("-1e1000")), '0', "-1e1000", &numbers{-math.MaxFloat64, math.MinInt64, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tok.Kind(); got != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", got, tt.wantKind)
+			}
+			if got := tt.tok.String(); got != tt.wantString {
+				t.Errorf("String = %q, want %q", got, tt.wantString)
+			}
+
+			// Float is also defined for the strings representing non-finite
+			// numbers, while Int and Uint are only defined for numbers.
+			isNumber := tt.wantKind == '0'
+			if tt.wantNum == nil {
+				for _, fn := range []func(){
+					func() { tt.tok.Float() },
+					func() { tt.tok.Int() },
+					func() { tt.tok.Uint() },
+				} {
+					if !panics(fn) {
+						t.Errorf("numeric accessor did not panic")
+					}
+				}
+				return
+			}
+			got := tt.tok.Float()
+			if math.Float64bits(got) != math.Float64bits(tt.wantNum.float) && !(math.IsNaN(got) && math.IsNaN(tt.wantNum.float)) {
+				t.Errorf("Float = %v, want %v", got, tt.wantNum.float)
+			}
+			if !isNumber {
+				if !panics(func() { tt.tok.Int() }) || !panics(func() { tt.tok.Uint() }) {
+					t.Errorf("Int or Uint did not panic")
+				}
+				return
+			}
+			if got := tt.tok.Int(); got != tt.wantNum.int {
+				t.Errorf("Int = %v, want %v", got, tt.wantNum.int)
+			}
+			if got := tt.tok.Uint(); got != tt.wantNum.uint {
+				t.Errorf("Uint = %v, want %v", got, tt.wantNum.uint)
+			}
+		})
+	}
+}
+
+func TestTokenBool(t *testing.T) {
+	if !True.Bool() || !Bool(true).Bool() {
+		t.Errorf("True.Bool = false, want true")
+	}
+	if False.Bool() || Bool(false).Bool() {
+		t.Errorf("False.Bool = true, want false")
+	}
+	for _, tok := range []Token{Null, String("true"), Int(1), BeginObject, {}} {
+		if !panics(func() { tok.Bool() }) {
+			t.Errorf("%v.Bool did not panic", tok)
+		}
+	}
+}
+
+func TestTokenFloatConstructor(t *testing.T) {
+	// Non-finite values are represented as strings,
+	// while negative zero remains a number.
+	tests := []struct {
+		in       float64
+		wantKind Kind
+		want     string
+	}{
+		{0, '0', "0"},
+		{math.Copysign(0, -1), '0', "-0"},
+		{math.NaN(), '"', "NaN"},
+		{math.Inf(+1), '"', "Infinity"},
+		{math.Inf(-1), '"', "-Infinity"},
+	}
+	for _, tt := range tests {
+		tok := Float(tt.in)
+		if tok.Kind() != tt.wantKind || tok.String() != tt.want {
+			t.Errorf("Float(%v) = %v %q, want %v %q", tt.in, tok.Kind(), tok.String(), tt.wantKind, tt.want)
+		}
+	}
+}
+
+func TestKindString(t *testing.T) {
+	tests := []struct {
+		in   Kind
+		want string
+	}{
+		{'n', "null"},
+		{'f', "false"},
+		{'t', "true"},
+		{'"', "string"},
+		{'0', "number"},
+		{'{', "{"},
+		{'}', "}"},
+		{'[', "["},
+		{']', "]"},
+		{0, `<invalid jsontext.Kind: '\x00'>`},
+		{'x', `<invalid jsontext.Kind: 'x'>`},
+		{'1', `<invalid jsontext.Kind: '1'>`},
+		{0xff, `<invalid jsontext.Kind: '\xff'>`},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Kind(%d).String = %q, want %q", byte(tt.in), got, tt.want)
+		}
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
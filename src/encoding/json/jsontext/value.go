@@ -0,0 +1,384 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonwire"
+)
+
+// NOTE: Value is analogous to v1 json.RawMessage.
+
+// Value represents a single raw JSON value, which may be one of the following:
+//   - a JSON literal (i.e., null, true, or false)
+//   - a JSON string (e.g., "hello, world!")
+//   - a JSON number (e.g., 123.456)
+//   - an entire JSON object (e.g., {"fizz":"buzz"} )
+//   - an entire JSON array (e.g., [1,2,3] )
+//
+// Value can represent entire array or object values, while [Token] cannot.
+// Value may contain leading and/or trailing whitespace.
+type Value []byte
+
+// Clone returns a copy of v.
+func (v Value) Clone() Value {
+	return bytes.Clone(v)
+}
+
+// String returns the string formatting of v.
+func (v Value) String() string {
+	if v == nil {
+		return "null"
+	}
+	return string(v)
+}
+
+// IsValid reports whether the raw JSON value is syntactically valid
+// according to the specified options.
+//
+// By default (if no options are specified), it validates according to RFC 7493.
+// It verifies whether the input is properly encoded as UTF-8,
+// that escape sequences within strings decode to valid Unicode codepoints, and
+// that all names in each object are unique.
+// It does not verify whether numbers are representable within the limits
+// of any common numeric type (e.g., float64, int64, or uint64).
+//
+// Relevant options include:
+//   - [AllowDuplicateNames]
+//   - [AllowInvalidUTF8]
+//
+// All other options are ignored.
+func (v Value) IsValid(opts ...Options) bool {
+	d := getBufferedDecoder(v, opts...)
+	defer putBufferedDecoder(d)
+	_, errVal := d.s.ReadValue(new(jsonwire.ValueFlags))
+	_, errEOF := d.s.ReadToken()
+	return errVal == nil && errEOF == io.EOF
+}
+
+// Format formats the raw JSON value in place.
+//
+// By default (if no options are specified), it validates according to RFC 7493
+// and produces the minimal JSON representation, where
+// all whitespace is elided and JSON strings use the shortest encoding.
+//
+// Relevant options include:
+//   - [AllowDuplicateNames]
+//   - [AllowInvalidUTF8]
+//   - [EscapeForHTML]
+//   - [EscapeForJS]
+//   - [Multiline]
+//   - [SpaceAfterColon]
+//   - [SpaceAfterComma]
+//   - [WithIndent]
+//   - [WithIndentPrefix]
+//
+// All other options are ignored.
+//
+// It is guaranteed to succeed if the value is valid according to the same options.
+// If the value is already formatted, then the buffer is not mutated.
+func (v *Value) Format(opts ...Options) error {
+	return v.format(opts, nil)
+}
+
+// format accepts two []Options to avoid the allocation appending them together.
+// It is equivalent to v.Format(append(opts, optsLast...)...).
+func (v *Value) format(opts, optsLast []Options) error {
+	e := getBufferedEncoder(opts...)
+	defer putBufferedEncoder(e)
+	e.s.Join(optsLast...)
+	e.s.Flags.Set(jsonflags.OmitTopLevelNewline | 1)
+	if err := e.s.WriteValue(*v); err != nil {
+		return err
+	}
+	if !bytes.Equal(*v, e.s.Buf) {
+		*v = append((*v)[:0], e.s.Buf...)
+	}
+	return nil
+}
+
+// Compact removes all whitespace from the raw JSON value.
+//
+// It does not reformat JSON strings or numbers to use any other representation.
+// To maximize the set of JSON values that can be formatted,
+// this permits values with duplicate names and invalid UTF-8.
+//
+// Compact is equivalent to calling [Value.Format] with
+// [AllowDuplicateNames](true) and [AllowInvalidUTF8](true),
+// except that existing escape sequences within strings are preserved.
+//
+// Any options specified by the caller are applied after the initial set
+// and may deliberately override prior options.
+func (v *Value) Compact(opts ...Options) error {
+	return v.format([]Options{
+		AllowDuplicateNames(true),
+		AllowInvalidUTF8(true),
+		jsonflags.PreserveRawStrings | 1,
+	}, opts)
+}
+
+// Indent reformats the whitespace in the raw JSON value so that each element
+// in a JSON object or array begins on a indented line according to the nesting.
+//
+// It does not reformat JSON strings or numbers to use any other representation.
+// To maximize the set of JSON values that can be formatted,
+// this permits values with duplicate names and invalid UTF-8.
+//
+// Indent is equivalent to calling [Value.Format] with
+// [AllowDuplicateNames](true), [AllowInvalidUTF8](true), and [Multiline](true),
+// except that existing escape sequences within strings are preserved.
+//
+// Any options specified by the caller are applied after the initial set
+// and may deliberately override prior options.
+func (v *Value) Indent(opts ...Options) error {
+	return v.format([]Options{
+		AllowDuplicateNames(true),
+		AllowInvalidUTF8(true),
+		jsonflags.PreserveRawStrings | 1,
+		Multiline(true),
+	}, opts)
+}
+
+// Canonicalize canonicalizes the raw JSON value according to the
+// JSON Canonicalization Scheme (JCS) as defined by RFC 8785
+// where it produces a stable representation of a JSON value.
+//
+// JSON strings are formatted to use their minimal representation,
+// JSON numbers are formatted as double precision numbers according
+// to some stable serialization algorithm.
+// JSON object members are sorted in ascending order by name.
+// All whitespace is removed.
+//
+// The output stability is dependent on the stability of the application data
+// (see RFC 8785, Appendix E). It cannot produce stable output from
+// fundamentally unstable input. For example, if the JSON value
+// contains ephemeral data (e.g., a frequently changing timestamp),
+// then the value is still unstable regardless of whether this is called.
+//
+// Relevant options include:
+//   - [AllowDuplicateNames]
+//   - [AllowInvalidUTF8]
+//
+// All other options are ignored.
+//
+// Note that JCS treats all JSON numbers as IEEE 754 double precision numbers.
+// Any numbers with precision beyond what is representable by that form
+// will lose their precision when canonicalized. For example,
+// integer values beyond ±2⁵³ will lose their precision.
+// To avoid this, a JSON number can be encoded as a JSON string.
+func (v *Value) Canonicalize(opts ...Options) error {
+	if err := v.format(opts, []Options{
+		jsonflags.CanonicalizeNumbers | 1,
+		EscapeForHTML(false),
+		EscapeForJS(false),
+		Multiline(false),
+		SpaceAfterColon(false),
+		SpaceAfterComma(false),
+	}); err != nil {
+		return err
+	}
+	d := getBufferedDecoder(*v, AllowDuplicateNames(true), AllowInvalidUTF8(true))
+	defer putBufferedDecoder(d)
+	reorderObjects(d, new([]byte))
+	return nil
+}
+
+// MarshalJSON returns v as the JSON encoding of v.
+// It returns the stored value as the raw JSON output without any validation.
+// If v is nil, then this returns a JSON null.
+func (v Value) MarshalJSON() ([]byte, error) {
+	// NOTE: This matches the behavior of v1 json.RawMessage.MarshalJSON.
+	if v == nil {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+// UnmarshalJSON sets v as the JSON encoding of b.
+// It stores a copy of the provided raw JSON input without any validation.
+func (v *Value) UnmarshalJSON(b []byte) error {
+	// NOTE: This matches the behavior of v1 json.RawMessage.UnmarshalJSON.
+	if v == nil {
+		return errors.New("jsontext.Value: UnmarshalJSON on nil pointer")
+	}
+	*v = append((*v)[:0], b...)
+	return nil
+}
+
+// Kind returns the starting token kind.
+// For a valid value, this will never include '}' or ']'.
+func (v Value) Kind() Kind {
+	if v := v[jsonwire.ConsumeWhitespace(v):]; len(v) > 0 {
+		return Kind(v[0]).normalize()
+	}
+	return invalidKind
+}
+
+// memberName is the name and raw bytes of a single JSON object member.
+type memberName struct {
+	// name is the unescaped name.
+	name []byte
+	// before and after are byte offsets into the buffer
+	// of the start and end of the member, excluding any delimiter.
+	before, after int
+}
+
+// reorderObjects recursively reorders all object members in place
+// according to the ordering specified in RFC 8785, section 3.2.3.
+//
+// Pre-conditions:
+//   - The value is valid (i.e., no decoder errors should ever occur).
+//   - The value is compact (i.e., no whitespace is present).
+//   - Initial call is provided a Decoder reading from the start of v.
+//
+// Post-conditions:
+//   - Exactly one JSON value is read from the Decoder.
+//   - All fully-parsed JSON objects are reordered by directly moving
+//     the members in the value buffer.
+//
+// The runtime is approximately O(n·log(n)) + O(m·log(m)),
+// where n is len(v) and m is the total number of object members.
+func reorderObjects(d *Decoder, scratch *[]byte) {
+	switch tok, _ := d.ReadToken(); tok.Kind() {
+	case '{':
+		// Iterate and collect the name and offsets for every object member.
+		var members []memberName
+		beforeBody := d.InputOffset() // offset after '{'
+		for d.PeekKind() != '}' {
+			before := d.InputOffset()
+			if len(members) > 0 {
+				before++ // skip the ',' delimiter
+			}
+			var flags jsonwire.ValueFlags
+			name, _ := d.s.ReadValue(&flags)
+			uname, _ := jsonwire.AppendUnquote(nil, name)
+			reorderObjects(d, scratch)
+			members = append(members, memberName{uname, int(before), int(d.InputOffset())})
+		}
+		afterBody := d.InputOffset() // offset before '}'
+		d.ReadToken()
+
+		// Sort the members; return early if it's already sorted.
+		isSorted := slices.IsSortedFunc(members, func(x, y memberName) int {
+			return compareUTF16(x.name, y.name)
+		})
+		if isSorted {
+			return
+		}
+		slices.SortFunc(members, func(x, y memberName) int {
+			return compareUTF16(x.name, y.name)
+		})
+
+		// Append the reordered members to a new buffer,
+		// then copy the reordered members back into the original buffer.
+		// The rest of the buffer is unaffected since the total length
+		// of the members remains the same.
+		v := d.s.buf // the decoder reads directly from the value being reordered
+		sorted := (*scratch)[:0]
+		for i, member := range members {
+			if i > 0 {
+				sorted = append(sorted, ',')
+			}
+			sorted = append(sorted, v[member.before:member.after]...)
+		}
+		copy(v[beforeBody:afterBody], sorted)
+		*scratch = sorted
+	case '[':
+		for d.PeekKind() != ']' {
+			reorderObjects(d, scratch)
+		}
+		d.ReadToken()
+	}
+}
+
+// compareUTF16 lexicographically compares x to y according
+// to the UTF-16 codepoints of the UTF-8 encoded input strings.
+// This implements the ordering specified in RFC 8785, section 3.2.3.
+func compareUTF16[Bytes []byte | string](x, y Bytes) int {
+	// NOTE: This is an optimized, mostly allocation-free implementation
+	// of slices.Compare(utf16.Encode([]rune(string(x))), utf16.Encode([]rune(string(y)))).
+	for {
+		if len(x) == 0 || len(y) == 0 {
+			return len(x) - len(y)
+		}
+
+		// ASCII fast-path.
+		if x[0] < utf8.RuneSelf || y[0] < utf8.RuneSelf {
+			if x[0] != y[0] {
+				return int(x[0]) - int(y[0])
+			}
+			x, y = x[1:], y[1:]
+			continue
+		}
+
+		// Decode next pair of runes as UTF-8.
+		rx, nx := utf8.DecodeRuneInString(string(truncateMaxUTF8(x)))
+		ry, ny := utf8.DecodeRuneInString(string(truncateMaxUTF8(y)))
+
+		selfx := isUTF16Self(rx)
+		selfy := isUTF16Self(ry)
+		switch {
+		// The x rune is a single UTF-16 codepoint, while
+		// the y rune is a surrogate pair of UTF-16 codepoints.
+		case selfx && !selfy:
+			ry, _ = utf16.EncodeRune(ry)
+		// The y rune is a single UTF-16 codepoint, while
+		// the x rune is a surrogate pair of UTF-16 codepoints.
+		case selfy && !selfx:
+			rx, _ = utf16.EncodeRune(rx)
+		}
+		if rx != ry {
+			return int(rx) - int(ry)
+		}
+
+		// Invalid UTF-8 must sort after valid UTF-8 since
+		// the invalid byte is encoded using the replacement character.
+		if isInvalidUTF8(rx, nx) || isInvalidUTF8(ry, ny) {
+			if nx != ny || x[0] != y[0] {
+				return int(x[0]) - int(y[0])
+			}
+		}
+		x, y = x[nx:], y[ny:]
+	}
+}
+
+// truncateMaxUTF8 truncates b such it contains at least one rune.
+//
+// The utf8 package currently lacks generic variants, which complicates
+// generic functions that operates on either []byte or string.
+// As a hack, we always call the utf8 function operating on strings,
+// but always truncate the input such that the result is identical.
+//
+// Example usage:
+//
+//	utf8.DecodeRuneInString(string(truncateMaxUTF8(b)))
+//
+// Converting a []byte to a string is stack allocated since
+// truncateMaxUTF8 guarantees that the []byte is short.
+func truncateMaxUTF8[Bytes ~[]byte | ~string](b Bytes) Bytes {
+	// TODO(https://go.dev/issue/56948): Remove this function and
+	// instead directly call generic utf8 functions wherever used.
+	if len(b) > utf8.UTFMax {
+		return b[:utf8.UTFMax]
+	}
+	return b
+}
+
+// isUTF16Self reports whether r is a single UTF-16 codepoint
+// (i.e., not part of a surrogate pair).
+func isUTF16Self(r rune) bool {
+	return ('\u0000' <= r && r <= '\ud7ff') || ('\ue000' <= r && r <= '\uffff')
+}
+
+func isInvalidUTF8(r rune, n int) bool {
+	return r == utf8.RuneError && n == 1
+}
@@ -0,0 +1,494 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package jsontext
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValueMethods(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           string
+		wantValid    bool
+		wantCompact  string
+		wantIndent   string
+		wantCanonErr bool
+		wantCanon    string
+	}{{
+		name:        "Number",
+		in:          ` 1e2 `,
+		wantValid:   true,
+		wantCompact: `1e2`,
+		wantIndent:  `1e2`,
+		wantCanon:   `100`,
+	}, {
+		name:        "String",
+		in:          `"\u0041\n\ud83d\ude00"`,
+		wantValid:   true,
+		wantCompact: `"\u0041\n\ud83d\ude00"`,
+		wantIndent:  `"\u0041\n\ud83d\ude00"`,
+		wantCanon:   "\"A\\n\U0001f600\"",
+	}, {
+		name:        "Object",
+		in:          `{ "b" : [ 1 , 2.50 ] , "a" : { } , "" : null }`,
+		wantValid:   true,
+		wantCompact: `{"b":[1,2.50],"a":{},"":null}`,
+		wantIndent:  "{\n\t\"b\": [\n\t\t1,\n\t\t2.50\n\t],\n\t\"a\": {},\n\t\"\": null\n}",
+		wantCanon:   `{"":null,"a":{},"b":[1,2.5]}`,
+	}, {
+		name:         "DuplicateName",
+		in:           `{"a":1,"a":2}`,
+		wantCompact:  `{"a":1,"a":2}`,
+		wantIndent:   "{\n\t\"a\": 1,\n\t\"a\": 2\n}",
+		wantCanonErr: true,
+	}, {
+		name:         "InvalidUTF8",
+		in:           "\"\xff\"",
+		wantCompact:  "\"\ufffd\"",
+		wantIndent:   "\"\ufffd\"",
+		wantCanonErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Value(tt.in).IsValid(); got != tt.wantValid {
+				t.Errorf("IsValid = %v, want %v", got, tt.wantValid)
+			}
+
+			v := Value(tt.in)
+			if err := v.Compact(); err != nil {
+				t.Errorf("Compact error: %v", err)
+			} else if string(v) != tt.wantCompact {
+				t.Errorf("Compact = %s, want %s", v, tt.wantCompact)
+			}
+
+			v = Value(tt.in)
+			if err := v.Indent(WithIndent("\t")); err != nil {
+				t.Errorf("Indent error: %v", err)
+			} else if string(v) != tt.wantIndent {
+				t.Errorf("Indent = %q, want %q", v, tt.wantIndent)
+			}
+
+			v = Value(tt.in)
+			err := v.Canonicalize()
+			switch {
+			case tt.wantCanonErr:
+				if err == nil {
+					t.Errorf("Canonicalize error = nil, want non-nil")
+				}
+			case err != nil:
+				t.Errorf("Canonicalize error: %v", err)
+			case string(v) != tt.wantCanon:
+				t.Errorf("Canonicalize = %s, want %s", v, tt.wantCanon)
+			}
+		})
+	}
+}
+
+func TestPointer(t *testing.T) {
+	p := Pointer("").AppendToken("a/b").AppendToken("~c").AppendToken("0")
+	if p != "/a~1b/~0c/0" {
+		t.Fatalf("AppendToken = %q, want %q", p, "/a~1b/~0c/0")
+	}
+	if !p.IsValid() {
+		t.Errorf("IsValid(%q) = false, want true", p)
+	}
+	if got := p.LastToken(); got != "0" {
+		t.Errorf("LastToken = %q, want %q", got, "0")
+	}
+	if got := p.Parent(); got != "/a~1b/~0c" {
+		t.Errorf("Parent = %q, want %q", got, "/a~1b/~0c")
+	}
+	if !p.Parent().Contains(p) || p.Contains(p.Parent()) {
+		t.Errorf("Contains reports the wrong relationship between %q and %q", p.Parent(), p)
+	}
+	var got []string
+	for tok := range p.Tokens() {
+		got = append(got, tok)
+	}
+	if len(got) != 3 || got[0] != "a/b" || got[1] != "~c" || got[2] != "0" {
+		t.Errorf("Tokens = %q, want [a/b ~c 0]", got)
+	}
+	for _, p := range []Pointer{"a", "/~2", "/~"} {
+		if p.IsValid() {
+			t.Errorf("IsValid(%q) = true, want false", p)
+		}
+	}
+}
+
+func TestValueIsValid(t *testing.T) {
+	tests := []struct {
+		in   string
+		opts []Options
+		want bool
+	}{
+		{in: ``, want: false},
+		{in: ` `, want: false},
+		{in: `null`, want: true},
+		{in: ` [ 1 , "a" , { } ] `, want: true},
+		{in: `nul`, want: false},
+		{in: `[1,]`, want: false},
+		{in: `{"a"}`, want: false},
+		{in: `{1:2}`, want: false},
+		{in: `1 2`, want: false},
+		{in: `{}x`, want: false},
+		{in: `"\ud800"`, want: false},
+		{in: `"\ud83d\ude00"`, want: true},
+		{in: `{"a":1,"a":2}`, want: false},
+		{in: `{"a":1,"a":2}`, opts: []Options{AllowDuplicateNames(true)}, want: true},
+		{in: `{"a":1,"\u0061":2}`, want: false},
+		{in: `[{"a":1},{"a":2}]`, want: true},
+		{in: "\"\xff\"", want: false},
+		{in: "\"\xff\"", opts: []Options{AllowInvalidUTF8(true)}, want: true},
+		{in: `"\ud800"`, opts: []Options{AllowInvalidUTF8(true)}, want: true},
+		{in: `{"a":1,"a":2}`, opts: []Options{EscapeForHTML(true), Multiline(true)}, want: false},
+	}
+	for _, tt := range tests {
+		if got := Value(tt.in).IsValid(tt.opts...); got != tt.want {
+			t.Errorf("Value(%q).IsValid = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValueFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		opts    []Options
+		want    string
+		wantErr bool
+	}{{
+		name: "Minimal",
+		in:   ` { "a" : "\u0041\/" , "b" : [ 1.0 , true ] } `,
+		want: `{"a":"A/","b":[1.0,true]}`,
+	}, {
+		name: "AlreadyFormatted",
+		in:   `{"a":[]}`,
+		want: `{"a":[]}`,
+	}, {
+		name: "Multiline",
+		in:   `{"a":[1,2],"b":{}}`,
+		opts: []Options{Multiline(true)},
+		want: "{\n\t\"a\": [\n\t\t1,\n\t\t2\n\t],\n\t\"b\": {}\n}",
+	}, {
+		name: "Indent",
+		in:   `[[1],{"a":null}]`,
+		opts: []Options{WithIndentPrefix("\t"), WithIndent("    ")},
+		want: "[\n\t    [\n\t        1\n\t    ],\n\t    {\n\t        \"a\": null\n\t    }\n\t]",
+	}, {
+		name: "SpaceAfterPunctuation",
+		in:   `{"a":[1,2]}`,
+		opts: []Options{SpaceAfterColon(true), SpaceAfterComma(true)},
+		want: `{"a": [1, 2]}`,
+	}, {
+		name: "EscapeForHTML",
+		in:   `"<>&"`,
+		opts: []Options{EscapeForHTML(true)},
+		want: `"\u003c\u003e\u0026"`,
+	}, {
+		name:    "DuplicateNames",
+		in:      `{"a":1,"a":2}`,
+		wantErr: true,
+	}, {
+		name: "AllowDuplicateNames",
+		in:   `{"a":1, "a":2}`,
+		opts: []Options{AllowDuplicateNames(true)},
+		want: `{"a":1,"a":2}`,
+	}, {
+		name:    "InvalidUTF8",
+		in:      "\"\xff\"",
+		wantErr: true,
+	}, {
+		name: "AllowInvalidUTF8",
+		in:   "\"\xff\"",
+		opts: []Options{AllowInvalidUTF8(true)},
+		want: "\"\ufffd\"",
+	}, {
+		name:    "Invalid",
+		in:      `[1,2`,
+		wantErr: true,
+	}, {
+		name:    "TrailingData",
+		in:      `1 2`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Value(tt.in)
+			err := v.Format(tt.opts...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Format error = nil, want non-nil")
+				}
+				if string(v) != tt.in {
+					t.Errorf("Format mutated the value on error: %q", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Format error: %v", err)
+			}
+			if string(v) != tt.want {
+				t.Errorf("Format:\n\tgot:  %q\n\twant: %q", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueCompactIndentOptions(t *testing.T) {
+	// Options passed to Compact and Indent take precedence.
+	v := Value(`{"a":1, "a":2}`)
+	if err := v.Compact(AllowDuplicateNames(false)); err == nil {
+		t.Errorf("Compact error = nil, want non-nil")
+	}
+	v = Value(`{"<":"\u003e"}`)
+	if err := v.Compact(EscapeForHTML(true)); err != nil {
+		t.Fatalf("Compact error: %v", err)
+	}
+	if want := `{"\u003c":"\u003e"}`; string(v) != want {
+		t.Errorf("Compact = %s, want %s", v, want)
+	}
+	v = Value(`[1,[2]]`)
+	if err := v.Indent(WithIndent(" "), WithIndentPrefix("\t")); err != nil {
+		t.Fatalf("Indent error: %v", err)
+	}
+	if want := "[\n\t 1,\n\t [\n\t  2\n\t ]\n\t]"; string(v) != want {
+		t.Errorf("Indent = %q, want %q", v, want)
+	}
+}
+
+func TestValueCanonicalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		opts []Options
+		want string
+	}{{
+		name: "Integers",
+		in:   `[0,-0,1,-1,9007199254740991,9007199254740993,12345678901234567890]`,
+		want: `[0,0,1,-1,9007199254740991,9007199254740992,12345678901234567000]`,
+	}, {
+		name: "Floats",
+		in:   `[1.0,-0.0,1E2,1e+21,1e-6,1e-7,0.1,123.456e3,1e1000,-1e1000]`,
+		want: `[1,0,100,1e+21,0.000001,1e-7,0.1,123456,1.7976931348623157e+308,-1.7976931348623157e+308]`,
+	}, {
+		name: "Strings",
+		in:   `["\u0041\/\u00e9", "\u001F\t\u2028", "\ud83d\ude00"]`,
+		want: "[\"A/é\",\"\\u001f\\t\u2028\",\"\U0001f600\"]",
+	}, {
+		name: "SortNames",
+		in:   `{"b":1,"a":2,"":3,"aa":4,"B":5}`,
+		want: `{"":3,"B":5,"a":2,"aa":4,"b":1}`,
+	}, {
+		name: "SortNestedNames",
+		in:   `{"z":[{"y":1,"x":2}],"m":{"n":{"b":1,"a":2},"c":3}}`,
+		want: `{"m":{"c":3,"n":{"a":2,"b":1}},"z":[{"x":2,"y":1}]}`,
+	}, {
+		name: "SortUTF16",
+		in:   "{\"\ufb33\":1,\"\U0001f600\":2,\"\u00e9\":3,\"\\u000a\":4}",
+		want: "{\"\\n\":4,\"\u00e9\":3,\"\U0001f600\":2,\"\ufb33\":1}",
+	}, {
+		name: "SortEscapedNames",
+		in:   `{"\u0062":1,"a":2}`,
+		want: `{"a":2,"b":1}`,
+	}, {
+		name: "SortInvalidUTF8",
+		in:   "{\"\xff\":1,\"\uffff\":2,\"a\":3}",
+		opts: []Options{AllowInvalidUTF8(true)},
+		want: "{\"a\":3,\"\ufffd\":1,\"\uffff\":2}",
+	}, {
+		name: "SortDuplicateNames",
+		in:   `{"b":1,"a":2,"b":0}`,
+		opts: []Options{AllowDuplicateNames(true)},
+		want: `{"a":2,"b":1,"b":0}`,
+	}, {
+		name: "FormattingOptionsIgnored",
+		in:   `{"b":"<>","a":[1, 2]}`,
+		opts: []Options{EscapeForHTML(true), Multiline(true), SpaceAfterComma(true)},
+		want: `{"a":[1,2],"b":"<>"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Value(tt.in)
+			if err := v.Canonicalize(tt.opts...); err != nil {
+				t.Fatalf("Canonicalize error: %v", err)
+			}
+			if string(v) != tt.want {
+				t.Errorf("Canonicalize:\n\tgot:  %s\n\twant: %s", v, tt.want)
+			}
+
+			// Canonicalization is idempotent.
+			v2 := v.Clone()
+			if err := v2.Canonicalize(tt.opts...); err != nil {
+				t.Fatalf("Canonicalize error: %v", err)
+			}
+			if string(v2) != string(v) {
+				t.Errorf("Canonicalize is not idempotent:\n\tgot:  %s\n\twant: %s", v2, v)
+			}
+		})
+	}
+}
+
+func TestCompareUTF16(t *testing.T) {
+	// The ordering must match the ordering of the UTF-16 encodings.
+	names := []string{"", "a", "ab", "b", "\u007f", "\u0080", "\u00e9", "\ud7ff", "\U00010000", "\U0001f600", "\ue000", "\uffff"}
+	for i, x := range names {
+		for j, y := range names {
+			got := compareUTF16(x, y)
+			switch {
+			case i < j && got >= 0, i == j && got != 0, i > j && got <= 0:
+				t.Errorf("compareUTF16(%q, %q) = %d, want sign of %d", x, y, got, i-j)
+			}
+		}
+	}
+}
+
+func TestValueMiscMethods(t *testing.T) {
+	if got := Value(nil).String(); got != "null" {
+		t.Errorf("Value(nil).String = %q, want %q", got, "null")
+	}
+	if got := Value(`[1]`).String(); got != "[1]" {
+		t.Errorf("Value.String = %q, want %q", got, "[1]")
+	}
+	for in, want := range map[string]Kind{``: 0, ` `: 0, ` null`: 'n', `-1`: '0', `7`: '0', `"x"`: '"', "\t{}": '{', `[]`: '['} {
+		if got := Value(in).Kind(); got != want {
+			t.Errorf("Value(%q).Kind = %v, want %v", in, got, want)
+		}
+	}
+
+	v := Value(`{"a":1}`)
+	v2 := v.Clone()
+	v2[1] = 'x'
+	if string(v) != `{"a":1}` {
+		t.Errorf("Clone shares memory with the original")
+	}
+	if b, err := Value(nil).MarshalJSON(); err != nil || string(b) != "null" {
+		t.Errorf("Value(nil).MarshalJSON = %s, %v, want null", b, err)
+	}
+	if b, err := v.MarshalJSON(); err != nil || string(b) != `{"a":1}` {
+		t.Errorf("MarshalJSON = %s, %v, want %s", b, err, v)
+	}
+	var v3 Value
+	in := []byte(`invalid`)
+	if err := v3.UnmarshalJSON(in); err != nil || string(v3) != "invalid" {
+		t.Errorf("UnmarshalJSON = %s, %v, want invalid", v3, err)
+	}
+	in[0] = 'x'
+	if string(v3) != "invalid" {
+		t.Errorf("UnmarshalJSON retained the input buffer")
+	}
+	if err := (*Value)(nil).UnmarshalJSON(in); err == nil {
+		t.Errorf("UnmarshalJSON on nil pointer error = nil, want non-nil")
+	}
+}
+
+func TestPointerMethods(t *testing.T) {
+	tests := []struct {
+		in         Pointer
+		valid      bool
+		parent     Pointer
+		lastToken  string
+		wantTokens []string
+	}{
+		{in: "", valid: true, parent: "", lastToken: ""},
+		{in: "/", valid: true, parent: "", lastToken: "", wantTokens: []string{""}},
+		{in: "//", valid: true, parent: "/", lastToken: "", wantTokens: []string{"", ""}},
+		{in: "/a", valid: true, parent: "", lastToken: "a", wantTokens: []string{"a"}},
+		{in: "/a/0/b", valid: true, parent: "/a/0", lastToken: "b", wantTokens: []string{"a", "0", "b"}},
+		{in: "/~0~1/~01", valid: true, parent: "/~0~1", lastToken: "~1", wantTokens: []string{"~/", "~1"}},
+		{in: "/日本/😀", valid: true, parent: "/日本", lastToken: "😀", wantTokens: []string{"日本", "😀"}},
+		{in: "a", valid: false},
+		{in: "/~", valid: false},
+		{in: "/~2", valid: false},
+		{in: "/a~", valid: false},
+		{in: "/\xff", valid: false},
+		{in: "/\ufffd", valid: true, parent: "", lastToken: "\ufffd", wantTokens: []string{"\ufffd"}},
+	}
+	for _, tt := range tests {
+		if got := tt.in.IsValid(); got != tt.valid {
+			t.Errorf("Pointer(%q).IsValid = %v, want %v", tt.in, got, tt.valid)
+		}
+		if !tt.valid {
+			continue
+		}
+		if got := tt.in.Parent(); got != tt.parent {
+			t.Errorf("Pointer(%q).Parent = %q, want %q", tt.in, got, tt.parent)
+		}
+		if got := tt.in.LastToken(); got != tt.lastToken {
+			t.Errorf("Pointer(%q).LastToken = %q, want %q", tt.in, got, tt.lastToken)
+		}
+		var got []string
+		for tok := range tt.in.Tokens() {
+			got = append(got, tok)
+		}
+		if !slices.Equal(got, tt.wantTokens) {
+			t.Errorf("Pointer(%q).Tokens = %q, want %q", tt.in, got, tt.wantTokens)
+		}
+
+		// Appending the tokens reconstructs the pointer.
+		var p Pointer
+		for _, tok := range tt.wantTokens {
+			p = p.AppendToken(tok)
+		}
+		if p != tt.in {
+			t.Errorf("AppendToken of %q = %q, want %q", tt.wantTokens, p, tt.in)
+		}
+	}
+
+	for _, tt := range []struct {
+		p, pc Pointer
+		want  bool
+	}{
+		{"", "", true},
+		{"", "/a", true},
+		{"/a", "/a", true},
+		{"/a", "/a/b", true},
+		{"/a", "/ab", false},
+		{"/a/b", "/a", false},
+		{"/a", "", false},
+	} {
+		if got := tt.p.Contains(tt.pc); got != tt.want {
+			t.Errorf("Pointer(%q).Contains(%q) = %v, want %v", tt.p, tt.pc, got, tt.want)
+		}
+	}
+
+	// Iteration may stop early.
+	var first []string
+	for tok := range Pointer("/a/b/c").Tokens() {
+		first = append(first, tok)
+		break
+	}
+	if !slices.Equal(first, []string{"a"}) {
+		t.Errorf("Tokens with break = %q, want [a]", first)
+	}
+}
+
+func TestSyntacticErrorString(t *testing.T) {
+	long := Pointer(strings.Repeat("/abcdefghi", 20))
+	tests := []struct {
+		err  *SyntacticError
+		want string
+	}{
+		{&SyntacticError{}, "jsontext: syntactic error"},
+		{&SyntacticError{ByteOffset: 5}, "jsontext: syntactic error after offset 5"},
+		{&SyntacticError{JSONPointer: "/a/0", Err: io.ErrUnexpectedEOF}, `jsontext: unexpected EOF within "/a/0"`},
+		{&SyntacticError{ByteOffset: 3, JSONPointer: "/a~1b", Err: ErrDuplicateName}, `jsontext: duplicate object member name "a/b" after offset 3`},
+		{&SyntacticError{ByteOffset: 3, JSONPointer: "/x/y", Err: ErrDuplicateName}, `jsontext: duplicate object member name "y" within "/x" after offset 3`},
+		{&SyntacticError{JSONPointer: long, Err: errMaxDepth}, `jsontext: exceeded max depth within "` + string(long[:50]) + "…" + string(long[len(long)-50:]) + `"`},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error:\n\tgot:  %s\n\twant: %s", got, tt.want)
+		}
+	}
+	err := error(&SyntacticError{Err: io.ErrUnexpectedEOF})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("errors.Is(%v, io.ErrUnexpectedEOF) = false, want true", err)
+	}
+}
@@ -14,8 +14,12 @@ package json
 // before diving into the scanner itself.
 
 import (
+	"bytes"
 	"strconv"
+	"strings"
 	"sync"
+
+	"encoding/json/internal/jsonwire"
 )
 
 // Valid reports whether data is a valid JSON encoding.
@@ -33,24 +37,111 @@ func checkValid(data []byte, scan *scanner) error {
 	for _, c := range data {
 		scan.bytes++
 		if scan.step(scan, c) == scanError {
-			return scan.err
+			return withPointer(scan.err, data)
 		}
 	}
 	if scan.eof() == scanError {
-		return scan.err
+		return withPointer(scan.err, data)
 	}
 	return nil
 }
 
+// withPointer sets the Pointer of err, a *SyntaxError from scanning data,
+// to the location of the error within data.
+func withPointer(err error, data []byte) error {
+	if serr, ok := err.(*SyntaxError); ok {
+		serr.Pointer = jsonPointer(data, int(min(serr.Offset, int64(len(data)))))
+	}
+	return err
+}
+
 // A SyntaxError is a description of a JSON syntax error.
 // [Unmarshal] will return a SyntaxError if the JSON can't be parsed.
 type SyntaxError struct {
-	msg    string // description of error
-	Offset int64  // error occurred after reading Offset bytes
+	msg     string // description of error
+	Offset  int64  // error occurred after reading Offset bytes
+	Pointer string // RFC 6901 JSON Pointer to the value containing the error
 }
 
 func (e *SyntaxError) Error() string { return e.msg }
 
+// jsonPointer returns the RFC 6901 JSON Pointer to the innermost value
+// in data that has begun within its first n bytes.
+// An object member only becomes part of the pointer once its name and
+// the colon that follows have been read, and an array element once
+// the preceding comma (or, for the first element, its first byte) has been read.
+func jsonPointer(data []byte, n int) string {
+	type frame struct {
+		object  bool
+		index   int    // index of the current array element, or -1 if none
+		name    string // name of the current object member
+		hasName bool
+		keyPos  int // offset of the current object member name
+	}
+	scan := newScanner()
+	defer freeScanner(scan)
+	scan.reset()
+	var stack []frame
+	for i, c := range data[:n] {
+		op := scan.step(scan, c)
+		if op == scanError {
+			break
+		}
+		var top *frame
+		if len(stack) > 0 {
+			top = &stack[len(stack)-1]
+		}
+		switch op {
+		case scanBeginLiteral, scanBeginObject, scanBeginArray:
+			switch {
+			case top == nil:
+			case !top.object && top.index < 0:
+				top.index = 0
+			case top.object && !top.hasName && op == scanBeginLiteral:
+				top.keyPos = i
+			}
+			switch op {
+			case scanBeginObject:
+				stack = append(stack, frame{object: true})
+			case scanBeginArray:
+				stack = append(stack, frame{index: -1})
+			}
+		case scanObjectKey:
+			key := data[top.keyPos:i]
+			key = key[:bytes.LastIndexByte(key, '"')+1]
+			name, _ := jsonwire.AppendUnquote(nil, key)
+			top.name, top.hasName = string(name), true
+		case scanObjectValue:
+			top.hasName = false
+		case scanArrayValue:
+			top.index++
+		case scanEndObject, scanEndArray:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	var b []byte
+	for _, f := range stack {
+		switch {
+		case f.object && f.hasName:
+			b = appendPointerToken(b, f.name)
+		case !f.object && f.index >= 0:
+			b = append(b, '/')
+			b = strconv.AppendInt(b, int64(f.index), 10)
+		}
+	}
+	return string(b)
+}
+
+// pointerEscaper escapes a reference token according to RFC 6901, section 3.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// appendPointerToken appends tok to the JSON Pointer b as a reference token.
+func appendPointerToken(b []byte, tok string) []byte {
+	b = append(b, '/')
+	return append(b, pointerEscaper.Replace(tok)...)
+}
+
 // A scanner is a JSON scanning state machine.
 // Callers call scan.reset and then pass bytes in one at a time
 // by calling scan.step(&scan, c) for each byte.
@@ -168,7 +259,7 @@ func (s *scanner) eof() int {
 		return scanEnd
 	}
 	if s.err == nil {
-		s.err = &SyntaxError{"unexpected end of JSON input", s.bytes}
+		s.err = &SyntaxError{msg: "unexpected end of JSON input", Offset: s.bytes}
 	}
 	return scanError
 }
@@ -590,7 +681,7 @@ func stateError(s *scanner, c byte) int {
 // error records an error and switches to the error state.
 func (s *scanner) error(c byte, context string) int {
 	s.step = stateError
-	s.err = &SyntaxError{"invalid character " + quoteChar(c) + " " + context, s.bytes}
+	s.err = &SyntaxError{msg: "invalid character " + quoteChar(c) + " " + context, Offset: s.bytes}
 	return scanError
 }
 
@@ -190,8 +190,8 @@ func TestIndentErrors(t *testing.T) {
 		in  string
 		err error
 	}{
-		{Name(""), `{"X": "foo", "Y"}`, &SyntaxError{"invalid character '}' after object key", 17}},
-		{Name(""), `{"X": "foo" "Y": "bar"}`, &SyntaxError{"invalid character '\"' after object key:value pair", 13}},
+		{Name(""), `{"X": "foo", "Y"}`, &SyntaxError{msg: "invalid character '}' after object key", Offset: 17}},
+		{Name(""), `{"X": "foo" "Y": "bar"}`, &SyntaxError{msg: "invalid character '\"' after object key:value pair", Offset: 13}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -302,3 +302,27 @@ func genMap(n int) map[string]any {
 	}
 	return x
 }
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		in   string
+		at   string // the pointer is computed up to the first occurrence of at
+		want string
+	}{
+		{in: `5`, at: `5`, want: ""},
+		{in: `[0,[1,2,3]]`, at: `3`, want: "/1/2"},
+		{in: `[[],{}, 5]`, at: `5`, want: "/2"},
+		{in: `{"x":1,"y":2}`, at: `2`, want: "/y"},
+		{in: `{"x":1,"y":2}`, at: `"y"`, want: ""},
+		{in: `{"a/b":{"~c":true}}`, at: `true`, want: "/a~1b/~0c"},
+		{in: `{"a\"b" : null}`, at: `null`, want: `/a"b`},
+		{in: `{"a":[1,2,x]}`, at: `x]`, want: "/a/2"},
+		{in: `{"a":[{"b":1}], "c":[]}`, at: `]}`, want: "/c"},
+	}
+	for _, tt := range tests {
+		n := strings.Index(tt.in, tt.at)
+		if got := jsonPointer([]byte(tt.in), n); got != tt.want {
+			t.Errorf("jsonPointer(%#q, %d) = %q, want %q", tt.in, n, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build !goexperiment.jsonv2
+
 package json
 
 import (
@@ -39,8 +41,26 @@ func (dec *Decoder) UseNumber() { dec.d.useNumber = true }
 // DisallowUnknownFields causes the Decoder to return an error when the destination
 // is a struct and the input contains object keys which do not match any
 // non-ignored, exported fields in the destination.
+// The error is an [UnknownFieldError].
 func (dec *Decoder) DisallowUnknownFields() { dec.d.disallowUnknownFields = true }
 
+// DisallowMissingRequiredFields causes the Decoder to return an error when the
+// destination is a struct and the input object has no key for a field whose
+// tag includes the "required" option, as in:
+//
+//	ID string `json:"id,required"`
+//
+// The error is a [MissingFieldError].
+func (dec *Decoder) DisallowMissingRequiredFields() { dec.d.requireFields = true }
+
+// ReportAllErrors causes [Decoder.Decode] to report every error that it
+// could recover from, such as an [UnmarshalTypeError], instead of only the
+// earliest one. If more than one error occurs, Decode returns an error that
+// wraps each of them in the order they occurred, as produced by [errors.Join].
+// Decoding still stops at the first [SyntaxError] or error returned by an
+// [Unmarshaler].
+func (dec *Decoder) ReportAllErrors() { dec.d.reportAllErrors = true }
+
 // Decode reads the next JSON-encoded value from its
 // input and stores it in the value pointed to by v.
 //
@@ -116,6 +136,9 @@ Input:
 					break Input
 				}
 			case scanError:
+				if serr, ok := dec.scan.err.(*SyntaxError); ok {
+					serr.Pointer = jsonPointer(dec.buf[dec.scanp:], scanp+1-dec.scanp)
+				}
 				dec.err = dec.scan.err
 				return 0, dec.scan.err
 			}
@@ -311,7 +334,7 @@ func (dec *Decoder) tokenPrepareForDecode() error {
 			return err
 		}
 		if c != ',' {
-			return &SyntaxError{"expected comma after array element", dec.InputOffset()}
+			return &SyntaxError{msg: "expected comma after array element", Offset: dec.InputOffset()}
 		}
 		dec.scanp++
 		dec.tokenState = tokenArrayValue
@@ -321,7 +344,7 @@ func (dec *Decoder) tokenPrepareForDecode() error {
 			return err
 		}
 		if c != ':' {
-			return &SyntaxError{"expected colon after object key", dec.InputOffset()}
+			return &SyntaxError{msg: "expected colon after object key", Offset: dec.InputOffset()}
 		}
 		dec.scanp++
 		dec.tokenState = tokenObjectValue
@@ -474,7 +497,7 @@ func (dec *Decoder) tokenError(c byte) (Token, error) {
 	case tokenObjectComma:
 		context = " after object key:value pair"
 	}
-	return nil, &SyntaxError{"invalid character " + quoteChar(c) + context, dec.InputOffset()}
+	return nil, &SyntaxError{msg: "invalid character " + quoteChar(c) + context, Offset: dec.InputOffset()}
 }
 
 // More reports whether there is another element in the
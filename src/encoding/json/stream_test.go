@@ -6,6 +6,7 @@ package json
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -444,18 +445,18 @@ func TestDecodeInStream(t *testing.T) {
 		{CaseName: Name(""), json: ` [{"a": 1} {"a": 2}] `, expTokens: []any{
 			Delim('['),
 			decodeThis{map[string]any{"a": float64(1)}},
-			decodeThis{&SyntaxError{"expected comma after array element", 11}},
+			decodeThis{&SyntaxError{msg: "expected comma after array element", Offset: 11}},
 		}},
 		{CaseName: Name(""), json: `{ "` + strings.Repeat("a", 513) + `" 1 }`, expTokens: []any{
 			Delim('{'), strings.Repeat("a", 513),
-			decodeThis{&SyntaxError{"expected colon after object key", 518}},
+			decodeThis{&SyntaxError{msg: "expected colon after object key", Offset: 518}},
 		}},
 		{CaseName: Name(""), json: `{ "\a" }`, expTokens: []any{
 			Delim('{'),
-			&SyntaxError{"invalid character 'a' in string escape code", 3},
+			&SyntaxError{msg: "invalid character 'a' in string escape code", Offset: 3},
 		}},
 		{CaseName: Name(""), json: ` \a`, expTokens: []any{
-			&SyntaxError{"invalid character '\\\\' looking for beginning of value", 1},
+			&SyntaxError{msg: "invalid character '\\\\' looking for beginning of value", Offset: 1},
 		}},
 	}
 	for _, tt := range tests {
@@ -520,3 +521,189 @@ func TestHTTPDecoding(t *testing.T) {
 		t.Errorf("Decode error:\n\tgot:  %v\n\twant: io.EOF", err)
 	}
 }
+
+func TestDecoderErrorLocation(t *testing.T) {
+	tests := []struct {
+		CaseName
+		in          string
+		ptr         any
+		wantPointer string
+		wantToken   string // the last occurrence of wantToken must span the error offset
+	}{{
+		CaseName:    Name("TypeError"),
+		in:          `{"b":[1,"x"]}{"b":[1,"x"]}`,
+		ptr:         new(struct{ B []int }),
+		wantPointer: "/b/1",
+		wantToken:   `"x"`,
+	}, {
+		CaseName:    Name("MapKeyError"),
+		in:          `{"1":true,"y":true}{"2":true,"y":true}`,
+		ptr:         new(map[int]bool),
+		wantPointer: "/y",
+		wantToken:   `"y"`,
+	}, {
+		CaseName:    Name("SyntaxError"),
+		in:          `{"b":[1]}{"b":[1,{"c":x}]}`,
+		ptr:         new(any),
+		wantPointer: "/b/1/c",
+		wantToken:   `x`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(tt.in))
+			dec.Decode(tt.ptr)
+			var ptr string
+			var offset, base int64
+			switch err := dec.Decode(tt.ptr).(type) {
+			case *UnmarshalTypeError:
+				// The offset is relative to the start of the decoded value.
+				ptr, offset = err.Pointer, err.Offset
+				base = int64(strings.LastIndex(tt.in, "}{") + 1)
+			case *SyntaxError:
+				// The offset is relative to the start of the input.
+				ptr, offset = err.Pointer, err.Offset
+			default:
+				t.Fatalf("%s: Decode error = %v (%T), want location", tt.Where, err, err)
+			}
+			start := int64(strings.LastIndex(tt.in, tt.wantToken)) - base
+			end := start + int64(len(tt.wantToken))
+			if ptr != tt.wantPointer || offset < start || offset > end {
+				t.Errorf("%s: error at (%q, %d), want (%q, %d..%d)", tt.Where, ptr, offset, tt.wantPointer, start, end)
+			}
+		})
+	}
+}
+
+func TestDecoderDisallowUnknownFields(t *testing.T) {
+	var v struct {
+		A struct{ B int } `json:"a"`
+	}
+	dec := NewDecoder(strings.NewReader(`{"a":{"b":1,"c/d":2}}`))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&v)
+	want := &UnknownFieldError{Field: "c/d", Pointer: "/a/c~1d"}
+	var got *UnknownFieldError
+	if !errors.As(err, &got) {
+		t.Fatalf("Decode error = %v, want %v", err, want)
+	}
+	if got.Field != want.Field || got.Pointer != want.Pointer || got.Error() != `json: unknown field "c/d"` {
+		t.Errorf("Decode error = %#v, want %#v", got, want)
+	}
+	if v.A.B != 1 {
+		t.Errorf("Decode: v.A.B = %d, want 1", v.A.B)
+	}
+}
+
+type requiredItem struct {
+	ID   string `json:"id,required"`
+	Name string `json:"name,omitempty,required"`
+	Note string `json:"note"`
+}
+
+func TestDecoderDisallowMissingRequiredFields(t *testing.T) {
+	tests := []struct {
+		CaseName
+		in          string
+		ptr         any
+		wantField   string
+		wantPointer string
+	}{{
+		CaseName: Name("Present"),
+		in:       `{"id":"1","NAME":"a"}`,
+		ptr:      new(requiredItem),
+	}, {
+		CaseName:  Name("Missing"),
+		in:        `{"id":"1","note":"a"}`,
+		ptr:       new(requiredItem),
+		wantField: "name",
+	}, {
+		CaseName:  Name("Null"),
+		in:        `{"id":null}`,
+		ptr:       new(requiredItem),
+		wantField: "name",
+	}, {
+		CaseName:    Name("Nested"),
+		in:          `{"items":[{"id":"1","name":"a"},{"name":"b"}]}`,
+		ptr:         new(struct{ Items []requiredItem }),
+		wantField:   "id",
+		wantPointer: "/items/1",
+	}, {
+		CaseName:    Name("Embedded"),
+		in:          `{"x":{"id":"1"}}`,
+		ptr:         new(map[string]struct{ requiredItem }),
+		wantField:   "name",
+		wantPointer: "/x",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// Without the option, the tag has no effect.
+			if err := NewDecoder(strings.NewReader(tt.in)).Decode(tt.ptr); err != nil {
+				t.Fatalf("%s: Decode error: %v", tt.Where, err)
+			}
+
+			dec := NewDecoder(strings.NewReader(tt.in))
+			dec.DisallowMissingRequiredFields()
+			err := dec.Decode(tt.ptr)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("%s: Decode error: %v", tt.Where, err)
+				}
+				return
+			}
+			var merr *MissingFieldError
+			if !errors.As(err, &merr) {
+				t.Fatalf("%s: Decode error = %v, want MissingFieldError", tt.Where, err)
+			}
+			if merr.Field != tt.wantField || merr.Pointer != tt.wantPointer {
+				t.Errorf("%s: Decode error = %#v, want field %q at %q", tt.Where, merr, tt.wantField, tt.wantPointer)
+			}
+		})
+	}
+}
+
+func TestDecoderReportAllErrors(t *testing.T) {
+	const in = `{"a":"x","b":1,"c":true,"d":2,"e":[1,"y"]}`
+	var v struct {
+		A int
+		B int
+		C string
+		E []int
+		F int `json:",required"`
+	}
+
+	dec := NewDecoder(strings.NewReader(in))
+	err := dec.Decode(&v)
+	if _, ok := err.(*UnmarshalTypeError); !ok {
+		t.Fatalf("Decode error = %v, want UnmarshalTypeError", err)
+	}
+
+	dec = NewDecoder(strings.NewReader(in))
+	dec.DisallowUnknownFields()
+	dec.DisallowMissingRequiredFields()
+	dec.ReportAllErrors()
+	err = dec.Decode(&v)
+	errs, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Decode error = %v, want multiple errors", err)
+	}
+	var got []string
+	for _, err := range errs.Unwrap() {
+		switch err := err.(type) {
+		case *UnmarshalTypeError:
+			got = append(got, "type "+err.Pointer)
+		case *UnknownFieldError:
+			got = append(got, "unknown "+err.Pointer)
+		case *MissingFieldError:
+			got = append(got, "missing "+err.Field)
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	want := []string{"type /a", "type /c", "unknown /d", "type /e/1", "missing F"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode errors:\n\tgot:  %q\n\twant: %q", got, want)
+	}
+	if v.B != 1 || len(v.E) != 2 || v.E[0] != 1 {
+		t.Errorf("Decode did not continue past errors: %+v", v)
+	}
+}
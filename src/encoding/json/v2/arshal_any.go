@@ -0,0 +1,113 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"strconv"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+// This file contains an optimized implementation of unmarshaling
+// into the Go any type, which avoids the overhead of reflection
+// for the common case of decoding arbitrary JSON.
+
+func unmarshalValueAny(dec *jsontext.Decoder, uo *jsonopts.Struct) (any, error) {
+	switch k := dec.PeekKind(); k {
+	case '{':
+		return unmarshalObjectAny(dec, uo)
+	case '[':
+		return unmarshalArrayAny(dec, uo)
+	default:
+		xd := export.Decoder(dec)
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return nil, err
+		}
+		switch val.Kind() {
+		case 'n':
+			return nil, nil
+		case 'f':
+			return false, nil
+		case 't':
+			return true, nil
+		case '"':
+			return string(unquoteValue(val, flags)), nil
+		case '0':
+			if uo.Flags.Get(jsonflags.UnmarshalAnyWithRawNumber) {
+				return internal.RawNumberOf(val), nil
+			}
+			fv, ok := jsonwire.ParseFloat(val, 64)
+			if !ok {
+				return fv, newUnmarshalErrorAfterWithValue(dec, float64Type, strconv.ErrRange)
+			}
+			return fv, nil
+		default:
+			panic("BUG: invalid kind: " + k.String())
+		}
+	}
+}
+
+func unmarshalObjectAny(dec *jsontext.Decoder, uo *jsonopts.Struct) (map[string]any, error) {
+	xd := export.Decoder(dec)
+	if _, err := dec.ReadToken(); err != nil {
+		return nil, err
+	}
+	obj := make(map[string]any)
+	var errUnmarshal error
+	for dec.PeekKind() != '}' {
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return obj, err
+		}
+		name := string(unquoteValue(val, flags))
+
+		// With duplicate names allowed, the last value wins,
+		// which is consistent with the behavior of a Go map.
+		depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+		v, err := unmarshalValueAny(dec, uo)
+		obj[name] = v
+		if err != nil {
+			if !isRecoverable(dec, uo, err, depth, length) {
+				return obj, err
+			}
+			errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+		}
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return obj, err
+	}
+	return obj, errUnmarshal
+}
+
+func unmarshalArrayAny(dec *jsontext.Decoder, uo *jsonopts.Struct) ([]any, error) {
+	xd := export.Decoder(dec)
+	if _, err := dec.ReadToken(); err != nil {
+		return nil, err
+	}
+	arr := []any{}
+	var errUnmarshal error
+	for dec.PeekKind() != ']' {
+		depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+		v, err := unmarshalValueAny(dec, uo)
+		arr = append(arr, v)
+		if err != nil {
+			if !isRecoverable(dec, uo, err, depth, length) {
+				return arr, err
+			}
+			errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+		}
+	}
+	if _, err := dec.ReadToken(); err != nil {
+		return arr, err
+	}
+	return arr, errUnmarshal
+}
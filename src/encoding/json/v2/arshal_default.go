@@ -0,0 +1,1667 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+// optimizeCommon specifies whether to use optimizations targeted for certain
+// common patterns, rather than using the slower, but more general logic.
+// All tests should pass regardless of whether this is true or not.
+const optimizeCommon = true
+
+var (
+	// Most natural Go type that correspond with each JSON type.
+	anyType          = reflect.TypeFor[any]()            // JSON value
+	boolType         = reflect.TypeFor[bool]()           // JSON bool
+	stringType       = reflect.TypeFor[string]()         // JSON string
+	float64Type      = reflect.TypeFor[float64]()        // JSON number
+	mapStringAnyType = reflect.TypeFor[map[string]any]() // JSON object
+	sliceAnyType     = reflect.TypeFor[[]any]()          // JSON array
+
+	bytesType         = reflect.TypeFor[[]byte]()
+	jsontextValueType = reflect.TypeFor[jsontext.Value]()
+)
+
+// startDetectingCyclesAfter is the depth after which cycle detection begins.
+// Below this depth, the cost of tracking visited pointers is not worth it.
+const startDetectingCyclesAfter = 1000
+
+type seenPointers = map[any]struct{}
+
+// seenPointer identifies a visited reference. Slices are also identified
+// by their length since a slice may reference a strict subset of itself.
+type seenPointer struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// visitPointer visits the reference held by v, reporting an error
+// if it was already visited. If successfully visited,
+// then the caller must eventually call leavePointer.
+func visitPointer(m *seenPointers, v reflect.Value) error {
+	p := seenPointer{v.Type(), v.Pointer(), 0}
+	if v.Kind() == reflect.Slice {
+		p.len = v.Len()
+	}
+	if _, ok := (*m)[p]; ok {
+		return fmt.Errorf("%w via %v", internal.ErrCycle, v.Type())
+	}
+	if *m == nil {
+		*m = make(seenPointers)
+	}
+	(*m)[p] = struct{}{}
+	return nil
+}
+
+func leavePointer(m *seenPointers, v reflect.Value) {
+	p := seenPointer{v.Type(), v.Pointer(), 0}
+	if v.Kind() == reflect.Slice {
+		p.len = v.Len()
+	}
+	delete(*m, p)
+}
+
+func makeDefaultArshaler(t reflect.Type) *arshaler {
+	switch t.Kind() {
+	case reflect.Bool:
+		return makeBoolArshaler(t)
+	case reflect.String:
+		return makeStringArshaler(t)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return makeIntArshaler(t)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return makeUintArshaler(t)
+	case reflect.Float32, reflect.Float64:
+		return makeFloatArshaler(t)
+	case reflect.Map:
+		return makeMapArshaler(t)
+	case reflect.Struct:
+		return makeStructArshaler(t)
+	case reflect.Slice:
+		fncs := makeSliceArshaler(t)
+		if t.Elem().Kind() == reflect.Uint8 {
+			return makeBytesArshaler(t, fncs)
+		}
+		return fncs
+	case reflect.Array:
+		fncs := makeArrayArshaler(t)
+		if t.Elem().Kind() == reflect.Uint8 {
+			return makeBytesArshaler(t, fncs)
+		}
+		return fncs
+	case reflect.Pointer:
+		return makePointerArshaler(t)
+	case reflect.Interface:
+		return makeInterfaceArshaler(t)
+	default:
+		return makeInvalidArshaler(t)
+	}
+}
+
+// hasFormat reports whether a format from a struct field option
+// applies to the value about to be processed at the given depth.
+func hasFormat(o *jsonopts.Struct, depth int) bool {
+	return o.Format != "" && o.FormatDepth == depth
+}
+
+// unquoteValue returns the contents of the JSON string val,
+// where flags are the value flags reported when val was read.
+func unquoteValue(val []byte, flags jsonwire.ValueFlags) []byte {
+	if flags.IsVerbatim() {
+		return val[len(`"`) : len(val)-len(`"`)]
+	}
+	b, _ := jsonwire.AppendUnquote(nil, val)
+	return b
+}
+
+// isLegacyStringify reports whether the v1 `string` tag option is in effect,
+// where JSON strings and booleans are also quoted within a JSON string.
+func isLegacyStringify(o *jsonopts.Struct) bool {
+	return o.Flags.Get(jsonflags.StringifyNumbers) && o.Flags.Get(jsonflags.StringifyWithLegacySemantics)
+}
+
+func makeBoolArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+
+		if isLegacyStringify(mo) {
+			return enc.WriteToken(jsontext.String(strconv.FormatBool(va.Bool())))
+		}
+		return enc.WriteToken(jsontext.Bool(va.Bool()))
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		switch val.Kind() {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetBool(false)
+			}
+			return nil
+		case 't', 'f':
+			if !isLegacyStringify(uo) {
+				va.SetBool(val.Kind() == 't')
+				return nil
+			}
+		case '"':
+			if isLegacyStringify(uo) {
+				switch string(unquoteValue(val, flags)) {
+				case "true":
+					va.SetBool(true)
+				case "false":
+					va.SetBool(false)
+				case "null":
+					if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+						va.SetBool(false)
+					}
+				default:
+					return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrSyntax)
+				}
+				return nil
+			}
+		}
+		return newUnmarshalErrorAfterWithValue(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makeStringArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		s := va.String()
+		if isLegacyStringify(mo) {
+			b, err := jsonwire.AppendQuote(nil, s, &mo.Flags)
+			if err != nil {
+				return newMarshalErrorBefore(enc, t, err)
+			}
+			s = string(b)
+		}
+		return enc.WriteToken(jsontext.String(s))
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		switch val.Kind() {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetString("")
+			}
+			return nil
+		case '"':
+			val = unquoteValue(val, flags)
+			if isLegacyStringify(uo) {
+				// With v1 semantics, a string field with the `string` option
+				// holds a JSON string (or null) within a JSON string.
+				if string(val) == "null" {
+					if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+						va.SetString("")
+					}
+					return nil
+				}
+				var flags2 jsonwire.ValueFlags
+				if n, err := jsonwire.ConsumeString(&flags2, val, false); err != nil || n != len(val) {
+					return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrSyntax)
+				}
+				val = unquoteValue(val, flags2)
+			}
+			va.SetString(string(val))
+			return nil
+		}
+		return newUnmarshalErrorAfterWithValue(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makeIntArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	bits := t.Bits()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+
+		k := jsontext.Kind('0')
+		if mo.Flags.Get(jsonflags.StringifyNumbers) || xe.Tokens.NeedObjectName() {
+			k = '"'
+		}
+		return xe.AppendRaw(k, true, func(b []byte) ([]byte, error) {
+			return strconv.AppendInt(b, va.Int(), 10), nil
+		})
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		stringify := uo.Flags.Get(jsonflags.StringifyNumbers) || xd.Tokens.NeedObjectName()
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		k := val.Kind()
+		switch k {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetInt(0)
+			}
+			return nil
+		case '"':
+			if !stringify {
+				break
+			}
+			val = unquoteValue(val, flags)
+			if uo.Flags.Get(jsonflags.StringifyWithLegacySemantics) && string(val) == "null" {
+				if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					va.SetInt(0)
+				}
+				return nil
+			}
+			fallthrough
+		case '0':
+			if stringify && k == '0' {
+				break
+			}
+			var negOffset int
+			neg := len(val) > 0 && val[0] == '-'
+			if neg {
+				negOffset = 1
+			}
+			n, ok := jsonwire.ParseUint(val[negOffset:])
+			maxInt := uint64(1) << (bits - 1)
+			overflow := (neg && n > maxInt) || (!neg && n > maxInt-1)
+			if !ok {
+				if n != math.MaxUint64 {
+					return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrSyntax)
+				}
+				overflow = true
+			}
+			if overflow {
+				return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrRange)
+			}
+			if neg {
+				va.SetInt(int64(-n))
+			} else {
+				va.SetInt(int64(+n))
+			}
+			return nil
+		}
+		return newUnmarshalErrorAfterWithValue(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makeUintArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	bits := t.Bits()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+
+		k := jsontext.Kind('0')
+		if mo.Flags.Get(jsonflags.StringifyNumbers) || xe.Tokens.NeedObjectName() {
+			k = '"'
+		}
+		return xe.AppendRaw(k, true, func(b []byte) ([]byte, error) {
+			return strconv.AppendUint(b, va.Uint(), 10), nil
+		})
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		stringify := uo.Flags.Get(jsonflags.StringifyNumbers) || xd.Tokens.NeedObjectName()
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		k := val.Kind()
+		switch k {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetUint(0)
+			}
+			return nil
+		case '"':
+			if !stringify {
+				break
+			}
+			val = unquoteValue(val, flags)
+			if uo.Flags.Get(jsonflags.StringifyWithLegacySemantics) && string(val) == "null" {
+				if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					va.SetUint(0)
+				}
+				return nil
+			}
+			fallthrough
+		case '0':
+			if stringify && k == '0' {
+				break
+			}
+			n, ok := jsonwire.ParseUint(val)
+			maxUint := uint64(1) << bits
+			overflow := 0 < maxUint && maxUint <= n
+			if !ok {
+				if n != math.MaxUint64 {
+					return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrSyntax)
+				}
+				overflow = true
+			}
+			if overflow {
+				return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrRange)
+			}
+			va.SetUint(n)
+			return nil
+		}
+		return newUnmarshalErrorAfterWithValue(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makeFloatArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	bits := t.Bits()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		var allowNonFinite bool
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			if mo.Format != "nonfinite" {
+				return newInvalidFormatError(enc, t, mo)
+			}
+			allowNonFinite = true
+		}
+
+		fv := va.Float()
+		if math.IsNaN(fv) || math.IsInf(fv, 0) {
+			if !allowNonFinite {
+				return newMarshalErrorBefore(enc, t, fmt.Errorf("%w: %v", errNonFiniteNumber, fv))
+			}
+			return enc.WriteToken(jsontext.Float(fv))
+		}
+
+		k := jsontext.Kind('0')
+		if mo.Flags.Get(jsonflags.StringifyNumbers) || xe.Tokens.NeedObjectName() {
+			k = '"'
+		}
+		return xe.AppendRaw(k, true, func(b []byte) ([]byte, error) {
+			return jsonwire.AppendFloat(b, fv, bits), nil
+		})
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		var allowNonFinite bool
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			if uo.Format != "nonfinite" {
+				return newInvalidFormatError(dec, t, uo)
+			}
+			allowNonFinite = true
+		}
+		stringify := uo.Flags.Get(jsonflags.StringifyNumbers) || xd.Tokens.NeedObjectName()
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		k := val.Kind()
+		switch k {
+		case 'n':
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetFloat(0)
+			}
+			return nil
+		case '"':
+			val = unquoteValue(val, flags)
+			if allowNonFinite {
+				switch string(val) {
+				case "NaN":
+					va.SetFloat(math.NaN())
+					return nil
+				case "Infinity":
+					va.SetFloat(math.Inf(+1))
+					return nil
+				case "-Infinity":
+					va.SetFloat(math.Inf(-1))
+					return nil
+				}
+			}
+			if !stringify {
+				break
+			}
+			if uo.Flags.Get(jsonflags.StringifyWithLegacySemantics) && string(val) == "null" {
+				if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					va.SetFloat(0)
+				}
+				return nil
+			}
+			if n, err := jsonwire.ConsumeNumber(val); n != len(val) || err != nil {
+				return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrSyntax)
+			}
+			fallthrough
+		case '0':
+			if stringify && k == '0' {
+				break
+			}
+			fv, ok := jsonwire.ParseFloat(val, bits)
+			va.SetFloat(fv)
+			if !ok {
+				return newUnmarshalErrorAfterWithValue(dec, t, strconv.ErrRange)
+			}
+			return nil
+		}
+		return newUnmarshalErrorAfterWithValue(dec, t, nil)
+	}
+	return &fncs
+}
+
+func makeMapArshaler(t reflect.Type) *arshaler {
+	// NOTE: Values retrieved from a map are not addressable,
+	// so we shallow copy the values to make them addressable and
+	// store them back into the map afterwards.
+	var fncs arshaler
+	var (
+		once    sync.Once
+		keyFncs *arshaler
+		valFncs *arshaler
+	)
+	init := func() {
+		keyFncs = lookupArshaler(t.Key())
+		valFncs = lookupArshaler(t.Elem())
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		// Check for cycles.
+		xe := export.Encoder(enc)
+		if xe.Tokens.Depth() > startDetectingCyclesAfter {
+			if err := visitPointer(&xe.SeenPointers, va.Value); err != nil {
+				return newMarshalErrorBefore(enc, t, err)
+			}
+			defer leavePointer(&xe.SeenPointers, va.Value)
+		}
+		if mo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics) && !isLegacyMapKey(t.Key(), textMarshalerType) {
+			return newMarshalErrorBefore(enc, t, errUnsupportedType)
+		}
+
+		emitNull := mo.Flags.Get(jsonflags.FormatNilMapAsNull)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			switch mo.Format {
+			case "emitnull":
+				emitNull = true
+			case "emitempty":
+				emitNull = false
+			default:
+				return newInvalidFormatError(enc, t, mo)
+			}
+		}
+
+		// Handle empty maps.
+		n := va.Len()
+		if n == 0 {
+			if emitNull && va.IsNil() {
+				return enc.WriteToken(jsontext.Null)
+			}
+			if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+				return err
+			}
+			return enc.WriteToken(jsontext.EndObject)
+		}
+
+		once.Do(init)
+		if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+			return err
+		}
+		marshalKey := keyFncs.marshal
+		marshalVal := valFncs.marshal
+		var customKey bool
+		if mo.Marshalers != nil {
+			marshalKey, customKey = mo.Marshalers.(*Marshalers).lookup(marshalKey, t.Key())
+			marshalVal, _ = mo.Marshalers.(*Marshalers).lookup(marshalVal, t.Elem())
+		}
+		k := newAddressableValue(t.Key())
+		v := newAddressableValue(t.Elem())
+
+		if !mo.Flags.Get(jsonflags.Deterministic) || n <= 1 {
+			for iter := va.MapRange(); iter.Next(); {
+				k.SetIterKey(iter)
+				if err := marshalKey(enc, k, mo); err != nil {
+					return err
+				}
+				v.SetIterValue(iter)
+				if err := marshalVal(enc, v, mo); err != nil {
+					return err
+				}
+			}
+		} else if t.Key().Kind() == reflect.String && !keyFncs.nonDefault && !customKey {
+			// Optimize for string keys, which are sorted by their Go value.
+			names := getStrings(n)
+			for i, iter := 0, va.MapRange(); i < n && iter.Next(); i++ {
+				k.SetIterKey(iter)
+				(*names)[i] = k.String()
+			}
+			names.sort()
+			for _, name := range *names {
+				if err := enc.WriteToken(jsontext.String(name)); err != nil {
+					return err
+				}
+				k.SetString(name)
+				v.Set(va.MapIndex(k.Value))
+				if err := marshalVal(enc, v, mo); err != nil {
+					return err
+				}
+			}
+			putStrings(names)
+		} else {
+			// Compute the JSON object name of every key and sort by that name.
+			// The value is captured during iteration since keys such as NaN
+			// cannot be used to look up the value again.
+			type member struct {
+				name string
+				val  reflect.Value
+			}
+			members := make([]member, 0, n)
+			for iter := va.MapRange(); iter.Next(); {
+				k := newAddressableValue(t.Key())
+				k.SetIterKey(iter)
+				name, err := marshalMapKeyName(enc, k, marshalKey, mo)
+				if err != nil {
+					return err
+				}
+				members = append(members, member{name, iter.Value()})
+			}
+			slices.SortFunc(members, func(x, y member) int { return cmp.Compare(x.name, y.name) })
+			for _, m := range members {
+				if err := enc.WriteToken(jsontext.String(m.name)); err != nil {
+					return err
+				}
+				v.Set(m.val)
+				if err := marshalVal(enc, v, mo); err != nil {
+					return err
+				}
+			}
+		}
+		return enc.WriteToken(jsontext.EndObject)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			switch uo.Format {
+			case "emitnull", "emitempty":
+				// Formats only affect marshaling.
+			default:
+				return newInvalidFormatError(dec, t, uo)
+			}
+		}
+		switch dec.PeekKind() {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		case '{':
+			if uo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics) && !isLegacyMapKey(t.Key(), textUnmarshalerType) {
+				return newUnmarshalErrorBeforeWithSkipping(dec, t, nil)
+			}
+			once.Do(init)
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if va.IsNil() {
+				va.Set(reflect.MakeMap(t))
+			}
+			unmarshalKey := keyFncs.unmarshal
+			unmarshalVal := valFncs.unmarshal
+			if uo.Unmarshalers != nil {
+				unmarshalKey, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshalKey, t.Key())
+				unmarshalVal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshalVal, t.Elem())
+			}
+			k := newAddressableValue(t.Key())
+			v := newAddressableValue(t.Elem())
+
+			var errUnmarshal error
+			for dec.PeekKind() != '}' {
+				// Unmarshal the object member name as the map key.
+				k.SetZero()
+				depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+				err := unmarshalKey(dec, k, uo)
+				if err == nil && k.Kind() == reflect.Interface && !k.IsNil() && !k.Elem().Type().Comparable() {
+					err = newUnmarshalErrorAfter(dec, t, fmt.Errorf("invalid incomparable key type %v", k.Elem().Type()))
+				}
+				if err != nil {
+					if !isRecoverable(dec, uo, err, depth, length) {
+						return err
+					}
+					errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+					if err := dec.SkipValue(); err != nil {
+						return err
+					}
+					continue
+				}
+
+				// Unmarshal the object member value as the map value.
+				if v2 := va.MapIndex(k.Value); v2.IsValid() && !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					v.Set(v2)
+				} else {
+					v.SetZero()
+				}
+				depth, length = xd.Tokens.Depth(), xd.Tokens.Length()
+				if err := unmarshalVal(dec, v, uo); err != nil {
+					if !isRecoverable(dec, uo, err, depth, length) {
+						return err
+					}
+					errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+				}
+				va.SetMapIndex(k.Value, v.Value)
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorMismatch(dec, t)
+	}
+	return &fncs
+}
+
+// isLegacyMapKey reports whether v1 supports map keys of type t,
+// which must be a string or integer kind or implement iface.
+func isLegacyMapKey(t, iface reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	_, ok := implements(t, iface)
+	return ok
+}
+
+// marshalMapKeyName computes the JSON object name for the map key k
+// by marshaling it as an object name into a scratch encoder.
+func marshalMapKeyName(enc *jsontext.Encoder, k addressableValue, marshalKey func(*jsontext.Encoder, addressableValue, *jsonopts.Struct) error, mo *jsonopts.Struct) (string, error) {
+	enc2 := export.GetBufferedEncoder(mo)
+	defer export.PutBufferedEncoder(enc2)
+	xe2 := export.Encoder(enc2)
+	xe2.Flags.Set(jsonflags.AnyWhitespace | 0)
+	if err := enc2.WriteToken(jsontext.BeginObject); err != nil {
+		return "", err
+	}
+	start := len(xe2.Buf)
+	if err := marshalKey(enc2, k, &xe2.Struct); err != nil {
+		// Report the error at the position of the actual encoder.
+		if serr, ok := err.(*SemanticError); ok {
+			return "", newMarshalErrorBefore(enc, serr.GoType, serr.Err)
+		}
+		return "", err
+	}
+	name, _ := jsonwire.AppendUnquote(nil, xe2.Buf[start:])
+	return string(name), nil
+}
+
+// isRecoverable reports whether err from unmarshaling a value may be
+// deferred so that unmarshaling continues with the next value.
+// This is only done with v1 semantics when err is a SemanticError and
+// the entire value was consumed, where depth and length are
+// the state of the decoder before the value was unmarshaled.
+func isRecoverable(dec *jsontext.Decoder, uo *jsonopts.Struct, err error, depth int, length int64) bool {
+	if !uo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+		return false
+	}
+	for _, err := range unwrapErrors(err) {
+		if _, ok := err.(*SemanticError); !ok {
+			return false
+		}
+	}
+	xd := export.Decoder(dec)
+	return xd.Tokens.Depth() == depth && xd.Tokens.Length() == length+1
+}
+
+// appendUnmarshalError combines err, a recoverable error from unmarshaling
+// a value, with errPrior, the error from unmarshaling a preceding value.
+// Only the first error is kept unless [jsonflags.ReportAllErrors] is set.
+func appendUnmarshalError(uo *jsonopts.Struct, errPrior, err error) error {
+	if errPrior == nil || !uo.Flags.Get(jsonflags.ReportAllErrors) {
+		return cmp.Or(errPrior, err)
+	}
+	errs, ok := errPrior.(*unmarshalErrors)
+	if !ok {
+		errs = &unmarshalErrors{errPrior}
+	}
+	*errs = append(*errs, unwrapErrors(err)...)
+	return errs
+}
+
+// unmarshalErrors is the list of errors combined by appendUnmarshalError,
+// in the order they occurred. It is appended to in place, so that
+// reporting many errors takes linear time.
+type unmarshalErrors []error
+
+func (e *unmarshalErrors) Error() string {
+	return errors.Join(*e...).Error()
+}
+
+func (e *unmarshalErrors) Unwrap() []error {
+	return *e
+}
+
+// unwrapErrors returns the errors combined by appendUnmarshalError.
+func unwrapErrors(err error) []error {
+	if errs, ok := err.(*unmarshalErrors); ok {
+		return *errs
+	}
+	return []error{err}
+}
+
+func makeStructArshaler(t reflect.Type) *arshaler {
+	// NOTE: Exactly duplicate names are rejected by the namespace tracking
+	// of the underlying encoder or decoder. Distinct names that match the same
+	// Go struct field case-insensitively are tracked locally when unmarshaling
+	// with an efficient bit-set based on which Go struct fields were seen.
+	var fncs arshaler
+	var (
+		once   sync.Once
+		fields structFields
+	)
+	init := func() {
+		fields = makeStructFields(t)
+		for i := range fields.flattened {
+			f := &fields.flattened[i]
+			f.fncs = lookupArshaler(f.typ)
+		}
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		once.Do(init)
+		if fields.err != nil && !mo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+			return newMarshalErrorBefore(enc, fields.err.GoType, fields.err.Err)
+		}
+		if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+			return err
+		}
+
+		for i := range fields.flattened {
+			f := &fields.flattened[i]
+			v := addressableValue{va.Field(f.index[0]), va.forcedAddr} // addressable if struct value is addressable
+			if len(f.index) > 1 {
+				v = v.fieldByIndex(f.index[1:], false)
+				if !v.IsValid() {
+					continue // implicit nil pointer, so nothing to marshal
+				}
+			}
+
+			// The `omitzero` option is determined by the Go value alone,
+			// which can be checked before marshaling anything.
+			if f.omitzero && f.isZero(v) {
+				continue
+			}
+
+			// With v1 semantics, the `omitempty` option is also determined
+			// by the Go value alone.
+			legacyOmitEmpty := mo.Flags.Get(jsonflags.OmitEmptyWithLegacyDefinition)
+			if f.omitempty && legacyOmitEmpty && f.isEmpty(v) {
+				continue
+			}
+
+			marshal := f.fncs.marshal
+			if mo.Marshalers != nil {
+				marshal, _ = mo.Marshalers.(*Marshalers).lookup(marshal, f.typ)
+			}
+
+			// Write the object member name.
+			omitEmpty := f.omitempty && !legacyOmitEmpty
+			cp := xe.Checkpoint()
+			if err := enc.WriteToken(jsontext.String(f.name)); err != nil {
+				return err
+			}
+			var nameLen int
+			if omitEmpty {
+				out, _ := xe.OutputSince(cp)
+				nameLen = len(out)
+			}
+
+			// Write the object member value.
+			flagsOriginal := mo.Flags
+			if f.string && (!mo.Flags.Get(jsonflags.StringifyWithLegacySemantics) || canLegacyStringify(f.typ)) {
+				mo.Flags.Set(jsonflags.StringifyNumbers | 1)
+			}
+			if f.format != "" {
+				mo.Format, mo.FormatDepth = f.format, xe.Tokens.Depth()
+			}
+			err := marshal(enc, v, mo)
+			mo.Flags = flagsOriginal
+			mo.Format = ""
+			if err != nil {
+				return err
+			}
+
+			// Unwrite the object member if the value is empty.
+			if omitEmpty {
+				if out, ok := xe.OutputSince(cp); ok && isEmptyValue(out[nameLen:]) {
+					xe.Rollback(cp)
+				}
+			}
+		}
+
+		if fields.inlinedFallback != nil && !mo.Flags.Get(jsonflags.DiscardUnknownMembers) {
+			if err := marshalInlinedFallbackAll(enc, va, mo, fields.inlinedFallback); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndObject)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		switch dec.PeekKind() {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetZero()
+			}
+			return nil
+		case '{':
+			once.Do(init)
+			if fields.err != nil && !uo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+				return newUnmarshalErrorBeforeWithSkipping(dec, fields.err.GoType, fields.err.Err)
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			var seenFields []uint64 // bit-set of the Go struct fields already seen
+			var seenRequired []*structField
+			requireFields := uo.Flags.Get(jsonflags.RejectMissingRequiredFields)
+			var errUnmarshal error
+			for dec.PeekKind() != '}' {
+				// Process the object member name.
+				var flags jsonwire.ValueFlags
+				val, err := xd.ReadValue(&flags)
+				if err != nil {
+					return err
+				}
+				name := unquoteValue(val, flags)
+				f := fields.byActualName[string(name)]
+				if f == nil {
+					matchCase := uo.Flags.Get(jsonflags.MatchCaseInsensitiveNames)
+					keepDelims := uo.Flags.Get(jsonflags.MatchCaseSensitiveDelimiter)
+					for _, f2 := range fields.lookupByFoldedName(name, keepDelims) {
+						if f2.casing&nocase != 0 || (matchCase && f2.casing&strictcase == 0) {
+							f = f2
+							break
+						}
+					}
+				}
+				if f == nil {
+					if uo.Flags.Get(jsonflags.RejectUnknownMembers) {
+						err := newUnmarshalErrorAfter(dec, t, ErrUnknownName)
+						err.(*SemanticError).JSONKind = 0 // the name itself is not at fault
+						if !uo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+							return err
+						}
+						errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+					}
+					if fields.inlinedFallback == nil || uo.Flags.Get(jsonflags.RejectUnknownMembers) {
+						if err := dec.SkipValue(); err != nil {
+							return err
+						}
+						continue
+					}
+					depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+					if err := unmarshalInlinedFallbackNext(dec, va, uo, fields.inlinedFallback, val, name); err != nil {
+						if !isRecoverable(dec, uo, err, depth, length) {
+							return err
+						}
+						errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+					}
+					continue
+				}
+
+				if !uo.Flags.Get(jsonflags.AllowDuplicateNames) {
+					i, bit := f.id/64, uint64(1)<<(f.id%64)
+					if i >= len(seenFields) {
+						seenFields = append(seenFields, make([]uint64, i+1-len(seenFields))...)
+					}
+					if seenFields[i]&bit != 0 {
+						return &jsontext.SyntacticError{
+							ByteOffset:  xd.PreviousOffsetStart(),
+							JSONPointer: dec.StackPointer(),
+							Err:         jsontext.ErrDuplicateName,
+						}
+					}
+					seenFields[i] |= bit
+				}
+				if f.required && requireFields {
+					seenRequired = append(seenRequired, f)
+				}
+
+				// Process the object member value.
+				unmarshal := f.fncs.unmarshal
+				if uo.Unmarshalers != nil {
+					unmarshal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshal, f.typ)
+				}
+				v := addressableValue{va.Field(f.index[0]), va.forcedAddr} // addressable if struct value is addressable
+				if len(f.index) > 1 {
+					v = v.fieldByIndex(f.index[1:], true)
+					if !v.IsValid() {
+						err := newUnmarshalErrorBeforeWithSkipping(dec, t, errNilField)
+						if _, ok := err.(*SemanticError); !ok || !uo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) {
+							return err
+						}
+						errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+						continue
+					}
+				}
+				flagsOriginal := uo.Flags
+				if f.string && (!uo.Flags.Get(jsonflags.StringifyWithLegacySemantics) || canLegacyStringify(f.typ)) {
+					uo.Flags.Set(jsonflags.StringifyNumbers | 1)
+				}
+				if f.format != "" {
+					uo.Format, uo.FormatDepth = f.format, xd.Tokens.Depth()
+				}
+				depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+				err = unmarshal(dec, v, uo)
+				uo.Flags = flagsOriginal
+				uo.Format = ""
+				if err != nil {
+					if !isRecoverable(dec, uo, err, depth, length) {
+						return err
+					}
+					errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+				}
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if requireFields {
+				for i := range fields.flattened {
+					f := &fields.flattened[i]
+					if !f.required || slices.Contains(seenRequired, f) {
+						continue
+					}
+					err := newUnmarshalErrorAfter(dec, t, internal.ErrMissingField)
+					err.(*SemanticError).JSONKind = 0 // the object itself is not at fault
+					err.(*SemanticError).JSONPointer = dec.StackPointer().AppendToken(f.name)
+					errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+				}
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorMismatch(dec, t)
+	}
+	return &fncs
+}
+
+// canLegacyStringify reports whether the v1 `string` tag option
+// applies to a field of type t, which is only the case for
+// booleans, numbers, and strings (or pointers to such types).
+func canLegacyStringify(t reflect.Type) bool {
+	if t.Name() == "" && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isEmptyValue reports whether b, which follows an object member name,
+// holds an empty JSON value (i.e., null, "", {}, or []).
+func isEmptyValue(b []byte) bool {
+	b = bytes.TrimLeft(b, " \t\r\n:")
+	switch string(b) {
+	case "null", `""`, "{}", "[]":
+		return true
+	}
+	return false
+}
+
+func makeSliceArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	var (
+		once    sync.Once
+		valFncs *arshaler
+	)
+	init := func() {
+		valFncs = lookupArshaler(t.Elem())
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		// Check for cycles.
+		xe := export.Encoder(enc)
+		if xe.Tokens.Depth() > startDetectingCyclesAfter {
+			if err := visitPointer(&xe.SeenPointers, va.Value); err != nil {
+				return newMarshalErrorBefore(enc, t, err)
+			}
+			defer leavePointer(&xe.SeenPointers, va.Value)
+		}
+
+		emitNull := mo.Flags.Get(jsonflags.FormatNilSliceAsNull)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			switch mo.Format {
+			case "emitnull":
+				emitNull = true
+			case "emitempty":
+				emitNull = false
+			default:
+				return newInvalidFormatError(enc, t, mo)
+			}
+		}
+
+		// Handle empty slices.
+		n := va.Len()
+		if n == 0 {
+			if emitNull && va.IsNil() {
+				return enc.WriteToken(jsontext.Null)
+			}
+			if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+				return err
+			}
+			return enc.WriteToken(jsontext.EndArray)
+		}
+
+		once.Do(init)
+		if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+			return err
+		}
+		marshal := valFncs.marshal
+		if mo.Marshalers != nil {
+			marshal, _ = mo.Marshalers.(*Marshalers).lookup(marshal, t.Elem())
+		}
+		for i := range n {
+			v := addressableValue{va.Index(i), false} // indexed slice element is always addressable
+			if err := marshal(enc, v, mo); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndArray)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			switch uo.Format {
+			case "emitnull", "emitempty":
+				// Formats only affect marshaling.
+			default:
+				return newInvalidFormatError(dec, t, uo)
+			}
+		}
+		switch dec.PeekKind() {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		case '[':
+			once.Do(init)
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			unmarshal := valFncs.unmarshal
+			if uo.Unmarshalers != nil {
+				unmarshal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshal, t.Elem())
+			}
+			merge := uo.Flags.Get(jsonflags.MergeWithLegacySemantics)
+			oldLen, cap := va.Len(), va.Cap()
+			va.SetLen(cap)
+			var i int
+			var errUnmarshal error
+			for dec.PeekKind() != ']' {
+				if i == cap {
+					va.Value.Grow(1)
+					cap = va.Cap()
+					va.SetLen(cap)
+				}
+				v := addressableValue{va.Index(i), false} // indexed slice element is always addressable
+				if !merge || i >= oldLen {
+					v.SetZero()
+				}
+				i++
+				depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+				if err := unmarshal(dec, v, uo); err != nil {
+					if !isRecoverable(dec, uo, err, depth, length) {
+						va.SetLen(i)
+						return err
+					}
+					errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+				}
+			}
+			if i == 0 {
+				va.Set(reflect.MakeSlice(va.Type(), 0, 0))
+			} else {
+				va.SetLen(i)
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorMismatch(dec, t)
+	}
+	return &fncs
+}
+
+func makeArrayArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	var (
+		once    sync.Once
+		valFncs *arshaler
+	)
+	init := func() {
+		valFncs = lookupArshaler(t.Elem())
+	}
+	n := t.Len()
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		once.Do(init)
+		if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+			return err
+		}
+		marshal := valFncs.marshal
+		if mo.Marshalers != nil {
+			marshal, _ = mo.Marshalers.(*Marshalers).lookup(marshal, t.Elem())
+		}
+		for i := range n {
+			v := addressableValue{va.Index(i), va.forcedAddr} // indexed array element is addressable if array is addressable
+			if err := marshal(enc, v, mo); err != nil {
+				return err
+			}
+		}
+		return enc.WriteToken(jsontext.EndArray)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		switch dec.PeekKind() {
+		case 'n':
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetZero()
+			}
+			return nil
+		case '[':
+			once.Do(init)
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			unmarshal := valFncs.unmarshal
+			if uo.Unmarshalers != nil {
+				unmarshal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshal, t.Elem())
+			}
+			anyLength := uo.Flags.Get(jsonflags.UnmarshalArrayFromAnyLength)
+			merge := uo.Flags.Get(jsonflags.MergeWithLegacySemantics)
+			var i int
+			var errUnmarshal error
+			for dec.PeekKind() != ']' {
+				if i >= n {
+					if !anyLength {
+						return newUnmarshalErrorBeforeWithSkipping(dec, t, errArrayOverflow)
+					}
+					if err := dec.SkipValue(); err != nil {
+						return err
+					}
+					continue
+				}
+				v := addressableValue{va.Index(i), va.forcedAddr} // indexed array element is addressable if array is addressable
+				if !merge {
+					v.SetZero()
+				}
+				i++
+				depth, length := xd.Tokens.Depth(), xd.Tokens.Length()
+				if err := unmarshal(dec, v, uo); err != nil {
+					if !isRecoverable(dec, uo, err, depth, length) {
+						return err
+					}
+					errUnmarshal = appendUnmarshalError(uo, errUnmarshal, err)
+				}
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if i < n {
+				if !anyLength {
+					return newUnmarshalErrorAfter(dec, t, errArrayUnderflow)
+				}
+				for ; i < n; i++ {
+					va.Index(i).SetZero()
+				}
+			}
+			return errUnmarshal
+		}
+		return newUnmarshalErrorMismatch(dec, t)
+	}
+	return &fncs
+}
+
+// byteFormats maps each binary format name to its encoding.
+var byteFormats = map[string]struct {
+	encodedLen func(int) int
+	encode     func(dst, src []byte)
+	decodedLen func(int) int
+	decode     func(dst, src []byte) (int, error)
+}{
+	"base64":    {base64.StdEncoding.EncodedLen, base64.StdEncoding.Encode, base64.StdEncoding.DecodedLen, base64.StdEncoding.Decode},
+	"base64url": {base64.URLEncoding.EncodedLen, base64.URLEncoding.Encode, base64.URLEncoding.DecodedLen, base64.URLEncoding.Decode},
+	"base32":    {base32.StdEncoding.EncodedLen, base32.StdEncoding.Encode, base32.StdEncoding.DecodedLen, base32.StdEncoding.Decode},
+	"base32hex": {base32.HexEncoding.EncodedLen, base32.HexEncoding.Encode, base32.HexEncoding.DecodedLen, base32.HexEncoding.Decode},
+	"base16":    {hex.EncodedLen, func(dst, src []byte) { hex.Encode(dst, src) }, hex.DecodedLen, hex.Decode},
+	"hex":       {hex.EncodedLen, func(dst, src []byte) { hex.Encode(dst, src) }, hex.DecodedLen, hex.Decode},
+}
+
+func makeBytesArshaler(t reflect.Type, fncs *arshaler) *arshaler {
+	// NOTE: This handles both []~byte and [N]~byte.
+	// A byte type with its own JSON representation is
+	// treated like any other element type.
+	isArray := t.Kind() == reflect.Array
+	if lookupArshaler(t.Elem()).nonDefault {
+		if isArray {
+			return fncs
+		}
+		// With v1 semantics, such a slice may still be unmarshaled
+		// from a JSON string containing base64 data.
+		unmarshalArray := fncs.unmarshal
+		fncs2 := *fncs
+		fncs2.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+			if !uo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) || dec.PeekKind() != '"' {
+				return unmarshalArray(dec, va, uo)
+			}
+			var flags jsonwire.ValueFlags
+			val, err := export.Decoder(dec).ReadValue(&flags)
+			if err != nil {
+				return err
+			}
+			val = unquoteValue(val, flags)
+			f := byteFormats["base64"]
+			b := make([]byte, f.decodedLen(len(val)))
+			n, err := f.decode(b, val)
+			if err != nil {
+				return newUnmarshalErrorAfter(dec, t, err)
+			}
+			va.SetBytes(b[:n])
+			return nil
+		}
+		return &fncs2
+	}
+	marshalArray := fncs.marshal
+	unmarshalArray := fncs.unmarshal
+
+	// bytesFormat reports the binary format in effect,
+	// or "array" if the value is to be handled as a JSON array.
+	bytesFormat := func(o *jsonopts.Struct, depth int) (format string, emitNull, ok bool) {
+		format = "base64"
+		if o.Flags.Has(jsonflags.FormatBytes) && o.FormatBytes != "" {
+			format = o.FormatBytes
+		}
+		if isArray && o.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) {
+			format = "array"
+		}
+		emitNull = o.Flags.Get(jsonflags.FormatNilSliceAsNull)
+		if hasFormat(o, depth) {
+			switch o.Format {
+			case "emitnull":
+				emitNull = !isArray
+			case "emitempty":
+				emitNull = false
+			default:
+				format = o.Format
+			}
+		}
+		_, ok = byteFormats[format]
+		return format, emitNull, ok || format == "array"
+	}
+
+	fncs2 := *fncs
+	fncs2.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		format, emitNull, ok := bytesFormat(mo, xe.Tokens.Depth())
+		if !ok {
+			if hasFormat(mo, xe.Tokens.Depth()) {
+				return newInvalidFormatError(enc, t, mo)
+			}
+			return newMarshalErrorBefore(enc, t, fmt.Errorf("invalid bytes format %q", mo.FormatBytes))
+		}
+		if format == "array" {
+			mo.Format = ""
+			return marshalArray(enc, va, mo)
+		}
+		if !isArray && va.IsNil() && emitNull {
+			return enc.WriteToken(jsontext.Null)
+		}
+		f := byteFormats[format]
+		b := va.Bytes()
+		return xe.AppendRaw('"', true, func(dst []byte) ([]byte, error) {
+			n := len(dst)
+			dst = slices.Grow(dst, f.encodedLen(len(b)))[:n+f.encodedLen(len(b))]
+			f.encode(dst[n:], b)
+			return dst, nil
+		})
+	}
+	fncs2.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		format, _, ok := bytesFormat(uo, xd.Tokens.Depth())
+		if !ok {
+			if hasFormat(uo, xd.Tokens.Depth()) {
+				return newInvalidFormatError(dec, t, uo)
+			}
+			return newUnmarshalErrorBeforeWithSkipping(dec, t, fmt.Errorf("invalid bytes format %q", uo.FormatBytes))
+		}
+		if format == "array" || (uo.Flags.Get(jsonflags.FormatBytesWithLegacySemantics) && dec.PeekKind() == '[') {
+			uo.Format = ""
+			return unmarshalArray(dec, va, uo)
+		}
+		var flags jsonwire.ValueFlags
+		val, err := xd.ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		switch val.Kind() {
+		case 'n':
+			if !isArray || !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+				va.SetZero()
+			}
+			return nil
+		case '"':
+			f := byteFormats[format]
+			val = unquoteValue(val, flags)
+			var b []byte
+			if !isArray {
+				b = va.Bytes()[:0]
+				if b == nil {
+					b = []byte{} // an empty JSON string is not a JSON null
+				}
+			}
+			n := f.decodedLen(len(val))
+			b = slices.Grow(b, n)[:n]
+			n, err := f.decode(b, val)
+			if err != nil {
+				return newUnmarshalErrorAfter(dec, t, err)
+			}
+			b = b[:n]
+			if isArray {
+				if n != t.Len() {
+					return newUnmarshalErrorAfter(dec, t, fmt.Errorf("decoded length of %d mismatches array length of %d", n, t.Len()))
+				}
+				copy(va.Bytes(), b)
+				return nil
+			}
+			va.SetBytes(b)
+			return nil
+		}
+		return newUnmarshalErrorAfter(dec, t, nil)
+	}
+	return &fncs2
+}
+
+func makePointerArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	var (
+		once    sync.Once
+		valFncs *arshaler
+	)
+	init := func() {
+		valFncs = lookupArshaler(t.Elem())
+	}
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		// Check for cycles.
+		xe := export.Encoder(enc)
+		if xe.Tokens.Depth() > startDetectingCyclesAfter {
+			if err := visitPointer(&xe.SeenPointers, va.Value); err != nil {
+				return newMarshalErrorBefore(enc, t, err)
+			}
+			defer leavePointer(&xe.SeenPointers, va.Value)
+		}
+
+		// NOTE: Struct.Format is forwarded to underlying marshal.
+		if va.IsNil() {
+			// With v1 semantics, a nil pointer map key is an empty name.
+			if mo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics) && xe.Tokens.NeedObjectName() {
+				return enc.WriteToken(jsontext.String(""))
+			}
+			return enc.WriteToken(jsontext.Null)
+		}
+		once.Do(init)
+		marshal := valFncs.marshal
+		if mo.Marshalers != nil {
+			marshal, _ = mo.Marshalers.(*Marshalers).lookup(marshal, t.Elem())
+		}
+		v := addressableValue{va.Elem(), false} // dereferenced pointer is always addressable
+		err := marshal(enc, v, mo)
+		if err != nil && mo.Flags.Get(jsonflags.ReportErrorsWithLegacySemantics) && t.Elem().Kind() != reflect.Pointer {
+			err = internal.PointerMarshalerError(err, t)
+		}
+		return err
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		// NOTE: Struct.Format is forwarded to underlying unmarshal.
+		if dec.PeekKind() == 'n' {
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			va.SetZero()
+			return nil
+		}
+		once.Do(init)
+		unmarshal := valFncs.unmarshal
+		if uo.Unmarshalers != nil {
+			unmarshal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshal, t.Elem())
+		}
+		if va.IsNil() {
+			va.Set(reflect.New(t.Elem()))
+		}
+		v := addressableValue{va.Elem(), false} // dereferenced pointer is always addressable
+		return unmarshal(dec, v, uo)
+	}
+	return &fncs
+}
+
+func makeInterfaceArshaler(t reflect.Type) *arshaler {
+	// NOTE: Values retrieved from an interface are not addressable,
+	// so we shallow copy the values to make them addressable and
+	// store them back into the interface afterwards.
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		xe := export.Encoder(enc)
+		if hasFormat(mo, xe.Tokens.Depth()) {
+			return newInvalidFormatError(enc, t, mo)
+		}
+		if va.IsNil() {
+			return enc.WriteToken(jsontext.Null)
+		}
+		if mo.Flags.Get(jsonflags.CallMethodsWithLegacySemantics) {
+			// With v1 semantics, a method declared by the interface type
+			// is called even if the interface holds a nil pointer.
+			if p := va.Elem(); p.Kind() == reflect.Pointer && p.IsNil() {
+				if ok, err := marshalNilMethod(enc, t, p); ok {
+					return err
+				}
+			}
+		}
+		v := newAddressableValue(va.Elem().Type())
+		v.Set(va.Elem())
+		marshal := lookupArshaler(v.Type()).marshal
+		if mo.Marshalers != nil {
+			marshal, _ = mo.Marshalers.(*Marshalers).lookup(marshal, v.Type())
+		}
+		return marshal(enc, v, mo)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		xd := export.Decoder(dec)
+		if hasFormat(uo, xd.Tokens.Depth()) {
+			return newInvalidFormatError(dec, t, uo)
+		}
+		if dec.PeekKind() == 'n' {
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			// With v1 semantics, a non-nil pointer to a pointer
+			// held by the interface is decoded into instead.
+			if uo.Flags.Get(jsonflags.MergeWithLegacySemantics) && !va.IsNil() &&
+				va.Elem().Kind() == reflect.Pointer && !va.Elem().IsNil() && va.Elem().Elem().Kind() == reflect.Pointer {
+				va.Elem().Elem().SetZero()
+				return nil
+			}
+			va.SetZero()
+			return nil
+		}
+
+		var v addressableValue
+		if !va.IsNil() && va.Elem().Kind() == reflect.Pointer && !va.Elem().IsNil() && !isSelfPointer(va) {
+			// Decode into the value referenced by the existing pointer.
+			v = newAddressableValue(va.Elem().Type())
+			v.Set(va.Elem())
+		} else {
+			// Only an empty interface can derive a concrete type
+			// from the JSON kind.
+			if t.NumMethod() > 0 {
+				return newUnmarshalErrorBeforeWithSkipping(dec, t, errNonConcreteValue)
+			}
+
+			// Optimize for the any type if there are no special options.
+			if optimizeCommon && t == anyType && uo.Unmarshalers == nil {
+				v, err := unmarshalValueAny(dec, uo)
+				// We must check for nil interface values up front.
+				// See https://go.dev/issue/52310.
+				if v != nil {
+					va.Set(reflect.ValueOf(v))
+				} else {
+					va.SetZero()
+				}
+				return err
+			}
+
+			switch k := dec.PeekKind(); k {
+			case 'f', 't':
+				v = newAddressableValue(boolType)
+			case '"':
+				v = newAddressableValue(stringType)
+			case '0':
+				if uo.Flags.Get(jsonflags.UnmarshalAnyWithRawNumber) {
+					val, err := dec.ReadValue()
+					if err != nil {
+						return err
+					}
+					va.Set(reflect.ValueOf(internal.RawNumberOf(val)))
+					return nil
+				}
+				v = newAddressableValue(float64Type)
+			case '{':
+				v = newAddressableValue(mapStringAnyType)
+			case '[':
+				v = newAddressableValue(sliceAnyType)
+			default:
+				// If k is invalid (e.g., due to an I/O or syntax error), then
+				// that will be cached by PeekKind and returned by ReadValue.
+				// If k is '}' or ']', then ReadValue must error since
+				// those are invalid kinds at the start of a JSON value.
+				_, err := dec.ReadValue()
+				return err
+			}
+		}
+
+		unmarshal := lookupArshaler(v.Type()).unmarshal
+		if uo.Unmarshalers != nil {
+			unmarshal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshal, v.Type())
+		}
+		err := unmarshal(dec, v, uo)
+		va.Set(v.Value)
+		return err
+	}
+	return &fncs
+}
+
+// isSelfPointer reports whether the interface value va holds
+// a pointer to itself (e.g., after "var v any; v = &v").
+// Decoding into such a value must not descend through the pointer,
+// otherwise it would recurse forever.
+func isSelfPointer(va addressableValue) bool {
+	p := va.Elem()
+	return p.Elem().Kind() == reflect.Interface && p.Pointer() == va.Addr().Pointer()
+}
+
+func makeInvalidArshaler(t reflect.Type) *arshaler {
+	var fncs arshaler
+	fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+		return newMarshalErrorBefore(enc, t, errUnsupportedType)
+	}
+	fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+		return newUnmarshalErrorBeforeWithSkipping(dec, t, errUnsupportedType)
+	}
+	return &fncs
+}
+
+// newInvalidFormatError reports that the format in o is not supported
+// for values of type t.
+func newInvalidFormatError(c any, t reflect.Type, o *jsonopts.Struct) error {
+	err := fmt.Errorf("invalid format flag %q", o.Format)
+	switch c := c.(type) {
+	case *jsontext.Encoder:
+		return newMarshalErrorBefore(c, t, err)
+	case *jsontext.Decoder:
+		return newUnmarshalErrorBeforeWithSkipping(c, t, err)
+	default:
+		panic(errors.New("BUG: invalid coder type"))
+	}
+}
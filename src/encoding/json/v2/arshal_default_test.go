@@ -0,0 +1,824 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+	"encoding/json/jsontext"
+)
+
+type (
+	namedBool    bool
+	namedString  string
+	namedInt     int16
+	namedUint    uint32
+	namedFloat   float32
+	namedMap     map[namedString]namedInt
+	namedSlice   []namedString
+	namedArray   [2]namedBool
+	namedPointer *namedInt
+	namedAny     any
+
+	structOmit struct {
+		Zero      int            `json:",omitzero"`
+		ZeroPtr   *int           `json:",omitzero"`
+		ZeroSt    structEmbedded `json:",omitzero"`
+		Empty     string         `json:",omitempty"`
+		EmptyMap  map[string]int `json:",omitempty"`
+		EmptySl   []int          `json:",omitempty"`
+		EmptyPtr  *[]int         `json:",omitempty"`
+		EmptyIfc  any            `json:",omitempty"`
+		EmptyNum  int            `json:",omitempty"`
+		EmptyBool bool           `json:",omitempty"`
+	}
+	structStringified struct {
+		Int   int         `json:",string"`
+		Uint  uint        `json:",string"`
+		Float float64     `json:",string"`
+		Ptr   *int        `json:",string"`
+		Slice []int       `json:",string"`
+		Str   string      `json:",string"`
+		Bool  bool        `json:",string"`
+		Map   map[int]int `json:",string"`
+	}
+	structRequired struct {
+		A int `json:",required"`
+		B int
+		C int `json:",required"`
+	}
+	structInlinedValue struct {
+		A    int
+		Rest jsontext.Value `json:",inline"`
+	}
+	structInlinedMap struct {
+		A    int
+		Rest map[string]int `json:",inline"`
+	}
+	structInlinedPointer struct {
+		*structEmbedded
+		C int
+	}
+	structRecursive struct {
+		Name string
+		Next *structRecursive `json:",omitzero"`
+	}
+	structEmbeddedUnexported struct{ A int }
+	recursiveSlice           []recursiveSlice
+	recursiveMap             map[string]recursiveMap
+)
+
+func TestMarshalDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   any
+		want string
+	}{{
+		name: "Bool",
+		in:   []any{true, false, namedBool(true)},
+		want: `[true,false,true]`,
+	}, {
+		name: "String",
+		in:   []any{"", "hello", namedString("named"), "\u2028<>&\x7fé"},
+		want: `["","hello","named","` + "\u2028<>&\x7fé" + `"]`,
+	}, {
+		name: "String/EscapeForHTML",
+		opts: []Options{jsontext.EscapeForHTML(true), jsontext.EscapeForJS(true)},
+		in:   "\u2028<>&",
+		want: `"\u2028\u003c\u003e\u0026"`,
+	}, {
+		name: "String/InvalidUTF8",
+		opts: []Options{jsontext.AllowInvalidUTF8(true)},
+		in:   "\xff",
+		want: "\"�\"",
+	}, {
+		name: "Ints",
+		in:   []any{int(0), int8(math.MinInt8), int16(math.MaxInt16), int32(math.MinInt32), int64(math.MaxInt64), namedInt(-1)},
+		want: `[0,-128,32767,-2147483648,9223372036854775807,-1]`,
+	}, {
+		name: "Uints",
+		in:   []any{uint(0), uint8(math.MaxUint8), uint16(math.MaxUint16), uint32(math.MaxUint32), uint64(math.MaxUint64), uintptr(1), namedUint(2)},
+		want: `[0,255,65535,4294967295,18446744073709551615,1,2]`,
+	}, {
+		name: "Floats",
+		in:   []any{0.0, math.Copysign(0, -1), 1.5, 1e21, 1e-7, math.MaxFloat64, math.SmallestNonzeroFloat64, 0.1},
+		want: `[0,-0,1.5,1e+21,1e-7,1.7976931348623157e+308,5e-324,0.1]`,
+	}, {
+		name: "Float32",
+		in:   []any{float32(0.1), float32(math.MaxFloat32), namedFloat(3.14)},
+		want: `[0.1,3.4028235e+38,3.14]`,
+	}, {
+		name: "Float/NonFiniteAsString",
+		in: struct {
+			NaN    float64 `json:",format:nonfinite"`
+			PosInf float64 `json:",format:nonfinite"`
+			NegInf float32 `json:",format:nonfinite"`
+			Finite float64 `json:",format:nonfinite"`
+		}{math.NaN(), math.Inf(+1), float32(math.Inf(-1)), 1},
+		want: `{"NaN":"NaN","PosInf":"Infinity","NegInf":"-Infinity","Finite":1}`,
+	}, {
+		name: "Map",
+		opts: []Options{Deterministic(true)},
+		in:   namedMap{"b": 2, "a": 1, "": 0},
+		want: `{"":0,"a":1,"b":2}`,
+	}, {
+		name: "Map/IntKeys",
+		opts: []Options{Deterministic(true)},
+		in:   map[int]bool{-1: true, 10: false, 2: true},
+		want: `{"-1":true,"10":false,"2":true}`,
+	}, {
+		name: "Map/UintKeys",
+		in:   map[uint8]int{255: 1},
+		want: `{"255":1}`,
+	}, {
+		name: "Map/FloatKeys",
+		opts: []Options{Deterministic(true)},
+		in:   map[float64]int{1.5: 1, -0.25: 2},
+		want: `{"-0.25":2,"1.5":1}`,
+	}, {
+		name: "Map/InterfaceKeys",
+		opts: []Options{Deterministic(true)},
+		in:   map[any]int{"b": 2, "a": 1},
+		want: `{"a":1,"b":2}`,
+	}, {
+		name: "Map/NilAndEmpty",
+		in:   struct{ Nil, Empty map[string]int }{Empty: map[string]int{}},
+		want: `{"Nil":{},"Empty":{}}`,
+	}, {
+		name: "Map/EmitNull",
+		in: struct {
+			Nil   map[string]int `json:",format:emitnull"`
+			Empty map[string]int `json:",format:emitnull"`
+		}{Empty: map[string]int{}},
+		want: `{"Nil":null,"Empty":{}}`,
+	}, {
+		name: "Slice",
+		in:   namedSlice{"a", "b"},
+		want: `["a","b"]`,
+	}, {
+		name: "Slice/NilAndEmpty",
+		in:   struct{ Nil, Empty []int }{Empty: []int{}},
+		want: `{"Nil":[],"Empty":[]}`,
+	}, {
+		name: "Slice/EmitNull",
+		in: struct {
+			Nil []int `json:",format:emitnull"`
+		}{},
+		want: `{"Nil":null}`,
+	}, {
+		name: "Slice/Nested",
+		in:   [][]int{{1}, nil, {}, {2, 3}},
+		want: `[[1],[],[],[2,3]]`,
+	}, {
+		name: "Array",
+		in:   namedArray{true, false},
+		want: `[true,false]`,
+	}, {
+		name: "Array/Empty",
+		in:   [0]int{},
+		want: `[]`,
+	}, {
+		name: "Array/Bytes",
+		in:   [4]byte{0xde, 0xad, 0xbe, 0xef},
+		want: `"3q2+7w=="`,
+	}, {
+		name: "Pointer",
+		in:   []any{addr(1), (*int)(nil), addr(addr("s")), namedPointer(addr(namedInt(2)))},
+		want: `[1,null,"s",2]`,
+	}, {
+		name: "Interface",
+		in:   []any{nil, namedAny(1), []any{namedAny(nil)}, map[string]any{}},
+		want: `[null,1,[null],{}]`,
+	}, {
+		name: "Interface/NonEmpty",
+		in:   struct{ E, N error }{N: (*SemanticError)(nil)},
+		want: `{"E":null,"N":null}`,
+	}, {
+		name: "Struct/Empty",
+		in:   struct{}{},
+		want: `{}`,
+	}, {
+		name: "Struct/Omit",
+		in:   structOmit{EmptyPtr: new([]int), EmptyIfc: []int{}},
+		want: `{"EmptyNum":0,"EmptyBool":false}`,
+	}, {
+		name: "Struct/OmitNonEmpty",
+		in: structOmit{
+			Zero: 1, ZeroPtr: new(int), ZeroSt: structEmbedded{1},
+			Empty: "e", EmptyMap: map[string]int{"": 0}, EmptySl: []int{0},
+			EmptyPtr: &[]int{0}, EmptyIfc: 0, EmptyNum: 1, EmptyBool: true,
+		},
+		want: `{"Zero":1,"ZeroPtr":0,"ZeroSt":{"B":1},"Empty":"e","EmptyMap":{"":0},"EmptySl":[0],"EmptyPtr":[0],"EmptyIfc":0,"EmptyNum":1,"EmptyBool":true}`,
+	}, {
+		name: "Struct/Stringified",
+		in: structStringified{
+			Int: -1, Uint: 2, Float: 3.5, Ptr: addr(4), Slice: []int{5},
+			Str: "6", Bool: true, Map: map[int]int{7: 8},
+		},
+		want: `{"Int":"-1","Uint":"2","Float":"3.5","Ptr":"4","Slice":["5"],"Str":"6","Bool":true,"Map":{"7":"8"}}`,
+	}, {
+		name: "Struct/InlinedValue",
+		in:   structInlinedValue{A: 1, Rest: jsontext.Value(` { "B" : 2 , "C" : [3] } `)},
+		want: `{"A":1,"B":2,"C":[3]}`,
+	}, {
+		name: "Struct/InlinedValueEmpty",
+		in:   structInlinedValue{A: 1},
+		want: `{"A":1}`,
+	}, {
+		name: "Struct/InlinedMap",
+		opts: []Options{Deterministic(true)},
+		in:   structInlinedMap{A: 1, Rest: map[string]int{"C": 3, "B": 2}},
+		want: `{"A":1,"B":2,"C":3}`,
+	}, {
+		name: "Struct/InlinedNilPointer",
+		in:   structInlinedPointer{C: 3},
+		want: `{"C":3}`,
+	}, {
+		name: "Struct/InlinedPointer",
+		in:   structInlinedPointer{&structEmbedded{2}, 3},
+		want: `{"B":2,"C":3}`,
+	}, {
+		name: "Struct/Recursive",
+		in:   structRecursive{"a", &structRecursive{"b", &structRecursive{Name: "c"}}},
+		want: `{"Name":"a","Next":{"Name":"b","Next":{"Name":"c"}}}`,
+	}, {
+		name: "Struct/Format",
+		opts: []Options{FormatNilSliceAsNull(true)},
+		in: struct {
+			A []int `json:",format:emitempty"`
+			B []int
+		}{},
+		want: `{"A":[],"B":null}`,
+	}, {
+		name: "JSONValue",
+		in:   []jsontext.Value{jsontext.Value(` [ 1 ] `), nil},
+		want: `[[1],null]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		into any
+		want any
+	}{{
+		name: "Bool",
+		in:   `[true,false,null]`,
+		into: &[]namedBool{false, true, true},
+		want: addr([]namedBool{true, false, false}),
+	}, {
+		name: "String",
+		in:   `["", "aé😀", "\/"]`,
+		into: new([]namedString),
+		want: addr([]namedString{"", "aé\U0001f600", "/"}),
+	}, {
+		name: "String/InvalidUTF8",
+		opts: []Options{jsontext.AllowInvalidUTF8(true)},
+		in:   "\"\xff\"",
+		into: new(string),
+		want: addr("\ufffd"),
+	}, {
+		name: "Ints",
+		in:   `[-128,32767,-2147483648,9223372036854775807,-0]`,
+		into: new([5]any),
+		want: &[5]any{-128.0, 32767.0, -2147483648.0, 9223372036854775807.0, math.Copysign(0, -1)},
+	}, {
+		name: "Ints/Typed",
+		in:   `{"A":-128,"B":32767,"C":-9223372036854775808,"D":-1}`,
+		into: new(struct {
+			A int8
+			B int16
+			C int64
+			D namedInt
+		}),
+		want: &struct {
+			A int8
+			B int16
+			C int64
+			D namedInt
+		}{-128, 32767, math.MinInt64, -1},
+	}, {
+		name: "Uints",
+		in:   `[0,255,18446744073709551615,4294967295]`,
+		into: new([]uint64),
+		want: addr([]uint64{0, 255, math.MaxUint64, math.MaxUint32}),
+	}, {
+		name: "Floats",
+		in:   `[0,-0,1.5,1e+21,1E-7,5e-324]`,
+		into: new([]float64),
+		want: addr([]float64{0, math.Copysign(0, -1), 1.5, 1e21, 1e-7, 5e-324}),
+	}, {
+		name: "Float32",
+		in:   `[0.1,3.4028235e+38]`,
+		into: new([]namedFloat),
+		want: addr([]namedFloat{0.1, math.MaxFloat32}),
+	}, {
+		name: "Float/NonFiniteAsString",
+		in:   `{"PosInf":"Infinity","NegInf":"-Infinity","Finite":1}`,
+		into: new(struct {
+			PosInf float64 `json:",format:nonfinite"`
+			NegInf float32 `json:",format:nonfinite"`
+			Finite float64 `json:",format:nonfinite"`
+		}),
+		want: &struct {
+			PosInf float64 `json:",format:nonfinite"`
+			NegInf float32 `json:",format:nonfinite"`
+			Finite float64 `json:",format:nonfinite"`
+		}{math.Inf(+1), float32(math.Inf(-1)), 1},
+	}, {
+		name: "Map",
+		in:   `{"a":1,"b":null}`,
+		into: &namedMap{"a": 9, "b": 9, "c": 9},
+		want: &namedMap{"a": 1, "b": 0, "c": 9},
+	}, {
+		name: "Map/Null",
+		in:   `null`,
+		into: &map[string]int{"a": 1},
+		want: new(map[string]int),
+	}, {
+		name: "Map/IntKeys",
+		in:   `{"-1":true,"10":false}`,
+		into: new(map[int]bool),
+		want: &map[int]bool{-1: true, 10: false},
+	}, {
+		name: "Map/FloatKeys",
+		in:   `{"1.5":1,"-0.25":2}`,
+		into: new(map[float64]int),
+		want: &map[float64]int{1.5: 1, -0.25: 2},
+	}, {
+		name: "Map/InterfaceKeys",
+		in:   `{"k":1}`,
+		into: new(map[any]int),
+		want: &map[any]int{"k": 1},
+	}, {
+		name: "Map/MergeValues",
+		in:   `{"k":{"B":2}}`,
+		into: &map[string]structInlinedMap{"k": {A: 1}},
+		want: &map[string]structInlinedMap{"k": {A: 1, Rest: map[string]int{"B": 2}}},
+	}, {
+		name: "Slice",
+		in:   `["a","b"]`,
+		into: &namedSlice{"x", "y", "z"},
+		want: &namedSlice{"a", "b"},
+	}, {
+		name: "Slice/Empty",
+		in:   `[]`,
+		into: new([]int),
+		want: addr([]int{}),
+	}, {
+		name: "Slice/Null",
+		in:   `null`,
+		into: addr([]int{1}),
+		want: new([]int),
+	}, {
+		name: "Slice/ResetElements",
+		in:   `[{"B":1},{}]`,
+		into: &[]structInlinedMap{{A: 1}, {A: 2}},
+		want: &[]structInlinedMap{{Rest: map[string]int{"B": 1}}, {}},
+	}, {
+		name: "Array",
+		in:   `[true,false]`,
+		into: &namedArray{false, true},
+		want: &namedArray{true, false},
+	}, {
+		name: "Array/Null",
+		in:   `null`,
+		into: &[2]int{1, 2},
+		want: &[2]int{},
+	}, {
+		name: "Array/Bytes",
+		in:   `"3q2+7w=="`,
+		into: new([4]byte),
+		want: &[4]byte{0xde, 0xad, 0xbe, 0xef},
+	}, {
+		name: "Pointer",
+		in:   `[1,null,"s"]`,
+		into: &[]any{new(int), new(int), new(*string)},
+		want: &[]any{1.0, nil, "s"},
+	}, {
+		name: "Pointer/Allocated",
+		in:   `{"C":1}`,
+		into: new(*structInlinedPointer),
+		want: addr(&structInlinedPointer{C: 1}),
+	}, {
+		name: "Pointer/Null",
+		in:   `null`,
+		into: addr(addr(1)),
+		want: new(*int),
+	}, {
+		name: "Pointer/Reused",
+		in:   `{"B":2}`,
+		into: addr(&structEmbedded{1}),
+		want: addr(&structEmbedded{2}),
+	}, {
+		name: "Interface/Types",
+		in:   `[null,true,"s",1.5,[1],{"k":[]}]`,
+		into: new(any),
+		want: addr(any([]any{nil, true, "s", 1.5, []any{1.0}, map[string]any{"k": []any{}}})),
+	}, {
+		name: "Interface/ReplaceMap",
+		in:   `{"b":2}`,
+		into: addr(any(map[string]any{"a": 1.0})),
+		want: addr(any(map[string]any{"b": 2.0})),
+	}, {
+		name: "Interface/ReplaceNonMap",
+		in:   `{"b":2}`,
+		into: addr(any([]int{1})),
+		want: addr(any(map[string]any{"b": 2.0})),
+	}, {
+		name: "Interface/ExistingPointer",
+		in:   `"s"`,
+		into: addr(any(addr("old"))),
+		want: addr(any(addr("s"))),
+	}, {
+		name: "Interface/Null",
+		in:   `null`,
+		into: addr(any(1)),
+		want: new(any),
+	}, {
+		name: "Struct/Omit",
+		in:   `{"Zero":1,"Empty":"e","EmptyIfc":[]}`,
+		into: new(structOmit),
+		want: &structOmit{Zero: 1, Empty: "e", EmptyIfc: []any{}},
+	}, {
+		name: "Struct/Stringified",
+		in:   `{"Int":"-1","Uint":"2","Float":"3.5","Ptr":"4","Slice":["5"],"Str":"6","Bool":true,"Map":{"7":"8"}}`,
+		into: new(structStringified),
+		want: &structStringified{
+			Int: -1, Uint: 2, Float: 3.5, Ptr: addr(4), Slice: []int{5},
+			Str: "6", Bool: true, Map: map[int]int{7: 8},
+		},
+	}, {
+		name: "Struct/Required",
+		in:   `{"C":3,"A":1}`,
+		into: new(structRequired),
+		want: &structRequired{A: 1, C: 3},
+	}, {
+		name: "Struct/InlinedValue",
+		in:   `{"B":2,"A":1,"C":[3]}`,
+		into: new(structInlinedValue),
+		want: &structInlinedValue{A: 1, Rest: jsontext.Value(`{"B":2,"C":[3]}`)},
+	}, {
+		name: "Struct/InlinedValueAppend",
+		in:   `{"C":3}`,
+		into: &structInlinedValue{Rest: jsontext.Value(`{"B":2}`)},
+		want: &structInlinedValue{Rest: jsontext.Value(`{"B":2,"C":3}`)},
+	}, {
+		name: "Struct/InlinedMap",
+		in:   `{"B":2,"A":1}`,
+		into: new(structInlinedMap),
+		want: &structInlinedMap{A: 1, Rest: map[string]int{"B": 2}},
+	}, {
+		name: "Struct/Recursive",
+		in:   `{"Name":"a","Next":{"Name":"b"}}`,
+		into: new(structRecursive),
+		want: &structRecursive{"a", &structRecursive{Name: "b"}},
+	}, {
+		name: "Struct/Null",
+		in:   `null`,
+		into: &structEmbedded{1},
+		want: &structEmbedded{},
+	}, {
+		name: "Struct/Merge",
+		in:   `{"B":2}`,
+		into: &structInlinedValue{A: 1},
+		want: &structInlinedValue{A: 1, Rest: jsontext.Value(`{"B":2}`)},
+	}, {
+		name: "JSONValue",
+		in:   `[ [ 1 ] , null ]`,
+		into: new([]jsontext.Value),
+		want: addr([]jsontext.Value{jsontext.Value(`[ 1 ]`), jsontext.Value(`null`)}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.in), tt.into, tt.opts...); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) && !equalNaN(tt.into, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+// equalNaN reports whether x and y are equal after formatting,
+// which treats NaN values as equal to each other.
+func equalNaN(x, y any) bool {
+	bx, errx := Marshal(x, jsontext.AllowInvalidUTF8(true), Deterministic(true), JoinOptions(StringifyNumbers(true)))
+	by, erry := Marshal(y, jsontext.AllowInvalidUTF8(true), Deterministic(true), JoinOptions(StringifyNumbers(true)))
+	return errx == nil && erry == nil && string(bx) == string(by) && strings.Contains(string(bx), "NaN")
+}
+
+func TestMarshalDefaultErrors(t *testing.T) {
+	cyclicMap := recursiveMap{}
+	cyclicMap["k"] = cyclicMap
+	cyclicSlice := recursiveSlice{nil}
+	cyclicSlice[0] = cyclicSlice
+	cyclicPointer := &structRecursive{Name: "a"}
+	cyclicPointer.Next = cyclicPointer
+
+	tests := []struct {
+		name    string
+		opts    []Options
+		in      any
+		wantErr string
+		wantIs  error
+		offset  int64
+		pointer jsontext.Pointer
+	}{{
+		name:    "Unsupported/Chan",
+		in:      map[string]any{"c": make(chan int)},
+		wantErr: `json: cannot marshal Go chan int within "/c": unsupported type`,
+		wantIs:  errUnsupportedType,
+		offset:  4,
+		pointer: "/c",
+	}, {
+		name:    "Unsupported/Func",
+		in:      []func(){nil},
+		wantErr: `json: cannot marshal Go func() within "/0": unsupported type`,
+		wantIs:  errUnsupportedType,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Unsupported/Complex",
+		in:      struct{ C complex128 }{},
+		wantErr: `json: cannot marshal Go complex128 within "/C": unsupported type`,
+		wantIs:  errUnsupportedType,
+		offset:  4,
+		pointer: "/C",
+	}, {
+		name:    "NonFinite",
+		in:      []float64{0, math.Inf(-1)},
+		wantErr: `json: cannot marshal Go float64 within "/1": cannot marshal non-finite number: -Inf`,
+		wantIs:  errNonFiniteNumber,
+		offset:  2,
+		pointer: "/1",
+	}, {
+		name:    "InvalidUTF8",
+		in:      map[string]string{"k": "\xff"},
+		wantErr: `jsontext: invalid UTF-8 within "/k" after offset 4`,
+		offset:  4,
+		pointer: "/k",
+	}, {
+		name:    "InvalidUTF8/Name",
+		in:      map[string]int{"\xff": 0},
+		wantErr: `jsontext: invalid UTF-8 after offset 1`,
+		offset:  1,
+	}, {
+		name:    "DuplicateName/InterfaceKeys",
+		in:      map[any]int{"1": 0, 1: 0},
+		wantErr: `jsontext: duplicate object member name "1"`,
+		wantIs:  jsontext.ErrDuplicateName,
+	}, {
+		name:    "Cycle/Map",
+		in:      cyclicMap,
+		wantErr: `encountered a cycle via json.recursiveMap`,
+	}, {
+		name:    "Cycle/Slice",
+		in:      cyclicSlice,
+		wantErr: `encountered a cycle via json.recursiveSlice`,
+	}, {
+		name:    "Cycle/Pointer",
+		in:      cyclicPointer,
+		wantErr: `encountered a cycle via *json.structRecursive`,
+	}, {
+		name:    "InvalidKeyKind",
+		in:      map[bool]int{true: 1},
+		wantErr: `jsontext: object member name must be a string after offset 1`,
+		offset:  1,
+	}, {
+		name: "InvalidFormat/Bool",
+		in: struct {
+			B bool `json:",format:hex"`
+		}{},
+		wantErr: `json: cannot marshal Go bool within "/B": invalid format flag "hex"`,
+		offset:  4,
+		pointer: "/B",
+	}, {
+		name: "InvalidFormat/Map",
+		in: struct {
+			M map[string]int `json:",format:emitNull"`
+		}{},
+		wantErr: `json: cannot marshal Go map[string]int within "/M": invalid format flag "emitNull"`,
+		offset:  4,
+		pointer: "/M",
+	}, {
+		name: "InvalidFormat/Interface",
+		in: struct {
+			I any `json:",format:hex"`
+		}{I: 1},
+		wantErr: `json: cannot marshal Go interface {} within "/I": invalid format flag "hex"`,
+		offset:  4,
+		pointer: "/I",
+	}, {
+		name: "InvalidTag",
+		in: []any{struct {
+			A int `json:",format"`
+		}{}},
+		wantErr: "json: cannot marshal Go struct { A int \"json:\\\",format\\\"\" } within \"/0\": Go struct field A is missing value for `format` tag option",
+		offset:  1,
+		pointer: "/0",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in, tt.opts...)
+			if strings.HasPrefix(tt.name, "Cycle/") || strings.HasPrefix(tt.name, "DuplicateName/") {
+				// The position of these errors depends on map iteration order
+				// or on the depth at which cycle detection starts.
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Marshal error:\n\tgot:  %v\n\twant: ...%s...", err, tt.wantErr)
+				}
+				if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+					t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+				}
+				return
+			}
+			checkErrorPosition(t, "Marshal", err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
+
+func TestUnmarshalDefaultErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Options
+		in      string
+		into    any
+		wantErr string
+		wantIs  error
+		offset  int64
+		pointer jsontext.Pointer
+	}{{
+		name:    "NilPointer",
+		in:      `{}`,
+		into:    (*structAll)(nil),
+		wantErr: `json: cannot unmarshal into Go *json.structAll: value must be passed as a non-nil pointer reference`,
+		wantIs:  errNonNilReference,
+	}, {
+		name:    "Mismatch/Bool",
+		in:      `{"Bool":"true"}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal JSON string "true" into Go bool within "/Bool"`,
+		offset:  8,
+		pointer: "/Bool",
+	}, {
+		name:    "Mismatch/String",
+		in:      `[1]`,
+		into:    new([]string),
+		wantErr: `json: cannot unmarshal JSON number 1 into Go string within "/0"`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Mismatch/Map",
+		in:      `{"Map":[]}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal JSON array into Go map[string]int within "/Map"`,
+		offset:  7,
+		pointer: "/Map",
+	}, {
+		name:    "Mismatch/Slice",
+		in:      `{"Slice":{}}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal JSON object into Go []string within "/Slice"`,
+		offset:  9,
+		pointer: "/Slice",
+	}, {
+		name:    "Mismatch/Struct",
+		in:      `[true]`,
+		into:    new([]structAll),
+		wantErr: `json: cannot unmarshal JSON boolean into Go json.structAll within "/0"`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Int/Overflow",
+		in:      `[127,128]`,
+		into:    new([]int8),
+		wantErr: `json: cannot unmarshal JSON number 128 into Go int8 within "/1": value out of range`,
+		wantIs:  strconv.ErrRange,
+		offset:  5,
+		pointer: "/1",
+	}, {
+		name:    "Int/Fraction",
+		in:      `[1.5]`,
+		into:    new([]int),
+		wantErr: `json: cannot unmarshal JSON number 1.5 into Go int within "/0": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Uint/Negative",
+		in:      `{"Uint":-1}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal JSON number -1 into Go uint8 within "/Uint": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  8,
+		pointer: "/Uint",
+	}, {
+		name:    "Float/Overflow",
+		in:      `[1e39]`,
+		into:    new([]float32),
+		wantErr: `json: cannot unmarshal JSON number 1e39 into Go float32 within "/0": value out of range`,
+		wantIs:  strconv.ErrRange,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Array/Overflow",
+		in:      `[1,2,3]`,
+		into:    new([2]int),
+		wantErr: `json: cannot unmarshal JSON number into Go [2]int within "/2": too many array elements`,
+		offset:  4,
+		pointer: "/2",
+	}, {
+		name:    "Array/Underflow",
+		in:      `{"A":[1]}`,
+		into:    new(struct{ A [2]int }),
+		wantErr: `json: cannot unmarshal JSON array into Go [2]int within "/A": too few array elements`,
+		offset:  7,
+		pointer: "/A",
+	}, {
+		name:    "Interface/NonEmpty",
+		in:      `{"E":"error"}`,
+		into:    new(struct{ E error }),
+		wantErr: `json: cannot unmarshal JSON string into Go error within "/E": cannot derive concrete type for nil interface with finite type set`,
+		wantIs:  errNonConcreteValue,
+		offset:  4,
+		pointer: "/E",
+	}, {
+		name:    "Map/KeySyntax",
+		in:      `{"x":1}`,
+		into:    new(map[int]int),
+		wantErr: `json: cannot unmarshal JSON string "x" into Go int within "/x": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  1,
+		pointer: "/x",
+	}, {
+		name:    "Struct/Required",
+		opts:    []Options{jsonflags.RejectMissingRequiredFields | 1},
+		in:      `{"A":1,"B":2}`,
+		into:    new(structRequired),
+		wantErr: `json: cannot unmarshal into Go json.structRequired within "/C": missing required field`,
+		wantIs:  internal.ErrMissingField,
+		offset:  12,
+		pointer: "/C",
+	}, {
+		name:    "Struct/InlinedNilUnexported",
+		in:      `{"A":1}`,
+		into:    new(struct{ *structEmbeddedUnexported }),
+		wantErr: `json: cannot unmarshal JSON number into Go struct { *json.structEmbeddedUnexported } within "/A": cannot set embedded pointer to unexported struct type`,
+		offset:  4,
+		pointer: "/A",
+	}, {
+		name: "Struct/InvalidTag",
+		in:   `[{}]`,
+		into: new([]struct {
+			A int `json:",case:upper"`
+		}),
+		wantErr: "json: cannot unmarshal JSON object into Go struct { A int \"json:\\\",case:upper\\\"\" } within \"/0\": Go struct field A has unknown `case:upper` tag value",
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Syntax/Truncated",
+		in:      `{"Slice":["a",`,
+		into:    new(structAll),
+		wantErr: `jsontext: unexpected EOF within "/Slice/1" after offset 14`,
+		wantIs:  io.ErrUnexpectedEOF,
+		offset:  14,
+		pointer: "/Slice/1",
+	}, {
+		name:    "Syntax/TrailingData",
+		in:      `{} {}`,
+		into:    new(structAll),
+		wantErr: `jsontext: invalid character '{' after top-level value after offset 3`,
+		offset:  3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.in), tt.into, tt.opts...)
+			checkErrorPosition(t, "Unmarshal", err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
@@ -0,0 +1,931 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"encoding/json/jsontext"
+)
+
+type (
+	funcPoint        struct{ X, Y int }
+	funcNamedPointer *funcPoint
+	funcStringer     int
+	funcTextPointer  struct{ s string }
+	funcJSONMethod   struct{}
+)
+
+func (n funcStringer) String() string { return "#" + strconv.Itoa(int(n)) }
+
+func (p *funcTextPointer) UnmarshalText(b []byte) error {
+	p.s = string(b)
+	return nil
+}
+
+func (funcJSONMethod) MarshalJSON() ([]byte, error) { return []byte(`"method"`), nil }
+
+var errFunc = errors.New("function error")
+
+// marshalPoint marshals a funcPoint as a JSON string of the form "X,Y".
+func marshalPoint(p funcPoint) ([]byte, error) {
+	return []byte(strconv.Quote(strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y))), nil
+}
+
+// unmarshalPoint unmarshals a funcPoint from a JSON string of the form "X,Y".
+func unmarshalPoint(b []byte, p *funcPoint) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	x, y, ok := strings.Cut(s, ",")
+	if !ok {
+		return errFunc
+	}
+	p.X, _ = strconv.Atoi(x)
+	p.Y, _ = strconv.Atoi(y)
+	return nil
+}
+
+func TestMarshalFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   any
+		want string
+	}{{
+		name: "MarshalFunc/Value",
+		opts: []Options{WithMarshalers(MarshalFunc(marshalPoint))},
+		in:   []funcPoint{{1, 2}, {3, 4}},
+		want: `["1,2","3,4"]`,
+	}, {
+		name: "MarshalFunc/TopLevel",
+		opts: []Options{WithMarshalers(MarshalFunc(marshalPoint))},
+		in:   funcPoint{5, 6},
+		want: `"5,6"`,
+	}, {
+		name: "MarshalFunc/PointerToValue",
+		opts: []Options{WithMarshalers(MarshalFunc(marshalPoint))},
+		in:   []*funcPoint{{1, 2}, nil},
+		want: `["1,2",null]`,
+	}, {
+		name: "MarshalFunc/Pointer",
+		opts: []Options{WithMarshalers(MarshalFunc(func(p *funcPoint) ([]byte, error) {
+			return []byte(strconv.Itoa(p.X + p.Y)), nil
+		}))},
+		in:   struct{ V, P, N *funcPoint }{V: &funcPoint{1, 2}, P: &funcPoint{3, 4}},
+		want: `{"V":3,"P":7,"N":null}`,
+	}, {
+		name: "MarshalFunc/PointerAddressable",
+		opts: []Options{WithMarshalers(MarshalFunc(func(p *funcPoint) ([]byte, error) {
+			return []byte(strconv.Itoa(p.X + p.Y)), nil
+		}))},
+		in:   map[string]funcPoint{"k": {1, 2}},
+		want: `{"k":3}`,
+	}, {
+		name: "MarshalFunc/Interface",
+		opts: []Options{WithMarshalers(MarshalFunc(func(s fmt.Stringer) ([]byte, error) {
+			return []byte(strconv.Quote(s.String())), nil
+		}))},
+		in:   []any{funcStringer(1), addr(funcStringer(2)), 3, time.Duration(0)},
+		want: `["#1","#2",3,"0s"]`,
+	}, {
+		name: "MarshalFunc/EmptyInterface",
+		opts: []Options{WithMarshalers(MarshalFunc(func(v any) ([]byte, error) {
+			return []byte(strconv.Quote(fmt.Sprintf("%T", v))), nil
+		}))},
+		in:   map[string]any{"k": []int{1}},
+		want: `"map[string]interface {}"`,
+	}, {
+		name: "MarshalFunc/MapKeys",
+		opts: []Options{WithMarshalers(MarshalFunc(func(n int) ([]byte, error) {
+			return []byte(`"` + strconv.Itoa(2*n) + `"`), nil
+		})), Deterministic(true)},
+		in:   map[int]int{1: 10, 2: 20},
+		want: `{"2":"20","4":"40"}`,
+	}, {
+		name: "MarshalFunc/OverridesMethod",
+		opts: []Options{WithMarshalers(MarshalFunc(func(funcJSONMethod) ([]byte, error) {
+			return []byte(`"function"`), nil
+		}))},
+		in:   []funcJSONMethod{{}},
+		want: `["function"]`,
+	}, {
+		name: "MarshalFunc/OverridesTime",
+		opts: []Options{WithMarshalers(MarshalFunc(func(d time.Duration) ([]byte, error) {
+			return []byte(strconv.FormatInt(int64(d), 10)), nil
+		}))},
+		in:   structDuration{D: 1, Sec: 2, Nano: 3},
+		want: `{"D":1,"Sec":2,"Nano":3}`,
+	}, {
+		name: "MarshalFunc/Reformatted",
+		opts: []Options{WithMarshalers(MarshalFunc(func(funcPoint) ([]byte, error) {
+			return []byte(" { \"a\" : [ 1 , 2 ] } "), nil
+		}))},
+		in:   []funcPoint{{}},
+		want: `[{"a":[1,2]}]`,
+	}, {
+		name: "MarshalFunc/Indented",
+		opts: []Options{WithMarshalers(MarshalFunc(func(funcPoint) ([]byte, error) {
+			return []byte(`{"a":[1,2]}`), nil
+		})), jsontext.WithIndent("\t")},
+		in:   []funcPoint{{}},
+		want: "[\n\t{\n\t\t\"a\": [\n\t\t\t1,\n\t\t\t2\n\t\t]\n\t}\n]",
+	}, {
+		name: "MarshalFunc/NotMatchingOtherTypes",
+		opts: []Options{WithMarshalers(MarshalFunc(marshalPoint))},
+		in:   []any{struct{ X, Y int }{1, 2}},
+		want: `[{"X":1,"Y":2}]`,
+	}, {
+		name: "MarshalToFunc",
+		opts: []Options{WithMarshalers(MarshalToFunc(func(enc *jsontext.Encoder, p funcPoint) error {
+			if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+				return err
+			}
+			if err := enc.WriteToken(jsontext.Int(int64(p.X))); err != nil {
+				return err
+			}
+			if err := enc.WriteToken(jsontext.Int(int64(p.Y))); err != nil {
+				return err
+			}
+			return enc.WriteToken(jsontext.EndArray)
+		}))},
+		in:   map[string]funcPoint{"p": {1, 2}},
+		want: `{"p":[1,2]}`,
+	}, {
+		name: "MarshalToFunc/Skip",
+		opts: []Options{WithMarshalers(MarshalToFunc(func(enc *jsontext.Encoder, n int) error {
+			if n%2 == 0 {
+				return SkipFunc
+			}
+			return enc.WriteToken(jsontext.String("odd"))
+		}))},
+		in:   []int{1, 2, 3, 4},
+		want: `["odd",2,"odd",4]`,
+	}, {
+		name: "MarshalToFunc/Recursive",
+		opts: []Options{WithMarshalers(MarshalToFunc(func(enc *jsontext.Encoder, m map[string]any) error {
+			return MarshalEncode(enc, struct {
+				Len int
+				Map map[string]any `json:",unknown"`
+			}{len(m), m}, Deterministic(true))
+		}))},
+		in:   map[string]any{"b": map[string]any{}, "a": 1},
+		want: `{"Len":2,"a":1,"b":{"Len":0}}`,
+	}, {
+		name: "MarshalToFunc/StackPointer",
+		opts: []Options{WithMarshalers(MarshalToFunc(func(enc *jsontext.Encoder, p funcPoint) error {
+			return enc.WriteToken(jsontext.String(string(enc.StackPointer())))
+		}))},
+		in:   map[string][]funcPoint{"a~b/c": {{}, {}}},
+		want: `{"a~b/c":["/a~0b~1c","/a~0b~1c/0"]}`, // pointer to the previously written value
+	}, {
+		name: "JoinMarshalers/Precedence",
+		opts: []Options{WithMarshalers(JoinMarshalers(
+			MarshalFunc(func(int) ([]byte, error) { return []byte(`"first"`), nil }),
+			MarshalFunc(func(int) ([]byte, error) { return []byte(`"second"`), nil }),
+		))},
+		in:   []int{1},
+		want: `["first"]`,
+	}, {
+		name: "JoinMarshalers/SkipToNext",
+		opts: []Options{WithMarshalers(JoinMarshalers(
+			MarshalToFunc(func(enc *jsontext.Encoder, n int) error {
+				if n < 0 {
+					return enc.WriteToken(jsontext.String("negative"))
+				}
+				return SkipFunc
+			}),
+			MarshalToFunc(func(enc *jsontext.Encoder, n int) error {
+				if n == 0 {
+					return enc.WriteToken(jsontext.String("zero"))
+				}
+				return SkipFunc
+			}),
+			MarshalFunc(func(n int) ([]byte, error) {
+				if n > 100 {
+					return []byte(`"large"`), nil
+				}
+				return []byte(strconv.Itoa(n)), nil
+			}),
+			MarshalFunc(func(int) ([]byte, error) { return []byte(`"unreachable"`), nil }),
+		))},
+		in:   []int{-1, 0, 1, 101},
+		want: `["negative","zero",1,"large"]`,
+	}, {
+		name: "JoinMarshalers/SkipToDefault",
+		opts: []Options{WithMarshalers(JoinMarshalers(
+			MarshalToFunc(func(*jsontext.Encoder, int) error { return SkipFunc }),
+			MarshalToFunc(func(*jsontext.Encoder, *int) error { return SkipFunc }),
+		))},
+		in:   []int{1},
+		want: `[1]`,
+	}, {
+		name: "JoinMarshalers/InterfaceBeforeConcrete",
+		opts: []Options{WithMarshalers(JoinMarshalers(
+			MarshalFunc(func(fmt.Stringer) ([]byte, error) { return []byte(`"stringer"`), nil }),
+			MarshalFunc(func(funcStringer) ([]byte, error) { return []byte(`"concrete"`), nil }),
+		))},
+		in:   funcStringer(0),
+		want: `"stringer"`,
+	}, {
+		name: "JoinMarshalers/Nested",
+		opts: []Options{WithMarshalers(JoinMarshalers(
+			nil,
+			JoinMarshalers(MarshalToFunc(func(*jsontext.Encoder, string) error { return SkipFunc })),
+			JoinMarshalers(nil, MarshalFunc(func(s string) ([]byte, error) {
+				return []byte(strconv.Quote(strings.ToUpper(s))), nil
+			})),
+		))},
+		in:   map[string]string{"k": "v"},
+		want: `{"K":"V"}`,
+	}, {
+		name: "WithMarshalers/Nil",
+		opts: []Options{WithMarshalers(nil)},
+		in:   funcPoint{1, 2},
+		want: `{"X":1,"Y":2}`,
+	}, {
+		name: "WithMarshalers/Last",
+		opts: []Options{
+			WithMarshalers(MarshalFunc(func(bool) ([]byte, error) { return []byte(`1`), nil })),
+			WithMarshalers(MarshalFunc(func(bool) ([]byte, error) { return []byte(`2`), nil })),
+		},
+		in:   true,
+		want: `2`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		into any
+		want any
+	}{{
+		name: "UnmarshalFunc",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(unmarshalPoint))},
+		in:   `["1,2","3,4"]`,
+		into: new([]funcPoint),
+		want: addr([]funcPoint{{1, 2}, {3, 4}}),
+	}, {
+		name: "UnmarshalFunc/TopLevel",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(unmarshalPoint))},
+		in:   ` "5,6" `,
+		into: new(funcPoint),
+		want: &funcPoint{5, 6},
+	}, {
+		name: "UnmarshalFunc/Pointers",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(unmarshalPoint))},
+		in:   `{"A":"1,2","B":null,"C":"3,4"}`,
+		into: &map[string]*funcPoint{"C": {9, 9}},
+		want: &map[string]*funcPoint{"A": {1, 2}, "B": nil, "C": {3, 4}},
+	}, {
+		name: "UnmarshalFunc/RawValue",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(func(b []byte, s *string) error {
+			*s = string(b)
+			return nil
+		}))},
+		in:   `[ {"a" : 1} , "A" , null ]`,
+		into: new([]string),
+		want: addr([]string{`{"a" : 1}`, `"A"`, `null`}),
+	}, {
+		name: "UnmarshalFunc/MapKeys",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(func(b []byte, s *string) error {
+			*s = strings.ToUpper(string(b))
+			return nil
+		}))},
+		in:   `{"k":"v"}`,
+		into: new(map[string]string),
+		want: addr(map[string]string{`"K"`: `"V"`}),
+	}, {
+		name: "UnmarshalFunc/Interface",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(func(b []byte, u encoding.TextUnmarshaler) error {
+			return u.UnmarshalText(append([]byte("func:"), b...))
+		}))},
+		in:   `[1,"2"]`,
+		into: new([]funcTextPointer),
+		want: addr([]funcTextPointer{{"func:1"}, {`func:"2"`}}),
+	}, {
+		name: "UnmarshalFunc/AnyElements",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(func(b []byte, f *float64) error {
+			*f = float64(len(b))
+			return nil
+		}))},
+		in:   `[1000,"x",{"k":-1}]`,
+		into: new(any),
+		want: addr(any([]any{4.0, "x", map[string]any{"k": 2.0}})),
+	}, {
+		name: "UnmarshalFunc/OverridesTime",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(func(b []byte, d *time.Duration) error {
+			n, err := strconv.ParseInt(string(b), 10, 64)
+			*d = time.Duration(n)
+			return err
+		}))},
+		in:   `{"D":1,"Sec":2,"Nano":3}`,
+		into: new(structDuration),
+		want: &structDuration{D: 1, Sec: 2, Nano: 3},
+	}, {
+		name: "UnmarshalFromFunc",
+		opts: []Options{WithUnmarshalers(UnmarshalFromFunc(func(dec *jsontext.Decoder, p *funcPoint) error {
+			var xy []int
+			if err := UnmarshalDecode(dec, &xy); err != nil {
+				return err
+			}
+			p.X, p.Y = xy[0], xy[1]
+			return nil
+		}))},
+		in:   `{"p":[1,2]}`,
+		into: new(map[string]funcPoint),
+		want: addr(map[string]funcPoint{"p": {1, 2}}),
+	}, {
+		name: "UnmarshalFromFunc/Skip",
+		opts: []Options{WithUnmarshalers(UnmarshalFromFunc(func(dec *jsontext.Decoder, n *int) error {
+			if dec.PeekKind() != '"' {
+				return SkipFunc
+			}
+			tok, err := dec.ReadToken()
+			if err != nil {
+				return err
+			}
+			*n = len(tok.String())
+			return nil
+		}))},
+		in:   `[1,"four",3]`,
+		into: new([]int),
+		want: addr([]int{1, 4, 3}),
+	}, {
+		name: "UnmarshalFromFunc/StackPointer",
+		opts: []Options{WithUnmarshalers(UnmarshalFromFunc(func(dec *jsontext.Decoder, s *string) error {
+			if err := dec.SkipValue(); err != nil {
+				return err
+			}
+			*s = string(dec.StackPointer())
+			return nil
+		}))},
+		in:   `{"a~b/c":[null,null]}`,
+		into: new(map[string][]string),
+		want: addr(map[string][]string{"/a~0b~1c": {"/a~0b~1c/0", "/a~0b~1c/1"}}),
+	}, {
+		name: "JoinUnmarshalers/Precedence",
+		opts: []Options{WithUnmarshalers(JoinUnmarshalers(
+			UnmarshalFunc(func(_ []byte, s *string) error { *s = "first"; return nil }),
+			UnmarshalFunc(func(_ []byte, s *string) error { *s = "second"; return nil }),
+		))},
+		in:   `"x"`,
+		into: new(string),
+		want: addr("first"),
+	}, {
+		name: "JoinUnmarshalers/SkipToNext",
+		opts: []Options{WithUnmarshalers(JoinUnmarshalers(
+			UnmarshalFromFunc(func(dec *jsontext.Decoder, n *int) error {
+				if dec.PeekKind() != 'f' && dec.PeekKind() != 't' {
+					return SkipFunc
+				}
+				var b bool
+				if err := UnmarshalDecode(dec, &b); err != nil {
+					return err
+				}
+				if b {
+					*n = 1
+				}
+				return nil
+			}),
+			UnmarshalFromFunc(func(dec *jsontext.Decoder, n *int) error {
+				if dec.PeekKind() != '"' {
+					return SkipFunc
+				}
+				var s string
+				if err := UnmarshalDecode(dec, &s); err != nil {
+					return err
+				}
+				var err error
+				*n, err = strconv.Atoi(s)
+				return err
+			}),
+		))},
+		in:   `[true,"2",3,false]`,
+		into: new([]int),
+		want: addr([]int{1, 2, 3, 0}),
+	}, {
+		name: "JoinUnmarshalers/Nested",
+		opts: []Options{WithUnmarshalers(JoinUnmarshalers(
+			nil,
+			JoinUnmarshalers(UnmarshalFromFunc(func(*jsontext.Decoder, *string) error { return SkipFunc })),
+			JoinUnmarshalers(nil, UnmarshalFunc(func(b []byte, s *string) error {
+				*s = strings.ToUpper(string(b))
+				return nil
+			})),
+		))},
+		in:   `"v"`,
+		into: new(string),
+		want: addr(`"V"`),
+	}, {
+		name: "WithUnmarshalers/Nil",
+		opts: []Options{WithUnmarshalers(nil)},
+		in:   `{"X":1,"Y":2}`,
+		into: new(funcPoint),
+		want: &funcPoint{1, 2},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.in), tt.into, tt.opts...); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalFuncErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		marshalers *Marshalers
+		in         any
+		wantErr    string
+		wantIs     error
+		offset     int64
+		pointer    jsontext.Pointer
+	}{{
+		name: "MarshalFunc/Error",
+		marshalers: MarshalFunc(func(funcPoint) ([]byte, error) {
+			return nil, errFunc
+		}),
+		in:      map[string]funcPoint{"p": {}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/p": function error`,
+		wantIs:  errFunc,
+		offset:  4,
+		pointer: "/p",
+	}, {
+		name: "MarshalFunc/ErrorInArray",
+		marshalers: MarshalFunc(func(n int) ([]byte, error) {
+			if n == 2 {
+				return nil, errFunc
+			}
+			return []byte(strconv.Itoa(n)), nil
+		}),
+		in:      [][]int{{1}, {1, 2}},
+		wantErr: `json: cannot marshal Go int within "/1/1": function error`,
+		wantIs:  errFunc,
+		offset:  7,
+		pointer: "/1/1",
+	}, {
+		name: "MarshalFunc/Skip",
+		marshalers: MarshalFunc(func(funcPoint) ([]byte, error) {
+			return nil, SkipFunc
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/0": marshal function of type func(T) ([]byte, error) cannot be skipped`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalFunc/InvalidOutput",
+		marshalers: MarshalFunc(func(funcPoint) ([]byte, error) {
+			return []byte(`{"a":}`), nil
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/0": jsontext: missing value after object name within "/0/a" after offset 6`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalFunc/TrailingOutput",
+		marshalers: MarshalFunc(func(funcPoint) ([]byte, error) {
+			return []byte(`1 2`), nil
+		}),
+		in:      funcPoint{},
+		wantErr: `json: cannot marshal Go json.funcPoint: jsontext: invalid character '2' after top-level value after offset 2`,
+		offset:  0,
+	}, {
+		name: "MarshalFunc/EmptyOutput",
+		marshalers: MarshalFunc(func(funcPoint) ([]byte, error) {
+			return nil, nil
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/0": jsontext: unexpected EOF within "/0" after offset 1`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalFunc/DuplicateName",
+		marshalers: MarshalFunc(func(funcPoint) ([]byte, error) {
+			return []byte(`{"a":1,"a":2}`), nil
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/0": jsontext: duplicate object member name "a" within "/0" after offset 8`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalFunc/NameNotString",
+		marshalers: MarshalFunc(func(int) ([]byte, error) {
+			return []byte(`1`), nil
+		}),
+		in:      map[int]int{1: 1},
+		wantErr: `json: cannot marshal Go int: jsontext: object member name must be a string after offset 1`,
+		offset:  1,
+	}, {
+		name: "MarshalToFunc/Error",
+		marshalers: MarshalToFunc(func(*jsontext.Encoder, funcPoint) error {
+			return errFunc
+		}),
+		in:      []funcPoint{{}, {}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/0": function error`,
+		wantIs:  errFunc,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalToFunc/ErrorAfterWrite",
+		marshalers: MarshalToFunc(func(enc *jsontext.Encoder, p funcPoint) error {
+			if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+				return err
+			}
+			if err := enc.WriteToken(jsontext.String("x")); err != nil {
+				return err
+			}
+			return errFunc
+		}),
+		in:      map[string]funcPoint{"p": {}},
+		wantErr: `json: cannot marshal within "/p/x": function error`,
+		wantIs:  errFunc,
+		offset:  9,
+		pointer: "/p/x",
+	}, {
+		name: "MarshalToFunc/SemanticError",
+		marshalers: MarshalToFunc(func(enc *jsontext.Encoder, p funcPoint) error {
+			return MarshalEncode(enc, map[string]any{"c": make(chan int)})
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal Go chan int within "/0/c": unsupported type`,
+		wantIs:  errUnsupportedType,
+		offset:  5,
+		pointer: "/0/c",
+	}, {
+		name: "MarshalToFunc/SyntacticError",
+		marshalers: MarshalToFunc(func(enc *jsontext.Encoder, p funcPoint) error {
+			return enc.WriteToken(jsontext.EndObject)
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `jsontext: mismatching structural token for object or array within "/0" after offset 1`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalToFunc/NothingWritten",
+		marshalers: MarshalToFunc(func(*jsontext.Encoder, funcPoint) error {
+			return nil
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal Go json.funcPoint within "/0": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "MarshalToFunc/TooManyWritten",
+		marshalers: MarshalToFunc(func(enc *jsontext.Encoder, _ funcPoint) error {
+			enc.WriteToken(jsontext.Null)
+			return enc.WriteToken(jsontext.Null)
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal within "/1": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  10,
+		pointer: "/1",
+	}, {
+		name: "MarshalToFunc/Incomplete",
+		marshalers: MarshalToFunc(func(enc *jsontext.Encoder, _ funcPoint) error {
+			return enc.WriteToken(jsontext.BeginArray)
+		}),
+		in:      struct{ P funcPoint }{},
+		wantErr: `json: cannot marshal within "/P": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  6,
+		pointer: "/P",
+	}, {
+		name: "MarshalToFunc/SkipAfterWrite",
+		marshalers: MarshalToFunc(func(enc *jsontext.Encoder, _ funcPoint) error {
+			enc.WriteToken(jsontext.Null)
+			return SkipFunc
+		}),
+		in:      []funcPoint{{}},
+		wantErr: `json: cannot marshal within "/0": must not read or write any tokens when skipping`,
+		wantIs:  errSkipMutation,
+		offset:  5,
+		pointer: "/0",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in, WithMarshalers(tt.marshalers))
+			checkErrorPosition(t, "Marshal", err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
+
+func TestUnmarshalFuncErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		unmarshalers *Unmarshalers
+		in           string
+		into         any
+		wantErr      string
+		wantIs       error
+		offset       int64
+		pointer      jsontext.Pointer
+	}{{
+		name:         "UnmarshalFunc/Error",
+		unmarshalers: UnmarshalFunc(unmarshalPoint),
+		in:           `{"a":"1,2","b":"12"}`,
+		into:         new(map[string]funcPoint),
+		wantErr:      `json: cannot unmarshal JSON string into Go *json.funcPoint within "/b": function error`,
+		wantIs:       errFunc,
+		offset:       15,
+		pointer:      "/b",
+	}, {
+		name:         "UnmarshalFunc/ErrorInArray",
+		unmarshalers: UnmarshalFunc(unmarshalPoint),
+		in:           `[["1,2"],["3,4",5]]`,
+		into:         new([][]funcPoint),
+		wantErr:      `json: cannot unmarshal JSON number into Go *json.funcPoint within "/1/1": invalid syntax`,
+		wantIs:       strconv.ErrSyntax,
+		offset:       16,
+		pointer:      "/1/1",
+	}, {
+		name: "UnmarshalFunc/Skip",
+		unmarshalers: UnmarshalFunc(func([]byte, *funcPoint) error {
+			return SkipFunc
+		}),
+		in:      `[{}]`,
+		into:    new([]funcPoint),
+		wantErr: `json: cannot unmarshal JSON object into Go *json.funcPoint within "/0": unmarshal function of type func([]byte, T) error cannot be skipped`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:         "UnmarshalFunc/SyntacticError",
+		unmarshalers: UnmarshalFunc(unmarshalPoint),
+		in:           `["1,2" "3,4"]`,
+		into:         new([]funcPoint),
+		wantErr:      `jsontext: invalid character '"' after array element (expecting ',' or ']') within "/1" after offset 7`,
+		offset:       7,
+		pointer:      "/1",
+	}, {
+		name: "UnmarshalFromFunc/Error",
+		unmarshalers: UnmarshalFromFunc(func(*jsontext.Decoder, *funcPoint) error {
+			return errFunc
+		}),
+		in:      `{"a":{}}`,
+		into:    new(map[string]funcPoint),
+		wantErr: `json: cannot unmarshal into Go *json.funcPoint within "/a": function error`,
+		wantIs:  errFunc,
+		offset:  4,
+		pointer: "/a",
+	}, {
+		name: "UnmarshalFromFunc/ErrorAfterRead",
+		unmarshalers: UnmarshalFromFunc(func(dec *jsontext.Decoder, _ *funcPoint) error {
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			if _, err := dec.ReadToken(); err != nil {
+				return err
+			}
+			return errFunc
+		}),
+		in:      `{"a":{"x":1}}`,
+		into:    new(map[string]funcPoint),
+		wantErr: `json: cannot unmarshal within "/a/x": function error`,
+		wantIs:  errFunc,
+		offset:  9,
+		pointer: "/a/x",
+	}, {
+		name: "UnmarshalFromFunc/SemanticError",
+		unmarshalers: UnmarshalFromFunc(func(dec *jsontext.Decoder, _ *funcPoint) error {
+			var v struct{ N uint8 }
+			return UnmarshalDecode(dec, &v)
+		}),
+		in:      `[{"N":256}]`,
+		into:    new([]funcPoint),
+		wantErr: `json: cannot unmarshal JSON number 256 into Go uint8 within "/0/N": value out of range`,
+		wantIs:  strconv.ErrRange,
+		offset:  6,
+		pointer: "/0/N",
+	}, {
+		name: "UnmarshalFromFunc/SyntacticError",
+		unmarshalers: UnmarshalFromFunc(func(dec *jsontext.Decoder, _ *funcPoint) error {
+			return dec.SkipValue()
+		}),
+		in:      `[{"a" 1}]`,
+		into:    new([]funcPoint),
+		wantErr: `jsontext: invalid character '1' after object name (expecting ':') within "/0/a" after offset 6`,
+		offset:  6,
+		pointer: "/0/a",
+	}, {
+		name: "UnmarshalFromFunc/NothingRead",
+		unmarshalers: UnmarshalFromFunc(func(*jsontext.Decoder, *funcPoint) error {
+			return nil
+		}),
+		in:      `[{}]`,
+		into:    new([]funcPoint),
+		wantErr: `json: cannot unmarshal into Go *json.funcPoint within "/0": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "UnmarshalFromFunc/TooManyRead",
+		unmarshalers: UnmarshalFromFunc(func(dec *jsontext.Decoder, _ *funcPoint) error {
+			dec.SkipValue()
+			return dec.SkipValue()
+		}),
+		in:      `[1,2,3]`,
+		into:    new([]funcPoint),
+		wantErr: `json: cannot unmarshal within "/1": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  4,
+		pointer: "/1",
+	}, {
+		name: "UnmarshalFromFunc/Incomplete",
+		unmarshalers: UnmarshalFromFunc(func(dec *jsontext.Decoder, _ *funcPoint) error {
+			_, err := dec.ReadToken()
+			return err
+		}),
+		in:      `{"P":[1]}`,
+		into:    new(struct{ P funcPoint }),
+		wantErr: `json: cannot unmarshal within "/P": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  6,
+		pointer: "/P",
+	}, {
+		name: "UnmarshalFromFunc/SkipAfterRead",
+		unmarshalers: UnmarshalFromFunc(func(dec *jsontext.Decoder, _ *funcPoint) error {
+			dec.SkipValue()
+			return SkipFunc
+		}),
+		in:      `[{}]`,
+		into:    new([]funcPoint),
+		wantErr: `json: cannot unmarshal within "/0": must not read or write any tokens when skipping`,
+		wantIs:  errSkipMutation,
+		offset:  3,
+		pointer: "/0",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.in), tt.into, WithUnmarshalers(tt.unmarshalers))
+			checkErrorPosition(t, "Unmarshal", err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
+
+// checkErrorPosition checks that err has the wanted message, underlying error,
+// byte offset, and JSON pointer.
+func checkErrorPosition(t *testing.T, call string, err error, wantErr string, wantIs error, offset int64, pointer jsontext.Pointer) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("%s error = nil, want non-nil", call)
+	}
+	if err.Error() != wantErr {
+		t.Errorf("%s error:\n\tgot:  %v\n\twant: %v", call, err, wantErr)
+	}
+	if wantIs != nil && !errors.Is(err, wantIs) {
+		t.Errorf("errors.Is(%v, %v) = false, want true", err, wantIs)
+	}
+	var gotOffset int64
+	var gotPointer jsontext.Pointer
+	var serr *SemanticError
+	var xerr *jsontext.SyntacticError
+	switch {
+	case errors.As(err, &serr):
+		gotOffset, gotPointer = serr.ByteOffset, serr.JSONPointer
+	case errors.As(err, &xerr):
+		gotOffset, gotPointer = xerr.ByteOffset, xerr.JSONPointer
+	default:
+		t.Fatalf("%s error type = %T, want *SemanticError or *jsontext.SyntacticError", call, err)
+	}
+	if gotOffset != offset {
+		t.Errorf("%s error offset = %d, want %d", call, gotOffset, offset)
+	}
+	if gotPointer != pointer {
+		t.Errorf("%s error pointer = %q, want %q", call, gotPointer, pointer)
+	}
+}
+
+func TestFuncInvalidTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		construct func()
+		wantPanic string
+	}{{
+		name:      "MarshalFunc/NamedPointer",
+		construct: func() { MarshalFunc(func(funcNamedPointer) ([]byte, error) { return nil, nil }) },
+		wantPanic: "input type json.funcNamedPointer must be an interface type, an unnamed pointer type, or a non-pointer type",
+	}, {
+		name:      "MarshalToFunc/NamedPointer",
+		construct: func() { MarshalToFunc(func(*jsontext.Encoder, funcNamedPointer) error { return nil }) },
+		wantPanic: "input type json.funcNamedPointer must be an interface type, an unnamed pointer type, or a non-pointer type",
+	}, {
+		name:      "UnmarshalFunc/NonPointer",
+		construct: func() { UnmarshalFunc(func([]byte, funcPoint) error { return nil }) },
+		wantPanic: "input type json.funcPoint must be an interface type or an unnamed pointer type",
+	}, {
+		name:      "UnmarshalFunc/NamedPointer",
+		construct: func() { UnmarshalFunc(func([]byte, funcNamedPointer) error { return nil }) },
+		wantPanic: "input type json.funcNamedPointer must be an interface type or an unnamed pointer type",
+	}, {
+		name:      "UnmarshalFromFunc/Map",
+		construct: func() { UnmarshalFromFunc(func(*jsontext.Decoder, map[string]int) error { return nil }) },
+		wantPanic: "input type map[string]int must be an interface type or an unnamed pointer type",
+	}, {
+		name:      "MarshalFunc/Map",
+		construct: func() { MarshalFunc(func(map[string]int) ([]byte, error) { return nil, nil }) },
+	}, {
+		name:      "MarshalFunc/PointerToPointer",
+		construct: func() { MarshalFunc(func(**int) ([]byte, error) { return nil, nil }) },
+	}, {
+		name:      "UnmarshalFunc/Interface",
+		construct: func() { UnmarshalFunc(func([]byte, any) error { return nil }) },
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						got = fmt.Sprint(r)
+					}
+				}()
+				tt.construct()
+			}()
+			if got != tt.wantPanic {
+				t.Errorf("panic = %q, want %q", got, tt.wantPanic)
+			}
+		})
+	}
+}
+
+func TestJoinArshalers(t *testing.T) {
+	if got := JoinMarshalers(); got != nil {
+		t.Errorf("JoinMarshalers() = %p, want nil", got)
+	}
+	if got := JoinMarshalers(nil, JoinMarshalers()); got != nil {
+		t.Errorf("JoinMarshalers(nil, nil) = %p, want nil", got)
+	}
+	if got := JoinUnmarshalers(); got != nil {
+		t.Errorf("JoinUnmarshalers() = %p, want nil", got)
+	}
+	if got := JoinUnmarshalers(nil, JoinUnmarshalers()); got != nil {
+		t.Errorf("JoinUnmarshalers(nil, nil) = %p, want nil", got)
+	}
+
+	// Joining must not alter the lists being joined,
+	// and the same list may be used concurrently with different types.
+	var calls []string
+	m1 := MarshalToFunc(func(*jsontext.Encoder, int) error {
+		calls = append(calls, "m1")
+		return SkipFunc
+	})
+	m2 := MarshalToFunc(func(*jsontext.Encoder, int) error {
+		calls = append(calls, "m2")
+		return SkipFunc
+	})
+	m12 := JoinMarshalers(m1, m2)
+	m21 := JoinMarshalers(m2, m1)
+	for _, tt := range []struct {
+		m    *Marshalers
+		want []string
+	}{
+		{m1, []string{"m1"}},
+		{m12, []string{"m1", "m2"}},
+		{m21, []string{"m2", "m1"}},
+		{JoinMarshalers(m12, m21), []string{"m1", "m2", "m2", "m1"}},
+	} {
+		calls = nil
+		for range 2 { // second call uses the cached lookup
+			calls = calls[:0]
+			if b, err := Marshal(1, WithMarshalers(tt.m)); err != nil || string(b) != "1" {
+				t.Fatalf("Marshal = (%s, %v), want (1, nil)", b, err)
+			}
+			if !reflect.DeepEqual(calls, tt.want) {
+				t.Errorf("calls = %v, want %v", calls, tt.want)
+			}
+		}
+		calls = nil
+		if b, err := Marshal("s", WithMarshalers(tt.m)); err != nil || string(b) != `"s"` || len(calls) > 0 {
+			t.Errorf("Marshal = (%s, %v) with calls %v, want (\"s\", nil) with no calls", b, err, calls)
+		}
+	}
+}
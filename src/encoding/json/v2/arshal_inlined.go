@@ -0,0 +1,198 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"io"
+	"reflect"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+// This package supports "inlining" a Go struct field, where the contents
+// of the serialized field (which must be a JSON object) are treated as if
+// they are part of the parent Go struct (which represents a JSON object).
+//
+// Generally, inlined fields are of a Go struct type, where the fields of the
+// nested struct are virtually hoisted up to the parent struct using rules
+// similar to how Go embedding works (but operating within the JSON namespace).
+//
+// However, inlined fields may also be of a Go map type with a string key or
+// a jsontext.Value. Such inlined fields are called "fallback" fields since they
+// represent any arbitrary JSON object member. Explicitly named fields take
+// precedence over the inlined fallback. Only one inlined fallback is allowed.
+
+var errRawInlinedNotObject = errors.New("inlined raw value must be a JSON object")
+
+// marshalInlinedFallbackAll marshals all the members in an inlined fallback.
+func marshalInlinedFallbackAll(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct, f *structField) error {
+	v := addressableValue{va.Field(f.index[0]), va.forcedAddr} // addressable if struct value is addressable
+	if len(f.index) > 1 {
+		v = v.fieldByIndex(f.index[1:], false)
+		if !v.IsValid() {
+			return nil // implicit nil pointer, so nothing to marshal
+		}
+	}
+	v = v.indirect(false)
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type() == jsontextValueType {
+		b := v.Bytes()
+		if len(b) == 0 { // an empty value is treated as an empty JSON object
+			return nil
+		}
+		dec := export.GetBufferedDecoder(b)
+		defer export.PutBufferedDecoder(dec)
+		xd := export.Decoder(dec)
+		xd.Flags.Set(jsonflags.AllowDuplicateNames | jsonflags.AllowInvalidUTF8 | 1)
+
+		tok, err := dec.ReadToken()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return newMarshalErrorBefore(enc, v.Type(), err)
+		}
+		if tok.Kind() != '{' {
+			return newMarshalErrorBefore(enc, v.Type(), errRawInlinedNotObject)
+		}
+		for dec.PeekKind() != '}' {
+			// Parse the JSON object name.
+			var flags jsonwire.ValueFlags
+			val, err := xd.ReadValue(&flags)
+			if err != nil {
+				return newMarshalErrorBefore(enc, v.Type(), err)
+			}
+			if err := enc.WriteValue(val); err != nil {
+				return err
+			}
+
+			// Parse the JSON object value.
+			val, err = xd.ReadValue(&flags)
+			if err != nil {
+				return newMarshalErrorBefore(enc, v.Type(), err)
+			}
+			if err := enc.WriteValue(val); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.ReadToken(); err != nil {
+			return newMarshalErrorBefore(enc, v.Type(), err)
+		}
+		if err := xd.CheckEOF(); err != nil {
+			return newMarshalErrorBefore(enc, v.Type(), err)
+		}
+		return nil
+	}
+
+	m := v // must be a map[~string]V
+	n := m.Len()
+	if n == 0 {
+		return nil
+	}
+	mk := newAddressableValue(m.Type().Key())
+	mv := newAddressableValue(m.Type().Elem())
+	marshalVal := lookupArshaler(mv.Type()).marshal
+	if mo.Marshalers != nil {
+		marshalVal, _ = mo.Marshalers.(*Marshalers).lookup(marshalVal, mv.Type())
+	}
+	if !mo.Flags.Get(jsonflags.Deterministic) || n <= 1 {
+		for iter := m.MapRange(); iter.Next(); {
+			mk.SetIterKey(iter)
+			if err := enc.WriteToken(jsontext.String(mk.String())); err != nil {
+				return err
+			}
+			mv.SetIterValue(iter)
+			if err := marshalVal(enc, mv, mo); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	names := getStrings(n)
+	defer putStrings(names)
+	for i, iter := 0, m.MapRange(); i < n && iter.Next(); i++ {
+		mk.SetIterKey(iter)
+		(*names)[i] = mk.String()
+	}
+	names.sort()
+	for _, name := range *names {
+		if err := enc.WriteToken(jsontext.String(name)); err != nil {
+			return err
+		}
+		mk.SetString(name)
+		mv.Set(m.MapIndex(mk.Value))
+		if err := marshalVal(enc, mv, mo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalInlinedFallbackNext unmarshals only the next member in an inlined fallback,
+// where quotedName and unquotedName are the member name that was just read.
+func unmarshalInlinedFallbackNext(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct, f *structField, quotedName, unquotedName []byte) error {
+	v := addressableValue{va.Field(f.index[0]), va.forcedAddr} // addressable if struct value is addressable
+	if len(f.index) > 1 {
+		v = v.fieldByIndex(f.index[1:], true)
+		if !v.IsValid() {
+			return newUnmarshalErrorBeforeWithSkipping(dec, va.Type(), errNilField)
+		}
+	}
+	v = v.indirect(true)
+
+	if v.Type() == jsontextValueType {
+		b := v.Addr().Interface().(*jsontext.Value)
+		if len(*b) == 0 { // an empty value is treated as an empty JSON object
+			*b = append(*b, '{')
+		} else {
+			*b = jsonwire.TrimSuffixWhitespace(*b)
+			if !jsonwire.HasSuffixByte(*b, '}') {
+				return newUnmarshalErrorBeforeWithSkipping(dec, v.Type(), errRawInlinedNotObject)
+			}
+			*b = jsonwire.TrimSuffixByte(*b, '}')
+			*b = jsonwire.TrimSuffixWhitespace(*b)
+			if !jsonwire.HasSuffixByte(*b, ',') && !jsonwire.HasSuffixByte(*b, '{') {
+				*b = append(*b, ',')
+			}
+		}
+		*b = append(*b, quotedName...)
+		*b = append(*b, ':')
+		var flags jsonwire.ValueFlags
+		val, err := export.Decoder(dec).ReadValue(&flags)
+		if err != nil {
+			return err
+		}
+		*b = append(*b, val...)
+		*b = append(*b, '}')
+		return nil
+	}
+
+	m := v // must be a map[~string]V
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	mk := reflect.ValueOf(string(unquotedName))
+	if mkt := m.Type().Key(); mkt != stringType {
+		mk = mk.Convert(mkt)
+	}
+	mv := newAddressableValue(m.Type().Elem())
+	if v2 := m.MapIndex(mk); v2.IsValid() {
+		mv.Set(v2)
+	}
+	unmarshal := lookupArshaler(mv.Type()).unmarshal
+	if uo.Unmarshalers != nil {
+		unmarshal, _ = uo.Unmarshalers.(*Unmarshalers).lookup(unmarshal, mv.Type())
+	}
+	err := unmarshal(dec, mv, uo)
+	m.SetMapIndex(mk, mv.Value)
+	return err
+}
@@ -0,0 +1,567 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"encoding/json/jsontext"
+)
+
+type (
+	// methodJSON implements Marshaler and Unmarshaler.
+	methodJSON struct{ s string }
+	// methodJSONTo implements MarshalerTo and UnmarshalerFrom.
+	methodJSONTo struct{ s string }
+	// methodText implements encoding.TextMarshaler and encoding.TextUnmarshaler.
+	methodText struct{ s string }
+	// methodAll implements every marshal and unmarshal method,
+	// where each method reports its own name.
+	methodAll struct{ s string }
+	// methodPointer implements Marshaler only on the pointer receiver.
+	methodPointer struct{ s string }
+	// methodInts implements MarshalerTo for a named slice type.
+	methodInts []int
+
+	// methodBehavior returns or writes exactly what it is configured to.
+	methodBehavior struct {
+		out    string // output of MarshalJSON or MarshalText
+		err    error  // error returned by every method
+		tokens int    // number of tokens read or written by the streaming methods
+	}
+	methodJSONBehavior   struct{ methodBehavior }
+	methodJSONToBehavior struct{ methodBehavior }
+	methodTextBehavior   struct{ methodBehavior }
+)
+
+func (m methodJSON) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote("json:" + m.s)), nil
+}
+func (m *methodJSON) UnmarshalJSON(b []byte) error {
+	m.s = "json:" + string(b)
+	return nil
+}
+
+func (m methodJSONTo) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String("jsonto:" + m.s))
+}
+func (m *methodJSONTo) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	m.s = "jsonfrom:" + string(val)
+	return err
+}
+
+func (m methodText) MarshalText() ([]byte, error) {
+	return []byte("text:" + m.s), nil
+}
+func (m *methodText) UnmarshalText(b []byte) error {
+	m.s = "text:" + string(b)
+	return nil
+}
+
+func (methodAll) MarshalJSON() ([]byte, error)    { return []byte(`"MarshalJSON"`), nil }
+func (methodAll) MarshalText() ([]byte, error)    { return []byte("MarshalText"), nil }
+func (m *methodAll) UnmarshalText(b []byte) error { m.s = "UnmarshalText"; return nil }
+func (m *methodAll) UnmarshalJSON(b []byte) error { m.s = "UnmarshalJSON"; return nil }
+func (methodAll) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String("MarshalJSONTo"))
+}
+func (m *methodAll) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	m.s = "UnmarshalJSONFrom"
+	return dec.SkipValue()
+}
+
+func (m *methodPointer) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote("pointer:" + m.s)), nil
+}
+
+func (m methodInts) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var sum int
+	for _, n := range m {
+		sum += n
+	}
+	return enc.WriteToken(jsontext.Int(int64(sum)))
+}
+
+func (m methodJSONBehavior) MarshalJSON() ([]byte, error) {
+	return []byte(m.out), m.err
+}
+func (m *methodJSONBehavior) UnmarshalJSON([]byte) error {
+	return m.err
+}
+
+func (m methodJSONToBehavior) MarshalJSONTo(enc *jsontext.Encoder) error {
+	for range m.tokens {
+		if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+			return err
+		}
+	}
+	return m.err
+}
+func (m *methodJSONToBehavior) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	for range m.tokens {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+	}
+	return m.err
+}
+
+func (m methodTextBehavior) MarshalText() ([]byte, error) {
+	return []byte(m.out), m.err
+}
+func (m *methodTextBehavior) UnmarshalText([]byte) error {
+	return m.err
+}
+
+func TestMarshalMethods(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   any
+		want string
+	}{{
+		name: "Marshaler",
+		in:   []methodJSON{{"a"}, {"b"}},
+		want: `["json:a","json:b"]`,
+	}, {
+		name: "Marshaler/Pointer",
+		in:   []*methodJSON{{"a"}, nil},
+		want: `["json:a",null]`,
+	}, {
+		name: "Marshaler/Interface",
+		in:   []any{methodJSON{"a"}, &methodJSON{"b"}},
+		want: `["json:a","json:b"]`,
+	}, {
+		name: "Marshaler/MapKey",
+		in:   map[methodJSON]int{{"k"}: 1},
+		want: `{"json:k":1}`,
+	}, {
+		name: "Marshaler/Reformatted",
+		in:   methodJSONBehavior{methodBehavior{out: ` { "a" : [ 1 , "b" ] } `}},
+		want: `{"a":[1,"b"]}`,
+	}, {
+		name: "Marshaler/Indented",
+		opts: []Options{jsontext.WithIndent("  ")},
+		in:   []methodJSONBehavior{{methodBehavior{out: `{"a":[]}`}}},
+		want: "[\n  {\n    \"a\": []\n  }\n]",
+	}, {
+		name: "MarshalerTo",
+		in:   map[string]methodJSONTo{"k": {"v"}},
+		want: `{"k":"jsonto:v"}`,
+	}, {
+		name: "MarshalerTo/MapKey",
+		in:   map[methodJSONTo]int{{"k"}: 1},
+		want: `{"jsonto:k":1}`,
+	}, {
+		name: "MarshalerTo/NamedSlice",
+		in:   struct{ S methodInts }{methodInts{1, 2, 3}},
+		want: `{"S":6}`,
+	}, {
+		name: "MarshalerTo/NilNamedSlice",
+		in:   struct{ S methodInts }{},
+		want: `{"S":0}`,
+	}, {
+		name: "TextMarshaler",
+		in:   []methodText{{"a"}, {""}},
+		want: `["text:a","text:"]`,
+	}, {
+		name: "TextMarshaler/MapKey",
+		opts: []Options{Deterministic(true)},
+		in:   map[methodText]methodText{{"2"}: {"b"}, {"1"}: {"a"}},
+		want: `{"text:1":"text:a","text:2":"text:b"}`,
+	}, {
+		name: "TextMarshaler/Escaped",
+		in:   methodTextBehavior{methodBehavior{out: "\"<\\>\"\n"}},
+		want: `"\"<\\>\"\n"`,
+	}, {
+		name: "TextMarshaler/EscapeForHTML",
+		opts: []Options{jsontext.EscapeForHTML(true)},
+		in:   methodTextBehavior{methodBehavior{out: "<&>"}},
+		want: `"\u003c\u0026\u003e"`,
+	}, {
+		name: "Precedence",
+		in:   methodAll{},
+		want: `"MarshalJSONTo"`,
+	}, {
+		name: "Precedence/MapKey",
+		in:   map[methodAll]bool{{}: true},
+		want: `{"MarshalJSONTo":true}`,
+	}, {
+		name: "PointerReceiver/Addressable",
+		in:   []methodPointer{{"a"}},
+		want: `["pointer:a"]`,
+	}, {
+		name: "PointerReceiver/TopLevel",
+		in:   methodPointer{"a"},
+		want: `"pointer:a"`,
+	}, {
+		name: "PointerReceiver/MapValue",
+		in:   map[string]methodPointer{"k": {"v"}},
+		want: `{"k":"pointer:v"}`,
+	}, {
+		name: "PointerReceiver/Interface",
+		in:   []any{methodPointer{"v"}},
+		want: `["pointer:v"]`,
+	}, {
+		name: "FuncPrecedence",
+		opts: []Options{WithMarshalers(MarshalFunc(func(methodAll) ([]byte, error) {
+			return []byte(`"MarshalFunc"`), nil
+		}))},
+		in:   methodAll{},
+		want: `"MarshalFunc"`,
+	}, {
+		name: "FuncSkipToMethod",
+		opts: []Options{WithMarshalers(MarshalToFunc(func(*jsontext.Encoder, methodAll) error {
+			return SkipFunc
+		}))},
+		in:   methodAll{},
+		want: `"MarshalJSONTo"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMethods(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		into any
+		want any
+	}{{
+		name: "Unmarshaler",
+		in:   `[ "a" , {"b" : 1} ]`,
+		into: new([]methodJSON),
+		want: addr([]methodJSON{{`json:"a"`}, {`json:{"b" : 1}`}}),
+	}, {
+		name: "Unmarshaler/Null",
+		in:   `[null]`,
+		into: &[]methodJSON{{"old"}},
+		want: addr([]methodJSON{{"json:null"}}),
+	}, {
+		name: "Unmarshaler/NullPointer",
+		in:   `[null,"b"]`,
+		into: &[]*methodJSON{{"old"}, nil},
+		want: addr([]*methodJSON{nil, {`json:"b"`}}),
+	}, {
+		name: "Unmarshaler/MapKey",
+		in:   `{"k":"v"}`,
+		into: new(map[methodJSON]methodJSON),
+		want: addr(map[methodJSON]methodJSON{{`json:"k"`}: {`json:"v"`}}),
+	}, {
+		name: "UnmarshalerFrom",
+		in:   `{"k":[1, 2]}`,
+		into: new(map[string]methodJSONTo),
+		want: addr(map[string]methodJSONTo{"k": {"jsonfrom:[1, 2]"}}),
+	}, {
+		name: "UnmarshalerFrom/MapKey",
+		in:   `{"k":1}`,
+		into: new(map[methodJSONTo]int),
+		want: addr(map[methodJSONTo]int{{`jsonfrom:"k"`}: 1}),
+	}, {
+		name: "TextUnmarshaler",
+		in:   `["a","b",""]`,
+		into: new([]methodText),
+		want: addr([]methodText{{"text:a"}, {"text:b"}, {"text:"}}),
+	}, {
+		name: "TextUnmarshaler/Null",
+		in:   `[null]`,
+		into: &[]methodText{{"old"}},
+		want: addr([]methodText{{}}),
+	}, {
+		name: "TextUnmarshaler/MapKey",
+		in:   `{"k":"v"}`,
+		into: new(map[methodText]methodText),
+		want: addr(map[methodText]methodText{{"text:k"}: {"text:v"}}),
+	}, {
+		name: "Precedence",
+		in:   `"x"`,
+		into: new(methodAll),
+		want: &methodAll{"UnmarshalJSONFrom"},
+	}, {
+		name: "Precedence/MapKey",
+		in:   `{"k":true}`,
+		into: new(map[methodAll]bool),
+		want: addr(map[methodAll]bool{{"UnmarshalJSONFrom"}: true}),
+	}, {
+		name: "Interface",
+		in:   `"x"`,
+		into: addr(any(&methodText{})),
+		want: addr(any(&methodText{"text:x"})),
+	}, {
+		name: "FuncPrecedence",
+		opts: []Options{WithUnmarshalers(UnmarshalFunc(func(_ []byte, m *methodAll) error {
+			m.s = "UnmarshalFunc"
+			return nil
+		}))},
+		in:   `"x"`,
+		into: new(methodAll),
+		want: &methodAll{"UnmarshalFunc"},
+	}, {
+		name: "FuncSkipToMethod",
+		opts: []Options{WithUnmarshalers(UnmarshalFromFunc(func(*jsontext.Decoder, *methodAll) error {
+			return SkipFunc
+		}))},
+		in:   `"x"`,
+		into: new(methodAll),
+		want: &methodAll{"UnmarshalJSONFrom"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.in), tt.into, tt.opts...); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalMethodErrors(t *testing.T) {
+	errMethod := errors.New("method error")
+	tests := []struct {
+		name    string
+		in      any
+		wantErr string
+		wantIs  error
+		offset  int64
+		pointer jsontext.Pointer
+	}{{
+		name:    "Marshaler/Error",
+		in:      map[string]any{"k": methodJSONBehavior{methodBehavior{err: errMethod}}},
+		wantErr: `json: cannot marshal Go json.methodJSONBehavior within "/k": method error`,
+		wantIs:  errMethod,
+		offset:  4,
+		pointer: "/k",
+	}, {
+		name:    "Marshaler/Skip",
+		in:      []any{methodJSONBehavior{methodBehavior{err: SkipFunc}}},
+		wantErr: `json: cannot marshal Go json.methodJSONBehavior within "/0": marshal method cannot be skipped`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Marshaler/InvalidOutput",
+		in:      []any{1, methodJSONBehavior{methodBehavior{out: `[1,]`}}},
+		wantErr: `json: cannot marshal Go json.methodJSONBehavior within "/1": jsontext: invalid character ']' at start of value within "/1/1" after offset 5`,
+		offset:  2,
+		pointer: "/1",
+	}, {
+		name:    "Marshaler/TrailingOutput",
+		in:      methodJSONBehavior{methodBehavior{out: `{}{}`}},
+		wantErr: `json: cannot marshal Go json.methodJSONBehavior: jsontext: invalid character '{' after top-level value after offset 2`,
+		offset:  0,
+	}, {
+		name:    "Marshaler/InvalidName",
+		in:      map[methodJSONBehavior]int{{methodBehavior{out: `null`}}: 0},
+		wantErr: `json: cannot marshal Go json.methodJSONBehavior: jsontext: object member name must be a string after offset 1`,
+		offset:  1,
+	}, {
+		name:    "MarshalerTo/Error",
+		in:      struct{ A, B any }{1, methodJSONToBehavior{methodBehavior{err: errMethod}}},
+		wantErr: `json: cannot marshal Go json.methodJSONToBehavior within "/B": method error`,
+		wantIs:  errMethod,
+		offset:  10,
+		pointer: "/B",
+	}, {
+		name:    "MarshalerTo/ErrorAfterWrite",
+		in:      struct{ A any }{methodJSONToBehavior{methodBehavior{err: errMethod, tokens: 2}}},
+		wantErr: `json: cannot marshal within "/A/0": method error`,
+		wantIs:  errMethod,
+		offset:  7,
+		pointer: "/A/0",
+	}, {
+		name:    "MarshalerTo/Skip",
+		in:      []any{methodJSONToBehavior{methodBehavior{err: SkipFunc}}},
+		wantErr: `json: cannot marshal Go json.methodJSONToBehavior within "/0": marshal method cannot be skipped`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "MarshalerTo/NothingWritten",
+		in:      []any{methodJSONToBehavior{}},
+		wantErr: `json: cannot marshal Go json.methodJSONToBehavior within "/0": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "MarshalerTo/Incomplete",
+		in:      []any{methodJSONToBehavior{methodBehavior{tokens: 1}}},
+		wantErr: `json: cannot marshal within "/0": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  2,
+		pointer: "/0",
+	}, {
+		name:    "TextMarshaler/Error",
+		in:      map[string][]any{"k": {methodTextBehavior{methodBehavior{err: errMethod}}}},
+		wantErr: `json: cannot marshal Go json.methodTextBehavior within "/k/0": method error`,
+		wantIs:  errMethod,
+		offset:  6,
+		pointer: "/k/0",
+	}, {
+		name:    "TextMarshaler/Skip",
+		in:      methodTextBehavior{methodBehavior{err: SkipFunc}},
+		wantErr: `json: cannot marshal Go json.methodTextBehavior: marshal method cannot be skipped`,
+	}, {
+		name:    "TextMarshaler/InvalidUTF8",
+		in:      []any{methodTextBehavior{methodBehavior{out: "\xff"}}},
+		wantErr: `json: cannot marshal Go json.methodTextBehavior within "/0": jsontext: invalid UTF-8 within "/0" after offset 1`,
+		offset:  1,
+		pointer: "/0",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in)
+			checkErrorPosition(t, "Marshal", err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
+
+func TestUnmarshalMethodErrors(t *testing.T) {
+	errMethod := errors.New("method error")
+	tests := []struct {
+		name    string
+		in      string
+		into    any
+		wantErr string
+		wantIs  error
+		offset  int64
+		pointer jsontext.Pointer
+	}{{
+		name:    "Unmarshaler/Error",
+		in:      `{"k":[1,2]}`,
+		into:    &map[string]methodJSONBehavior{"k": {methodBehavior{err: errMethod}}},
+		wantErr: `json: cannot unmarshal JSON array into Go json.methodJSONBehavior within "/k": method error`,
+		wantIs:  errMethod,
+		offset:  5,
+		pointer: "/k",
+	}, {
+		name:    "Unmarshaler/Skip",
+		in:      `{"A":true}`,
+		into:    &struct{ A methodJSONBehavior }{methodJSONBehavior{methodBehavior{err: SkipFunc}}},
+		wantErr: `json: cannot unmarshal JSON boolean into Go json.methodJSONBehavior within "/A": unmarshal method cannot be skipped`,
+		offset:  5,
+		pointer: "/A",
+	}, {
+		name:    "Unmarshaler/SyntacticError",
+		in:      `[{]`,
+		into:    new([]methodJSONBehavior),
+		wantErr: `jsontext: mismatching structural token for object or array within "/0" after offset 2`,
+		offset:  2,
+		pointer: "/0",
+	}, {
+		name: "UnmarshalerFrom/Error",
+		in:   `{"A":1,"B":2}`,
+		into: &struct{ A, B methodJSONToBehavior }{
+			A: methodJSONToBehavior{methodBehavior{tokens: 1}},
+			B: methodJSONToBehavior{methodBehavior{err: errMethod}},
+		},
+		wantErr: `json: cannot unmarshal into Go json.methodJSONToBehavior within "/B": method error`,
+		wantIs:  errMethod,
+		offset:  10,
+		pointer: "/B",
+	}, {
+		name:    "UnmarshalerFrom/ErrorAfterRead",
+		in:      `{"A":{"a":{"b":1}}}`,
+		into:    &struct{ A methodJSONToBehavior }{methodJSONToBehavior{methodBehavior{err: errMethod, tokens: 2}}},
+		wantErr: `json: cannot unmarshal within "/A/a": method error`,
+		wantIs:  errMethod,
+		offset:  9,
+		pointer: "/A/a",
+	}, {
+		name:    "UnmarshalerFrom/Skip",
+		in:      `{"A":{}}`,
+		into:    &struct{ A methodJSONToBehavior }{methodJSONToBehavior{methodBehavior{err: SkipFunc}}},
+		wantErr: `json: cannot unmarshal into Go json.methodJSONToBehavior within "/A": unmarshal method cannot be skipped`,
+		offset:  4,
+		pointer: "/A",
+	}, {
+		name:    "UnmarshalerFrom/NothingRead",
+		in:      `[{}]`,
+		into:    new([]methodJSONToBehavior),
+		wantErr: `json: cannot unmarshal into Go json.methodJSONToBehavior within "/0": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "UnmarshalerFrom/TooManyRead",
+		in:      `{"A":1,"B":2}`,
+		into:    &struct{ A, B methodJSONToBehavior }{A: methodJSONToBehavior{methodBehavior{tokens: 2}}},
+		wantErr: `json: cannot unmarshal within "/B": must read or write exactly one value`,
+		wantIs:  errNonSingularValue,
+		offset:  10,
+		pointer: "/B",
+	}, {
+		name:    "UnmarshalerFrom/SyntacticError",
+		in:      `{"A":[1 2]}`,
+		into:    &struct{ A methodJSONToBehavior }{methodJSONToBehavior{methodBehavior{tokens: 3}}},
+		wantErr: `jsontext: invalid character '2' after array element (expecting ',' or ']') within "/A/1" after offset 8`,
+		offset:  8,
+		pointer: "/A/1",
+	}, {
+		name:    "TextUnmarshaler/Error",
+		in:      `{"a":"","b":"x"}`,
+		into:    &map[string]methodTextBehavior{"b": {methodBehavior{err: errMethod}}},
+		wantErr: `json: cannot unmarshal JSON string into Go json.methodTextBehavior within "/b": method error`,
+		wantIs:  errMethod,
+		offset:  12,
+		pointer: "/b",
+	}, {
+		name:    "TextUnmarshaler/Skip",
+		in:      `"x"`,
+		into:    &methodTextBehavior{methodBehavior{err: SkipFunc}},
+		wantErr: `json: cannot unmarshal JSON string into Go json.methodTextBehavior: unmarshal method cannot be skipped`,
+	}, {
+		name:    "TextUnmarshaler/NonString",
+		in:      `["",1]`,
+		into:    new([]methodText),
+		wantErr: `json: cannot unmarshal JSON number 1 into Go json.methodText within "/1": JSON value must be string type`,
+		wantIs:  errNonStringValue,
+		offset:  4,
+		pointer: "/1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.in), tt.into)
+			checkErrorPosition(t, "Unmarshal", err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
+
+// TestMethodsCallOrder verifies that a value is only ever marshaled
+// or unmarshaled through its highest precedence method.
+func TestMethodsCallOrder(t *testing.T) {
+	var calls []string
+	m := JoinMarshalers(
+		MarshalToFunc(func(_ *jsontext.Encoder, m methodAll) error {
+			calls = append(calls, "MarshalToFunc")
+			return SkipFunc
+		}),
+		MarshalFunc(func(m *methodAll) ([]byte, error) {
+			calls = append(calls, "MarshalFunc")
+			return []byte(strconv.Quote(strings.Join(calls, ","))), nil
+		}),
+	)
+	got, err := Marshal(methodAll{}, WithMarshalers(m))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `"MarshalToFunc,MarshalFunc"`; string(got) != want {
+		t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, want)
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"encoding/json/jsontext"
+)
+
+type (
+	structAll struct {
+		Bool    bool
+		String  string
+		Int     int64
+		Uint    uint8
+		Float   float64
+		Bytes   []byte
+		Slice   []string
+		Map     map[string]int
+		Pointer *structAll
+		Iface   any
+	}
+	structTags struct {
+		Renamed    string `json:"renamed"`
+		Quoted     string `json:"'with,comma'"`
+		OmitZero   int    `json:",omitzero"`
+		OmitEmpty  []int  `json:",omitempty"`
+		Stringify  int64  `json:",string"`
+		Ignored    string `json:"-"`
+		Fold       string `json:",case:ignore"`
+		unexported int
+	}
+	structInlined struct {
+		A int
+		structEmbedded
+		Rest jsontext.Value `json:",unknown"`
+	}
+	structEmbedded struct {
+		B int
+	}
+	structDuration struct {
+		D    time.Duration
+		Sec  time.Duration `json:",format:sec"`
+		Nano time.Duration `json:",format:nano"`
+	}
+	structBytes struct {
+		Default []byte
+		Hex     []byte `json:",format:hex"`
+		Array   []byte `json:",format:array"`
+	}
+	zeroer struct{ N int }
+)
+
+func (z zeroer) IsZero() bool { return z.N < 0 }
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   any
+		want string
+	}{{
+		name: "Nil",
+		in:   nil,
+		want: `null`,
+	}, {
+		name: "StructAll",
+		in: structAll{
+			Bool: true, String: "hello", Int: -1, Uint: 255, Float: 3.5,
+			Bytes: []byte("hi"), Slice: []string{"a"}, Map: map[string]int{"k": 1},
+			Pointer: &structAll{}, Iface: 1.5,
+		},
+		want: `{"Bool":true,"String":"hello","Int":-1,"Uint":255,"Float":3.5,"Bytes":"aGk=","Slice":["a"],"Map":{"k":1},"Pointer":{"Bool":false,"String":"","Int":0,"Uint":0,"Float":0,"Bytes":"","Slice":[],"Map":{},"Pointer":null,"Iface":null},"Iface":1.5}`,
+	}, {
+		name: "NilAsNull",
+		opts: []Options{FormatNilSliceAsNull(true), FormatNilMapAsNull(true)},
+		in: struct {
+			S []int
+			M map[string]int
+		}{},
+		want: `{"S":null,"M":null}`,
+	}, {
+		name: "StructTags",
+		in:   structTags{Renamed: "r", Quoted: "q", Stringify: math.MaxInt64, Ignored: "x", Fold: "f"},
+		want: `{"renamed":"r","with,comma":"q","Stringify":"9223372036854775807","Fold":"f"}`,
+	}, {
+		name: "OmitZeroMethod",
+		in: struct {
+			Z1 zeroer `json:",omitzero"`
+			Z2 zeroer `json:",omitzero"`
+		}{Z1: zeroer{-1}},
+		want: `{"Z2":{"N":0}}`,
+	}, {
+		name: "StringifyNumbers",
+		opts: []Options{StringifyNumbers(true)},
+		in:   []any{int8(1), uint(2), 3.5, "4"},
+		want: `["1","2","3.5","4"]`,
+	}, {
+		name: "Deterministic",
+		opts: []Options{Deterministic(true)},
+		in:   map[string]int{"c": 3, "a": 1, "b": 2},
+		want: `{"a":1,"b":2,"c":3}`,
+	}, {
+		name: "Inlined",
+		in:   structInlined{A: 1, structEmbedded: structEmbedded{B: 2}, Rest: jsontext.Value(`{"C":3}`)},
+		want: `{"A":1,"B":2,"C":3}`,
+	}, {
+		name: "DiscardUnknownMembers",
+		opts: []Options{DiscardUnknownMembers(true)},
+		in:   structInlined{A: 1, Rest: jsontext.Value(`{"C":3}`)},
+		want: `{"A":1,"B":0}`,
+	}, {
+		name: "Duration",
+		in:   structDuration{D: time.Hour + time.Second, Sec: 1500 * time.Millisecond, Nano: time.Microsecond},
+		want: `{"D":"1h0m1s","Sec":1.5,"Nano":1000}`,
+	}, {
+		name: "FormatDuration",
+		opts: []Options{FormatDuration("milli")},
+		in:   structDuration{D: time.Second},
+		want: `{"D":1000,"Sec":0,"Nano":0}`,
+	}, {
+		name: "Bytes",
+		in:   structBytes{Default: []byte{0xff}, Hex: []byte{0xff}, Array: []byte{0xff}},
+		want: `{"Default":"/w==","Hex":"ff","Array":[255]}`,
+	}, {
+		name: "FormatBytes",
+		opts: []Options{FormatBytes("base64url")},
+		in:   structBytes{Default: []byte{0xff}},
+		want: `{"Default":"_w==","Hex":"","Array":[]}`,
+	}, {
+		name: "Time",
+		in:   time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		want: `"2024-01-02T03:04:05.000000006Z"`,
+	}, {
+		name: "Multiline",
+		opts: []Options{jsontext.WithIndent("  ")},
+		in:   []int{1, 2},
+		want: "[\n  1,\n  2\n]",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		into any
+		want any
+	}{{
+		name: "StructAll",
+		in:   `{"Bool":true,"String":"hello","Int":-1,"Uint":255,"Float":3.5,"Bytes":"aGk=","Slice":["a"],"Map":{"k":1},"Pointer":{},"Iface":[1,"2",null]}`,
+		into: new(structAll),
+		want: &structAll{
+			Bool: true, String: "hello", Int: -1, Uint: 255, Float: 3.5,
+			Bytes: []byte("hi"), Slice: []string{"a"}, Map: map[string]int{"k": 1},
+			Pointer: &structAll{}, Iface: []any{1.0, "2", nil},
+		},
+	}, {
+		name: "CaseSensitive",
+		in:   `{"bool":true,"STRING":"x"}`,
+		into: new(structAll),
+		want: new(structAll),
+	}, {
+		name: "MatchCaseInsensitiveNames",
+		opts: []Options{MatchCaseInsensitiveNames(true)},
+		in:   `{"bool":true,"STRING":"x"}`,
+		into: new(structAll),
+		want: &structAll{Bool: true, String: "x"},
+	}, {
+		name: "CaseIgnoreTag",
+		in:   `{"f_o_l_d":"x","renamed":"r","with,comma":"q","Stringify":"-5"}`,
+		into: new(structTags),
+		want: &structTags{Fold: "x", Renamed: "r", Quoted: "q", Stringify: -5},
+	}, {
+		name: "Inlined",
+		in:   `{"A":1,"B":2,"C":3}`,
+		into: new(structInlined),
+		want: &structInlined{A: 1, structEmbedded: structEmbedded{B: 2}, Rest: jsontext.Value(`{"C":3}`)},
+	}, {
+		name: "MergeStruct",
+		in:   `{"Int":2}`,
+		into: &structAll{String: "keep"},
+		want: &structAll{String: "keep", Int: 2},
+	}, {
+		name: "Duration",
+		in:   `{"D":"1h0m1s","Sec":1.5,"Nano":1000}`,
+		into: new(structDuration),
+		want: &structDuration{D: time.Hour + time.Second, Sec: 1500 * time.Millisecond, Nano: time.Microsecond},
+	}, {
+		name: "FormatDuration",
+		opts: []Options{FormatDuration("sec")},
+		in:   `{"D":2.5}`,
+		into: new(structDuration),
+		want: &structDuration{D: 2500 * time.Millisecond},
+	}, {
+		name: "Bytes",
+		in:   `{"Default":"/w==","Hex":"ff","Array":[255]}`,
+		into: new(structBytes),
+		want: &structBytes{Default: []byte{0xff}, Hex: []byte{0xff}, Array: []byte{0xff}},
+	}, {
+		name: "FormatBytes",
+		opts: []Options{FormatBytes("base16")},
+		in:   `{"Default":"ff"}`,
+		into: new(structBytes),
+		want: &structBytes{Default: []byte{0xff}},
+	}, {
+		name: "Time",
+		in:   `"2024-01-02T03:04:05.000000006Z"`,
+		into: new(time.Time),
+		want: addr(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.in), tt.into, tt.opts...); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+func addr[T any](v T) *T { return &v }
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Options
+		in      string
+		into    any
+		wantErr error
+		pointer jsontext.Pointer
+	}{{
+		name:    "DuplicateName",
+		in:      `{"Int":1,"Int":2}`,
+		into:    new(structAll),
+		wantErr: jsontext.ErrDuplicateName,
+		pointer: "/Int",
+	}, {
+		name:    "RejectUnknownMembers",
+		opts:    []Options{RejectUnknownMembers(true)},
+		in:      `{"Int":1,"Other":2}`,
+		into:    new(structAll),
+		wantErr: ErrUnknownName,
+		pointer: "/Other",
+	}, {
+		name:    "Overflow",
+		in:      `{"Uint":256}`,
+		into:    new(structAll),
+		wantErr: strconv.ErrRange,
+		pointer: "/Uint",
+	}, {
+		name:    "WrongKind",
+		in:      `{"Map":{"k":"v"}}`,
+		into:    new(structAll),
+		pointer: "/Map/k",
+	}, {
+		name:    "NonPointer",
+		in:      `{}`,
+		into:    structAll{},
+		wantErr: errNonNilReference,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.in), tt.into, tt.opts...)
+			if err == nil {
+				t.Fatal("Unmarshal error = nil, want non-nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Unmarshal error = %v, want %v", err, tt.wantErr)
+			}
+			var ptr jsontext.Pointer
+			var serr *SemanticError
+			var xerr *jsontext.SyntacticError
+			switch {
+			case errors.As(err, &serr):
+				ptr = serr.JSONPointer
+			case errors.As(err, &xerr):
+				ptr = xerr.JSONPointer
+			}
+			if ptr != tt.pointer {
+				t.Errorf("Unmarshal error pointer = %q, want %q", ptr, tt.pointer)
+			}
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      any
+		wantErr error
+	}{
+		{name: "Channel", in: make(chan int), wantErr: errUnsupportedType},
+		{name: "NaN", in: math.NaN(), wantErr: errNonFiniteNumber},
+		{name: "InvalidUTF8", in: "\xff", wantErr: errors.New("invalid UTF-8")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in)
+			if err == nil {
+				t.Fatal("Marshal error = nil, want non-nil")
+			}
+			if !errors.Is(err, tt.wantErr) && !strings.Contains(err.Error(), tt.wantErr.Error()) {
+				t.Errorf("Marshal error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCallerMarshalers(t *testing.T) {
+	type point struct{ X, Y int }
+	marshalers := JoinMarshalers(
+		MarshalFunc(func(p point) ([]byte, error) {
+			return []byte(strconv.Quote(strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y))), nil
+		}),
+		MarshalToFunc(func(enc *jsontext.Encoder, b bool) error {
+			if b {
+				return enc.WriteToken(jsontext.String("yes"))
+			}
+			return SkipFunc
+		}),
+	)
+	unmarshalers := UnmarshalFromFunc(func(dec *jsontext.Decoder, p *point) error {
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return err
+		}
+		x, y, _ := strings.Cut(tok.String(), ",")
+		p.X, _ = strconv.Atoi(x)
+		p.Y, _ = strconv.Atoi(y)
+		return nil
+	})
+
+	in := map[string]any{"p": point{1, 2}, "t": true, "f": false}
+	got, err := Marshal(in, WithMarshalers(marshalers), Deterministic(true))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	const want = `{"f":false,"p":"1,2","t":"yes"}`
+	if string(got) != want {
+		t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, want)
+	}
+
+	var out []point
+	if err := Unmarshal([]byte(`["3,4","5,6"]`), &out, WithUnmarshalers(unmarshalers)); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if wantOut := []point{{3, 4}, {5, 6}}; !reflect.DeepEqual(out, wantOut) {
+		t.Errorf("Unmarshal:\n\tgot:  %v\n\twant: %v", out, wantOut)
+	}
+}
+
+// TestUnmarshalDecodeStream checks that elements of a large JSON array
+// can be unmarshaled one at a time from a jsontext.Decoder.
+func TestUnmarshalDecodeStream(t *testing.T) {
+	var in bytes.Buffer
+	in.WriteString("[")
+	for i := range 1000 {
+		if i > 0 {
+			in.WriteString(",")
+		}
+		in.WriteString(`{"Int":` + strconv.Itoa(i) + `}`)
+	}
+	in.WriteString("]")
+
+	dec := jsontext.NewDecoder(&in)
+	if _, err := dec.ReadToken(); err != nil {
+		t.Fatalf("ReadToken error: %v", err)
+	}
+	var sum int64
+	for dec.PeekKind() != ']' {
+		var v structAll
+		if err := UnmarshalDecode(dec, &v); err != nil {
+			t.Fatalf("UnmarshalDecode error: %v", err)
+		}
+		sum += v.Int
+	}
+	if sum != 999*1000/2 {
+		t.Errorf("sum = %d, want %d", sum, 999*1000/2)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	opts := JoinOptions(Deterministic(true), FormatDuration("sec"), jsontext.WithIndent("\t"))
+	if v, ok := GetOption(opts, Deterministic); !v || !ok {
+		t.Errorf("GetOption(Deterministic) = (%v, %v), want (true, true)", v, ok)
+	}
+	if v, ok := GetOption(opts, FormatDuration); v != "sec" || !ok {
+		t.Errorf("GetOption(FormatDuration) = (%q, %v), want (sec, true)", v, ok)
+	}
+	if v, ok := GetOption(opts, jsontext.WithIndent); v != "\t" || !ok {
+		t.Errorf("GetOption(WithIndent) = (%q, %v), want (\\t, true)", v, ok)
+	}
+	if v, ok := GetOption(opts, StringifyNumbers); v || ok {
+		t.Errorf("GetOption(StringifyNumbers) = (%v, %v), want (false, false)", v, ok)
+	}
+	opts = JoinOptions(opts, Deterministic(false))
+	if v, ok := GetOption(opts, Deterministic); v || !ok {
+		t.Errorf("GetOption(Deterministic) = (%v, %v), want (false, true)", v, ok)
+	}
+}
@@ -0,0 +1,500 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+var (
+	timeDurationType = reflect.TypeFor[time.Duration]()
+	timeTimeType     = reflect.TypeFor[time.Time]()
+)
+
+func makeTimeArshaler(fncs *arshaler, t reflect.Type) *arshaler {
+	// Ideally, time types would implement MarshalerTo and UnmarshalerFrom,
+	// but that would result in the "time" package depending on "json".
+	// Until then, we handle them here, overriding any methods on the types
+	// unless v1 semantics are in effect.
+	switch t {
+	case timeDurationType:
+		fncs.nonDefault = true
+		marshalNano := fncs.marshal
+		fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+			xe := export.Encoder(enc)
+			var m durationArshaler
+			switch {
+			case hasFormat(mo, xe.Tokens.Depth()):
+				if !m.initFormat(mo.Format) {
+					return newInvalidFormatError(enc, t, mo)
+				}
+			case mo.Flags.Has(jsonflags.FormatDuration):
+				if !m.initFormat(mo.FormatDuration) {
+					return newMarshalErrorBefore(enc, t, fmt.Errorf("invalid duration format %q", mo.FormatDuration))
+				}
+			case mo.Flags.Get(jsonflags.FormatTimeWithLegacySemantics):
+				return marshalNano(enc, va, mo)
+			}
+
+			m.td = *va.Addr().Interface().(*time.Duration)
+			k := stringOrNumberKind(!m.isNumeric() || xe.Tokens.NeedObjectName() || mo.Flags.Get(jsonflags.StringifyNumbers))
+			return xe.AppendRaw(k, true, m.appendMarshal)
+		}
+		unmarshalNano := fncs.unmarshal
+		fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+			xd := export.Decoder(dec)
+			var u durationArshaler
+			switch {
+			case hasFormat(uo, xd.Tokens.Depth()):
+				if !u.initFormat(uo.Format) {
+					return newInvalidFormatError(dec, t, uo)
+				}
+			case uo.Flags.Has(jsonflags.FormatDuration):
+				if !u.initFormat(uo.FormatDuration) {
+					return newUnmarshalErrorBeforeWithSkipping(dec, t, fmt.Errorf("invalid duration format %q", uo.FormatDuration))
+				}
+			case uo.Flags.Get(jsonflags.FormatTimeWithLegacySemantics):
+				return unmarshalNano(dec, va, uo)
+			}
+
+			stringify := !u.isNumeric() || xd.Tokens.NeedObjectName() || uo.Flags.Get(jsonflags.StringifyNumbers)
+			var flags jsonwire.ValueFlags
+			td := va.Addr().Interface().(*time.Duration)
+			val, err := xd.ReadValue(&flags)
+			if err != nil {
+				return err
+			}
+			switch k := val.Kind(); k {
+			case 'n':
+				if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					*td = time.Duration(0)
+				}
+				return nil
+			case '"':
+				if !stringify {
+					break
+				}
+				val = unquoteValue(val, flags)
+				if err := u.unmarshal(val); err != nil {
+					return newUnmarshalErrorAfter(dec, t, err)
+				}
+				*td = u.td
+				return nil
+			case '0':
+				if stringify {
+					break
+				}
+				if err := u.unmarshal(val); err != nil {
+					return newUnmarshalErrorAfter(dec, t, err)
+				}
+				*td = u.td
+				return nil
+			}
+			return newUnmarshalErrorAfterWithValue(dec, t, nil)
+		}
+	case timeTimeType:
+		fncs.nonDefault = true
+		marshalMethod := fncs.marshal
+		fncs.marshal = func(enc *jsontext.Encoder, va addressableValue, mo *jsonopts.Struct) error {
+			xe := export.Encoder(enc)
+			var m timeArshaler
+			switch {
+			case hasFormat(mo, xe.Tokens.Depth()):
+				if !m.initFormat(mo.Format) {
+					return newInvalidFormatError(enc, t, mo)
+				}
+			case mo.Flags.Get(jsonflags.FormatTimeWithLegacySemantics):
+				return marshalMethod(enc, va, mo)
+			default:
+				m.initFormat("")
+			}
+
+			m.tt = *va.Addr().Interface().(*time.Time)
+			k := stringOrNumberKind(!m.isNumeric() || xe.Tokens.NeedObjectName() || mo.Flags.Get(jsonflags.StringifyNumbers))
+			if err := xe.AppendRaw(k, !m.hasCustomFormat(), m.appendMarshal); err != nil {
+				if !isSyntacticError(err) && !export.IsIOError(err) {
+					err = newMarshalErrorBefore(enc, t, err)
+				}
+				return err
+			}
+			return nil
+		}
+		unmarshalMethod := fncs.unmarshal
+		fncs.unmarshal = func(dec *jsontext.Decoder, va addressableValue, uo *jsonopts.Struct) error {
+			xd := export.Decoder(dec)
+			var u timeArshaler
+			switch {
+			case hasFormat(uo, xd.Tokens.Depth()):
+				if !u.initFormat(uo.Format) {
+					return newInvalidFormatError(dec, t, uo)
+				}
+			case uo.Flags.Get(jsonflags.FormatTimeWithLegacySemantics):
+				return unmarshalMethod(dec, va, uo)
+			default:
+				u.initFormat("")
+			}
+
+			stringify := !u.isNumeric() || xd.Tokens.NeedObjectName() || uo.Flags.Get(jsonflags.StringifyNumbers)
+			var flags jsonwire.ValueFlags
+			tt := va.Addr().Interface().(*time.Time)
+			val, err := xd.ReadValue(&flags)
+			if err != nil {
+				return err
+			}
+			switch k := val.Kind(); k {
+			case 'n':
+				if !uo.Flags.Get(jsonflags.MergeWithLegacySemantics) {
+					*tt = time.Time{}
+				}
+				return nil
+			case '"':
+				if !stringify {
+					break
+				}
+				val = unquoteValue(val, flags)
+				if err := u.unmarshal(val); err != nil {
+					return newUnmarshalErrorAfter(dec, t, err)
+				}
+				*tt = u.tt
+				return nil
+			case '0':
+				if stringify {
+					break
+				}
+				if err := u.unmarshal(val); err != nil {
+					return newUnmarshalErrorAfter(dec, t, err)
+				}
+				*tt = u.tt
+				return nil
+			}
+			return newUnmarshalErrorAfterWithValue(dec, t, nil)
+		}
+	}
+	return fncs
+}
+
+func stringOrNumberKind(isString bool) jsontext.Kind {
+	if isString {
+		return '"'
+	} else {
+		return '0'
+	}
+}
+
+type durationArshaler struct {
+	td time.Duration
+
+	// base records the representation where:
+	//   - 0 uses time.Duration.String
+	//   - 1e0, 1e3, 1e6, or 1e9 use a decimal encoding of the duration as
+	//     nanoseconds, microseconds, milliseconds, or seconds.
+	base uint64
+}
+
+func (a *durationArshaler) initFormat(format string) (ok bool) {
+	switch format {
+	case "", "units":
+		a.base = 0
+	case "sec":
+		a.base = 1e9
+	case "milli":
+		a.base = 1e6
+	case "micro":
+		a.base = 1e3
+	case "nano":
+		a.base = 1e0
+	default:
+		return false
+	}
+	return true
+}
+
+func (a *durationArshaler) isNumeric() bool {
+	return a.base != 0
+}
+
+func (a *durationArshaler) appendMarshal(b []byte) ([]byte, error) {
+	switch a.base {
+	case 0:
+		return append(b, a.td.String()...), nil
+	default:
+		return appendDurationBase10(b, a.td, a.base), nil
+	}
+}
+
+func (a *durationArshaler) unmarshal(b []byte) (err error) {
+	switch a.base {
+	case 0:
+		a.td, err = time.ParseDuration(string(b))
+	default:
+		a.td, err = parseDurationBase10(b, a.base)
+	}
+	return err
+}
+
+type timeArshaler struct {
+	tt time.Time
+
+	// base records the representation where:
+	//   - 0 uses RFC 3339 encoding of the timestamp
+	//   - 1e0, 1e3, 1e6, or 1e9 use a decimal encoding of the timestamp as
+	//     seconds, milliseconds, microseconds, or nanoseconds since Unix epoch.
+	base uint64
+
+	// format is the layout passed to time.Time.AppendFormat and
+	// time.Parse if base is zero.
+	format string
+}
+
+func (a *timeArshaler) initFormat(format string) bool {
+	// We assume that an exported constant in the time package will
+	// always start with an uppercase ASCII letter.
+	if len(format) == 0 {
+		a.format = time.RFC3339Nano
+		return true
+	}
+	a.base = 0
+	if c := format[0]; !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+		a.format = format
+		return true
+	}
+	switch format {
+	case "ANSIC":
+		a.format = time.ANSIC
+	case "UnixDate":
+		a.format = time.UnixDate
+	case "RubyDate":
+		a.format = time.RubyDate
+	case "RFC822":
+		a.format = time.RFC822
+	case "RFC822Z":
+		a.format = time.RFC822Z
+	case "RFC850":
+		a.format = time.RFC850
+	case "RFC1123":
+		a.format = time.RFC1123
+	case "RFC1123Z":
+		a.format = time.RFC1123Z
+	case "RFC3339":
+		a.format = time.RFC3339
+	case "RFC3339Nano":
+		a.format = time.RFC3339Nano
+	case "Kitchen":
+		a.format = time.Kitchen
+	case "Stamp":
+		a.format = time.Stamp
+	case "StampMilli":
+		a.format = time.StampMilli
+	case "StampMicro":
+		a.format = time.StampMicro
+	case "StampNano":
+		a.format = time.StampNano
+	case "DateTime":
+		a.format = time.DateTime
+	case "DateOnly":
+		a.format = time.DateOnly
+	case "TimeOnly":
+		a.format = time.TimeOnly
+	case "unix":
+		a.base = 1e0
+	case "unixmilli":
+		a.base = 1e3
+	case "unixmicro":
+		a.base = 1e6
+	case "unixnano":
+		a.base = 1e9
+	default:
+		// Reject any Go identifier in case new constants are supported.
+		if strings.TrimFunc(format, isLetterOrDigit) == "" {
+			return false
+		}
+		a.format = format
+	}
+	return true
+}
+
+func (a *timeArshaler) isNumeric() bool {
+	return a.base != 0
+}
+
+// hasCustomFormat reports whether the output may contain characters
+// that need escaping, which is only possible for a custom layout.
+func (a *timeArshaler) hasCustomFormat() bool {
+	return a.base == 0 && a.format != time.RFC3339 && a.format != time.RFC3339Nano
+}
+
+func (a *timeArshaler) appendMarshal(b []byte) ([]byte, error) {
+	if a.base == 0 {
+		if a.format == time.RFC3339 || a.format == time.RFC3339Nano {
+			// RFC 3339 only permits four-digit years.
+			if y := a.tt.Year(); y < 0 || y > 9999 {
+				return b, errors.New("year outside of range [0,9999]")
+			}
+		}
+		return a.tt.AppendFormat(b, a.format), nil
+	}
+	return appendTimeUnix(b, a.tt, a.base), nil
+}
+
+func (a *timeArshaler) unmarshal(b []byte) (err error) {
+	if a.base == 0 {
+		a.tt, err = time.Parse(a.format, string(b))
+		return err
+	}
+	a.tt, err = parseTimeUnix(b, a.base)
+	return err
+}
+
+// appendDurationBase10 appends d formatted as a decimal fractional number,
+// where pow10 is a power-of-10 used to scale down the number.
+func appendDurationBase10(b []byte, d time.Duration, pow10 uint64) []byte {
+	b, n := mayAppendDurationSign(b, d)            // append sign
+	whole, frac := bits.Div64(0, n, uint64(pow10)) // compute whole and frac fields
+	b = strconv.AppendUint(b, whole, 10)           // append whole field
+	return appendFracBase10(b, frac, pow10)        // append frac field
+}
+
+// parseDurationBase10 parses d from a decimal fractional number,
+// where pow10 is a power-of-10 used to scale up the number.
+func parseDurationBase10(b []byte, pow10 uint64) (time.Duration, error) {
+	suffix, neg := consumeSign(b)                              // consume sign
+	wholeBytes, fracBytes, _ := bytes.Cut(suffix, []byte(".")) // consume whole and frac fields
+	whole, okWhole := jsonwire.ParseUint(wholeBytes)           // parse whole field; may overflow
+	frac, okFrac := parseFracBase10(fracBytes, pow10, len(suffix) > len(wholeBytes))
+	hi, lo := bits.Mul64(whole, uint64(pow10)) // overflow if hi > 0
+	sum, co := bits.Add64(lo, uint64(frac), 0) // overflow if co > 0
+	switch d := mayApplyDurationSign(sum, neg); {
+	case (!okWhole && whole != math.MaxUint64) || !okFrac:
+		return 0, fmt.Errorf("invalid duration %q: %w", b, strconv.ErrSyntax)
+	case !okWhole || hi > 0 || co > 0 || (d != 0 && neg != (d < 0)):
+		return 0, fmt.Errorf("invalid duration %q: %w", b, strconv.ErrRange)
+	default:
+		return d, nil
+	}
+}
+
+// mayAppendDurationSign appends a negative sign if n is negative.
+func mayAppendDurationSign(b []byte, d time.Duration) ([]byte, uint64) {
+	if d < 0 {
+		b = append(b, '-')
+		d *= -1
+	}
+	return b, uint64(d)
+}
+
+// mayApplyDurationSign inverts n if neg is specified.
+func mayApplyDurationSign(n uint64, neg bool) time.Duration {
+	if neg {
+		return -1 * time.Duration(n)
+	} else {
+		return +1 * time.Duration(n)
+	}
+}
+
+// appendTimeUnix appends t formatted as a decimal fractional number,
+// where pow10 is the number of units per second.
+func appendTimeUnix(b []byte, t time.Time, pow10 uint64) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	unit := 1e9 / pow10 // nanoseconds per unit
+	whole, frac := sec*int64(pow10)+int64(nsec/unit), nsec%unit
+	neg := sec < 0
+	if neg {
+		if frac > 0 {
+			whole, frac = whole+1, unit-frac
+		}
+		b = append(b, '-')
+		b = strconv.AppendUint(b, uint64(-whole), 10)
+	} else {
+		b = strconv.AppendUint(b, uint64(whole), 10)
+	}
+	return appendFracBase10(b, frac, unit)
+}
+
+// parseTimeUnix parses t from a decimal fractional number,
+// where pow10 is the number of units per second.
+func parseTimeUnix(b []byte, pow10 uint64) (time.Time, error) {
+	suffix, neg := consumeSign(b)
+	wholeBytes, fracBytes, _ := bytes.Cut(suffix, []byte("."))
+	whole, okWhole := jsonwire.ParseUint(wholeBytes)
+	unit := 1e9 / pow10 // nanoseconds per unit
+	frac, okFrac := parseFracBase10(fracBytes, unit, len(suffix) > len(wholeBytes))
+	switch {
+	case (!okWhole && whole != math.MaxUint64) || !okFrac:
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", b, strconv.ErrSyntax)
+	case !okWhole || whole/pow10 > math.MaxInt64:
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", b, strconv.ErrRange)
+	}
+	sec := int64(whole / pow10)
+	nsec := int64((whole%pow10)*unit + frac)
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// appendFracBase10 appends the fraction of n/pow10,
+// where pow10 is a power-of-10 that is larger than n.
+// Trailing zeros are omitted, and nothing is appended if n is zero.
+func appendFracBase10(b []byte, n, pow10 uint64) []byte {
+	switch {
+	case pow10 == 1:
+		return b
+	case n == 0:
+		return b
+	default:
+		// Format with a leading 1 to obtain leading zeros,
+		// then replace the leading 1 with the decimal point.
+		b = append(b, '.')
+		start := len(b)
+		b = strconv.AppendUint(b, n+pow10, 10)
+		b = append(b[:start], b[start+1:]...)
+		return bytes.TrimRight(b, "0")
+	}
+}
+
+// parseFracBase10 parses the fraction digits in b as a multiple of 1/pow10.
+// The digits must not exceed the precision of pow10.
+// If hasDot is set, then b must contain at least one digit.
+func parseFracBase10(b []byte, pow10 uint64, hasDot bool) (uint64, bool) {
+	if !hasDot {
+		return 0, true
+	}
+	digits := len(strconv.FormatUint(pow10, 10)) - 1
+	if len(b) == 0 || len(b) > digits {
+		return 0, false
+	}
+	var n uint64
+	for _, c := range b {
+		if c < '0' || '9' < c {
+			return 0, false
+		}
+		n = 10*n + uint64(c-'0')
+	}
+	for range digits - len(b) {
+		n *= 10
+	}
+	return n, true
+}
+
+// consumeSign consumes an optional leading negative sign.
+func consumeSign(b []byte) ([]byte, bool) {
+	if len(b) > 0 && b[0] == '-' {
+		return b[len("-"):], true
+	}
+	return b, false
+}
@@ -0,0 +1,433 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"encoding/json/jsontext"
+)
+
+type structTimes struct {
+	Default   time.Time
+	RFC3339   time.Time `json:",format:RFC3339"`
+	DateOnly  time.Time `json:",format:DateOnly"`
+	Kitchen   time.Time `json:",format:Kitchen"`
+	Layout    time.Time `json:",format:'2006/01/02'"`
+	Unix      time.Time `json:",format:unix"`
+	UnixMilli time.Time `json:",format:unixmilli"`
+	UnixMicro time.Time `json:",format:unixmicro"`
+	UnixNano  time.Time `json:",format:unixnano"`
+}
+
+var (
+	timeEpoch  = time.Unix(0, 0).UTC()
+	timeSample = time.Date(2024, time.March, 4, 15, 6, 7, 123456789, time.UTC)
+)
+
+func TestMarshalTime(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   any
+		want string
+	}{{
+		name: "Default",
+		in:   []time.Time{timeSample, timeEpoch, {}},
+		want: `["2024-03-04T15:06:07.123456789Z","1970-01-01T00:00:00Z","0001-01-01T00:00:00Z"]`,
+	}, {
+		name: "Default/TimeZone",
+		in:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", -(7*3600+30*60))),
+		want: `"2024-01-02T03:04:05-07:30"`,
+	}, {
+		name: "Formats",
+		in: structTimes{
+			Default:   timeSample,
+			RFC3339:   timeSample,
+			DateOnly:  timeSample,
+			Kitchen:   timeSample,
+			Layout:    timeSample,
+			Unix:      timeSample,
+			UnixMilli: timeSample,
+			UnixMicro: timeSample,
+			UnixNano:  timeSample,
+		},
+		want: `{` +
+			`"Default":"2024-03-04T15:06:07.123456789Z",` +
+			`"RFC3339":"2024-03-04T15:06:07Z",` +
+			`"DateOnly":"2024-03-04",` +
+			`"Kitchen":"3:06PM",` +
+			`"Layout":"2024/03/04",` +
+			`"Unix":1709564767.123456789,` +
+			`"UnixMilli":1709564767123.456789,` +
+			`"UnixMicro":1709564767123456.789,` +
+			`"UnixNano":1709564767123456789}`,
+	}, {
+		name: "Formats/Epoch",
+		in:   structTimes{Unix: timeEpoch, UnixMilli: timeEpoch, UnixMicro: timeEpoch, UnixNano: timeEpoch},
+		want: `{` +
+			`"Default":"0001-01-01T00:00:00Z",` +
+			`"RFC3339":"0001-01-01T00:00:00Z",` +
+			`"DateOnly":"0001-01-01",` +
+			`"Kitchen":"12:00AM",` +
+			`"Layout":"0001/01/01",` +
+			`"Unix":0,"UnixMilli":0,"UnixMicro":0,"UnixNano":0}`,
+	}, {
+		name: "Unix/Negative",
+		in: struct {
+			A time.Time `json:",format:unix"`
+			B time.Time `json:",format:unixmilli"`
+			C time.Time `json:",format:unixnano"`
+		}{
+			A: time.Unix(-2, 500_000_000),
+			B: time.Unix(-1, 0),
+			C: time.Unix(0, -1),
+		},
+		want: `{"A":-1.5,"B":-1000,"C":-1}`,
+	}, {
+		name: "Unix/TrailingZerosOmitted",
+		in: struct {
+			T time.Time `json:",format:unix"`
+		}{time.Unix(1, 100_000_000)},
+		want: `{"T":1.1}`,
+	}, {
+		name: "Unix/StringifyNumbers",
+		opts: []Options{StringifyNumbers(true)},
+		in: struct {
+			T time.Time `json:",format:unixmilli"`
+		}{time.Unix(1, 0)},
+		want: `{"T":"1000"}`,
+	}, {
+		name: "MapKey",
+		opts: []Options{Deterministic(true)},
+		in:   map[time.Time]int{timeEpoch: 0, timeSample: 1},
+		want: `{"1970-01-01T00:00:00Z":0,"2024-03-04T15:06:07.123456789Z":1}`,
+	}, {
+		name: "Layout/Escaped",
+		in: struct {
+			T time.Time `json:",format:'\"2006\"'"`
+		}{timeSample},
+		want: `{"T":"\"2024\""}`,
+	}, {
+		name: "Pointer",
+		in:   struct{ P, N *time.Time }{P: &timeEpoch},
+		want: `{"P":"1970-01-01T00:00:00Z","N":null}`,
+	}, {
+		name: "Interface",
+		in:   []any{timeEpoch, time.Second},
+		want: `["1970-01-01T00:00:00Z","1s"]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalTime(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		into any
+		want any
+	}{{
+		name: "Default",
+		in:   `["2024-03-04T15:06:07.123456789Z","1970-01-01T00:00:00Z"]`,
+		into: new([]time.Time),
+		want: addr([]time.Time{timeSample, timeEpoch}),
+	}, {
+		name: "Default/Null",
+		in:   `null`,
+		into: addr(timeSample),
+		want: addr(time.Time{}),
+	}, {
+		name: "Formats",
+		in: `{` +
+			`"Default":"2024-03-04T15:06:07.123456789Z",` +
+			`"RFC3339":"2024-03-04T15:06:07.123456789Z",` +
+			`"DateOnly":"2024-03-04",` +
+			`"Layout":"2024/03/04",` +
+			`"Unix":1709564767.123456789,` +
+			`"UnixMilli":1709564767123.456789,` +
+			`"UnixMicro":1709564767123456.789,` +
+			`"UnixNano":1709564767123456789}`,
+		into: new(structTimes),
+		want: &structTimes{
+			Default:   timeSample,
+			RFC3339:   timeSample,
+			DateOnly:  time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+			Layout:    time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+			Unix:      timeSample,
+			UnixMilli: timeSample,
+			UnixMicro: timeSample,
+			UnixNano:  timeSample,
+		},
+	}, {
+		name: "Unix/Negative",
+		in:   `{"A":-1.5,"B":-1000,"C":-1}`,
+		into: new(struct {
+			A time.Time `json:",format:unix"`
+			B time.Time `json:",format:unixmilli"`
+			C time.Time `json:",format:unixnano"`
+		}),
+		want: &struct {
+			A time.Time `json:",format:unix"`
+			B time.Time `json:",format:unixmilli"`
+			C time.Time `json:",format:unixnano"`
+		}{
+			A: time.Unix(-2, 500_000_000).UTC(),
+			B: time.Unix(-1, 0).UTC(),
+			C: time.Unix(0, -1).UTC(),
+		},
+	}, {
+		name: "Unix/StringifyNumbers",
+		opts: []Options{StringifyNumbers(true)},
+		in:   `{"T":"1000"}`,
+		into: new(struct {
+			T time.Time `json:",format:unixmilli"`
+		}),
+		want: &struct {
+			T time.Time `json:",format:unixmilli"`
+		}{time.Unix(1, 0).UTC()},
+	}, {
+		name: "MapKey",
+		in:   `{"1970-01-01T00:00:00Z":0}`,
+		into: new(map[time.Time]int),
+		want: addr(map[time.Time]int{timeEpoch: 0}),
+	}, {
+		name: "Interface",
+		in:   `"1970-01-01T00:00:00Z"`,
+		into: new(any),
+		want: addr(any("1970-01-01T00:00:00Z")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.in), tt.into, tt.opts...); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %v\n\twant: %v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		marshal bool
+		in      any
+		data    string
+		wantErr string
+		wantIs  error
+		offset  int64
+		pointer jsontext.Pointer
+	}{{
+		name:    "Marshal/YearOutOfRange",
+		marshal: true,
+		in:      []time.Time{time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		wantErr: `json: cannot marshal Go time.Time within "/0": year outside of range [0,9999]`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "Marshal/NegativeYear",
+		marshal: true,
+		in:      map[string]time.Time{"t": time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)},
+		wantErr: `json: cannot marshal Go time.Time within "/t": year outside of range [0,9999]`,
+		offset:  4,
+		pointer: "/t",
+	}, {
+		name:    "Marshal/InvalidFormat",
+		marshal: true,
+		in: struct {
+			T time.Time `json:",format:UnknownConstant"`
+		}{},
+		wantErr: `json: cannot marshal Go time.Time within "/T": invalid format flag "UnknownConstant"`,
+		offset:  4,
+		pointer: "/T",
+	}, {
+		name: "Unmarshal/InvalidFormat",
+		in: new(struct {
+			T time.Time `json:",format:UnknownConstant"`
+		}),
+		data:    `{"T":""}`,
+		wantErr: `json: cannot unmarshal JSON string into Go time.Time within "/T": invalid format flag "UnknownConstant"`,
+		offset:  4,
+		pointer: "/T",
+	}, {
+		name:    "Unmarshal/ParseError",
+		in:      new([]time.Time),
+		data:    `["2024-03-04T15:06:07Z","2024-13-01T00:00:00Z"]`,
+		wantErr: `json: cannot unmarshal JSON string into Go time.Time within "/1": parsing time "2024-13-01T00:00:00Z": month out of range`,
+		offset:  24,
+		pointer: "/1",
+	}, {
+		name:    "Unmarshal/Number",
+		in:      new(time.Time),
+		data:    `0`,
+		wantErr: `json: cannot unmarshal JSON number 0 into Go time.Time`,
+	}, {
+		name: "Unmarshal/UnixString",
+		in: new(struct {
+			T time.Time `json:",format:unix"`
+		}),
+		data:    `{"T":"0"}`,
+		wantErr: `json: cannot unmarshal JSON string "0" into Go time.Time within "/T"`,
+		offset:  5,
+		pointer: "/T",
+	}, {
+		name: "Unmarshal/UnixTooPrecise",
+		in: new(struct {
+			T time.Time `json:",format:unixmilli"`
+		}),
+		data:    `{"T":1.0000001}`,
+		wantErr: `json: cannot unmarshal JSON number into Go time.Time within "/T": invalid time "1.0000001": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  5,
+		pointer: "/T",
+	}, {
+		name: "Unmarshal/UnixExponent",
+		in: new(struct {
+			T time.Time `json:",format:unixmilli"`
+		}),
+		data:    `{"T":1e3}`,
+		wantErr: `json: cannot unmarshal JSON number into Go time.Time within "/T": invalid time "1e3": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  5,
+		pointer: "/T",
+	}, {
+		name: "Unmarshal/UnixOverflow",
+		in: new(struct {
+			T time.Time `json:",format:unix"`
+		}),
+		data:    `{"T":18446744073709551616}`,
+		wantErr: `json: cannot unmarshal JSON number into Go time.Time within "/T": invalid time "18446744073709551616": value out of range`,
+		wantIs:  strconv.ErrRange,
+		offset:  5,
+		pointer: "/T",
+	}, {
+		name:    "Unmarshal/DurationOverflow",
+		in:      new(map[string]time.Duration),
+		data:    `{"d":"9223372036854775808ns"}`,
+		wantErr: `json: cannot unmarshal JSON string into Go time.Duration within "/d": time: invalid duration "9223372036854775808ns"`,
+		offset:  5,
+		pointer: "/d",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			call := "Unmarshal"
+			if tt.marshal {
+				call = "Marshal"
+				_, err = Marshal(tt.in)
+			} else {
+				err = Unmarshal([]byte(tt.data), tt.in)
+			}
+			checkErrorPosition(t, call, err, tt.wantErr, tt.wantIs, tt.offset, tt.pointer)
+		})
+	}
+}
+
+func TestDurationBase10(t *testing.T) {
+	tests := []struct {
+		in     time.Duration
+		pow10  uint64
+		want   string
+		parsed string // alternate input that parses as in, if any
+	}{
+		{0, 1e0, "0", "-0"},
+		{0, 1e9, "0", "0.000"},
+		{1, 1e9, "0.000000001", ""},
+		{-1, 1e9, "-0.000000001", ""},
+		{1500 * time.Millisecond, 1e9, "1.5", "1.500000000"},
+		{time.Second, 1e3, "1000000", ""},
+		{time.Second, 1e6, "1000", "1000.0"},
+		{math.MaxInt64, 1e0, "9223372036854775807", ""},
+		{math.MaxInt64, 1e9, "9223372036.854775807", ""},
+		{math.MinInt64, 1e0, "-9223372036854775808", ""},
+		{math.MinInt64, 1e9, "-9223372036.854775808", ""},
+	}
+	for _, tt := range tests {
+		got := string(appendDurationBase10(nil, tt.in, tt.pow10))
+		if got != tt.want {
+			t.Errorf("appendDurationBase10(%d, %d) = %s, want %s", tt.in, tt.pow10, got, tt.want)
+		}
+		for _, s := range []string{tt.want, tt.parsed} {
+			if s == "" {
+				continue
+			}
+			d, err := parseDurationBase10([]byte(s), tt.pow10)
+			if d != tt.in || err != nil {
+				t.Errorf("parseDurationBase10(%s, %d) = (%d, %v), want (%d, nil)", s, tt.pow10, d, err, tt.in)
+			}
+		}
+	}
+
+	errTests := []struct {
+		in     string
+		pow10  uint64
+		wantIs error
+	}{
+		{"", 1e9, strconv.ErrSyntax},
+		{"-", 1e9, strconv.ErrSyntax},
+		{"1.", 1e9, strconv.ErrSyntax},
+		{".1", 1e9, strconv.ErrSyntax},
+		{"1.0000000001", 1e9, strconv.ErrSyntax},
+		{"1.5", 1e0, strconv.ErrSyntax},
+		{"1e3", 1e0, strconv.ErrSyntax},
+		{"+1", 1e0, strconv.ErrSyntax},
+		{"9223372036854775808", 1e0, strconv.ErrRange},
+		{"-9223372036854775809", 1e0, strconv.ErrRange},
+		{"9223372036.854775808", 1e9, strconv.ErrRange},
+		{"18446744073709551616", 1e0, strconv.ErrRange},
+		{"18446744073709551615", 1e3, strconv.ErrRange},
+	}
+	for _, tt := range errTests {
+		if _, err := parseDurationBase10([]byte(tt.in), tt.pow10); !errors.Is(err, tt.wantIs) {
+			t.Errorf("parseDurationBase10(%q, %d) error = %v, want %v", tt.in, tt.pow10, err, tt.wantIs)
+		}
+	}
+}
+
+func TestTimeUnix(t *testing.T) {
+	tests := []struct {
+		in    time.Time
+		pow10 uint64
+		want  string
+	}{
+		{timeEpoch, 1e0, "0"},
+		{timeEpoch, 1e9, "0"},
+		{time.Unix(1, 1), 1e0, "1.000000001"},
+		{time.Unix(1, 1), 1e3, "1000.000001"},
+		{time.Unix(1, 1), 1e6, "1000000.001"},
+		{time.Unix(1, 1), 1e9, "1000000001"},
+		{time.Unix(-1, 0), 1e0, "-1"},
+		{time.Unix(-1, 1), 1e0, "-0.999999999"},
+		{time.Unix(0, -1), 1e3, "-0.000001"},
+		{time.Unix(1<<40, 999_999_999), 1e0, "1099511627776.999999999"},
+	}
+	for _, tt := range tests {
+		got := string(appendTimeUnix(nil, tt.in, tt.pow10))
+		if got != tt.want {
+			t.Errorf("appendTimeUnix(%v, %d) = %s, want %s", tt.in, tt.pow10, got, tt.want)
+		}
+		tm, err := parseTimeUnix([]byte(tt.want), tt.pow10)
+		if !tm.Equal(tt.in) || err != nil {
+			t.Errorf("parseTimeUnix(%s, %d) = (%v, %v), want (%v, nil)", tt.want, tt.pow10, tm, err, tt.in)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+)
+
+const errorPrefix = "json: "
+
+// export exposes internal functionality of the "jsontext" package.
+var export = jsontext.Internal.Export(&internal.AllowInternalUse)
+
+// ErrUnknownName indicates that a JSON object member could not be
+// unmarshaled because the name is not known to the target Go struct.
+// This error is directly wrapped within a [SemanticError] when produced.
+//
+// The name of an unknown JSON object member can be extracted as:
+//
+//	err := ...
+//	var serr *json.SemanticError
+//	if errors.As(err, &serr) && serr.Err == json.ErrUnknownName {
+//		ptr := serr.JSONPointer // JSON pointer to unknown name
+//		name := ptr.LastToken() // unknown name itself
+//		...
+//	}
+//
+// This error is only returned if [RejectUnknownMembers] is true.
+var ErrUnknownName = errors.New("unknown object member name")
+
+// SkipFunc may be returned by [MarshalToFunc] and [UnmarshalFromFunc] functions.
+//
+// Any function that returns SkipFunc must not cause observable side effects
+// on the provided [jsontext.Encoder] or [jsontext.Decoder].
+// For example, it is permissible to call [jsontext.Decoder.PeekKind],
+// but not permissible to call [jsontext.Decoder.ReadToken] or
+// [jsontext.Encoder.WriteToken] since such methods mutate the state.
+var SkipFunc = errors.New("json: skip function")
+
+var (
+	errNonNilReference  = errors.New("value must be passed as a non-nil pointer reference")
+	errNilField         = internal.ErrNilField
+	errSkipMutation     = errors.New("must not read or write any tokens when skipping")
+	errNonStringValue   = errors.New("JSON value must be string type")
+	errNonFiniteNumber  = internal.ErrNonFiniteNumber
+	errRangeOverflow    = errors.New("value out of range")
+	errUnsupportedType  = internal.ErrUnsupportedType
+	errArrayUnderflow   = errors.New("too few array elements")
+	errArrayOverflow    = errors.New("too many array elements")
+	errMissingName      = errors.New("missing name in object")
+	errNonConcreteValue = errors.New("cannot derive concrete type for nil interface with finite type set")
+)
+
+// SemanticError describes an error determining the meaning
+// of JSON data as Go data or vice-versa.
+//
+// The contents of this error as produced by this package may change over time.
+type SemanticError struct {
+	requireKeyedLiterals
+	nonComparable
+
+	action string // either "marshal" or "unmarshal"
+
+	// ByteOffset indicates that an error occurred after this byte offset.
+	ByteOffset int64
+	// JSONPointer indicates that an error occurred within this JSON value
+	// as indicated using the JSON Pointer notation (see RFC 6901).
+	JSONPointer jsontext.Pointer
+
+	// JSONKind is the JSON kind that could not be handled.
+	JSONKind jsontext.Kind // may be zero if unknown
+	// JSONValue is the JSON number or string that could not be unmarshaled.
+	// It is not populated during marshaling.
+	JSONValue jsontext.Value // may be nil if irrelevant or unknown
+	// GoType is the Go type that could not be handled.
+	GoType reflect.Type // nil if unknown
+
+	// Err is the underlying error.
+	Err error // may be nil
+}
+
+// requireKeyedLiterals can be embedded in a struct to require keyed literals.
+type requireKeyedLiterals struct{}
+
+// nonComparable can be embedded in a struct to prevent comparability.
+type nonComparable [0]func()
+
+func (e *SemanticError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(errorPrefix)
+
+	// Format the JSON kind and Go type in the order of the data flow.
+	var jsonKind string
+	switch e.JSONKind {
+	case 'n':
+		jsonKind = "JSON null"
+	case 'f', 't':
+		jsonKind = "JSON boolean"
+	case '"':
+		jsonKind = "JSON string"
+	case '0':
+		jsonKind = "JSON number"
+	case '{', '}':
+		jsonKind = "JSON object"
+	case '[', ']':
+		jsonKind = "JSON array"
+	}
+	if len(e.JSONValue) > 0 && len(e.JSONValue) < 100 && jsonKind != "" {
+		jsonKind += " " + string(e.JSONValue)
+	}
+	var goType string
+	if e.GoType != nil {
+		goType = "Go " + e.GoType.String()
+	}
+	switch e.action {
+	case "marshal":
+		sb.WriteString("cannot marshal")
+		if goType != "" {
+			sb.WriteString(" " + goType)
+		}
+		if jsonKind != "" {
+			sb.WriteString(" into " + jsonKind)
+		}
+	case "unmarshal":
+		sb.WriteString("cannot unmarshal")
+		if jsonKind != "" {
+			sb.WriteString(" " + jsonKind)
+		}
+		if goType != "" {
+			sb.WriteString(" into " + goType)
+		}
+	default:
+		sb.WriteString("cannot handle")
+		if goType != "" {
+			sb.WriteString(" " + goType)
+		}
+	}
+
+	// Format the location of the error.
+	if e.JSONPointer != "" {
+		sb.WriteString(" within ")
+		sb.WriteString(strconv.Quote(jsonwire.TruncatePointer(string(e.JSONPointer), 100)))
+	}
+
+	// Format the underlying error.
+	switch err := e.Err; err {
+	case nil:
+	case ErrUnknownName:
+		// The unknown name is the last token of the pointer.
+		sb.WriteString(": ")
+		sb.WriteString(err.Error())
+		if name := e.JSONPointer.LastToken(); name != "" {
+			sb.WriteString(" ")
+			sb.WriteString(strconv.Quote(name))
+		}
+	default:
+		sb.WriteString(": ")
+		sb.WriteString(strings.TrimPrefix(err.Error(), errorPrefix))
+	}
+	return sb.String()
+}
+
+func (e *SemanticError) Unwrap() error {
+	return e.Err
+}
+
+// newMarshalErrorBefore wraps err in a SemanticError for the value
+// of type t that is about to be written by enc.
+func newMarshalErrorBefore(enc *jsontext.Encoder, t reflect.Type, err error) error {
+	return &SemanticError{action: "marshal", GoType: t, Err: err,
+		ByteOffset:  enc.OutputOffset(),
+		JSONPointer: nextPointer(enc.StackPointer(), enc.StackDepth(), enc.StackIndex),
+	}
+}
+
+// newUnmarshalErrorBefore wraps err in a SemanticError for the value
+// of type t that is about to be read by dec.
+func newUnmarshalErrorBefore(dec *jsontext.Decoder, t reflect.Type, err error) error {
+	return &SemanticError{action: "unmarshal", GoType: t, Err: err,
+		ByteOffset:  dec.InputOffset(),
+		JSONPointer: nextPointer(dec.StackPointer(), dec.StackDepth(), dec.StackIndex),
+		JSONKind:    dec.PeekKind(),
+	}
+}
+
+// newUnmarshalErrorBeforeWithSkipping is like newUnmarshalErrorBefore,
+// but also skips the next value to keep the decoder in a consistent state.
+func newUnmarshalErrorBeforeWithSkipping(dec *jsontext.Decoder, t reflect.Type, err error) error {
+	err = newUnmarshalErrorBefore(dec, t, err)
+	if err2 := dec.SkipValue(); err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// newUnmarshalErrorMismatch skips the next value and reports that
+// its JSON kind cannot be unmarshaled into a Go value of type t.
+func newUnmarshalErrorMismatch(dec *jsontext.Decoder, t reflect.Type) error {
+	var flags jsonwire.ValueFlags
+	if _, err := export.Decoder(dec).ReadValue(&flags); err != nil {
+		return err
+	}
+	return newUnmarshalErrorAfterWithValue(dec, t, nil)
+}
+
+// newUnmarshalErrorAfter wraps err in a SemanticError for the value
+// of type t that was just read by dec.
+func newUnmarshalErrorAfter(dec *jsontext.Decoder, t reflect.Type, err error) error {
+	xd := export.Decoder(dec)
+	return &SemanticError{action: "unmarshal", GoType: t, Err: err,
+		ByteOffset:  xd.PreviousOffsetStart(),
+		JSONPointer: dec.StackPointer(),
+		JSONKind:    jsontext.Value(xd.PreviousTokenOrValue()).Kind(),
+	}
+}
+
+// newUnmarshalErrorAfterWithValue is like newUnmarshalErrorAfter,
+// but also records the JSON string or number that was just read.
+func newUnmarshalErrorAfterWithValue(dec *jsontext.Decoder, t reflect.Type, err error) error {
+	serr := newUnmarshalErrorAfter(dec, t, err).(*SemanticError)
+	if serr.JSONKind == '"' || serr.JSONKind == '0' {
+		serr.JSONValue = jsontext.Value(export.Decoder(dec).PreviousTokenOrValue()).Clone()
+	}
+	return serr
+}
+
+// newSemanticErrorWithPosition annotates a SemanticError returned from
+// a user-provided method or function with the position of the value.
+// Any other error is wrapped in a SemanticError.
+func newSemanticErrorWithPosition(c any, t reflect.Type, prevDepth int, prevLength int64, err error) error {
+	serr, _ := err.(*SemanticError)
+	if serr == nil {
+		serr = &SemanticError{Err: err}
+	}
+	var currDepth int
+	var currLength int64
+	var coderState interface {
+		StackDepth() int
+		StackIndex(int) (jsontext.Kind, int64)
+		StackPointer() jsontext.Pointer
+	}
+	var offset int64
+	switch c := c.(type) {
+	case *jsontext.Encoder:
+		serr.action = "marshal"
+		currDepth, currLength = export.Encoder(c).Tokens.Depth(), export.Encoder(c).Tokens.Length()
+		coderState, offset = c, c.OutputOffset()
+	case *jsontext.Decoder:
+		serr.action = "unmarshal"
+		currDepth, currLength = export.Decoder(c).Tokens.Depth(), export.Decoder(c).Tokens.Length()
+		coderState, offset = c, c.InputOffset()
+	}
+	isRoot := prevDepth == currDepth && prevLength == currLength
+	if serr.GoType == nil && isRoot {
+		serr.GoType = t
+	}
+	if serr.ByteOffset == 0 {
+		serr.ByteOffset = offset
+	}
+	if serr.JSONPointer == "" {
+		serr.JSONPointer = coderState.StackPointer()
+		if isRoot {
+			// Nothing was processed, so refer to the value about to be processed.
+			serr.JSONPointer = nextPointer(serr.JSONPointer, coderState.StackDepth(), coderState.StackIndex)
+		}
+	}
+	return serr
+}
+
+// nextPointer returns a JSON pointer to the next value to be processed,
+// given the pointer ptr to the most recently processed value.
+func nextPointer(ptr jsontext.Pointer, depth int, index func(int) (jsontext.Kind, int64)) jsontext.Pointer {
+	k, n := index(depth)
+	switch {
+	case k == '[':
+		// The pointer refers to the previous element, if any.
+		if n > 0 {
+			ptr = ptr.Parent()
+		}
+		return ptr.AppendToken(strconv.FormatInt(n, 10))
+	default:
+		// Within an object, the name of the next value was already processed.
+		return ptr
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+)
+
+// By default, JSON object names are matched case-sensitively and
+// duplicate names are rejected. Both can be relaxed per call.
+func Example_caseSensitivity() {
+	type Config struct {
+		Name string
+	}
+	in := `{"name":"gopher"}`
+
+	var c1 Config
+	if err := json.Unmarshal([]byte(in), &c1); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%q\n", c1.Name)
+
+	var c2 Config
+	if err := json.Unmarshal([]byte(in), &c2, json.MatchCaseInsensitiveNames(true)); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%q\n", c2.Name)
+
+	err := json.Unmarshal([]byte(`{"Name":"a","Name":"b"}`), new(Config))
+	fmt.Println(errors.Is(err, jsontext.ErrDuplicateName))
+
+	// Output:
+	// ""
+	// "gopher"
+	// true
+}
+
+// The representation of some types can be changed for a single call.
+func Example_formatOptions() {
+	v := struct {
+		Timeout time.Duration
+		Key     []byte
+	}{time.Minute + 500*time.Millisecond, []byte{0xde, 0xad}}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+
+	b, err = json.Marshal(v, json.FormatDuration("sec"), json.FormatBytes("hex"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+
+	// Output:
+	// {"Timeout":"1m0.5s","Key":"3q0="}
+	// {"Timeout":60.5,"Key":"dead"}
+}
+
+// The caller can override the representation of any type,
+// even types that it does not own.
+func ExampleWithMarshalers() {
+	type Point struct{ X, Y int }
+	marshalers := json.MarshalToFunc(func(enc *jsontext.Encoder, p Point) error {
+		return enc.WriteToken(jsontext.String(fmt.Sprintf("%d,%d", p.X, p.Y)))
+	})
+	b, err := json.Marshal([]Point{{1, 2}, {3, 4}}, json.WithMarshalers(marshalers))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+
+	// Output:
+	// ["1,2","3,4"]
+}
+
+// Elements of a large JSON array can be processed one at a time
+// without holding the entire array in memory.
+func ExampleUnmarshalDecode() {
+	const in = `[{"Name":"alice","Age":30},{"Name":"bob","Age":25}]`
+	dec := jsontext.NewDecoder(strings.NewReader(in))
+	if _, err := dec.ReadToken(); err != nil { // read '['
+		log.Fatal(err)
+	}
+	for dec.PeekKind() != ']' {
+		var person struct {
+			Name string
+			Age  int
+		}
+		if err := json.UnmarshalDecode(dec, &person); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s is %d\n", person.Name, person.Age)
+	}
+	if _, err := dec.ReadToken(); err != nil { // read ']'
+		log.Fatal(err)
+	}
+
+	// Output:
+	// alice is 30
+	// bob is 25
+}
@@ -0,0 +1,538 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonwire"
+)
+
+var errNoExportedFields = errors.New("Go struct has no exported fields")
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeFor[isZeroer]()
+
+type structFields struct {
+	flattened       []structField // listed in depth-first ordering
+	byActualName    map[string]*structField
+	byFoldedName    map[string][]*structField
+	byFoldedDelims  map[string][]*structField // folded without ignoring delimiters
+	inlinedFallback *structField
+
+	// err is an error describing a malformed struct type, which is
+	// reported lazily so that it can be ignored with v1 semantics.
+	err *SemanticError
+}
+
+// lookupByFoldedName looks up name by a case-insensitive match
+// that also ignores underscores and dashes unless keepDelims is true.
+func (fs *structFields) lookupByFoldedName(name []byte, keepDelims bool) []*structField {
+	if keepDelims {
+		return fs.byFoldedDelims[string(foldNameWithDelims(name))]
+	}
+	return fs.byFoldedName[string(foldName(name))]
+}
+
+type structField struct {
+	id      int   // unique numeric ID in depth-first ordering
+	index   []int // index into a struct according to reflect.Type.FieldByIndex
+	typ     reflect.Type
+	fncs    *arshaler
+	isZero  func(addressableValue) bool
+	isEmpty func(addressableValue) bool
+	fieldOptions
+}
+
+// makeStructFields computes the JSON object members of the struct type root,
+// following the Go rules for embedding to resolve conflicting names.
+func makeStructFields(root reflect.Type) (fs structFields) {
+	// Setup a queue for a breath-first search.
+	var queueIndex int
+	type queueEntry struct {
+		typ   reflect.Type
+		index []int
+	}
+	queue := []queueEntry{{root, nil}}
+	seen := map[reflect.Type]int{root: 0} // depth at which each struct type was first inlined
+	reportError := func(t reflect.Type, err error) {
+		if fs.err == nil {
+			fs.err = &SemanticError{GoType: t, Err: err}
+		}
+	}
+
+	// Perform a breadth-first search over all reachable fields.
+	// This ensures that len(f.index) will be monotonically increasing.
+	var allFields, inlinedFallbacks []structField
+	var hasAnyJSONTag bool
+	for queueIndex < len(queue) {
+		qe := queue[queueIndex]
+		queueIndex++
+
+		t := qe.typ
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			_, hasTag := sf.Tag.Lookup("json")
+			hasAnyJSONTag = hasAnyJSONTag || hasTag
+			options, ignored, err := parseFieldOptions(sf)
+			if err != nil {
+				reportError(t, err)
+			}
+			if ignored {
+				continue
+			}
+			f := structField{
+				index:        append(slices.Clip(qe.index), i),
+				typ:          sf.Type,
+				fieldOptions: options,
+			}
+
+			// Embedded structs without an explicit name are implicitly inlined.
+			tf := indirectType(f.typ)
+			if sf.Anonymous && !f.hasName && tf.Kind() == reflect.Struct {
+				f.inline = true
+			}
+			if !sf.IsExported() && !(sf.Anonymous && tf.Kind() == reflect.Struct) {
+				continue // unexported fields are only visited if they are embedded structs
+			}
+
+			if f.inline || f.unknown {
+				// Handle an inlined field that serializes to/from
+				// zero or more JSON object members.
+				switch {
+				case tf.Kind() == reflect.Struct && !f.unknown && tf != jsontextValueType:
+					// A struct type inlined multiple times at the same depth
+					// is visited each time so that the conflicting fields
+					// annihilate each other, but deeper occurrences
+					// (including cycles) are dominated and thus skipped.
+					if depth, ok := seen[tf]; ok && depth < len(f.index) {
+						continue
+					}
+					seen[tf] = len(f.index)
+					queue = append(queue, queueEntry{tf, f.index})
+				case isInlinedFallbackType(f.typ):
+					f.fncs = lookupArshaler(f.typ)
+					inlinedFallbacks = append(inlinedFallbacks, f)
+				default:
+					reportError(t, fmt.Errorf("inlined Go struct field %s of type %s must be a Go struct, Go map of string key, or jsontext.Value", sf.Name, sf.Type))
+				}
+				continue
+			}
+
+			// Handle a normal field that serializes to/from a JSON object member.
+			allFields = append(allFields, f)
+		}
+	}
+
+	// Resolve conflicting names by keeping only the dominant field,
+	// which is the shallowest field, preferring a field with an explicit
+	// JSON name over one without. If no single field dominates,
+	// then all fields of that name are ignored.
+	slices.SortStableFunc(allFields, func(x, y structField) int {
+		switch {
+		case x.name != y.name:
+			return strings.Compare(x.name, y.name)
+		case len(x.index) != len(y.index):
+			return len(x.index) - len(y.index)
+		case x.hasName && !y.hasName:
+			return -1
+		case !x.hasName && y.hasName:
+			return +1
+		default:
+			return 0
+		}
+	})
+	var fields []structField
+	for len(allFields) > 0 {
+		n := 1
+		for n < len(allFields) && allFields[n].name == allFields[0].name {
+			n++
+		}
+		if dominant, ok := dominantField(allFields[:n]); ok {
+			fields = append(fields, dominant)
+		}
+		allFields = allFields[n:]
+	}
+	slices.SortFunc(fields, func(x, y structField) int {
+		return slices.Compare(x.index, y.index)
+	})
+
+	// New users are occasionally surprised that unexported fields are ignored.
+	// To reduce friction, reject a non-empty struct that has no JSON
+	// representable fields and no `json` tags suggesting that this is intended.
+	// For example, errors returned by errors.New would otherwise be
+	// silently serialized as an empty JSON object.
+	if root.NumField() > 0 && len(fields) == 0 && len(inlinedFallbacks) == 0 && !hasAnyJSONTag {
+		reportError(root, errNoExportedFields)
+	}
+
+	// Determine the field that holds unknown members, if any.
+	// There may only be one such field at the shallowest depth.
+	if len(inlinedFallbacks) > 0 {
+		if len(inlinedFallbacks) > 1 && len(inlinedFallbacks[0].index) == len(inlinedFallbacks[1].index) {
+			reportError(root, fmt.Errorf("inlined Go struct fields %s and %s cannot both be the fallback for unknown members",
+				root.FieldByIndex(inlinedFallbacks[0].index).Name, root.FieldByIndex(inlinedFallbacks[1].index).Name))
+		} else {
+			fs.inlinedFallback = &inlinedFallbacks[0]
+		}
+	}
+
+	// Compute the lookup tables and per-field helpers.
+	fs.flattened = fields
+	fs.byActualName = make(map[string]*structField, len(fields))
+	fs.byFoldedName = make(map[string][]*structField, len(fields))
+	fs.byFoldedDelims = make(map[string][]*structField, len(fields))
+	for i := range fs.flattened {
+		f := &fs.flattened[i]
+		f.id = i
+		f.isZero = makeIsZero(f.typ)
+		f.isEmpty = makeIsLegacyEmpty(f.typ)
+		fs.byActualName[f.name] = f
+		foldedName := string(foldName([]byte(f.name)))
+		fs.byFoldedName[foldedName] = append(fs.byFoldedName[foldedName], f)
+		foldedName = string(foldNameWithDelims([]byte(f.name)))
+		fs.byFoldedDelims[foldedName] = append(fs.byFoldedDelims[foldedName], f)
+	}
+	return fs
+}
+
+// dominantField reports the single dominant field among fields,
+// which all have the same name and are sorted in order of precedence.
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].hasName == fields[1].hasName {
+		return structField{}, false
+	}
+	return fields[0], true
+}
+
+// indirectType returns the element type of t if it is an unnamed pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer && t.Name() == "" {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isInlinedFallbackType reports whether t can hold unknown object members,
+// which is the case for jsontext.Value and maps with a string key.
+func isInlinedFallbackType(t reflect.Type) bool {
+	t = indirectType(t)
+	return t == jsontextValueType || (t.Kind() == reflect.Map && t.Key().Kind() == reflect.String)
+}
+
+// makeIsZero returns a function that reports whether a value of type t
+// is zero, preferring to call the IsZero method if t declares one.
+func makeIsZero(t reflect.Type) func(addressableValue) bool {
+	switch {
+	case t.Kind() == reflect.Interface && t.Implements(isZeroerType):
+		return func(va addressableValue) bool {
+			// Avoid panics calling IsZero on a nil interface or
+			// non-nil interface with nil pointer.
+			return va.IsNil() ||
+				(va.Elem().Kind() == reflect.Pointer && va.Elem().IsNil()) ||
+				va.Interface().(isZeroer).IsZero()
+		}
+	case t.Kind() == reflect.Pointer && t.Implements(isZeroerType):
+		return func(va addressableValue) bool {
+			// Avoid panics calling IsZero on nil pointer.
+			return va.IsNil() || va.Interface().(isZeroer).IsZero()
+		}
+	case t.Implements(isZeroerType):
+		return func(va addressableValue) bool { return va.Interface().(isZeroer).IsZero() }
+	case reflect.PointerTo(t).Implements(isZeroerType):
+		return func(va addressableValue) bool { return va.Addr().Interface().(isZeroer).IsZero() }
+	default:
+		return func(va addressableValue) bool { return va.IsZero() }
+	}
+}
+
+// makeIsLegacyEmpty returns a function that reports whether a value
+// of type t is empty according to the v1 definition of omitempty.
+func makeIsLegacyEmpty(t reflect.Type) func(addressableValue) bool {
+	switch t.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return func(va addressableValue) bool { return va.Len() == 0 }
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return func(va addressableValue) bool { return va.IsZero() }
+	default:
+		return func(addressableValue) bool { return false }
+	}
+}
+
+type fieldOptions struct {
+	name       string
+	quotedName string // quoted name per RFC 8785, section 3.2.2.2.
+	hasName    bool
+	casing     int8 // either 0, nocase, or strictcase
+	inline     bool
+	unknown    bool
+	omitzero   bool
+	omitempty  bool
+	string     bool
+	required   bool // only enforced with v1 RejectMissingRequiredFields
+	format     string
+}
+
+const (
+	nocase     = 1
+	strictcase = 2
+)
+
+// parseFieldOptions parses the `json` tag in a Go struct field as
+// a structured set of options configuring parameters such as
+// the JSON member name and other features.
+func parseFieldOptions(sf reflect.StructField) (out fieldOptions, ignored bool, err error) {
+	tag, hasTag := sf.Tag.Lookup("json")
+
+	// Check whether this field is explicitly ignored.
+	if tag == "-" {
+		return fieldOptions{}, true, nil
+	}
+
+	// Check whether this field is unexported and not embedded,
+	// which Go reflection cannot mutate for the sake of serialization.
+	//
+	// An embedded field of an unexported type is still capable of
+	// forwarding exported fields, which may be JSON serialized.
+	// This technically operates on the edge of what is permissible by
+	// the Go language, but the most recent decision is to permit this.
+	//
+	// See https://go.dev/issue/24153 and https://go.dev/issue/32772.
+	if !sf.IsExported() && !sf.Anonymous {
+		// Tag options specified on an unexported field suggests user error.
+		if hasTag {
+			err = firstError(err, fmt.Errorf("unexported Go struct field %s cannot have non-ignored `json:%q` tag", sf.Name, tag))
+		}
+		return fieldOptions{}, true, err
+	}
+
+	// Determine the JSON member name for this Go field. A user-specified name
+	// may be provided as either an identifier or a single-quoted string.
+	// The single-quoted string allows arbitrary characters in the name.
+	// See https://go.dev/issue/2718 and https://go.dev/issue/3546.
+	out.name = sf.Name // always starts with an uppercase character
+	if len(tag) > 0 && !strings.HasPrefix(tag, ",") {
+		// For better compatibility with v1, accept almost any unescaped name.
+		n := len(tag) - len(strings.TrimLeftFunc(tag, func(r rune) bool {
+			return !strings.ContainsRune(",\\'\"`", r) // reserve comma, backslash, and quotes
+		}))
+		name := tag[:n]
+
+		// If the next character is not a comma, then the name is either
+		// malformed (if n > 0) or a single-quoted name.
+		// In either case, call consumeTagOption to handle it further.
+		var err2 error
+		if !strings.HasPrefix(tag[n:], ",") && len(name) != len(tag) {
+			name, n, err2 = consumeTagOption(tag)
+			if err2 != nil {
+				err = firstError(err, fmt.Errorf("Go struct field %s has malformed `json` tag: %v", sf.Name, err2))
+			}
+		}
+		if !utf8.ValidString(name) {
+			err = firstError(err, fmt.Errorf("Go struct field %s has JSON object name %q with invalid UTF-8", sf.Name, name))
+			name = string([]rune(name)) // replace invalid UTF-8 with utf8.RuneError
+		}
+		if err2 == nil {
+			out.hasName = true
+			out.name = name
+		}
+		tag = tag[n:]
+	}
+	b, _ := jsonwire.AppendQuote(nil, out.name, &jsonflags.Flags{})
+	out.quotedName = string(b)
+
+	// Handle any additional tag options (if any).
+	var wasFormat bool
+	seenOpts := make(map[string]bool)
+	for len(tag) > 0 {
+		// Consume comma delimiter.
+		if tag[0] != ',' {
+			err = firstError(err, fmt.Errorf("Go struct field %s has malformed `json` tag: invalid character %q before next option (expecting ',')", sf.Name, tag[0]))
+		} else {
+			tag = tag[len(","):]
+			if len(tag) == 0 {
+				err = firstError(err, fmt.Errorf("Go struct field %s has malformed `json` tag: invalid trailing ',' character", sf.Name))
+				break
+			}
+		}
+
+		// Consume and process the tag option.
+		opt, n, err2 := consumeTagOption(tag)
+		if err2 != nil {
+			err = firstError(err, fmt.Errorf("Go struct field %s has malformed `json` tag: %v", sf.Name, err2))
+		}
+		rawOpt := tag[:n]
+		tag = tag[n:]
+		switch {
+		case wasFormat:
+			err = firstError(err, fmt.Errorf("Go struct field %s has `format` tag option that was not specified last", sf.Name))
+		case strings.HasPrefix(rawOpt, "'") && strings.TrimFunc(opt, isLetterOrDigit) == "":
+			err = firstError(err, fmt.Errorf("Go struct field %s has unnecessarily quoted appearance of `%s` tag option; specify `%s` instead", sf.Name, rawOpt, opt))
+		}
+		switch opt {
+		case "case":
+			if !strings.HasPrefix(tag, ":") {
+				err = firstError(err, fmt.Errorf("Go struct field %s is missing value for `case` tag option; specify `case:ignore` or `case:strict` instead", sf.Name))
+				break
+			}
+			tag = tag[len(":"):]
+			opt, n, err2 := consumeTagOption(tag)
+			if err2 != nil {
+				err = firstError(err, fmt.Errorf("Go struct field %s has malformed value for `case` tag option: %v", sf.Name, err2))
+				break
+			}
+			tag = tag[n:]
+			switch opt {
+			case "ignore":
+				out.casing |= nocase
+			case "strict":
+				out.casing |= strictcase
+			default:
+				err = firstError(err, fmt.Errorf("Go struct field %s has unknown `case:%s` tag value", sf.Name, opt))
+			}
+		case "inline":
+			out.inline = true
+		case "unknown":
+			out.unknown = true
+		case "omitzero":
+			out.omitzero = true
+		case "omitempty":
+			out.omitempty = true
+		case "string":
+			out.string = true
+		case "required":
+			out.required = true
+		case "format":
+			if !strings.HasPrefix(tag, ":") {
+				err = firstError(err, fmt.Errorf("Go struct field %s is missing value for `format` tag option", sf.Name))
+				break
+			}
+			tag = tag[len(":"):]
+			opt, n, err2 := consumeTagOption(tag)
+			if err2 != nil {
+				err = firstError(err, fmt.Errorf("Go struct field %s has malformed value for `format` tag option: %v", sf.Name, err2))
+				break
+			}
+			tag = tag[n:]
+			out.format = opt
+			wasFormat = true
+		default:
+			// Reject keys that resemble one of the supported options.
+			// This catches invalid mutants such as "omitEmpty" or "omit_empty".
+			normOpt := strings.ReplaceAll(strings.ToLower(opt), "_", "")
+			switch normOpt {
+			case "case", "inline", "unknown", "omitzero", "omitempty", "string", "format":
+				err = firstError(err, fmt.Errorf("Go struct field %s has invalid appearance of `%s` tag option; specify `%s` instead", sf.Name, opt, normOpt))
+			}
+
+			// NOTE: Everything else is ignored. This does not mean it is
+			// forward compatible to insert arbitrary tag options since
+			// a future version of this package may understand that tag.
+		}
+
+		// Reject duplicates.
+		switch {
+		case out.casing == nocase|strictcase:
+			err = firstError(err, fmt.Errorf("Go struct field %s cannot have both `case:ignore` and `case:strict` tag options", sf.Name))
+		case seenOpts[opt]:
+			err = firstError(err, fmt.Errorf("Go struct field %s has duplicate appearance of `%s` tag option", sf.Name, rawOpt))
+		}
+		seenOpts[opt] = true
+	}
+	return out, false, err
+}
+
+// consumeTagOption consumes the next option,
+// which is either a Go identifier or a single-quoted string.
+// If the next option is invalid, it returns all of in until the next comma,
+// and reports an error.
+func consumeTagOption(in string) (string, int, error) {
+	// For legacy compatibility with v1, assume options are comma-separated.
+	i := strings.IndexByte(in, ',')
+	if i < 0 {
+		i = len(in)
+	}
+
+	switch r, _ := utf8.DecodeRuneInString(in); {
+	// Option as a Go identifier.
+	case isLetter(r):
+		n := len(in) - len(strings.TrimLeftFunc(in, isLetterOrDigit))
+		return in[:n], n, nil
+	// Option as a single-quoted string.
+	case r == '\'':
+		// The grammar is nearly identical to a double-quoted Go string literal,
+		// but uses single quotes as the terminators. The reason for a custom
+		// grammar is because both backtick and double quotes cannot be used
+		// verbatim in a struct tag.
+		//
+		// Convert a single-quoted string to a double-quote string and rely on
+		// strconv.Unquote to handle the rest.
+		var inEscape bool
+		b := []byte{'"'}
+		n := len(`'`)
+		for len(in) > n {
+			r, rn := utf8.DecodeRuneInString(in[n:])
+			switch {
+			case inEscape:
+				if r == '\'' {
+					b = b[:len(b)-1] // remove escape character: `\'` => `'`
+				}
+				inEscape = false
+			case r == '\\':
+				inEscape = true
+			case r == '"':
+				b = append(b, '\\') // insert escape character: `"` => `\"`
+			case r == '\'':
+				b = append(b, '"')
+				n += len(`'`)
+				out, err := strconv.Unquote(string(b))
+				if err != nil {
+					return in[:i], i, fmt.Errorf("invalid single-quoted string: %s", in[:n])
+				}
+				return out, n, nil
+			}
+			b = append(b, in[n:][:rn]...)
+			n += rn
+		}
+		if n > 10 {
+			n = 10 // limit the amount of context printed in the error
+		}
+		return in[:i], i, fmt.Errorf("single-quoted string not terminated: %s...", in[:n])
+	case len(in) == 0:
+		return in[:i], i, errors.New("missing value")
+	default:
+		return in[:i], i, fmt.Errorf("invalid character %q at start of option (expecting Unicode letter or single quote)", r)
+	}
+}
+
+func isLetter(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isLetterOrDigit(r rune) bool {
+	return isLetter(r) || unicode.IsDigit(r)
+}
+
+func firstError(err1, err2 error) error {
+	if err1 == nil {
+		return err2
+	}
+	return err1
+}
@@ -0,0 +1,511 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/json/jsontext"
+)
+
+type (
+	fieldsEmbedded struct {
+		A, B int
+	}
+	fieldsEmbeddedTagged struct {
+		X int `json:"A"`
+	}
+	fieldsConflict struct {
+		fieldsEmbedded
+		fieldsEmbeddedTagged
+	}
+	fieldsAnnihilate struct {
+		fieldsEmbedded
+		Other fieldsEmbedded `json:",inline"`
+	}
+	fieldsShallow struct {
+		fieldsEmbedded
+		B string
+	}
+	fieldsUnexportedEmbedded struct {
+		fieldsEmbedded
+		unexported int
+	}
+	fieldsPointerEmbedded struct {
+		*fieldsEmbedded
+		C int
+	}
+	fieldsCycle struct {
+		*fieldsCycle
+		D int
+	}
+	fieldsNamedEmbedded struct {
+		fieldsEmbedded `json:"E"`
+	}
+	fieldsFallbacks struct {
+		A int
+		X map[string]any `json:",unknown"`
+		Y struct {
+			Z jsontext.Value `json:",unknown"`
+		} `json:",inline"`
+	}
+	fieldsNoExported struct {
+		a, b int
+	}
+	fieldsNoExportedTagged struct {
+		A int `json:"-"`
+		b int
+	}
+)
+
+func TestMakeStructFields(t *testing.T) {
+	type field struct {
+		name  string
+		index []int
+	}
+	tests := []struct {
+		name         string
+		in           any
+		want         []field
+		wantFallback []int
+		wantErr      string
+	}{{
+		name: "Empty",
+		in:   struct{}{},
+	}, {
+		name: "Basic",
+		in: struct {
+			A int
+			B int `json:"b"`
+			C int `json:"-"`
+			D int `json:"'-'"`
+		}{},
+		want: []field{{"A", []int{0}}, {"b", []int{1}}, {"-", []int{3}}},
+	}, {
+		name: "Embedded",
+		in: struct {
+			fieldsEmbedded
+			C int
+		}{},
+		want: []field{{"A", []int{0, 0}}, {"B", []int{0, 1}}, {"C", []int{1}}},
+	}, {
+		name: "Embedded/Pointer",
+		in:   fieldsPointerEmbedded{},
+		want: []field{{"A", []int{0, 0}}, {"B", []int{0, 1}}, {"C", []int{1}}},
+	}, {
+		name: "Embedded/Named",
+		in:   fieldsNamedEmbedded{},
+		want: []field{{"E", []int{0}}},
+	}, {
+		name: "Embedded/UnexportedType",
+		in:   fieldsUnexportedEmbedded{},
+		want: []field{{"A", []int{0, 0}}, {"B", []int{0, 1}}},
+	}, {
+		name: "Embedded/Cycle",
+		in:   fieldsCycle{},
+		want: []field{{"D", []int{1}}},
+	}, {
+		name: "Dominance/Shallowest",
+		in:   fieldsShallow{},
+		want: []field{{"A", []int{0, 0}}, {"B", []int{1}}},
+	}, {
+		name: "Dominance/Tagged",
+		in:   fieldsConflict{},
+		want: []field{{"B", []int{0, 1}}, {"A", []int{1, 0}}},
+	}, {
+		name: "Dominance/Annihilate",
+		in:   fieldsAnnihilate{},
+	}, {
+		name: "Inline/Map",
+		in: struct {
+			A int
+			M map[string]int `json:",inline"`
+		}{},
+		want:         []field{{"A", []int{0}}},
+		wantFallback: []int{1},
+	}, {
+		name:         "Inline/ShallowestFallback",
+		in:           fieldsFallbacks{},
+		want:         []field{{"A", []int{0}}},
+		wantFallback: []int{1},
+	}, {
+		name: "Inline/MultipleFallbacks",
+		in: struct {
+			X map[string]any `json:",unknown"`
+			Y jsontext.Value `json:",unknown"`
+		}{},
+		wantErr: "inlined Go struct fields X and Y cannot both be the fallback for unknown members",
+	}, {
+		name: "Inline/InvalidType",
+		in: struct {
+			A int `json:",inline"`
+		}{},
+		wantErr: "inlined Go struct field A of type int must be a Go struct, Go map of string key, or jsontext.Value",
+	}, {
+		name: "Inline/InvalidMapKey",
+		in: struct {
+			M map[int]any `json:",inline"`
+		}{},
+		wantErr: "inlined Go struct field M of type map[int]interface {} must be a Go struct, Go map of string key, or jsontext.Value",
+	}, {
+		name:    "NoExportedFields",
+		in:      fieldsNoExported{},
+		wantErr: "Go struct has no exported fields",
+	}, {
+		name: "NoExportedFields/Tagged",
+		in:   fieldsNoExportedTagged{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := makeStructFields(reflect.TypeOf(tt.in))
+			var got []field
+			for _, f := range fs.flattened {
+				got = append(got, field{f.name, f.index})
+			}
+			if tt.wantErr == "" && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields:\n\tgot:  %v\n\twant: %v", got, tt.want)
+			}
+			var gotFallback []int
+			if fs.inlinedFallback != nil {
+				gotFallback = fs.inlinedFallback.index
+			}
+			if !reflect.DeepEqual(gotFallback, tt.wantFallback) {
+				t.Errorf("inlined fallback = %v, want %v", gotFallback, tt.wantFallback)
+			}
+			var gotErr string
+			if fs.err != nil {
+				gotErr = fs.err.Err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("error:\n\tgot:  %s\n\twant: %s", gotErr, tt.wantErr)
+			}
+			for i, f := range fs.flattened {
+				if f.id != i {
+					t.Errorf("field %s id = %d, want %d", f.name, f.id, i)
+				}
+			}
+		})
+	}
+}
+
+func TestLookupByFoldedName(t *testing.T) {
+	fs := makeStructFields(reflect.TypeFor[struct {
+		FooBar  int
+		Foo_Bar int
+		FOOBAR  int `json:"foo-bar"`
+		Other   int
+	}]())
+	tests := []struct {
+		in         string
+		keepDelims bool
+		want       []string
+	}{
+		{"foobar", false, []string{"FooBar", "Foo_Bar", "foo-bar"}},
+		{"F_O_O_B_A_R", false, []string{"FooBar", "Foo_Bar", "foo-bar"}},
+		{"foobar", true, []string{"FooBar"}},
+		{"FOO_BAR", true, []string{"Foo_Bar"}},
+		{"FOO-bar", true, []string{"foo-bar"}},
+		{"other", true, []string{"Other"}},
+		{"missing", false, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, f := range fs.lookupByFoldedName([]byte(tt.in), tt.keepDelims) {
+			got = append(got, f.name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lookupByFoldedName(%q, %v) = %q, want %q", tt.in, tt.keepDelims, got, tt.want)
+		}
+	}
+}
+
+func TestParseFieldOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          any // struct with a single field named V, or the reflect.StructField itself
+		want        fieldOptions
+		wantIgnored bool
+		wantErr     string
+	}{{
+		name: "NoTag",
+		in:   struct{ V int }{},
+		want: fieldOptions{name: "V", quotedName: `"V"`},
+	}, {
+		name: "Ignored",
+		in: struct {
+			V int `json:"-"`
+		}{},
+		wantIgnored: true,
+	}, {
+		name: "Dash",
+		in: struct {
+			V int `json:"'-'"`
+		}{},
+		want: fieldOptions{hasName: true, name: "-", quotedName: `"-"`},
+	}, {
+		name: "Dash/TrailingComma",
+		in: struct {
+			V int `json:"-,"`
+		}{},
+		want:    fieldOptions{hasName: true, name: "-", quotedName: `"-"`},
+		wantErr: "Go struct field V has malformed `json` tag: invalid trailing ',' character",
+	}, {
+		name: "Name",
+		in: struct {
+			V int `json:"name"`
+		}{},
+		want: fieldOptions{hasName: true, name: "name", quotedName: `"name"`},
+	}, {
+		name: "Name/Punctuation",
+		in: struct {
+			V int `json:"a:b;c+d"`
+		}{},
+		want: fieldOptions{hasName: true, name: "a:b;c+d", quotedName: `"a:b;c+d"`},
+	}, {
+		name: "Name/SingleQuoted",
+		in: struct {
+			V int `json:"'a,b\\'c\"d'"`
+		}{},
+		want: fieldOptions{hasName: true, name: `a,b'c"d`, quotedName: `"a,b'c\"d"`},
+	}, {
+		name: "Name/SingleQuotedEscapes",
+		in: struct {
+			V int `json:"'\\u00e9\\n'"`
+		}{},
+		want: fieldOptions{hasName: true, name: "é\n", quotedName: `"é\n"`},
+	}, {
+		name: "Name/Empty",
+		in: struct {
+			V int `json:"''"`
+		}{},
+		want: fieldOptions{hasName: true, name: "", quotedName: `""`},
+	}, {
+		name: "Options",
+		in: struct {
+			V int `json:",omitzero,omitempty,string,required,inline,unknown,case:ignore"`
+		}{},
+		want: fieldOptions{name: "V", quotedName: `"V"`, omitzero: true, omitempty: true, string: true, required: true, inline: true, unknown: true, casing: nocase},
+	}, {
+		name: "Options/CaseStrict",
+		in: struct {
+			V int `json:"v,case:strict"`
+		}{},
+		want: fieldOptions{hasName: true, name: "v", quotedName: `"v"`, casing: strictcase},
+	}, {
+		name: "Options/Format",
+		in: struct {
+			V int `json:",format:'2006-01-02'"`
+		}{},
+		want: fieldOptions{name: "V", quotedName: `"V"`, format: "2006-01-02"},
+	}, {
+		name: "Options/Unknown",
+		in: struct {
+			V int `json:",someOption"`
+		}{},
+		want: fieldOptions{name: "V", quotedName: `"V"`},
+	}, {
+		name:        "Unexported",
+		in:          struct{ v int }{},
+		wantIgnored: true,
+	}, {
+		name:        "Unexported/Tagged",
+		in:          reflect.StructField{Name: "v", PkgPath: "json", Type: reflect.TypeFor[int](), Tag: `json:",omitzero"`},
+		wantIgnored: true,
+		wantErr:     "unexported Go struct field v cannot have non-ignored `json:\",omitzero\"` tag",
+	}, {
+		name: "Error/NameInvalidUTF8",
+		in: struct {
+			V int `json:"'\\xff'"`
+		}{},
+		want:    fieldOptions{hasName: true, name: "\ufffd", quotedName: "\"\ufffd\""},
+		wantErr: "Go struct field V has JSON object name \"\\xff\" with invalid UTF-8",
+	}, {
+		name: "Error/NameUnterminated",
+		in: struct {
+			V int `json:"'abcdefghijk"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has malformed `json` tag: single-quoted string not terminated: 'abcdefghi...",
+	}, {
+		name: "Error/NameInvalidEscape",
+		in: struct {
+			V int `json:"'\\q'"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has malformed `json` tag: invalid single-quoted string: '\\q'",
+	}, {
+		name: "Error/NameInvalidCharacter",
+		in: struct {
+			V int `json:"a\\b"`
+		}{},
+		want:    fieldOptions{hasName: true, name: "a", quotedName: `"a"`},
+		wantErr: "Go struct field V has malformed `json` tag: invalid character '\\\\' before next option (expecting ',')",
+	}, {
+		name: "Error/TrailingComma",
+		in: struct {
+			V int `json:"v,omitzero,"`
+		}{},
+		want:    fieldOptions{hasName: true, name: "v", quotedName: `"v"`, omitzero: true},
+		wantErr: "Go struct field V has malformed `json` tag: invalid trailing ',' character",
+	}, {
+		name: "Error/MissingComma",
+		in: struct {
+			V int `json:"'v'omitzero"`
+		}{},
+		want:    fieldOptions{hasName: true, name: "v", quotedName: `"v"`, omitzero: true},
+		wantErr: "Go struct field V has malformed `json` tag: invalid character 'o' before next option (expecting ',')",
+	}, {
+		name: "Error/EmptyOption",
+		in: struct {
+			V int `json:",,omitzero"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`, omitzero: true},
+		wantErr: "Go struct field V has malformed `json` tag: invalid character ',' at start of option (expecting Unicode letter or single quote)",
+	}, {
+		name: "Error/QuotedOption",
+		in: struct {
+			V int `json:",'omitzero'"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`, omitzero: true},
+		wantErr: "Go struct field V has unnecessarily quoted appearance of `'omitzero'` tag option; specify `omitzero` instead",
+	}, {
+		name: "Error/MisspelledOption",
+		in: struct {
+			V int `json:",omit_Empty"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has invalid appearance of `omit_Empty` tag option; specify `omitempty` instead",
+	}, {
+		name: "Error/DuplicateOption",
+		in: struct {
+			V int `json:",string,string"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`, string: true},
+		wantErr: "Go struct field V has duplicate appearance of `string` tag option",
+	}, {
+		name: "Error/CaseMissingValue",
+		in: struct {
+			V int `json:",case"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V is missing value for `case` tag option; specify `case:ignore` or `case:strict` instead",
+	}, {
+		name: "Error/CaseMalformedValue",
+		in: struct {
+			V int `json:",case:"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has malformed value for `case` tag option: missing value",
+	}, {
+		name: "Error/CaseUnknownValue",
+		in: struct {
+			V int `json:",case:upper"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has unknown `case:upper` tag value",
+	}, {
+		name: "Error/CaseConflict",
+		in: struct {
+			V int `json:",case:ignore,case:strict"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`, casing: nocase | strictcase},
+		wantErr: "Go struct field V cannot have both `case:ignore` and `case:strict` tag options",
+	}, {
+		name: "Error/FormatMissingValue",
+		in: struct {
+			V int `json:",format"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V is missing value for `format` tag option",
+	}, {
+		name: "Error/FormatMalformedValue",
+		in: struct {
+			V int `json:",format:'abc"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has malformed value for `format` tag option: single-quoted string not terminated: 'abc...",
+	}, {
+		name: "Error/FormatNotLast",
+		in: struct {
+			V int `json:",format:sec,omitzero"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`, format: "sec", omitzero: true},
+		wantErr: "Go struct field V has `format` tag option that was not specified last",
+	}, {
+		name: "Error/FirstReported",
+		in: struct {
+			V int `json:",inLine,omitZero"`
+		}{},
+		want:    fieldOptions{name: "V", quotedName: `"V"`},
+		wantErr: "Go struct field V has invalid appearance of `inLine` tag option; specify `inline` instead",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sf, ok := tt.in.(reflect.StructField)
+			if !ok {
+				sf = reflect.TypeOf(tt.in).Field(0)
+			}
+			got, gotIgnored, err := parseFieldOptions(sf)
+			if !reflect.DeepEqual(got, tt.want) || gotIgnored != tt.wantIgnored {
+				t.Errorf("parseFieldOptions:\n\tgot:  (%+v, %v)\n\twant: (%+v, %v)", got, gotIgnored, tt.want, tt.wantIgnored)
+			}
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("parseFieldOptions error:\n\tgot:  %s\n\twant: %s", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStructFieldErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      any
+		wantErr string
+		wantIs  error
+	}{{
+		name:    "NoExportedFields",
+		in:      []any{fieldsNoExported{}},
+		wantErr: `json: cannot marshal Go json.fieldsNoExported within "/0": Go struct has no exported fields`,
+		wantIs:  errNoExportedFields,
+	}, {
+		name:    "NoExportedFields/Error",
+		in:      map[string]any{"err": errors.New("error")},
+		wantErr: `json: cannot marshal Go errors.errorString within "/err": Go struct has no exported fields`,
+		wantIs:  errNoExportedFields,
+	}, {
+		name: "MalformedTag",
+		in: struct {
+			V int `json:",case"`
+		}{},
+		wantErr: "json: cannot marshal Go struct { V int \"json:\\\",case\\\"\" }: Go struct field V is missing value for `case` tag option; specify `case:ignore` or `case:strict` instead",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Marshal error:\n\tgot:  %v\n\twant: %s", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestUnmarshalNoExportedFields(t *testing.T) {
+	err := Unmarshal([]byte(`{"a":1}`), new(fieldsNoExported))
+	if !errors.Is(err, errNoExportedFields) {
+		t.Errorf("Unmarshal error = %v, want %v", err, errNoExportedFields)
+	}
+	if err := Unmarshal([]byte(`{"a":1}`), new(fieldsNoExportedTagged)); err != nil {
+		t.Errorf("Unmarshal error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,1037 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package json
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"encoding/json/jsontext"
+)
+
+type (
+	structCase struct {
+		Name   string
+		Strict string `json:",case:strict"`
+		Ignore string `json:",case:ignore"`
+	}
+	structCaseConflict struct {
+		Hello string
+		HELLO string
+	}
+	structFoldDelims struct {
+		FooBar string `json:"foo_bar,case:ignore"`
+	}
+	structDurations struct {
+		D     time.Duration
+		Units time.Duration `json:",format:units"`
+		Sec   time.Duration `json:",format:sec"`
+		Milli time.Duration `json:",format:milli"`
+		Micro time.Duration `json:",format:micro"`
+		Nano  time.Duration `json:",format:nano"`
+	}
+	structAllBytes struct {
+		Default   []byte
+		Base64    []byte  `json:",format:base64"`
+		Base64URL []byte  `json:",format:base64url"`
+		Base32    []byte  `json:",format:base32"`
+		Base32Hex []byte  `json:",format:base32hex"`
+		Base16    []byte  `json:",format:base16"`
+		Hex       []byte  `json:",format:hex"`
+		Array     []byte  `json:",format:array"`
+		Fixed     [2]byte `json:",format:hex"`
+	}
+	structNils struct {
+		S          []int
+		M          map[string]int
+		B          []byte
+		SEmitNull  []int          `json:",format:emitnull"`
+		MEmitNull  map[string]int `json:",format:emitnull"`
+		SEmitEmpty []int          `json:",format:emitempty"`
+		MEmitEmpty map[string]int `json:",format:emitempty"`
+	}
+	structUnknown struct {
+		A       int
+		Unknown map[string]any `json:",unknown"`
+	}
+)
+
+func TestMarshalOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   any
+		want string
+	}{{
+		name: "Deterministic/StringKeys",
+		opts: []Options{Deterministic(true)},
+		in:   map[string]int{"b": 2, "a": 1, "aa": 3, "": 0, "B": 4},
+		want: `{"":0,"B":4,"a":1,"aa":3,"b":2}`,
+	}, {
+		name: "Deterministic/IntKeys",
+		opts: []Options{Deterministic(true)},
+		in:   map[int]bool{10: true, 2: false, 1: true, -1: false},
+		want: `{"-1":false,"1":true,"10":true,"2":false}`,
+	}, {
+		name: "Deterministic/FloatKeys",
+		opts: []Options{Deterministic(true)},
+		in:   map[float64]int{1.5: 1, -0.25: 2, 100: 3},
+		want: `{"-0.25":2,"1.5":1,"100":3}`,
+	}, {
+		name: "Deterministic/Nested",
+		opts: []Options{Deterministic(true)},
+		in: map[string]any{
+			"z": map[string]int{"y": 1, "x": 2},
+			"a": []any{map[string]int{"d": 1, "c": 2}},
+		},
+		want: `{"a":[{"c":2,"d":1}],"z":{"x":2,"y":1}}`,
+	}, {
+		name: "Deterministic/InlinedFallback",
+		opts: []Options{Deterministic(true)},
+		in:   structUnknown{A: 1, Unknown: map[string]any{"c": 3, "b": 2, "d": map[string]int{"f": 1, "e": 2}}},
+		want: `{"A":1,"b":2,"c":3,"d":{"e":2,"f":1}}`,
+	}, {
+		name: "Deterministic/EscapedNames",
+		opts: []Options{Deterministic(true)},
+		in:   map[string]int{"é": 1, "e": 2, "\U0001f600": 3, "￿": 4},
+		want: "{\"e\":2,\"é\":1,\"￿\":4,\"\U0001f600\":3}",
+	}, {
+		name: "StringifyNumbers",
+		opts: []Options{StringifyNumbers(true)},
+		in: struct {
+			I  int
+			U  uint16
+			F  float32
+			P  *int
+			B  bool
+			S  string
+			Sl []int
+		}{I: -5, U: 7, F: 0.5, P: addr(3), B: true, S: "s", Sl: []int{1}},
+		want: `{"I":"-5","U":"7","F":"0.5","P":"3","B":true,"S":"s","Sl":["1"]}`,
+	}, {
+		name: "StringifyNumbers/Disabled",
+		opts: []Options{StringifyNumbers(false)},
+		in:   structTags{Stringify: 1},
+		want: `{"renamed":"","with,comma":"","Stringify":"1","Fold":""}`,
+	}, {
+		name: "StringifyNumbers/Duration",
+		opts: []Options{StringifyNumbers(true)},
+		in:   structDuration{Sec: time.Second},
+		want: `{"D":"0s","Sec":"1","Nano":"0"}`,
+	}, {
+		name: "FormatNilSliceAsNull",
+		opts: []Options{FormatNilSliceAsNull(true)},
+		in:   structNils{},
+		want: `{"S":null,"M":{},"B":null,"SEmitNull":null,"MEmitNull":null,"SEmitEmpty":[],"MEmitEmpty":{}}`,
+	}, {
+		name: "FormatNilMapAsNull",
+		opts: []Options{FormatNilMapAsNull(true)},
+		in:   structNils{},
+		want: `{"S":[],"M":null,"B":"","SEmitNull":null,"MEmitNull":null,"SEmitEmpty":[],"MEmitEmpty":{}}`,
+	}, {
+		name: "FormatNilAsNull/Default",
+		in:   structNils{},
+		want: `{"S":[],"M":{},"B":"","SEmitNull":null,"MEmitNull":null,"SEmitEmpty":[],"MEmitEmpty":{}}`,
+	}, {
+		name: "FormatNilAsNull/NonNil",
+		opts: []Options{FormatNilSliceAsNull(true), FormatNilMapAsNull(true)},
+		in:   structNils{S: []int{}, M: map[string]int{}, B: []byte{}},
+		want: `{"S":[],"M":{},"B":"","SEmitNull":null,"MEmitNull":null,"SEmitEmpty":[],"MEmitEmpty":{}}`,
+	}, {
+		name: "DiscardUnknownMembers/Map",
+		opts: []Options{DiscardUnknownMembers(true)},
+		in:   structUnknown{A: 1, Unknown: map[string]any{"B": 2}},
+		want: `{"A":1}`,
+	}, {
+		name: "DiscardUnknownMembers/Disabled",
+		opts: []Options{DiscardUnknownMembers(false)},
+		in:   structUnknown{A: 1, Unknown: map[string]any{"B": 2}},
+		want: `{"A":1,"B":2}`,
+	}, {
+		name: "RejectUnknownMembers/Ignored",
+		opts: []Options{RejectUnknownMembers(true)},
+		in:   structUnknown{A: 1, Unknown: map[string]any{"B": 2}},
+		want: `{"A":1,"B":2}`,
+	}, {
+		name: "MatchCaseInsensitiveNames/Ignored",
+		opts: []Options{MatchCaseInsensitiveNames(true)},
+		in:   structCase{Name: "n", Strict: "s", Ignore: "i"},
+		want: `{"Name":"n","Strict":"s","Ignore":"i"}`,
+	}, {
+		name: "FormatDuration/Units",
+		opts: []Options{FormatDuration("units")},
+		in:   []time.Duration{0, 90 * time.Minute, -time.Millisecond},
+		want: `["0s","1h30m0s","-1ms"]`,
+	}, {
+		name: "FormatDuration/Sec",
+		opts: []Options{FormatDuration("sec")},
+		in:   []time.Duration{0, time.Second, 1500 * time.Millisecond, -time.Nanosecond, math.MaxInt64},
+		want: `[0,1,1.5,-0.000000001,9223372036.854775807]`,
+	}, {
+		name: "FormatDuration/Milli",
+		opts: []Options{FormatDuration("milli")},
+		in:   []time.Duration{time.Second, time.Microsecond, -2500 * time.Microsecond},
+		want: `[1000,0.001,-2.5]`,
+	}, {
+		name: "FormatDuration/Micro",
+		opts: []Options{FormatDuration("micro")},
+		in:   []time.Duration{time.Millisecond, time.Nanosecond},
+		want: `[1000,0.001]`,
+	}, {
+		name: "FormatDuration/Nano",
+		opts: []Options{FormatDuration("nano")},
+		in:   []time.Duration{time.Microsecond, math.MinInt64},
+		want: `[1000,-9223372036854775808]`,
+	}, {
+		name: "FormatDuration/FieldOverrides",
+		opts: []Options{FormatDuration("nano")},
+		in:   structDurations{D: time.Second, Units: time.Second, Sec: time.Second, Milli: time.Second, Micro: time.Second, Nano: time.Second},
+		want: `{"D":1000000000,"Units":"1s","Sec":1,"Milli":1000,"Micro":1000000,"Nano":1000000000}`,
+	}, {
+		name: "FormatDuration/MapKey",
+		opts: []Options{FormatDuration("milli")},
+		in:   map[time.Duration]int{time.Second: 1},
+		want: `{"1000":1}`,
+	}, {
+		name: "FormatDuration/Nested",
+		opts: []Options{FormatDuration("sec")},
+		in:   map[string][]*time.Duration{"x": {addr(time.Minute), nil}},
+		want: `{"x":[60,null]}`,
+	}, {
+		name: "FormatBytes/Default",
+		in:   structAllBytes{},
+		want: `{"Default":"","Base64":"","Base64URL":"","Base32":"","Base32Hex":"","Base16":"","Hex":"","Array":[],"Fixed":"0000"}`,
+	}, {
+		name: "FormatBytes/AllFormats",
+		in: structAllBytes{
+			Default:   []byte("\xfb\xff"),
+			Base64:    []byte("\xfb\xff"),
+			Base64URL: []byte("\xfb\xff"),
+			Base32:    []byte("hello"),
+			Base32Hex: []byte("hello"),
+			Base16:    []byte("\x01\xab"),
+			Hex:       []byte("\x01\xab"),
+			Array:     []byte("\x01\xab"),
+			Fixed:     [2]byte{0xde, 0xad},
+		},
+		want: `{"Default":"+/8=","Base64":"+/8=","Base64URL":"-_8=","Base32":"NBSWY3DP","Base32Hex":"D1IMOR3F","Base16":"01ab","Hex":"01ab","Array":[1,171],"Fixed":"dead"}`,
+	}, {
+		name: "FormatBytes/Base64URL",
+		opts: []Options{FormatBytes("base64url")},
+		in:   []byte("\xfb\xff"),
+		want: `"-_8="`,
+	}, {
+		name: "FormatBytes/Base32",
+		opts: []Options{FormatBytes("base32")},
+		in:   []byte("hi"),
+		want: `"NBUQ===="`,
+	}, {
+		name: "FormatBytes/Base32Hex",
+		opts: []Options{FormatBytes("base32hex")},
+		in:   []byte("hi"),
+		want: `"D1KG===="`,
+	}, {
+		name: "FormatBytes/Hex",
+		opts: []Options{FormatBytes("hex")},
+		in:   []byte("hi"),
+		want: `"6869"`,
+	}, {
+		name: "FormatBytes/Array",
+		opts: []Options{FormatBytes("array")},
+		in:   []byte("hi"),
+		want: `[104,105]`,
+	}, {
+		name: "FormatBytes/ByteArray",
+		opts: []Options{FormatBytes("base16")},
+		in:   [3]byte{1, 2, 3},
+		want: `"010203"`,
+	}, {
+		name: "FormatBytes/FieldOverrides",
+		opts: []Options{FormatBytes("array")},
+		in:   structBytes{Default: []byte{1}, Hex: []byte{2}, Array: []byte{3}},
+		want: `{"Default":[1],"Hex":"02","Array":[3]}`,
+	}, {
+		name: "FormatBytes/Nested",
+		opts: []Options{FormatBytes("hex")},
+		in:   map[string][][]byte{"k": {{0xab}, nil}},
+		want: `{"k":["ab",""]}`,
+	}, {
+		name: "FormatBytes/NamedByte",
+		opts: []Options{FormatBytes("hex")},
+		in:   []jsontext.Kind{'a'},
+		want: `"61"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal:\n\tgot:  %s\n\twant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Options
+		in   string
+		into any
+		want any
+	}{{
+		name: "CaseSensitive/Default",
+		in:   `{"name":"n","strict":"s","ignore":"i"}`,
+		into: new(structCase),
+		want: &structCase{Ignore: "i"},
+	}, {
+		name: "CaseSensitive/Exact",
+		in:   `{"Name":"n","Strict":"s","Ignore":"i"}`,
+		into: new(structCase),
+		want: &structCase{Name: "n", Strict: "s", Ignore: "i"},
+	}, {
+		name: "MatchCaseInsensitiveNames",
+		opts: []Options{MatchCaseInsensitiveNames(true)},
+		in:   `{"NAME":"n","STRICT":"s","IGNORE":"i"}`,
+		into: new(structCase),
+		want: &structCase{Name: "n", Ignore: "i"},
+	}, {
+		name: "MatchCaseInsensitiveNames/Disabled",
+		opts: []Options{MatchCaseInsensitiveNames(false)},
+		in:   `{"NAME":"n","STRICT":"s","IGNORE":"i"}`,
+		into: new(structCase),
+		want: &structCase{Ignore: "i"},
+	}, {
+		name: "MatchCaseInsensitiveNames/Underscores",
+		opts: []Options{MatchCaseInsensitiveNames(true)},
+		in:   `{"N_A_M_E":"n","s-t-r-i-c-t":"s","I-G-N-O-R-E":"i"}`,
+		into: new(structCase),
+		want: &structCase{Name: "n", Ignore: "i"},
+	}, {
+		name: "MatchCaseInsensitiveNames/ExactPrecedence",
+		opts: []Options{MatchCaseInsensitiveNames(true)},
+		in:   `{"HELLO":"upper","Hello":"title"}`,
+		into: new(structCaseConflict),
+		want: &structCaseConflict{Hello: "title", HELLO: "upper"},
+	}, {
+		name: "MatchCaseInsensitiveNames/FirstDeclared",
+		opts: []Options{MatchCaseInsensitiveNames(true)},
+		in:   `{"hello":"lower"}`,
+		into: new(structCaseConflict),
+		want: &structCaseConflict{Hello: "lower"},
+	}, {
+		name: "CaseIgnoreTag/Delimiters",
+		in:   `{"FOOBAR":"x"}`,
+		into: new(structFoldDelims),
+		want: &structFoldDelims{FooBar: "x"},
+	}, {
+		name: "CaseIgnoreTag/Exact",
+		in:   `{"foo_bar":"x"}`,
+		into: new(structFoldDelims),
+		want: &structFoldDelims{FooBar: "x"},
+	}, {
+		name: "AllowDuplicateNames/Struct",
+		opts: []Options{jsontext.AllowDuplicateNames(true)},
+		in:   `{"Int":1,"Int":2}`,
+		into: new(structAll),
+		want: &structAll{Int: 2},
+	}, {
+		name: "AllowDuplicateNames/MatchCaseInsensitiveNames",
+		opts: []Options{jsontext.AllowDuplicateNames(true), MatchCaseInsensitiveNames(true)},
+		in:   `{"NAME":"a","name":"b","Name":"c","IGNORE":"d","ignore":"e"}`,
+		into: new(structCase),
+		want: &structCase{Name: "c", Ignore: "e"},
+	}, {
+		name: "AllowDuplicateNames/Map",
+		opts: []Options{jsontext.AllowDuplicateNames(true)},
+		in:   `{"a":1,"b":2,"a":3}`,
+		into: new(map[string]int),
+		want: addr(map[string]int{"a": 3, "b": 2}),
+	}, {
+		name: "AllowDuplicateNames/Any",
+		opts: []Options{jsontext.AllowDuplicateNames(true)},
+		in:   `{"a":{"x":1},"a":{"y":2}}`,
+		into: new(any),
+		want: addr(any(map[string]any{"a": map[string]any{"y": 2.0}})),
+	}, {
+		name: "AllowDuplicateNames/MergeStruct",
+		opts: []Options{jsontext.AllowDuplicateNames(true)},
+		in:   `{"Pointer":{"Int":1},"Pointer":{"Uint":2}}`,
+		into: new(structAll),
+		want: &structAll{Pointer: &structAll{Int: 1, Uint: 2}},
+	}, {
+		name: "AllowDuplicateNames/Inlined",
+		opts: []Options{jsontext.AllowDuplicateNames(true)},
+		in:   `{"C":1,"C":2}`,
+		into: new(structInlined),
+		want: &structInlined{Rest: jsontext.Value(`{"C":1,"C":2}`)},
+	}, {
+		name: "StringifyNumbers",
+		opts: []Options{StringifyNumbers(true)},
+		in:   `{"Int":"-5","Uint":"7","Float":"0.5","Bool":true,"String":"s"}`,
+		into: new(structAll),
+		want: &structAll{Int: -5, Uint: 7, Float: 0.5, Bool: true, String: "s"},
+	}, {
+		name: "StringifyNumbers/Any",
+		opts: []Options{StringifyNumbers(true)},
+		in:   `[1,"2"]`,
+		into: new(any),
+		want: addr(any([]any{1.0, "2"})),
+	}, {
+		name: "StringifyNumbers/MapKey",
+		in:   `{"-1":"a","2":"b"}`,
+		into: new(map[int]string),
+		want: addr(map[int]string{-1: "a", 2: "b"}),
+	}, {
+		name: "StringifyNumbers/Duration",
+		opts: []Options{StringifyNumbers(true), FormatDuration("milli")},
+		in:   `"1500"`,
+		into: new(time.Duration),
+		want: addr(1500 * time.Millisecond),
+	}, {
+		name: "RejectUnknownMembers/Disabled",
+		opts: []Options{RejectUnknownMembers(false)},
+		in:   `{"A":1,"B":2}`,
+		into: new(structUnknown),
+		want: &structUnknown{A: 1, Unknown: map[string]any{"B": 2.0}},
+	}, {
+		name: "RejectUnknownMembers/Known",
+		opts: []Options{RejectUnknownMembers(true)},
+		in:   `{"A":1}`,
+		into: new(structUnknown),
+		want: &structUnknown{A: 1},
+	}, {
+		name: "DiscardUnknownMembers/Ignored",
+		opts: []Options{DiscardUnknownMembers(true)},
+		in:   `{"A":1,"B":2}`,
+		into: new(structUnknown),
+		want: &structUnknown{A: 1, Unknown: map[string]any{"B": 2.0}},
+	}, {
+		name: "FormatNilAsNull/Ignored",
+		opts: []Options{FormatNilSliceAsNull(true), FormatNilMapAsNull(true)},
+		in:   `{"S":[],"M":{},"B":"","SEmitNull":[1],"MEmitEmpty":{"a":1}}`,
+		into: new(structNils),
+		want: &structNils{S: []int{}, M: map[string]int{}, B: []byte{}, SEmitNull: []int{1}, MEmitEmpty: map[string]int{"a": 1}},
+	}, {
+		name: "Deterministic/Ignored",
+		opts: []Options{Deterministic(true)},
+		in:   `{"b":1,"a":2}`,
+		into: new(map[string]int),
+		want: addr(map[string]int{"a": 2, "b": 1}),
+	}, {
+		name: "FormatDuration/Units",
+		opts: []Options{FormatDuration("units")},
+		in:   `["0s","1h30m","-1ms","1.5us"]`,
+		into: new([]time.Duration),
+		want: addr([]time.Duration{0, 90 * time.Minute, -time.Millisecond, 1500 * time.Nanosecond}),
+	}, {
+		name: "FormatDuration/Sec",
+		opts: []Options{FormatDuration("sec")},
+		in:   `[0,1,1.5,-0.000000001,9223372036.854775807,-9223372036.854775808,null]`,
+		into: addr([]time.Duration{}),
+		want: addr([]time.Duration{0, time.Second, 1500 * time.Millisecond, -time.Nanosecond, math.MaxInt64, math.MinInt64, 0}),
+	}, {
+		name: "FormatDuration/Milli",
+		opts: []Options{FormatDuration("milli")},
+		in:   `[1000,0.001,-2.5]`,
+		into: new([]time.Duration),
+		want: addr([]time.Duration{time.Second, time.Microsecond, -2500 * time.Microsecond}),
+	}, {
+		name: "FormatDuration/Micro",
+		opts: []Options{FormatDuration("micro")},
+		in:   `[1000,0.001]`,
+		into: new([]time.Duration),
+		want: addr([]time.Duration{time.Millisecond, time.Nanosecond}),
+	}, {
+		name: "FormatDuration/Nano",
+		opts: []Options{FormatDuration("nano")},
+		in:   `[1000,-9223372036854775808]`,
+		into: new([]time.Duration),
+		want: addr([]time.Duration{time.Microsecond, math.MinInt64}),
+	}, {
+		name: "FormatDuration/FieldOverrides",
+		opts: []Options{FormatDuration("nano")},
+		in:   `{"D":1000000000,"Units":"1s","Sec":1,"Milli":1000,"Micro":1000000,"Nano":1000000000}`,
+		into: new(structDurations),
+		want: &structDurations{D: time.Second, Units: time.Second, Sec: time.Second, Milli: time.Second, Micro: time.Second, Nano: time.Second},
+	}, {
+		name: "FormatDuration/MapKey",
+		opts: []Options{FormatDuration("milli")},
+		in:   `{"1000":1,"0.5":2}`,
+		into: new(map[time.Duration]int),
+		want: addr(map[time.Duration]int{time.Second: 1, 500 * time.Microsecond: 2}),
+	}, {
+		name: "FormatBytes/AllFormats",
+		in:   `{"Default":"+/8=","Base64":"+/8=","Base64URL":"-_8=","Base32":"NBSWY3DP","Base32Hex":"D1IMOR3F","Base16":"01ab","Hex":"01AB","Array":[1,171],"Fixed":"dead"}`,
+		into: new(structAllBytes),
+		want: &structAllBytes{
+			Default:   []byte("\xfb\xff"),
+			Base64:    []byte("\xfb\xff"),
+			Base64URL: []byte("\xfb\xff"),
+			Base32:    []byte("hello"),
+			Base32Hex: []byte("hello"),
+			Base16:    []byte("\x01\xab"),
+			Hex:       []byte("\x01\xab"),
+			Array:     []byte("\x01\xab"),
+			Fixed:     [2]byte{0xde, 0xad},
+		},
+	}, {
+		name: "FormatBytes/Null",
+		in:   `{"Default":null,"Array":null,"Fixed":null}`,
+		into: &structAllBytes{Default: []byte{1}, Array: []byte{2}, Fixed: [2]byte{3, 4}},
+		want: &structAllBytes{},
+	}, {
+		name: "FormatBytes/Reuse",
+		in:   `"AQI="`,
+		into: addr(make([]byte, 0, 8)),
+		want: addr([]byte{1, 2}),
+	}, {
+		name: "FormatBytes/Base64URL",
+		opts: []Options{FormatBytes("base64url")},
+		in:   `"-_8="`,
+		into: new([]byte),
+		want: addr([]byte("\xfb\xff")),
+	}, {
+		name: "FormatBytes/Base32",
+		opts: []Options{FormatBytes("base32")},
+		in:   `"NBUQ===="`,
+		into: new([]byte),
+		want: addr([]byte("hi")),
+	}, {
+		name: "FormatBytes/Base32Hex",
+		opts: []Options{FormatBytes("base32hex")},
+		in:   `"D1KG===="`,
+		into: new([]byte),
+		want: addr([]byte("hi")),
+	}, {
+		name: "FormatBytes/Hex",
+		opts: []Options{FormatBytes("hex")},
+		in:   `"6869"`,
+		into: new([]byte),
+		want: addr([]byte("hi")),
+	}, {
+		name: "FormatBytes/Array",
+		opts: []Options{FormatBytes("array")},
+		in:   `[104,105]`,
+		into: new([]byte),
+		want: addr([]byte("hi")),
+	}, {
+		name: "FormatBytes/ByteArray",
+		opts: []Options{FormatBytes("base16")},
+		in:   `"010203"`,
+		into: new([3]byte),
+		want: addr([3]byte{1, 2, 3}),
+	}, {
+		name: "FormatBytes/FieldOverrides",
+		opts: []Options{FormatBytes("array")},
+		in:   `{"Default":[1],"Hex":"02","Array":[3]}`,
+		into: new(structBytes),
+		want: &structBytes{Default: []byte{1}, Hex: []byte{2}, Array: []byte{3}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.in), tt.into, tt.opts...); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("Unmarshal:\n\tgot:  %#v\n\twant: %#v", tt.into, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Options
+		marshal   bool
+		in        string
+		into      any
+		wantErr   string
+		wantIs    error
+		offset    int64
+		pointer   jsontext.Pointer
+		wantValue any
+	}{{
+		name:    "DuplicateName/Struct",
+		in:      `{"Int":1,"Int":2}`,
+		into:    new(structAll),
+		wantErr: `jsontext: duplicate object member name "Int" after offset 9`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  9,
+		pointer: "/Int",
+	}, {
+		name:    "DuplicateName/Map",
+		in:      `{"a":1,"b":{"c":2,"c":3}}`,
+		into:    new(map[string]any),
+		wantErr: `jsontext: duplicate object member name "c" within "/b" after offset 18`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  18,
+		pointer: "/b/c",
+	}, {
+		name:    "DuplicateName/EscapedName",
+		in:      `[{"a":1,"a":2}]`,
+		into:    new([]map[string]int),
+		wantErr: `jsontext: duplicate object member name "a" within "/0" after offset 8`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  8,
+		pointer: "/0/a",
+	}, {
+		name:    "DuplicateName/Inlined",
+		in:      `{"A":1,"C":1,"C":2}`,
+		into:    new(structInlined),
+		wantErr: `jsontext: duplicate object member name "C" after offset 13`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  13,
+		pointer: "/C",
+	}, {
+		name:    "DuplicateName/MatchCaseInsensitiveNames",
+		opts:    []Options{MatchCaseInsensitiveNames(true)},
+		in:      `{"Name":"a","NAME":"b"}`,
+		into:    new(structCase),
+		wantErr: `jsontext: duplicate object member name "NAME" after offset 12`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  12,
+		pointer: "/NAME",
+	}, {
+		name:    "DuplicateName/CaseIgnoreTag",
+		in:      `{"Pointer":{"ignore":"a","IGNORE":"b"}}`,
+		into:    new(struct{ Pointer *structCase }),
+		wantErr: `jsontext: duplicate object member name "IGNORE" within "/Pointer" after offset 25`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  25,
+		pointer: "/Pointer/IGNORE",
+	}, {
+		name:    "DuplicateName/Marshal",
+		marshal: true,
+		in:      `unused`,
+		into:    structInlined{A: 1, Rest: jsontext.Value(`{"A":2}`)},
+		wantErr: `jsontext: duplicate object member name "A" after offset 12`,
+		wantIs:  jsontext.ErrDuplicateName,
+		offset:  12,
+		pointer: "/A",
+	}, {
+		name:    "RejectUnknownMembers",
+		opts:    []Options{RejectUnknownMembers(true)},
+		in:      `{"A":1,"B":2}`,
+		into:    new(structUnknown),
+		wantErr: `json: cannot unmarshal into Go json.structUnknown within "/B": unknown object member name "B"`,
+		wantIs:  ErrUnknownName,
+		offset:  7,
+		pointer: "/B",
+	}, {
+		name:    "RejectUnknownMembers/Nested",
+		opts:    []Options{RejectUnknownMembers(true)},
+		in:      `{"Pointer":{"Pointer":{"Bogus~/":null}}}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal into Go json.structAll within "/Pointer/Pointer/Bogus~0~1": unknown object member name "Bogus~/"`,
+		wantIs:  ErrUnknownName,
+		offset:  23,
+		pointer: "/Pointer/Pointer/Bogus~0~1",
+	}, {
+		name:    "RejectUnknownMembers/CaseMismatch",
+		opts:    []Options{RejectUnknownMembers(true)},
+		in:      `{"int":1}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal into Go json.structAll within "/int": unknown object member name "int"`,
+		wantIs:  ErrUnknownName,
+		offset:  1,
+		pointer: "/int",
+	}, {
+		name:    "StringifyNumbers/BareNumber",
+		opts:    []Options{StringifyNumbers(true)},
+		in:      `{"Int":5}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal JSON number 5 into Go int64 within "/Int"`,
+		offset:  7,
+		pointer: "/Int",
+	}, {
+		name:    "StringifyNumbers/QuotedWithoutOption",
+		in:      `{"Int":"5"}`,
+		into:    new(structAll),
+		wantErr: `json: cannot unmarshal JSON string "5" into Go int64 within "/Int"`,
+		offset:  7,
+		pointer: "/Int",
+	}, {
+		name:    "StringifyNumbers/InvalidNumber",
+		opts:    []Options{StringifyNumbers(true)},
+		in:      `[" 1"]`,
+		into:    new([]int),
+		wantErr: `json: cannot unmarshal JSON string " 1" into Go int within "/0": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "FormatDuration/Invalid",
+		opts:    []Options{FormatDuration("hours")},
+		in:      `{"D":1}`,
+		into:    new(structDuration),
+		wantErr: `json: cannot unmarshal JSON number into Go time.Duration within "/D": invalid duration format "hours"`,
+		offset:  4,
+		pointer: "/D",
+	}, {
+		name:    "FormatDuration/InvalidMarshal",
+		opts:    []Options{FormatDuration("hours")},
+		marshal: true,
+		into:    []time.Duration{1},
+		wantErr: `json: cannot marshal Go time.Duration within "/0": invalid duration format "hours"`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name: "FormatDuration/InvalidTag",
+		in:   `1`,
+		into: new(struct {
+			D time.Duration `json:",format:hours"`
+		}),
+		wantErr: `json: cannot unmarshal JSON number 1 into Go struct { D time.Duration "json:\",format:hours\"" }`,
+		offset:  0,
+	}, {
+		name: "FormatDuration/InvalidTagValue",
+		in:   `{"D":1}`,
+		into: new(struct {
+			D time.Duration `json:",format:hours"`
+		}),
+		wantErr: `json: cannot unmarshal JSON number into Go time.Duration within "/D": invalid format flag "hours"`,
+		offset:  4,
+		pointer: "/D",
+	}, {
+		name:    "FormatDuration/WrongKind",
+		in:      `{"Sec":"1s"}`,
+		into:    new(structDuration),
+		wantErr: `json: cannot unmarshal JSON string "1s" into Go time.Duration within "/Sec"`,
+		offset:  7,
+		pointer: "/Sec",
+	}, {
+		name:    "FormatDuration/InvalidUnits",
+		in:      `{"D":"1 hour"}`,
+		into:    new(structDuration),
+		wantErr: `json: cannot unmarshal JSON string into Go time.Duration within "/D": time: unknown unit " hour" in duration "1 hour"`,
+		offset:  5,
+		pointer: "/D",
+	}, {
+		name:    "FormatDuration/TooPrecise",
+		opts:    []Options{FormatDuration("milli")},
+		in:      `[0.0000001]`,
+		into:    new([]time.Duration),
+		wantErr: `json: cannot unmarshal JSON number into Go time.Duration within "/0": invalid duration "0.0000001": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "FormatDuration/Overflow",
+		opts:    []Options{FormatDuration("sec")},
+		in:      `[9223372036.854775808]`,
+		into:    new([]time.Duration),
+		wantErr: `json: cannot unmarshal JSON number into Go time.Duration within "/0": invalid duration "9223372036.854775808": value out of range`,
+		wantIs:  strconv.ErrRange,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "FormatDuration/Exponent",
+		opts:    []Options{FormatDuration("sec")},
+		in:      `[1e3]`,
+		into:    new([]time.Duration),
+		wantErr: `json: cannot unmarshal JSON number into Go time.Duration within "/0": invalid duration "1e3": invalid syntax`,
+		wantIs:  strconv.ErrSyntax,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "FormatBytes/Invalid",
+		opts:    []Options{FormatBytes("base2")},
+		in:      `{"Default":"AA=="}`,
+		into:    new(structBytes),
+		wantErr: `json: cannot unmarshal JSON string into Go []uint8 within "/Default": invalid bytes format "base2"`,
+		offset:  10,
+		pointer: "/Default",
+	}, {
+		name:    "FormatBytes/InvalidMarshal",
+		opts:    []Options{FormatBytes("base2")},
+		marshal: true,
+		into:    map[string][]byte{"k": nil},
+		wantErr: `json: cannot marshal Go []uint8 within "/k": invalid bytes format "base2"`,
+		offset:  4,
+		pointer: "/k",
+	}, {
+		name: "FormatBytes/InvalidTag",
+		in:   `{"B":""}`,
+		into: new(struct {
+			B []byte `json:",format:base2"`
+		}),
+		wantErr: `json: cannot unmarshal JSON string into Go []uint8 within "/B": invalid format flag "base2"`,
+		offset:  4,
+		pointer: "/B",
+	}, {
+		name:    "FormatBytes/InvalidData",
+		in:      `{"Hex":"fg"}`,
+		into:    new(structBytes),
+		wantErr: `json: cannot unmarshal JSON string into Go []uint8 within "/Hex": encoding/hex: invalid byte: U+0067 'g'`,
+		offset:  7,
+		pointer: "/Hex",
+	}, {
+		name:    "FormatBytes/InvalidBase64",
+		in:      `["AA="]`,
+		into:    new([][]byte),
+		wantErr: `json: cannot unmarshal JSON string into Go []uint8 within "/0": illegal base64 data at input byte 3`,
+		offset:  1,
+		pointer: "/0",
+	}, {
+		name:    "FormatBytes/ArrayLength",
+		in:      `"AQID"`,
+		into:    new([2]byte),
+		wantErr: `json: cannot unmarshal JSON string into Go [2]uint8: decoded length of 3 mismatches array length of 2`,
+		offset:  0,
+	}, {
+		name:    "FormatBytes/WrongKind",
+		in:      `{"Default":[1]}`,
+		into:    new(structBytes),
+		wantErr: `json: cannot unmarshal JSON array into Go []uint8 within "/Default"`,
+		offset:  11,
+		pointer: "/Default",
+	}, {
+		name:    "FormatBytes/ArrayElement",
+		in:      `{"Array":[1,256]}`,
+		into:    new(structBytes),
+		wantErr: `json: cannot unmarshal JSON number 256 into Go uint8 within "/Array/1": value out of range`,
+		wantIs:  strconv.ErrRange,
+		offset:  12,
+		pointer: "/Array/1",
+	}, {
+		name:    "FormatNilSliceAsNull/InvalidTag",
+		marshal: true,
+		into: struct {
+			S []int `json:",format:emitnil"`
+		}{},
+		wantErr: `json: cannot marshal Go []int within "/S": invalid format flag "emitnil"`,
+		offset:  4,
+		pointer: "/S",
+	}, {
+		name:    "FormatNilMapAsNull/InvalidTag",
+		marshal: true,
+		into: struct {
+			M map[string]int `json:",format:emitnil"`
+		}{},
+		wantErr: `json: cannot marshal Go map[string]int within "/M": invalid format flag "emitnil"`,
+		offset:  4,
+		pointer: "/M",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.marshal {
+				_, err = Marshal(tt.into, tt.opts...)
+			} else {
+				err = Unmarshal([]byte(tt.in), tt.into, tt.opts...)
+			}
+			if err == nil {
+				t.Fatal("error = nil, want non-nil")
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("error:\n\tgot:  %v\n\twant: %v", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+			}
+			var offset int64
+			var ptr jsontext.Pointer
+			var serr *SemanticError
+			var xerr *jsontext.SyntacticError
+			switch {
+			case errors.As(err, &serr):
+				offset, ptr = serr.ByteOffset, serr.JSONPointer
+			case errors.As(err, &xerr):
+				offset, ptr = xerr.ByteOffset, xerr.JSONPointer
+			default:
+				t.Fatalf("error type = %T, want *SemanticError or *jsontext.SyntacticError", err)
+			}
+			if offset != tt.offset {
+				t.Errorf("error offset = %d, want %d", offset, tt.offset)
+			}
+			if ptr != tt.pointer {
+				t.Errorf("error pointer = %q, want %q", ptr, tt.pointer)
+			}
+		})
+	}
+}
+
+// TestOptionsPrecedence checks that options passed to a call
+// take precedence over the options already held by the coder
+// and that later options take precedence over earlier ones.
+func TestOptionsPrecedence(t *testing.T) {
+	in := map[string]time.Duration{"b": time.Second, "a": time.Millisecond}
+
+	var buf strings.Builder
+	enc := jsontext.NewEncoder(&buf, Deterministic(true), FormatDuration("milli"))
+	if err := MarshalEncode(enc, in); err != nil {
+		t.Fatalf("MarshalEncode error: %v", err)
+	}
+	if err := MarshalEncode(enc, in, FormatDuration("nano"), FormatDuration("micro")); err != nil {
+		t.Fatalf("MarshalEncode error: %v", err)
+	}
+	if err := MarshalEncode(enc, in); err != nil {
+		t.Fatalf("MarshalEncode error: %v", err)
+	}
+	const want = `{"a":1,"b":1000}` + "\n" + `{"a":1000,"b":1000000}` + "\n" + `{"a":1,"b":1000}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("MarshalEncode:\n\tgot:  %s\n\twant: %s", got, want)
+	}
+
+	dec := jsontext.NewDecoder(strings.NewReader(`{"A":1} {"A":2}`), MatchCaseInsensitiveNames(true))
+	var s1, s2 structUnknown
+	if err := UnmarshalDecode(dec, &s1, MatchCaseInsensitiveNames(false)); err != nil {
+		t.Fatalf("UnmarshalDecode error: %v", err)
+	}
+	if err := UnmarshalDecode(dec, &s2); err != nil {
+		t.Fatalf("UnmarshalDecode error: %v", err)
+	}
+	if s1.A != 1 || s2.A != 2 {
+		t.Errorf("UnmarshalDecode = (%d, %d), want (1, 2)", s1.A, s2.A)
+	}
+}
+
+func TestGetOption(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		getter func(Options) (any, bool)
+		want   any
+		wantOk bool
+	}{{
+		name:   "Unset",
+		opts:   JoinOptions(),
+		getter: func(o Options) (any, bool) { return GetOption(o, Deterministic) },
+		want:   false,
+	}, {
+		name:   "Bool",
+		opts:   JoinOptions(StringifyNumbers(true)),
+		getter: func(o Options) (any, bool) { return GetOption(o, StringifyNumbers) },
+		want:   true,
+		wantOk: true,
+	}, {
+		name:   "BoolFalse",
+		opts:   JoinOptions(StringifyNumbers(true), StringifyNumbers(false)),
+		getter: func(o Options) (any, bool) { return GetOption(o, StringifyNumbers) },
+		want:   false,
+		wantOk: true,
+	}, {
+		name:   "Direct",
+		opts:   MatchCaseInsensitiveNames(true),
+		getter: func(o Options) (any, bool) { return GetOption(o, MatchCaseInsensitiveNames) },
+		want:   true,
+		wantOk: true,
+	}, {
+		name:   "DirectOther",
+		opts:   MatchCaseInsensitiveNames(true),
+		getter: func(o Options) (any, bool) { return GetOption(o, RejectUnknownMembers) },
+		want:   false,
+	}, {
+		name:   "FormatBytes",
+		opts:   JoinOptions(FormatBytes("hex"), Deterministic(true)),
+		getter: func(o Options) (any, bool) { return GetOption(o, FormatBytes) },
+		want:   "hex",
+		wantOk: true,
+	}, {
+		name:   "FormatDuration",
+		opts:   JoinOptions(FormatDuration("sec"), FormatDuration("nano")),
+		getter: func(o Options) (any, bool) { return GetOption(o, FormatDuration) },
+		want:   "nano",
+		wantOk: true,
+	}, {
+		name:   "FormatDurationUnset",
+		opts:   FormatBytes("hex"),
+		getter: func(o Options) (any, bool) { return GetOption(o, FormatDuration) },
+		want:   "",
+	}, {
+		name:   "Nested",
+		opts:   JoinOptions(JoinOptions(DiscardUnknownMembers(true)), JoinOptions(FormatNilMapAsNull(true))),
+		getter: func(o Options) (any, bool) { return GetOption(o, DiscardUnknownMembers) },
+		want:   true,
+		wantOk: true,
+	}, {
+		name:   "Jsontext",
+		opts:   JoinOptions(jsontext.AllowDuplicateNames(true), FormatNilSliceAsNull(true)),
+		getter: func(o Options) (any, bool) { return GetOption(o, jsontext.AllowDuplicateNames) },
+		want:   true,
+		wantOk: true,
+	}, {
+		name:   "DefaultOptionsV2",
+		opts:   DefaultOptionsV2(),
+		getter: func(o Options) (any, bool) { return GetOption(o, Deterministic) },
+		want:   false,
+		wantOk: true,
+	}, {
+		name:   "DefaultOptionsV2/Override",
+		opts:   JoinOptions(DefaultOptionsV2(), Deterministic(true)),
+		getter: func(o Options) (any, bool) { return GetOption(o, Deterministic) },
+		want:   true,
+		wantOk: true,
+	}, {
+		name:   "DefaultOptionsV2/FormatBytes",
+		opts:   DefaultOptionsV2(),
+		getter: func(o Options) (any, bool) { return GetOption(o, FormatBytes) },
+		want:   "",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := tt.getter(tt.opts)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("GetOption = (%v, %v), want (%v, %v)", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetOptionArshalers(t *testing.T) {
+	m := MarshalFunc(func(bool) ([]byte, error) { return []byte("0"), nil })
+	u := UnmarshalFunc(func([]byte, *bool) error { return nil })
+
+	opts := JoinOptions(WithMarshalers(m), WithUnmarshalers(u))
+	if got, ok := GetOption(opts, WithMarshalers); got != m || !ok {
+		t.Errorf("GetOption(WithMarshalers) = (%p, %v), want (%p, true)", got, ok, m)
+	}
+	if got, ok := GetOption(opts, WithUnmarshalers); got != u || !ok {
+		t.Errorf("GetOption(WithUnmarshalers) = (%p, %v), want (%p, true)", got, ok, u)
+	}
+	if got, ok := GetOption(Deterministic(true), WithMarshalers); got != nil || ok {
+		t.Errorf("GetOption(WithMarshalers) = (%p, %v), want (nil, false)", got, ok)
+	}
+
+	// Options unrelated to marshaling are ignored when unmarshaling
+	// and vice versa.
+	var got bool
+	if err := Unmarshal([]byte(`true`), &got, opts); err != nil || got {
+		t.Errorf("Unmarshal = (%v, %v), want (false, nil)", got, err)
+	}
+	if b, err := Marshal(true, opts); err != nil || string(b) != "0" {
+		t.Errorf("Marshal = (%s, %v), want (0, nil)", b, err)
+	}
+}
+
+// TestOptionsCoderState checks that the options of a coder
+// are restored after a call with per-call options returns.
+func TestOptionsCoderState(t *testing.T) {
+	var buf strings.Builder
+	enc := jsontext.NewEncoder(&buf)
+	if err := MarshalEncode(enc, map[string]int{"b": 1, "a": 2}, Deterministic(true), jsontext.WithIndent("\t")); err != nil {
+		t.Fatalf("MarshalEncode error: %v", err)
+	}
+	if v, ok := GetOption(enc.Options(), Deterministic); v || ok {
+		t.Errorf("GetOption(Deterministic) = (%v, %v), want (false, false)", v, ok)
+	}
+	if v, _ := GetOption(enc.Options(), jsontext.Multiline); v {
+		t.Errorf("GetOption(Multiline) = true, want false")
+	}
+	// Coder options such as indentation cannot be changed by a call.
+	const want = `{"a":2,"b":1}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("MarshalEncode:\n\tgot:  %s\n\twant: %s", got, want)
+	}
+}
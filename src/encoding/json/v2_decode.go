@@ -0,0 +1,425 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// Unmarshal parses the JSON-encoded data and stores the result
+// in the value pointed to by v. If v is nil or not a pointer,
+// Unmarshal returns an [InvalidUnmarshalError].
+//
+// Unmarshal uses the inverse of the encodings that
+// [Marshal] uses, allocating maps, slices, and pointers as necessary,
+// with the following additional rules:
+//
+// To unmarshal JSON into a pointer, Unmarshal first handles the case of
+// the JSON being the JSON literal null. In that case, Unmarshal sets
+// the pointer to nil. Otherwise, Unmarshal unmarshals the JSON into
+// the value pointed at by the pointer. If the pointer is nil, Unmarshal
+// allocates a new value for it to point to.
+//
+// To unmarshal JSON into a value implementing [Unmarshaler],
+// Unmarshal calls that value's [Unmarshaler.UnmarshalJSON] method, including
+// when the input is a JSON null.
+// Otherwise, if the value implements [encoding.TextUnmarshaler]
+// and the input is a JSON quoted string, Unmarshal calls
+// [encoding.TextUnmarshaler.UnmarshalText] with the unquoted form of the string.
+//
+// To unmarshal JSON into a struct, Unmarshal matches incoming object
+// keys to the keys used by [Marshal] (either the struct field name or its tag),
+// preferring an exact match but also accepting a case-insensitive match. By
+// default, object keys which don't have a corresponding struct field are
+// ignored (see [Decoder.DisallowUnknownFields] for an alternative).
+// The "required" option in a struct field's tag has no effect unless
+// decoding with [Decoder.DisallowMissingRequiredFields].
+//
+// To unmarshal JSON into an interface value,
+// Unmarshal stores one of these in the interface value:
+//
+//   - bool, for JSON booleans
+//   - float64, for JSON numbers
+//   - string, for JSON strings
+//   - []interface{}, for JSON arrays
+//   - map[string]interface{}, for JSON objects
+//   - nil for JSON null
+//
+// To unmarshal a JSON array into a slice, Unmarshal resets the slice length
+// to zero and then appends each element to the slice.
+// As a special case, to unmarshal an empty JSON array into a slice,
+// Unmarshal replaces the slice with a new empty slice.
+//
+// To unmarshal a JSON array into a Go array, Unmarshal decodes
+// JSON array elements into corresponding Go array elements.
+// If the Go array is smaller than the JSON array,
+// the additional JSON array elements are discarded.
+// If the JSON array is smaller than the Go array,
+// the additional Go array elements are set to zero values.
+//
+// To unmarshal a JSON object into a map, Unmarshal first establishes a map to
+// use. If the map is nil, Unmarshal allocates a new map. Otherwise Unmarshal
+// reuses the existing map, keeping existing entries. Unmarshal then stores
+// key-value pairs from the JSON object into the map. The map's key type must
+// either be any string type, an integer, implement [json.Unmarshaler], or
+// implement [encoding.TextUnmarshaler].
+//
+// If the JSON-encoded data contain a syntax error, Unmarshal returns a [SyntaxError].
+//
+// If a JSON value is not appropriate for a given target type,
+// or if a JSON number overflows the target type, Unmarshal
+// skips that field and completes the unmarshaling as best it can.
+// If no more serious errors are encountered, Unmarshal returns
+// an [UnmarshalTypeError] describing the earliest such error. In any
+// case, it's not guaranteed that all the remaining fields following
+// the problematic one will be unmarshaled into the target object.
+//
+// Both [SyntaxError] and [UnmarshalTypeError] report where the error
+// occurred as a byte offset and as an RFC 6901 JSON Pointer.
+//
+// The JSON null value unmarshals into an interface, map, pointer, or slice
+// by setting that Go value to nil. Because null is often used in JSON to mean
+// “not present,” unmarshaling a JSON null into any other Go type has no effect
+// on the value and produces no error.
+//
+// When unmarshaling quoted strings, invalid UTF-8 or
+// invalid UTF-16 surrogate pairs are not treated as an error.
+// Instead, they are replaced by the Unicode replacement
+// character U+FFFD.
+func Unmarshal(data []byte, v any) error {
+	// Check for well-formedness.
+	// Avoids filling out half a data structure
+	// before discovering a JSON syntax error.
+	scan := newScanner()
+	defer freeScanner(scan)
+	if err := checkValid(data, scan); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &InvalidUnmarshalError{reflect.TypeOf(v)}
+	}
+	return transformUnmarshalError(v, jsonv2.Unmarshal(data, v, v1Options))
+}
+
+// Unmarshaler is the interface implemented by types
+// that can unmarshal a JSON description of themselves.
+// The input can be assumed to be a valid encoding of
+// a JSON value. UnmarshalJSON must copy the JSON data
+// if it wishes to retain the data after returning.
+//
+// By convention, to approximate the behavior of [Unmarshal] itself,
+// Unmarshalers implement UnmarshalJSON([]byte("null")) as a no-op.
+type Unmarshaler = jsonv2.Unmarshaler
+
+// An UnmarshalTypeError describes a JSON value that was
+// not appropriate for a value of a specific Go type.
+type UnmarshalTypeError struct {
+	Value   string       // description of JSON value - "bool", "array", "number -5"
+	Type    reflect.Type // type of Go value it could not be assigned to
+	Offset  int64        // error occurred after reading Offset bytes
+	Struct  string       // name of the struct type containing the field
+	Field   string       // the full path from root node to the field
+	Pointer string       // RFC 6901 JSON Pointer to the JSON value
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	if e.Struct != "" || e.Field != "" {
+		return "json: cannot unmarshal " + e.Value + " into Go struct field " + e.Struct + "." + e.Field + " of type " + e.Type.String()
+	}
+	return "json: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
+}
+
+// An UnknownFieldError describes a JSON object key that does not match
+// any field of the destination struct, as reported by a [Decoder]
+// after a call to [Decoder.DisallowUnknownFields].
+type UnknownFieldError struct {
+	Field   string // the JSON object key
+	Offset  int64  // error occurred after reading Offset bytes
+	Pointer string // RFC 6901 JSON Pointer to the object member
+}
+
+func (e *UnknownFieldError) Error() string {
+	return "json: unknown field " + strconv.Quote(e.Field)
+}
+
+// A MissingFieldError describes a JSON object that lacks a member for
+// a struct field marked with the "required" option, as reported by a
+// [Decoder] after a call to [Decoder.DisallowMissingRequiredFields].
+type MissingFieldError struct {
+	Field   string       // the JSON object key of the required field
+	Type    reflect.Type // type of the Go struct containing the field
+	Offset  int64        // error occurred after reading Offset bytes
+	Pointer string       // RFC 6901 JSON Pointer to the JSON object
+}
+
+func (e *MissingFieldError) Error() string {
+	return "json: missing required field " + strconv.Quote(e.Field) + " for Go struct of type " + e.Type.String()
+}
+
+// An UnmarshalFieldError describes a JSON object key that
+// led to an unexported (and therefore unwritable) struct field.
+//
+// Deprecated: No longer used; kept for compatibility.
+type UnmarshalFieldError struct {
+	Key   string
+	Type  reflect.Type
+	Field reflect.StructField
+}
+
+func (e *UnmarshalFieldError) Error() string {
+	return "json: cannot unmarshal object key " + strconv.Quote(e.Key) + " into unexported field " + e.Field.Name + " of type " + e.Type.String()
+}
+
+// An InvalidUnmarshalError describes an invalid argument passed to [Unmarshal].
+// (The argument to [Unmarshal] must be a non-nil pointer.)
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnmarshalError) Error() string {
+	if e.Type == nil {
+		return "json: Unmarshal(nil)"
+	}
+
+	if e.Type.Kind() != reflect.Pointer {
+		return "json: Unmarshal(non-pointer " + e.Type.String() + ")"
+	}
+	return "json: Unmarshal(nil " + e.Type.String() + ")"
+}
+
+// A Number represents a JSON number literal.
+type Number string
+
+// String returns the literal text of the number.
+func (n Number) String() string { return string(n) }
+
+// Float64 returns the number as a float64.
+func (n Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// Int64 returns the number as an int64.
+func (n Number) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// transformUnmarshalError converts an error from "encoding/json/v2"
+// into the error type historically returned by [Unmarshal].
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func transformUnmarshalError(root any, err error) error {
+	if errs, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs2 []error
+		for _, err := range errs.Unwrap() {
+			errs2 = append(errs2, transformUnmarshalError(root, err))
+		}
+		return errors.Join(errs2...)
+	}
+	if uerr, ok := err.(*unmarshalerError); ok {
+		if terr, ok := uerr.err.(*UnmarshalTypeError); ok {
+			structName, field, _ := fieldContext(reflect.TypeOf(root), uerr.ptr)
+			if structName != "" || field != "" {
+				terr.Struct, terr.Field = structName, field
+			}
+		}
+		return uerr.err
+	}
+	var serr *jsonv2.SemanticError
+	if !errors.As(err, &serr) {
+		return err
+	}
+	if serr.Err == jsonv2.ErrUnknownName {
+		return &UnknownFieldError{
+			Field:   serr.JSONPointer.LastToken(),
+			Offset:  serr.ByteOffset,
+			Pointer: string(serr.JSONPointer),
+		}
+	}
+	if serr.Err == internal.ErrMissingField {
+		return &MissingFieldError{
+			Field:   serr.JSONPointer.LastToken(),
+			Type:    serr.GoType,
+			Offset:  serr.ByteOffset,
+			Pointer: string(serr.JSONPointer.Parent()),
+		}
+	}
+	if serr.Err == internal.ErrNilField {
+		if t := nilEmbeddedType(serr.GoType, serr.JSONPointer.LastToken()); t != nil {
+			return fmt.Errorf("json: cannot set embedded pointer to unexported struct: %v", t)
+		}
+	}
+	if serr.JSONKind == 0 || serr.GoType == nil {
+		return err
+	}
+
+	structName, field, sf := fieldContext(reflect.TypeOf(root), serr.JSONPointer)
+	if sf != nil && hasStringOption(sf.Tag) &&
+		(serr.JSONKind != '"' || errors.Is(serr.Err, strconv.ErrSyntax)) {
+		if serr.JSONKind != '"' {
+			return fmt.Errorf("json: invalid use of ,string struct tag, trying to unmarshal unquoted value into %v", serr.GoType)
+		}
+		s, _ := jsonwire.AppendUnquote(nil, serr.JSONValue)
+		return fmt.Errorf("json: invalid use of ,string struct tag, trying to unmarshal %q into %v", s, serr.GoType)
+	}
+
+	var value string
+	switch serr.JSONKind {
+	case 'n':
+		value = "null"
+	case 'f', 't':
+		value = "bool"
+	case '"':
+		value = "string"
+	case '0':
+		value = "number"
+	case '{':
+		value = "object"
+	case '[':
+		value = "array"
+	}
+	switch serr.GoType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		// A number that is out of range, or a map key that is not
+		// a number, is reported together with its literal value.
+		if serr.Err != nil && len(serr.JSONValue) > 0 {
+			s := []byte(serr.JSONValue)
+			if serr.JSONKind == '"' {
+				s, _ = jsonwire.AppendUnquote(nil, s)
+			}
+			value = "number " + string(s)
+		}
+	}
+	// A value that implements encoding.TextUnmarshaler through a pointer
+	// is historically reported as the declared pointer type.
+	typ := serr.GoType
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		declared := reflect.TypeOf(root)
+		if serr.JSONPointer != "" {
+			declared = nil
+			if sf != nil {
+				declared = sf.Type
+			}
+		}
+		if declared != nil && declared.Kind() == reflect.Pointer && declared.Elem() == typ {
+			typ = declared
+		}
+	}
+	return &UnmarshalTypeError{
+		Value:   value,
+		Type:    typ,
+		Offset:  serr.ByteOffset,
+		Struct:  structName,
+		Field:   field,
+		Pointer: string(serr.JSONPointer),
+	}
+}
+
+// nilEmbeddedType returns the type of the embedded struct pointer
+// within struct type t through which the field for name is promoted.
+func nilEmbeddedType(t reflect.Type, name string) reflect.Type {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	_, sf, ok := lookupField(t, name)
+	if !ok {
+		return nil
+	}
+	for _, i := range sf.Index[:len(sf.Index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return t.Elem()
+		}
+	}
+	return nil
+}
+
+// hasStringOption reports whether the struct tag specifies the "string" option.
+func hasStringOption(tag reflect.StructTag) bool {
+	_, opts, _ := strings.Cut(tag.Get("json"), ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "string" {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldContext follows ptr from the Go type t, reporting the name of the
+// innermost struct type and the dot-separated path of struct field names
+// that lead to the value referenced by ptr. If the last token of ptr
+// names a struct field, that field is also reported.
+func fieldContext(t reflect.Type, ptr jsontext.Pointer) (structName, field string, sf *reflect.StructField) {
+	var names []string
+	for tok := range ptr.Tokens() {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil {
+			break
+		}
+		sf = nil
+		switch t.Kind() {
+		case reflect.Struct:
+			name, f, ok := lookupField(t, tok)
+			if !ok {
+				return structName, strings.Join(names, "."), nil
+			}
+			structName = t.Name()
+			names = append(names, name)
+			t, sf = f.Type, &f
+		case reflect.Map, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			t = nil
+		}
+	}
+	return structName, strings.Join(names, "."), sf
+}
+
+// lookupField returns the JSON name and the field in struct type t
+// that the JSON object name matches, preferring an exact match.
+func lookupField(t reflect.Type, name string) (string, reflect.StructField, bool) {
+	var foldName string
+	var foldField reflect.StructField
+	var folded bool
+	for _, sf := range reflect.VisibleFields(t) {
+		tag := sf.Tag.Get("json")
+		if tag == "-" || !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && jsonName == "" {
+			continue // fields are promoted into the parent
+		}
+		if jsonName == "" {
+			jsonName = sf.Name
+		}
+		switch {
+		case jsonName == name:
+			return jsonName, sf, true
+		case !folded && strings.EqualFold(jsonName, name):
+			foldName, foldField, folded = jsonName, sf, true
+		}
+	}
+	return foldName, foldField, folded
+}
@@ -0,0 +1,11 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+// startDetectingCyclesAfter mirrors the depth
+// after which "encoding/json/v2" detects cycles.
+const startDetectingCyclesAfter = 1000
@@ -0,0 +1,129 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+import (
+	"fmt"
+	"reflect"
+
+	"encoding/json/internal"
+	"encoding/json/internal/jsonflags"
+	"encoding/json/internal/jsonopts"
+	"encoding/json/internal/jsonwire"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// v1Options is the set of options under which "encoding/json/v2"
+// reproduces the historical behavior of this package.
+var v1Options = jsonv2.JoinOptions(
+	&jsonopts.DefaultOptionsV1,
+	jsonflags.PreserveRawStrings|1,
+	jsonv2.WithMarshalers(jsonv2.MarshalToFunc(marshalNumber)),
+	jsonv2.WithUnmarshalers(jsonv2.UnmarshalFromFunc(unmarshalNumber)),
+)
+
+func init() {
+	internal.RawNumberOf = func(b []byte) any { return Number(b) }
+	internal.NewMarshalerError = func(val any, err error, funcName string) error {
+		if _, ok := err.(*MarshalerError); ok {
+			return err
+		}
+		return &MarshalerError{reflect.TypeOf(val).Elem(), err, funcName}
+	}
+	internal.PointerMarshalerError = func(err error, t reflect.Type) error {
+		if merr, ok := err.(*MarshalerError); ok && merr.Type == t.Elem() {
+			merr.Type = t
+		}
+		return err
+	}
+	internal.NewInvalidOutputError = func(b []byte, err error) error {
+		scan := newScanner()
+		defer freeScanner(scan)
+		if serr := checkValid(b, scan); serr != nil {
+			return serr
+		}
+		return err
+	}
+	internal.NewUnmarshalerError = func(err error, ptr string) error {
+		return &unmarshalerError{err, jsontext.Pointer(ptr)}
+	}
+}
+
+// unmarshalerError records where an UnmarshalJSON or UnmarshalText
+// method failed so that transformUnmarshalError can add the
+// struct field context to the error, as this package historically did.
+type unmarshalerError struct {
+	err error
+	ptr jsontext.Pointer
+}
+
+func (e *unmarshalerError) Error() string { return e.err.Error() }
+func (e *unmarshalerError) Unwrap() error { return e.err }
+
+var numberType = reflect.TypeFor[Number]()
+
+// marshalNumber encodes a Number as a JSON number,
+// or as a JSON string if the "string" tag option is in effect.
+func marshalNumber(enc *jsontext.Encoder, n Number) error {
+	s := string(n)
+	if s == "" {
+		s = "0" // an empty Number is historically encoded as zero
+	}
+	if !isValidNumber(s) {
+		return fmt.Errorf("json: invalid number literal %q", s)
+	}
+	if quoted, _ := jsonv2.GetOption(enc.Options(), jsonv2.StringifyNumbers); quoted || isObjectName(enc) {
+		return enc.WriteToken(jsontext.String(s))
+	}
+	return enc.WriteValue(jsontext.Value(s))
+}
+
+// isObjectName reports whether the next token written by enc is an object name.
+func isObjectName(enc *jsontext.Encoder) bool {
+	k, n := enc.StackIndex(enc.StackDepth())
+	return k == '{' && n%2 == 0
+}
+
+// unmarshalNumber decodes a JSON number, or a JSON string
+// containing a valid JSON number, into a Number.
+func unmarshalNumber(dec *jsontext.Decoder, n *Number) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch k := val.Kind(); k {
+	case 'n':
+		return nil // null is historically a no-op
+	case '0':
+		*n = Number(val)
+		return nil
+	case '"':
+		b, _ := jsonwire.AppendUnquote(nil, val)
+		if !isValidNumber(string(b)) {
+			if quoted, _ := jsonv2.GetOption(dec.Options(), jsonv2.StringifyNumbers); quoted {
+				return fmt.Errorf("json: invalid use of ,string struct tag, trying to unmarshal %q into %v", b, numberType)
+			}
+			return fmt.Errorf("json: invalid number literal, trying to unmarshal %q into Number", val)
+		}
+		*n = Number(b)
+		return nil
+	default:
+		return &jsonv2.SemanticError{
+			ByteOffset:  dec.InputOffset() - int64(len(val)),
+			JSONPointer: dec.StackPointer(),
+			JSONKind:    k,
+			GoType:      numberType,
+		}
+	}
+}
+
+// isValidNumber reports whether s is a valid JSON number literal.
+func isValidNumber(s string) bool {
+	n, err := jsonwire.ConsumeNumber([]byte(s))
+	return err == nil && n == len(s)
+}
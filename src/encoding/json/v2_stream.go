@@ -0,0 +1,344 @@
+// Copyright 2010 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build goexperiment.jsonv2
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+
+	"encoding/json/internal/jsonflags"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// A Decoder reads and decodes JSON values from an input stream.
+type Decoder struct {
+	dec       *jsontext.Decoder
+	opts      jsonv2.Options
+	useNumber bool
+	err       error
+}
+
+// NewDecoder returns a new decoder that reads from r.
+//
+// The decoder introduces its own buffering and may
+// read data from r beyond the JSON values requested.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: jsontext.NewDecoder(r, v1Options), opts: v1Options}
+}
+
+// UseNumber causes the Decoder to unmarshal a number into an interface{} as a
+// [Number] instead of as a float64.
+func (dec *Decoder) UseNumber() {
+	dec.useNumber = true
+	dec.opts = jsonv2.JoinOptions(dec.opts, jsonflags.UnmarshalAnyWithRawNumber|1)
+}
+
+// DisallowUnknownFields causes the Decoder to return an error when the destination
+// is a struct and the input contains object keys which do not match any
+// non-ignored, exported fields in the destination.
+// The error is an [UnknownFieldError].
+func (dec *Decoder) DisallowUnknownFields() {
+	dec.opts = jsonv2.JoinOptions(dec.opts, jsonv2.RejectUnknownMembers(true))
+}
+
+// DisallowMissingRequiredFields causes the Decoder to return an error when the
+// destination is a struct and the input object has no key for a field whose
+// tag includes the "required" option, as in:
+//
+//	ID string `json:"id,required"`
+//
+// The error is a [MissingFieldError].
+func (dec *Decoder) DisallowMissingRequiredFields() {
+	dec.opts = jsonv2.JoinOptions(dec.opts, jsonflags.RejectMissingRequiredFields|1)
+}
+
+// ReportAllErrors causes [Decoder.Decode] to report every error that it
+// could recover from, such as an [UnmarshalTypeError], instead of only the
+// earliest one. If more than one error occurs, Decode returns an error that
+// wraps each of them in the order they occurred, as produced by [errors.Join].
+// Decoding still stops at the first [SyntaxError] or error returned by an
+// [Unmarshaler].
+func (dec *Decoder) ReportAllErrors() {
+	dec.opts = jsonv2.JoinOptions(dec.opts, jsonflags.ReportAllErrors|1)
+}
+
+// Decode reads the next JSON-encoded value from its
+// input and stores it in the value pointed to by v.
+//
+// See the documentation for [Unmarshal] for details about
+// the conversion of JSON into a Go value.
+func (dec *Decoder) Decode(v any) error {
+	if dec.err != nil {
+		return dec.err
+	}
+
+	// Read the whole value before unmarshaling so that
+	// a semantic error does not leave the stream misaligned.
+	val, err := dec.dec.ReadValue()
+	if err != nil {
+		dec.err = dec.transformSyntacticError(err, false)
+		return dec.err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &InvalidUnmarshalError{reflect.TypeOf(v)}
+	}
+	return transformUnmarshalError(v, jsonv2.Unmarshal(val, v, dec.opts))
+}
+
+// Buffered returns a reader of the data remaining in the Decoder's
+// buffer. The reader is valid until the next call to [Decoder.Decode].
+func (dec *Decoder) Buffered() io.Reader {
+	return bytes.NewReader(dec.dec.UnreadBuffer())
+}
+
+// transformSyntacticError converts an error from "encoding/json/jsontext"
+// into the error historically returned by [Decoder.Decode],
+// or by [Decoder.Token] if inToken is set.
+//
+// The wording of syntax errors differs between the two implementations,
+// so the unread input is rescanned with the scanner used by [Unmarshal]
+// to reconstruct the historical message.
+func (dec *Decoder) transformSyntacticError(err error, inToken bool) error {
+	var serr *jsontext.SyntacticError
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return io.ErrUnexpectedEOF
+	case !errors.As(err, &serr):
+		return err
+	}
+	base := dec.dec.InputOffset()
+	buf := dec.dec.UnreadBuffer()
+	i := skipSpace(buf, 0)
+
+	// Consume the separator expected before the next value, if any.
+	k, n := dec.dec.StackIndex(dec.dec.StackDepth())
+	var sep byte
+	var context string
+	switch {
+	case k == '[' && n > 0:
+		sep, context = ',', "after array element"
+	case k == '{' && n%2 == 1:
+		sep, context = ':', "after object key"
+	case k == '{' && n > 0:
+		sep, context = ',', "after object key:value pair"
+	}
+	if sep != 0 && i < len(buf) {
+		if buf[i] != sep {
+			if inToken {
+				return &SyntaxError{msg: "invalid character " + quoteChar(buf[i]) + " " + context, Offset: base + int64(i)}
+			}
+			if sep == ':' {
+				return &SyntaxError{msg: "expected colon after object key", Offset: base + int64(i)}
+			}
+			return &SyntaxError{msg: "expected comma " + context, Offset: base + int64(i)}
+		}
+		i = skipSpace(buf, i+1)
+	}
+
+	// Rescan the next value to find the historical error message.
+	scan := newScanner()
+	defer freeScanner(scan)
+	for j, c := range buf[i:] {
+		if scan.step(scan, c) != scanError {
+			continue
+		}
+		if inToken && j == 0 {
+			context = "looking for beginning of value"
+			if k == '{' && n%2 == 0 {
+				context = "looking for beginning of object key string"
+			}
+			return &SyntaxError{msg: "invalid character " + quoteChar(c) + " " + context, Offset: base + int64(i)}
+		}
+		msg := scan.err.(*SyntaxError).msg
+		if inToken {
+			return &SyntaxError{msg: msg, Offset: int64(j + 1)}
+		}
+		return &SyntaxError{msg: msg, Offset: base + int64(i+j+1), Pointer: jsonPointer(buf[i:], j+1)}
+	}
+	return &SyntaxError{msg: serr.Err.Error(), Offset: serr.ByteOffset}
+}
+
+// skipSpace returns the index of the first non-space byte in b at or after i.
+func skipSpace(b []byte, i int) int {
+	for i < len(b) && isSpace(b[i]) {
+		i++
+	}
+	return i
+}
+
+// An Encoder writes JSON values to an output stream.
+type Encoder struct {
+	w          io.Writer
+	err        error
+	escapeHTML bool
+
+	indentBuf    []byte
+	indentPrefix string
+	indentValue  string
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, escapeHTML: true}
+}
+
+// Encode writes the JSON encoding of v to the stream,
+// followed by a newline character.
+//
+// See the documentation for [Marshal] for details about the
+// conversion of Go values to JSON.
+func (enc *Encoder) Encode(v any) error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	b, err := jsonv2.Marshal(v, v1Options, jsontext.EscapeForHTML(enc.escapeHTML))
+	if err != nil {
+		return transformMarshalError(v, err)
+	}
+
+	// Terminate each value with a newline.
+	// This makes the output look a little nicer
+	// when debugging, and some kind of space
+	// is required if the encoded value was a number,
+	// so that the reader knows there aren't more
+	// digits coming.
+	b = append(b, '\n')
+
+	if enc.indentPrefix != "" || enc.indentValue != "" {
+		enc.indentBuf, err = appendIndent(enc.indentBuf[:0], b, enc.indentPrefix, enc.indentValue)
+		if err != nil {
+			return err
+		}
+		b = enc.indentBuf
+	}
+	if _, err = enc.w.Write(b); err != nil {
+		enc.err = err
+	}
+	return err
+}
+
+// SetIndent instructs the encoder to format each subsequent encoded
+// value as if indented by the package-level function Indent(dst, src, prefix, indent).
+// Calling SetIndent("", "") disables indentation.
+func (enc *Encoder) SetIndent(prefix, indent string) {
+	enc.indentPrefix = prefix
+	enc.indentValue = indent
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters
+// should be escaped inside JSON quoted strings.
+// The default behavior is to escape &, <, and > to \u0026, \u003c, and \u003e
+// to avoid certain safety problems that can arise when embedding JSON in HTML.
+//
+// In non-HTML settings where the escaping interferes with the readability
+// of the output, SetEscapeHTML(false) disables this behavior.
+func (enc *Encoder) SetEscapeHTML(on bool) {
+	enc.escapeHTML = on
+}
+
+// RawMessage is a raw encoded JSON value.
+// It implements [Marshaler] and [Unmarshaler] and can
+// be used to delay JSON decoding or precompute a JSON encoding.
+type RawMessage []byte
+
+// MarshalJSON returns m as the JSON encoding of m.
+func (m RawMessage) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	return m, nil
+}
+
+// UnmarshalJSON sets *m to a copy of data.
+func (m *RawMessage) UnmarshalJSON(data []byte) error {
+	if m == nil {
+		return errors.New("json.RawMessage: UnmarshalJSON on nil pointer")
+	}
+	*m = append((*m)[0:0], data...)
+	return nil
+}
+
+var _ Marshaler = (*RawMessage)(nil)
+var _ Unmarshaler = (*RawMessage)(nil)
+
+// A Token holds a value of one of these types:
+//
+//   - [Delim], for the four JSON delimiters [ ] { }
+//   - bool, for JSON booleans
+//   - float64, for JSON numbers
+//   - [Number], for JSON numbers
+//   - string, for JSON string literals
+//   - nil, for JSON null
+type Token any
+
+// A Delim is a JSON array or object delimiter, one of [ ] { or }.
+type Delim rune
+
+func (d Delim) String() string {
+	return string(d)
+}
+
+// Token returns the next JSON token in the input stream.
+// At the end of the input stream, Token returns nil, [io.EOF].
+//
+// Token guarantees that the delimiters [ ] { } it returns are
+// properly nested and matched: if Token encounters an unexpected
+// delimiter in the input, it will return an error.
+//
+// The input stream consists of basic JSON values—bool, string,
+// number, and null—along with delimiters [ ] { } of type [Delim]
+// to mark the start and end of arrays and objects.
+// Commas and colons are elided.
+func (dec *Decoder) Token() (Token, error) {
+	if dec.err != nil {
+		return nil, dec.err
+	}
+	tok, err := dec.dec.ReadToken()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		dec.err = dec.transformSyntacticError(err, true)
+		return nil, dec.err
+	}
+	switch k := tok.Kind(); k {
+	case 'n':
+		return nil, nil
+	case 'f', 't':
+		return tok.Bool(), nil
+	case '"':
+		return tok.String(), nil
+	case '0':
+		if dec.useNumber {
+			return Number(tok.String()), nil
+		}
+		return tok.Float(), nil
+	case '{', '}', '[', ']':
+		return Delim(k), nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// More reports whether there is another element in the
+// current array or object being parsed.
+func (dec *Decoder) More() bool {
+	k := dec.dec.PeekKind()
+	return k > 0 && k != ']' && k != '}'
+}
+
+// InputOffset returns the input stream byte offset of the current decoder position.
+// The offset gives the location of the end of the most recently returned token
+// and the beginning of the next token.
+func (dec *Decoder) InputOffset() int64 {
+	return dec.dec.InputOffset()
+}
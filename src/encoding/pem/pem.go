@@ -182,6 +182,127 @@ func Decode(data []byte) (p *Block, rest []byte) {
 	}
 }
 
+// DecodeStrict is like [Decode], but requires the next PEM block in the input
+// to follow the strict textual encoding of RFC 7468, Section 3, which is the
+// encoding written by [Encode] for blocks without headers. That is:
+//
+//   - the block starts at the beginning of a line, and the type label in the
+//     encapsulation boundaries consists of printable ASCII characters, with
+//     single hyphens or spaces allowed between them;
+//   - the block has no headers;
+//   - the base64 data is written in lines of exactly 64 characters, except for
+//     the last line, which may be shorter and alone holds any padding, and
+//     the lines contain no whitespace;
+//   - the base64 encoding is canonical, with zero padding bits;
+//   - all lines, including the end boundary, end with CRLF, CR or LF.
+//
+// Text before and after the block is skipped. If no block is found, p is nil,
+// the whole of the input is returned in rest and err is nil. If the next
+// block does not follow the strict encoding, p is nil, the whole of the input
+// is returned in rest and err describes the problem.
+func DecodeStrict(data []byte) (p *Block, rest []byte, err error) {
+	// Find the start of the first encapsulation boundary at the start of a line.
+	start := 0
+	for {
+		i := bytes.Index(data[start:], pemStart[1:])
+		if i < 0 {
+			return nil, data, nil
+		}
+		start += i
+		if start == 0 || data[start-1] == '\n' || data[start-1] == '\r' {
+			break
+		}
+		start++
+	}
+
+	line, rest, ok := strictLine(data[start:])
+	if !ok {
+		return nil, data, errors.New("pem: missing end of line after BEGIN boundary")
+	}
+	label, ok := bytes.CutPrefix(line, pemStart[1:])
+	if ok {
+		label, ok = bytes.CutSuffix(label, pemEndOfLine)
+	}
+	if !ok || !validLabel(label) {
+		return nil, data, errors.New("pem: invalid BEGIN boundary")
+	}
+
+	var base64Data []byte
+	for lines := 0; ; lines++ {
+		line, rest, ok = strictLine(rest)
+		if !ok {
+			return nil, data, errors.New("pem: missing END boundary")
+		}
+		if end, ok := bytes.CutPrefix(line, pemEnd[1:]); ok {
+			end, ok = bytes.CutSuffix(end, pemEndOfLine)
+			if !ok || !bytes.Equal(end, label) {
+				return nil, data, errors.New("pem: END boundary does not match BEGIN boundary")
+			}
+			if lines == 0 {
+				return nil, data, errors.New("pem: block has no data")
+			}
+			break
+		}
+		if lines == 0 && bytes.Contains(line, colon) {
+			return nil, data, errors.New("pem: headers are not allowed in strict encoding")
+		}
+		if len(base64Data)%pemLineLength != 0 {
+			return nil, data, errors.New("pem: base64 line shorter than 64 characters before the last line")
+		}
+		if len(line) == 0 || len(line) > pemLineLength || len(line)%4 != 0 {
+			return nil, data, errors.New("pem: invalid base64 line length")
+		}
+		base64Data = append(base64Data, line...)
+	}
+
+	p = &Block{
+		Type:    string(label),
+		Headers: make(map[string]string),
+	}
+	// The strict decoder rejects non-zero padding bits, and the newlines it
+	// would otherwise skip were removed along with the line structure.
+	enc := base64.StdEncoding.Strict()
+	p.Bytes = make([]byte, enc.DecodedLen(len(base64Data)))
+	n, err := enc.Decode(p.Bytes, base64Data)
+	if err != nil {
+		return nil, data, errors.New("pem: invalid base64 data")
+	}
+	p.Bytes = p.Bytes[:n]
+	return p, rest, nil
+}
+
+// strictLine returns the first line in data and the data after it. Lines end
+// in CRLF, CR or LF, which is not included. If data has no line ending,
+// ok is false.
+func strictLine(data []byte) (line, rest []byte, ok bool) {
+	i := bytes.IndexAny(data, "\r\n")
+	if i < 0 {
+		return nil, data, false
+	}
+	line, rest = data[:i], data[i+1:]
+	if data[i] == '\r' && len(rest) > 0 && rest[0] == '\n' {
+		rest = rest[1:]
+	}
+	return line, rest, true
+}
+
+// validLabel reports whether label is valid in the strict encoding of
+// RFC 7468: printable ASCII characters other than hyphen, each pair of which
+// may be separated by a single hyphen or space.
+func validLabel(label []byte) bool {
+	for i, c := range label {
+		switch {
+		case c == '-' || c == ' ':
+			if i == 0 || i == len(label)-1 || label[i-1] == '-' || label[i-1] == ' ' {
+				return false
+			}
+		case c < 0x21 || c > 0x7e:
+			return false
+		}
+	}
+	return true
+}
+
 const pemLineLength = 64
 
 type lineBreaker struct {
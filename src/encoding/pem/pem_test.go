@@ -159,6 +159,79 @@ func TestBadDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeStrict(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	enc := string(EncodeToMemory(&Block{Type: "TEST BLOCK", Bytes: data}))
+	crlf := strings.ReplaceAll(enc, "\n", "\r\n")
+	cr := strings.ReplaceAll(enc, "\n", "\r")
+	for _, input := range []string{enc, crlf, cr, "text before\n" + enc} {
+		p, rest, err := DecodeStrict([]byte(input + "after"))
+		if err != nil {
+			t.Errorf("DecodeStrict(%q) error: %v", input, err)
+			continue
+		}
+		if p.Type != "TEST BLOCK" || !bytes.Equal(p.Bytes, data) || len(p.Headers) != 0 {
+			t.Errorf("DecodeStrict(%q) = %#v", input, p)
+		}
+		if string(rest) != "after" {
+			t.Errorf("DecodeStrict(%q) rest = %q; want %q", input, rest, "after")
+		}
+	}
+
+	for n := range 100 {
+		b := &Block{Type: "X", Bytes: bytes.Repeat([]byte{0xa5}, n+1)}
+		p, _, err := DecodeStrict(EncodeToMemory(b))
+		if err != nil || !reflect.DeepEqual(p, &Block{Type: "X", Headers: map[string]string{}, Bytes: b.Bytes}) {
+			t.Errorf("%d bytes: DecodeStrict = %#v, %v", n+1, p, err)
+		}
+	}
+
+	p, rest, err := DecodeStrict([]byte("no block here\n"))
+	if p != nil || string(rest) != "no block here\n" || err != nil {
+		t.Errorf("DecodeStrict without block = %v, %q, %v", p, rest, err)
+	}
+}
+
+var badStrictPEMTests = []struct {
+	input, err string
+}{
+	{"-----BEGIN A-----\nHeader: 1\n\ndGVzdA==\n-----END A-----\n", "headers"},
+	{"-----BEGIN A-----\n-----END A-----\n", "no data"},
+	{"-----BEGIN A-----\ndGVzdA==\n-----END B-----\n", "does not match"},
+	{"-----BEGIN A-----\ndGVzdA==\n-----END A-----", "missing END"},
+	{"-----BEGIN A -----\ndGVzdA==\n-----END A -----\n", "BEGIN boundary"},
+	{"-----BEGIN A--B-----\ndGVzdA==\n-----END A--B-----\n", "BEGIN boundary"},
+	{"-----BEGIN A\tB-----\ndGVzdA==\n-----END A\tB-----\n", "BEGIN boundary"},
+	{"-----BEGIN A----- \ndGVzdA==\n-----END A-----\n", "BEGIN boundary"},
+	{"-----BEGIN A-----\ndGVzdA== \n-----END A-----\n", "line length"},
+	{"-----BEGIN A-----\ndGVz\ndA==\n-----END A-----\n", "shorter than 64"},
+	{"-----BEGIN A-----\ndGVzdA=\n-----END A-----\n", "line length"},
+	{"-----BEGIN A-----\ndGVzdB==\n-----END A-----\n", "invalid base64"},
+	{"-----BEGIN A-----\nd=VzdA==\n-----END A-----\n", "invalid base64"},
+	{"-----BEGIN A-----\n" + strings.Repeat("A", 68) + "\n-----END A-----\n", "line length"},
+}
+
+func TestBadDecodeStrict(t *testing.T) {
+	for _, test := range badStrictPEMTests {
+		p, rest, err := DecodeStrict([]byte(test.input))
+		if p != nil || err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("DecodeStrict(%q) = %v, %v; want error containing %q", test.input, p, err, test.err)
+		}
+		if string(rest) != test.input {
+			t.Errorf("DecodeStrict(%q) rest = %q; want the input", test.input, rest)
+		}
+	}
+
+	// Decode accepts what DecodeStrict rejects.
+	lax := "-----BEGIN A-----\n dGVz\ndA==\n-----END A-----"
+	if p, _ := Decode([]byte(lax)); p == nil {
+		t.Errorf("Decode(%q) failed", lax)
+	}
+	if _, _, err := DecodeStrict([]byte(lax)); err == nil {
+		t.Errorf("DecodeStrict(%q) succeeded", lax)
+	}
+}
+
 func TestCVE202224675(t *testing.T) {
 	// Prior to CVE-2022-24675, this input would cause a stack overflow.
 	input := []byte(strings.Repeat("-----BEGIN \n", 10000000))
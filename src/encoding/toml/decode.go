@@ -0,0 +1,569 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/internal/structfield"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unmarshal parses the TOML document in data and stores the result
+// in the value pointed to by v. If v is nil or not a pointer,
+// Unmarshal returns an [InvalidUnmarshalError].
+//
+// If data is not a valid TOML 1.0 document, Unmarshal returns a
+// [SyntaxError] giving the line and column of the error, without
+// modifying v. Use a [Decoder] to learn which keys of the document
+// were not stored in v.
+//
+// Unmarshal uses the inverse of the encodings that
+// [Marshal] uses, allocating maps, slices, and pointers as necessary,
+// with the following additional rules:
+//
+// To unmarshal TOML into a value implementing [Unmarshaler],
+// Unmarshal calls that value's [Unmarshaler.UnmarshalTOML] method
+// with the TOML value as it would be unmarshaled into an interface value.
+// Otherwise, if the value implements [encoding.TextUnmarshaler]
+// and the input is a TOML string, Unmarshal calls
+// [encoding.TextUnmarshaler.UnmarshalText] with the contents of the string.
+// Offset and local date-times, dates and times are passed to
+// UnmarshalText in the form written by [Marshal], except when
+// unmarshaling into a [time.Time], [LocalDateTime], [LocalDate] or
+// [LocalTime], which accept only the corresponding kind of TOML value or
+// a string.
+//
+// To unmarshal a TOML table into a struct, Unmarshal matches the keys of
+// the table to the keys used by [Marshal] (either the struct field name
+// or its tag), preferring an exact match but also accepting a
+// case-insensitive match, as [encoding/json] does. By default, keys that
+// don't have a corresponding struct field are ignored (see
+// [Decoder.Undecoded] and [Decoder.DisallowUnknownFields]).
+//
+// Maps, slices and arrays are filled in as by [encoding/json.Unmarshal]:
+// entries are added to existing maps, slices are reset to length zero
+// before appending, Go arrays keep only the elements that fit, and any
+// Go array elements left over are set to zero. Map keys must be strings,
+// integers, or implement [encoding.TextUnmarshaler].
+//
+// To unmarshal TOML into an interface value,
+// Unmarshal stores one of these in the interface value:
+//
+//   - string, for TOML strings
+//   - int64, for TOML integers
+//   - float64, for TOML floats
+//   - bool, for TOML booleans
+//   - [time.Time], for TOML offset date-times
+//   - [LocalDateTime], [LocalDate] or [LocalTime], for TOML local
+//     date-times, dates and times
+//   - []any, for TOML arrays and arrays of tables
+//   - map[string]any, for TOML tables
+//
+// Integers are converted when unmarshaling into a floating-point value.
+// If a TOML value is not appropriate for a given target type, or if an
+// integer overflows the target type, Unmarshal skips that field and
+// completes the unmarshaling as best it can. If no more serious errors are
+// encountered, Unmarshal returns an [UnmarshalTypeError] describing the
+// earliest such error.
+func Unmarshal(data []byte, v any) error {
+	// Parse the whole document first so that a syntax error
+	// does not leave v half-filled.
+	root, err := parse(data)
+	if err != nil {
+		return err
+	}
+	d := decodeState{data: data}
+	return d.unmarshal(root, v)
+}
+
+// Unmarshaler is the interface implemented by types
+// that can unmarshal a TOML value of themselves. The value is
+// given as [Unmarshal] would store it in an interface value.
+type Unmarshaler interface {
+	UnmarshalTOML(value any) error
+}
+
+// An UnmarshalTypeError describes a TOML value that was
+// not appropriate for a value of a specific Go type.
+type UnmarshalTypeError struct {
+	Value  string       // description of TOML value - "boolean", "array", "integer 300"
+	Type   reflect.Type // type of Go value it could not be assigned to
+	Line   int          // line of the value, starting at 1
+	Column int          // byte offset of the value within its line, starting at 1
+	Struct string       // name of the struct type containing the field
+	Field  string       // the full path from root node to the field, include embedded struct
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	pos := "toml: line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) + ": "
+	if e.Struct != "" || e.Field != "" {
+		return pos + "cannot unmarshal " + e.Value + " into Go struct field " + e.Struct + "." + e.Field + " of type " + e.Type.String()
+	}
+	return pos + "cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
+}
+
+// An InvalidUnmarshalError describes an invalid argument passed to [Unmarshal].
+// (The argument to [Unmarshal] must be a non-nil pointer.)
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnmarshalError) Error() string {
+	if e.Type == nil {
+		return "toml: Unmarshal(nil)"
+	}
+
+	if e.Type.Kind() != reflect.Pointer {
+		return "toml: Unmarshal(non-pointer " + e.Type.String() + ")"
+	}
+	return "toml: Unmarshal(nil " + e.Type.String() + ")"
+}
+
+// decodeState represents the state while decoding a parsed TOML document.
+type decodeState struct {
+	data         []byte // source of the document, for positions in errors
+	errorContext *errorContext
+	savedError   error
+
+	key       Key // key of the table being decoded
+	undecoded []undecodedKey
+
+	disallowUnknownFields bool
+}
+
+// An undecodedKey is a key of a table that did not match a struct field.
+type undecodedKey struct {
+	off int
+	key Key
+}
+
+// An errorContext provides context for type errors during decoding.
+type errorContext struct {
+	Struct     reflect.Type
+	FieldStack []string
+}
+
+func (d *decodeState) unmarshal(root *table, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &InvalidUnmarshalError{reflect.TypeOf(v)}
+	}
+
+	// We decode rv not rv.Elem because the Unmarshaler interface
+	// test must be applied at the top level of the value.
+	err := d.value(&value{kind: kindTable, table: root}, rv)
+	if err != nil {
+		return d.addErrorContext(err)
+	}
+	return d.savedError
+}
+
+// saveError saves the first err it is called with,
+// for reporting at the end of the unmarshal.
+func (d *decodeState) saveError(err error) {
+	if d.savedError == nil {
+		d.savedError = d.addErrorContext(err)
+	}
+}
+
+// addErrorContext returns a new error enhanced with information from d.errorContext
+func (d *decodeState) addErrorContext(err error) error {
+	if d.errorContext != nil && (d.errorContext.Struct != nil || len(d.errorContext.FieldStack) > 0) {
+		switch err := err.(type) {
+		case *UnmarshalTypeError:
+			err.Struct = d.errorContext.Struct.Name()
+			fieldStack := d.errorContext.FieldStack
+			if err.Field != "" {
+				fieldStack = append(fieldStack, err.Field)
+			}
+			err.Field = strings.Join(fieldStack, ".")
+		}
+	}
+	return err
+}
+
+// typeError records an UnmarshalTypeError for the value v.
+func (d *decodeState) typeError(v *value, t reflect.Type) {
+	desc := v.kind.String()
+	if v.kind == kindInteger {
+		desc += " " + strconv.FormatInt(v.scalar.(int64), 10)
+	}
+	line, col := position(d.data, v.off)
+	d.saveError(&UnmarshalTypeError{Value: desc, Type: t, Line: line, Column: col})
+}
+
+// indirect walks down v allocating pointers as needed,
+// until it gets to a non-pointer.
+// If it encounters an Unmarshaler or an encoding.TextUnmarshaler,
+// indirect stops and returns that.
+func indirect(v reflect.Value) (u Unmarshaler, ut encoding.TextUnmarshaler, pv reflect.Value) {
+	pv = structfield.Indirect(v, false, func(v reflect.Value) bool {
+		var ok bool
+		if u, ok = v.Interface().(Unmarshaler); ok {
+			return true
+		}
+		ut, ok = v.Interface().(encoding.TextUnmarshaler)
+		return ok
+	})
+	return u, ut, pv
+}
+
+// value decodes the TOML value v into rv.
+func (d *decodeState) value(v *value, rv reflect.Value) error {
+	u, ut, pv := indirect(rv)
+	if u != nil {
+		return u.UnmarshalTOML(valueInterface(v))
+	}
+	if ut != nil {
+		return d.text(v, rv, ut)
+	}
+	rv = pv
+
+	switch v.kind {
+	case kindTable:
+		return d.table(v, rv)
+	case kindArray:
+		return d.array(v, rv)
+	}
+
+	if rv.Kind() == reflect.Interface && rv.NumMethod() == 0 {
+		rv.Set(reflect.ValueOf(v.scalar))
+		return nil
+	}
+	switch x := v.scalar.(type) {
+	case string:
+		switch {
+		case rv.Kind() == reflect.String:
+			rv.SetString(x)
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			b, err := base64.StdEncoding.DecodeString(x)
+			if err != nil {
+				d.saveError(err)
+				break
+			}
+			rv.SetBytes(b)
+		default:
+			d.typeError(v, rv.Type())
+		}
+	case int64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.OverflowInt(x) {
+				d.typeError(v, rv.Type())
+				break
+			}
+			rv.SetInt(x)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if x < 0 || rv.OverflowUint(uint64(x)) {
+				d.typeError(v, rv.Type())
+				break
+			}
+			rv.SetUint(uint64(x))
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(float64(x))
+		default:
+			d.typeError(v, rv.Type())
+		}
+	case float64:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if !math.IsInf(x, 0) && rv.OverflowFloat(x) {
+				d.typeError(v, rv.Type())
+				break
+			}
+			rv.SetFloat(x)
+		default:
+			d.typeError(v, rv.Type())
+		}
+	case bool:
+		if rv.Kind() != reflect.Bool {
+			d.typeError(v, rv.Type())
+			break
+		}
+		rv.SetBool(x)
+	default:
+		// Date-times are stored only in interfaces and in values
+		// implementing encoding.TextUnmarshaler.
+		d.typeError(v, rv.Type())
+	}
+	return nil
+}
+
+// text decodes the TOML value v into ut, the
+// encoding.TextUnmarshaler implementation of rv.
+func (d *decodeState) text(v *value, rv reflect.Value, ut encoding.TextUnmarshaler) error {
+	var ok bool
+	switch p := ut.(type) {
+	case *time.Time:
+		*p, ok = v.scalar.(time.Time)
+	case *LocalDateTime:
+		*p, ok = v.scalar.(LocalDateTime)
+	case *LocalDate:
+		*p, ok = v.scalar.(LocalDate)
+	case *LocalTime:
+		*p, ok = v.scalar.(LocalTime)
+	default:
+		var text []byte
+		switch x := v.scalar.(type) {
+		case time.Time:
+			text = x.AppendFormat(nil, time.RFC3339Nano)
+		case LocalDateTime:
+			text = x.appendTo(nil)
+		case LocalDate:
+			text = x.appendTo(nil)
+		case LocalTime:
+			text = x.appendTo(nil)
+		}
+		if text != nil {
+			return ut.UnmarshalText(text)
+		}
+	}
+	if ok {
+		return nil
+	}
+	if s, isString := v.scalar.(string); isString {
+		return ut.UnmarshalText([]byte(s))
+	}
+	d.typeError(v, rv.Type())
+	return nil
+}
+
+// table decodes the TOML table v into rv.
+func (d *decodeState) table(v *value, rv reflect.Value) error {
+	t := v.table
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			d.typeError(v, rv.Type())
+			return nil
+		}
+		rv.Set(reflect.ValueOf(valueInterface(v)))
+		return nil
+
+	case reflect.Map:
+		kt := rv.Type().Key()
+		switch kt.Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !reflect.PointerTo(kt).Implements(textUnmarshalerType) {
+				d.typeError(v, rv.Type())
+				return nil
+			}
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+		for _, e := range t.entries {
+			kv, ok := d.mapKey(e, kt)
+			if !ok {
+				continue
+			}
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			d.key = append(d.key, e.key)
+			err := d.value(e.val, elem)
+			d.key = d.key[:len(d.key)-1]
+			if err != nil {
+				return err
+			}
+			rv.SetMapIndex(kv, elem)
+		}
+		return nil
+
+	case reflect.Struct:
+		fields := cachedTypeFields(rv.Type())
+		for _, e := range t.entries {
+			f := fields.byName[e.key]
+			if f == nil {
+				for i := range fields.list {
+					if strings.EqualFold(fields.list[i].name, e.key) {
+						f = &fields.list[i]
+						break
+					}
+				}
+			}
+			if f == nil {
+				d.unknownKey(e)
+				continue
+			}
+			subv := d.structField(rv, f)
+			if !subv.IsValid() {
+				continue
+			}
+			d.key = append(d.key, e.key)
+			err := d.fieldValue(rv, subv, f.name, e.val)
+			d.key = d.key[:len(d.key)-1]
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	d.typeError(v, rv.Type())
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// mapKey returns the key of type kt for the table entry e.
+func (d *decodeState) mapKey(e *entry, kt reflect.Type) (reflect.Value, bool) {
+	switch {
+	case reflect.PointerTo(kt).Implements(textUnmarshalerType):
+		kv := reflect.New(kt)
+		if err := kv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(e.key)); err != nil {
+			d.saveError(err)
+			return reflect.Value{}, false
+		}
+		return kv.Elem(), true
+	case kt.Kind() == reflect.String:
+		return reflect.ValueOf(e.key).Convert(kt), true
+	}
+	kv := reflect.New(kt).Elem()
+	switch kt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(e.key, 10, 64)
+		if err != nil || kv.OverflowInt(n) {
+			d.keyError(e, kt)
+			return reflect.Value{}, false
+		}
+		kv.SetInt(n)
+	default:
+		n, err := strconv.ParseUint(e.key, 10, 64)
+		if err != nil || kv.OverflowUint(n) {
+			d.keyError(e, kt)
+			return reflect.Value{}, false
+		}
+		kv.SetUint(n)
+	}
+	return kv, true
+}
+
+// keyError records an UnmarshalTypeError for the key of e.
+func (d *decodeState) keyError(e *entry, t reflect.Type) {
+	line, col := position(d.data, e.off)
+	d.saveError(&UnmarshalTypeError{Value: "key " + strconv.Quote(e.key), Type: t, Line: line, Column: col})
+}
+
+// unknownKey records that the table entry e has no corresponding
+// struct field.
+func (d *decodeState) unknownKey(e *entry) {
+	key := append(d.key[:len(d.key):len(d.key)], e.key)
+	d.undecoded = append(d.undecoded, undecodedKey{e.off, key})
+	if d.disallowUnknownFields {
+		line, col := position(d.data, e.off)
+		d.saveError(fmt.Errorf("toml: line %d, column %d: unknown field %s", line, col, key))
+	}
+}
+
+// undecodedKeys returns the keys recorded by unknownKey in the order they
+// appear in the document, listing the keys of the tables in an array of
+// tables once.
+func (d *decodeState) undecodedKeys() []Key {
+	slices.SortFunc(d.undecoded, func(a, b undecodedKey) int { return a.off - b.off })
+	var keys []Key
+	seen := make(map[string]bool)
+	for _, u := range d.undecoded {
+		s := u.key.String()
+		if !seen[s] {
+			seen[s] = true
+			keys = append(keys, u.key)
+		}
+	}
+	return keys
+}
+
+// fieldValue decodes v into subv, which is the field with the given name
+// of the struct rv, maintaining the error context.
+func (d *decodeState) fieldValue(rv, subv reflect.Value, name string, v *value) error {
+	if d.errorContext == nil {
+		d.errorContext = new(errorContext)
+	}
+	origErrorContext := *d.errorContext
+	d.errorContext.FieldStack = append(d.errorContext.FieldStack, name)
+	d.errorContext.Struct = rv.Type()
+	err := d.value(v, subv)
+	d.errorContext.FieldStack = origErrorContext.FieldStack
+	d.errorContext.Struct = origErrorContext.Struct
+	return err
+}
+
+// structField returns the field f of the struct v, allocating embedded
+// pointers as needed. It returns the zero Value if f cannot be set.
+func (d *decodeState) structField(v reflect.Value, f *field) reflect.Value {
+	subv, err := structfield.ByIndexAlloc(v, f.index)
+	if err != nil {
+		d.saveError(errors.New("toml: " + err.Error()))
+	}
+	return subv
+}
+
+// array decodes the TOML array v into rv.
+func (d *decodeState) array(v *value, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			d.typeError(v, rv.Type())
+			return nil
+		}
+		rv.Set(reflect.ValueOf(valueInterface(v)))
+		return nil
+	case reflect.Slice:
+		n := len(v.array)
+		if rv.IsNil() || rv.Cap() < n {
+			rv.Set(reflect.MakeSlice(rv.Type(), n, n))
+		} else {
+			old := rv.Len()
+			rv.SetLen(n)
+			for i := old; i < n; i++ {
+				rv.Index(i).SetZero()
+			}
+		}
+	case reflect.Array:
+		for i := len(v.array); i < rv.Len(); i++ {
+			rv.Index(i).SetZero()
+		}
+	default:
+		d.typeError(v, rv.Type())
+		return nil
+	}
+	for i, elem := range v.array {
+		if i == rv.Len() {
+			break
+		}
+		if err := d.value(elem, rv.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// valueInterface returns the TOML value v as a Go value
+// of the types described for unmarshaling into an interface value.
+func valueInterface(v *value) any {
+	switch v.kind {
+	case kindArray:
+		a := make([]any, len(v.array))
+		for i, elem := range v.array {
+			a[i] = valueInterface(elem)
+		}
+		return a
+	case kindTable:
+		m := make(map[string]any, len(v.table.entries))
+		for _, e := range v.table.entries {
+			m[e.key] = valueInterface(e.val)
+		}
+		return m
+	}
+	return v.scalar
+}
@@ -0,0 +1,358 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Config struct {
+	Title   string
+	Owner   Owner
+	DB      Database `toml:"database"`
+	Servers map[string]Server
+	Clients Clients
+	Ignored int `toml:"-"`
+}
+
+type Owner struct {
+	Name string
+	DOB  time.Time `toml:"dob"`
+}
+
+type Database struct {
+	Enabled bool
+	Ports   []int
+	Data    [][]any
+	Temp    struct {
+		CPU  float64 `toml:"cpu"`
+		Case float32
+	} `toml:"temp_targets"`
+}
+
+type Server struct {
+	IP   netip.Addr
+	Role string
+}
+
+type Clients struct {
+	Hosts []string
+}
+
+const exampleTOML = `# This is a TOML document
+
+title = "TOML Example"
+
+[owner]
+name = "Tom Preston-Werner"
+dob = 1979-05-27T07:32:00-08:00
+
+[database]
+enabled = true
+ports = [ 8000, 8001, 8002 ]
+data = [ ["delta", "phi"], [3.14] ]
+temp_targets = { cpu = 79.5, case = 72.0 }
+
+[servers]
+
+[servers.alpha]
+ip = "10.0.0.1"
+role = "frontend"
+
+[servers.beta]
+ip = "10.0.0.2"
+role = "backend"
+`
+
+func TestUnmarshalExample(t *testing.T) {
+	var got Config
+	if err := Unmarshal([]byte(exampleTOML), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Config{
+		Title: "TOML Example",
+		Owner: Owner{Name: "Tom Preston-Werner", DOB: time.Date(1979, 5, 27, 7, 32, 0, 0, time.FixedZone("", -8*3600))},
+		DB: Database{
+			Enabled: true,
+			Ports:   []int{8000, 8001, 8002},
+			Data:    [][]any{{"delta", "phi"}, {3.14}},
+		},
+		Servers: map[string]Server{
+			"alpha": {netip.MustParseAddr("10.0.0.1"), "frontend"},
+			"beta":  {netip.MustParseAddr("10.0.0.2"), "backend"},
+		},
+	}
+	want.DB.Temp.CPU = 79.5
+	want.DB.Temp.Case = 72
+	if !got.Owner.DOB.Equal(want.Owner.DOB) {
+		t.Errorf("DOB = %v, want %v", got.Owner.DOB, want.Owner.DOB)
+	}
+	got.Owner.DOB = want.Owner.DOB
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+type Embedded struct {
+	Level1a int
+	Level1b int `toml:"renamed"`
+}
+
+type EmbeddedPtr struct {
+	Level2 string
+}
+
+type Outer struct {
+	Embedded
+	*EmbeddedPtr
+	Level1a string // hides Embedded.Level1a
+}
+
+type unmarshalerValue struct {
+	v any
+}
+
+func (u *unmarshalerValue) UnmarshalTOML(v any) error {
+	if v == "fail" {
+		return errors.New("unmarshalerValue failed")
+	}
+	u.v = v
+	return nil
+}
+
+type textValue string
+
+func (t *textValue) UnmarshalText(b []byte) error {
+	*t = textValue(strings.ToUpper(string(b)))
+	return nil
+}
+
+type intKey int
+
+func (k *intKey) UnmarshalText(b []byte) error {
+	*k = intKey(len(b))
+	return nil
+}
+
+var unmarshalTests = []struct {
+	in   string
+	ptr  any // new(type)
+	want any
+	err  string
+}{
+	// Scalars into the types they convert to.
+	{in: "a = 1", ptr: new(struct{ A int8 }), want: struct{ A int8 }{1}},
+	{in: "a = 1", ptr: new(struct{ A uint }), want: struct{ A uint }{1}},
+	{in: "a = 1", ptr: new(struct{ A float32 }), want: struct{ A float32 }{1}},
+	{in: "a = 1.5", ptr: new(struct{ A float64 }), want: struct{ A float64 }{1.5}},
+	{in: "a = 'x'", ptr: new(struct{ A *string }), want: struct{ A *string }{ptr("x")}},
+	{in: "a = 'aGVsbG8='", ptr: new(struct{ A []byte }), want: struct{ A []byte }{[]byte("hello")}},
+	{in: "a = true", ptr: new(struct{ A any }), want: struct{ A any }{true}},
+	{in: "a = 'x'", ptr: new(struct{ A textValue }), want: struct{ A textValue }{"X"}},
+	{in: "a = 1979-05-27", ptr: new(struct{ A textValue }), want: struct{ A textValue }{"1979-05-27"}},
+	{in: "a = 1979-05-27", ptr: new(struct{ A LocalDate }), want: struct{ A LocalDate }{LocalDate{1979, 5, 27}}},
+	{in: "a = '1979-05-27'", ptr: new(struct{ A LocalDate }), want: struct{ A LocalDate }{LocalDate{1979, 5, 27}}},
+	{in: "a = 07:32:00", ptr: new(struct{ A *LocalTime }), want: struct{ A *LocalTime }{&LocalTime{7, 32, 0, 0}}},
+	{in: "a = 1979-05-27T07:32:00", ptr: new(struct{ A LocalDateTime }), want: struct{ A LocalDateTime }{LocalDateTime{LocalDate{1979, 5, 27}, LocalTime{7, 32, 0, 0}}}},
+	{in: "a = 1979-05-27T07:32:00Z", ptr: new(struct{ A time.Time }), want: struct{ A time.Time }{time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC)}},
+
+	// Field matching.
+	{in: "TITLE = 'x'", ptr: new(struct{ Title string }), want: struct{ Title string }{"x"}},
+	{in: "title = 'x'\nTitle = 'y'", ptr: new(struct{ Title string }), want: struct{ Title string }{"y"}},
+	{in: "a = 1", ptr: new(struct {
+		A int `toml:"-"`
+	}), want: struct {
+		A int `toml:"-"`
+	}{}},
+	{
+		in:   "level1a = 'x'\nrenamed = 2\nlevel2 = 'y'",
+		ptr:  new(Outer),
+		want: Outer{Level1a: "x", Embedded: Embedded{Level1b: 2}, EmbeddedPtr: &EmbeddedPtr{"y"}},
+	},
+
+	// Maps.
+	{in: "a = 1\nb = 2", ptr: new(map[string]int), want: map[string]int{"a": 1, "b": 2}},
+	{in: "1 = 'a'\n-2 = 'b'", ptr: new(map[int8]string), want: map[int8]string{1: "a", -2: "b"}},
+	{in: "abc = 1", ptr: new(map[intKey]int), want: map[intKey]int{3: 1}},
+	{in: "a.b = 1", ptr: new(map[string]map[string]int), want: map[string]map[string]int{"a": {"b": 1}}},
+
+	// Arrays.
+	{in: "a = [1, 2, 3]", ptr: new(struct{ A [2]int }), want: struct{ A [2]int }{[2]int{1, 2}}},
+	{in: "a = [1]", ptr: new(struct{ A [2]int }), want: struct{ A [2]int }{[2]int{1, 0}}},
+	{in: "a = []", ptr: new(struct{ A []int }), want: struct{ A []int }{[]int{}}},
+	{in: "[[a]]\nb = 1\n[[a]]\nb = 2", ptr: new(struct{ A []struct{ B int } }), want: struct{ A []struct{ B int } }{[]struct{ B int }{{1}, {2}}}},
+
+	// Unmarshalers.
+	{in: "a = [1, {b = 'c'}]", ptr: new(struct{ A unmarshalerValue }), want: struct{ A unmarshalerValue }{unmarshalerValue{[]any{int64(1), map[string]any{"b": "c"}}}}},
+	{in: "a = 'fail'", ptr: new(struct{ A unmarshalerValue }), err: "unmarshalerValue failed"},
+	{in: "a = 'x'", ptr: new(struct{ A netip.Addr }), err: `ParseAddr("x"): unable to parse IP`},
+
+	// Type errors.
+	{in: "a = 'x'", ptr: new(struct{ A int }), err: "toml: line 1, column 5: cannot unmarshal string into Go struct field .A of type int"},
+	{in: "\n\na = 300", ptr: new(struct{ A int8 }), err: "toml: line 3, column 5: cannot unmarshal integer 300 into Go struct field .A of type int8"},
+	{in: "a = -1", ptr: new(struct{ A uint }), err: "toml: line 1, column 5: cannot unmarshal integer -1 into Go struct field .A of type uint"},
+	{in: "a = 1.5", ptr: new(struct{ A int }), err: "toml: line 1, column 5: cannot unmarshal float into Go struct field .A of type int"},
+	{in: "a = 1e300", ptr: new(struct{ A float32 }), err: "toml: line 1, column 5: cannot unmarshal float into Go struct field .A of type float32"},
+	{in: "a = 1979-05-27", ptr: new(struct{ A time.Time }), err: "toml: line 1, column 5: cannot unmarshal local date into Go struct field .A of type time.Time"},
+	{in: "a = 07:32:00", ptr: new(struct{ A string }), err: "toml: line 1, column 5: cannot unmarshal local time into Go struct field .A of type string"},
+	{in: "[a]\nb = 1", ptr: new(struct{ A []int }), err: "toml: line 1, column 1: cannot unmarshal table into Go struct field .A of type []int"},
+	{in: "a = [1]", ptr: new(struct{ A map[string]int }), err: "toml: line 1, column 5: cannot unmarshal array into Go struct field .A of type map[string]int"},
+	{in: "[a]\nb = { c = true }", ptr: new(map[string]struct{ B struct{ C string } }), err: "toml: line 2, column 11: cannot unmarshal boolean into Go struct field .B.C of type string"},
+	{in: "300 = 1", ptr: new(map[int8]int), err: `toml: line 1, column 1: cannot unmarshal key "300" into Go value of type int8`},
+	{in: "a = 1", ptr: new(map[[2]int]int), err: "toml: line 1, column 1: cannot unmarshal table into Go value of type map[[2]int]int"},
+	{in: "a = 'x'", ptr: new(struct{ A error }), err: "toml: line 1, column 5: cannot unmarshal string into Go struct field .A of type error"},
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestUnmarshal(t *testing.T) {
+	for _, tt := range unmarshalTests {
+		v := reflect.New(reflect.TypeOf(tt.ptr).Elem())
+		err := Unmarshal([]byte(tt.in), v.Interface())
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("Unmarshal(%q) into %T: error = %v, want %s", tt.in, tt.ptr, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%q) into %T: %v", tt.in, tt.ptr, err)
+			continue
+		}
+		if got := v.Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unmarshal(%q) into %T:\ngot  %#v\nwant %#v", tt.in, tt.ptr, got, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalTypeErrorContinues(t *testing.T) {
+	var v struct {
+		A int
+		B string
+		C int
+	}
+	err := Unmarshal([]byte("a = 'x'\nb = 'y'\nc = true"), &v)
+	var ute *UnmarshalTypeError
+	if !errors.As(err, &ute) || ute.Line != 1 || ute.Field != "A" {
+		t.Errorf("error = %v, want UnmarshalTypeError for field A on line 1", err)
+	}
+	if v.B != "y" {
+		t.Errorf("B = %q, want %q", v.B, "y")
+	}
+}
+
+func TestUnmarshalReuse(t *testing.T) {
+	v := struct {
+		M map[string]int
+		S []int
+		P *int
+	}{
+		M: map[string]int{"old": 1},
+		S: make([]int, 1, 10),
+		P: new(int),
+	}
+	p := v.P
+	if err := Unmarshal([]byte("m = {new = 2}\ns = [3, 4]\np = 5"), &v); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"old": 1, "new": 2}; !reflect.DeepEqual(v.M, want) {
+		t.Errorf("M = %v, want %v", v.M, want)
+	}
+	if want := []int{3, 4}; !reflect.DeepEqual(v.S, want) || cap(v.S) != 10 {
+		t.Errorf("S = %v with cap %d, want %v with cap 10", v.S, cap(v.S), want)
+	}
+	if v.P != p || *p != 5 {
+		t.Errorf("P was reallocated or not set")
+	}
+}
+
+func TestInvalidUnmarshal(t *testing.T) {
+	for _, tt := range []struct {
+		v    any
+		want string
+	}{
+		{nil, "toml: Unmarshal(nil)"},
+		{struct{}{}, "toml: Unmarshal(non-pointer struct {})"},
+		{(*int)(nil), "toml: Unmarshal(nil *int)"},
+	} {
+		err := Unmarshal([]byte("a = 1"), tt.v)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Unmarshal into %#v: error = %v, want %s", tt.v, err, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalSyntaxErrorLeavesValue(t *testing.T) {
+	v := map[string]any{"x": 1}
+	if err := Unmarshal([]byte("a = 1\nb ="), &v); err == nil {
+		t.Fatal("Unmarshal succeeded")
+	}
+	if len(v) != 1 {
+		t.Errorf("Unmarshal modified the map: %v", v)
+	}
+}
+
+func TestLocalTypes(t *testing.T) {
+	for _, tt := range []struct {
+		v    interface{ String() string }
+		text string
+	}{
+		{LocalDate{2024, 2, 29}, "2024-02-29"},
+		{LocalDate{1, 1, 1}, "0001-01-01"},
+		{LocalTime{7, 32, 0, 0}, "07:32:00"},
+		{LocalTime{23, 59, 59, 500000000}, "23:59:59.5"},
+		{LocalTime{0, 0, 0, 1}, "00:00:00.000000001"},
+		{LocalDateTime{LocalDate{1979, 5, 27}, LocalTime{0, 32, 0, 999999000}}, "1979-05-27T00:32:00.999999"},
+	} {
+		if got := tt.v.String(); got != tt.text {
+			t.Errorf("%#v.String() = %q, want %q", tt.v, got, tt.text)
+		}
+		p := reflect.New(reflect.TypeOf(tt.v))
+		if err := p.Interface().(interface{ UnmarshalText([]byte) error }).UnmarshalText([]byte(tt.text)); err != nil {
+			t.Errorf("UnmarshalText(%q): %v", tt.text, err)
+		} else if got := p.Elem().Interface(); got != tt.v {
+			t.Errorf("UnmarshalText(%q) = %#v, want %#v", tt.text, got, tt.v)
+		}
+	}
+
+	for _, tt := range []struct {
+		v    interface{ UnmarshalText([]byte) error }
+		text string
+	}{
+		{new(LocalDate), "2023-02-29"},
+		{new(LocalDate), "2023-02-28T00:00:00"},
+		{new(LocalDate), " 2023-02-28"},
+		{new(LocalTime), "24:00:00"},
+		{new(LocalTime), "12:00"},
+		{new(LocalDateTime), "2023-02-28T00:00:00Z"},
+		{new(LocalDateTime), "2023-02-28"},
+	} {
+		if err := tt.v.UnmarshalText([]byte(tt.text)); err == nil {
+			t.Errorf("%T.UnmarshalText(%q) succeeded", tt.v, tt.text)
+		}
+	}
+
+	for _, v := range []interface{ MarshalText() ([]byte, error) }{
+		LocalDate{2023, 2, 29},
+		LocalDate{10000, 1, 1},
+		LocalTime{0, 0, 60, 0},
+		LocalTime{0, 0, 0, -1},
+		LocalDateTime{LocalDate{2023, 1, 1}, LocalTime{25, 0, 0, 0}},
+	} {
+		if _, err := v.MarshalText(); err == nil {
+			t.Errorf("%#v.MarshalText() succeeded", v)
+		}
+	}
+
+	dt := LocalDateTime{LocalDate{1979, 5, 27}, LocalTime{7, 32, 0, 0}}
+	if got, want := dt.In(time.UTC), time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("In(UTC) = %v, want %v", got, want)
+	}
+}
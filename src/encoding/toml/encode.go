@@ -0,0 +1,669 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package toml implements encoding and decoding of TOML documents as
+// defined by the TOML 1.0.0 specification at https://toml.io/en/v1.0.0.
+// The mapping between TOML and Go values is described in the
+// documentation for the Marshal and Unmarshal functions.
+//
+// TOML is a configuration file format. A document is a table of
+// key/value pairs, which may hold further tables and arrays of tables:
+//
+//	title = "example"
+//
+//	[server]
+//	host = "localhost"
+//	ports = [8080, 8081]
+//
+//	[[user]]
+//	name = "gopher"
+//
+// The package follows the conventions of [encoding/json]: struct fields
+// are controlled by a "toml" struct tag that accepts the same names and
+// options as the "json" tag.
+//
+// Errors in the input are reported with their line and column. A
+// [Decoder] can additionally report the keys of a document that did not
+// match any struct field, so that programs can warn about misspelled
+// configuration settings, or reject them with
+// [Decoder.DisallowUnknownFields].
+package toml
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/internal/structfield"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Marshal returns the TOML document encoding of v, which must be a
+// struct or a map, or a pointer or interface holding one.
+//
+// Marshal traverses the value v recursively.
+// If an encountered value implements [Marshaler]
+// and is not a nil pointer, Marshal calls [Marshaler.MarshalTOML]
+// and encodes the value it returns in its place. If no
+// [Marshaler.MarshalTOML] method is present but the value implements
+// [encoding.TextMarshaler] instead, Marshal calls
+// [encoding.TextMarshaler.MarshalText] and encodes the result as a
+// TOML string.
+//
+// Otherwise, Marshal uses the following type-dependent default encodings:
+//
+// Boolean values encode as TOML booleans.
+//
+// Integer values encode as TOML integers. Unsigned integers greater than
+// [math.MaxInt64] cannot be represented in TOML and cause Marshal to
+// return an [UnsupportedValueError].
+//
+// Floating point values encode as TOML floats, including inf and nan.
+//
+// String values encode as TOML basic strings. Strings that are not valid
+// UTF-8 cause Marshal to return an [UnsupportedValueError].
+//
+// Array and slice values encode as TOML arrays, except that []byte
+// encodes as a base64-encoded string. A nil slice encodes as an empty
+// array. A non-empty array or slice of tables encodes as an array of
+// tables, written as a sequence of [[header]] sections.
+//
+// Struct values encode as TOML tables, with a key for each exported
+// field. The "toml" key in a field's tag gives the key's name and the
+// "omitempty", "omitzero" and "-" options, and fields of embedded
+// structs are promoted, as for the "json" tag of [encoding/json]:
+//
+//	Port int `toml:"port,omitempty"`
+//
+// Map values encode as TOML tables. The map's key type must either be a
+// string, an integer type, or implement [encoding.TextMarshaler]. The map
+// keys are sorted and used as table keys. A nil map encodes as an empty
+// table.
+//
+// The key/value pairs of a table are written before its tables and
+// arrays of tables, which follow as separate sections. Tables within
+// arrays are written as inline tables.
+//
+// Pointer values encode as the value pointed to, and interface values
+// encode as the value contained in the interface. TOML has no null
+// value: struct fields and map entries holding a nil pointer or nil
+// interface value are omitted, and an array holding one causes Marshal to
+// return an [UnsupportedValueError].
+//
+// A [time.Time] encodes as a TOML offset date-time, and a [LocalDateTime],
+// [LocalDate] or [LocalTime] encodes as the corresponding TOML local
+// date or time. Times whose year is outside the range 0 through 9999
+// cannot be represented in TOML.
+//
+// Channel, complex, and function values cannot be encoded in TOML.
+// Attempting to encode such a value causes Marshal to return
+// an [UnsupportedTypeError].
+//
+// Values nested more than 1000 deep, which include cyclic data
+// structures, cause Marshal to return an [UnsupportedValueError].
+func Marshal(v any) ([]byte, error) {
+	e := &encodeState{}
+	if err := e.marshal(v); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// Marshaler is the interface implemented by types that can marshal
+// themselves into a value that this package can encode, such as a
+// string, a number or a map. MarshalTOML is called again for the
+// returned value if it implements Marshaler.
+type Marshaler interface {
+	MarshalTOML() (any, error)
+}
+
+// An UnsupportedTypeError is returned by [Marshal] when attempting
+// to encode an unsupported value type.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "toml: unsupported type: " + e.Type.String()
+}
+
+// An UnsupportedValueError is returned by [Marshal] when attempting
+// to encode an unsupported value.
+type UnsupportedValueError struct {
+	Value reflect.Value
+	Str   string
+}
+
+func (e *UnsupportedValueError) Error() string {
+	return "toml: unsupported value: " + e.Str
+}
+
+// A MarshalerError represents an error from calling a
+// [Marshaler.MarshalTOML] or [encoding.TextMarshaler.MarshalText] method.
+type MarshalerError struct {
+	Type       reflect.Type
+	Err        error
+	sourceFunc string
+}
+
+func (e *MarshalerError) Error() string {
+	srcFunc := e.sourceFunc
+	if srcFunc == "" {
+		srcFunc = "MarshalTOML"
+	}
+	return "toml: error calling " + srcFunc +
+		" for type " + e.Type.String() +
+		": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *MarshalerError) Unwrap() error { return e.Err }
+
+// An encodeState encodes a TOML document into a byte slice.
+type encodeState struct {
+	buf   []byte
+	depth int // nesting depth of the value being encoded
+}
+
+func (e *encodeState) marshal(v any) (err error) {
+	defer catch(&err)
+	rv := e.resolve(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return &UnsupportedValueError{rv, "nil document"}
+	}
+	if !isTable(rv) {
+		return &UnsupportedTypeError{rv.Type()}
+	}
+	e.table(nil, rv)
+	return nil
+}
+
+// error aborts the encoding by panicking with err wrapped in tomlError.
+func (e *encodeState) error(err error) {
+	panic(tomlError{err})
+}
+
+// enter records that the encoder descends into the table or array v.
+func (e *encodeState) enter(v reflect.Value) {
+	e.depth++
+	if e.depth > maxDepth {
+		e.error(&UnsupportedValueError{v, "exceeded max depth"})
+	}
+}
+
+func (e *encodeState) leave() { e.depth-- }
+
+var (
+	marshalerType     = reflect.TypeFor[Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	timeType          = reflect.TypeFor[time.Time]()
+	localDateTimeType = reflect.TypeFor[LocalDateTime]()
+	localDateType     = reflect.TypeFor[LocalDate]()
+	localTimeType     = reflect.TypeFor[LocalTime]()
+)
+
+// resolve returns the value to encode in place of v, calling
+// MarshalTOML methods and following pointers and interfaces.
+// It returns the zero Value if v is nil.
+func (e *encodeState) resolve(v reflect.Value) reflect.Value {
+	for n := 0; ; n++ {
+		if !v.IsValid() || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return reflect.Value{}
+		}
+		if n > maxDepth {
+			e.error(&UnsupportedValueError{v, "exceeded max depth"})
+		}
+		var m Marshaler
+		if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(marshalerType) {
+			m = v.Addr().Interface().(Marshaler)
+		} else if v.Type().Implements(marshalerType) && v.CanInterface() {
+			m = v.Interface().(Marshaler)
+		}
+		if m != nil {
+			x, err := m.MarshalTOML()
+			if err != nil {
+				e.error(&MarshalerError{v.Type(), err, "MarshalTOML"})
+			}
+			v = reflect.ValueOf(x)
+			continue
+		}
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			return v
+		}
+		v = v.Elem()
+	}
+}
+
+// textMarshaler returns the encoding.TextMarshaler implementation
+// of v, if any.
+func textMarshaler(v reflect.Value) encoding.TextMarshaler {
+	if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		return v.Addr().Interface().(encoding.TextMarshaler)
+	}
+	if v.Type().Implements(textMarshalerType) && v.CanInterface() {
+		return v.Interface().(encoding.TextMarshaler)
+	}
+	return nil
+}
+
+// isTable reports whether the resolved value v encodes as a table.
+func isTable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct:
+		return textMarshaler(v) == nil
+	}
+	return false
+}
+
+// An encodeEntry is a key/value pair of a table being encoded.
+type encodeEntry struct {
+	key string
+	val reflect.Value // resolved value
+
+	// If val is an array or slice, elems holds its resolved elements,
+	// and tables reports whether they are all tables.
+	elems  []reflect.Value
+	tables bool
+}
+
+// entries returns the key/value pairs of the resolved table v,
+// omitting nil values.
+func (e *encodeState) entries(v reflect.Value) []encodeEntry {
+	var entries []encodeEntry
+	add := func(key string, fv reflect.Value) {
+		fv = e.resolve(fv)
+		if !fv.IsValid() {
+			return
+		}
+		ent := encodeEntry{key: key, val: fv}
+		if isArray(fv) {
+			ent.elems = make([]reflect.Value, fv.Len())
+			ent.tables = len(ent.elems) > 0
+			for i := range ent.elems {
+				ent.elems[i] = e.resolve(fv.Index(i))
+				ent.tables = ent.tables && isTable(ent.elems[i])
+			}
+		}
+		entries = append(entries, ent)
+	}
+
+	if v.Kind() == reflect.Struct {
+		for _, f := range cachedTypeFields(v.Type()).list {
+			fv, ok := structfield.ByIndex(v, f.index)
+			if !ok ||
+				(f.omitEmpty && isEmptyValue(fv)) ||
+				(f.omitZero && (f.isZero == nil && fv.IsZero() || (f.isZero != nil && f.isZero(fv)))) {
+				continue
+			}
+			add(f.name, fv)
+		}
+		return entries
+	}
+
+	keys := make([]string, v.Len())
+	vals := make([]reflect.Value, v.Len())
+	for i, mi := 0, v.MapRange(); mi.Next(); i++ {
+		keys[i] = e.mapKey(mi.Key())
+		vals[i] = mi.Value()
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int { return strings.Compare(keys[i], keys[j]) })
+	for _, i := range order {
+		add(keys[i], vals[i])
+	}
+	return entries
+}
+
+// mapKey returns the table key for the map key k.
+func (e *encodeState) mapKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return ""
+		}
+		b, err := tm.MarshalText()
+		if err != nil {
+			e.error(&MarshalerError{k.Type(), err, "MarshalText"})
+		}
+		return string(b)
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10)
+	}
+	e.error(&UnsupportedTypeError{k.Type()})
+	panic("unreachable")
+}
+
+// isArray reports whether the resolved value v encodes as an array.
+func isArray(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8 && textMarshaler(v) == nil
+	case reflect.Array:
+		return textMarshaler(v) == nil
+	}
+	return false
+}
+
+// table writes the key/value pairs of the resolved table v, which has the
+// given key, followed by its tables and arrays of tables.
+func (e *encodeState) table(key Key, v reflect.Value) {
+	e.enter(v)
+	entries := e.entries(v)
+	// Key/value pairs must precede the sections of the table,
+	// to which they would belong otherwise.
+	for _, ent := range entries {
+		if isTable(ent.val) || ent.tables {
+			continue
+		}
+		e.buf = appendKeyPart(e.buf, ent.key)
+		e.buf = append(e.buf, " = "...)
+		if ent.elems != nil {
+			e.array(ent.val, ent.elems)
+		} else {
+			e.value(ent.val)
+		}
+		e.buf = append(e.buf, '\n')
+	}
+	for _, ent := range entries {
+		switch {
+		case isTable(ent.val):
+			sub := append(key[:len(key):len(key)], ent.key)
+			e.header(sub, false)
+			e.table(sub, ent.val)
+		case ent.tables:
+			sub := append(key[:len(key):len(key)], ent.key)
+			for _, elem := range ent.elems {
+				e.header(sub, true)
+				e.table(sub, elem)
+			}
+		}
+	}
+	e.leave()
+}
+
+// header writes the header of a table or of an element of an array of
+// tables, separated by a blank line from what precedes it.
+func (e *encodeState) header(key Key, array bool) {
+	if len(e.buf) > 0 {
+		e.buf = append(e.buf, '\n')
+	}
+	e.buf = append(e.buf, '[')
+	if array {
+		e.buf = append(e.buf, '[')
+	}
+	e.buf = key.appendTo(e.buf)
+	e.buf = append(e.buf, ']')
+	if array {
+		e.buf = append(e.buf, ']')
+	}
+	e.buf = append(e.buf, '\n')
+}
+
+// value writes the resolved value v as an inline value.
+func (e *encodeState) value(v reflect.Value) {
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if y := t.Year(); y < 0 || y > 9999 {
+			e.error(&UnsupportedValueError{v, "time year outside of range [0,9999]"})
+		}
+		e.buf = t.AppendFormat(e.buf, time.RFC3339Nano)
+		return
+	case localDateTimeType:
+		e.localValue(v, v.Interface().(LocalDateTime))
+		return
+	case localDateType:
+		e.localValue(v, v.Interface().(LocalDate))
+		return
+	case localTimeType:
+		e.localValue(v, v.Interface().(LocalTime))
+		return
+	}
+	if tm := textMarshaler(v); tm != nil {
+		b, err := tm.MarshalText()
+		if err != nil {
+			e.error(&MarshalerError{v.Type(), err, "MarshalText"})
+		}
+		e.string(v, string(b))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.buf = strconv.AppendBool(e.buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf = strconv.AppendInt(e.buf, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			e.error(&UnsupportedValueError{v, strconv.FormatUint(v.Uint(), 10)})
+		}
+		e.buf = strconv.AppendUint(e.buf, v.Uint(), 10)
+	case reflect.Float32:
+		e.buf = appendFloat(e.buf, v.Float(), 32)
+	case reflect.Float64:
+		e.buf = appendFloat(e.buf, v.Float(), 64)
+	case reflect.String:
+		e.string(v, v.String())
+	case reflect.Slice:
+		if !isArray(v) {
+			e.string(v, base64.StdEncoding.EncodeToString(v.Bytes()))
+			return
+		}
+		fallthrough
+	case reflect.Array:
+		elems := make([]reflect.Value, v.Len())
+		for i := range elems {
+			elems[i] = e.resolve(v.Index(i))
+		}
+		e.array(v, elems)
+	case reflect.Map, reflect.Struct:
+		e.enter(v)
+		e.buf = append(e.buf, '{')
+		for i, ent := range e.entries(v) {
+			if i > 0 {
+				e.buf = append(e.buf, ", "...)
+			}
+			e.buf = appendKeyPart(e.buf, ent.key)
+			e.buf = append(e.buf, " = "...)
+			if ent.elems != nil {
+				e.array(ent.val, ent.elems)
+			} else {
+				e.value(ent.val)
+			}
+		}
+		e.buf = append(e.buf, '}')
+		e.leave()
+	default:
+		e.error(&UnsupportedTypeError{v.Type()})
+	}
+}
+
+// localValue writes a local date or time.
+func (e *encodeState) localValue(v reflect.Value, tm encoding.TextMarshaler) {
+	b, err := tm.MarshalText()
+	if err != nil {
+		e.error(&UnsupportedValueError{v, v.Type().Name() + " out of range"})
+	}
+	e.buf = append(e.buf, b...)
+}
+
+// array writes the array v, whose resolved elements are elems,
+// as an inline value.
+func (e *encodeState) array(v reflect.Value, elems []reflect.Value) {
+	e.enter(v)
+	e.buf = append(e.buf, '[')
+	for i, elem := range elems {
+		if i > 0 {
+			e.buf = append(e.buf, ", "...)
+		}
+		if !elem.IsValid() {
+			e.error(&UnsupportedValueError{v, "nil element in array"})
+		}
+		e.value(elem)
+	}
+	e.buf = append(e.buf, ']')
+	e.leave()
+}
+
+func (e *encodeState) string(v reflect.Value, s string) {
+	if !utf8.ValidString(s) {
+		e.error(&UnsupportedValueError{v, "invalid UTF-8 in string " + strconv.Quote(s)})
+	}
+	e.buf = appendString(e.buf, s)
+}
+
+const hex = "0123456789ABCDEF"
+
+// appendString appends s as a TOML basic string.
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != '"' && c != '\\' && c != 0x7f {
+			continue
+		}
+		b = append(b, s[start:i]...)
+		switch c {
+		case '"', '\\':
+			b = append(b, '\\', c)
+		case '\b':
+			b = append(b, '\\', 'b')
+		case '\t':
+			b = append(b, '\\', 't')
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\f':
+			b = append(b, '\\', 'f')
+		case '\r':
+			b = append(b, '\\', 'r')
+		default:
+			b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+		}
+		start = i + 1
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// appendKeyPart appends a simple key, quoting it unless it is a bare key.
+func appendKeyPart(b []byte, k string) []byte {
+	if k == "" {
+		return append(b, `""`...)
+	}
+	for i := 0; i < len(k); i++ {
+		if !isBare(k[i]) {
+			return appendString(b, k)
+		}
+	}
+	return append(b, k...)
+}
+
+// appendFloat appends f, which has the given bit size, as a TOML float.
+func appendFloat(b []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(b, "nan"...)
+	case math.IsInf(f, 1):
+		return append(b, "inf"...)
+	case math.IsInf(f, -1):
+		return append(b, "-inf"...)
+	}
+	// Use the shortest representation, in exponential form for very
+	// large and small values as encoding/json does.
+	fmt := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			fmt = 'e'
+		}
+	}
+	n := len(b)
+	b = strconv.AppendFloat(b, f, fmt, -1, bits)
+	if fmt == 'f' && !bytes.ContainsRune(b[n:], '.') {
+		// A TOML float needs a fractional part or an exponent.
+		b = append(b, ".0"...)
+	}
+	return b
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+type structFields struct {
+	list   []field
+	byName map[string]*field
+}
+
+// A field is a struct field that is encoded as a key of a table.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	omitZero  bool
+	isZero    func(reflect.Value) bool
+}
+
+// typeFields returns the fields that TOML should recognize for the
+// struct type t.
+func typeFields(t reflect.Type) structFields {
+	list := structfield.Fields(t, "toml", func(name string, _ structfield.Options) string {
+		if !structfield.ValidName(name) {
+			return ""
+		}
+		return name
+	})
+	fields := make([]field, len(list))
+	byName := make(map[string]*field, len(list))
+	for i, sf := range list {
+		f := &fields[i]
+		*f = field{
+			name:      sf.Name,
+			index:     sf.Index,
+			omitEmpty: sf.Options.Contains("omitempty"),
+			omitZero:  sf.Options.Contains("omitzero"),
+		}
+		if f.omitZero {
+			f.isZero = structfield.IsZeroFunc(sf.Type)
+		}
+		byName[f.name] = f
+	}
+	return structFields{fields, byName}
+}
+
+var fieldCache sync.Map // map[reflect.Type]structFields
+
+// cachedTypeFields is like typeFields but uses a cache to avoid repeated work.
+func cachedTypeFields(t reflect.Type) structFields {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.(structFields)
+	}
+	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	return f.(structFields)
+}
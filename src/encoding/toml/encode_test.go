@@ -0,0 +1,226 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"errors"
+	"math"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalExample(t *testing.T) {
+	c := Config{
+		Title: "TOML Example",
+		Owner: Owner{Name: "Tom Preston-Werner", DOB: time.Date(1979, 5, 27, 7, 32, 0, 0, time.FixedZone("", -8*3600))},
+		DB: Database{
+			Enabled: true,
+			Ports:   []int{8000, 8001, 8002},
+			Data:    [][]any{{"delta", "phi"}, {3.14}},
+		},
+		Servers: map[string]Server{
+			"beta":  {netip.MustParseAddr("10.0.0.2"), "backend"},
+			"alpha": {netip.MustParseAddr("10.0.0.1"), "frontend"},
+		},
+		Ignored: 1,
+	}
+	c.DB.Temp.CPU = 79.5
+	c.DB.Temp.Case = 72
+	got, err := Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `Title = "TOML Example"
+
+[Owner]
+Name = "Tom Preston-Werner"
+dob = 1979-05-27T07:32:00-08:00
+
+[database]
+Enabled = true
+Ports = [8000, 8001, 8002]
+Data = [["delta", "phi"], [3.14]]
+
+[database.temp_targets]
+cpu = 79.5
+Case = 72.0
+
+[Servers]
+
+[Servers.alpha]
+IP = "10.0.0.1"
+Role = "frontend"
+
+[Servers.beta]
+IP = "10.0.0.2"
+Role = "backend"
+
+[Clients]
+Hosts = []
+`
+	if string(got) != want {
+		t.Errorf("Marshal:\n%s\nwant:\n%s", got, want)
+	}
+
+	var c2 Config
+	if err := Unmarshal(got, &c2); err != nil {
+		t.Fatal(err)
+	}
+	c.Ignored = 0
+	c.Clients.Hosts = []string{}
+	if !c2.Owner.DOB.Equal(c.Owner.DOB) {
+		t.Errorf("DOB = %v, want %v", c2.Owner.DOB, c.Owner.DOB)
+	}
+	c2.Owner.DOB = c.Owner.DOB
+	if !reflect.DeepEqual(c2, c) {
+		t.Errorf("round trip:\ngot  %+v\nwant %+v", c2, c)
+	}
+}
+
+type marshalerValue struct {
+	v   any
+	err error
+}
+
+func (m marshalerValue) MarshalTOML() (any, error) { return m.v, m.err }
+
+type addrText struct{ s string }
+
+func (m *addrText) MarshalText() ([]byte, error) { return []byte(m.s), nil }
+
+type omitFields struct {
+	A int            `toml:"a,omitempty"`
+	B []int          `toml:"b,omitempty"`
+	C *int           `toml:"c,omitzero"`
+	D time.Time      `toml:"d,omitzero"`
+	E map[string]int `toml:"e,omitempty"`
+}
+
+var marshalTests = []struct {
+	v    any
+	want string
+}{
+	{struct{}{}, ""},
+	{map[string]any{}, ""},
+	{&struct{ A int }{1}, "A = 1\n"},
+	{map[string]any{"b": 1, "a": "x", "c": nil, "d": (*int)(nil)}, "a = \"x\"\nb = 1\n"},
+	{map[int]bool{10: true, 9: false}, "10 = true\n9 = false\n"},
+	{map[string]int{"a b": 1, "": 2, "ключ": 3, "a.b": 4}, "\"\" = 2\n\"a b\" = 1\n\"a.b\" = 4\n\"ключ\" = 3\n"},
+
+	// Scalars.
+	{map[string]any{"a": int8(-5), "b": uint64(math.MaxInt64), "c": true}, "a = -5\nb = 9223372036854775807\nc = true\n"},
+	{map[string]any{
+		"a": 1.0, "b": -0.5, "c": 1e21, "d": 1e-7, "e": float32(0.1), "f": math.Inf(1), "g": math.Inf(-1), "h": math.NaN(), "i": math.Copysign(0, -1),
+	}, "a = 1.0\nb = -0.5\nc = 1e+21\nd = 1e-07\ne = 0.1\nf = inf\ng = -inf\nh = nan\ni = -0.0\n"},
+	{map[string]any{"s": "tab\t\"quote\" \\ nl\n del\x7f nul\x00 é"}, `s = "tab\t\"quote\" \\ nl\n del\u007F nul\u0000 é"` + "\n"},
+	{map[string]any{"b": []byte("hello"), "a": [2]byte{1, 2}}, "a = [1, 2]\nb = \"aGVsbG8=\"\n"},
+	{map[string]any{"t": time.Date(1979, 5, 27, 7, 32, 0, 999999000, time.UTC)}, "t = 1979-05-27T07:32:00.999999Z\n"},
+	{map[string]any{
+		"a": LocalDate{1979, 5, 27},
+		"b": LocalTime{7, 32, 0, 0},
+		"c": LocalDateTime{LocalDate{1979, 5, 27}, LocalTime{7, 32, 0, 500000000}},
+	}, "a = 1979-05-27\nb = 07:32:00\nc = 1979-05-27T07:32:00.5\n"},
+	{map[string]any{"a": netip.MustParseAddr("::1"), "b": &addrText{"text"}}, "a = \"::1\"\nb = \"text\"\n"},
+	{map[string]any{"a": marshalerValue{v: []int{1, 2}}, "b": marshalerValue{v: marshalerValue{v: "nested"}}}, "a = [1, 2]\nb = \"nested\"\n"},
+	{map[string]any{"a": marshalerValue{v: map[string]int{"x": 1}}}, "[a]\nx = 1\n"},
+	{marshalerValue{v: map[string]int{"x": 1}}, "x = 1\n"},
+
+	// Tables.
+	{map[string]any{"t": map[string]any{}, "a": 1}, "a = 1\n\n[t]\n"},
+	{map[string]any{"t": map[string]any{"u": map[string]any{"v": 1}}}, "[t]\n\n[t.u]\nv = 1\n"},
+	{map[string]any{"a b": map[string]int{"c.d": 1}}, "[\"a b\"]\n\"c.d\" = 1\n"},
+	{struct{ M map[string]int }{}, "[M]\n"},
+
+	// Arrays.
+	{map[string]any{"a": []any{}, "b": []int(nil), "c": []any{1, "x", []int{2}}}, "a = []\nb = []\nc = [1, \"x\", [2]]\n"},
+	{map[string]any{"a": []any{map[string]int{"x": 1}, 2}}, "a = [{x = 1}, 2]\n"},
+	{map[string]any{"a": []any{map[string]any{"x": 1, "y": []any{map[string]int{}}}}}, "[[a]]\nx = 1\n\n[[a.y]]\n"},
+	{map[string]any{"a": []map[string]any{{"x": 1}, {"t": map[string]int{"y": 2}}}}, "[[a]]\nx = 1\n\n[[a]]\n\n[a.t]\ny = 2\n"},
+	{map[string]any{"a": [][]map[string]int{{{"x": 1}}}}, "a = [[{x = 1}]]\n"},
+	{map[string]any{"a": []any{map[string]any{"t": map[string]any{"u": []int{1}}}, 1}}, "a = [{t = {u = [1]}}, 1]\n"},
+
+	// Options.
+	{omitFields{}, ""},
+	{omitFields{A: 1, B: []int{}, C: new(int), D: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, "a = 1\nc = 0\nd = 2000-01-01T00:00:00Z\n"},
+	{Outer{Level1a: "x", Embedded: Embedded{Level1a: 1, Level1b: 2}}, "renamed = 2\nLevel1a = \"x\"\n"},
+	{Outer{EmbeddedPtr: &EmbeddedPtr{"y"}}, "renamed = 0\nLevel2 = \"y\"\nLevel1a = \"\"\n"},
+}
+
+func TestMarshal(t *testing.T) {
+	for _, tt := range marshalTests {
+		got, err := Marshal(tt.v)
+		if err != nil {
+			t.Errorf("Marshal(%#v): %v", tt.v, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%#v):\ngot  %q\nwant %q", tt.v, got, tt.want)
+			continue
+		}
+
+		// The output must be a valid document that holds the same
+		// values after another round trip.
+		var v1, v2 any
+		if err := Unmarshal(got, &v1); err != nil {
+			t.Errorf("Unmarshal(Marshal(%#v)): %v", tt.v, err)
+			continue
+		}
+		again, err := Marshal(v1)
+		if err != nil {
+			t.Errorf("Marshal(Unmarshal(Marshal(%#v))): %v", tt.v, err)
+			continue
+		}
+		if err := Unmarshal(again, &v2); err != nil {
+			t.Errorf("Unmarshal(%q): %v", again, err)
+			continue
+		}
+		if !reflect.DeepEqual(v1, v2) && !strings.Contains(tt.want, "nan") {
+			t.Errorf("round trip of %#v:\ngot  %#v\nwant %#v", tt.v, v2, v1)
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	type cycle struct {
+		Next *cycle
+	}
+	c := &cycle{}
+	c.Next = c
+
+	errMarshal := errors.New("marshal failed")
+	for _, tt := range []struct {
+		v    any
+		want string
+	}{
+		{nil, "toml: unsupported value: nil document"},
+		{(*struct{})(nil), "toml: unsupported value: nil document"},
+		{1, "toml: unsupported type: int"},
+		{[]any{map[string]int{}}, "toml: unsupported type: []interface {}"},
+		{time.Time{}, "toml: unsupported type: time.Time"},
+		{map[string]any{"a": make(chan int)}, "toml: unsupported type: chan int"},
+		{map[string]any{"a": complex(1, 2)}, "toml: unsupported type: complex128"},
+		{map[[2]int]int{{1, 2}: 3}, "toml: unsupported type: [2]int"},
+		{map[string]any{"a": uint64(math.MaxInt64 + 1)}, "toml: unsupported value: 9223372036854775808"},
+		{map[string]any{"a": "\xff"}, `toml: unsupported value: invalid UTF-8 in string "\xff"`},
+		{map[string]any{"a": []any{1, nil}}, "toml: unsupported value: nil element in array"},
+		{map[string]any{"a": time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)}, "toml: unsupported value: time year outside of range [0,9999]"},
+		{map[string]any{"a": LocalDate{2023, 2, 29}}, "toml: unsupported value: LocalDate out of range"},
+		{c, "toml: unsupported value: exceeded max depth"},
+		{map[string]any{"a": marshalerValue{err: errMarshal}}, "toml: error calling MarshalTOML for type toml.marshalerValue: marshal failed"},
+	} {
+		_, err := Marshal(tt.v)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Marshal(%#v): error = %v, want %s", tt.v, err, tt.want)
+		}
+	}
+
+	_, err := Marshal(map[string]any{"a": marshalerValue{err: errMarshal}})
+	if !errors.Is(err, errMarshal) {
+		t.Errorf("Marshal error %v does not wrap the MarshalTOML error", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml_test
+
+import (
+	"encoding/toml"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+func ExampleUnmarshal() {
+	const doc = `
+name = "api"
+timeout = "30s"
+
+[listen]
+addr = "0.0.0.0"
+port = 8080
+
+[[backend]]
+url = "http://10.0.0.1"
+weight = 2
+
+[[backend]]
+url = "http://10.0.0.2"
+`
+	type Backend struct {
+		URL    string `toml:"url"`
+		Weight int    `toml:"weight"`
+	}
+	type Config struct {
+		Name    string
+		Timeout string
+		Listen  struct {
+			Addr string
+			Port uint16
+		}
+		Backends []Backend `toml:"backend"`
+	}
+	var c Config
+	if err := toml.Unmarshal([]byte(doc), &c); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s listens on %s:%d\n", c.Name, c.Listen.Addr, c.Listen.Port)
+	for _, b := range c.Backends {
+		fmt.Printf("%s weight %d\n", b.URL, b.Weight)
+	}
+	// Output:
+	// api listens on 0.0.0.0:8080
+	// http://10.0.0.1 weight 2
+	// http://10.0.0.2 weight 0
+}
+
+func ExampleMarshal() {
+	type Release struct {
+		Version string         `toml:"version"`
+		Date    toml.LocalDate `toml:"date"`
+		Tags    []string       `toml:"tags,omitempty"`
+	}
+	type Project struct {
+		Name     string    `toml:"name"`
+		Updated  time.Time `toml:"updated"`
+		Owner    map[string]string
+		Releases []Release `toml:"release"`
+	}
+	p := Project{
+		Name:    "toml",
+		Updated: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Owner:   map[string]string{"name": "Gopher", "email": "gopher@example.com"},
+		Releases: []Release{
+			{Version: "1.0.0", Date: toml.LocalDate{Year: 2023, Month: time.June, Day: 1}, Tags: []string{"stable"}},
+			{Version: "1.1.0", Date: toml.LocalDate{Year: 2024, Month: time.January, Day: 15}},
+		},
+	}
+	b, err := toml.Marshal(p)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(b)
+	// Output:
+	// name = "toml"
+	// updated = 2024-03-01T12:00:00Z
+	//
+	// [Owner]
+	// email = "gopher@example.com"
+	// name = "Gopher"
+	//
+	// [[release]]
+	// version = "1.0.0"
+	// date = 2023-06-01
+	// tags = ["stable"]
+	//
+	// [[release]]
+	// version = "1.1.0"
+	// date = 2024-01-15
+}
+
+func ExampleDecoder_Undecoded() {
+	const doc = `
+port = 8080
+debug = true
+
+[log]
+level = "info"
+format = "json"
+`
+	var c struct {
+		Port int
+		Log  struct {
+			Level string
+		}
+	}
+	dec := toml.NewDecoder(strings.NewReader(doc))
+	if err := dec.Decode(&c); err != nil {
+		log.Fatal(err)
+	}
+	for _, key := range dec.Undecoded() {
+		fmt.Println("unused setting:", key)
+	}
+	// Output:
+	// unused setting: debug
+	// unused setting: log.format
+}
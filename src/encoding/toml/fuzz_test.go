@@ -0,0 +1,52 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzUnmarshal(f *testing.F) {
+	f.Add([]byte(exampleTOML))
+	f.Add([]byte(undecodedTOML))
+	for _, tt := range parseTests {
+		f.Add([]byte(tt.in))
+	}
+	for _, tt := range syntaxErrorTests {
+		f.Add([]byte(tt.in))
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		for _, typ := range []func() any{
+			func() any { return new(any) },
+			func() any { return new(map[string]any) },
+			func() any { return new(Config) },
+		} {
+			i := typ()
+			if err := Unmarshal(b, i); err != nil {
+				continue
+			}
+
+			// Every decoded document must encode, and its encoding must
+			// be stable across another round trip.
+			encoded, err := Marshal(i)
+			if err != nil {
+				t.Fatalf("failed to marshal %T: %v", i, err)
+			}
+			j := typ()
+			if err := Unmarshal(encoded, j); err != nil {
+				t.Fatalf("failed to roundtrip %q: %v", encoded, err)
+			}
+			again, err := Marshal(j)
+			if err != nil {
+				t.Fatalf("failed to marshal %T: %v", j, err)
+			}
+			if !bytes.Equal(encoded, again) {
+				t.Fatalf("encoding changed after roundtrip:\n%s\nthen:\n%s", encoded, again)
+			}
+		}
+	})
+}
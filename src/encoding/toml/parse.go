@@ -0,0 +1,943 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A SyntaxError describes input that is not a valid TOML document.
+type SyntaxError struct {
+	msg    string // description of error
+	Line   int    // line of the error, starting at 1
+	Column int    // byte offset of the error within its line, starting at 1
+	Offset int64  // error occurred after reading Offset bytes
+}
+
+func (e *SyntaxError) Error() string {
+	return "toml: line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) + ": " + e.msg
+}
+
+// maxDepth is the maximum nesting depth of arrays and tables that the
+// parser accepts, and of the values that the encoder writes. Each part
+// of a table header, and each part of a dotted key but the last, counts
+// as a table.
+const maxDepth = 1000
+
+// position returns the line and column of the byte at data[off].
+func position(data []byte, off int) (line, col int) {
+	line = 1 + bytes.Count(data[:off], []byte{'\n'})
+	col = off - bytes.LastIndexByte(data[:off], '\n')
+	return line, col
+}
+
+// A valueKind is the type of a TOML value.
+type valueKind uint8
+
+const (
+	kindString valueKind = iota
+	kindInteger
+	kindFloat
+	kindBool
+	kindDateTime // offset date-time
+	kindLocalDateTime
+	kindLocalDate
+	kindLocalTime
+	kindArray
+	kindTable
+)
+
+var kindNames = [...]string{
+	kindString:        "string",
+	kindInteger:       "integer",
+	kindFloat:         "float",
+	kindBool:          "boolean",
+	kindDateTime:      "offset date-time",
+	kindLocalDateTime: "local date-time",
+	kindLocalDate:     "local date",
+	kindLocalTime:     "local time",
+	kindArray:         "array",
+	kindTable:         "table",
+}
+
+func (k valueKind) String() string { return kindNames[k] }
+
+// A value is a value in a parsed TOML document.
+type value struct {
+	kind valueKind
+	off  int // offset of the value, or of the header or key that created a table
+
+	// scalar holds the Go representation of a string, integer, float,
+	// boolean or date-time: a string, int64, float64, bool, time.Time,
+	// LocalDateTime, LocalDate or LocalTime.
+	scalar any
+	array  []*value
+	table  *table
+
+	// tableArray reports whether an array was created by [[header]] lines,
+	// which can append more tables to it.
+	tableArray bool
+}
+
+// A tableKind records how a table was created, which determines
+// whether later parts of the document may add to it.
+type tableKind uint8
+
+const (
+	tableImplicit tableKind = iota // created as the parent of a [header]
+	tableHeader                    // defined by a [header] or [[header]]
+	tableDotted                    // created by a dotted key
+	tableInline                    // an inline table, which is complete
+)
+
+// A table is a TOML table, with its entries in the order they are defined.
+type table struct {
+	kind    tableKind
+	entries []*entry
+	index   map[string]*entry
+}
+
+// An entry is a key of a table and its value.
+type entry struct {
+	key string
+	off int // offset of the key
+	val *value
+}
+
+func newTable(kind tableKind) *table {
+	return &table{kind: kind, index: make(map[string]*entry)}
+}
+
+func (t *table) add(key string, off int, v *value) *entry {
+	e := &entry{key: key, off: off, val: v}
+	t.entries = append(t.entries, e)
+	t.index[key] = e
+	return e
+}
+
+// tomlError is an error wrapper type for internal use only.
+// Panics with errors are wrapped in tomlError so that the top-level recover
+// can distinguish intentional panics from this package.
+type tomlError struct{ error }
+
+// catch recovers a panic raised by errorAt and stores its error in *err.
+func catch(err *error) {
+	if r := recover(); r != nil {
+		if te, ok := r.(tomlError); ok {
+			*err = te.error
+		} else {
+			panic(r)
+		}
+	}
+}
+
+// A parser parses a TOML document into a tree of tables.
+type parser struct {
+	data   []byte
+	off    int // next read offset in data
+	depth  int // nesting depth of the tables and arrays being parsed
+	root   *table
+	cur    *table // table receiving key/value pairs
+	curKey Key    // key of cur
+}
+
+// parse parses the TOML document in data.
+func parse(data []byte) (root *table, err error) {
+	defer catch(&err)
+	p := &parser{data: data, root: newTable(tableHeader)}
+	p.cur = p.root
+	p.document()
+	return p.root, nil
+}
+
+// errorAt aborts parsing with a SyntaxError at data[off].
+func (p *parser) errorAt(off int, format string, args ...any) {
+	line, col := position(p.data, off)
+	panic(tomlError{&SyntaxError{fmt.Sprintf(format, args...), line, col, int64(off)}})
+}
+
+// unexpected aborts parsing with an error reporting that the
+// input at p.off is not what was expected.
+func (p *parser) unexpected(what string) {
+	var found string
+	switch {
+	case p.off == len(p.data):
+		found = "end of file"
+	case p.data[p.off] == '\n' || p.data[p.off] == '\r':
+		found = "newline"
+	default:
+		r, _ := utf8.DecodeRune(p.data[p.off:])
+		found = strconv.QuoteRune(r)
+	}
+	p.errorAt(p.off, "expected %s, found %s", what, found)
+}
+
+func (p *parser) hasPrefix(s string) bool {
+	return bytes.HasPrefix(p.data[p.off:], []byte(s))
+}
+
+func (p *parser) peek(c byte) bool {
+	return p.off < len(p.data) && p.data[p.off] == c
+}
+
+// enter records that the parser descends into an array or table
+// starting at off.
+func (p *parser) enter(off int) {
+	p.depth++
+	if p.depth > maxDepth {
+		p.errorAt(off, "exceeded max depth")
+	}
+}
+
+func (p *parser) document() {
+	if !utf8.Valid(p.data) {
+		off := 0
+		for {
+			r, size := utf8.DecodeRune(p.data[off:])
+			if r == utf8.RuneError && size == 1 {
+				p.errorAt(off, "invalid UTF-8")
+			}
+			off += size
+		}
+	}
+	if p.hasPrefix("\xef\xbb\xbf") {
+		p.off += len("\xef\xbb\xbf")
+	}
+	for {
+		p.skipSpace()
+		if p.off == len(p.data) {
+			return
+		}
+		switch p.data[p.off] {
+		case '#', '\n', '\r':
+		case '[':
+			p.header()
+		default:
+			p.keyValue(p.cur, p.curKey)
+		}
+		p.endLine()
+	}
+}
+
+// endLine consumes optional whitespace and a comment
+// up to and including the end of the line.
+func (p *parser) endLine() {
+	p.skipSpace()
+	p.comment()
+	if p.off < len(p.data) && !p.newline() {
+		p.unexpected("newline")
+	}
+}
+
+func (p *parser) skipSpace() {
+	for p.off < len(p.data) && (p.data[p.off] == ' ' || p.data[p.off] == '\t') {
+		p.off++
+	}
+}
+
+// skipSpaceAndComments consumes whitespace, newlines and comments
+// between the elements of an array.
+func (p *parser) skipSpaceAndComments() {
+	for {
+		p.skipSpace()
+		p.comment()
+		if !p.newline() {
+			return
+		}
+	}
+}
+
+// newline consumes a newline, if there is one.
+func (p *parser) newline() bool {
+	if p.off < len(p.data) {
+		switch p.data[p.off] {
+		case '\n':
+			p.off++
+			return true
+		case '\r':
+			if p.off+1 < len(p.data) && p.data[p.off+1] == '\n' {
+				p.off += 2
+				return true
+			}
+			p.errorAt(p.off, "carriage return not followed by newline")
+		}
+	}
+	return false
+}
+
+// comment consumes a comment up to the end of the line, if there is one.
+func (p *parser) comment() {
+	if !p.peek('#') {
+		return
+	}
+	for p.off++; p.off < len(p.data); p.off++ {
+		c := p.data[p.off]
+		if c == '\n' || c == '\r' {
+			return
+		}
+		if isControl(c) {
+			p.errorAt(p.off, "invalid control character %U in comment", rune(c))
+		}
+	}
+}
+
+// isControl reports whether c is a control character other than tab,
+// which may not appear literally in comments and strings.
+func isControl(c byte) bool {
+	return c < 0x20 && c != '\t' || c == 0x7f
+}
+
+func isBare(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// key parses a simple or dotted key, returning its parts and their offsets.
+func (p *parser) key() (Key, []int) {
+	var key Key
+	var offs []int
+	for {
+		offs = append(offs, p.off)
+		key = append(key, p.simpleKey())
+		p.skipSpace()
+		if !p.peek('.') {
+			return key, offs
+		}
+		p.off++
+		p.skipSpace()
+	}
+}
+
+func (p *parser) simpleKey() string {
+	if p.off < len(p.data) {
+		switch c := p.data[p.off]; {
+		case c == '"':
+			if p.hasPrefix(`"""`) {
+				p.errorAt(p.off, "multi-line string cannot be a key")
+			}
+			return p.basicString()
+		case c == '\'':
+			if p.hasPrefix(`'''`) {
+				p.errorAt(p.off, "multi-line string cannot be a key")
+			}
+			return p.literalString()
+		case isBare(c):
+			start := p.off
+			for p.off < len(p.data) && isBare(p.data[p.off]) {
+				p.off++
+			}
+			return string(p.data[start:p.off])
+		}
+	}
+	p.unexpected("key")
+	panic("unreachable")
+}
+
+// header parses a [table] or [[array of tables]] header
+// and makes the table it defines the current table.
+func (p *parser) header() {
+	start := p.off
+	p.off++
+	array := p.peek('[')
+	if array {
+		p.off++
+	}
+	p.skipSpace()
+	key, offs := p.key()
+	p.depth = 0
+	for _, off := range offs {
+		p.enter(off)
+	}
+	if !p.peek(']') {
+		p.unexpected("']' after table name")
+	}
+	p.off++
+	if array {
+		if !p.peek(']') {
+			p.unexpected("']]' after array of tables name")
+		}
+		p.off++
+	}
+
+	t := p.root
+	for i, k := range key[:len(key)-1] {
+		e := t.index[k]
+		if e == nil {
+			e = t.add(k, offs[i], &value{kind: kindTable, off: offs[i], table: newTable(tableImplicit)})
+		}
+		switch v := e.val; {
+		case v.kind == kindTable && v.table.kind != tableInline:
+			t = v.table
+		case v.kind == kindArray && v.tableArray:
+			t = v.array[len(v.array)-1].table
+		default:
+			p.redefined(offs[i], key[:i+1], e)
+		}
+	}
+
+	p.curKey = key
+	k, off := key[len(key)-1], offs[len(key)-1]
+	e := t.index[k]
+	switch {
+	case array:
+		if e == nil {
+			e = t.add(k, off, &value{kind: kindArray, off: start, tableArray: true})
+		} else if !e.val.tableArray {
+			p.redefined(off, key, e)
+		}
+		p.cur = newTable(tableHeader)
+		e.val.array = append(e.val.array, &value{kind: kindTable, off: start, table: p.cur})
+	case e == nil:
+		p.cur = newTable(tableHeader)
+		t.add(k, off, &value{kind: kindTable, off: start, table: p.cur})
+	case e.val.kind == kindTable && e.val.table.kind == tableImplicit:
+		p.cur = e.val.table
+		p.cur.kind = tableHeader
+		e.val.off = start
+	default:
+		p.redefined(off, key, e)
+	}
+}
+
+// redefined aborts parsing with an error reporting that the key
+// at off was already defined by the entry e.
+func (p *parser) redefined(off int, key Key, e *entry) {
+	line, _ := position(p.data, e.off)
+	p.errorAt(off, "key %s already defined at line %d", key, line)
+}
+
+// keyValue parses a key/value pair and adds it to t, which has the
+// given key.
+func (p *parser) keyValue(t *table, tkey Key) {
+	key, offs := p.key()
+	if !p.peek('=') {
+		p.unexpected("'=' after key")
+	}
+	p.off++
+	p.skipSpace()
+	dotted := offs[:len(offs)-1]
+	for _, off := range dotted {
+		p.enter(off)
+	}
+	key = append(tkey[:len(tkey):len(tkey)], key...)
+	offs = append(make([]int, len(tkey)), offs...)
+	v := p.value(key)
+	p.depth -= len(dotted)
+
+	for i := len(tkey); i < len(key)-1; i++ {
+		k := key[i]
+		e := t.index[k]
+		if e == nil {
+			e = t.add(k, offs[i], &value{kind: kindTable, off: offs[i], table: newTable(tableDotted)})
+		} else if e.val.kind != kindTable || e.val.table.kind != tableDotted && e.val.table.kind != tableImplicit {
+			p.redefined(offs[i], key[:i+1], e)
+		}
+		t = e.val.table
+		t.kind = tableDotted
+	}
+	k, off := key[len(key)-1], offs[len(key)-1]
+	if e := t.index[k]; e != nil {
+		p.redefined(off, key, e)
+	}
+	t.add(k, off, v)
+}
+
+// value parses the value of the given key.
+func (p *parser) value(key Key) *value {
+	start := p.off
+	if p.off == len(p.data) {
+		p.unexpected("value")
+	}
+	switch c := p.data[p.off]; {
+	case c == '"':
+		if p.hasPrefix(`"""`) {
+			return &value{kind: kindString, off: start, scalar: p.multilineString('"')}
+		}
+		return &value{kind: kindString, off: start, scalar: p.basicString()}
+	case c == '\'':
+		if p.hasPrefix(`'''`) {
+			return &value{kind: kindString, off: start, scalar: p.multilineString('\'')}
+		}
+		return &value{kind: kindString, off: start, scalar: p.literalString()}
+	case c == '[':
+		return p.array(key)
+	case c == '{':
+		return p.inlineTable(key)
+	case p.hasPrefix("true"):
+		p.off += len("true")
+		return &value{kind: kindBool, off: start, scalar: true}
+	case p.hasPrefix("false"):
+		p.off += len("false")
+		return &value{kind: kindBool, off: start, scalar: false}
+	case p.isDateTime():
+		return p.dateTime()
+	}
+	return p.number()
+}
+
+func (p *parser) array(key Key) *value {
+	v := &value{kind: kindArray, off: p.off}
+	p.enter(p.off)
+	p.off++
+	for {
+		p.skipSpaceAndComments()
+		if p.peek(']') {
+			break
+		}
+		v.array = append(v.array, p.value(key))
+		p.skipSpaceAndComments()
+		if p.peek(',') {
+			p.off++
+			continue
+		}
+		if !p.peek(']') {
+			p.unexpected("',' or ']' in array")
+		}
+		break
+	}
+	p.off++
+	p.depth--
+	return v
+}
+
+func (p *parser) inlineTable(key Key) *value {
+	v := &value{kind: kindTable, off: p.off, table: newTable(tableInline)}
+	p.enter(p.off)
+	p.off++
+	p.skipSpace()
+	if !p.peek('}') {
+		for {
+			p.keyValue(v.table, key)
+			p.skipSpace()
+			if p.peek(',') {
+				p.off++
+				p.skipSpace()
+				continue
+			}
+			if !p.peek('}') {
+				p.unexpected("',' or '}' in inline table")
+			}
+			break
+		}
+	}
+	p.off++
+	p.depth--
+	return v
+}
+
+// basicString parses a basic string, starting at its opening quotation mark.
+func (p *parser) basicString() string {
+	open := p.off
+	p.off++
+	var b []byte
+	start := p.off
+	for p.off < len(p.data) {
+		switch c := p.data[p.off]; {
+		case c == '"':
+			s := string(append(b, p.data[start:p.off]...))
+			p.off++
+			return s
+		case c == '\\':
+			b = append(b, p.data[start:p.off]...)
+			b = p.escape(b)
+			start = p.off
+		case c == '\n' || c == '\r':
+			p.errorAt(open, "unterminated string")
+		case isControl(c):
+			p.errorAt(p.off, "invalid control character %U in string", rune(c))
+		default:
+			p.off++
+		}
+	}
+	p.errorAt(open, "unterminated string")
+	panic("unreachable")
+}
+
+// escape parses the escape sequence at p.off and appends
+// the character it denotes to b.
+func (p *parser) escape(b []byte) []byte {
+	start := p.off
+	p.off++
+	if p.off == len(p.data) {
+		p.errorAt(start, "unterminated string")
+	}
+	c := p.data[p.off]
+	p.off++
+	switch c {
+	case 'b':
+		return append(b, '\b')
+	case 't':
+		return append(b, '\t')
+	case 'n':
+		return append(b, '\n')
+	case 'f':
+		return append(b, '\f')
+	case 'r':
+		return append(b, '\r')
+	case '"', '\\':
+		return append(b, c)
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.off+n > len(p.data) {
+			p.errorAt(start, "invalid escape sequence")
+		}
+		x, err := strconv.ParseUint(string(p.data[p.off:p.off+n]), 16, 32)
+		if err != nil {
+			p.errorAt(start, "invalid escape sequence")
+		}
+		if !utf8.ValidRune(rune(x)) {
+			p.errorAt(start, "invalid Unicode code point U+%04X", x)
+		}
+		p.off += n
+		return utf8.AppendRune(b, rune(x))
+	}
+	p.errorAt(start, "invalid escape sequence")
+	panic("unreachable")
+}
+
+// literalString parses a literal string, starting at its opening apostrophe.
+func (p *parser) literalString() string {
+	open := p.off
+	p.off++
+	start := p.off
+	for p.off < len(p.data) {
+		switch c := p.data[p.off]; {
+		case c == '\'':
+			p.off++
+			return string(p.data[start : p.off-1])
+		case c == '\n' || c == '\r':
+			p.errorAt(open, "unterminated string")
+		case isControl(c):
+			p.errorAt(p.off, "invalid control character %U in string", rune(c))
+		}
+		p.off++
+	}
+	p.errorAt(open, "unterminated string")
+	panic("unreachable")
+}
+
+// multilineString parses a multi-line basic string if quote is a quotation
+// mark, or a multi-line literal string if quote is an apostrophe, starting
+// at its opening delimiter. Newlines in the string are normalized to "\n".
+func (p *parser) multilineString(quote byte) string {
+	open := p.off
+	p.off += 3
+	p.newline() // A newline immediately following the delimiter is trimmed.
+	var b []byte
+	start := p.off
+	for p.off < len(p.data) {
+		switch c := p.data[p.off]; {
+		case c == quote:
+			n := 1
+			for n < 5 && p.off+n < len(p.data) && p.data[p.off+n] == quote {
+				n++
+			}
+			if n < 3 {
+				p.off += n
+				continue
+			}
+			// Up to two quotes before the closing delimiter belong
+			// to the string.
+			b = append(b, p.data[start:p.off+n-3]...)
+			p.off += n
+			return string(b)
+		case c == '\\' && quote == '"':
+			b = append(b, p.data[start:p.off]...)
+			if !p.lineEndingBackslash() {
+				b = p.escape(b)
+			}
+			start = p.off
+		case c == '\r':
+			if p.off+1 == len(p.data) || p.data[p.off+1] != '\n' {
+				p.errorAt(p.off, "carriage return not followed by newline")
+			}
+			b = append(b, p.data[start:p.off]...)
+			p.off++
+			start = p.off
+		case c == '\n':
+			p.off++
+		case isControl(c):
+			p.errorAt(p.off, "invalid control character %U in string", rune(c))
+		default:
+			p.off++
+		}
+	}
+	p.errorAt(open, "unterminated string")
+	panic("unreachable")
+}
+
+// lineEndingBackslash consumes a backslash at the end of a line in a
+// multi-line basic string, along with the whitespace and newlines that
+// follow it. It reports false, consuming nothing, if the backslash at
+// p.off is followed by more characters on its line.
+func (p *parser) lineEndingBackslash() bool {
+	off := p.off + 1
+	for off < len(p.data) && (p.data[off] == ' ' || p.data[off] == '\t') {
+		off++
+	}
+	if off == len(p.data) || p.data[off] != '\n' && !bytes.HasPrefix(p.data[off:], []byte("\r\n")) {
+		return false
+	}
+	for off < len(p.data) {
+		switch {
+		case p.data[off] == ' ' || p.data[off] == '\t' || p.data[off] == '\n':
+			off++
+			continue
+		case bytes.HasPrefix(p.data[off:], []byte("\r\n")):
+			off += 2
+			continue
+		}
+		break
+	}
+	p.off = off
+	return true
+}
+
+// number parses an integer or float.
+func (p *parser) number() *value {
+	start := p.off
+	for p.off < len(p.data) && (isBare(p.data[p.off]) || p.data[p.off] == '+' || p.data[p.off] == '.') {
+		p.off++
+	}
+	s := string(p.data[start:p.off])
+	if s == "" {
+		p.unexpected("value")
+	}
+	unsigned := strings.TrimLeft(s[:1], "+-") + s[1:]
+	switch unsigned {
+	case "inf":
+		return &value{kind: kindFloat, off: start, scalar: math.Inf(1 - 2*strings.Count(s[:1], "-"))}
+	case "nan":
+		return &value{kind: kindFloat, off: start, scalar: math.NaN()}
+	}
+
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'o' || s[1] == 'b') {
+		base := map[byte]int{'x': 16, 'o': 8, 'b': 2}[s[1]]
+		if !validDigits(s[2:], base) {
+			p.errorAt(start, "invalid integer %s", s)
+		}
+		n, err := strconv.ParseInt(strings.ReplaceAll(s[2:], "_", ""), base, 64)
+		if err != nil {
+			p.errorAt(start, "integer %s out of range", s)
+		}
+		return &value{kind: kindInteger, off: start, scalar: n}
+	}
+
+	// The integer part ends at the fractional part or the exponent.
+	intPart, rest := unsigned, ""
+	if i := strings.IndexAny(unsigned, ".eE"); i >= 0 {
+		intPart, rest = unsigned[:i], unsigned[i:]
+	}
+	if !validDigits(intPart, 10) || len(intPart) > 1 && intPart[0] == '0' {
+		if intPart == "" || !isDigit(intPart[0]) {
+			p.errorAt(start, "invalid value %s", s)
+		}
+		p.errorAt(start, "invalid number %s", s)
+	}
+	if rest == "" {
+		n, err := strconv.ParseInt(strings.ReplaceAll(s, "_", ""), 10, 64)
+		if err != nil {
+			p.errorAt(start, "integer %s out of range", s)
+		}
+		return &value{kind: kindInteger, off: start, scalar: n}
+	}
+	if rest[0] == '.' {
+		frac := rest[1:]
+		rest = ""
+		if i := strings.IndexAny(frac, "eE"); i >= 0 {
+			frac, rest = frac[:i], frac[i:]
+		}
+		if !validDigits(frac, 10) {
+			p.errorAt(start, "invalid float %s", s)
+		}
+	}
+	if rest != "" {
+		exp := rest[1:]
+		if exp != "" && (exp[0] == '+' || exp[0] == '-') {
+			exp = exp[1:]
+		}
+		if !validDigits(exp, 10) {
+			p.errorAt(start, "invalid float %s", s)
+		}
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(s, "_", ""), 64)
+	if err != nil && math.IsInf(f, 0) {
+		p.errorAt(start, "float %s out of range", s)
+	}
+	return &value{kind: kindFloat, off: start, scalar: f}
+}
+
+// validDigits reports whether s is a non-empty sequence of digits in the
+// given base in which each underscore is surrounded by digits.
+func validDigits(s string, base int) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' {
+			if i == 0 || i == len(s)-1 || s[i+1] == '_' {
+				return false
+			}
+			continue
+		}
+		var d int
+		switch {
+		case '0' <= c && c <= '9':
+			d = int(c - '0')
+		case 'a' <= c && c <= 'f':
+			d = int(c-'a') + 10
+		case 'A' <= c && c <= 'F':
+			d = int(c-'A') + 10
+		default:
+			return false
+		}
+		if d >= base {
+			return false
+		}
+	}
+	return true
+}
+
+// isDateTime reports whether the input at p.off starts a date or time.
+func (p *parser) isDateTime() bool {
+	digitsThen := func(n int, sep byte) bool {
+		if p.off+n >= len(p.data) || p.data[p.off+n] != sep {
+			return false
+		}
+		for _, c := range p.data[p.off : p.off+n] {
+			if !isDigit(c) {
+				return false
+			}
+		}
+		return true
+	}
+	return digitsThen(4, '-') || digitsThen(2, ':')
+}
+
+// dateTime parses an offset date-time, local date-time, local date or
+// local time.
+func (p *parser) dateTime() *value {
+	start := p.off
+	if p.off+2 < len(p.data) && p.data[p.off+2] == ':' {
+		return &value{kind: kindLocalTime, off: start, scalar: p.localTime(start)}
+	}
+	d := LocalDate{Year: p.digits(4)}
+	p.separator('-')
+	d.Month = time.Month(p.digits(2))
+	p.separator('-')
+	d.Day = p.digits(2)
+	if !d.valid() {
+		p.errorAt(start, "invalid date %s", p.data[start:p.off])
+	}
+	if p.off == len(p.data) {
+		return &value{kind: kindLocalDate, off: start, scalar: d}
+	}
+	switch c := p.data[p.off]; {
+	case c == 'T' || c == 't':
+	case c == ' ' && p.off+1 < len(p.data) && isDigit(p.data[p.off+1]):
+	default:
+		return &value{kind: kindLocalDate, off: start, scalar: d}
+	}
+	p.off++
+	t := p.localTime(start)
+	if p.off == len(p.data) {
+		return &value{kind: kindLocalDateTime, off: start, scalar: LocalDateTime{d, t}}
+	}
+
+	loc := time.UTC
+	switch c := p.data[p.off]; c {
+	case 'Z', 'z':
+		p.off++
+	case '+', '-':
+		p.off++
+		hour := p.digits(2)
+		p.separator(':')
+		min := p.digits(2)
+		if hour > 23 || min > 59 {
+			p.errorAt(start, "invalid time zone offset in %s", p.data[start:p.off])
+		}
+		if offset := (hour*60 + min) * 60; offset != 0 {
+			if c == '-' {
+				offset = -offset
+			}
+			loc = time.FixedZone("", offset)
+		}
+	default:
+		return &value{kind: kindLocalDateTime, off: start, scalar: LocalDateTime{d, t}}
+	}
+	tm := time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+	return &value{kind: kindDateTime, off: start, scalar: tm}
+}
+
+// localTime parses a time of day for the date or time starting at start.
+func (p *parser) localTime(start int) LocalTime {
+	var t LocalTime
+	t.Hour = p.digits(2)
+	p.separator(':')
+	t.Minute = p.digits(2)
+	p.separator(':')
+	t.Second = p.digits(2)
+	if p.peek('.') {
+		p.off++
+		if p.off == len(p.data) || !isDigit(p.data[p.off]) {
+			p.unexpected("digit in fractional seconds")
+		}
+		// Digits beyond nanosecond precision are truncated.
+		for scale := 100000000; p.off < len(p.data) && isDigit(p.data[p.off]); p.off++ {
+			t.Nanosecond += int(p.data[p.off]-'0') * scale
+			scale /= 10
+		}
+	}
+	if !t.valid() {
+		p.errorAt(start, "invalid time %s", p.data[start:p.off])
+	}
+	return t
+}
+
+func (p *parser) digits(n int) int {
+	x := 0
+	for range n {
+		if p.off == len(p.data) || !isDigit(p.data[p.off]) {
+			p.unexpected("digit in date-time")
+		}
+		x = x*10 + int(p.data[p.off]-'0')
+		p.off++
+	}
+	return x
+}
+
+func (p *parser) separator(c byte) {
+	if !p.peek(c) {
+		p.unexpected(strconv.QuoteRune(rune(c)) + " in date-time")
+	}
+	p.off++
+}
+
+// parseDateTime parses text as a single TOML date or time.
+func parseDateTime(text []byte) (v *value, err error) {
+	defer catch(&err)
+	p := &parser{data: text}
+	if !p.isDateTime() {
+		p.unexpected("date-time")
+	}
+	v = p.dateTime()
+	if p.off != len(p.data) {
+		p.unexpected("end of date-time")
+	}
+	return v, nil
+}
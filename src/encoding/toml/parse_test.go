@@ -0,0 +1,283 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var parseTests = []struct {
+	in   string
+	want map[string]any
+}{
+	{"", map[string]any{}},
+	{"# comment only\n\n", map[string]any{}},
+	{"\xef\xbb\xbfa = 1", map[string]any{"a": int64(1)}},
+	{"a = 1\r\nb = 2\r\n", map[string]any{"a": int64(1), "b": int64(2)}},
+
+	// Keys.
+	{`bare_key-1 = 1`, map[string]any{"bare_key-1": int64(1)}},
+	{`1234 = 1`, map[string]any{"1234": int64(1)}},
+	{`"quoted key" = 1`, map[string]any{"quoted key": int64(1)}},
+	{`'literal "key"' = 1`, map[string]any{`literal "key"`: int64(1)}},
+	{`"" = 1`, map[string]any{"": int64(1)}},
+	{`"ʎǝʞ" = 1`, map[string]any{"ʎǝʞ": int64(1)}},
+	{`a . b . "c.d" = 1`, map[string]any{"a": map[string]any{"b": map[string]any{"c.d": int64(1)}}}},
+	{"3.14159 = 1", map[string]any{"3": map[string]any{"14159": int64(1)}}},
+	{"a.b = 1\na.c = 2", map[string]any{"a": map[string]any{"b": int64(1), "c": int64(2)}}},
+
+	// Strings.
+	{`s = "tab\there \"quoted\" \\ \u00e9 \U0001F600"`, map[string]any{"s": "tab\there \"quoted\" \\ é 😀"}},
+	{`s = "\b\f\n\r"`, map[string]any{"s": "\b\f\n\r"}},
+	{`s = 'C:\Users\nodejs'`, map[string]any{"s": `C:\Users\nodejs`}},
+	{"s = \"\"\"\nRoses are red\nViolets are blue\"\"\"", map[string]any{"s": "Roses are red\nViolets are blue"}},
+	{"s = \"\"\"\r\none\r\ntwo\"\"\"", map[string]any{"s": "one\ntwo"}},
+	{"s = \"\"\"\nThe quick brown \\\n\n\n  fox jumps \\\n   over.\\\n  \"\"\"", map[string]any{"s": "The quick brown fox jumps over."}},
+	{"s = \"\"\"Here are two quotation marks: \"\". Simple enough.\"\"\"", map[string]any{"s": `Here are two quotation marks: "". Simple enough.`}},
+	{`s = """"This," she said, "is just a pointless statement.""""`, map[string]any{"s": `"This," she said, "is just a pointless statement."`}},
+	{`s = """a"""""`, map[string]any{"s": `a""`}},
+	{"s = '''\nThe first newline is\ntrimmed in raw strings.\n   All other whitespace\n   is preserved.\n'''", map[string]any{"s": "The first newline is\ntrimmed in raw strings.\n   All other whitespace\n   is preserved.\n"}},
+	{`s = '''Here are fifteen quotation marks: """""""""""""""'''`, map[string]any{"s": `Here are fifteen quotation marks: """""""""""""""`}},
+	{`s = ''''That,' she said, 'is still pointless.''''`, map[string]any{"s": `'That,' she said, 'is still pointless.'`}},
+	{`s = '''\n'''`, map[string]any{"s": `\n`}},
+
+	// Integers.
+	{"a = +99\nb = 42\nc = 0\nd = -17\ne = -0", map[string]any{"a": int64(99), "b": int64(42), "c": int64(0), "d": int64(-17), "e": int64(0)}},
+	{"a = 1_000\nb = 5_349_221\nc = 1_2_3_4_5", map[string]any{"a": int64(1000), "b": int64(5349221), "c": int64(12345)}},
+	{"a = 0xDEADBEEF\nb = 0xdead_beef\nc = 0o755\nd = 0b11010110\ne = 0x00", map[string]any{"a": int64(0xdeadbeef), "b": int64(0xdeadbeef), "c": int64(0o755), "d": int64(0b11010110), "e": int64(0)}},
+	{"a = 9223372036854775807\nb = -9223372036854775808\nc = 0x7fffffffffffffff", map[string]any{"a": int64(math.MaxInt64), "b": int64(math.MinInt64), "c": int64(math.MaxInt64)}},
+
+	// Floats.
+	{"a = +1.0\nb = 3.1415\nc = -0.01\nd = 5e+22\ne = 1e06\nf = -2E-2\ng = 6.626e-34\nh = 224_617.445_991_228",
+		map[string]any{"a": 1.0, "b": 3.1415, "c": -0.01, "d": 5e+22, "e": 1e06, "f": -2e-2, "g": 6.626e-34, "h": 224617.445991228}},
+	{"a = inf\nb = +inf\nc = -inf", map[string]any{"a": math.Inf(1), "b": math.Inf(1), "c": math.Inf(-1)}},
+	{"a = 0.0\nb = -0.0\nc = 0e0", map[string]any{"a": 0.0, "b": math.Copysign(0, -1), "c": 0.0}},
+
+	// Booleans.
+	{"t = true\nf = false", map[string]any{"t": true, "f": false}},
+
+	// Date-times.
+	{"a = 1979-05-27T07:32:00Z\nb = 1979-05-27T00:32:00-07:00\nc = 1979-05-27 00:32:00.999999+07:00\nd = 1979-05-27t07:32:00z",
+		map[string]any{
+			"a": time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+			"b": time.Date(1979, 5, 27, 0, 32, 0, 0, time.FixedZone("", -7*3600)),
+			"c": time.Date(1979, 5, 27, 0, 32, 0, 999999000, time.FixedZone("", 7*3600)),
+			"d": time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+		}},
+	{"a = 1979-05-27T07:32:00+00:00", map[string]any{"a": time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC)}},
+	{"a = 1979-05-27T07:32:00\nb = 1979-05-27 00:32:00.123456789123",
+		map[string]any{
+			"a": LocalDateTime{LocalDate{1979, 5, 27}, LocalTime{7, 32, 0, 0}},
+			"b": LocalDateTime{LocalDate{1979, 5, 27}, LocalTime{0, 32, 0, 123456789}},
+		}},
+	{"a = 1979-05-27\nb = 2000-02-29 # leap year", map[string]any{"a": LocalDate{1979, 5, 27}, "b": LocalDate{2000, 2, 29}}},
+	{"a = 07:32:00\nb = 00:32:00.5", map[string]any{"a": LocalTime{7, 32, 0, 0}, "b": LocalTime{0, 32, 0, 500000000}}},
+
+	// Arrays.
+	{"a = []\nb = [ 1, 2, 3, ]\nc = [[1, 2], ['a', \"b\"]]\nd = [0.1, 'x', 1979-05-27, {x = 1}]",
+		map[string]any{
+			"a": []any{},
+			"b": []any{int64(1), int64(2), int64(3)},
+			"c": []any{[]any{int64(1), int64(2)}, []any{"a", "b"}},
+			"d": []any{0.1, "x", LocalDate{1979, 5, 27}, map[string]any{"x": int64(1)}},
+		}},
+	{"a = [\n  1, # one\n  2\n  # end\n]", map[string]any{"a": []any{int64(1), int64(2)}}},
+
+	// Inline tables.
+	{"name = { first = \"Tom\", last = \"Preston-Werner\" }\npoint = {x=1,y=2}\nanimal = { type.name = \"pug\" }\nempty = {}",
+		map[string]any{
+			"name":   map[string]any{"first": "Tom", "last": "Preston-Werner"},
+			"point":  map[string]any{"x": int64(1), "y": int64(2)},
+			"animal": map[string]any{"type": map[string]any{"name": "pug"}},
+			"empty":  map[string]any{},
+		}},
+
+	// Tables.
+	{"[table-1]\nkey1 = \"some string\"\n\n[table-2]\nkey1 = \"another string\"",
+		map[string]any{
+			"table-1": map[string]any{"key1": "some string"},
+			"table-2": map[string]any{"key1": "another string"},
+		}},
+	{"[ dog . \"tater.man\" ]\ntype.name = \"pug\"",
+		map[string]any{"dog": map[string]any{"tater.man": map[string]any{"type": map[string]any{"name": "pug"}}}}},
+	{"[x.y.z.w]\n[x]\na = 1", map[string]any{"x": map[string]any{"a": int64(1), "y": map[string]any{"z": map[string]any{"w": map[string]any{}}}}}},
+	{"[fruit]\napple.color = \"red\"\napple.taste.sweet = true\n\n[fruit.apple.texture]\nsmooth = true",
+		map[string]any{"fruit": map[string]any{"apple": map[string]any{
+			"color":   "red",
+			"taste":   map[string]any{"sweet": true},
+			"texture": map[string]any{"smooth": true},
+		}}}},
+	{"[a.b.c]\nz = 1\n[a]\nb.d = 2", map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"z": int64(1)}, "d": int64(2)}}}},
+
+	// Arrays of tables.
+	{"[[products]]\nname = \"Hammer\"\n\n[[products]]  # empty table within the array\n\n[[products]]\nname = \"Nail\"",
+		map[string]any{"products": []any{
+			map[string]any{"name": "Hammer"},
+			map[string]any{},
+			map[string]any{"name": "Nail"},
+		}}},
+	{"[[fruits]]\nname = \"apple\"\n[fruits.physical]\ncolor = \"red\"\n[[fruits.varieties]]\nname = \"red delicious\"\n[[fruits.varieties]]\nname = \"granny smith\"\n[[fruits]]\nname = \"banana\"\n[[fruits.varieties]]\nname = \"plantain\"",
+		map[string]any{"fruits": []any{
+			map[string]any{
+				"name":      "apple",
+				"physical":  map[string]any{"color": "red"},
+				"varieties": []any{map[string]any{"name": "red delicious"}, map[string]any{"name": "granny smith"}},
+			},
+			map[string]any{
+				"name":      "banana",
+				"varieties": []any{map[string]any{"name": "plantain"}},
+			},
+		}}},
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range parseTests {
+		var got map[string]any
+		if err := Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unmarshal(%q):\ngot  %#v\nwant %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNaN(t *testing.T) {
+	var got map[string]float64
+	if err := Unmarshal([]byte("a = nan\nb = +nan\nc = -nan"), &got); err != nil {
+		t.Fatal(err)
+	}
+	for k, f := range got {
+		if !math.IsNaN(f) {
+			t.Errorf("%s = %v, want NaN", k, f)
+		}
+	}
+}
+
+var syntaxErrorTests = []struct {
+	in        string
+	line, col int
+	msg       string
+}{
+	{"a = 1\nb", 2, 2, "expected '=' after key, found end of file"},
+	{"a = ", 1, 5, "expected value, found end of file"},
+	{"a = 1 b = 2", 1, 7, "expected newline, found 'b'"},
+	{"= 1", 1, 1, "expected key, found '='"},
+	{"a = 1\n\xff = 2", 2, 1, "invalid UTF-8"},
+	{"a = 1\rb = 2", 1, 6, "carriage return not followed by newline"},
+	{"# comment \x01", 1, 11, "invalid control character U+0001 in comment"},
+	{`"""a""" = 1`, 1, 1, "multi-line string cannot be a key"},
+	{"a. = 1", 1, 4, "expected key, found '='"},
+
+	// Duplicates.
+	{"a = 1\na = 2", 2, 1, "key a already defined at line 1"},
+	{"a = 1\na.b = 2", 2, 1, "key a already defined at line 1"},
+	{"[a]\nb = 1\n[a]", 3, 2, "key a already defined at line 1"},
+	{"[a]\nb = 1\n[a.b]", 3, 4, "key a.b already defined at line 2"},
+	{"[fruit]\napple.color = \"red\"\n[fruit.apple]", 3, 8, "key fruit.apple already defined at line 2"},
+	{"[a.b.c]\nz = 9\n[a]\nb.c.t = 1", 4, 3, "key a.b.c already defined at line 1"},
+	{"a = {b = 1}\n[a]", 2, 2, "key a already defined at line 1"},
+	{"a = {b = 1}\na.c = 2", 2, 1, "key a already defined at line 1"},
+	{"a = {b = 1}\n[a.c]", 2, 2, "key a already defined at line 1"},
+	{"a = {b = 1, b = 2}", 1, 13, "key a.b already defined at line 1"},
+	{"a = [1]\n[[a]]", 2, 3, "key a already defined at line 1"},
+	{"[a]\n[[a]]", 2, 3, "key a already defined at line 1"},
+	{"[[a]]\n[a]", 2, 2, "key a already defined at line 1"},
+	{`"a" = 1` + "\na = 2", 2, 1, "key a already defined at line 1"},
+
+	// Strings.
+	{`a = "abc`, 1, 5, "unterminated string"},
+	{"a = \"abc\ndef\"", 1, 5, "unterminated string"},
+	{"a = 'abc\ndef'", 1, 5, "unterminated string"},
+	{`a = """abc`, 1, 5, "unterminated string"},
+	{`a = '''abc''''''`, 1, 16, "expected newline, found '\\''"},
+	{`a = "\x"`, 1, 6, "invalid escape sequence"},
+	{`a = "\u00"`, 1, 6, "invalid escape sequence"},
+	{`a = "\uD800"`, 1, 6, "invalid Unicode code point U+D800"},
+	{`a = "\U00110000"`, 1, 6, "invalid Unicode code point U+110000"},
+	{"a = \"tab\x7f\"", 1, 9, "invalid control character U+007F in string"},
+	{"a = \"\"\"\\ x\"\"\"", 1, 8, "invalid escape sequence"},
+
+	// Numbers.
+	{"a = 01", 1, 5, "invalid number 01"},
+	{"a = 1__0", 1, 5, "invalid number 1__0"},
+	{"a = _1", 1, 5, "invalid value _1"},
+	{"a = 1_", 1, 5, "invalid number 1_"},
+	{"a = +0x10", 1, 5, "invalid number +0x10"},
+	{"a = 0xG", 1, 5, "invalid integer 0xG"},
+	{"a = 0o8", 1, 5, "invalid integer 0o8"},
+	{"a = 9223372036854775808", 1, 5, "integer 9223372036854775808 out of range"},
+	{"a = 1.", 1, 5, "invalid float 1."},
+	{"a = .5", 1, 5, "invalid value .5"},
+	{"a = 1e", 1, 5, "invalid float 1e"},
+	{"a = 1.e5", 1, 5, "invalid float 1.e5"},
+	{"a = 1e_5", 1, 5, "invalid float 1e_5"},
+	{"a = 1e400", 1, 5, "float 1e400 out of range"},
+	{"a = nope", 1, 5, "invalid value nope"},
+
+	// Date-times.
+	{"a = 1979-13-27", 1, 5, "invalid date 1979-13-27"},
+	{"a = 1979-02-29", 1, 5, "invalid date 1979-02-29"},
+	{"a = 1979-05-27T24:00:00", 1, 5, "invalid time 1979-05-27T24:00:00"},
+	{"a = 07:60:00", 1, 5, "invalid time 07:60:00"},
+	{"a = 07:32", 1, 10, "expected ':' in date-time, found end of file"},
+	{"a = 1979-05-27T07:32:00.", 1, 25, "expected digit in fractional seconds, found end of file"},
+	{"a = 1979-05-27T07:32:00+24:00", 1, 5, "invalid time zone offset in 1979-05-27T07:32:00+24:00"},
+	{"a = 1979-5-27", 1, 11, "expected digit in date-time, found '-'"},
+
+	// Arrays and inline tables.
+	{"a = [1 2]", 1, 8, "expected ',' or ']' in array, found '2'"},
+	{"a = [1,,]", 1, 8, "expected value, found ','"},
+	{"a = [1", 1, 7, "expected ',' or ']' in array, found end of file"},
+	{"a = {b = 1,}", 1, 12, "expected key, found '}'"},
+	{"a = {b = 1\n}", 1, 11, "expected ',' or '}' in inline table, found newline"},
+	{"a = {b = 1 c = 2}", 1, 12, "expected ',' or '}' in inline table, found 'c'"},
+	{"a = " + strings.Repeat("[", maxDepth+1), 1, 4 + maxDepth + 1, "exceeded max depth"},
+	{strings.Repeat("a.", maxDepth+1) + "a = 1", 1, 2*maxDepth + 1, "exceeded max depth"},
+	{"a = " + strings.Repeat("{a.a = ", maxDepth/2+1), 1, 4 + 7*(maxDepth/2) + 1, "exceeded max depth"},
+
+	// Headers.
+	{"[a", 1, 3, "expected ']' after table name, found end of file"},
+	{"[[a]", 1, 5, "expected ']]' after array of tables name, found end of file"},
+	{"[a] b = 1", 1, 5, "expected newline, found 'b'"},
+	{"[]", 1, 2, "expected key, found ']'"},
+	{"[[a] ]", 1, 5, "expected ']]' after array of tables name, found ' '"},
+	{"[" + strings.Repeat("a.", maxDepth) + "a]", 1, 2*maxDepth + 2, "exceeded max depth"},
+	{"[a]\nb = " + strings.Repeat("[", maxDepth), 2, 4 + maxDepth, "exceeded max depth"},
+}
+
+func TestSyntaxError(t *testing.T) {
+	for _, tt := range syntaxErrorTests {
+		var v any
+		err := Unmarshal([]byte(tt.in), &v)
+		se, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("Unmarshal(%q) error = %v, want SyntaxError", tt.in, err)
+			continue
+		}
+		if se.Line != tt.line || se.Column != tt.col || se.msg != tt.msg {
+			t.Errorf("Unmarshal(%q) error = %d:%d %q, want %d:%d %q", tt.in, se.Line, se.Column, se.msg, tt.line, tt.col, tt.msg)
+		}
+		if v != nil {
+			t.Errorf("Unmarshal(%q) modified its argument", tt.in)
+		}
+	}
+}
+
+func TestSyntaxErrorString(t *testing.T) {
+	err := Unmarshal([]byte("a = 1\n\n  b = \"x"), new(any))
+	want := "toml: line 3, column 7: unterminated string"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %s", err, want)
+	}
+	if se := err.(*SyntaxError); se.Offset != 13 {
+		t.Errorf("Offset = %d, want 13", se.Offset)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"io"
+	"slices"
+)
+
+// A Key is the key of a TOML value, given as the keys of the tables
+// that lead to it. The key of a value in an array of tables does not
+// identify the element of the array that holds it.
+type Key []string
+
+// String returns the key in TOML dotted form, quoting the parts that
+// are not bare keys, such as server."host name".
+func (k Key) String() string {
+	return string(k.appendTo(nil))
+}
+
+func (k Key) appendTo(b []byte) []byte {
+	for i, part := range k {
+		if i > 0 {
+			b = append(b, '.')
+		}
+		b = appendKeyPart(b, part)
+	}
+	return b
+}
+
+// A Decoder reads and decodes a TOML document from an input stream.
+type Decoder struct {
+	r                     io.Reader
+	disallowUnknownFields bool
+	undecoded             []Key
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// DisallowUnknownFields causes the Decoder to return an error when the
+// destination is a struct and the input contains keys which do not match
+// any non-ignored, exported fields in the destination. The error gives
+// the line and column of the first such key.
+func (dec *Decoder) DisallowUnknownFields() { dec.disallowUnknownFields = true }
+
+// Decode reads the input until EOF and stores the TOML document it
+// holds in the value pointed to by v.
+//
+// See the documentation for [Unmarshal] for details about
+// the conversion of TOML into a Go value.
+func (dec *Decoder) Decode(v any) error {
+	dec.undecoded = nil
+	data, err := io.ReadAll(dec.r)
+	if err != nil {
+		return err
+	}
+	root, err := parse(data)
+	if err != nil {
+		return err
+	}
+	d := decodeState{data: data, disallowUnknownFields: dec.disallowUnknownFields}
+	err = d.unmarshal(root, v)
+	dec.undecoded = d.undecodedKeys()
+	return err
+}
+
+// Undecoded returns the keys of the document read by the most recent
+// call to [Decoder.Decode] that did not match a field of the struct that
+// the table holding them was decoded into, in the order they appear in
+// the document. The keys of values within such a key, which were not
+// decoded either, are not included.
+//
+// Undecoded reports each key once, although an array of tables may hold
+// it in several of its tables. Keys decoded into a map, an interface
+// value or a value implementing [Unmarshaler] count as decoded.
+func (dec *Decoder) Undecoded() []Key {
+	keys := make([]Key, len(dec.undecoded))
+	for i, k := range dec.undecoded {
+		keys[i] = slices.Clone(k)
+	}
+	return keys
+}
+
+// An Encoder writes TOML documents to an output stream.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the TOML document encoding of v to the stream.
+//
+// See the documentation for [Marshal] for details about the
+// conversion of Go values to TOML.
+func (enc *Encoder) Encode(v any) error {
+	b, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = enc.w.Write(b)
+	return err
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestKeyString(t *testing.T) {
+	for _, tt := range []struct {
+		key  Key
+		want string
+	}{
+		{nil, ""},
+		{Key{"a"}, "a"},
+		{Key{"a", "b-c", "d_1"}, "a.b-c.d_1"},
+		{Key{"server", "host name"}, `server."host name"`},
+		{Key{"", "a.b", `q"`}, `"".` + `"a.b".` + `"q\""`},
+	} {
+		if got := tt.key.String(); got != tt.want {
+			t.Errorf("%q.String() = %s, want %s", []string(tt.key), got, tt.want)
+		}
+	}
+}
+
+const undecodedTOML = `
+title = "x"
+extra = 1
+
+[owner]
+name = "Tom"
+nickname = "T"
+pets = { cat = 1 }
+
+[database.replica]
+host = "db2"
+
+[[clients.list]]
+host = "a"
+
+[[clients.list]]
+host = "b"
+
+[servers.alpha]
+ip = "10.0.0.1"
+zone = "eu"
+`
+
+func TestDecoderUndecoded(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(undecodedTOML))
+	var c Config
+	if err := dec.Decode(&c); err != nil {
+		t.Fatal(err)
+	}
+	want := []Key{
+		{"extra"},
+		{"owner", "nickname"},
+		{"owner", "pets"},
+		{"database", "replica"},
+		{"clients", "list"},
+		{"servers", "alpha", "zone"},
+	}
+	got := dec.Undecoded()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Undecoded() = %v, want %v", got, want)
+	}
+	got[0][0] = "changed"
+	if dec.Undecoded()[0][0] != "extra" {
+		t.Errorf("modifying the result of Undecoded changed the Decoder")
+	}
+
+	// Keys decoded into a map or an interface value count as decoded.
+	for _, v := range []any{new(any), new(map[string]any)} {
+		dec := NewDecoder(strings.NewReader(undecodedTOML))
+		if err := dec.Decode(v); err != nil {
+			t.Fatal(err)
+		}
+		if got := dec.Undecoded(); len(got) != 0 {
+			t.Errorf("Decode(%T): Undecoded() = %v, want none", v, got)
+		}
+	}
+
+	// Keys of array of tables elements are reported once.
+	type list struct {
+		List []struct{ Host string }
+	}
+	dec = NewDecoder(strings.NewReader("[[list]]\nhost = 'a'\nport = 1\n[[list]]\nport = 2\nuser = 'u'\n"))
+	var l list
+	if err := dec.Decode(&l); err != nil {
+		t.Fatal(err)
+	}
+	want = []Key{{"list", "port"}, {"list", "user"}}
+	if got := dec.Undecoded(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Undecoded() = %v, want %v", got, want)
+	}
+}
+
+func TestDecoderDisallowUnknownFields(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(undecodedTOML))
+	dec.DisallowUnknownFields()
+	var c Config
+	err := dec.Decode(&c)
+	const want = "toml: line 3, column 1: unknown field extra"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Decode error = %v, want %s", err, want)
+	}
+	// Decoding carries on past the unknown field.
+	if c.Title != "x" || c.Owner.Name != "Tom" || len(c.Servers) != 1 {
+		t.Errorf("Decode stopped at the unknown field: %+v", c)
+	}
+
+	dec = NewDecoder(strings.NewReader("a = 1\n"))
+	dec.DisallowUnknownFields()
+	var m map[string]int
+	if err := dec.Decode(&m); err != nil {
+		t.Errorf("Decode into map: %v", err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	errRead := errors.New("read failed")
+	dec := NewDecoder(iotest.ErrReader(errRead))
+	var v any
+	if err := dec.Decode(&v); err != errRead {
+		t.Errorf("Decode error = %v, want %v", err, errRead)
+	}
+
+	dec = NewDecoder(iotest.OneByteReader(strings.NewReader("a = 1\nb = \n")))
+	var se *SyntaxError
+	if err := dec.Decode(&v); !errors.As(err, &se) || se.Line != 2 {
+		t.Errorf("Decode error = %v, want SyntaxError on line 2", err)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	var b strings.Builder
+	enc := NewEncoder(&b)
+	if err := enc.Encode(map[string]any{"a": 1, "t": map[string]string{"b": "c"}}); err != nil {
+		t.Fatal(err)
+	}
+	const want = "a = 1\n\n[t]\nb = \"c\"\n"
+	if b.String() != want {
+		t.Errorf("Encode wrote %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := enc.Encode(1); err == nil {
+		t.Errorf("Encode(1) succeeded, want error")
+	}
+	if b.Len() != 0 {
+		t.Errorf("failed Encode wrote %q", b.String())
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toml
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// A LocalDate is a TOML local date: a calendar day without a time of day
+// or relation to a time zone, such as 1979-05-27.
+type LocalDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// String returns the date in the form 2006-01-02.
+func (d LocalDate) String() string {
+	return string(d.appendTo(nil))
+}
+
+func (d LocalDate) appendTo(b []byte) []byte {
+	b = appendDigits(b, d.Year, 4)
+	b = append(b, '-')
+	b = appendDigits(b, int(d.Month), 2)
+	b = append(b, '-')
+	return appendDigits(b, d.Day, 2)
+}
+
+// valid reports whether d is a date that TOML can represent.
+func (d LocalDate) valid() bool {
+	if d.Year < 0 || d.Year > 9999 || d.Month < time.January || d.Month > time.December || d.Day < 1 {
+		return false
+	}
+	// The day after the last day of the month normalizes to the
+	// first day of the following month.
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Day() == d.Day
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// The encoding is the same as returned by [LocalDate.String].
+func (d LocalDate) MarshalText() ([]byte, error) {
+	if !d.valid() {
+		return nil, errors.New("toml: invalid LocalDate")
+	}
+	return d.appendTo(nil), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The date must be in the form 2006-01-02.
+func (d *LocalDate) UnmarshalText(text []byte) error {
+	v, err := parseDateTime(text)
+	if err != nil || v.kind != kindLocalDate {
+		return errors.New("toml: invalid local date " + strconv.Quote(string(text)))
+	}
+	*d = v.scalar.(LocalDate)
+	return nil
+}
+
+// A LocalTime is a TOML local time: a time of day without a date or
+// relation to a time zone, such as 07:32:00.999999.
+type LocalTime struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// String returns the time in the form 15:04:05.999999999,
+// omitting trailing zeros of the fractional second.
+func (t LocalTime) String() string {
+	return string(t.appendTo(nil))
+}
+
+func (t LocalTime) appendTo(b []byte) []byte {
+	b = appendDigits(b, t.Hour, 2)
+	b = append(b, ':')
+	b = appendDigits(b, t.Minute, 2)
+	b = append(b, ':')
+	b = appendDigits(b, t.Second, 2)
+	if t.Nanosecond != 0 {
+		b = append(b, '.')
+		b = appendDigits(b, t.Nanosecond, 9)
+		for b[len(b)-1] == '0' {
+			b = b[:len(b)-1]
+		}
+	}
+	return b
+}
+
+// valid reports whether t is a time of day that TOML can represent.
+func (t LocalTime) valid() bool {
+	return 0 <= t.Hour && t.Hour <= 23 &&
+		0 <= t.Minute && t.Minute <= 59 &&
+		0 <= t.Second && t.Second <= 59 &&
+		0 <= t.Nanosecond && t.Nanosecond <= 999999999
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// The encoding is the same as returned by [LocalTime.String].
+func (t LocalTime) MarshalText() ([]byte, error) {
+	if !t.valid() {
+		return nil, errors.New("toml: invalid LocalTime")
+	}
+	return t.appendTo(nil), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The time must be in the form 15:04:05, optionally followed by a
+// fractional second.
+func (t *LocalTime) UnmarshalText(text []byte) error {
+	v, err := parseDateTime(text)
+	if err != nil || v.kind != kindLocalTime {
+		return errors.New("toml: invalid local time " + strconv.Quote(string(text)))
+	}
+	*t = v.scalar.(LocalTime)
+	return nil
+}
+
+// A LocalDateTime is a TOML local date-time: a date and time of day
+// without relation to a time zone, such as 1979-05-27T07:32:00.
+type LocalDateTime struct {
+	Date LocalDate
+	Time LocalTime
+}
+
+// String returns the date-time in the form 2006-01-02T15:04:05.999999999,
+// omitting trailing zeros of the fractional second.
+func (dt LocalDateTime) String() string {
+	return string(dt.appendTo(nil))
+}
+
+func (dt LocalDateTime) appendTo(b []byte) []byte {
+	b = dt.Date.appendTo(b)
+	b = append(b, 'T')
+	return dt.Time.appendTo(b)
+}
+
+func (dt LocalDateTime) valid() bool {
+	return dt.Date.valid() && dt.Time.valid()
+}
+
+// In returns the time in the location loc at the date and time of day
+// given by dt.
+func (dt LocalDateTime) In(loc *time.Location) time.Time {
+	return time.Date(dt.Date.Year, dt.Date.Month, dt.Date.Day,
+		dt.Time.Hour, dt.Time.Minute, dt.Time.Second, dt.Time.Nanosecond, loc)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+// The encoding is the same as returned by [LocalDateTime.String].
+func (dt LocalDateTime) MarshalText() ([]byte, error) {
+	if !dt.valid() {
+		return nil, errors.New("toml: invalid LocalDateTime")
+	}
+	return dt.appendTo(nil), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// The date-time must be in the form 2006-01-02T15:04:05, optionally
+// followed by a fractional second. The T may also be a space.
+func (dt *LocalDateTime) UnmarshalText(text []byte) error {
+	v, err := parseDateTime(text)
+	if err != nil || v.kind != kindLocalDateTime {
+		return errors.New("toml: invalid local date-time " + strconv.Quote(string(text)))
+	}
+	*dt = v.scalar.(LocalDateTime)
+	return nil
+}
+
+// appendDigits appends the decimal form of x, which must be
+// non-negative, padded with zeros to width digits.
+func appendDigits(b []byte, x, width int) []byte {
+	var buf [20]byte
+	s := strconv.AppendInt(buf[:0], int64(x), 10)
+	for i := len(s); i < width; i++ {
+		b = append(b, '0')
+	}
+	return append(b, s...)
+}
@@ -0,0 +1,649 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xml
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// A CanonicalMethod is an XML canonicalization algorithm.
+type CanonicalMethod int
+
+const (
+	// CanonicalXML11 is Canonical XML Version 1.1,
+	// https://www.w3.org/TR/xml-c14n11/.
+	CanonicalXML11 CanonicalMethod = iota
+
+	// CanonicalXML10 is Canonical XML Version 1.0,
+	// https://www.w3.org/TR/2001/REC-xml-c14n-20010315.
+	// It differs from version 1.1 only in how the subtrees selected by
+	// [Canonicalizer.Subtree] inherit the xml:id and xml:base
+	// attributes of their ancestors.
+	CanonicalXML10
+
+	// ExclusiveCanonicalXML is Exclusive XML Canonicalization
+	// Version 1.0, https://www.w3.org/TR/xml-exc-c14n/. It declares
+	// only the name spaces that an element uses, and subtrees do not
+	// inherit the attributes of their ancestors, so that the canonical
+	// form of a subtree does not depend on the document around it.
+	ExclusiveCanonicalXML
+)
+
+// A Canonicalizer writes the canonical form of an XML document, or of
+// part of it, as needed to compute and verify XML signatures.
+//
+// A Canonicalizer works on the stream of tokens of the document and
+// does not build a tree. The part of the document to canonicalize, the
+// document subset, is made of whole element subtrees, selected by
+// Subtree and pruned by Omit. This covers the same-document references
+// and the enveloped signature transform of XML signatures.
+type Canonicalizer struct {
+	// Method is the canonicalization algorithm.
+	Method CanonicalMethod
+
+	// Comments selects the "with comments" variant of Method,
+	// which keeps the comments of the document subset.
+	Comments bool
+
+	// InclusivePrefixes is the InclusiveNamespaces PrefixList parameter
+	// of exclusive canonicalization: name space prefixes, with
+	// "#default" standing for the default name space, that are declared
+	// as by inclusive canonicalization. It is ignored by other methods.
+	InclusivePrefixes []string
+
+	// Subtree, if not nil, restricts the output to the subtrees rooted
+	// at the elements for which it returns true. If Subtree is nil, the
+	// output is the whole document.
+	//
+	// Subtree and Omit are called with the names of the element and its
+	// attributes resolved as by [Decoder.Token], and are not called
+	// again within a subtree once they have returned true.
+	Subtree func(StartElement) bool
+
+	// Omit, if not nil, removes from the output the subtrees rooted at
+	// the elements for which it returns true, such as the Signature
+	// element for the enveloped signature transform.
+	Omit func(StartElement) bool
+}
+
+// Canonicalize reads an XML document from d and writes its canonical
+// form to w.
+//
+// The Decoder must have been created by [NewDecoder] and not used
+// yet. Canonicalize reads the document with [Decoder.RawToken],
+// normalizing white space in attribute values as the XML specification
+// requires. It returns an error if the document uses an undeclared
+// name space prefix.
+//
+// The canonical form does not include the document type declaration.
+// Because the Decoder does not process it, Canonicalize cannot apply
+// the attribute defaults and entities that a DTD may declare; set
+// [Decoder.DisallowDTD] to reject documents that have one.
+func (c *Canonicalizer) Canonicalize(w io.Writer, d *Decoder) error {
+	if d.t != nil {
+		return errors.New("xml: Canonicalize requires a Decoder returned by NewDecoder")
+	}
+	s := &c14nState{
+		Canonicalizer: c,
+		d:             d,
+		w:             bufio.NewWriter(w),
+		ns:            make(map[string]string),
+		out:           make(map[string]string),
+	}
+	if c.Method == ExclusiveCanonicalXML {
+		for _, prefix := range c.InclusivePrefixes {
+			if prefix == "#default" {
+				prefix = ""
+			}
+			s.inclusive = append(s.inclusive, prefix)
+		}
+	}
+
+	d.normalizeAttr = true
+	defer func() { d.normalizeAttr = false }()
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case StartElement:
+			err = s.start(t)
+		case EndElement:
+			err = s.end(t)
+		case CharData:
+			if len(s.stack) > 0 && s.stack[len(s.stack)-1].output {
+				s.escapeText(t)
+			}
+		case Comment:
+			if c.Comments && s.misc() {
+				s.w.WriteString("<!--")
+				s.w.Write(t)
+				s.w.WriteString("-->")
+				s.endMisc()
+			}
+		case ProcInst:
+			if t.Target != xmlPrefix && s.misc() {
+				s.w.WriteString("<?")
+				s.w.WriteString(t.Target)
+				if len(t.Inst) > 0 {
+					s.w.WriteByte(' ')
+					s.w.Write(t.Inst)
+				}
+				s.w.WriteString("?>")
+				s.endMisc()
+			}
+		case Directive:
+			// The document type declaration is not part of the canonical form.
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(s.stack) > 0 {
+		return d.syntaxError("unexpected EOF")
+	}
+	return s.w.Flush()
+}
+
+// c14nState is the state of a call to Canonicalize.
+type c14nState struct {
+	*Canonicalizer
+	d         *Decoder
+	w         *bufio.Writer
+	inclusive []string // InclusivePrefixes, with "" for #default
+
+	ns      map[string]string // name space declarations in scope in the input
+	nsUndo  []nsBinding
+	out     map[string]string // name space declarations in effect in the output
+	outUndo []nsBinding
+
+	stack     []c14nElem
+	afterRoot bool // the document element has ended
+}
+
+// A c14nElem is an open element.
+type c14nElem struct {
+	name    Name // as written, with the prefix as Space
+	output  bool // in the document subset
+	omitted bool // in a subtree removed by Omit
+	nsMark  int  // len(nsUndo) before the element's declarations
+	outMark int  // len(outUndo) before the element's declarations
+	xmlAttr []Attr
+}
+
+// A c14nAttr is an attribute being written.
+type c14nAttr struct {
+	name  Name // as written
+	url   string
+	value string
+}
+
+// pushBinding binds prefix to url in m, recording the change in undo.
+func pushBinding(m map[string]string, undo *[]nsBinding, prefix, url string) {
+	prev, ok := m[prefix]
+	*undo = append(*undo, nsBinding{prefix: prefix, url: url, prev: prev, hadPrev: ok})
+	m[prefix] = url
+}
+
+// popBindings undoes the changes to m recorded in undo[mark:].
+func popBindings(m map[string]string, undo *[]nsBinding, mark int) {
+	for i := len(*undo) - 1; i >= mark; i-- {
+		b := &(*undo)[i]
+		if b.hadPrev {
+			m[b.prefix] = b.prev
+		} else {
+			delete(m, b.prefix)
+		}
+	}
+	*undo = (*undo)[:mark]
+}
+
+// resolve returns the name space of the element or attribute name n.
+func (s *c14nState) resolve(n Name, isElementName bool) (string, error) {
+	switch {
+	case n.Space == xmlnsPrefix:
+		return xmlnsPrefix, nil
+	case n.Space == "" && !isElementName:
+		return "", nil
+	case n.Space == xmlPrefix:
+		return xmlURL, nil
+	}
+	url, ok := s.ns[n.Space]
+	if !ok && n.Space != "" {
+		return "", s.d.syntaxError("undeclared name space prefix " + n.Space)
+	}
+	return url, nil
+}
+
+func (s *c14nState) start(t StartElement) error {
+	e := c14nElem{name: t.Name, nsMark: len(s.nsUndo), outMark: len(s.outUndo)}
+	for _, a := range t.Attr {
+		switch {
+		case a.Name.Space == xmlnsPrefix:
+			pushBinding(s.ns, &s.nsUndo, a.Name.Local, a.Value)
+		case a.Name.Space == "" && a.Name.Local == xmlnsPrefix:
+			pushBinding(s.ns, &s.nsUndo, "", a.Value)
+		case a.Name.Space == xmlPrefix:
+			e.xmlAttr = append(e.xmlAttr, a)
+		}
+	}
+
+	// Resolve the names, for the callbacks and to sort the attributes.
+	resolved := StartElement{Name: t.Name, Attr: make([]Attr, len(t.Attr))}
+	var err error
+	if resolved.Name.Space, err = s.resolve(t.Name, true); err != nil {
+		return err
+	}
+	for i, a := range t.Attr {
+		resolved.Attr[i] = a
+		if resolved.Attr[i].Name.Space, err = s.resolve(a.Name, false); err != nil {
+			return err
+		}
+	}
+
+	var parent *c14nElem
+	if len(s.stack) > 0 {
+		parent = &s.stack[len(s.stack)-1]
+	}
+	switch {
+	case parent != nil && parent.omitted:
+		e.omitted = true
+	case parent != nil && parent.output:
+		e.output = true
+	default:
+		e.output = s.Subtree == nil || s.Subtree(resolved)
+	}
+	if e.output && s.Omit != nil && s.Omit(resolved) {
+		e.output, e.omitted = false, true
+	}
+	if e.output {
+		s.writeStart(t, resolved, parent == nil || !parent.output)
+	}
+	s.stack = append(s.stack, e)
+	return nil
+}
+
+// writeStart writes the start tag of an element of the document subset.
+// apex reports whether its parent is not in the subset.
+func (s *c14nState) writeStart(t, resolved StartElement, apex bool) {
+	// Name space declarations.
+	var decls []nsBinding
+	declare := func(prefix string) {
+		url, ok := s.ns[prefix]
+		if prefix == xmlPrefix || !ok && prefix != "" {
+			return
+		}
+		if s.out[prefix] != url && !slices.ContainsFunc(decls, func(b nsBinding) bool { return b.prefix == prefix }) {
+			decls = append(decls, nsBinding{prefix: prefix, url: url})
+		}
+	}
+	if s.Method == ExclusiveCanonicalXML {
+		// Declare the visibly utilized prefixes.
+		declare(t.Name.Space)
+		for _, a := range t.Attr {
+			if a.Name.Space != "" && a.Name.Space != xmlnsPrefix {
+				declare(a.Name.Space)
+			}
+		}
+		for _, prefix := range s.inclusive {
+			declare(prefix)
+		}
+	} else {
+		for prefix := range s.ns {
+			declare(prefix)
+		}
+	}
+	slices.SortFunc(decls, func(a, b nsBinding) int { return strings.Compare(a.prefix, b.prefix) })
+	for _, b := range decls {
+		pushBinding(s.out, &s.outUndo, b.prefix, b.url)
+	}
+
+	// Attributes.
+	var attrs []c14nAttr
+	for i, a := range t.Attr {
+		url := resolved.Attr[i].Name.Space
+		if url == xmlnsPrefix || a.Name.Space == "" && a.Name.Local == xmlnsPrefix {
+			continue
+		}
+		attrs = append(attrs, c14nAttr{a.Name, url, a.Value})
+	}
+	if apex && len(s.stack) > 0 && s.Method != ExclusiveCanonicalXML {
+		attrs = s.inheritXMLAttrs(attrs)
+	}
+	slices.SortFunc(attrs, func(a, b c14nAttr) int {
+		if c := strings.Compare(a.url, b.url); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name.Local, b.name.Local)
+	})
+
+	s.w.WriteByte('<')
+	s.writeName(t.Name)
+	for _, b := range decls {
+		s.w.WriteString(" xmlns")
+		if b.prefix != "" {
+			s.w.WriteByte(':')
+			s.w.WriteString(b.prefix)
+		}
+		s.w.WriteString(`="`)
+		s.escapeAttr(b.url)
+		s.w.WriteByte('"')
+	}
+	for _, a := range attrs {
+		s.w.WriteByte(' ')
+		s.writeName(a.name)
+		s.w.WriteString(`="`)
+		s.escapeAttr(a.value)
+		s.w.WriteByte('"')
+	}
+	s.w.WriteByte('>')
+}
+
+// inheritXMLAttrs adds to the attributes of the apex of a subtree the
+// attributes in the xml name space that it inherits from its ancestors
+// under inclusive canonicalization.
+func (s *c14nState) inheritXMLAttrs(attrs []c14nAttr) []c14nAttr {
+	has := func(local string) int {
+		return slices.IndexFunc(attrs, func(a c14nAttr) bool { return a.url == xmlURL && a.name.Local == local })
+	}
+	var base string
+	var haveBase bool
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		for _, a := range s.stack[i].xmlAttr {
+			switch {
+			case a.Name.Local == "base" && s.Method == CanonicalXML11:
+				haveBase = true
+			case s.Method == CanonicalXML11 && a.Name.Local != "lang" && a.Name.Local != "space":
+				// Canonical XML 1.1 inherits only the simple inheritable attributes.
+			case has(a.Name.Local) < 0:
+				attrs = append(attrs, c14nAttr{a.Name, xmlURL, a.Value})
+			}
+		}
+	}
+	if !haveBase {
+		return attrs
+	}
+
+	// xml:base fixup: join the values of the ancestors,
+	// starting from the document element.
+	for _, e := range s.stack {
+		for _, a := range e.xmlAttr {
+			if a.Name.Local == "base" {
+				base = joinBase(base, a.Value)
+			}
+		}
+	}
+	if i := has("base"); i >= 0 {
+		attrs[i].value = joinBase(base, attrs[i].value)
+	} else if base != "" {
+		attrs = append(attrs, c14nAttr{Name{xmlPrefix, "base"}, xmlURL, base})
+	}
+	return attrs
+}
+
+func (s *c14nState) end(t EndElement) error {
+	if len(s.stack) == 0 {
+		return s.d.syntaxError("unexpected end element </" + qualifiedName(t.Name) + ">")
+	}
+	e := &s.stack[len(s.stack)-1]
+	if e.name != t.Name {
+		return s.d.syntaxError("element <" + qualifiedName(e.name) + "> closed by </" + qualifiedName(t.Name) + ">")
+	}
+	if e.output {
+		s.w.WriteString("</")
+		s.writeName(e.name)
+		s.w.WriteByte('>')
+	}
+	popBindings(s.out, &s.outUndo, e.outMark)
+	popBindings(s.ns, &s.nsUndo, e.nsMark)
+	s.stack = s.stack[:len(s.stack)-1]
+	if len(s.stack) == 0 {
+		s.afterRoot = true
+	}
+	return nil
+}
+
+// misc reports whether a comment or processing instruction at the
+// current position is output, writing the line break that precedes it
+// if it follows the document element.
+func (s *c14nState) misc() bool {
+	if len(s.stack) > 0 {
+		return s.stack[len(s.stack)-1].output
+	}
+	// Outside the document element, there is a line break
+	// between the node and the document element.
+	if s.Subtree != nil {
+		return false
+	}
+	if s.afterRoot {
+		s.w.WriteByte('\n')
+	}
+	return true
+}
+
+// endMisc writes the line break that follows a comment or processing
+// instruction that precedes the document element.
+func (s *c14nState) endMisc() {
+	if len(s.stack) == 0 && !s.afterRoot {
+		s.w.WriteByte('\n')
+	}
+}
+
+func (s *c14nState) writeName(n Name) {
+	if n.Space != "" {
+		s.w.WriteString(n.Space)
+		s.w.WriteByte(':')
+	}
+	s.w.WriteString(n.Local)
+}
+
+func qualifiedName(n Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+func (s *c14nState) escapeText(b []byte) {
+	last := 0
+	for i, c := range b {
+		var esc string
+		switch c {
+		case '&':
+			esc = "&amp;"
+		case '<':
+			esc = "&lt;"
+		case '>':
+			esc = "&gt;"
+		case '\r':
+			esc = "&#xD;"
+		default:
+			continue
+		}
+		s.w.Write(b[last:i])
+		s.w.WriteString(esc)
+		last = i + 1
+	}
+	s.w.Write(b[last:])
+}
+
+func (s *c14nState) escapeAttr(v string) {
+	last := 0
+	for i := 0; i < len(v); i++ {
+		var esc string
+		switch v[i] {
+		case '&':
+			esc = "&amp;"
+		case '<':
+			esc = "&lt;"
+		case '"':
+			esc = "&quot;"
+		case '\t':
+			esc = "&#x9;"
+		case '\n':
+			esc = "&#xA;"
+		case '\r':
+			esc = "&#xD;"
+		default:
+			continue
+		}
+		s.w.WriteString(v[last:i])
+		s.w.WriteString(esc)
+		last = i + 1
+	}
+	s.w.WriteString(v[last:])
+}
+
+// joinBase resolves the URI reference ref against base, as RFC 3986
+// does, except that leading ".." segments of relative paths are kept,
+// as Canonical XML 1.1 requires for the xml:base fixup.
+func joinBase(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+	b, r := parseURIRef(base), parseURIRef(ref)
+	var t uriRef
+	switch {
+	case r.scheme != "":
+		t = r
+		t.path = removeDotSegments(r.path)
+	case r.hasAuthority:
+		t = r
+		t.scheme = b.scheme
+		t.path = removeDotSegments(r.path)
+	case r.path == "":
+		t = b
+		t.hasFragment = false
+		if r.hasQuery {
+			t.query, t.hasQuery = r.query, true
+		}
+	default:
+		t = b
+		if strings.HasPrefix(r.path, "/") {
+			t.path = removeDotSegments(r.path)
+		} else if b.hasAuthority && b.path == "" {
+			t.path = removeDotSegments("/" + r.path)
+		} else {
+			i := strings.LastIndexByte(b.path, '/')
+			t.path = removeDotSegments(b.path[:i+1] + r.path)
+		}
+		t.query, t.hasQuery = r.query, r.hasQuery
+	}
+	t.fragment, t.hasFragment = r.fragment, r.hasFragment
+	return t.String()
+}
+
+// A uriRef is an RFC 3986 URI reference split into its components.
+type uriRef struct {
+	scheme, authority, path, query, fragment string
+	hasAuthority, hasQuery, hasFragment      bool
+}
+
+func parseURIRef(s string) uriRef {
+	var u uriRef
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		u.fragment, u.hasFragment = s[i+1:], true
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		u.query, u.hasQuery = s[i+1:], true
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, ':'); i > 0 && isScheme(s[:i]) {
+		u.scheme = s[:i]
+		s = s[i+1:]
+	}
+	if rest, ok := strings.CutPrefix(s, "//"); ok {
+		i := strings.IndexByte(rest, '/')
+		if i < 0 {
+			i = len(rest)
+		}
+		u.authority, u.hasAuthority = rest[:i], true
+		s = rest[i:]
+	}
+	u.path = s
+	return u
+}
+
+func isScheme(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		case i > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+func (u uriRef) String() string {
+	var b strings.Builder
+	if u.scheme != "" {
+		b.WriteString(u.scheme)
+		b.WriteByte(':')
+	}
+	if u.hasAuthority {
+		b.WriteString("//")
+		b.WriteString(u.authority)
+	}
+	b.WriteString(u.path)
+	if u.hasQuery {
+		b.WriteByte('?')
+		b.WriteString(u.query)
+	}
+	if u.hasFragment {
+		b.WriteByte('#')
+		b.WriteString(u.fragment)
+	}
+	return b.String()
+}
+
+// removeDotSegments removes the "." and ".." segments of path, keeping
+// the ".." segments of a relative path that have nothing to remove.
+func removeDotSegments(path string) string {
+	if path == "" {
+		return ""
+	}
+	abs := path[0] == '/'
+	segs := strings.Split(path, "/")
+	if abs {
+		segs = segs[1:]
+	}
+	var out []string
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		switch seg {
+		case ".":
+		case "..":
+			if n := len(out); n > 0 && out[n-1] != ".." {
+				out = out[:n-1]
+			} else if !abs {
+				out = append(out, "..")
+			}
+		default:
+			out = append(out, seg)
+			continue
+		}
+		if last {
+			// Keep the trailing slash.
+			out = append(out, "")
+		}
+	}
+	p := strings.Join(out, "/")
+	if abs {
+		p = "/" + p
+	}
+	return p
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xml
+
+import (
+	"strings"
+	"testing"
+)
+
+// Examples from section 3 of the Canonical XML specification.
+const (
+	c14nPIsAndComments = `<?xml version="1.0"?>
+
+<?xml-stylesheet   href="doc.xsl"
+   type="text/xsl"   ?>
+
+<!DOCTYPE doc SYSTEM "doc.dtd">
+
+<doc>Hello, world!<!-- Comment 1 --></doc>
+
+<?pi-without-data     ?>
+
+<!-- Comment 2 -->
+
+<!-- Comment 3 -->
+`
+
+	// The attr attribute of e9 is given explicitly, since the
+	// Decoder does not apply attribute defaults from a DTD.
+	c14nStartEndTags = `<doc>
+   <e1   />
+   <e2   ></e2>
+   <e3   name = "elem3"   id="elem3"   />
+   <e4   name="elem4"   id="elem4"   ></e4>
+   <e5 a:attr="out" b:attr="sorted" attr2="all" attr="I'm"
+      xmlns:b="http://www.ietf.org"
+      xmlns:a="http://www.w3.org"
+      xmlns="http://example.org"/>
+   <e6 xmlns="" xmlns:a="http://www.w3.org">
+      <e7 xmlns="http://www.ietf.org">
+         <e8 xmlns="" xmlns:a="http://www.w3.org">
+            <e9 xmlns="" xmlns:a="http://www.ietf.org" attr="default"/>
+         </e8>
+      </e7>
+   </e6>
+</doc>`
+
+	c14nCharModifications = `<doc>
+   <text>First line&#x0d;&#10;Second line</text>
+   <value>&#x32;</value>
+   <compute><![CDATA[value>"0" && value<"10" ?"valid":"error"]]></compute>
+   <compute expr='value>"0" &amp;&amp; value&lt;"10" ?"valid":"error"'>valid</compute>
+   <norm attr=' &apos;   &#x20;&#13;&#xa;&#9;   &apos; '/>
+</doc>`
+
+	// From section 2.2 of the Exclusive XML Canonicalization specification.
+	c14nExclusive = `<n0:local xmlns:n0="foo:bar" xmlns:n3="ftp://example.org">
+  <n1:elem2 xmlns:n1="http://example.net" xml:lang="en">
+     <n3:stuff xmlns:n3="ftp://example.org"/>
+  </n1:elem2>
+</n0:local>`
+
+	c14nXMLAttrs = `<a xml:lang="en" xml:id="x" xml:base="http://e.org/dir/"><b xml:base="sub/"><c xml:space="preserve"/></b></a>`
+
+	c14nSignature = `<doc xmlns="urn:doc" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" xmlns:p="urn:p">
+  <p:item id="1">text</p:item>
+  <ds:Signature><ds:SignedInfo/></ds:Signature>
+</doc>`
+)
+
+func elementNamed(local string) func(StartElement) bool {
+	return func(e StartElement) bool { return e.Name.Local == local }
+}
+
+var canonicalizeTests = []struct {
+	name string
+	c    Canonicalizer
+	in   string
+	out  string
+}{
+	{
+		name: "PIsAndComments",
+		in:   c14nPIsAndComments,
+		out: `<?xml-stylesheet href="doc.xsl"
+   type="text/xsl"   ?>
+<doc>Hello, world!</doc>
+<?pi-without-data?>`,
+	},
+	{
+		name: "PIsAndCommentsWithComments",
+		c:    Canonicalizer{Comments: true},
+		in:   c14nPIsAndComments,
+		out: `<?xml-stylesheet href="doc.xsl"
+   type="text/xsl"   ?>
+<doc>Hello, world!<!-- Comment 1 --></doc>
+<?pi-without-data?>
+<!-- Comment 2 -->
+<!-- Comment 3 -->`,
+	},
+	{
+		name: "StartEndTags",
+		in:   c14nStartEndTags,
+		out: `<doc>
+   <e1></e1>
+   <e2></e2>
+   <e3 id="elem3" name="elem3"></e3>
+   <e4 id="elem4" name="elem4"></e4>
+   <e5 xmlns="http://example.org" xmlns:a="http://www.w3.org" xmlns:b="http://www.ietf.org" attr="I'm" attr2="all" b:attr="sorted" a:attr="out"></e5>
+   <e6 xmlns:a="http://www.w3.org">
+      <e7 xmlns="http://www.ietf.org">
+         <e8 xmlns="">
+            <e9 xmlns:a="http://www.ietf.org" attr="default"></e9>
+         </e8>
+      </e7>
+   </e6>
+</doc>`,
+	},
+	{
+		name: "CharModifications",
+		in:   c14nCharModifications,
+		out: `<doc>
+   <text>First line&#xD;
+Second line</text>
+   <value>2</value>
+   <compute>value&gt;"0" &amp;&amp; value&lt;"10" ?"valid":"error"</compute>
+   <compute expr="value>&quot;0&quot; &amp;&amp; value&lt;&quot;10&quot; ?&quot;valid&quot;:&quot;error&quot;">valid</compute>
+   <norm attr=" '    &#xD;&#xA;&#x9;   ' "></norm>
+</doc>`,
+	},
+	{
+		name: "InclusiveSubtree",
+		c:    Canonicalizer{Subtree: elementNamed("elem2")},
+		in:   c14nExclusive,
+		out: `<n1:elem2 xmlns:n0="foo:bar" xmlns:n1="http://example.net" xmlns:n3="ftp://example.org" xml:lang="en">
+     <n3:stuff></n3:stuff>
+  </n1:elem2>`,
+	},
+	{
+		name: "ExclusiveSubtree",
+		c:    Canonicalizer{Method: ExclusiveCanonicalXML, Subtree: elementNamed("elem2")},
+		in:   c14nExclusive,
+		out: `<n1:elem2 xmlns:n1="http://example.net" xml:lang="en">
+     <n3:stuff xmlns:n3="ftp://example.org"></n3:stuff>
+  </n1:elem2>`,
+	},
+	{
+		name: "ExclusiveInclusivePrefixes",
+		c: Canonicalizer{
+			Method:            ExclusiveCanonicalXML,
+			InclusivePrefixes: []string{"n0", "#default"},
+			Subtree:           elementNamed("elem2"),
+		},
+		in: c14nExclusive,
+		out: `<n1:elem2 xmlns:n0="foo:bar" xmlns:n1="http://example.net" xml:lang="en">
+     <n3:stuff xmlns:n3="ftp://example.org"></n3:stuff>
+  </n1:elem2>`,
+	},
+	{
+		name: "XMLAttrs11",
+		c:    Canonicalizer{Subtree: elementNamed("c")},
+		in:   c14nXMLAttrs,
+		out:  `<c xml:base="http://e.org/dir/sub/" xml:lang="en" xml:space="preserve"></c>`,
+	},
+	{
+		name: "XMLAttrs10",
+		c:    Canonicalizer{Method: CanonicalXML10, Subtree: elementNamed("c")},
+		in:   c14nXMLAttrs,
+		out:  `<c xml:base="sub/" xml:id="x" xml:lang="en" xml:space="preserve"></c>`,
+	},
+	{
+		name: "XMLAttrsExclusive",
+		c:    Canonicalizer{Method: ExclusiveCanonicalXML, Subtree: elementNamed("c")},
+		in:   c14nXMLAttrs,
+		out:  `<c xml:space="preserve"></c>`,
+	},
+	{
+		name: "EnvelopedSignature",
+		c:    Canonicalizer{Omit: elementNamed("Signature")},
+		in:   c14nSignature,
+		out: `<doc xmlns="urn:doc" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" xmlns:p="urn:p">
+  <p:item id="1">text</p:item>
+  ` + "\n</doc>",
+	},
+	{
+		name: "EnvelopedSignatureExclusive",
+		c: Canonicalizer{
+			Method:  ExclusiveCanonicalXML,
+			Subtree: elementNamed("item"),
+			Omit:    elementNamed("Signature"),
+		},
+		in:  c14nSignature,
+		out: `<p:item xmlns:p="urn:p" id="1">text</p:item>`,
+	},
+}
+
+func TestCanonicalize(t *testing.T) {
+	for _, tt := range canonicalizeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := tt.c.Canonicalize(&b, NewDecoder(strings.NewReader(tt.in))); err != nil {
+				t.Fatalf("Canonicalize error: %v", err)
+			}
+			if b.String() != tt.out {
+				t.Errorf("Canonicalize:\ngot  %s\nwant %s", b.String(), tt.out)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeErrors(t *testing.T) {
+	tests := []struct {
+		in  string
+		err string
+	}{
+		{`<a><p:b/></a>`, "undeclared name space prefix p"},
+		{`<a></b>`, "element <a> closed by </b>"},
+		{`<a>`, "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		var c Canonicalizer
+		err := c.Canonicalize(new(strings.Builder), NewDecoder(strings.NewReader(tt.in)))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Canonicalize(%q) error = %v, want %q", tt.in, err, tt.err)
+		}
+	}
+
+	d := NewDecoder(strings.NewReader(`<!DOCTYPE a><a/>`))
+	d.DisallowDTD = true
+	var c Canonicalizer
+	if err := c.Canonicalize(new(strings.Builder), d); err == nil {
+		t.Errorf("Canonicalize with DisallowDTD accepted a DOCTYPE")
+	}
+}
+
+func TestJoinBase(t *testing.T) {
+	tests := []struct {
+		base, ref, want string
+	}{
+		// From section 5.4 of RFC 3986.
+		{"http://a/b/c/d;p?q", "g", "http://a/b/c/g"},
+		{"http://a/b/c/d;p?q", "./g", "http://a/b/c/g"},
+		{"http://a/b/c/d;p?q", "g/", "http://a/b/c/g/"},
+		{"http://a/b/c/d;p?q", "/g", "http://a/g"},
+		{"http://a/b/c/d;p?q", "//g", "http://g"},
+		{"http://a/b/c/d;p?q", "?y", "http://a/b/c/d;p?y"},
+		{"http://a/b/c/d;p?q", "#s", "http://a/b/c/d;p?q#s"},
+		{"http://a/b/c/d;p?q", "", "http://a/b/c/d;p?q"},
+		{"http://a/b/c/d;p?q", "..", "http://a/b/"},
+		{"http://a/b/c/d;p?q", "../g", "http://a/b/g"},
+		{"http://a/b/c/d;p?q", "../../g", "http://a/g"},
+		{"http://a/b/c/d;p?q", "../../../g", "http://a/g"},
+		{"http://a/b/c/d;p?q", "g;x=1/../y", "http://a/b/c/y"},
+		{"http://a/b/c/d;p?q", "g:h", "g:h"},
+
+		// Relative bases, as allowed by Canonical XML 1.1.
+		{"../a/", "b/", "../a/b/"},
+		{"a/b/", "../../../c", "../c"},
+		{"", "c", "c"},
+	}
+	for _, tt := range tests {
+		if got := joinBase(tt.base, tt.ref); got != tt.want {
+			t.Errorf("joinBase(%q, %q) = %q, want %q", tt.base, tt.ref, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"strings"
 )
 
 func ExampleMarshalIndent() {
@@ -90,6 +91,67 @@ func ExampleEncoder() {
 	//   </person>
 }
 
+func ExampleEncoder_BindPrefix() {
+	type Item struct {
+		XMLName xml.Name `xml:"urn:example:order item"`
+		SKU     string   `xml:"urn:example:order sku,attr"`
+		Name    string   `xml:"urn:example:order name"`
+	}
+	type Order struct {
+		XMLName xml.Name `xml:"urn:example:order order"`
+		Items   []Item
+	}
+
+	enc := xml.NewEncoder(os.Stdout)
+	enc.Indent("", "  ")
+	if err := enc.BindPrefix("o", "urn:example:order"); err != nil {
+		fmt.Printf("error: %v\n", err)
+	}
+	v := Order{Items: []Item{{SKU: "A1", Name: "Widget"}}}
+	if err := enc.Encode(v); err != nil {
+		fmt.Printf("error: %v\n", err)
+	}
+
+	// Output:
+	// <o:order xmlns:o="urn:example:order">
+	//   <o:item o:sku="A1">
+	//     <o:name>Widget</o:name>
+	//   </o:item>
+	// </o:order>
+}
+
+// This example computes the exclusive canonical form of the element
+// signed by an enveloped XML signature, leaving out the Signature
+// element itself.
+func ExampleCanonicalizer() {
+	const doc = `<?xml version="1.0"?>
+<env:Envelope xmlns:env="urn:example:env" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+  <env:Body xmlns:b="urn:example:body" b:Id='body' >
+    <b:Value>1 &lt; 2</b:Value><ds:Signature><ds:SignedInfo/></ds:Signature>
+  </env:Body>
+</env:Envelope>`
+
+	c := &xml.Canonicalizer{
+		Method: xml.ExclusiveCanonicalXML,
+		Subtree: func(e xml.StartElement) bool {
+			return e.Name.Local == "Body"
+		},
+		Omit: func(e xml.StartElement) bool {
+			return e.Name.Space == "http://www.w3.org/2000/09/xmldsig#" && e.Name.Local == "Signature"
+		},
+	}
+	d := xml.NewDecoder(strings.NewReader(doc))
+	d.DisallowDTD = true
+	if err := c.Canonicalize(os.Stdout, d); err != nil {
+		fmt.Printf("error: %v\n", err)
+	}
+
+	// Output:
+	// <env:Body xmlns:b="urn:example:body" xmlns:env="urn:example:env" b:Id="body">
+	//     <b:Value>1 &lt; 2</b:Value>
+	//   </env:Body>
+}
+
 // This example demonstrates unmarshaling an XML excerpt into a value with
 // some preset fields. Note that the Phone field isn't modified and that
 // the XML <Company> element is ignored. Also, the Groups field is assigned
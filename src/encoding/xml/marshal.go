@@ -60,6 +60,12 @@ const (
 //     if the field value is empty. The empty values are false, 0, any
 //     nil pointer or interface value, and any array, slice, map, or
 //     string of length zero.
+//   - a field with a tag including the "omitzero" option is omitted
+//     if the field value is zero. If the field type has an "IsZero() bool"
+//     method, that method is used to determine whether the value is zero;
+//     otherwise the value is zero if it is the zero value for its type.
+//     If both "omitempty" and "omitzero" are specified, the field is
+//     omitted if its value is either empty or zero.
 //   - an anonymous struct field is handled as if the fields of its
 //     value were part of the outer struct.
 //   - a field implementing [Marshaler] is written by calling its MarshalXML
@@ -160,6 +166,41 @@ func (enc *Encoder) Indent(prefix, indent string) {
 	enc.p.indent = indent
 }
 
+// BindPrefix binds prefix to the name space url, so that the Encoder
+// writes elements and attributes in that name space with the prefix,
+// declaring it with an xmlns:prefix attribute where it is not already
+// in scope. An empty prefix makes url the default name space for the
+// elements in it. Binding another prefix to url replaces the binding.
+//
+// The first call to BindPrefix also makes the Encoder keep track of the
+// name space declarations in scope, for all name spaces: it omits
+// declarations that would repeat one in scope, declares the default
+// name space empty (xmlns="") for elements in no name space below
+// elements in one, and writes the xmlns attributes of a [StartElement]
+// returned by [Decoder.Token], whose Name.Space is "xmlns", as
+// declarations, so that tokens can be re-encoded with their original
+// prefixes.
+//
+// BindPrefix returns an error if prefix is not a valid name space
+// prefix, if it starts with "xml", or if url is empty.
+func (enc *Encoder) BindPrefix(prefix, url string) error {
+	if prefix != "" && (!isNameString(prefix) || strings.Contains(prefix, ":")) {
+		return fmt.Errorf("xml: invalid name space prefix %q", prefix)
+	}
+	if len(prefix) >= 3 && strings.EqualFold(prefix[:3], "xml") {
+		return fmt.Errorf("xml: reserved name space prefix %q", prefix)
+	}
+	if url == "" || url == xmlURL {
+		return fmt.Errorf("xml: cannot bind prefix %q to name space %q", prefix, url)
+	}
+	p := &enc.p
+	if p.nsBound == nil {
+		p.nsBound = make(map[string]string)
+	}
+	p.nsBound[url] = prefix
+	return nil
+}
+
 // Encode writes the XML encoding of v to the stream.
 //
 // See the documentation for [Marshal] for details about the conversion
@@ -330,6 +371,26 @@ type printer struct {
 	tags       []Name
 	closed     bool
 	err        error
+
+	// Name space declarations in scope, tracked for BindPrefix.
+	nsBound  map[string]string // map name space -> prefix bound by BindPrefix
+	ns       map[string]string // map prefix -> name space, "" for the default
+	nsScope  []nsBinding       // declarations of the open elements
+	tagScope []tagScope        // for each open element
+}
+
+// An nsBinding is a name space declaration on an open element,
+// together with the binding of its prefix that it shadows.
+type nsBinding struct {
+	prefix, url string
+	prev        string
+	hadPrev     bool
+}
+
+// A tagScope records how an open element was written.
+type tagScope struct {
+	prefix string // prefix of the element name
+	mark   int    // len(nsScope) before the element's declarations
 }
 
 // createAttrPrefix finds the name space prefix attribute to use for the given name space,
@@ -353,6 +414,25 @@ func (p *printer) createAttrPrefix(url string) string {
 		p.attrNS = make(map[string]string)
 	}
 
+	prefix := p.newPrefix(url, func(prefix string) bool { return p.attrNS[prefix] != "" })
+
+	p.attrPrefix[url] = prefix
+	p.attrNS[prefix] = url
+
+	p.WriteString(`xmlns:`)
+	p.WriteString(prefix)
+	p.WriteString(`="`)
+	EscapeText(p, []byte(url))
+	p.WriteString(`" `)
+
+	p.prefixes = append(p.prefixes, prefix)
+
+	return prefix
+}
+
+// newPrefix picks a new prefix for the name space url
+// that is not taken.
+func (p *printer) newPrefix(url string, taken func(prefix string) bool) string {
 	// Pick a name. We try to use the final element of the path
 	// but fall back to _.
 	prefix := strings.TrimRight(url, "/")
@@ -369,27 +449,15 @@ func (p *printer) createAttrPrefix(url string) string {
 	if len(prefix) >= 3 && strings.EqualFold(prefix[:3], "xml") {
 		prefix = "_" + prefix
 	}
-	if p.attrNS[prefix] != "" {
+	if taken(prefix) {
 		// Name is taken. Find a better one.
 		for p.seq++; ; p.seq++ {
-			if id := prefix + "_" + strconv.Itoa(p.seq); p.attrNS[id] == "" {
+			if id := prefix + "_" + strconv.Itoa(p.seq); !taken(id) {
 				prefix = id
 				break
 			}
 		}
 	}
-
-	p.attrPrefix[url] = prefix
-	p.attrNS[prefix] = url
-
-	p.WriteString(`xmlns:`)
-	p.WriteString(prefix)
-	p.WriteString(`="`)
-	EscapeText(p, []byte(url))
-	p.WriteString(`" `)
-
-	p.prefixes = append(p.prefixes, prefix)
-
 	return prefix
 }
 
@@ -532,6 +600,9 @@ func (p *printer) marshalValue(val reflect.Value, finfo *fieldInfo, startTemplat
 		if finfo.flags&fOmitEmpty != 0 && (!fv.IsValid() || isEmptyValue(fv)) {
 			continue
 		}
+		if finfo.flags&fOmitZero != 0 && (!fv.IsValid() || isZeroValue(fv)) {
+			continue
+		}
 
 		if fv.Kind() == reflect.Interface && fv.IsNil() {
 			continue
@@ -720,8 +791,12 @@ func (p *printer) writeStart(start *StartElement) error {
 	if start.Name.Local == "" {
 		return fmt.Errorf("xml: start tag with no name")
 	}
+	if p.nsBound != nil {
+		return p.writeStartNS(start)
+	}
 
 	p.tags = append(p.tags, start.Name)
+	p.tagScope = append(p.tagScope, tagScope{mark: len(p.nsScope)})
 	p.markPrefix()
 
 	p.writeIndent(1)
@@ -768,16 +843,217 @@ func (p *printer) writeEnd(name Name) error {
 		return fmt.Errorf("xml: end tag </%s> in namespace %s does not match start tag <%s> in namespace %s", name.Local, name.Space, top.Local, top.Space)
 	}
 	p.tags = p.tags[:len(p.tags)-1]
+	scope := p.tagScope[len(p.tagScope)-1]
+	p.tagScope = p.tagScope[:len(p.tagScope)-1]
 
 	p.writeIndent(-1)
 	p.WriteByte('<')
 	p.WriteByte('/')
-	p.WriteString(name.Local)
+	p.writeQName(scope.prefix, name.Local)
 	p.WriteByte('>')
 	p.popPrefix()
+	p.popNS(scope.mark)
 	return nil
 }
 
+// writeStartNS is writeStart for an Encoder that manages
+// name space declarations, as described for [Encoder.BindPrefix].
+func (p *printer) writeStartNS(start *StartElement) error {
+	mark := len(p.nsScope)
+
+	// Process explicit declarations first, so that
+	// the element and attribute names can use them.
+	var attrs []Attr
+	for _, attr := range start.Attr {
+		name := attr.Name
+		var prefix string
+		switch {
+		case name.Local == "":
+			continue
+		case name.Space == xmlnsPrefix:
+			prefix = name.Local
+		case name.Space == "" && name.Local == xmlnsPrefix:
+		case name.Space == "" && strings.HasPrefix(name.Local, "xmlns:"):
+			prefix = name.Local[len("xmlns:"):]
+		default:
+			attrs = append(attrs, attr)
+			continue
+		}
+		if prefix == xmlPrefix {
+			// The xml prefix is predefined.
+			continue
+		}
+		if prefix != "" && attr.Value == "" {
+			p.popNS(mark)
+			return fmt.Errorf("xml: cannot undeclare name space prefix %q", prefix)
+		}
+		if p.ns[prefix] != attr.Value && !p.declaredSince(mark, prefix) {
+			p.bindNS(prefix, attr.Value)
+		}
+	}
+
+	prefix := p.elementPrefix(start.Name.Space, mark)
+	attrPrefixes := make([]string, len(attrs))
+	for i, attr := range attrs {
+		if attr.Name.Space != "" {
+			attrPrefixes[i] = p.nsAttrPrefix(attr.Name.Space, mark)
+		}
+	}
+
+	p.tags = append(p.tags, start.Name)
+	p.tagScope = append(p.tagScope, tagScope{prefix: prefix, mark: mark})
+	p.markPrefix()
+
+	p.writeIndent(1)
+	p.WriteByte('<')
+	p.writeQName(prefix, start.Name.Local)
+	for _, b := range p.nsScope[mark:] {
+		p.WriteString(" xmlns")
+		if b.prefix != "" {
+			p.WriteByte(':')
+			p.WriteString(b.prefix)
+		}
+		p.WriteString(`="`)
+		p.EscapeString(b.url)
+		p.WriteByte('"')
+	}
+	for i, attr := range attrs {
+		p.WriteByte(' ')
+		p.writeQName(attrPrefixes[i], attr.Name.Local)
+		p.WriteString(`="`)
+		p.EscapeString(attr.Value)
+		p.WriteByte('"')
+	}
+	p.WriteByte('>')
+	return nil
+}
+
+func (p *printer) writeQName(prefix, local string) {
+	if prefix != "" {
+		p.WriteString(prefix)
+		p.WriteByte(':')
+	}
+	p.WriteString(local)
+}
+
+// elementPrefix returns the prefix to use for an element in the name
+// space url, declaring it on the element being written if necessary.
+// mark is the length of nsScope before the element's declarations.
+func (p *printer) elementPrefix(url string, mark int) string {
+	switch url {
+	case "":
+		if p.ns[""] != "" && !p.declaredSince(mark, "") {
+			p.bindNS("", "")
+		}
+		return ""
+	case xmlURL:
+		return xmlPrefix
+	}
+	if prefix, ok := p.nsBound[url]; ok {
+		if p.ns[prefix] == url {
+			return prefix
+		}
+		if !p.declaredSince(mark, prefix) {
+			p.bindNS(prefix, url)
+			return prefix
+		}
+	}
+	if p.ns[""] == url {
+		return ""
+	}
+	if prefix, ok := p.lookupPrefix(url); ok {
+		return prefix
+	}
+	if !p.declaredSince(mark, "") {
+		p.bindNS("", url)
+		return ""
+	}
+	prefix := p.newPrefix(url, p.prefixTaken)
+	p.bindNS(prefix, url)
+	return prefix
+}
+
+// nsAttrPrefix is like elementPrefix, but for an attribute,
+// which is in no name space unless it has a prefix.
+func (p *printer) nsAttrPrefix(url string, mark int) string {
+	if url == xmlURL {
+		return xmlPrefix
+	}
+	if prefix := p.nsBound[url]; prefix != "" {
+		if p.ns[prefix] == url {
+			return prefix
+		}
+		if !p.declaredSince(mark, prefix) {
+			p.bindNS(prefix, url)
+			return prefix
+		}
+	}
+	if prefix, ok := p.lookupPrefix(url); ok {
+		return prefix
+	}
+	prefix := p.newPrefix(url, p.prefixTaken)
+	p.bindNS(prefix, url)
+	return prefix
+}
+
+// lookupPrefix returns a non-empty prefix bound to url in scope.
+func (p *printer) lookupPrefix(url string) (string, bool) {
+	for i := len(p.nsScope) - 1; i >= 0; i-- {
+		b := &p.nsScope[i]
+		if b.prefix != "" && b.url == url && p.ns[b.prefix] == url {
+			return b.prefix, true
+		}
+	}
+	return "", false
+}
+
+// prefixTaken reports whether prefix is in scope or bound by BindPrefix.
+func (p *printer) prefixTaken(prefix string) bool {
+	if _, ok := p.ns[prefix]; ok {
+		return true
+	}
+	for _, bound := range p.nsBound {
+		if bound == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// declaredSince reports whether prefix has been declared
+// on the element whose declarations start at nsScope[mark].
+func (p *printer) declaredSince(mark int, prefix string) bool {
+	for _, b := range p.nsScope[mark:] {
+		if b.prefix == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// bindNS declares prefix for url on the element being written.
+func (p *printer) bindNS(prefix, url string) {
+	if p.ns == nil {
+		p.ns = make(map[string]string)
+	}
+	prev, ok := p.ns[prefix]
+	p.nsScope = append(p.nsScope, nsBinding{prefix: prefix, url: url, prev: prev, hadPrev: ok})
+	p.ns[prefix] = url
+}
+
+// popNS removes the declarations from nsScope[mark:].
+func (p *printer) popNS(mark int) {
+	for i := len(p.nsScope) - 1; i >= mark; i-- {
+		b := &p.nsScope[i]
+		if b.hadPrev {
+			p.ns[b.prefix] = b.prev
+		} else {
+			delete(p.ns, b.prefix)
+		}
+	}
+	p.nsScope = p.nsScope[:mark]
+}
+
 func (p *printer) marshalSimple(typ reflect.Type, val reflect.Value) (string, []byte, error) {
 	switch val.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -842,6 +1118,9 @@ func (p *printer) marshalStruct(tinfo *typeInfo, val reflect.Value) error {
 			// nil. Skip it.
 			continue
 		}
+		if finfo.flags&fOmitZero != 0 && isZeroValue(vf) {
+			continue
+		}
 
 		switch finfo.flags & fMode {
 		case fCDATA, fCharData:
@@ -1129,3 +1408,46 @@ func isEmptyValue(v reflect.Value) bool {
 	}
 	return false
 }
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeFor[isZeroer]()
+
+// isZeroValue reports whether v is zero for the purposes of the
+// "omitzero" option, preferring an IsZero method when one is declared.
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		// Avoid panics calling IsZero on a nil interface or
+		// non-nil interface with nil pointer.
+		if v.IsNil() {
+			return true
+		}
+		if e := v.Elem(); e.Kind() == reflect.Pointer && e.IsNil() {
+			return true
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return true
+		}
+	}
+	if !v.CanInterface() {
+		return v.IsZero()
+	}
+	t := v.Type()
+	switch {
+	case t.Implements(isZeroerType):
+		return v.Interface().(isZeroer).IsZero()
+	case reflect.PointerTo(t).Implements(isZeroerType):
+		if !v.CanAddr() {
+			// Temporarily box v so we can take the address.
+			v2 := reflect.New(t).Elem()
+			v2.Set(v)
+			v = v2
+		}
+		return v.Addr().Interface().(isZeroer).IsZero()
+	}
+	return v.IsZero()
+}
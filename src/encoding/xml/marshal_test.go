@@ -233,6 +233,34 @@ type OmitFieldTest struct {
 	Ptr   *PresenceTest `xml:",omitempty"`
 }
 
+type nonZeroStruct struct{}
+
+func (nonZeroStruct) IsZero() bool { return false }
+
+type ptrZeroer struct {
+	N int
+}
+
+func (p *ptrZeroer) IsZero() bool { return p.N < 0 }
+
+type OmitZeroTest struct {
+	Int    int            `xml:",omitzero"`
+	Attr   int            `xml:",attr,omitzero"`
+	Str    string         `xml:",omitzero"`
+	Bytes  []byte         `xml:",omitzero"`
+	Array  [2]int         `xml:",omitzero"`
+	Struct PresenceTest   `xml:",omitzero"`
+	Time   time.Time      `xml:",omitzero"`
+	TAttr  time.Time      `xml:",attr,omitzero"`
+	NZ     nonZeroStruct  `xml:",omitzero"`
+	PZ     ptrZeroer      `xml:",omitzero"`
+	PPZ    *ptrZeroer     `xml:",omitzero"`
+	Iface  isZeroer       `xml:",omitzero"`
+	Both   []int          `xml:",omitempty,omitzero"`
+	Ptr    *PresenceTest  `xml:",omitzero"`
+	Empty  *nonZeroStruct `xml:",omitempty"`
+}
+
 type AnyTest struct {
 	XMLName  struct{}  `xml:"a"`
 	Nested   string    `xml:"nested>value"`
@@ -1855,6 +1883,57 @@ func (lw *limitedBytesWriter) Write(p []byte) (n int, err error) {
 	return n, err
 }
 
+func TestMarshalOmitZero(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		v    *OmitZeroTest
+		want string
+	}{{
+		v:    &OmitZeroTest{},
+		want: `<OmitZeroTest><NZ></NZ><PZ><N>0</N></PZ></OmitZeroTest>`,
+	}, {
+		v: &OmitZeroTest{
+			Bytes: []byte{},
+			Array: [2]int{0, 1},
+			Time:  time.Time{}.Local(),
+			PZ:    ptrZeroer{N: -1},
+			PPZ:   &ptrZeroer{N: -1},
+			Iface: (*ptrZeroer)(nil),
+			Both:  []int{},
+		},
+		want: `<OmitZeroTest><Bytes></Bytes><Array>0</Array><Array>1</Array><NZ></NZ></OmitZeroTest>`,
+	}, {
+		v: &OmitZeroTest{
+			Int:   1,
+			Attr:  2,
+			Str:   "s",
+			TAttr: tm,
+			PZ:    ptrZeroer{N: 3},
+			Iface: tm,
+			Ptr:   &PresenceTest{},
+		},
+		want: `<OmitZeroTest Attr="2" TAttr="2024-01-02T03:04:05Z"><Int>1</Int><Str>s</Str>` +
+			`<NZ></NZ><PZ><N>3</N></PZ><Iface>2024-01-02T03:04:05Z</Iface><Ptr></Ptr></OmitZeroTest>`,
+	}}
+	for i, tt := range tests {
+		got, err := Marshal(tt.v)
+		if err != nil {
+			t.Errorf("#%d: Marshal error: %v", i, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("#%d: Marshal:\n\tgot:  %s\n\twant: %s", i, got, tt.want)
+		}
+	}
+
+	type chardataOmitZero struct {
+		Text string `xml:",chardata,omitzero"`
+	}
+	if _, err := Marshal(chardataOmitZero{}); err == nil {
+		t.Errorf("Marshal of omitzero on chardata field: got nil error, want error")
+	}
+}
+
 func TestMarshalWriteErrors(t *testing.T) {
 	var buf bytes.Buffer
 	const writeCap = 1024
@@ -2589,3 +2668,82 @@ func TestClose(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoderBindPrefix(t *testing.T) {
+	const soap = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+		`<soap:Body><m:Get xmlns:m="urn:m" m:id="1"><m:x>1</m:x><plain xmlns="">y</plain></m:Get></soap:Body>` +
+		`</soap:Envelope>`
+	d := NewDecoder(strings.NewReader(soap))
+	var b strings.Builder
+	enc := NewEncoder(&b)
+	if err := enc.BindPrefix("soap", "http://schemas.xmlsoap.org/soap/envelope/"); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.EncodeToken(tok); err != nil {
+			t.Fatalf("EncodeToken(%#v) error: %v", tok, err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// The redundant xmlns="" of plain is dropped.
+	want := strings.Replace(soap, `<plain xmlns="">`, `<plain>`, 1)
+	if b.String() != want {
+		t.Errorf("round trip of tokens:\ngot  %s\nwant %s", b.String(), want)
+	}
+
+	type item struct {
+		XMLName Name   `xml:"urn:p item"`
+		ID      string `xml:"urn:p id,attr"`
+		Name    string `xml:"urn:p name"`
+		Note    string `xml:"note"`
+		Other   string `xml:"urn:q other"`
+	}
+	type doc struct {
+		XMLName Name   `xml:"urn:doc doc"`
+		Items   []item `xml:"urn:p item"`
+	}
+	b.Reset()
+	enc = NewEncoder(&b)
+	if err := enc.BindPrefix("", "urn:doc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.BindPrefix("p", "urn:p"); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(doc{Items: []item{{ID: "1", Name: "a", Note: "n", Other: "o"}}}); err != nil {
+		t.Fatal(err)
+	}
+	want = `<doc xmlns="urn:doc"><p:item xmlns:p="urn:p" p:id="1"><p:name>a</p:name>` +
+		`<note xmlns="">n</note><other xmlns="urn:q">o</other></p:item></doc>`
+	if b.String() != want {
+		t.Errorf("Encode:\ngot  %s\nwant %s", b.String(), want)
+	}
+}
+
+func TestEncoderBindPrefixErrors(t *testing.T) {
+	tests := []struct {
+		prefix, url, err string
+	}{
+		{"a:b", "urn:x", `xml: invalid name space prefix "a:b"`},
+		{"1a", "urn:x", `xml: invalid name space prefix "1a"`},
+		{"xmlfoo", "urn:x", `xml: reserved name space prefix "xmlfoo"`},
+		{"XMLp", "urn:x", `xml: reserved name space prefix "XMLp"`},
+		{"p", "", `xml: cannot bind prefix "p" to name space ""`},
+		{"p", xmlURL, `xml: cannot bind prefix "p" to name space "` + xmlURL + `"`},
+	}
+	for _, tt := range tests {
+		err := NewEncoder(io.Discard).BindPrefix(tt.prefix, tt.url)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("BindPrefix(%q, %q) error = %v, want %s", tt.prefix, tt.url, err, tt.err)
+		}
+	}
+}
@@ -38,6 +38,7 @@ const (
 	fAny
 
 	fOmitEmpty
+	fOmitZero
 
 	fMode = fElement | fAttr | fCDATA | fCharData | fInnerXML | fComment | fAny
 
@@ -141,6 +142,8 @@ func structFieldInfo(typ reflect.Type, f *reflect.StructField) (*fieldInfo, erro
 				finfo.flags |= fAny
 			case "omitempty":
 				finfo.flags |= fOmitEmpty
+			case "omitzero":
+				finfo.flags |= fOmitZero
 			}
 		}
 
@@ -160,7 +163,7 @@ func structFieldInfo(typ reflect.Type, f *reflect.StructField) (*fieldInfo, erro
 		if finfo.flags&fMode == fAny {
 			finfo.flags |= fElement
 		}
-		if finfo.flags&fOmitEmpty != 0 && finfo.flags&(fElement|fAttr) == 0 {
+		if finfo.flags&(fOmitEmpty|fOmitZero) != 0 && finfo.flags&(fElement|fAttr) == 0 {
 			valid = false
 		}
 		if !valid {
@@ -197,6 +197,14 @@ type Decoder struct {
 	// the attribute xmlns="DefaultSpace".
 	DefaultSpace string
 
+	// DisallowDTD, if true, causes the Decoder to reject a document
+	// type declaration, or any other directive, and references to
+	// entities other than lt, gt, amp, apos and quot, regardless of
+	// Strict and Entity. Applications that must not let a DTD change
+	// the meaning of untrusted input, such as XML signature
+	// verification, should set it.
+	DisallowDTD bool
+
 	r              io.ByteReader
 	t              TokenReader
 	buf            bytes.Buffer
@@ -213,6 +221,10 @@ type Decoder struct {
 	linestart      int64
 	offset         int64
 	unmarshalDepth int
+
+	// normalizeAttr enables attribute-value normalization of
+	// white space, as required by Canonicalizer.
+	normalizeAttr bool
 }
 
 // NewDecoder creates a new XML parser reading from r.
@@ -550,7 +562,11 @@ func (d *Decoder) RawToken() (Token, error) {
 
 func (d *Decoder) rawToken() (Token, error) {
 	if d.t != nil {
-		return d.t.Token()
+		t, err := d.t.Token()
+		if _, ok := t.(Directive); ok && d.DisallowDTD {
+			return nil, d.syntaxError("document type declaration not allowed")
+		}
+		return t, err
 	}
 	if d.err != nil {
 		return nil, d.err
@@ -711,6 +727,10 @@ func (d *Decoder) rawToken() (Token, error) {
 		// Probably a directive: <!DOCTYPE ...>, <!ENTITY ...>, etc.
 		// We don't care, but accumulate for caller. Quoted angle
 		// brackets do not count for nesting.
+		if d.DisallowDTD {
+			d.err = d.syntaxError("document type declaration not allowed")
+			return nil, d.err
+		}
 		d.buf.Reset()
 		d.buf.WriteByte(b)
 		inquote := uint8(0)
@@ -1093,7 +1113,7 @@ Input:
 						if r, ok := entity[s]; ok {
 							text = string(r)
 							haveText = true
-						} else if d.Entity != nil {
+						} else if d.Entity != nil && !d.DisallowDTD {
 							text, haveText = d.Entity[s]
 						}
 					}
@@ -1106,7 +1126,7 @@ Input:
 				b0, b1 = 0, 0
 				continue Input
 			}
-			if !d.Strict {
+			if !d.Strict && !d.DisallowDTD {
 				b0, b1 = 0, 0
 				continue Input
 			}
@@ -1119,10 +1139,18 @@ Input:
 		}
 
 		// We must rewrite unescaped \r and \r\n into \n.
+		// Attribute-value normalization further turns
+		// literal white space into spaces.
 		if b == '\r' {
-			d.buf.WriteByte('\n')
+			if quote >= 0 && d.normalizeAttr {
+				d.buf.WriteByte(' ')
+			} else {
+				d.buf.WriteByte('\n')
+			}
 		} else if b1 == '\r' && b == '\n' {
 			// Skip \r\n--we already wrote \n.
+		} else if quote >= 0 && d.normalizeAttr && (b == '\n' || b == '\t') {
+			d.buf.WriteByte(' ')
 		} else {
 			d.buf.WriteByte(b)
 		}
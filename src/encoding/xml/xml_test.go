@@ -1486,3 +1486,35 @@ func TestHTMLAutoClose(t *testing.T) {
 		}
 	}
 }
+
+func TestDisallowDTD(t *testing.T) {
+	tests := []struct {
+		in     string
+		strict bool
+		entity map[string]string
+		err    string
+	}{
+		{in: `<!DOCTYPE a [<!ENTITY e "x">]><a>&e;</a>`, strict: true, err: "document type declaration not allowed"},
+		{in: `<a><!ELEMENT a ANY></a>`, strict: true, err: "document type declaration not allowed"},
+		{in: `<a>&e;</a>`, strict: true, entity: map[string]string{"e": "x"}, err: "invalid character entity &e;"},
+		{in: `<a>&e;</a>`, strict: false, err: "invalid character entity &e;"},
+		{in: `<a b="&e;"/>`, strict: true, entity: map[string]string{"e": "x"}, err: "invalid character entity &e;"},
+		{in: `<?xml version="1.0"?><a b="&amp;&#x41;">&lt;&gt;&apos;&quot;&#65;<!--c--></a>`, strict: true},
+	}
+	for _, tt := range tests {
+		d := NewDecoder(strings.NewReader(tt.in))
+		d.Strict = tt.strict
+		d.Entity = tt.entity
+		d.DisallowDTD = true
+		var err error
+		for err == nil {
+			_, err = d.Token()
+		}
+		if err == io.EOF {
+			err = nil
+		}
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%s: Token error = %v, want %q", tt.in, err, tt.err)
+		}
+	}
+}
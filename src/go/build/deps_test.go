@@ -251,9 +251,21 @@ var depsRules = `
 
 	fmt !< encoding/base32, encoding/base64;
 
-	FMT, encoding/base32, encoding/base64, internal/saferio
+	FMT
+	< encoding/internal/structfield;
+
+	FMT, encoding/base32, encoding/base64, encoding/internal/structfield, internal/saferio
 	< encoding/ascii85, encoding/csv, encoding/gob, encoding/hex,
-	  encoding/json, encoding/pem, encoding/xml, mime;
+	  encoding/pem, encoding/xml, mime;
+
+	FMT, encoding/base32, encoding/base64, encoding/hex
+	< encoding/json/internal
+	< encoding/json/internal/jsonflags
+	< encoding/json/internal/jsonopts
+	< encoding/json/internal/jsonwire
+	< encoding/json/jsontext
+	< encoding/json/v2
+	< encoding/json;
 
 	# hashes
 	io
@@ -264,9 +276,17 @@ var depsRules = `
 	FMT, math/rand
 	< math/big;
 
+	FMT, encoding/binary, encoding/internal/structfield, math/big
+	< encoding/cbor;
+
+	FMT, encoding/base64, encoding/internal/structfield
+	< encoding/toml;
+
 	# compression
 	FMT, encoding/binary, hash/adler32, hash/crc32, sort
-	< compress/bzip2, compress/flate, compress/lzw, internal/zstd
+	< compress/internal/huffman, compress/internal/lz
+	< compress/brotli, compress/bzip2, compress/flate, compress/lzw, internal/zstd
+	< compress/zstd
 	< archive/zip, compress/gzip, compress/zlib;
 
 	# templates
@@ -446,8 +466,12 @@ var depsRules = `
 	crypto/internal/boring/sig, crypto/internal/boring/fipstls < crypto/tls/fipsonly;
 
 	# CRYPTO is core crypto algorithms - no cgo, fmt, net.
+	OS
+	< crypto/internal/sysrand;
+
 	crypto/internal/boring/sig,
 	crypto/internal/boring/syso,
+	crypto/internal/sysrand,
 	golang.org/x/sys/cpu,
 	hash, embed
 	< crypto
@@ -474,20 +498,26 @@ var depsRules = `
 	crypto/boring, crypto/internal/edwards25519/field
 	< crypto/ecdh;
 
-	# Unfortunately, stuck with reflect via encoding/binary.
-	encoding/binary, crypto/boring < golang.org/x/crypto/sha3;
+	crypto/subtle
+	< crypto/internal/sha3
+	< crypto/sha3;
+
+	crypto/hmac
+	< crypto/hkdf, crypto/pbkdf2;
 
 	crypto/aes,
 	crypto/des,
 	crypto/ecdh,
+	crypto/hkdf,
 	crypto/hmac,
 	crypto/internal/edwards25519,
 	crypto/md5,
+	crypto/pbkdf2,
 	crypto/rc4,
 	crypto/sha1,
 	crypto/sha256,
-	crypto/sha512,
-	golang.org/x/crypto/sha3
+	crypto/sha3,
+	crypto/sha512
 	< CRYPTO;
 
 	CGO, fmt, net !< CRYPTO;
@@ -499,7 +529,7 @@ var depsRules = `
 	< crypto/internal/mlkem768
 	< crypto/ed25519
 	< encoding/asn1
-	< golang.org/x/crypto/cryptobyte/asn1
+	< encoding/asn1/der, golang.org/x/crypto/cryptobyte/asn1
 	< golang.org/x/crypto/cryptobyte
 	< crypto/internal/bigmod
 	< crypto/dsa, crypto/elliptic, crypto/rsa
@@ -508,6 +538,9 @@ var depsRules = `
 
 	CGO, net !< CRYPTO-MATH;
 
+	CRYPTO-MATH, encoding/base64
+	< crypto/scrypt;
+
 	# TLS, Prince of Dependencies.
 	CRYPTO-MATH, NET, container/list, encoding/hex, encoding/pem
 	< golang.org/x/crypto/internal/alias
@@ -515,7 +548,6 @@ var depsRules = `
 	< golang.org/x/crypto/chacha20
 	< golang.org/x/crypto/internal/poly1305
 	< golang.org/x/crypto/chacha20poly1305
-	< golang.org/x/crypto/hkdf
 	< crypto/internal/hpke
 	< crypto/x509/internal/macos
 	< crypto/x509/pkix;
@@ -537,6 +569,9 @@ var depsRules = `
 	crypto/tls
 	< net/smtp;
 
+	crypto/tls
+	< crypto/keystore;
+
 	crypto/rand
 	< hash/maphash; # for purego implementation
 
@@ -557,7 +592,9 @@ var depsRules = `
 	NET, crypto/tls
 	< net/http/httptrace;
 
+	compress/brotli,
 	compress/gzip,
+	compress/zstd,
 	golang.org/x/net/http/httpguts,
 	golang.org/x/net/http/httpproxy,
 	golang.org/x/net/http2/hpack,
@@ -584,6 +621,14 @@ var depsRules = `
 	< net/http/cgi
 	< net/http/fcgi;
 
+	# ACME
+	encoding/json, net/http
+	< crypto/acme
+	< crypto/acme/autocert;
+
+	crypto/acme, net/http/httptest
+	< crypto/acme/internal/acmetest;
+
 	# Profiling
 	FMT, compress/gzip, encoding/binary, sort, text/tabwriter
 	< runtime/pprof;
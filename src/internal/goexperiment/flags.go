@@ -115,4 +115,8 @@ type Flags struct {
 	// Requires that gotypesalias=1 is set with GODEBUG.
 	// This flag will be removed with Go 1.24.
 	AliasTypeParams bool
+
+	// JSONv2 implements the encoding/json package
+	// in terms of the encoding/json/v2 package.
+	JSONv2 bool
 }
@@ -0,0 +1,105 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// dictMagic is the magic number that starts a formatted dictionary.
+const dictMagic = 0xec30a437
+
+// Dict is a zstd dictionary. RFC 5.
+//
+// A dictionary is either formatted, starting with a magic number and
+// holding an ID, entropy tables, repeat offsets and content, or is
+// raw content with an ID of zero.
+type Dict struct {
+	id      uint32
+	content []byte
+
+	// The repeat offsets that start each frame.
+	offsets [3]uint32
+
+	// The Huffman table for literals, if any.
+	huffmanTable     []uint16
+	huffmanTableBits int
+
+	// The sequence FSE tables, if any.
+	seqTables    [3][]fseBaselineEntry
+	seqTableBits [3]uint8
+}
+
+// ParseDict parses a dictionary. If data does not start with the
+// dictionary magic number it is used as raw content.
+// The returned Dict refers to data, which must not be modified.
+func ParseDict(data []byte) (*Dict, error) {
+	d := &Dict{offsets: [3]uint32{1, 4, 8}}
+	if len(data) < 8 || binary.LittleEndian.Uint32(data) != dictMagic {
+		d.content = data
+		return d, nil
+	}
+	d.id = binary.LittleEndian.Uint32(data[4:])
+
+	// The entropy tables use the same format as a compressed block,
+	// so borrow a Reader to read them.
+	var r Reader
+	b := block(data)
+	off := 8
+
+	d.huffmanTable = make([]uint16, 1<<maxHuffmanBits)
+	huffmanTableBits, off, err := r.readHuff(b, off, d.huffmanTable)
+	if err != nil {
+		return nil, dictError(err)
+	}
+	d.huffmanTableBits = huffmanTableBits
+
+	for _, kind := range [...]seqCode{seqOffset, seqMatch, seqLiteral} {
+		// Dictionary tables always use FSE_Compressed_Mode.
+		off, err = r.setSeqTable(b, off, kind, 2)
+		if err != nil {
+			return nil, dictError(err)
+		}
+		d.seqTables[kind] = r.seqTables[kind]
+		d.seqTableBits[kind] = r.seqTableBits[kind]
+	}
+
+	if off+12 > len(data) {
+		return nil, dictError(errors.New("missing repeat offsets"))
+	}
+	d.content = data[off+12:]
+	for i := range d.offsets {
+		o := binary.LittleEndian.Uint32(data[off+4*i:])
+		if o == 0 || uint64(o) > uint64(len(d.content)) {
+			return nil, dictError(fmt.Errorf("invalid repeat offset %d", o))
+		}
+		d.offsets[i] = o
+	}
+
+	return d, nil
+}
+
+// dictError wraps an error found while parsing a dictionary.
+func dictError(err error) error {
+	return fmt.Errorf("zstd: invalid dictionary: %w", err)
+}
+
+// ID returns the dictionary ID, or 0 for a raw content dictionary.
+func (d *Dict) ID() uint32 {
+	return d.id
+}
+
+// Content returns the dictionary content, which is treated as
+// data preceding each frame.
+func (d *Dict) Content() []byte {
+	return d.content
+}
+
+// RepeatOffsets returns the repeat offsets used at the start of each frame.
+func (d *Dict) RepeatOffsets() [3]uint32 {
+	return d.offsets
+}
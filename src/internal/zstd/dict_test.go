@@ -0,0 +1,86 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zstd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readDictTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "dict", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDict(t *testing.T) {
+	want := readDictTestdata(t, "input.json")
+	tests := []struct {
+		compressed, dict string
+		id               uint32
+	}{
+		{"input.json.zst", "json.dict", 0x3bf430ec},
+		{"noid.zst", "json.dict", 0x3bf430ec},
+		{"raw.zst", "raw.dict", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.compressed, func(t *testing.T) {
+			d, err := ParseDict(readDictTestdata(t, test.dict))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.ID() != test.id {
+				t.Errorf("got dictionary ID %#x, want %#x", d.ID(), test.id)
+			}
+
+			compressed := readDictTestdata(t, test.compressed)
+			r := NewReaderDict(bytes.NewReader(compressed), d)
+			// Decompress twice to check that Reset keeps the dictionary,
+			// and that the dictionary tables are not modified.
+			for i := 0; i < 2; i++ {
+				got, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					showDiffs(t, got, want)
+				}
+				r.Reset(bytes.NewReader(compressed))
+			}
+		})
+	}
+}
+
+func TestDictErrors(t *testing.T) {
+	compressed := readDictTestdata(t, "input.json.zst")
+
+	_, err := io.ReadAll(NewReader(bytes.NewReader(compressed)))
+	if err == nil || !strings.Contains(err.Error(), "missing dictionary") {
+		t.Errorf("without dictionary got error %v, want missing dictionary", err)
+	}
+
+	raw, err := ParseDict(readDictTestdata(t, "raw.dict"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = io.ReadAll(NewReaderDict(bytes.NewReader(compressed), raw))
+	if err == nil || !strings.Contains(err.Error(), "wrong dictionary") {
+		t.Errorf("with wrong dictionary got error %v, want wrong dictionary", err)
+	}
+
+	formatted := readDictTestdata(t, "json.dict")
+	for _, n := range []int{9, 20, 100} {
+		if _, err := ParseDict(formatted[:n]); err == nil {
+			t.Errorf("ParseDict of %d bytes succeeded unexpectedly", n)
+		}
+	}
+}
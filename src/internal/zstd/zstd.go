@@ -3,7 +3,7 @@
 // license that can be found in the LICENSE file.
 
 // Package zstd provides a decompressor for zstd streams,
-// described in RFC 8878. It does not support dictionaries.
+// described in RFC 8878.
 package zstd
 
 import (
@@ -22,6 +22,9 @@ type Reader struct {
 	// The underlying Reader.
 	r io.Reader
 
+	// The dictionary, if any.
+	dict *Dict
+
 	// Whether we have read the frame header.
 	// This is of interest when buffer is empty.
 	// If true we expect to see a new block.
@@ -91,8 +94,19 @@ func NewReader(input io.Reader) *Reader {
 	return r
 }
 
+// NewReaderDict is like [NewReader] but uses a dictionary.
+// The dictionary is used for frames that name its ID,
+// and for frames that do not name a dictionary.
+func NewReaderDict(input io.Reader, d *Dict) *Reader {
+	r := new(Reader)
+	r.dict = d
+	r.Reset(input)
+	return r
+}
+
 // Reset discards the current state and starts reading a new stream from r.
 // This permits reusing a Reader rather than allocating a new one.
+// Any dictionary is kept.
 func (r *Reader) Reset(input io.Reader) {
 	r.r = input
 
@@ -255,13 +269,17 @@ retry:
 	}
 
 	// Dictionary_ID. RFC 3.1.1.1.3.
-	if dictionaryIdSize != 0 {
-		dictionaryId := r.scratch[windowDescriptorSize : windowDescriptorSize+dictionaryIdSize]
-		// Allow only zero Dictionary ID.
-		for _, b := range dictionaryId {
-			if b != 0 {
-				return r.makeError(relativeOffset, "dictionaries are not supported")
-			}
+	// A zero ID means that no particular dictionary is required.
+	var dictionaryId uint32
+	for i, b := range r.scratch[windowDescriptorSize : windowDescriptorSize+dictionaryIdSize] {
+		dictionaryId |= uint32(b) << (8 * i)
+	}
+	if dictionaryId != 0 {
+		if r.dict == nil {
+			return r.makeError(relativeOffset, fmt.Sprintf("missing dictionary %d", dictionaryId))
+		}
+		if r.dict.id != dictionaryId {
+			return r.makeError(relativeOffset, fmt.Sprintf("wrong dictionary: frame uses %d, have %d", dictionaryId, r.dict.id))
 		}
 	}
 
@@ -313,9 +331,35 @@ retry:
 	r.seqTables[1] = nil
 	r.seqTables[2] = nil
 
+	if r.dict != nil {
+		r.loadDict()
+	}
+
 	return nil
 }
 
+// loadDict sets up the state at the start of a frame from the dictionary.
+// The dictionary content is treated as data preceding the frame,
+// so the window is extended to hold it. RFC 5.
+func (r *Reader) loadDict() {
+	d := r.dict
+	r.repeatedOffset1 = d.offsets[0]
+	r.repeatedOffset2 = d.offsets[1]
+	r.repeatedOffset3 = d.offsets[2]
+	if d.huffmanTableBits != 0 {
+		// Copy the table, as a new Huffman table is read in place.
+		if len(r.huffmanTable) < 1<<maxHuffmanBits {
+			r.huffmanTable = make([]uint16, 1<<maxHuffmanBits)
+		}
+		copy(r.huffmanTable, d.huffmanTable)
+		r.huffmanTableBits = d.huffmanTableBits
+	}
+	r.seqTables = d.seqTables
+	r.seqTableBits = d.seqTableBits
+	r.window.reset(r.window.size + len(d.content))
+	r.window.save(d.content)
+}
+
 // skipFrame skips a skippable frame. RFC 3.1.2.
 func (r *Reader) skipFrame() error {
 	relativeOffset := 0
@@ -0,0 +1,395 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// HTTP content coding: compression of message bodies.
+// RFC 9110, Section 8.4.
+
+package http
+
+import (
+	"compress/brotli"
+	"compress/gzip"
+	"compress/zstd"
+	"fmt"
+	"io"
+	"net/http/internal/ascii"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// supportedEncoding reports whether the Transport and CompressHandler
+// can decode and encode the content coding enc.
+func supportedEncoding(enc string) bool {
+	switch enc {
+	case "gzip", "br", "zstd":
+		return true
+	}
+	return false
+}
+
+// acceptEncoding returns the Accept-Encoding value the Transport adds
+// to req, or "" if it adds none itself.
+func (t *Transport) acceptEncoding(req *Request) (string, error) {
+	if len(t.AcceptEncodings) == 0 ||
+		t.DisableCompression ||
+		req.Header.Get("Accept-Encoding") != "" ||
+		req.Header.Get("Range") != "" ||
+		req.Method == "HEAD" {
+		// See persistConn.roundTrip for why HEAD and range
+		// requests are not compressed.
+		return "", nil
+	}
+	for _, enc := range t.AcceptEncodings {
+		if !supportedEncoding(enc) {
+			return "", fmt.Errorf("net/http: unsupported content coding %q in Transport.AcceptEncodings", enc)
+		}
+	}
+	return strings.Join(t.AcceptEncodings, ", "), nil
+}
+
+// decodeResponse arranges for the body of resp to be transparently
+// decoded if it uses one of the content codings in accepted,
+// as requested by the Transport.
+func decodeResponse(resp *Response, accepted []string) {
+	enc := resp.Header.Get("Content-Encoding")
+	for _, a := range accepted {
+		if ascii.EqualFold(enc, a) {
+			resp.Body = &decodingBody{body: resp.Body, enc: a}
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
+			return
+		}
+	}
+}
+
+// decodingBody wraps a response body so that it lazily creates
+// a decoder on the first call to Read.
+type decodingBody struct {
+	body io.ReadCloser
+	enc  string
+	zr   io.Reader // lazily-initialized decoder
+	zerr error     // any error from creating the decoder; sticky
+}
+
+func (d *decodingBody) Read(p []byte) (int, error) {
+	if d.zr == nil {
+		if d.zerr == nil {
+			switch d.enc {
+			case "gzip":
+				var zr *gzip.Reader
+				zr, d.zerr = gzip.NewReader(d.body)
+				d.zr = zr
+			case "br":
+				d.zr = brotli.NewReader(d.body)
+			case "zstd":
+				d.zr = zstd.NewReader(d.body)
+			}
+		}
+		if d.zerr != nil {
+			return 0, d.zerr
+		}
+	}
+	return d.zr.Read(p)
+}
+
+func (d *decodingBody) Close() error {
+	return d.body.Close()
+}
+
+// preferredEncodings lists the content codings CompressHandler uses,
+// in order of preference when the client has none.
+var preferredEncodings = [...]string{"zstd", "br", "gzip"}
+
+// negotiateEncoding returns the content coding to use for a response
+// to a request with the Accept-Encoding header values accept, or ""
+// to leave the response uncompressed. RFC 9110, Section 12.5.3.
+func negotiateEncoding(accept []string) string {
+	var q [len(preferredEncodings)]float64
+	for i := range q {
+		q[i] = -1 // not mentioned
+	}
+	wildcard := -1.0
+	for _, v := range accept {
+		for _, item := range strings.Split(v, ",") {
+			coding, params, _ := strings.Cut(item, ";")
+			coding, _ = ascii.ToLower(textproto.TrimString(coding))
+			weight := 1.0
+			if params != "" {
+				weight = parseQValue(params)
+			}
+			if coding == "*" {
+				wildcard = weight
+				continue
+			}
+			if coding == "x-gzip" {
+				coding = "gzip"
+			}
+			for i, enc := range preferredEncodings {
+				if coding == enc {
+					q[i] = weight
+				}
+			}
+		}
+	}
+	best, bestQ := "", 0.0
+	for i, enc := range preferredEncodings {
+		w := q[i]
+		if w < 0 {
+			w = max(wildcard, 0)
+		}
+		if w > bestQ {
+			best, bestQ = enc, w
+		}
+	}
+	return best
+}
+
+// parseQValue returns the weight in the parameters of an
+// Accept-Encoding item, such as "q=0.5". An invalid weight is 0.
+func parseQValue(params string) float64 {
+	for _, p := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(textproto.TrimString(p), "=")
+		if !ok || !ascii.EqualFold(textproto.TrimString(name), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(textproto.TrimString(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
+
+// compressMinLength is the smallest response, by its Content-Length,
+// that CompressHandler compresses.
+const compressMinLength = 256
+
+// CompressHandler returns a [Handler] that runs h with a [ResponseWriter]
+// that compresses the response body with a content coding the client
+// accepts, as given by its Accept-Encoding request header. The codings
+// used are zstd, br (Brotli) and gzip, at levels suited to compressing
+// responses as they are generated.
+//
+// Responses are left uncompressed if h sets a Content-Encoding header,
+// the status code does not permit a body or is 206 Partial Content,
+// the Content-Type denotes data that is usually already compressed, such
+// as images, or the Content-Length is small. If h does not set the
+// Content-Type, it is detected from the uncompressed data as the server
+// would otherwise do. A strong ETag is made weak, since the compressed
+// representation differs from the uncompressed one.
+//
+// The ResponseWriter passed to h supports [Flusher], which flushes the
+// compressed data written so far, and an Unwrap method for use with
+// [ResponseController].
+func CompressHandler(h Handler) Handler {
+	return HandlerFunc(func(w ResponseWriter, r *Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		enc := negotiateEncoding(r.Header["Accept-Encoding"])
+		if enc == "" || r.Method == "HEAD" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{rw: w, enc: enc}
+		h.ServeHTTP(cw, r)
+		cw.close()
+	})
+}
+
+// A compressWriter is the ResponseWriter passed to the handler
+// wrapped by CompressHandler.
+type compressWriter struct {
+	rw          ResponseWriter
+	enc         string
+	zw          contentEncoder // nil until compression starts
+	wroteHeader bool
+	status      int
+	decided     bool // whether zw was created, if it is to be
+}
+
+// contentEncoder is implemented by the compressors in compress/...
+type contentEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// Levels used by CompressHandler.
+const (
+	compressGzipLevel   = gzip.DefaultCompression
+	compressBrotliLevel = 4
+	compressZstdLevel   = zstd.DefaultCompression
+)
+
+// contentEncoderPools holds encoders for each of preferredEncodings.
+var contentEncoderPools [len(preferredEncodings)]sync.Pool
+
+func getContentEncoder(enc string, w io.Writer) contentEncoder {
+	for i, e := range preferredEncodings {
+		if e != enc {
+			continue
+		}
+		if zw, ok := contentEncoderPools[i].Get().(contentEncoder); ok {
+			zw.Reset(w)
+			return zw
+		}
+		switch enc {
+		case "gzip":
+			zw, _ := gzip.NewWriterLevel(w, compressGzipLevel)
+			return zw
+		case "br":
+			zw, _ := brotli.NewWriterLevel(w, compressBrotliLevel)
+			return zw
+		case "zstd":
+			zw, _ := zstd.NewWriterLevel(w, compressZstdLevel)
+			return zw
+		}
+	}
+	panic("http: unknown content coding " + enc)
+}
+
+func putContentEncoder(enc string, zw contentEncoder) {
+	zw.Reset(nil)
+	for i, e := range preferredEncodings {
+		if e == enc {
+			contentEncoderPools[i].Put(zw)
+		}
+	}
+}
+
+func (cw *compressWriter) Header() Header {
+	return cw.rw.Header()
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		// Let the underlying ResponseWriter report the superfluous call.
+		cw.rw.WriteHeader(code)
+		return
+	}
+	if code >= 100 && code <= 199 && code != StatusSwitchingProtocols {
+		cw.rw.WriteHeader(code)
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+}
+
+// start decides whether to compress the response, given its first
+// data p, and writes the header.
+func (cw *compressWriter) start(p []byte) {
+	if cw.decided {
+		return
+	}
+	cw.decided = true
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		cw.status = StatusOK
+	}
+	h := cw.rw.Header()
+	if _, haveType := h["Content-Type"]; !haveType && len(p) > 0 && h.Get("Transfer-Encoding") == "" {
+		h.Set("Content-Type", DetectContentType(p))
+	}
+	if cw.shouldCompress() {
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", cw.enc)
+		if etag := h.Get("Etag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("Etag", "W/"+etag)
+		}
+		cw.zw = getContentEncoder(cw.enc, cw.rw)
+	}
+	cw.rw.WriteHeader(cw.status)
+}
+
+// shouldCompress reports whether the response should be compressed.
+func (cw *compressWriter) shouldCompress() bool {
+	if !bodyAllowedForStatus(cw.status) || cw.status == StatusPartialContent {
+		return false
+	}
+	h := cw.rw.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	if cl := h.Get("Content-Length"); cl != "" {
+		if n, err := strconv.ParseInt(cl, 10, 64); err == nil && n < compressMinLength {
+			return false
+		}
+	}
+	return compressibleType(h.Get("Content-Type"))
+}
+
+// compressibleType reports whether content of type ct is worth
+// compressing.
+func compressibleType(ct string) bool {
+	ct, _, _ = strings.Cut(ct, ";")
+	ct, _ = ascii.ToLower(textproto.TrimString(ct))
+	major, minor, _ := strings.Cut(ct, "/")
+	switch major {
+	case "image":
+		return minor == "svg+xml" || minor == "bmp" || minor == "x-icon" || minor == "vnd.microsoft.icon"
+	case "audio", "video":
+		return false
+	case "application":
+		switch minor {
+		case "zip", "gzip", "x-gzip", "zstd", "x-bzip2", "x-xz", "x-7z-compressed", "vnd.rar",
+			"pdf", "octet-stream", "wasm":
+			return false
+		}
+	case "font":
+		return minor != "woff" && minor != "woff2"
+	}
+	return true
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	cw.start(p)
+	if cw.zw == nil {
+		return cw.rw.Write(p)
+	}
+	return cw.zw.Write(p)
+}
+
+// FlushError flushes the compressed data written so far
+// and then the underlying ResponseWriter.
+func (cw *compressWriter) FlushError() error {
+	cw.start(nil)
+	if cw.zw != nil {
+		if err := cw.zw.Flush(); err != nil {
+			return err
+		}
+	}
+	return NewResponseController(cw.rw).Flush()
+}
+
+func (cw *compressWriter) Flush() {
+	cw.FlushError()
+}
+
+func (cw *compressWriter) Unwrap() ResponseWriter {
+	return cw.rw
+}
+
+// close finishes the response after the handler returns.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// Let the server send its default response.
+			return
+		}
+		// A response with no body is not compressed.
+		cw.decided = true
+		cw.rw.WriteHeader(cw.status)
+		return
+	}
+	if cw.zw != nil {
+		if err := cw.zw.Close(); err == nil {
+			putContentEncoder(cw.enc, cw.zw)
+		}
+		cw.zw = nil
+	}
+}
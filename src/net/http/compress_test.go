@@ -0,0 +1,271 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http_test
+
+import (
+	"bytes"
+	"compress/brotli"
+	"compress/gzip"
+	"compress/zstd"
+	"fmt"
+	"io"
+	. "net/http"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		accept []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{""}, ""},
+		{[]string{"identity"}, ""},
+		{[]string{"gzip"}, "gzip"},
+		{[]string{"x-gzip"}, "gzip"},
+		{[]string{"GZIP"}, "gzip"},
+		{[]string{"deflate, gzip"}, "gzip"},
+		{[]string{"gzip, deflate, br"}, "br"},
+		{[]string{"gzip, deflate, br, zstd"}, "zstd"},
+		{[]string{"gzip", "br"}, "br"},
+		{[]string{"br;q=0.5, gzip"}, "gzip"},
+		{[]string{"br;q=1.0, zstd;q=0.9"}, "br"},
+		{[]string{"br ; q=0.8 , gzip ; q=0.2"}, "br"},
+		{[]string{"gzip;q=0"}, ""},
+		{[]string{"gzip;q=bad"}, ""},
+		{[]string{"gzip;level=1"}, "gzip"},
+		{[]string{"*"}, "zstd"},
+		{[]string{"*;q=0"}, ""},
+		{[]string{"zstd;q=0, *"}, "br"},
+		{[]string{"gzip;q=0.5, *;q=0.1"}, "gzip"},
+	}
+	for _, test := range tests {
+		if got := ExportNegotiateEncoding(test.accept); got != test.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", test.accept, got, test.want)
+		}
+	}
+}
+
+func decodeBody(t *testing.T, enc string, body []byte) []byte {
+	t.Helper()
+	var r io.Reader
+	switch enc {
+	case "gzip":
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = zr
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(body))
+	case "zstd":
+		r = zstd.NewReader(bytes.NewReader(body))
+	case "":
+		return body
+	default:
+		t.Fatalf("unexpected Content-Encoding %q", enc)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("decoding %s: %v", enc, err)
+	}
+	return got
+}
+
+func TestCompressHandler(t *testing.T) { run(t, testCompressHandler) }
+func testCompressHandler(t *testing.T, mode testMode) {
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 200)
+	cst := newClientServerTest(t, mode, CompressHandler(HandlerFunc(func(w ResponseWriter, r *Request) {
+		switch r.URL.Path {
+		case "/text":
+			w.Header().Set("Etag", `"abc"`)
+			io.WriteString(w, text)
+		case "/small":
+			w.Header().Set("Content-Length", "5")
+			io.WriteString(w, "hello")
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, text)
+		case "/encoded":
+			w.Header().Set("Content-Encoding", "custom")
+			io.WriteString(w, text)
+		case "/nocontent":
+			w.WriteHeader(StatusNoContent)
+		case "/empty":
+		}
+	})), func(tr *Transport) {
+		tr.DisableCompression = true
+	})
+
+	tests := []struct {
+		path, accept string
+		wantEnc      string
+		wantBody     string
+	}{
+		{"/text", "", "", text},
+		{"/text", "gzip", "gzip", text},
+		{"/text", "gzip, br", "br", text},
+		{"/text", "gzip, br, zstd", "zstd", text},
+		{"/text", "br;q=0.5, gzip", "gzip", text},
+		{"/small", "gzip", "", "hello"},
+		{"/image", "gzip", "", text},
+		{"/encoded", "gzip", "custom", text},
+		{"/nocontent", "gzip", "", ""},
+		{"/empty", "gzip", "", ""},
+	}
+	for _, test := range tests {
+		req, _ := NewRequest("GET", cst.ts.URL+test.path, nil)
+		if test.accept != "" {
+			req.Header.Set("Accept-Encoding", test.accept)
+		}
+		res, err := cst.c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := fmt.Sprintf("%s with Accept-Encoding %q", test.path, test.accept)
+		enc := res.Header.Get("Content-Encoding")
+		if enc != test.wantEnc {
+			t.Errorf("%s: Content-Encoding = %q, want %q", name, enc, test.wantEnc)
+			continue
+		}
+		if enc == "custom" {
+			enc = ""
+		}
+		if got := decodeBody(t, enc, body); string(got) != test.wantBody {
+			t.Errorf("%s: got body of %d bytes, want %d", name, len(got), len(test.wantBody))
+		}
+		if got := res.Header.Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("%s: Vary = %q, want Accept-Encoding", name, got)
+		}
+		if test.path != "/text" {
+			continue
+		}
+		wantETag := `"abc"`
+		if test.wantEnc != "" {
+			wantETag = `W/"abc"`
+		}
+		if got := res.Header.Get("Etag"); got != wantETag {
+			t.Errorf("%s: Etag = %q, want %q", name, got, wantETag)
+		}
+		if got := res.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+			t.Errorf("%s: Content-Type = %q, want text/plain", name, got)
+		}
+	}
+}
+
+func TestCompressHandlerFlush(t *testing.T) { run(t, testCompressHandlerFlush) }
+func testCompressHandlerFlush(t *testing.T, mode testMode) {
+	for _, enc := range []string{"gzip", "br", "zstd"} {
+		t.Run(enc, func(t *testing.T) {
+			next := make(chan bool)
+			cst := newClientServerTest(t, mode, CompressHandler(HandlerFunc(func(w ResponseWriter, r *Request) {
+				for i := range 3 {
+					fmt.Fprintf(w, "line %d\n", i)
+					if err := NewResponseController(w).Flush(); err != nil {
+						t.Error(err)
+						return
+					}
+					<-next
+				}
+			})), func(tr *Transport) {
+				tr.AcceptEncodings = []string{enc}
+			})
+			res, err := cst.c.Get(cst.ts.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if !res.Uncompressed {
+				t.Errorf("Uncompressed = false, want true")
+			}
+			for i := range 3 {
+				want := fmt.Sprintf("line %d\n", i)
+				got := make([]byte, len(want))
+				if _, err := io.ReadFull(res.Body, got); err != nil {
+					t.Fatalf("reading line %d: %v", i, err)
+				}
+				if string(got) != want {
+					t.Errorf("got %q, want %q", got, want)
+				}
+				next <- true
+			}
+			if rest, err := io.ReadAll(res.Body); len(rest) != 0 || err != nil {
+				t.Errorf("at end, read %q, %v; want no data and no error", rest, err)
+			}
+		})
+	}
+}
+
+func TestTransportAcceptEncodings(t *testing.T) { run(t, testTransportAcceptEncodings) }
+func testTransportAcceptEncodings(t *testing.T, mode testMode) {
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 200)
+	var gotAccept string
+	cst := newClientServerTest(t, mode, HandlerFunc(func(w ResponseWriter, r *Request) {
+		gotAccept = r.Header.Get("Accept-Encoding")
+		CompressHandler(HandlerFunc(func(w ResponseWriter, r *Request) {
+			io.WriteString(w, text)
+		})).ServeHTTP(w, r)
+	}))
+
+	tests := []struct {
+		encodings  []string
+		accept     string // Accept-Encoding set on the Request
+		wantAccept string
+		wantDecode bool
+	}{
+		{nil, "", "gzip", true},
+		{[]string{"br"}, "", "br", true},
+		{[]string{"zstd", "br", "gzip"}, "", "zstd, br, gzip", true},
+		{[]string{"gzip"}, "", "gzip", true},
+		{[]string{"br"}, "gzip", "gzip", false},
+	}
+	for _, test := range tests {
+		cst.tr.AcceptEncodings = test.encodings
+		req, _ := NewRequest("GET", cst.ts.URL, nil)
+		if test.accept != "" {
+			req.Header.Set("Accept-Encoding", test.accept)
+		}
+		res, err := cst.c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := fmt.Sprintf("AcceptEncodings %q", test.encodings)
+		if gotAccept != test.wantAccept {
+			t.Errorf("%s: server got Accept-Encoding %q, want %q", name, gotAccept, test.wantAccept)
+		}
+		if got := req.Header.Get("Accept-Encoding"); got != test.accept {
+			t.Errorf("%s: Request Accept-Encoding mutated to %q", name, got)
+		}
+		if test.wantDecode {
+			if enc := res.Header.Get("Content-Encoding"); enc != "" {
+				t.Errorf("%s: Content-Encoding = %q, want none", name, enc)
+			}
+			if !res.Uncompressed || res.ContentLength != -1 {
+				t.Errorf("%s: Uncompressed = %v, ContentLength = %d; want true, -1", name, res.Uncompressed, res.ContentLength)
+			}
+		} else {
+			body = decodeBody(t, res.Header.Get("Content-Encoding"), body)
+		}
+		if string(body) != text {
+			t.Errorf("%s: got body of %d bytes, want %d", name, len(body), len(text))
+		}
+	}
+
+	cst.tr.AcceptEncodings = []string{"deflate"}
+	if _, err := cst.c.Get(cst.ts.URL); err == nil {
+		t.Errorf("request with unsupported AcceptEncodings succeeded")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"net/textproto"
 	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -201,6 +202,17 @@ type Transport struct {
 	// uncompressed.
 	DisableCompression bool
 
+	// AcceptEncodings lists the content codings, in order of
+	// preference, that the Transport requests with an Accept-Encoding
+	// request header when DisableCompression is false and the Request
+	// contains no existing Accept-Encoding or Range value. A response
+	// using one of them is transparently decoded in the Response.Body,
+	// as with gzip above. The supported codings are "gzip", "br"
+	// (Brotli) and "zstd".
+	//
+	// If AcceptEncodings is empty, the Transport requests only gzip.
+	AcceptEncodings []string
+
 	// MaxIdleConns controls the maximum number of idle (keep-alive)
 	// connections across all hosts. Zero means no limit.
 	MaxIdleConns int
@@ -322,6 +334,7 @@ func (t *Transport) Clone() *Transport {
 		TLSHandshakeTimeout:    t.TLSHandshakeTimeout,
 		DisableKeepAlives:      t.DisableKeepAlives,
 		DisableCompression:     t.DisableCompression,
+		AcceptEncodings:        slices.Clone(t.AcceptEncodings),
 		MaxIdleConns:           t.MaxIdleConns,
 		MaxIdleConnsPerHost:    t.MaxIdleConnsPerHost,
 		MaxConnsPerHost:        t.MaxConnsPerHost,
@@ -559,8 +572,31 @@ func (t *Transport) roundTrip(req *Request) (_ *Response, err error) {
 	origReq := req
 	req = setupRewindBody(req)
 
+	var acceptEncodings []string
+	if isHTTP {
+		ae, err := t.acceptEncoding(req)
+		if err != nil {
+			req.closeBody()
+			return nil, err
+		}
+		if ae != "" {
+			// Setting the header explicitly turns off the
+			// HTTP/1 and HTTP/2 transports' own gzip handling.
+			if req == origReq {
+				r2 := *req
+				req = &r2
+			}
+			req.Header = req.Header.Clone()
+			req.Header.Set("Accept-Encoding", ae)
+			acceptEncodings = t.AcceptEncodings
+		}
+	}
+
 	if altRT := t.alternateRoundTripper(req); altRT != nil {
 		if resp, err := altRT.RoundTrip(req); err != ErrSkipAltProtocol {
+			if err == nil {
+				decodeResponse(resp, acceptEncodings)
+			}
 			return resp, err
 		}
 		var err error
@@ -653,6 +689,7 @@ func (t *Transport) roundTrip(req *Request) (_ *Response, err error) {
 				cancel(errRequestDone)
 			}
 			resp.Request = origReq
+			decodeResponse(resp, acceptEncodings)
 			return resp, nil
 		}
 